@@ -1,16 +1,67 @@
 package app
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
 	"time"
 
+	"plus/internal/access"
+	"plus/internal/alert"
+	"plus/internal/approval"
 	"plus/internal/api"
+	"plus/internal/attest"
+	"plus/internal/authz"
+	"plus/internal/branding"
+	"plus/internal/cache"
+	"plus/internal/cdn"
+	"plus/internal/chat"
+	"plus/internal/cluster"
 	"plus/internal/config"
+	"plus/internal/connlimit"
+	"plus/internal/delivery"
+	"plus/internal/eventbus"
+	"plus/internal/eventlog"
+	"plus/internal/feature"
+	"plus/internal/filepolicy"
+	"plus/internal/forwarded"
+	"plus/internal/index"
+	"plus/internal/license"
+	"plus/internal/listener"
 	"plus/internal/log"
+	"plus/internal/metalink"
+	"plus/internal/metrics"
+	"plus/internal/middleware"
+	"plus/internal/mimetype"
+	"plus/internal/notify"
+	"plus/internal/pathnorm"
+	"plus/internal/proxy"
+	"plus/internal/scheduler"
 	"plus/internal/service"
+	"plus/internal/signing"
+	"plus/internal/spool"
+	"plus/internal/throttle"
+	"plus/internal/thumbnail"
+	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/internal/worker"
 
 	"plus/pkg/repo"
+	"plus/pkg/storage"
+	"plus/pkg/storage/breaker"
+	"plus/pkg/storage/compress"
+	"plus/pkg/storage/replicated"
+	"plus/pkg/storage/retry"
+	"plus/pkg/storage/s3"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/urfave/cli"
 	"github.com/valyala/fasthttp"
 )
@@ -19,53 +70,1496 @@ const Name = "plus"
 const MaxRequestBodySize = 8 * 1024 * 1024 * 1024
 
 func Run(c *cli.Context) error {
-	cfg := &config.Config{
-		Listen:      c.String("listen"),
-		StoragePath: filepath.Clean(c.String("storage-path")),
-		Log: c.String("log"),
-		LogLevel: c.String("log-level"),
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
 	}
 
 	log.Init(cfg.Log, cfg.LogLevel)	
 
-	repos := repo.NewRepoFactory(cfg)
+	// 存储和数据库目录升级到当前布局
+	if err := upgradeLayout(cfg); err != nil {
+		return err
+	}
 
-	// 初始化 RPM 仓库管理器
-	rpmRepo, err := repos.CreateRepo(repo.RPM)
+	// 清理上次崩溃留下的元数据生成目录和临时文件；元数据发布中断的仓库在工作池就绪后重新生成
+	interrupted := recoverStorage(cfg)
+
+	// 收到 SIGINT/SIGTERM 时取消，后台任务随之停止，服务排空后退出
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	keyring, err := signing.NewKeyring(cfg.Signing)
 	if err != nil {
 		return err
 	}
 
-	log.Logger.Debugf("RPM repo init success: %s", rpmRepo.Type())
+	log.Logger.Debugf("Signing keyring loaded: %d keys", len(keyring.List()))
 
-	filesRepo, err := repos.CreateRepo(repo.Files)
+	// 对象存储仓库的本地磁盘读缓存
+	readCache, err := newReadCache(cfg.Storage.ReadCache)
 	if err != nil {
 		return err
 	}
 
-	log.Logger.Debugf("Files repo init success: %s", filesRepo.Type())
+	// 对象存储熔断，故障期间直接返回 503
+	storageBreaker, err := newStorageBreaker(cfg.Storage.CircuitBreaker)
+	if err != nil {
+		return err
+	}
+
+	// 对象存储透明压缩，关闭时仍解压已压缩的对象
+	compressor, err := newCompressor(cfg.Storage.Compression)
+	if err != nil {
+		return err
+	}
+
+	// 对象存储副本，单个后端故障时由其他副本提供服务
+	retryCfg, err := newRetryConfig(cfg.Storage.Retry)
+	if err != nil {
+		return err
+	}
+	replicator, err := newReplicator(cfg, retryCfg)
+	if err != nil {
+		return err
+	}
+
+	// 包下载重定向到对象存储中 storage-path 的副本
+	presigner, err := newPresigner(cfg)
+	if err != nil {
+		return err
+	}
 
 	// 初始化服务
-	repoService := service.NewRepoService(rpmRepo, filesRepo)
+	repos, err := newRepoFactory(cfg, storageLayers{
+		readCache:  readCache,
+		breaker:    storageBreaker,
+		compressor: compressor,
+		replicator: replicator,
+		presigner:  presigner,
+	})
+	if err != nil {
+		return err
+	}
+	repoService, err := createRepos(repos)
+	if err != nil {
+		return err
+	}
+	if err := setOverwritePolicy(cfg, repoService); err != nil {
+		return err
+	}
+	if err := setFilenamePolicy(cfg, repoService); err != nil {
+		return err
+	}
+	if err := setLicensePolicy(cfg, repoService); err != nil {
+		return err
+	}
+	if err := setReleaseFields(cfg, repoService, keyring); err != nil {
+		return err
+	}
+	// 来源证明的签名公钥
+	attestKeys, err := attest.NewVerifier(cfg.Attestations.Keys)
+	if err != nil {
+		return err
+	}
+	repoService.SetAttestationVerifier(attestKeys, cfg.Attestations.RequireVerified)
+	// 功能开关在初始化其他子系统之前加载，子系统按开关决定是否启用
+	flags, err := newFeatures(cfg)
+	if err != nil {
+		return err
+	}
+	setDirectorySums(cfg, repoService, keyring)
+	if cfg.Paths.Normalize || cfg.Paths.Lowercase {
+		repoService.SetPathNormalizer(&pathnorm.Normalizer{Lowercase: cfg.Paths.Lowercase})
+	}
+	// plus migrate-storage 切换存储后不停服务切换到新存储
+	go watchStorageBackends(ctx, cfg, repos)
 
 	log.Logger.Debug("service load success")
 
+	// 多实例部署：共享仓库注册表，同一仓库的元数据同一时间只由一个实例生成；
+	// 集群范围的定时任务只在选出的领导者上执行
+	var jobs *scheduler.Scheduler
+	var coordinator *cluster.Cluster
+	if cfg.Cluster.Enabled {
+		c, err := newCluster(cfg)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		coordinator = c
+		// 心跳停止后其他实例才会重放本实例未完成的操作
+		go c.Heartbeat(ctx)
+		repoService.SetCluster(c)
+		if err := repoService.LoadRepoTypes(ctx); err != nil {
+			return fmt.Errorf("load cluster registry: %w", err)
+		}
+		jobs = scheduler.New(c.Node(), c.Elect(ctx))
+	} else {
+		hostname, _ := os.Hostname()
+		jobs = scheduler.New(hostname, nil)
+	}
+
+	// 上传后的校验和计算、元数据刷新在独立的工作池中执行
+	repoService.SetWorkerPool(worker.New(cfg.Limits.Workers, cfg.Limits.WorkerQueue))
+	for _, name := range interrupted {
+		if err := repoService.ScheduleRefresh(ctx, name); err != nil {
+			log.Logger.Warnf("Failed to schedule refresh of %s: %v", name, err)
+		}
+	}
+	if err := replayJournal(ctx, cfg, repoService); err != nil {
+		return err
+	}
+
+	// 刷新后保存元数据历史，客户端可以按版本号或时间读取旧的元数据
+	repoService.SetGenerations(func(repoName string) int {
+		rc, _ := cfg.RepoConfig(repoName)
+		return rc.Generations
+	})
+
+	// 初始化代理仓库（上游内容校验后缓存到本地存储）
+	proxyStorage, err := storage.Create(storage.Local, cfg.StoragePath)
+	if err != nil {
+		return err
+	}
+	if presigner != nil {
+		proxyStorage = storage.WithPresigner(proxyStorage, presigner)
+	}
+	proxies, err := proxy.NewManager(cfg, proxyStorage)
+	if err != nil {
+		return err
+	}
+	for name, repoType := range proxies.Repos() {
+		if err := proxyStorage.CreateDir(context.Background(), name); err != nil {
+			return err
+		}
+		if err := repoService.SetRepoType(context.Background(), name, repoType); err != nil {
+			return err
+		}
+		log.Logger.Debugf("Proxy repo registered: %s (%s)", name, repoType)
+	}
+	proxies.StartHealthChecks(ctx)
+	// 代理缓存在本实例的本地存储中，每个实例各自同步
+	for name, interval := range proxies.SyncIntervals() {
+		name := name
+		if err := jobs.Add(scheduler.Job{
+			Name:     "proxy-sync/" + name,
+			Scope:    scheduler.ScopeNode,
+			Interval: interval,
+			Run:      func(ctx context.Context) error { return proxies.Sync(ctx, name) },
+		}); err != nil {
+			return err
+		}
+	}
+
+	// 制品索引：启动时全量扫描，之后定期修正；代理同步后重建该仓库的索引
+	if cfg.Index.Enabled {
+		idx, interval, err := newIndex(cfg)
+		if err != nil {
+			return err
+		}
+		defer idx.Close()
+		repoService.SetIndex(idx)
+		// 索引在本实例本地，每个实例各自扫描
+		if interval > 0 {
+			if err := jobs.Add(scheduler.Job{
+				Name:     "index-reconcile",
+				Scope:    scheduler.ScopeNode,
+				Interval: interval,
+				Run:      repoService.Reconcile,
+			}); err != nil {
+				return err
+			}
+		} else {
+			go func() {
+				if err := repoService.Reconcile(ctx); err != nil {
+					log.Logger.Warnf("Index reconcile failed: %v", err)
+				}
+			}()
+		}
+		proxies.OnSync(func(repoName string) { repoService.ReindexRepo(context.Background(), repoName) })
+	}
+
 	// 初始化处理器
 	r := api.NewAPI(repoService, cfg)
+	r.SetKeyring(keyring)
+	r.SetAttestationKeys(attestKeys)
+	brand, err := branding.New(cfg.Branding)
+	if err != nil {
+		return err
+	}
+	r.SetBranding(brand)
+	r.SetFeatures(flags)
+	// 插件已在创建仓库之前加载，其中的中间件和钩子可以使用
+	chain, err := middleware.New(cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.Limits.RateLimit > 0 && !chain.Uses(middleware.RateLimit) {
+		log.Logger.Warnf("limits.rate-limit is set but no middleware chain includes %s", middleware.RateLimit)
+	}
+	r.SetMiddleware(chain)
+	r.SetProxy(proxies)
+	r.SetReadCache(readCache)
+	r.SetBreaker(storageBreaker)
+	if cfg.Storage.Compression.Enabled {
+		r.SetCompression(compressor)
+	}
+	if replicator != nil {
+		r.SetReplication(replicator)
+	}
+
+	// 热点元数据内存缓存，仓库刷新或代理同步后失效
+	if cfg.Cache.Enabled {
+		metaCache, err := newMetadataCache(cfg.Cache)
+		if err != nil {
+			return err
+		}
+		repoService.OnChange(func(repoName string, _ bool) { metaCache.Invalidate(repoName) })
+		proxies.OnSync(metaCache.Invalidate)
+		r.SetMetadataCache(metaCache)
+	}
+
+	// 大文件的 metalink 和 zsync，生成结果按文件大小和修改时间缓存
+	if cfg.Metalink.Enabled {
+		opts, metalinkCache, err := newMetalink(cfg.Metalink)
+		if err != nil {
+			return err
+		}
+		r.SetMetalink(opts, metalinkCache)
+	}
+
+	// files 仓库中图片的缩略图，生成结果按原图的大小和修改时间缓存
+	if cfg.Thumbnails.Enabled {
+		size, thumbCache, err := newThumbnails(cfg.Thumbnails)
+		if err != nil {
+			return err
+		}
+		r.SetThumbnails(size, thumbCache)
+	}
+
+	// 带宽限制（未配置时只统计吞吐量）
+	bandwidth, err := throttle.New(cfg.Limits.Bandwidth)
+	if err != nil {
+		return err
+	}
+	r.SetThrottle(bandwidth)
+
+	// 累计计数器定期保存到 database-path，重启后继续累计
+	stats, statsInterval, err := newStatsStore(cfg, bandwidth)
+	if err != nil {
+		return err
+	}
+	if stats != nil {
+		r.SetStats(stats)
+		if err := jobs.Add(scheduler.Job{
+			Name:     "stats-save",
+			Scope:    scheduler.ScopeNode,
+			Interval: statsInterval,
+			Run:      func(context.Context) error { return stats.Save() },
+		}); err != nil {
+			return err
+		}
+	}
+	// 按用户和令牌统计的每日用量，与累计计数器一起保存
+	usageDir := ""
+	if stats != nil {
+		usageDir = cfg.DatabasePath
+	}
+	usage, err := metrics.OpenUsage(usageDir, cfg.Stats.UsageRetention)
+	if err != nil {
+		return err
+	}
+	r.SetUsage(usage)
+	if stats != nil {
+		if err := jobs.Add(scheduler.Job{
+			Name:     "usage-save",
+			Scope:    scheduler.ScopeNode,
+			Interval: statsInterval,
+			Run:      func(context.Context) error { return usage.Save() },
+		}); err != nil {
+			return err
+		}
+	}
+	// 仓库配置中的定时任务（定期刷新元数据、清理旧版本）
+	if err := addRepoJobs(jobs, cfg, repoService); err != nil {
+		return err
+	}
+	// 上传时指定了有效期的制品到期删除，仓库内容在共享存储中，只在领导者上执行
+	expiryInterval, err := newExpiry(cfg.Upload)
+	if err != nil {
+		return err
+	}
+	if err := jobs.Add(scheduler.Job{
+		Name:     "artifact-expiry",
+		Scope:    scheduler.ScopeCluster,
+		Interval: expiryInterval,
+		Run:      repoService.ExpireAll,
+	}); err != nil {
+		return err
+	}
+	r.SetScheduler(jobs)
+
+	// 持续的 5xx、失败的定时任务和存储用量告警
+	var alerts *alert.Manager
+	if cfg.Alerts.Enabled {
+		alerts, err = newAlerts(cfg, jobs, repoService)
+		if err != nil {
+			return err
+		}
+		r.SetAlerts(alerts)
+	}
+
+	// 事件通知：按配置发送邮件，按通过接口配置的订阅发到聊天 webhook，发布到 Kafka 或 NATS
+	var channels []notify.Channel
+	var direct notify.Fanout // 没有投递队列时直接发送
+	if cfg.Notifications.Enabled {
+		notifier, err := notify.New(cfg.Notifications, jobs.Status().Node, cfg.ExternalURL)
+		if err != nil {
+			return err
+		}
+		channels = append(channels, notifier)
+		direct = append(direct, notifier)
+		if (alerts == nil || cfg.Alerts.Storage.Threshold == 0) && len(notifier.Recipients(notify.EventQuota, "")) > 0 {
+			log.Logger.Warnf("Notifications for %s require alerts.storage.threshold", notify.EventQuota)
+		}
+		log.Logger.Infof("Email notifications enabled via %s", cfg.Notifications.SMTP.Addr)
+	}
+	if cfg.Events.Publish.Kind != "" {
+		bus, err := newEventBus(cfg, jobs.Status().Node)
+		if err != nil {
+			return err
+		}
+		defer bus.Close(5 * time.Second)
+		channels = append(channels, bus)
+		direct = append(direct, bus)
+	}
+	var senders notify.Fanout
+	if cfg.DatabasePath != "" {
+		chatStore, err := chat.Open(cfg.DatabasePath)
+		if err != nil {
+			return err
+		}
+		r.SetChat(chatStore)
+		channels = append(channels, chatStore)
+
+		// 投递队列：每个目标的投递保存在 database-path 下，失败后重试，重启后继续
+		queue, err := delivery.Open(cfg.DatabasePath, jobs.Status().Node, cfg.Events.DeliveryAttempts, channels...)
+		if err != nil {
+			return err
+		}
+		defer queue.Close()
+		r.SetDeliveries(queue)
+
+		// 事件日志：记录全部事件，外部系统通过 /api/v1/events 补齐停机期间的事件。
+		// 事件记录后带着 seq 进入投递队列
+		events, err := eventlog.Open(cfg.DatabasePath, jobs.Status().Node, cfg.Events.Retention)
+		if err != nil {
+			return err
+		}
+		defer events.Close()
+		r.SetEvents(events)
+		events.OnAppend(queue.Enqueue)
+		senders = append(senders, events)
+		if err := jobs.Add(scheduler.Job{
+			Name:     "events-compact",
+			Scope:    scheduler.ScopeNode,
+			Interval: 24 * time.Hour,
+			Run:      func(context.Context) error { return events.Compact() },
+		}); err != nil {
+			return err
+		}
+	} else {
+		// 没有 database-path 时直接发送，失败后不保证送达
+		senders = direct
+	}
+	if len(senders) > 0 {
+		// 只记录和发布、不发通知的仓库变化
+		repoService.OnChange(func(repoName string, deleted bool) {
+			name := eventlog.EventRepoRefreshed
+			if deleted {
+				name = eventlog.EventRepoDeleted
+			}
+			senders.Send(notify.Event{Name: name, Repo: repoName})
+		})
+	}
+	if len(senders) > 0 {
+		r.SetNotifier(senders)
+		proxies.OnSyncFailed(func(repoName string, err error) {
+			senders.Send(notify.Event{Name: notify.EventSyncFailed, Repo: repoName, Message: err.Error()})
+		})
+		repoService.OnPublish(func(repoName string, files []string) {
+			senders.Send(notify.Event{Name: notify.EventPackagePublished, Repo: repoName, Files: files})
+		})
+		repoService.OnRetention(func(repoName string, removed []string) {
+			senders.Send(notify.Event{Name: notify.EventRetentionDeleted, Repo: repoName, Files: removed, Count: len(removed)})
+		})
+		if alerts != nil && cfg.Alerts.Storage.Threshold > 0 {
+			alerts.AddNotifier(notify.Alerts{Sender: senders})
+		}
+	}
+
+	// 按接口类别的访问策略
+	if len(cfg.Auth.Policy) > 0 {
+		policy, err := newPolicy(cfg.Auth.Policy)
+		if err != nil {
+			return err
+		}
+		r.SetPolicy(policy)
+	}
+
+	// 写操作前询问外部授权服务
+	if cfg.Auth.Webhook.URL != "" {
+		webhook, err := authz.New(cfg.Auth.Webhook)
+		if err != nil {
+			return err
+		}
+		r.SetAuthz(webhook)
+	}
+
+	// 用户和仓库权限，LDAP 目录定期同步
+	if cfg.Access.Enabled {
+		users, syncInterval, err := newAccess(cfg)
+		if err != nil {
+			return err
+		}
+		// 会话令牌在共用 session-secret 的实例间通用，撤销记录在集群中
+		if coordinator != nil {
+			users.SetCluster(coordinator)
+		}
+		r.SetAccess(users)
+		if syncInterval > 0 {
+			if err := jobs.Add(scheduler.Job{
+				Name:     "ldap-sync",
+				Scope:    scheduler.ScopeNode,
+				Interval: syncInterval,
+				Run: func(ctx context.Context) error {
+					result, err := users.SyncLDAP(ctx)
+					if err == nil {
+						log.Logger.Infof("LDAP sync: %d created, %d updated, %d disabled", result.Created, result.Updated, result.Disabled)
+					}
+					return err
+				},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 受保护仓库的删除和覆盖需要另一位管理员批准
+	if err := setupApprovals(r, cfg); err != nil {
+		return err
+	}
+
+	// 单 IP 并发连接数和下载数限制
+	connLimit := connlimit.New(cfg.Limits)
+	r.SetConnLimit(connLimit)
+
+	// 包下载重定向到对象存储
+	if cfg.Storage.Redirect.Enabled {
+		if err := setupRedirect(r, cfg.Storage.Redirect); err != nil {
+			return err
+		}
+	}
 
 	// 设置路由
 	router := api.SetupRouter(r)
 
+	// 前置 CDN：输出缓存头，元数据变化时清除缓存
+	if cfg.CDN.Enabled {
+		cdnCache, err := cdn.New(cfg.CDN, repoService.ListRepos)
+		if err != nil {
+			return err
+		}
+		repoService.OnChange(cdnCache.Purge)
+		proxies.OnSync(func(repoName string) { cdnCache.Purge(repoName, false) })
+		router = cdnCache.Handler(router)
+	}
+
 	log.Logger.Debug("router setup success")
 
+	handler := middleware.RequestIDMiddleware(bandwidth.Handler(connLimit.Handler(router)))
+	// 可信代理的 X-Forwarded-For 在最外层处理，限流、日志和授权都使用真实的客户端 IP
+	if len(cfg.TrustedProxies) > 0 || cfg.ExternalURL != "" {
+		resolver, err := forwarded.New(cfg.TrustedProxies, cfg.ExternalURL)
+		if err != nil {
+			return err
+		}
+		r.SetForwarded(resolver)
+		connLimit.TrustProxies(resolver.FromTrustedProxy)
+		handler = resolver.Handler(handler)
+		log.Logger.Infof("Trusting forwarded headers from %d proxies, external URL %q", len(cfg.TrustedProxies), cfg.ExternalURL)
+	}
+
+	// 上传请求体在处理请求时流式读取（同时按上行限速），multipart 表单中较大的文件写入 spool 目录
+	spooler, maxBodySize, err := newSpooler(cfg.Upload)
+	if err != nil {
+		return err
+	}
+	r.SetSpool(spooler)
+
 	server := &fasthttp.Server{
-		Handler:            router,
-		MaxRequestBodySize: MaxRequestBodySize,
+		Handler:                      handler,
+		ConnState:                    connLimit.ConnState,
+		MaxRequestBodySize:           int(maxBodySize),
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 		// 其他可选配置
 		ReadTimeout:  time.Second * 60,
 		WriteTimeout: time.Second * 60,
 	}
 
-	log.Logger.Debugf("Server starting on %s", cfg.Listen)
-	return server.ListenAndServe(cfg.Listen)
+	// 未配置 listeners 时只监听 listen
+	listenerConfigs := cfg.Listeners
+	if len(listenerConfigs) == 0 {
+		listenerConfigs = []config.ListenerConfig{{Address: cfg.Listen}}
+	}
+	listeners, err := listener.Open(listenerConfigs)
+	if err != nil {
+		return err
+	}
+	infos := make([]types.ListenerInfo, 0, len(listeners))
+	for _, ln := range listeners {
+		infos = append(infos, types.ListenerInfo{Address: ln.Address(), Network: ln.Network, TLS: ln.TLS != nil, Systemd: ln.Systemd})
+	}
+	r.SetListeners(infos)
+
+	// 收到 SIGINT/SIGTERM 时先排空，等待负载均衡器摘除实例，再停止接受连接并等待处理中的请求结束
+	drainDelay, shutdownTimeout, err := newShutdownConfig(cfg.Shutdown)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		r.Drain()
+		if drainDelay > 0 {
+			log.Logger.Infof("Waiting %s for load balancers to stop sending traffic", drainDelay)
+			time.Sleep(drainDelay)
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		log.Logger.Infof("Shutting down, waiting up to %s for requests to finish", shutdownTimeout)
+		if err := server.ShutdownWithContext(shutdownCtx); err != nil {
+			log.Logger.Warnf("Shutdown: %v", err)
+		}
+	}()
+	jobs.Start(ctx)
+
+	// 限速连接在 TLS 之内，处理器通过 ctx.Conn() 为请求设置限速器；任一地址停止服务时全部停止
+	errs := make(chan error, len(listeners))
+	for i, ln := range listeners {
+		var l net.Listener = bandwidth.Listen(connLimit.Listen(ln))
+		if ln.TLS != nil {
+			l = tls.NewListener(l, ln.TLS)
+		}
+		log.Logger.Infof("Server listening on %s (%s, tls=%t, systemd=%t)", infos[i].Address, ln.Network, ln.TLS != nil, ln.Systemd)
+		go func() { errs <- server.Serve(l) }()
+	}
+	err = <-errs
+	if err != nil {
+		listener.Close(listeners)
+	}
+	if stats != nil {
+		if saveErr := stats.Save(); saveErr != nil {
+			log.Logger.Warnf("Failed to save stats: %v", saveErr)
+		}
+		if saveErr := usage.Save(); saveErr != nil {
+			log.Logger.Warnf("Failed to save usage: %v", saveErr)
+		}
+	}
+	return err
+}
+
+// newPresigner 根据配置创建本地存储后端使用的预签名器，未启用重定向时返回 nil
+func newPresigner(cfg *config.Config) (storage.Presigner, error) {
+	rc := cfg.Storage.Redirect
+	if !rc.Enabled {
+		return nil, nil
+	}
+	if len(cfg.Storage.Encryption.Keys) > 0 {
+		return nil, fmt.Errorf("storage redirect cannot be used with storage encryption: clients would download ciphertext")
+	}
+	if cfg.Storage.Compression.Enabled {
+		return nil, fmt.Errorf("storage redirect cannot be used with storage compression: clients would download zstd data")
+	}
+	presigner, err := s3.NewPresigner(s3.PresignConfig{
+		Endpoint:  rc.Endpoint,
+		Region:    rc.Region,
+		Bucket:    rc.Bucket,
+		Prefix:    rc.Prefix,
+		AccessKey: rc.AccessKey,
+		SecretKey: rc.SecretKey,
+		PathStyle: rc.PathStyle,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return presigner, nil
+}
+
+// setupRedirect 设置预签名地址的有效期，预签名器由 newPresigner 加到仓库的存储后端
+func setupRedirect(r *api.API, rc config.RedirectConfig) error {
+	expires := 15 * time.Minute
+	if rc.Expires != "" {
+		var err error
+		if expires, err = time.ParseDuration(rc.Expires); err != nil {
+			return fmt.Errorf("invalid redirect expires %q: %w", rc.Expires, err)
+		}
+	}
+	r.SetRedirect(expires, rc.MinSize)
+	log.Logger.Debugf("Package downloads redirect to %s/%s", rc.Endpoint, rc.Bucket)
+	return nil
+}
+
+// newReadCache 根据配置创建磁盘读缓存，未配置路径时返回 nil
+func newReadCache(rc config.ReadCacheConfig) (*cache.DiskCache, error) {
+	if rc.Path == "" {
+		return nil, nil
+	}
+	maxSize, err := utils.ParseSize(rc.MaxSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid read cache max-size: %w", err)
+	}
+	c, err := cache.NewDiskCache(rc.Path, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger.Debugf("Read cache enabled at %s (%s)", rc.Path, utils.FormatFileSize(c.Metrics().MaxSize))
+	return c, nil
+}
+
+// newIndex 打开 database-path 下的制品索引并解析扫描间隔
+func newIndex(cfg *config.Config) (*index.Index, time.Duration, error) {
+	if cfg.DatabasePath == "" {
+		return nil, 0, fmt.Errorf("index requires database-path")
+	}
+	interval := 10 * time.Minute
+	if ic := cfg.Index.ReconcileInterval; ic != "" {
+		d, err := time.ParseDuration(ic)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid index reconcile-interval %q: %w", ic, err)
+		}
+		interval = d
+	}
+	idx, err := index.Open(cfg.DatabasePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	log.Logger.Debugf("Index opened at %s", filepath.Join(cfg.DatabasePath, index.FileName))
+	return idx, interval, nil
+}
+
+// newEventBus 按配置创建 Kafka 或 NATS 发布
+func newEventBus(cfg *config.Config, node string) (*eventbus.Bus, error) {
+	pc := cfg.Events.Publish
+	bc := eventbus.Config{
+		Kind:     pc.Kind,
+		Brokers:  pc.Brokers,
+		Topic:    pc.Topic,
+		Username: pc.Username,
+		Password: pc.Password,
+		Token:    pc.Token,
+		TLS:      pc.TLS,
+	}
+	if pc.Timeout != "" {
+		d, err := time.ParseDuration(pc.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid events publish timeout %q: %w", pc.Timeout, err)
+		}
+		bc.Timeout = d
+	}
+	bus, err := eventbus.New(bc, node)
+	if err != nil {
+		return nil, fmt.Errorf("events publish: %w", err)
+	}
+	log.Logger.Infof("Publishing events to %s %s", pc.Kind, strings.Join(pc.Brokers, ","))
+	return bus, nil
+}
+
+// newCluster 连接 Redis 并创建集群协调器
+func newCluster(cfg *config.Config) (*cluster.Cluster, error) {
+	cc := cfg.Cluster
+	rc := cluster.RedisConfig{
+		Addr:     cc.Redis.Addr,
+		Password: cc.Redis.Password,
+		DB:       cc.Redis.DB,
+		Prefix:   cc.Redis.Prefix,
+	}
+	var ttl time.Duration
+	for _, p := range []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"lock-ttl", cc.LockTTL, &ttl},
+		{"redis timeout", cc.Redis.Timeout, &rc.Timeout},
+	} {
+		if p.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(p.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster %s %q: %w", p.name, p.value, err)
+		}
+		*p.dst = d
+	}
+
+	node := cc.Node
+	if node == "" {
+		node, _ = os.Hostname()
+	}
+	if cfg.Storage.Type == "" || cfg.Storage.Type == "local" {
+		log.Logger.Warnf("Cluster mode with local storage: %s must be shared by all nodes", cfg.StoragePath)
+	}
+	store, err := cluster.NewRedisStore(rc)
+	if err != nil {
+		return nil, fmt.Errorf("cluster store: %w", err)
+	}
+	log.Logger.Infof("Cluster node %s using redis %s", node, rc.Addr)
+	return cluster.New(store, node, ttl), nil
+}
+
+// newPolicy 转换 auth.policy，只保留配置了的类别
+func newPolicy(rules []config.PolicyRule) (*access.Policy, error) {
+	converted := make([]access.Rule, 0, len(rules))
+	for _, rule := range rules {
+		r := access.Rule{Repos: rule.Repos, Roles: make(map[access.Class][]string)}
+		for class, roles := range map[access.Class][]string{
+			access.ClassDownload: rule.Download,
+			access.ClassUpload:   rule.Upload,
+			access.ClassRefresh:  rule.Refresh,
+			access.ClassDelete:   rule.Delete,
+			access.ClassAdmin:    rule.Admin,
+		} {
+			if roles != nil {
+				r.Roles[class] = roles
+			}
+		}
+		converted = append(converted, r)
+	}
+	return access.NewPolicy(converted)
+}
+
+// setupApprovals 有受保护仓库时打开 database-path 下的批准请求库。
+// 双人批准按身份区分管理员，因此要求启用认证
+func setupApprovals(r *api.API, cfg *config.Config) error {
+	var protected []string
+	for name, rc := range cfg.Repositories {
+		if rc.Protected {
+			protected = append(protected, name)
+		}
+	}
+	if len(protected) == 0 {
+		return nil
+	}
+	sort.Strings(protected)
+	if !cfg.Auth.Enabled {
+		return fmt.Errorf("protected repositories %v require auth.enabled", protected)
+	}
+	if cfg.DatabasePath == "" {
+		return fmt.Errorf("protected repositories %v require database-path", protected)
+	}
+	approvals, err := approval.Open(cfg.DatabasePath)
+	if err != nil {
+		return err
+	}
+	r.SetApprovals(approvals)
+	log.Logger.Infof("Deletions and overwrites in %v require approval by a second admin", protected)
+	return nil
+}
+
+// newFeatures 加载功能开关，有 database-path 时保存运行时的修改
+func newFeatures(cfg *config.Config) (*feature.Set, error) {
+	flags, err := feature.New(cfg.Features, cfg.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range flags.List() {
+		if !feature.Registered(f.Name) {
+			log.Logger.Warnf("Unknown feature flag %s, check the name in features", f.Name)
+			continue
+		}
+		if f.Source != feature.SourceDefault {
+			log.Logger.Infof("Feature flag %s: enabled=%v rollout=%d%% (%s)", f.Name, f.Enabled, f.Rollout, f.Source)
+		}
+	}
+	if cfg.DatabasePath == "" {
+		log.Logger.Debug("Runtime feature flag changes are not kept across restarts without database-path")
+	}
+	return flags, nil
+}
+
+// setOverwritePolicy 按仓库配置的 overwrite 处理上传已有的文件名
+func setOverwritePolicy(cfg *config.Config, repoService *service.RepoService) error {
+	for name, rc := range cfg.Repositories {
+		if !service.ValidOverwritePolicy(rc.Overwrite) {
+			return fmt.Errorf("invalid overwrite policy %q for repository %s, expected overwrite, reject or auto-version", rc.Overwrite, name)
+		}
+	}
+	repoService.SetOverwritePolicy(func(repoName string) string {
+		rc, _ := cfg.RepoConfig(repoName)
+		return rc.Overwrite
+	})
+	return nil
+}
+
+// setFilenamePolicy 编译每个仓库的 filenames 规则，不符合规则的上传被拒绝
+func setFilenamePolicy(cfg *config.Config, repoService *service.RepoService) error {
+	policies := make(map[string]*filepolicy.Policy)
+	for name, rc := range cfg.Repositories {
+		policy, err := filepolicy.New(rc.Filenames.Allow, rc.Filenames.Deny, rc.Filenames.Message)
+		if err != nil {
+			return fmt.Errorf("repository %s: %w", name, err)
+		}
+		if policy == nil {
+			continue
+		}
+		policies[name] = policy
+		if rc.Name != "" {
+			policies[rc.Name] = policy
+		}
+	}
+	repoService.SetFilenamePolicy(func(repoName string) *filepolicy.Policy {
+		return policies[repoName]
+	})
+	return nil
+}
+
+// setLicensePolicy 按每个仓库的 licenses 拒绝许可证被禁止的包
+func setLicensePolicy(cfg *config.Config, repoService *service.RepoService) error {
+	policies := make(map[string]*license.Policy)
+	for name, rc := range cfg.Repositories {
+		policy, err := license.New(rc.Licenses.Deny, rc.Licenses.Message)
+		if err != nil {
+			return fmt.Errorf("repository %s: %w", name, err)
+		}
+		if policy == nil {
+			continue
+		}
+		policies[name] = policy
+		if rc.Name != "" {
+			policies[rc.Name] = policy
+		}
+	}
+	repoService.SetLicensePolicy(func(repoName string) *license.Policy {
+		return policies[repoName]
+	})
+	return nil
+}
+
+// setReleaseFields 按仓库配置的 release 生成 DEB 仓库的 Release 文件，仓库的签名密钥配置了私钥时同时生成 InRelease 和 Release.gpg
+func setReleaseFields(cfg *config.Config, repoService *service.RepoService, keyring *signing.Keyring) error {
+	validFor := make(map[string]time.Duration)
+	for name, rc := range cfg.Repositories {
+		if rc.Release.ValidUntil == "" {
+			continue
+		}
+		d, err := time.ParseDuration(rc.Release.ValidUntil)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid release valid-until %q for repository %s", rc.Release.ValidUntil, name)
+		}
+		validFor[rc.Release.ValidUntil] = d
+	}
+	repoService.SetReleaseFields(func(repoName string) repo.ReleaseFields {
+		rc, _ := cfg.RepoConfig(repoName)
+		return repo.ReleaseFields{
+			Origin:   rc.Release.Origin,
+			Label:    rc.Release.Label,
+			Suite:    rc.Release.Suite,
+			Codename: rc.Release.Codename,
+			ValidFor: validFor[rc.Release.ValidUntil],
+
+			Sign:      keyring.SignForRepo(cfg, repoName),
+			ClearSign: keyring.ClearSignForRepo(cfg, repoName),
+		}
+	})
+	return nil
+}
+
+// setDirectorySums 按仓库配置的 sha256sums 在 files 仓库的每个目录中维护 SHA256SUMS，仓库的签名密钥配置了私钥时同时签名
+func setDirectorySums(cfg *config.Config, repoService *service.RepoService, keyring *signing.Keyring) {
+	repoService.SetDirectorySums(func(repoName string) repo.DirectorySums {
+		rc, _ := cfg.RepoConfig(repoName)
+		if !rc.SHA256Sums {
+			return repo.DirectorySums{}
+		}
+		return repo.DirectorySums{Enabled: true, Sign: keyring.SignForRepo(cfg, repoName)}
+	})
+}
+
+// newAccess 加载 database-path 下的用户库，配置了 LDAP 时返回同步间隔
+func newAccess(cfg *config.Config) (*access.Store, time.Duration, error) {
+	if cfg.DatabasePath == "" {
+		return nil, 0, fmt.Errorf("access requires database-path")
+	}
+	users, err := access.Open(cfg.DatabasePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	sessionTTL := access.DefaultSessionTTL
+	if v := cfg.Access.SessionTTL; v != "" {
+		if sessionTTL, err = time.ParseDuration(v); err != nil || sessionTTL <= 0 {
+			return nil, 0, fmt.Errorf("invalid access session-ttl %q", v)
+		}
+	}
+	users.SetSessions(cfg.Access.SessionSecret, sessionTTL)
+	if v := cfg.Access.UploadTokenMaxTTL; v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, 0, fmt.Errorf("invalid access upload-token-max-ttl %q", v)
+		}
+		users.SetUploadTokenMaxTTL(d)
+	}
+
+	lc := cfg.Access.LDAP
+	if lc.URL == "" {
+		return users, 0, nil
+	}
+
+	ldap := access.LDAPConfig{
+		URL:                lc.URL,
+		BindDN:             lc.BindDN,
+		BindPassword:       lc.BindPassword,
+		BaseDN:             lc.BaseDN,
+		Filter:             lc.Filter,
+		UserAttr:           lc.UserAttr,
+		EmailAttr:          lc.EmailAttr,
+		GroupAttr:          lc.GroupAttr,
+		InsecureSkipVerify: lc.InsecureSkipVerify,
+	}
+	interval := time.Hour
+	for _, p := range []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"timeout", lc.Timeout, &ldap.Timeout},
+		{"sync-interval", lc.SyncInterval, &interval},
+	} {
+		if p.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(p.value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid ldap %s %q: %w", p.name, p.value, err)
+		}
+		*p.dst = d
+	}
+	users.SetLDAP(ldap)
+	log.Logger.Infof("LDAP sync from %s every %s", lc.URL, interval)
+	return users, interval, nil
+}
+
+// newShutdownConfig 解析排空等待时间和停止服务的超时时间
+func newShutdownConfig(sc config.ShutdownConfig) (drainDelay, timeout time.Duration, err error) {
+	timeout = 30 * time.Second
+	for _, p := range []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"drain-delay", sc.DrainDelay, &drainDelay},
+		{"timeout", sc.Timeout, &timeout},
+	} {
+		if p.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(p.value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid shutdown %s %q: %w", p.name, p.value, err)
+		}
+		*p.dst = d
+	}
+	return drainDelay, timeout, nil
+}
+
+// newStatsStore 加载 database-path 下保存的累计计数器，未配置 database-path 或间隔为 0 时返回 nil
+func newStatsStore(cfg *config.Config, bandwidth *throttle.Throttle) (*metrics.Store, time.Duration, error) {
+	interval := time.Minute
+	if pi := cfg.Stats.PersistInterval; pi != "" {
+		d, err := time.ParseDuration(pi)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid stats persist-interval %q: %w", pi, err)
+		}
+		interval = d
+	}
+	if cfg.DatabasePath == "" || interval <= 0 {
+		return nil, 0, nil
+	}
+	stats, err := metrics.OpenStore(cfg.DatabasePath, func() (int64, int64) {
+		m := bandwidth.Metrics()
+		return m.BytesSent, m.BytesReceived
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	log.Logger.Debugf("Lifetime stats loaded from %s", filepath.Join(cfg.DatabasePath, metrics.StatsFileName))
+	return stats, interval, nil
+}
+
+// newAlerts 创建告警检查并加入定时任务
+func newAlerts(cfg *config.Config, jobs *scheduler.Scheduler, repoService *service.RepoService) (*alert.Manager, error) {
+	src := alert.Sources{
+		Requests: func() (int64, int64) { return metrics.GetMetrics().RequestCount, metrics.ServerErrors() },
+		Jobs:     func() []types.JobStatus { return jobs.Status().Jobs },
+	}
+	if cfg.Alerts.Storage.Threshold > 0 {
+		quota, err := utils.ParseSize(cfg.Alerts.Storage.Quota)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alerts storage quota: %w", err)
+		}
+		switch {
+		case quota > 0:
+			// 用量为索引中制品的总大小
+			if !cfg.Index.Enabled {
+				return nil, fmt.Errorf("alerts storage quota requires the index to be enabled")
+			}
+			src.Storage = func() (int64, int64, error) {
+				m := repoService.IndexMetrics()
+				if m == nil || !m.Ready {
+					return 0, 0, fmt.Errorf("index is not ready")
+				}
+				return m.Bytes, quota, nil
+			}
+		case cfg.Storage.Type == "" || cfg.Storage.Type == "local":
+			src.Storage = func() (int64, int64, error) { return alert.DiskUsage(cfg.StoragePath) }
+		default:
+			return nil, fmt.Errorf("alerts storage threshold on %s storage requires a quota", cfg.Storage.Type)
+		}
+	}
+
+	alerts, interval, err := alert.New(cfg.Alerts, jobs.Status().Node, src)
+	if err != nil {
+		return nil, err
+	}
+	if err := jobs.Add(scheduler.Job{
+		Name:     "alerts-check",
+		Scope:    scheduler.ScopeNode,
+		Interval: interval,
+		Run:      alerts.Check,
+	}); err != nil {
+		return nil, err
+	}
+	log.Logger.Infof("Alerts enabled, checking every %s", interval)
+	return alerts, nil
+}
+
+// newMetadataCache 根据配置创建元数据内存缓存
+func newMetadataCache(cc config.CacheConfig) (*cache.MetadataCache, error) {
+	var ttl time.Duration
+	if cc.TTL != "" {
+		d, err := time.ParseDuration(cc.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache ttl %q: %w", cc.TTL, err)
+		}
+		ttl = d
+	}
+	c := cache.NewMetadataCache(ttl, int64(cc.MaxSize)<<20)
+	log.Logger.Debugf("Metadata cache enabled (%s)", utils.FormatFileSize(c.Metrics().MaxSize))
+	return c, nil
+}
+
+// newMetalink 解析 metalink 配置并创建生成结果的缓存
+func newMetalink(mc config.MetalinkConfig) (metalink.Options, *cache.MetadataCache, error) {
+	opts := metalink.Options{
+		MinSize:   defaultMetalinkMinSize,
+		PieceSize: metalink.DefaultPieceSize,
+		Mirrors:   mc.Mirrors,
+		Zsync:     mc.Zsync,
+		Trackers:  mc.Torrent.Trackers,
+	}
+	if mc.Torrent.Enabled {
+		opts.TorrentMinSize = defaultTorrentMinSize
+	}
+	for _, v := range []struct {
+		name  string
+		value string
+		dst   *int64
+	}{
+		{"min-size", mc.MinSize, &opts.MinSize},
+		{"piece-size", mc.PieceSize, &opts.PieceSize},
+		{"torrent min-size", mc.Torrent.MinSize, &opts.TorrentMinSize},
+	} {
+		if v.value == "" {
+			continue
+		}
+		n, err := utils.ParseSize(v.value)
+		if err != nil {
+			return opts, nil, fmt.Errorf("invalid metalink %s: %w", v.name, err)
+		}
+		*v.dst = n
+	}
+	if opts.PieceSize < 1<<10 {
+		return opts, nil, fmt.Errorf("metalink piece-size must be at least 1KB")
+	}
+	for _, mirror := range mc.Mirrors {
+		if !strings.HasPrefix(mirror, "http://") && !strings.HasPrefix(mirror, "https://") {
+			return opts, nil, fmt.Errorf("invalid metalink mirror %q: must be an http(s) URL", mirror)
+		}
+	}
+	if !mc.Torrent.Enabled {
+		opts.TorrentMinSize = 0
+	} else if opts.TorrentMinSize <= 0 {
+		return opts, nil, fmt.Errorf("metalink torrent min-size must be positive")
+	}
+	for _, tracker := range mc.Torrent.Trackers {
+		if !strings.Contains(tracker, "://") {
+			return opts, nil, fmt.Errorf("invalid torrent tracker %q: must be a URL", tracker)
+		}
+	}
+	// 键中包含文件大小和修改时间，文件变化后不会命中旧结果
+	c := cache.NewMetadataCache(24*time.Hour, int64(mc.CacheSize)<<20)
+	log.Logger.Infof("Metalink enabled for files of at least %s with %d mirrors", utils.FormatFileSize(opts.MinSize), len(opts.Mirrors))
+	if opts.TorrentMinSize > 0 {
+		log.Logger.Infof("Torrents enabled for files of at least %s with %d trackers", utils.FormatFileSize(opts.TorrentMinSize), len(opts.Trackers))
+	}
+	return opts, c, nil
+}
+
+// newThumbnails 检查缩略图配置并创建生成结果的缓存
+func newThumbnails(tc config.ThumbnailsConfig) (int, *cache.MetadataCache, error) {
+	size := tc.Size
+	if size == 0 {
+		size = thumbnail.DefaultSize
+	}
+	if size < thumbnail.MinSize || size > thumbnail.MaxSize {
+		return 0, nil, fmt.Errorf("thumbnails size must be between %d and %d", thumbnail.MinSize, thumbnail.MaxSize)
+	}
+	if tc.CacheSize < 0 {
+		return 0, nil, fmt.Errorf("thumbnails cache-size must not be negative")
+	}
+	// 键中包含原图的大小和修改时间，原图变化后不会命中旧结果
+	c := cache.NewMetadataCache(24*time.Hour, int64(tc.CacheSize)<<20)
+	log.Logger.Infof("Thumbnails enabled for images in files repositories, default size %dpx", size)
+	return size, c, nil
+}
+
+// newSpooler 解析上传配置，返回 spool 和请求体的最大长度
+func newSpooler(uc config.UploadConfig) (*spool.Spooler, int64, error) {
+	maxBodySize, threshold := int64(MaxRequestBodySize), int64(defaultSpoolThreshold)
+	for _, v := range []struct {
+		name  string
+		value string
+		dst   *int64
+	}{
+		{"max-body-size", uc.MaxBodySize, &maxBodySize},
+		{"spool-threshold", uc.SpoolThreshold, &threshold},
+	} {
+		if v.value == "" {
+			continue
+		}
+		n, err := utils.ParseSize(v.value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid upload %s: %w", v.name, err)
+		}
+		*v.dst = n
+	}
+	if maxBodySize <= 0 {
+		return nil, 0, fmt.Errorf("upload max-body-size must be positive")
+	}
+	s, err := spool.New(uc.SpoolDir, threshold, maxBodySize)
+	if err != nil {
+		return nil, 0, err
+	}
+	log.Logger.Debugf("Spooling uploads above %s to %s, max body size %s", utils.FormatFileSize(threshold), s.Dir(), utils.FormatFileSize(maxBodySize))
+	return s, maxBodySize, nil
+}
+
+// newExpiry 检查制品有效期的配置，返回检查到期制品的间隔
+func newExpiry(uc config.UploadConfig) (time.Duration, error) {
+	if uc.MaxExpireAfter != "" {
+		if max, err := utils.ParseDuration(uc.MaxExpireAfter); err != nil || max <= 0 {
+			return 0, fmt.Errorf("invalid upload max-expire-after %q: must be a positive duration", uc.MaxExpireAfter)
+		}
+	}
+	interval := defaultExpiryInterval
+	if uc.ExpiryInterval != "" {
+		d, err := utils.ParseDuration(uc.ExpiryInterval)
+		if err != nil || d <= 0 {
+			return 0, fmt.Errorf("invalid upload expiry-interval %q: must be a positive duration", uc.ExpiryInterval)
+		}
+		interval = d
+	}
+	return interval, nil
+}
+
+// defaultExpiryInterval 默认每分钟检查一次到期的制品
+const defaultExpiryInterval = time.Minute
+
+// defaultSpoolThreshold 每个上传请求默认最多在内存中保存 1MB 表单内容
+const defaultSpoolThreshold = 1 << 20
+
+// defaultMetalinkMinSize 默认只为 10MB 以上的文件提供 metalink
+const defaultMetalinkMinSize = 10 << 20
+
+// defaultTorrentMinSize 默认只为 1GB 以上的文件提供 .torrent
+const defaultTorrentMinSize = 1 << 30
+
+// newRetryConfig 解析对象存储的重试配置，未设置的参数使用默认值
+func newRetryConfig(rc config.RetryConfig) (retry.Config, error) {
+	cfg := retry.Config{Attempts: rc.Attempts}
+	for _, d := range []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"backoff", rc.Backoff, &cfg.Backoff},
+		{"max-backoff", rc.MaxBackoff, &cfg.MaxBackoff},
+		{"timeout", rc.Timeout, &cfg.Timeout},
+	} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid storage retry %s %q: %w", d.name, d.value, err)
+		}
+		*d.dst = v
+	}
+	if len(rc.RetryableErrors) > 0 {
+		extra := rc.RetryableErrors
+		cfg.Retryable = func(err error) bool {
+			return retry.IsRetryable(err) || retry.MatchMessage(err, extra)
+		}
+	}
+	return cfg, nil
+}
+
+// newStorageBreaker 解析对象存储的熔断配置，未设置的参数使用默认值
+func newStorageBreaker(bc config.CircuitBreakerConfig) (*breaker.Breaker, error) {
+	cfg, err := newBreakerConfig(bc)
+	if err != nil {
+		return nil, err
+	}
+	return breaker.New("object-storage", cfg), nil
+}
+
+func newBreakerConfig(bc config.CircuitBreakerConfig) (breaker.Config, error) {
+	cfg := breaker.Config{Failures: bc.Failures}
+	if bc.Cooldown != "" {
+		d, err := time.ParseDuration(bc.Cooldown)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid storage circuit-breaker cooldown %q: %w", bc.Cooldown, err)
+		}
+		cfg.Cooldown = d
+	}
+	return cfg, nil
+}
+
+// newCompressor 解析对象存储的压缩配置，未启用时只解压已压缩的对象
+func newCompressor(cc config.CompressionConfig) (*compress.Compressor, error) {
+	cfg := compress.Config{Extensions: cc.Extensions, ReadOnly: !cc.Enabled}
+	if cc.Level != "" {
+		ok, level := zstd.EncoderLevelFromString(cc.Level)
+		if !ok {
+			return nil, fmt.Errorf("invalid storage compression level %q", cc.Level)
+		}
+		cfg.Level = level
+	}
+	if cc.MinSize != "" {
+		size, err := utils.ParseSize(cc.MinSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid storage compression min-size: %w", err)
+		}
+		cfg.MinSize = size
+	}
+	return compress.New(cfg), nil
+}
+
+// newReplicator 根据配置创建对象存储副本，未配置副本时返回 nil；每个副本各自重试
+func newReplicator(cfg *config.Config, retryCfg retry.Config) (*replicated.Replicator, error) {
+	rc := cfg.Storage.Replication
+	if len(rc.Replicas) == 0 {
+		return nil, nil
+	}
+	breakerCfg, err := newBreakerConfig(cfg.Storage.CircuitBreaker)
+	if err != nil {
+		return nil, err
+	}
+	var replicas []replicated.Replica
+	for _, r := range rc.Replicas {
+		if r.Path == "" || filepath.Clean(r.Path) == filepath.Clean(cfg.StoragePath) {
+			return nil, fmt.Errorf("storage replica %s needs a path other than storage-path", r.Name)
+		}
+		st := storage.StorageType(r.Type)
+		if st == "" {
+			st = storage.S3
+		}
+		backend, err := storage.Create(st, r.Path)
+		if err != nil {
+			return nil, fmt.Errorf("storage replica %s: %w", r.Name, err)
+		}
+		replicas = append(replicas, replicated.Replica{Name: r.Name, Storage: retry.New(backend, retryCfg)})
+	}
+	return replicated.New(replicas, replicated.Config{
+		WriteQuorum: rc.WriteQuorum,
+		Breaker:     breakerCfg,
+		SpoolDir:    rc.SpoolDir,
+	})
+}
+
+// storageLayers 对象存储后端外层的组件，为 nil 的组件不启用
+type storageLayers struct {
+	readCache  *cache.DiskCache
+	breaker    *breaker.Breaker
+	compressor *compress.Compressor
+	replicator *replicated.Replicator
+	presigner  storage.Presigner
+}
+
+// newRepoService 创建各类型仓库管理器并注册到服务
+func newRepoService(cfg *config.Config, layers storageLayers) (*service.RepoService, error) {
+	repos, err := newRepoFactory(cfg, layers)
+	if err != nil {
+		return nil, err
+	}
+	repoService, err := createRepos(repos)
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := signing.NewKeyring(cfg.Signing)
+	if err != nil {
+		return nil, err
+	}
+	if err := setReleaseFields(cfg, repoService, keyring); err != nil {
+		return nil, err
+	}
+	setDirectorySums(cfg, repoService, keyring)
+	return repoService, nil
+}
+
+// newRepoFactory 创建使用 layers 中存储组件的仓库工厂
+func newRepoFactory(cfg *config.Config, layers storageLayers) (*repo.RepoFactory, error) {
+	if err := loadPlugins(cfg); err != nil {
+		return nil, err
+	}
+	if err := loadStorageBackends(cfg); err != nil {
+		return nil, err
+	}
+	repos := repo.NewRepoFactory(cfg)
+	repos.SetCompression(layers.compressor)
+	repos.SetReplication(layers.replicator)
+	if layers.readCache != nil {
+		repos.SetReadCache(layers.readCache)
+	}
+	if layers.breaker != nil {
+		repos.SetBreaker(layers.breaker)
+	}
+	if layers.presigner != nil {
+		repos.SetPresigner(layers.presigner)
+	}
+	keyring, err := newEncryptionKeyring(cfg.Storage.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	if keyring != nil {
+		repos.SetEncryption(keyring)
+	}
+	retryCfg, err := newRetryConfig(cfg.Storage.Retry)
+	if err != nil {
+		return nil, err
+	}
+	repos.SetRetry(retryCfg)
+	return repos, nil
+}
+
+// createRepos 创建各类型仓库管理器并注册到服务
+func createRepos(repos *repo.RepoFactory) (*service.RepoService, error) {
+	// 初始化 RPM 仓库管理器
+	rpmRepo, err := repos.CreateRepo(repo.RPM)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Logger.Debugf("RPM repo init success: %s", rpmRepo.Type())
+
+	filesRepo, err := repos.CreateRepo(repo.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Logger.Debugf("Files repo init success: %s", filesRepo.Type())
+
+	debRepo, err := repos.CreateRepo(repo.DEB)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Logger.Debugf("DEB repo init success: %s", debRepo.Type())
+
+	all := []repo.Repo{rpmRepo, filesRepo, debRepo}
+	// 插件注册的仓库类型
+	for _, rt := range repo.Types() {
+		if rt == repo.RPM || rt == repo.Files || rt == repo.DEB {
+			continue
+		}
+		r, err := repos.CreateRepo(rt)
+		if err != nil {
+			return nil, err
+		}
+		log.Logger.Debugf("%s repo init success", rt)
+		all = append(all, r)
+	}
+
+	repoService := service.NewRepoService(all...)
+	repoService.SetBackends(repos.Storage)
+	return repoService, nil
+}
+
+// loadConfig 读取配置文件（不存在时使用空配置），命令行显式指定的参数优先
+func loadConfig(c *cli.Context) (*config.Config, error) {
+	var paths []string
+	if path := c.String("config"); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		} else if c.IsSet("config") {
+			return nil, fmt.Errorf("config file not found: %s", path)
+		}
+	}
+	// Kubernetes 部署中 ConfigMap 和 Secret 各自挂载为目录，片段按文件名顺序合并
+	if dir := c.String("config-dir"); dir != "" {
+		files, err := config.DirFiles(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config dir %s: %w", dir, err)
+		}
+		paths = append(paths, files...)
+	}
+
+	cfg := &config.Config{}
+	if len(paths) > 0 {
+		loaded, err := config.Load(paths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = loaded
+	}
+
+	if c.IsSet("listen") || cfg.Listen == "" {
+		cfg.Listen = c.String("listen")
+	}
+	// 命令行指定 --listen 时覆盖配置文件中的 listeners；都未配置时使用 systemd 传入的 socket
+	if c.IsSet("listen") {
+		cfg.Listeners = nil
+	} else if len(cfg.Listeners) == 0 && listener.Activated() {
+		cfg.Listeners = []config.ListenerConfig{{Address: "systemd"}}
+	}
+	if c.IsSet("storage-path") || cfg.StoragePath == "" {
+		cfg.StoragePath = c.String("storage-path")
+	}
+	cfg.StoragePath = filepath.Clean(cfg.StoragePath)
+	if c.IsSet("log") || cfg.Log == "" {
+		cfg.Log = c.String("log")
+	}
+	if c.IsSet("log-level") || cfg.LogLevel == "" {
+		cfg.LogLevel = c.String("log-level")
+	}
+	if c.IsSet("no-migrate") {
+		cfg.NoMigrate = c.Bool("no-migrate")
+	}
+	// 上传、导入、迁移和下载都使用配置的 Content-Type
+	if err := mimetype.Set(cfg.MimeTypes); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"plus/internal/bench"
+	"plus/internal/types"
+
+	"github.com/urfave/cli"
+)
+
+// Bench 对运行中的服务执行压测，打印结果并按需写入 JSON；超出基线时以退出码 1 结束
+func Bench(c *cli.Context) error {
+	mix, err := parseMix(c.String("mix"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 2)
+	}
+
+	runner, err := bench.New(bench.Config{
+		URL:         c.String("url"),
+		Scenario:    c.String("scenario"),
+		Mix:         mix,
+		Repo:        c.String("repo"),
+		RefreshRepo: c.String("refresh-repo"),
+		Concurrency: c.Int("concurrency"),
+		Requests:    c.Int64("requests"),
+		Duration:    c.Duration("duration"),
+		Warmup:      c.Duration("warmup"),
+		Size:        c.Int("size"),
+		Token:       c.String("token"),
+		Timeout:     c.Duration("timeout"),
+	})
+	if err != nil {
+		return cli.NewExitError(err.Error(), 2)
+	}
+
+	// Ctrl-C 时中止并清理临时仓库
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := runner.Run(ctx)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 2)
+	}
+	printReport(c.App.Writer, report)
+
+	if path := c.String("output"); path != "" {
+		data, err := report.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+
+	if path := c.String("baseline"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cli.NewExitError(fmt.Sprintf("failed to read baseline: %v", err), 2)
+		}
+		baseline := &types.BenchReport{}
+		if err := baseline.UnmarshalJSON(data); err != nil {
+			return cli.NewExitError(fmt.Sprintf("invalid baseline %s: %v", path, err), 2)
+		}
+		if regressions := bench.Compare(baseline, report, c.Float64("max-regression")); len(regressions) > 0 {
+			return cli.NewExitError("regressions against baseline:\n  "+strings.Join(regressions, "\n  "), 1)
+		}
+	}
+	return nil
+}
+
+// parseMix 解析 "download=60,list=25" 形式的操作比例
+func parseMix(s string) (map[string]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	mix := make(map[string]int)
+	for _, part := range strings.Split(s, ",") {
+		op, weight, ok := strings.Cut(strings.TrimSpace(part), "=")
+		n, err := strconv.Atoi(weight)
+		if !ok || err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid mix entry %q, expected op=weight", part)
+		}
+		mix[op] = n
+	}
+	return mix, nil
+}
+
+func printReport(w io.Writer, r *types.BenchReport) {
+	fmt.Fprintf(w, "scenario %s against %s: %d requests, %d errors in %.1fs (%.2f req/s, concurrency %d)\n\n",
+		r.Scenario, r.URL, r.Requests, r.Errors, r.DurationSeconds, r.RPS, r.Concurrency)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "operation\trequests\terrors\treq/s\tmin\tmean\tp50\tp90\tp95\tp99\tmax\t")
+	for _, op := range r.Operations {
+		l := op.Latency
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t\n",
+			op.Name, op.Requests, op.Errors, op.RPS, l.Min, l.Mean, l.P50, l.P90, l.P95, l.P99, l.Max)
+	}
+	tw.Flush()
+	fmt.Fprintln(w, "\nlatencies in milliseconds")
+}
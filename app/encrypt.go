@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+	"plus/pkg/storage/encrypt"
+	"plus/pkg/storage/retry"
+
+	"github.com/urfave/cli"
+)
+
+// newEncryptionKeyring 读取对象存储加密的主密钥，未配置密钥时返回 nil
+func newEncryptionKeyring(ec config.EncryptionConfig) (*encrypt.Keyring, error) {
+	if len(ec.Keys) == 0 {
+		return nil, nil
+	}
+	keys := make([]encrypt.Key, 0, len(ec.Keys))
+	for _, kc := range ec.Keys {
+		secret, err := loadEncryptionKey(kc)
+		if err != nil {
+			return nil, fmt.Errorf("storage encryption key %q: %w", kc.ID, err)
+		}
+		keys = append(keys, encrypt.Key{ID: kc.ID, Secret: secret})
+	}
+	keyring, err := encrypt.NewKeyring(ec.ActiveKey, keys)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger.Debugf("Storage encryption enabled with %d keys (active %s)", len(keys), keyring.Active())
+	return keyring, nil
+}
+
+// loadEncryptionKey 从配置、文件或命令输出读取 base64 编码的密钥
+func loadEncryptionKey(kc config.EncryptionKeyConfig) ([]byte, error) {
+	var value string
+	switch {
+	case kc.Key != "":
+		value = kc.Key
+	case kc.KeyFile != "":
+		data, err := os.ReadFile(kc.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		value = string(data)
+	case kc.KeyCommand != "":
+		out, err := exec.Command("sh", "-c", kc.KeyCommand).Output()
+		if err != nil {
+			return nil, fmt.Errorf("key-command failed: %w", err)
+		}
+		value = string(out)
+	default:
+		return nil, fmt.Errorf("one of key, key-file or key-command is required")
+	}
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 key: %w", err)
+	}
+	return secret, nil
+}
+
+// RotateKeys 将对象存储中的对象改为使用当前的 active 密钥，未加密的对象加密保存
+func RotateKeys(c *cli.Context) error {
+	cfg, err := loadConfig(c.Parent())
+	if err != nil {
+		return err
+	}
+
+	log.Init(cfg.Log, cfg.LogLevel)
+
+	if err := upgradeLayout(cfg); err != nil {
+		return err
+	}
+
+	keyring, err := newEncryptionKeyring(cfg.Storage.Encryption)
+	if err != nil {
+		return err
+	}
+	if keyring == nil {
+		return cli.NewExitError("storage encryption is not configured", 2)
+	}
+	retryCfg, err := newRetryConfig(cfg.Storage.Retry)
+	if err != nil {
+		return err
+	}
+
+	if err := loadPlugins(cfg); err != nil {
+		return err
+	}
+	if err := loadStorageBackends(cfg); err != nil {
+		return err
+	}
+	backend, err := storage.CreateByLable(cfg.StoragePath, string(repo.Files))
+	if err != nil {
+		return err
+	}
+	if storage.TypeByLable(string(repo.Files)) == storage.Local {
+		return cli.NewExitError("files repositories are on local storage, which is not encrypted", 2)
+	}
+
+	// 配置了副本时所有副本上的对象都需要轮换
+	backend = retry.New(backend, retryCfg)
+	replicator, err := newReplicator(cfg, retryCfg)
+	if err != nil {
+		return err
+	}
+	if replicator != nil {
+		backend = replicator.Wrap(backend)
+	}
+	s := encrypt.New(backend, keyring)
+	stats, err := s.Rotate(context.Background(), c.String("prefix"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 2)
+	}
+	fmt.Fprintf(c.App.Writer, "rotated %d, encrypted %d, already current %d, failed %d\n",
+		stats.Rotated, stats.Encrypted, stats.Current, stats.Failed)
+
+	if stats.Failed > 0 {
+		return cli.NewExitError("", 1)
+	}
+	return nil
+}
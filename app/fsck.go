@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"plus/internal/log"
+
+	"github.com/urfave/cli"
+)
+
+// Fsck 检查仓库一致性，向标准输出写入 JSON 报告；发现问题时以退出码 1 结束
+func Fsck(c *cli.Context) error {
+	repoName := c.String("repo")
+	if repoName == "" {
+		return cli.NewExitError("--repo is required", 2)
+	}
+
+	cfg, err := loadConfig(c.Parent())
+	if err != nil {
+		return err
+	}
+
+	log.Init(cfg.Log, cfg.LogLevel)
+
+	if err := upgradeLayout(cfg); err != nil {
+		return err
+	}
+
+	compressor, err := newCompressor(cfg.Storage.Compression)
+	if err != nil {
+		return err
+	}
+	retryCfg, err := newRetryConfig(cfg.Storage.Retry)
+	if err != nil {
+		return err
+	}
+	replicator, err := newReplicator(cfg, retryCfg)
+	if err != nil {
+		return err
+	}
+	// 直接校验存储中的内容，不经过读缓存和熔断
+	repoService, err := newRepoService(cfg, storageLayers{compressor: compressor, replicator: replicator})
+	if err != nil {
+		return err
+	}
+
+	report, err := repoService.CheckRepo(context.Background(), repoName, c.Bool("repair"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 2)
+	}
+
+	data, err := report.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer, string(data))
+
+	if !report.Healthy {
+		return cli.NewExitError("", 1)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"plus/internal/log"
+
+	"github.com/urfave/cli"
+)
+
+// Import 把现有的 yum 和 apt 仓库树导入配置的存储，向标准输出写入 JSON 报告；
+// 有仓库导入失败时以退出码 1 结束
+func Import(c *cli.Context) error {
+	root := c.String("path")
+	if root == "" {
+		return cli.NewExitError("--path is required", 2)
+	}
+
+	cfg, err := loadConfig(c.Parent())
+	if err != nil {
+		return err
+	}
+
+	log.Init(cfg.Log, cfg.LogLevel)
+
+	if err := upgradeLayout(cfg); err != nil {
+		return err
+	}
+
+	compressor, err := newCompressor(cfg.Storage.Compression)
+	if err != nil {
+		return err
+	}
+	retryCfg, err := newRetryConfig(cfg.Storage.Retry)
+	if err != nil {
+		return err
+	}
+	replicator, err := newReplicator(cfg, retryCfg)
+	if err != nil {
+		return err
+	}
+	repoService, err := newRepoService(cfg, storageLayers{compressor: compressor, replicator: replicator})
+	if err != nil {
+		return err
+	}
+
+	report, err := repoService.Import(context.Background(), root, c.String("prefix"), c.Bool("regenerate"), c.Bool("dry-run"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 2)
+	}
+
+	data, err := report.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer, string(data))
+
+	if report.Failed > 0 {
+		return cli.NewExitError("", 1)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/scheduler"
+	"plus/internal/service"
+)
+
+// defaultRetentionKeep retention 任务默认保留的版本数
+const defaultRetentionKeep = 3
+
+// addRepoJobs 注册仓库配置中的定时任务
+func addRepoJobs(jobs *scheduler.Scheduler, cfg *config.Config, repoService *service.RepoService) error {
+	names := make([]string, 0, len(cfg.Repositories))
+	for name := range cfg.Repositories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, repoName := range names {
+		for _, jc := range cfg.Repositories[repoName].Jobs {
+			job, err := newRepoJob(repoName, jc, repoService)
+			if err != nil {
+				return fmt.Errorf("repository %s: %w", repoName, err)
+			}
+			if err := jobs.Add(job); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newRepoJob 解析仓库任务的计划和动作。仓库内容在共享存储中，任务只在领导者上执行
+func newRepoJob(repoName string, jc config.RepoJobConfig, repoService *service.RepoService) (scheduler.Job, error) {
+	job := scheduler.Job{Name: scheduler.RepoJobName(jc.Action, repoName), Scope: scheduler.ScopeCluster}
+	if jc.Schedule == "" {
+		return job, fmt.Errorf("job %s needs a schedule", jc.Action)
+	}
+	if d, err := time.ParseDuration(jc.Schedule); err == nil {
+		if d <= 0 {
+			return job, fmt.Errorf("job %s: interval must be positive", jc.Action)
+		}
+		job.Interval = d
+	} else {
+		cron, err := scheduler.ParseCron(jc.Schedule)
+		if err != nil {
+			return job, fmt.Errorf("job %s: %w", jc.Action, err)
+		}
+		job.Cron = cron
+	}
+
+	switch jc.Action {
+	case "refresh":
+		job.Run = func(ctx context.Context) error { return repoService.RefreshMetadata(ctx, repoName) }
+	case "retention":
+		keep := jc.Keep
+		if keep == 0 {
+			keep = defaultRetentionKeep
+		}
+		if keep < 0 {
+			return job, fmt.Errorf("job retention: keep must be positive")
+		}
+		job.Run = func(ctx context.Context) error {
+			_, err := repoService.ApplyRetention(ctx, repoName, keep)
+			return err
+		}
+	case "resign", "re-sign":
+		return job, fmt.Errorf("job %s is not supported: the server only holds public keys, sign metadata before uploading it", jc.Action)
+	default:
+		return job, fmt.Errorf("unknown job action %q (supported: refresh, retention)", jc.Action)
+	}
+	return job, nil
+}
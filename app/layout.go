@@ -0,0 +1,32 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"plus/internal/config"
+	"plus/internal/layout"
+)
+
+// storageMigrations storage-path 的布局升级，布局变化（如内容寻址存储、按架构分目录）在这里按版本追加
+var storageMigrations []layout.Migration
+
+// databaseMigrations database-path 的布局升级
+var databaseMigrations []layout.Migration
+
+// upgradeLayout 把 storage-path 和 database-path 升级到当前布局；配置 no-migrate 时只检查，布局不是当前版本时拒绝启动
+func upgradeLayout(cfg *config.Config) error {
+	trees := []layout.Tree{{Name: "storage", Root: cfg.StoragePath, Migrations: storageMigrations}}
+	if cfg.DatabasePath != "" {
+		trees = append(trees, layout.Tree{Name: "database", Root: cfg.DatabasePath, Migrations: databaseMigrations})
+	}
+	for _, tree := range trees {
+		if _, _, err := tree.Upgrade(cfg.NoMigrate); err != nil {
+			if errors.Is(err, layout.ErrMigrationRequired) {
+				return fmt.Errorf("%w; back up %s and start without --no-migrate to upgrade it", err, tree.Root)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/migrate"
+	"plus/internal/types"
+	"plus/internal/utils"
+
+	"github.com/urfave/cli"
+)
+
+// Migrate 从配置的 Nexus、Artifactory 或 Pulp 服务复制仓库，向标准输出写入 JSON 报告；
+// 有文件复制失败时以退出码 1 结束，重新运行会跳过已复制的文件
+func Migrate(c *cli.Context) error {
+	name := c.String("source")
+	if name == "" {
+		return cli.NewExitError("--source is required", 2)
+	}
+	if len(c.StringSlice("repo")) == 0 {
+		return cli.NewExitError("at least one --repo is required", 2)
+	}
+	type mapping struct{ remote, local string }
+	var repos []mapping
+	for _, arg := range c.StringSlice("repo") {
+		remote, local, ok := strings.Cut(arg, "=")
+		if !ok {
+			local = remote
+		}
+		local = strings.Trim(local, "/")
+		if remote == "" || !utils.IsValidRepoName(local) {
+			return cli.NewExitError(fmt.Sprintf("invalid --repo %q", arg), 2)
+		}
+		repos = append(repos, mapping{remote, local})
+	}
+
+	cfg, err := loadConfig(c.Parent())
+	if err != nil {
+		return err
+	}
+
+	log.Init(cfg.Log, cfg.LogLevel)
+
+	if err := upgradeLayout(cfg); err != nil {
+		return err
+	}
+
+	var sourceCfg *config.MigrationSourceConfig
+	for i := range cfg.Migration.Sources {
+		if cfg.Migration.Sources[i].Name == name {
+			sourceCfg = &cfg.Migration.Sources[i]
+		}
+	}
+	if sourceCfg == nil {
+		return cli.NewExitError(fmt.Sprintf("migration source %s is not configured", name), 2)
+	}
+	src, err := migrate.New(*sourceCfg)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 2)
+	}
+
+	compressor, err := newCompressor(cfg.Storage.Compression)
+	if err != nil {
+		return err
+	}
+	retryCfg, err := newRetryConfig(cfg.Storage.Retry)
+	if err != nil {
+		return err
+	}
+	replicator, err := newReplicator(cfg, retryCfg)
+	if err != nil {
+		return err
+	}
+	repoService, err := newRepoService(cfg, storageLayers{compressor: compressor, replicator: replicator})
+	if err != nil {
+		return err
+	}
+
+	report := &types.MigrationReport{
+		Status: types.Status{Status: "success"},
+		Source: sourceCfg.Name,
+		Kind:   sourceCfg.Kind,
+		DryRun: c.Bool("dry-run"),
+	}
+	for _, m := range repos {
+		r := migrate.Migrate(context.Background(), src, repoService, m.remote, m.local, report.DryRun)
+		if r.Error != "" || r.Failed > 0 {
+			report.Status.Status = "partial"
+		}
+		report.Repos = append(report.Repos, r)
+	}
+
+	data, err := report.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer, string(data))
+
+	if report.Status.Status != "success" {
+		return cli.NewExitError("", 1)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"strings"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/pkg/plugin"
+)
+
+// loadPlugins 加载配置的插件，插件注册的仓库类型和存储类型在创建仓库之前可用
+func loadPlugins(cfg *config.Config) error {
+	if len(cfg.Plugins) == 0 {
+		return nil
+	}
+	loaded, err := plugin.Load(cfg.Plugins)
+	for _, p := range loaded {
+		log.Logger.Infof("Loaded plugin %s %s from %s: repository types [%s], storage types [%s]",
+			p.Name, p.Version, p.Path, strings.Join(p.RepoTypes, ", "), strings.Join(p.StorageTypes, ", "))
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/journal"
+	"plus/internal/log"
+	"plus/internal/recovery"
+	"plus/internal/service"
+	"plus/pkg/storage/replicated"
+)
+
+// recoveryMinAge 多个实例共用存储时只清理早于该时间的残留，不影响其他实例进行中的写入和元数据生成。
+// 操作日志按记录的实例是否仍有心跳判断，只有没有记录实例的旧记录按该时间判断
+const recoveryMinAge = time.Hour
+
+// recoveryAge 单实例时清理全部残留
+func recoveryAge(cfg *config.Config) time.Duration {
+	if cfg.Cluster.Enabled {
+		return recoveryMinAge
+	}
+	return 0
+}
+
+// recoverStorage 清理上次崩溃留下的元数据生成目录、写入中断的临时文件和上传，记录恢复摘要，
+// 返回元数据发布中断、需要重新生成元数据的仓库
+func recoverStorage(cfg *config.Config) []string {
+	minAge := recoveryAge(cfg)
+	start := time.Now()
+	s := recovery.Storage(cfg.StoragePath, minAge)
+	if cfg.DatabasePath != "" {
+		s.Add(recovery.Database(cfg.DatabasePath, minAge))
+	}
+	if dir := cfg.Storage.Replication.SpoolDir; dir != "" {
+		s.Add(recovery.Files(dir, minAge, func(name string) bool {
+			return strings.HasPrefix(name, replicated.SpoolPrefix)
+		}))
+	}
+
+	for _, e := range s.Errors {
+		log.Logger.Warnf("Crash recovery: %s", e)
+	}
+	if s.Empty() {
+		log.Logger.Debugf("No leftovers of an unclean shutdown found")
+		return nil
+	}
+	log.Logger.Infof("Recovered from an unclean shutdown in %s: %s", time.Since(start).Round(time.Millisecond), s.String())
+	for _, name := range s.Repos {
+		log.Logger.Infof("Metadata publish of %s was interrupted, scheduling a refresh", name)
+	}
+	return s.Repos
+}
+
+// replayJournal 打开存储根目录下的操作日志，完成或回滚上次崩溃时进行中的发布、保留策略、克隆和排队的元数据刷新
+func replayJournal(ctx context.Context, cfg *config.Config, repoService *service.RepoService) error {
+	j, err := journal.Open(filepath.Join(cfg.StoragePath, journal.Dir))
+	if err != nil {
+		return err
+	}
+	repoService.SetJournal(j)
+	n, err := repoService.ReplayJournal(ctx, recoveryAge(cfg))
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Logger.Infof("Replayed %d interrupted operations from the journal", n)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/storagemigrate"
+	"plus/internal/types"
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+
+	"github.com/urfave/cli"
+)
+
+// storageCutoverInterval 服务检查 plus migrate-storage 是否已切换存储的间隔
+const storageCutoverInterval = 5 * time.Second
+
+// loadStorageBackends 使用 plus migrate-storage 切换后的存储类型。rpm 和 deb 仓库在本地文件系统上生成元数据，只能使用本地存储
+func loadStorageBackends(cfg *config.Config) error {
+	backends, err := storage.LoadBackends(cfg.StoragePath)
+	if err != nil {
+		return err
+	}
+	for label, st := range backends {
+		if label != string(repo.Files) && st != storage.Local {
+			return fmt.Errorf("%s: %s repositories require local storage", storage.BackendsFile, label)
+		}
+		storage.SetBackend(label, st)
+	}
+	return nil
+}
+
+// watchStorageBackends 发现 plus migrate-storage 切换了 files 仓库的存储后，暂停写入、复制最后的改动再切换；
+// 切换前后读取都由可用的后端提供。切换失败时继续使用旧后端，下次检查时重试
+func watchStorageBackends(ctx context.Context, cfg *config.Config, repos *repo.RepoFactory) {
+	file := filepath.Join(cfg.StoragePath, storage.BackendsFile)
+	var checked time.Time
+	ticker := time.NewTicker(storageCutoverInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		info, err := os.Stat(file)
+		if err != nil || !info.ModTime().After(checked) {
+			continue
+		}
+		backends, err := storage.LoadBackends(cfg.StoragePath)
+		if err != nil {
+			log.Logger.Errorf("Failed to read %s: %v", file, err)
+			continue
+		}
+		label := string(repo.Files)
+		from, to := storage.TypeByLable(label), backends[label]
+		if to == "" || to == from {
+			checked = info.ModTime()
+			continue
+		}
+		start := time.Now()
+		var pass types.StorageSyncPass
+		err = repos.SwitchBackend(repo.Files, to, func(current, next storage.Storage) error {
+			pass = storagemigrate.Sync(ctx, current, next, false)
+			if pass.Failed > 0 {
+				return fmt.Errorf("%d objects failed to copy: %v", pass.Failed, pass.Errors)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Logger.Errorf("Failed to switch files repositories from %s to %s storage: %v", from, to, err)
+			continue
+		}
+		storage.SetBackend(label, to)
+		checked = info.ModTime()
+		log.Logger.Infof("Switched files repositories from %s to %s storage in %s (%d objects copied during cutover)",
+			from, to, time.Since(start).Round(time.Millisecond), pass.Copied)
+	}
+}
+
+// MigrateStorage 把 files 仓库复制到另一种存储并校验，再原子替换 BackendsFile 完成切换，向标准输出写入 JSON 报告。
+// 运行中的服务在切换前继续读写旧存储，发现切换后复制最后的改动并切换；复制失败时不切换，以退出码 1 结束
+func MigrateStorage(c *cli.Context) error {
+	from, to := storage.StorageType(c.String("from")), storage.StorageType(c.String("to"))
+	if from == to {
+		return cli.NewExitError("--from and --to must differ", 2)
+	}
+
+	cfg, err := loadConfig(c.Parent())
+	if err != nil {
+		return err
+	}
+
+	log.Init(cfg.Log, cfg.LogLevel)
+
+	// 插件注册的存储类型也可以作为迁移的来源和目标
+	if err := loadPlugins(cfg); err != nil {
+		return err
+	}
+	for _, st := range []storage.StorageType{from, to} {
+		if !storage.Registered(st) {
+			return cli.NewExitError(fmt.Sprintf("invalid storage type %q, expected one of %v", st, storage.Types()), 2)
+		}
+	}
+
+	if err := upgradeLayout(cfg); err != nil {
+		return err
+	}
+
+	compressor, err := newCompressor(cfg.Storage.Compression)
+	if err != nil {
+		return err
+	}
+	retryCfg, err := newRetryConfig(cfg.Storage.Retry)
+	if err != nil {
+		return err
+	}
+	replicator, err := newReplicator(cfg, retryCfg)
+	if err != nil {
+		return err
+	}
+	repos, err := newRepoFactory(cfg, storageLayers{compressor: compressor, replicator: replicator})
+	if err != nil {
+		return err
+	}
+
+	label := string(repo.Files)
+	if current := storage.TypeByLable(label); current != from {
+		return cli.NewExitError(fmt.Sprintf("files repositories are on %s storage, not %s", current, from), 2)
+	}
+	src, err := repos.Backend(repo.Files, from)
+	if err != nil {
+		return err
+	}
+	dst, err := repos.Backend(repo.Files, to)
+	if err != nil {
+		return err
+	}
+
+	report := &types.StorageMigrationReport{
+		Status: types.Status{Status: "success"},
+		Label:  label,
+		From:   string(from),
+		To:     string(to),
+		DryRun: c.Bool("dry-run"),
+	}
+	// 第一轮复制全部对象，第二轮复制第一轮期间的改动，之后的改动由服务在切换时复制
+	passes := 2
+	if report.DryRun {
+		passes = 1
+	}
+	for i := 0; i < passes && report.Status.Status == "success"; i++ {
+		pass := storagemigrate.Sync(context.Background(), src, dst, report.DryRun)
+		if pass.Failed > 0 {
+			report.Status.Status = "partial"
+		}
+		report.Passes = append(report.Passes, pass)
+	}
+
+	if report.Status.Status == "success" && !report.DryRun {
+		backends, err := storage.LoadBackends(cfg.StoragePath)
+		if err != nil {
+			return err
+		}
+		backends[label] = to
+		if err := storage.SaveBackends(cfg.StoragePath, backends); err != nil {
+			return err
+		}
+		report.Switched = true
+		log.Logger.Infof("Switched files repositories from %s to %s storage", from, to)
+	}
+
+	data, err := report.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer, string(data))
+
+	if report.Status.Status != "success" {
+		return cli.NewExitError("", 1)
+	}
+	return nil
+}
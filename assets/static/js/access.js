@@ -0,0 +1,374 @@
+// 访问令牌保存在 localStorage，所有请求通过 apiFetch 带上 Authorization 头
+const TOKEN_KEY = 'plus-token';
+const SESSION_KEY = 'plus-session';
+
+function apiFetch(url, options = {}) {
+    const token = localStorage.getItem(TOKEN_KEY);
+    if (token) {
+        options.headers = Object.assign({}, options.headers, {
+            'Authorization': `Bearer ${token}`
+        });
+    }
+    return fetch(url, options);
+}
+
+function escapeHTML(value) {
+    return String(value ?? '').replace(/[&<>"']/g, c => ({
+        '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;'
+    })[c]);
+}
+
+// 逗号分隔的列表
+function splitList(value) {
+    return value.split(',').map(s => s.trim()).filter(Boolean);
+}
+
+class AccessManager {
+    constructor() {
+        this.bindEvents();
+        this.loadIdentity();
+    }
+
+    bindEvents() {
+        const bind = (id, event, handler) => {
+            const el = document.getElementById(id);
+            if (el) {
+                el.addEventListener(event, handler.bind(this));
+            }
+        };
+        bind('tokenForm', 'submit', this.handleTokenSubmit);
+        bind('signOut', 'click', this.handleSignOut);
+        bind('userForm', 'submit', this.handleUserSubmit);
+        bind('groupForm', 'submit', this.handleGroupSubmit);
+        bind('roleForm', 'submit', this.handleRoleSubmit);
+        bind('importForm', 'submit', this.handleImportSubmit);
+        bind('ldapSync', 'click', this.handleLDAPSync);
+    }
+
+    async request(url, options = {}) {
+        const response = await apiFetch(url, options);
+        const data = await response.json().catch(() => ({}));
+        if (!response.ok) {
+            throw new Error(data.message || data.error || `HTTP ${response.status}`);
+        }
+        return data;
+    }
+
+    show(message, type) {
+        const el = document.getElementById('accessResult');
+        if (el) {
+            el.textContent = message;
+            el.className = `result ${type}`;
+            el.style.display = 'block';
+        }
+    }
+
+    async loadIdentity() {
+        const status = document.getElementById('identity');
+        const admin = document.getElementById('accessAdmin');
+        try {
+            const identity = await this.request('/api/v1/whoami');
+            if (status) {
+                status.textContent = identity.authenticated
+                    ? `Signed in as ${identity.name}${identity.admin ? ' (admin)' : ''}`
+                    : 'Not signed in';
+            }
+            if (admin) {
+                admin.style.display = identity.admin ? '' : 'none';
+            }
+            if (identity.admin) {
+                this.loadAll();
+            }
+        } catch (error) {
+            if (status) {
+                status.textContent = 'Sign-in failed: ' + error.message;
+            }
+        }
+    }
+
+    async handleTokenSubmit(e) {
+        e.preventDefault();
+        const input = document.getElementById('accessToken');
+        localStorage.setItem(TOKEN_KEY, input.value.trim());
+        localStorage.removeItem(SESSION_KEY);
+        input.value = '';
+        // 用户令牌换取会话，浏览器中只保存会话令牌；auth.token 或未启用用户管理时直接使用令牌
+        try {
+            const session = await this.request('/api/v1/sessions', { method: 'POST' });
+            localStorage.setItem(TOKEN_KEY, session.token);
+            localStorage.setItem(SESSION_KEY, session.id);
+        } catch (error) {
+            console.log('Session not created:', error.message);
+        }
+        this.loadIdentity();
+    }
+
+    async handleSignOut() {
+        const id = localStorage.getItem(SESSION_KEY);
+        if (id) {
+            try {
+                await this.request(`/api/v1/sessions/${encodeURIComponent(id)}`, { method: 'DELETE' });
+            } catch (error) {
+                console.log('Session not revoked:', error.message);
+            }
+        }
+        localStorage.removeItem(TOKEN_KEY);
+        localStorage.removeItem(SESSION_KEY);
+        this.loadIdentity();
+    }
+
+    async loadAll() {
+        try {
+            const [users, groups, roles, sessions] = await Promise.all([
+                this.request('/api/v1/users'),
+                this.request('/api/v1/groups'),
+                this.request('/api/v1/roles'),
+                this.request('/api/v1/sessions'),
+            ]);
+            this.renderUsers(users.users || []);
+            this.renderGroups(groups.groups || []);
+            this.renderRoles(roles.roles || []);
+            this.renderSessions(sessions.sessions || []);
+        } catch (error) {
+            this.show(error.message, 'error');
+        }
+    }
+
+    renderUsers(users) {
+        const list = document.getElementById('userList');
+        if (!list) {
+            return;
+        }
+        list.innerHTML = users.map(u => `
+            <tr>
+                <td>${escapeHTML(u.name)}${u.disabled ? ' <em>(disabled)</em>' : ''}</td>
+                <td>${escapeHTML(u.email)}</td>
+                <td>${escapeHTML((u.groups || []).join(', '))}</td>
+                <td>${escapeHTML((u.roles || []).join(', '))}</td>
+                <td>${escapeHTML(u.source)}</td>
+                <td>${(u.tokens || []).map(t => `
+                    <span class="token">${escapeHTML(t.name || t.id)}
+                        <button class="btn-link" data-action="revoke" data-user="${escapeHTML(u.name)}" data-id="${escapeHTML(t.id)}">×</button>
+                    </span>`).join('')}
+                </td>
+                <td>
+                    <button class="btn-secondary" data-action="token" data-user="${escapeHTML(u.name)}">New Token</button>
+                    <button class="btn-secondary" data-action="toggle" data-user="${escapeHTML(u.name)}">${u.disabled ? 'Enable' : 'Disable'}</button>
+                    <button class="btn-secondary" data-action="delete-user" data-user="${escapeHTML(u.name)}">Delete</button>
+                </td>
+            </tr>`).join('');
+        this.users = users;
+        list.querySelectorAll('button[data-action]').forEach(btn => {
+            btn.addEventListener('click', () => this.handleUserAction(btn.dataset));
+        });
+    }
+
+    renderGroups(groups) {
+        const list = document.getElementById('groupList');
+        if (!list) {
+            return;
+        }
+        list.innerHTML = groups.map(g => `
+            <tr>
+                <td>${escapeHTML(g.name)}</td>
+                <td>${escapeHTML((g.roles || []).join(', '))}</td>
+                <td>${escapeHTML((g.members || []).join(', '))}</td>
+                <td>${escapeHTML(g.source)}</td>
+                <td><button class="btn-secondary" data-group="${escapeHTML(g.name)}">Delete</button></td>
+            </tr>`).join('');
+        list.querySelectorAll('button[data-group]').forEach(btn => {
+            btn.addEventListener('click', () => this.remove(`/api/v1/groups/${encodeURIComponent(btn.dataset.group)}`));
+        });
+    }
+
+    renderRoles(roles) {
+        const list = document.getElementById('roleList');
+        if (!list) {
+            return;
+        }
+        list.innerHTML = roles.map(r => `
+            <tr>
+                <td>${escapeHTML(r.name)}${r.admin ? ' <em>(admin)</em>' : ''}</td>
+                <td>${(r.permissions || []).map(p => `${escapeHTML(p.repo)}: ${escapeHTML(p.actions.join(', '))}`).join('<br>')}</td>
+                <td>${r.builtin ? 'built-in' : `<button class="btn-secondary" data-role="${escapeHTML(r.name)}">Delete</button>`}</td>
+            </tr>`).join('');
+        list.querySelectorAll('button[data-role]').forEach(btn => {
+            btn.addEventListener('click', () => this.remove(`/api/v1/roles/${encodeURIComponent(btn.dataset.role)}`));
+        });
+    }
+
+    renderSessions(sessions) {
+        const list = document.getElementById('sessionList');
+        if (!list) {
+            return;
+        }
+        const current = localStorage.getItem(SESSION_KEY);
+        list.innerHTML = sessions.map(s => `
+            <tr>
+                <td>${escapeHTML(s.user)}${s.id === current ? ' <em>(this browser)</em>' : ''}</td>
+                <td>${escapeHTML(s.address)}</td>
+                <td>${escapeHTML(s.user_agent)}</td>
+                <td>${escapeHTML(s.created)}</td>
+                <td>${escapeHTML(s.expires)}</td>
+                <td>
+                    <button class="btn-secondary" data-session="${escapeHTML(s.id)}">Revoke</button>
+                    <button class="btn-secondary" data-session-user="${escapeHTML(s.user)}">Revoke All</button>
+                </td>
+            </tr>`).join('');
+        list.querySelectorAll('button[data-session]').forEach(btn => {
+            btn.addEventListener('click', () => this.remove(`/api/v1/sessions/${encodeURIComponent(btn.dataset.session)}`));
+        });
+        list.querySelectorAll('button[data-session-user]').forEach(btn => {
+            btn.addEventListener('click', () => this.remove(`/api/v1/sessions?user=${encodeURIComponent(btn.dataset.sessionUser)}`));
+        });
+    }
+
+    async handleUserAction({ action, user, id }) {
+        const base = `/api/v1/users/${encodeURIComponent(user)}`;
+        try {
+            switch (action) {
+                case 'token': {
+                    const name = prompt(`Token name for ${user}:`, '');
+                    if (name === null) {
+                        return;
+                    }
+                    const token = await this.request(`${base}/tokens`, {
+                        method: 'POST',
+                        headers: { 'Content-Type': 'application/json' },
+                        body: JSON.stringify({ name })
+                    });
+                    // 令牌只显示这一次
+                    prompt('Copy the token now, it will not be shown again:', token.token);
+                    break;
+                }
+                case 'revoke':
+                    if (!confirm(`Revoke token ${id} of ${user}?`)) {
+                        return;
+                    }
+                    await this.request(`${base}/tokens/${encodeURIComponent(id)}`, { method: 'DELETE' });
+                    break;
+                case 'toggle': {
+                    const u = this.users.find(u => u.name === user);
+                    await this.request(base, {
+                        method: 'PUT',
+                        headers: { 'Content-Type': 'application/json' },
+                        body: JSON.stringify(Object.assign({}, u, { disabled: !u.disabled }))
+                    });
+                    break;
+                }
+                case 'delete-user':
+                    if (!confirm(`Delete user ${user}?`)) {
+                        return;
+                    }
+                    await this.request(base, { method: 'DELETE' });
+                    break;
+            }
+            this.loadAll();
+        } catch (error) {
+            this.show(error.message, 'error');
+        }
+    }
+
+    async remove(url) {
+        if (!confirm(`Delete ${decodeURIComponent(url.split(/[/=]/).pop())}?`)) {
+            return;
+        }
+        try {
+            await this.request(url, { method: 'DELETE' });
+            this.loadAll();
+        } catch (error) {
+            this.show(error.message, 'error');
+        }
+    }
+
+    async submit(e, url, body) {
+        e.preventDefault();
+        try {
+            await this.request(url, {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify(body)
+            });
+            e.target.reset();
+            this.show(`${body.name} created`, 'success');
+            this.loadAll();
+        } catch (error) {
+            this.show(error.message, 'error');
+        }
+    }
+
+    handleUserSubmit(e) {
+        const form = new FormData(e.target);
+        this.submit(e, '/api/v1/users', {
+            name: form.get('name').trim(),
+            email: form.get('email').trim(),
+            groups: splitList(form.get('groups')),
+            roles: splitList(form.get('roles'))
+        });
+    }
+
+    handleGroupSubmit(e) {
+        const form = new FormData(e.target);
+        this.submit(e, '/api/v1/groups', {
+            name: form.get('name').trim(),
+            description: form.get('description').trim(),
+            roles: splitList(form.get('roles'))
+        });
+    }
+
+    handleRoleSubmit(e) {
+        const form = new FormData(e.target);
+        this.submit(e, '/api/v1/roles', {
+            name: form.get('name').trim(),
+            description: form.get('description').trim(),
+            permissions: splitList(form.get('repos')).map(repo => ({
+                repo,
+                actions: form.getAll('actions')
+            }))
+        });
+    }
+
+    async handleImportSubmit(e) {
+        e.preventDefault();
+        const file = document.getElementById('importFile').files[0];
+        if (!file) {
+            return;
+        }
+        try {
+            const result = await this.request('/api/v1/users/import', {
+                method: 'POST',
+                headers: { 'Content-Type': 'text/csv' },
+                body: await file.text()
+            });
+            e.target.reset();
+            this.showImportResult('Import', result);
+        } catch (error) {
+            this.show(error.message, 'error');
+        }
+    }
+
+    async handleLDAPSync() {
+        try {
+            const result = await this.request('/api/v1/users/sync', { method: 'POST' });
+            this.showImportResult('LDAP sync', result);
+        } catch (error) {
+            this.show(error.message, 'error');
+        }
+    }
+
+    showImportResult(what, result) {
+        let message = `${what}: ${result.created} created, ${result.updated} updated`;
+        if (result.disabled) {
+            message += `, ${result.disabled} disabled`;
+        }
+        if (result.errors && result.errors.length) {
+            message += `; ${result.errors.length} errors: ${result.errors.join('; ')}`;
+        }
+        this.show(message, result.errors && result.errors.length ? 'error' : 'success');
+        this.loadAll();
+    }
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    new AccessManager();
+});
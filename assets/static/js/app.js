@@ -129,7 +129,7 @@ class RepoManager {
     async loadRepositories() {
         try {
             console.log('Loading repositories...');
-            const response = await fetch('/repos');
+            const response = await apiFetch('/repos');
 
             console.log('Response status:', response.status);
 
@@ -293,7 +293,7 @@ class RepoManager {
             const refreshUrl = `/repo/${encodeURIComponent(repoName)}/refresh`;
             console.log('Refresh URL:', refreshUrl);
 
-            const response = await fetch(refreshUrl, {
+            const response = await apiFetch(refreshUrl, {
                 method: 'POST'
             });
 
@@ -368,7 +368,7 @@ class RepoManager {
             const uploadUrl = `/repo/${encodeURIComponent(repository)}/upload`;
             console.log('Upload URL:', uploadUrl);
 
-            const response = await fetch(uploadUrl, {
+            const response = await apiFetch(uploadUrl, {
                 method: 'POST',
                 body: formData
             });
@@ -437,7 +437,7 @@ class RepoManager {
     // 修改：异步获取仓库类型
     async getRepositoryType(repository) {
         try {
-            const response = await fetch(`/repo/${encodeURIComponent(repository)}`);
+            const response = await apiFetch(`/repo/${encodeURIComponent(repository)}`);
             if (response.ok) {
                 const data = await response.json();
                 const statusInfo = this.parseResponseStatus(data);
@@ -510,7 +510,7 @@ class RepoManager {
         }
 
         try {
-            const response = await fetch('/repos', {
+            const response = await apiFetch('/repos', {
                 method: 'POST',
                 headers: {
                     'Content-Type': 'application/json'
@@ -544,7 +544,7 @@ class RepoManager {
             const refreshUrl = `/repo/${encodeURIComponent(repoName)}/refresh`;
             console.log('Refresh URL:', refreshUrl);
 
-            const response = await fetch(refreshUrl, {
+            const response = await apiFetch(refreshUrl, {
                 method: 'POST'
             });
 
@@ -580,7 +580,7 @@ class RepoManager {
             const infoUrl = `/repo/${encodeURIComponent(repoName)}`;
             console.log('Info URL:', infoUrl);
 
-            const response = await fetch(infoUrl);
+            const response = await apiFetch(infoUrl);
             const data = await response.json();
 
             console.log('Repository info response:', data);
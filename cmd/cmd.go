@@ -5,6 +5,7 @@ import (
 	"os"
 	App "plus/app"
 	_ "plus/pkg"
+	"time"
 
 	"github.com/urfave/cli"
 )
@@ -19,6 +20,10 @@ func Execute(name, usage, version, commit string) {
 			Value: "config.yaml",
 			Usage: "Configuration file path",
 		},
+		&cli.StringFlag{
+			Name:  "config-dir",
+			Usage: "Directory of configuration fragments (*.yaml, *.yml) merged in name order over --config",
+		},
 		&cli.StringFlag{
 			Name:  "listen, l",
 			Value: ":8080",
@@ -38,8 +43,176 @@ func Execute(name, usage, version, commit string) {
 			Value: "debug",
 			Usage: "set  the log level ('DEBUG/debug', 'INFO/info', 'WARN/warn', 'ERROR/error', 'FATAL/fatal')",
 		},
+		cli.BoolFlag{
+			Name:  "no-migrate",
+			Usage: "Refuse to start instead of upgrading an older storage or database layout",
+		},
 	}
 	app.Action = App.Run
+	app.Commands = []cli.Command{
+		{
+			Name:  "fsck",
+			Usage: "Check repository consistency and print a JSON report",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "repo, r",
+					Usage: "Repository name",
+				},
+				cli.BoolFlag{
+					Name:  "repair",
+					Usage: "Refresh metadata to fix missing or orphaned packages",
+				},
+			},
+			Action: App.Fsck,
+		},
+		{
+			Name:  "import",
+			Usage: "Import existing yum and apt repository trees (e.g. from nginx+createrepo) and print a JSON report",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "path",
+					Usage: "Directory to scan for repositories",
+				},
+				cli.StringFlag{
+					Name:  "prefix, p",
+					Usage: "Prefix for the names of imported repositories",
+				},
+				cli.BoolFlag{
+					Name:  "regenerate",
+					Usage: "Always regenerate metadata instead of keeping existing repodata",
+				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Only report the repositories that would be imported",
+				},
+			},
+			Action: App.Import,
+		},
+		{
+			Name:  "migrate",
+			Usage: "Copy repositories from a Nexus, Artifactory or Pulp server configured under migration.sources and print a JSON report",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "source, s",
+					Usage: "Name of the migration source",
+				},
+				cli.StringSliceFlag{
+					Name:  "repo, r",
+					Usage: "Remote repository to copy, as REMOTE or REMOTE=LOCAL (repeatable)",
+				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Only report where each remote file would go",
+				},
+			},
+			Action: App.Migrate,
+		},
+		{
+			Name:  "migrate-storage",
+			Usage: "Copy files repositories to another storage backend, verify every object and switch to it; a running server switches without downtime",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "from",
+					Usage: "Storage the files repositories are on (local or s3)",
+				},
+				cli.StringFlag{
+					Name:  "to",
+					Usage: "Storage to move the files repositories to (local or s3)",
+				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Only report what would be copied",
+				},
+			},
+			Action: App.MigrateStorage,
+		},
+		{
+			Name:  "rotate-keys",
+			Usage: "Re-encrypt object storage data keys with the active encryption key and encrypt remaining plaintext objects",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "prefix, p",
+					Usage: "Only rotate objects under this path (default: all)",
+				},
+			},
+			Action: App.RotateKeys,
+		},
+		{
+			Name:  "bench",
+			Usage: "Run a load test against a running server and report latency percentiles",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "url, u",
+					Value: "http://localhost:8080",
+					Usage: "Server base URL",
+				},
+				cli.StringFlag{
+					Name:  "scenario",
+					Value: "mixed",
+					Usage: "Workload: upload, download, list, refresh or mixed",
+				},
+				cli.StringFlag{
+					Name:  "mix",
+					Usage: "Custom operation weights, e.g. 'download=80,upload=20' (overrides --scenario)",
+				},
+				cli.StringFlag{
+					Name:  "repo, r",
+					Usage: "Existing files repository for upload, download and list (default: temporary repository)",
+				},
+				cli.StringFlag{
+					Name:  "refresh-repo",
+					Usage: "Existing rpm or deb repository to refresh (default: temporary rpm repository)",
+				},
+				cli.IntFlag{
+					Name:  "concurrency, n",
+					Value: 10,
+					Usage: "Concurrent clients",
+				},
+				cli.Int64Flag{
+					Name:  "requests",
+					Usage: "Stop after this many requests instead of --duration",
+				},
+				cli.DurationFlag{
+					Name:  "duration, d",
+					Value: 30 * time.Second,
+					Usage: "Measurement duration",
+				},
+				cli.DurationFlag{
+					Name:  "warmup",
+					Value: 5 * time.Second,
+					Usage: "Warmup duration, excluded from results",
+				},
+				cli.IntFlag{
+					Name:  "size",
+					Value: 64 * 1024,
+					Usage: "Size in bytes of uploaded and downloaded files",
+				},
+				cli.StringFlag{
+					Name:  "token",
+					Usage: "Bearer token for servers with authentication",
+				},
+				cli.DurationFlag{
+					Name:  "timeout",
+					Value: 30 * time.Second,
+					Usage: "Per-request timeout",
+				},
+				cli.StringFlag{
+					Name:  "output, o",
+					Usage: "Write results as JSON to this file",
+				},
+				cli.StringFlag{
+					Name:  "baseline",
+					Usage: "JSON results of a previous run; exit 1 on regression",
+				},
+				cli.Float64Flag{
+					Name:  "max-regression",
+					Value: 20,
+					Usage: "Allowed p95 latency increase or throughput drop against --baseline, in percent",
+				},
+			},
+			Action: App.Bench,
+		},
+	}
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
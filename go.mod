@@ -5,28 +5,32 @@ go 1.24.4
 toolchain go1.24.5
 
 require (
+	github.com/ProtonMail/go-crypto v1.3.0
+	github.com/cavaliergopher/rpm v1.3.0
 	github.com/elastic-io/mindb v1.1.0
+	github.com/klauspost/compress v1.18.0
 	github.com/mailru/easyjson v0.9.0
+	github.com/russross/blackfriday/v2 v2.1.0
 	github.com/stianwa/createrepo v0.1.9
+	github.com/ulikunitz/xz v0.5.12
 	github.com/urfave/cli v1.22.17
 	github.com/valyala/fasthttp v1.63.0
 	go.uber.org/zap v1.27.0
+	golang.org/x/crypto v0.39.0
+	golang.org/x/text v0.26.0
+	golang.org/x/time v0.12.0
 	gopkg.in/natefinch/lumberjack.v2 v2.2.1
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/andybalholm/brotli v1.2.0 // indirect
-	github.com/cavaliergopher/rpm v1.3.0 // indirect
+	github.com/cloudflare/circl v1.6.1 // indirect
 	github.com/cpuguy83/go-md2man/v2 v2.0.7 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
-	github.com/klauspost/compress v1.18.0 // indirect
 	github.com/pkg/xattr v0.4.11 // indirect
-	github.com/russross/blackfriday/v2 v2.1.0 // indirect
-	github.com/ulikunitz/xz v0.5.12 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
-	golang.org/x/crypto v0.39.0 // indirect
 	golang.org/x/sys v0.34.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
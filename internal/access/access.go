@@ -0,0 +1,731 @@
+package access
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/types"
+)
+
+// FileName 用户库在 DatabasePath 下的文件名
+const FileName = "access.json"
+
+const (
+	ActionRead   = "read"
+	ActionWrite  = "write"
+	ActionDelete = "delete"
+	ActionAdmin  = "admin" // 管理仓库，例如签发上传令牌
+)
+
+// 用户和用户组的来源
+const (
+	SourceLocal = "local"
+	SourceCSV   = "csv"
+	SourceLDAP  = "ldap"
+)
+
+// tokenPrefix 令牌前缀，便于在日志和代码中识别泄露的令牌
+const tokenPrefix = "plus_"
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrExists   = errors.New("already exists")
+	ErrBuiltin  = errors.New("built-in roles cannot be changed")
+	ErrInvalid  = errors.New("invalid")
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._@-]{0,127}$`)
+
+// builtinRoles 内置角色，不能修改或删除
+var builtinRoles = []types.Role{
+	{Name: "admin", Description: "Full access, including user management", Admin: true, Builtin: true,
+		Permissions: []types.Permission{{Repo: "*", Actions: []string{ActionAdmin, ActionDelete, ActionRead, ActionWrite}}}},
+	{Name: "writer", Description: "Read and upload to all repositories", Builtin: true,
+		Permissions: []types.Permission{{Repo: "*", Actions: []string{ActionRead, ActionWrite}}}},
+	{Name: "reader", Description: "Read all repositories", Builtin: true,
+		Permissions: []types.Permission{{Repo: "*", Actions: []string{ActionRead}}}},
+}
+
+// Store 用户、用户组、角色和令牌。全部保存在内存中，每次修改重写 DatabasePath 下的文件
+type Store struct {
+	file     string
+	ldap     *LDAPConfig
+	sessions sessionConfig
+	// 上传令牌的最长有效期
+	uploadMaxTTL time.Duration
+	// 集群模式下共享的会话和撤销列表
+	cluster Cluster
+
+	mu    sync.RWMutex
+	state *state
+}
+
+type state struct {
+	users  map[string]*types.User
+	groups map[string]*types.Group
+	roles  map[string]*types.Role
+	tokens map[string]*types.TokenRecord // SHA256 -> 令牌
+
+	uploads    map[string]*types.UploadToken // SHA256 -> 上传令牌
+	sessions   map[string]*types.Session
+	revoked    map[string]string // 会话 ID -> 过期时间
+	sessionKey string
+}
+
+// Open 加载 dir 下的用户库，文件不存在时创建空库
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create access directory: %w", err)
+	}
+	s := &Store{file: filepath.Join(dir, FileName), state: newState()}
+	data, err := os.ReadFile(s.file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read access data: %w", err)
+	}
+	// 用户库损坏时拒绝启动，避免以空库覆盖
+	var saved types.AccessData
+	if err := saved.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("access data %s is corrupt: %w", s.file, err)
+	}
+	for i := range saved.Users {
+		u := saved.Users[i]
+		s.state.users[u.Name] = &u
+	}
+	for i := range saved.Groups {
+		g := saved.Groups[i]
+		s.state.groups[g.Name] = &g
+	}
+	for i := range saved.Roles {
+		r := saved.Roles[i]
+		s.state.roles[r.Name] = &r
+	}
+	for i := range saved.Tokens {
+		t := saved.Tokens[i]
+		s.state.tokens[t.Hash] = &t
+	}
+	for i := range saved.Uploads {
+		t := saved.Uploads[i]
+		s.state.uploads[t.Hash] = &t
+	}
+	for i := range saved.Sessions {
+		se := saved.Sessions[i]
+		s.state.sessions[se.ID] = &se
+	}
+	for _, r := range saved.Revoked {
+		s.state.revoked[r.ID] = r.Expires
+	}
+	s.state.sessionKey = saved.SessionKey
+	return s, nil
+}
+
+func newState() *state {
+	return &state{
+		users:  make(map[string]*types.User),
+		groups: make(map[string]*types.Group),
+		roles:  make(map[string]*types.Role),
+		tokens: make(map[string]*types.TokenRecord),
+
+		uploads:  make(map[string]*types.UploadToken),
+		sessions: make(map[string]*types.Session),
+		revoked:  make(map[string]string),
+	}
+}
+
+// clone 深拷贝，修改在副本上进行，保存成功后替换
+func (st *state) clone() *state {
+	c := newState()
+	for k, u := range st.users {
+		cu := *u
+		cu.Groups = append([]string(nil), u.Groups...)
+		cu.Roles = append([]string(nil), u.Roles...)
+		c.users[k] = &cu
+	}
+	for k, g := range st.groups {
+		cg := *g
+		cg.Roles = append([]string(nil), g.Roles...)
+		c.groups[k] = &cg
+	}
+	for k, r := range st.roles {
+		cr := *r
+		cr.Permissions = append([]types.Permission(nil), r.Permissions...)
+		c.roles[k] = &cr
+	}
+	for k, t := range st.tokens {
+		ct := *t
+		c.tokens[k] = &ct
+	}
+	for k, t := range st.uploads {
+		ct := *t
+		c.uploads[k] = &ct
+	}
+	for k, se := range st.sessions {
+		cs := *se
+		c.sessions[k] = &cs
+	}
+	for k, v := range st.revoked {
+		c.revoked[k] = v
+	}
+	c.sessionKey = st.sessionKey
+	return c
+}
+
+func (st *state) role(name string) (*types.Role, bool) {
+	for i := range builtinRoles {
+		if builtinRoles[i].Name == name {
+			return &builtinRoles[i], true
+		}
+	}
+	r, ok := st.roles[name]
+	return r, ok
+}
+
+// update 在副本上执行 fn，成功后写入文件并替换当前状态
+func (s *Store) update(fn func(st *state) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := s.state.clone()
+	if err := fn(next); err != nil {
+		return err
+	}
+	if err := s.save(next); err != nil {
+		return err
+	}
+	s.state = next
+	return nil
+}
+
+func (s *Store) save(st *state) error {
+	saved := types.AccessData{
+		Users:  make([]types.User, 0, len(st.users)),
+		Groups: make([]types.Group, 0, len(st.groups)),
+		Roles:  make([]types.Role, 0, len(st.roles)),
+		Tokens: make([]types.TokenRecord, 0, len(st.tokens)),
+
+		SessionKey: st.sessionKey,
+	}
+	for _, u := range st.users {
+		saved.Users = append(saved.Users, *u)
+	}
+	for _, g := range st.groups {
+		saved.Groups = append(saved.Groups, *g)
+	}
+	for _, r := range st.roles {
+		saved.Roles = append(saved.Roles, *r)
+	}
+	for _, t := range st.tokens {
+		saved.Tokens = append(saved.Tokens, *t)
+	}
+	for _, t := range st.uploads {
+		saved.Uploads = append(saved.Uploads, *t)
+	}
+	for _, se := range st.sessions {
+		saved.Sessions = append(saved.Sessions, *se)
+	}
+	for id, expires := range st.revoked {
+		saved.Revoked = append(saved.Revoked, types.RevokedSession{ID: id, Expires: expires})
+	}
+	sort.Slice(saved.Users, func(i, j int) bool { return saved.Users[i].Name < saved.Users[j].Name })
+	sort.Slice(saved.Groups, func(i, j int) bool { return saved.Groups[i].Name < saved.Groups[j].Name })
+	sort.Slice(saved.Roles, func(i, j int) bool { return saved.Roles[i].Name < saved.Roles[j].Name })
+	sort.Slice(saved.Tokens, func(i, j int) bool { return saved.Tokens[i].ID < saved.Tokens[j].ID })
+	sort.Slice(saved.Uploads, func(i, j int) bool { return saved.Uploads[i].ID < saved.Uploads[j].ID })
+	sort.Slice(saved.Sessions, func(i, j int) bool { return saved.Sessions[i].ID < saved.Sessions[j].ID })
+	sort.Slice(saved.Revoked, func(i, j int) bool { return saved.Revoked[i].ID < saved.Revoked[j].ID })
+
+	data, err := saved.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	// 文件中有令牌摘要，只允许本用户读取
+	tmp := s.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write access data: %w", err)
+	}
+	if err := os.Rename(tmp, s.file); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write access data: %w", err)
+	}
+	return nil
+}
+
+// Users 返回全部用户，按名称排序
+func (s *Store) Users() []types.User {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	users := make([]types.User, 0, len(s.state.users))
+	for _, u := range s.state.users {
+		users = append(users, s.state.userView(u))
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users
+}
+
+// User 返回用户及其令牌（不含令牌内容）
+func (s *Store) User(name string) (*types.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	u, ok := s.state.users[name]
+	if !ok {
+		return nil, fmt.Errorf("user %s: %w", name, ErrNotFound)
+	}
+	view := s.state.userView(u)
+	return &view, nil
+}
+
+func (st *state) userView(u *types.User) types.User {
+	view := *u
+	view.Tokens = nil
+	for _, t := range st.tokens {
+		if t.User == u.Name {
+			view.Tokens = append(view.Tokens, types.UserToken{ID: t.ID, Name: t.Name, Created: t.Created})
+		}
+	}
+	sort.Slice(view.Tokens, func(i, j int) bool { return view.Tokens[i].Created < view.Tokens[j].Created })
+	return view
+}
+
+// CreateUser 创建用户
+func (s *Store) CreateUser(u types.User) error {
+	return s.update(func(st *state) error {
+		if _, ok := st.users[u.Name]; ok {
+			return fmt.Errorf("user %s: %w", u.Name, ErrExists)
+		}
+		if u.Source == "" {
+			u.Source = SourceLocal
+		}
+		return st.putUser(u, true)
+	})
+}
+
+// UpdateUser 替换用户的邮箱、用户组、角色和禁用状态
+func (s *Store) UpdateUser(u types.User) error {
+	return s.update(func(st *state) error {
+		old, ok := st.users[u.Name]
+		if !ok {
+			return fmt.Errorf("user %s: %w", u.Name, ErrNotFound)
+		}
+		u.Source, u.Created = old.Source, old.Created
+		return st.putUser(u, false)
+	})
+}
+
+func (st *state) putUser(u types.User, created bool) error {
+	if !validName.MatchString(u.Name) {
+		return fmt.Errorf("user name %q: %w", u.Name, ErrInvalid)
+	}
+	u.Groups = normalize(u.Groups)
+	u.Roles = normalize(u.Roles)
+	for _, g := range u.Groups {
+		if _, ok := st.groups[g]; !ok {
+			return fmt.Errorf("group %s: %w", g, ErrNotFound)
+		}
+	}
+	for _, r := range u.Roles {
+		if _, ok := st.role(r); !ok {
+			return fmt.Errorf("role %s: %w", r, ErrNotFound)
+		}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	if created {
+		u.Created = now
+	}
+	u.Updated = now
+	u.Tokens = nil
+	st.users[u.Name] = &u
+	return nil
+}
+
+// DeleteUser 删除用户和用户的全部令牌
+func (s *Store) DeleteUser(name string) error {
+	match := func(se *types.Session) bool { return se.User == name }
+	var revoked map[string]string
+	err := s.update(func(st *state) error {
+		if _, ok := st.users[name]; !ok {
+			return fmt.Errorf("user %s: %w", name, ErrNotFound)
+		}
+		delete(st.users, name)
+		for hash, t := range st.tokens {
+			if t.User == name {
+				delete(st.tokens, hash)
+			}
+		}
+		// 之后创建的同名用户不能使用原来的会话
+		revoked = st.revokeSessions(match)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.revokeShared(match, revoked)
+	return err
+}
+
+// Groups 返回全部用户组和成员，按名称排序
+func (s *Store) Groups() []types.Group {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	groups := make([]types.Group, 0, len(s.state.groups))
+	for _, g := range s.state.groups {
+		groups = append(groups, s.state.groupView(g))
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+// Group 返回用户组和成员
+func (s *Store) Group(name string) (*types.Group, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	g, ok := s.state.groups[name]
+	if !ok {
+		return nil, fmt.Errorf("group %s: %w", name, ErrNotFound)
+	}
+	view := s.state.groupView(g)
+	return &view, nil
+}
+
+func (st *state) groupView(g *types.Group) types.Group {
+	view := *g
+	view.Members = nil
+	for _, u := range st.users {
+		if contains(u.Groups, g.Name) {
+			view.Members = append(view.Members, u.Name)
+		}
+	}
+	sort.Strings(view.Members)
+	return view
+}
+
+// PutGroup 创建或替换用户组，create 为 true 时用户组已存在返回 ErrExists
+func (s *Store) PutGroup(g types.Group, create bool) error {
+	return s.update(func(st *state) error {
+		old, exists := st.groups[g.Name]
+		switch {
+		case create && exists:
+			return fmt.Errorf("group %s: %w", g.Name, ErrExists)
+		case !create && !exists:
+			return fmt.Errorf("group %s: %w", g.Name, ErrNotFound)
+		case exists:
+			g.Source = old.Source
+		case g.Source == "":
+			g.Source = SourceLocal
+		}
+		return st.putGroup(g)
+	})
+}
+
+func (st *state) putGroup(g types.Group) error {
+	if !validName.MatchString(g.Name) {
+		return fmt.Errorf("group name %q: %w", g.Name, ErrInvalid)
+	}
+	g.Roles = normalize(g.Roles)
+	for _, r := range g.Roles {
+		if _, ok := st.role(r); !ok {
+			return fmt.Errorf("role %s: %w", r, ErrNotFound)
+		}
+	}
+	g.Members = nil
+	st.groups[g.Name] = &g
+	return nil
+}
+
+// DeleteGroup 删除用户组并从成员中移除
+func (s *Store) DeleteGroup(name string) error {
+	return s.update(func(st *state) error {
+		if _, ok := st.groups[name]; !ok {
+			return fmt.Errorf("group %s: %w", name, ErrNotFound)
+		}
+		delete(st.groups, name)
+		for _, u := range st.users {
+			u.Groups = remove(u.Groups, name)
+		}
+		return nil
+	})
+}
+
+// Roles 返回内置角色和自定义角色，按名称排序
+func (s *Store) Roles() []types.Role {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	roles := append([]types.Role(nil), builtinRoles...)
+	for _, r := range s.state.roles {
+		roles = append(roles, *r)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles
+}
+
+// Role 返回角色
+func (s *Store) Role(name string) (*types.Role, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r, ok := s.state.role(name)
+	if !ok {
+		return nil, fmt.Errorf("role %s: %w", name, ErrNotFound)
+	}
+	view := *r
+	return &view, nil
+}
+
+// PutRole 创建或替换自定义角色，create 为 true 时角色已存在返回 ErrExists
+func (s *Store) PutRole(r types.Role, create bool) error {
+	return s.update(func(st *state) error {
+		if old, ok := st.role(r.Name); ok && old.Builtin {
+			return fmt.Errorf("role %s: %w", r.Name, ErrBuiltin)
+		}
+		_, exists := st.roles[r.Name]
+		switch {
+		case create && exists:
+			return fmt.Errorf("role %s: %w", r.Name, ErrExists)
+		case !create && !exists:
+			return fmt.Errorf("role %s: %w", r.Name, ErrNotFound)
+		}
+		if !validName.MatchString(r.Name) {
+			return fmt.Errorf("role name %q: %w", r.Name, ErrInvalid)
+		}
+		for i, p := range r.Permissions {
+			p.Repo = strings.Trim(p.Repo, "/")
+			if _, err := path.Match(p.Repo, ""); err != nil || p.Repo == "" {
+				return fmt.Errorf("permission repo pattern %q: %w", p.Repo, ErrInvalid)
+			}
+			p.Actions = normalize(p.Actions)
+			if len(p.Actions) == 0 {
+				return fmt.Errorf("permission for %s has no actions: %w", p.Repo, ErrInvalid)
+			}
+			for _, a := range p.Actions {
+				if a != ActionRead && a != ActionWrite && a != ActionDelete && a != ActionAdmin {
+					return fmt.Errorf("action %q: %w", a, ErrInvalid)
+				}
+			}
+			r.Permissions[i] = p
+		}
+		r.Builtin = false
+		st.roles[r.Name] = &r
+		return nil
+	})
+}
+
+// DeleteRole 删除自定义角色并从用户和用户组中移除
+func (s *Store) DeleteRole(name string) error {
+	return s.update(func(st *state) error {
+		if r, ok := st.role(name); ok && r.Builtin {
+			return fmt.Errorf("role %s: %w", name, ErrBuiltin)
+		}
+		if _, ok := st.roles[name]; !ok {
+			return fmt.Errorf("role %s: %w", name, ErrNotFound)
+		}
+		delete(st.roles, name)
+		for _, u := range st.users {
+			u.Roles = remove(u.Roles, name)
+		}
+		for _, g := range st.groups {
+			g.Roles = remove(g.Roles, name)
+		}
+		return nil
+	})
+}
+
+// CreateToken 为用户创建访问令牌，返回的 Token 只有这一次可见
+func (s *Store) CreateToken(user, name string) (*types.UserToken, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := tokenPrefix + hex.EncodeToString(secret)
+	hash := hashToken(token)
+	record := types.TokenRecord{
+		ID:      tokenID(hash),
+		User:    user,
+		Name:    name,
+		Hash:    hash,
+		Created: time.Now().UTC().Format(time.RFC3339),
+	}
+	err := s.update(func(st *state) error {
+		if _, ok := st.users[user]; !ok {
+			return fmt.Errorf("user %s: %w", user, ErrNotFound)
+		}
+		st.tokens[record.Hash] = &record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.UserToken{ID: record.ID, Name: name, Token: token, Created: record.Created}, nil
+}
+
+// DeleteToken 撤销用户的令牌
+func (s *Store) DeleteToken(user, id string) error {
+	match := func(se *types.Session) bool { return se.User == user && se.TokenID == id }
+	var revoked map[string]string
+	err := s.update(func(st *state) error {
+		for hash, t := range st.tokens {
+			if t.User == user && t.ID == id {
+				delete(st.tokens, hash)
+				revoked = st.revokeSessions(match)
+				return nil
+			}
+		}
+		return fmt.Errorf("token %s of user %s: %w", id, user, ErrNotFound)
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.revokeShared(match, revoked)
+	return err
+}
+
+// Authenticate 按用户令牌或会话令牌查找用户，用户不存在或已禁用时返回 false
+func (s *Store) Authenticate(token string) (*Principal, bool) {
+	if strings.Count(token, ".") == 2 {
+		return s.authenticateSession(token)
+	}
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hash := hashToken(token)
+	if up, ok := s.state.uploads[hash]; ok {
+		return uploadPrincipal(up)
+	}
+	t, ok := s.state.tokens[hash]
+	if !ok {
+		return nil, false
+	}
+	u, ok := s.state.users[t.User]
+	if !ok || u.Disabled {
+		return nil, false
+	}
+	p := s.state.principal(u)
+	p.TokenID = t.ID
+	return p, true
+}
+
+func (st *state) principal(u *types.User) *Principal {
+	p := &Principal{Name: u.Name}
+	roles := append([]string(nil), u.Roles...)
+	for _, g := range u.Groups {
+		if group, ok := st.groups[g]; ok {
+			roles = append(roles, group.Roles...)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, name := range roles {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if r, ok := st.role(name); ok {
+			p.Roles = append(p.Roles, r.Name)
+			p.Admin = p.Admin || r.Admin
+			p.Permissions = append(p.Permissions, r.Permissions...)
+		}
+	}
+	return p
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenID 令牌的公开 ID 取哈希的前 8 位，不能用来推出令牌
+func tokenID(hash string) string {
+	return hash[:8]
+}
+
+// Principal 已认证的请求身份
+type Principal struct {
+	Name        string
+	Admin       bool
+	Roles       []string // 有效的角色（含用户组的角色），用于访问策略
+	Permissions []types.Permission
+	TokenID     string // 使用用户令牌认证时为令牌 ID
+	Session     string // 使用会话令牌认证时为会话 ID
+	Repo        string // 上传令牌只能访问这一个仓库，不包括子路径
+}
+
+// Superuser 返回拥有全部权限的身份，用于 auth.token
+func Superuser(name string) *Principal {
+	return &Principal{Name: name, Admin: true, Roles: []string{builtinRoles[0].Name}, Permissions: builtinRoles[0].Permissions}
+}
+
+// Can 判断是否可以对仓库执行操作。权限的仓库模式匹配 repo 本身或它的任一上级路径；
+// repo 为空时（列表等不针对单个仓库的请求）只要有该操作的任一权限即可
+func (p *Principal) Can(repo, action string) bool {
+	repo = strings.Trim(repo, "/")
+	if p.Repo != "" && repo != p.Repo {
+		return false
+	}
+	for _, perm := range p.Permissions {
+		if !contains(perm.Actions, action) {
+			continue
+		}
+		if repo == "" || matchRepo(perm.Repo, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRepo 仓库模式匹配 repo 本身或它的任一上级路径
+func matchRepo(pattern, repo string) bool {
+	prefix := ""
+	for _, seg := range strings.Split(repo, "/") {
+		if prefix != "" {
+			prefix += "/"
+		}
+		prefix += seg
+		if ok, _ := path.Match(pattern, prefix); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize 去掉空值和重复值并排序
+func normalize(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(values []string, v string) []string {
+	out := values[:0]
+	for _, x := range values {
+		if x != v {
+			out = append(out, x)
+		}
+	}
+	return out
+}
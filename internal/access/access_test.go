@@ -0,0 +1,385 @@
+package access
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/cluster"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestUsersAndPermissions(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.PutRole(types.Role{Name: "el9-deployer", Permissions: []types.Permission{
+		{Repo: "/el9/*/", Actions: []string{"write", "read"}},
+	}}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutRole(types.Role{Name: "reader"}, true); !errors.Is(err, ErrBuiltin) {
+		t.Fatalf("expected ErrBuiltin, got %v", err)
+	}
+	if err := s.PutRole(types.Role{Name: "bad", Permissions: []types.Permission{{Repo: "*", Actions: []string{"own"}}}}, true); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid, got %v", err)
+	}
+	if err := s.PutGroup(types.Group{Name: "ci", Roles: []string{"el9-deployer"}}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateUser(types.User{Name: "builder", Groups: []string{"ci"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateUser(types.User{Name: "ghost", Groups: []string{"missing"}}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected missing group error, got %v", err)
+	}
+
+	token, err := s.CreateToken("builder", "ci pipeline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ID 会显示在列表和日志中，不能是令牌的一部分
+	if strings.Contains(token.Token, token.ID) {
+		t.Fatalf("token ID %s is part of the token", token.ID)
+	}
+	p, ok := s.Authenticate(token.Token)
+	if !ok || p.Name != "builder" || p.Admin {
+		t.Fatalf("unexpected principal %+v", p)
+	}
+	checks := []struct {
+		repo, action string
+		want         bool
+	}{
+		{"el9/x86_64", "write", true},
+		{"el9/x86_64/Packages/foo.rpm", "read", true},
+		{"el9", "write", false},
+		{"el8/x86_64", "read", false},
+		{"el9/x86_64", "delete", false},
+		{"", "read", true},
+	}
+	for _, c := range checks {
+		if got := p.Can(c.repo, c.action); got != c.want {
+			t.Errorf("Can(%q, %q) = %v, want %v", c.repo, c.action, got, c.want)
+		}
+	}
+
+	// 持久化后重新加载
+	s, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Authenticate(token.Token); !ok {
+		t.Fatal("token lost after reopen")
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, FileName))
+	if strings.Contains(string(data), token.Token) {
+		t.Fatal("token stored in plain text")
+	}
+
+	// 禁用用户后令牌失效；删除角色后从用户组中移除
+	u, _ := s.User("builder")
+	if len(u.Tokens) != 1 || u.Tokens[0].Token != "" {
+		t.Fatalf("unexpected tokens %+v", u.Tokens)
+	}
+	u.Disabled = true
+	if err := s.UpdateUser(*u); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Authenticate(token.Token); ok {
+		t.Fatal("disabled user authenticated")
+	}
+	if err := s.DeleteRole("el9-deployer"); err != nil {
+		t.Fatal(err)
+	}
+	if g, _ := s.Group("ci"); len(g.Roles) != 0 || len(g.Members) != 1 {
+		t.Fatalf("unexpected group %+v", g)
+	}
+	if err := s.DeleteUser("builder"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Authenticate(token.Token); ok {
+		t.Fatal("token of deleted user authenticated")
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateUser(types.User{Name: "alice", Email: "old@example.com", Roles: []string{"admin"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.ImportCSV(strings.NewReader(`name,email,groups,disabled
+alice,alice@example.com,ops;dev,
+bob,bob@example.com,dev,true
+,nobody@example.com,,
+carol,,,maybe
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 1 || result.Updated != 1 || result.Groups != 2 || len(result.Errors) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	alice, _ := s.User("alice")
+	// roles 列未提供，保持原值
+	if alice.Email != "alice@example.com" || len(alice.Roles) != 1 || strings.Join(alice.Groups, ",") != "dev,ops" {
+		t.Fatalf("unexpected user %+v", alice)
+	}
+	bob, _ := s.User("bob")
+	if !bob.Disabled || bob.Source != SourceCSV {
+		t.Fatalf("unexpected user %+v", bob)
+	}
+
+	if _, err := s.ImportCSV(strings.NewReader("login,email\nx,y\n")); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected invalid header error, got %v", err)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateUser(types.User{Name: "alice", Roles: []string{"reader"}}); err != nil {
+		t.Fatal(err)
+	}
+	token, err := s.CreateToken("alice", "laptop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := s.Authenticate(token.Token)
+	session, err := s.CreateSession(p, "192.0.2.1", "curl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp, ok := s.Authenticate(session.Token)
+	if !ok || sp.Name != "alice" || sp.Session != session.ID || !sp.Can("el9", ActionRead) {
+		t.Fatalf("unexpected principal %+v", sp)
+	}
+	// 会话不能换取新的会话；篡改的令牌无效
+	if _, err := s.CreateSession(sp, "", ""); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid, got %v", err)
+	}
+	if _, ok := s.Authenticate(session.Token[:len(session.Token)-2] + "AA"); ok {
+		t.Fatal("tampered session authenticated")
+	}
+	if list, _ := s.Sessions("alice"); len(list) != 1 || list[0].TokenID != token.ID || list[0].Token != "" {
+		t.Fatalf("unexpected sessions %+v", list)
+	}
+
+	// 撤销后重新加载仍然无效
+	if err := s.RevokeSession(session.ID); err != nil {
+		t.Fatal(err)
+	}
+	s, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Authenticate(session.Token); ok {
+		t.Fatal("revoked session authenticated")
+	}
+
+	// 撤销用户令牌时撤销由它换取的会话
+	second, _ := s.CreateSession(p, "", "")
+	if _, ok := s.Authenticate(second.Token); !ok {
+		t.Fatal("session lost after reopen")
+	}
+	if err := s.DeleteToken("alice", token.ID); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := s.Sessions(""); len(list) != 0 {
+		t.Fatalf("unexpected sessions %+v", list)
+	}
+	if _, ok := s.Authenticate(second.Token); ok {
+		t.Fatal("session outlived its token")
+	}
+
+	// 共用 session-secret 的实例可以校验彼此的会话
+	other, _ := Open(t.TempDir())
+	if err := other.CreateUser(types.User{Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	s.SetSessions("shared", time.Minute)
+	other.SetSessions("shared", time.Minute)
+	token, _ = s.CreateToken("alice", "")
+	p, _ = s.Authenticate(token.Token)
+	third, _ := s.CreateSession(p, "", "")
+	if _, ok := other.Authenticate(third.Token); !ok {
+		t.Fatal("session not accepted by instance sharing the secret")
+	}
+}
+
+func TestClusterSessions(t *testing.T) {
+	c := cluster.New(cluster.NewMemoryStore(), "test", 0)
+	var nodes [2]*Store
+	for i := range nodes {
+		s, err := Open(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateUser(types.User{Name: "alice"}); err != nil {
+			t.Fatal(err)
+		}
+		s.SetSessions("shared", time.Minute)
+		s.SetCluster(c)
+		nodes[i] = s
+	}
+	a, b := nodes[0], nodes[1]
+	token, _ := a.CreateToken("alice", "")
+	p, _ := a.Authenticate(token.Token)
+	first, err := a.CreateSession(p, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := a.CreateSession(p, "", "")
+	if list, err := b.Sessions("alice"); err != nil || len(list) != 2 {
+		t.Fatalf("unexpected sessions on other instance %+v, %v", list, err)
+	}
+
+	// 在另一个实例上撤销，签发的实例同样拒绝
+	if err := b.RevokeSession(first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := a.Authenticate(first.Token); ok {
+		t.Fatal("session revoked on another instance authenticated")
+	}
+	if _, ok := a.Authenticate(second.Token); !ok {
+		t.Fatal("unrevoked session rejected")
+	}
+	if n, err := b.RevokeSessions("alice"); err != nil || n != 1 {
+		t.Fatalf("expected 1 session revoked, got %d, %v", n, err)
+	}
+	if _, ok := a.Authenticate(second.Token); ok {
+		t.Fatal("session revoked on another instance authenticated")
+	}
+	if err := b.RevokeSession(second.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUploadTokens(t *testing.T) {
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetUploadTokenMaxTTL(2 * time.Hour)
+	if _, err := s.CreateUploadToken("el9/x86_64", "ci", "alice", 3*time.Hour); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid, got %v", err)
+	}
+	token, err := s.CreateUploadToken("/el9/x86_64/", "ci", "alice", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(token.Token, token.ID) {
+		t.Fatalf("upload token ID %s is part of the token", token.ID)
+	}
+	p, ok := s.Authenticate(token.Token)
+	if !ok || p.Admin || p.TokenID != "" {
+		t.Fatalf("unexpected principal %+v", p)
+	}
+	// 只能写这一个仓库
+	checks := []struct {
+		repo, action string
+		want         bool
+	}{
+		{"el9/x86_64", ActionWrite, true},
+		{"el9/x86_64", ActionRead, false},
+		{"el9/x86_64/debug", ActionWrite, false},
+		{"el9", ActionWrite, false},
+		{"", ActionWrite, false},
+	}
+	for _, c := range checks {
+		if got := p.Can(c.repo, c.action); got != c.want {
+			t.Errorf("Can(%q, %q) = %v, want %v", c.repo, c.action, got, c.want)
+		}
+	}
+	if _, err := s.CreateSession(p, "", ""); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("upload token created a session: %v", err)
+	}
+
+	list := s.UploadTokens("el9/x86_64")
+	if len(list) != 1 || list[0].Token != "" || list[0].Hash != "" || list[0].CreatedBy != "alice" {
+		t.Fatalf("unexpected tokens %+v", list)
+	}
+	if err := s.DeleteUploadToken("el9", token.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := s.DeleteUploadToken("el9/x86_64", token.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Authenticate(token.Token); ok {
+		t.Fatal("revoked upload token authenticated")
+	}
+
+	// 过期的令牌无效
+	token, _ = s.CreateUploadToken("el9/x86_64", "", "alice", time.Minute)
+	for _, up := range s.state.uploads {
+		up.Expires = time.Now().Add(-time.Second).UTC().Format(time.RFC3339)
+	}
+	if _, ok := s.Authenticate(token.Token); ok || len(s.UploadTokens("el9/x86_64")) != 0 {
+		t.Fatal("expired upload token accepted")
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	policy, err := NewPolicy([]Rule{
+		{Repos: "el9/internal", Roles: map[Class][]string{ClassDownload: {"staff"}, ClassUpload: {}}},
+		{Repos: "el9/*", Roles: map[Class][]string{ClassDownload: {RoleAnonymous}, ClassUpload: {"ci", RoleUploadToken}}},
+		{Repos: "*", Roles: map[Class][]string{ClassRefresh: {RoleAuthenticated}, ClassAdmin: {"ops"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ci := &Principal{Name: "builder", Roles: []string{"ci"}}
+	staff := &Principal{Name: "alice", Roles: []string{"staff", "ci"}}
+	checks := []struct {
+		class Class
+		repo  string
+		who   *Principal
+		want  Decision
+	}{
+		{ClassDownload, "el9/x86_64/Packages/foo.rpm", nil, Allow},
+		{ClassDownload, "el9/internal/x86_64", nil, Deny},
+		{ClassDownload, "el9/internal", staff, Allow},
+		{ClassUpload, "el9/internal", staff, Deny},
+		{ClassUpload, "el9/x86_64", ci, Allow},
+		{ClassUpload, "el9/x86_64", staff, Allow},
+		{ClassUpload, "el9/x86_64", nil, Deny},
+		{ClassUpload, "el8/x86_64", ci, Default},
+		{ClassDelete, "el9/x86_64", ci, Default},
+		{ClassRefresh, "el8", ci, Allow},
+		{ClassRefresh, "el8", nil, Deny},
+		{ClassAdmin, "", &Principal{Roles: []string{"ops"}}, Allow},
+		{ClassDownload, "", nil, Default},
+	}
+	for _, c := range checks {
+		if got := policy.Evaluate(c.class, c.repo, c.who); got != c.want {
+			t.Errorf("Evaluate(%s, %q, %v) = %d, want %d", c.class, c.repo, c.who, got, c.want)
+		}
+	}
+
+	if _, err := NewPolicy([]Rule{{Repos: "el9/[", Roles: nil}}); err == nil {
+		t.Error("expected invalid pattern error")
+	}
+	if _, err := NewPolicy([]Rule{{Repos: "*", Roles: map[Class][]string{"browse": {"x"}}}}); err == nil {
+		t.Error("expected unknown class error")
+	}
+}
@@ -0,0 +1,290 @@
+package access
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// BER 编码，只实现 LDAP 同步用到的部分
+
+const (
+	tagBoolean     = 0x01
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x30
+	tagSet         = 0x31
+
+	berConstructed = 0x20
+
+	// maxBERLength 单条消息的最大长度
+	maxBERLength = 16 << 20
+)
+
+// ber 解码后的元素，构造类型的内容解析到 children
+type ber struct {
+	tag      byte
+	value    []byte
+	children []ber
+}
+
+func berEncode(tag byte, content []byte) []byte {
+	out := append([]byte{tag}, berLength(len(content))...)
+	return append(out, content...)
+}
+
+func berLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var b []byte
+	for ; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+func berSeq(tag byte, parts ...[]byte) []byte {
+	var content []byte
+	for _, p := range parts {
+		content = append(content, p...)
+	}
+	return berEncode(tag, content)
+}
+
+func berString(tag byte, s string) []byte {
+	return berEncode(tag, []byte(s))
+}
+
+// berInt 按最短的补码编码整数
+func berInt(tag byte, v int64) []byte {
+	var b []byte
+	for {
+		b = append([]byte{byte(v)}, b...)
+		if v >= -128 && v < 128 {
+			break
+		}
+		v >>= 8
+	}
+	return berEncode(tag, b)
+}
+
+func berBool(v bool) []byte {
+	if v {
+		return berEncode(tagBoolean, []byte{0xff})
+	}
+	return berEncode(tagBoolean, []byte{0})
+}
+
+// readBER 从连接读取一个完整元素
+func readBER(rd *bufio.Reader) (ber, error) {
+	tag, err := rd.ReadByte()
+	if err != nil {
+		return ber{}, err
+	}
+	first, err := rd.ReadByte()
+	if err != nil {
+		return ber{}, err
+	}
+	n := int(first)
+	if first&0x80 != 0 {
+		size := int(first & 0x7f)
+		if size == 0 || size > 4 {
+			return ber{}, fmt.Errorf("ldap: unsupported length encoding")
+		}
+		n = 0
+		for i := 0; i < size; i++ {
+			b, err := rd.ReadByte()
+			if err != nil {
+				return ber{}, err
+			}
+			n = n<<8 | int(b)
+		}
+	}
+	if n > maxBERLength {
+		return ber{}, fmt.Errorf("ldap: message of %d bytes is too large", n)
+	}
+	content := make([]byte, n)
+	if _, err := io.ReadFull(rd, content); err != nil {
+		return ber{}, err
+	}
+	return newBER(tag, content)
+}
+
+// parseBER 解析 data 开头的一个元素，返回元素和剩余的数据
+func parseBER(data []byte) (ber, []byte, error) {
+	if len(data) < 2 {
+		return ber{}, nil, fmt.Errorf("ldap: truncated element")
+	}
+	tag, n, off := data[0], int(data[1]), 2
+	if data[1]&0x80 != 0 {
+		size := int(data[1] & 0x7f)
+		if size == 0 || size > 4 || len(data) < 2+size {
+			return ber{}, nil, fmt.Errorf("ldap: invalid length")
+		}
+		n = 0
+		for _, b := range data[2 : 2+size] {
+			n = n<<8 | int(b)
+		}
+		off += size
+	}
+	if n < 0 || len(data)-off < n {
+		return ber{}, nil, fmt.Errorf("ldap: truncated element")
+	}
+	el, err := newBER(tag, data[off:off+n])
+	return el, data[off+n:], err
+}
+
+func newBER(tag byte, content []byte) (ber, error) {
+	el := ber{tag: tag, value: content}
+	if tag&berConstructed == 0 {
+		return el, nil
+	}
+	for rest := content; len(rest) > 0; {
+		child, next, err := parseBER(rest)
+		if err != nil {
+			return ber{}, err
+		}
+		el.children = append(el.children, child)
+		rest = next
+	}
+	return el, nil
+}
+
+func (b ber) int() int64 {
+	var v int64
+	for i, c := range b.value {
+		if i == 0 && c&0x80 != 0 {
+			v = -1
+		}
+		v = v<<8 | int64(c)
+	}
+	return v
+}
+
+func (b ber) str() string {
+	return string(b.value)
+}
+
+func (b ber) child(i int) ber {
+	if i < len(b.children) {
+		return b.children[i]
+	}
+	return ber{}
+}
+
+// compileFilter 把 RFC 4515 过滤器编码为 BER，支持 & | ! = 存在性和子串匹配
+func compileFilter(filter string) ([]byte, error) {
+	out, rest, err := parseFilter(strings.TrimSpace(filter))
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("ldap filter %q: unexpected %q", filter, rest)
+	}
+	return out, nil
+}
+
+func parseFilter(f string) ([]byte, string, error) {
+	if !strings.HasPrefix(f, "(") || len(f) < 3 {
+		return nil, "", fmt.Errorf("ldap filter: expected ( at %q", f)
+	}
+	f = f[1:]
+	switch f[0] {
+	case '&', '|':
+		tag := byte(0xa0)
+		if f[0] == '|' {
+			tag = 0xa1
+		}
+		f = f[1:]
+		var parts [][]byte
+		for strings.HasPrefix(f, "(") {
+			part, rest, err := parseFilter(f)
+			if err != nil {
+				return nil, "", err
+			}
+			parts, f = append(parts, part), rest
+		}
+		if !strings.HasPrefix(f, ")") {
+			return nil, "", fmt.Errorf("ldap filter: expected ) at %q", f)
+		}
+		return berSeq(tag, parts...), f[1:], nil
+	case '!':
+		part, rest, err := parseFilter(f[1:])
+		if err != nil {
+			return nil, "", err
+		}
+		if !strings.HasPrefix(rest, ")") {
+			return nil, "", fmt.Errorf("ldap filter: expected ) at %q", rest)
+		}
+		return berEncode(0xa2, part), rest[1:], nil
+	}
+
+	end := strings.IndexByte(f, ')')
+	if end < 0 {
+		return nil, "", fmt.Errorf("ldap filter: missing )")
+	}
+	item, rest := f[:end], f[end+1:]
+	attr, value, ok := strings.Cut(item, "=")
+	if !ok || attr == "" || strings.ContainsAny(attr, "<>~:") {
+		return nil, "", fmt.Errorf("ldap filter: unsupported item %q", item)
+	}
+	if value == "*" {
+		return berString(0x87, attr), rest, nil
+	}
+	if !strings.Contains(value, "*") {
+		v, err := unescapeFilter(value)
+		if err != nil {
+			return nil, "", err
+		}
+		return berSeq(0xa3, berString(tagOctetString, attr), berString(tagOctetString, v)), rest, nil
+	}
+
+	parts := strings.Split(value, "*")
+	var subs [][]byte
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		v, err := unescapeFilter(p)
+		if err != nil {
+			return nil, "", err
+		}
+		tag := byte(0x81) // any
+		switch i {
+		case 0:
+			tag = 0x80 // initial
+		case len(parts) - 1:
+			tag = 0x82 // final
+		}
+		subs = append(subs, berString(tag, v))
+	}
+	return berSeq(0xa4, berString(tagOctetString, attr), berSeq(tagSequence, subs...)), rest, nil
+}
+
+// unescapeFilter 还原 \XX 转义
+func unescapeFilter(v string) (string, error) {
+	if !strings.Contains(v, `\`) {
+		return v, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] != '\\' {
+			b.WriteByte(v[i])
+			continue
+		}
+		if i+3 > len(v) {
+			return "", fmt.Errorf("ldap filter: invalid escape in %q", v)
+		}
+		c, err := hex.DecodeString(v[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("ldap filter: invalid escape in %q", v)
+		}
+		b.Write(c)
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,154 @@
+package access
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"plus/internal/types"
+)
+
+// CSV 导入的列，name 必填，列表用 ; 分隔
+const (
+	colName     = "name"
+	colEmail    = "email"
+	colGroups   = "groups"
+	colRoles    = "roles"
+	colDisabled = "disabled"
+)
+
+// entry 导入的一个用户，has 记录提供了哪些列，未提供的列保持原值
+type entry struct {
+	name     string
+	email    string
+	groups   []string
+	roles    []string
+	disabled bool
+	has      map[string]bool
+}
+
+// ImportCSV 按表头导入用户：已存在的用户更新提供的列，不存在的创建；引用的用户组不存在时创建。
+// 出错的行跳过并记录在结果中，其余行一次写入
+func (s *Store) ImportCSV(r io.Reader) (*types.ImportResult, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, col := range header {
+		col = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))
+		switch col {
+		case colName, colEmail, colGroups, colRoles, colDisabled:
+			columns[col] = i
+		default:
+			return nil, fmt.Errorf("unknown csv column %q: %w", col, ErrInvalid)
+		}
+	}
+	if _, ok := columns[colName]; !ok {
+		return nil, fmt.Errorf("csv header has no name column: %w", ErrInvalid)
+	}
+
+	result := &types.ImportResult{}
+	var entries []entry
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+		field := func(col string) string {
+			if i, ok := columns[col]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		e := entry{name: field(colName), email: field(colEmail), has: make(map[string]bool)}
+		if e.name == "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: empty name", line))
+			continue
+		}
+		for col := range columns {
+			e.has[col] = true
+		}
+		e.groups = splitList(field(colGroups))
+		e.roles = splitList(field(colRoles))
+		if v := field(colDisabled); v != "" {
+			if e.disabled, err = strconv.ParseBool(v); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d: invalid disabled value %q", line, v))
+				continue
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	err = s.update(func(st *state) error {
+		st.apply(entries, SourceCSV, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// apply 创建或更新用户，source 为新建用户和用户组的来源
+func (st *state) apply(entries []entry, source string, result *types.ImportResult) {
+	for _, e := range entries {
+		var groupErr error
+		for _, g := range e.groups {
+			if _, ok := st.groups[g]; ok {
+				continue
+			}
+			if groupErr = st.putGroup(types.Group{Name: g, Source: source}); groupErr != nil {
+				break
+			}
+			result.Groups++
+		}
+		if groupErr != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("user %s: %v", e.name, groupErr))
+			continue
+		}
+
+		u := types.User{Name: e.name, Source: source}
+		old, exists := st.users[e.name]
+		if exists {
+			u = *old
+		}
+		if e.has[colEmail] {
+			u.Email = e.email
+		}
+		if e.has[colGroups] {
+			u.Groups = e.groups
+		}
+		if e.has[colRoles] {
+			u.Roles = e.roles
+		}
+		if e.has[colDisabled] {
+			u.Disabled = e.disabled
+		}
+		if err := st.putUser(u, !exists); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("user %s: %v", e.name, err))
+			continue
+		}
+		if exists {
+			result.Updated++
+		} else {
+			result.Created++
+		}
+	}
+}
+
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return normalize(strings.Split(v, ";"))
+}
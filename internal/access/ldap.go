@@ -0,0 +1,366 @@
+package access
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"plus/internal/types"
+)
+
+// LDAPConfig LDAP 目录同步参数
+type LDAPConfig struct {
+	URL                string // ldap://host:389 或 ldaps://host:636
+	BindDN             string // 为空时匿名查询
+	BindPassword       string
+	BaseDN             string
+	Filter             string        // 用户过滤器，默认 (objectClass=person)
+	UserAttr           string        // 用户名属性，默认 uid（Active Directory 用 sAMAccountName）
+	EmailAttr          string        // 默认 mail
+	GroupAttr          string        // 用户所属组的 DN，默认 memberOf
+	Timeout            time.Duration // 一次同步的超时，默认 30s
+	InsecureSkipVerify bool
+}
+
+const (
+	pagedResultsOID = "1.2.840.113556.1.4.319"
+	ldapPageSize    = 500
+)
+
+// LDAP 协议操作的标签
+const (
+	ldapBindRequest   = 0x60
+	ldapBindResponse  = 0x61
+	ldapUnbindRequest = 0x42
+	ldapSearchRequest = 0x63
+	ldapSearchEntry   = 0x64
+	ldapSearchDone    = 0x65
+	ldapSearchRef     = 0x73
+	ldapControls      = 0xa0
+)
+
+// SetLDAP 配置 LDAP 同步
+func (s *Store) SetLDAP(cfg LDAPConfig) {
+	if cfg.Filter == "" {
+		cfg.Filter = "(objectClass=person)"
+	}
+	if cfg.UserAttr == "" {
+		cfg.UserAttr = "uid"
+	}
+	if cfg.EmailAttr == "" {
+		cfg.EmailAttr = "mail"
+	}
+	if cfg.GroupAttr == "" {
+		cfg.GroupAttr = "memberOf"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	s.ldap = &cfg
+}
+
+// LDAPEnabled 返回是否配置了 LDAP 同步
+func (s *Store) LDAPEnabled() bool {
+	return s.ldap != nil
+}
+
+// SyncLDAP 从 LDAP 目录同步用户：创建或更新用户的邮箱和 LDAP 用户组（本地添加的用户组保留），
+// 目录中已不存在的 LDAP 用户被禁用。用户的角色不受影响，由管理员分配给用户或用户组
+func (s *Store) SyncLDAP(ctx context.Context) (*types.ImportResult, error) {
+	if s.ldap == nil {
+		return nil, errors.New("ldap is not configured")
+	}
+	cfg := *s.ldap
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	entries, err := searchLDAP(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.ImportResult{}
+	err = s.update(func(st *state) error {
+		seen := make(map[string]bool)
+		var sync []entry
+		for _, e := range entries {
+			if u, ok := st.users[e.name]; ok {
+				if u.Source != SourceLDAP {
+					result.Errors = append(result.Errors, fmt.Sprintf("user %s exists as a %s user, skipped", e.name, u.Source))
+					continue
+				}
+				for _, g := range u.Groups {
+					if group, ok := st.groups[g]; ok && group.Source != SourceLDAP {
+						e.groups = append(e.groups, g)
+					}
+				}
+				e.groups = normalize(e.groups)
+			}
+			seen[e.name] = true
+			sync = append(sync, e)
+		}
+		st.apply(sync, SourceLDAP, result)
+
+		now := time.Now().UTC().Format(time.RFC3339)
+		for _, u := range st.users {
+			if u.Source == SourceLDAP && !seen[u.Name] && !u.Disabled {
+				u.Disabled = true
+				u.Updated = now
+				result.Disabled++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// searchLDAP 分页查询全部用户
+func searchLDAP(ctx context.Context, cfg LDAPConfig) ([]entry, error) {
+	filter, err := compileFilter(cfg.Filter)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := dialLDAP(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.close()
+
+	if cfg.BindDN != "" {
+		if err := conn.bind(cfg.BindDN, cfg.BindPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	attrs := []string{cfg.UserAttr, cfg.EmailAttr, cfg.GroupAttr}
+	var entries []entry
+	var cookie string
+	for {
+		page, next, err := conn.search(cfg.BaseDN, filter, attrs, cookie)
+		if err != nil {
+			return nil, err
+		}
+		for _, attrs := range page {
+			name := first(attrs[strings.ToLower(cfg.UserAttr)])
+			if name == "" {
+				continue
+			}
+			e := entry{
+				name:  name,
+				email: first(attrs[strings.ToLower(cfg.EmailAttr)]),
+				has:   map[string]bool{colEmail: true, colGroups: true, colDisabled: true},
+			}
+			for _, dn := range attrs[strings.ToLower(cfg.GroupAttr)] {
+				if g := groupName(dn); g != "" {
+					e.groups = append(e.groups, g)
+				}
+			}
+			e.groups = normalize(e.groups)
+			entries = append(entries, e)
+		}
+		if next == "" {
+			return entries, nil
+		}
+		cookie = next
+	}
+}
+
+// groupName 取组 DN 的第一个 RDN 的值作为用户组名，不允许的字符替换为 -
+func groupName(dn string) string {
+	rdn, _, _ := strings.Cut(dn, ",")
+	_, value, ok := strings.Cut(rdn, "=")
+	if !ok {
+		value = rdn
+	}
+	value = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '@', r == '-':
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(value))
+	return strings.Trim(value, "-")
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+type ldapConn struct {
+	conn  net.Conn
+	rd    *bufio.Reader
+	msgID int64
+}
+
+func dialLDAP(ctx context.Context, cfg LDAPConfig) (*ldapConn, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ldap url: %w", err)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		port := "389"
+		if u.Scheme == "ldaps" {
+			port = "636"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	d := net.Dialer{Timeout: cfg.Timeout}
+	var conn net.Conn
+	switch u.Scheme {
+	case "ldap":
+		conn, err = d.DialContext(ctx, "tcp", host)
+	case "ldaps":
+		td := tls.Dialer{NetDialer: &d, Config: &tls.Config{ServerName: u.Hostname(), InsecureSkipVerify: cfg.InsecureSkipVerify}}
+		conn, err = td.DialContext(ctx, "tcp", host)
+	default:
+		return nil, fmt.Errorf("unsupported ldap url scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect to ldap %s: %w", host, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	return &ldapConn{conn: conn, rd: bufio.NewReader(conn)}, nil
+}
+
+func (c *ldapConn) send(op []byte, controls ...[]byte) (int64, error) {
+	c.msgID++
+	parts := [][]byte{berInt(tagInteger, c.msgID), op}
+	if len(controls) > 0 {
+		parts = append(parts, berSeq(ldapControls, controls...))
+	}
+	_, err := c.conn.Write(berSeq(tagSequence, parts...))
+	return c.msgID, err
+}
+
+func (c *ldapConn) receive(id int64) (ber, error) {
+	msg, err := readBER(c.rd)
+	if err != nil {
+		return ber{}, fmt.Errorf("ldap: %w", err)
+	}
+	if len(msg.children) < 2 || msg.children[0].int() != id {
+		return ber{}, fmt.Errorf("ldap: unexpected message")
+	}
+	return msg, nil
+}
+
+// ldapResult 检查 LDAPResult，成功时返回 nil
+func ldapResult(op string, el ber) error {
+	if code := el.child(0).int(); code != 0 {
+		return fmt.Errorf("ldap %s failed with result code %d: %s", op, code, el.child(2).str())
+	}
+	return nil
+}
+
+func (c *ldapConn) bind(dn, password string) error {
+	id, err := c.send(berSeq(ldapBindRequest,
+		berInt(tagInteger, 3),
+		berString(tagOctetString, dn),
+		berString(0x80, password),
+	))
+	if err != nil {
+		return err
+	}
+	msg, err := c.receive(id)
+	if err != nil {
+		return err
+	}
+	if msg.children[1].tag != ldapBindResponse {
+		return fmt.Errorf("ldap: unexpected bind response")
+	}
+	return ldapResult("bind", msg.children[1])
+}
+
+// search 查询一页，返回每个条目的属性（属性名小写）和下一页的 cookie
+func (c *ldapConn) search(base string, filter []byte, attrs []string, cookie string) ([]map[string][]string, string, error) {
+	var attrList [][]byte
+	for _, a := range attrs {
+		attrList = append(attrList, berString(tagOctetString, a))
+	}
+	paging := berSeq(tagSequence,
+		berString(tagOctetString, pagedResultsOID),
+		berEncode(tagOctetString, berSeq(tagSequence, berInt(tagInteger, ldapPageSize), berString(tagOctetString, cookie))),
+	)
+	id, err := c.send(berSeq(ldapSearchRequest,
+		berString(tagOctetString, base),
+		berInt(tagEnumerated, 2), // wholeSubtree
+		berInt(tagEnumerated, 0), // neverDerefAliases
+		berInt(tagInteger, 0),
+		berInt(tagInteger, 0),
+		berBool(false),
+		filter,
+		berSeq(tagSequence, attrList...),
+	), paging)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var entries []map[string][]string
+	for {
+		msg, err := c.receive(id)
+		if err != nil {
+			return nil, "", err
+		}
+		op := msg.children[1]
+		switch op.tag {
+		case ldapSearchEntry:
+			values := make(map[string][]string)
+			for _, attr := range op.child(1).children {
+				name := strings.ToLower(attr.child(0).str())
+				for _, v := range attr.child(1).children {
+					values[name] = append(values[name], v.str())
+				}
+			}
+			entries = append(entries, values)
+		case ldapSearchRef:
+		case ldapSearchDone:
+			if err := ldapResult("search", op); err != nil {
+				return nil, "", err
+			}
+			return entries, pagingCookie(msg), nil
+		default:
+			return nil, "", fmt.Errorf("ldap: unexpected search response 0x%x", op.tag)
+		}
+	}
+}
+
+// pagingCookie 取出响应中分页控制的 cookie，没有更多页时为空
+func pagingCookie(msg ber) string {
+	for _, el := range msg.children[2:] {
+		if el.tag != ldapControls {
+			continue
+		}
+		for _, control := range el.children {
+			if control.child(0).str() != pagedResultsOID {
+				continue
+			}
+			value := control.children[len(control.children)-1]
+			paged, _, err := parseBER(value.value)
+			if err != nil {
+				return ""
+			}
+			return paged.child(1).str()
+		}
+	}
+	return ""
+}
+
+func (c *ldapConn) close() {
+	c.send(berEncode(ldapUnbindRequest, nil))
+	c.conn.Close()
+}
@@ -0,0 +1,171 @@
+package access
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"plus/internal/types"
+)
+
+// fakeDirectory 最小的 LDAP 服务器：校验绑定密码，每页返回一个固定的条目
+type fakeDirectory struct {
+	entries [][]byte // 编码后的 SearchResultEntry
+	pages   atomic.Int32
+}
+
+func (d *fakeDirectory) serve(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go d.handle(conn)
+	}
+}
+
+func (d *fakeDirectory) handle(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	reply := func(id int64, parts ...[]byte) {
+		conn.Write(berSeq(tagSequence, append([][]byte{berInt(tagInteger, id)}, parts...)...))
+	}
+	success := func(tag byte) []byte {
+		return berSeq(tag, berInt(tagEnumerated, 0), berString(tagOctetString, ""), berString(tagOctetString, ""))
+	}
+	for {
+		msg, err := readBER(rd)
+		if err != nil {
+			return
+		}
+		id, op := msg.child(0).int(), msg.child(1)
+		switch op.tag {
+		case ldapBindRequest:
+			if op.child(2).str() != "secret" {
+				reply(id, berSeq(ldapBindResponse, berInt(tagEnumerated, 49), berString(tagOctetString, ""), berString(tagOctetString, "invalid credentials")))
+				continue
+			}
+			reply(id, success(ldapBindResponse))
+		case ldapSearchRequest:
+			// 每页一个条目，cookie 为下一个条目的序号
+			paged, _, _ := parseBER(msg.child(2).child(0).child(1).value)
+			next := 0
+			if c := paged.child(1).str(); c != "" {
+				next = int(c[0] - '0')
+			}
+			d.pages.Add(1)
+			reply(id, d.entries[next])
+			cookie := ""
+			if next+1 < len(d.entries) {
+				cookie = string(rune('0' + next + 1))
+			}
+			control := berSeq(tagSequence,
+				berString(tagOctetString, pagedResultsOID),
+				berEncode(tagOctetString, berSeq(tagSequence, berInt(tagInteger, 0), berString(tagOctetString, cookie))),
+			)
+			reply(id, success(ldapSearchDone), berSeq(ldapControls, control))
+		case ldapUnbindRequest:
+			return
+		}
+	}
+}
+
+func ldapEntry(dn string, attrs map[string][]string) []byte {
+	var list [][]byte
+	for name, values := range attrs {
+		var vals [][]byte
+		for _, v := range values {
+			vals = append(vals, berString(tagOctetString, v))
+		}
+		list = append(list, berSeq(tagSequence, berString(tagOctetString, name), berSeq(tagSet, vals...)))
+	}
+	return berSeq(ldapSearchEntry, berString(tagOctetString, dn), berSeq(tagSequence, list...))
+}
+
+func TestSyncLDAP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	dir := &fakeDirectory{entries: [][]byte{
+		ldapEntry("uid=alice,ou=people,dc=example,dc=org", map[string][]string{
+			"uid":      {"alice"},
+			"mail":     {"alice@example.org"},
+			"memberOf": {"cn=Release Engineers,ou=groups,dc=example,dc=org", "cn=ops,ou=groups,dc=example,dc=org"},
+		}),
+		ldapEntry("uid=bob,ou=people,dc=example,dc=org", map[string][]string{
+			"UID": {"bob"},
+		}),
+	}}
+	go dir.serve(ln)
+
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 本地用户组在同步后保留，目录中已删除的 LDAP 用户被禁用
+	if err := s.PutGroup(types.Group{Name: "oncall"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateUser(types.User{Name: "alice", Groups: []string{"oncall"}, Source: SourceLDAP}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateUser(types.User{Name: "mallory", Source: SourceLDAP}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetLDAP(LDAPConfig{
+		URL:          "ldap://" + ln.Addr().String(),
+		BindDN:       "cn=plus,dc=example,dc=org",
+		BindPassword: "wrong",
+		BaseDN:       "ou=people,dc=example,dc=org",
+		Filter:       "(&(objectClass=inetOrgPerson)(!(uid=svc-*)))",
+	})
+	if _, err := s.SyncLDAP(context.Background()); err == nil || !strings.Contains(err.Error(), "invalid credentials") {
+		t.Fatalf("expected bind failure, got %v", err)
+	}
+
+	s.ldap.BindPassword = "secret"
+	result, err := s.SyncLDAP(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 1 || result.Updated != 1 || result.Disabled != 1 || result.Groups != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if n := dir.pages.Load(); n != 2 {
+		t.Fatalf("expected 2 pages, got %d", n)
+	}
+	alice, _ := s.User("alice")
+	if alice.Email != "alice@example.org" || strings.Join(alice.Groups, ",") != "Release-Engineers,oncall,ops" {
+		t.Fatalf("unexpected user %+v", alice)
+	}
+	if mallory, _ := s.User("mallory"); !mallory.Disabled {
+		t.Fatal("user removed from the directory was not disabled")
+	}
+	if g, _ := s.Group("ops"); g.Source != SourceLDAP {
+		t.Fatalf("unexpected group %+v", g)
+	}
+}
+
+func TestCompileFilter(t *testing.T) {
+	for _, f := range []string{
+		"(uid=alice)",
+		"(objectClass=*)",
+		"(&(objectClass=person)(|(ou=dev)(ou=ops))(!(cn=svc*)))",
+		`(cn=a\2ab*c*d)`,
+	} {
+		if _, err := compileFilter(f); err != nil {
+			t.Errorf("%s: %v", f, err)
+		}
+	}
+	for _, f := range []string{"uid=alice", "(uid>=5)", "(&(uid=a)", `(cn=\zz)`, "(uid=a))"} {
+		if _, err := compileFilter(f); err == nil {
+			t.Errorf("%s: expected error", f)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package access
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Class 接口类别，访问策略按类别声明允许的角色
+type Class string
+
+const (
+	ClassDownload Class = "download" // 下载、元数据、浏览和仓库列表
+	ClassUpload   Class = "upload"   // 上传、目录树上传和创建仓库
+	ClassRefresh  Class = "refresh"  // 刷新元数据和 fsck 修复
+	ClassDelete   Class = "delete"   // 删除仓库
+	ClassAdmin    Class = "admin"    // 仓库管理（上传令牌）；仓库为空时为全局管理接口
+)
+
+// Action 没有策略规则时按角色权限检查的操作
+func (c Class) Action() string {
+	switch c {
+	case ClassDownload:
+		return ActionRead
+	case ClassUpload, ClassRefresh:
+		return ActionWrite
+	case ClassDelete:
+		return ActionDelete
+	}
+	return ActionAdmin
+}
+
+// 策略中的特殊角色
+const (
+	RoleAnonymous     = "anonymous"     // 任何请求，包括未认证的
+	RoleAuthenticated = "authenticated" // 任何有效令牌
+	RoleUploadToken   = "upload-token"  // 上传令牌
+)
+
+// Decision 策略的判定结果
+type Decision int
+
+const (
+	Default Decision = iota // 没有规则声明该类别，按角色权限检查
+	Allow
+	Deny
+)
+
+// Rule 一条策略规则：对匹配 Repos 的仓库，Roles 声明每类接口允许的角色。
+// 未声明的类别交给后续规则；声明为空列表表示不允许任何人
+type Rule struct {
+	Repos string
+	Roles map[Class][]string
+}
+
+// Policy 按顺序匹配的访问策略
+type Policy struct {
+	rules []Rule
+}
+
+// NewPolicy 校验规则的仓库模式和类别
+func NewPolicy(rules []Rule) (*Policy, error) {
+	p := &Policy{}
+	for i, r := range rules {
+		r.Repos = strings.Trim(r.Repos, "/")
+		if _, err := path.Match(r.Repos, ""); err != nil || r.Repos == "" {
+			return nil, fmt.Errorf("policy rule %d: invalid repos pattern %q", i+1, r.Repos)
+		}
+		for class := range r.Roles {
+			switch class {
+			case ClassDownload, ClassUpload, ClassRefresh, ClassDelete, ClassAdmin:
+			default:
+				return nil, fmt.Errorf("policy rule %d: unknown class %q", i+1, class)
+			}
+		}
+		p.rules = append(p.rules, r)
+	}
+	return p, nil
+}
+
+// Evaluate 返回第一条匹配仓库且声明了该类别的规则的判定。
+// repo 为空（仓库列表、全局管理接口）时只匹配模式为 * 的规则；who 为 nil 表示未认证
+func (p *Policy) Evaluate(class Class, repo string, who *Principal) Decision {
+	if p == nil {
+		return Default
+	}
+	repo = strings.Trim(repo, "/")
+	for _, r := range p.rules {
+		roles, ok := r.Roles[class]
+		if !ok {
+			continue
+		}
+		if repo == "" && r.Repos != "*" || repo != "" && !matchRepo(r.Repos, repo) {
+			continue
+		}
+		for _, role := range roles {
+			switch {
+			case role == RoleAnonymous:
+				return Allow
+			case who == nil:
+			case role == RoleAuthenticated, contains(who.Roles, role):
+				return Allow
+			}
+		}
+		return Deny
+	}
+	return Default
+}
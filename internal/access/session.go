@@ -0,0 +1,372 @@
+package access
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+// DefaultSessionTTL 会话默认有效期
+const DefaultSessionTTL = 12 * time.Hour
+
+// 会话令牌固定使用 HS256，头部预先编码
+var sessionHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type sessionConfig struct {
+	secret []byte // session-secret，多个实例共用；为空时使用用户库中生成的密钥
+	ttl    time.Duration
+}
+
+// Cluster 多个实例共享的会话和撤销列表，由 cluster.Cluster 实现。
+// 会话是 JWT，共用 session-secret 的实例互相接受，撤销必须对所有实例生效
+type Cluster interface {
+	Sessions(ctx context.Context) (map[string]string, error) // 会话 ID -> 会话（JSON）
+	SetSession(ctx context.Context, id, session string) error
+	DeleteSession(ctx context.Context, id string) error
+	RevokeSession(ctx context.Context, id, expires string) error
+	SessionRevoked(ctx context.Context, id string) (bool, error)
+}
+
+// SetCluster 集群模式下在集群中记录会话和撤销列表，在处理请求之前调用
+func (s *Store) SetCluster(c Cluster) {
+	s.cluster = c
+}
+
+// SetSessions 设置会话签名密钥和有效期
+func (s *Store) SetSessions(secret string, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = sessionConfig{ttl: ttl}
+	if secret != "" {
+		s.sessions.secret = []byte(secret)
+	}
+}
+
+// signingKey 返回签名密钥，调用方持有锁
+func (s *Store) signingKey(st *state) []byte {
+	if len(s.sessions.secret) > 0 {
+		return s.sessions.secret
+	}
+	key, _ := hex.DecodeString(st.sessionKey)
+	return key
+}
+
+// CreateSession 用用户令牌换取会话。会话令牌是 HS256 JWT，到期前可以撤销；
+// 会话令牌本身不能再换取会话，避免无限续期
+func (s *Store) CreateSession(p *Principal, address, userAgent string) (*types.Session, error) {
+	if p.TokenID == "" {
+		return nil, fmt.Errorf("sessions can only be created with a user token: %w", ErrInvalid)
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	session := types.Session{
+		ID:        hex.EncodeToString(id),
+		User:      p.Name,
+		TokenID:   p.TokenID,
+		Address:   address,
+		UserAgent: userAgent,
+		Created:   now.Format(time.RFC3339),
+	}
+
+	var key []byte
+	var expires time.Time
+	err := s.update(func(st *state) error {
+		if _, ok := st.users[p.Name]; !ok {
+			return fmt.Errorf("user %s: %w", p.Name, ErrNotFound)
+		}
+		ttl := s.sessions.ttl
+		if ttl <= 0 {
+			ttl = DefaultSessionTTL
+		}
+		expires = now.Add(ttl)
+		session.Expires = expires.Format(time.RFC3339)
+		st.pruneSessions(now)
+		if st.sessionKey == "" && len(s.sessions.secret) == 0 {
+			k := make([]byte, 32)
+			if _, err := rand.Read(k); err != nil {
+				return err
+			}
+			st.sessionKey = hex.EncodeToString(k)
+		}
+		key = s.signingKey(st)
+		stored := session
+		st.sessions[session.ID] = &stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.cluster != nil {
+		data, err := session.MarshalJSON()
+		if err == nil {
+			err = s.cluster.SetSession(context.Background(), session.ID, string(data))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("record session in cluster: %w", err)
+		}
+	}
+
+	claims := types.SessionClaims{Subject: p.Name, ID: session.ID, IssuedAt: now.Unix(), Expires: expires.Unix()}
+	payload, err := claims.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	signed := sessionHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	session.Token = signed + "." + base64.RawURLEncoding.EncodeToString(sign(key, signed))
+	return &session, nil
+}
+
+// authenticateSession 校验签名、有效期和撤销列表。其他实例签发的会话（共用 session-secret）不在本地列表中，同样有效；
+// 集群模式下还要检查集群中的撤销列表，集群不可用时拒绝会话令牌
+func (s *Store) authenticateSession(token string) (*Principal, bool) {
+	p, ok := s.verifySession(token)
+	if !ok || s.cluster == nil {
+		return p, ok
+	}
+	revoked, err := s.cluster.SessionRevoked(context.Background(), p.Session)
+	if err != nil {
+		log.Logger.Warnf("Failed to check revocation of session %s: %v", p.Session, err)
+		return nil, false
+	}
+	if revoked {
+		return nil, false
+	}
+	return p, true
+}
+
+// verifySession 校验签名、有效期和本实例的撤销列表
+func (s *Store) verifySession(token string) (*Principal, bool) {
+	i := strings.LastIndexByte(token, '.')
+	signed, sig := token[:i], token[i+1:]
+	header, payload, _ := strings.Cut(signed, ".")
+	if header != sessionHeader {
+		return nil, false
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return nil, false
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key := s.signingKey(s.state)
+	if len(key) == 0 || !hmac.Equal(mac, sign(key, signed)) {
+		return nil, false
+	}
+	var claims types.SessionClaims
+	if err := claims.UnmarshalJSON(data); err != nil {
+		return nil, false
+	}
+	if time.Now().Unix() >= claims.Expires {
+		return nil, false
+	}
+	if _, revoked := s.state.revoked[claims.ID]; revoked {
+		return nil, false
+	}
+	u, ok := s.state.users[claims.Subject]
+	if !ok || u.Disabled {
+		return nil, false
+	}
+	p := s.state.principal(u)
+	p.Session = claims.ID
+	return p, true
+}
+
+func sign(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// Sessions 返回用户未过期的会话，user 为空时返回全部，按创建时间排序。
+// 集群模式下返回集群中记录的会话，包括其他实例签发的
+func (s *Store) Sessions(user string) ([]types.Session, error) {
+	var all map[string]*types.Session
+	if s.cluster != nil {
+		var err error
+		if all, err = s.sharedSessions(context.Background()); err != nil {
+			return nil, err
+		}
+	} else {
+		s.mu.RLock()
+		all = make(map[string]*types.Session, len(s.state.sessions))
+		for id, se := range s.state.sessions {
+			c := *se
+			all[id] = &c
+		}
+		s.mu.RUnlock()
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	sessions := make([]types.Session, 0)
+	for _, se := range all {
+		if (user == "" || se.User == user) && se.Expires > now {
+			sessions = append(sessions, *se)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Created < sessions[j].Created })
+	return sessions, nil
+}
+
+// Session 返回会话，集群模式下先查找集群中记录的会话
+func (s *Store) Session(id string) (*types.Session, error) {
+	if s.cluster != nil {
+		sessions, err := s.sharedSessions(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		if se, ok := sessions[id]; ok {
+			return se, nil
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	se, ok := s.state.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("session %s: %w", id, ErrNotFound)
+	}
+	c := *se
+	return &c, nil
+}
+
+// sharedSessions 返回集群中记录的会话
+func (s *Store) sharedSessions(ctx context.Context) (map[string]*types.Session, error) {
+	shared, err := s.cluster.Sessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions in cluster: %w", err)
+	}
+	sessions := make(map[string]*types.Session, len(shared))
+	for id, data := range shared {
+		var se types.Session
+		if err := se.UnmarshalJSON([]byte(data)); err != nil {
+			log.Logger.Warnf("Ignoring malformed session %s in cluster: %v", id, err)
+			continue
+		}
+		sessions[id] = &se
+	}
+	return sessions, nil
+}
+
+// RevokeSession 撤销会话，会话 ID 加入撤销列表直到会话过期
+func (s *Store) RevokeSession(id string) error {
+	match := func(se *types.Session) bool { return se.ID == id }
+	var local map[string]string
+	err := s.update(func(st *state) error {
+		local = st.revokeSessions(match)
+		if len(local) == 0 && s.cluster == nil {
+			return fmt.Errorf("session %s: %w", id, ErrNotFound)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	n, err := s.revokeShared(match, local)
+	if err == nil && n == 0 {
+		err = fmt.Errorf("session %s: %w", id, ErrNotFound)
+	}
+	return err
+}
+
+// RevokeSessions 撤销用户的全部会话，返回撤销的数量
+func (s *Store) RevokeSessions(user string) (int, error) {
+	match := func(se *types.Session) bool { return se.User == user }
+	var local map[string]string
+	err := s.update(func(st *state) error {
+		if _, ok := st.users[user]; !ok {
+			return fmt.Errorf("user %s: %w", user, ErrNotFound)
+		}
+		local = st.revokeSessions(match)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return s.revokeShared(match, local)
+}
+
+// revokeShared 集群模式下把本实例撤销的会话 local 和集群中匹配的会话加入集群的撤销列表，
+// 同时清理集群中过期的会话。返回撤销的会话总数
+func (s *Store) revokeShared(match func(*types.Session) bool, local map[string]string) (int, error) {
+	n := len(local)
+	if s.cluster == nil {
+		return n, nil
+	}
+	ctx := context.Background()
+	sessions, err := s.sharedSessions(ctx)
+	if err != nil {
+		return n, err
+	}
+	for id, expires := range local {
+		if _, ok := sessions[id]; !ok {
+			if err := s.cluster.RevokeSession(ctx, id, expires); err != nil {
+				return n, fmt.Errorf("revoke session %s in cluster: %w", id, err)
+			}
+		}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for id, se := range sessions {
+		switch {
+		case se.Expires <= now:
+			err = s.cluster.DeleteSession(ctx, id)
+		case match(se):
+			err = s.cluster.RevokeSession(ctx, id, se.Expires)
+			if _, ok := local[id]; !ok {
+				n++
+			}
+		}
+		if err != nil {
+			return n, fmt.Errorf("revoke session %s in cluster: %w", id, err)
+		}
+	}
+	return n, nil
+}
+
+// revokeSessions 撤销匹配的会话，同时清理过期的会话和撤销记录。返回撤销的会话 ID 和过期时间
+func (st *state) revokeSessions(match func(*types.Session) bool) map[string]string {
+	now := time.Now().UTC()
+	st.pruneSessions(now)
+	revoked := make(map[string]string)
+	for id, se := range st.sessions {
+		if match(se) {
+			st.revoked[id] = se.Expires
+			revoked[id] = se.Expires
+			delete(st.sessions, id)
+		}
+	}
+	return revoked
+}
+
+// pruneSessions 过期的会话和撤销记录不再需要保留
+func (st *state) pruneSessions(now time.Time) {
+	ts := now.Format(time.RFC3339)
+	for id, se := range st.sessions {
+		if se.Expires <= ts {
+			delete(st.sessions, id)
+		}
+	}
+	for id, expires := range st.revoked {
+		if expires <= ts {
+			delete(st.revoked, id)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package access
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"plus/internal/types"
+)
+
+// 上传令牌的默认有效期和最长有效期
+const (
+	DefaultUploadTokenTTL    = time.Hour
+	DefaultUploadTokenMaxTTL = 24 * time.Hour
+)
+
+// SetUploadTokenMaxTTL 设置上传令牌的最长有效期
+func (s *Store) SetUploadTokenMaxTTL(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uploadMaxTTL = d
+}
+
+// CreateUploadToken 签发只能向 repo 上传的令牌。令牌不属于任何用户，过期或撤销前一直有效
+func (s *Store) CreateUploadToken(repo, name, createdBy string, ttl time.Duration) (*types.UploadToken, error) {
+	repo = strings.Trim(repo, "/")
+	if repo == "" {
+		return nil, fmt.Errorf("upload token requires a repository: %w", ErrInvalid)
+	}
+	if ttl <= 0 {
+		ttl = DefaultUploadTokenTTL
+	}
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := tokenPrefix + hex.EncodeToString(secret)
+	hash := hashToken(token)
+	now := time.Now().UTC()
+	record := types.UploadToken{
+		ID:        tokenID(hash),
+		Repo:      repo,
+		Name:      name,
+		Hash:      hash,
+		CreatedBy: createdBy,
+		Created:   now.Format(time.RFC3339),
+		Expires:   now.Add(ttl).Format(time.RFC3339),
+	}
+	err := s.update(func(st *state) error {
+		max := s.uploadMaxTTL
+		if max <= 0 {
+			max = DefaultUploadTokenMaxTTL
+		}
+		if ttl > max {
+			return fmt.Errorf("upload token ttl %s exceeds the maximum of %s: %w", ttl, max, ErrInvalid)
+		}
+		st.pruneUploads(now)
+		st.uploads[record.Hash] = &record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	view := record
+	view.Hash = ""
+	view.Token = token
+	return &view, nil
+}
+
+// UploadTokens 返回仓库未过期的上传令牌（不含令牌内容），按创建时间排序
+func (s *Store) UploadTokens(repo string) []types.UploadToken {
+	repo = strings.Trim(repo, "/")
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := time.Now().UTC().Format(time.RFC3339)
+	tokens := make([]types.UploadToken, 0)
+	for _, t := range s.state.uploads {
+		if t.Repo == repo && t.Expires > now {
+			view := *t
+			view.Hash = ""
+			tokens = append(tokens, view)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Created < tokens[j].Created })
+	return tokens
+}
+
+// DeleteUploadToken 撤销仓库的上传令牌
+func (s *Store) DeleteUploadToken(repo, id string) error {
+	repo = strings.Trim(repo, "/")
+	return s.update(func(st *state) error {
+		st.pruneUploads(time.Now().UTC())
+		for hash, t := range st.uploads {
+			if t.Repo == repo && t.ID == id {
+				delete(st.uploads, hash)
+				return nil
+			}
+		}
+		return fmt.Errorf("upload token %s of %s: %w", id, repo, ErrNotFound)
+	})
+}
+
+// uploadPrincipal 上传令牌只有这一个仓库的写权限
+func uploadPrincipal(t *types.UploadToken) (*Principal, bool) {
+	if t.Expires <= time.Now().UTC().Format(time.RFC3339) {
+		return nil, false
+	}
+	return &Principal{
+		Name:        "upload-token:" + t.ID,
+		Roles:       []string{RoleUploadToken},
+		Permissions: []types.Permission{{Repo: t.Repo, Actions: []string{ActionWrite}}},
+		Repo:        t.Repo,
+	}, true
+}
+
+func (st *state) pruneUploads(now time.Time) {
+	ts := now.Format(time.RFC3339)
+	for hash, t := range st.uploads {
+		if t.Expires <= ts {
+			delete(st.uploads, hash)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+)
+
+// 告警状态
+const (
+	StateFiring   = "firing"
+	StateResolved = "resolved"
+)
+
+// 告警名称
+const (
+	ErrorRate = "error-rate"
+	Storage   = "storage"
+	jobPrefix = "job/"
+)
+
+const (
+	defaultInterval    = time.Minute
+	defaultFor         = 5 * time.Minute
+	defaultMinRequests = 20
+)
+
+// Sources 检查告警使用的数据，为 nil 的来源不检查
+type Sources struct {
+	// Requests 返回启动以来的请求数和其中 5xx 响应数
+	Requests func() (total, serverErrors int64)
+	// Jobs 返回定时任务的状态
+	Jobs func() []types.JobStatus
+	// Storage 返回存储的用量和容量（字节）
+	Storage func() (used, capacity int64, err error)
+}
+
+// Notifier 发送告警通知
+type Notifier interface {
+	Notify(ctx context.Context, a types.Alert) error
+}
+
+// Manager 定期检查规则，告警触发和恢复时各通知一次
+type Manager struct {
+	node      string
+	src       Sources
+	notifiers []Notifier
+	now       func() time.Time
+
+	errorRate   float64
+	errorFor    time.Duration
+	minRequests int64
+	jobs        []string
+	storage     float64
+
+	mu     sync.Mutex
+	active map[string]*types.Alert
+
+	// 上一次检查时的请求计数
+	sampled    bool
+	lastCheck  time.Time
+	lastTotal  int64
+	lastErrors int64
+	badSince   time.Time // 错误率开始超过阈值的时间
+}
+
+// New 根据配置创建告警检查，返回检查间隔
+func New(cfg config.AlertsConfig, node string, src Sources) (*Manager, time.Duration, error) {
+	interval := defaultInterval
+	if cfg.Interval != "" {
+		d, err := time.ParseDuration(cfg.Interval)
+		if err != nil || d <= 0 {
+			return nil, 0, fmt.Errorf("invalid alerts interval %q", cfg.Interval)
+		}
+		interval = d
+	}
+	m := &Manager{
+		node:        node,
+		src:         src,
+		now:         time.Now,
+		errorRate:   cfg.ErrorRate.Threshold,
+		errorFor:    defaultFor,
+		minRequests: cfg.ErrorRate.MinRequests,
+		jobs:        cfg.Jobs,
+		storage:     cfg.Storage.Threshold,
+		active:      make(map[string]*types.Alert),
+	}
+	for _, t := range []struct {
+		name  string
+		value float64
+	}{{"error-rate threshold", m.errorRate}, {"storage threshold", m.storage}} {
+		if t.value < 0 || t.value > 1 {
+			return nil, 0, fmt.Errorf("alerts %s must be between 0 and 1, got %g", t.name, t.value)
+		}
+	}
+	if cfg.ErrorRate.For != "" {
+		d, err := time.ParseDuration(cfg.ErrorRate.For)
+		if err != nil || d < 0 {
+			return nil, 0, fmt.Errorf("invalid alerts error-rate for %q", cfg.ErrorRate.For)
+		}
+		m.errorFor = d
+	}
+	if m.minRequests <= 0 {
+		m.minRequests = defaultMinRequests
+	}
+	for _, pattern := range m.jobs {
+		if !utils.ValidGlob(pattern) {
+			return nil, 0, fmt.Errorf("invalid alerts job pattern %q", pattern)
+		}
+	}
+
+	if cfg.Webhook.URL != "" {
+		w, err := newWebhook(cfg.Webhook)
+		if err != nil {
+			return nil, 0, err
+		}
+		m.notifiers = append(m.notifiers, w)
+	}
+	if cfg.Email.SMTP != "" {
+		e, err := newEmail(cfg.Email)
+		if err != nil {
+			return nil, 0, err
+		}
+		m.notifiers = append(m.notifiers, e)
+	}
+	return m, interval, nil
+}
+
+// AddNotifier 增加告警通知方式，需在开始检查前调用
+func (m *Manager) AddNotifier(n Notifier) {
+	m.notifiers = append(m.notifiers, n)
+}
+
+// Active 返回正在触发的告警，按名称排序
+func (m *Manager) Active() []types.Alert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	alerts := make([]types.Alert, 0, len(m.active))
+	for _, a := range m.active {
+		alerts = append(alerts, *a)
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Name < alerts[j].Name })
+	return alerts
+}
+
+// Check 检查一次全部规则，并为状态变化的告警发送通知
+func (m *Manager) Check(ctx context.Context) error {
+	now := m.now()
+	var used, capacity int64
+	var storageErr error
+	if m.storage > 0 && m.src.Storage != nil {
+		used, capacity, storageErr = m.src.Storage()
+	}
+
+	var changed []types.Alert
+	m.mu.Lock()
+	if m.errorRate > 0 && m.src.Requests != nil {
+		changed = append(changed, m.checkErrorRate(now)...)
+	}
+	if len(m.jobs) > 0 && m.src.Jobs != nil {
+		changed = append(changed, m.checkJobs(now)...)
+	}
+	if m.storage > 0 && m.src.Storage != nil {
+		if storageErr != nil {
+			log.Logger.Debugf("Storage alert not checked: %v", storageErr)
+		} else {
+			changed = append(changed, m.checkStorage(now, used, capacity)...)
+		}
+	}
+	m.mu.Unlock()
+
+	var errs []error
+	for _, a := range changed {
+		if a.State == StateFiring {
+			log.Logger.Warnf("Alert %s firing: %s", a.Name, a.Message)
+		} else {
+			log.Logger.Infof("Alert %s resolved: %s", a.Name, a.Message)
+		}
+		for _, n := range m.notifiers {
+			if err := n.Notify(ctx, a); err != nil {
+				errs = append(errs, fmt.Errorf("notify %s: %w", a.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// set 更新告警状态，状态变化时返回要通知的告警。调用时持有锁
+func (m *Manager) set(now time.Time, name string, firing bool, message string, value, threshold float64) []types.Alert {
+	a, active := m.active[name]
+	switch {
+	case firing && !active:
+		a = &types.Alert{
+			Name:      name,
+			State:     StateFiring,
+			Message:   message,
+			Value:     value,
+			Threshold: threshold,
+			Node:      m.node,
+			Since:     now.UTC().Format(time.RFC3339),
+		}
+		m.active[name] = a
+		return []types.Alert{*a}
+	case firing:
+		a.Message, a.Value = message, value
+	case active:
+		delete(m.active, name)
+		resolved := *a
+		resolved.State = StateResolved
+		resolved.Message = message
+		resolved.Value = value
+		resolved.Resolved = now.UTC().Format(time.RFC3339)
+		return []types.Alert{resolved}
+	}
+	return nil
+}
+
+// checkErrorRate 按两次检查之间的请求计算 5xx 占比，请求太少时视为正常
+func (m *Manager) checkErrorRate(now time.Time) []types.Alert {
+	total, serverErrors := m.src.Requests()
+	last, lastTotal, lastErrors := m.lastCheck, m.lastTotal, m.lastErrors
+	m.lastCheck, m.lastTotal, m.lastErrors = now, total, serverErrors
+	if !m.sampled {
+		m.sampled = true
+		return nil
+	}
+
+	requests := total - lastTotal
+	var rate float64
+	if requests > 0 {
+		rate = float64(serverErrors-lastErrors) / float64(requests)
+	}
+	if requests < m.minRequests || rate < m.errorRate {
+		m.badSince = time.Time{}
+		return m.set(now, ErrorRate, false, fmt.Sprintf("5xx rate is %.1f%% of %d requests", rate*100, requests), rate, m.errorRate)
+	}
+	if m.badSince.IsZero() {
+		m.badSince = last
+	}
+	firing := now.Sub(m.badSince) >= m.errorFor
+	message := fmt.Sprintf("5xx rate is %.1f%% of %d requests since %s, threshold %.1f%%",
+		rate*100, requests, m.badSince.UTC().Format(time.RFC3339), m.errorRate*100)
+	return m.set(now, ErrorRate, firing, message, rate, m.errorRate)
+}
+
+// checkJobs 匹配的任务最近一次执行失败时告警，下一次成功后恢复
+func (m *Manager) checkJobs(now time.Time) []types.Alert {
+	var changed []types.Alert
+	seen := make(map[string]bool)
+	for _, j := range m.src.Jobs() {
+		if !m.watched(j.Name) {
+			continue
+		}
+		name := jobPrefix + j.Name
+		seen[name] = true
+		if j.LastError != "" {
+			changed = append(changed, m.set(now, name, true, fmt.Sprintf("Job %s failed: %s", j.Name, j.LastError), float64(j.Failures), 0)...)
+		} else if j.Runs > 0 {
+			changed = append(changed, m.set(now, name, false, fmt.Sprintf("Job %s succeeded", j.Name), 0, 0)...)
+		}
+	}
+	// 任务已删除（如仓库被删除）
+	for name := range m.active {
+		if strings.HasPrefix(name, jobPrefix) && !seen[name] {
+			changed = append(changed, m.set(now, name, false, fmt.Sprintf("Job %s was removed", strings.TrimPrefix(name, jobPrefix)), 0, 0)...)
+		}
+	}
+	return changed
+}
+
+func (m *Manager) watched(job string) bool {
+	for _, pattern := range m.jobs {
+		if utils.MatchGlob(pattern, job, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStorage 用量占容量的比例超过阈值时告警
+func (m *Manager) checkStorage(now time.Time, used, capacity int64) []types.Alert {
+	if capacity <= 0 {
+		return nil
+	}
+	ratio := float64(used) / float64(capacity)
+	message := fmt.Sprintf("Storage is %.1f%% full: %s of %s", ratio*100, utils.FormatFileSize(used), utils.FormatFileSize(capacity))
+	return m.set(now, Storage, ratio >= m.storage, message, ratio, m.storage)
+}
@@ -0,0 +1,169 @@
+package alert
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+// recorder 记录收到的通知
+type recorder struct {
+	mu     sync.Mutex
+	alerts []types.Alert
+}
+
+func (r *recorder) Notify(_ context.Context, a types.Alert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alerts = append(r.alerts, a)
+	return nil
+}
+
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, a := range r.alerts {
+		out = append(out, a.State+":"+a.Name)
+	}
+	r.alerts = nil
+	return out
+}
+
+func TestErrorRate(t *testing.T) {
+	var total, errs int64
+	m, _, err := New(config.AlertsConfig{ErrorRate: config.ErrorRateAlert{Threshold: 0.1, For: "2m"}}, "node-1",
+		Sources{Requests: func() (int64, int64) { return total, errs }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &recorder{}
+	m.notifiers = []Notifier{rec}
+	now := time.Unix(0, 0)
+	m.now = func() time.Time { return now }
+	step := func(requests, failures int64) []string {
+		total += requests
+		errs += failures
+		now = now.Add(time.Minute)
+		if err := m.Check(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return rec.take()
+	}
+
+	step(0, 0)
+	if got := step(100, 50); got != nil {
+		t.Errorf("fired after one bad minute: %v", got)
+	}
+	if got := step(100, 50); len(got) != 1 || got[0] != "firing:error-rate" {
+		t.Errorf("after two bad minutes = %v", got)
+	}
+	if got := step(100, 50); got != nil {
+		t.Errorf("fired twice: %v", got)
+	}
+	if a := m.Active(); len(a) != 1 || a[0].Node != "node-1" || a[0].Value != 0.5 {
+		t.Errorf("active = %+v", a)
+	}
+	// 请求太少不计算
+	if got := step(5, 5); len(got) != 1 || got[0] != "resolved:error-rate" {
+		t.Errorf("low traffic = %v", got)
+	}
+	step(100, 50)
+	if got := step(100, 5); got != nil {
+		t.Errorf("below threshold = %v", got)
+	}
+}
+
+func TestJobsAndStorage(t *testing.T) {
+	jobs := []types.JobStatus{
+		{Name: "repo-refresh/el/9", Runs: 1, LastError: "createrepo failed"},
+		{Name: "stats-save", Runs: 1, LastError: "disk full"},
+	}
+	used := int64(95)
+	m, _, err := New(config.AlertsConfig{
+		Jobs:    []string{"repo-refresh/**"},
+		Storage: config.StorageAlert{Threshold: 0.9},
+	}, "", Sources{
+		Jobs:    func() []types.JobStatus { return jobs },
+		Storage: func() (int64, int64, error) { return used, 100, nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &recorder{}
+	m.notifiers = []Notifier{rec}
+
+	m.Check(context.Background())
+	if got := strings.Join(rec.take(), " "); got != "firing:job/repo-refresh/el/9 firing:storage" {
+		t.Errorf("first check = %s", got)
+	}
+	jobs[0].LastError = ""
+	used = 50
+	m.Check(context.Background())
+	if got := strings.Join(rec.take(), " "); got != "resolved:job/repo-refresh/el/9 resolved:storage" {
+		t.Errorf("second check = %s", got)
+	}
+
+	// 任务删除后恢复
+	jobs[0].LastError = "failed again"
+	m.Check(context.Background())
+	jobs = jobs[1:]
+	m.Check(context.Background())
+	if got := strings.Join(rec.take(), " "); got != "firing:job/repo-refresh/el/9 resolved:job/repo-refresh/el/9" {
+		t.Errorf("removed job = %s", got)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		if r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	w, err := newWebhook(config.AlertWebhookConfig{URL: srv.URL, Headers: map[string]string{"X-Token": "secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Notify(context.Background(), types.Alert{Name: "storage", State: StateFiring, Message: "full"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, `"name":"storage"`) || !strings.Contains(body, `"state":"firing"`) {
+		t.Errorf("body = %s", body)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	for _, cfg := range []config.AlertsConfig{
+		{Interval: "soon"},
+		{ErrorRate: config.ErrorRateAlert{Threshold: 5}},
+		{Jobs: []string{"repo-[refresh"}},
+		{Email: config.AlertEmailConfig{SMTP: "mail.example.com"}},
+		{Email: config.AlertEmailConfig{SMTP: "mail.example.com:25"}},
+	} {
+		if _, _, err := New(cfg, "", Sources{}); err == nil {
+			t.Errorf("New(%+v) should fail", cfg)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package alert
+
+import "syscall"
+
+// DiskUsage 返回 path 所在文件系统的已用空间和容量（字节）
+func DiskUsage(path string) (used, capacity int64, err error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+	capacity = int64(st.Blocks) * int64(st.Bsize)
+	used = capacity - int64(st.Bfree)*int64(st.Bsize)
+	return used, capacity, nil
+}
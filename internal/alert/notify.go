@@ -0,0 +1,115 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/types"
+)
+
+const (
+	defaultWebhookTimeout = 10 * time.Second
+	webhookAttempts       = 3
+)
+
+// webhook 以 JSON POST 告警
+type webhook struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func newWebhook(cfg config.AlertWebhookConfig) (*webhook, error) {
+	timeout := defaultWebhookTimeout
+	if cfg.Timeout != "" {
+		d, err := time.ParseDuration(cfg.Timeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid alerts webhook timeout %q", cfg.Timeout)
+		}
+		timeout = d
+	}
+	return &webhook{url: cfg.URL, headers: cfg.Headers, client: &http.Client{Timeout: timeout}}, nil
+}
+
+// Notify 发送告警，失败时重试
+func (w *webhook) Notify(ctx context.Context, a types.Alert) error {
+	body, err := a.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		err = w.post(ctx, body)
+		if err == nil || attempt == webhookAttempts || ctx.Err() != nil {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+}
+
+func (w *webhook) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// email 通过 SMTP 发送告警邮件
+type email struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+}
+
+func newEmail(cfg config.AlertEmailConfig) (*email, error) {
+	host, _, err := net.SplitHostPort(cfg.SMTP)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alerts email smtp %q, expected host:port", cfg.SMTP)
+	}
+	if cfg.From == "" || len(cfg.To) == 0 {
+		return nil, fmt.Errorf("alerts email requires from and to")
+	}
+	e := &email{addr: cfg.SMTP, from: cfg.From, to: cfg.To}
+	if cfg.Username != "" {
+		e.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+	return e, nil
+}
+
+// Notify 发送一封纯文本邮件
+func (e *email) Notify(_ context.Context, a types.Alert) error {
+	subject := fmt.Sprintf("[plus] %s: %s", strings.ToUpper(a.State), a.Name)
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\r\n\r\n", a.Message)
+	fmt.Fprintf(&body, "Alert: %s\r\nState: %s\r\nSince: %s\r\n", a.Name, a.State, a.Since)
+	if a.Resolved != "" {
+		fmt.Fprintf(&body, "Resolved: %s\r\n", a.Resolved)
+	}
+	if a.Node != "" {
+		fmt.Fprintf(&body, "Node: %s\r\n", a.Node)
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s",
+		e.from, strings.Join(e.to, ", "), subject, time.Now().Format(time.RFC1123Z), body.String())
+	return smtp.SendMail(e.addr, e.auth, e.from, e.to, []byte(msg))
+}
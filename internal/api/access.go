@@ -0,0 +1,629 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"plus/internal/access"
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/types"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetAccess 设置用户库，启用认证时按仓库检查权限
+func (h *API) SetAccess(s *access.Store) {
+	h.access = s
+}
+
+// SetPolicy 设置 auth.policy 访问策略
+func (h *API) SetPolicy(p *access.Policy) {
+	h.policy = p
+}
+
+// principal 返回请求携带的身份。支持 Bearer 令牌和 Basic 认证（用户名 + 令牌，供 dnf/apt 使用）；
+// auth.token 拥有全部权限，其他令牌在用户库中查找
+func (h *API) principal(ctx *fasthttp.RequestCtx) (*access.Principal, bool) {
+	header := string(ctx.Request.Header.Peek("Authorization"))
+	var user, token string
+	switch {
+	case strings.HasPrefix(header, "Bearer "):
+		token = strings.TrimPrefix(header, "Bearer ")
+	case strings.HasPrefix(header, "Basic "):
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(header, "Basic "))
+		if err != nil {
+			return nil, false
+		}
+		user, token, _ = strings.Cut(string(decoded), ":")
+	}
+	if token == "" {
+		return nil, false
+	}
+	if t := h.config.Auth.Token; t != "" && subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+		return access.Superuser("token"), true
+	}
+	if h.access != nil {
+		if p, ok := h.access.Authenticate(token); ok && (user == "" || user == p.Name) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// authorize 检查请求能否访问仓库的这类接口，不允许时写入 401/403 并返回 false；
+// 仓库处于维护状态时写入 503。暂存区和等待批准的上传不能直接访问，返回 404
+func (h *API) authorize(ctx *fasthttp.RequestCtx, repo string, class access.Class) bool {
+	if service.IsHiddenPath(repo) {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Not found", nil)
+		return false
+	}
+	return h.permit(ctx, repo, class) && h.available(ctx, repo)
+}
+
+// permit 启用认证时检查请求能否访问仓库的这类接口，不允许时写入 401/403 并返回 false。
+// auth.policy 中有对应规则时按规则判定；否则按角色权限检查（没有用户库时只有 auth.token），下载只在 require-read-auth 时检查
+func (h *API) permit(ctx *fasthttp.RequestCtx, repo string, class access.Class) bool {
+	if !h.config.Auth.Enabled {
+		return true
+	}
+	p, _ := h.principal(ctx)
+	allowed := true
+	switch h.policy.Evaluate(class, repo, p) {
+	case access.Deny:
+		allowed = false
+	case access.Default:
+		if class == access.ClassDownload && !h.config.Auth.RequireReadAuth {
+			return true
+		}
+		allowed = p != nil && p.Can(repo, class.Action())
+	}
+	// 上传令牌只能访问自己的仓库
+	if allowed && p != nil && p.Repo != "" {
+		allowed = p.Can(repo, class.Action())
+	}
+	if !allowed {
+		h.deny(ctx, p, fmt.Sprintf("%s is not allowed to %s %s", principalName(p), class, repo))
+	}
+	return allowed
+}
+
+// requireAdmin 启用认证时要求 auth.token 或 admin 角色，auth.policy 中 * 的 admin 规则优先
+func (h *API) requireAdmin(ctx *fasthttp.RequestCtx) bool {
+	p, allowed := h.isAdmin(ctx)
+	if !allowed {
+		h.deny(ctx, p, "Administrator role required")
+	}
+	return allowed
+}
+
+// isAdmin 与 requireAdmin 相同，但不写入响应
+func (h *API) isAdmin(ctx *fasthttp.RequestCtx) (*access.Principal, bool) {
+	if !h.config.Auth.Enabled {
+		return nil, true
+	}
+	p, _ := h.principal(ctx)
+	switch h.policy.Evaluate(access.ClassAdmin, "", p) {
+	case access.Allow:
+		return p, p == nil || p.Repo == ""
+	case access.Default:
+		return p, p != nil && p.Admin
+	}
+	return p, false
+}
+
+// requireCredentials 中间件 auth：启用认证时在路由之前拒绝没有有效凭据的请求，
+// 读请求只在 require-read-auth 时检查。仓库和角色权限仍由各接口检查
+func (h *API) requireCredentials(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if h.config.Auth.Enabled && !ctx.IsOptions() {
+			if read := ctx.IsGet() || ctx.IsHead(); !read || h.config.Auth.RequireReadAuth {
+				if _, ok := h.principal(ctx); !ok {
+					h.unauthorized(ctx)
+					return
+				}
+			}
+		}
+		next(ctx)
+	}
+}
+
+// deny 未认证时返回 401，否则返回 403
+func (h *API) deny(ctx *fasthttp.RequestCtx, p *access.Principal, message string) {
+	if p == nil {
+		h.unauthorized(ctx)
+		return
+	}
+	h.sendJSONError(ctx, message, fasthttp.StatusForbidden)
+}
+
+func principalName(p *access.Principal) string {
+	if p == nil {
+		return "anonymous"
+	}
+	return p.Name
+}
+
+func (h *API) unauthorized(ctx *fasthttp.RequestCtx) {
+	ctx.Response.Header.Set("WWW-Authenticate", `Basic realm="plus"`)
+	h.sendJSONError(ctx, "Authorization required", fasthttp.StatusUnauthorized)
+}
+
+// actor 返回请求者名称，用于审计日志
+func (h *API) actor(ctx *fasthttp.RequestCtx) string {
+	p, _ := h.principal(ctx)
+	return principalName(p)
+}
+
+// routeClass 仓库端点所属的接口类别
+func routeClass(pattern, method string) access.Class {
+	switch {
+	// 暂存区的内容尚未发布，查看也需要上传权限
+	case pattern == "staging" && method != "DELETE":
+		return access.ClassUpload
+	case method == "GET" || method == "HEAD" || pattern == "archive":
+		return access.ClassDownload
+	case method == "DELETE":
+		return access.ClassDelete
+	case pattern == "refresh" || pattern == "publish":
+		return access.ClassRefresh
+	}
+	return access.ClassUpload
+}
+
+// WhoAmI 当前请求的身份: GET /api/v1/whoami
+func (h *API) WhoAmI(ctx *fasthttp.RequestCtx) {
+	identity := &types.Identity{Permissions: []types.Permission{}}
+	p, ok := h.principal(ctx)
+	if ok {
+		identity.Authenticated = true
+		identity.Name = p.Name
+	} else if !h.config.Auth.Enabled {
+		// 未启用认证时任何请求都拥有全部权限
+		p = access.Superuser("")
+	}
+	if p != nil {
+		identity.Admin = p.Admin
+		identity.Permissions = append(identity.Permissions, p.Permissions...)
+	}
+	h.sendJSONResponse(ctx, identity, fasthttp.StatusOK)
+}
+
+// accessEnabled 未启用用户库时返回 404
+func (h *API) accessEnabled(ctx *fasthttp.RequestCtx) bool {
+	if h.access == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "User management is not enabled", nil)
+		return false
+	}
+	return h.requireAdmin(ctx)
+}
+
+func (h *API) sendAccessError(ctx *fasthttp.RequestCtx, err error) {
+	status := fasthttp.StatusInternalServerError
+	switch {
+	case errors.Is(err, access.ErrNotFound):
+		status = fasthttp.StatusNotFound
+	case errors.Is(err, access.ErrExists), errors.Is(err, access.ErrBuiltin):
+		status = fasthttp.StatusConflict
+	case errors.Is(err, access.ErrInvalid):
+		status = fasthttp.StatusBadRequest
+	}
+	h.sendJSONError(ctx, err.Error(), status)
+}
+
+// ListUsers 用户列表: GET /api/v1/users
+func (h *API) ListUsers(ctx *fasthttp.RequestCtx) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	h.sendJSONResponse(ctx, &types.UserList{Users: h.access.Users()}, fasthttp.StatusOK)
+}
+
+// GetUser 用户详情: GET /api/v1/users/{name}
+func (h *API) GetUser(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	u, err := h.access.User(name)
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	h.sendJSONResponse(ctx, u, fasthttp.StatusOK)
+}
+
+// PutUser 创建（POST /api/v1/users）或替换（PUT /api/v1/users/{name}）用户
+func (h *API) PutUser(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	u := types.User{}
+	if err := u.UnmarshalJSON(ctx.PostBody()); err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+		return
+	}
+	var err error
+	if name == "" {
+		err = h.access.CreateUser(u)
+	} else {
+		u.Name = name
+		err = h.access.UpdateUser(u)
+	}
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("User %s saved by %s", u.Name, h.actor(ctx))
+	h.GetUser(ctx, u.Name)
+}
+
+// DeleteUser 删除用户: DELETE /api/v1/users/{name}
+func (h *API) DeleteUser(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	if err := h.access.DeleteUser(name); err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("User %s deleted by %s", name, h.actor(ctx))
+	h.sendSuccess(ctx, "User deleted")
+}
+
+// CreateUserToken 创建令牌: POST /api/v1/users/{name}/tokens，令牌只在响应中出现一次
+func (h *API) CreateUserToken(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	req := types.UserToken{}
+	if body := ctx.PostBody(); len(body) > 0 {
+		if err := req.UnmarshalJSON(body); err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+			return
+		}
+	}
+	token, err := h.access.CreateToken(name, req.Name)
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Token %s for user %s created by %s", token.ID, name, h.actor(ctx))
+	h.sendJSONResponse(ctx, token, fasthttp.StatusCreated)
+}
+
+// DeleteUserToken 撤销令牌: DELETE /api/v1/users/{name}/tokens/{id}
+func (h *API) DeleteUserToken(ctx *fasthttp.RequestCtx, name, id string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	if err := h.access.DeleteToken(name, id); err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Token %s of user %s revoked by %s", id, name, h.actor(ctx))
+	h.sendSuccess(ctx, "Token revoked")
+}
+
+// ImportUsers 批量导入: POST /api/v1/users/import，请求体为 CSV
+func (h *API) ImportUsers(ctx *fasthttp.RequestCtx) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	result, err := h.access.ImportCSV(strings.NewReader(string(ctx.PostBody())))
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Users imported by %s: %d created, %d updated", h.actor(ctx), result.Created, result.Updated)
+	h.sendJSONResponse(ctx, result, fasthttp.StatusOK)
+}
+
+// SyncUsers 立即从 LDAP 同步: POST /api/v1/users/sync
+func (h *API) SyncUsers(ctx *fasthttp.RequestCtx) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	if !h.access.LDAPEnabled() {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "LDAP sync is not configured", nil)
+		return
+	}
+	result, err := h.access.SyncLDAP(ctx)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusBadGateway, apierr.CodeUpstreamFailed, "LDAP sync failed", err)
+		return
+	}
+	log.Logger.Infof("LDAP sync by %s: %d created, %d updated, %d disabled", h.actor(ctx), result.Created, result.Updated, result.Disabled)
+	h.sendJSONResponse(ctx, result, fasthttp.StatusOK)
+}
+
+// ListGroups 用户组列表: GET /api/v1/groups
+func (h *API) ListGroups(ctx *fasthttp.RequestCtx) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	h.sendJSONResponse(ctx, &types.GroupList{Groups: h.access.Groups()}, fasthttp.StatusOK)
+}
+
+// GetGroup 用户组详情: GET /api/v1/groups/{name}
+func (h *API) GetGroup(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	g, err := h.access.Group(name)
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	h.sendJSONResponse(ctx, g, fasthttp.StatusOK)
+}
+
+// PutGroup 创建（POST /api/v1/groups）或替换（PUT /api/v1/groups/{name}）用户组
+func (h *API) PutGroup(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	g := types.Group{}
+	if err := g.UnmarshalJSON(ctx.PostBody()); err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+		return
+	}
+	if name != "" {
+		g.Name = name
+	}
+	if err := h.access.PutGroup(g, name == ""); err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Group %s saved by %s", g.Name, h.actor(ctx))
+	h.GetGroup(ctx, g.Name)
+}
+
+// DeleteGroup 删除用户组: DELETE /api/v1/groups/{name}
+func (h *API) DeleteGroup(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	if err := h.access.DeleteGroup(name); err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Group %s deleted by %s", name, h.actor(ctx))
+	h.sendSuccess(ctx, "Group deleted")
+}
+
+// ListRoles 角色列表: GET /api/v1/roles
+func (h *API) ListRoles(ctx *fasthttp.RequestCtx) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	h.sendJSONResponse(ctx, &types.RoleList{Roles: h.access.Roles()}, fasthttp.StatusOK)
+}
+
+// GetRole 角色详情: GET /api/v1/roles/{name}
+func (h *API) GetRole(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	r, err := h.access.Role(name)
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	h.sendJSONResponse(ctx, r, fasthttp.StatusOK)
+}
+
+// PutRole 创建（POST /api/v1/roles）或替换（PUT /api/v1/roles/{name}）角色
+func (h *API) PutRole(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	r := types.Role{}
+	if err := r.UnmarshalJSON(ctx.PostBody()); err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+		return
+	}
+	if name != "" {
+		r.Name = name
+	}
+	if err := h.access.PutRole(r, name == ""); err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Role %s saved by %s", r.Name, h.actor(ctx))
+	h.GetRole(ctx, r.Name)
+}
+
+// DeleteRole 删除角色: DELETE /api/v1/roles/{name}
+func (h *API) DeleteRole(ctx *fasthttp.RequestCtx, name string) {
+	if !h.accessEnabled(ctx) {
+		return
+	}
+	if err := h.access.DeleteRole(name); err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Role %s deleted by %s", name, h.actor(ctx))
+	h.sendSuccess(ctx, "Role deleted")
+}
+
+// sessionPrincipal 会话接口要求启用用户库并携带有效令牌
+func (h *API) sessionPrincipal(ctx *fasthttp.RequestCtx) (*access.Principal, bool) {
+	if h.access == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "User management is not enabled", nil)
+		return nil, false
+	}
+	p, ok := h.principal(ctx)
+	if !ok {
+		h.unauthorized(ctx)
+		return nil, false
+	}
+	return p, true
+}
+
+// CreateSession 用用户令牌换取短期会话: POST /api/v1/sessions
+func (h *API) CreateSession(ctx *fasthttp.RequestCtx) {
+	p, ok := h.sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	session, err := h.access.CreateSession(p, ctx.RemoteIP().String(), string(ctx.UserAgent()))
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Session %s created for %s from %s", session.ID, p.Name, session.Address)
+	h.sendJSONResponse(ctx, session, fasthttp.StatusCreated)
+}
+
+// ListSessions 未过期的会话: GET /api/v1/sessions?user={name}。
+// 普通用户只能查看自己的会话，管理员不指定用户时返回全部
+func (h *API) ListSessions(ctx *fasthttp.RequestCtx) {
+	p, ok := h.sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	user := string(ctx.QueryArgs().Peek("user"))
+	if user == "" && !p.Admin {
+		user = p.Name
+	}
+	if user != p.Name && !p.Admin {
+		h.sendJSONError(ctx, "Administrator role required", fasthttp.StatusForbidden)
+		return
+	}
+	sessions, err := h.access.Sessions(user)
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	h.sendJSONResponse(ctx, &types.SessionList{Sessions: sessions}, fasthttp.StatusOK)
+}
+
+// RevokeSession 撤销会话: DELETE /api/v1/sessions/{id}，普通用户只能撤销自己的会话
+func (h *API) RevokeSession(ctx *fasthttp.RequestCtx, id string) {
+	p, ok := h.sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	session, err := h.access.Session(id)
+	if err == nil && session.User != p.Name && !p.Admin {
+		err = fmt.Errorf("session %s: %w", id, access.ErrNotFound)
+	}
+	if err == nil {
+		err = h.access.RevokeSession(id)
+	}
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Session %s of %s revoked by %s", id, session.User, p.Name)
+	h.sendSuccess(ctx, "Session revoked")
+}
+
+// RevokeSessions 撤销用户的全部会话: DELETE /api/v1/sessions?user={name}，默认为当前用户
+func (h *API) RevokeSessions(ctx *fasthttp.RequestCtx) {
+	p, ok := h.sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	user := string(ctx.QueryArgs().Peek("user"))
+	if user == "" {
+		user = p.Name
+	}
+	if user != p.Name && !p.Admin {
+		h.sendJSONError(ctx, "Administrator role required", fasthttp.StatusForbidden)
+		return
+	}
+	n, err := h.access.RevokeSessions(user)
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("%d sessions of %s revoked by %s", n, user, p.Name)
+	h.sendSuccess(ctx, fmt.Sprintf("%d sessions revoked", n))
+}
+
+// repoAdmin 要求对仓库有 admin 权限（或管理员角色），auth.policy 中的 admin 规则优先
+func (h *API) repoAdmin(ctx *fasthttp.RequestCtx, repo string) (*access.Principal, bool) {
+	p, ok := h.sessionPrincipal(ctx)
+	if !ok {
+		return nil, false
+	}
+	allowed := false
+	switch h.policy.Evaluate(access.ClassAdmin, repo, p) {
+	case access.Allow:
+		allowed = p.Repo == ""
+	case access.Default:
+		allowed = p.Admin || p.Can(repo, access.ActionAdmin)
+	}
+	if !allowed {
+		h.sendJSONError(ctx, fmt.Sprintf("%s is not allowed to administer %s", p.Name, repo), fasthttp.StatusForbidden)
+		return nil, false
+	}
+	return p, true
+}
+
+// CreateUploadToken 签发只能向一个仓库上传的限时令牌: POST /api/v1/repos/{repo}/upload-tokens
+func (h *API) CreateUploadToken(ctx *fasthttp.RequestCtx, repo string) {
+	repo = strings.Trim(repo, "/")
+	if !utils.IsValidRepoName(repo) {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Invalid repository path", nil)
+		return
+	}
+	p, ok := h.repoAdmin(ctx, repo)
+	if !ok {
+		return
+	}
+	req := types.UploadTokenRequest{}
+	if body := ctx.PostBody(); len(body) > 0 {
+		if err := req.UnmarshalJSON(body); err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+			return
+		}
+	}
+	var ttl time.Duration
+	if req.TTL != "" {
+		d, err := time.ParseDuration(req.TTL)
+		if err != nil || d <= 0 {
+			h.sendJSONError(ctx, fmt.Sprintf("Invalid ttl %q", req.TTL), fasthttp.StatusBadRequest)
+			return
+		}
+		ttl = d
+	}
+	token, err := h.access.CreateUploadToken(repo, req.Name, p.Name, ttl)
+	if err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Upload token %s for %s created by %s, expires %s", token.ID, repo, p.Name, token.Expires)
+	h.sendJSONResponse(ctx, token, fasthttp.StatusCreated)
+}
+
+// ListUploadTokens 仓库未过期的上传令牌: GET /api/v1/repos/{repo}/upload-tokens
+func (h *API) ListUploadTokens(ctx *fasthttp.RequestCtx, repo string) {
+	repo = strings.Trim(repo, "/")
+	if _, ok := h.repoAdmin(ctx, repo); !ok {
+		return
+	}
+	h.sendJSONResponse(ctx, &types.UploadTokenList{Tokens: h.access.UploadTokens(repo)}, fasthttp.StatusOK)
+}
+
+// DeleteUploadToken 撤销上传令牌: DELETE /api/v1/repos/{repo}/upload-tokens/{id}
+func (h *API) DeleteUploadToken(ctx *fasthttp.RequestCtx, repo, id string) {
+	repo = strings.Trim(repo, "/")
+	p, ok := h.repoAdmin(ctx, repo)
+	if !ok {
+		return
+	}
+	if err := h.access.DeleteUploadToken(repo, id); err != nil {
+		h.sendAccessError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Upload token %s for %s revoked by %s", id, repo, p.Name)
+	h.sendSuccess(ctx, "Upload token revoked")
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"plus/internal/access"
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/types"
+	"plus/pkg/repo/files"
+	"plus/pkg/storage/local"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+)
+
+func TestTokenOnlyAuth(t *testing.T) {
+	log.Logger = zap.NewNop().Sugar()
+	store, _ := local.NewLocalStorage(t.TempDir())
+	s := service.NewRepoService(files.NewFilesRepo(store))
+	if err := s.SetRepoType(context.Background(), "docs", "files"); err != nil {
+		t.Fatal(err)
+	}
+	// 只配置 auth.token，没有用户库
+	cfg := &config.Config{StoragePath: t.TempDir(), Auth: config.AuthConfig{Enabled: true, Token: "secret"}}
+	handler := SetupRouter(NewAPI(s, cfg))
+
+	request := func(method, uri, token string) int {
+		var ctx fasthttp.RequestCtx
+		ctx.Request.Header.SetMethod(method)
+		ctx.Request.SetRequestURI(uri)
+		if token != "" {
+			ctx.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		handler(&ctx)
+		return ctx.Response.StatusCode()
+	}
+
+	for _, tc := range []struct {
+		method, uri string
+	}{
+		{"POST", "/repo/docs/upload"},
+		{"POST", "/repo/docs/refresh"},
+		{"GET", "/repo/docs/staging"},
+	} {
+		if code := request(tc.method, tc.uri, ""); code != fasthttp.StatusUnauthorized {
+			t.Errorf("%s %s without credentials = %d, expected 401", tc.method, tc.uri, code)
+		}
+		if code := request(tc.method, tc.uri, "wrong"); code != fasthttp.StatusUnauthorized {
+			t.Errorf("%s %s with a wrong token = %d, expected 401", tc.method, tc.uri, code)
+		}
+	}
+	// auth.token 通过认证，上传请求没有文件
+	if code := request("POST", "/repo/docs/upload", "secret"); code == fasthttp.StatusUnauthorized || code == fasthttp.StatusForbidden {
+		t.Errorf("upload with auth.token = %d", code)
+	}
+	// 没有 require-read-auth 时下载不需要认证
+	if code := request("GET", "/repo/docs", ""); code == fasthttp.StatusUnauthorized {
+		t.Errorf("repository info without credentials = %d", code)
+	}
+}
+
+func TestLegalHoldRequiresAdmin(t *testing.T) {
+	log.Logger = zap.NewNop().Sugar()
+	store, err := local.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := service.NewRepoService(files.NewFilesRepo(store))
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "docs", "files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "docs", "app.tar.gz", strings.NewReader("x")); err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := access.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	maintainer := types.Role{Name: "maintainer", Permissions: []types.Permission{
+		{Repo: "*", Actions: []string{access.ActionRead, access.ActionWrite, access.ActionDelete}},
+	}}
+	if err := users.PutRole(maintainer, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.CreateUser(types.User{Name: "bob", Roles: []string{"maintainer"}}); err != nil {
+		t.Fatal(err)
+	}
+	token, err := users.CreateToken("bob", "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{StoragePath: t.TempDir(), Auth: config.AuthConfig{Enabled: true, Token: "secret"}}
+	h := NewAPI(s, cfg)
+	h.SetAccess(users)
+	handler := SetupRouter(h)
+
+	request := func(method, token string) int {
+		var ctx fasthttp.RequestCtx
+		ctx.Request.Header.SetMethod(method)
+		ctx.Request.SetRequestURI("/api/v1/repos/docs/holds/app.tar.gz")
+		ctx.Request.Header.Set("Authorization", "Bearer "+token)
+		handler(&ctx)
+		return ctx.Response.StatusCode()
+	}
+
+	// 有上传和删除权限但不是管理员，不能放置或解除保留标记
+	for _, method := range []string{"PUT", "DELETE"} {
+		if code := request(method, token.Token); code != fasthttp.StatusForbidden {
+			t.Errorf("%s by a maintainer = %d, expected 403", method, code)
+		}
+	}
+	if code := request("PUT", "secret"); code != fasthttp.StatusOK {
+		t.Errorf("PUT by an admin = %d", code)
+	}
+	if code := request("GET", token.Token); code != fasthttp.StatusOK {
+		t.Errorf("GET by a maintainer = %d", code)
+	}
+	if code := request("DELETE", "secret"); code != fasthttp.StatusOK {
+		t.Errorf("DELETE by an admin = %d", code)
+	}
+}
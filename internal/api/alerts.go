@@ -0,0 +1,26 @@
+package api
+
+import (
+	"plus/internal/alert"
+	"plus/internal/apierr"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetAlerts 设置告警检查，用于列出正在触发的告警
+func (h *API) SetAlerts(a *alert.Manager) {
+	h.alerts = a
+}
+
+// ListAlerts 正在触发的告警: GET /api/v1/alerts
+func (h *API) ListAlerts(ctx *fasthttp.RequestCtx) {
+	if h.alerts == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "Alerts are not enabled", nil)
+		return
+	}
+	h.sendJSONResponse(ctx, &types.AlertList{
+		Status: types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Alerts: h.alerts.Active(),
+	}, fasthttp.StatusOK)
+}
@@ -6,21 +6,47 @@ import (
 	"io"
 	"io/fs"
 
-	"mime/multipart"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"plus/assets"
+	"plus/internal/access"
+	"plus/internal/alert"
+	"plus/internal/apierr"
+	"plus/internal/attest"
+	"plus/internal/approval"
+	"plus/internal/forwarded"
+	"plus/internal/authz"
+	"plus/internal/branding"
+	"plus/internal/cache"
+	"plus/internal/chat"
+	"plus/internal/delivery"
+	"plus/internal/eventlog"
+	"plus/internal/feature"
 	"plus/internal/config"
+	"plus/internal/connlimit"
+	"plus/internal/i18n"
 	"plus/internal/log"
+	"plus/internal/metalink"
 	"plus/internal/metrics"
 	"plus/internal/middleware"
+	"plus/internal/notify"
+	"plus/internal/progress"
+	"plus/internal/proxy"
+	"plus/internal/scheduler"
 	"plus/internal/service"
+	"plus/internal/signing"
+	"plus/internal/spool"
+	"plus/internal/throttle"
 	"plus/internal/types"
 	"plus/internal/utils"
+	"plus/pkg/storage/breaker"
+	"plus/pkg/storage/compress"
+	"plus/pkg/storage/replicated"
 
 	"github.com/valyala/fasthttp"
 )
@@ -28,12 +54,54 @@ import (
 type API struct {
 	repoService *service.RepoService
 	config      *config.Config
+	keyring     *signing.Keyring
+	proxy       *proxy.Manager
+	throttle    *throttle.Throttle
+	connLimit   *connlimit.Limiter
+	readCache   *cache.DiskCache
+	metaCache   *cache.MetadataCache
+	breaker     *breaker.Breaker
+	compressor  *compress.Compressor
+	replicator  *replicated.Replicator
+	stats       *metrics.Store
+	scheduler   *scheduler.Scheduler
+	access      *access.Store
+	policy      *access.Policy
+	authz       *authz.Webhook
+	approvals   *approval.Store
+	draining    int64 // 开始排空的时间（UnixNano），0 表示未排空
+	listeners   []types.ListenerInfo
+	forwarded   *forwarded.Resolver // 为 nil 时按连接和 Host 头生成地址
+	spool       *spool.Spooler
+	uploads     *progress.Tracker
+	usage       *metrics.Usage
+	alerts      *alert.Manager
+	notifier    notify.Sender
+	chat        *chat.Store
+	events      *eventlog.Log
+	deliveries  *delivery.Queue
+
+	redirectExpires time.Duration // 为 0 时不重定向
+	redirectMinSize int64
+
+	metalink      *metalink.Options // 为 nil 时不提供 .meta4/.zsync
+	metalinkCache *cache.MetadataCache
+
+	thumbSize  int                  // 缩略图长边的默认像素数
+	thumbCache *cache.MetadataCache // 为 nil 时不提供缩略图
+
+	attestKeys *attest.Verifier
+
+	branding *branding.Branding // 为 nil 时页面不做定制
+	middleware *middleware.Chain // 为 nil 时按配置使用默认的中间件
+	features *feature.Set
 }
 
 func NewAPI(repoService *service.RepoService, config *config.Config) *API {
 	return &API{
 		repoService: repoService,
 		config:      config,
+		uploads:     progress.New(uploadProgressKeep),
 	}
 }
 
@@ -43,7 +111,7 @@ func (h *API) RefreshRepo(ctx *fasthttp.RequestCtx) {
 
 	// 移除 /repo/ 前缀和 /refresh 后缀
 	if !strings.HasPrefix(path, "/repo/") || !strings.HasSuffix(path, "/refresh") {
-		ctx.Error("Invalid refresh path", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Invalid refresh path", nil)
 		return
 	}
 
@@ -52,7 +120,7 @@ func (h *API) RefreshRepo(ctx *fasthttp.RequestCtx) {
 	repoPath = strings.TrimSuffix(repoPath, "/refresh")
 
 	if repoPath == "" {
-		ctx.Error("Repository path is required", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Repository path is required", nil)
 		return
 	}
 	
@@ -62,28 +130,38 @@ func (h *API) RefreshRepo(ctx *fasthttp.RequestCtx) {
 	repoType, err := h.repoService.GetRepoType(ctx, repoPath)
 	if err != nil {
 		log.Logger.Debugf("Failed to get repository type for %s: %v", repoPath, err)
-		h.sendJSONError(ctx, "Repository not found", fasthttp.StatusNotFound)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
 		return
 	}
 
 	// Files 类型仓库不需要刷新元数据
 	if repoType == "files" {
 		log.Logger.Debugf("Repository %s is files type, no metadata refresh needed", repoPath)
-		h.sendJSONError(ctx, "Files repositories do not require metadata refresh", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, "Files repositories do not require metadata refresh", nil)
+		return
+	}
+
+	if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpRefresh, Repo: repoPath, RepoType: repoType}) {
+		return
+	}
+
+	// 代理仓库从上游同步并校验元数据
+	if h.proxy != nil && h.proxy.IsProxy(repoPath) {
+		h.syncUpstream(ctx, repoPath)
 		return
 	}
 
 	err = h.repoService.RefreshMetadata(ctx, repoPath)
 	if err != nil {
 		log.Logger.Debugf("Refresh metadata failed for repo %s: %v", repoPath, err)
-		h.sendJSONError(ctx, fmt.Sprintf("Refresh failed: %v", err), fasthttp.StatusInternalServerError)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Refresh failed", err)
 		return
 	}
 
 	response := &types.RepoStatus{
 		Status: types.Status{
 			Status:  "success",
-			Message: "Repository metadata refreshed successfully",
+			Message: h.localize(ctx, "Repository metadata refreshed successfully"),
 		},
 		Repo: repoPath,
 	}
@@ -103,44 +181,130 @@ func (h *API) sendJSONResponse(ctx *fasthttp.RequestCtx, data io.WriterTo, statu
 	}
 }
 
-// 发送 JSON 错误响应
+// 发送错误响应，错误码按 HTTP 状态取默认值
 func (h *API) sendJSONError(ctx *fasthttp.RequestCtx, message string, statusCode int) {
-	response := types.Status{
-		Status:  "error",
-		Message: message,
-		Code:    statusCode,
-	}
-
-	ctx.Response.Header.Set("Content-Type", "application/json; charset=utf-8")
-	ctx.SetStatusCode(statusCode)
+	apierr.Write(ctx, statusCode, apierr.CodeFor(statusCode), message, "")
+}
 
-	if _, err := response.WriteTo(ctx); err != nil {
-		log.Logger.Debugf("Failed to encode JSON error response: %v", err)
-		ctx.SetBodyString(fmt.Sprintf(`{"status":"error","message":"%s"}`, message))
+// 发送带错误码的错误响应，err 不为空时作为详情返回
+func (h *API) sendError(ctx *fasthttp.RequestCtx, statusCode int, code, message string, err error) {
+	detail := ""
+	if err != nil {
+		detail = err.Error()
 	}
+	apierr.Write(ctx, statusCode, code, message, detail)
 }
 
 // 发送 JSON 成功响应（简化版）
 func (h *API) sendSuccess(ctx *fasthttp.RequestCtx, message string) {
 	response := &types.Status{
 		Status:  "success",
-		Message: message,
+		Message: h.localize(ctx, message),
 		Code:    fasthttp.StatusOK,
 	}
 
 	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
 }
 
+// localize 按 Accept-Language 翻译成功响应的消息，错误消息由 apierr.Write 翻译
+func (h *API) localize(ctx *fasthttp.RequestCtx, message string) string {
+	lang := i18n.FromRequest(ctx)
+	i18n.SetHeaders(ctx, lang)
+	return i18n.Message(lang, message)
+}
+
 func (h *API) Health(ctx *fasthttp.RequestCtx) {
-	response := &types.Status{
-		Status: "healthy",
-		Server: "plus",
+	response := &types.HealthCheck{
+		Status:    "healthy",
+		Server:    "plus",
+		Listeners: h.listeners,
 	}
 
 	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
 }
 
+// SetListeners 设置服务的监听地址，在 /health 中展示
+func (h *API) SetListeners(listeners []types.ListenerInfo) {
+	h.listeners = listeners
+}
+
+// SetBranding 设置服务生成的页面的定制
+func (h *API) SetBranding(b *branding.Branding) {
+	h.branding = b
+}
+
+// SetMiddleware 设置请求中间件的顺序和钩子，在 SetupRouter 之前调用
+func (h *API) SetMiddleware(c *middleware.Chain) {
+	h.middleware = c
+}
+
+// SetForwarded 设置可信代理和对外地址，用于生成绝对地址和页面链接
+func (h *API) SetForwarded(r *forwarded.Resolver) {
+	h.forwarded = r
+}
+
+// sendHTML 返回 HTML 页面，按配置定制并按 Accept-Language 翻译页面，
+// external-url 带路径前缀时为页面中以 / 开头的链接加上前缀
+func (h *API) sendHTML(ctx *fasthttp.RequestCtx, html string) {
+	// 先定制再翻译，自定义的标题和页脚不翻译
+	html = h.branding.Apply(html)
+	lang := i18n.FromRequest(ctx)
+	html = i18n.Page(lang, html)
+	i18n.SetHeaders(ctx, lang)
+	if prefix := h.forwarded.PathPrefix(); prefix != "" {
+		html = strings.NewReplacer(`href="/`, `href="`+prefix+`/`, `src="/`, `src="`+prefix+`/`, `fetch('/`, `fetch('`+prefix+`/`).Replace(html)
+	}
+	ctx.SetContentType("text/html; charset=utf-8")
+	ctx.SetBodyString(html)
+}
+
+// SetThrottle 设置带宽限制，用于在指标中展示吞吐量
+func (h *API) SetThrottle(t *throttle.Throttle) {
+	h.throttle = t
+}
+
+// SetConnLimit 设置单 IP 连接/下载数限制，用于在指标中展示
+func (h *API) SetConnLimit(l *connlimit.Limiter) {
+	h.connLimit = l
+}
+
+// SetReadCache 设置对象存储的磁盘读缓存，用于在指标中展示
+func (h *API) SetReadCache(c *cache.DiskCache) {
+	h.readCache = c
+}
+
+// SetCompression 设置对象存储压缩，用于在指标中展示压缩率
+func (h *API) SetCompression(c *compress.Compressor) {
+	h.compressor = c
+}
+
+// SetStats 设置跨重启累计的计数器，用于在指标中展示
+func (h *API) SetStats(s *metrics.Store) {
+	h.stats = s
+}
+
+// SetScheduler 设置后台任务调度器，用于任务列表和手动触发
+func (h *API) SetScheduler(s *scheduler.Scheduler) {
+	h.scheduler = s
+}
+
+// SetReplication 设置对象存储副本，用于在指标中展示各副本状态
+func (h *API) SetReplication(r *replicated.Replicator) {
+	h.replicator = r
+}
+
 func (h *API) Metrics(ctx *fasthttp.RequestCtx) {
+	// Prometheus 抓取时返回文本格式
+	if openMetrics, ok := textMetricsFormat(ctx); ok {
+		contentType := metrics.PrometheusContentType
+		if openMetrics {
+			contentType = metrics.OpenMetricsContentType
+		}
+		ctx.SetContentType(contentType)
+		metrics.WriteText(ctx, openMetrics)
+		return
+	}
+
 	m := metrics.GetMetrics()
 
 	var memStats runtime.MemStats
@@ -151,12 +315,14 @@ func (h *API) Metrics(ctx *fasthttp.RequestCtx) {
 			Total:     m.RequestCount,
 			Uploads:   m.UploadCount,
 			Downloads: m.DownloadCount,
+			Redirects: m.RedirectCount,
 			Errors:    m.ErrorCount,
 			Active:    m.ActiveRequests,
 		},
 		Performance: types.Performance{
 			ResponseTimeMs: m.ResponseTime,
 			Goroutines:     runtime.NumGoroutine(),
+			Routes:         metrics.RouteLatencies(),
 		},
 		Memory: types.Memory{
 			AllocMB:      memStats.Alloc / 1024 / 1024,
@@ -165,15 +331,95 @@ func (h *API) Metrics(ctx *fasthttp.RequestCtx) {
 			GCCycles:     memStats.NumGC,
 		},
 	}
+	if h.proxy != nil {
+		response.Upstreams = h.proxy.Metrics()
+	}
+	if h.throttle != nil {
+		response.Bandwidth = h.throttle.Metrics()
+	}
+	if h.connLimit != nil {
+		response.Connections = h.connLimit.Metrics()
+	}
+	if h.readCache != nil {
+		response.ReadCache = h.readCache.Metrics()
+	}
+	if h.metaCache != nil {
+		response.MetadataCache = h.metaCache.Metrics()
+	}
+	response.Workers = h.repoService.WorkerMetrics()
+	if h.breaker != nil {
+		response.StorageBreaker = h.breaker.Metrics()
+	}
+	if h.compressor != nil {
+		response.Compression = h.compressor.Metrics()
+	}
+	if h.replicator != nil {
+		response.Replication = h.replicator.Metrics()
+	}
+	response.Index = h.repoService.IndexMetrics()
+	response.Cluster = h.repoService.ClusterMetrics()
+	if h.stats != nil {
+		response.Lifetime = h.stats.Stats()
+	}
 
 	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
 }
 
+// textMetricsFormat 按 format 参数或 Accept 头判断是否返回文本格式，第一个返回值表示 OpenMetrics
+func textMetricsFormat(ctx *fasthttp.RequestCtx) (bool, bool) {
+	switch string(ctx.QueryArgs().Peek("format")) {
+	case "prometheus":
+		return false, true
+	case "openmetrics":
+		return true, true
+	case "json":
+		return false, false
+	}
+	accept := string(ctx.Request.Header.Peek("Accept"))
+	switch {
+	case strings.Contains(accept, "application/openmetrics-text"):
+		return true, true
+	case strings.HasPrefix(accept, "text/plain"):
+		return false, true
+	}
+	return false, false
+}
+
 func (h *API) Ready(ctx *fasthttp.RequestCtx) {
+	// 排空期间报告未就绪，负载均衡器停止转发新请求
+	if h.Draining() {
+		response := &types.ReadyCheck{
+			Status: types.Status{
+				Status: "draining",
+				Code:   fasthttp.StatusServiceUnavailable},
+			Checks: types.Checks{
+				Storage: "not checked",
+			},
+		}
+		h.sendJSONResponse(ctx, response, fasthttp.StatusServiceUnavailable)
+		return
+	}
+
+	// 对象存储熔断时仍可返回缓存的元数据，报告降级而不是继续访问故障的后端
+	if h.breaker != nil {
+		if state := h.breaker.State(); state != breaker.StateClosed {
+			response := &types.ReadyCheck{
+				Status: types.Status{
+					Status: "degraded"},
+				Checks: types.Checks{
+					Storage: "circuit " + state,
+				},
+			}
+			ctx.Response.Header.Set("Content-Type", "application/json")
+			response.WriteTo(ctx)
+			return
+		}
+	}
+
 	// 检查存储是否可用
 	_, err := h.repoService.ListRepos(ctx)
 	if err != nil {
-		ctx.Error("Service not ready", fasthttp.StatusServiceUnavailable)
+		h.sendJSONError(ctx, "Service not ready", fasthttp.StatusServiceUnavailable)
 		return
 	}
 
@@ -184,6 +430,13 @@ func (h *API) Ready(ctx *fasthttp.RequestCtx) {
 			Storage: "ok",
 		},
 	}
+	// 副本不可用时仍可读写，但已失去冗余
+	if h.replicator != nil {
+		if down := h.replicator.Unavailable(); len(down) > 0 {
+			response.Status.Status = "degraded"
+			response.Checks.Storage = "replicas unavailable: " + strings.Join(down, ", ")
+		}
+	}
 
 	ctx.Response.Header.Set("Content-Type", "application/json")
 	response.WriteTo(ctx)
@@ -194,10 +447,27 @@ func SetupRouter(h *API) fasthttp.RequestHandler {
 		"download_rpm": regexp.MustCompile(`^/repo/(.+)/rpm/([^/]+)$`),
 		"download_deb": regexp.MustCompile(`^/repo/(.+)/deb/([^/]+)$`),
 		"metadata":     regexp.MustCompile(`^/repo/(.+)/repodata/(.+)$`),
-		"deb_metadata": regexp.MustCompile(`^/repo/(.+)/(Packages|Packages\.gz|Release)$`),
+		"deb_metadata": regexp.MustCompile(`^/repo/(.+)/(Packages|Packages\.gz|Sources|Sources\.gz|Release|SHA256SUMS)$`),
 		"upload":       regexp.MustCompile(`^/repo/(.+)/upload$`),
 		"refresh":      regexp.MustCompile(`^/repo/(.+)/refresh$`),
+		"publish":      regexp.MustCompile(`^/repo/(.+)/publish$`),
+		"staging":      regexp.MustCompile(`^/repo/(.+)/staging$`),
+		"archive":      regexp.MustCompile(`^/repo/(.+)/archive$`),
 		"checksum":     regexp.MustCompile(`^/repo/(.+)/checksum/([^/]+)$`),
+		"gpg_key":      regexp.MustCompile(`^/repo/(.+)/gpg-key$`),
+		"client_config": regexp.MustCompile(`^/repo/(.+)/config$`),
+		"setup_script":  regexp.MustCompile(`^/repo/(.+)/setup\.sh$`),
+		"metadata_bundle": regexp.MustCompile(`^/repo/(.+)/metadata\.tar\.gz$`),
+		"tree_upload":   regexp.MustCompile(`^/repo/(.+)/tree/upload$`),
+		"tree_validate": regexp.MustCompile(`^/repo/(.+)/tree/validate$`),
+		"package_deps":  regexp.MustCompile(`^/repo/(.+)/package/([^/]+)/deps$`),
+		"package_rdeps": regexp.MustCompile(`^/repo/(.+)/package/([^/]+)/rdeps$`),
+		"attestations":  regexp.MustCompile(`^/repo/(.+)/package/([^/]+)/attestations$`),
+		"attestation":   regexp.MustCompile(`^/repo/(.+)/package/([^/]+)/attestations/([0-9a-f]{64})$`),
+		"signatures":    regexp.MustCompile(`^/repo/(.+)/package/([^/]+)/signatures$`),
+		"signature":     regexp.MustCompile(`^/repo/(.+)/package/([^/]+)/signatures/([0-9a-f]{64})$`),
+		"verify":        regexp.MustCompile(`^/repo/(.+)/package/([^/]+)/verify$`),
+		"thumb":         regexp.MustCompile(`^/repo/(.+?)/thumb/(.+)$`),
 		"repo_info":    regexp.MustCompile(`^/repo/([^/]+(?:/[^/]+)*)$`),
 		"repo_files":   regexp.MustCompile(`^/repo/(.+)/files/?(.*)$`),
 		"repo_browse":  regexp.MustCompile(`^/repo/(.+)/browse/?(.*)$`),
@@ -215,62 +485,75 @@ func SetupRouter(h *API) fasthttp.RequestHandler {
 		staticHandler = createEmbeddedStaticHandler()
 		log.Logger.Info("Using embedded static files (production mode)")
 	}
+	// branding.static-dir 中的文件优先
+	if dir := h.config.Branding.StaticDir; dir != "" {
+		staticHandler = createOverrideStaticHandler(dir, staticHandler)
+		log.Logger.Infof("Static files in %s override the built-in ones", dir)
+	}
 
 	repoHandler := createRepoHandler(h.config.StoragePath)
 
-	return middleware.CORSMiddleware(
-		middleware.LoggingMiddleware(
-			middleware.MetricsMiddleware(
-				func(ctx *fasthttp.RequestCtx) {
-					path := string(ctx.Path())
-					method := string(ctx.Method())
+	chain := h.middleware
+	if chain == nil {
+		chain = middleware.Default(h.config)
+	}
 
-					log.Logger.Debugf("🔍 Request: %s %s", method, path)
+	// 中间件的顺序和钩子按 middleware 配置，auth 由 API 提供
+	return chain.Handler(
+		h.recordUsage(func(ctx *fasthttp.RequestCtx) {
+			path := string(ctx.Path())
+			method := string(ctx.Method())
 
-					// 1. Web UI 静态文件服务
-					if method == "GET" && strings.HasPrefix(path, "/static/") {
-						handleWebStatic(ctx, staticHandler)
-						return
-					}
+			log.Logger.Debugf("🔍 Request: %s %s", method, path)
 
-					// 2. 根路径处理
-					if method == "GET" && path == "/" {
-						handleRootPath(ctx)
-						return
-					}
+			// 排空期间响应后关闭连接，客户端重连时由负载均衡器转到其他实例
+			if h.Draining() {
+				ctx.SetConnectionClose()
+			}
 
-					// 3. 仓库列表页面
-					if method == "GET" && path == "/repo/" {
-						handleRepoListPage(ctx, h)
-						return
-					}
+			// 1. Web UI 静态文件服务
+			if method == "GET" && strings.HasPrefix(path, "/static/") {
+				handleWebStatic(ctx, staticHandler)
+				return
+			}
 
-					// 4. API 端点
-					if handleAPIEndpoints(ctx, method, path, h) {
-						return
-					}
+			// 2. 根路径处理
+			if method == "GET" && path == "/" {
+				handleRootPath(ctx, h)
+				return
+			}
 
-					// 5. 仓库相关端点 - 优先匹配特定端点
-					if handleRepoEndpoints(ctx, method, h.config.StoragePath, path, patterns, h) {
-						return
-					}
+			// 3. 仓库列表页面
+			if method == "GET" && path == "/repo/" {
+				handleRepoListPage(ctx, h)
+				return
+			}
 
-					// 6. 直接路径浏览 - 只处理 files 类型仓库
-					if method == "GET" && h.handleDirectFileSystemAccess(ctx, path) {
-						return
-					}
+			// 4. API 端点
+			if handleAPIEndpoints(ctx, method, path, h) {
+				return
+			}
 
-					// 7. 仓库文件直接访问 - 最后匹配
-					if method == "GET" && strings.HasPrefix(path, "/repo/") {
-						if h.handleRepoFileAccess(ctx, repoHandler) {
-							return
-						}
-					}
+			// 5. 仓库相关端点 - 优先匹配特定端点
+			if handleRepoEndpoints(ctx, method, h.config.StoragePath, path, patterns, h) {
+				return
+			}
 
-					ctx.Error("Not Found", fasthttp.StatusNotFound)
-				},
-			),
-		),
+			// 6. 直接路径浏览 - 只处理 files 类型仓库
+			if method == "GET" && h.handleDirectFileSystemAccess(ctx, path) {
+				return
+			}
+
+			// 7. 仓库文件直接访问 - 最后匹配
+			if method == "GET" && strings.HasPrefix(path, "/repo/") {
+				if h.handleRepoFileAccess(ctx, repoHandler) {
+					return
+				}
+			}
+
+			h.sendJSONError(ctx, "Not Found", fasthttp.StatusNotFound)
+		}),
+		map[string]middleware.Func{middleware.Auth: h.requireCredentials},
 	)
 }
 
@@ -279,8 +562,12 @@ func (h *API) handleDirectFileSystemAccess(ctx *fasthttp.RequestCtx, path string
     if path == "/" || strings.HasPrefix(path, "/static/") || 
        strings.HasPrefix(path, "/health") ||
        strings.HasPrefix(path, "/ready") || 
+       strings.HasPrefix(path, "/live") ||
+       strings.HasPrefix(path, "/admin/") ||
        strings.HasPrefix(path, "/metrics") ||
        strings.HasPrefix(path, "/repos") ||
+       strings.HasPrefix(path, "/keys") ||
+       strings.HasPrefix(path, "/api/") ||
        strings.HasPrefix(path, "/repo/") { // 排除 /repo/ 开头的路径
         return false
     }
@@ -292,7 +579,34 @@ func (h *API) handleDirectFileSystemAccess(ctx *fasthttp.RequestCtx, path string
 
     log.Logger.Debugf("🔍 Direct filesystem access attempt: %s", cleanPath)
 
+    if !h.authorize(ctx, cleanPath, access.ClassDownload) {
+        return true
+    }
+
+    // 请求旧版本的元数据
+    if generation := generationParam(ctx); generation != "" && h.serveDirectGeneration(ctx, cleanPath, generation) {
+        return true
+    }
+
+    // 大文件的 metalink 和 zsync
+    if h.serveMetalink(ctx, cleanPath) {
+        return true
+    }
+
+    // 代理仓库：未缓存的文件先从上游拉取并校验
+    if h.proxy != nil {
+        if repoName, rel, ok := h.proxy.Match(cleanPath); ok && !h.fetchUpstream(ctx, repoName, rel) {
+            return true
+        }
+    }
+
+    // 静态网站模式的 files 仓库
+    if h.serveWebsite(ctx, cleanPath) {
+        return true
+    }
+
     // 🔥 新增：先尝试本地文件系统（保持原有性能）
+    cleanPath = h.localName("", cleanPath)
     fullPath := filepath.Join(h.config.StoragePath, cleanPath)
     
     if info, err := os.Stat(fullPath); err == nil {
@@ -302,8 +616,11 @@ func (h *API) handleDirectFileSystemAccess(ctx *fasthttp.RequestCtx, path string
             // 智能目录处理
             h.handleSmartDirectoryListing(ctx, cleanPath, fullPath)
         } else {
-            // 文件处理
-            h.handleDirectFileServe(ctx, cleanPath, fullPath)
+            h.linkMetalink(ctx, cleanPath, info.Size())
+            if !h.redirectDownload(ctx, cleanPath, info.Size()) {
+                // 文件处理
+                h.handleDirectFileServe(ctx, cleanPath, fullPath)
+            }
         }
         return true
     }
@@ -351,7 +668,15 @@ func (h *API) handleObjectStorageDirectory(ctx *fasthttp.RequestCtx, repoName, d
     packages, err := h.repoService.ListPackages(ctx, displayPath)
     if err != nil {
         log.Logger.Debugf("❌ Failed to list packages for repo %s: %v", displayPath, err)
-        ctx.Error("Failed to access repository", fasthttp.StatusInternalServerError)
+        if h.storageUnavailable(ctx, err) {
+            return true
+        }
+        h.sendJSONError(ctx, "Failed to access repository", fasthttp.StatusInternalServerError)
+        return true
+    }
+
+    if wantsJSON(ctx) {
+        h.writeObjectStorageListing(ctx, displayPath, packages)
         return true
     }
 
@@ -364,14 +689,39 @@ func (h *API) handleObjectStorageDirectory(ctx *fasthttp.RequestCtx, repoName, d
 func (h *API) handleObjectStorageFile(ctx *fasthttp.RequestCtx, repoName, filePath string) bool {
     log.Logger.Debugf("🔍 Object storage file: repo=%s, path=%s", repoName, filePath)
 
-    // 尝试下载文件
-    reader, err := h.repoService.DownloadPackageFiles(ctx, repoName, filePath)
+    // 先获取大小，用于 Content-Length 和 Range 请求
+    info, err := h.repoService.StatPackageFile(ctx, repoName, filePath)
+    if err != nil || info.IsDir {
+        log.Logger.Debugf("❌ Object storage file not found: repo=%s, path=%s, error=%v", repoName, filePath, err)
+        if h.storageUnavailable(ctx, err) {
+            return true
+        }
+        h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFileNotFound, "File not found", nil)
+        return true
+    }
+
+    offset, length := int64(0), info.Size
+    byteRange := ctx.Request.Header.Peek("Range")
+    if len(byteRange) > 0 {
+        start, end, err := fasthttp.ParseByteRange(byteRange, int(info.Size))
+        if err != nil {
+            h.sendJSONError(ctx, "Requested range not satisfiable", fasthttp.StatusRequestedRangeNotSatisfiable)
+            ctx.Response.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", info.Size))
+            return true
+        }
+        offset, length = int64(start), int64(end-start+1)
+    }
+
+    reader, err := h.repoService.OpenPackageFileRange(ctx, repoName, filePath, offset, length)
     if err != nil {
         log.Logger.Debugf("❌ Object storage file not found: repo=%s, path=%s, error=%v", repoName, filePath, err)
-        ctx.Error("File not found", fasthttp.StatusNotFound)
+        if h.storageUnavailable(ctx, err) {
+            return true
+        }
+        h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFileNotFound, "File not found", nil)
         return true
     }
-    defer reader.Close()
+    // reader 由 fasthttp 在响应写完后关闭
 
     // 设置适当的 Content-Type
     contentType := utils.GetContentTypeByExtension(filePath)
@@ -380,14 +730,23 @@ func (h *API) handleObjectStorageFile(ctx *fasthttp.RequestCtx, repoName, filePa
     // 设置文件名
     filename := filepath.Base(filePath)
     ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+    h.filePreview(ctx, filePath)()
+    ctx.Response.Header.Set("Accept-Ranges", "bytes")
+    h.linkMetalink(ctx, filePath, info.Size)
+    if !info.ModTime.IsZero() {
+        ctx.Response.Header.SetLastModified(info.ModTime)
+    }
+    if len(byteRange) > 0 {
+        ctx.SetStatusCode(fasthttp.StatusPartialContent)
+        ctx.Response.Header.SetContentRange(int(offset), int(offset+length-1), int(info.Size))
+    }
     
-    ctx.SetBodyStream(reader, -1)
+    ctx.SetBodyStream(reader, int(length))
     return true
 }
 
 func (h *API) generateObjectStorageDirectoryHTML(ctx *fasthttp.RequestCtx, repoName, displayPath string, packages []types.PackageInfo) {
-    ctx.SetContentType("text/html; charset=utf-8")
-    ctx.SetBodyString(utils.GenerateObjectStorageDirectoryHTML(repoName, displayPath, packages))
+    h.sendHTML(ctx, withReadme(utils.GenerateObjectStorageDirectoryHTML(repoName, displayPath, packages), h.objectReadme(ctx, displayPath, packages)))
 }
 
 func (h *API) handleSmartDirectoryListing(ctx *fasthttp.RequestCtx, cleanPath, fullPath string) {
@@ -396,23 +755,27 @@ func (h *API) handleSmartDirectoryListing(ctx *fasthttp.RequestCtx, cleanPath, f
     
     log.Logger.Debugf("🔍 Detected repo type for %s: %s", cleanPath, repoType)
     
-    if repoType != "unknown" {
+    if wantsJSON(ctx) {
+        h.writeDirectListing(ctx, cleanPath, fullPath, repoType)
+    } else if repoType != "unknown" {
         // 是仓库目录，生成增强的HTML
         h.generateEnhancedDirectoryHTML(ctx, cleanPath, fullPath, repoType)
     } else {
         // 普通目录，使用基本HTML
-        handleDirectoryListingNew(ctx, cleanPath, fullPath)
+        handleDirectoryListingNew(ctx, cleanPath, fullPath, h)
     }
 }
 
 func (h *API) generateEnhancedDirectoryHTML(ctx *fasthttp.RequestCtx, cleanPath, fullPath, repoType string) {
 	str ,err := utils.GenerateEnhancedDirectoryHTML(cleanPath, fullPath, repoType)
 	if err != nil {
-		ctx.Error(err.Error(), fasthttp.StatusInternalServerError)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to generate directory listing", err)
 		return
 	}
-    ctx.SetContentType("text/html; charset=utf-8")
-    ctx.SetBodyString(str)
+	if entries, err := os.ReadDir(fullPath); err == nil {
+		str = withReadme(str, localReadme(fullPath, entries))
+	}
+    h.sendHTML(ctx, str)
 }
 
 func (h *API) handleDirectFileServe(ctx *fasthttp.RequestCtx, cleanPath, fullPath string) {
@@ -422,6 +785,9 @@ func (h *API) handleDirectFileServe(ctx *fasthttp.RequestCtx, cleanPath, fullPat
         contentType := utils.GetContentType(filename)
         ctx.Response.Header.Set("Content-Type", contentType)
         ctx.Response.Header.Set("Cache-Control", "public, max-age=300")
+    } else if treePath, ok := utils.FindInstallTreePath(cleanPath); ok {
+        // 安装树文件（.treeinfo、images/、EFI/ 等）
+        ctx.Response.Header.Set("Content-Type", utils.GetInstallTreeContentType(treePath))
     }
     
     // 对于包文件，设置下载头
@@ -430,8 +796,13 @@ func (h *API) handleDirectFileServe(ctx *fasthttp.RequestCtx, cleanPath, fullPat
         ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
         metrics.IncrementDownloads()
     }
-    
+
+    if h.serveCachedFile(ctx, cleanPath, fullPath) {
+        return
+    }
+    preview := h.filePreview(ctx, cleanPath)
     fasthttp.ServeFile(ctx, fullPath)
+    preview()
 }
 
 // 仓库文件直接访问 (nginx 兼容方式)
@@ -457,15 +828,25 @@ func (h *API) handleRepoFileAccess(ctx *fasthttp.RequestCtx, _ fasthttp.RequestH
 		}
 	}
 
+	if !h.authorize(ctx, repoName+"/"+filePath, access.ClassDownload) {
+		return true
+	}
+	if h.serveWebsite(ctx, repoName+"/"+filePath) {
+		return true
+	}
+
 	// 检查是否是直接文件访问
+	filePath = h.localName(repoName, filePath)
 	fullPath := fmt.Sprintf("%s/%s/%s", h.config.StoragePath, repoName, filePath)
 	if info, err := os.Stat(fullPath); err == nil {
 		if info.IsDir() {
 			// 目录访问 - 生成目录列表
-			handleDirectoryListing(ctx, repoName, filePath, fullPath)
-		} else {
+			handleDirectoryListing(ctx, repoName, filePath, fullPath, h)
+		} else if rel := filepath.Join(repoName, filePath); !h.redirectDownload(ctx, rel, info.Size()) && !h.serveCachedFile(ctx, rel, fullPath) {
 			// 文件访问 - 直接服务文件
+			preview := h.filePreview(ctx, rel)
 			fasthttp.ServeFile(ctx, fullPath)
+			preview()
 		}
 		return true
 	}
@@ -479,7 +860,7 @@ func (h *API) handleRepoBrowse(ctx *fasthttp.RequestCtx, browseRegex *regexp.Reg
 	matches := browseRegex.FindStringSubmatch(path)
 
 	if matches == nil {
-		ctx.Error("Invalid browse path", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Invalid browse path", nil)
 		return
 	}
 
@@ -489,24 +870,37 @@ func (h *API) handleRepoBrowse(ctx *fasthttp.RequestCtx, browseRegex *regexp.Reg
 	fullPath := fmt.Sprintf("%s/%s/%s", h.config.StoragePath, repoName, subPath)
 
 	if info, err := os.Stat(fullPath); err != nil {
-		ctx.Error("Path not found", fasthttp.StatusNotFound)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFileNotFound, "Path not found", nil)
 		return
 	} else if info.IsDir() {
-		handleDirectoryListing(ctx, repoName, subPath, fullPath)
+		handleDirectoryListing(ctx, repoName, subPath, fullPath, h)
 	} else {
 		fasthttp.ServeFile(ctx, fullPath)
 	}
 }
 
 func (h *API) ServeMetadata(ctx *fasthttp.RequestCtx, repoName, filename string) {
+	if generation := generationParam(ctx); generation != "" {
+		h.serveGeneration(ctx, repoName, generation, filename)
+		return
+	}
+	contentType := utils.GetContentType(filename)
+	if h.serveCachedMetadata(ctx, repoName, filename) {
+		ctx.Response.Header.Set("Content-Type", contentType)
+		ctx.Response.Header.Set("Cache-Control", "public, max-age=300")
+		return
+	}
+
 	reader, err := h.repoService.GetMetadata(ctx, repoName, filename)
 	if err != nil {
-		ctx.Error("Metadata not found", fasthttp.StatusNotFound)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeMetadataNotFound, "Metadata not found", nil)
 		return
 	}
-	defer reader.Close()
+	// reader 由 fasthttp 在响应写完后关闭
 
-	contentType := utils.GetContentType(filename)
 	ctx.Response.Header.Set("Content-Type", contentType)
 	ctx.Response.Header.Set("Cache-Control", "public, max-age=300")
 
@@ -517,7 +911,10 @@ func (h *API) GetRepoInfo(ctx *fasthttp.RequestCtx, repoName string) {
 	packages, err := h.repoService.ListPackages(ctx, repoName)
 	if err != nil {
 		log.Logger.Debugf("Get repo info failed for %s: %v", repoName, err)
-		h.sendJSONError(ctx, fmt.Sprintf("Failed to get repository info: %v", err), fasthttp.StatusInternalServerError)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to get repository info", err)
 		return
 	}
 
@@ -528,6 +925,31 @@ func (h *API) GetRepoInfo(ctx *fasthttp.RequestCtx, repoName string) {
 		repoType = "unknown" // 设置默认值而不是返回错误
 	}
 
+	// 代理仓库的上游校验状态
+	var upstream *types.UpstreamStatus
+	if h.proxy != nil {
+		upstream, _ = h.proxy.Status(repoName)
+	}
+
+	h.addLicenses(ctx, repoName, packages)
+
+	var description string
+	if rc, ok := h.config.RepoConfig(repoName); ok {
+		description = rc.Description
+	}
+
+	// 按架构统计全部包，再按 arch 参数过滤
+	arches := archStats(packages)
+	if filter := archFilter(ctx.QueryArgs()); filter != nil {
+		filtered := make([]types.PackageInfo, 0, len(packages))
+		for _, pkg := range packages {
+			if filter[pkg.Arch] {
+				filtered = append(filtered, pkg)
+			}
+		}
+		packages = filtered
+	}
+
 	// 统计信息
 	var totalSize int64
 	rpmCount := 0
@@ -552,6 +974,11 @@ func (h *API) GetRepoInfo(ctx *fasthttp.RequestCtx, repoName string) {
 		DEBCount:     debCount,
 		TotalSize:    totalSize,
 		Packages:     packages,
+		Arches:       arches,
+		Upstream:     upstream,
+		Jobs:         h.repoJobs(repoName),
+		Description:  description,
+		Readme:       h.repoInfoReadme(ctx, repoName),
 	}, fasthttp.StatusOK)
 }
 
@@ -571,10 +998,12 @@ func (h *API) buildRepoTreeWithTypes(repos []string) map[string]*types.TreeNode
 						repoType = "unknown"
 					}
 					
+					_, _, maintenance := h.repoService.Maintenance(repo)
 					current[part] = &types.TreeNode{
-						Type:     "repo",
-						Path:     repo,
-						RepoType: repoType, // 新增仓库类型字段
+						Type:        "repo",
+						Path:        repo,
+						RepoType:    repoType, // 新增仓库类型字段
+						Maintenance: maintenance,
 					}
 				} else {
 					// 中间节点
@@ -600,7 +1029,7 @@ func (h *API) ListRepos(ctx *fasthttp.RequestCtx) {
 	repos, err := h.repoService.ListRepos(ctx)
 	if err != nil {
 		log.Logger.Debugf("List repositories failed: %v", err)
-		h.sendJSONError(ctx, fmt.Sprintf("Failed to list repositories: %v", err), fasthttp.StatusInternalServerError)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to list repositories", err)
 		return
 	}
 
@@ -612,14 +1041,26 @@ func (h *API) ListRepos(ctx *fasthttp.RequestCtx) {
 		Repositories: repos,
 		Tree:         repoTree,
 		Count:        len(repos),
+		Maintenance:  h.repoService.MaintenanceRepos(),
 	}, fasthttp.StatusOK)
 }
 
 func (h *API) DeleteRepo(ctx *fasthttp.RequestCtx, repoName string) {
+	if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpDelete, Repo: repoName}) {
+		return
+	}
+	if h.protected(repoName) {
+		h.requestApproval(ctx, types.ApprovalRequest{Operation: approval.OpDeleteRepo, Repo: repoName})
+		return
+	}
+
 	err := h.repoService.DeleteRepo(ctx, repoName)
 	if err != nil {
 		log.Logger.Debugf("Delete repository failed for %s: %v", repoName, err)
-		h.sendJSONError(ctx, fmt.Sprintf("Failed to delete repository: %v", err), fasthttp.StatusInternalServerError)
+		if h.storageUnavailable(ctx, err) || h.legalHeld(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to delete repository", err)
 		return
 	}
 
@@ -632,7 +1073,7 @@ func (h *API) ServeStatic(ctx *fasthttp.RequestCtx) {
 
 	// 安全检查，防止目录遍历攻击
 	if strings.Contains(filename, "..") {
-		ctx.Error("Forbidden", fasthttp.StatusForbidden)
+		h.sendJSONError(ctx, "Forbidden", fasthttp.StatusForbidden)
 		return
 	}
 	staticPath := filepath.Join("./static", filename)
@@ -641,17 +1082,18 @@ func (h *API) ServeStatic(ctx *fasthttp.RequestCtx) {
 
 func (h *API) BatchUpload(ctx *fasthttp.RequestCtx) {
 	// 解析 multipart form
-	form, err := ctx.MultipartForm()
+	form, err := h.uploadForm(ctx)
 	if err != nil {
-		ctx.Error("Failed to parse multipart form", fasthttp.StatusBadRequest)
+		if !h.rejectUpload(ctx, err) {
+			h.sendJSONError(ctx, "Failed to parse multipart form", fasthttp.StatusBadRequest)
+		}
 		return
 	}
-	defer ctx.Request.RemoveMultipartFormFiles()
 
 	// 获取仓库名称
 	repoNames := form.Value["repository"]
 	if len(repoNames) == 0 {
-		ctx.Error("Repository name is required", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Repository name is required", nil)
 		return
 	}
 	repoName := repoNames[0]
@@ -659,7 +1101,7 @@ func (h *API) BatchUpload(ctx *fasthttp.RequestCtx) {
 	// 获取文件列表
 	files := form.File["files"]
 	if len(files) == 0 {
-		ctx.Error("No files uploaded", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeNoFile, "No files uploaded", nil)
 		return
 	}
 
@@ -669,21 +1111,26 @@ func (h *API) BatchUpload(ctx *fasthttp.RequestCtx) {
 	}
 
 	// 批量上传文件
+	stage := h.staging(ctx, repoName)
 	for _, fileHeader := range files {
-		result := h.uploadSingleFile(ctx, repoName, fileHeader)
+		result := h.uploadSingleFile(ctx, repoName, fileHeader, stage)
 		response.Results = append(response.Results, result)
 
-		if result.Status == "success" {
+		switch result.Status {
+		case "success":
 			response.Success++
-		} else {
+		case "pending":
+			response.Pending++
+		default:
 			response.Failed++
 		}
 	}
 
-	// 检查是否需要自动刷新
+	// 检查是否需要自动刷新（在后台工作池中执行）
 	autoRefresh := form.Value["auto_refresh"]
-	if len(autoRefresh) > 0 && autoRefresh[0] == "true" {
-		if err := h.repoService.RefreshMetadata(ctx, repoName); err != nil {
+	if !stage && ((len(autoRefresh) > 0 && autoRefresh[0] == "true") || h.autoRefresh(repoName)) {
+		if err := h.repoService.ScheduleRefresh(ctx, repoName); err != nil {
+			log.Logger.Warnf("Failed to schedule metadata refresh for %s: %v", repoName, err)
 			response.Status = "partial_success"
 		} else {
 			response.Status = "success"
@@ -702,7 +1149,7 @@ func (h *API) BatchUpload(ctx *fasthttp.RequestCtx) {
 	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
 }
 
-func (h *API) uploadSingleFile(ctx *fasthttp.RequestCtx, repoName string, fileHeader *multipart.FileHeader) types.BatchUploadResult {
+func (h *API) uploadSingleFile(ctx *fasthttp.RequestCtx, repoName string, fileHeader *spool.File, stage bool) types.BatchUploadResult {
 	result := types.BatchUploadResult{
 		Filename: fileHeader.Filename,
 	}
@@ -724,36 +1171,55 @@ func (h *API) uploadSingleFile(ctx *fasthttp.RequestCtx, repoName string, fileHe
 	defer file.Close()
 
 	// 上传文件
-	if err := h.repoService.UploadPackage(ctx, repoName, fileHeader.Filename, file); err != nil {
+	var stored string
+	if !stage && h.protected(repoName) {
+		var id string
+		id, stored, err = h.holdUpload(ctx, repoName, fileHeader.Filename, file)
+		if err == nil && id != "" {
+			result.Status = "pending"
+			result.Approval = id
+			return result
+		}
+	} else {
+		upload := h.repoService.UploadPackageAs
+		if stage {
+			upload = h.repoService.StagePackageAs
+		}
+		stored, err = upload(ctx, repoName, fileHeader.Filename, file)
+	}
+	if err != nil {
 		result.Status = "failed"
 		result.Error = fmt.Sprintf("Upload failed: %v", err)
 		return result
 	}
 
 	result.Status = "success"
+	if stored != fileHeader.Filename {
+		result.StoredAs = stored
+	}
 	return result
 }
 
 func (h *API) CreateRepo(ctx *fasthttp.RequestCtx) {
 	rt := &types.RepoTable{}
 	if err := rt.UnmarshalJSON(ctx.PostBody()); err != nil {
-		h.sendJSONError(ctx, "Invalid JSON format", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
 		return
 	}
 
 	if rt.Name == "" {
-		h.sendJSONError(ctx, "Repository name is required", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Repository name is required", nil)
 		return
 	}
 
 	// 新增：验证仓库类型
 	if rt.Type == "" {
-		h.sendJSONError(ctx, "Repository type is required", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidRepoType, "Repository type is required", nil)
 		return
 	}
 
-	// 验证仓库类型是否有效
-	validTypes := []string{"rpm", "deb", "files"}
+	// 验证仓库类型是否有效，包括插件注册的类型
+	validTypes := h.repoService.RepoTypes()
 	isValidType := false
 	for _, validType := range validTypes {
 		if rt.Type == validType {
@@ -762,7 +1228,7 @@ func (h *API) CreateRepo(ctx *fasthttp.RequestCtx) {
 		}
 	}
 	if !isValidType {
-		h.sendJSONError(ctx, "Invalid repository type. Must be one of: rpm, deb, files", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidRepoType, "Invalid repository type. Must be one of: "+strings.Join(validTypes, ", "), nil)
 		return
 	}
 
@@ -778,14 +1244,21 @@ func (h *API) CreateRepo(ctx *fasthttp.RequestCtx) {
 
 	// 验证路径格式
 	if !utils.IsValidRepoName(repoPath) {
-		h.sendJSONError(ctx, "Invalid repository path. Use only letters, numbers, hyphens, underscores and forward slashes", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Invalid repository path. Use only letters, numbers, hyphens, underscores and forward slashes", nil)
+		return
+	}
+
+	if !h.authorize(ctx, repoPath, access.ClassUpload) {
+		return
+	}
+	if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpCreate, Repo: repoPath, RepoType: rt.Type}) {
 		return
 	}
 
 	err := h.repoService.CreateRepo(ctx, repoPath, rt.Type)
 	if err != nil {
 		log.Logger.Debugf("Create repository failed for %s (type: %s): %v", repoPath, rt.Type, err)
-		h.sendJSONError(ctx, fmt.Sprintf("Failed to create repository: %v", err), fasthttp.StatusInternalServerError)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to create repository", err)
 		return
 	}
 
@@ -798,7 +1271,7 @@ func (h *API) Upload(ctx *fasthttp.RequestCtx) {
 
 	// 移除 /repo/ 前缀和 /upload 后缀
 	if !strings.HasPrefix(path, "/repo/") || !strings.HasSuffix(path, "/upload") {
-		h.sendJSONError(ctx, "Invalid upload path", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Invalid upload path", nil)
 		return
 	}
 
@@ -807,14 +1280,17 @@ func (h *API) Upload(ctx *fasthttp.RequestCtx) {
 	repoPath = strings.TrimSuffix(repoPath, "/upload")
 
 	if repoPath == "" {
-		h.sendJSONError(ctx, "Repository path is required", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Repository path is required", nil)
 		return
 	}
 
 	// 获取上传的文件
-	fileHeader, err := ctx.FormFile("file")
+	fileHeader, err := h.formFile(ctx, "file")
+	if h.rejectUpload(ctx, err) {
+		return
+	}
 	if err != nil {
-		h.sendJSONError(ctx, "No file uploaded", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeNoFile, "No file uploaded", nil)
 		return
 	}
 
@@ -822,13 +1298,35 @@ func (h *API) Upload(ctx *fasthttp.RequestCtx) {
 	repoType, err := h.repoService.GetRepoType(ctx, repoPath)
 	if err != nil {
 		log.Logger.Debugf("Failed to get repository type for %s: %v", repoPath, err)
-		h.sendJSONError(ctx, "Repository not found", fasthttp.StatusNotFound)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
 		return
 	}
 
 	// 验证文件类型与仓库类型的匹配
-	if !utils.ValidateFileTypeForRepo(fileHeader.Filename, repoType) {
-		h.sendJSONError(ctx, utils.GetFileTypeErrorMessage(repoType), fasthttp.StatusBadRequest)
+	switch repoType {
+	case "rpm", "deb", "files":
+		if !utils.ValidateFileTypeForRepo(fileHeader.Filename, repoType) {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedFile, utils.GetFileTypeErrorMessage(repoType), nil)
+			return
+		}
+	default:
+		// 插件注册的仓库类型由插件检查文件名
+		if err := h.repoService.ValidateFileName(fileHeader.Filename, repoType); err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedFile, utils.GetFileTypeErrorMessage(repoType)+": "+err.Error(), nil)
+			return
+		}
+	}
+
+	// 可选的包说明或发布说明
+	note, err := h.uploadNote(ctx)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, err.Error(), nil)
+		return
+	}
+	// 可选的有效期，到期后自动删除
+	expireAfter, err := h.uploadExpiry(ctx)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, err.Error(), nil)
 		return
 	}
 
@@ -839,15 +1337,70 @@ func (h *API) Upload(ctx *fasthttp.RequestCtx) {
 	}
 	defer file.Close()
 
-	// 上传文件到指定路径
-	err = h.repoService.UploadPackage(ctx, repoPath, fileHeader.Filename, file)
+	if h.authz != nil {
+		input := types.AuthzInput{
+			Operation: authz.OpUpload,
+			Repo:      repoPath,
+			RepoType:  repoType,
+			Path:      fileHeader.Filename,
+			Size:      fileHeader.Size,
+			Package:   h.authz.Inspect(file, fileHeader.Filename),
+		}
+		if !h.checkWrite(ctx, input) {
+			return
+		}
+	}
+
+	// 上传文件到指定路径，启用暂存时进入暂存区，发布后才可见；
+	// 受保护仓库中覆盖已有文件的上传等待另一位管理员批准
+	// 文件名已存在时按仓库的覆盖策略处理，stored 为实际保存的文件名
+	stage := h.staging(ctx, repoPath)
+	var pending, stored string
+	switch {
+	case stage:
+		stored, err = h.repoService.StagePackageAs(ctx, repoPath, fileHeader.Filename, file)
+	case h.protected(repoPath):
+		pending, stored, err = h.holdUpload(ctx, repoPath, fileHeader.Filename, file)
+	default:
+		stored, err = h.repoService.UploadPackageAs(ctx, repoPath, fileHeader.Filename, file)
+	}
 	if err != nil {
 		log.Logger.Debugf("Upload failed for repo %s, file %s: %v", repoPath, fileHeader.Filename, err)
-		h.sendJSONError(ctx, fmt.Sprintf("Upload failed: %v", err), fasthttp.StatusInternalServerError)
+		if h.storageUnavailable(ctx, err) || h.fileExists(ctx, err) || h.legalHeld(ctx, err) || h.filenameRejected(ctx, err) || h.licenseRejected(ctx, err) || h.invalidPackage(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Upload failed", err)
+		return
+	}
+	h.saveUploadNote(ctx, repoPath, stored, note)
+	expires := h.saveUploadExpiry(ctx, repoPath, stored, expireAfter)
+
+	if stage {
+		h.sendUploaded(ctx, "Package staged, publish it with POST /repo/"+repoPath+"/publish", fileHeader.Filename, stored, expires)
 		return
 	}
+	if pending != "" {
+		if r, err := h.approvals.Get(pending); err == nil {
+			h.sendApproval(ctx, r, fasthttp.StatusAccepted)
+			return
+		}
+	}
 
-	h.sendSuccess(ctx, "Package uploaded successfully")
+	if h.formValue(ctx, "auto_refresh") == "true" || h.autoRefresh(repoPath) {
+		if err := h.repoService.ScheduleRefresh(ctx, repoPath); err != nil {
+			log.Logger.Warnf("Failed to schedule metadata refresh for %s: %v", repoPath, err)
+			h.sendUploaded(ctx, "Package uploaded successfully, metadata refresh not scheduled", fileHeader.Filename, stored, expires)
+			return
+		}
+	}
+
+	h.sendUploaded(ctx, "Package uploaded successfully", fileHeader.Filename, stored, expires)
+}
+
+// autoRefresh 仓库配置了上传后自动刷新元数据
+func (h *API) autoRefresh(repoName string) bool {
+	rc, ok := h.config.RepoConfig(repoName)
+	return ok && rc.AutoRefresh
 }
 
 func (h *API) GetPackageChecksum(ctx *fasthttp.RequestCtx) {
@@ -855,7 +1408,7 @@ func (h *API) GetPackageChecksum(ctx *fasthttp.RequestCtx) {
 
 	// 使用字符串操作解析路径
 	if !strings.HasPrefix(path, "/repo/") || !strings.Contains(path, "/checksum/") {
-		h.sendJSONError(ctx, "Invalid checksum path format", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Invalid checksum path format", nil)
 		return
 	}
 
@@ -865,7 +1418,7 @@ func (h *API) GetPackageChecksum(ctx *fasthttp.RequestCtx) {
 	// 查找 /checksum/ 的位置
 	checksumIndex := strings.LastIndex(pathWithoutPrefix, "/checksum/")
 	if checksumIndex == -1 {
-		h.sendJSONError(ctx, "Invalid checksum path format", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Invalid checksum path format", nil)
 		return
 	}
 
@@ -874,7 +1427,7 @@ func (h *API) GetPackageChecksum(ctx *fasthttp.RequestCtx) {
 	filename := pathWithoutPrefix[checksumIndex+10:] // 10 是 "/checksum/" 的长度
 
 	if repoName == "" || filename == "" {
-		h.sendJSONError(ctx, "Invalid checksum path format", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Invalid checksum path format", nil)
 		return
 	}
 
@@ -884,7 +1437,10 @@ func (h *API) GetPackageChecksum(ctx *fasthttp.RequestCtx) {
 	checksum, err := h.repoService.GetPackageChecksum(ctx, repoName, filename)
 	if err != nil {
 		log.Logger.Debugf("❌ Failed to get checksum: repo=%s, file=%s, error=%v", repoName, filename, err)
-		h.sendJSONError(ctx, fmt.Sprintf("Failed to get checksum: %v", err), fasthttp.StatusNotFound)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFileNotFound, "Failed to get checksum", err)
 		return
 	}
 
@@ -894,7 +1450,7 @@ func (h *API) GetPackageChecksum(ctx *fasthttp.RequestCtx) {
 	response := &types.PackageChecksum{
 		Status: types.Status{
 			Status:  "success",
-			Message: "Checksum retrieved successfully",
+			Message: h.localize(ctx, "Checksum retrieved successfully"),
 			Code:    fasthttp.StatusOK,
 		},
 		Filename: filename,
@@ -910,24 +1466,28 @@ func (h *API) DownloadPackage(ctx *fasthttp.RequestCtx, repoName, filename strin
 
 	// 根据文件扩展名确定包类型
 	var contentType string
-	if strings.HasSuffix(filename, ".rpm") {
-		contentType = "application/x-rpm"
-		metrics.IncrementDownloads()
-	} else if strings.HasSuffix(filename, ".deb") {
-		contentType = "application/vnd.debian.binary-package"
+	if strings.HasSuffix(filename, ".rpm") || strings.HasSuffix(filename, ".deb") {
+		contentType = utils.GetContentTypeByExtension(filename)
 		metrics.IncrementDownloads()
 	} else {
-		ctx.Error("Unsupported package type", fasthttp.StatusBadRequest)
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedFile, "Unsupported package type", nil)
+		return
+	}
+
+	if h.redirectPackage(ctx, repoName, filename) {
 		return
 	}
 
 	reader, err := h.repoService.DownloadPackage(ctx, repoName, filename)
 	if err != nil {
 		log.Logger.Debugf("❌ Package not found: repo=%s, file=%s, error=%v", repoName, filename, err)
-		ctx.Error("Package not found", fasthttp.StatusNotFound)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodePackageNotFound, "Package not found", nil)
 		return
 	}
-	defer reader.Close()
+	// reader 由 fasthttp 在响应写完后关闭
 
 	log.Logger.Debugf("✅ Serving package: %s/%s", repoName, filename)
 
@@ -938,13 +1498,13 @@ func (h *API) DownloadPackage(ctx *fasthttp.RequestCtx, repoName, filename strin
 	ctx.SetBodyStream(reader, -1)
 }
 
-func handleDirectoryListing(ctx *fasthttp.RequestCtx, repoName, subPath, fullPath string) {
+func handleDirectoryListing(ctx *fasthttp.RequestCtx, repoName, subPath, fullPath string, h *API) {
 	log.Logger.Debugf("🔍 Directory listing: repo=%s, subPath=%s, fullPath=%s", repoName, subPath, fullPath)
 
 	entries, err := os.ReadDir(fullPath)
 	if err != nil {
 		log.Logger.Debugf("❌ Cannot read directory %s: %v", fullPath, err)
-		ctx.Error("Cannot read directory", fasthttp.StatusInternalServerError)
+		apierr.Write(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Cannot read directory", "")
 		return
 	}
 
@@ -953,11 +1513,25 @@ func handleDirectoryListing(ctx *fasthttp.RequestCtx, repoName, subPath, fullPat
 		log.Logger.Debugf("  - %s (dir: %v)", entry.Name(), entry.IsDir())
 	}
 
+	if wantsJSON(ctx) {
+		base := "/repo/" + repoName + "/files/" + strings.Trim(subPath, "/")
+		listing := &types.DirectoryListing{
+			Path:    strings.TrimSuffix(base, "/") + "/",
+			Repo:    repoName,
+			Entries: localEntries(entries, base, repoName),
+			Readme:  localReadme(fullPath, entries),
+		}
+		if repoType, err := h.repoService.GetRepoType(ctx, repoName); err == nil {
+			h.addThumbnails(repoName, repoType, strings.Trim(subPath, "/"), listing.Entries)
+		}
+		writeListing(ctx, listing)
+		return
+	}
+
 	// 生成 HTML 目录列表
-	html := utils.GenerateDirectoryHTML(repoName, subPath, entries)
+	html := withReadme(utils.GenerateDirectoryHTML(repoName, subPath, entries), localReadme(fullPath, entries))
 
-	ctx.SetContentType("text/html; charset=utf-8")
-	ctx.SetBodyString(html)
+	h.sendHTML(ctx, html)
 }
 
 func handleRepoEndpoints(ctx *fasthttp.RequestCtx, method, root, path string, patterns map[string]*regexp.Regexp, h *API) bool {
@@ -974,7 +1548,9 @@ func handleRepoEndpoints(ctx *fasthttp.RequestCtx, method, root, path string, pa
 			log.Logger.Debugf("✅ Matched files pattern: repo='%s', file='%s'", repoPath, filePath)
 
 			if method == "GET" {
-				handleRepoFiles(ctx, root, repoPath, filePath)
+				if h.authorize(ctx, repoPath, access.ClassDownload) {
+					handleRepoFiles(ctx, root, repoPath, filePath, h)
+				}
 				return true
 			}
 		}
@@ -982,7 +1558,7 @@ func handleRepoEndpoints(ctx *fasthttp.RequestCtx, method, root, path string, pa
 
 	// 按优先级顺序检查模式
 	priorityPatterns := []string{
-		"upload", "refresh", "checksum", "download_rpm", "download_deb",
+		"tree_upload", "tree_validate", "package_deps", "package_rdeps", "attestations", "attestation", "signatures", "signature", "verify", "thumb", "upload", "refresh", "publish", "staging", "archive", "checksum", "gpg_key", "client_config", "setup_script", "metadata_bundle", "download_rpm", "download_deb",
 		"metadata", "deb_metadata", "repo_files", "repo_browse", "repo_info",
 	}
 
@@ -991,6 +1567,10 @@ func handleRepoEndpoints(ctx *fasthttp.RequestCtx, method, root, path string, pa
 		if matches := regex.FindStringSubmatch(path); matches != nil {
 			log.Logger.Debugf("✅ Matched pattern: %s for path: %s, matches: %v", patternName, path, matches)
 
+			if !h.authorize(ctx, matches[1], routeClass(patternName, method)) {
+				return true
+			}
+
 			switch patternName {
 			case "download_rpm", "download_deb":
 				if method == "GET" {
@@ -999,6 +1579,13 @@ func handleRepoEndpoints(ctx *fasthttp.RequestCtx, method, root, path string, pa
 				}
 			case "metadata", "deb_metadata":
 				if method == "GET" {
+					rel := matches[2]
+					if patternName == "metadata" {
+						rel = "repodata/" + rel
+					}
+					if !h.fetchUpstream(ctx, matches[1], rel) {
+						return true
+					}
 					h.ServeMetadata(ctx, matches[1], matches[2])
 					return true
 				}
@@ -1012,15 +1599,103 @@ func handleRepoEndpoints(ctx *fasthttp.RequestCtx, method, root, path string, pa
 					h.RefreshRepo(ctx)
 					return true
 				}
+			case "publish":
+				if method == "POST" {
+					h.Publish(ctx, matches[1])
+					return true
+				}
+			case "staging":
+				if method == "GET" || method == "DELETE" {
+					h.Staging(ctx, matches[1], method)
+					return true
+				}
+			case "archive":
+				if method == "GET" || method == "POST" {
+					h.Archive(ctx, matches[1])
+					return true
+				}
 			case "checksum":
 				if method == "GET" {
 					h.GetPackageChecksum(ctx)
 					return true
 				}
+			case "gpg_key":
+				if method == "GET" {
+					h.GetRepoGPGKey(ctx, matches[1])
+					return true
+				}
+			case "client_config":
+				if method == "GET" {
+					h.GetRepoClientConfig(ctx, matches[1])
+					return true
+				}
+			case "setup_script":
+				if method == "GET" {
+					h.GetRepoSetupScript(ctx, matches[1])
+					return true
+				}
+			case "metadata_bundle":
+				if method == "GET" {
+					h.MetadataBundle(ctx, matches[1])
+					return true
+				}
+			case "tree_upload":
+				if method == "POST" {
+					h.UploadTreeFile(ctx, matches[1])
+					return true
+				}
+			case "tree_validate":
+				if method == "GET" {
+					h.ValidateInstallTree(ctx, matches[1])
+					return true
+				}
+			case "package_deps":
+				if method == "GET" {
+					h.GetPackageDeps(ctx, matches[1], matches[2])
+					return true
+				}
+			case "package_rdeps":
+				if method == "GET" {
+					h.GetPackageReverseDeps(ctx, matches[1], matches[2])
+					return true
+				}
+			case "attestations":
+				if method == "GET" || method == "POST" {
+					h.Attestations(ctx, matches[1], matches[2], method)
+					return true
+				}
+			case "attestation":
+				if method == "GET" || method == "DELETE" {
+					h.Attestation(ctx, matches[1], matches[2], matches[3], method)
+					return true
+				}
+			case "signatures":
+				if method == "GET" || method == "POST" {
+					h.Signatures(ctx, matches[1], matches[2], method)
+					return true
+				}
+			case "signature":
+				if method == "GET" || method == "DELETE" {
+					h.Signature(ctx, matches[1], matches[2], matches[3], method)
+					return true
+				}
+			case "verify":
+				if method == "GET" {
+					h.VerifyArtifact(ctx, matches[1], matches[2])
+					return true
+				}
+			case "thumb":
+				if method == "GET" {
+					h.Thumbnail(ctx, matches[1], matches[2])
+					return true
+				}
 			case "repo_files":
 				if method == "GET" {
 					log.Logger.Debugf("Handling repo_files: repo=%s, path=%s", matches[1], matches[2])
-					handleRepoFiles(ctx, h.config.StoragePath, matches[1], matches[2])
+					if !h.fetchUpstream(ctx, matches[1], matches[2]) {
+						return true
+					}
+					handleRepoFiles(ctx, h.config.StoragePath, matches[1], matches[2], h)
 					return true
 				}
 			case "repo_browse":
@@ -1055,11 +1730,25 @@ func createExternalStaticHandler(root string) fasthttp.RequestHandler {
 		IndexNames:         []string{"index.html"},
 		GenerateIndexPages: false,
 		AcceptByteRange:    true,
+		PathNotFound:       fileNotFound,
 	}
 	return fs.NewRequestHandler()
 }
 
-func handleRepoFiles(ctx *fasthttp.RequestCtx, root, repoName, filePath string) {
+// createOverrideStaticHandler 先在 root 中查找文件，不存在时交给 fallback
+func createOverrideStaticHandler(root string, fallback fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		// 目录（如 /static/ 本身）交给 fallback 返回 index.html
+		name := filepath.Join(root, filepath.Clean("/"+string(ctx.Path())))
+		if info, err := os.Stat(name); err == nil && info.Mode().IsRegular() {
+			fasthttp.ServeFile(ctx, name)
+			return
+		}
+		fallback(ctx)
+	}
+}
+
+func handleRepoFiles(ctx *fasthttp.RequestCtx, root, repoName, filePath string, h *API) {
 	log.Logger.Debugf("handleRepoFiles called: repo=%s, path='%s'", repoName, filePath)
 
 	// 构建完整路径
@@ -1076,13 +1765,13 @@ func handleRepoFiles(ctx *fasthttp.RequestCtx, root, repoName, filePath string)
 	info, err := os.Stat(fullPath)
 	if err != nil {
 		log.Logger.Debugf("Path not found: %s, error: %v", fullPath, err)
-		ctx.Error("Path not found", fasthttp.StatusNotFound)
+		apierr.Write(ctx, fasthttp.StatusNotFound, apierr.CodeFileNotFound, "Path not found", "")
 		return
 	}
 
 	if info.IsDir() {
 		log.Logger.Debugf("Serving directory listing for: %s", fullPath)
-		handleDirectoryListing(ctx, repoName, filePath, fullPath)
+		handleDirectoryListing(ctx, repoName, filePath, fullPath, h)
 	} else {
 		log.Logger.Debugf("Serving file: %s", fullPath)
 		// 对于元数据文件，设置正确的 Content-Type
@@ -1091,8 +1780,13 @@ func handleRepoFiles(ctx *fasthttp.RequestCtx, root, repoName, filePath string)
 			contentType := utils.GetContentType(filename)
 			ctx.Response.Header.Set("Content-Type", contentType)
 			ctx.Response.Header.Set("Cache-Control", "public, max-age=300")
+		} else if utils.IsInstallTreePath(filePath) {
+			// 安装树文件（.treeinfo、images/、EFI/ 等）
+			ctx.Response.Header.Set("Content-Type", utils.GetInstallTreeContentType(filePath))
 		}
+		preview := h.filePreview(ctx, repoName+"/"+filePath)
 		fasthttp.ServeFile(ctx, fullPath)
+		preview()
 	}
 }
 
@@ -1137,7 +1831,7 @@ func createEmbeddedStaticHandler() fasthttp.RequestHandler {
 		data, err := assets.StaticFiles.ReadFile(fullPath)
 		if err != nil {
 			log.Logger.Debugf("❌ File not found: %s, error: %v", fullPath, err)
-			ctx.Error("File not found", fasthttp.StatusNotFound)
+			apierr.Write(ctx, fasthttp.StatusNotFound, apierr.CodeFileNotFound, "File not found", "")
 			return
 		}
 
@@ -1150,18 +1844,23 @@ func createEmbeddedStaticHandler() fasthttp.RequestHandler {
 	}
 }
 
+// fileNotFound 文件服务找不到文件时返回统一的错误响应
+func fileNotFound(ctx *fasthttp.RequestCtx) {
+	apierr.Write(ctx, fasthttp.StatusNotFound, apierr.CodeFileNotFound, "File not found", "")
+}
+
 func createRepoHandler(root string) fasthttp.RequestHandler {
 	fs := &fasthttp.FS{
 		Root:               root,
 		GenerateIndexPages: true, // 启用目录索引
 		AcceptByteRange:    true,
+		PathNotFound:       fileNotFound,
 	}
 	return fs.NewRequestHandler()
 }
 
-func handleRootPath(ctx *fasthttp.RequestCtx) {
-	ctx.SetContentType("text/html; charset=utf-8")
-	ctx.SetBodyString(utils.HandleRootPath())
+func handleRootPath(ctx *fasthttp.RequestCtx, h *API) {
+	h.sendHTML(ctx, utils.HandleRootPath())
 }
 
 func handleAPIEndpoints(ctx *fasthttp.RequestCtx, method, path string, h *API) bool {
@@ -1176,6 +1875,17 @@ func handleAPIEndpoints(ctx *fasthttp.RequestCtx, method, path string, h *API) b
 			h.Ready(ctx)
 			return true
 		}
+	case "/live":
+		if method == "GET" {
+			h.Live(ctx)
+			return true
+		}
+	case "/admin/drain":
+		switch method {
+		case "GET", "POST", "DELETE":
+			h.DrainSwitch(ctx, method)
+			return true
+		}
 	case "/metrics":
 		if method == "GET" {
 			h.Metrics(ctx)
@@ -1183,12 +1893,28 @@ func handleAPIEndpoints(ctx *fasthttp.RequestCtx, method, path string, h *API) b
 		}
 	case "/repos":
 		if method == "GET" {
-			h.ListRepos(ctx)
+			if h.authorize(ctx, "", access.ClassDownload) {
+				h.ListRepos(ctx)
+			}
 			return true
 		} else if method == "POST" {
 			h.CreateRepo(ctx)
 			return true
 		}
+	case "/keys":
+		if method == "GET" {
+			h.ListKeys(ctx)
+			return true
+		}
+	}
+
+	if method == "GET" && strings.HasPrefix(path, "/keys/") {
+		h.GetKey(ctx, strings.TrimPrefix(path, "/keys/"))
+		return true
+	}
+
+	if strings.HasPrefix(path, "/api/v1/") {
+		return handleAPIV1(ctx, method, path, h)
 	}
 	return false
 }
@@ -1206,31 +1932,34 @@ func handleRepoListPage(ctx *fasthttp.RequestCtx, h *API) {
 	repos, err := h.repoService.ListRepos(ctx)
 	if err != nil {
 		log.Logger.Debugf("Failed to list repositories: %v", err)
-		ctx.Error("Failed to load repositories", fasthttp.StatusInternalServerError)
+		h.sendJSONError(ctx, "Failed to load repositories", fasthttp.StatusInternalServerError)
+		return
+	}
+
+	if wantsJSON(ctx) {
+		h.writeRepoList(ctx, repos)
 		return
 	}
 
 	// 生成包含类型信息的 HTML 页面
 	html := utils.GenerateRepoListHTMLWithTypes(repos, h.repoService.GetRepoType)
-	ctx.SetContentType("text/html; charset=utf-8")
-	ctx.SetBodyString(html)
+	h.sendHTML(ctx, html)
 }
 
-func handleDirectoryListingNew(ctx *fasthttp.RequestCtx, repoPath, fullPath string) {
+func handleDirectoryListingNew(ctx *fasthttp.RequestCtx, repoPath, fullPath string, h *API) {
 	log.Logger.Debugf("🔍 Direct directory listing: repoPath=%s, fullPath=%s", repoPath, fullPath)
 
 	entries, err := os.ReadDir(fullPath)
 	if err != nil {
 		log.Logger.Debugf("❌ Cannot read directory %s: %v", fullPath, err)
-		ctx.Error("Cannot read directory", fasthttp.StatusInternalServerError)
+		apierr.Write(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Cannot read directory", "")
 		return
 	}
 
 	log.Logger.Debugf("📁 Found %d entries in directory %s", len(entries), fullPath)
 
 	// 生成新的 HTML 目录列表
-	html := utils.GenerateDirectoryHTMLNew(repoPath, entries)
+	html := withReadme(utils.GenerateDirectoryHTMLNew(repoPath, entries), localReadme(fullPath, entries))
 
-	ctx.SetContentType("text/html; charset=utf-8")
-	ctx.SetBodyString(html)
+	h.sendHTML(ctx, html)
 }
\ No newline at end of file
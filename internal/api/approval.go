@@ -0,0 +1,241 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"plus/internal/apierr"
+	"plus/internal/approval"
+	"plus/internal/log"
+	"plus/internal/notify"
+	"plus/internal/service"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetApprovals 设置批准请求库，有受保护仓库时使用
+func (h *API) SetApprovals(s *approval.Store) {
+	h.approvals = s
+}
+
+// protected 仓库配置了 protected，删除和覆盖需要另一位管理员批准
+func (h *API) protected(repoName string) bool {
+	if h.approvals == nil {
+		return false
+	}
+	rc, ok := h.config.RepoConfig(repoName)
+	return ok && rc.Protected
+}
+
+// requestApproval 记录待批准的请求并返回 202
+func (h *API) requestApproval(ctx *fasthttp.RequestCtx, r types.ApprovalRequest) {
+	r.RequestedBy = h.actor(ctx)
+	// 预先生成 ID，以区分新请求和已有的相同请求
+	id, err := approval.NewID()
+	if err == nil {
+		r.ID = id
+		r, err = h.approvals.Create(r)
+	}
+	if err != nil {
+		log.Logger.Errorf("Failed to record approval request for %s on %s: %v", r.Operation, r.Repo, err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to record approval request", err)
+		return
+	}
+	log.Logger.Infof("%s on protected repository %s by %s is waiting for approval %s", r.Operation, r.Repo, r.RequestedBy, r.ID)
+	if r.ID == id {
+		h.notifyApproval(r)
+	}
+	h.sendApproval(ctx, r, fasthttp.StatusAccepted)
+}
+
+func (h *API) sendApproval(ctx *fasthttp.RequestCtx, r types.ApprovalRequest, status int) {
+	response := &types.ApprovalResponse{
+		Status:   types.Status{Status: "success", Code: status},
+		Approval: r,
+	}
+	switch r.State {
+	case approval.StatePending:
+		response.Status.Status = "pending"
+		response.Status.Message = "Waiting for approval by another admin"
+	case approval.StateFailed:
+		response.Status.Status = "error"
+		response.Status.Message = "Approved operation failed: " + r.Error
+	}
+	h.sendJSONResponse(ctx, response, status)
+}
+
+// holdUpload 上传到受保护的仓库，返回保存的文件名。会覆盖已有文件时保存上传并创建批准请求，返回请求 ID
+func (h *API) holdUpload(ctx *fasthttp.RequestCtx, repoName, filename string, reader io.Reader) (string, string, error) {
+	id, err := approval.NewID()
+	if err != nil {
+		return "", "", err
+	}
+	stored, existing, err := h.repoService.UploadProtected(ctx, repoName, id, filename, reader)
+	if err != nil {
+		return "", "", err
+	}
+	if len(existing) == 0 {
+		h.notify(notify.Event{Name: notify.EventProtectedUpload, Repo: repoName, User: h.actor(ctx), Files: []string{stored}})
+		return "", stored, nil
+	}
+	r, err := h.approvals.Create(types.ApprovalRequest{
+		ID:          id,
+		Operation:   approval.OpOverwrite,
+		Repo:        repoName,
+		Files:       existing,
+		RequestedBy: h.actor(ctx),
+	})
+	if err != nil {
+		if err := h.repoService.DropHeld(ctx, repoName, id); err != nil {
+			log.Logger.Warnf("Failed to remove held upload %s: %v", id, err)
+		}
+		return "", "", err
+	}
+	log.Logger.Infof("Overwrite of %v in protected repository %s by %s is waiting for approval %s", existing, repoName, r.RequestedBy, id)
+	h.notifyApproval(r)
+	return id, stored, nil
+}
+
+// notifyApproval 通知新的待批准请求
+func (h *API) notifyApproval(r types.ApprovalRequest) {
+	h.notify(notify.Event{
+		Name:      notify.EventApprovalRequested,
+		Repo:      r.Repo,
+		User:      r.RequestedBy,
+		Operation: r.Operation,
+		Approval:  r.ID,
+		Files:     r.Files,
+	})
+}
+
+// approvalsEnabled 没有受保护仓库时返回 404
+func (h *API) approvalsEnabled(ctx *fasthttp.RequestCtx) bool {
+	if h.approvals == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "No repository is protected", nil)
+		return false
+	}
+	return true
+}
+
+// ListApprovals 批准队列: GET /api/v1/approvals?state=pending，需要管理员令牌
+func (h *API) ListApprovals(ctx *fasthttp.RequestCtx) {
+	if !h.approvalsEnabled(ctx) || !h.requireAdmin(ctx) {
+		return
+	}
+	list := h.approvals.List(string(ctx.QueryArgs().Peek("state")))
+	h.sendJSONResponse(ctx, &types.ApprovalList{
+		Status:    types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Count:     len(list),
+		Approvals: list,
+	}, fasthttp.StatusOK)
+}
+
+// GetApproval 查看批准请求: GET /api/v1/approvals/{id}
+func (h *API) GetApproval(ctx *fasthttp.RequestCtx, id string) {
+	if !h.approvalsEnabled(ctx) || !h.requireAdmin(ctx) {
+		return
+	}
+	r, err := h.approvals.Get(id)
+	if err != nil {
+		h.sendApprovalError(ctx, err)
+		return
+	}
+	h.sendApproval(ctx, r, fasthttp.StatusOK)
+}
+
+// DecideApproval 批准或拒绝: POST /api/v1/approvals/{id}/approve|reject。
+// 批准需要发起人以外的管理员，批准后立即执行；发起人可以拒绝（撤回）自己的请求
+func (h *API) DecideApproval(ctx *fasthttp.RequestCtx, id, action string) {
+	if !h.approvalsEnabled(ctx) {
+		return
+	}
+	r, err := h.approvals.Get(id)
+	if err != nil {
+		if h.requireAdmin(ctx) {
+			h.sendApprovalError(ctx, err)
+		}
+		return
+	}
+	by := h.actor(ctx)
+	p, admin := h.isAdmin(ctx)
+	if !admin && !(action == "reject" && p != nil && p.Name == r.RequestedBy) {
+		h.deny(ctx, p, "Administrator role required")
+		return
+	}
+
+	if action == "reject" {
+		req := &types.ApprovalDecision{}
+		if body := ctx.PostBody(); len(body) > 0 {
+			if err := req.UnmarshalJSON(body); err != nil {
+				h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+				return
+			}
+		}
+		r, err = h.approvals.Reject(id, by, strings.TrimSpace(req.Reason))
+		if err != nil {
+			h.sendApprovalError(ctx, err)
+			return
+		}
+		if r.Operation == approval.OpOverwrite {
+			if err := h.repoService.DropHeld(ctx, r.Repo, r.ID); err != nil {
+				log.Logger.Warnf("Failed to remove held upload %s: %v", r.ID, err)
+			}
+		}
+		log.Logger.Infof("Approval %s (%s on %s) rejected by %s", r.ID, r.Operation, r.Repo, by)
+		h.sendApproval(ctx, r, fasthttp.StatusOK)
+		return
+	}
+
+	if r, err = h.approvals.Approve(id, by); err != nil {
+		h.sendApprovalError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Approval %s (%s on %s) approved by %s", r.ID, r.Operation, r.Repo, by)
+	execErr := h.executeApproval(ctx, r)
+	if execErr != nil {
+		log.Logger.Errorf("Approved %s on %s failed: %v", r.Operation, r.Repo, execErr)
+	}
+	if r, err = h.approvals.Complete(id, execErr); err != nil {
+		log.Logger.Errorf("Failed to record the result of approval %s: %v", id, err)
+	}
+	status := fasthttp.StatusOK
+	switch {
+	case errors.Is(execErr, service.ErrLegalHold):
+		status = fasthttp.StatusConflict
+	case execErr != nil:
+		status = fasthttp.StatusInternalServerError
+	}
+	h.sendApproval(ctx, r, status)
+}
+
+// executeApproval 执行已批准的操作
+func (h *API) executeApproval(ctx *fasthttp.RequestCtx, r types.ApprovalRequest) error {
+	switch r.Operation {
+	case approval.OpDeleteRepo:
+		return h.repoService.DeleteRepo(ctx, r.Repo)
+	case approval.OpOverwrite:
+		_, err := h.repoService.ReleaseHeld(ctx, r.Repo, r.ID)
+		return err
+	case approval.OpPublish:
+		_, _, err := h.repoService.Publish(ctx, r.Repo, r.Changeset)
+		return err
+	}
+	return fmt.Errorf("unknown operation %q", r.Operation)
+}
+
+func (h *API) sendApprovalError(ctx *fasthttp.RequestCtx, err error) {
+	switch {
+	case errors.Is(err, approval.ErrNotFound):
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Approval request not found", nil)
+	case errors.Is(err, approval.ErrDecided):
+		h.sendError(ctx, fasthttp.StatusConflict, apierr.CodeConflict, "Approval request was already decided", nil)
+	case errors.Is(err, approval.ErrSelfApproval):
+		h.sendError(ctx, fasthttp.StatusForbidden, apierr.CodeForbidden, "Requests must be approved by a different admin", nil)
+	default:
+		log.Logger.Errorf("Approval request failed: %v", err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Approval request failed", err)
+	}
+}
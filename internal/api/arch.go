@@ -0,0 +1,49 @@
+package api
+
+import (
+	"sort"
+	"strings"
+
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// archFilter 读取 arch 参数（可重复，也可用逗号分隔，如 aarch64,noarch），没有时返回 nil
+func archFilter(args *fasthttp.Args) map[string]bool {
+	var arches map[string]bool
+	for _, v := range args.PeekMulti("arch") {
+		for _, arch := range strings.Split(string(v), ",") {
+			if arch = strings.TrimSpace(arch); arch != "" {
+				if arches == nil {
+					arches = make(map[string]bool)
+				}
+				arches[arch] = true
+			}
+		}
+	}
+	return arches
+}
+
+// archStats 按架构统计包的数量和总大小，按架构名排序，没有架构的文件不统计
+func archStats(packages []types.PackageInfo) []types.ArchStats {
+	byArch := make(map[string]*types.ArchStats)
+	for _, pkg := range packages {
+		if pkg.Arch == "" {
+			continue
+		}
+		stats := byArch[pkg.Arch]
+		if stats == nil {
+			stats = &types.ArchStats{Arch: pkg.Arch}
+			byArch[pkg.Arch] = stats
+		}
+		stats.PackageCount++
+		stats.TotalSize += pkg.Size
+	}
+	result := make([]types.ArchStats, 0, len(byArch))
+	for _, stats := range byArch {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Arch < result[j].Arch })
+	return result
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// maxArchiveFiles 一个归档最多包含的文件数
+const maxArchiveFiles = 10000
+
+// archiveFormats 支持的归档格式及其 Content-Type
+var archiveFormats = map[string]string{
+	"tar": "application/x-tar",
+	"zip": "application/zip",
+}
+
+// archiveEntry 归档中的一个文件
+type archiveEntry struct {
+	name    string // 归档内的名称
+	path    string // 相对仓库根目录的路径
+	size    int64
+	modTime time.Time
+}
+
+// Archive 把仓库中的多个文件或一个目录打包下载: POST /repo/{repo}/archive。
+// 请求体为 {"paths": [...]} 或 {"dir": "..."}，也可以用 GET 和查询参数 path（可重复）、dir、format。
+// 归档边读边写，不在服务端生成临时文件
+func (h *API) Archive(ctx *fasthttp.RequestCtx, repoName string) {
+	req := &types.ArchiveRequest{}
+	if ctx.IsPost() {
+		if err := req.UnmarshalJSON(ctx.PostBody()); err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+			return
+		}
+	} else {
+		args := ctx.QueryArgs()
+		for _, p := range args.PeekMulti("path") {
+			req.Paths = append(req.Paths, string(p))
+		}
+		req.Dir = string(args.Peek("dir"))
+		req.Format = string(args.Peek("format"))
+	}
+	if req.Format == "" {
+		req.Format = "tar"
+	}
+	contentType, ok := archiveFormats[req.Format]
+	if !ok {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, fmt.Sprintf("Unsupported archive format %q, use tar or zip", req.Format), nil)
+		return
+	}
+	if (len(req.Paths) == 0) == (req.Dir == "") {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, "Specify either paths or dir", nil)
+		return
+	}
+
+	if _, err := h.repoService.GetRepoType(ctx, repoName); err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	files, err := h.repoService.ListFiles(ctx, repoName)
+	if err != nil {
+		log.Logger.Errorf("Failed to list files of %s: %v", repoName, err)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to list files", err)
+		return
+	}
+
+	entries, name, err := archiveEntries(repoName, files, req)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Archive contents not found", err)
+		return
+	}
+	if len(entries) > maxArchiveFiles {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, fmt.Sprintf("Archive would contain %d files, the limit is %d", len(entries), maxArchiveFiles), nil)
+		return
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+
+	log.Logger.Debugf("Building %s archive of %s: %d files, %d bytes", req.Format, repoName, len(entries), total)
+
+	ctx.Response.Header.Set("Content-Type", contentType)
+	ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", name, req.Format))
+	ctx.Response.Header.Set("X-Archive-Files", fmt.Sprintf("%d", len(entries)))
+	ctx.Response.Header.Set("X-Archive-Size", fmt.Sprintf("%d", total))
+	ctx.SetStatusCode(fasthttp.StatusOK)
+
+	format := req.Format
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.writeArchive(w, format, repoName, entries); err != nil {
+			log.Logger.Warnf("Archive stream for %s aborted: %v", repoName, err)
+		}
+	})
+}
+
+// archiveEntries 按请求选出文件并返回下载文件名（不含扩展名）。
+// 按 dir 打包时名称相对 dir 的上一级，解压后得到该目录；按 paths 打包时使用相对仓库根目录的路径
+func archiveEntries(repoName string, files []types.Artifact, req *types.ArchiveRequest) ([]archiveEntry, string, error) {
+	byPath := make(map[string]types.Artifact, len(files))
+	for _, f := range files {
+		byPath[f.Path] = f
+	}
+	entry := func(f types.Artifact, name string) archiveEntry {
+		modTime, _ := time.Parse(time.RFC3339, f.ModTime)
+		return archiveEntry{name: name, path: f.Path, size: f.Size, modTime: modTime}
+	}
+
+	var entries []archiveEntry
+	if req.Dir != "" {
+		dir := strings.Trim(path.Clean("/"+req.Dir), "/")
+		name := utils.RepoID(repoName)
+		prefix := ""
+		if dir != "" {
+			name, prefix = path.Base(dir), dir+"/"
+		}
+		parent := strings.TrimSuffix(prefix, path.Base(dir)+"/")
+		for _, f := range files {
+			if strings.HasPrefix(f.Path, prefix) {
+				entries = append(entries, entry(f, strings.TrimPrefix(f.Path, parent)))
+			}
+		}
+		if len(entries) == 0 {
+			return nil, "", fmt.Errorf("directory %s is empty or does not exist", req.Dir)
+		}
+		return entries, name, nil
+	}
+
+	seen := make(map[string]bool, len(req.Paths))
+	for _, p := range req.Paths {
+		clean := strings.TrimPrefix(path.Clean("/"+p), "/")
+		f, ok := byPath[clean]
+		if !ok {
+			return nil, "", fmt.Errorf("file %s does not exist", p)
+		}
+		if !seen[clean] {
+			seen[clean] = true
+			entries = append(entries, entry(f, clean))
+		}
+	}
+	return entries, utils.RepoID(repoName), nil
+}
+
+// writeArchive 依次读取文件写出 tar 或 zip，zip 中的文件不压缩
+func (h *API) writeArchive(w io.Writer, format, repoName string, entries []archiveEntry) error {
+	if format == "zip" {
+		zw := zip.NewWriter(w)
+		for _, e := range entries {
+			fw, err := zw.CreateHeader(&zip.FileHeader{Name: e.name, Method: zip.Store, Modified: e.modTime})
+			if err != nil {
+				return err
+			}
+			if err := h.copyArchiveFile(fw, repoName, e); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	}
+
+	tw := tar.NewWriter(w)
+	for _, e := range entries {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    e.name,
+			Mode:    0644,
+			Size:    e.size,
+			ModTime: e.modTime,
+		}); err != nil {
+			return err
+		}
+		if err := h.copyArchiveFile(tw, repoName, e); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func (h *API) copyArchiveFile(w io.Writer, repoName string, e archiveEntry) error {
+	reader, err := h.repoService.OpenFile(context.Background(), repoName, e.path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", e.path, err)
+	}
+	defer reader.Close()
+	if _, err := io.CopyN(w, reader, e.size); err != nil {
+		return fmt.Errorf("failed to write %s: %w", e.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"errors"
+	"io/fs"
+
+	"plus/internal/apierr"
+	"plus/internal/attest"
+	"plus/internal/authz"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetAttestationKeys 设置 /api/v1/attestation-keys 列出的公钥
+func (h *API) SetAttestationKeys(v *attest.Verifier) {
+	h.attestKeys = v
+}
+
+// Attestations 制品的来源证明: GET/POST /repo/{repo}/package/{file}/attestations。
+// POST 的请求体为 DSSE 封装的 in-toto 声明，如 cosign attest 生成的内容
+func (h *API) Attestations(ctx *fasthttp.RequestCtx, repoName, name, method string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	key := service.NoteKey(repoType, name)
+
+	switch method {
+	case "GET":
+		list, err := h.repoService.Attestations(ctx, repoName, key)
+		if err != nil {
+			h.attestationFailed(ctx, repoName, err)
+			return
+		}
+		response := &types.AttestationList{
+			Status:       types.Status{Status: "success", Code: fasthttp.StatusOK},
+			Repo:         repoName,
+			File:         key,
+			Attestations: []types.Attestation{},
+		}
+		for _, a := range list {
+			response.Attestations = append(response.Attestations, attestationInfo(repoName, name, a))
+		}
+		h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+
+	case "POST":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpAttest, Repo: repoName, RepoType: repoType, Path: key}) {
+			return
+		}
+		a, err := h.repoService.AddAttestation(ctx, repoName, key, ctx.PostBody(), h.actor(ctx))
+		if err != nil {
+			h.attestationFailed(ctx, repoName, err)
+			return
+		}
+		log.Logger.Infof("Attestation %s (%s, verified: %v) added to %s in %s by %s",
+			a.ID, a.PredicateType, a.Verified, key, repoName, a.By)
+		h.sendJSONResponse(ctx, &types.AttestationResponse{
+			Status:      types.Status{Status: "success", Code: fasthttp.StatusCreated},
+			Repo:        repoName,
+			File:        key,
+			Attestation: attestationInfo(repoName, name, a),
+		}, fasthttp.StatusCreated)
+	}
+}
+
+// Attestation 下载或删除一个来源证明: GET/DELETE /repo/{repo}/package/{file}/attestations/{id}。
+// GET 返回上传的原始 DSSE 封装，可以直接交给 cosign verify-attestation 等工具验证
+func (h *API) Attestation(ctx *fasthttp.RequestCtx, repoName, name, id, method string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	key := service.NoteKey(repoType, name)
+
+	switch method {
+	case "GET":
+		list, err := h.repoService.Attestations(ctx, repoName, key)
+		if err != nil {
+			h.attestationFailed(ctx, repoName, err)
+			return
+		}
+		for _, a := range list {
+			if a.ID == id {
+				ctx.SetContentType("application/json")
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				ctx.SetBody(a.Envelope)
+				return
+			}
+		}
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Attestation not found", nil)
+
+	case "DELETE":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpAttest, Repo: repoName, RepoType: repoType, Path: key}) {
+			return
+		}
+		ok, err := h.repoService.DeleteAttestation(ctx, repoName, key, id)
+		if err != nil {
+			h.attestationFailed(ctx, repoName, err)
+			return
+		}
+		if !ok {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Attestation not found", nil)
+			return
+		}
+		log.Logger.Infof("Attestation %s of %s in %s deleted by %s", id, key, repoName, h.actor(ctx))
+		h.sendSuccess(ctx, "Attestation deleted")
+	}
+}
+
+// ListAttestationKeys 验证来源证明的公钥: GET /api/v1/attestation-keys
+func (h *API) ListAttestationKeys(ctx *fasthttp.RequestCtx) {
+	response := &types.AttestationKeys{
+		Status: types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Keys:   []types.AttestationKey{},
+	}
+	for _, key := range h.attestKeys.Keys() {
+		response.Keys = append(response.Keys, types.AttestationKey{
+			Name:      key.Name,
+			Algorithm: key.Algorithm,
+			PublicKey: key.PEM,
+		})
+	}
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
+
+// attestationInfo 去掉响应中的原始封装，改为下载地址
+func attestationInfo(repoName, name string, a types.Attestation) types.Attestation {
+	a.Envelope = nil
+	a.URL = "/repo/" + repoName + "/package/" + name + "/attestations/" + a.ID
+	return a
+}
+
+func (h *API) attestationFailed(ctx *fasthttp.RequestCtx, repoName string, err error) {
+	switch {
+	case errors.Is(err, attest.ErrInvalid), errors.Is(err, attest.ErrSubjectMismatch):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidAttestation, "Invalid attestation", err)
+		return
+	case errors.Is(err, attest.ErrUnverified):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnverified, "Attestation signature not verified", err)
+		return
+	case errors.Is(err, fs.ErrNotExist):
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodePackageNotFound, "Package not found", nil)
+		return
+	}
+	log.Logger.Errorf("Attestation request for %s failed: %v", repoName, err)
+	if h.storageUnavailable(ctx, err) {
+		return
+	}
+	h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Attestation request failed", err)
+}
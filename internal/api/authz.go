@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+
+	"plus/internal/apierr"
+	"plus/internal/authz"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetAuthz 设置写操作前调用的授权 webhook
+func (h *API) SetAuthz(w *authz.Webhook) {
+	h.authz = w
+}
+
+// checkWrite 配置了授权 webhook 时询问能否执行写操作，在 authorize 之后调用。
+// 不允许时写入 403，webhook 不可用时写入 503，并返回 false
+func (h *API) checkWrite(ctx *fasthttp.RequestCtx, input types.AuthzInput) bool {
+	if h.authz == nil {
+		return true
+	}
+	p, _ := h.principal(ctx)
+	input.Roles = []string{}
+	if p != nil {
+		input.User = p.Name
+		input.Roles = append(input.Roles, p.Roles...)
+	}
+	input.Method = string(ctx.Method())
+	input.ClientIP = ctx.RemoteIP().String()
+
+	allowed, reason, err := h.authz.Check(ctx, input)
+	if err != nil {
+		log.Logger.Errorf("Authorization check for %s on %s failed: %v", input.Operation, input.Repo, err)
+		h.sendError(ctx, fasthttp.StatusServiceUnavailable, apierr.CodeAuthzUnavailable, "Authorization service unavailable", err)
+		return false
+	}
+	if !allowed {
+		if reason == "" {
+			reason = fmt.Sprintf("%s on %s denied by authorization policy", input.Operation, input.Repo)
+		}
+		log.Logger.Infof("Authorization webhook denied %s on %s for %s: %s", input.Operation, input.Repo, principalName(p), reason)
+		h.sendError(ctx, fasthttp.StatusForbidden, apierr.CodePolicyDenied, reason, nil)
+		return false
+	}
+	return true
+}
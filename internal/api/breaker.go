@@ -0,0 +1,32 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/pkg/storage/breaker"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetBreaker 设置对象存储熔断器，用于就绪检查和指标
+func (h *API) SetBreaker(b *breaker.Breaker) {
+	h.breaker = b
+}
+
+// storageUnavailable 存储熔断时返回 503 和 Retry-After，err 不是熔断错误时返回 false 由调用方处理
+func (h *API) storageUnavailable(ctx *fasthttp.RequestCtx, err error) bool {
+	var open *breaker.OpenError
+	if !errors.As(err, &open) {
+		return false
+	}
+	seconds := int64((open.RetryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	ctx.Response.Header.Set("Retry-After", strconv.FormatInt(seconds, 10))
+	h.sendError(ctx, fasthttp.StatusServiceUnavailable, apierr.CodeStorageUnavailable, "Storage temporarily unavailable", nil)
+	return true
+}
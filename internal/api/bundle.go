@@ -0,0 +1,162 @@
+package api
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/deps"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+const bundleManifestName = "bundle.json"
+
+// BuildBundle 生成离线安装包集合: POST /api/v1/repos/{repo}/bundle
+// 解析请求包的依赖闭包，以 tar 流返回所有需要的 RPM/DEB 及 bundle.json 清单
+func (h *API) BuildBundle(ctx *fasthttp.RequestCtx, repoName string) {
+	req := &types.BundleRequest{}
+	if err := req.UnmarshalJSON(ctx.PostBody()); err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+		return
+	}
+	if len(req.Packages) == 0 {
+		h.sendJSONError(ctx, "At least one package name is required", fasthttp.StatusBadRequest)
+		return
+	}
+
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	if repoType != "rpm" && repoType != "deb" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, "Bundles are only available for rpm and deb repositories", nil)
+		return
+	}
+
+	res, err := h.repoService.ResolveBundle(ctx, repoName, req.Packages, req.Arch)
+	if err != nil {
+		log.Logger.Debugf("Bundle resolution failed for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to resolve bundle", err)
+		return
+	}
+
+	manifest := newBundleManifest(repoName, req, res)
+
+	if len(res.Missing) > 0 || (req.Strict && len(res.Unresolved) > 0) {
+		manifest.Status.Status = "failed"
+		manifest.Status.Message = "Bundle cannot be resolved completely"
+		manifest.Status.Code = fasthttp.StatusUnprocessableEntity
+		h.sendJSONResponse(ctx, manifest, fasthttp.StatusUnprocessableEntity)
+		return
+	}
+
+	manifestData, err := manifest.MarshalJSON()
+	if err != nil {
+		h.sendJSONError(ctx, "Failed to encode bundle manifest", fasthttp.StatusInternalServerError)
+		return
+	}
+
+	log.Logger.Debugf("Building bundle for %s: %d packages, %d bytes", repoName, manifest.Count, manifest.TotalSize)
+
+	ctx.Response.Header.Set("Content-Type", "application/x-tar")
+	ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-bundle.tar", utils.RepoID(repoName)))
+	ctx.Response.Header.Set("X-Bundle-Packages", fmt.Sprintf("%d", manifest.Count))
+	ctx.SetStatusCode(fasthttp.StatusOK)
+
+	packages := res.Packages
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.writeBundle(w, repoName, manifestData, packages); err != nil {
+			log.Logger.Warnf("Bundle stream for %s aborted: %v", repoName, err)
+		}
+	})
+}
+
+func newBundleManifest(repoName string, req *types.BundleRequest, res *deps.Resolution) *types.BundleManifest {
+	manifest := &types.BundleManifest{
+		Status:     types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:       repoName,
+		Requested:  req.Packages,
+		Packages:   []types.BundleItem{},
+		Missing:    res.Missing,
+		Unresolved: res.Unresolved,
+	}
+	for _, p := range res.Packages {
+		manifest.Packages = append(manifest.Packages, types.BundleItem{
+			Name:    p.Name,
+			Version: p.Version,
+			Arch:    p.Arch,
+			File:    path.Base(p.Location),
+			Size:    p.Size,
+		})
+		manifest.TotalSize += p.Size
+	}
+	manifest.Count = len(manifest.Packages)
+	return manifest
+}
+
+// writeBundle 写出 tar：先写清单，再按解析顺序写入包文件（平铺在根目录）
+func (h *API) writeBundle(w io.Writer, repoName string, manifest []byte, packages []*deps.Package) error {
+	tw := tar.NewWriter(w)
+	now := time.Now()
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    bundleManifestName,
+		Mode:    0644,
+		Size:    int64(len(manifest)),
+		ModTime: now,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return err
+	}
+
+	for _, p := range packages {
+		if err := h.writeBundleFile(tw, repoName, p, now); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func (h *API) writeBundleFile(tw *tar.Writer, repoName string, p *deps.Package, modTime time.Time) error {
+	reader, err := h.repoService.OpenPackageFile(context.Background(), repoName, p.Location)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", p.Location, err)
+	}
+	defer reader.Close()
+
+	// 以实际文件大小为准，元数据中的大小可能过期
+	size := p.Size
+	if f, ok := reader.(*os.File); ok {
+		if info, err := f.Stat(); err == nil {
+			size = info.Size()
+			modTime = info.ModTime()
+		}
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    path.Base(p.Location),
+		Mode:    0644,
+		Size:    size,
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(tw, reader, size); err != nil {
+		return fmt.Errorf("failed to write %s: %w", p.Location, err)
+	}
+	return nil
+}
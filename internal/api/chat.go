@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+
+	"plus/internal/apierr"
+	"plus/internal/chat"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetChat 设置聊天 webhook 库，配置了 database-path 时使用
+func (h *API) SetChat(s *chat.Store) {
+	h.chat = s
+}
+
+// chatEnabled 没有聊天 webhook 库时返回 404，之后要求管理员令牌
+func (h *API) chatEnabled(ctx *fasthttp.RequestCtx) bool {
+	if h.chat == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "Chat webhooks require database-path", nil)
+		return false
+	}
+	return h.requireAdmin(ctx)
+}
+
+func (h *API) sendChatError(ctx *fasthttp.RequestCtx, err error) {
+	switch {
+	case errors.Is(err, chat.ErrNotFound):
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Chat webhook not found", nil)
+	case errors.Is(err, chat.ErrInvalid):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, err.Error(), nil)
+	default:
+		log.Logger.Errorf("Chat webhook request failed: %v", err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Chat webhook request failed", err)
+	}
+}
+
+// ListChatWebhooks 聊天 webhook 列表: GET /api/v1/chat-webhooks，地址只显示主机
+func (h *API) ListChatWebhooks(ctx *fasthttp.RequestCtx) {
+	if !h.chatEnabled(ctx) {
+		return
+	}
+	list := h.chat.List()
+	for i := range list {
+		list[i] = chat.Redact(list[i])
+	}
+	h.sendJSONResponse(ctx, &types.ChatWebhookList{
+		Status:   types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Count:    len(list),
+		Webhooks: list,
+	}, fasthttp.StatusOK)
+}
+
+// GetChatWebhook 聊天 webhook 详情: GET /api/v1/chat-webhooks/{id}
+func (h *API) GetChatWebhook(ctx *fasthttp.RequestCtx, id string) {
+	if !h.chatEnabled(ctx) {
+		return
+	}
+	w, err := h.chat.Get(id)
+	if err != nil {
+		h.sendChatError(ctx, err)
+		return
+	}
+	h.sendChatWebhook(ctx, w, fasthttp.StatusOK)
+}
+
+// PutChatWebhook 创建（POST /api/v1/chat-webhooks）或替换（PUT /api/v1/chat-webhooks/{id}）聊天 webhook
+func (h *API) PutChatWebhook(ctx *fasthttp.RequestCtx, id string) {
+	if !h.chatEnabled(ctx) {
+		return
+	}
+	req := types.ChatWebhook{}
+	if err := req.UnmarshalJSON(ctx.PostBody()); err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+		return
+	}
+
+	var w types.ChatWebhook
+	var err error
+	status := fasthttp.StatusOK
+	if id == "" {
+		req.CreatedBy = h.actor(ctx)
+		w, err = h.chat.Create(req)
+		status = fasthttp.StatusCreated
+	} else {
+		w, err = h.chat.Update(id, req)
+	}
+	if err != nil {
+		h.sendChatError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Chat webhook %s (%s) saved by %s", w.Name, w.ID, h.actor(ctx))
+	h.sendChatWebhook(ctx, w, status)
+}
+
+// DeleteChatWebhook 删除聊天 webhook: DELETE /api/v1/chat-webhooks/{id}
+func (h *API) DeleteChatWebhook(ctx *fasthttp.RequestCtx, id string) {
+	if !h.chatEnabled(ctx) {
+		return
+	}
+	if err := h.chat.Delete(id); err != nil {
+		h.sendChatError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Chat webhook %s deleted by %s", id, h.actor(ctx))
+	h.sendSuccess(ctx, "Chat webhook deleted")
+}
+
+// TestChatWebhook 发送测试消息: POST /api/v1/chat-webhooks/{id}/test
+func (h *API) TestChatWebhook(ctx *fasthttp.RequestCtx, id string) {
+	if !h.chatEnabled(ctx) {
+		return
+	}
+	if err := h.chat.Test(ctx, id); err != nil {
+		if errors.Is(err, chat.ErrNotFound) {
+			h.sendChatError(ctx, err)
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusBadGateway, apierr.CodeUpstreamFailed, "Chat webhook failed: "+err.Error(), nil)
+		return
+	}
+	h.sendSuccess(ctx, "Test message sent")
+}
+
+func (h *API) sendChatWebhook(ctx *fasthttp.RequestCtx, w types.ChatWebhook, status int) {
+	h.sendJSONResponse(ctx, &types.ChatWebhookResponse{
+		Status:  types.Status{Status: "success", Code: status},
+		Webhook: chat.Redact(w),
+	}, status)
+}
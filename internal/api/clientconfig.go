@@ -0,0 +1,112 @@
+package api
+
+import (
+	"fmt"
+
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// baseURL 返回客户端访问本服务使用的地址，考虑 external-url 和可信代理的 X-Forwarded-Proto/Host
+func (h *API) baseURL(ctx *fasthttp.RequestCtx) string {
+	return h.forwarded.BaseURL(ctx)
+}
+
+// clientRepoConfig 汇总生成客户端配置所需的仓库信息，地址以 base 开头
+func (h *API) clientRepoConfig(base, repoName string) utils.ClientRepoConfig {
+	id := utils.RepoID(repoName)
+
+	c := utils.ClientRepoConfig{
+		ID:      id,
+		Name:    "Plus repository " + repoName,
+		BaseURL: fmt.Sprintf("%s/repo/%s/files/", base, repoName),
+	}
+
+	if rc, ok := h.config.RepoConfig(repoName); ok && rc.Description != "" {
+		c.Name = rc.Description
+	}
+
+	if h.keyring != nil {
+		if _, ok := h.keyring.ForRepo(h.config, repoName); ok {
+			c.GPGKey = fmt.Sprintf("%s/repo/%s/gpg-key", base, repoName)
+			// 只有配置了私钥时 Release 才有签名，apt 才能按 signed-by 校验
+			if h.keyring.ClearSignForRepo(h.config, repoName) != nil {
+				c.KeyPath = fmt.Sprintf("/etc/apt/keyrings/%s.gpg", id)
+			}
+		}
+	}
+
+	return c
+}
+
+// GetRepoClientConfig 生成客户端仓库配置文件: GET /repo/{repo}/config?format=yum|apt
+func (h *API) GetRepoClientConfig(ctx *fasthttp.RequestCtx, repoName string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+
+	format := string(ctx.QueryArgs().Peek("format"))
+	if format == "" {
+		switch repoType {
+		case "rpm":
+			format = "yum"
+		case "deb":
+			format = "apt"
+		}
+	}
+
+	c := h.clientRepoConfig(h.baseURL(ctx), repoName)
+
+	var content, filename string
+	switch format {
+	case "yum", "dnf":
+		content = utils.GenerateYumRepoFile(c)
+		filename = c.ID + ".repo"
+	case "apt":
+		content = utils.GenerateAptListFile(c)
+		filename = c.ID + ".list"
+	default:
+		h.sendJSONError(ctx, "Unsupported config format. Must be one of: yum, apt", fasthttp.StatusBadRequest)
+		return
+	}
+
+	ctx.Response.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("inline; filename=%s", filename))
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetBodyString(content)
+}
+
+// GetRepoSetupScript 生成一键配置脚本: GET /repo/{repo}/setup.sh
+func (h *API) GetRepoSetupScript(ctx *fasthttp.RequestCtx, repoName string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+
+	if repoType != "rpm" && repoType != "deb" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, "Setup script is only available for rpm and deb repositories", nil)
+		return
+	}
+
+	// 脚本以 root 运行，地址只使用配置的 external-url，不使用请求的 Host 头
+	base := h.forwarded.ExternalURL()
+	if base == "" {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "Setup script requires external-url to be configured", nil)
+		return
+	}
+
+	script := utils.GenerateSetupScript(repoName, repoType, h.clientRepoConfig(base, repoName))
+
+	ctx.Response.Header.Set("Content-Type", "text/x-shellscript; charset=utf-8")
+	ctx.Response.Header.Set("Content-Disposition", "inline; filename=setup.sh")
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetBodyString(script)
+}
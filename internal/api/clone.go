@@ -0,0 +1,70 @@
+package api
+
+import (
+	"strings"
+
+	"plus/internal/access"
+	"plus/internal/apierr"
+	"plus/internal/authz"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// CloneRepo 克隆仓库: POST /api/v1/repos/{repo}/clone {"name": "el9-release-1.2"}
+// 新仓库与源仓库共享存储中的文件（硬链接或服务端复制），包含已生成的元数据
+func (h *API) CloneRepo(ctx *fasthttp.RequestCtx, source string) {
+	req := &types.CloneRequest{}
+	if err := req.UnmarshalJSON(ctx.PostBody()); err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+		return
+	}
+	name := strings.Trim(req.Name, "/")
+	if name == "" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Repository name is required", nil)
+		return
+	}
+	if !utils.IsValidRepoName(name) || name == source {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "Invalid repository path. Use only letters, numbers, hyphens, underscores and forward slashes", nil)
+		return
+	}
+
+	repoType, err := h.repoService.GetRepoType(ctx, source)
+	if err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", source, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	if !h.authorize(ctx, name, access.ClassUpload) {
+		return
+	}
+	if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpClone, Repo: name, RepoType: repoType, Source: source}) {
+		return
+	}
+	if _, err := h.repoService.GetRepoType(ctx, name); err == nil {
+		h.sendError(ctx, fasthttp.StatusConflict, apierr.CodeConflict, "Repository "+name+" already exists", nil)
+		return
+	}
+
+	files, size, err := h.repoService.CloneRepo(ctx, source, name)
+	if err != nil {
+		log.Logger.Errorf("Failed to clone %s to %s: %v", source, name, err)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to clone repository", err)
+		return
+	}
+
+	resp := &types.CloneResult{
+		Status: types.Status{Status: "success", Message: h.localize(ctx, "Repository cloned successfully"), Code: fasthttp.StatusCreated},
+		Source: source,
+		Repo:   name,
+		Type:   repoType,
+		Files:  files,
+		Size:   size,
+	}
+	h.sendJSONResponse(ctx, resp, fasthttp.StatusCreated)
+}
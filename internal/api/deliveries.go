@@ -0,0 +1,103 @@
+package api
+
+import (
+	"errors"
+
+	"plus/internal/apierr"
+	"plus/internal/delivery"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetDeliveries 设置投递队列，配置了 database-path 时使用
+func (h *API) SetDeliveries(q *delivery.Queue) {
+	h.deliveries = q
+}
+
+// deliveriesEnabled 没有投递队列时返回 404，之后要求管理员令牌
+func (h *API) deliveriesEnabled(ctx *fasthttp.RequestCtx) bool {
+	if h.deliveries == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "The delivery queue requires database-path", nil)
+		return false
+	}
+	return h.requireAdmin(ctx)
+}
+
+// ListDeliveries 待投递和失败的投递: GET /api/v1/deliveries?state=pending|dead
+func (h *API) ListDeliveries(ctx *fasthttp.RequestCtx) {
+	if !h.deliveriesEnabled(ctx) {
+		return
+	}
+	state := string(ctx.QueryArgs().Peek("state"))
+	if state != "" && state != delivery.StatePending && state != delivery.StateDead {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, "state must be pending or dead", nil)
+		return
+	}
+	list := h.deliveries.List(state)
+	h.sendJSONResponse(ctx, &types.DeliveryList{
+		Status:     types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Count:      len(list),
+		Deliveries: list,
+	}, fasthttp.StatusOK)
+}
+
+// GetDelivery 投递详情: GET /api/v1/deliveries/{id}
+func (h *API) GetDelivery(ctx *fasthttp.RequestCtx, id string) {
+	if !h.deliveriesEnabled(ctx) {
+		return
+	}
+	d, err := h.deliveries.Get(id)
+	if err != nil {
+		h.sendDeliveryError(ctx, err)
+		return
+	}
+	h.sendDelivery(ctx, d)
+}
+
+// RetryDelivery 立即重新投递: POST /api/v1/deliveries/{id}/retry
+func (h *API) RetryDelivery(ctx *fasthttp.RequestCtx, id string) {
+	if !h.deliveriesEnabled(ctx) {
+		return
+	}
+	d, err := h.deliveries.Retry(id)
+	if err != nil {
+		h.sendDeliveryError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Delivery %s of %s event to %s requeued by %s", id, d.Event.Event, d.Channel, h.actor(ctx))
+	h.sendDelivery(ctx, d)
+}
+
+// DeleteDelivery 放弃投递: DELETE /api/v1/deliveries/{id}
+func (h *API) DeleteDelivery(ctx *fasthttp.RequestCtx, id string) {
+	if !h.deliveriesEnabled(ctx) {
+		return
+	}
+	if err := h.deliveries.Delete(id); err != nil {
+		h.sendDeliveryError(ctx, err)
+		return
+	}
+	log.Logger.Infof("Delivery %s discarded by %s", id, h.actor(ctx))
+	h.sendSuccess(ctx, "Delivery deleted")
+}
+
+func (h *API) sendDeliveryError(ctx *fasthttp.RequestCtx, err error) {
+	switch {
+	case errors.Is(err, delivery.ErrNotFound):
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Delivery not found", nil)
+	case errors.Is(err, delivery.ErrNoChannel):
+		h.sendError(ctx, fasthttp.StatusConflict, apierr.CodeConflict, err.Error(), nil)
+	default:
+		log.Logger.Errorf("Delivery request failed: %v", err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Delivery request failed", err)
+	}
+}
+
+func (h *API) sendDelivery(ctx *fasthttp.RequestCtx, d types.Delivery) {
+	h.sendJSONResponse(ctx, &types.DeliveryResponse{
+		Status:   types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Delivery: d,
+	}, fasthttp.StatusOK)
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"path"
+
+	"plus/internal/apierr"
+	"plus/internal/deps"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// GetPackageDeps 查询包的依赖与被依赖关系: GET /repo/{repo}/package/{file}/deps
+func (h *API) GetPackageDeps(ctx *fasthttp.RequestCtx, repoName, file string) {
+	ix, pkg, ok := h.lookupDependencyPackage(ctx, repoName, file)
+	if !ok {
+		return
+	}
+
+	resp := &types.PackageDependencies{
+		Status:     types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:       repoName,
+		Name:       pkg.Name,
+		Version:    pkg.Version,
+		Arch:       pkg.Arch,
+		File:       path.Base(pkg.Location),
+		Provides:   []string{},
+		Requires:   []types.RequirementInfo{},
+		RequiredBy: []types.DependentInfo{},
+	}
+
+	for _, c := range pkg.Provides {
+		resp.Provides = append(resp.Provides, c.String())
+	}
+
+	for _, req := range pkg.Requires {
+		info := types.RequirementInfo{Requirement: req.String(), Providers: []string{}}
+		seen := make(map[*deps.Package]bool)
+		for _, dep := range req {
+			for _, p := range ix.Providers(dep) {
+				if !seen[p] {
+					seen[p] = true
+					info.Providers = append(info.Providers, path.Base(p.Location))
+				}
+			}
+		}
+		info.Satisfied = len(info.Providers) > 0
+		resp.Requires = append(resp.Requires, info)
+	}
+
+	for _, d := range ix.RequiredBy(pkg) {
+		resp.RequiredBy = append(resp.RequiredBy, dependentInfo(d.Package, d.Requirement, d.Sole, 0))
+	}
+
+	h.sendJSONResponse(ctx, resp, fasthttp.StatusOK)
+}
+
+// GetPackageReverseDeps 删除前的影响分析: GET /repo/{repo}/package/{file}/rdeps
+// 返回删除该包后（递归地）依赖无法满足的所有包
+func (h *API) GetPackageReverseDeps(ctx *fasthttp.RequestCtx, repoName, file string) {
+	ix, pkg, ok := h.lookupDependencyPackage(ctx, repoName, file)
+	if !ok {
+		return
+	}
+
+	resp := &types.ReverseDependencies{
+		Status: types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:   repoName,
+		File:   path.Base(pkg.Location),
+		Broken: []types.DependentInfo{},
+	}
+	for _, b := range ix.Impact(pkg) {
+		resp.Broken = append(resp.Broken, dependentInfo(b.Package, b.Requirement, false, b.Depth))
+	}
+	resp.Count = len(resp.Broken)
+	resp.Safe = resp.Count == 0
+
+	h.sendJSONResponse(ctx, resp, fasthttp.StatusOK)
+}
+
+func (h *API) lookupDependencyPackage(ctx *fasthttp.RequestCtx, repoName, file string) (*deps.Index, *deps.Package, bool) {
+	ix, ok := h.metadataIndex(ctx, repoName, "Dependencies")
+	if !ok {
+		return nil, nil, false
+	}
+
+	pkg, ok := ix.LookupFile(file)
+	if !ok {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodePackageNotFound, "Package not found in repository metadata", nil)
+		return nil, nil, false
+	}
+	return ix, pkg, true
+}
+
+// metadataIndex 返回 rpm/deb 仓库元数据中的包索引，失败时写入错误响应。feature 用于错误信息
+func (h *API) metadataIndex(ctx *fasthttp.RequestCtx, repoName, feature string) (*deps.Index, bool) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return nil, false
+	}
+	if repoType != "rpm" && repoType != "deb" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, feature+" are only available for rpm and deb repositories", nil)
+		return nil, false
+	}
+
+	ix, err := h.repoService.DependencyIndex(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to load dependency index for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeMetadataNotFound, "Repository metadata not available, refresh the repository first", nil)
+		return nil, false
+	}
+	return ix, true
+}
+
+func dependentInfo(p *deps.Package, req deps.Requirement, sole bool, depth int) types.DependentInfo {
+	return types.DependentInfo{
+		Name:         p.Name,
+		Version:      p.Version,
+		Arch:         p.Arch,
+		File:         path.Base(p.Location),
+		Requirement:  req.String(),
+		SoleProvider: sole,
+		Depth:        depth,
+	}
+}
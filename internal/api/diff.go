@@ -0,0 +1,78 @@
+package api
+
+import (
+	"path"
+
+	"plus/internal/apierr"
+	"plus/internal/deps"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// DiffRepos 比较两个 rpm/deb 仓库元数据中的包: GET /api/v1/diff?a=staging&b=prod
+// 用于确认晋级或同步是否完整
+func (h *API) DiffRepos(ctx *fasthttp.RequestCtx, a, b string) {
+	if a == "" || b == "" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, "Both a and b repositories are required", nil)
+		return
+	}
+	typeA, errA := h.repoService.GetRepoType(ctx, a)
+	typeB, errB := h.repoService.GetRepoType(ctx, b)
+	if errA == nil && errB == nil && typeA != typeB {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, "Cannot compare "+typeA+" repository with "+typeB+" repository", nil)
+		return
+	}
+	ixA, ok := h.metadataIndex(ctx, a, "Repository diffs")
+	if !ok {
+		return
+	}
+	ixB, ok := h.metadataIndex(ctx, b, "Repository diffs")
+	if !ok {
+		return
+	}
+
+	d := deps.Compare(ixA, ixB)
+	resp := &types.RepoDiff{
+		Status:     types.Status{Status: "success", Code: fasthttp.StatusOK},
+		A:          a,
+		B:          b,
+		Identical:  len(d.OnlyA) == 0 && len(d.OnlyB) == 0 && len(d.Mismatches) == 0,
+		OnlyInA:    diffPackages(d.OnlyA),
+		OnlyInB:    diffPackages(d.OnlyB),
+		Mismatches: make([]types.DiffMismatch, 0, len(d.Mismatches)),
+	}
+	for _, m := range d.Mismatches {
+		dm := types.DiffMismatch{
+			Name:            m.Name,
+			Arch:            m.Arch,
+			A:               versionStrings(m.A),
+			B:               versionStrings(m.B),
+			ChecksumDiffers: m.ChecksumDiffers,
+		}
+		switch {
+		case m.Newer > 0:
+			dm.Newer = "a"
+		case m.Newer < 0:
+			dm.Newer = "b"
+		}
+		resp.Mismatches = append(resp.Mismatches, dm)
+	}
+	h.sendJSONResponse(ctx, resp, fasthttp.StatusOK)
+}
+
+func diffPackages(pkgs []*deps.Package) []types.DiffPackage {
+	out := make([]types.DiffPackage, 0, len(pkgs))
+	for _, p := range pkgs {
+		out = append(out, types.DiffPackage{Name: p.Name, Version: p.Version, Arch: p.Arch, File: path.Base(p.Location)})
+	}
+	return out
+}
+
+func versionStrings(pkgs []*deps.Package) []string {
+	out := make([]string, 0, len(pkgs))
+	for _, p := range pkgs {
+		out = append(out, p.Version)
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"sync/atomic"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/metrics"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Drain 进入排空状态：/ready 返回 503，响应带 Connection: close，处理中的请求不受影响
+func (h *API) Drain() {
+	if atomic.CompareAndSwapInt64(&h.draining, 0, time.Now().UnixNano()) {
+		log.Logger.Infof("Draining: readiness check now fails, %d requests in flight", metrics.GetMetrics().ActiveRequests)
+	}
+}
+
+// Undrain 退出排空状态
+func (h *API) Undrain() {
+	if atomic.SwapInt64(&h.draining, 0) != 0 {
+		log.Logger.Infof("Drain cancelled, accepting traffic again")
+	}
+}
+
+// Draining 返回是否处于排空状态
+func (h *API) Draining() bool {
+	return atomic.LoadInt64(&h.draining) != 0
+}
+
+func (h *API) drainStatus() *types.DrainStatus {
+	status := &types.DrainStatus{ActiveRequests: metrics.GetMetrics().ActiveRequests}
+	if since := atomic.LoadInt64(&h.draining); since != 0 {
+		status.Draining = true
+		status.Since = time.Unix(0, since).UTC().Format(time.RFC3339)
+	}
+	return status
+}
+
+// Live 存活检查: GET /live，进程能处理请求即返回 200，不检查存储，排空期间也返回 200
+func (h *API) Live(ctx *fasthttp.RequestCtx) {
+	response := &types.Status{
+		Status: "alive",
+		Server: "plus",
+	}
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
+
+// DrainSwitch 排空开关: GET /admin/drain 查询，POST 开始排空，DELETE 取消。
+// 启用认证时 POST 和 DELETE 需要管理员令牌
+func (h *API) DrainSwitch(ctx *fasthttp.RequestCtx, method string) {
+	if (method != "GET" || h.config.Auth.RequireReadAuth) && !h.requireAdmin(ctx) {
+		return
+	}
+	switch method {
+	case "POST":
+		h.Drain()
+	case "DELETE":
+		h.Undrain()
+	}
+	h.sendJSONResponse(ctx, h.drainStatus(), fasthttp.StatusOK)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+
+	"plus/internal/apierr"
+	"plus/internal/eventlog"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetEvents 设置事件日志，配置了 database-path 时使用
+func (h *API) SetEvents(l *eventlog.Log) {
+	h.events = l
+}
+
+// Events 回放事件: GET /api/v1/events?since=<seq>&limit=<n>。
+// 返回 seq 大于 since 的事件，客户端保存响应中的 next 作为下次的 since
+func (h *API) Events(ctx *fasthttp.RequestCtx) {
+	if h.events == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "The event log requires database-path", nil)
+		return
+	}
+	if !h.requireAdmin(ctx) {
+		return
+	}
+
+	args := ctx.QueryArgs()
+	var since int64
+	if args.Has("since") {
+		n, err := args.GetUint("since")
+		if err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, "since must be a non-negative sequence number", err)
+			return
+		}
+		since = int64(n)
+	}
+	limit := eventlog.DefaultLimit
+	if args.Has("limit") {
+		n, err := args.GetUint("limit")
+		if err != nil || n == 0 || n > eventlog.MaxLimit {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, fmt.Sprintf("limit must be between 1 and %d", eventlog.MaxLimit), nil)
+			return
+		}
+		limit = n
+	}
+
+	records, latest, truncated, err := h.events.Read(since, limit)
+	if err != nil {
+		log.Logger.Errorf("Failed to read the event log: %v", err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to read the event log", err)
+		return
+	}
+	next := since
+	if len(records) > 0 {
+		next = records[len(records)-1].Seq
+	}
+	h.sendJSONResponse(ctx, &types.EventList{
+		Status:    types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Events:    records,
+		Next:      next,
+		Latest:    latest,
+		Truncated: truncated,
+	}, fasthttp.StatusOK)
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// uploadExpiry 读取上传的有效期：X-Expire-After 请求头，或 expire_after 查询参数和表单字段，如 "12h"、"7d"。
+// 没有时返回 0
+func (h *API) uploadExpiry(ctx *fasthttp.RequestCtx) (time.Duration, error) {
+	value := string(ctx.Request.Header.Peek("X-Expire-After"))
+	if value == "" {
+		value = h.formValue(ctx, "expire_after")
+	}
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := utils.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid expire_after: %w", err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("expire_after must be positive")
+	}
+	if h.config.Upload.MaxExpireAfter != "" {
+		// 启动时已检查过配置
+		if max, err := utils.ParseDuration(h.config.Upload.MaxExpireAfter); err == nil && ttl > max {
+			return 0, fmt.Errorf("expire_after must not exceed %s", h.config.Upload.MaxExpireAfter)
+		}
+	}
+	return ttl, nil
+}
+
+// saveUploadExpiry 记录上传的文件到期删除的时间，返回到期时间。没有有效期时取消之前上传的同名文件的有效期，返回零值。
+// 上传已完成，失败只记录日志
+func (h *API) saveUploadExpiry(ctx *fasthttp.RequestCtx, repoName, filename string, ttl time.Duration) time.Time {
+	var at time.Time
+	if ttl > 0 {
+		at = time.Now().Add(ttl).Truncate(time.Second)
+	}
+	if err := h.repoService.SetExpiry(ctx, repoName, filename, at); err != nil {
+		log.Logger.Warnf("Failed to save expiry of %s in %s: %v", filename, repoName, err)
+		return time.Time{}
+	}
+	return at
+}
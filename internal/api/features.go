@@ -0,0 +1,84 @@
+package api
+
+import (
+	"errors"
+
+	"plus/internal/apierr"
+	"plus/internal/feature"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetFeatures 设置功能开关
+func (h *API) SetFeatures(s *feature.Set) {
+	h.features = s
+}
+
+// ListFeatures 功能开关列表: GET /api/v1/features
+func (h *API) ListFeatures(ctx *fasthttp.RequestCtx) {
+	if !h.requireAdmin(ctx) {
+		return
+	}
+	list := h.features.List()
+	h.sendJSONResponse(ctx, &types.FeatureList{
+		Status:   types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Count:    len(list),
+		Features: list,
+	}, fasthttp.StatusOK)
+}
+
+// Feature 功能开关: GET/PUT/DELETE /api/v1/features/{name}。
+// PUT 在运行时修改开关，DELETE 删除运行时设置，恢复配置的状态
+func (h *API) Feature(ctx *fasthttp.RequestCtx, name, method string) {
+	if !h.requireAdmin(ctx) {
+		return
+	}
+	var f types.FeatureFlag
+	var err error
+	switch method {
+	case "GET":
+		f, err = h.features.Get(name)
+	case "PUT":
+		req := &types.FeatureRequest{}
+		if err := req.UnmarshalJSON(ctx.PostBody()); err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+			return
+		}
+		if req.Enabled == nil && req.Rollout == nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, "enabled or rollout is required", nil)
+			return
+		}
+		f, err = h.features.Override(name, req.Enabled, req.Rollout, h.actor(ctx))
+		if err == nil {
+			log.Logger.Infof("Feature flag %s set to enabled=%v rollout=%d%% by %s", name, f.Enabled, f.Rollout, f.By)
+		}
+	case "DELETE":
+		f, err = h.features.Reset(name)
+		if err == nil {
+			log.Logger.Infof("Feature flag %s reset to enabled=%v rollout=%d%% by %s", name, f.Enabled, f.Rollout, h.actor(ctx))
+		}
+	}
+	if err != nil {
+		h.featureFailed(ctx, name, err)
+		return
+	}
+	h.sendJSONResponse(ctx, &types.FeatureResponse{
+		Status:  types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Feature: f,
+	}, fasthttp.StatusOK)
+}
+
+func (h *API) featureFailed(ctx *fasthttp.RequestCtx, name string, err error) {
+	switch {
+	case errors.Is(err, feature.ErrNotFound):
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Feature flag not found", nil)
+	case errors.Is(err, feature.ErrInvalid):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, err.Error(), nil)
+	default:
+		// 开关已在内存中修改，只是没有保存
+		log.Logger.Errorf("Failed to save feature flag %s: %v", name, err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Feature flag changed but not saved", err)
+	}
+}
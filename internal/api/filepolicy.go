@@ -0,0 +1,20 @@
+package api
+
+import (
+	"errors"
+
+	"plus/internal/apierr"
+	"plus/internal/filepolicy"
+
+	"github.com/valyala/fasthttp"
+)
+
+// filenameRejected 文件名不符合仓库的规则时返回 400 和规则说明，err 不是该错误时返回 false 由调用方处理
+func (h *API) filenameRejected(ctx *fasthttp.RequestCtx, err error) bool {
+	var v *filepolicy.Violation
+	if !errors.As(err, &v) {
+		return false
+	}
+	h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeFilenameRejected, "File name rejected by the repository policy: "+v.Error(), nil)
+	return true
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"plus/internal/apierr"
+	"plus/internal/log"
+
+	"github.com/valyala/fasthttp"
+)
+
+// CheckRepo 仓库一致性检查: GET /api/v1/repos/{repo}/fsck
+// POST 且带 repair=true 时在发现缺失/孤立包后刷新元数据修复
+func (h *API) CheckRepo(ctx *fasthttp.RequestCtx, repoName string, repair bool) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	if repoType != "rpm" && repoType != "deb" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, "Consistency checks are only available for rpm and deb repositories", nil)
+		return
+	}
+
+	report, err := h.repoService.CheckRepo(ctx, repoName, repair)
+	if err != nil {
+		log.Logger.Errorf("Consistency check failed for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Consistency check failed", err)
+		return
+	}
+
+	report.Status.Code = fasthttp.StatusOK
+	h.sendJSONResponse(ctx, report, fasthttp.StatusOK)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+	"strings"
+
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/types"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// generationHeader 请求旧版本元数据的请求头，也可以使用 ?generation= 参数；响应中为实际使用的版本号
+const generationHeader = "X-Metadata-Generation"
+
+// generationParam 返回请求的元数据版本（版本号或时间），未指定时为空
+func generationParam(ctx *fasthttp.RequestCtx) string {
+	if v := ctx.QueryArgs().Peek("generation"); len(v) > 0 {
+		return string(v)
+	}
+	return string(ctx.Request.Header.Peek(generationHeader))
+}
+
+// ListGenerations 元数据历史: GET /api/v1/repos/{repo}/generations
+func (h *API) ListGenerations(ctx *fasthttp.RequestCtx, repoName string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	if repoType != "rpm" && repoType != "deb" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, "Metadata generations are only kept for rpm and deb repositories", nil)
+		return
+	}
+	generations, keep, err := h.repoService.Generations(ctx, repoName)
+	if err != nil {
+		log.Logger.Errorf("Failed to list metadata generations of %s: %v", repoName, err)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to list metadata generations", err)
+		return
+	}
+	h.sendJSONResponse(ctx, &types.GenerationList{
+		Status:      types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:        repoName,
+		Keep:        keep,
+		Generations: generations,
+	}, fasthttp.StatusOK)
+}
+
+// serveGeneration 返回指定版本中的元数据文件。旧版本不经过元数据缓存，也不允许共享缓存保存
+func (h *API) serveGeneration(ctx *fasthttp.RequestCtx, repoName, generation, filename string) {
+	reader, id, err := h.repoService.GenerationMetadata(ctx, repoName, generation, filename)
+	if err != nil {
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		if errors.Is(err, service.ErrGenerationNotFound) {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeMetadataNotFound, "Metadata generation not found", err)
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeMetadataNotFound, "Metadata not found", nil)
+		return
+	}
+	// reader 由 fasthttp 在响应写完后关闭
+
+	ctx.Response.Header.Set("Content-Type", utils.GetContentType(filename))
+	ctx.Response.Header.Set("Cache-Control", "private, no-store")
+	ctx.Response.Header.Set(generationHeader, id)
+	ctx.SetBodyStream(reader, -1)
+}
+
+// serveDirectGeneration 处理 /{repo}/repodata/... 等直接访问路径上的旧版本元数据请求，
+// cleanPath 不是仓库的元数据文件时返回 false，按普通文件处理
+func (h *API) serveDirectGeneration(ctx *fasthttp.RequestCtx, cleanPath, generation string) bool {
+	repoName := h.repoFor(ctx, cleanPath)
+	if repoName == "" {
+		return false
+	}
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		return false
+	}
+	filename, ok := service.MetadataFileName(repoType, strings.TrimPrefix(cleanPath, repoName+"/"))
+	if !ok {
+		return false
+	}
+	h.serveGeneration(ctx, repoName, generation, filename)
+	return true
+}
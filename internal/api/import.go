@@ -0,0 +1,48 @@
+package api
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ImportRepos 导入服务器上现有的 yum 和 apt 仓库树:
+// POST /api/v1/import {"path": "/var/www/repos", "prefix": "", "regenerate": false, "dry_run": false}
+// 读取服务器本地文件，只允许管理员调用
+func (h *API) ImportRepos(ctx *fasthttp.RequestCtx) {
+	if !h.requireAdmin(ctx) {
+		return
+	}
+	req := &types.ImportRequest{}
+	if err := req.UnmarshalJSON(ctx.PostBody()); err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+		return
+	}
+	if !filepath.IsAbs(req.Path) {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, "An absolute path is required", nil)
+		return
+	}
+
+	report, err := h.repoService.Import(ctx, req.Path, req.Prefix, req.Regenerate, req.DryRun)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Import path not found", nil)
+			return
+		}
+		log.Logger.Errorf("Failed to import %s: %v", req.Path, err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Import failed", err)
+		return
+	}
+	if !req.DryRun {
+		log.Logger.Infof("Import of %s by %s: %d imported, %d failed", req.Path, h.actor(ctx), report.Imported, report.Failed)
+	}
+
+	report.Status.Code = fasthttp.StatusOK
+	h.sendJSONResponse(ctx, report, fasthttp.StatusOK)
+}
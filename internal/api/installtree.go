@@ -0,0 +1,93 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"plus/internal/apierr"
+	"plus/internal/authz"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// UploadTreeFile 上传安装树文件: POST /repo/{repo}/tree/upload
+// 表单字段 file 为文件内容，path 为安装树内的相对路径（如 images/pxeboot/vmlinuz）
+func (h *API) UploadTreeFile(ctx *fasthttp.RequestCtx, repoName string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+
+	if repoType != "rpm" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, "Install trees are only supported in rpm repositories", nil)
+		return
+	}
+
+	fileHeader, err := h.formFile(ctx, "file")
+	if h.rejectUpload(ctx, err) {
+		return
+	}
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeNoFile, "No file uploaded", nil)
+		return
+	}
+
+	treePath := strings.TrimSpace(h.formValue(ctx, "path"))
+	if treePath == "" {
+		treePath = fileHeader.Filename
+	}
+
+	if !utils.IsInstallTreePath(treePath) {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPath, fmt.Sprintf("Invalid install tree path: %s", treePath), nil)
+		return
+	}
+
+	if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpTreeUpload, Repo: repoName, RepoType: repoType, Path: treePath, Size: fileHeader.Size}) {
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.sendJSONError(ctx, "Failed to open uploaded file", fasthttp.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	if err := h.repoService.UploadTreeFile(ctx, repoName, treePath, file); err != nil {
+		log.Logger.Debugf("Install tree upload failed for repo %s, path %s: %v", repoName, treePath, err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Upload failed", err)
+		return
+	}
+
+	h.sendSuccess(ctx, "Install tree file uploaded successfully")
+}
+
+// ValidateInstallTree 校验安装树: GET /repo/{repo}/tree/validate
+func (h *API) ValidateInstallTree(ctx *fasthttp.RequestCtx, repoName string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+
+	if repoType != "rpm" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, "Install trees are only supported in rpm repositories", nil)
+		return
+	}
+
+	report, err := h.repoService.ValidateInstallTree(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Install tree validation failed for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Install tree validation failed", err)
+		return
+	}
+
+	report.Status.Code = fasthttp.StatusOK
+	h.sendJSONResponse(ctx, report, fasthttp.StatusOK)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/scheduler"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ListJobs 后台任务列表: GET /api/v1/jobs，包含每个任务的状态和最近的执行记录
+func (h *API) ListJobs(ctx *fasthttp.RequestCtx) {
+	if h.scheduler == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "Scheduler is not enabled", nil)
+		return
+	}
+	h.sendJSONResponse(ctx, h.scheduler.Status(), fasthttp.StatusOK)
+}
+
+// RunJob 立即执行一次任务: POST /api/v1/jobs/{name}/run。
+// 集群任务只能在领导者上触发，启用认证时需要管理员令牌
+func (h *API) RunJob(ctx *fasthttp.RequestCtx, name string) {
+	if !h.requireAdmin(ctx) {
+		return
+	}
+	if h.scheduler == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "Scheduler is not enabled", nil)
+		return
+	}
+	err := h.scheduler.Trigger(name)
+	switch {
+	case err == nil:
+		log.Logger.Infof("Job %s triggered by %s", name, h.actor(ctx))
+		h.sendJSONResponse(ctx, h.scheduler.Status(), fasthttp.StatusAccepted)
+	case errors.Is(err, scheduler.ErrUnknownJob):
+		h.sendJSONError(ctx, "Job not found: "+name, fasthttp.StatusNotFound)
+	case errors.Is(err, scheduler.ErrNotLeader), errors.Is(err, scheduler.ErrRunning):
+		h.sendJSONError(ctx, err.Error(), fasthttp.StatusConflict)
+	default:
+		h.sendJSONError(ctx, err.Error(), fasthttp.StatusInternalServerError)
+	}
+}
+
+// repoJobs 返回仓库配置中定时任务的状态，没有任务时为空
+func (h *API) repoJobs(repoName string) []types.JobStatus {
+	if h.scheduler == nil || h.config == nil {
+		return nil
+	}
+	rc, ok := h.config.RepoConfig(repoName)
+	if !ok {
+		return nil
+	}
+	var jobs []types.JobStatus
+	for _, jc := range rc.Jobs {
+		if st, ok := h.scheduler.Job(scheduler.RepoJobName(jc.Action, repoName)); ok {
+			jobs = append(jobs, st)
+		}
+	}
+	return jobs
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/signing"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+const pgpKeysContentType = "application/pgp-keys"
+
+func (h *API) SetKeyring(keyring *signing.Keyring) {
+	h.keyring = keyring
+}
+
+// ListKeys 列出所有签名公钥及指纹: GET /keys
+func (h *API) ListKeys(ctx *fasthttp.RequestCtx) {
+	response := &types.KeyList{
+		Status: types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Keys:   []types.KeyInfo{},
+	}
+
+	if h.keyring != nil {
+		for _, key := range h.keyring.List() {
+			response.Keys = append(response.Keys, types.KeyInfo{
+				Name:        key.Name,
+				Fingerprint: key.Fingerprint,
+				KeyID:       key.KeyID,
+				UserIDs:     key.UserIDs,
+				Created:     key.Created.UTC().Format(time.RFC3339),
+				Default:     key.Name == h.keyring.DefaultName(),
+				URL:         "/keys/" + key.Name,
+			})
+		}
+	}
+	response.Count = len(response.Keys)
+
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
+
+// GetKey 下载指定公钥: GET /keys/{name}
+func (h *API) GetKey(ctx *fasthttp.RequestCtx, name string) {
+	name = strings.TrimSuffix(name, ".asc")
+	if h.keyring == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeKeyNotFound, "Key not found", nil)
+		return
+	}
+
+	key, ok := h.keyring.Get(name)
+	if !ok {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeKeyNotFound, "Key not found", nil)
+		return
+	}
+
+	h.serveKey(ctx, key)
+}
+
+// GetRepoGPGKey 下载仓库使用的签名公钥: GET /repo/{repo}/gpg-key
+func (h *API) GetRepoGPGKey(ctx *fasthttp.RequestCtx, repoName string) {
+	if _, err := h.repoService.GetRepoType(ctx, repoName); err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+
+	if h.keyring == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeKeyNotFound, "No signing key configured for repository", nil)
+		return
+	}
+
+	key, ok := h.keyring.ForRepo(h.config, repoName)
+	if !ok {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeKeyNotFound, "No signing key configured for repository", nil)
+		return
+	}
+
+	h.serveKey(ctx, key)
+}
+
+func (h *API) serveKey(ctx *fasthttp.RequestCtx, key *signing.PublicKey) {
+	ctx.Response.Header.Set("Content-Type", pgpKeysContentType)
+	ctx.Response.Header.Set("X-Key-Fingerprint", key.Fingerprint)
+	ctx.Response.Header.Set("Cache-Control", "public, max-age=3600")
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetBody(key.Armored)
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"plus/internal/apierr"
+	"plus/internal/authz"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// maxHoldReasonSize 保留原因的最大长度
+const maxHoldReasonSize = 1024
+
+// legalHeld 制品有保留标记时返回 409，err 不是该错误时返回 false 由调用方处理
+func (h *API) legalHeld(ctx *fasthttp.RequestCtx, err error) bool {
+	if !errors.Is(err, service.ErrLegalHold) {
+		return false
+	}
+	h.sendError(ctx, fasthttp.StatusConflict, apierr.CodeLegalHold, "Artifact is under legal hold", err)
+	return true
+}
+
+// ListLegalHolds 仓库中的保留标记: GET /api/v1/repos/{repo}/holds
+func (h *API) ListLegalHolds(ctx *fasthttp.RequestCtx, repoName string) {
+	if _, err := h.repoService.GetRepoType(ctx, repoName); err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	holds, err := h.repoService.LegalHolds(ctx, repoName)
+	if err != nil {
+		h.legalHoldFailed(ctx, repoName, err)
+		return
+	}
+	h.sendJSONResponse(ctx, &types.LegalHoldList{
+		Status: types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:   repoName,
+		Holds:  holds,
+	}, fasthttp.StatusOK)
+}
+
+// LegalHold 制品的保留标记: GET/PUT/DELETE /api/v1/repos/{repo}/holds/{file}。
+// PUT 的请求体为 {"reason": "..."}，可以为空；标记解除前保留策略、过期和删除仓库都不会删除该制品
+func (h *API) LegalHold(ctx *fasthttp.RequestCtx, repoName, name, method string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	key := service.NoteKey(repoType, name)
+
+	switch method {
+	case "GET":
+		hold, ok, err := h.repoService.LegalHold(ctx, repoName, key)
+		if err != nil {
+			h.legalHoldFailed(ctx, repoName, err)
+			return
+		}
+		if !ok {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Artifact is not held", nil)
+			return
+		}
+		h.sendLegalHold(ctx, repoName, key, hold)
+
+	case "PUT":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpHold, Repo: repoName, RepoType: repoType, Path: key}) {
+			return
+		}
+		req := &types.LegalHoldRequest{}
+		if body := ctx.PostBody(); len(body) > 0 {
+			if err := req.UnmarshalJSON(body); err != nil {
+				h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+				return
+			}
+		}
+		reason := strings.TrimSpace(req.Reason)
+		if len(reason) > maxHoldReasonSize || !utf8.ValidString(reason) {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest,
+				fmt.Sprintf("reason must be UTF-8 text of at most %d bytes", maxHoldReasonSize), nil)
+			return
+		}
+		exists, err := h.repoService.PackageExists(ctx, repoName, key)
+		if err != nil {
+			h.legalHoldFailed(ctx, repoName, err)
+			return
+		}
+		if !exists {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodePackageNotFound, "Package not found", nil)
+			return
+		}
+		hold := types.LegalHold{
+			Reason: reason,
+			By:     h.actor(ctx),
+			Since:  time.Now().UTC().Format(time.RFC3339),
+		}
+		if err := h.repoService.SetLegalHold(ctx, repoName, key, hold); err != nil {
+			h.legalHoldFailed(ctx, repoName, err)
+			return
+		}
+		log.Logger.Infof("Legal hold placed on %s in %s by %s: %s", key, repoName, hold.By, hold.Reason)
+		h.sendLegalHold(ctx, repoName, key, hold)
+
+	case "DELETE":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpRelease, Repo: repoName, RepoType: repoType, Path: key}) {
+			return
+		}
+		ok, err := h.repoService.ReleaseLegalHold(ctx, repoName, key)
+		if err != nil {
+			h.legalHoldFailed(ctx, repoName, err)
+			return
+		}
+		if !ok {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Artifact is not held", nil)
+			return
+		}
+		log.Logger.Infof("Legal hold on %s in %s released by %s", key, repoName, h.actor(ctx))
+		h.sendSuccess(ctx, "Legal hold released")
+	}
+}
+
+func (h *API) sendLegalHold(ctx *fasthttp.RequestCtx, repoName, key string, hold types.LegalHold) {
+	h.sendJSONResponse(ctx, &types.LegalHoldResponse{
+		Status: types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:   repoName,
+		File:   key,
+		Hold:   hold,
+	}, fasthttp.StatusOK)
+}
+
+func (h *API) legalHoldFailed(ctx *fasthttp.RequestCtx, repoName string, err error) {
+	log.Logger.Errorf("Legal hold request for %s failed: %v", repoName, err)
+	if h.storageUnavailable(ctx, err) {
+		return
+	}
+	h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Legal hold request failed", err)
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+
+	"plus/internal/apierr"
+	"plus/internal/license"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+
+	"github.com/valyala/fasthttp"
+)
+
+// licenseRejected 包的许可证被仓库禁止或无法读取时返回 400，err 不是这两种错误时返回 false 由调用方处理
+func (h *API) licenseRejected(ctx *fasthttp.RequestCtx, err error) bool {
+	var v *license.Violation
+	switch {
+	case errors.As(err, &v):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeLicenseDenied, "License rejected by the repository policy: "+v.Error(), nil)
+	case errors.Is(err, license.ErrUnreadable):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPackage, "Cannot read the package license", err)
+	default:
+		return false
+	}
+	return true
+}
+
+// addLicenses 按仓库元数据为 RPM、DEB 包加上许可证，元数据不存在时不处理
+func (h *API) addLicenses(ctx *fasthttp.RequestCtx, repoName string, packages []types.PackageInfo) {
+	if len(packages) == 0 {
+		return
+	}
+	if repoType, _ := h.repoService.GetRepoType(ctx, repoName); repoType != string(repo.RPM) && repoType != string(repo.DEB) {
+		return
+	}
+	ix, err := h.repoService.DependencyIndex(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("No metadata for the licenses of %s: %v", repoName, err)
+		return
+	}
+	for i := range packages {
+		if p, ok := ix.LookupFile(packages[i].Name); ok {
+			packages[i].License = p.License
+		}
+	}
+}
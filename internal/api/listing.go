@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/pkg/repo"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	defaultFileListLimit = 1000
+	maxFileListLimit     = 10000
+)
+
+// wantsJSON 判断目录列表是否返回 JSON。?format=json 或 ?format=html 优先，
+// 否则按 Accept，浏览器和 */* 仍得到 HTML
+func wantsJSON(ctx *fasthttp.RequestCtx) bool {
+	ctx.Response.Header.Add("Vary", "Accept")
+	switch string(ctx.QueryArgs().Peek("format")) {
+	case "json":
+		return true
+	case "html":
+		return false
+	}
+	return apierr.Preferred(string(ctx.Request.Header.Peek("Accept")), "text/html", "application/json") == "application/json"
+}
+
+// writeListing 写入 JSON 目录列表
+func writeListing(ctx *fasthttp.RequestCtx, listing *types.DirectoryListing) {
+	if listing.Entries == nil {
+		listing.Entries = []types.DirectoryEntry{}
+	}
+	ctx.SetContentType("application/json; charset=utf-8")
+	if _, err := listing.WriteTo(ctx); err != nil {
+		log.Logger.Debugf("Failed to write directory listing %s: %v", listing.Path, err)
+	}
+}
+
+// localEntries 把本地目录项转换为列表项。base 是目录的 URL，repoName 不为空时软件包带校验和链接
+func localEntries(entries []os.DirEntry, base, repoName string) []types.DirectoryEntry {
+	base = strings.TrimSuffix(base, "/")
+	out := make([]types.DirectoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		// 包说明和过期时间通过 notes、expires_at 字段显示
+		if repo.StateFile(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		e := types.DirectoryEntry{
+			Name:    entry.Name(),
+			Type:    "file",
+			ModTime: info.ModTime().UTC().Format(time.RFC3339),
+			URL:     base + "/" + entry.Name(),
+		}
+		if entry.IsDir() {
+			e.Type = "dir"
+			e.URL += "/"
+		} else {
+			e.Size = info.Size()
+			e.ChecksumURL = checksumURL(repoName, entry.Name())
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// checksumURL 返回软件包的校验和端点，其他文件为空
+func checksumURL(repo, name string) string {
+	if repo == "" || !(strings.HasSuffix(name, ".rpm") || strings.HasSuffix(name, ".deb")) {
+		return ""
+	}
+	return "/repo/" + repo + "/checksum/" + name
+}
+
+// repoFor 返回包含 cleanPath 的仓库，按最长前缀匹配，找不到时为空
+func (h *API) repoFor(ctx context.Context, cleanPath string) string {
+	repos, err := h.repoService.ListRepos(ctx)
+	if err != nil {
+		return ""
+	}
+	var found string
+	for _, repo := range repos {
+		if (cleanPath == repo || strings.HasPrefix(cleanPath, repo+"/")) && len(repo) > len(found) {
+			found = repo
+		}
+	}
+	return found
+}
+
+// writeDirectListing 以 JSON 列出 /{path} 直接访问的本地目录
+func (h *API) writeDirectListing(ctx *fasthttp.RequestCtx, cleanPath, fullPath, repoType string) {
+	entries, err := os.ReadDir(fullPath)
+	if err != nil {
+		log.Logger.Debugf("❌ Cannot read directory %s: %v", fullPath, err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Cannot read directory", nil)
+		return
+	}
+	cleanPath = strings.Trim(cleanPath, "/")
+	listing := &types.DirectoryListing{Path: "/" + cleanPath + "/", Repo: h.repoFor(ctx, cleanPath)}
+	if repoType != "unknown" {
+		listing.RepoType = repoType
+	}
+	// 已知仓库以仓库配置的类型为准，按目录结构推测的类型可能不准
+	if listing.Repo != "" {
+		if t, err := h.repoService.GetRepoType(ctx, listing.Repo); err == nil {
+			listing.RepoType = t
+		}
+	}
+	listing.Entries = localEntries(entries, listing.Path, listing.Repo)
+	listing.Readme = localReadme(fullPath, entries)
+	if listing.Repo != "" {
+		dir := strings.TrimPrefix(strings.TrimPrefix(cleanPath, listing.Repo), "/")
+		h.annotate(ctx, listing.Repo, listing.RepoType, dir, listing.Entries)
+		h.addThumbnails(listing.Repo, listing.RepoType, dir, listing.Entries)
+	}
+	writeListing(ctx, listing)
+}
+
+// writeObjectStorageListing 以 JSON 列出对象存储仓库中的软件包
+func (h *API) writeObjectStorageListing(ctx *fasthttp.RequestCtx, displayPath string, packages []types.PackageInfo) {
+	listing := &types.DirectoryListing{Path: "/" + displayPath + "/", Repo: displayPath}
+	listing.RepoType, _ = h.repoService.GetRepoType(ctx, displayPath)
+	for _, pkg := range packages {
+		listing.Entries = append(listing.Entries, types.DirectoryEntry{
+			Name:        pkg.Name,
+			Type:        "file",
+			Size:        pkg.Size,
+			ModTime:     pkg.ModTime,
+			URL:         path.Join("/", displayPath, pkg.Name),
+			Checksum:    pkg.Checksum,
+			ChecksumURL: checksumURL(displayPath, pkg.Name),
+		})
+	}
+	listing.Readme = h.objectReadme(ctx, displayPath, packages)
+	h.annotate(ctx, displayPath, listing.RepoType, "", listing.Entries)
+	h.addThumbnails(displayPath, listing.RepoType, "", listing.Entries)
+	writeListing(ctx, listing)
+}
+
+// writeRepoList 以 JSON 列出所有仓库
+func (h *API) writeRepoList(ctx *fasthttp.RequestCtx, repos []string) {
+	listing := &types.DirectoryListing{Path: "/repo/"}
+	for _, repo := range repos {
+		repoType, _ := h.repoService.GetRepoType(ctx, repo)
+		_, _, maintenance := h.repoService.Maintenance(repo)
+		listing.Entries = append(listing.Entries, types.DirectoryEntry{
+			Name:        repo,
+			Type:        "repo",
+			URL:         "/" + repo + "/",
+			RepoType:    repoType,
+			Maintenance: maintenance,
+		})
+	}
+	writeListing(ctx, listing)
+}
+
+// ListRepoFiles 按 glob 列出仓库中的文件: GET /api/v1/repos/{repo}/files?glob=**/*.rpm&recursive=true&arch=&limit=
+// 非递归时 ** 与 * 相同，只匹配 glob 中写出的目录层级。arch 只保留这些架构的包
+func (h *API) ListRepoFiles(ctx *fasthttp.RequestCtx, repoName string) {
+	args := ctx.QueryArgs()
+	recursive := args.GetBool("recursive")
+	arches := archFilter(args)
+	glob := strings.Trim(string(args.Peek("glob")), "/")
+	if glob == "" {
+		glob = "*"
+		if recursive {
+			glob = "**"
+		}
+	}
+	if !utils.ValidGlob(glob) {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, fmt.Sprintf("Invalid glob %q", glob), nil)
+		return
+	}
+	limit := defaultFileListLimit
+	if args.Has("limit") {
+		n, err := args.GetUint("limit")
+		if err != nil || n == 0 || n > maxFileListLimit {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxFileListLimit), nil)
+			return
+		}
+		limit = n
+	}
+
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to get repository type for %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	files, err := h.repoService.ListFiles(ctx, repoName)
+	if err != nil {
+		log.Logger.Errorf("Failed to list files of %s: %v", repoName, err)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to list files", err)
+		return
+	}
+
+	response := &types.FileList{
+		Status:    types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:      repoName,
+		Glob:      glob,
+		Recursive: recursive,
+		Files:     []types.DirectoryEntry{},
+	}
+	for _, f := range files {
+		if !utils.MatchGlob(glob, f.Path, recursive) {
+			continue
+		}
+		if arches != nil && !arches[utils.PackageArch(f.Path)] {
+			continue
+		}
+		if len(response.Files) == limit {
+			response.Truncated = true
+			break
+		}
+		e := types.DirectoryEntry{
+			Name:     f.Path,
+			Type:     "file",
+			Size:     f.Size,
+			ModTime:  f.ModTime,
+			URL:      "/" + repoName + "/" + f.Path,
+			Checksum: f.Checksum,
+		}
+		if repoType != "files" {
+			e.ChecksumURL = checksumURL(repoName, path.Base(f.Path))
+		}
+		response.Files = append(response.Files, e)
+	}
+	response.Count = len(response.Files)
+	h.annotate(ctx, repoName, repoType, "", response.Files)
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"plus/internal/access"
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// maintenanceBypassHeader 管理员带该头时可以在维护期间访问仓库，用于执行整理操作
+const maintenanceBypassHeader = "X-Maintenance-Bypass"
+
+// available 仓库（或上级仓库）处于维护状态时写入 503 并返回 false
+func (h *API) available(ctx *fasthttp.RequestCtx, path string) bool {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return true
+	}
+	repoName, m, ok := h.repoService.Maintenance(path)
+	if !ok {
+		return true
+	}
+	if len(ctx.Request.Header.Peek(maintenanceBypassHeader)) > 0 {
+		if _, admin := h.isAdmin(ctx); admin {
+			return true
+		}
+	}
+	message := fmt.Sprintf("Repository %s is under maintenance, please try again later", repoName)
+	if m.Message != "" {
+		message += ": " + m.Message
+	}
+	h.sendError(ctx, fasthttp.StatusServiceUnavailable, apierr.CodeRepoMaintenance, message, nil)
+	return false
+}
+
+// MaintenanceSwitch 仓库维护开关: GET /api/v1/repos/{repo}/maintenance 查询，
+// PUT 进入维护（可选 {"message": "..."}），DELETE 退出。PUT 和 DELETE 需要管理员令牌
+func (h *API) MaintenanceSwitch(ctx *fasthttp.RequestCtx, repoName, method string) {
+	repoName = strings.Trim(repoName, "/")
+	if method == "GET" {
+		if !h.permit(ctx, repoName, access.ClassDownload) {
+			return
+		}
+	} else if !h.requireAdmin(ctx) {
+		return
+	}
+
+	// 退出维护不要求仓库存在，以便清除已删除仓库的状态
+	if method != "DELETE" {
+		if _, err := h.repoService.GetRepoType(ctx, repoName); err != nil {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+			return
+		}
+	}
+
+	var err error
+	switch method {
+	case "PUT":
+		req := &types.MaintenanceRequest{}
+		if body := ctx.PostBody(); len(body) > 0 {
+			if err := req.UnmarshalJSON(body); err != nil {
+				h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+				return
+			}
+		}
+		err = h.repoService.SetMaintenance(ctx, repoName, types.Maintenance{
+			Message: strings.TrimSpace(req.Message),
+			Since:   time.Now().UTC().Format(time.RFC3339),
+			By:      h.actor(ctx),
+		})
+	case "DELETE":
+		err = h.repoService.ClearMaintenance(ctx, repoName)
+	}
+	if err != nil {
+		log.Logger.Errorf("Failed to change maintenance of %s: %v", repoName, err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to change maintenance state", err)
+		return
+	}
+
+	response := &types.MaintenanceStatus{
+		Status: types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:   repoName,
+	}
+	// 只报告仓库本身的状态，不包括上级仓库
+	if name, m, ok := h.repoService.Maintenance(repoName); ok && name == repoName {
+		response.Enabled = true
+		response.Maintenance = &m
+	}
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"plus/internal/cache"
+	"plus/internal/cdn"
+	"plus/internal/log"
+	"plus/internal/utils"
+	"plus/pkg/storage/breaker"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetMetadataCache 启用热点元数据的内存缓存
+func (h *API) SetMetadataCache(c *cache.MetadataCache) {
+	h.metaCache = c
+}
+
+// serveCachedFile 从内存缓存返回存储中相对路径 rel 的元数据文件，不适用时返回 false 由调用方直接读文件
+func (h *API) serveCachedFile(ctx *fasthttp.RequestCtx, rel, fullPath string) bool {
+	if h.metaCache == nil || !cacheableRequest(ctx) || cdn.Classify(rel) != cdn.ClassMetadata {
+		return false
+	}
+
+	key := filepath.ToSlash(rel)
+	entry, ok := h.metaCache.Lookup(key)
+	if !ok {
+		info, err := os.Stat(fullPath)
+		if err != nil || info.IsDir() || !h.metaCache.Cacheable(info.Size()) {
+			return false
+		}
+		entry, err = h.metaCache.Load(key, func() (*cache.MetadataEntry, error) {
+			data, err := os.ReadFile(fullPath)
+			if err != nil {
+				return nil, err
+			}
+			return &cache.MetadataEntry{Data: data, ModTime: info.ModTime()}, nil
+		})
+		if err != nil {
+			log.Logger.Debugf("Metadata cache load failed for %s: %v", rel, err)
+			return false
+		}
+	}
+
+	if len(ctx.Response.Header.Peek("Content-Type")) == 0 {
+		ctx.Response.Header.Set("Content-Type", metadataContentType(rel))
+	}
+	writeMetadataEntry(ctx, entry)
+	return true
+}
+
+// serveCachedMetadata 从内存缓存返回 /repo/{name}/repodata/ 等接口的元数据，reader 由仓库实现提供
+func (h *API) serveCachedMetadata(ctx *fasthttp.RequestCtx, repoName, filename string) bool {
+	if h.metaCache == nil || !cacheableRequest(ctx) {
+		return false
+	}
+
+	key := path.Join(repoName, "@metadata", filename)
+	entry, ok := h.metaCache.Lookup(key)
+	if !ok {
+		var err error
+		entry, err = h.metaCache.Load(key, func() (*cache.MetadataEntry, error) {
+			reader, err := h.repoService.GetMetadata(ctx, repoName, filename)
+			if err != nil {
+				return nil, err
+			}
+			defer reader.Close()
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return nil, err
+			}
+			return &cache.MetadataEntry{Data: data}, nil
+		})
+		// 存储熔断时返回过期的缓存内容
+		if errors.Is(err, breaker.ErrOpen) {
+			if stale, ok := h.metaCache.Stale(key); ok {
+				log.Logger.Debugf("Serving stale metadata %s: %v", key, err)
+				entry, err = stale, nil
+			}
+		}
+		if err != nil {
+			return false
+		}
+	}
+	writeMetadataEntry(ctx, entry)
+	return true
+}
+
+// cacheableRequest 只缓存完整的 GET/HEAD 响应，Range 请求仍按文件处理
+func cacheableRequest(ctx *fasthttp.RequestCtx) bool {
+	return (ctx.IsGet() || ctx.IsHead()) && len(ctx.Request.Header.Peek("Range")) == 0
+}
+
+func writeMetadataEntry(ctx *fasthttp.RequestCtx, entry *cache.MetadataEntry) {
+	if !entry.ModTime.IsZero() {
+		modTime := entry.ModTime.UTC().Truncate(time.Second)
+		ctx.Response.Header.SetLastModified(modTime)
+		if ims, err := fasthttp.ParseHTTPDate(ctx.Request.Header.Peek("If-Modified-Since")); err == nil && !modTime.After(ims) {
+			ctx.NotModified()
+			return
+		}
+	}
+	// 缓存内容只读，直接引用避免复制
+	ctx.Response.SetBodyRaw(entry.Data)
+}
+
+// metadataContentType 元数据文件的 Content-Type：Release 等无扩展名文件为文本
+func metadataContentType(rel string) string {
+	name := filepath.Base(rel)
+	switch {
+	case filepath.Ext(name) == "":
+		return "text/plain; charset=utf-8"
+	case filepath.Base(filepath.Dir(rel)) == "repodata":
+		return utils.GetContentType(name)
+	default:
+		return utils.GetContentTypeByExtension(name)
+	}
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"plus/internal/apierr"
+	"plus/internal/cache"
+	"plus/internal/cdn"
+	"plus/internal/log"
+	"plus/internal/metalink"
+	"plus/pkg/storage"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetMetalink 为大文件提供 {file}.meta4（及 {file}.zsync、{file}.torrent），生成结果保存在 c 中
+func (h *API) SetMetalink(opts metalink.Options, c *cache.MetadataCache) {
+	h.metalink = &opts
+	h.metalinkCache = c
+}
+
+// metalinkKind 描述文件的种类：后缀、媒体类型和最小文件大小
+type metalinkKind struct {
+	name        string
+	suffix      string
+	contentType string
+	minSize     int64
+}
+
+// metalinkTarget 返回 .meta4/.zsync/.torrent 请求对应的文件，不是这类请求时返回 false
+func (h *API) metalinkTarget(cleanPath string) (string, metalinkKind, bool) {
+	if h.metalink == nil {
+		return "", metalinkKind{}, false
+	}
+	kinds := []metalinkKind{{"meta4", metalink.Suffix, metalink.ContentType, h.metalink.MinSize}}
+	if h.metalink.Zsync {
+		kinds = append(kinds, metalinkKind{"zsync", metalink.ZsyncSuffix, metalink.ZsyncContentType, h.metalink.MinSize})
+	}
+	if h.metalink.TorrentMinSize > 0 {
+		kinds = append(kinds, metalinkKind{"torrent", metalink.TorrentSuffix, metalink.TorrentContentType, h.metalink.TorrentMinSize})
+	}
+	for _, kind := range kinds {
+		if target, ok := strings.CutSuffix(cleanPath, kind.suffix); ok && target != "" {
+			return target, kind, true
+		}
+	}
+	return "", metalinkKind{}, false
+}
+
+// metalinkEligible 判断存储中相对路径 rel、大小为 size 的文件是否提供 metalink
+func (h *API) metalinkEligible(rel string, size int64) bool {
+	return h.metalink != nil && size >= h.metalink.MinSize && cdn.Classify(rel) != cdn.ClassMetadata
+}
+
+// metalinkURLs 返回文件的下载地址：本服务在前，之后是配置的镜像
+func (h *API) metalinkURLs(ctx *fasthttp.RequestCtx, rel string) []string {
+	urls := []string{h.baseURL(ctx) + "/" + rel}
+	for _, mirror := range h.metalink.Mirrors {
+		urls = append(urls, strings.TrimSuffix(mirror, "/")+"/"+rel)
+	}
+	return urls
+}
+
+// serveMetalink 处理 /{path}.meta4、/{path}.zsync 和 /{path}.torrent，返回 false 时按普通文件处理
+func (h *API) serveMetalink(ctx *fasthttp.RequestCtx, cleanPath string) bool {
+	target, kind, ok := h.metalinkTarget(cleanPath)
+	if !ok || (!ctx.IsGet() && !ctx.IsHead()) {
+		return false
+	}
+	// 存储中确有该文件时直接返回
+	if _, err := os.Stat(filepath.Join(h.config.StoragePath, cleanPath)); err == nil {
+		return false
+	}
+	info, open, ok := h.metalinkSource(ctx, target)
+	if !ok || !h.metalinkEligible(target, info.Size) || info.Size < kind.minSize {
+		return false
+	}
+
+	name := path.Base(target)
+	// metalink 和 torrent 中的地址与请求的 Host 有关，zsync 使用相对地址
+	key := fmt.Sprintf("%s:%s:%d:%d", kind.name, target, info.Size, info.ModTime.UnixNano())
+	if kind.name != "zsync" {
+		key += ":" + h.baseURL(ctx)
+	}
+	entry, err := h.metalinkCache.Load(key, func() (*cache.MetadataEntry, error) {
+		reader, err := open()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		var data []byte
+		switch kind.name {
+		case "zsync":
+			data, err = metalink.Zsync(reader, name, name, info.Size, info.ModTime)
+		case "torrent":
+			// 本服务和镜像作为 web seed
+			data, err = metalink.Torrent(reader, name, info.Size, info.ModTime, h.metalinkURLs(ctx, target), h.metalink.Trackers)
+		default:
+			var digest *metalink.Digest
+			if digest, err = metalink.Compute(reader, h.metalink.PieceSize); err == nil {
+				data, err = metalink.Build(metalink.File{
+					Name:      name,
+					Published: info.ModTime,
+					Digest:    digest,
+					URLs:      h.metalinkURLs(ctx, target),
+				})
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		log.Logger.Debugf("Generated %s for %s (%d bytes)", kind.name, target, len(data))
+		return &cache.MetadataEntry{Data: data, ModTime: info.ModTime}, nil
+	})
+	if err != nil {
+		log.Logger.Errorf("Failed to generate %s for %s: %v", kind.name, target, err)
+		if h.storageUnavailable(ctx, err) {
+			return true
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to generate "+kind.name, err)
+		return true
+	}
+
+	ctx.Response.Header.Set("Content-Type", kind.contentType)
+	ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s%s", name, kind.suffix))
+	if !entry.ModTime.IsZero() {
+		ctx.Response.Header.SetLastModified(entry.ModTime)
+	}
+	ctx.SetBody(entry.Data)
+	return true
+}
+
+// metalinkSource 查找 metalink 描述的文件，与直接访问相同，先查本地文件系统再查对象存储
+func (h *API) metalinkSource(ctx *fasthttp.RequestCtx, rel string) (storage.FileInfo, func() (io.ReadCloser, error), bool) {
+	fullPath := filepath.Join(h.config.StoragePath, rel)
+	if info, err := os.Stat(fullPath); err == nil {
+		open := func() (io.ReadCloser, error) { return os.Open(fullPath) }
+		return storage.FileInfo{Name: rel, Size: info.Size(), IsDir: info.IsDir(), ModTime: info.ModTime()}, open, !info.IsDir()
+	}
+	info, err := h.repoService.StatPackageFile(ctx, "", rel)
+	if err != nil || info.IsDir {
+		return info, nil, false
+	}
+	open := func() (io.ReadCloser, error) { return h.repoService.OpenPackageFileRange(ctx, "", rel, 0, -1) }
+	return info, open, true
+}
+
+// linkMetalink 在大文件的下载响应中通过 Link 头（RFC 6249）告知 metalink 的地址
+func (h *API) linkMetalink(ctx *fasthttp.RequestCtx, rel string, size int64) {
+	if !h.metalinkEligible(rel, size) {
+		return
+	}
+	ctx.Response.Header.Add("Link", fmt.Sprintf(`<%s/%s%s>; rel=describedby; type="%s"`, h.baseURL(ctx), rel, metalink.Suffix, metalink.ContentType))
+	if h.metalink.TorrentMinSize > 0 && size >= h.metalink.TorrentMinSize {
+		ctx.Response.Header.Add("Link", fmt.Sprintf(`<%s/%s%s>; rel=describedby; type="%s"`, h.baseURL(ctx), rel, metalink.TorrentSuffix, metalink.TorrentContentType))
+	}
+}
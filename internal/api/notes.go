@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"plus/internal/apierr"
+	"plus/internal/authz"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// maxNoteSize 说明文本和上传的说明文件的最大长度
+	maxNoteSize = 256 << 10
+	// noteSummaryLen 列表中显示的说明长度（字符）
+	noteSummaryLen = 120
+)
+
+// noteFromForm 从表单读取说明：textField 为文本，fileField 为 CHANGELOG 之类的文件，两者都没有时返回 nil
+func (h *API) noteFromForm(ctx *fasthttp.RequestCtx, textField, fileField string) (*types.NoteRequest, error) {
+	if fileHeader, err := h.formFile(ctx, fileField); err == nil {
+		if fileHeader.Size > maxNoteSize {
+			return nil, fmt.Errorf("notes file is larger than %d bytes", maxNoteSize)
+		}
+		f, err := fileHeader.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		data, err := io.ReadAll(io.LimitReader(f, maxNoteSize+1))
+		if err != nil {
+			return nil, err
+		}
+		return &types.NoteRequest{Text: string(data), Source: path.Base(fileHeader.Filename)}, nil
+	}
+	if text := h.formValue(ctx, textField); len(text) > 0 {
+		return &types.NoteRequest{Text: text}, nil
+	}
+	return nil, nil
+}
+
+// validNote 检查说明并生成保存的内容
+func (h *API) validNote(ctx *fasthttp.RequestCtx, req *types.NoteRequest) (types.PackageNote, error) {
+	text := strings.TrimSpace(req.Text)
+	switch {
+	case text == "":
+		return types.PackageNote{}, fmt.Errorf("notes text is empty")
+	case len(text) > maxNoteSize:
+		return types.PackageNote{}, fmt.Errorf("notes are larger than %d bytes", maxNoteSize)
+	case !utf8.ValidString(text):
+		return types.PackageNote{}, fmt.Errorf("notes must be UTF-8 text")
+	}
+	return types.PackageNote{
+		Text:    text,
+		Source:  strings.TrimSpace(req.Source),
+		Author:  h.actor(ctx),
+		Updated: time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// noteSummary 返回说明的第一个非空行，用于列表
+func noteSummary(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if utf8.RuneCountInString(line) > noteSummaryLen {
+			line = string([]rune(line)[:noteSummaryLen-1]) + "…"
+		}
+		return line
+	}
+	return ""
+}
+
+func notesURL(repoName, key string) string {
+	return "/api/v1/repos/" + repoName + "/notes/" + key
+}
+
+// annotate 为列表中有说明的文件加上说明摘要和链接，有有效期的文件加上到期时间，有保留标记的文件加上标记。dir 是列表所在目录相对仓库根目录的路径
+func (h *API) annotate(ctx *fasthttp.RequestCtx, repoName, repoType, dir string, entries []types.DirectoryEntry) {
+	notes, err := h.repoService.Notes(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to read notes of %s: %v", repoName, err)
+	}
+	expires, err := h.repoService.Expiries(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to read expiry of %s: %v", repoName, err)
+	}
+	holds, err := h.repoService.LegalHolds(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to read legal holds of %s: %v", repoName, err)
+	}
+	if len(notes) == 0 && len(expires) == 0 && len(holds) == 0 {
+		return
+	}
+	for i := range entries {
+		if entries[i].Type != "file" {
+			continue
+		}
+		key := service.NoteKey(repoType, path.Join(dir, entries[i].Name))
+		if note, ok := notes[key]; ok {
+			entries[i].Notes = noteSummary(note.Text)
+			entries[i].NotesURL = notesURL(repoName, key)
+		}
+		if at, ok := expires[key]; ok {
+			entries[i].ExpiresAt = at.UTC().Format(time.RFC3339)
+		}
+		if _, ok := holds[key]; ok {
+			entries[i].LegalHold = true
+		}
+	}
+}
+
+// PackageNotes 包说明: GET/PUT/DELETE /api/v1/repos/{repo}/notes/{file}。
+// PUT 的请求体为 {"text": "...", "source": "..."}，或 multipart 表单的 text 字段或 file 文件
+func (h *API) PackageNotes(ctx *fasthttp.RequestCtx, repoName, name, method string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	key := service.NoteKey(repoType, name)
+
+	switch method {
+	case "GET":
+		note, ok, err := h.repoService.Note(ctx, repoName, key)
+		if err != nil {
+			h.notesFailed(ctx, repoName, err)
+			return
+		}
+		if !ok {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Package has no notes", nil)
+			return
+		}
+		h.sendNote(ctx, repoName, key, note)
+
+	case "PUT":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpAnnotate, Repo: repoName, RepoType: repoType, Path: key}) {
+			return
+		}
+		req := &types.NoteRequest{}
+		if bytes.HasPrefix(ctx.Request.Header.ContentType(), []byte("multipart/form-data")) {
+			if req, err = h.noteFromForm(ctx, "text", "file"); err == nil && req == nil {
+				err = fmt.Errorf("text or file is required")
+			}
+			if err != nil {
+				h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, err.Error(), nil)
+				return
+			}
+		} else if err := req.UnmarshalJSON(ctx.PostBody()); err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+			return
+		}
+		note, err := h.validNote(ctx, req)
+		if err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, err.Error(), nil)
+			return
+		}
+		exists, err := h.repoService.PackageExists(ctx, repoName, key)
+		if err != nil {
+			h.notesFailed(ctx, repoName, err)
+			return
+		}
+		if !exists {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodePackageNotFound, "Package not found", nil)
+			return
+		}
+		if err := h.repoService.SetNote(ctx, repoName, key, note); err != nil {
+			h.notesFailed(ctx, repoName, err)
+			return
+		}
+		log.Logger.Infof("Notes of %s in %s updated by %s", key, repoName, note.Author)
+		h.sendNote(ctx, repoName, key, note)
+
+	case "DELETE":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpAnnotate, Repo: repoName, RepoType: repoType, Path: key}) {
+			return
+		}
+		ok, err := h.repoService.DeleteNote(ctx, repoName, key)
+		if err != nil {
+			h.notesFailed(ctx, repoName, err)
+			return
+		}
+		if !ok {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Package has no notes", nil)
+			return
+		}
+		log.Logger.Infof("Notes of %s in %s removed by %s", key, repoName, h.actor(ctx))
+		h.sendSuccess(ctx, "Notes removed")
+	}
+}
+
+// uploadNote 读取上传时附带的说明（表单 notes 或 notes_file），没有时返回 nil
+func (h *API) uploadNote(ctx *fasthttp.RequestCtx) (*types.PackageNote, error) {
+	req, err := h.noteFromForm(ctx, "notes", "notes_file")
+	if err != nil || req == nil {
+		return nil, err
+	}
+	note, err := h.validNote(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// saveUploadNote 保存上传附带的说明。上传已完成，失败只记录日志
+func (h *API) saveUploadNote(ctx *fasthttp.RequestCtx, repoName, filename string, note *types.PackageNote) {
+	if note == nil {
+		return
+	}
+	if err := h.repoService.SetNote(ctx, repoName, filename, *note); err != nil {
+		log.Logger.Warnf("Failed to save notes of %s in %s: %v", filename, repoName, err)
+	}
+}
+
+func (h *API) sendNote(ctx *fasthttp.RequestCtx, repoName, key string, note types.PackageNote) {
+	h.sendJSONResponse(ctx, &types.NoteResponse{
+		Status: types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:   repoName,
+		File:   key,
+		Note:   note,
+	}, fasthttp.StatusOK)
+}
+
+func (h *API) notesFailed(ctx *fasthttp.RequestCtx, repoName string, err error) {
+	log.Logger.Errorf("Package notes request for %s failed: %v", repoName, err)
+	if h.storageUnavailable(ctx, err) {
+		return
+	}
+	h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Package notes request failed", err)
+}
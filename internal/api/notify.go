@@ -0,0 +1,15 @@
+package api
+
+import "plus/internal/notify"
+
+// SetNotifier 设置事件通知（邮件和聊天 webhook）
+func (h *API) SetNotifier(n notify.Sender) {
+	h.notifier = n
+}
+
+// notify 在后台发送事件通知，未配置通知时忽略
+func (h *API) notify(e notify.Event) {
+	if h.notifier != nil {
+		h.notifier.Send(e)
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/service"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// fileExists 仓库的覆盖策略为 reject 时上传已有文件名返回 409，err 不是该错误时返回 false 由调用方处理
+func (h *API) fileExists(ctx *fasthttp.RequestCtx, err error) bool {
+	if !errors.Is(err, service.ErrFileExists) {
+		return false
+	}
+	h.sendError(ctx, fasthttp.StatusConflict, apierr.CodeFileExists, "File already exists and the repository does not allow overwrites", err)
+	return true
+}
+
+// sendUploaded 上传成功的响应，按覆盖策略或文件名规范化改名保存时在消息中说明
+func (h *API) sendUploaded(ctx *fasthttp.RequestCtx, message, filename, stored string, expires time.Time) {
+	response := &types.UploadResult{
+		Status:   "success",
+		Message:  message,
+		Code:     fasthttp.StatusOK,
+		Filename: stored,
+		Renamed:  stored != filename,
+	}
+	if !expires.IsZero() {
+		response.ExpiresAt = expires.UTC().Format(time.RFC3339)
+	}
+	if response.Renamed {
+		response.Message += " as " + stored
+		if normalized := h.repoService.PathNormalizer().Name(filename); stored != normalized {
+			response.Message += ", " + normalized + " already exists"
+		}
+	}
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
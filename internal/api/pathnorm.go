@@ -0,0 +1,25 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// localName 存储目录 dir 下不存在 name 时，启用了文件名规范化且规范化后的文件存在则返回规范化后的名称
+func (h *API) localName(dir, name string) string {
+	paths := h.repoService.PathNormalizer()
+	if paths == nil {
+		return name
+	}
+	if _, err := os.Stat(filepath.Join(h.config.StoragePath, dir, name)); err == nil {
+		return name
+	}
+	normalized := paths.Name(name)
+	if normalized == name {
+		return name
+	}
+	if _, err := os.Stat(filepath.Join(h.config.StoragePath, dir, normalized)); err != nil {
+		return name
+	}
+	return normalized
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"plus/internal/types"
+	"plus/pkg/plugin"
+	"plus/pkg/storage"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ListPlugins 已加载的插件和可用的仓库类型、存储类型: GET /api/v1/plugins
+func (h *API) ListPlugins(ctx *fasthttp.RequestCtx) {
+	if !h.requireAdmin(ctx) {
+		return
+	}
+	plugins := plugin.List()
+	storageTypes := []string{}
+	for _, st := range storage.Types() {
+		storageTypes = append(storageTypes, string(st))
+	}
+	h.sendJSONResponse(ctx, &types.PluginList{
+		Status:       types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Count:        len(plugins),
+		Plugins:      plugins,
+		RepoTypes:    h.repoService.RepoTypes(),
+		StorageTypes: storageTypes,
+	}, fasthttp.StatusOK)
+}
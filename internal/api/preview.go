@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"path"
+
+	"plus/internal/config"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// inlineRequested 返回 rel（存储中的相对路径）是否在浏览器中预览：?inline=true/false 优先，否则按所在仓库的 inline 配置。
+// 都没有指定时返回 false，由调用方使用原来的响应头
+func (h *API) inlineRequested(ctx *fasthttp.RequestCtx, rel string) (inline, ok bool) {
+	args := ctx.QueryArgs()
+	if args.Has("inline") {
+		return args.GetBool("inline"), true
+	}
+	if h.config == nil || !hasInlineRepos(h.config) {
+		return false, false
+	}
+	repoName := h.repoFor(ctx, rel)
+	if repoName == "" {
+		return false, false
+	}
+	if rc, found := h.config.RepoConfig(repoName); found && rc.Inline {
+		return true, true
+	}
+	return false, false
+}
+
+// hasInlineRepos 判断是否有仓库默认预览，没有时不必为每个下载查找仓库
+func hasInlineRepos(cfg *config.Config) bool {
+	for _, rc := range cfg.Repositories {
+		if rc.Inline {
+			return true
+		}
+	}
+	return false
+}
+
+// filePreview 按 inlineRequested 确定文件的 Content-Disposition，返回在文件的响应头设置之后调用的函数：
+// fasthttp.ServeFile 会替换请求地址并覆盖 Content-Type，因此先读取参数，后设置响应头。
+// 预览时文本类文件按纯文本显示，不能在浏览器中显示的文件（如软件包）仍作为附件下载
+func (h *API) filePreview(ctx *fasthttp.RequestCtx, rel string) func() {
+	inline, ok := h.inlineRequested(ctx, rel)
+	if !ok {
+		return func() {}
+	}
+	return func() {
+		if status := ctx.Response.StatusCode(); status != fasthttp.StatusOK && status != fasthttp.StatusPartialContent {
+			return
+		}
+		filename := path.Base(rel)
+		if contentType, previewable := utils.PreviewContentType(filename); inline && previewable {
+			ctx.Response.Header.Set("Content-Type", contentType)
+			ctx.Response.Header.Set("X-Content-Type-Options", "nosniff")
+			ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("inline; filename=%s", filename))
+			return
+		}
+		ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"errors"
+
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/proxy"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+func (h *API) SetProxy(m *proxy.Manager) {
+	h.proxy = m
+}
+
+// fetchUpstream 代理仓库的文件未缓存时从上游拉取并校验；返回 false 表示已写入错误响应
+func (h *API) fetchUpstream(ctx *fasthttp.RequestCtx, repoName, filePath string) bool {
+	if h.proxy == nil || !h.proxy.IsProxy(repoName) {
+		return true
+	}
+
+	err := h.proxy.Fetch(ctx, repoName, filePath)
+	switch {
+	case err == nil, errors.Is(err, proxy.ErrNotFound):
+		// 未找到时交给后续处理返回 404
+		return true
+	case errors.Is(err, proxy.ErrVerification):
+		log.Logger.Warnf("Refusing to serve %s/%s: %v", repoName, filePath, err)
+		h.sendError(ctx, fasthttp.StatusBadGateway, apierr.CodeUpstreamFailed, "Upstream content failed verification", nil)
+	default:
+		log.Logger.Debugf("Upstream fetch failed for %s/%s: %v", repoName, filePath, err)
+		h.sendError(ctx, fasthttp.StatusBadGateway, apierr.CodeUpstreamFailed, "Upstream fetch failed", err)
+	}
+	return false
+}
+
+// syncUpstream 刷新代理仓库：从上游同步并校验元数据
+func (h *API) syncUpstream(ctx *fasthttp.RequestCtx, repoName string) {
+	if err := h.proxy.Sync(ctx, repoName); err != nil {
+		log.Logger.Debugf("Upstream sync failed for repo %s: %v", repoName, err)
+		if errors.Is(err, proxy.ErrVerification) {
+			h.sendError(ctx, fasthttp.StatusBadGateway, apierr.CodeUpstreamFailed, "Upstream verification failed", err)
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusBadGateway, apierr.CodeUpstreamFailed, "Upstream sync failed", err)
+		return
+	}
+
+	response := &types.RepoStatus{
+		Status: types.Status{
+			Status:  "success",
+			Message: h.localize(ctx, "Upstream metadata synced and verified"),
+		},
+		Repo: repoName,
+	}
+
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
+
+// GetUpstreamHealth 代理仓库各上游的健康状态: GET /api/v1/repos/{repo}/upstream
+// POST /api/v1/repos/{repo}/upstream/check 立即执行一次检查
+func (h *API) GetUpstreamHealth(ctx *fasthttp.RequestCtx, repoName string, checkNow bool) {
+	if h.proxy == nil || !h.proxy.IsProxy(repoName) {
+		h.sendJSONError(ctx, "Repository is not a proxy repository", fasthttp.StatusNotFound)
+		return
+	}
+
+	var health *types.UpstreamHealth
+	if checkNow {
+		var err error
+		if health, err = h.proxy.CheckHealth(ctx, repoName); err != nil {
+			h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Upstream health check failed", err)
+			return
+		}
+	} else {
+		health, _ = h.proxy.Health(repoName)
+	}
+
+	health.Status = types.Status{Status: "success", Code: fasthttp.StatusOK}
+	if !health.Healthy {
+		health.Status.Status = "unhealthy"
+	}
+	h.sendJSONResponse(ctx, health, fasthttp.StatusOK)
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"plus/internal/types"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// localReadme 读取本地目录 fullPath 中的说明文件，没有或超过 utils.MaxReadmeSize 时返回 nil
+func localReadme(fullPath string, entries []os.DirEntry) *types.Readme {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	name, ok := utils.FindReadme(names)
+	if !ok {
+		return nil
+	}
+	info, err := os.Stat(filepath.Join(fullPath, name))
+	if err != nil || info.Size() > utils.MaxReadmeSize {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(fullPath, name))
+	if err != nil {
+		return nil
+	}
+	return newReadme(name, data)
+}
+
+// objectReadme 从对象存储读取 displayPath 目录中的说明文件，packages 为该目录下的文件（名称相对该目录）
+func (h *API) objectReadme(ctx *fasthttp.RequestCtx, displayPath string, packages []types.PackageInfo) *types.Readme {
+	var names []string
+	sizes := make(map[string]int64)
+	for _, pkg := range packages {
+		if !strings.Contains(pkg.Name, "/") {
+			names = append(names, pkg.Name)
+			sizes[pkg.Name] = pkg.Size
+		}
+	}
+	name, ok := utils.FindReadme(names)
+	if !ok || sizes[name] > utils.MaxReadmeSize {
+		return nil
+	}
+	reader, err := h.repoService.OpenPackageFileRange(ctx, "", path.Join(displayPath, name), 0, sizes[name])
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, utils.MaxReadmeSize))
+	if err != nil {
+		return nil
+	}
+	return newReadme(name, data)
+}
+
+func newReadme(name string, data []byte) *types.Readme {
+	return &types.Readme{Name: name, Source: string(data), HTML: utils.RenderReadme(name, data)}
+}
+
+// withReadme 在目录列表页面下方显示说明文件
+func withReadme(page string, readme *types.Readme) string {
+	if readme == nil {
+		return page
+	}
+	return utils.InsertReadme(page, readme.Name, readme.HTML)
+}
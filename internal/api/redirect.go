@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"plus/internal/cdn"
+	"plus/internal/log"
+	"plus/internal/metrics"
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetRedirect 启用包下载重定向：满足条件的包返回 302 到存储后端生成的预签名地址
+func (h *API) SetRedirect(expires time.Duration, minSize int64) {
+	h.redirectExpires = expires
+	h.redirectMinSize = minSize
+}
+
+// redirectDownload 为存储中相对路径 rel 的包文件返回 302，未启用、仓库的存储后端不支持预签名或不满足条件时
+// 返回 false 由 plus 直接返回文件
+func (h *API) redirectDownload(ctx *fasthttp.RequestCtx, rel string, size int64) bool {
+	if h.redirectExpires <= 0 || (!ctx.IsGet() && !ctx.IsHead()) {
+		return false
+	}
+	// 元数据需保持最新且体积小，仍由 plus 返回
+	if size < h.redirectMinSize || cdn.Classify(rel) != cdn.ClassPackage {
+		return false
+	}
+
+	rel = filepath.ToSlash(rel)
+	url, err := h.presign(ctx, rel)
+	if errors.Is(err, storage.ErrNotPresignable) {
+		return false
+	}
+	if err != nil {
+		log.Logger.Warnf("Failed to presign %s, serving directly: %v", rel, err)
+		return false
+	}
+
+	metrics.IncrementRedirects()
+	log.Logger.Debugf("Redirecting download of %s to object storage", rel)
+	// 不经过 ctx.Redirect，避免重新编码破坏签名
+	ctx.Response.Header.Set("Location", url)
+	ctx.Response.Header.Set("Cache-Control", "no-store")
+	ctx.SetStatusCode(fasthttp.StatusFound)
+	return true
+}
+
+// presign 由文件所在仓库的存储后端生成预签名地址，代理仓库使用缓存所在的存储
+func (h *API) presign(ctx context.Context, rel string) (string, error) {
+	if h.proxy != nil {
+		if _, _, ok := h.proxy.Match(rel); ok {
+			return h.proxy.Presign(ctx, rel, h.redirectExpires)
+		}
+	}
+	repoName, _, _ := strings.Cut(rel, "/")
+	return h.repoService.PresignFile(ctx, repoName, rel, h.redirectExpires)
+}
+
+// redirectPackage 在仓库的存储后端中查找包文件（RPM 位于 Packages/ 下，源码包位于 SRPMS/Packages/ 下）并重定向
+func (h *API) redirectPackage(ctx *fasthttp.RequestCtx, repoName, filename string) bool {
+	if h.redirectExpires <= 0 {
+		return false
+	}
+	for _, rel := range []string{
+		filepath.Join(repoName, filepath.FromSlash(repo.RPMPackagePath(filename))),
+		filepath.Join(repoName, "Packages", filename),
+		filepath.Join(repoName, filename),
+	} {
+		if info, err := h.repoService.StatFile(ctx, repoName, filepath.ToSlash(rel)); err == nil && !info.IsDir {
+			return h.redirectDownload(ctx, rel, info.Size)
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"plus/internal/apierr"
+	"plus/internal/authz"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// repoReadmeName 渲染仓库说明时使用的文件名，按 Markdown 渲染
+const repoReadmeName = "README.md"
+
+// RepoReadme 仓库说明: GET/PUT/DELETE /api/v1/repos/{repo}/readme。
+// PUT 的请求体为 {"text": "..."}，Content-Type 为 text/* 时为 Markdown 原文
+func (h *API) RepoReadme(ctx *fasthttp.RequestCtx, repoName, method string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+
+	switch method {
+	case "GET":
+		readme, ok, err := h.repoService.RepoReadme(ctx, repoName)
+		if err != nil {
+			h.readmeFailed(ctx, repoName, err)
+			return
+		}
+		if !ok {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Repository has no readme", nil)
+			return
+		}
+		h.sendRepoReadme(ctx, repoName, readme)
+
+	case "PUT":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpReadme, Repo: repoName, RepoType: repoType}) {
+			return
+		}
+		req := &types.ReadmeRequest{}
+		if strings.HasPrefix(string(ctx.Request.Header.ContentType()), "text/") {
+			req.Text = string(ctx.PostBody())
+		} else if err := req.UnmarshalJSON(ctx.PostBody()); err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+			return
+		}
+		text := strings.TrimSpace(req.Text)
+		switch {
+		case text == "":
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest, "readme text is empty", nil)
+			return
+		case len(text) > utils.MaxReadmeSize || !utf8.ValidString(text):
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest,
+				fmt.Sprintf("readme must be UTF-8 text of at most %d bytes", utils.MaxReadmeSize), nil)
+			return
+		}
+		readme := types.RepoReadme{
+			Text:    text + "\n",
+			Author:  h.actor(ctx),
+			Updated: time.Now().UTC().Format(time.RFC3339),
+		}
+		if err := h.repoService.SetRepoReadme(ctx, repoName, readme); err != nil {
+			h.readmeFailed(ctx, repoName, err)
+			return
+		}
+		log.Logger.Infof("Readme of %s updated by %s", repoName, readme.Author)
+		h.sendRepoReadme(ctx, repoName, readme)
+
+	case "DELETE":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpReadme, Repo: repoName, RepoType: repoType}) {
+			return
+		}
+		_, ok, err := h.repoService.RepoReadme(ctx, repoName)
+		if err != nil {
+			h.readmeFailed(ctx, repoName, err)
+			return
+		}
+		if !ok {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Repository has no readme", nil)
+			return
+		}
+		if err := h.repoService.SetRepoReadme(ctx, repoName, types.RepoReadme{}); err != nil {
+			h.readmeFailed(ctx, repoName, err)
+			return
+		}
+		log.Logger.Infof("Readme of %s deleted by %s", repoName, h.actor(ctx))
+		h.sendSuccess(ctx, "Readme deleted")
+	}
+}
+
+// repoInfoReadme 返回仓库信息中的仓库说明，没有或读取失败时返回 nil
+func (h *API) repoInfoReadme(ctx *fasthttp.RequestCtx, repoName string) *types.Readme {
+	readme, ok, err := h.repoService.RepoReadme(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("Failed to read readme of %s: %v", repoName, err)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	return newReadme(repoReadmeName, []byte(h.expandReadme(ctx, repoName, readme.Text)))
+}
+
+// expandReadme 替换说明中的变量：{{repo}}、{{server_url}}、{{base_url}}（仓库文件的地址）、
+// {{gpg_key_url}}（仓库没有签名密钥时为空）、{{config_url}} 和 {{setup_url}}
+func (h *API) expandReadme(ctx *fasthttp.RequestCtx, repoName, text string) string {
+	server := h.baseURL(ctx)
+	c := h.clientRepoConfig(server, repoName)
+	return strings.NewReplacer(
+		"{{repo}}", repoName,
+		"{{server_url}}", server,
+		"{{base_url}}", c.BaseURL,
+		"{{gpg_key_url}}", c.GPGKey,
+		"{{config_url}}", server+"/repo/"+repoName+"/config",
+		"{{setup_url}}", server+"/repo/"+repoName+"/setup.sh",
+	).Replace(text)
+}
+
+func (h *API) sendRepoReadme(ctx *fasthttp.RequestCtx, repoName string, readme types.RepoReadme) {
+	h.sendJSONResponse(ctx, &types.ReadmeResponse{
+		Status: types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:   repoName,
+		Readme: readme,
+		HTML:   utils.RenderReadme(repoReadmeName, []byte(h.expandReadme(ctx, repoName, readme.Text))),
+	}, fasthttp.StatusOK)
+}
+
+func (h *API) readmeFailed(ctx *fasthttp.RequestCtx, repoName string, err error) {
+	log.Logger.Errorf("Readme request for %s failed: %v", repoName, err)
+	if h.storageUnavailable(ctx, err) {
+		return
+	}
+	h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Readme request failed", err)
+}
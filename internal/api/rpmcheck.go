@@ -0,0 +1,23 @@
+package api
+
+import (
+	"errors"
+
+	"plus/internal/apierr"
+	"plus/internal/service"
+
+	"github.com/valyala/fasthttp"
+)
+
+// invalidPackage RPM 包头无法读取或与文件名不一致时返回 400，err 不是这两种错误时返回 false 由调用方处理
+func (h *API) invalidPackage(ctx *fasthttp.RequestCtx, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrInvalidPackage):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidPackage, "Not a valid RPM package", err)
+	case errors.Is(err, service.ErrPackageMismatch):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodePackageMismatch, "File name does not match the RPM header", err)
+	default:
+		return false
+	}
+	return true
+}
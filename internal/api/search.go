@@ -0,0 +1,191 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/facet"
+	"plus/internal/fulltext"
+	"plus/internal/index"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	defaultSearchLimit = 100
+	maxSearchLimit     = 1000
+)
+
+// packageFacets 包搜索支持统计的字段
+var packageFacets = []string{"repo", "type", "arch", "license"}
+
+// searchFacets 读取 facets 参数（可重复，也可用逗号分隔），没有时返回 nil
+func searchFacets(args *fasthttp.Args) []string {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, v := range args.PeekMulti("facets") {
+		for _, f := range strings.Split(string(v), ",") {
+			if f = strings.TrimSpace(f); f != "" && !seen[f] {
+				seen[f] = true
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+// Search 搜索制品: GET /api/v1/search?q=&repo=&type=&arch=&label=arch:x86_64&size=&mtime=&facets=&limit=
+// label 可重复，全部匹配才返回，arch=x86_64 与 label=arch:x86_64 相同。facets 为要统计的字段：repo、type、size、mtime 或标签名，
+// 统计覆盖全部匹配的制品，不受 limit 影响
+func (h *API) Search(ctx *fasthttp.RequestCtx) {
+	args := ctx.QueryArgs()
+	q := index.Query{
+		Text:  string(args.Peek("q")),
+		Repo:  strings.Trim(string(args.Peek("repo")), "/"),
+		Type:  string(args.Peek("type")),
+		Size:  string(args.Peek("size")),
+		MTime: string(args.Peek("mtime")),
+	}
+	for field, value := range map[string]string{facet.Size: q.Size, facet.MTime: q.MTime} {
+		if value != "" && !facet.ValidBucket(field, value) {
+			h.sendJSONError(ctx, fmt.Sprintf("Invalid %s range %q", field, value), fasthttp.StatusBadRequest)
+			return
+		}
+	}
+	for _, label := range args.PeekMulti("label") {
+		key, value, ok := strings.Cut(string(label), ":")
+		if !ok || key == "" {
+			h.sendJSONError(ctx, fmt.Sprintf("Invalid label %q, expected key:value", label), fasthttp.StatusBadRequest)
+			return
+		}
+		if q.Labels == nil {
+			q.Labels = make(map[string]string)
+		}
+		q.Labels[key] = value
+	}
+	if arch := string(args.Peek("arch")); arch != "" {
+		if q.Labels == nil {
+			q.Labels = make(map[string]string)
+		}
+		q.Labels["arch"] = arch
+	}
+
+	limit := defaultSearchLimit
+	if args.Has("limit") {
+		n, err := args.GetUint("limit")
+		if err != nil || n == 0 || n > maxSearchLimit {
+			h.sendJSONError(ctx, fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit), fasthttp.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	var counts *facet.Counter
+	if fields := searchFacets(args); fields != nil {
+		counts = facet.New(fields, time.Now())
+	}
+
+	artifacts, truncated, indexed, err := h.repoService.Search(ctx, q, limit, counts)
+	if err != nil {
+		log.Logger.Errorf("Search failed: %v", err)
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Search failed", err)
+		return
+	}
+	if artifacts == nil {
+		artifacts = []types.Artifact{}
+	}
+
+	response := &types.SearchResult{
+		Status:    types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Query:     q.Text,
+		Count:     len(artifacts),
+		Truncated: truncated,
+		Indexed:   indexed,
+		Artifacts: artifacts,
+	}
+	if counts != nil {
+		response.Facets = counts.Result()
+	}
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
+
+// SearchPackages 全文搜索包: GET /api/v1/search/packages?q=&repo=&type=&arch=&license=&facets=&limit=
+// 在 rpm、deb 包的名称、摘要和描述中搜索，所有词都出现的包按相关度排序。
+// facets 可统计 repo、type、arch、license
+func (h *API) SearchPackages(ctx *fasthttp.RequestCtx) {
+	args := ctx.QueryArgs()
+	q := fulltext.Query{
+		Text:    string(args.Peek("q")),
+		Repo:    strings.Trim(string(args.Peek("repo")), "/"),
+		Type:    string(args.Peek("type")),
+		Arch:    string(args.Peek("arch")),
+		License: string(args.Peek("license")),
+	}
+	if len(fulltext.Tokenize(q.Text)) == 0 {
+		h.sendJSONError(ctx, "Query q must contain at least one word", fasthttp.StatusBadRequest)
+		return
+	}
+
+	limit := defaultSearchLimit
+	if args.Has("limit") {
+		n, err := args.GetUint("limit")
+		if err != nil || n == 0 || n > maxSearchLimit {
+			h.sendJSONError(ctx, fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit), fasthttp.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	var counts *facet.Counter
+	if fields := searchFacets(args); fields != nil {
+		for _, f := range fields {
+			if !slices.Contains(packageFacets, f) {
+				h.sendJSONError(ctx, fmt.Sprintf("Unsupported facet %q, use %s", f, strings.Join(packageFacets, ", ")), fasthttp.StatusBadRequest)
+				return
+			}
+		}
+		counts = facet.New(fields, time.Now())
+	}
+
+	hits, truncated, err := h.repoService.SearchPackages(ctx, q, limit, counts)
+	if err != nil {
+		log.Logger.Errorf("Package search failed: %v", err)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Search failed", err)
+		return
+	}
+
+	response := &types.PackageSearchResult{
+		Status:    types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Query:     q.Text,
+		Count:     len(hits),
+		Truncated: truncated,
+		Packages:  make([]types.PackageHit, 0, len(hits)),
+	}
+	for _, hit := range hits {
+		response.Packages = append(response.Packages, types.PackageHit{
+			Repo:        hit.Repo,
+			Type:        hit.Type,
+			Name:        hit.Name,
+			Version:     hit.Version,
+			Arch:        hit.Arch,
+			Path:        hit.Path,
+			Summary:     hit.Summary,
+			Description: hit.Description,
+			License:     hit.License,
+			Score:       math.Round(hit.Score*1000) / 1000,
+		})
+	}
+	if counts != nil {
+		response.Facets = counts.Result()
+	}
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"errors"
+
+	"plus/internal/apierr"
+	"plus/internal/attest"
+	"plus/internal/authz"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Signatures 制品的 cosign 签名: GET/POST /repo/{repo}/package/{file}/signatures。
+// POST 的请求体为 cosign sign-blob 输出的 base64 签名或 bundle
+func (h *API) Signatures(ctx *fasthttp.RequestCtx, repoName, name, method string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	key := service.NoteKey(repoType, name)
+
+	switch method {
+	case "GET":
+		list, err := h.repoService.Signatures(ctx, repoName, key)
+		if err != nil {
+			h.signatureFailed(ctx, repoName, err)
+			return
+		}
+		response := &types.SignatureList{
+			Status:     types.Status{Status: "success", Code: fasthttp.StatusOK},
+			Repo:       repoName,
+			File:       key,
+			Signatures: []types.Signature{},
+		}
+		for _, sig := range list {
+			response.Signatures = append(response.Signatures, signatureInfo(repoName, name, sig))
+		}
+		h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+
+	case "POST":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpSign, Repo: repoName, RepoType: repoType, Path: key}) {
+			return
+		}
+		sig, err := h.repoService.AddSignature(ctx, repoName, key, ctx.PostBody(), h.actor(ctx))
+		if err != nil {
+			h.signatureFailed(ctx, repoName, err)
+			return
+		}
+		log.Logger.Infof("Signature %s (verified: %v) added to %s in %s by %s", sig.ID, sig.Verified, key, repoName, sig.By)
+		h.sendJSONResponse(ctx, &types.SignatureResponse{
+			Status:    types.Status{Status: "success", Code: fasthttp.StatusCreated},
+			Repo:      repoName,
+			File:      key,
+			Signature: signatureInfo(repoName, name, sig),
+		}, fasthttp.StatusCreated)
+	}
+}
+
+// Signature 下载或删除一个签名: GET/DELETE /repo/{repo}/package/{file}/signatures/{id}。
+// GET 返回 base64 签名，可以直接用于 cosign verify-blob --signature
+func (h *API) Signature(ctx *fasthttp.RequestCtx, repoName, name, id, method string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	key := service.NoteKey(repoType, name)
+
+	switch method {
+	case "GET":
+		list, err := h.repoService.Signatures(ctx, repoName, key)
+		if err != nil {
+			h.signatureFailed(ctx, repoName, err)
+			return
+		}
+		for _, sig := range list {
+			if sig.ID == id {
+				ctx.SetContentType("text/plain; charset=utf-8")
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				ctx.SetBodyString(sig.Signature)
+				return
+			}
+		}
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Signature not found", nil)
+
+	case "DELETE":
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpSign, Repo: repoName, RepoType: repoType, Path: key}) {
+			return
+		}
+		ok, err := h.repoService.DeleteSignature(ctx, repoName, key, id)
+		if err != nil {
+			h.signatureFailed(ctx, repoName, err)
+			return
+		}
+		if !ok {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Signature not found", nil)
+			return
+		}
+		log.Logger.Infof("Signature %s of %s in %s deleted by %s", id, key, repoName, h.actor(ctx))
+		h.sendSuccess(ctx, "Signature deleted")
+	}
+}
+
+// VerifyArtifact 用配置的公钥验证制品的签名和来源证明: GET /repo/{repo}/package/{file}/verify[?key=name]
+func (h *API) VerifyArtifact(ctx *fasthttp.RequestCtx, repoName, name string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	key := service.NoteKey(repoType, name)
+	result, err := h.repoService.VerifyArtifact(ctx, repoName, key, string(ctx.QueryArgs().Peek("key")))
+	if err != nil {
+		h.signatureFailed(ctx, repoName, err)
+		return
+	}
+	result.Status = types.Status{Status: "success", Code: fasthttp.StatusOK}
+	result.Repo = repoName
+	h.sendJSONResponse(ctx, &result, fasthttp.StatusOK)
+}
+
+// signatureInfo 补充下载地址
+func signatureInfo(repoName, name string, sig types.Signature) types.Signature {
+	sig.URL = "/repo/" + repoName + "/package/" + name + "/signatures/" + sig.ID
+	return sig
+}
+
+func (h *API) signatureFailed(ctx *fasthttp.RequestCtx, repoName string, err error) {
+	switch {
+	case errors.Is(err, attest.ErrInvalidSignature):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidSignature, "Invalid signature", err)
+	case errors.Is(err, attest.ErrUnverified):
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnverifiedSig, "Signature not verified", err)
+	case errors.Is(err, attest.ErrUnknownKey):
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeKeyNotFound, "Key not found", err)
+	default:
+		h.attestationFailed(ctx, repoName, err)
+	}
+}
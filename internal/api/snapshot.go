@@ -0,0 +1,117 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/utils"
+
+	"github.com/valyala/fasthttp"
+)
+
+// MetadataBundle 元数据快照: GET /repo/{repo}/metadata.tar.gz，
+// 一次返回同一次刷新生成的 repodata/ 或 Packages/Release 文件，供离线扫描和合规工具使用；
+// 指定 generation 时返回保留的旧版本
+func (h *API) MetadataBundle(ctx *fasthttp.RequestCtx, repoName string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	if repoType != "rpm" && repoType != "deb" {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, "Metadata bundles are only available for rpm and deb repositories", nil)
+		return
+	}
+
+	var (
+		files []service.MetadataFile
+		id    string
+	)
+	if generation := generationParam(ctx); generation != "" {
+		files, id, err = h.repoService.GenerationSnapshot(ctx, repoName, generation)
+	} else {
+		files, err = h.repoService.MetadataSnapshot(ctx, repoName)
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrGenerationNotFound) {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeMetadataNotFound, "Metadata generation not found", err)
+			return
+		}
+		if errors.Is(err, service.ErrNoMetadata) {
+			h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeMetadataNotFound, "Repository has no metadata, refresh it first", nil)
+			return
+		}
+		log.Logger.Errorf("Failed to read metadata of %s: %v", repoName, err)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to read metadata", err)
+		return
+	}
+
+	var modTime time.Time
+	for _, f := range files {
+		if f.ModTime.After(modTime) {
+			modTime = f.ModTime
+		}
+	}
+	modTime = modTime.UTC().Truncate(time.Second)
+	if !modTime.IsZero() {
+		ctx.Response.Header.SetLastModified(modTime)
+		if ims, err := fasthttp.ParseHTTPDate(ctx.Request.Header.Peek("If-Modified-Since")); err == nil && !modTime.After(ims) {
+			ctx.NotModified()
+			return
+		}
+	}
+
+	body, err := metadataTarball(files)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to build metadata bundle", err)
+		return
+	}
+	name := utils.RepoID(repoName) + "-metadata"
+	if id != "" {
+		name += "-" + id
+		ctx.Response.Header.Set(generationHeader, id)
+	}
+	ctx.Response.Header.Set("Content-Type", "application/gzip")
+	ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.tar.gz", name))
+	ctx.Response.Header.Set("Cache-Control", "no-cache")
+	ctx.Response.Header.Set("X-Metadata-Files", fmt.Sprintf("%d", len(files)))
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetBody(body)
+}
+
+// metadataTarball 把元数据文件按仓库中的相对路径写入 tar.gz
+func metadataTarball(files []service.MetadataFile) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    f.Name,
+			Mode:    0644,
+			Size:    int64(len(f.Data)),
+			ModTime: f.ModTime,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(f.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/progress"
+	"plus/internal/spool"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// uploadFormKey 请求中保存解析后表单的键；请求结束时 fasthttp 关闭该值，释放 spool 文件
+	uploadFormKey = "plus.uploadForm"
+	// uploadProgressKey 请求中保存上传进度的键；请求结束时记录响应状态
+	uploadProgressKey = "plus.uploadProgress"
+	// uploadIDHeader 客户端指定上传 ID 的请求头，也可以使用查询参数 upload_id
+	uploadIDHeader = "X-Upload-ID"
+	// uploadProgressKeep 完成的上传进度保留时间
+	uploadProgressKeep = 10 * time.Minute
+)
+
+// 未调用 SetSpool 时使用系统临时目录
+var (
+	defaultSpoolOnce sync.Once
+	defaultSpool     *spool.Spooler
+	defaultSpoolErr  error
+)
+
+// SetSpool 设置读取 multipart 上传表单使用的 spool
+func (h *API) SetSpool(s *spool.Spooler) {
+	h.spool = s
+}
+
+// uploadForm 读取 multipart 请求体，同一请求中多次调用返回同一结果（包括错误）。
+// 请求体按配置的阈值写入 spool，而不是整体读入内存
+func (h *API) uploadForm(ctx *fasthttp.RequestCtx) (*spool.Form, error) {
+	switch v := ctx.UserValue(uploadFormKey).(type) {
+	case *spool.Form:
+		return v, nil
+	case error:
+		return nil, v
+	}
+	form, err := h.readUploadForm(ctx)
+	if err != nil {
+		ctx.SetUserValue(uploadFormKey, err)
+		return nil, err
+	}
+	ctx.SetUserValue(uploadFormKey, form)
+	return form, nil
+}
+
+func (h *API) readUploadForm(ctx *fasthttp.RequestCtx) (*spool.Form, error) {
+	boundary := string(ctx.Request.Header.MultipartFormBoundary())
+	if boundary == "" {
+		return nil, fasthttp.ErrNoMultipartForm
+	}
+	s := h.spool
+	if s == nil {
+		defaultSpoolOnce.Do(func() { defaultSpool, defaultSpoolErr = spool.New("", 1<<20, 0) })
+		if defaultSpoolErr != nil {
+			return nil, defaultSpoolErr
+		}
+		s = defaultSpool
+	}
+
+	var body io.Reader
+	if ctx.Request.IsBodyStream() {
+		body = ctx.RequestBodyStream()
+	} else {
+		body = bytes.NewReader(ctx.Request.Body())
+	}
+	upload, err := h.trackUpload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if upload != nil {
+		body = upload.Reader(body)
+	}
+	form, err := s.ReadForm(body, boundary)
+	if err != nil {
+		return nil, err
+	}
+	if upload != nil {
+		upload.Received()
+	}
+	return form, nil
+}
+
+// trackUpload 请求带有上传 ID 时开始记录进度，没有时返回 nil
+func (h *API) trackUpload(ctx *fasthttp.RequestCtx) (*progress.Upload, error) {
+	id := string(ctx.Request.Header.Peek(uploadIDHeader))
+	if id == "" {
+		id = string(ctx.QueryArgs().Peek("upload_id"))
+	}
+	if id == "" || h.uploads == nil {
+		return nil, nil
+	}
+	upload, err := h.uploads.Start(id, int64(ctx.Request.Header.ContentLength()))
+	if err != nil {
+		return nil, err
+	}
+	ctx.SetUserValue(uploadProgressKey, &uploadDone{upload: upload, resp: &ctx.Response})
+	return upload, nil
+}
+
+// uploadDone 请求结束时由 fasthttp 关闭，按响应状态码结束上传记录
+type uploadDone struct {
+	upload *progress.Upload
+	resp   *fasthttp.Response
+}
+
+func (d *uploadDone) Close() error {
+	d.upload.Finish(d.resp.StatusCode())
+	return nil
+}
+
+// GetUploadProgress 上传进度: GET /api/v1/uploads/{id}/progress，
+// id 为上传请求的 X-Upload-ID 头或 upload_id 参数
+func (h *API) GetUploadProgress(ctx *fasthttp.RequestCtx, id string) {
+	p, ok := h.uploads.Get(id)
+	if !ok {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeNotFound, "Upload not found: "+id, nil)
+		return
+	}
+	ctx.Response.Header.Set("Cache-Control", "no-store")
+	h.sendJSONResponse(ctx, &p, fasthttp.StatusOK)
+}
+
+// formFile 返回上传表单中的文件
+func (h *API) formFile(ctx *fasthttp.RequestCtx, name string) (*spool.File, error) {
+	form, err := h.uploadForm(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return form.FileHeader(name)
+}
+
+// formValue 依次从查询参数、urlencoded 请求体和 multipart 表单中取值
+func (h *API) formValue(ctx *fasthttp.RequestCtx, name string) string {
+	if v := ctx.QueryArgs().Peek(name); len(v) > 0 {
+		return string(v)
+	}
+	if len(ctx.Request.Header.MultipartFormBoundary()) == 0 {
+		return string(ctx.PostArgs().Peek(name))
+	}
+	form, err := h.uploadForm(ctx)
+	if err != nil {
+		return ""
+	}
+	return form.FormValue(name)
+}
+
+// rejectUpload 请求体超过上限或上传 ID 不可用时返回错误响应，其他错误返回 false 由调用方处理
+func (h *API) rejectUpload(ctx *fasthttp.RequestCtx, err error) bool {
+	switch {
+	case errors.Is(err, spool.ErrTooLarge):
+		h.sendJSONError(ctx, "Request body too large", fasthttp.StatusRequestEntityTooLarge)
+	case errors.Is(err, progress.ErrDuplicate):
+		h.sendJSONError(ctx, err.Error(), fasthttp.StatusConflict)
+	case errors.Is(err, progress.ErrInvalidID):
+		h.sendJSONError(ctx, err.Error(), fasthttp.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
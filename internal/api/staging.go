@@ -0,0 +1,174 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/approval"
+	"plus/internal/authz"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// staging 上传是否进入暂存区：仓库配置了 staging，或表单带 stage=true
+func (h *API) staging(ctx *fasthttp.RequestCtx, repoName string) bool {
+	if h.formValue(ctx, "stage") == "true" {
+		return true
+	}
+	rc, ok := h.config.RepoConfig(repoName)
+	return ok && rc.Staging
+}
+
+// Staging 仓库暂存区: GET /repo/{repo}/staging 列出待发布的文件和变更集标识，DELETE 丢弃全部暂存文件
+func (h *API) Staging(ctx *fasthttp.RequestCtx, repoName, method string) {
+	if _, err := h.repoService.GetRepoType(ctx, repoName); err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+
+	if method == "DELETE" {
+		if !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpDelete, Repo: repoName, Path: service.StagingRoot}) {
+			return
+		}
+		n, err := h.repoService.DiscardStaged(ctx, repoName)
+		if err != nil {
+			log.Logger.Errorf("Failed to discard staged files of %s: %v", repoName, err)
+			if h.storageUnavailable(ctx, err) {
+				return
+			}
+			h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to discard staged files", err)
+			return
+		}
+		h.sendJSONResponse(ctx, &types.StagingList{
+			Status: types.Status{Status: "success", Message: h.localize(ctx, "Staged files discarded"), Code: fasthttp.StatusOK},
+			Repo:   repoName,
+			Count:  n,
+			Files:  []types.DirectoryEntry{},
+		}, fasthttp.StatusOK)
+		return
+	}
+
+	files, changeset, err := h.repoService.Staged(ctx, repoName)
+	if err != nil {
+		log.Logger.Errorf("Failed to list staged files of %s: %v", repoName, err)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to list staged files", err)
+		return
+	}
+	response := &types.StagingList{
+		Status:    types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:      repoName,
+		Changeset: changeset,
+		Count:     len(files),
+		Files:     make([]types.DirectoryEntry, 0, len(files)),
+	}
+	for _, f := range files {
+		response.Files = append(response.Files, types.DirectoryEntry{
+			Name:    f.Name,
+			Type:    "file",
+			Size:    f.Size,
+			ModTime: f.ModTime.UTC().Format(time.RFC3339),
+		})
+	}
+	h.sendJSONResponse(ctx, response, fasthttp.StatusOK)
+}
+
+// Publish 发布暂存区: POST /repo/{repo}/publish，可选 {"changeset": "..."}。
+// 暂存区在审核后有变化时返回 409，发布后刷新元数据
+func (h *API) Publish(ctx *fasthttp.RequestCtx, repoName string) {
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	req := &types.PublishRequest{}
+	if body := ctx.PostBody(); len(body) > 0 {
+		if err := req.UnmarshalJSON(body); err != nil {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeInvalidJSON, "Invalid JSON format", err)
+			return
+		}
+	}
+	changeset := strings.TrimSpace(req.Changeset)
+
+	// 授权检查看到的文件必须与发布的一致，未指定变更集时固定为当前内容
+	if h.authz != nil {
+		files, id, err := h.repoService.Staged(ctx, repoName)
+		if err != nil {
+			h.publishFailed(ctx, repoName, err)
+			return
+		}
+		if changeset == "" {
+			changeset = id
+		}
+		input := types.AuthzInput{Operation: authz.OpPublish, Repo: repoName, RepoType: repoType, Files: []string{}}
+		for _, f := range files {
+			input.Files = append(input.Files, f.Name)
+		}
+		if !h.checkWrite(ctx, input) {
+			return
+		}
+	}
+
+	// 受保护仓库中覆盖已有文件的发布等待另一位管理员批准，批准的是当前的变更集
+	if h.protected(repoName) {
+		existing, id, err := h.repoService.StagedOverwrites(ctx, repoName)
+		switch {
+		case err == nil && id == "":
+			err = service.ErrNothingStaged
+		case err == nil && changeset != "" && changeset != id:
+			err = service.ErrChangesetChanged
+		}
+		if err != nil {
+			h.publishFailed(ctx, repoName, err)
+			return
+		}
+		if len(existing) > 0 {
+			h.requestApproval(ctx, types.ApprovalRequest{Operation: approval.OpPublish, Repo: repoName, Files: existing, Changeset: id})
+			return
+		}
+		changeset = id
+	}
+
+	published, id, err := h.repoService.Publish(ctx, repoName, changeset)
+	if err != nil && len(published) == 0 {
+		h.publishFailed(ctx, repoName, err)
+		return
+	}
+	response := &types.PublishResult{
+		Status:    types.Status{Status: "success", Message: h.localize(ctx, "Changeset published"), Code: fasthttp.StatusOK},
+		Repo:      repoName,
+		Changeset: id,
+		Count:     len(published),
+		Files:     published,
+	}
+	if err != nil {
+		log.Logger.Errorf("Publishing %s was incomplete: %v", repoName, err)
+		response.Status = types.Status{Status: "partial_success", Message: h.localize(ctx, "Changeset partially published: "+err.Error()), Code: fasthttp.StatusInternalServerError}
+	}
+	h.sendJSONResponse(ctx, response, response.Status.Code)
+}
+
+func (h *API) publishFailed(ctx *fasthttp.RequestCtx, repoName string, err error) {
+	switch {
+	case errors.Is(err, service.ErrNothingStaged):
+		h.sendError(ctx, fasthttp.StatusConflict, apierr.CodeConflict, "Nothing is staged", nil)
+	case errors.Is(err, service.ErrChangesetChanged):
+		h.sendError(ctx, fasthttp.StatusConflict, apierr.CodeConflict, "Staging area changed since the changeset was reviewed, list it again", nil)
+	default:
+		if h.legalHeld(ctx, err) {
+			return
+		}
+		log.Logger.Errorf("Failed to publish %s: %v", repoName, err)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to publish", err)
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"plus/internal/apierr"
+	"plus/internal/cache"
+	"plus/internal/log"
+	"plus/internal/thumbnail"
+	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/pkg/repo"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetThumbnails 为 files 仓库中的图片提供缩略图，size 为默认的长边像素数，生成结果保存在 c 中
+func (h *API) SetThumbnails(size int, c *cache.MetadataCache) {
+	h.thumbSize = size
+	h.thumbCache = c
+	utils.SetThumbnailURL(h.thumbnailHref)
+}
+
+// thumbnailURL 返回仓库中文件 rel 的缩略图地址
+func thumbnailURL(repoName, rel string) string {
+	return "/repo/" + repoName + "/thumb/" + rel
+}
+
+// addThumbnails 为 files 仓库目录列表中的图片设置缩略图地址，dir 为目录在仓库中的路径
+func (h *API) addThumbnails(repoName, repoType, dir string, entries []types.DirectoryEntry) {
+	if h.thumbCache == nil || repoType != string(repo.Files) {
+		return
+	}
+	for i := range entries {
+		if entries[i].Type == "file" && thumbnail.Supported(entries[i].Name) {
+			entries[i].ThumbnailURL = thumbnailURL(repoName, path.Join(dir, entries[i].Name))
+		}
+	}
+}
+
+// thumbnailHref 返回目录页面中文件链接（/{repo}/{path} 或 /repo/{repo}/files/{path}）的缩略图地址，
+// 不是 files 仓库中的图片时为空
+func (h *API) thumbnailHref(href string) string {
+	if !thumbnail.Supported(href) {
+		return ""
+	}
+	p := strings.TrimPrefix(path.Clean(href), "/")
+	if rest, ok := strings.CutPrefix(p, "repo/"); ok {
+		repoName, rel, ok := strings.Cut(rest, "/files/")
+		if !ok {
+			return ""
+		}
+		p = repoName + "/" + rel
+	}
+	ctx := context.Background()
+	repoName := h.repoFor(ctx, p)
+	if repoName == "" {
+		return ""
+	}
+	if repoType, err := h.repoService.GetRepoType(ctx, repoName); err != nil || repoType != string(repo.Files) {
+		return ""
+	}
+	return thumbnailURL(repoName, strings.TrimPrefix(p, repoName+"/"))
+}
+
+// Thumbnail 返回 files 仓库中图片的缩略图: GET /repo/{repo}/thumb/{path}?size=。
+// 缩略图按原图的大小和修改时间缓存，原图变化后重新生成
+func (h *API) Thumbnail(ctx *fasthttp.RequestCtx, repoName, filePath string) {
+	if h.thumbCache == nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFeatureDisabled, "Thumbnails are not enabled", nil)
+		return
+	}
+	repoType, err := h.repoService.GetRepoType(ctx, repoName)
+	if err != nil {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeRepoNotFound, "Repository not found", nil)
+		return
+	}
+	if repoType != string(repo.Files) {
+		h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeUnsupportedRepo, "Thumbnails are only available in files repositories", nil)
+		return
+	}
+	rel := strings.Trim(path.Clean("/"+filePath), "/")
+	if !thumbnail.Supported(rel) {
+		h.sendError(ctx, fasthttp.StatusUnsupportedMediaType, apierr.CodeUnsupportedFile, "Thumbnails are only available for PNG, JPEG and GIF images", nil)
+		return
+	}
+	size := h.thumbSize
+	if args := ctx.QueryArgs(); args.Has("size") {
+		n, err := args.GetUint("size")
+		if err != nil || n < thumbnail.MinSize || n > thumbnail.MaxSize {
+			h.sendError(ctx, fasthttp.StatusBadRequest, apierr.CodeBadRequest,
+				fmt.Sprintf("size must be between %d and %d", thumbnail.MinSize, thumbnail.MaxSize), nil)
+			return
+		}
+		size = n
+	}
+
+	name := path.Join(repoName, rel)
+	info, err := h.repoService.StatPackageFile(ctx, "", name)
+	if err != nil || info.IsDir {
+		if err != nil && h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFileNotFound, "File not found", nil)
+		return
+	}
+	if info.Size > thumbnail.MaxSourceSize {
+		h.sendError(ctx, fasthttp.StatusUnprocessableEntity, apierr.CodeUnsupportedFile, "Image is too large for a thumbnail", nil)
+		return
+	}
+
+	key := fmt.Sprintf("%s@%d:%d:%d", name, size, info.Size, info.ModTime.UnixNano())
+	entry, err := h.thumbCache.Load(key, func() (*cache.MetadataEntry, error) {
+		reader, err := h.repoService.OpenPackageFileRange(ctx, "", name, 0, info.Size)
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		data, err := thumbnail.Generate(reader, size)
+		if err != nil {
+			return nil, err
+		}
+		log.Logger.Debugf("Generated %dpx thumbnail for %s (%d bytes)", size, name, len(data))
+		return &cache.MetadataEntry{Data: data, ModTime: info.ModTime}, nil
+	})
+	if err != nil {
+		if errors.Is(err, thumbnail.ErrUnsupported) || errors.Is(err, thumbnail.ErrTooLarge) {
+			h.sendError(ctx, fasthttp.StatusUnprocessableEntity, apierr.CodeUnsupportedFile, "Cannot generate a thumbnail for this file", err)
+			return
+		}
+		log.Logger.Errorf("Failed to generate thumbnail for %s: %v", name, err)
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusInternalServerError, apierr.CodeInternal, "Failed to generate thumbnail", err)
+		return
+	}
+
+	ctx.Response.Header.Set("Content-Type", http.DetectContentType(entry.Data))
+	writeMetadataEntry(ctx, entry)
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"plus/internal/metrics"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// SetUsage 设置按用户和令牌统计的用量
+func (h *API) SetUsage(u *metrics.Usage) {
+	h.usage = u
+}
+
+// recordUsage 请求结束后按身份记入请求数和请求体、响应体的字节数
+func (h *API) recordUsage(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		next(ctx)
+		if h.usage == nil {
+			return
+		}
+		var uploaded, downloaded int64
+		if n := ctx.Request.Header.ContentLength(); n > 0 {
+			uploaded = int64(n)
+		}
+		if !ctx.IsHead() {
+			// 流式响应只有 Content-Length 已知时计入
+			if !ctx.Response.IsBodyStream() {
+				downloaded = int64(len(ctx.Response.Body()))
+			} else if n := ctx.Response.Header.ContentLength(); n > 0 {
+				downloaded = int64(n)
+			}
+		}
+		p, _ := h.principal(ctx)
+		var token string
+		if p != nil {
+			token = p.TokenID
+		}
+		h.usage.Record(principalName(p), token, uploaded, downloaded)
+	}
+}
+
+// Usage 用量报表: GET /api/v1/usage?from=&to=&user=&group=user|token|day&format=json|csv。
+// 日期为 UTC 的 YYYY-MM-DD，默认为本月 1 日到今天。管理员可查看所有用户，其他用户只能查看自己
+func (h *API) Usage(ctx *fasthttp.RequestCtx) {
+	args := ctx.QueryArgs()
+	user := string(args.Peek("user"))
+	if h.config.Auth.Enabled {
+		p, admin := h.isAdmin(ctx)
+		switch {
+		case admin:
+		case p == nil:
+			h.unauthorized(ctx)
+			return
+		case user != "" && user != p.Name:
+			h.sendJSONError(ctx, "Only administrators can view the usage of other users", fasthttp.StatusForbidden)
+			return
+		default:
+			user = p.Name
+		}
+	}
+
+	today := time.Now().UTC()
+	from := today.Format("2006-01") + "-01"
+	to := today.Format(metrics.DateLayout)
+	for _, d := range []struct {
+		name string
+		dst  *string
+	}{{"from", &from}, {"to", &to}} {
+		if v := string(args.Peek(d.name)); v != "" {
+			if _, err := time.Parse(metrics.DateLayout, v); err != nil {
+				h.sendJSONError(ctx, fmt.Sprintf("Invalid %s date %q, expected YYYY-MM-DD", d.name, v), fasthttp.StatusBadRequest)
+				return
+			}
+			*d.dst = v
+		}
+	}
+	if from > to {
+		h.sendJSONError(ctx, "from must not be after to", fasthttp.StatusBadRequest)
+		return
+	}
+	group := string(args.Peek("group"))
+	switch group {
+	case "":
+		group = metrics.UsageByUser
+	case metrics.UsageByUser, metrics.UsageByToken, metrics.UsageByDay:
+	default:
+		h.sendJSONError(ctx, fmt.Sprintf("Invalid group %q, use user, token or day", group), fasthttp.StatusBadRequest)
+		return
+	}
+	format := string(args.Peek("format"))
+	if format == "" && strings.Contains(string(ctx.Request.Header.Peek("Accept")), "text/csv") {
+		format = "csv"
+	}
+
+	if h.usage == nil {
+		h.sendJSONError(ctx, "Usage tracking is not enabled", fasthttp.StatusNotFound)
+		return
+	}
+	records, total := h.usage.Report(from, to, user, group)
+
+	switch format {
+	case "csv":
+		h.sendUsageCSV(ctx, from, to, records)
+	case "", "json":
+		h.sendJSONResponse(ctx, &types.UsageReport{
+			Status:  types.Status{Status: "success", Code: fasthttp.StatusOK},
+			From:    from,
+			To:      to,
+			Group:   group,
+			Records: records,
+			Total:   total,
+		}, fasthttp.StatusOK)
+	default:
+		h.sendJSONError(ctx, fmt.Sprintf("Invalid format %q, use json or csv", format), fasthttp.StatusBadRequest)
+	}
+}
+
+// sendUsageCSV 以 CSV 返回用量，每条记录一行
+func (h *API) sendUsageCSV(ctx *fasthttp.RequestCtx, from, to string, records []types.UsageRecord) {
+	ctx.SetContentType("text/csv; charset=utf-8")
+	ctx.Response.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=usage-%s-%s.csv", from, to))
+	w := csv.NewWriter(ctx)
+	w.Write([]string{"date", "user", "token", "requests", "bytes_uploaded", "bytes_downloaded"})
+	for _, r := range records {
+		w.Write([]string{
+			r.Date,
+			r.User,
+			r.Token,
+			strconv.FormatInt(r.Requests, 10),
+			strconv.FormatInt(r.BytesUploaded, 10),
+			strconv.FormatInt(r.BytesDownloaded, 10),
+		})
+	}
+	w.Flush()
+}
@@ -0,0 +1,467 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+
+	"plus/internal/access"
+	"plus/internal/authz"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// /api/v1 路由，按顺序匹配
+var v1Routes = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"bundle", regexp.MustCompile(`^/api/v1/repos/(.+)/bundle$`)},
+	{"upload_token", regexp.MustCompile(`^/api/v1/repos/(.+)/upload-tokens/([^/]+)$`)},
+	{"upload_tokens", regexp.MustCompile(`^/api/v1/repos/(.+)/upload-tokens$`)},
+	{"fsck", regexp.MustCompile(`^/api/v1/repos/(.+)/fsck$`)},
+	{"files", regexp.MustCompile(`^/api/v1/repos/(.+)/files$`)},
+	{"notes", regexp.MustCompile(`^/api/v1/repos/(.+?)/notes/(.+)$`)},
+	{"legal_hold", regexp.MustCompile(`^/api/v1/repos/(.+?)/holds/(.+)$`)},
+	{"legal_holds", regexp.MustCompile(`^/api/v1/repos/(.+)/holds$`)},
+	{"readme", regexp.MustCompile(`^/api/v1/repos/(.+)/readme$`)},
+	{"clone", regexp.MustCompile(`^/api/v1/repos/(.+)/clone$`)},
+	{"generations", regexp.MustCompile(`^/api/v1/repos/(.+)/generations$`)},
+	{"package_versions", regexp.MustCompile(`^/api/v1/repos/(.+)/packages/([^/]+)/versions$`)},
+	{"package_latest", regexp.MustCompile(`^/api/v1/repos/(.+)/packages/([^/]+)/latest$`)},
+	{"maintenance", regexp.MustCompile(`^/api/v1/repos/(.+)/maintenance$`)},
+	{"upstream_check", regexp.MustCompile(`^/api/v1/repos/(.+)/upstream/check$`)},
+	{"upstream", regexp.MustCompile(`^/api/v1/repos/(.+)/upstream$`)},
+	{"job_run", regexp.MustCompile(`^/api/v1/jobs/(.+)/run$`)},
+	{"jobs", regexp.MustCompile(`^/api/v1/jobs$`)},
+	{"alerts", regexp.MustCompile(`^/api/v1/alerts$`)},
+	{"approval_decide", regexp.MustCompile(`^/api/v1/approvals/([^/]+)/(approve|reject)$`)},
+	{"approval", regexp.MustCompile(`^/api/v1/approvals/([^/]+)$`)},
+	{"approvals", regexp.MustCompile(`^/api/v1/approvals$`)},
+	{"chat_webhook_test", regexp.MustCompile(`^/api/v1/chat-webhooks/([^/]+)/test$`)},
+	{"chat_webhook", regexp.MustCompile(`^/api/v1/chat-webhooks/([^/]+)$`)},
+	{"chat_webhooks", regexp.MustCompile(`^/api/v1/chat-webhooks$`)},
+	{"events", regexp.MustCompile(`^/api/v1/events$`)},
+	{"delivery_retry", regexp.MustCompile(`^/api/v1/deliveries/([^/]+)/retry$`)},
+	{"delivery", regexp.MustCompile(`^/api/v1/deliveries/([^/]+)$`)},
+	{"deliveries", regexp.MustCompile(`^/api/v1/deliveries$`)},
+	{"import", regexp.MustCompile(`^/api/v1/import$`)},
+	{"attestation_keys", regexp.MustCompile(`^/api/v1/attestation-keys$`)},
+	{"feature", regexp.MustCompile(`^/api/v1/features/([^/]+)$`)},
+	{"features", regexp.MustCompile(`^/api/v1/features$`)},
+	{"plugins", regexp.MustCompile(`^/api/v1/plugins$`)},
+	{"search", regexp.MustCompile(`^/api/v1/search$`)},
+	{"search_packages", regexp.MustCompile(`^/api/v1/search/packages$`)},
+	{"diff", regexp.MustCompile(`^/api/v1/diff$`)},
+	{"whoami", regexp.MustCompile(`^/api/v1/whoami$`)},
+	{"usage", regexp.MustCompile(`^/api/v1/usage$`)},
+	{"users_import", regexp.MustCompile(`^/api/v1/users/import$`)},
+	{"users_sync", regexp.MustCompile(`^/api/v1/users/sync$`)},
+	{"user_token", regexp.MustCompile(`^/api/v1/users/([^/]+)/tokens/([^/]+)$`)},
+	{"user_tokens", regexp.MustCompile(`^/api/v1/users/([^/]+)/tokens$`)},
+	{"user", regexp.MustCompile(`^/api/v1/users/([^/]+)$`)},
+	{"users", regexp.MustCompile(`^/api/v1/users$`)},
+	{"group", regexp.MustCompile(`^/api/v1/groups/([^/]+)$`)},
+	{"groups", regexp.MustCompile(`^/api/v1/groups$`)},
+	{"role", regexp.MustCompile(`^/api/v1/roles/([^/]+)$`)},
+	{"roles", regexp.MustCompile(`^/api/v1/roles$`)},
+	{"upload_progress", regexp.MustCompile(`^/api/v1/uploads/([^/]+)/progress$`)},
+	{"session", regexp.MustCompile(`^/api/v1/sessions/([^/]+)$`)},
+	{"sessions", regexp.MustCompile(`^/api/v1/sessions$`)},
+}
+
+func handleAPIV1(ctx *fasthttp.RequestCtx, method, path string, h *API) bool {
+	for _, route := range v1Routes {
+		matches := route.pattern.FindStringSubmatch(path)
+		if matches == nil {
+			continue
+		}
+
+		switch route.name {
+		case "bundle":
+			if method == "POST" {
+				if !h.authorize(ctx, matches[1], access.ClassDownload) {
+					return true
+				}
+				h.BuildBundle(ctx, matches[1])
+				return true
+			}
+		case "upload_token":
+			if method == "DELETE" {
+				h.DeleteUploadToken(ctx, matches[1], matches[2])
+				return true
+			}
+		case "upload_tokens":
+			switch method {
+			case "GET":
+				h.ListUploadTokens(ctx, matches[1])
+				return true
+			case "POST":
+				h.CreateUploadToken(ctx, matches[1])
+				return true
+			}
+		case "fsck":
+			switch method {
+			case "GET":
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.CheckRepo(ctx, matches[1], false)
+				}
+				return true
+			case "POST":
+				repair := ctx.QueryArgs().GetBool("repair")
+				class := access.ClassDownload
+				if repair {
+					class = access.ClassRefresh
+				}
+				if !h.authorize(ctx, matches[1], class) {
+					return true
+				}
+				if repair && !h.checkWrite(ctx, types.AuthzInput{Operation: authz.OpRefresh, Repo: matches[1]}) {
+					return true
+				}
+				h.CheckRepo(ctx, matches[1], repair)
+				return true
+			}
+		case "files":
+			if method == "GET" {
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.ListRepoFiles(ctx, matches[1])
+				}
+				return true
+			}
+		case "notes":
+			switch method {
+			case "GET":
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.PackageNotes(ctx, matches[1], matches[2], method)
+				}
+				return true
+			case "PUT", "DELETE":
+				if h.authorize(ctx, matches[1], access.ClassUpload) {
+					h.PackageNotes(ctx, matches[1], matches[2], method)
+				}
+				return true
+			}
+		case "readme":
+			switch method {
+			case "GET":
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.RepoReadme(ctx, matches[1], method)
+				}
+				return true
+			case "PUT", "DELETE":
+				if h.authorize(ctx, matches[1], access.ClassUpload) {
+					h.RepoReadme(ctx, matches[1], method)
+				}
+				return true
+			}
+		case "legal_hold":
+			switch method {
+			case "GET":
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.LegalHold(ctx, matches[1], matches[2], method)
+				}
+				return true
+			case "PUT", "DELETE":
+				// 上传和删除权限不够，否则有这些权限的人可以自己解除标记再删除制品
+				if h.requireAdmin(ctx) && h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.LegalHold(ctx, matches[1], matches[2], method)
+				}
+				return true
+			}
+		case "legal_holds":
+			if method == "GET" {
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.ListLegalHolds(ctx, matches[1])
+				}
+				return true
+			}
+		case "clone":
+			if method == "POST" {
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.CloneRepo(ctx, matches[1])
+				}
+				return true
+			}
+		case "generations":
+			if method == "GET" {
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.ListGenerations(ctx, matches[1])
+				}
+				return true
+			}
+		case "package_versions":
+			if method == "GET" {
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.ListPackageVersions(ctx, matches[1], matches[2])
+				}
+				return true
+			}
+		case "package_latest":
+			if method == "GET" {
+				if h.authorize(ctx, matches[1], access.ClassDownload) {
+					h.GetLatestPackage(ctx, matches[1], matches[2])
+				}
+				return true
+			}
+		case "maintenance":
+			switch method {
+			case "GET", "PUT", "DELETE":
+				h.MaintenanceSwitch(ctx, matches[1], method)
+				return true
+			}
+		case "upstream_check":
+			if method == "POST" {
+				h.GetUpstreamHealth(ctx, matches[1], true)
+				return true
+			}
+		case "upstream":
+			if method == "GET" {
+				h.GetUpstreamHealth(ctx, matches[1], false)
+				return true
+			}
+		case "job_run":
+			if method == "POST" {
+				h.RunJob(ctx, matches[1])
+				return true
+			}
+		case "jobs":
+			if method == "GET" {
+				h.ListJobs(ctx)
+				return true
+			}
+		case "alerts":
+			if method == "GET" {
+				h.ListAlerts(ctx)
+				return true
+			}
+		case "approval_decide":
+			if method == "POST" {
+				h.DecideApproval(ctx, matches[1], matches[2])
+				return true
+			}
+		case "approval":
+			if method == "GET" {
+				h.GetApproval(ctx, matches[1])
+				return true
+			}
+		case "approvals":
+			if method == "GET" {
+				h.ListApprovals(ctx)
+				return true
+			}
+		case "chat_webhook_test":
+			if method == "POST" {
+				h.TestChatWebhook(ctx, matches[1])
+				return true
+			}
+		case "chat_webhook":
+			switch method {
+			case "GET":
+				h.GetChatWebhook(ctx, matches[1])
+				return true
+			case "PUT":
+				h.PutChatWebhook(ctx, matches[1])
+				return true
+			case "DELETE":
+				h.DeleteChatWebhook(ctx, matches[1])
+				return true
+			}
+		case "chat_webhooks":
+			switch method {
+			case "GET":
+				h.ListChatWebhooks(ctx)
+				return true
+			case "POST":
+				h.PutChatWebhook(ctx, "")
+				return true
+			}
+		case "events":
+			if method == "GET" {
+				h.Events(ctx)
+				return true
+			}
+		case "delivery_retry":
+			if method == "POST" {
+				h.RetryDelivery(ctx, matches[1])
+				return true
+			}
+		case "delivery":
+			switch method {
+			case "GET":
+				h.GetDelivery(ctx, matches[1])
+				return true
+			case "DELETE":
+				h.DeleteDelivery(ctx, matches[1])
+				return true
+			}
+		case "deliveries":
+			if method == "GET" {
+				h.ListDeliveries(ctx)
+				return true
+			}
+		case "import":
+			if method == "POST" {
+				h.ImportRepos(ctx)
+				return true
+			}
+		case "attestation_keys":
+			if method == "GET" {
+				h.ListAttestationKeys(ctx)
+				return true
+			}
+		case "feature":
+			switch method {
+			case "GET", "PUT", "DELETE":
+				h.Feature(ctx, matches[1], method)
+				return true
+			}
+		case "features":
+			if method == "GET" {
+				h.ListFeatures(ctx)
+				return true
+			}
+		case "plugins":
+			if method == "GET" {
+				h.ListPlugins(ctx)
+				return true
+			}
+		case "search":
+			if method == "GET" {
+				h.Search(ctx)
+				return true
+			}
+		case "search_packages":
+			if method == "GET" {
+				h.SearchPackages(ctx)
+				return true
+			}
+		case "diff":
+			if method == "GET" {
+				a := strings.Trim(string(ctx.QueryArgs().Peek("a")), "/")
+				b := strings.Trim(string(ctx.QueryArgs().Peek("b")), "/")
+				// 缺少参数时由 DiffRepos 返回 400
+				if a == "" || b == "" || h.authorize(ctx, a, access.ClassDownload) && h.authorize(ctx, b, access.ClassDownload) {
+					h.DiffRepos(ctx, a, b)
+				}
+				return true
+			}
+		case "whoami":
+			if method == "GET" {
+				h.WhoAmI(ctx)
+				return true
+			}
+		case "usage":
+			if method == "GET" {
+				h.Usage(ctx)
+				return true
+			}
+		case "users_import":
+			if method == "POST" {
+				h.ImportUsers(ctx)
+				return true
+			}
+		case "users_sync":
+			if method == "POST" {
+				h.SyncUsers(ctx)
+				return true
+			}
+		case "user_token":
+			if method == "DELETE" {
+				h.DeleteUserToken(ctx, matches[1], matches[2])
+				return true
+			}
+		case "user_tokens":
+			if method == "POST" {
+				h.CreateUserToken(ctx, matches[1])
+				return true
+			}
+		case "user":
+			switch method {
+			case "GET":
+				h.GetUser(ctx, matches[1])
+				return true
+			case "PUT":
+				h.PutUser(ctx, matches[1])
+				return true
+			case "DELETE":
+				h.DeleteUser(ctx, matches[1])
+				return true
+			}
+		case "users":
+			switch method {
+			case "GET":
+				h.ListUsers(ctx)
+				return true
+			case "POST":
+				h.PutUser(ctx, "")
+				return true
+			}
+		case "group":
+			switch method {
+			case "GET":
+				h.GetGroup(ctx, matches[1])
+				return true
+			case "PUT":
+				h.PutGroup(ctx, matches[1])
+				return true
+			case "DELETE":
+				h.DeleteGroup(ctx, matches[1])
+				return true
+			}
+		case "groups":
+			switch method {
+			case "GET":
+				h.ListGroups(ctx)
+				return true
+			case "POST":
+				h.PutGroup(ctx, "")
+				return true
+			}
+		case "role":
+			switch method {
+			case "GET":
+				h.GetRole(ctx, matches[1])
+				return true
+			case "PUT":
+				h.PutRole(ctx, matches[1])
+				return true
+			case "DELETE":
+				h.DeleteRole(ctx, matches[1])
+				return true
+			}
+		case "roles":
+			switch method {
+			case "GET":
+				h.ListRoles(ctx)
+				return true
+			case "POST":
+				h.PutRole(ctx, "")
+				return true
+			}
+		case "upload_progress":
+			if method == "GET" {
+				h.GetUploadProgress(ctx, matches[1])
+				return true
+			}
+		case "session":
+			if method == "DELETE" {
+				h.RevokeSession(ctx, matches[1])
+				return true
+			}
+		case "sessions":
+			switch method {
+			case "GET":
+				h.ListSessions(ctx)
+				return true
+			case "POST":
+				h.CreateSession(ctx)
+				return true
+			case "DELETE":
+				h.RevokeSessions(ctx)
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"path"
+
+	"plus/internal/apierr"
+	"plus/internal/deps"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ListPackageVersions 包在仓库元数据中的所有版本，按 EVR 从新到旧:
+// GET /api/v1/repos/{repo}/packages/{name}/versions?arch=
+func (h *API) ListPackageVersions(ctx *fasthttp.RequestCtx, repoName, name string) {
+	pkgs, ok := h.packageVersions(ctx, repoName, name)
+	if !ok {
+		return
+	}
+
+	resp := &types.PackageVersions{
+		Status:   types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:     repoName,
+		Name:     name,
+		Latest:   pkgs[0].Version,
+		Count:    len(pkgs),
+		Versions: make([]types.PackageVersion, 0, len(pkgs)),
+	}
+	for _, p := range pkgs {
+		resp.Versions = append(resp.Versions, packageVersion(repoName, p))
+	}
+	h.sendJSONResponse(ctx, resp, fasthttp.StatusOK)
+}
+
+// GetLatestPackage 包的最新版本: GET /api/v1/repos/{repo}/packages/{name}/latest?arch=
+// 带 redirect=true 时重定向到包文件，便于 curl -L 直接下载
+func (h *API) GetLatestPackage(ctx *fasthttp.RequestCtx, repoName, name string) {
+	pkgs, ok := h.packageVersions(ctx, repoName, name)
+	if !ok {
+		return
+	}
+
+	latest := packageVersion(repoName, pkgs[0])
+	if ctx.QueryArgs().GetBool("redirect") {
+		ctx.Redirect(latest.URL, fasthttp.StatusFound)
+		return
+	}
+	resp := &types.PackageLatest{
+		Status:  types.Status{Status: "success", Code: fasthttp.StatusOK},
+		Repo:    repoName,
+		Name:    name,
+		Package: latest,
+	}
+	h.sendJSONResponse(ctx, resp, fasthttp.StatusOK)
+}
+
+// packageVersions 返回包的所有版本（按 arch 参数过滤，noarch/all 始终包含），没有时写入 404
+func (h *API) packageVersions(ctx *fasthttp.RequestCtx, repoName, name string) ([]*deps.Package, bool) {
+	ix, ok := h.metadataIndex(ctx, repoName, "Package versions")
+	if !ok {
+		return nil, false
+	}
+
+	arch := string(ctx.QueryArgs().Peek("arch"))
+	var pkgs []*deps.Package
+	for _, p := range ix.Versions(name) {
+		if arch == "" || p.Arch == arch || p.Arch == "noarch" || p.Arch == "all" {
+			pkgs = append(pkgs, p)
+		}
+	}
+	if len(pkgs) == 0 {
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodePackageNotFound, "Package not found in repository metadata", nil)
+		return nil, false
+	}
+	return pkgs, true
+}
+
+func packageVersion(repoName string, p *deps.Package) types.PackageVersion {
+	return types.PackageVersion{
+		Version:      p.Version,
+		Arch:         p.Arch,
+		File:         path.Base(p.Location),
+		Size:         p.Size,
+		Checksum:     p.Checksum,
+		ChecksumType: p.SumType,
+		URL:          path.Join("/", repoName, p.Location),
+		License:      p.License,
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"path"
+	"strings"
+
+	"plus/internal/apierr"
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/utils"
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+
+	"github.com/valyala/fasthttp"
+)
+
+// defaultWebsiteIndex 没有配置 index 时目录的首页
+const defaultWebsiteIndex = "index.html"
+
+// websiteFor 返回包含 cleanPath 且开启了 website 的 files 仓库及其配置
+func (h *API) websiteFor(ctx *fasthttp.RequestCtx, cleanPath string) (string, config.WebsiteConfig, bool) {
+	if h.config == nil || !hasWebsites(h.config) {
+		return "", config.WebsiteConfig{}, false
+	}
+	repoName := h.repoFor(ctx, cleanPath)
+	if repoName == "" {
+		return "", config.WebsiteConfig{}, false
+	}
+	rc, ok := h.config.RepoConfig(repoName)
+	if !ok || !rc.Website.Enabled {
+		return "", config.WebsiteConfig{}, false
+	}
+	if repoType, err := h.repoService.GetRepoType(ctx, repoName); err != nil || repoType != string(repo.Files) {
+		return "", config.WebsiteConfig{}, false
+	}
+	if rc.Website.Index == "" {
+		rc.Website.Index = defaultWebsiteIndex
+	}
+	return repoName, rc.Website, true
+}
+
+// hasWebsites 判断是否有仓库开启了 website，没有时不必为每个请求查找仓库
+func hasWebsites(cfg *config.Config) bool {
+	for _, rc := range cfg.Repositories {
+		if rc.Website.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// serveWebsite 以静态网站方式返回 cleanPath（存储中的相对路径）：文件按扩展名设置 Content-Type 在浏览器中打开，
+// 目录返回其中的首页，找不到时返回配置的 404 页面。不在网站仓库中，或是没有首页的目录时返回 false，按普通文件和目录列表处理
+func (h *API) serveWebsite(ctx *fasthttp.RequestCtx, cleanPath string) bool {
+	cleanPath = strings.Trim(path.Clean("/"+cleanPath), "/")
+	repoName, site, ok := h.websiteFor(ctx, cleanPath)
+	if !ok {
+		return false
+	}
+
+	info, err := h.repoService.StatPackageFile(ctx, "", cleanPath)
+	if h.storageUnavailable(ctx, err) {
+		return true
+	}
+	if err == nil && !info.IsDir {
+		h.serveWebsiteFile(ctx, cleanPath, info, fasthttp.StatusOK)
+		return true
+	}
+
+	// 请求 JSON 目录列表时不返回首页
+	index := path.Join(cleanPath, site.Index)
+	if indexInfo, err := h.repoService.StatPackageFile(ctx, "", index); err == nil && !indexInfo.IsDir && !wantsJSON(ctx) {
+		// 目录地址不以 / 结尾时重定向，页面中的相对链接才能正确解析
+		if p := ctx.Path(); !bytes.HasSuffix(p, []byte("/")) {
+			location := string(p) + "/"
+			if query := ctx.URI().QueryString(); len(query) > 0 {
+				location += "?" + string(query)
+			}
+			ctx.Response.Header.Set("Location", location)
+			ctx.SetStatusCode(fasthttp.StatusMovedPermanently)
+			return true
+		}
+		h.serveWebsiteFile(ctx, index, indexInfo, fasthttp.StatusOK)
+		return true
+	}
+
+	// 没有首页的目录仍返回目录列表。对象存储中没有目录，以 / 结尾的地址按目录处理
+	if (err == nil && info.IsDir) || bytes.HasSuffix(ctx.Path(), []byte("/")) || cleanPath == repoName {
+		return false
+	}
+	if site.NotFound == "" {
+		return false
+	}
+	notFound := path.Join(repoName, strings.Trim(path.Clean("/"+site.NotFound), "/"))
+	notFoundInfo, err := h.repoService.StatPackageFile(ctx, "", notFound)
+	if err != nil || notFoundInfo.IsDir {
+		log.Logger.Warnf("Website 404 page %s of %s not found", site.NotFound, repoName)
+		return false
+	}
+	h.serveWebsiteFile(ctx, notFound, notFoundInfo, fasthttp.StatusNotFound)
+	return true
+}
+
+// serveWebsiteFile 返回网站中的文件，不作为附件下载
+func (h *API) serveWebsiteFile(ctx *fasthttp.RequestCtx, name string, info storage.FileInfo, statusCode int) {
+	reader, err := h.repoService.OpenPackageFileRange(ctx, "", name, 0, info.Size)
+	if err != nil {
+		if h.storageUnavailable(ctx, err) {
+			return
+		}
+		h.sendError(ctx, fasthttp.StatusNotFound, apierr.CodeFileNotFound, "File not found", nil)
+		return
+	}
+	// reader 由 fasthttp 在响应写完后关闭
+	ctx.Response.Header.Set("Content-Type", utils.GetWebsiteContentType(name))
+	if !info.ModTime.IsZero() {
+		ctx.Response.Header.SetLastModified(info.ModTime)
+	}
+	ctx.SetStatusCode(statusCode)
+	ctx.SetBodyStream(reader, int(info.Size))
+}
@@ -0,0 +1,224 @@
+package apierr
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"plus/internal/i18n"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// 错误码，见 docs/api.md 的 Error Codes
+const (
+	CodeBadRequest         = "bad_request"
+	CodeInvalidJSON        = "invalid_json"
+	CodeInvalidPath        = "invalid_path"
+	CodeInvalidRepoType    = "invalid_repo_type"
+	CodeUnsupportedFile    = "unsupported_file_type"
+	CodeUnsupportedRepo    = "unsupported_for_repo_type"
+	CodeNoFile             = "no_file"
+	CodeFilenameRejected   = "filename_rejected"
+	CodeLicenseDenied      = "license_denied"
+	CodeInvalidPackage     = "invalid_package"
+	CodeInvalidAttestation = "invalid_attestation"
+	CodeUnverified         = "unverified_attestation"
+	CodeInvalidSignature   = "invalid_signature"
+	CodeUnverifiedSig      = "unverified_signature"
+	CodePackageMismatch    = "package_mismatch"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodePolicyDenied       = "policy_denied"
+	CodeNotFound           = "not_found"
+	CodeRepoNotFound       = "repo_not_found"
+	CodePackageNotFound    = "package_not_found"
+	CodeFileNotFound       = "file_not_found"
+	CodeMetadataNotFound   = "metadata_not_found"
+	CodeKeyNotFound        = "key_not_found"
+	CodeFeatureDisabled    = "feature_disabled"
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeConflict           = "conflict"
+	CodeFileExists         = "file_exists"
+	CodeRangeNotSatisfied  = "range_not_satisfiable"
+	CodeTooManyRequests    = "too_many_requests"
+	CodeInternal           = "internal_error"
+	CodeUpstreamFailed     = "upstream_failed"
+	CodeUnavailable        = "service_unavailable"
+	CodeStorageUnavailable = "storage_unavailable"
+	CodeAuthzUnavailable   = "authz_unavailable"
+	CodeRepoMaintenance    = "repo_maintenance"
+	CodeLegalHold          = "legal_hold"
+)
+
+// 请求 ID 保存在 UserValue 中
+const requestIDKey = "request_id"
+
+// HeaderRequestID 请求和响应中的请求 ID 头
+const HeaderRequestID = "X-Request-ID"
+
+// 支持的错误响应格式
+const (
+	mediaJSON    = "application/json"
+	mediaProblem = "application/problem+json"
+	mediaText    = "text/plain"
+)
+
+// CodeFor 返回 HTTP 状态对应的默认错误码
+func CodeFor(status int) string {
+	switch status {
+	case fasthttp.StatusBadRequest:
+		return CodeBadRequest
+	case fasthttp.StatusUnauthorized:
+		return CodeUnauthorized
+	case fasthttp.StatusForbidden:
+		return CodeForbidden
+	case fasthttp.StatusNotFound:
+		return CodeNotFound
+	case fasthttp.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case fasthttp.StatusConflict:
+		return CodeConflict
+	case fasthttp.StatusRequestedRangeNotSatisfiable:
+		return CodeRangeNotSatisfied
+	case fasthttp.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case fasthttp.StatusBadGateway:
+		return CodeUpstreamFailed
+	case fasthttp.StatusServiceUnavailable:
+		return CodeUnavailable
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// RequestID 返回请求 ID：沿用客户端或上游代理传入的 X-Request-ID，否则生成一个，并写入响应头
+func RequestID(ctx *fasthttp.RequestCtx) string {
+	if id, ok := ctx.UserValue(requestIDKey).(string); ok {
+		return id
+	}
+	id := string(ctx.Request.Header.Peek(HeaderRequestID))
+	if !validID(id) {
+		b := make([]byte, 8)
+		rand.Read(b)
+		id = hex.EncodeToString(b)
+	}
+	ctx.SetUserValue(requestIDKey, id)
+	ctx.Response.Header.Set(HeaderRequestID, id)
+	return id
+}
+
+// validID 只接受较短的可打印 ID，避免日志和响应头注入
+func validID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Write 写入错误响应。按 Accept 返回 JSON（默认）、RFC 9457 problem+json 或纯文本，
+// detail 为可选的详细原因，如底层错误
+func Write(ctx *fasthttp.RequestCtx, status int, code, message, detail string) {
+	id := RequestID(ctx)
+	ctx.SetStatusCode(status)
+	ctx.Response.ResetBody()
+	// 按 Accept-Language 翻译消息，错误码和详情不翻译
+	lang := i18n.FromRequest(ctx)
+	message = i18n.Message(lang, message)
+	i18n.SetHeaders(ctx, lang)
+
+	var body interface{ MarshalJSON() ([]byte, error) }
+	switch Preferred(string(ctx.Request.Header.Peek("Accept")), mediaJSON, mediaProblem, mediaText) {
+	case mediaText:
+		ctx.SetContentType("text/plain; charset=utf-8")
+		fmt.Fprintf(ctx, "%s\n", message)
+		if detail != "" {
+			fmt.Fprintf(ctx, "%s\n", detail)
+		}
+		fmt.Fprintf(ctx, "code: %s\nrequest-id: %s\n", code, id)
+		return
+	case mediaProblem:
+		ctx.SetContentType("application/problem+json")
+		body = &types.Problem{
+			Type:      "urn:plus:error:" + code,
+			Title:     message,
+			Status:    status,
+			Detail:    detail,
+			Instance:  string(ctx.Path()),
+			Code:      code,
+			RequestID: id,
+		}
+	default:
+		// 顶层的 message 与旧格式相同，带有详情
+		legacy := message
+		if detail != "" {
+			legacy += ": " + detail
+		}
+		ctx.SetContentType("application/json; charset=utf-8")
+		body = &types.ErrorResponse{
+			Status:  "error",
+			Message: legacy,
+			Code:    status,
+			Error:   types.ErrorDetail{Code: code, Message: message, Detail: detail, RequestID: id},
+		}
+	}
+
+	data, err := body.MarshalJSON()
+	if err != nil {
+		log.Logger.Debugf("Failed to encode error response: %v", err)
+		ctx.SetBodyString(`{"status":"error","message":"Internal server error"}`)
+		return
+	}
+	ctx.SetBody(data)
+}
+
+// Preferred 按 Accept 的 q 值从 offers 中选择响应格式。*/* 和没有匹配时返回 offers[0]，
+// type/* 匹配 offers 中第一个同类型的格式
+func Preferred(accept string, offers ...string) string {
+	type candidate struct {
+		media string
+		q     float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(accept, ",") {
+		media, params, _ := strings.Cut(part, ";")
+		c := candidate{media: strings.ToLower(strings.TrimSpace(media)), q: 1}
+		if c.media == "" {
+			continue
+		}
+		for _, p := range strings.Split(params, ";") {
+			if k, v, ok := strings.Cut(strings.TrimSpace(p), "="); ok && strings.TrimSpace(k) == "q" {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+					c.q = q
+				}
+			}
+		}
+		if c.q > 0 {
+			candidates = append(candidates, c)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	for _, c := range candidates {
+		if c.media == "*/*" {
+			return offers[0]
+		}
+		for _, offer := range offers {
+			if c.media == offer || strings.HasSuffix(c.media, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(c.media, "*")) {
+				return offer
+			}
+		}
+	}
+	return offers[0]
+}
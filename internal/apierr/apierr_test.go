@@ -0,0 +1,116 @@
+package apierr
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestPreferred(t *testing.T) {
+	cases := map[string]string{
+		"":                                    mediaJSON,
+		"*/*":                                 mediaJSON,
+		"application/json":                    mediaJSON,
+		"application/problem+json":            mediaProblem,
+		"text/plain":                          mediaText,
+		"text/*":                              mediaText,
+		"text/plain;q=0.5, application/json":  mediaJSON,
+		"application/json;q=0, text/plain":    mediaText,
+		"text/html,application/xml;q=0.9,*/*": mediaJSON,
+		"image/png":                           mediaJSON,
+	}
+	for accept, want := range cases {
+		if got := Preferred(accept, mediaJSON, mediaProblem, mediaText); got != want {
+			t.Errorf("%q: got %s, want %s", accept, got, want)
+		}
+	}
+	// 浏览器和 curl 默认仍得到 HTML
+	html := map[string]string{
+		"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8": "text/html",
+		"*/*":                         "text/html",
+		"application/json":            "application/json",
+		"application/json, text/html": "application/json",
+	}
+	for accept, want := range html {
+		if got := Preferred(accept, "text/html", "application/json"); got != want {
+			t.Errorf("%q: got %s, want %s", accept, got, want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var ctx fasthttp.RequestCtx
+	ctx.Request.SetRequestURI("/repo/el9/upload")
+	ctx.Request.Header.Set(HeaderRequestID, "req-1")
+	Write(&ctx, fasthttp.StatusInternalServerError, CodeInternal, "Upload failed", "disk full")
+
+	var resp types.ErrorResponse
+	if err := resp.UnmarshalJSON(ctx.Response.Body()); err != nil {
+		t.Fatal(err)
+	}
+	// 顶层字段与旧格式兼容
+	if resp.Status != "error" || resp.Code != 500 || resp.Message != "Upload failed: disk full" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if resp.Error != (types.ErrorDetail{Code: CodeInternal, Message: "Upload failed", Detail: "disk full", RequestID: "req-1"}) {
+		t.Fatalf("unexpected error %+v", resp.Error)
+	}
+	if got := string(ctx.Response.Header.Peek(HeaderRequestID)); got != "req-1" {
+		t.Fatalf("X-Request-ID = %q", got)
+	}
+
+	var problem fasthttp.RequestCtx
+	problem.Request.SetRequestURI("/repo/missing")
+	problem.Request.Header.Set("Accept", "application/problem+json")
+	Write(&problem, fasthttp.StatusNotFound, CodeRepoNotFound, "Repository not found", "")
+	var p types.Problem
+	if err := p.UnmarshalJSON(problem.Response.Body()); err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != 404 || p.Code != CodeRepoNotFound || p.Instance != "/repo/missing" || len(p.RequestID) != 16 {
+		t.Fatalf("unexpected problem %+v", p)
+	}
+	if ct := string(problem.Response.Header.ContentType()); ct != "application/problem+json" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	var text fasthttp.RequestCtx
+	text.Request.Header.Set("Accept", "text/plain")
+	text.Request.Header.Set(HeaderRequestID, "bad id\r\nX-Evil: 1")
+	Write(&text, fasthttp.StatusTooManyRequests, CodeTooManyRequests, "Too many concurrent downloads", "")
+	body := string(text.Response.Body())
+	if !strings.HasPrefix(body, "Too many concurrent downloads\ncode: too_many_requests\nrequest-id: ") || strings.Contains(body, "Evil") {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
+
+func TestWriteLocalized(t *testing.T) {
+	var ctx fasthttp.RequestCtx
+	ctx.Request.Header.Set("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
+	Write(&ctx, fasthttp.StatusNotFound, CodeRepoNotFound, "Repository not found", "el9")
+	var resp types.ErrorResponse
+	if err := resp.UnmarshalJSON(ctx.Response.Body()); err != nil {
+		t.Fatal(err)
+	}
+	// 只翻译消息，错误码和详情不变
+	if resp.Message != "仓库不存在: el9" || resp.Error.Message != "仓库不存在" || resp.Error.Code != CodeRepoNotFound || resp.Error.Detail != "el9" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if got := string(ctx.Response.Header.Peek("Content-Language")); got != "zh" {
+		t.Fatalf("Content-Language = %q", got)
+	}
+	if got := string(ctx.Response.Header.Peek("Vary")); !strings.Contains(got, "Accept-Language") {
+		t.Fatalf("Vary = %q", got)
+	}
+}
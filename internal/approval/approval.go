@@ -0,0 +1,230 @@
+package approval
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"plus/internal/types"
+)
+
+// FileName 批准请求在 DatabasePath 下的文件名
+const FileName = "approvals.json"
+
+// 需要批准的操作
+const (
+	OpDeleteRepo = "delete_repo"
+	OpOverwrite  = "overwrite" // 上传覆盖已有文件
+	OpPublish    = "publish"   // 发布的暂存区会覆盖已有文件
+)
+
+// 请求状态
+const (
+	StatePending  = "pending"
+	StateApproved = "approved" // 已批准，正在执行
+	StateRejected = "rejected"
+	StateExecuted = "executed"
+	StateFailed   = "failed"
+)
+
+// keepDecided 已处理的请求保留的时间
+const keepDecided = 30 * 24 * time.Hour
+
+var (
+	ErrNotFound     = errors.New("approval request not found")
+	ErrDecided      = errors.New("approval request was already decided")
+	ErrSelfApproval = errors.New("approval request must be approved by a different admin")
+)
+
+// Store 批准请求，保存在内存中，每次修改重写 DatabasePath 下的文件
+type Store struct {
+	file string
+
+	mu       sync.Mutex
+	requests map[string]*types.ApprovalRequest
+}
+
+// Open 加载 dir 下的批准请求。上次退出时正在执行的请求标记为失败
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create approval directory: %w", err)
+	}
+	s := &Store{file: filepath.Join(dir, FileName), requests: make(map[string]*types.ApprovalRequest)}
+	data, err := os.ReadFile(s.file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read approvals: %w", err)
+	}
+	var saved types.ApprovalData
+	if err := saved.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("approvals %s are corrupt: %w", s.file, err)
+	}
+	for i := range saved.Requests {
+		r := saved.Requests[i]
+		if r.State == StateApproved {
+			r.State = StateFailed
+			r.Error = "interrupted by a restart"
+		}
+		s.requests[r.ID] = &r
+	}
+	return s, nil
+}
+
+// NewID 生成请求 ID。覆盖上传在创建请求前就要按 ID 保存文件
+func NewID() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// Create 创建待批准的请求，r.ID 为空时生成。同一仓库已有相同的待批准删除或发布时返回已有请求
+func (s *Store) Create(r types.ApprovalRequest) (types.ApprovalRequest, error) {
+	if r.ID == "" {
+		id, err := NewID()
+		if err != nil {
+			return r, err
+		}
+		r.ID = id
+	}
+	r.State = StatePending
+	r.RequestedAt = time.Now().UTC().Format(time.RFC3339)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Operation != OpOverwrite {
+		for _, existing := range s.requests {
+			if existing.State == StatePending && existing.Operation == r.Operation &&
+				existing.Repo == r.Repo && existing.Changeset == r.Changeset {
+				return *existing, nil
+			}
+		}
+	}
+	s.requests[r.ID] = &r
+	return r, s.save()
+}
+
+// Get 返回请求
+func (s *Store) Get(id string) (types.ApprovalRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.requests[id]
+	if !ok {
+		return types.ApprovalRequest{}, ErrNotFound
+	}
+	return *r, nil
+}
+
+// List 按创建时间返回请求，state 不为空时只返回该状态的请求
+func (s *Store) List(state string) []types.ApprovalRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]types.ApprovalRequest, 0, len(s.requests))
+	for _, r := range s.requests {
+		if state == "" || r.State == state {
+			list = append(list, *r)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].RequestedAt != list[j].RequestedAt {
+			return list[i].RequestedAt < list[j].RequestedAt
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// Approve 批准请求，之后由调用方执行并调用 Complete。发起人不能批准自己的请求
+func (s *Store) Approve(id, by string) (types.ApprovalRequest, error) {
+	return s.decide(id, func(r *types.ApprovalRequest) error {
+		if r.RequestedBy == by {
+			return ErrSelfApproval
+		}
+		r.State = StateApproved
+		r.DecidedBy = by
+		return nil
+	})
+}
+
+// Reject 拒绝请求，发起人也可以撤回自己的请求
+func (s *Store) Reject(id, by, reason string) (types.ApprovalRequest, error) {
+	return s.decide(id, func(r *types.ApprovalRequest) error {
+		r.State = StateRejected
+		r.DecidedBy = by
+		r.Reason = reason
+		return nil
+	})
+}
+
+func (s *Store) decide(id string, apply func(*types.ApprovalRequest) error) (types.ApprovalRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.requests[id]
+	if !ok {
+		return types.ApprovalRequest{}, ErrNotFound
+	}
+	if r.State != StatePending {
+		return *r, ErrDecided
+	}
+	updated := *r
+	if err := apply(&updated); err != nil {
+		return *r, err
+	}
+	updated.DecidedAt = time.Now().UTC().Format(time.RFC3339)
+	*r = updated
+	return *r, s.save()
+}
+
+// Complete 记录已批准请求的执行结果
+func (s *Store) Complete(id string, err error) (types.ApprovalRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.requests[id]
+	if !ok {
+		return types.ApprovalRequest{}, ErrNotFound
+	}
+	r.State = StateExecuted
+	if err != nil {
+		r.State = StateFailed
+		r.Error = err.Error()
+	}
+	return *r, s.save()
+}
+
+// save 重写文件，同时清理过期的已处理请求。调用方持有锁
+func (s *Store) save() error {
+	cutoff := time.Now().Add(-keepDecided).UTC().Format(time.RFC3339)
+	saved := types.ApprovalData{Requests: make([]types.ApprovalRequest, 0, len(s.requests))}
+	for id, r := range s.requests {
+		if r.State != StatePending && r.State != StateApproved && r.DecidedAt != "" && r.DecidedAt < cutoff {
+			delete(s.requests, id)
+			continue
+		}
+		saved.Requests = append(saved.Requests, *r)
+	}
+	sort.Slice(saved.Requests, func(i, j int) bool { return saved.Requests[i].ID < saved.Requests[j].ID })
+
+	data, err := saved.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	tmp := s.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("write approvals: %w", err)
+	}
+	if err := os.Rename(tmp, s.file); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write approvals: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package approval
+
+import (
+	"errors"
+	"testing"
+
+	"plus/internal/types"
+)
+
+func TestApproveRequiresSecondAdmin(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := s.Create(types.ApprovalRequest{Operation: OpDeleteRepo, Repo: "el9", RequestedBy: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ID == "" || r.State != StatePending {
+		t.Fatalf("Unexpected request: %+v", r)
+	}
+	// 相同的待批准删除不重复创建
+	if dup, _ := s.Create(types.ApprovalRequest{Operation: OpDeleteRepo, Repo: "el9", RequestedBy: "bob"}); dup.ID != r.ID {
+		t.Errorf("Duplicate delete request created: %+v", dup)
+	}
+
+	if _, err := s.Approve(r.ID, "alice"); !errors.Is(err, ErrSelfApproval) {
+		t.Fatalf("expected ErrSelfApproval, got %v", err)
+	}
+	approved, err := s.Approve(r.ID, "bob")
+	if err != nil || approved.State != StateApproved || approved.DecidedBy != "bob" {
+		t.Fatalf("Approve = %+v, %v", approved, err)
+	}
+	if _, err := s.Reject(r.ID, "carol", ""); !errors.Is(err, ErrDecided) {
+		t.Fatalf("expected ErrDecided, got %v", err)
+	}
+
+	// 执行中重启的请求标记为失败
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := reopened.Get(r.ID); got.State != StateFailed || got.Error == "" {
+		t.Errorf("Interrupted request = %+v", got)
+	}
+
+	if done, err := s.Complete(r.ID, nil); err != nil || done.State != StateExecuted {
+		t.Fatalf("Complete = %+v, %v", done, err)
+	}
+	if pending := s.List(StatePending); len(pending) != 0 {
+		t.Errorf("Unexpected pending requests: %+v", pending)
+	}
+}
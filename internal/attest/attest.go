@@ -0,0 +1,241 @@
+package attest
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"plus/internal/config"
+	"plus/internal/types"
+)
+
+// MaxSize 来源证明封装的最大长度
+const MaxSize = 1 << 20
+
+// statementPrefix in-toto 声明的 _type，v0.1 和 v1 都以此开头
+const statementPrefix = "https://in-toto.io/Statement/"
+
+var (
+	// ErrInvalid 不是 DSSE 封装的 in-toto 声明
+	ErrInvalid = errors.New("invalid attestation")
+	// ErrSubjectMismatch 声明的对象中没有与制品 SHA256 相同的
+	ErrSubjectMismatch = errors.New("attestation subject does not match the artifact")
+	// ErrUnverified 没有配置的公钥能验证的签名
+	ErrUnverified = errors.New("attestation signature not verified")
+	// ErrUnknownKey 没有该名称的公钥
+	ErrUnknownKey = errors.New("unknown attestation key")
+)
+
+// Statement 解析后的来源证明
+type Statement struct {
+	Envelope      types.DSSEEnvelope
+	PayloadType   string
+	PredicateType string
+	Subjects      []types.InTotoSubject
+	payload       []byte
+}
+
+// Parse 解析 DSSE 封装及其中的 in-toto 声明，不验证签名
+func Parse(data []byte) (*Statement, error) {
+	if len(data) > MaxSize {
+		return nil, fmt.Errorf("%w: larger than %d bytes", ErrInvalid, MaxSize)
+	}
+	st := &Statement{}
+	if err := st.Envelope.UnmarshalJSON(bytes.TrimSpace(data)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if len(st.Envelope.Signatures) == 0 {
+		return nil, fmt.Errorf("%w: the envelope has no signatures", ErrInvalid)
+	}
+	payload, err := decode(st.Envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: payload is not base64: %v", ErrInvalid, err)
+	}
+	var statement types.InTotoStatement
+	if err := statement.UnmarshalJSON(payload); err != nil {
+		return nil, fmt.Errorf("%w: payload is not an in-toto statement: %v", ErrInvalid, err)
+	}
+	if !strings.HasPrefix(statement.Type, statementPrefix) {
+		return nil, fmt.Errorf("%w: unsupported statement type %q", ErrInvalid, statement.Type)
+	}
+	if len(statement.Subject) == 0 {
+		return nil, fmt.Errorf("%w: the statement has no subject", ErrInvalid)
+	}
+	st.PayloadType = st.Envelope.PayloadType
+	st.PredicateType = statement.PredicateType
+	st.Subjects = statement.Subject
+	st.payload = payload
+	return st, nil
+}
+
+// Covers 判断声明的对象中是否有 SHA256 为 sum（十六进制）的制品
+func (st *Statement) Covers(sum string) bool {
+	for _, s := range st.Subjects {
+		if strings.EqualFold(s.Digest["sha256"], sum) {
+			return true
+		}
+	}
+	return false
+}
+
+// SubjectNames 返回声明的对象名称
+func (st *Statement) SubjectNames() []string {
+	names := make([]string, 0, len(st.Subjects))
+	for _, s := range st.Subjects {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// Key 验证签名的公钥
+type Key struct {
+	Name      string
+	Algorithm string // ecdsa、ed25519、rsa
+	PEM       string
+	public    crypto.PublicKey
+}
+
+// Verifier 用配置的公钥验证来源证明的签名
+type Verifier struct {
+	keys []Key
+}
+
+// NewVerifier 读取配置的公钥文件，keys 为空时返回的 Verifier 不验证任何签名
+func NewVerifier(keys []config.AttestationKeyConfig) (*Verifier, error) {
+	v := &Verifier{}
+	for _, kc := range keys {
+		if kc.Name == "" || kc.PublicKey == "" {
+			return nil, fmt.Errorf("attestation key needs a name and a public-key file")
+		}
+		data, err := os.ReadFile(kc.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("attestation key %s: %w", kc.Name, err)
+		}
+		key, err := ParseKey(kc.Name, data)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = append(v.keys, key)
+	}
+	return v, nil
+}
+
+// ParseKey 解析 PEM 格式的 PKIX 公钥
+func ParseKey(name string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, fmt.Errorf("attestation key %s: no PEM block", name)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return Key{}, fmt.Errorf("attestation key %s: %w", name, err)
+	}
+	key := Key{Name: name, PEM: string(pem.EncodeToMemory(block)), public: pub}
+	switch pub.(type) {
+	case *ecdsa.PublicKey:
+		key.Algorithm = "ecdsa"
+	case ed25519.PublicKey:
+		key.Algorithm = "ed25519"
+	case *rsa.PublicKey:
+		key.Algorithm = "rsa"
+	default:
+		return Key{}, fmt.Errorf("attestation key %s: unsupported key type %T", name, pub)
+	}
+	return key, nil
+}
+
+// Keys 返回配置的公钥
+func (v *Verifier) Keys() []Key {
+	if v == nil {
+		return nil
+	}
+	return v.keys
+}
+
+// Only 返回只使用公钥 name 的 Verifier
+func (v *Verifier) Only(name string) (*Verifier, error) {
+	for _, key := range v.Keys() {
+		if key.Name == name {
+			return &Verifier{keys: []Key{key}}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownKey, name)
+}
+
+// Verify 返回验证了某个签名的公钥名称，没有时返回 false。
+// 签名的 keyid 与公钥名称相同时先用该公钥验证
+func (v *Verifier) Verify(st *Statement) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	message := pae(st.PayloadType, st.payload)
+	for _, sig := range st.Envelope.Signatures {
+		raw, err := decode(sig.Sig)
+		if err != nil {
+			continue
+		}
+		for _, key := range v.ordered(sig.KeyID) {
+			if verify(key.public, message, raw) {
+				return key.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (v *Verifier) ordered(keyID string) []Key {
+	keys := make([]Key, 0, len(v.keys))
+	for _, key := range v.keys {
+		if key.Name == keyID {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range v.keys {
+		if key.Name != keyID {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func verify(pub crypto.PublicKey, message, sig []byte) bool {
+	digest := sha256.Sum256(message)
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest[:], sig)
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, message, sig)
+	case *rsa.PublicKey:
+		if rsa.VerifyPSS(k, crypto.SHA256, digest[:], sig, nil) == nil {
+			return true
+		}
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	}
+	return false
+}
+
+// pae DSSE 签名的内容："DSSEv1" SP LEN(type) SP type SP LEN(body) SP body
+func pae(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+// decode 解码 base64，DSSE 允许标准和 URL 两种编码
+func decode(s string) ([]byte, error) {
+	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return data, nil
+	}
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
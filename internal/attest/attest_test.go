@@ -0,0 +1,121 @@
+package attest
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"testing"
+
+	"plus/internal/types"
+)
+
+const payloadType = "application/vnd.in-toto+json"
+
+func TestVerify(t *testing.T) {
+	artifact := sha256.Sum256([]byte("app-1.0.tar.gz"))
+	statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"app-1.0.tar.gz","digest":{"sha256":"%x"}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{"buildDefinition":{}}}`, artifact)
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edPub, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ci := mustKey(t, "ci", &ecKey.PublicKey)
+	release := mustKey(t, "release", edPub)
+
+	message := pae(payloadType, []byte(statement))
+	digest := sha256.Sum256(message)
+	ecSig, _ := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	envelope := testEnvelope(statement, "", ecSig)
+
+	st, err := Parse(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.PredicateType != "https://slsa.dev/provenance/v1" || len(st.SubjectNames()) != 1 {
+		t.Errorf("statement = %+v", st)
+	}
+	if !st.Covers(fmt.Sprintf("%X", artifact)) || st.Covers(fmt.Sprintf("%x", sha256.Sum256(nil))) {
+		t.Error("Covers")
+	}
+
+	v := &Verifier{keys: []Key{release, ci}}
+	if name, ok := v.Verify(st); !ok || name != "ci" {
+		t.Errorf("Verify = %q, %v", name, ok)
+	}
+	if _, ok := (&Verifier{keys: []Key{release}}).Verify(st); ok {
+		t.Error("verified with the wrong key")
+	}
+	var none *Verifier
+	if _, ok := none.Verify(st); ok {
+		t.Error("nil verifier verified")
+	}
+
+	// keyid 与公钥名称相同，Ed25519 签名
+	st, err = Parse(testEnvelope(statement, "release", ed25519.Sign(edKey, message)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, ok := v.Verify(st); !ok || name != "release" {
+		t.Errorf("Verify ed25519 = %q, %v", name, ok)
+	}
+
+	// 签名的不是这个声明
+	tampered := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"evil","digest":{"sha256":"%x"}}]}`, artifact)
+	if st, err := Parse(testEnvelope(tampered, "", ecSig)); err != nil {
+		t.Fatal(err)
+	} else if _, ok := v.Verify(st); ok {
+		t.Error("tampered statement verified")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"not json":      []byte("provenance"),
+		"no signatures": []byte(`{"payloadType":"x","payload":"e30=","signatures":[]}`),
+		"bad payload":   testEnvelope("not json", "", []byte("sig")),
+		"not in-toto":   testEnvelope(`{"_type":"https://example.com/v1","subject":[{"name":"a"}]}`, "", []byte("sig")),
+		"no subject":    testEnvelope(`{"_type":"https://in-toto.io/Statement/v0.1","subject":[]}`, "", []byte("sig")),
+	} {
+		if _, err := Parse(data); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: err = %v, want ErrInvalid", name, err)
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	if _, err := ParseKey("bad", []byte("not pem")); err == nil {
+		t.Error("accepted a key without PEM block")
+	}
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if key := mustKey(t, "ci", &ecKey.PublicKey); key.Algorithm != "ecdsa" {
+		t.Errorf("Algorithm = %q", key.Algorithm)
+	}
+}
+
+func mustKey(t *testing.T, name string, pub any) Key {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ParseKey(name, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func testEnvelope(statement, keyID string, sig []byte) []byte {
+	env := types.DSSEEnvelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString([]byte(statement)),
+		Signatures:  []types.DSSESignature{{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}
+	data, _ := env.MarshalJSON()
+	return data
+}
@@ -0,0 +1,78 @@
+package attest
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+
+	"plus/internal/types"
+)
+
+// MaxSignatureSize cosign 签名或签名 bundle 的最大长度
+const MaxSignatureSize = 64 << 10
+
+// ErrInvalidSignature 不是 base64 签名或 cosign bundle
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Signature 解析后的 cosign 签名
+type Signature struct {
+	Raw         []byte
+	Certificate string // PEM，没有时为空
+}
+
+// ParseSignature 解析 cosign sign-blob 输出的 base64 签名（--output-signature）
+// 或 bundle（--bundle），不验证签名
+func ParseSignature(data []byte) (*Signature, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > MaxSignatureSize {
+		return nil, fmt.Errorf("%w: larger than %d bytes", ErrInvalidSignature, MaxSignatureSize)
+	}
+	sig := &Signature{}
+	encoded := string(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		var bundle types.CosignBundle
+		if err := bundle.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+		encoded = bundle.Base64Signature
+		if bundle.Cert != "" {
+			cert, err := decode(bundle.Cert)
+			if err != nil || !bytes.HasPrefix(cert, []byte("-----BEGIN")) {
+				return nil, fmt.Errorf("%w: cert is not a base64 encoded PEM certificate", ErrInvalidSignature)
+			}
+			sig.Certificate = string(cert)
+		}
+	}
+	raw, err := decode(strings.TrimSpace(encoded))
+	if err != nil || len(raw) == 0 {
+		return nil, fmt.Errorf("%w: not a base64 encoded signature", ErrInvalidSignature)
+	}
+	sig.Raw = raw
+	return sig, nil
+}
+
+// VerifyDigest 返回验证了制品签名的公钥名称，digest 为制品的 SHA256。
+// cosign 的 ECDSA 和 RSA 签名针对制品的 SHA256；Ed25519 签名需要制品全文，不支持
+func (v *Verifier) VerifyDigest(digest []byte, sig []byte) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	for _, key := range v.keys {
+		switch k := key.public.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, digest, sig) {
+				return key.Name, true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, sig) == nil ||
+				rsa.VerifyPSS(k, crypto.SHA256, digest, sig, nil) == nil {
+				return key.Name, true
+			}
+		}
+	}
+	return "", false
+}
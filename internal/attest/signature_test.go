@@ -0,0 +1,56 @@
+package attest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSignature(t *testing.T) {
+	blob := []byte("app-1.0.tar.gz")
+	digest := sha256.Sum256(blob)
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	raw, _ := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	encoded := base64.StdEncoding.EncodeToString(raw)
+
+	sig, err := ParseSignature([]byte(encoded + "\n"))
+	if err != nil || string(sig.Raw) != string(raw) || sig.Certificate != "" {
+		t.Fatalf("ParseSignature = %+v, %v", sig, err)
+	}
+	cert := base64.StdEncoding.EncodeToString([]byte("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"))
+	bundle := fmt.Sprintf(`{"base64Signature":%q,"cert":%q,"rekorBundle":{"SignedEntryTimestamp":"x"}}`, encoded, cert)
+	if sig, err := ParseSignature([]byte(bundle)); err != nil || string(sig.Raw) != string(raw) || sig.Certificate == "" {
+		t.Errorf("bundle = %+v, %v", sig, err)
+	}
+	for _, data := range []string{"", "not base64!", `{"base64Signature":""}`, `{"base64Signature":"c2ln","cert":"bm90IHBlbQ=="}`} {
+		if _, err := ParseSignature([]byte(data)); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("ParseSignature(%q) = %v, want ErrInvalidSignature", data, err)
+		}
+	}
+
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v := &Verifier{keys: []Key{mustKey(t, "other", &other.PublicKey), mustKey(t, "ci", &key.PublicKey)}}
+	if name, ok := v.VerifyDigest(digest[:], raw); !ok || name != "ci" {
+		t.Errorf("VerifyDigest = %q, %v", name, ok)
+	}
+	changed := sha256.Sum256([]byte("app-1.1.tar.gz"))
+	if _, ok := v.VerifyDigest(changed[:], raw); ok {
+		t.Error("verified another file")
+	}
+
+	only, err := v.Only("other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := only.VerifyDigest(digest[:], raw); ok {
+		t.Error("verified with a key that was not selected")
+	}
+	if _, err := v.Only("missing"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Only(missing) = %v", err)
+	}
+}
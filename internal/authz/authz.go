@@ -0,0 +1,287 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/license"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/cavaliergopher/rpm"
+)
+
+// 写操作
+const (
+	OpCreate     = "create"
+	OpClone      = "clone"
+	OpUpload     = "upload"
+	OpTreeUpload = "tree_upload"
+	OpRefresh    = "refresh"
+	OpPublish    = "publish"
+	OpAnnotate   = "annotate" // 设置或删除包说明
+	OpHold       = "hold"     // 设置保留标记
+	OpRelease    = "release"  // 解除保留标记
+	OpAttest     = "attest"   // 上传或删除来源证明
+	OpSign       = "sign"     // 上传或删除 cosign 签名
+	OpReadme     = "readme"   // 设置或删除仓库说明
+	OpDelete     = "delete"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+	maxResponse    = 1 << 20
+)
+
+// ErrUnavailable webhook 调用失败且没有配置 fail-open
+var ErrUnavailable = errors.New("authorization webhook unavailable")
+
+// RPM 签名头中的签名标签：RSA/DSA 只签名包头，PGP/GPG 签名包头和内容
+var (
+	headerSignatureTags  = []int{268, 267}
+	packageSignatureTags = []int{1002, 1005}
+)
+
+// leadSize RPM 文件开头 lead 的长度
+const leadSize = 96
+
+// Webhook 写操作前询问外部授权服务，如 OPA
+type Webhook struct {
+	cfg     config.AuthzWebhookConfig
+	client  *http.Client
+	keyring openpgp.EntityList // 校验 RPM 签名的可信公钥
+}
+
+// New 创建授权 webhook
+func New(cfg config.AuthzWebhookConfig) (*Webhook, error) {
+	if _, err := url.ParseRequestURI(cfg.URL); err != nil {
+		return nil, fmt.Errorf("invalid authorization webhook url %q: %w", cfg.URL, err)
+	}
+	timeout := defaultTimeout
+	if cfg.Timeout != "" {
+		d, err := time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid authorization webhook timeout %q: %w", cfg.Timeout, err)
+		}
+		timeout = d
+	}
+	w := &Webhook{cfg: cfg, client: &http.Client{Timeout: timeout}}
+	for _, path := range cfg.TrustedKeys {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read trusted key: %w", err)
+		}
+		entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+		if err != nil {
+			if entities, err = openpgp.ReadKeyRing(bytes.NewReader(data)); err != nil {
+				return nil, fmt.Errorf("parse trusted key %s: %w", path, err)
+			}
+		}
+		w.keyring = append(w.keyring, entities...)
+	}
+	return w, nil
+}
+
+// Check 询问 webhook 是否允许写操作，拒绝时返回原因。
+// webhook 不可用时配置了 fail-open 则允许，否则返回 ErrUnavailable
+func (w *Webhook) Check(ctx context.Context, input types.AuthzInput) (bool, string, error) {
+	allowed, reason, err := w.query(ctx, input)
+	if err != nil {
+		if w.cfg.FailOpen {
+			log.Logger.Warnf("Authorization webhook failed, allowing %s on %s: %v", input.Operation, input.Repo, err)
+			return true, "", nil
+		}
+		return false, "", fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return allowed, reason, nil
+}
+
+func (w *Webhook) query(ctx context.Context, input types.AuthzInput) (bool, string, error) {
+	req := &types.AuthzRequest{Input: input}
+	body, err := req.MarshalJSON()
+	if err != nil {
+		return false, "", err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range w.cfg.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(httpReq)
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return false, "", fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponse))
+	if err != nil {
+		return false, "", err
+	}
+	var r types.AuthzResponse
+	if err := r.UnmarshalJSON(data); err != nil {
+		return false, "", fmt.Errorf("invalid webhook response: %w", err)
+	}
+	return decide(&r)
+}
+
+// decide 解析 webhook 的决定。OPA 的规则未定义时响应中没有 result，视为拒绝
+func decide(r *types.AuthzResponse) (bool, string, error) {
+	result := bytes.TrimSpace(r.Result)
+	switch string(result) {
+	case "", "null":
+		return r.Allow, r.Reason, nil
+	case "true":
+		return true, "", nil
+	case "false":
+		return false, "", nil
+	}
+	var res types.AuthzResult
+	if err := res.UnmarshalJSON(result); err != nil {
+		return false, "", fmt.Errorf("unexpected webhook result %s", result)
+	}
+	return res.Allow, res.Reason, nil
+}
+
+// Inspect 读取上传的软件包的名称、版本、许可证和签名，读完后回到文件开头。
+// RPM 从包头读取，签名用 trusted-keys 校验，没有可信公钥时 signed 始终为 false；
+// DEB 按 name_version_arch.deb 的文件名解析，许可证从 control 读取，不含签名信息。无法识别时返回 nil
+func (w *Webhook) Inspect(r io.ReadSeeker, filename string) *types.AuthzPackage {
+	switch {
+	case strings.HasSuffix(filename, ".rpm"):
+		pkg, err := rpm.Read(r)
+		if _, serr := r.Seek(0, io.SeekStart); serr != nil {
+			log.Logger.Warnf("Failed to rewind %s: %v", filename, serr)
+		}
+		if err != nil {
+			log.Logger.Debugf("Failed to read rpm header of %s: %v", filename, err)
+			return nil
+		}
+		p := &types.AuthzPackage{
+			Format:  "rpm",
+			Name:    pkg.Name(),
+			Version: pkg.Version(),
+			Release: pkg.Release(),
+			Arch:    pkg.Architecture(),
+			License: strings.TrimSpace(pkg.License()),
+		}
+		if id, ok := w.verify(r, &pkg.Signature); ok {
+			p.Signed, p.KeyID = true, id
+		}
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			log.Logger.Warnf("Failed to rewind %s: %v", filename, err)
+		}
+		return p
+	case strings.HasSuffix(filename, ".deb"):
+		parts := strings.Split(strings.TrimSuffix(path.Base(filename), ".deb"), "_")
+		if len(parts) != 3 {
+			return nil
+		}
+		version, err := url.PathUnescape(parts[1])
+		if err != nil {
+			version = parts[1]
+		}
+		p := &types.AuthzPackage{Format: "deb", Name: parts[0], Version: version, Arch: parts[2]}
+		p.License, _ = license.Read(r, filename)
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			log.Logger.Warnf("Failed to rewind %s: %v", filename, err)
+		}
+		return p
+	}
+	return nil
+}
+
+// verify 用可信公钥校验 RPM 的签名，先校验只签名包头的签名，再校验签名包头和内容的签名。
+// 通过时返回签名密钥的 ID
+func (w *Webhook) verify(r io.ReadSeeker, sigHeader *rpm.Header) (string, bool) {
+	if len(w.keyring) == 0 {
+		return "", false
+	}
+	start, end, err := headerRange(r)
+	if err != nil {
+		log.Logger.Debugf("Failed to locate rpm header: %v", err)
+		return "", false
+	}
+	for _, group := range []struct {
+		tags       []int
+		headerOnly bool
+	}{{headerSignatureTags, true}, {packageSignatureTags, false}} {
+		for _, tag := range group.tags {
+			sig := sigHeader.GetTag(tag).Bytes()
+			if len(sig) == 0 {
+				continue
+			}
+			if _, err := r.Seek(start, io.SeekStart); err != nil {
+				return "", false
+			}
+			var signed io.Reader = r
+			if group.headerOnly {
+				signed = io.LimitReader(r, end-start)
+			}
+			if _, err := openpgp.CheckDetachedSignature(w.keyring, signed, bytes.NewReader(sig), nil); err != nil {
+				log.Logger.Debugf("RPM signature tag %d not verified: %v", tag, err)
+				continue
+			}
+			return keyID(sig), true
+		}
+	}
+	return "", false
+}
+
+// headerRange 返回 RPM 主包头在文件中的起止位置。签名头在 lead 之后，按 8 字节对齐
+func headerRange(r io.ReadSeeker) (int64, int64, error) {
+	size := func(at int64) (int64, error) {
+		var pre [16]byte
+		if _, err := r.Seek(at, io.SeekStart); err != nil {
+			return 0, err
+		}
+		if _, err := io.ReadFull(r, pre[:]); err != nil {
+			return 0, err
+		}
+		return 16 + 16*int64(binary.BigEndian.Uint32(pre[8:12])) + int64(binary.BigEndian.Uint32(pre[12:16])), nil
+	}
+	sigSize, err := size(leadSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	start := leadSize + (sigSize+7)/8*8
+	hdrSize, err := size(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, start + hdrSize, nil
+}
+
+// keyID 返回 OpenPGP 签名的签发密钥 ID，无法解析时为空
+func keyID(sig []byte) string {
+	p, err := packet.Read(bytes.NewReader(sig))
+	if err != nil {
+		return ""
+	}
+	switch s := p.(type) {
+	case *packet.Signature:
+		if s.IssuerKeyId != nil {
+			return fmt.Sprintf("%016x", *s.IssuerKeyId)
+		}
+	}
+	return ""
+}
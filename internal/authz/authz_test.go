@@ -0,0 +1,234 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestCheck(t *testing.T) {
+	var response string
+	var got types.AuthzRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer opa" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := got.UnmarshalJSON(body); err != nil {
+			t.Error(err)
+		}
+		fmt.Fprint(w, response)
+	}))
+	defer srv.Close()
+
+	w, err := New(config.AuthzWebhookConfig{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer opa"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := types.AuthzInput{Operation: OpUpload, User: "ci", Repo: "prod/el9", Path: "a.rpm", Size: 42}
+
+	cases := []struct {
+		response string
+		allowed  bool
+		reason   string
+	}{
+		{`{"result": true}`, true, ""},
+		{`{"result": false}`, false, ""},
+		{`{"result": {"allow": false, "reason": "unsigned package"}}`, false, "unsigned package"},
+		{`{"result": {"allow": true}}`, true, ""},
+		{`{}`, false, ""}, // OPA 规则未定义
+		{`{"allow": true}`, true, ""},
+		{`{"allow": false, "reason": "frozen"}`, false, "frozen"},
+	}
+	for _, tc := range cases {
+		response = tc.response
+		allowed, reason, err := w.Check(context.Background(), input)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.response, err)
+		}
+		if allowed != tc.allowed || reason != tc.reason {
+			t.Errorf("%s: got %v %q, want %v %q", tc.response, allowed, reason, tc.allowed, tc.reason)
+		}
+	}
+	if got.Input.Operation != OpUpload || got.Input.Repo != "prod/el9" || got.Input.Size != 42 {
+		t.Fatalf("unexpected input %+v", got.Input)
+	}
+
+	// 响应无法解析或 webhook 不可用时默认拒绝，fail-open 时允许
+	response = `{"result": "yes"}`
+	if _, _, err := w.Check(context.Background(), input); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable, got %v", err)
+	}
+	w.cfg.Headers = nil
+	if _, _, err := w.Check(context.Background(), input); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable, got %v", err)
+	}
+	w.cfg.FailOpen = true
+	if allowed, _, err := w.Check(context.Background(), input); err != nil || !allowed {
+		t.Fatalf("fail-open: got %v, %v", allowed, err)
+	}
+
+	if _, err := New(config.AuthzWebhookConfig{URL: srv.URL, Timeout: "soon"}); err == nil {
+		t.Fatal("expected invalid timeout error")
+	}
+}
+
+func TestInspect(t *testing.T) {
+	deb := (&Webhook{}).Inspect(strings.NewReader("!<arch>\n"), "pool/nginx_1%3a1.24.0-1_amd64.deb")
+	if deb == nil || deb.Name != "nginx" || deb.Version != "1:1.24.0-1" || deb.Arch != "amd64" || deb.Signed {
+		t.Fatalf("unexpected deb %+v", deb)
+	}
+
+	// 无法解析的 RPM 返回 nil，并回到文件开头供后续上传
+	r := strings.NewReader("not an rpm")
+	if p := (&Webhook{}).Inspect(r, "a.rpm"); p != nil {
+		t.Fatalf("unexpected package %+v", p)
+	}
+	if rest, _ := io.ReadAll(r); string(rest) != "not an rpm" {
+		t.Fatalf("reader not rewound: %q", rest)
+	}
+}
+
+func TestKeyID(t *testing.T) {
+	e, err := openpgp.NewEntity("plus", "", "plus@example.org", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sig bytes.Buffer
+	if err := openpgp.DetachSign(&sig, e, strings.NewReader("header"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := keyID(sig.Bytes()), fmt.Sprintf("%016x", e.PrimaryKey.KeyId); got != want {
+		t.Fatalf("keyID = %q, want %q", got, want)
+	}
+	if got := keyID([]byte("garbage")); got != "" {
+		t.Fatalf("keyID of garbage = %q", got)
+	}
+}
+
+// rpmHeader 生成 RPM 包头，值为 STRING（字符串）或 BIN（字节）
+func rpmHeader(tags []int, values []interface{}) []byte {
+	var index, store bytes.Buffer
+	for i, tag := range tags {
+		typ, count := 6, 1
+		data := values[i]
+		switch v := data.(type) {
+		case string:
+			data = append([]byte(v), 0)
+		case []byte:
+			typ, count = 7, len(v)
+		}
+		binary.Write(&index, binary.BigEndian, []uint32{uint32(tag), uint32(typ), uint32(store.Len()), uint32(count)})
+		store.Write(data.([]byte))
+	}
+	var h bytes.Buffer
+	h.Write([]byte{0x8e, 0xad, 0xe8, 0x01, 0, 0, 0, 0})
+	binary.Write(&h, binary.BigEndian, []uint32{uint32(len(tags)), uint32(store.Len())})
+	h.Write(index.Bytes())
+	h.Write(store.Bytes())
+	return h.Bytes()
+}
+
+// testRPM 生成只有包头和内容的 RPM，sign 返回签名头中的签名
+func testRPM(name string, payload []byte, sign func(header []byte) (int, []byte)) []byte {
+	header := rpmHeader([]int{1000, 1001, 1002, 1022}, []interface{}{name, "1.0", "1", "x86_64"})
+	tag, sig := sign(header)
+	sigHeader := rpmHeader([]int{tag}, []interface{}{sig})
+	for len(sigHeader)%8 != 0 {
+		sigHeader = append(sigHeader, 0)
+	}
+	lead := make([]byte, leadSize)
+	copy(lead, []byte{0xed, 0xab, 0xee, 0xdb, 3, 0})
+	binary.BigEndian.PutUint16(lead[78:], 5) // 签名类型 RPMSIGTYPE_HEADERSIG
+	var rpm bytes.Buffer
+	for _, b := range [][]byte{lead, sigHeader, header, payload} {
+		rpm.Write(b)
+	}
+	return rpm.Bytes()
+}
+
+func TestInspectSignature(t *testing.T) {
+	trusted, _ := openpgp.NewEntity("release", "", "release@example.org", nil)
+	other, _ := openpgp.NewEntity("other", "", "other@example.org", nil)
+	keyFile := filepath.Join(t.TempDir(), "release.asc")
+	f, _ := os.Create(keyFile)
+	if err := trusted.Serialize(f); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	w, err := New(config.AuthzWebhookConfig{URL: "http://opa:8181/v1/data/plus/authz", TrustedKeys: []string{keyFile}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	detach := func(e *openpgp.Entity, data []byte) []byte {
+		var sig bytes.Buffer
+		if err := openpgp.DetachSign(&sig, e, bytes.NewReader(data), nil); err != nil {
+			t.Fatal(err)
+		}
+		return sig.Bytes()
+	}
+	payload := []byte("payload")
+	headerSigned := func(e *openpgp.Entity) func([]byte) (int, []byte) {
+		return func(h []byte) (int, []byte) { return 268, detach(e, h) }
+	}
+	wantID := fmt.Sprintf("%016x", trusted.PrimaryKey.KeyId)
+
+	for _, tc := range []struct {
+		desc   string
+		data   []byte
+		signed bool
+	}{
+		{"header signature", testRPM("nginx", payload, headerSigned(trusted)), true},
+		{"header and payload signature", testRPM("nginx", payload, func(h []byte) (int, []byte) {
+			return 1002, detach(trusted, append(append([]byte{}, h...), payload...))
+		}), true},
+		{"untrusted key", testRPM("nginx", payload, headerSigned(other)), false},
+		{"garbage signature", testRPM("nginx", payload, func([]byte) (int, []byte) { return 268, []byte("forged signature") }), false},
+		// 签名后修改了包头
+		{"tampered header", bytes.Replace(testRPM("nginx", payload, headerSigned(trusted)), []byte("nginx"), []byte("evilx"), 1), false},
+		{"tampered payload", append(testRPM("nginx", payload, func(h []byte) (int, []byte) {
+			return 1005, detach(trusted, append(append([]byte{}, h...), payload...))
+		}), '!'), false},
+	} {
+		r := bytes.NewReader(tc.data)
+		p := w.Inspect(r, "nginx-1.0-1.x86_64.rpm")
+		if p == nil {
+			t.Fatalf("%s: package not parsed", tc.desc)
+		}
+		if p.Signed != tc.signed || (tc.signed && p.KeyID != wantID) || (!tc.signed && p.KeyID != "") {
+			t.Errorf("%s: signed %v, key_id %q", tc.desc, p.Signed, p.KeyID)
+		}
+		if rest, _ := io.ReadAll(r); !bytes.Equal(rest, tc.data) {
+			t.Errorf("%s: reader not rewound", tc.desc)
+		}
+	}
+
+	// 没有可信公钥时不认为包已签名
+	p := (&Webhook{}).Inspect(bytes.NewReader(testRPM("nginx", payload, headerSigned(trusted))), "nginx.rpm")
+	if p == nil || p.Signed || p.KeyID != "" {
+		t.Errorf("without trusted keys: %+v", p)
+	}
+}
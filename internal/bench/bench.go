@@ -0,0 +1,316 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"plus/internal/types"
+)
+
+// 操作
+const (
+	OpUpload   = "upload"
+	OpDownload = "download"
+	OpList     = "list"
+	OpRefresh  = "refresh"
+)
+
+// seedFile 下载操作使用的文件，启动时上传
+const seedFile = "bench-seed.tar.gz"
+
+// Scenarios 各场景的操作比例
+var Scenarios = map[string]map[string]int{
+	OpUpload:   {OpUpload: 1},
+	OpDownload: {OpDownload: 1},
+	OpList:     {OpList: 1},
+	OpRefresh:  {OpRefresh: 1},
+	"mixed":    {OpDownload: 60, OpList: 25, OpUpload: 10, OpRefresh: 5},
+}
+
+// Config 压测参数
+type Config struct {
+	URL         string
+	Scenario    string
+	Mix         map[string]int // 覆盖场景的操作比例
+	Repo        string         // 上传、下载、列表使用的 files 仓库，为空时创建临时仓库
+	RefreshRepo string         // 刷新使用的 rpm/deb 仓库，为空时创建临时 rpm 仓库
+	Concurrency int
+	Requests    int64 // 大于 0 时按请求数结束，否则按 Duration
+	Duration    time.Duration
+	Warmup      time.Duration // 预热期间的请求不计入结果
+	Size        int           // 上传和种子文件的大小（字节）
+	Token       string
+	Timeout     time.Duration
+}
+
+// Runner 对运行中的 plus 服务执行压测
+type Runner struct {
+	cfg     Config
+	client  *http.Client
+	ops     []string // 按比例展开的操作，随机选取
+	payload []byte
+	seq     int64
+	temp    []string // 压测结束后删除的临时仓库
+}
+
+// New 校验参数并创建压测
+func New(cfg Config) (*Runner, error) {
+	mix := cfg.Mix
+	if len(mix) == 0 {
+		var ok bool
+		if mix, ok = Scenarios[cfg.Scenario]; !ok {
+			return nil, fmt.Errorf("unknown scenario %q", cfg.Scenario)
+		}
+	}
+
+	var ops []string
+	for op, weight := range mix {
+		switch op {
+		case OpUpload, OpDownload, OpList, OpRefresh:
+		default:
+			return nil, fmt.Errorf("unknown operation %q", op)
+		}
+		for i := 0; i < weight; i++ {
+			ops = append(ops, op)
+		}
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("no operations to run")
+	}
+	sort.Strings(ops)
+
+	cfg.URL = strings.TrimRight(cfg.URL, "/")
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.Requests <= 0 && cfg.Duration <= 0 {
+		return nil, fmt.Errorf("either requests or duration must be set")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	payload := make([]byte, cfg.Size)
+	rand.Read(payload)
+
+	return &Runner{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			// 复用连接，避免默认的空闲连接数限制压测自身
+			Transport: &http.Transport{MaxIdleConnsPerHost: cfg.Concurrency},
+		},
+		ops:     ops,
+		payload: payload,
+	}, nil
+}
+
+// Run 准备仓库、预热，然后执行压测并返回结果
+func (r *Runner) Run(ctx context.Context) (*types.BenchReport, error) {
+	defer r.cleanup()
+	if err := r.setup(ctx); err != nil {
+		return nil, err
+	}
+
+	if r.cfg.Warmup > 0 {
+		warmCtx, cancel := context.WithTimeout(ctx, r.cfg.Warmup)
+		r.load(warmCtx, 0, nil)
+		cancel()
+	}
+
+	runCtx := ctx
+	if r.cfg.Requests <= 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, r.cfg.Duration)
+		defer cancel()
+	}
+
+	started := time.Now()
+	rec := newRecorder()
+	r.load(runCtx, r.cfg.Requests, rec)
+	elapsed := time.Since(started)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report := rec.report(elapsed)
+	report.Scenario = r.cfg.Scenario
+	report.URL = r.cfg.URL
+	report.Concurrency = r.cfg.Concurrency
+	report.StartedAt = started.UTC().Format(time.RFC3339)
+	report.WarmupSeconds = r.cfg.Warmup.Seconds()
+	return report, nil
+}
+
+// load 以 Concurrency 个并发执行操作，直到 ctx 结束或完成 limit 个请求；rec 为 nil 时不记录
+func (r *Runner) load(ctx context.Context, limit int64, rec *recorder) {
+	var issued int64
+	var wg sync.WaitGroup
+	for i := 0; i < r.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := mrand.New(mrand.NewSource(seed))
+			for ctx.Err() == nil {
+				if limit > 0 && atomic.AddInt64(&issued, 1) > limit {
+					return
+				}
+				op := r.ops[rnd.Intn(len(r.ops))]
+				n, d, err := r.do(ctx, op)
+				// 压测时间结束时被中断的请求不计入
+				if rec != nil && (limit > 0 || ctx.Err() == nil) {
+					rec.add(op, d, n, err)
+				}
+			}
+		}(time.Now().UnixNano() + int64(i))
+	}
+	wg.Wait()
+}
+
+// do 执行一次操作，返回读取的字节数和耗时
+func (r *Runner) do(ctx context.Context, op string) (int64, time.Duration, error) {
+	var req *http.Request
+	var err error
+	switch op {
+	case OpUpload:
+		name := fmt.Sprintf("bench-%d-%d.tar.gz", time.Now().UnixNano(), atomic.AddInt64(&r.seq, 1))
+		req, err = r.uploadRequest(ctx, r.cfg.Repo, name)
+	case OpDownload:
+		req, err = r.request(ctx, http.MethodGet, "/"+r.cfg.Repo+"/"+seedFile, nil)
+	case OpList:
+		req, err = r.request(ctx, http.MethodGet, "/"+r.cfg.Repo+"/", nil)
+	case OpRefresh:
+		req, err = r.request(ctx, http.MethodPost, "/repo/"+r.cfg.RefreshRepo+"/refresh", nil)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	start := time.Now()
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, time.Since(start), err
+	}
+	n, err := io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	d := time.Since(start)
+	if err != nil {
+		return n, d, err
+	}
+	if resp.StatusCode >= 300 {
+		return n, d, fmt.Errorf("%s returned %s", op, resp.Status)
+	}
+	return n, d, nil
+}
+
+func (r *Runner) request(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, r.cfg.URL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if r.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.cfg.Token)
+	}
+	return req, nil
+}
+
+func (r *Runner) uploadRequest(ctx context.Context, repoName, filename string) (*http.Request, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	fw.Write(r.payload)
+	mw.Close()
+
+	req, err := r.request(ctx, http.MethodPost, "/repo/"+repoName+"/upload", &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req, nil
+}
+
+// setup 创建所需的临时仓库并上传下载用的种子文件
+func (r *Runner) setup(ctx context.Context) error {
+	needs := make(map[string]bool)
+	for _, op := range r.ops {
+		needs[op] = true
+	}
+	suffix := time.Now().Unix()
+
+	if (needs[OpUpload] || needs[OpDownload] || needs[OpList]) && r.cfg.Repo == "" {
+		r.cfg.Repo = fmt.Sprintf("bench-%d", suffix)
+		if err := r.createRepo(ctx, r.cfg.Repo, "files"); err != nil {
+			return err
+		}
+	}
+	if needs[OpRefresh] && r.cfg.RefreshRepo == "" {
+		r.cfg.RefreshRepo = fmt.Sprintf("bench-%d-rpm", suffix)
+		if err := r.createRepo(ctx, r.cfg.RefreshRepo, "rpm"); err != nil {
+			return err
+		}
+	}
+	if needs[OpDownload] {
+		req, err := r.uploadRequest(ctx, r.cfg.Repo, seedFile)
+		if err != nil {
+			return err
+		}
+		if err := r.expectOK(req); err != nil {
+			return fmt.Errorf("failed to upload seed file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) createRepo(ctx context.Context, name, repoType string) error {
+	body, err := (&types.RepoTable{Name: name, Type: repoType}).MarshalJSON()
+	if err != nil {
+		return err
+	}
+	req, err := r.request(ctx, http.MethodPost, "/repos", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := r.expectOK(req); err != nil {
+		return fmt.Errorf("failed to create repository %s: %w", name, err)
+	}
+	r.temp = append(r.temp, name)
+	return nil
+}
+
+// cleanup 删除临时仓库
+func (r *Runner) cleanup() {
+	for _, name := range r.temp {
+		req, err := r.request(context.Background(), http.MethodDelete, "/repo/"+name, nil)
+		if err == nil {
+			r.expectOK(req)
+		}
+	}
+}
+
+func (r *Runner) expectOK(req *http.Request) error {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
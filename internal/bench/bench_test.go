@@ -0,0 +1,123 @@
+package bench
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"plus/internal/types"
+)
+
+func TestRunMixed(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/upload"):
+			if _, _, err := r.FormFile("file"); err != nil {
+				http.Error(w, "no file", http.StatusBadRequest)
+				return
+			}
+			key = "upload"
+		case strings.HasSuffix(r.URL.Path, "/refresh"):
+			key = "refresh"
+		case strings.HasSuffix(r.URL.Path, "/"+seedFile):
+			key = "download"
+			w.Write(make([]byte, 128))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/"):
+			key = "list"
+		}
+		mu.Lock()
+		calls[key]++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	r, err := New(Config{URL: server.URL + "/", Scenario: "mixed", Concurrency: 4, Requests: 200, Size: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Requests != 200 || report.Errors != 0 || len(report.Operations) != 4 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	for _, op := range report.Operations {
+		if op.Requests == 0 || op.Latency.P50 > op.Latency.P99 || op.Latency.Max < op.Latency.Min {
+			t.Errorf("unexpected stats for %s: %+v", op.Name, op)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// 临时的 files 和 rpm 仓库各创建、删除一次，种子文件另上传一次
+	if calls["POST /repos"] != 2 || calls["upload"] < 2 {
+		t.Errorf("setup calls = %v", calls)
+	}
+	deletes := 0
+	for key, n := range calls {
+		if strings.HasPrefix(key, "DELETE /repo/bench-") {
+			deletes += n
+		}
+	}
+	if deletes != 2 {
+		t.Errorf("temporary repositories deleted %d times, calls = %v", deletes, calls)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	r, err := New(Config{URL: server.URL, Scenario: OpList, Repo: "existing", Duration: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Requests == 0 || report.Errors != report.Requests {
+		t.Errorf("all requests should fail: %+v", report)
+	}
+
+	if _, err := New(Config{Scenario: "nope", Requests: 1}); err == nil {
+		t.Error("unknown scenario should be rejected")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	got := summarize(latencies)
+	want := types.BenchLatency{Min: 1, Mean: 50.5, P50: 50, P90: 90, P95: 95, P99: 99, Max: 100}
+	if got != want {
+		t.Errorf("summarize = %+v, want %+v", got, want)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	op := func(rps, p95 float64, errors int64) types.BenchOperation {
+		return types.BenchOperation{Name: OpDownload, RPS: rps, Errors: errors, Latency: types.BenchLatency{P95: p95}}
+	}
+	baseline := &types.BenchReport{Operations: []types.BenchOperation{op(1000, 10, 0)}}
+
+	if got := Compare(baseline, &types.BenchReport{Operations: []types.BenchOperation{op(900, 11.5, 0)}}, 20); len(got) != 0 {
+		t.Errorf("within tolerance, got %v", got)
+	}
+	if got := Compare(baseline, &types.BenchReport{Operations: []types.BenchOperation{op(700, 15, 3)}}, 20); len(got) != 3 {
+		t.Errorf("expected p95, rps and error regressions, got %v", got)
+	}
+}
@@ -0,0 +1,136 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"plus/internal/types"
+)
+
+// recorder 记录各操作的耗时
+type recorder struct {
+	mu  sync.Mutex
+	ops map[string]*opStats
+}
+
+type opStats struct {
+	latencies []time.Duration
+	errors    int64
+	bytes     int64
+}
+
+func newRecorder() *recorder {
+	return &recorder{ops: make(map[string]*opStats)}
+}
+
+func (r *recorder) add(op string, d time.Duration, n int64, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.ops[op]
+	if !ok {
+		s = &opStats{}
+		r.ops[op] = s
+	}
+	s.latencies = append(s.latencies, d)
+	s.bytes += n
+	if err != nil {
+		s.errors++
+	}
+}
+
+// report 汇总结果，按操作名排序
+func (r *recorder) report(elapsed time.Duration) *types.BenchReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &types.BenchReport{DurationSeconds: round(elapsed.Seconds())}
+	for name, s := range r.ops {
+		op := types.BenchOperation{
+			Name:     name,
+			Requests: int64(len(s.latencies)),
+			Errors:   s.errors,
+			Bytes:    s.bytes,
+			Latency:  summarize(s.latencies),
+		}
+		if elapsed > 0 {
+			op.RPS = round(float64(op.Requests) / elapsed.Seconds())
+		}
+		report.Requests += op.Requests
+		report.Errors += op.Errors
+		report.Operations = append(report.Operations, op)
+	}
+	sort.Slice(report.Operations, func(i, j int) bool { return report.Operations[i].Name < report.Operations[j].Name })
+	if elapsed > 0 {
+		report.RPS = round(float64(report.Requests) / elapsed.Seconds())
+	}
+	return report
+}
+
+// summarize 计算耗时分布（毫秒），百分位按最近秩
+func summarize(latencies []time.Duration) types.BenchLatency {
+	if len(latencies) == 0 {
+		return types.BenchLatency{}
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		return ms(sorted[rank])
+	}
+	return types.BenchLatency{
+		Min:  ms(sorted[0]),
+		Mean: ms(total / time.Duration(len(sorted))),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P95:  percentile(95),
+		P99:  percentile(99),
+		Max:  ms(sorted[len(sorted)-1]),
+	}
+}
+
+// Compare 与基线比较，返回 p95 耗时上升或吞吐量下降超过 maxRegression（百分比）的操作
+func Compare(baseline, current *types.BenchReport, maxRegression float64) []string {
+	base := make(map[string]types.BenchOperation)
+	for _, op := range baseline.Operations {
+		base[op.Name] = op
+	}
+
+	var regressions []string
+	limit := maxRegression / 100
+	for _, op := range current.Operations {
+		b, ok := base[op.Name]
+		if !ok {
+			continue
+		}
+		if b.Latency.P95 > 0 && op.Latency.P95 > b.Latency.P95*(1+limit) {
+			regressions = append(regressions, fmt.Sprintf("%s: p95 %.2fms -> %.2fms", op.Name, b.Latency.P95, op.Latency.P95))
+		}
+		if b.RPS > 0 && op.RPS < b.RPS*(1-limit) {
+			regressions = append(regressions, fmt.Sprintf("%s: rps %.2f -> %.2f", op.Name, b.RPS, op.RPS))
+		}
+		if b.Errors == 0 && op.Errors > 0 {
+			regressions = append(regressions, fmt.Sprintf("%s: %d errors, baseline had none", op.Name, op.Errors))
+		}
+	}
+	return regressions
+}
+
+func ms(d time.Duration) float64 {
+	return round(float64(d) / float64(time.Millisecond))
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
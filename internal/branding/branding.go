@@ -0,0 +1,121 @@
+// Package branding 按配置定制服务生成的页面：标题、标志、颜色、样式表和页脚
+package branding
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"plus/internal/config"
+)
+
+// 页面中的默认名称和页脚
+const (
+	defaultTitle  = "Plus Artifacts Server"
+	defaultFooter = "<p><em>Generated by Plus Artifacts Server</em></p>"
+)
+
+// colorPattern 允许的颜色值，不允许 ; { } 等字符以免注入其他样式
+var colorPattern = regexp.MustCompile(`^(#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})|[a-zA-Z]+|(rgb|rgba|hsl|hsla)\([0-9.,%/ ]+\))$`)
+
+// Branding 页面定制，为 nil 时页面保持不变
+type Branding struct {
+	title  string // 已转义
+	footer string
+	head   string // 插入到 </head> 之前的样式
+	logo   string // 插入到 <body> 之后的标志
+}
+
+// New 检查配置，没有任何定制时返回 nil
+func New(cfg config.BrandingConfig) (*Branding, error) {
+	b := &Branding{title: html.EscapeString(strings.TrimSpace(cfg.Title)), footer: strings.TrimSpace(cfg.Footer)}
+
+	var css []string
+	for _, c := range []struct{ name, value, rule string }{
+		{"primary", cfg.Colors.Primary, ".file-list a, .file-name a, .option a, .repo-name { color: %[1]s; } h1 { border-bottom-color: %[1]s; } .header { background: %[1]s; }"},
+		{"background", cfg.Colors.Background, "body { background-color: %s; }"},
+		{"text", cfg.Colors.Text, "body { color: %s; }"},
+	} {
+		v := strings.TrimSpace(c.value)
+		if v == "" {
+			continue
+		}
+		if !colorPattern.MatchString(v) {
+			return nil, fmt.Errorf("invalid branding color %s %q", c.name, c.value)
+		}
+		css = append(css, fmt.Sprintf(c.rule, v))
+	}
+	if len(css) > 0 {
+		b.head += "<style>" + strings.Join(css, " ") + "</style>"
+	}
+	if cfg.Stylesheet != "" {
+		u, err := checkURL("stylesheet", cfg.Stylesheet)
+		if err != nil {
+			return nil, err
+		}
+		b.head += `<link rel="stylesheet" href="` + u + `">`
+	}
+	if cfg.Logo != "" {
+		u, err := checkURL("logo", cfg.Logo)
+		if err != nil {
+			return nil, err
+		}
+		alt := b.title
+		if alt == "" {
+			alt = defaultTitle
+		}
+		b.logo = `<a href="/" class="brand-logo"><img src="` + u + `" alt="` + alt + `" style="max-height: 48px;"></a>`
+	}
+
+	if b.title == "" && b.footer == "" && b.head == "" && b.logo == "" {
+		return nil, nil
+	}
+	return b, nil
+}
+
+// checkURL 地址必须以 / 开头或为 http(s) URL，返回转义后的地址
+func checkURL(name, raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil || (!strings.HasPrefix(raw, "/") && u.Scheme != "http" && u.Scheme != "https") || strings.HasPrefix(raw, "//") {
+		return "", fmt.Errorf("invalid branding %s %q: must start with / or be an http(s) URL", name, raw)
+	}
+	return html.EscapeString(raw), nil
+}
+
+// Apply 定制页面。首页的名称替换为标题，其他页面的标题后加上该名称
+func (b *Branding) Apply(page string) string {
+	if b == nil {
+		return page
+	}
+	if b.title != "" {
+		if strings.Contains(page, "<title>"+defaultTitle+"</title>") {
+			page = strings.NewReplacer(
+				"<title>"+defaultTitle+"</title>", "<title>"+b.title+"</title>",
+				"<h1>"+defaultTitle+"</h1>", "<h1>"+b.title+"</h1>",
+			).Replace(page)
+		} else {
+			page = strings.Replace(page, "</title>", " - "+b.title+"</title>", 1)
+		}
+	}
+	if b.footer != "" {
+		footer := `<footer class="brand-footer">` + b.footer + `</footer>`
+		switch {
+		case strings.Contains(page, defaultFooter):
+			page = strings.Replace(page, defaultFooter, footer, 1)
+		case strings.Contains(page, "</body>"):
+			page = strings.Replace(page, "</body>", footer+"\n</body>", 1)
+		default:
+			// 部分页面没有 </body>
+			page += "\n" + footer
+		}
+	}
+	if b.head != "" {
+		page = strings.Replace(page, "</head>", b.head+"\n</head>", 1)
+	}
+	if b.logo != "" {
+		page = strings.Replace(page, "<body>", "<body>\n"+b.logo, 1)
+	}
+	return page
+}
@@ -0,0 +1,79 @@
+package branding
+
+import (
+	"strings"
+	"testing"
+
+	"plus/internal/config"
+)
+
+const listing = `<html>
+<head>
+    <title>Index of /docs</title>
+</head>
+<body>
+    <h1>📁 Repository: docs</h1>
+    <p><em>Generated by Plus Artifacts Server</em></p>
+</body>
+</html>`
+
+func TestApply(t *testing.T) {
+	b, err := New(config.BrandingConfig{
+		Title:      "Acme <Artifacts>",
+		Logo:       "/static/logo.png",
+		Footer:     `© Acme · <a href="https://acme.example/support">Support</a>`,
+		Stylesheet: "https://cdn.acme.example/brand.css",
+		Colors:     config.BrandingColors{Primary: "#0b5cad", Background: "rgb(250, 250, 250)"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := b.Apply(listing)
+	for _, want := range []string{
+		"<title>Index of /docs - Acme &lt;Artifacts&gt;</title>",
+		`<img src="/static/logo.png" alt="Acme &lt;Artifacts&gt;"`,
+		`<footer class="brand-footer">© Acme · <a href="https://acme.example/support">Support</a></footer>`,
+		"h1 { border-bottom-color: #0b5cad; }",
+		"body { background-color: rgb(250, 250, 250); }",
+		`<link rel="stylesheet" href="https://cdn.acme.example/brand.css">` + "\n</head>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page missing %q:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "Generated by") {
+		t.Errorf("default footer not replaced:\n%s", page)
+	}
+
+	root := b.Apply("<title>Plus Artifacts Server</title><body><h1>Plus Artifacts Server</h1></body>")
+	if !strings.Contains(root, "<title>Acme &lt;Artifacts&gt;</title>") || !strings.Contains(root, "<h1>Acme &lt;Artifacts&gt;</h1>") {
+		t.Errorf("root page = %s", root)
+	}
+	// 没有默认页脚的页面在 </body> 之前加上页脚
+	if page := b.Apply("<body><ul></ul></body>"); !strings.Contains(page, "</footer>\n</body>") {
+		t.Errorf("footer not appended: %s", page)
+	}
+	if page := b.Apply("<body><ul></ul>"); !strings.HasSuffix(page, "</footer>") {
+		t.Errorf("footer not appended without </body>: %s", page)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if b, err := New(config.BrandingConfig{StaticDir: "/srv/brand"}); b != nil || err != nil {
+		t.Errorf("New without page changes = %v, %v", b, err)
+	}
+	if page := (*Branding)(nil).Apply(listing); page != listing {
+		t.Errorf("nil branding changed the page")
+	}
+	for _, cfg := range []config.BrandingConfig{
+		{Colors: config.BrandingColors{Primary: "red; } body { display: none"}},
+		{Colors: config.BrandingColors{Text: "url(javascript:alert(1))"}},
+		{Logo: "javascript:alert(1)"},
+		{Logo: "//evil.example/logo.png"},
+		{Stylesheet: "brand.css"},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) accepted", cfg)
+		}
+	}
+}
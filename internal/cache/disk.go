@@ -0,0 +1,285 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/storage"
+)
+
+// DefaultDiskCacheSize 未配置大小上限时的默认值
+const DefaultDiskCacheSize int64 = 10 << 30
+
+// DiskCache 对象存储的本地磁盘读缓存，按最近最少使用淘汰，总大小不超过 maxSize
+type DiskCache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element // key -> lru 元素
+	lru     *list.List               // 头部为最近使用
+	size    int64
+	epoch   uint64 // 每次失效递增，填充期间发生失效的内容不写入缓存
+
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+type diskEntry struct {
+	key  string
+	file string
+	size int64
+}
+
+// NewDiskCache 创建磁盘缓存，目录中已有的内容会被清空（后端可能在停机期间变化）
+func NewDiskCache(dir string, maxSize int64) (*DiskCache, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultDiskCacheSize
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("failed to clear read cache %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "tmp"), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create read cache %s: %w", dir, err)
+	}
+	return &DiskCache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}, nil
+}
+
+// Wrap 为存储后端加上读缓存，namespace 区分共用同一缓存的不同后端
+func (c *DiskCache) Wrap(backend storage.Storage, namespace string) storage.Storage {
+	return &cachedStorage{Storage: backend, cache: c, namespace: namespace}
+}
+
+// Metrics 返回缓存使用情况
+func (c *DiskCache) Metrics() *types.ReadCacheMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &types.ReadCacheMetrics{
+		Path:      c.dir,
+		MaxSize:   c.maxSize,
+		Size:      c.size,
+		Entries:   c.lru.Len(),
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
+
+// open 打开缓存的文件并标记为最近使用
+func (c *DiskCache) open(key string) (*os.File, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	entry := elem.Value.(*diskEntry)
+	f, err := os.Open(entry.file)
+	if err != nil {
+		log.Logger.Warnf("Read cache entry %s unreadable, dropping: %v", key, err)
+		c.remove(elem)
+		c.misses++
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	c.hits++
+	return f, true
+}
+
+// commit 将写好的临时文件加入缓存，epoch 变化说明期间内容已失效
+func (c *DiskCache) commit(key, tmp string, size int64, epoch uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.epoch != epoch || size > c.maxSize {
+		os.Remove(tmp)
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	file := c.filePath(key)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		os.Remove(tmp)
+		return
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		log.Logger.Warnf("Failed to add %s to read cache: %v", key, err)
+		os.Remove(tmp)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&diskEntry{key: key, file: file, size: size})
+	c.size += size
+
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+		c.evictions++
+	}
+}
+
+// invalidate 删除 key 以及以 key/ 开头（目录）的缓存
+func (c *DiskCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	prefix := key + "/"
+	for k, elem := range c.entries {
+		if strings.HasPrefix(k, prefix) {
+			c.remove(elem)
+		}
+	}
+}
+
+func (c *DiskCache) currentEpoch() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.epoch
+}
+
+// remove 调用方需持有锁；已打开的文件在 Linux 上仍可继续读取
+func (c *DiskCache) remove(elem *list.Element) {
+	entry := elem.Value.(*diskEntry)
+	c.lru.Remove(elem)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+	if err := os.Remove(entry.file); err != nil && !os.IsNotExist(err) {
+		log.Logger.Warnf("Failed to remove read cache file %s: %v", entry.file, err)
+	}
+}
+
+// filePath 缓存文件按 key 的哈希存放，避免文件与目录同名冲突
+func (c *DiskCache) filePath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, name[:2], name)
+}
+
+// cachedStorage 读取时优先使用磁盘缓存，写入和删除时使缓存失效
+type cachedStorage struct {
+	storage.Storage
+	cache     *DiskCache
+	namespace string
+}
+
+func (s *cachedStorage) key(p string) string {
+	return s.namespace + path.Clean("/"+filepath.ToSlash(p))
+}
+
+func (s *cachedStorage) Get(ctx context.Context, p string) (io.ReadCloser, error) {
+	key := s.key(p)
+	if f, ok := s.cache.open(key); ok {
+		return f, nil
+	}
+
+	epoch := s.cache.currentEpoch()
+	reader, err := s.Storage.Get(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(filepath.Join(s.cache.dir, "tmp"), "fill-")
+	if err != nil {
+		log.Logger.Warnf("Read cache unavailable for %s: %v", key, err)
+		return reader, nil
+	}
+	return &fillReader{reader: reader, tmp: tmp, cache: s.cache, key: key, epoch: epoch}, nil
+}
+
+// OpenRange 命中时从缓存文件读取；未命中时直接读取后端，部分内容不写入缓存
+func (s *cachedStorage) OpenRange(ctx context.Context, p string, offset, length int64) (io.ReadCloser, error) {
+	if f, ok := s.cache.open(s.key(p)); ok {
+		return storage.Section(f, offset, length)
+	}
+	return s.Storage.OpenRange(ctx, p, offset, length)
+}
+
+func (s *cachedStorage) Store(ctx context.Context, p string, reader io.Reader) error {
+	err := s.Storage.Store(ctx, p, reader)
+	s.cache.invalidate(s.key(p))
+	return err
+}
+
+func (s *cachedStorage) Delete(ctx context.Context, p string) error {
+	err := s.Storage.Delete(ctx, p)
+	s.cache.invalidate(s.key(p))
+	return err
+}
+
+func (s *cachedStorage) Copy(ctx context.Context, src, dst string) error {
+	err := s.Storage.Copy(ctx, src, dst)
+	s.cache.invalidate(s.key(dst))
+	return err
+}
+
+func (s *cachedStorage) Rename(ctx context.Context, src, dst string) error {
+	err := s.Storage.Rename(ctx, src, dst)
+	s.cache.invalidate(s.key(src))
+	s.cache.invalidate(s.key(dst))
+	return err
+}
+
+// fillReader 在读取后端内容的同时写入临时文件，完整读完后加入缓存
+type fillReader struct {
+	reader  io.ReadCloser
+	tmp     *os.File
+	cache   *DiskCache
+	key     string
+	epoch   uint64
+	written int64
+	done    bool // 已读到 EOF
+	failed  bool // 写入失败或超过缓存上限
+}
+
+func (r *fillReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && !r.failed {
+		r.written += int64(n)
+		if r.written > r.cache.maxSize {
+			r.failed = true
+		} else if _, werr := r.tmp.Write(p[:n]); werr != nil {
+			log.Logger.Warnf("Failed to write read cache for %s: %v", r.key, werr)
+			r.failed = true
+		}
+	}
+	if err == io.EOF {
+		r.done = true
+	}
+	return n, err
+}
+
+func (r *fillReader) Close() error {
+	err := r.reader.Close()
+	tmpName := r.tmp.Name()
+	if cerr := r.tmp.Close(); cerr != nil {
+		r.failed = true
+	}
+	// 未读完（客户端中断）的内容不完整，丢弃
+	if !r.done || r.failed || err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	r.cache.commit(r.key, tmpName, r.written, r.epoch)
+	return nil
+}
@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+// memStorage 统计 Get 次数的内存存储
+type memStorage struct {
+	storage.Storage
+	objects map[string][]byte
+	gets    int
+}
+
+func (m *memStorage) Get(_ context.Context, p string) (io.ReadCloser, error) {
+	m.gets++
+	data, ok := m.objects[p]
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", p)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memStorage) Store(_ context.Context, p string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	m.objects[p] = data
+	return err
+}
+
+func (m *memStorage) Delete(_ context.Context, p string) error {
+	for k := range m.objects {
+		if k == p || strings.HasPrefix(k, p+"/") {
+			delete(m.objects, k)
+		}
+	}
+	return nil
+}
+
+func read(t *testing.T, s storage.Storage, p string) string {
+	t.Helper()
+	r, err := s.Get(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestDiskCacheReadThrough(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &memStorage{objects: map[string][]byte{"repo/a.txt": []byte("hello")}}
+	s := c.Wrap(backend, "files")
+
+	for i := 0; i < 3; i++ {
+		if got := read(t, s, "repo/a.txt"); got != "hello" {
+			t.Fatalf("read %d = %q", i, got)
+		}
+	}
+	if backend.gets != 1 {
+		t.Errorf("backend read %d times, want 1", backend.gets)
+	}
+	m := c.Metrics()
+	if m.Hits != 2 || m.Misses != 1 || m.Size != 5 || m.Entries != 1 {
+		t.Errorf("metrics = %+v", m)
+	}
+
+	// 范围读取命中缓存
+	r, err := s.OpenRange(context.Background(), "repo/a.txt", 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+	if string(data) != "ell" || backend.gets != 1 {
+		t.Errorf("range read = %q after %d backend reads", data, backend.gets)
+	}
+
+	// 写入和删除目录后重新从后端读取
+	if err := s.Store(context.Background(), "repo/a.txt", strings.NewReader("world")); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(t, s, "repo/a.txt"); got != "world" {
+		t.Errorf("after store = %q", got)
+	}
+	if err := s.Delete(context.Background(), "repo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(context.Background(), "repo/a.txt"); err == nil {
+		t.Error("deleted object should not be served from cache")
+	}
+}
+
+func TestDiskCacheEviction(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &memStorage{objects: map[string][]byte{
+		"a":   []byte("aaaa"),
+		"b":   []byte("bbbb"),
+		"c":   []byte("cccc"),
+		"big": []byte("0123456789abc"),
+	}}
+	s := c.Wrap(backend, "files")
+
+	read(t, s, "a")
+	read(t, s, "b")
+	read(t, s, "a") // a 最近使用，淘汰 b
+	read(t, s, "c")
+
+	m := c.Metrics()
+	if m.Size != 8 || m.Entries != 2 || m.Evictions != 1 {
+		t.Errorf("metrics = %+v", m)
+	}
+	gets := backend.gets
+	read(t, s, "a")
+	read(t, s, "b")
+	if backend.gets != gets+1 {
+		t.Errorf("expected only b to miss, backend reads %d -> %d", gets, backend.gets)
+	}
+
+	// 超过上限的对象不缓存
+	read(t, s, "big")
+	if c.Metrics().Size > 10 {
+		t.Errorf("cache exceeded max size: %+v", c.Metrics())
+	}
+}
+
+func TestDiskCachePartialRead(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &memStorage{objects: map[string][]byte{"a": []byte("hello")}}
+	s := c.Wrap(backend, "files")
+
+	r, err := s.Get(context.Background(), "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Read(make([]byte, 2))
+	r.Close()
+
+	if m := c.Metrics(); m.Entries != 0 {
+		t.Errorf("incomplete read should not be cached: %+v", m)
+	}
+}
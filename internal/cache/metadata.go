@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/types"
+)
+
+const (
+	DefaultMetadataTTL     = 5 * time.Minute
+	DefaultMetadataMaxSize = 64 << 20
+	// 单个文件最多占用缓存上限的 1/8，避免大文件挤占热点元数据
+	metadataEntryRatio = 8
+)
+
+// MetadataEntry 缓存的元数据文件内容，Data 只读
+type MetadataEntry struct {
+	Data    []byte
+	ModTime time.Time
+}
+
+// MetadataCache 热点元数据（repomd.xml、Release、Packages.gz 等）的内存缓存；
+// 同一文件的并发加载只读取一次存储，仓库刷新时按仓库失效
+type MetadataCache struct {
+	ttl     time.Duration
+	maxSize int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // 头部为最近使用
+	size    int64
+	epoch   uint64 // 每次失效递增，失效前开始的加载结果不写入缓存
+	flight  flight
+
+	hits   int64
+	misses int64
+	shared int64 // 等待其他请求加载完成的次数
+	stale  int64 // 存储不可用时返回过期内容的次数
+}
+
+type metadataItem struct {
+	key     string
+	entry   *MetadataEntry
+	expires time.Time
+}
+
+// NewMetadataCache 创建元数据缓存，ttl 为兜底过期时间（覆盖存储被外部修改的情况）
+func NewMetadataCache(ttl time.Duration, maxSize int64) *MetadataCache {
+	if ttl <= 0 {
+		ttl = DefaultMetadataTTL
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMetadataMaxSize
+	}
+	return &MetadataCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Cacheable 判断该大小的文件是否适合缓存
+func (c *MetadataCache) Cacheable(size int64) bool {
+	return size <= c.maxSize/metadataEntryRatio
+}
+
+// Lookup 返回缓存中未过期的内容
+func (c *MetadataCache) Lookup(key string) (*MetadataEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.get(key); ok {
+		c.hits++
+		return entry, true
+	}
+	c.misses++
+	return nil, false
+}
+
+// Load 在未命中后加载内容，同一 key 的并发调用只执行一次 load
+func (c *MetadataCache) Load(key string, load func() (*MetadataEntry, error)) (*MetadataEntry, error) {
+	c.mu.Lock()
+	epoch := c.epoch
+	c.mu.Unlock()
+
+	// 失效后发起的请求不复用失效前开始的加载
+	entry, err, shared := c.flight.do(fmt.Sprintf("%d:%s", epoch, key), func() (*MetadataEntry, error) {
+		c.mu.Lock()
+		cached, ok := c.get(key)
+		c.mu.Unlock()
+		if ok {
+			return cached, nil
+		}
+
+		entry, err := load()
+		if err != nil {
+			return nil, err
+		}
+		c.add(key, entry, epoch)
+		return entry, nil
+	})
+	if shared {
+		c.mu.Lock()
+		c.shared++
+		c.mu.Unlock()
+	}
+	if err == nil && entry == nil {
+		return nil, fmt.Errorf("metadata load for %s aborted", key)
+	}
+	return entry, err
+}
+
+// Stale 返回缓存中的内容，包括已过期的；用于存储不可用时降级服务，仓库刷新失效后的内容不会返回
+func (c *MetadataCache) Stale(key string) (*MetadataEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.stale++
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*metadataItem).entry, true
+}
+
+// Invalidate 删除仓库下的全部缓存（key 以 repoName/ 开头）
+func (c *MetadataCache) Invalidate(repoName string) {
+	prefix := strings.Trim(repoName, "/") + "/"
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	for key, elem := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(elem)
+		}
+	}
+}
+
+// Metrics 返回缓存使用情况
+func (c *MetadataCache) Metrics() *types.MetadataCacheMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &types.MetadataCacheMetrics{
+		MaxSize: c.maxSize,
+		Size:    c.size,
+		Entries: c.lru.Len(),
+		Hits:    c.hits,
+		Misses:  c.misses,
+		Shared:  c.shared,
+		Stale:   c.stale,
+	}
+}
+
+// get 调用方需持有锁；过期的内容保留到被替换或淘汰，供 Stale 使用
+func (c *MetadataCache) get(key string) (*MetadataEntry, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	item := elem.Value.(*metadataItem)
+	if time.Now().After(item.expires) {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return item.entry, true
+}
+
+func (c *MetadataCache) add(key string, entry *MetadataEntry, epoch uint64) {
+	size := int64(len(entry.Data))
+	if !c.Cacheable(size) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.epoch != epoch {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(&metadataItem{key: key, entry: entry, expires: time.Now().Add(c.ttl)})
+	c.size += size
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *MetadataCache) remove(elem *list.Element) {
+	item := elem.Value.(*metadataItem)
+	c.lru.Remove(elem)
+	delete(c.entries, item.key)
+	c.size -= int64(len(item.entry.Data))
+}
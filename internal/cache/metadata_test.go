@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMetadataCacheSingleflight(t *testing.T) {
+	c := NewMetadataCache(time.Minute, 1024)
+
+	var loads int32
+	release := make(chan struct{})
+	load := func() (*MetadataEntry, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return &MetadataEntry{Data: []byte("repomd")}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entry, err := c.Load("centos/repodata/repomd.xml", load)
+			if err != nil || string(entry.Data) != "repomd" {
+				t.Errorf("Load = %v, %v", entry, err)
+			}
+		}()
+	}
+	// 等待所有调用进入加载后再放行
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if loads != 1 {
+		t.Errorf("storage read %d times, want 1", loads)
+	}
+	if _, ok := c.Lookup("centos/repodata/repomd.xml"); !ok {
+		t.Error("loaded entry should be cached")
+	}
+}
+
+func TestMetadataCacheInvalidate(t *testing.T) {
+	c := NewMetadataCache(time.Minute, 1024)
+	data := func(s string) func() (*MetadataEntry, error) {
+		return func() (*MetadataEntry, error) { return &MetadataEntry{Data: []byte(s)}, nil }
+	}
+
+	c.Load("centos/8/repodata/repomd.xml", data("a"))
+	c.Load("centos/9/repodata/repomd.xml", data("b"))
+	c.Load("ubuntu/dists/stable/Release", data("c"))
+
+	c.Invalidate("centos/8")
+	if _, ok := c.Lookup("centos/8/repodata/repomd.xml"); ok {
+		t.Error("invalidated repo should miss")
+	}
+	if _, ok := c.Lookup("centos/9/repodata/repomd.xml"); !ok {
+		t.Error("other repos should stay cached")
+	}
+
+	c.Invalidate("ubuntu")
+	if entry, _ := c.Load("ubuntu/dists/stable/Release", data("new")); string(entry.Data) != "new" {
+		t.Errorf("after invalidation got %q", entry.Data)
+	}
+}
+
+func TestMetadataCacheLimits(t *testing.T) {
+	c := NewMetadataCache(20*time.Millisecond, 80)
+	entry := func(n int) func() (*MetadataEntry, error) {
+		return func() (*MetadataEntry, error) { return &MetadataEntry{Data: make([]byte, n)}, nil }
+	}
+
+	// 超过单文件上限（80/8）的内容照常返回但不缓存
+	if e, err := c.Load("r/big", entry(20)); err != nil || len(e.Data) != 20 {
+		t.Fatalf("Load = %v, %v", e, err)
+	}
+	if _, ok := c.Lookup("r/big"); ok {
+		t.Error("oversized entry should not be cached")
+	}
+
+	for _, key := range []string{"r/1", "r/2", "r/3", "r/4", "r/5", "r/6", "r/7", "r/8", "r/9"} {
+		c.Load(key, entry(10))
+	}
+	if m := c.Metrics(); m.Size > 80 || m.Entries != 8 {
+		t.Errorf("metrics = %+v", m)
+	}
+	if _, ok := c.Lookup("r/1"); ok {
+		t.Error("least recently used entry should be evicted")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := c.Lookup("r/9"); ok {
+		t.Error("expired entry should miss")
+	}
+	// 过期内容保留，存储不可用时仍可返回
+	if e, ok := c.Stale("r/9"); !ok || len(e.Data) != 10 {
+		t.Error("expired entry should be available as stale")
+	}
+	c.Invalidate("r")
+	if _, ok := c.Stale("r/9"); ok {
+		t.Error("invalidated entry must not be served stale")
+	}
+}
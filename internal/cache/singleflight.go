@@ -0,0 +1,41 @@
+package cache
+
+import "sync"
+
+// flight 同一 key 的并发加载只执行一次，其余调用等待并共享结果
+type flight struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	wg    sync.WaitGroup
+	entry *MetadataEntry
+	err   error
+}
+
+// do 执行或等待 key 对应的加载，shared 表示结果来自其他调用
+func (f *flight) do(key string, fn func() (*MetadataEntry, error)) (entry *MetadataEntry, err error, shared bool) {
+	f.mu.Lock()
+	if f.calls == nil {
+		f.calls = make(map[string]*flightCall)
+	}
+	if call, ok := f.calls[key]; ok {
+		f.mu.Unlock()
+		call.wg.Wait()
+		return call.entry, call.err, true
+	}
+	call := &flightCall{}
+	call.wg.Add(1)
+	f.calls[key] = call
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		delete(f.calls, key)
+		f.mu.Unlock()
+		call.wg.Done()
+	}()
+	call.entry, call.err = fn()
+	return call.entry, call.err, false
+}
@@ -0,0 +1,264 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// 路径类别
+const (
+	ClassMetadata = "metadata"
+	ClassPackage  = "package"
+)
+
+const (
+	defaultMetadataCacheControl = "public, max-age=60, s-maxage=300"
+	defaultPackageCacheControl  = "public, max-age=86400, s-maxage=31536000"
+	defaultPurgeTimeout         = 10 * time.Second
+	purgeAttempts               = 3
+	repoListTTL                 = 30 * time.Second
+)
+
+// exempt 不输出 CDN 缓存头的路径前缀
+var exempt = []string{"/api/", "/static/", "/health", "/ready", "/metrics", "/keys"}
+
+// repoRoutes /repo/{name}/... 形式的下载和元数据路由
+var repoRoutes = []struct {
+	re    *regexp.Regexp
+	class string
+}{
+	{regexp.MustCompile(`^/repo/(.+)/(?:rpm|deb)/[^/]+$`), ClassPackage},
+	{regexp.MustCompile(`^/repo/(.+)/repodata/.+$`), ClassMetadata},
+	{regexp.MustCompile(`^/repo/(.+)/(?:Packages|Packages\.gz|Release)$`), ClassMetadata},
+	{regexp.MustCompile(`^/repo/(.+)/files/(.+)$`), ""},
+}
+
+// CDN 为响应输出 Cache-Control 和 Surrogate-Key，并在仓库变化时调用清除缓存的 webhook
+type CDN struct {
+	metadataCC string
+	packageCC  string
+	prefix     string
+
+	purge  config.PurgeConfig
+	client *http.Client
+
+	listRepos func(ctx context.Context) ([]string, error)
+	reposMu   sync.Mutex
+	repos     []string // 按长度降序，便于最长匹配
+	reposAt   time.Time
+}
+
+// New 创建 CDN 集成；listRepos 用于从 /{repo}/... 形式的路径中识别仓库
+func New(cfg config.CDNConfig, listRepos func(ctx context.Context) ([]string, error)) (*CDN, error) {
+	c := &CDN{
+		metadataCC: cfg.MetadataCacheControl,
+		packageCC:  cfg.PackageCacheControl,
+		prefix:     cfg.KeyPrefix,
+		purge:      cfg.Purge,
+		listRepos:  listRepos,
+	}
+	if c.metadataCC == "" {
+		c.metadataCC = defaultMetadataCacheControl
+	}
+	if c.packageCC == "" {
+		c.packageCC = defaultPackageCacheControl
+	}
+	if c.purge.Method == "" {
+		c.purge.Method = http.MethodPost
+	}
+
+	timeout := defaultPurgeTimeout
+	if cfg.Purge.Timeout != "" {
+		d, err := time.ParseDuration(cfg.Purge.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cdn purge timeout %q: %w", cfg.Purge.Timeout, err)
+		}
+		timeout = d
+	}
+	c.client = &http.Client{Timeout: timeout}
+	return c, nil
+}
+
+// Handler 为成功的包和元数据响应添加缓存头
+func (c *CDN) Handler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		next(ctx)
+
+		if !ctx.IsGet() && !ctx.IsHead() {
+			return
+		}
+		switch ctx.Response.StatusCode() {
+		case fasthttp.StatusOK, fasthttp.StatusPartialContent, fasthttp.StatusNotModified:
+		default:
+			return
+		}
+		// 目录列表等页面内容随时变化，不缓存
+		if bytes.HasPrefix(ctx.Response.Header.ContentType(), []byte("text/html")) {
+			return
+		}
+
+		repoName, class := c.classify(ctx, string(ctx.Path()))
+		if repoName == "" {
+			return
+		}
+		if len(ctx.Response.Header.Peek("Cache-Control")) == 0 {
+			if class == ClassMetadata {
+				ctx.Response.Header.Set("Cache-Control", c.metadataCC)
+			} else {
+				ctx.Response.Header.Set("Cache-Control", c.packageCC)
+			}
+		}
+		ctx.Response.Header.Set("Surrogate-Key", strings.Join(c.Keys(repoName, class), " "))
+	}
+}
+
+// Keys 返回响应的 Surrogate-Key：仓库和仓库+类别
+func (c *CDN) Keys(repoName, class string) []string {
+	return []string{c.repoKey(repoName), c.repoKey(repoName) + ":" + class}
+}
+
+func (c *CDN) repoKey(repoName string) string {
+	return c.prefix + "repo:" + strings.Trim(repoName, "/")
+}
+
+// classify 识别路径所属仓库和类别，不属于任何仓库时返回空
+func (c *CDN) classify(ctx context.Context, p string) (string, string) {
+	if p == "/" {
+		return "", ""
+	}
+	for _, prefix := range exempt {
+		if strings.HasPrefix(p, prefix) {
+			return "", ""
+		}
+	}
+
+	if strings.HasPrefix(p, "/repo/") {
+		for _, r := range repoRoutes {
+			m := r.re.FindStringSubmatch(p)
+			if m == nil {
+				continue
+			}
+			if r.class != "" {
+				return m[1], r.class
+			}
+			return m[1], Classify(m[2])
+		}
+		return "", ""
+	}
+
+	rel := strings.TrimPrefix(p, "/")
+	for _, name := range c.knownRepos(ctx) {
+		if strings.HasPrefix(rel, name+"/") && len(rel) > len(name)+1 {
+			return name, Classify(rel[len(name)+1:])
+		}
+	}
+	return "", ""
+}
+
+// Classify 按仓库内的相对路径判断类别
+func Classify(rel string) string {
+	rel = strings.TrimPrefix(rel, "/")
+	if strings.HasPrefix(rel, "repodata/") || strings.HasPrefix(rel, "SRPMS/repodata/") || strings.HasPrefix(rel, "dists/") {
+		return ClassMetadata
+	}
+	switch path.Base(rel) {
+	case "InRelease", "Release", "Release.gpg", "Packages", "Packages.gz", "Packages.xz", "Sources", "Sources.gz", "SHA256SUMS", "treeinfo", ".treeinfo":
+		return ClassMetadata
+	}
+	return ClassPackage
+}
+
+// knownRepos 返回缓存的仓库列表，过期后重新读取
+func (c *CDN) knownRepos(ctx context.Context) []string {
+	c.reposMu.Lock()
+	defer c.reposMu.Unlock()
+
+	if c.listRepos == nil || time.Since(c.reposAt) < repoListTTL {
+		return c.repos
+	}
+	repos, err := c.listRepos(ctx)
+	c.reposAt = time.Now()
+	if err != nil {
+		log.Logger.Warnf("Failed to list repositories for CDN headers: %v", err)
+		return c.repos
+	}
+	sort.Slice(repos, func(i, j int) bool { return len(repos[i]) > len(repos[j]) })
+	c.repos = repos
+	return c.repos
+}
+
+// Purge 在后台通知 CDN 清除仓库的缓存：刷新元数据时清除元数据，删除仓库时清除整个仓库
+func (c *CDN) Purge(repoName string, deleted bool) {
+	if c.purge.URL == "" {
+		return
+	}
+	// 新的仓库可能刚刚创建，下次请求时重新读取列表
+	c.reposMu.Lock()
+	c.reposAt = time.Time{}
+	c.reposMu.Unlock()
+
+	req := &types.PurgeRequest{Event: "refresh", Repo: repoName, SurrogateKeys: []string{c.repoKey(repoName) + ":" + ClassMetadata}}
+	if deleted {
+		req.Event = "delete"
+		req.SurrogateKeys = []string{c.repoKey(repoName)}
+	}
+	go func() {
+		if err := c.send(req); err != nil {
+			log.Logger.Warnf("CDN purge for %s failed: %v", repoName, err)
+			return
+		}
+		log.Logger.Debugf("CDN purge sent for %s: %s", repoName, strings.Join(req.SurrogateKeys, " "))
+	}()
+}
+
+// send 调用 webhook，失败时重试
+func (c *CDN) send(req *types.PurgeRequest) error {
+	body, err := req.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = c.post(body, req.SurrogateKeys)
+		if err == nil || attempt == purgeAttempts {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+}
+
+func (c *CDN) post(body []byte, keys []string) error {
+	httpReq, err := http.NewRequest(c.purge.Method, c.purge.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Surrogate-Key", strings.Join(keys, " "))
+	for k, v := range c.purge.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("purge webhook returned %s", resp.Status)
+	}
+	return nil
+}
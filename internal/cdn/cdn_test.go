@@ -0,0 +1,126 @@
+package cdn
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestHandler(t *testing.T) {
+	repos := func(context.Context) ([]string, error) { return []string{"centos", "centos/8"}, nil }
+	c, err := New(config.CDNConfig{KeyPrefix: "plus-", PackageCacheControl: "public, max-age=600"}, repos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path, contentType string
+		cacheControl, key string
+	}{
+		{"/repo/centos/8/rpm/a.rpm", "", "public, max-age=600", "plus-repo:centos/8 plus-repo:centos/8:package"},
+		{"/repo/centos/8/repodata/repomd.xml", "", defaultMetadataCacheControl, "plus-repo:centos/8 plus-repo:centos/8:metadata"},
+		{"/centos/8/repodata/primary.xml.gz", "", defaultMetadataCacheControl, "plus-repo:centos/8 plus-repo:centos/8:metadata"},
+		{"/centos/7/Packages/b.rpm", "", "public, max-age=600", "plus-repo:centos plus-repo:centos:package"},
+		{"/centos/8/Packages/", "text/html; charset=utf-8", "", ""},
+		{"/api/v1/repos", "", "", ""},
+		{"/repo/centos/8/refresh", "", "", ""},
+		{"/ubuntu/pool/a.deb", "", "", ""},
+	}
+	for _, tc := range cases {
+		handler := c.Handler(func(ctx *fasthttp.RequestCtx) {
+			if tc.contentType != "" {
+				ctx.SetContentType(tc.contentType)
+			}
+			ctx.SetBodyString("data")
+		})
+		var ctx fasthttp.RequestCtx
+		ctx.Request.SetRequestURI(tc.path)
+		handler(&ctx)
+
+		if got := string(ctx.Response.Header.Peek("Cache-Control")); got != tc.cacheControl {
+			t.Errorf("%s: Cache-Control = %q, want %q", tc.path, got, tc.cacheControl)
+		}
+		if got := string(ctx.Response.Header.Peek("Surrogate-Key")); got != tc.key {
+			t.Errorf("%s: Surrogate-Key = %q, want %q", tc.path, got, tc.key)
+		}
+	}
+}
+
+func TestPurge(t *testing.T) {
+	type call struct {
+		method, key, auth, body string
+	}
+	calls := make(chan call, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls <- call{r.Method, r.Header.Get("Surrogate-Key"), r.Header.Get("Fastly-Key"), string(body)}
+	}))
+	defer server.Close()
+
+	c, err := New(config.CDNConfig{Purge: config.PurgeConfig{
+		URL:     server.URL,
+		Headers: map[string]string{"Fastly-Key": "secret"},
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wait := func() call {
+		select {
+		case got := <-calls:
+			return got
+		case <-time.After(5 * time.Second):
+			t.Fatal("purge webhook not called")
+		}
+		return call{}
+	}
+
+	c.Purge("centos/8", false)
+	got := wait()
+	if got.method != http.MethodPost || got.key != "repo:centos/8:metadata" || got.auth != "secret" {
+		t.Errorf("refresh purge = %+v", got)
+	}
+	if !strings.Contains(got.body, `"event":"refresh"`) || !strings.Contains(got.body, `"surrogate_keys":["repo:centos/8:metadata"]`) {
+		t.Errorf("refresh purge body = %s", got.body)
+	}
+
+	c.Purge("centos/8", true)
+	if got := wait(); got.key != "repo:centos/8" || !strings.Contains(got.body, `"event":"delete"`) {
+		t.Errorf("delete purge = %+v", got)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	cases := map[string]string{
+		"repodata/repomd.xml":                 ClassMetadata,
+		"SRPMS/repodata/repomd.xml":           ClassMetadata,
+		"Sources.gz":                          ClassMetadata,
+		"dists/stable/main/binary-amd64/a.gz": ClassMetadata,
+		"Packages.gz":                         ClassMetadata,
+		"InRelease":                           ClassMetadata,
+		"Packages/a-1.0.rpm":                  ClassPackage,
+		"SRPMS/Packages/a-1.0.src.rpm":        ClassPackage,
+		"pool/main/a/a_1.0_amd64.deb":         ClassPackage,
+	}
+	for rel, want := range cases {
+		if got := Classify(rel); got != want {
+			t.Errorf("Classify(%q) = %q, want %q", rel, got, want)
+		}
+	}
+}
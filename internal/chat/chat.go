@@ -0,0 +1,345 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/eventlog"
+	"plus/internal/log"
+	"plus/internal/notify"
+	"plus/internal/types"
+	"plus/internal/utils"
+)
+
+// FileName 聊天 webhook 在 DatabasePath 下的文件名
+const FileName = "chat-webhooks.json"
+
+// 支持的聊天工具。webhook 为通用的 webhook，收到事件日志格式的 JSON
+const (
+	KindSlack      = "slack"
+	KindTeams      = "teams"
+	KindMattermost = "mattermost"
+	KindWebhook    = "webhook"
+)
+
+const sendTimeout = 10 * time.Second
+
+var (
+	ErrNotFound = errors.New("chat webhook not found")
+	ErrInvalid  = errors.New("invalid chat webhook")
+)
+
+// Store 聊天 webhook，保存在内存中，每次修改重写 DatabasePath 下的文件
+type Store struct {
+	file   string
+	client *http.Client
+
+	mu    sync.Mutex
+	hooks map[string]*types.ChatWebhook
+}
+
+// Open 加载 dir 下的聊天 webhook
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create chat webhook directory: %w", err)
+	}
+	s := &Store{
+		file:   filepath.Join(dir, FileName),
+		client: &http.Client{Timeout: sendTimeout},
+		hooks:  make(map[string]*types.ChatWebhook),
+	}
+	data, err := os.ReadFile(s.file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read chat webhooks: %w", err)
+	}
+	var saved types.ChatWebhookData
+	if err := saved.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("chat webhooks %s are corrupt: %w", s.file, err)
+	}
+	for i := range saved.Webhooks {
+		w := saved.Webhooks[i]
+		s.hooks[w.ID] = &w
+	}
+	return s, nil
+}
+
+// validate 检查并规范化 webhook 的设置
+func validate(w *types.ChatWebhook) error {
+	w.Name = strings.TrimSpace(w.Name)
+	if w.Name == "" {
+		return fmt.Errorf("name is required: %w", ErrInvalid)
+	}
+	switch w.Kind {
+	case KindSlack, KindTeams, KindMattermost, KindWebhook:
+	default:
+		return fmt.Errorf("kind %q, expected slack, teams, mattermost or webhook: %w", w.Kind, ErrInvalid)
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("url must be an http(s) URL: %w", ErrInvalid)
+	}
+	if len(w.Events) == 0 {
+		return fmt.Errorf("events are required: %w", ErrInvalid)
+	}
+	for _, name := range w.Events {
+		if !validEvent(w.Kind, name) && name != "*" {
+			return fmt.Errorf("event %q, expected one of %s: %w", name, strings.Join(notify.Events, ", "), ErrInvalid)
+		}
+	}
+	for _, pattern := range w.Repos {
+		if !utils.ValidGlob(pattern) {
+			return fmt.Errorf("repository pattern %q: %w", pattern, ErrInvalid)
+		}
+	}
+	return nil
+}
+
+// Create 添加 webhook，生成 ID
+func (s *Store) Create(w types.ChatWebhook) (types.ChatWebhook, error) {
+	if err := validate(&w); err != nil {
+		return w, err
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return w, err
+	}
+	w.ID = hex.EncodeToString(id)
+	w.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	w.LastSent, w.LastError = "", ""
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks[w.ID] = &w
+	return w, s.save()
+}
+
+// Update 替换 webhook 的名称、类型、订阅和地址；url 为空时保留原地址
+func (s *Store) Update(id string, w types.ChatWebhook) (types.ChatWebhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.hooks[id]
+	if !ok {
+		return w, ErrNotFound
+	}
+	if w.URL == "" {
+		w.URL = existing.URL
+	}
+	if err := validate(&w); err != nil {
+		return w, err
+	}
+	w.ID, w.CreatedBy, w.CreatedAt = existing.ID, existing.CreatedBy, existing.CreatedAt
+	w.LastSent, w.LastError = existing.LastSent, existing.LastError
+	*existing = w
+	return w, s.save()
+}
+
+// Delete 删除 webhook
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.hooks[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.hooks, id)
+	return s.save()
+}
+
+// Get 返回 webhook
+func (s *Store) Get(id string) (types.ChatWebhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.hooks[id]
+	if !ok {
+		return types.ChatWebhook{}, ErrNotFound
+	}
+	return *w, nil
+}
+
+// List 按名称返回全部 webhook
+func (s *Store) List() []types.ChatWebhook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]types.ChatWebhook, 0, len(s.hooks))
+	for _, w := range s.hooks {
+		list = append(list, *w)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// validEvent 通用 webhook 还可以订阅只记入事件日志的事件
+func validEvent(kind, name string) bool {
+	if kind == KindWebhook {
+		return notify.Valid(name) || name == eventlog.EventRepoRefreshed || name == eventlog.EventRepoDeleted
+	}
+	return notify.Valid(name)
+}
+
+// Name 实现 notify.Channel
+func (s *Store) Name() string { return "chat" }
+
+// Targets 实现 notify.Channel，返回订阅了事件的 webhook 的 ID
+func (s *Store) Targets(e notify.Event) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, w := range s.hooks {
+		if validEvent(w.Kind, e.Name) && notify.Match(w.Events, w.Repos, e.Name, e.Repo) {
+			ids = append(ids, w.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Deliver 实现 notify.Channel，把事件发给一个 webhook。webhook 已删除时不再发送
+func (s *Store) Deliver(ctx context.Context, id string, e notify.Event) error {
+	w, err := s.Get(id)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = s.deliver(ctx, w, e)
+	s.record(id, err)
+	return err
+}
+
+// Test 立即向 webhook 发送一条测试消息
+func (s *Store) Test(ctx context.Context, id string) error {
+	w, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	err = s.deliver(ctx, w, notify.Event{Name: "test", Message: "Test message from plus: the webhook " + w.Name + " works"})
+	s.record(id, err)
+	return err
+}
+
+// record 记录最近一次发送的时间和结果
+func (s *Store) record(id string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.hooks[id]
+	if !ok {
+		return
+	}
+	w.LastSent = time.Now().UTC().Format(time.RFC3339)
+	w.LastError = ""
+	if err != nil {
+		w.LastError = err.Error()
+	}
+	if err := s.save(); err != nil {
+		log.Logger.Warnf("Failed to save chat webhooks: %v", err)
+	}
+}
+
+// deliver 按 webhook 的类型生成消息并发送一次
+func (s *Store) deliver(ctx context.Context, w types.ChatWebhook, e notify.Event) error {
+	body, err := payload(w.Kind, e)
+	if err != nil {
+		return err
+	}
+	return s.post(ctx, w.URL, body)
+}
+
+// payload 生成各聊天工具传入 webhook 的消息体
+func payload(kind string, e notify.Event) ([]byte, error) {
+	if kind == KindWebhook {
+		rec := eventlog.NewRecord(e, e.Node, time.Now())
+		return rec.MarshalJSON()
+	}
+	text := notify.Summary(e)
+	if kind == KindTeams {
+		return (&types.TeamsMessage{
+			Type:    "MessageCard",
+			Context: "https://schema.org/extensions",
+			Summary: text,
+			Title:   e.Repo,
+			Text:    text,
+		}).MarshalJSON()
+	}
+	return (&types.ChatMessage{Text: text}).MarshalJSON()
+}
+
+func (s *Store) post(ctx context.Context, target string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// 错误信息中的地址含有 webhook 的密钥
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusRequestTimeout:
+		// webhook 已失效或拒绝了消息，重试也不会成功
+		return fmt.Errorf("%w: webhook returned %s", notify.ErrPermanent, resp.Status)
+	default:
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+}
+
+// Redact 隐藏地址的路径和查询参数，传入 webhook 的地址本身就是密钥
+func Redact(w types.ChatWebhook) types.ChatWebhook {
+	if u, err := url.Parse(w.URL); err == nil && u.Host != "" {
+		w.URL = u.Scheme + "://" + u.Host + "/***"
+	} else {
+		w.URL = "***"
+	}
+	return w
+}
+
+// save 重写文件，调用方持有锁。文件含有 webhook 地址，只允许所有者读取
+func (s *Store) save() error {
+	saved := types.ChatWebhookData{Webhooks: make([]types.ChatWebhook, 0, len(s.hooks))}
+	for _, w := range s.hooks {
+		saved.Webhooks = append(saved.Webhooks, *w)
+	}
+	sort.Slice(saved.Webhooks, func(i, j int) bool { return saved.Webhooks[i].ID < saved.Webhooks[j].ID })
+
+	data, err := saved.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	tmp := s.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write chat webhooks: %w", err)
+	}
+	if err := os.Rename(tmp, s.file); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write chat webhooks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/eventlog"
+	"plus/internal/log"
+	"plus/internal/notify"
+	"plus/internal/types"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bad := range []types.ChatWebhook{
+		{Kind: KindSlack, URL: "https://hooks.example.com/x", Events: []string{"*"}},
+		{Name: "a", Kind: "irc", URL: "https://hooks.example.com/x", Events: []string{"*"}},
+		{Name: "a", Kind: KindSlack, URL: "hooks.example.com/x", Events: []string{"*"}},
+		{Name: "a", Kind: KindSlack, URL: "https://hooks.example.com/x"},
+		{Name: "a", Kind: KindSlack, URL: "https://hooks.example.com/x", Events: []string{"package.uploaded"}},
+		{Name: "a", Kind: KindSlack, URL: "https://hooks.example.com/x", Events: []string{"*"}, Repos: []string{"["}},
+	} {
+		if _, err := s.Create(bad); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Create(%+v) = %v, want ErrInvalid", bad, err)
+		}
+	}
+
+	w, err := s.Create(types.ChatWebhook{Name: "releases", Kind: KindSlack, URL: "https://hooks.slack.com/services/T0/B0/secret",
+		Events: []string{notify.EventPackagePublished}, Repos: []string{"prod/**"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// url 为空时保留原地址
+	updated, err := s.Update(w.ID, types.ChatWebhook{Name: "releases", Kind: KindSlack, Events: []string{"*"}})
+	if err != nil || updated.URL != w.URL || updated.CreatedAt != w.CreatedAt || len(updated.Repos) != 0 {
+		t.Fatalf("Update = %+v, %v", updated, err)
+	}
+	if got := Redact(updated).URL; got != "https://hooks.slack.com/***" {
+		t.Errorf("Redact = %q", got)
+	}
+
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list := reopened.List(); len(list) != 1 || list[0].Events[0] != "*" {
+		t.Errorf("Reopened list = %+v", list)
+	}
+	if info, err := os.Stat(dir + "/" + FileName); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Webhook file mode = %v, %v", info.Mode(), err)
+	}
+
+	if err := s.Delete(w.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(w.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after delete = %v", err)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	received := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		received <- r.URL.Path + " " + string(body)
+	}))
+	defer server.Close()
+
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	slack, _ := s.Create(types.ChatWebhook{Name: "slack", Kind: KindSlack, URL: server.URL + "/slack",
+		Events: []string{notify.EventPackagePublished}, Repos: []string{"prod/*"}})
+	teams, _ := s.Create(types.ChatWebhook{Name: "teams", Kind: KindTeams, URL: server.URL + "/teams",
+		Events: []string{"*"}})
+	hook, _ := s.Create(types.ChatWebhook{Name: "indexer", Kind: KindWebhook, URL: server.URL + "/hook",
+		Events: []string{eventlog.EventRepoRefreshed}})
+	if _, err := s.Create(types.ChatWebhook{Name: "chat", Kind: KindSlack, URL: server.URL + "/slack",
+		Events: []string{eventlog.EventRepoRefreshed}}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Chat webhook for a log-only event = %v, want ErrInvalid", err)
+	}
+
+	published := notify.Event{Name: notify.EventPackagePublished, Repo: "prod/el9", Files: []string{"a.rpm", "b.rpm"}}
+	if got := s.Targets(notify.Event{Name: notify.EventPackagePublished, Repo: "dev/el9"}); len(got) != 1 || got[0] != teams.ID {
+		t.Errorf("Targets(dev/el9) = %v", got)
+	}
+	if err := s.Deliver(ctx, slack.ID, published); err != nil {
+		t.Fatal(err)
+	}
+	if got := wait(t, received); got != `/slack {"text":"Published to prod/el9: a.rpm, b.rpm"}` {
+		t.Errorf("Slack message = %s", got)
+	}
+
+	if err := s.Deliver(ctx, teams.ID, notify.Event{Name: notify.EventRetentionDeleted, Repo: "prod/el9", Count: 12}); err != nil {
+		t.Fatal(err)
+	}
+	got := wait(t, received)
+	if !strings.HasPrefix(got, `/teams {"@type":"MessageCard"`) || !strings.Contains(got, `"text":"Retention deleted 12 old package version(s) from prod/el9"`) {
+		t.Errorf("Teams message = %s", got)
+	}
+
+	// 通用 webhook 收到事件日志格式的 JSON，聊天工具不会收到只记入日志的事件
+	refreshed := notify.Event{Seq: 42, Name: eventlog.EventRepoRefreshed, Repo: "prod/el9", Node: "node-1", Time: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)}
+	if got := s.Targets(refreshed); len(got) != 1 || got[0] != hook.ID {
+		t.Errorf("Targets(repo.refreshed) = %v", got)
+	}
+	if err := s.Deliver(ctx, hook.ID, refreshed); err != nil {
+		t.Fatal(err)
+	}
+	if got := wait(t, received); got != `/hook {"seq":42,"time":"2026-10-16T09:00:00Z","event":"repo.refreshed","repo":"prod/el9","node":"node-1"}` {
+		t.Errorf("Webhook message = %s", got)
+	}
+
+	if err := s.Test(ctx, slack.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := wait(t, received); !strings.Contains(got, "Test message from plus") {
+		t.Errorf("Test message = %s", got)
+	}
+	if w, _ := s.Get(slack.ID); w.LastSent == "" || w.LastError != "" {
+		t.Errorf("Slack webhook status = %+v", w)
+	}
+
+	gone, _ := s.Create(types.ChatWebhook{Name: "gone", Kind: KindSlack, URL: server.URL + "/gone", Events: []string{"*"}})
+	if err := s.Deliver(ctx, gone.ID, published); !errors.Is(err, notify.ErrPermanent) {
+		t.Errorf("Deliver to a removed webhook = %v, want ErrPermanent", err)
+	}
+	s.Delete(gone.ID)
+	if err := s.Deliver(ctx, gone.ID, published); err != nil {
+		t.Errorf("Deliver to a deleted webhook = %v", err)
+	}
+}
+
+func wait(t *testing.T, received chan string) string {
+	t.Helper()
+	select {
+	case got := <-received:
+		return got
+	case <-time.After(5 * time.Second):
+		t.Fatal("No message received")
+		return ""
+	}
+}
@@ -0,0 +1,381 @@
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+// Store 多个实例共享的状态：带过期时间的锁和字符串哈希表
+type Store interface {
+	// TryLock 锁不存在时以 token 持有 key，ttl 后自动过期
+	TryLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	// Extend 仍由 token 持有时延长过期时间，锁已过期或被他人持有时返回 false
+	Extend(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	// Unlock 仍由 token 持有时释放
+	Unlock(ctx context.Context, key, token string) error
+
+	HGet(ctx context.Context, hash, field string) (string, bool, error)
+	HSet(ctx context.Context, hash, field, value string) error
+	HDel(ctx context.Context, hash, field string) error
+	HGetAll(ctx context.Context, hash string) (map[string]string, error)
+
+	Close() error
+}
+
+const (
+	reposHash       = "repos"       // 仓库名 -> 类型
+	refreshedHash   = "refreshed"   // 仓库名 -> 最近一次成功刷新元数据的开始时间（UnixNano）
+	maintenanceHash = "maintenance" // 仓库名 -> 维护状态（JSON）
+	sessionsHash    = "sessions"    // 会话 ID -> 会话（JSON）
+	revokedHash     = "revoked"     // 已撤销的会话 ID -> 会话的过期时间
+
+	// DefaultLockTTL 锁的默认过期时间，持有期间每 1/3 过期时间续期一次
+	DefaultLockTTL = 30 * time.Second
+)
+
+// ErrLeaseLost 持有锁期间续期失败，锁可能已被其他实例获取
+var ErrLeaseLost = errors.New("cluster lock lease lost")
+
+// Cluster 多个实例共享仓库注册表，并用分布式锁保证同一仓库的元数据同一时间只有一个实例在生成
+type Cluster struct {
+	store Store
+	node  string
+	ttl   time.Duration
+
+	acquired  int64
+	waits     int64
+	coalesced int64
+	lost      int64
+	held      int64
+	leader    atomic.Bool
+
+	mu        sync.Mutex
+	lastError string
+}
+
+// New 创建集群协调器，node 为本实例在锁和日志中的名称
+func New(store Store, node string, ttl time.Duration) *Cluster {
+	if ttl <= 0 {
+		ttl = DefaultLockTTL
+	}
+	return &Cluster{store: store, node: node, ttl: ttl}
+}
+
+// Node 返回本实例的名称
+func (c *Cluster) Node() string {
+	return c.node
+}
+
+// Close 关闭共享存储的连接
+func (c *Cluster) Close() error {
+	return c.store.Close()
+}
+
+// Lease 持有中的锁，后台自动续期
+type Lease struct {
+	c      *Cluster
+	key    string
+	token  string
+	stop   chan struct{}
+	done   chan struct{}
+	lost   atomic.Bool
+	cancel context.CancelFunc
+}
+
+// Lock 获取名为 name 的锁，被其他实例持有时等待直到获取或 ctx 结束。
+// 续期失败时取消返回的 ctx，持有者应停止写入
+func (c *Cluster) Lock(ctx context.Context, name string) (*Lease, context.Context, error) {
+	token := c.node + "/" + randomSuffix()
+	backoff := 50 * time.Millisecond
+	waited := false
+	for {
+		ok, err := c.store.TryLock(ctx, name, token, c.ttl)
+		if err != nil {
+			c.setError(err)
+			return nil, nil, fmt.Errorf("acquire cluster lock %s: %w", name, err)
+		}
+		if ok {
+			break
+		}
+		if !waited {
+			waited = true
+			atomic.AddInt64(&c.waits, 1)
+			log.Logger.Debugf("Cluster lock %s is held by another node, waiting", name)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff < time.Second {
+			backoff *= 2
+		}
+	}
+	atomic.AddInt64(&c.acquired, 1)
+	atomic.AddInt64(&c.held, 1)
+
+	leaseCtx, cancel := context.WithCancel(ctx)
+	l := &Lease{c: c, key: name, token: token, stop: make(chan struct{}), done: make(chan struct{}), cancel: cancel}
+	go l.keepAlive()
+	return l, leaseCtx, nil
+}
+
+func (l *Lease) keepAlive() {
+	defer close(l.done)
+	ticker := time.NewTicker(l.c.ttl / 3)
+	defer ticker.Stop()
+	extended := time.Now()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), l.c.ttl/3)
+		ok, err := l.c.store.Extend(ctx, l.key, l.token, l.c.ttl)
+		cancel()
+		switch {
+		case err == nil && ok:
+			extended = time.Now()
+			continue
+		case err == nil:
+			err = ErrLeaseLost
+		case time.Since(extended) < l.c.ttl:
+			// 共享存储暂时不可用，锁过期前继续重试
+			l.c.setError(err)
+			log.Logger.Warnf("Failed to extend cluster lock %s: %v", l.key, err)
+			continue
+		}
+		l.lost.Store(true)
+		atomic.AddInt64(&l.c.lost, 1)
+		l.c.setError(err)
+		log.Logger.Warnf("Cluster lock %s lost: %v", l.key, err)
+		l.cancel()
+		return
+	}
+}
+
+// Unlock 停止续期并释放锁；锁已丢失时返回 ErrLeaseLost
+func (l *Lease) Unlock() error {
+	close(l.stop)
+	<-l.done
+	l.cancel()
+	atomic.AddInt64(&l.c.held, -1)
+	if l.lost.Load() {
+		return ErrLeaseLost
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), l.c.ttl)
+	defer cancel()
+	if err := l.c.store.Unlock(ctx, l.key, l.token); err != nil {
+		l.c.setError(err)
+		return fmt.Errorf("release cluster lock %s: %w", l.key, err)
+	}
+	return nil
+}
+
+// RefreshMetadata 在仓库锁内执行 fn 重新生成元数据。requested 为发起刷新的时间，
+// 获取锁后如果已有其他实例在 requested 之后开始并完成了一次刷新，则跳过 fn 并返回 false
+func (c *Cluster) RefreshMetadata(ctx context.Context, repoName string, requested time.Time, fn func(context.Context) error) (bool, error) {
+	lease, leaseCtx, err := c.Lock(ctx, "refresh/"+repoName)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := lease.Unlock(); err != nil {
+			log.Logger.Warnf("Refresh of %s: %v", repoName, err)
+		}
+	}()
+
+	if last, ok, err := c.store.HGet(ctx, refreshedHash, repoName); err != nil {
+		c.setError(err)
+		log.Logger.Warnf("Failed to read last refresh of %s, refreshing anyway: %v", repoName, err)
+	} else if ok {
+		if ns, err := strconv.ParseInt(last, 10, 64); err == nil && ns > requested.UnixNano() {
+			atomic.AddInt64(&c.coalesced, 1)
+			log.Logger.Debugf("Metadata of %s was refreshed by another node, skipping", repoName)
+			return false, nil
+		}
+	}
+
+	start := time.Now()
+	if err := fn(leaseCtx); err != nil {
+		if lease.lost.Load() {
+			return true, fmt.Errorf("refresh %s: %w", repoName, ErrLeaseLost)
+		}
+		return true, err
+	}
+	if err := c.store.HSet(ctx, refreshedHash, repoName, strconv.FormatInt(start.UnixNano(), 10)); err != nil {
+		c.setError(err)
+		log.Logger.Warnf("Failed to record refresh of %s: %v", repoName, err)
+	}
+	return true, nil
+}
+
+// RepoTypes 返回注册表中的全部仓库类型
+func (c *Cluster) RepoTypes(ctx context.Context) (map[string]string, error) {
+	repos, err := c.store.HGetAll(ctx, reposHash)
+	if err != nil {
+		c.setError(err)
+	}
+	return repos, err
+}
+
+// RepoType 返回注册表中的仓库类型
+func (c *Cluster) RepoType(ctx context.Context, repoName string) (string, bool, error) {
+	typ, ok, err := c.store.HGet(ctx, reposHash, repoName)
+	if err != nil {
+		c.setError(err)
+	}
+	return typ, ok, err
+}
+
+// RegisterRepo 把仓库类型写入注册表
+func (c *Cluster) RegisterRepo(ctx context.Context, repoName, repoType string) error {
+	err := c.store.HSet(ctx, reposHash, repoName, repoType)
+	if err != nil {
+		c.setError(err)
+	}
+	return err
+}
+
+// UnregisterRepo 从注册表删除仓库
+func (c *Cluster) UnregisterRepo(ctx context.Context, repoName string) error {
+	err := c.store.HDel(ctx, reposHash, repoName)
+	if err == nil {
+		err = c.store.HDel(ctx, refreshedHash, repoName)
+	}
+	if err == nil {
+		err = c.store.HDel(ctx, maintenanceHash, repoName)
+	}
+	if err != nil {
+		c.setError(err)
+	}
+	return err
+}
+
+// Maintenance 返回所有处于维护状态的仓库
+func (c *Cluster) Maintenance(ctx context.Context) (map[string]string, error) {
+	repos, err := c.store.HGetAll(ctx, maintenanceHash)
+	if err != nil {
+		c.setError(err)
+	}
+	return repos, err
+}
+
+// SetMaintenance 记录仓库的维护状态，state 为空时退出维护
+func (c *Cluster) SetMaintenance(ctx context.Context, repoName, state string) error {
+	var err error
+	if state == "" {
+		err = c.store.HDel(ctx, maintenanceHash, repoName)
+	} else {
+		err = c.store.HSet(ctx, maintenanceHash, repoName, state)
+	}
+	if err != nil {
+		c.setError(err)
+	}
+	return err
+}
+
+// Sessions 返回各实例签发的会话
+func (c *Cluster) Sessions(ctx context.Context) (map[string]string, error) {
+	sessions, err := c.store.HGetAll(ctx, sessionsHash)
+	if err != nil {
+		c.setError(err)
+	}
+	return sessions, err
+}
+
+// SetSession 记录会话，其他实例可以列出和撤销
+func (c *Cluster) SetSession(ctx context.Context, id, session string) error {
+	err := c.store.HSet(ctx, sessionsHash, id, session)
+	if err != nil {
+		c.setError(err)
+	}
+	return err
+}
+
+// DeleteSession 删除已过期的会话
+func (c *Cluster) DeleteSession(ctx context.Context, id string) error {
+	err := c.store.HDel(ctx, sessionsHash, id)
+	if err != nil {
+		c.setError(err)
+	}
+	return err
+}
+
+// RevokeSession 把会话加入撤销列表直到 expires（RFC3339），同时清理已过期的撤销记录
+func (c *Cluster) RevokeSession(ctx context.Context, id, expires string) error {
+	err := c.store.HSet(ctx, revokedHash, id, expires)
+	if err == nil {
+		err = c.store.HDel(ctx, sessionsHash, id)
+	}
+	var revoked map[string]string
+	if err == nil {
+		revoked, err = c.store.HGetAll(ctx, revokedHash)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for rid, exp := range revoked {
+		if err == nil && exp <= now {
+			err = c.store.HDel(ctx, revokedHash, rid)
+		}
+	}
+	if err != nil {
+		c.setError(err)
+	}
+	return err
+}
+
+// SessionRevoked 返回会话是否已被任一实例撤销
+func (c *Cluster) SessionRevoked(ctx context.Context, id string) (bool, error) {
+	_, ok, err := c.store.HGet(ctx, revokedHash, id)
+	if err != nil {
+		c.setError(err)
+	}
+	return ok, err
+}
+
+// Metrics 返回锁的统计
+func (c *Cluster) Metrics() *types.ClusterMetrics {
+	c.mu.Lock()
+	lastError := c.lastError
+	c.mu.Unlock()
+	return &types.ClusterMetrics{
+		Node:          c.node,
+		Leader:        c.leader.Load(),
+		LocksHeld:     atomic.LoadInt64(&c.held),
+		LocksAcquired: atomic.LoadInt64(&c.acquired),
+		LockWaits:     atomic.LoadInt64(&c.waits),
+		Coalesced:     atomic.LoadInt64(&c.coalesced),
+		LeasesLost:    atomic.LoadInt64(&c.lost),
+		LastError:     lastError,
+	}
+}
+
+func (c *Cluster) setLeader(leader bool) {
+	c.leader.Store(leader)
+}
+
+func (c *Cluster) setError(err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	c.mu.Lock()
+	c.lastError = err.Error()
+	c.mu.Unlock()
+}
+
+func randomSuffix() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,155 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestLockExclusive(t *testing.T) {
+	store := NewMemoryStore()
+	a, b := New(store, "a", time.Second), New(store, "b", time.Second)
+	ctx := context.Background()
+
+	lease, _, err := a.Lock(ctx, "refresh/el9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	if _, _, err := b.Lock(waitCtx, "refresh/el9"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second node acquired a held lock: %v", err)
+	}
+
+	// 持有时间超过 ttl 时自动续期
+	time.Sleep(1500 * time.Millisecond)
+	if ok, _ := store.TryLock(ctx, "refresh/el9", "x", time.Second); ok {
+		t.Fatal("lock expired while held")
+	}
+	if err := lease.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	lease, _, err = b.Lock(ctx, "refresh/el9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lease.Unlock()
+	if m := b.Metrics(); m.LockWaits != 1 || m.LocksAcquired != 1 || m.LocksHeld != 0 {
+		t.Errorf("metrics = %+v", m)
+	}
+}
+
+func TestLeaseLost(t *testing.T) {
+	store := NewMemoryStore()
+	c := New(store, "a", 150*time.Millisecond)
+	lease, ctx, err := c.Lock(context.Background(), "refresh/el9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 模拟锁过期后被其他实例获取
+	mem := store.(*memoryStore)
+	mem.mu.Lock()
+	mem.locks["refresh/el9"] = memoryLock{token: "other", expires: time.Now().Add(time.Hour)}
+	mem.mu.Unlock()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("lease context not cancelled")
+	}
+	if err := lease.Unlock(); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Unlock = %v", err)
+	}
+	if ok, _ := store.TryLock(context.Background(), "refresh/el9", "x", time.Second); ok {
+		t.Error("lost lease released another node's lock")
+	}
+}
+
+func TestRefreshCoalesced(t *testing.T) {
+	store := NewMemoryStore()
+	nodes := []*Cluster{New(store, "a", time.Second), New(store, "b", time.Second)}
+	ctx := context.Background()
+
+	var running, runs int32
+	refresh := func(ctx context.Context) error {
+		if atomic.AddInt32(&running, 1) != 1 {
+			t.Error("two nodes refreshing the same repository")
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&runs, 1)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+
+	// 两个实例在刷新开始前同时收到上传，一次刷新即可覆盖
+	requested := time.Now()
+	var wg sync.WaitGroup
+	for _, c := range nodes {
+		wg.Add(1)
+		go func(c *Cluster) {
+			defer wg.Done()
+			if _, err := c.RefreshMetadata(ctx, "el9", requested, refresh); err != nil {
+				t.Error(err)
+			}
+		}(c)
+	}
+	wg.Wait()
+	if runs != 1 {
+		t.Errorf("refresh ran %d times for requests made before either started", runs)
+	}
+
+	if ran, _ := nodes[0].RefreshMetadata(ctx, "el9", time.Now(), refresh); !ran || runs != 2 {
+		t.Errorf("request after the last refresh was skipped")
+	}
+	if nodes[0].Metrics().Coalesced+nodes[1].Metrics().Coalesced != 1 {
+		t.Error("coalesced refresh not counted")
+	}
+}
+
+func TestElection(t *testing.T) {
+	store := NewMemoryStore()
+	a, b := New(store, "a", 300*time.Millisecond), New(store, "b", 300*time.Millisecond)
+	ctxA, stopA := context.WithCancel(context.Background())
+	ctxB, stopB := context.WithCancel(context.Background())
+	defer stopB()
+
+	ea := a.Elect(ctxA)
+	waitFor(t, func() bool { _, ok := ea.Lead(); return ok })
+	eb := b.Elect(ctxB)
+	time.Sleep(200 * time.Millisecond)
+	if _, ok := eb.Lead(); ok {
+		t.Fatal("two leaders elected")
+	}
+	leaderCtx, _ := ea.Lead()
+
+	// 领导者退出时释放租约，另一个实例接任
+	stopA()
+	waitFor(t, func() bool { return leaderCtx.Err() != nil })
+	waitFor(t, func() bool { _, ok := eb.Lead(); return ok })
+	if !b.Metrics().Leader || a.Metrics().Leader {
+		t.Fatal("leader flag not updated in metrics")
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
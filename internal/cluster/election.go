@@ -0,0 +1,129 @@
+package cluster
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"plus/internal/log"
+)
+
+const (
+	leaderLock = "leader"
+	jobsHash   = "jobs" // 任务名 -> 最近一次开始执行的时间（UnixNano）
+)
+
+// Election 通过共享存储中的租约选出一个领导者，领导者执行集群范围的定时任务
+type Election struct {
+	c     *Cluster
+	token string
+
+	mu     sync.Mutex
+	ctx    context.Context // 领导期间有效，失去领导权时取消
+	cancel context.CancelFunc
+}
+
+// Elect 开始参与选举，ctx 结束后退出并释放领导权
+func (c *Cluster) Elect(ctx context.Context) *Election {
+	e := &Election{c: c, token: c.node + "/" + randomSuffix()}
+	go e.run(ctx)
+	return e
+}
+
+// Lead 本实例是领导者时返回 true 和在失去领导权时取消的 ctx
+func (e *Election) Lead() (context.Context, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.ctx == nil {
+		return nil, false
+	}
+	return e.ctx, true
+}
+
+func (e *Election) run(ctx context.Context) {
+	ticker := time.NewTicker(e.c.ttl / 3)
+	defer ticker.Stop()
+	renewed := time.Time{}
+	for {
+		leading := e.leading()
+		opCtx, cancel := context.WithTimeout(ctx, e.c.ttl/3)
+		var ok bool
+		var err error
+		if leading {
+			ok, err = e.c.store.Extend(opCtx, leaderLock, e.token, e.c.ttl)
+		} else {
+			ok, err = e.c.store.TryLock(opCtx, leaderLock, e.token, e.c.ttl)
+		}
+		cancel()
+
+		switch {
+		case err == nil && ok:
+			renewed = time.Now()
+			if !leading {
+				e.setLeader(true)
+				log.Logger.Infof("Cluster node %s is now the leader", e.c.node)
+			}
+		case err == nil || (leading && time.Since(renewed) >= e.c.ttl):
+			// 租约被他人持有，或共享存储不可用的时间超过租约
+			if leading {
+				e.setLeader(false)
+				log.Logger.Warnf("Cluster node %s lost leadership", e.c.node)
+			}
+		default:
+			e.c.setError(err)
+			log.Logger.Debugf("Leader election: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if e.leading() {
+				e.setLeader(false)
+				releaseCtx, cancel := context.WithTimeout(context.Background(), e.c.ttl/3)
+				e.c.store.Unlock(releaseCtx, leaderLock, e.token)
+				cancel()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Election) leading() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.ctx != nil
+}
+
+func (e *Election) setLeader(leader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if leader {
+		e.ctx, e.cancel = context.WithCancel(context.Background())
+		e.c.setLeader(true)
+		return
+	}
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.ctx, e.cancel = nil, nil
+	e.c.setLeader(false)
+}
+
+// LastRun 返回集群任务最近一次开始执行的时间
+func (e *Election) LastRun(ctx context.Context, job string) (time.Time, bool, error) {
+	v, ok, err := e.c.store.HGet(ctx, jobsHash, job)
+	if err != nil || !ok {
+		return time.Time{}, false, err
+	}
+	ns, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, false, nil
+	}
+	return time.Unix(0, ns), true, nil
+}
+
+// RecordRun 记录集群任务开始执行的时间
+func (e *Election) RecordRun(ctx context.Context, job string, started time.Time) error {
+	return e.c.store.HSet(ctx, jobsHash, job, strconv.FormatInt(started.UnixNano(), 10))
+}
@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryStore 进程内的共享状态，用于单实例和测试
+type memoryStore struct {
+	mu     sync.Mutex
+	locks  map[string]memoryLock
+	hashes map[string]map[string]string
+}
+
+type memoryLock struct {
+	token   string
+	expires time.Time
+}
+
+// NewMemoryStore 创建进程内存储，只能协调同一进程中的 Cluster
+func NewMemoryStore() Store {
+	return &memoryStore{
+		locks:  make(map[string]memoryLock),
+		hashes: make(map[string]map[string]string),
+	}
+}
+
+func (m *memoryStore) TryLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.locks[key]; ok && time.Now().Before(l.expires) {
+		return false, nil
+	}
+	m.locks[key] = memoryLock{token: token, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (m *memoryStore) Extend(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.locks[key]
+	if !ok || l.token != token || !time.Now().Before(l.expires) {
+		return false, nil
+	}
+	m.locks[key] = memoryLock{token: token, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (m *memoryStore) Unlock(ctx context.Context, key, token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.locks[key]; ok && l.token == token {
+		delete(m.locks, key)
+	}
+	return nil
+}
+
+func (m *memoryStore) HGet(ctx context.Context, hash, field string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.hashes[hash][field]
+	return v, ok, nil
+}
+
+func (m *memoryStore) HSet(ctx context.Context, hash, field, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.hashes[hash] == nil {
+		m.hashes[hash] = make(map[string]string)
+	}
+	m.hashes[hash][field] = value
+	return nil
+}
+
+func (m *memoryStore) HDel(ctx context.Context, hash, field string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.hashes[hash], field)
+	return nil
+}
+
+func (m *memoryStore) HGetAll(ctx context.Context, hash string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	all := make(map[string]string, len(m.hashes[hash]))
+	for k, v := range m.hashes[hash] {
+		all[k] = v
+	}
+	return all, nil
+}
+
+func (m *memoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,60 @@
+package cluster
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"plus/internal/log"
+)
+
+const nodesHash = "nodes" // 实例名 -> 最近一次心跳的时间（UnixNano）
+
+// Beat 记录本实例的心跳
+func (c *Cluster) Beat(ctx context.Context) error {
+	err := c.store.HSet(ctx, nodesHash, c.node, strconv.FormatInt(time.Now().UnixNano(), 10))
+	if err != nil {
+		c.setError(err)
+	}
+	return err
+}
+
+// Heartbeat 每 1/3 过期时间记录一次心跳，ctx 结束后删除心跳，本实例不再被视为运行中
+func (c *Cluster) Heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(c.ttl / 3)
+	defer ticker.Stop()
+	for {
+		opCtx, cancel := context.WithTimeout(ctx, c.ttl/3)
+		if err := c.Beat(opCtx); err != nil && ctx.Err() == nil {
+			log.Logger.Warnf("Failed to record heartbeat of cluster node %s: %v", c.node, err)
+		}
+		cancel()
+		select {
+		case <-ctx.Done():
+			stopCtx, cancel := context.WithTimeout(context.Background(), c.ttl/3)
+			if err := c.store.HDel(stopCtx, nodesHash, c.node); err != nil {
+				c.setError(err)
+			}
+			cancel()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NodeAlive 返回实例在一个过期时间内是否有心跳
+func (c *Cluster) NodeAlive(ctx context.Context, node string) (bool, error) {
+	v, ok, err := c.store.HGet(ctx, nodesHash, node)
+	if err != nil {
+		c.setError(err)
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+	ns, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return false, nil
+	}
+	return time.Since(time.Unix(0, ns)) < c.ttl, nil
+}
@@ -0,0 +1,268 @@
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RedisConfig Redis 连接参数
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+	Prefix   string        // 所有 key 的前缀，多套部署共用 Redis 时区分，默认 "plus:"
+	Timeout  time.Duration // 连接和单个命令的超时，默认 5s
+}
+
+// 只在 key 仍由 token 持有时释放或续期
+const (
+	unlockScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+	extendScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("pexpire", KEYS[1], ARGV[2]) else return 0 end`
+)
+
+// redisStore 使用 RESP 协议访问 Redis，命令在一个连接上依次执行，出错后重新连接
+type redisStore struct {
+	cfg RedisConfig
+
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+// redisError Redis 返回的错误回复
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// NewRedisStore 连接 Redis 并验证连接可用
+func NewRedisStore(cfg RedisConfig) (Store, error) {
+	if cfg.Addr == "" {
+		return nil, errors.New("redis addr is required")
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = "plus:"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	r := &redisStore{cfg: cfg}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+	if _, err := r.do(ctx, "PING"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *redisStore) key(name string) string {
+	return r.cfg.Prefix + name
+}
+
+func (r *redisStore) lockKey(name string) string {
+	return r.cfg.Prefix + "lock:" + name
+}
+
+func (r *redisStore) TryLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	reply, err := r.do(ctx, "SET", r.lockKey(key), token, "NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+	// 未设置时返回 nil
+	return reply != nil, nil
+}
+
+func (r *redisStore) Extend(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	reply, err := r.do(ctx, "EVAL", extendScript, "1", r.lockKey(key), token, strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+	n, _ := reply.(int64)
+	return n == 1, nil
+}
+
+func (r *redisStore) Unlock(ctx context.Context, key, token string) error {
+	_, err := r.do(ctx, "EVAL", unlockScript, "1", r.lockKey(key), token)
+	return err
+}
+
+func (r *redisStore) HGet(ctx context.Context, hash, field string) (string, bool, error) {
+	reply, err := r.do(ctx, "HGET", r.key(hash), field)
+	if err != nil || reply == nil {
+		return "", false, err
+	}
+	s, ok := reply.(string)
+	if !ok {
+		return "", false, fmt.Errorf("redis: unexpected HGET reply %T", reply)
+	}
+	return s, true, nil
+}
+
+func (r *redisStore) HSet(ctx context.Context, hash, field, value string) error {
+	_, err := r.do(ctx, "HSET", r.key(hash), field, value)
+	return err
+}
+
+func (r *redisStore) HDel(ctx context.Context, hash, field string) error {
+	_, err := r.do(ctx, "HDEL", r.key(hash), field)
+	return err
+}
+
+func (r *redisStore) HGetAll(ctx context.Context, hash string) (map[string]string, error) {
+	reply, err := r.do(ctx, "HGETALL", r.key(hash))
+	if err != nil {
+		return nil, err
+	}
+	items, ok := reply.([]interface{})
+	if !ok || len(items)%2 != 0 {
+		return nil, fmt.Errorf("redis: unexpected HGETALL reply %T", reply)
+	}
+	all := make(map[string]string, len(items)/2)
+	for i := 0; i < len(items); i += 2 {
+		k, _ := items[i].(string)
+		v, _ := items[i+1].(string)
+		all[k] = v
+	}
+	return all, nil
+}
+
+func (r *redisStore) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	return err
+}
+
+// do 执行一条命令。回复为 nil（空值）、string、int64 或 []interface{}
+func (r *redisStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil {
+		if err := r.connect(ctx); err != nil {
+			return nil, err
+		}
+	}
+	deadline := time.Now().Add(r.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	r.conn.SetDeadline(deadline)
+
+	reply, err := r.roundTrip(args)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// 连接状态未知，下一条命令重新连接
+		r.conn.Close()
+		r.conn = nil
+	}
+	return reply, err
+}
+
+func (r *redisStore) connect(ctx context.Context) error {
+	d := net.Dialer{Timeout: r.cfg.Timeout}
+	conn, err := d.DialContext(ctx, "tcp", r.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("connect to redis %s: %w", r.cfg.Addr, err)
+	}
+	r.conn = conn
+	r.rd = bufio.NewReader(conn)
+	conn.SetDeadline(time.Now().Add(r.cfg.Timeout))
+
+	if r.cfg.Password != "" {
+		if _, err := r.roundTrip([]string{"AUTH", r.cfg.Password}); err != nil {
+			conn.Close()
+			r.conn = nil
+			return err
+		}
+	}
+	if r.cfg.DB != 0 {
+		if _, err := r.roundTrip([]string{"SELECT", strconv.Itoa(r.cfg.DB)}); err != nil {
+			conn.Close()
+			r.conn = nil
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *redisStore) roundTrip(args []string) (interface{}, error) {
+	if _, err := r.conn.Write(encodeCommand(args)); err != nil {
+		return nil, err
+	}
+	return readReply(r.rd)
+}
+
+// encodeCommand 按 RESP 数组编码命令
+func encodeCommand(args []string) []byte {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	return buf
+}
+
+func readReply(rd *bufio.Reader) (interface{}, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	payload := line[1 : len(line)-2]
+	switch line[0] {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, redisError(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", payload)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(rd, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", payload)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = readReply(rd); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+}
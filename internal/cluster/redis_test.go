@@ -0,0 +1,149 @@
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRedis 实现测试用到的 Redis 命令子集，锁不过期
+func fakeRedis(t *testing.T, password string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	strs := make(map[string]string)
+	hashes := make(map[string]map[string]string)
+	bulk := func(s string) string { return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n" }
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				rd := bufio.NewReader(conn)
+				authed := password == ""
+				for {
+					reply, err := readReply(rd)
+					if err != nil {
+						return
+					}
+					args := make([]string, 0)
+					for _, a := range reply.([]interface{}) {
+						args = append(args, a.(string))
+					}
+					var out string
+					switch {
+					case args[0] == "AUTH":
+						authed = args[1] == password
+						out = "+OK\r\n"
+						if !authed {
+							out = "-WRONGPASS invalid password\r\n"
+						}
+					case !authed:
+						out = "-NOAUTH Authentication required.\r\n"
+					case args[0] == "PING":
+						out = "+PONG\r\n"
+					case args[0] == "SET":
+						if _, ok := strs[args[1]]; ok {
+							out = "$-1\r\n"
+						} else {
+							strs[args[1]] = args[2]
+							out = "+OK\r\n"
+						}
+					case args[0] == "EVAL":
+						out = ":0\r\n"
+						if strs[args[3]] == args[4] {
+							if strings.Contains(args[1], "del") {
+								delete(strs, args[3])
+							}
+							out = ":1\r\n"
+						}
+					case args[0] == "HSET":
+						if hashes[args[1]] == nil {
+							hashes[args[1]] = make(map[string]string)
+						}
+						hashes[args[1]][args[2]] = args[3]
+						out = ":1\r\n"
+					case args[0] == "HDEL":
+						delete(hashes[args[1]], args[2])
+						out = ":1\r\n"
+					case args[0] == "HGET":
+						out = "$-1\r\n"
+						if v, ok := hashes[args[1]][args[2]]; ok {
+							out = bulk(v)
+						}
+					case args[0] == "HGETALL":
+						out = "*" + strconv.Itoa(2*len(hashes[args[1]])) + "\r\n"
+						for k, v := range hashes[args[1]] {
+							out += bulk(k) + bulk(v)
+						}
+					default:
+						out = "-ERR unknown command\r\n"
+					}
+					conn.Write([]byte(out))
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestRedisStore(t *testing.T) {
+	addr := fakeRedis(t, "secret")
+	if _, err := NewRedisStore(RedisConfig{Addr: addr, Password: "wrong"}); err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+		t.Fatalf("wrong password: %v", err)
+	}
+	store, err := NewRedisStore(RedisConfig{Addr: addr, Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	if ok, err := store.TryLock(ctx, "refresh/el9", "a", time.Second); !ok || err != nil {
+		t.Fatalf("TryLock = %v, %v", ok, err)
+	}
+	if ok, _ := store.TryLock(ctx, "refresh/el9", "b", time.Second); ok {
+		t.Error("lock acquired twice")
+	}
+	if ok, _ := store.Extend(ctx, "refresh/el9", "b", time.Second); ok {
+		t.Error("extended another holder's lock")
+	}
+	store.Unlock(ctx, "refresh/el9", "b")
+	if ok, _ := store.Extend(ctx, "refresh/el9", "a", time.Second); !ok {
+		t.Error("lock released by another holder")
+	}
+	store.Unlock(ctx, "refresh/el9", "a")
+	if ok, _ := store.TryLock(ctx, "refresh/el9", "b", time.Second); !ok {
+		t.Error("lock not released")
+	}
+
+	store.HSet(ctx, reposHash, "el9", "rpm")
+	store.HSet(ctx, reposHash, "jammy", "deb")
+	store.HDel(ctx, reposHash, "jammy")
+	if v, ok, err := store.HGet(ctx, reposHash, "el9"); v != "rpm" || !ok || err != nil {
+		t.Errorf("HGet = %q, %v, %v", v, ok, err)
+	}
+	if _, ok, _ := store.HGet(ctx, reposHash, "jammy"); ok {
+		t.Error("deleted field returned")
+	}
+	if all, err := store.HGetAll(ctx, reposHash); err != nil || len(all) != 1 || all["el9"] != "rpm" {
+		t.Errorf("HGetAll = %v, %v", all, err)
+	}
+
+	// 连接断开后重新连接
+	store.(*redisStore).conn.Close()
+	store.HGet(ctx, reposHash, "el9")
+	if _, ok, err := store.HGet(ctx, reposHash, "el9"); !ok || err != nil {
+		t.Errorf("after reconnect: %v, %v", ok, err)
+	}
+}
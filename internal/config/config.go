@@ -1,23 +1,50 @@
 package config
 
-import (
-	"io/ioutil"
-
-	"gopkg.in/yaml.v2"
-)
-
 type Config struct {
-	Listen       string                `yaml:"listen"`
-	StoragePath  string                `yaml:"storage-path"`
-	DatabasePath string                `yaml:"database-path"`
-	Auth         AuthConfig            `yaml:"auth"`
-	Cache        CacheConfig           `yaml:"cache"`
-	Repositories map[string]RepoConfig `yaml:"repositories"`
-	Limits       LimitsConfig          `yaml:"limits"`
-	Storage      StorageConfig         `yaml:"storage"`
-	DevMode      bool                  `yaml:"dev-mode"`
-	Log          string                `yaml:"log"`
-	LogLevel     string                `yaml:"log-level"`
+	Listen        string                `yaml:"listen"`
+	Listeners     []ListenerConfig      `yaml:"listeners"` // 多个监听地址，配置后忽略 listen
+	StoragePath   string                `yaml:"storage-path"`
+	DatabasePath  string                `yaml:"database-path"`
+	Auth          AuthConfig            `yaml:"auth"`
+	Cache         CacheConfig           `yaml:"cache"`
+	Repositories  map[string]RepoConfig `yaml:"repositories"`
+	Limits        LimitsConfig          `yaml:"limits"`
+	Storage       StorageConfig         `yaml:"storage"`
+	DevMode       bool                  `yaml:"dev-mode"`
+	Log           string                `yaml:"log"`
+	LogLevel      string                `yaml:"log-level"`
+	Signing       SigningConfig         `yaml:"signing"`
+	CDN           CDNConfig             `yaml:"cdn"`
+	Index         IndexConfig           `yaml:"index"`
+	Stats         StatsConfig           `yaml:"stats"`
+	Shutdown      ShutdownConfig        `yaml:"shutdown"`
+	Cluster       ClusterConfig         `yaml:"cluster"`
+	Access        AccessConfig          `yaml:"access"`
+	Metalink      MetalinkConfig        `yaml:"metalink"`
+	Thumbnails    ThumbnailsConfig      `yaml:"thumbnails"`
+	Upload        UploadConfig          `yaml:"upload"`
+	Paths         PathsConfig           `yaml:"paths"`
+	Alerts        AlertsConfig          `yaml:"alerts"`
+	Notifications NotificationsConfig   `yaml:"notifications"`
+	Events        EventsConfig          `yaml:"events"`
+	Migration     MigrationConfig       `yaml:"migration"`
+	Attestations  AttestationsConfig    `yaml:"attestations"`
+	Branding      BrandingConfig        `yaml:"branding"`
+	// 插件（go build -buildmode=plugin 编译的 .so 文件或其所在目录），注册新的仓库类型和存储类型
+	Plugins []string `yaml:"plugins"`
+	// 请求中间件的顺序和钩子，可以按路由类别分别配置
+	Middleware MiddlewareConfig `yaml:"middleware"`
+	// 功能开关，按名称配置，运行时可以通过 /api/v1/features 修改
+	Features map[string]FeatureConfig `yaml:"features"`
+	// 存储和数据库目录的布局不是当前版本时拒绝启动，而不是自动升级
+	NoMigrate bool `yaml:"no-migrate"`
+
+	// 可信反向代理的 IP、CIDR 或 "unix"（unix socket 上的连接），来自这些地址的请求按 X-Forwarded-For/Proto/Host 确定客户端 IP 和对外地址
+	TrustedProxies []string `yaml:"trusted-proxies"`
+	// 对外地址，如 https://repo.example.com/plus，生成的绝对地址和页面链接使用该地址
+	ExternalURL string `yaml:"external-url"`
+	// 扩展名到 Content-Type 的映射，如 .qcow2: application/x-qemu-disk，覆盖或补充内置的类型
+	MimeTypes map[string]string `yaml:"mime-types"`
 }
 
 type AuthConfig struct {
@@ -25,43 +52,560 @@ type AuthConfig struct {
 	Token           string `yaml:"token"`
 	APIKey          string `yaml:"api-key"`
 	RequireReadAuth bool   `yaml:"require-read-auth"`
+	// 按仓库模式声明每类接口允许的角色，按顺序匹配
+	Policy []PolicyRule `yaml:"policy"`
+	// 写操作前调用的外部授权服务
+	Webhook AuthzWebhookConfig `yaml:"webhook"`
+}
+
+// AuthzWebhookConfig 外部授权 webhook，请求体与 OPA 的 Data API 兼容。
+// 创建仓库、上传、刷新和删除前都会调用，webhook 拒绝时返回 403
+type AuthzWebhookConfig struct {
+	URL      string            `yaml:"url"`       // 如 http://opa:8181/v1/data/plus/authz
+	Headers  map[string]string `yaml:"headers"`   // 如 Authorization
+	Timeout  string            `yaml:"timeout"`   // 默认 5s
+	FailOpen bool              `yaml:"fail-open"` // webhook 不可用时允许写操作，默认拒绝
+	// 校验上传的 RPM 签名的公钥文件（armored 或二进制），只有通过校验的包 signed 为 true
+	TrustedKeys []string `yaml:"trusted-keys"`
+}
+
+// PolicyRule 一条访问策略规则。未配置的类别交给后续规则，空列表表示不允许任何人。
+// 除用户库中的角色外，可以使用 anonymous、authenticated 和 upload-token
+type PolicyRule struct {
+	Repos    string   `yaml:"repos"`
+	Download []string `yaml:"download"`
+	Upload   []string `yaml:"upload"`
+	Refresh  []string `yaml:"refresh"`
+	Delete   []string `yaml:"delete"`
+	Admin    []string `yaml:"admin"`
+}
+
+// AccessConfig 用户、用户组、角色和仓库权限，保存在 database-path 下，通过 /api/v1 管理。
+// auth.enabled 时按仓库检查权限，auth.token 仍拥有全部权限
+type AccessConfig struct {
+	Enabled           bool       `yaml:"enabled"`
+	SessionTTL        string     `yaml:"session-ttl"`          // 会话有效期，默认 12h
+	SessionSecret     string     `yaml:"session-secret"`       // 会话签名密钥，多个实例共用时配置；默认在用户库中生成
+	UploadTokenMaxTTL string     `yaml:"upload-token-max-ttl"` // 上传令牌的最长有效期，默认 24h
+	LDAP              LDAPConfig `yaml:"ldap"`
+}
+
+// LDAPConfig 定期从 LDAP 目录同步用户和用户组，url 为空时不同步
+type LDAPConfig struct {
+	URL                string `yaml:"url"` // ldap://host:389 或 ldaps://host:636
+	BindDN             string `yaml:"bind-dn"`
+	BindPassword       string `yaml:"bind-password"`
+	BaseDN             string `yaml:"base-dn"`
+	Filter             string `yaml:"filter"`        // 默认 (objectClass=person)
+	UserAttr           string `yaml:"user-attr"`     // 默认 uid
+	EmailAttr          string `yaml:"email-attr"`    // 默认 mail
+	GroupAttr          string `yaml:"group-attr"`    // 默认 memberOf
+	Timeout            string `yaml:"timeout"`       // 默认 30s
+	SyncInterval       string `yaml:"sync-interval"` // 默认 1h
+	InsecureSkipVerify bool   `yaml:"insecure-skip-verify"`
+}
+
+// MiddlewareConfig 请求中间件。内置中间件为 cors、logging、metrics、auth 和 ratelimit，
+// 也可以使用插件注册的中间件；pre 和 post 为插件注册的钩子，在处理请求之前和之后按顺序调用
+type MiddlewareConfig struct {
+	Chain  []string                   `yaml:"chain"` // 靠前的在外层，默认 cors、logging、metrics
+	Pre    []string                   `yaml:"pre"`
+	Post   []string                   `yaml:"post"`
+	Routes map[string]MiddlewareRoute `yaml:"routes"` // 按路由类别（api、download、metadata、upload）覆盖
+}
+
+// MiddlewareRoute 一类路由的中间件，没有配置的字段沿用全局设置，[] 表示不使用
+type MiddlewareRoute struct {
+	Chain []string `yaml:"chain"`
+	Pre   []string `yaml:"pre"`
+	Post  []string `yaml:"post"`
+}
+
+// FeatureConfig 功能开关，用于逐步上线新的子系统，出问题时不用重新部署就能关闭
+type FeatureConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Rollout int  `yaml:"rollout"` // 开启的百分比（1-100），按仓库等键的哈希选择，0 表示全部
+}
+
+// BrandingConfig 服务生成的页面（/、/repo/ 和目录列表）的标题、标志、颜色和页脚，不影响 /static/ 下的 Web UI
+type BrandingConfig struct {
+	Title      string         `yaml:"title"`      // 替换 Plus Artifacts Server，其他页面的标题后加上该名称
+	Logo       string         `yaml:"logo"`       // 标志图片的地址，以 / 开头或 http(s) URL，如 /static/logo.png
+	Footer     string         `yaml:"footer"`     // 页脚的 HTML，替换 Generated by Plus Artifacts Server
+	Stylesheet string         `yaml:"stylesheet"` // 在内置样式之后加载的样式表地址，如 /static/brand.css
+	Colors     BrandingColors `yaml:"colors"`
+	StaticDir  string         `yaml:"static-dir"` // 其中的文件覆盖 /static/ 下的同名文件，用于标志、样式表和 Web UI 的文件
+}
+
+// BrandingColors 页面颜色，十六进制（#0b5cad）、rgb()/hsl() 或颜色名
+type BrandingColors struct {
+	Primary    string `yaml:"primary"` // 链接、标题栏和标题下划线
+	Background string `yaml:"background"`
+	Text       string `yaml:"text"`
+}
+
+// AttestationsConfig 制品的来源证明（DSSE 封装的 in-toto 声明，如 SLSA provenance），上传时用 keys 中的公钥验证签名
+type AttestationsConfig struct {
+	Keys            []AttestationKeyConfig `yaml:"keys"`
+	RequireVerified bool                   `yaml:"require-verified"` // 拒绝没有有效签名的证明，默认保存并标记为未验证
+}
+
+// AttestationKeyConfig 验证来源证明签名的公钥
+type AttestationKeyConfig struct {
+	Name      string `yaml:"name"`       // 与签名的 keyid 相同时优先使用
+	PublicKey string `yaml:"public-key"` // PEM 格式的 ECDSA、Ed25519 或 RSA 公钥文件，如 cosign.pub
+}
+
+// IndexConfig 制品索引，保存在 database-path 下，仓库列表、包列表和搜索从索引读取
+type IndexConfig struct {
+	Enabled           bool   `yaml:"enabled"`
+	ReconcileInterval string `yaml:"reconcile-interval"` // 后台全量扫描的间隔，默认 10m，0 表示只在启动时扫描
+}
+
+// AlertsConfig 异常告警，配置了阈值的规则才检查，触发和恢复时通过 webhook 和邮件通知
+type AlertsConfig struct {
+	Enabled   bool               `yaml:"enabled"`
+	Interval  string             `yaml:"interval"` // 检查间隔，默认 1m
+	ErrorRate ErrorRateAlert     `yaml:"error-rate"`
+	Jobs      []string           `yaml:"jobs"` // 失败时告警的定时任务，支持通配符，如 "repo-refresh/*"
+	Storage   StorageAlert       `yaml:"storage"`
+	Webhook   AlertWebhookConfig `yaml:"webhook"`
+	Email     AlertEmailConfig   `yaml:"email"`
+}
+
+// ErrorRateAlert 5xx 响应占比持续超过阈值时告警
+type ErrorRateAlert struct {
+	Threshold   float64 `yaml:"threshold"`    // 如 0.05 表示 5%，0 表示不检查
+	For         string  `yaml:"for"`          // 持续多久后告警，默认 5m
+	MinRequests int64   `yaml:"min-requests"` // 一个检查间隔内请求数少于该值时不计算，默认 20
+}
+
+// StorageAlert 存储用量超过配额的一定比例时告警
+type StorageAlert struct {
+	Threshold float64 `yaml:"threshold"` // 如 0.9 表示 90%，0 表示不检查
+	// 配额，如 "500GB"，用量为索引中制品的总大小；为空时按 storage-path 所在文件系统的容量和已用空间
+	Quota string `yaml:"quota"`
+}
+
+// AlertWebhookConfig 告警通知的 webhook，请求体为 JSON
+type AlertWebhookConfig struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	Timeout string            `yaml:"timeout"` // 默认 10s
+}
+
+// AlertEmailConfig 通过 SMTP 发送告警邮件
+type AlertEmailConfig struct {
+	SMTP     string   `yaml:"smtp"` // host:port
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// NotificationsConfig 按事件发送邮件通知
+type NotificationsConfig struct {
+	Enabled   bool                     `yaml:"enabled"`
+	SMTP      SMTPConfig               `yaml:"smtp"`
+	Routes    []NotificationRoute      `yaml:"routes"`    // 每个事件发给所有匹配的路由的收件人
+	Templates map[string]EmailTemplate `yaml:"templates"` // 按事件覆盖默认的主题和正文（text/template）
+}
+
+// SMTPConfig 发送邮件的 SMTP 服务器，服务器支持时使用 STARTTLS
+type SMTPConfig struct {
+	Addr     string `yaml:"addr"` // host:port
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+// NotificationRoute 把事件发给一组收件人
+type NotificationRoute struct {
+	Events []string `yaml:"events"` // 事件名称，"*" 表示全部
+	Repos  []string `yaml:"repos"`  // 仓库模式，为空时匹配全部仓库
+	To     []string `yaml:"to"`
+}
+
+// EmailTemplate 邮件的主题和正文模板
+type EmailTemplate struct {
+	Subject string `yaml:"subject"`
+	Body    string `yaml:"body"`
+}
+
+// StatsConfig 累计计数器保存在 database-path 下，重启后继续累计
+type StatsConfig struct {
+	PersistInterval string `yaml:"persist-interval"` // 保存间隔，默认 1m，0 表示不保存
+	UsageRetention  int    `yaml:"usage-retention"`  // 按用户统计的每日用量保留天数，默认 400
+}
+
+// EventsConfig 仓库事件日志和投递队列，保存在 database-path 下
+type EventsConfig struct {
+	Retention        int                `yaml:"retention"`         // 保留天数，默认 90
+	DeliveryAttempts int                `yaml:"delivery-attempts"` // 每个目标最多尝试的次数，之后转为死信，默认 10
+	Publish          EventPublishConfig `yaml:"publish"`
+}
+
+// EventPublishConfig 把事件发布到 Kafka 主题或 NATS subject
+type EventPublishConfig struct {
+	Kind     string   `yaml:"kind"`     // kafka 或 nats，为空时不发布
+	Brokers  []string `yaml:"brokers"`  // host:port
+	Topic    string   `yaml:"topic"`    // Kafka 主题或 NATS subject 前缀，默认 "plus.events"
+	Username string   `yaml:"username"` // Kafka SASL/PLAIN 或 NATS 用户
+	Password string   `yaml:"password"`
+	Token    string   `yaml:"token"` // NATS 令牌
+	TLS      bool     `yaml:"tls"`
+	Timeout  string   `yaml:"timeout"` // 默认 10s
+}
+
+// MigrationConfig plus migrate 使用的远程仓库服务
+type MigrationConfig struct {
+	Sources []MigrationSourceConfig `yaml:"sources"`
+}
+
+// MigrationSourceConfig 一个 Nexus、Artifactory 或 Pulp 服务
+type MigrationSourceConfig struct {
+	Name     string `yaml:"name"`     // plus migrate --source 使用的名称
+	Kind     string `yaml:"kind"`     // nexus、artifactory 或 pulp
+	URL      string `yaml:"url"`      // 如 https://nexus.example.com、https://example.com/artifactory
+	Username string `yaml:"username"` // HTTP Basic 认证
+	Password string `yaml:"password"`
+	Token    string `yaml:"token"`   // Bearer 令牌（Artifactory 访问令牌），与 username 二选一
+	Timeout  string `yaml:"timeout"` // 每个请求的超时，默认 10m
+}
+
+// ListenerConfig 一个监听地址。address 为 ":8080"、"0.0.0.0:8080"、"[::]:8080"、unix socket 路径（"unix:/run/plus.sock"），
+// 或 systemd 传入的 socket（"systemd" 为全部，"systemd:<FileDescriptorName>" 为指定名称）
+type ListenerConfig struct {
+	Address string            `yaml:"address"`
+	Mode    string            `yaml:"mode"` // unix socket 文件的权限，如 "0660"
+	TLS     ListenerTLSConfig `yaml:"tls"`
+}
+
+// ListenerTLSConfig 监听地址的 TLS 设置，配置 cert-file 后启用
+type ListenerTLSConfig struct {
+	CertFile   string `yaml:"cert-file"`
+	KeyFile    string `yaml:"key-file"`
+	ClientCA   string `yaml:"client-ca"`   // 配置后要求客户端出示由该 CA 签发的证书
+	MinVersion string `yaml:"min-version"` // "1.2" 或 "1.3"，默认 1.2
+}
+
+// UploadConfig 上传请求体的处理。请求体在处理请求时流式读取，multipart 表单中
+// 超过 spool-threshold 的部分写入 spool-dir，不在内存中保存整个请求体
+type UploadConfig struct {
+	MaxBodySize    string `yaml:"max-body-size"`   // 请求体的最大长度，默认 8GB
+	SpoolDir       string `yaml:"spool-dir"`       // 默认系统临时目录
+	SpoolThreshold string `yaml:"spool-threshold"` // 每个请求在内存中保存的表单内容上限，默认 1MB
+	// 上传时用 X-Expire-After 或 expire_after 指定的有效期上限，如 30d，默认不限制
+	MaxExpireAfter string `yaml:"max-expire-after"`
+	ExpiryInterval string `yaml:"expiry-interval"` // 检查并删除到期制品的间隔，默认 1m
+}
+
+// PathsConfig 上传和查找时规范化文件名，避免仅大小写或 Unicode 形式不同的重复文件
+type PathsConfig struct {
+	Normalize bool `yaml:"normalize"` // 转换为 Unicode NFC
+	Lowercase bool `yaml:"lowercase"` // 同时转为小写，包含 normalize
 }
 
+// ShutdownConfig 收到 SIGINT/SIGTERM 后先排空（/ready 返回 503）再停止服务
+type ShutdownConfig struct {
+	DrainDelay string `yaml:"drain-delay"` // 排空后等待负载均衡器摘除实例的时间，默认 0
+	Timeout    string `yaml:"timeout"`     // 停止接受连接后等待处理中请求的最长时间，默认 30s
+}
+
+// ClusterConfig 多个实例共用同一存储时，通过 Redis 共享仓库注册表和元数据刷新锁
+type ClusterConfig struct {
+	Enabled bool        `yaml:"enabled"`
+	Node    string      `yaml:"node"`     // 本实例名称，默认主机名
+	LockTTL string      `yaml:"lock-ttl"` // 实例故障后锁自动释放的时间，默认 30s
+	Redis   RedisConfig `yaml:"redis"`
+}
+
+type RedisConfig struct {
+	Addr     string `yaml:"addr"` // host:port
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+	Prefix   string `yaml:"prefix"`  // key 前缀，默认 "plus:"
+	Timeout  string `yaml:"timeout"` // 默认 5s
+}
+
+// CacheConfig 热点元数据的内存缓存
 type CacheConfig struct {
 	Enabled bool   `yaml:"enabled"`
-	TTL     string `yaml:"ttl"`
-	MaxSize int    `yaml:"max-size"`
+	TTL     string `yaml:"ttl"`      // 兜底过期时间，默认 5m
+	MaxSize int    `yaml:"max-size"` // 内存上限（MB），默认 64
 }
 
 type RepoConfig struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
-	Type        string `yaml:"type"` // rpm, deb
-	Enabled     bool   `yaml:"enabled"`
-	AutoRefresh bool   `yaml:"auto-refresh"`
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
+	Type        string          `yaml:"type"` // rpm, deb
+	Enabled     bool            `yaml:"enabled"`
+	AutoRefresh bool            `yaml:"auto-refresh"`
+	Staging     bool            `yaml:"staging"`     // 上传先进入暂存区，POST /repo/{repo}/publish 后才可见
+	Protected   bool            `yaml:"protected"`   // 删除和覆盖已有文件需要另一位管理员批准
+	GPGKey      string          `yaml:"gpg-key"`     // 签名公钥名称，为空时使用默认公钥
+	Generations int             `yaml:"generations"` // 保留的元数据历史版本数，0 表示不保留
+	Overwrite   string          `yaml:"overwrite"`   // 上传已有文件名时：overwrite（默认，替换）、reject（拒绝）或 auto-version（加构建号另存）
+	Filenames   FilenamesConfig `yaml:"filenames"`   // 上传文件名的规则
+	Licenses    LicensesConfig  `yaml:"licenses"`    // RPM、DEB 包的许可证规则
+	Release     ReleaseConfig   `yaml:"release"`     // DEB 仓库 Release 文件的字段
+	SHA256Sums  bool            `yaml:"sha256sums"`  // files 仓库在每个目录中维护 SHA256SUMS，密钥配置了私钥时同时生成 SHA256SUMS.asc
+	Website     WebsiteConfig   `yaml:"website"`     // files 仓库作为静态网站服务
+	Inline      bool            `yaml:"inline"`      // files 仓库中可以预览的文件（文本、图片、PDF）默认在浏览器中打开，?inline=false 时仍下载
+	Upstream    UpstreamConfig  `yaml:"upstream"`    // 代理/镜像的上游仓库，url 非空时为代理仓库
+	Jobs        []RepoJobConfig `yaml:"jobs"`        // 仓库的定时任务
+}
+
+// FilenamesConfig 上传的文件名（不含目录）必须匹配 allow 中的一个正则表达式，且不能匹配 deny 中的任何一个
+type FilenamesConfig struct {
+	Allow   []string `yaml:"allow"` // 为空时接受 deny 以外的所有文件名
+	Deny    []string `yaml:"deny"`
+	Message string   `yaml:"message"` // 拒绝上传时返回的说明
+}
+
+// LicensesConfig 许可证（RPM 包头的 LICENSE、DEB control 的 License 字段）中有一项匹配 deny 中的模式时拒绝上传。
+// 模式不区分大小写，可以使用 * 通配符，如 AGPL*
+type LicensesConfig struct {
+	Deny    []string `yaml:"deny"`
+	Message string   `yaml:"message"` // 拒绝上传时返回的说明
+}
+
+// WebsiteConfig 静态网站模式：目录返回其中的首页，文件按扩展名设置 Content-Type 在浏览器中打开，找不到的路径返回 404 页面
+type WebsiteConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Index    string `yaml:"index"`     // 目录的首页，默认 index.html
+	NotFound string `yaml:"not-found"` // 找不到文件时返回的页面，相对仓库根目录，如 404.html；为空时返回默认的错误响应
+}
+
+// ReleaseConfig DEB 仓库刷新元数据时写入 Release 的字段，apt pinning 按这些字段匹配仓库，为空的字段不写入
+type ReleaseConfig struct {
+	Origin     string `yaml:"origin"`
+	Label      string `yaml:"label"`
+	Suite      string `yaml:"suite"`
+	Codename   string `yaml:"codename"`
+	ValidUntil string `yaml:"valid-until"` // Release 的有效期（如 168h），过期后 apt 拒绝使用，需要在此之前刷新元数据
+}
+
+// RepoJobConfig 仓库定时任务，在集群的领导者上执行，状态见仓库信息和 /api/v1/jobs
+type RepoJobConfig struct {
+	Action   string `yaml:"action"`   // refresh（重新生成元数据）或 retention（删除旧版本后刷新）
+	Schedule string `yaml:"schedule"` // cron 表达式（如 "0 3 * * *"）、@daily/@weekly/@monthly 或间隔（如 12h）
+	Keep     int    `yaml:"keep"`     // retention：每个包（按 name/arch）保留的最新版本数，默认 3
+}
+
+type UpstreamConfig struct {
+	URL            string               `yaml:"url"`
+	Weight         int                  `yaml:"weight"`       // url 的权重（weighted 策略），默认 1
+	Mirrors        []MirrorConfig       `yaml:"mirrors"`      // 其他上游
+	Strategy       string               `yaml:"strategy"`     // failover（默认，按顺序故障转移）或 weighted（按权重分流）
+	Distribution   string               `yaml:"distribution"` // DEB 上游的发行版（dists/{distribution}），为空时为扁平仓库
+	GPGKeys        []string             `yaml:"gpg-keys"`     // 固定的上游签名公钥文件
+	SkipVerify     bool                 `yaml:"skip-verify"`  // 跳过上游签名校验（不推荐）
+	Timeout        string               `yaml:"timeout"`
+	SyncInterval   string               `yaml:"sync-interval"` // 定期同步上游元数据的间隔，为空或 0 时只在请求时同步
+	HealthCheck    HealthCheckConfig    `yaml:"health-check"`
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit-breaker"`
+}
+
+type MirrorConfig struct {
+	URL    string `yaml:"url"`
+	Weight int    `yaml:"weight"`
+}
+
+type CircuitBreakerConfig struct {
+	Failures int    `yaml:"failures"` // 连续失败多少次后熔断，默认 3
+	Cooldown string `yaml:"cooldown"` // 熔断持续时间，之后放行试探请求，默认 30s
+}
+
+type HealthCheckConfig struct {
+	Interval   string `yaml:"interval"`    // 检查间隔，默认 5m，0 表示关闭
+	MaxAge     string `yaml:"max-age"`     // 上游元数据超过该时长视为过期，为空时不检查
+	SpotChecks int    `yaml:"spot-checks"` // 每次随机抽查并校验的包数
 }
 
 type LimitsConfig struct {
-	MaxFileSize          int64 `yaml:"max-file-size"` // bytes
-	MaxConcurrentUploads int   `yaml:"max-concurrent-uploads"`
-	RateLimit            int   `yaml:"rate-limit"` // requests per minute
+	MaxFileSize          int64           `yaml:"max-file-size"` // bytes
+	MaxConcurrentUploads int             `yaml:"max-concurrent-uploads"`
+	MaxConnsPerIP        int             `yaml:"max-conns-per-ip"`     // 单个客户端 IP 的最大并发连接数，0 表示不限制
+	MaxDownloadsPerIP    int             `yaml:"max-downloads-per-ip"` // 单个客户端 IP 的最大并发下载数，0 表示不限制
+	RateLimit            int             `yaml:"rate-limit"`           // requests per minute
+	Bandwidth            BandwidthConfig `yaml:"bandwidth"`
+	Workers              int             `yaml:"workers"`      // 上传后台任务（校验和、元数据生成）的并发数，默认 CPU 数
+	WorkerQueue          int             `yaml:"worker-queue"` // 后台任务队列长度，默认 256
+}
+
+// BandwidthConfig 带宽限制，速率形如 "10MB"（每秒，单位 B/KB/MB/GB），为空表示不限制
+type BandwidthConfig struct {
+	Download  string                    `yaml:"download"`   // 全局下行
+	Upload    string                    `yaml:"upload"`     // 全局上行
+	PerClient BandwidthLimit            `yaml:"per-client"` // 每个客户端（未识别令牌时按 IP）的默认限制
+	Repos     map[string]BandwidthLimit `yaml:"repos"`      // 按仓库限制
+	Tokens    []TokenBandwidth          `yaml:"tokens"`     // 按令牌（Bearer 或 X-API-Key）限制
+}
+
+type BandwidthLimit struct {
+	Download string `yaml:"download"`
+	Upload   string `yaml:"upload"`
+}
+
+type TokenBandwidth struct {
+	Name     string `yaml:"name"` // 指标中显示的名称
+	Token    string `yaml:"token"`
+	Download string `yaml:"download"` // 为空时使用 per-client
+	Upload   string `yaml:"upload"`
+}
+
+// CDNConfig 为前置 CDN 输出缓存头和 Surrogate-Key，并在元数据变化时通知清除缓存
+type CDNConfig struct {
+	Enabled              bool        `yaml:"enabled"`
+	MetadataCacheControl string      `yaml:"metadata-cache-control"` // 默认 "public, max-age=60, s-maxage=300"
+	PackageCacheControl  string      `yaml:"package-cache-control"`  // 默认 "public, max-age=86400, s-maxage=31536000"
+	KeyPrefix            string      `yaml:"key-prefix"`             // Surrogate-Key 前缀，多个实例共用 CDN 时区分
+	Purge                PurgeConfig `yaml:"purge"`
+}
+
+// MetalinkConfig 为大文件提供 metalink（{file}.meta4）和 zsync（{file}.zsync）文件，
+// 下载工具可以从多个地址并行下载、按块校验并断点续传
+type MetalinkConfig struct {
+	Enabled   bool          `yaml:"enabled"`
+	MinSize   string        `yaml:"min-size"`   // 小于该大小的文件不提供，默认 10MB
+	PieceSize string        `yaml:"piece-size"` // 分块校验的块大小，默认 4MB
+	Mirrors   []string      `yaml:"mirrors"`    // 与本服务路径相同的镜像地址，如 https://mirror.example.com/plus
+	Zsync     bool          `yaml:"zsync"`      // 同时提供 .zsync
+	Torrent   TorrentConfig `yaml:"torrent"`
+	CacheSize int           `yaml:"cache-size"` // 生成结果的内存缓存上限（MB），默认 64
+}
+
+// ThumbnailsConfig 为 files 仓库中的 PNG、JPEG 和 GIF 图片生成缩略图，通过 /repo/{repo}/thumb/{path} 访问，
+// 目录页面和 JSON 列表中带有缩略图
+type ThumbnailsConfig struct {
+	Enabled   bool `yaml:"enabled"`
+	Size      int  `yaml:"size"`       // 缩略图长边的默认像素数，默认 256，可用 ?size= 指定
+	CacheSize int  `yaml:"cache-size"` // 生成结果的内存缓存上限（MB），默认 64
+}
+
+// TorrentConfig 为特别大的文件（如 ISO）提供 {file}.torrent，本服务和镜像作为 web seed，
+// 大量机器同时下载时可以互相分发
+type TorrentConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	MinSize  string   `yaml:"min-size"` // 小于该大小的文件不提供，默认 1GB
+	Trackers []string `yaml:"trackers"` // tracker 地址，为空时客户端只能通过 web seed 和 DHT 找到数据
+}
+
+// PurgeConfig 清除缓存的 webhook，请求头 Surrogate-Key 和 JSON 请求体中带有要清除的 key
+type PurgeConfig struct {
+	URL     string            `yaml:"url"`
+	Method  string            `yaml:"method"`  // 默认 POST
+	Headers map[string]string `yaml:"headers"` // 如 Fastly-Key
+	Timeout string            `yaml:"timeout"` // 默认 10s
 }
 
 type StorageConfig struct {
-	Type   string            `yaml:"type"` // local, s3
-	Config map[string]string `yaml:"config"`
+	Type      string            `yaml:"type"` // local, s3
+	Config    map[string]string `yaml:"config"`
+	Redirect  RedirectConfig    `yaml:"redirect"`
+	ReadCache ReadCacheConfig   `yaml:"read-cache"`
+	Retry     RetryConfig       `yaml:"retry"`
+	// 对象存储连续失败后熔断，期间返回 503，默认启用
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit-breaker"`
+	// 对象存储内容加密，配置 keys 后启用
+	Encryption EncryptionConfig `yaml:"encryption"`
+	// 对象存储中可压缩的文件（日志、文本、未压缩的 tar 包）使用 zstd 压缩保存
+	Compression CompressionConfig `yaml:"compression"`
+	// 对象写入多个后端，读取时使用第一个可用的后端
+	Replication ReplicationConfig `yaml:"replication"`
 }
 
-func LoadConfig(path string) (*Config, error) {
-	data, err := ioutil.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
+// ReplicationConfig 对象存储副本，storage-path 上的存储为主副本
+type ReplicationConfig struct {
+	Replicas    []ReplicaConfig `yaml:"replicas"`
+	WriteQuorum int             `yaml:"write-quorum"` // 写入成功的最少副本数（含主副本），默认全部
+	SpoolDir    string          `yaml:"spool-dir"`    // 上传分发到各副本前的临时目录，默认系统临时目录
+}
+
+type ReplicaConfig struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"` // local、s3，默认 s3
+	Path string `yaml:"path"`
+}
+
+// CompressionConfig 对象存储透明压缩；关闭后已压缩的对象仍可正常读取
+type CompressionConfig struct {
+	Enabled    bool     `yaml:"enabled"`
+	Level      string   `yaml:"level"`      // fastest、default、better、best，默认 default
+	MinSize    string   `yaml:"min-size"`   // 小于该大小的文件不压缩，默认 1KB
+	Extensions []string `yaml:"extensions"` // 压缩的扩展名，默认常见文本格式和 .tar；其他文件按内容判断
+}
+
+// EncryptionConfig 对象存储内容加密（AES-256-GCM）
+type EncryptionConfig struct {
+	ActiveKey string                `yaml:"active-key"` // 新写入对象使用的密钥 ID，默认第一个
+	Keys      []EncryptionKeyConfig `yaml:"keys"`
+}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, err
+// EncryptionKeyConfig 主密钥，key、key-file、key-command 三选一，内容为 base64 编码的 32 字节密钥
+type EncryptionKeyConfig struct {
+	ID         string `yaml:"id"` // 写入每个对象，最长 32 字节
+	Key        string `yaml:"key"`
+	KeyFile    string `yaml:"key-file"`
+	KeyCommand string `yaml:"key-command"` // 如通过 KMS 解密保存的密钥
+}
+
+// RetryConfig 对象存储操作失败时的重试，默认启用
+type RetryConfig struct {
+	Attempts        int      `yaml:"attempts"`         // 总尝试次数，默认 3，1 表示不重试
+	Backoff         string   `yaml:"backoff"`          // 第一次重试前的等待，之后翻倍，默认 100ms
+	MaxBackoff      string   `yaml:"max-backoff"`      // 等待上限，默认 2s
+	Timeout         string   `yaml:"timeout"`          // 每次尝试的超时，默认 30s
+	RetryableErrors []string `yaml:"retryable-errors"` // 额外视为临时错误的错误信息片段
+}
+
+// ReadCacheConfig 对象存储仓库的本地磁盘读缓存，设置 path 后启用
+type ReadCacheConfig struct {
+	Path    string `yaml:"path"`     // 缓存目录，启动时清空
+	MaxSize string `yaml:"max-size"` // 缓存总大小上限，如 10GB（默认）
+}
+
+// RedirectConfig 包下载重定向到对象存储的预签名地址，存储目录需与桶中的对象一致（如挂载的桶）
+type RedirectConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	Endpoint  string `yaml:"endpoint"` // 如 https://s3.us-east-1.amazonaws.com
+	Region    string `yaml:"region"`   // 默认 us-east-1
+	Bucket    string `yaml:"bucket"`
+	Prefix    string `yaml:"prefix"` // 存储目录在桶中的前缀
+	AccessKey string `yaml:"access-key"`
+	SecretKey string `yaml:"secret-key"`
+	PathStyle bool   `yaml:"path-style"` // MinIO 等使用 path-style 地址
+	Expires   string `yaml:"expires"`    // 预签名有效期，默认 15m
+	MinSize   int64  `yaml:"min-size"`   // 小于该大小（字节）的文件仍由 plus 直接返回
+}
+
+type SigningConfig struct {
+	DefaultKey string      `yaml:"default-key"`
+	Keys       []KeyConfig `yaml:"keys"`
+}
+
+type KeyConfig struct {
+	Name           string `yaml:"name"`
+	PublicKey      string `yaml:"public-key"`      // 公钥文件路径（ASCII armored 或二进制格式）
+	PrivateKey     string `yaml:"private-key"`     // 可选，对应的私钥文件路径，配置后服务可以生成签名（如 SHA256SUMS.asc）
+	PassphraseFile string `yaml:"passphrase-file"` // 私钥加密时保存口令的文件
+}
+
+// RepoConfig 按仓库路径查找仓库配置，找不到时再按 name 字段匹配
+func (c *Config) RepoConfig(repoName string) (RepoConfig, bool) {
+	if rc, ok := c.Repositories[repoName]; ok {
+		return rc, true
 	}
+	for _, rc := range c.Repositories {
+		if rc.Name == repoName {
+			return rc, true
+		}
+	}
+	return RepoConfig{}, false
+}
 
-	return &cfg, nil
+// LoadConfig 加载单个配置文件
+func LoadConfig(path string) (*Config, error) {
+	return Load([]string{path})
 }
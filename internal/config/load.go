@@ -0,0 +1,218 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// Load 依次加载多个配置片段并合并：映射逐层合并，其他值（包括列表）由后面的片段覆盖。
+// 加载前展开 ${...} 引用，见 Expand
+func Load(paths []string) (*Config, error) {
+	var merged interface{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data, err = Expand(data, filepath.Dir(path))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		// 先单独解析一次，类型错误指明是哪个片段（有引用时行号按展开后的片段计算）
+		var check Config
+		if err := yaml.Unmarshal(data, &check); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		var fragment interface{}
+		if err := yaml.Unmarshal(data, &fragment); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		merged = merge(merged, fragment)
+	}
+
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// DirFiles 返回目录中的 .yaml 和 .yml 文件，按文件名排序。
+// 跳过以 . 开头的条目（Kubernetes 挂载 ConfigMap 时生成的 ..data 等）
+func DirFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		ext := filepath.Ext(name)
+		if strings.HasPrefix(name, ".") || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		// ConfigMap 中的文件是符号链接，按链接目标判断
+		if fi, err := os.Stat(path); err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Expand 展开配置中的引用：
+//
+//	${NAME}          环境变量，未设置时报错
+//	${NAME:-default} 环境变量，未设置或为空时使用 default
+//	${file:path}     文件内容（去掉末尾换行），相对路径相对于 dir，用于挂载的 Secret
+//	$${              字面量 ${
+//
+// 先解析 YAML，只展开标量（值和键）中的引用，值中的 :、#、引号和换行不会改变配置的结构。
+// 不加引号的标量按展开后的内容确定类型（如 true、8080），加引号的始终是字符串。
+// 注释不展开，其他 $ 保持原样。返回展开后重新生成的 YAML
+func Expand(data []byte, dir string) ([]byte, error) {
+	if !bytes.Contains(data, []byte("${")) {
+		return data, nil
+	}
+	var doc yaml3.Node
+	if err := yaml3.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var missing []string
+	if err := expandNode(&doc, dir, &missing); err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("environment variables not set: %s", strings.Join(missing, ", "))
+	}
+	var buf bytes.Buffer
+	enc := yaml3.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// expandNode 展开 n 及其子节点中标量的引用，未设置的环境变量记入 missing
+func expandNode(n *yaml3.Node, dir string, missing *[]string) error {
+	if n.Kind == yaml3.ScalarNode && strings.Contains(n.Value, "${") {
+		value, err := expandString(n.Value, dir, missing)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", n.Line, err)
+		}
+		n.Value = value
+		// 不加引号、没有显式标签的标量重新按内容确定类型，生成时无法作为普通标量的值会加上引号
+		if n.Style == 0 {
+			n.Tag = ""
+		}
+	}
+	for _, c := range n.Content {
+		if err := expandNode(c, dir, missing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandString 展开一个标量中的引用
+func expandString(s, dir string, missing *[]string) (string, error) {
+	var b strings.Builder
+	rest := s
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			b.WriteString(rest)
+			return b.String(), nil
+		}
+		if start > 0 && rest[start-1] == '$' {
+			b.WriteString(rest[:start-1])
+			b.WriteString("${")
+			rest = rest[start+2:]
+			continue
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated ${")
+		}
+		b.WriteString(rest[:start])
+		ref := rest[start+2 : start+end]
+		rest = rest[start+end+1:]
+
+		value, ok, err := resolve(ref, dir)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			*missing = append(*missing, ref)
+			continue
+		}
+		b.WriteString(value)
+	}
+}
+
+// resolve 返回一个引用的值，环境变量未设置且没有默认值时 ok 为 false
+func resolve(ref, dir string) (string, bool, error) {
+	if path, ok := strings.CutPrefix(ref, "file:"); ok {
+		if path == "" {
+			return "", false, fmt.Errorf("empty file reference")
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("read secret file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), true, nil
+	}
+	name, def, hasDefault := strings.Cut(ref, ":-")
+	if name == "" {
+		return "", false, fmt.Errorf("empty variable reference")
+	}
+	if v := os.Getenv(name); v != "" || hasDefault {
+		if v == "" {
+			v = def
+		}
+		return v, true, nil
+	}
+	if _, set := os.LookupEnv(name); set {
+		return "", true, nil
+	}
+	return "", false, nil
+}
+
+// merge 把 src 合并到 dst，返回合并结果
+func merge(dst, src interface{}) interface{} {
+	srcMap, ok := src.(map[interface{}]interface{})
+	if !ok {
+		if src == nil {
+			return dst
+		}
+		return src
+	}
+	dstMap, ok := dst.(map[interface{}]interface{})
+	if !ok {
+		return srcMap
+	}
+	for k, v := range srcMap {
+		dstMap[k] = merge(dstMap[k], v)
+	}
+	return dstMap
+}
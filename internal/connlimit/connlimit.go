@@ -0,0 +1,227 @@
+package connlimit
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"plus/internal/apierr"
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// retryAfter 超出限制时建议客户端等待的秒数
+const retryAfter = "5"
+
+// exempt 不计入下载数的路径前缀（API、状态检查等）
+var exempt = []string{"/api/", "/static/", "/health", "/ready", "/metrics", "/keys"}
+
+// Limiter 按客户端 IP 限制并发连接数和并发下载数
+type Limiter struct {
+	maxConns     int
+	maxDownloads int
+	proxy        func(net.Addr) bool // 可信反向代理的地址，不限制连接数
+
+	mu        sync.Mutex
+	conns     map[string]int      // IP -> 打开的连接数
+	downloads map[string]int      // IP -> 进行中的下载数
+	holding   map[net.Conn]string // 占用下载名额、响应尚未发送完的连接
+
+	rejectedConns     int64
+	rejectedDownloads int64
+}
+
+// New 根据配置创建限制，0 表示不限制
+func New(cfg config.LimitsConfig) *Limiter {
+	return &Limiter{
+		maxConns:     cfg.MaxConnsPerIP,
+		maxDownloads: cfg.MaxDownloadsPerIP,
+		conns:        make(map[string]int),
+		downloads:    make(map[string]int),
+		holding:      make(map[net.Conn]string),
+	}
+}
+
+// TrustProxies 来自可信反向代理（proxy 返回 true）的连接仍然计数，但不受 max-conns-per-ip 限制：
+// 代理后面的全部客户端共用代理的地址，接受连接时还没有 X-Forwarded-For。
+// 下载数在请求级别按转发的客户端 IP 限制，需要 Handler 在解析转发头之后执行
+func (l *Limiter) TrustProxies(proxy func(net.Addr) bool) {
+	l.proxy = proxy
+}
+
+// Listen 包装监听器，超出单 IP 连接数的连接直接返回 429 并关闭
+func (l *Limiter) Listen(ln net.Listener) net.Listener {
+	return &listener{Listener: ln, l: l}
+}
+
+type listener struct {
+	net.Listener
+	l *Limiter
+}
+
+func (ln *listener) Accept() (net.Conn, error) {
+	for {
+		c, err := ln.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		ip := hostIP(c.RemoteAddr())
+		limited := ln.l.proxy == nil || !ln.l.proxy(c.RemoteAddr())
+		if ln.l.openConn(ip, limited) {
+			return &conn{Conn: c, l: ln.l, ip: ip}, nil
+		}
+		atomic.AddInt64(&ln.l.rejectedConns, 1)
+		log.Logger.Warnf("Too many connections from %s (max %d)", ip, ln.l.maxConns)
+		go reject(c)
+	}
+}
+
+// conn 关闭时释放连接名额
+type conn struct {
+	net.Conn
+	l    *Limiter
+	ip   string
+	once sync.Once
+}
+
+func (c *conn) Close() error {
+	c.once.Do(func() { c.l.closeConn(c.ip) })
+	return c.Conn.Close()
+}
+
+func (l *Limiter) openConn(ip string, limited bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limited && l.maxConns > 0 && l.conns[ip] >= l.maxConns {
+		return false
+	}
+	l.conns[ip]++
+	return true
+}
+
+func (l *Limiter) closeConn(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conns[ip]--; l.conns[ip] <= 0 {
+		delete(l.conns, ip)
+	}
+}
+
+// reject 在读取请求前直接写回 429
+func reject(c net.Conn) {
+	c.SetWriteDeadline(time.Now().Add(time.Second))
+	msg := "Too many connections from your address"
+	fmt.Fprintf(c, "HTTP/1.1 429 Too Many Requests\r\nConnection: close\r\nRetry-After: %s\r\nContent-Type: text/plain; charset=utf-8\r\nContent-Length: %d\r\n\r\n%s",
+		retryAfter, len(msg), msg)
+	c.Close()
+}
+
+// Handler 为下载请求占用名额；响应为文件流时名额保留到响应发送完（见 ConnState）
+func (l *Limiter) Handler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if l.maxDownloads <= 0 || !isDownload(ctx) {
+			next(ctx)
+			return
+		}
+
+		ip := ctx.RemoteIP().String()
+		if !l.acquire(ip) {
+			atomic.AddInt64(&l.rejectedDownloads, 1)
+			ctx.Response.Header.Set("Retry-After", retryAfter)
+			apierr.Write(ctx, fasthttp.StatusTooManyRequests, apierr.CodeTooManyRequests, "Too many concurrent downloads", "")
+			return
+		}
+
+		next(ctx)
+
+		if ctx.Response.IsBodyStream() && ctx.Conn() != nil {
+			l.mu.Lock()
+			l.holding[ctx.Conn()] = ip
+			l.mu.Unlock()
+			return
+		}
+		l.release(ip)
+	}
+}
+
+// ConnState 响应发送完或连接关闭时释放下载名额
+func (l *Limiter) ConnState(c net.Conn, state fasthttp.ConnState) {
+	if state == fasthttp.StateNew || state == fasthttp.StateActive {
+		return
+	}
+	l.mu.Lock()
+	ip, ok := l.holding[c]
+	delete(l.holding, c)
+	l.mu.Unlock()
+	if ok {
+		l.release(ip)
+	}
+}
+
+func (l *Limiter) acquire(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.downloads[ip] >= l.maxDownloads {
+		return false
+	}
+	l.downloads[ip]++
+	return true
+}
+
+func (l *Limiter) release(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.downloads[ip]--; l.downloads[ip] <= 0 {
+		delete(l.downloads, ip)
+	}
+}
+
+func isDownload(ctx *fasthttp.RequestCtx) bool {
+	if !ctx.IsGet() && !ctx.IsHead() {
+		return false
+	}
+	path := string(ctx.Path())
+	if path == "/" {
+		return false
+	}
+	for _, p := range exempt {
+		if strings.HasPrefix(path, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Metrics 返回当前连接和下载数及拒绝次数
+func (l *Limiter) Metrics() *types.ConnectionMetrics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	m := &types.ConnectionMetrics{
+		Clients:           len(l.conns),
+		MaxConnsPerIP:     l.maxConns,
+		MaxDownloadsPerIP: l.maxDownloads,
+		RejectedConns:     atomic.LoadInt64(&l.rejectedConns),
+		RejectedDownloads: atomic.LoadInt64(&l.rejectedDownloads),
+	}
+	for _, n := range l.conns {
+		m.Open += n
+	}
+	for _, n := range l.downloads {
+		m.Downloads += n
+	}
+	return m
+}
+
+func hostIP(addr net.Addr) string {
+	host := addr.String()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host
+}
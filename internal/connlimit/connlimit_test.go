@@ -0,0 +1,173 @@
+package connlimit
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+// serve 启动带限制的测试服务器，返回地址
+func serve(t *testing.T, l *Limiter, handler fasthttp.RequestHandler) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fasthttp.Server{Handler: l.Handler(handler), ConnState: l.ConnState}
+	go server.Serve(l.Listen(ln))
+	t.Cleanup(func() { server.Shutdown() })
+	return ln.Addr().String()
+}
+
+func TestMaxConnsPerIP(t *testing.T) {
+	l := New(config.LimitsConfig{MaxConnsPerIP: 1})
+	addr := serve(t, l, func(ctx *fasthttp.RequestCtx) {})
+
+	first, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	// 等待第一个连接被接受
+	for i := 0; i < 100 && l.Metrics().Open == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	second, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+	second.SetReadDeadline(time.Now().Add(5 * time.Second))
+	resp, err := http.ReadResponse(bufio.NewReader(second), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Errorf("status = %d, Retry-After = %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+	if m := l.Metrics(); m.RejectedConns != 1 || m.Open != 1 {
+		t.Errorf("metrics = %+v", m)
+	}
+
+	// 关闭后名额释放
+	first.Close()
+	for i := 0; i < 100 && l.Metrics().Open != 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if open := l.Metrics().Open; open != 0 {
+		t.Errorf("open = %d after close", open)
+	}
+}
+
+func TestTrustedProxyConns(t *testing.T) {
+	l := New(config.LimitsConfig{MaxConnsPerIP: 1})
+	l.TrustProxies(func(addr net.Addr) bool { return hostIP(addr) == "127.0.0.1" })
+	addr := serve(t, l, func(ctx *fasthttp.RequestCtx) {})
+
+	// 代理后面的客户端共用代理的地址，连接数不受限制
+	for i := 0; i < 3; i++ {
+		c, err := net.Dial("tcp", addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+	}
+	for i := 0; i < 100 && l.Metrics().Open < 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if m := l.Metrics(); m.Open != 3 || m.RejectedConns != 0 {
+		t.Errorf("metrics = %+v", m)
+	}
+}
+
+func TestMaxDownloadsPerIP(t *testing.T) {
+	l := New(config.LimitsConfig{MaxDownloadsPerIP: 1})
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	addr := serve(t, l, func(ctx *fasthttp.RequestCtx) {
+		if string(ctx.Path()) == "/repo/a.rpm" {
+			pr, pw := io.Pipe()
+			go func() {
+				<-unblock
+				pw.Write([]byte("data"))
+				pw.Close()
+			}()
+			ctx.SetBodyStream(pr, -1)
+			close(started)
+			return
+		}
+		ctx.SetBodyString("ok")
+	})
+
+	get := func(path string) int {
+		resp, err := http.Get("http://" + addr + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode
+	}
+
+	done := make(chan int)
+	go func() { done <- get("/repo/a.rpm") }()
+	<-started
+
+	// 响应仍在发送，名额被占用；API 请求不受影响
+	if code := get("/repo/b.rpm"); code != http.StatusTooManyRequests {
+		t.Errorf("second download status = %d, want 429", code)
+	}
+	if code := get("/api/v1/repos"); code != http.StatusOK {
+		t.Errorf("api status = %d, want 200", code)
+	}
+
+	close(unblock)
+	if code := <-done; code != http.StatusOK {
+		t.Errorf("first download status = %d", code)
+	}
+	for i := 0; i < 100 && l.Metrics().Downloads != 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if code := get("/repo/b.rpm"); code != http.StatusOK {
+		t.Errorf("download after release status = %d, want 200", code)
+	}
+	if m := l.Metrics(); m.RejectedDownloads != 1 {
+		t.Errorf("rejected downloads = %d, want 1", m.RejectedDownloads)
+	}
+}
+
+func TestIsDownload(t *testing.T) {
+	cases := map[string]bool{
+		"GET /centos/8/Packages/a.rpm": true,
+		"HEAD /repo/x/deb/a.deb":       true,
+		"POST /repo/x/upload":          false,
+		"GET /api/v1/repos":            false,
+		"GET /metrics":                 false,
+		"GET /":                        false,
+	}
+	for in, want := range cases {
+		method, path, _ := strings.Cut(in, " ")
+		var ctx fasthttp.RequestCtx
+		ctx.Request.Header.SetMethod(method)
+		ctx.Request.SetRequestURI(path)
+		if got := isDownload(&ctx); got != want {
+			t.Errorf("isDownload(%s) = %v, want %v", in, got, want)
+		}
+	}
+}
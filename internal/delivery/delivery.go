@@ -0,0 +1,385 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/eventlog"
+	"plus/internal/log"
+	"plus/internal/notify"
+	"plus/internal/types"
+)
+
+// DirName 投递队列在 DatabasePath 下的目录，每项投递一个文件
+const DirName = "deliveries"
+
+// 投递状态
+const (
+	StatePending = "pending"
+	StateDead    = "dead" // 重试次数用完或永久失败，等待管理员重试或删除
+)
+
+// DefaultAttempts 默认的最多尝试次数
+const DefaultAttempts = 10
+
+const (
+	firstBackoff   = 30 * time.Second
+	maxBackoff     = time.Hour
+	deliverTimeout = 30 * time.Second
+	idle           = time.Hour
+)
+
+var (
+	ErrNotFound  = errors.New("delivery not found")
+	ErrNoChannel = errors.New("notification channel is not configured")
+)
+
+// Queue 持久化的投递队列。事件先为每个目标保存一项投递，投递成功后删除，
+// 因此重启后未完成的投递继续进行，每个目标至少收到一次。同一目标的投递按入队顺序进行
+type Queue struct {
+	dir      string
+	node     string
+	attempts int
+	channels map[string]notify.Channel
+	order    []string // channels 的名称，按注册顺序
+	now      func() time.Time
+
+	mu    sync.Mutex
+	items map[string]*types.Delivery
+	seq   uint64
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Open 加载 dir 下未完成的投递。attempts 为最多尝试次数，<= 0 时为 DefaultAttempts。
+// 通知方式已不再配置的投递转为失败
+func Open(dir, node string, attempts int, channels ...notify.Channel) (*Queue, error) {
+	if attempts <= 0 {
+		attempts = DefaultAttempts
+	}
+	q := &Queue{
+		dir:      filepath.Join(dir, DirName),
+		node:     node,
+		attempts: attempts,
+		channels: make(map[string]notify.Channel),
+		now:      time.Now,
+		items:    make(map[string]*types.Delivery),
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for _, ch := range channels {
+		q.channels[ch.Name()] = ch
+		q.order = append(q.order, ch.Name())
+	}
+	if err := os.MkdirAll(q.dir, 0700); err != nil {
+		return nil, fmt.Errorf("create delivery directory: %w", err)
+	}
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read deliveries: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".json") {
+			// 写入中断留下的临时文件
+			os.Remove(filepath.Join(q.dir, name))
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(q.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("read deliveries: %w", err)
+		}
+		var d types.Delivery
+		if err := d.UnmarshalJSON(data); err != nil || d.ID+".json" != name {
+			log.Logger.Warnf("Ignoring corrupt delivery %s", name)
+			continue
+		}
+		if n, err := strconv.ParseUint(d.ID, 16, 64); err == nil && n > q.seq {
+			q.seq = n
+		}
+		if _, ok := q.channels[d.Channel]; !ok && d.State == StatePending {
+			d.State = StateDead
+			d.NextAttempt = ""
+			d.LastError = fmt.Sprintf("%s notifications are no longer configured", d.Channel)
+			if err := q.save(&d); err != nil {
+				return nil, err
+			}
+		}
+		q.items[d.ID] = &d
+	}
+	if pending := q.count(StatePending); pending > 0 {
+		log.Logger.Infof("Resuming %d pending event deliveries", pending)
+	}
+	go q.run()
+	return q, nil
+}
+
+// Send 实现 notify.Sender
+func (q *Queue) Send(e notify.Event) {
+	q.Enqueue(eventlog.NewRecord(e, q.node, q.now()))
+}
+
+// Enqueue 为订阅了事件的每个目标保存一项投递
+func (q *Queue) Enqueue(rec types.EventRecord) {
+	e := eventlog.Event(rec)
+	now := q.now().UTC().Format(time.RFC3339)
+
+	q.mu.Lock()
+	added := false
+	for _, name := range q.order {
+		for _, target := range q.channels[name].Targets(e) {
+			q.seq++
+			d := &types.Delivery{
+				ID:          fmt.Sprintf("%016x", q.seq),
+				Channel:     name,
+				Target:      target,
+				State:       StatePending,
+				NextAttempt: now,
+				CreatedAt:   now,
+				Event:       rec,
+			}
+			if err := q.save(d); err != nil {
+				log.Logger.Errorf("Failed to queue %s event for %s %s: %v", rec.Event, name, target, err)
+				continue
+			}
+			q.items[d.ID] = d
+			added = true
+		}
+	}
+	q.mu.Unlock()
+	if added {
+		q.signal()
+	}
+}
+
+func (q *Queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *Queue) run() {
+	defer close(q.done)
+	for {
+		due, wait := q.due()
+		if len(due) == 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-q.wake:
+			case <-timer.C:
+			case <-q.stop:
+				timer.Stop()
+				return
+			}
+			timer.Stop()
+			continue
+		}
+		// 不同目标的投递并行进行
+		var wg sync.WaitGroup
+		for _, d := range due {
+			wg.Add(1)
+			go func(d types.Delivery) {
+				defer wg.Done()
+				q.attempt(d)
+			}(d)
+		}
+		wg.Wait()
+		select {
+		case <-q.stop:
+			return
+		default:
+		}
+	}
+}
+
+// due 返回每个目标最早的待投递项中已到重试时间的，以及距下一次重试的时间
+func (q *Queue) due() ([]types.Delivery, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := q.now()
+	wait := idle
+	heads := make(map[string]bool)
+	var due []types.Delivery
+	for _, d := range q.sorted(StatePending) {
+		key := d.Channel + "\x00" + d.Target
+		if heads[key] {
+			continue
+		}
+		heads[key] = true
+		next, _ := time.Parse(time.RFC3339, d.NextAttempt)
+		if !next.After(now) {
+			due = append(due, d)
+		} else if w := next.Sub(now); w < wait {
+			wait = w
+		}
+	}
+	return due, wait
+}
+
+// attempt 投递一次，成功后删除，失败时安排重试或转为失败
+func (q *Queue) attempt(d types.Delivery) {
+	ch := q.channels[d.Channel]
+	ctx, cancel := context.WithTimeout(context.Background(), deliverTimeout)
+	err := ch.Deliver(ctx, d.Target, eventlog.Event(d.Event))
+	cancel()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item, ok := q.items[d.ID]
+	if !ok || item.State != StatePending {
+		// 投递期间被删除
+		return
+	}
+	if err == nil {
+		delete(q.items, d.ID)
+		if err := os.Remove(q.path(d.ID)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Logger.Warnf("Failed to remove delivery %s: %v", d.ID, err)
+		}
+		return
+	}
+
+	item.Attempts++
+	item.LastError = err.Error()
+	if item.Attempts >= q.attempts || errors.Is(err, notify.ErrPermanent) {
+		item.State = StateDead
+		item.NextAttempt = ""
+		log.Logger.Errorf("Delivery of %s event to %s %s failed after %d attempt(s): %v", d.Event.Event, d.Channel, d.Target, item.Attempts, err)
+	} else {
+		item.NextAttempt = q.now().Add(backoff(item.Attempts)).UTC().Format(time.RFC3339)
+		log.Logger.Warnf("Delivery of %s event to %s %s failed, retrying at %s: %v", d.Event.Event, d.Channel, d.Target, item.NextAttempt, err)
+	}
+	if err := q.save(item); err != nil {
+		log.Logger.Errorf("Failed to save delivery %s: %v", d.ID, err)
+	}
+}
+
+// backoff 第 n 次失败后等待的时间，从 30s 开始翻倍，最长 1h
+func backoff(n int) time.Duration {
+	d := firstBackoff
+	for i := 1; i < n && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// List 按入队顺序返回投递，state 为空时返回全部
+func (q *Queue) List(state string) []types.Delivery {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.sorted(state)
+}
+
+// sorted 调用方持有锁
+func (q *Queue) sorted(state string) []types.Delivery {
+	list := make([]types.Delivery, 0, len(q.items))
+	for _, d := range q.items {
+		if state == "" || d.State == state {
+			list = append(list, *d)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func (q *Queue) count(state string) int {
+	n := 0
+	for _, d := range q.items {
+		if d.State == state {
+			n++
+		}
+	}
+	return n
+}
+
+// Get 返回投递
+func (q *Queue) Get(id string) (types.Delivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	d, ok := q.items[id]
+	if !ok {
+		return types.Delivery{}, ErrNotFound
+	}
+	return *d, nil
+}
+
+// Retry 立即重新投递，失败的投递重新获得全部尝试次数
+func (q *Queue) Retry(id string) (types.Delivery, error) {
+	q.mu.Lock()
+	d, ok := q.items[id]
+	if !ok {
+		q.mu.Unlock()
+		return types.Delivery{}, ErrNotFound
+	}
+	if _, ok := q.channels[d.Channel]; !ok {
+		q.mu.Unlock()
+		return *d, fmt.Errorf("%s: %w", d.Channel, ErrNoChannel)
+	}
+	if d.State == StateDead {
+		d.Attempts = 0
+	}
+	d.State = StatePending
+	d.NextAttempt = q.now().UTC().Format(time.RFC3339)
+	err := q.save(d)
+	out := *d
+	q.mu.Unlock()
+	q.signal()
+	return out, err
+}
+
+// Delete 删除投递，不再发送
+func (q *Queue) Delete(id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.items[id]; !ok {
+		return ErrNotFound
+	}
+	delete(q.items, id)
+	if err := os.Remove(q.path(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Close 等待进行中的投递完成后停止，未完成的投递在下次启动时继续
+func (q *Queue) Close() {
+	q.once.Do(func() { close(q.stop) })
+	<-q.done
+}
+
+func (q *Queue) path(id string) string {
+	return filepath.Join(q.dir, id+".json")
+}
+
+// save 写入一项投递，调用方持有锁。投递中含有收件人和 webhook ID，只允许所有者读取
+func (q *Queue) save(d *types.Delivery) error {
+	data, err := d.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	tmp := q.path(d.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write delivery: %w", err)
+	}
+	if err := os.Rename(tmp, q.path(d.ID)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/notify"
+	"plus/internal/types"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+// channel 按 targets 订阅全部事件，err 不为空时投递失败
+type channel struct {
+	name      string
+	targets   []string
+	delivered chan string
+
+	mu  sync.Mutex
+	err error
+}
+
+func newChannel(name string, targets ...string) *channel {
+	return &channel{name: name, targets: targets, delivered: make(chan string, 16)}
+}
+
+func (c *channel) Name() string                  { return c.name }
+func (c *channel) Targets(notify.Event) []string { return c.targets }
+
+func (c *channel) Deliver(_ context.Context, target string, e notify.Event) error {
+	c.mu.Lock()
+	err := c.err
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	c.delivered <- fmt.Sprintf("%s %s %d", target, e.Name, e.Seq)
+	return nil
+}
+
+func (c *channel) fail(err error) {
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+}
+
+func record(seq int64) types.EventRecord {
+	return types.EventRecord{Seq: seq, Time: "2026-10-16T09:00:00Z", Event: notify.EventPackagePublished, Repo: "prod/el9"}
+}
+
+func TestDeliver(t *testing.T) {
+	chat := newChannel("chat", "a", "b")
+	q, err := Open(t.TempDir(), "node-1", 0, chat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	q.Enqueue(record(1))
+	q.Enqueue(record(2))
+	got := map[string][]string{}
+	for i := 0; i < 4; i++ {
+		d := wait(t, chat.delivered)
+		got[d[:1]] = append(got[d[:1]], d)
+	}
+	// 同一目标按顺序投递
+	for _, target := range []string{"a", "b"} {
+		want := []string{target + " package.published 1", target + " package.published 2"}
+		if fmt.Sprint(got[target]) != fmt.Sprint(want) {
+			t.Errorf("Target %s received %v, want %v", target, got[target], want)
+		}
+	}
+	waitFor(t, func() bool { return len(q.List("")) == 0 })
+}
+
+func TestRetryAndRestart(t *testing.T) {
+	dir := t.TempDir()
+	kafka := newChannel("kafka", "plus.events")
+	kafka.fail(errors.New("connection refused"))
+	q, err := Open(dir, "node-1", 3, kafka)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Enqueue(record(7))
+	waitFor(t, func() bool {
+		list := q.List(StatePending)
+		return len(list) == 1 && list[0].Attempts == 1
+	})
+	d := q.List("")[0]
+	if d.LastError != "connection refused" || d.NextAttempt == "" || d.Event.Seq != 7 {
+		t.Errorf("Pending delivery = %+v", d)
+	}
+	q.Close()
+
+	// 重启后继续投递
+	kafka.fail(nil)
+	q, err = Open(dir, "node-1", 3, kafka)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+	if _, err := q.Retry(d.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := wait(t, kafka.delivered); got != "plus.events package.published 7" {
+		t.Errorf("Delivered %s", got)
+	}
+	waitFor(t, func() bool { return len(q.List("")) == 0 })
+	if entries, _ := os.ReadDir(q.dir); len(entries) != 0 {
+		t.Errorf("Delivery files left: %v", entries)
+	}
+}
+
+func TestDeadLetters(t *testing.T) {
+	dir := t.TempDir()
+	email := newChannel("email", "ops@example.com")
+	email.fail(fmt.Errorf("%w: 550 mailbox unavailable", notify.ErrPermanent))
+	q, err := Open(dir, "", 0, email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Enqueue(record(1))
+	waitFor(t, func() bool { return len(q.List(StateDead)) == 1 })
+	dead := q.List(StateDead)[0]
+	if dead.Attempts != 1 || dead.NextAttempt != "" {
+		t.Errorf("Dead letter = %+v", dead)
+	}
+
+	// 死信不阻塞同一目标之后的投递
+	email.fail(nil)
+	q.Enqueue(record(2))
+	if got := wait(t, email.delivered); got != "ops@example.com package.published 2" {
+		t.Errorf("Delivered %s", got)
+	}
+	if _, err := q.Retry(dead.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := wait(t, email.delivered); got != "ops@example.com package.published 1" {
+		t.Errorf("Delivered %s after retry", got)
+	}
+	q.Close()
+
+	// 通知方式不再配置时，未完成的投递转为死信
+	other := newChannel("email", "ops@example.com")
+	other.fail(errors.New("timeout"))
+	q, err = Open(dir, "", 1, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Enqueue(record(3))
+	waitFor(t, func() bool { return len(q.List(StateDead)) == 1 })
+	q.Close()
+	q, err = Open(dir, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+	list := q.List("")
+	if len(list) != 1 || list[0].State != StateDead || list[0].LastError != "timeout" {
+		t.Errorf("Deliveries after reopen = %+v", list)
+	}
+	if _, err := q.Retry(list[0].ID); err == nil {
+		t.Error("Retry without the channel should fail")
+	}
+	if err := q.Delete(list[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.Get(list[0].ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after delete = %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for n, want := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 5: 8 * time.Minute, 8: time.Hour, 20: time.Hour} {
+		if got := backoff(n); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", n, got, want)
+		}
+	}
+}
+
+func wait(t *testing.T, delivered chan string) string {
+	t.Helper()
+	select {
+	case got := <-delivered:
+		return got
+	case <-time.After(5 * time.Second):
+		t.Fatal("Nothing delivered")
+		return ""
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,272 @@
+package deps
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Dependency 单个依赖项，Relation 为空时不限制版本
+type Dependency struct {
+	Name     string
+	Relation string // =, <, <=, >, >=
+	Version  string
+}
+
+func (d Dependency) String() string {
+	if d.Relation == "" {
+		return d.Name
+	}
+	return d.Name + " " + d.Relation + " " + d.Version
+}
+
+// Requirement 一条依赖，满足其中任一项即可（DEB 的 "a | b"）
+type Requirement []Dependency
+
+func (r Requirement) String() string {
+	parts := make([]string, len(r))
+	for i, d := range r {
+		parts[i] = d.String()
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Capability 包提供的能力（包名、虚拟包、文件路径等）
+type Capability struct {
+	Name    string
+	Version string
+}
+
+func (c Capability) String() string {
+	if c.Version == "" {
+		return c.Name
+	}
+	return c.Name + " = " + c.Version
+}
+
+// Package 仓库元数据中的包及其依赖信息
+type Package struct {
+	Name     string
+	Version  string
+	Arch     string
+	Location string // 相对仓库根目录的路径
+	Size     int64
+	Checksum string
+	SumType  string // sha256、sha1、md5 等
+	Provides []Capability
+	Requires []Requirement
+
+	Summary     string
+	Description string
+	License     string // DEB 包只有 control 中写了 License 字段时才有
+}
+
+// Resolution 依赖解析结果
+type Resolution struct {
+	Packages   []*Package // 按解析顺序排列的包（请求的包在前）
+	Missing    []string   // 仓库中不存在的请求包
+	Unresolved []string   // 仓库内无法满足的依赖
+}
+
+// Index 包与能力的索引
+type Index struct {
+	packages []*Package
+	compare  Comparer
+	byName   map[string][]*Package
+	byFile   map[string]*Package
+	provides map[string][]provider
+
+	reverseOnce sync.Once
+	reverse     map[*Package][]Dependent
+}
+
+type provider struct {
+	pkg     *Package
+	version string
+}
+
+// NewIndex 建立索引；arch 非空时只保留该架构及 noarch/all 的包。
+// compare 为仓库类型的版本比较规则（见 VersionComparer），为 nil 时使用 CompareVersions
+func NewIndex(pkgs []Package, arch string, compare Comparer) *Index {
+	if compare == nil {
+		compare = CompareVersions
+	}
+	ix := &Index{
+		compare:  compare,
+		byName:   make(map[string][]*Package),
+		byFile:   make(map[string]*Package),
+		provides: make(map[string][]provider),
+	}
+
+	for i := range pkgs {
+		p := &pkgs[i]
+		if arch != "" && p.Arch != arch && p.Arch != "noarch" && p.Arch != "all" {
+			continue
+		}
+		ix.packages = append(ix.packages, p)
+		ix.byName[p.Name] = append(ix.byName[p.Name], p)
+		ix.byFile[path.Base(p.Location)] = p
+
+		// 包名本身也是能力
+		ix.provides[p.Name] = append(ix.provides[p.Name], provider{pkg: p, version: p.Version})
+		for _, c := range p.Provides {
+			if c.Name == p.Name {
+				continue
+			}
+			ix.provides[c.Name] = append(ix.provides[c.Name], provider{pkg: p, version: c.Version})
+		}
+	}
+
+	for _, list := range ix.byName {
+		sort.SliceStable(list, func(i, j int) bool {
+			return compare(list[i].Version, list[j].Version) > 0
+		})
+	}
+	return ix
+}
+
+// Packages 返回索引中的所有包
+func (ix *Index) Packages() []*Package {
+	return ix.packages
+}
+
+// Lookup 按包名返回最新版本
+func (ix *Index) Lookup(name string) (*Package, bool) {
+	list := ix.byName[name]
+	if len(list) == 0 {
+		return nil, false
+	}
+	return list[0], true
+}
+
+// Versions 按包名返回所有版本，从新到旧
+func (ix *Index) Versions(name string) []*Package {
+	return ix.byName[name]
+}
+
+// Superseded 返回每个 name/arch 保留最新 keep 个版本后多出的旧版本
+func (ix *Index) Superseded(keep int) []*Package {
+	groups := groupByNameArch(ix)
+	var old []*Package
+	for _, key := range sortedKeys(groups) {
+		if list := groups[key]; len(list) > keep {
+			old = append(old, list[keep:]...)
+		}
+	}
+	return old
+}
+
+// LookupFile 按包文件名查找
+func (ix *Index) LookupFile(file string) (*Package, bool) {
+	p, ok := ix.byFile[path.Base(file)]
+	return p, ok
+}
+
+// Providers 返回满足依赖的包，版本从新到旧
+func (ix *Index) Providers(dep Dependency) []*Package {
+	var result []*Package
+	seen := make(map[*Package]bool)
+	for _, pr := range ix.provides[dep.Name] {
+		if seen[pr.pkg] || !ix.satisfies(dep, pr.version) {
+			continue
+		}
+		seen[pr.pkg] = true
+		result = append(result, pr.pkg)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		// 同名包优先，其次版本新的优先
+		if (result[i].Name == dep.Name) != (result[j].Name == dep.Name) {
+			return result[i].Name == dep.Name
+		}
+		return ix.compare(result[i].Version, result[j].Version) > 0
+	})
+	return result
+}
+
+// Resolve 从请求的包名出发解析完整的依赖闭包
+func (ix *Index) Resolve(names []string) *Resolution {
+	res := &Resolution{}
+	selected := make(map[*Package]bool)
+	unresolved := make(map[string]bool)
+
+	var queue []*Package
+	add := func(p *Package) {
+		if !selected[p] {
+			selected[p] = true
+			res.Packages = append(res.Packages, p)
+			queue = append(queue, p)
+		}
+	}
+
+	for _, name := range names {
+		if p, ok := ix.Lookup(name); ok {
+			add(p)
+		} else {
+			res.Missing = append(res.Missing, name)
+		}
+	}
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		for _, req := range p.Requires {
+			if ix.satisfiedBy(req, selected) {
+				continue
+			}
+			if best := ix.bestProvider(req); best != nil {
+				add(best)
+				continue
+			}
+			if s := req.String(); !unresolved[s] {
+				unresolved[s] = true
+				res.Unresolved = append(res.Unresolved, s)
+			}
+		}
+	}
+
+	sort.Strings(res.Unresolved)
+	return res
+}
+
+func (ix *Index) satisfiedBy(req Requirement, selected map[*Package]bool) bool {
+	for _, dep := range req {
+		for _, p := range ix.Providers(dep) {
+			if selected[p] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (ix *Index) bestProvider(req Requirement) *Package {
+	for _, dep := range req {
+		if providers := ix.Providers(dep); len(providers) > 0 {
+			return providers[0]
+		}
+	}
+	return nil
+}
+
+// satisfies 判断提供的版本是否满足依赖的版本约束（无版本的能力视为满足）
+func (ix *Index) satisfies(dep Dependency, version string) bool {
+	if dep.Relation == "" || version == "" {
+		return true
+	}
+	c := ix.compare(version, dep.Version)
+	switch dep.Relation {
+	case "=":
+		return c == 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return true
+}
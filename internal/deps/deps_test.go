@@ -0,0 +1,279 @@
+package deps
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.1", -1},
+		{"1.10", "1.9", 1},
+		{"1.0-2", "1.0-10", -1},
+		{"1:1.0", "2.0", 1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0a", "1.0", 1},
+		{"1.0", "1.0-5", 0},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompareDebVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.1", -1},
+		{"1.10", "1.9", 1},
+		{"1.0-2", "1.0-10", -1},
+		{"1:1.0", "2.0", 1},
+		{"0:1.0", "1.0", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~~", "1.0~", -1},
+		{"1.0~rc1-1", "1.0-1", -1},
+		{"1.0", "1.0-0", 0},
+		{"1.0", "1.0-5", -1},
+		{"1.0a", "1.0+", -1},
+		{"1.0+b1", "1.0+b1~", 1},
+		{"1.0.", "1.0", 1},
+		{"1.0-beta-2", "1.0-beta-10", -1},
+		{"1.0-1+b1", "1.0-1", 1},
+		{"001.0", "1.0", 0},
+	}
+	for _, tt := range tests {
+		if got := CompareDebVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareDebVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := CompareDebVersions(tt.b, tt.a); got != -tt.want {
+			t.Errorf("CompareDebVersions(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestVersionComparer(t *testing.T) {
+	pkgs := []Package{
+		{Name: "libfoo", Version: "1.0-1", Arch: "amd64"},
+		{Name: "libfoo", Version: "1.0+", Arch: "amd64"},
+		{Name: "libfoo", Version: "1.0a", Arch: "amd64"},
+	}
+	newer := Dependency{Name: "libfoo", Relation: ">", Version: "1.0a"}
+
+	// dpkg：字母小于其他字符，1.0a < 1.0+；没有 revision 的 1.0 小于 1.0-1
+	deb := NewIndex(pkgs, "", VersionComparer("deb"))
+	var got []string
+	for _, p := range deb.Versions("libfoo") {
+		got = append(got, p.Version)
+	}
+	if want := "1.0+ 1.0a 1.0-1"; strings.Join(got, " ") != want {
+		t.Errorf("deb Versions = %v, want %s", got, want)
+	}
+	if p := deb.Providers(newer); len(p) != 1 || p[0].Version != "1.0+" {
+		t.Errorf("deb Providers(%s) = %v, want 1.0+", newer, p)
+	}
+	if p := deb.Providers(Dependency{Name: "libfoo", Relation: "=", Version: "1.0"}); len(p) != 0 {
+		t.Errorf("deb Providers(libfoo = 1.0) = %v, want none", p)
+	}
+
+	// rpmvercmp 忽略分隔符，1.0+ 与 1.0 相同
+	rpm := NewIndex(pkgs, "", VersionComparer("rpm"))
+	if p := rpm.Providers(newer); len(p) != 0 {
+		t.Errorf("rpm Providers(%s) = %v, want none", newer, p)
+	}
+}
+
+const testPrimary = `<?xml version="1.0" encoding="UTF-8"?>
+<metadata xmlns="http://linux.duke.edu/metadata/common" xmlns:rpm="http://linux.duke.edu/metadata/rpm" packages="4">
+<package type="rpm"><name>app</name><arch>x86_64</arch>
+<version epoch="0" ver="2.0" rel="1"/><size package="100"/>
+<location href="Packages/a/app-2.0-1.x86_64.rpm"/>
+<format><rpm:requires>
+<rpm:entry name="libfoo.so.1()(64bit)"/>
+<rpm:entry name="bar" flags="GE" epoch="0" ver="1.2"/>
+<rpm:entry name="rpmlib(CompressedFileNames)" flags="LE" epoch="0" ver="3.0.4" rel="1"/>
+</rpm:requires></format></package>
+<package type="rpm"><name>libfoo</name><arch>x86_64</arch>
+<version epoch="0" ver="1.0" rel="1"/><size package="50"/>
+<location href="Packages/l/libfoo-1.0-1.x86_64.rpm"/>
+<format><rpm:provides><rpm:entry name="libfoo.so.1()(64bit)"/></rpm:provides></format></package>
+<package type="rpm"><name>bar</name><arch>noarch</arch>
+<version epoch="0" ver="1.1" rel="1"/><size package="10"/>
+<location href="Packages/b/bar-1.1-1.noarch.rpm"/></package>
+<package type="rpm"><name>bar</name><arch>noarch</arch>
+<version epoch="0" ver="1.3" rel="1"/><size package="10"/>
+<location href="Packages/b/bar-1.3-1.noarch.rpm"/>
+<format><rpm:requires><rpm:entry name="missing-dep"/></rpm:requires></format></package>
+</metadata>`
+
+func TestResolveRPM(t *testing.T) {
+	pkgs, err := ParseRPMPrimary(strings.NewReader(testPrimary))
+	if err != nil {
+		t.Fatalf("ParseRPMPrimary failed: %v", err)
+	}
+	if len(pkgs) != 4 || len(pkgs[0].Requires) != 2 {
+		t.Fatalf("Unexpected packages: %+v", pkgs)
+	}
+
+	res := NewIndex(pkgs, "x86_64", nil).Resolve([]string{"app", "nope"})
+
+	var got []string
+	for _, p := range res.Packages {
+		got = append(got, p.Name+"-"+p.Version)
+	}
+	want := "app-2.0-1 libfoo-1.0-1 bar-1.3-1"
+	if strings.Join(got, " ") != want {
+		t.Errorf("Resolved %v, want %s", got, want)
+	}
+	if len(res.Missing) != 1 || res.Missing[0] != "nope" {
+		t.Errorf("Unexpected missing: %v", res.Missing)
+	}
+	if len(res.Unresolved) != 1 || res.Unresolved[0] != "missing-dep" {
+		t.Errorf("Unexpected unresolved: %v", res.Unresolved)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	ix := NewIndex([]Package{
+		{Name: "app", Version: "1.9-1", Arch: "x86_64"},
+		{Name: "app", Version: "1:1.0-1", Arch: "x86_64"},
+		{Name: "app", Version: "1.10-1", Arch: "x86_64"},
+		{Name: "app", Version: "1.10-1~rc1", Arch: "x86_64"},
+		{Name: "lib", Version: "2.0-1", Arch: "x86_64"},
+	}, "", nil)
+
+	var got []string
+	for _, p := range ix.Versions("app") {
+		got = append(got, p.Version)
+	}
+	if want := "1:1.0-1 1.10-1 1.10-1~rc1 1.9-1"; strings.Join(got, " ") != want {
+		t.Errorf("Versions = %v, want %s", got, want)
+	}
+	if len(ix.Versions("nope")) != 0 {
+		t.Errorf("Unexpected versions for unknown package")
+	}
+}
+
+func TestSuperseded(t *testing.T) {
+	ix := NewIndex([]Package{
+		{Name: "app", Version: "1.0-1", Arch: "x86_64", Location: "Packages/app-1.0-1.x86_64.rpm"},
+		{Name: "app", Version: "1.2-1", Arch: "x86_64", Location: "Packages/app-1.2-1.x86_64.rpm"},
+		{Name: "app", Version: "1.1-1", Arch: "x86_64", Location: "Packages/app-1.1-1.x86_64.rpm"},
+		{Name: "app", Version: "1.0-1", Arch: "aarch64", Location: "Packages/app-1.0-1.aarch64.rpm"},
+		{Name: "lib", Version: "2.0-1", Arch: "x86_64", Location: "Packages/lib-2.0-1.x86_64.rpm"},
+	}, "", nil)
+
+	var got []string
+	for _, p := range ix.Superseded(1) {
+		got = append(got, p.Location)
+	}
+	if want := "Packages/app-1.1-1.x86_64.rpm Packages/app-1.0-1.x86_64.rpm"; strings.Join(got, " ") != want {
+		t.Errorf("Superseded(1) = %v, want %s", got, want)
+	}
+	if old := ix.Superseded(3); len(old) != 0 {
+		t.Errorf("Superseded(3) = %v, want none", old)
+	}
+}
+
+func TestParseDebRelations(t *testing.T) {
+	reqs := parseDebRelations("libc6 (>= 2.34), mail-transport-agent | postfix:any [amd64], debconf (<< 2.0) <!nocheck>")
+	if len(reqs) != 3 {
+		t.Fatalf("Unexpected requirements: %v", reqs)
+	}
+	if got := reqs[0].String(); got != "libc6 >= 2.34" {
+		t.Errorf("reqs[0] = %q", got)
+	}
+	if got := reqs[1].String(); got != "mail-transport-agent | postfix" {
+		t.Errorf("reqs[1] = %q", got)
+	}
+	if got := reqs[2].String(); got != "debconf < 2.0" {
+		t.Errorf("reqs[2] = %q", got)
+	}
+}
+
+func TestParseDebDescription(t *testing.T) {
+	pkgs, err := ParseDebPackages(strings.NewReader("Package: hello\nVersion: 2.10-2\nArchitecture: amd64\nLicense: GPL-3.0-or-later\n" +
+		"Description: example package based on GNU hello\n The GNU hello program produces a\n familiar message.\n .\n It is an example.\n"))
+	if err != nil || len(pkgs) != 1 {
+		t.Fatalf("ParseDebPackages = %v, %v", pkgs, err)
+	}
+	if pkgs[0].Summary != "example package based on GNU hello" {
+		t.Errorf("Summary = %q", pkgs[0].Summary)
+	}
+	if want := "The GNU hello program produces a\nfamiliar message.\n\nIt is an example."; pkgs[0].Description != want {
+		t.Errorf("Description = %q", pkgs[0].Description)
+	}
+	if pkgs[0].License != "GPL-3.0-or-later" {
+		t.Errorf("License = %q", pkgs[0].License)
+	}
+}
+
+func TestImpact(t *testing.T) {
+	pkgs, err := ParseRPMPrimary(strings.NewReader(testPrimary))
+	if err != nil {
+		t.Fatalf("ParseRPMPrimary failed: %v", err)
+	}
+	ix := NewIndex(pkgs, "", nil)
+
+	libfoo, ok := ix.LookupFile("libfoo-1.0-1.x86_64.rpm")
+	if !ok {
+		t.Fatal("libfoo not found by file name")
+	}
+	rdeps := ix.RequiredBy(libfoo)
+	if len(rdeps) != 1 || rdeps[0].Package.Name != "app" || !rdeps[0].Sole {
+		t.Errorf("Unexpected reverse deps: %+v", rdeps)
+	}
+	if impact := ix.Impact(libfoo); len(impact) != 1 || impact[0].Package.Name != "app" || impact[0].Depth != 1 {
+		t.Errorf("Unexpected impact: %+v", impact)
+	}
+
+	// 只有 bar-1.3 满足 app 的 bar >= 1.2
+	bar, _ := ix.LookupFile("bar-1.3-1.noarch.rpm")
+	if impact := ix.Impact(bar); len(impact) != 1 {
+		t.Errorf("Removing the only bar >= 1.2 should break app, got %+v", impact)
+	}
+	oldBar, _ := ix.LookupFile("bar-1.1-1.noarch.rpm")
+	if impact := ix.Impact(oldBar); len(impact) != 0 {
+		t.Errorf("Removing bar-1.1 should be safe, got %+v", impact)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := NewIndex([]Package{
+		{Name: "app", Version: "2.0-1", Arch: "x86_64", Checksum: "a2", SumType: "sha256"},
+		{Name: "app", Version: "1.0-1", Arch: "x86_64", Checksum: "a1", SumType: "sha256"},
+		{Name: "lib", Version: "1.0-1", Arch: "x86_64", Checksum: "l1", SumType: "sha256"},
+		{Name: "tool", Version: "1.0-1", Arch: "noarch", Checksum: "t1", SumType: "sha256"},
+		{Name: "staging-only", Version: "0.1-1", Arch: "noarch"},
+	}, "", nil)
+	b := NewIndex([]Package{
+		{Name: "app", Version: "1.0-1", Arch: "x86_64", Checksum: "a1", SumType: "sha256"},
+		{Name: "lib", Version: "1.0-1", Arch: "x86_64", Checksum: "rebuilt", SumType: "sha256"},
+		{Name: "tool", Version: "1.0-1", Arch: "noarch", Checksum: "t1", SumType: "sha256"},
+		{Name: "tool", Version: "1.0-1", Arch: "aarch64"},
+	}, "", nil)
+
+	d := Compare(a, b)
+	if len(d.OnlyA) != 1 || d.OnlyA[0].Name != "staging-only" {
+		t.Errorf("Unexpected only in A: %+v", d.OnlyA)
+	}
+	if len(d.OnlyB) != 1 || d.OnlyB[0].Name != "tool" || d.OnlyB[0].Arch != "aarch64" {
+		t.Errorf("Unexpected only in B: %+v", d.OnlyB)
+	}
+	if len(d.Mismatches) != 2 {
+		t.Fatalf("Unexpected mismatches: %+v", d.Mismatches)
+	}
+	if m := d.Mismatches[0]; m.Name != "app" || m.Newer != 1 || len(m.A) != 2 || len(m.B) != 1 || len(m.ChecksumDiffers) != 0 {
+		t.Errorf("Unexpected app mismatch: %+v", m)
+	}
+	if m := d.Mismatches[1]; m.Name != "lib" || m.Newer != 0 || len(m.ChecksumDiffers) != 1 || m.ChecksumDiffers[0] != "1.0-1" {
+		t.Errorf("Unexpected lib mismatch: %+v", m)
+	}
+}
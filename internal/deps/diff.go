@@ -0,0 +1,96 @@
+package deps
+
+import "sort"
+
+// Diff 两个索引中包的差异，按 name/arch 比较
+type Diff struct {
+	OnlyA      []*Package // 只在 A 中的包（所有版本）
+	OnlyB      []*Package
+	Mismatches []Mismatch
+}
+
+// Mismatch 两边都有但版本集合不同、或相同版本校验和不同的包
+type Mismatch struct {
+	Name            string
+	Arch            string
+	A               []*Package // 从新到旧
+	B               []*Package
+	Newer           int      // 比较两边的最新版本：1 表示 A 较新，-1 表示 B 较新
+	ChecksumDiffers []string // 两边都有但内容不同的版本
+}
+
+type nameArch struct{ name, arch string }
+
+// Compare 比较两个索引
+func Compare(a, b *Index) *Diff {
+	groupsA, groupsB := groupByNameArch(a), groupByNameArch(b)
+	d := &Diff{}
+
+	for _, key := range sortedKeys(groupsA, groupsB) {
+		pa, pb := groupsA[key], groupsB[key]
+		switch {
+		case len(pb) == 0:
+			d.OnlyA = append(d.OnlyA, pa...)
+		case len(pa) == 0:
+			d.OnlyB = append(d.OnlyB, pb...)
+		default:
+			if m, ok := compareVersions(a.compare, key, pa, pb); ok {
+				d.Mismatches = append(d.Mismatches, m)
+			}
+		}
+	}
+	return d
+}
+
+func compareVersions(compare Comparer, key nameArch, pa, pb []*Package) (Mismatch, bool) {
+	m := Mismatch{Name: key.name, Arch: key.arch, A: pa, B: pb, Newer: compare(pa[0].Version, pb[0].Version)}
+	byVersion := make(map[string]*Package, len(pb))
+	for _, p := range pb {
+		byVersion[p.Version] = p
+	}
+	same := len(pa) == len(pb)
+	for _, p := range pa {
+		q, ok := byVersion[p.Version]
+		if !ok {
+			same = false
+			continue
+		}
+		// 只比较同一算法的校验和
+		if p.Checksum != "" && p.SumType == q.SumType && p.Checksum != q.Checksum {
+			m.ChecksumDiffers = append(m.ChecksumDiffers, p.Version)
+		}
+	}
+	return m, !same || len(m.ChecksumDiffers) > 0
+}
+
+// groupByNameArch 按 name/arch 分组，组内保持从新到旧
+func groupByNameArch(ix *Index) map[nameArch][]*Package {
+	groups := make(map[nameArch][]*Package)
+	for _, list := range ix.byName {
+		for _, p := range list {
+			key := nameArch{p.Name, p.Arch}
+			groups[key] = append(groups[key], p)
+		}
+	}
+	return groups
+}
+
+func sortedKeys(groups ...map[nameArch][]*Package) []nameArch {
+	seen := make(map[nameArch]bool)
+	var keys []nameArch
+	for _, g := range groups {
+		for k := range g {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].arch < keys[j].arch
+	})
+	return keys
+}
@@ -0,0 +1,255 @@
+package deps
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"plus/internal/types"
+)
+
+type rpmEntry struct {
+	Name  string `xml:"name,attr"`
+	Flags string `xml:"flags,attr"`
+	Epoch string `xml:"epoch,attr"`
+	Ver   string `xml:"ver,attr"`
+	Rel   string `xml:"rel,attr"`
+}
+
+type rpmPackage struct {
+	Name     string         `xml:"name"`
+	Arch     string         `xml:"arch"`
+	Version  types.Version  `xml:"version"`
+	Location types.Location `xml:"location"`
+	Checksum types.Checksum `xml:"checksum"`
+	Size     struct {
+		Package int64 `xml:"package,attr"`
+	} `xml:"size"`
+	Format struct {
+		Provides []rpmEntry `xml:"provides>entry"`
+		Requires []rpmEntry `xml:"requires>entry"`
+		Files    []string   `xml:"file"`
+		License  string     `xml:"license"`
+	} `xml:"format"`
+
+	Summary     string `xml:"summary"`
+	Description string `xml:"description"`
+}
+
+var rpmFlags = map[string]string{
+	"EQ": "=",
+	"LT": "<",
+	"LE": "<=",
+	"GT": ">",
+	"GE": ">=",
+}
+
+// evr 拼接 [epoch:]ver[-rel]，epoch 为 0 时省略
+func evr(epoch, ver, rel string) string {
+	v := ver
+	if rel != "" {
+		v += "-" + rel
+	}
+	if epoch != "" && epoch != "0" {
+		v = epoch + ":" + v
+	}
+	return v
+}
+
+// ParseRPMPrimary 解析（已解压的）primary.xml 中的包及 Provides/Requires
+func ParseRPMPrimary(r io.Reader) ([]Package, error) {
+	var pkgs []Package
+
+	decoder := xml.NewDecoder(r)
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse primary.xml: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "package" {
+			continue
+		}
+
+		var rp rpmPackage
+		if err := decoder.DecodeElement(&rp, &start); err != nil {
+			return nil, fmt.Errorf("failed to parse primary.xml: %w", err)
+		}
+
+		p := Package{
+			Name:     rp.Name,
+			Version:  evr(rp.Version.Epoch, rp.Version.Ver, rp.Version.Rel),
+			Arch:     rp.Arch,
+			Location: rp.Location.Href,
+			Size:     rp.Size.Package,
+			Checksum: strings.TrimSpace(rp.Checksum.Value),
+			SumType:  rp.Checksum.Type,
+
+			Summary:     strings.TrimSpace(rp.Summary),
+			Description: strings.TrimSpace(rp.Description),
+			License:     strings.TrimSpace(rp.Format.License),
+		}
+		for _, e := range rp.Format.Provides {
+			p.Provides = append(p.Provides, Capability{Name: e.Name, Version: evr(e.Epoch, e.Ver, e.Rel)})
+		}
+		for _, f := range rp.Format.Files {
+			p.Provides = append(p.Provides, Capability{Name: f})
+		}
+		for _, e := range rp.Format.Requires {
+			// rpmlib() 由 rpm 自身提供
+			if strings.HasPrefix(e.Name, "rpmlib(") {
+				continue
+			}
+			dep := Dependency{Name: e.Name}
+			if rel, ok := rpmFlags[e.Flags]; ok {
+				dep.Relation = rel
+				dep.Version = evr(e.Epoch, e.Ver, e.Rel)
+			}
+			p.Requires = append(p.Requires, Requirement{dep})
+		}
+		pkgs = append(pkgs, p)
+	}
+
+	return pkgs, nil
+}
+
+// ParseDebPackages 解析 Packages 索引中的包及 Depends/Pre-Depends/Provides
+func ParseDebPackages(r io.Reader) ([]Package, error) {
+	var pkgs []Package
+	fields := make(map[string]string)
+	lastKey := ""
+
+	flush := func() {
+		if fields["Package"] != "" {
+			size, _ := strconv.ParseInt(fields["Size"], 10, 64)
+			p := Package{
+				Name:     fields["Package"],
+				Version:  fields["Version"],
+				Arch:     fields["Architecture"],
+				Location: strings.TrimPrefix(fields["Filename"], "./"),
+				Size:     size,
+				License:  fields["License"],
+			}
+			p.Summary, p.Description = debDescription(fields["Description"])
+			for _, sum := range []struct{ field, algo string }{{"SHA256", "sha256"}, {"SHA1", "sha1"}, {"MD5sum", "md5"}} {
+				if v := fields[sum.field]; v != "" {
+					p.Checksum, p.SumType = v, sum.algo
+					break
+				}
+			}
+			for _, req := range parseDebRelations(fields["Provides"]) {
+				for _, d := range req {
+					p.Provides = append(p.Provides, Capability{Name: d.Name, Version: d.Version})
+				}
+			}
+			p.Requires = append(p.Requires, parseDebRelations(fields["Pre-Depends"])...)
+			p.Requires = append(p.Requires, parseDebRelations(fields["Depends"])...)
+			pkgs = append(pkgs, p)
+		}
+		fields = make(map[string]string)
+		lastKey = ""
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		// 续行
+		if line[0] == ' ' || line[0] == '\t' {
+			if lastKey != "" {
+				fields[lastKey] += "\n" + strings.TrimSpace(line)
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		lastKey = key
+		fields[key] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse Packages: %w", err)
+	}
+	flush()
+
+	return pkgs, nil
+}
+
+// debDescription 拆分 Description 字段：第一行为摘要，其余为详细描述，只有 "." 的行表示空行
+func debDescription(s string) (summary, description string) {
+	summary, rest, _ := strings.Cut(s, "\n")
+	lines := strings.Split(rest, "\n")
+	for i, line := range lines {
+		if line == "." {
+			lines[i] = ""
+		}
+	}
+	return strings.TrimSpace(summary), strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+var debRelations = map[string]string{
+	"<<": "<",
+	"<=": "<=",
+	"=":  "=",
+	">=": ">=",
+	">>": ">",
+	"<":  "<=", // 旧语法
+	">":  ">=",
+}
+
+// parseDebRelations 解析 "a (>= 1.0), b | c [amd64]" 形式的关系字段
+func parseDebRelations(s string) []Requirement {
+	var reqs []Requirement
+	for _, group := range strings.Split(s, ",") {
+		var req Requirement
+		for _, alt := range strings.Split(group, "|") {
+			name, constraint := alt, ""
+			if i := strings.IndexByte(alt, '('); i >= 0 {
+				name, constraint = alt[:i], alt[i+1:]
+				if j := strings.IndexByte(constraint, ')'); j >= 0 {
+					constraint = constraint[:j]
+				}
+			}
+			// 去掉 [amd64] 架构限定和 <!nocheck> 构建配置
+			if i := strings.IndexAny(name, "[<"); i >= 0 {
+				name = name[:i]
+			}
+			name = strings.TrimSpace(name)
+			// 去掉 :any / :amd64 等多架构限定
+			if i := strings.IndexByte(name, ':'); i > 0 {
+				name = name[:i]
+			}
+			if name == "" {
+				continue
+			}
+
+			dep := Dependency{Name: name}
+			constraint = strings.TrimSpace(constraint)
+			i := 0
+			for i < len(constraint) && strings.IndexByte("<>=", constraint[i]) >= 0 {
+				i++
+			}
+			if rel, ok := debRelations[constraint[:i]]; ok {
+				dep.Relation = rel
+				dep.Version = strings.TrimSpace(constraint[i:])
+			}
+			req = append(req, dep)
+		}
+		if len(req) > 0 {
+			reqs = append(reqs, req)
+		}
+	}
+	return reqs
+}
@@ -0,0 +1,95 @@
+package deps
+
+// Dependent 反向依赖：Package 的 Requirement 可由被查询的包满足
+type Dependent struct {
+	Package     *Package
+	Requirement Requirement
+	Sole        bool // 被查询的包是该依赖在仓库内的唯一提供者
+}
+
+// Breakage 删除包后依赖无法满足的包
+type Breakage struct {
+	Package     *Package
+	Requirement Requirement
+	Depth       int // 1 表示直接依赖被删除的包
+}
+
+// RequiredBy 返回直接依赖 p 的包
+func (ix *Index) RequiredBy(p *Package) []Dependent {
+	ix.reverseOnce.Do(ix.buildReverse)
+	return ix.reverse[p]
+}
+
+func (ix *Index) buildReverse() {
+	ix.reverse = make(map[*Package][]Dependent)
+	for _, q := range ix.packages {
+		for _, req := range q.Requires {
+			providers := ix.requirementProviders(req)
+			for _, pr := range providers {
+				if pr == q {
+					continue
+				}
+				ix.reverse[pr] = append(ix.reverse[pr], Dependent{
+					Package:     q,
+					Requirement: req,
+					Sole:        len(providers) == 1,
+				})
+			}
+		}
+	}
+}
+
+// requirementProviders 返回可满足任一备选项的包（去重）
+func (ix *Index) requirementProviders(req Requirement) []*Package {
+	var result []*Package
+	seen := make(map[*Package]bool)
+	for _, dep := range req {
+		for _, p := range ix.Providers(dep) {
+			if !seen[p] {
+				seen[p] = true
+				result = append(result, p)
+			}
+		}
+	}
+	return result
+}
+
+// Impact 计算删除 p 后（递归地）依赖无法满足的包，用于删除前的影响分析
+func (ix *Index) Impact(p *Package) []Breakage {
+	ix.reverseOnce.Do(ix.buildReverse)
+
+	removed := map[*Package]bool{p: true}
+	depth := map[*Package]int{p: 0}
+	var result []Breakage
+
+	queue := []*Package{p}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, d := range ix.reverse[cur] {
+			if removed[d.Package] || !ix.broken(d.Requirement, removed) {
+				continue
+			}
+			removed[d.Package] = true
+			depth[d.Package] = depth[cur] + 1
+			result = append(result, Breakage{
+				Package:     d.Package,
+				Requirement: d.Requirement,
+				Depth:       depth[d.Package],
+			})
+			queue = append(queue, d.Package)
+		}
+	}
+	return result
+}
+
+// broken 判断依赖的所有提供者是否都已被删除
+func (ix *Index) broken(req Requirement, removed map[*Package]bool) bool {
+	for _, p := range ix.requirementProviders(req) {
+		if !removed[p] {
+			return false
+		}
+	}
+	return true
+}
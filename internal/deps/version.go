@@ -0,0 +1,224 @@
+package deps
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Comparer 比较两个版本，返回 -1、0、1
+type Comparer func(a, b string) int
+
+// VersionComparer 按仓库类型返回版本比较规则：deb 仓库使用 dpkg 规则，其他使用 rpmvercmp
+func VersionComparer(repoType string) Comparer {
+	if repoType == "deb" {
+		return CompareDebVersions
+	}
+	return CompareVersions
+}
+
+// CompareVersions 比较 [epoch:]version[-release] 形式的版本（rpmvercmp 规则，支持 ~），
+// 返回 -1、0、1
+func CompareVersions(a, b string) int {
+	ea, va := splitEpoch(a)
+	eb, vb := splitEpoch(b)
+	if ea != eb {
+		if ea < eb {
+			return -1
+		}
+		return 1
+	}
+
+	verA, relA := splitRelease(va)
+	verB, relB := splitRelease(vb)
+	if c := vercmp(verA, verB); c != 0 {
+		return c
+	}
+	// 一方未指定 release 时只比较版本
+	if relA == "" || relB == "" {
+		return 0
+	}
+	return vercmp(relA, relB)
+}
+
+func splitEpoch(v string) (int, string) {
+	if i := strings.IndexByte(v, ':'); i > 0 {
+		if e, err := strconv.Atoi(v[:i]); err == nil {
+			return e, v[i+1:]
+		}
+	}
+	return 0, v
+}
+
+func splitRelease(v string) (string, string) {
+	if i := strings.LastIndexByte(v, '-'); i > 0 {
+		return v[:i], v[i+1:]
+	}
+	return v, ""
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+func isAlpha(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+func isSeparator(r rune) bool {
+	if r == '~' {
+		return false
+	}
+	return r >= 128 || (!isDigit(byte(r)) && !isAlpha(byte(r)))
+}
+
+// vercmp 逐段比较：数字段按数值比较，字母段按字典序，数字段大于字母段，~ 小于任何内容
+func vercmp(a, b string) int {
+	for len(a) > 0 || len(b) > 0 {
+		a = strings.TrimLeftFunc(a, isSeparator)
+		b = strings.TrimLeftFunc(b, isSeparator)
+
+		aTilde := len(a) > 0 && a[0] == '~'
+		bTilde := len(b) > 0 && b[0] == '~'
+		if aTilde || bTilde {
+			if aTilde && bTilde {
+				a, b = a[1:], b[1:]
+				continue
+			}
+			if aTilde {
+				return -1
+			}
+			return 1
+		}
+
+		if len(a) == 0 || len(b) == 0 {
+			break
+		}
+
+		var segA, segB string
+		numeric := isDigit(a[0])
+		if numeric {
+			segA, a = span(a, isDigit)
+			segB, b = span(b, isDigit)
+		} else {
+			segA, a = span(a, isAlpha)
+			segB, b = span(b, isAlpha)
+		}
+
+		// 类型不同：数字段更大
+		if segB == "" {
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		if numeric {
+			segA = strings.TrimLeft(segA, "0")
+			segB = strings.TrimLeft(segB, "0")
+			if len(segA) != len(segB) {
+				if len(segA) < len(segB) {
+					return -1
+				}
+				return 1
+			}
+		}
+		if c := strings.Compare(segA, segB); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func span(s string, fn func(byte) bool) (string, string) {
+	i := 0
+	for i < len(s) && fn(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// CompareDebVersions 按 dpkg 规则比较 [epoch:]upstream[-revision] 形式的版本，返回 -1、0、1。
+// revision 从最后一个 - 处分开，没有 revision 时视为空
+func CompareDebVersions(a, b string) int {
+	ea, va := splitEpoch(a)
+	eb, vb := splitEpoch(b)
+	if ea != eb {
+		if ea < eb {
+			return -1
+		}
+		return 1
+	}
+
+	upA, revA := splitRelease(va)
+	upB, revB := splitRelease(vb)
+	if c := debVercmp(upA, upB); c != 0 {
+		return c
+	}
+	return debVercmp(revA, revB)
+}
+
+// debOrder 非数字部分的排序权重：~ 最小，其次是结尾，字母小于其他字符
+func debOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	switch c := s[i]; {
+	case c == '~':
+		return -1
+	case isDigit(c):
+		return 0
+	case isAlpha(c):
+		return int(c)
+	default:
+		return int(c) + 256
+	}
+}
+
+// debVercmp dpkg 的 verrevcmp：非数字部分按 debOrder 逐字符比较，数字部分按数值比较
+func debVercmp(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			oa, ob := debOrder(a, i), debOrder(b, j)
+			if oa != ob {
+				if oa < ob {
+					return -1
+				}
+				return 1
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		diff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if diff == 0 {
+				diff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if diff != 0 {
+			if diff < 0 {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
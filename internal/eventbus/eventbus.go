@@ -0,0 +1,253 @@
+package eventbus
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"plus/internal/eventlog"
+	"plus/internal/log"
+	"plus/internal/notify"
+	"plus/internal/types"
+)
+
+// 支持的消息系统
+const (
+	KindKafka = "kafka"
+	KindNATS  = "nats"
+)
+
+// DefaultTopic 默认的 Kafka 主题和 NATS subject 前缀
+const DefaultTopic = "plus.events"
+
+const (
+	queueSize  = 10000
+	maxBackoff = 30 * time.Second
+)
+
+// Config 消息系统的连接参数
+type Config struct {
+	Kind     string
+	Brokers  []string // host:port，依次尝试
+	Topic    string
+	Username string // Kafka SASL/PLAIN 或 NATS 用户
+	Password string
+	Token    string        // NATS 令牌
+	TLS      bool          // 使用系统根证书验证服务器
+	Timeout  time.Duration // 连接和单次发布的超时，默认 10s
+}
+
+// transport 向消息系统发布一条消息，出错后下次调用重新连接
+type transport interface {
+	publish(ctx context.Context, rec types.EventRecord, value []byte) error
+	close() error
+}
+
+// Bus 在后台按顺序发布事件。消息系统不可用时重试，队列满后丢弃新事件。
+// 配置了 database-path 时改由投递队列通过 Deliver 发布，事件在重启后也不会丢失
+type Bus struct {
+	cfg   Config
+	node  string
+	queue chan types.EventRecord
+
+	tmu sync.Mutex // transport 不能并发使用
+	t   transport
+
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	dropped int64
+}
+
+// New 检查配置并启动发布
+func New(cfg Config, node string) (*Bus, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("event publishing requires brokers")
+	}
+	for _, addr := range cfg.Brokers {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("broker %q must be host:port", addr)
+		}
+	}
+	if cfg.Topic == "" {
+		cfg.Topic = DefaultTopic
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	b := &Bus{
+		cfg:   cfg,
+		node:  node,
+		queue: make(chan types.EventRecord, queueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	switch cfg.Kind {
+	case KindKafka:
+		b.t = newKafka(cfg)
+	case KindNATS:
+		if cfg.Token != "" && cfg.Username != "" {
+			return nil, errors.New("nats accepts either a token or a username, not both")
+		}
+		if err := natsSubject(cfg.Topic); err != nil {
+			return nil, err
+		}
+		b.t = newNATS(cfg)
+	default:
+		return nil, fmt.Errorf("event publishing kind %q, expected kafka or nats", cfg.Kind)
+	}
+	go b.run()
+	return b, nil
+}
+
+// Send 实现 notify.Sender，没有事件日志时使用
+func (b *Bus) Send(e notify.Event) {
+	b.Publish(eventlog.NewRecord(e, b.node, time.Now()))
+}
+
+// Publish 把记录加入发布队列，不阻塞
+func (b *Bus) Publish(rec types.EventRecord) {
+	select {
+	case b.queue <- rec:
+	default:
+		b.mu.Lock()
+		b.dropped++
+		n := b.dropped
+		b.mu.Unlock()
+		// 只在 1、10、100... 时记录，避免消息系统长时间不可用时刷屏
+		if isPowerOf10(n) {
+			log.Logger.Warnf("Event publishing queue is full, dropped %d events so far", n)
+		}
+	}
+}
+
+func isPowerOf10(n int64) bool {
+	for n%10 == 0 && n > 1 {
+		n /= 10
+	}
+	return n == 1
+}
+
+func (b *Bus) run() {
+	defer close(b.done)
+	for {
+		select {
+		case rec := <-b.queue:
+			b.deliver(rec)
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// deliver 发布一条记录，失败时退避重试直到成功或停止
+func (b *Bus) deliver(rec types.EventRecord) {
+	value, err := rec.MarshalJSON()
+	if err != nil {
+		log.Logger.Errorf("Failed to encode %s event: %v", rec.Event, err)
+		return
+	}
+	backoff := time.Second
+	for failures := 0; ; failures++ {
+		ctx, cancel := context.WithTimeout(context.Background(), b.cfg.Timeout)
+		err := b.publish(ctx, rec, value)
+		cancel()
+		if errors.Is(err, notify.ErrPermanent) {
+			log.Logger.Errorf("Failed to publish %s event to %s: %v", rec.Event, b.cfg.Kind, err)
+			return
+		}
+		if err == nil {
+			if failures > 0 {
+				log.Logger.Infof("Event publishing to %s recovered", b.cfg.Kind)
+			}
+			return
+		}
+		if failures == 0 {
+			log.Logger.Warnf("Failed to publish %s event to %s, retrying: %v", rec.Event, b.cfg.Kind, err)
+		}
+		select {
+		case <-time.After(backoff):
+		case <-b.stop:
+			return
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (b *Bus) publish(ctx context.Context, rec types.EventRecord, value []byte) error {
+	b.tmu.Lock()
+	defer b.tmu.Unlock()
+	return b.t.publish(ctx, rec, value)
+}
+
+// Name 实现 notify.Channel
+func (b *Bus) Name() string { return b.cfg.Kind }
+
+// Targets 实现 notify.Channel，每个事件都发布到配置的主题
+func (b *Bus) Targets(notify.Event) []string { return []string{b.cfg.Topic} }
+
+// Deliver 实现 notify.Channel，发布一次，不重试
+func (b *Bus) Deliver(ctx context.Context, _ string, e notify.Event) error {
+	rec := eventlog.NewRecord(e, b.node, time.Now())
+	value, err := rec.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return b.publish(ctx, rec, value)
+}
+
+// Close 在 timeout 内发布队列中剩余的事件后断开连接
+func (b *Bus) Close(timeout time.Duration) error {
+	deadline := time.After(timeout)
+drain:
+	for len(b.queue) > 0 {
+		select {
+		case <-deadline:
+			log.Logger.Warnf("Dropping %d unpublished events on shutdown", len(b.queue))
+			break drain
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	b.shutdown()
+	b.tmu.Lock()
+	defer b.tmu.Unlock()
+	return b.t.close()
+}
+
+func (b *Bus) shutdown() {
+	b.once.Do(func() { close(b.stop) })
+	<-b.done
+}
+
+// dial 连接 addr，连接的截止时间取超时和 ctx 中较早的一个
+func dial(ctx context.Context, cfg Config, addr string) (net.Conn, error) {
+	d := net.Dialer{Timeout: cfg.Timeout}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+	return conn, nil
+}
+
+// startTLS 在连接上进行 TLS 握手
+func startTLS(conn net.Conn, addr string) (net.Conn, error) {
+	host, _, _ := net.SplitHostPort(addr)
+	tc := tls.Client(conn, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+	if err := tc.Handshake(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("tls handshake with %s: %w", addr, err)
+	}
+	return tc, nil
+}
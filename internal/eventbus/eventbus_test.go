@@ -0,0 +1,268 @@
+package eventbus
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/notify"
+	"plus/internal/types"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func listen(t *testing.T) net.Listener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return ln
+}
+
+// fakeNATS 接受 token 为 secret 的连接，把收到的消息以 "subject payload" 发到 received
+func fakeNATS(t *testing.T, received chan<- string) string {
+	ln := listen(t)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.WriteString(conn, `INFO {"server_id":"test","max_payload":1024}`+"\r\n")
+				rd := bufio.NewReader(conn)
+				for {
+					line, err := rd.ReadString('\n')
+					if err != nil {
+						return
+					}
+					line = strings.TrimRight(line, "\r\n")
+					switch {
+					case strings.HasPrefix(line, "CONNECT "):
+						if !strings.Contains(line, `"auth_token":"secret"`) {
+							io.WriteString(conn, "-ERR 'Authorization Violation'\r\n")
+							return
+						}
+					case line == "PING":
+						io.WriteString(conn, "PONG\r\n")
+					case strings.HasPrefix(line, "PUB "):
+						f := strings.Fields(line)
+						n, _ := strconv.Atoi(f[2])
+						payload := make([]byte, n+2)
+						if _, err := io.ReadFull(rd, payload); err != nil {
+							return
+						}
+						received <- f[1] + " " + string(payload[:n])
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestNATS(t *testing.T) {
+	received := make(chan string, 4)
+	addr := fakeNATS(t, received)
+
+	if _, err := New(Config{Kind: KindNATS, Brokers: []string{addr}, Topic: "plus.*"}, "node-1"); err == nil {
+		t.Error("Expected an error for a wildcard subject")
+	}
+	b, err := New(Config{Kind: KindNATS, Brokers: []string{"127.0.0.1:1", addr}, Token: "secret"}, "node-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Publish(types.EventRecord{Seq: 7, Time: "2026-10-16T09:00:00Z", Event: notify.EventPackagePublished, Repo: "prod/el9", Node: "node-1"})
+	b.Send(notify.Event{Name: notify.EventRetentionDeleted, Repo: "prod/el9", Files: []string{strings.Repeat("x", 2000)}})
+	b.Send(notify.Event{Name: notify.EventSyncFailed, Repo: "mirror/baseos"})
+
+	want := `plus.events.package.published {"seq":7,"time":"2026-10-16T09:00:00Z","event":"package.published","repo":"prod/el9","node":"node-1"}`
+	if got := wait(t, received); got != want {
+		t.Errorf("Received %s, want %s", got, want)
+	}
+	// 超过 max_payload 的事件被丢弃，不阻塞之后的事件
+	if got := wait(t, received); !strings.HasPrefix(got, `plus.events.sync.failed {"time":`) {
+		t.Errorf("Received %s", got)
+	}
+	if err := b.Close(time.Second); err != nil {
+		t.Error(err)
+	}
+}
+
+// fakeKafka 实现 Metadata v4 和 Produce v3，主题有 2 个分区，把收到的消息以 "partition key value" 发到 received
+func fakeKafka(t *testing.T, topic string, received chan<- string) string {
+	ln := listen(t)
+	_, portStr, _ := net.SplitHostPort(ln.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				for {
+					var size [4]byte
+					if _, err := io.ReadFull(conn, size[:]); err != nil {
+						return
+					}
+					req := make([]byte, binary.BigEndian.Uint32(size[:]))
+					if _, err := io.ReadFull(conn, req); err != nil {
+						return
+					}
+					r := &kafkaReader{b: req}
+					api, version, corr := r.int16(), r.int16(), r.int32()
+					r.string()
+
+					w := newKafkaBuf()
+					w.int32(corr)
+					switch {
+					case api == apiMetadata && version == metadataVersion:
+						w.int32(0)
+						w.int32(1)
+						w.int32(1)
+						w.string("127.0.0.1")
+						w.int32(int32(port))
+						w.nullableString(nil)
+						w.nullableString(nil)
+						w.int32(1)
+						w.int32(1)
+						w.int16(0)
+						w.string(topic)
+						w.bool(false)
+						w.int32(2)
+						for p := int32(0); p < 2; p++ {
+							w.int16(0)
+							w.int32(p)
+							w.int32(1)
+							w.int32(0)
+							w.int32(0)
+						}
+					case api == apiProduce && version == produceVersion:
+						r.int16()
+						if acks := r.int16(); acks != -1 {
+							t.Errorf("acks = %d", acks)
+						}
+						r.int32()
+						r.int32()
+						name := r.string()
+						r.int32()
+						partition := r.int32()
+						batch := r.next(int(r.int32()))
+						key, value, err := decodeBatch(batch)
+						if err != nil {
+							t.Error(err)
+							return
+						}
+						received <- strconv.Itoa(int(partition)) + " " + key + " " + value
+						w.int32(1)
+						w.string(name)
+						w.int32(1)
+						w.int32(partition)
+						w.int16(0)
+						w.int64(0)
+						w.int64(-1)
+						w.int32(0)
+					default:
+						t.Errorf("Unexpected request %d v%d", api, version)
+						return
+					}
+					out := newKafkaBuf()
+					out.bytes(w.b)
+					conn.Write(out.b)
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// decodeBatch 检查记录批次的 crc，返回唯一一条记录的 key 和 value
+func decodeBatch(b []byte) (string, string, error) {
+	r := &kafkaReader{b: b}
+	r.int64()
+	if n := r.int32(); int(n) != len(r.b) {
+		return "", "", io.ErrUnexpectedEOF
+	}
+	r.int32()
+	r.next(1)
+	crc := uint32(r.int32())
+	if crc32.Checksum(r.b, crc32.MakeTable(crc32.Castagnoli)) != crc {
+		return "", "", io.ErrUnexpectedEOF
+	}
+	r.next(2 + 4 + 8 + 8 + 8 + 2 + 4 + 4)
+	br := strings.NewReader(string(r.b))
+	varint := func() int64 { v, _ := binary.ReadVarint(br); return v }
+	varint() // length
+	br.ReadByte()
+	varint()
+	varint()
+	key := make([]byte, varint())
+	br.Read(key)
+	value := make([]byte, varint())
+	br.Read(value)
+	return string(key), string(value), nil
+}
+
+func TestKafka(t *testing.T) {
+	received := make(chan string, 4)
+	addr := fakeKafka(t, "repo-events", received)
+
+	b, err := New(Config{Kind: KindKafka, Brokers: []string{addr}, Topic: "repo-events"}, "node-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close(time.Second)
+	rec := types.EventRecord{Seq: 3, Time: "2026-10-16T09:00:00Z", Event: notify.EventPackagePublished, Repo: "prod/el9"}
+	b.Publish(rec)
+	b.Publish(rec)
+
+	first := wait(t, received)
+	if !strings.HasSuffix(first, ` prod/el9 {"seq":3,"time":"2026-10-16T09:00:00Z","event":"package.published","repo":"prod/el9"}`) {
+		t.Errorf("Received %s", first)
+	}
+	// 同一仓库的事件进入同一分区
+	if second := wait(t, received); second != first {
+		t.Errorf("Second message %s, want %s", second, first)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	for name, cfg := range map[string]Config{
+		"kind":    {Kind: "amqp", Brokers: []string{"localhost:5672"}},
+		"brokers": {Kind: KindKafka},
+		"port":    {Kind: KindKafka, Brokers: []string{"localhost"}},
+		"auth":    {Kind: KindNATS, Brokers: []string{"localhost:4222"}, Username: "a", Token: "b"},
+	} {
+		if _, err := New(cfg, ""); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func wait(t *testing.T, received chan string) string {
+	t.Helper()
+	select {
+	case got := <-received:
+		return got
+	case <-time.After(5 * time.Second):
+		t.Fatal("No message received")
+		return ""
+	}
+}
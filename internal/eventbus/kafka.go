@@ -0,0 +1,475 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"plus/internal/notify"
+	"plus/internal/types"
+)
+
+// Kafka 协议中用到的请求及版本，Kafka 1.0 及以上都支持
+const (
+	apiProduce          = 0
+	apiMetadata         = 3
+	apiSaslHandshake    = 17
+	apiSaslAuthenticate = 36
+
+	produceVersion  = 3
+	metadataVersion = 4
+	clientID        = "plus"
+)
+
+// 需要刷新元数据后重试的错误码
+var kafkaRetriable = map[int16]string{
+	3:  "UNKNOWN_TOPIC_OR_PARTITION",
+	5:  "LEADER_NOT_AVAILABLE",
+	6:  "NOT_LEADER_FOR_PARTITION",
+	7:  "REQUEST_TIMED_OUT",
+	19: "NOT_ENOUGH_REPLICAS",
+	20: "NOT_ENOUGH_REPLICAS_AFTER_APPEND",
+}
+
+const kafkaMessageTooLarge = 10
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// kafkaClient 只发布消息的 Kafka 客户端。消息的 key 为仓库名称，同一仓库的事件进入同一分区并保持顺序；
+// 每条消息等待所有同步副本确认（acks=all）
+type kafkaClient struct {
+	cfg   Config
+	corr  int32
+	hosts map[int32]string // broker id -> host:port
+	// leaders 各分区 leader 的 broker id，为空时需要刷新元数据
+	leaders []int32
+	conns   map[int32]*kafkaConn
+}
+
+type kafkaConn struct {
+	net.Conn
+	rd *bufio.Reader
+}
+
+func newKafka(cfg Config) *kafkaClient {
+	return &kafkaClient{cfg: cfg, conns: make(map[int32]*kafkaConn)}
+}
+
+func (c *kafkaClient) publish(ctx context.Context, rec types.EventRecord, value []byte) error {
+	if c.leaders == nil {
+		if err := c.refresh(ctx); err != nil {
+			return err
+		}
+	}
+	partition := int32(0)
+	if rec.Repo != "" {
+		h := fnv.New32a()
+		h.Write([]byte(rec.Repo))
+		partition = int32(h.Sum32() % uint32(len(c.leaders)))
+	}
+	leader := c.leaders[partition]
+	conn, err := c.conn(ctx, leader)
+	if err != nil {
+		c.leaders = nil
+		return err
+	}
+
+	ts := time.Now().UnixMilli()
+	body := newKafkaBuf()
+	body.nullableString(nil) // transactional_id
+	body.int16(-1)           // acks=all
+	body.int32(int32(c.cfg.Timeout.Milliseconds()))
+	body.int32(1)
+	body.string(c.cfg.Topic)
+	body.int32(1)
+	body.int32(partition)
+	body.bytes(recordBatch([]byte(rec.Repo), value, ts))
+
+	resp, err := c.roundTrip(ctx, leader, conn, apiProduce, produceVersion, body)
+	if err != nil {
+		return err
+	}
+	topics := resp.int32()
+	var code int16
+	for i := int32(0); i < topics && resp.err == nil; i++ {
+		resp.string()
+		for j, n := int32(0), resp.int32(); j < n && resp.err == nil; j++ {
+			resp.int32()
+			code = resp.int16()
+			resp.int64() // base_offset
+			resp.int64() // log_append_time
+		}
+	}
+	if resp.err != nil {
+		c.drop(leader)
+		return fmt.Errorf("kafka produce response: %w", resp.err)
+	}
+	switch {
+	case code == 0:
+		return nil
+	case code == kafkaMessageTooLarge:
+		return fmt.Errorf("%s event is %d bytes, too large for kafka topic %s: %w", rec.Event, len(value), c.cfg.Topic, notify.ErrPermanent)
+	case kafkaRetriable[code] != "":
+		c.leaders = nil
+		return fmt.Errorf("kafka produce to %s/%d: %s", c.cfg.Topic, partition, kafkaRetriable[code])
+	default:
+		return fmt.Errorf("kafka produce to %s/%d: error code %d", c.cfg.Topic, partition, code)
+	}
+}
+
+// refresh 从任一可用的 broker 读取主题的分区和 leader
+func (c *kafkaClient) refresh(ctx context.Context) error {
+	var errs []string
+	for _, addr := range c.cfg.Brokers {
+		conn, err := c.open(ctx, addr)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		err = c.metadata(ctx, conn)
+		conn.Close()
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", addr, err))
+	}
+	return fmt.Errorf("kafka metadata: %s", strings.Join(errs, "; "))
+}
+
+func (c *kafkaClient) metadata(ctx context.Context, conn *kafkaConn) error {
+	body := newKafkaBuf()
+	body.int32(1)
+	body.string(c.cfg.Topic)
+	body.bool(false) // allow_auto_topic_creation
+	resp, err := c.roundTrip(ctx, -1, conn, apiMetadata, metadataVersion, body)
+	if err != nil {
+		return err
+	}
+
+	resp.int32() // throttle_time_ms
+	hosts := make(map[int32]string)
+	for i, n := int32(0), resp.int32(); i < n && resp.err == nil; i++ {
+		id := resp.int32()
+		host := resp.string()
+		port := resp.int32()
+		resp.nullableString() // rack
+		hosts[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	resp.nullableString() // cluster_id
+	resp.int32()          // controller_id
+	var leaders []int32
+	var topicErr int16
+	for i, n := int32(0), resp.int32(); i < n && resp.err == nil; i++ {
+		code := resp.int16()
+		name := resp.string()
+		resp.bool() // is_internal
+		for j, m := int32(0), resp.int32(); j < m && resp.err == nil; j++ {
+			resp.int16()
+			index := resp.int32()
+			leader := resp.int32()
+			resp.int32Array() // replica_nodes
+			resp.int32Array() // isr_nodes
+			if name != c.cfg.Topic {
+				continue
+			}
+			for int(index) >= len(leaders) {
+				leaders = append(leaders, -1)
+			}
+			leaders[index] = leader
+		}
+		if name == c.cfg.Topic {
+			topicErr = code
+		}
+	}
+	if resp.err != nil {
+		return resp.err
+	}
+	if topicErr != 0 || len(leaders) == 0 {
+		return fmt.Errorf("topic %s is not available (error code %d)", c.cfg.Topic, topicErr)
+	}
+	for p, leader := range leaders {
+		if _, ok := hosts[leader]; !ok {
+			return fmt.Errorf("partition %d of %s has no leader", p, c.cfg.Topic)
+		}
+	}
+	c.hosts, c.leaders = hosts, leaders
+	return nil
+}
+
+// conn 返回到 broker 的连接，没有时新建
+func (c *kafkaClient) conn(ctx context.Context, id int32) (*kafkaConn, error) {
+	if conn, ok := c.conns[id]; ok {
+		return conn, nil
+	}
+	conn, err := c.open(ctx, c.hosts[id])
+	if err != nil {
+		return nil, err
+	}
+	c.conns[id] = conn
+	return conn, nil
+}
+
+// open 连接 broker，配置了用户时进行 SASL/PLAIN 认证
+func (c *kafkaClient) open(ctx context.Context, addr string) (*kafkaConn, error) {
+	raw, err := dial(ctx, c.cfg, addr)
+	if err != nil {
+		return nil, err
+	}
+	if c.cfg.TLS {
+		if raw, err = startTLS(raw, addr); err != nil {
+			return nil, err
+		}
+	}
+	conn := &kafkaConn{Conn: raw, rd: bufio.NewReader(raw)}
+	if c.cfg.Username == "" {
+		return conn, nil
+	}
+
+	body := newKafkaBuf()
+	body.string("PLAIN")
+	resp, err := c.roundTrip(ctx, -1, conn, apiSaslHandshake, 1, body)
+	if err == nil {
+		if code := resp.int16(); code != 0 {
+			err = fmt.Errorf("broker does not support SASL/PLAIN (error code %d)", code)
+		}
+	}
+	if err == nil {
+		body = newKafkaBuf()
+		body.bytes([]byte("\x00" + c.cfg.Username + "\x00" + c.cfg.Password))
+		resp, err = c.roundTrip(ctx, -1, conn, apiSaslAuthenticate, 0, body)
+		if err == nil {
+			code := resp.int16()
+			msg := resp.nullableString()
+			if code != 0 {
+				err = fmt.Errorf("authentication failed: %s", msg)
+			} else if resp.err != nil {
+				err = resp.err
+			}
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("kafka %s: %w", addr, err)
+	}
+	return conn, nil
+}
+
+// roundTrip 发送请求并读取响应。id 为缓存的连接所属的 broker，出错时关闭该连接；临时连接为 -1
+func (c *kafkaClient) roundTrip(ctx context.Context, id int32, conn *kafkaConn, api, version int16, body *kafkaBuf) (*kafkaReader, error) {
+	deadline := time.Now().Add(c.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	c.corr++
+	req := newKafkaBuf()
+	req.int32(0) // 长度，最后填写
+	req.int16(api)
+	req.int16(version)
+	req.int32(c.corr)
+	req.string(clientID)
+	req.b = append(req.b, body.b...)
+	binary.BigEndian.PutUint32(req.b, uint32(len(req.b)-4))
+
+	resp, err := func() (*kafkaReader, error) {
+		if _, err := conn.Write(req.b); err != nil {
+			return nil, err
+		}
+		var size [4]byte
+		if _, err := io.ReadFull(conn.rd, size[:]); err != nil {
+			return nil, err
+		}
+		n := binary.BigEndian.Uint32(size[:])
+		if n < 4 || n > 64<<20 {
+			return nil, fmt.Errorf("kafka response of %d bytes", n)
+		}
+		data := make([]byte, n)
+		if _, err := io.ReadFull(conn.rd, data); err != nil {
+			return nil, err
+		}
+		r := &kafkaReader{b: data}
+		if corr := r.int32(); corr != c.corr {
+			return nil, fmt.Errorf("kafka response for request %d, expected %d", corr, c.corr)
+		}
+		return r, nil
+	}()
+	if err != nil && id >= 0 {
+		c.drop(id)
+	}
+	return resp, err
+}
+
+func (c *kafkaClient) drop(id int32) {
+	if conn, ok := c.conns[id]; ok {
+		conn.Close()
+		delete(c.conns, id)
+	}
+}
+
+func (c *kafkaClient) close() error {
+	for id := range c.conns {
+		c.drop(id)
+	}
+	return nil
+}
+
+// recordBatch 编码只含一条记录的 v2 记录批次（magic 2），不压缩
+func recordBatch(key, value []byte, ts int64) []byte {
+	rec := newKafkaBuf()
+	rec.int8(0)   // attributes
+	rec.varint(0) // timestamp_delta
+	rec.varint(0) // offset_delta
+	rec.varBytes(key)
+	rec.varBytes(value)
+	rec.varint(0) // headers
+
+	// crc 覆盖 attributes 之后的部分
+	tail := newKafkaBuf()
+	tail.int16(0)  // attributes
+	tail.int32(0)  // last_offset_delta
+	tail.int64(ts) // first_timestamp
+	tail.int64(ts) // max_timestamp
+	tail.int64(-1) // producer_id
+	tail.int16(-1) // producer_epoch
+	tail.int32(-1) // base_sequence
+	tail.int32(1)  // records
+	tail.varint(int64(len(rec.b)))
+	tail.b = append(tail.b, rec.b...)
+
+	batch := newKafkaBuf()
+	batch.int64(0)                              // base_offset
+	batch.int32(int32(4 + 1 + 4 + len(tail.b))) // batch_length
+	batch.int32(-1)                             // partition_leader_epoch
+	batch.int8(2)                               // magic
+	batch.int32(int32(crc32.Checksum(tail.b, crc32c)))
+	batch.b = append(batch.b, tail.b...)
+	return batch.b
+}
+
+// kafkaBuf 按 Kafka 协议编码，整数为大端
+type kafkaBuf struct {
+	b []byte
+}
+
+func newKafkaBuf() *kafkaBuf { return &kafkaBuf{b: make([]byte, 0, 256)} }
+
+func (w *kafkaBuf) int8(v int8)    { w.b = append(w.b, byte(v)) }
+func (w *kafkaBuf) int16(v int16)  { w.b = binary.BigEndian.AppendUint16(w.b, uint16(v)) }
+func (w *kafkaBuf) int32(v int32)  { w.b = binary.BigEndian.AppendUint32(w.b, uint32(v)) }
+func (w *kafkaBuf) int64(v int64)  { w.b = binary.BigEndian.AppendUint64(w.b, uint64(v)) }
+func (w *kafkaBuf) varint(v int64) { w.b = binary.AppendVarint(w.b, v) }
+
+func (w *kafkaBuf) bool(v bool) {
+	if v {
+		w.int8(1)
+	} else {
+		w.int8(0)
+	}
+}
+
+func (w *kafkaBuf) string(s string) {
+	w.int16(int16(len(s)))
+	w.b = append(w.b, s...)
+}
+
+func (w *kafkaBuf) nullableString(s *string) {
+	if s == nil {
+		w.int16(-1)
+		return
+	}
+	w.string(*s)
+}
+
+func (w *kafkaBuf) bytes(p []byte) {
+	w.int32(int32(len(p)))
+	w.b = append(w.b, p...)
+}
+
+func (w *kafkaBuf) varBytes(p []byte) {
+	if p == nil {
+		w.varint(-1)
+		return
+	}
+	w.varint(int64(len(p)))
+	w.b = append(w.b, p...)
+}
+
+// kafkaReader 解码响应，数据不足时记录错误，之后的读取返回零值
+type kafkaReader struct {
+	b   []byte
+	err error
+}
+
+var errShortResponse = errors.New("short kafka response")
+
+func (r *kafkaReader) next(n int) []byte {
+	if r.err != nil || n < 0 || len(r.b) < n {
+		if r.err == nil {
+			r.err = errShortResponse
+		}
+		return nil
+	}
+	p := r.b[:n]
+	r.b = r.b[n:]
+	return p
+}
+
+func (r *kafkaReader) int16() int16 {
+	if p := r.next(2); p != nil {
+		return int16(binary.BigEndian.Uint16(p))
+	}
+	return 0
+}
+
+func (r *kafkaReader) int32() int32 {
+	if p := r.next(4); p != nil {
+		return int32(binary.BigEndian.Uint32(p))
+	}
+	return 0
+}
+
+func (r *kafkaReader) int64() int64 {
+	if p := r.next(8); p != nil {
+		return int64(binary.BigEndian.Uint64(p))
+	}
+	return 0
+}
+
+func (r *kafkaReader) bool() bool {
+	p := r.next(1)
+	return p != nil && p[0] != 0
+}
+
+func (r *kafkaReader) string() string {
+	return string(r.next(int(r.int16())))
+}
+
+func (r *kafkaReader) nullableString() string {
+	n := r.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(r.next(int(n)))
+}
+
+func (r *kafkaReader) int32Array() []int32 {
+	n := r.int32()
+	if n < 0 {
+		return nil
+	}
+	var out []int32
+	for i := int32(0); i < n && r.err == nil; i++ {
+		out = append(out, r.int32())
+	}
+	return out
+}
@@ -0,0 +1,176 @@
+package eventbus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"plus/internal/notify"
+	"plus/internal/types"
+)
+
+// natsClient 使用 NATS 文本协议发布消息。每条消息之后发送 PING，收到 PONG 说明服务器已处理该消息
+type natsClient struct {
+	cfg        Config
+	conn       net.Conn
+	rd         *bufio.Reader
+	maxPayload int64
+}
+
+func newNATS(cfg Config) *natsClient {
+	return &natsClient{cfg: cfg}
+}
+
+// subject 按事件名称发布到 <topic>.<event>，订阅者可以用通配符过滤
+func (c *natsClient) subject(rec types.EventRecord) string {
+	return c.cfg.Topic + "." + rec.Event
+}
+
+func (c *natsClient) publish(ctx context.Context, rec types.EventRecord, value []byte) error {
+	if c.conn == nil {
+		if err := c.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if c.maxPayload > 0 && int64(len(value)) > c.maxPayload {
+		return fmt.Errorf("%s event is %d bytes, nats max_payload is %d: %w", rec.Event, len(value), c.maxPayload, notify.ErrPermanent)
+	}
+	deadline := time.Now().Add(c.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	c.conn.SetDeadline(deadline)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "PUB %s %d\r\n", c.subject(rec), len(value))
+	buf.Write(value)
+	buf.WriteString("\r\nPING\r\n")
+	err := c.write(buf.Bytes())
+	if err == nil {
+		err = c.awaitPong()
+	}
+	if err != nil {
+		// 连接状态未知，下次重新连接
+		c.close()
+	}
+	return err
+}
+
+// connect 依次尝试 brokers，读取 INFO 后发送 CONNECT 并用 PING 确认认证通过
+func (c *natsClient) connect(ctx context.Context) error {
+	var errs []string
+	for _, addr := range c.cfg.Brokers {
+		err := c.handshake(ctx, addr)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return fmt.Errorf("connect to nats: %s", strings.Join(errs, "; "))
+}
+
+func (c *natsClient) handshake(ctx context.Context, addr string) error {
+	conn, err := dial(ctx, c.cfg, addr)
+	if err != nil {
+		return err
+	}
+	c.conn, c.rd = conn, bufio.NewReader(conn)
+	line, err := c.readLine()
+	if err != nil {
+		c.close()
+		return fmt.Errorf("%s: %w", addr, err)
+	}
+	var info types.NATSInfo
+	if !strings.HasPrefix(line, "INFO ") || info.UnmarshalJSON([]byte(line[5:])) != nil {
+		c.close()
+		return fmt.Errorf("%s: unexpected greeting %q", addr, line)
+	}
+	if info.TLSRequired && !c.cfg.TLS {
+		c.close()
+		return fmt.Errorf("%s requires tls", addr)
+	}
+	if c.cfg.TLS {
+		if c.conn, err = startTLS(conn, addr); err != nil {
+			c.conn = nil
+			return err
+		}
+		c.rd = bufio.NewReader(c.conn)
+	}
+	c.maxPayload = info.MaxPayload
+
+	connect, _ := (&types.NATSConnect{
+		TLSRequired: c.cfg.TLS,
+		Name:        "plus",
+		Lang:        "go",
+		Version:     "1.0",
+		User:        c.cfg.Username,
+		Pass:        c.cfg.Password,
+		AuthToken:   c.cfg.Token,
+	}).MarshalJSON()
+	msg := append([]byte("CONNECT "), connect...)
+	msg = append(msg, "\r\nPING\r\n"...)
+	err = c.write(msg)
+	if err == nil {
+		err = c.awaitPong()
+	}
+	if err != nil {
+		c.close()
+		return fmt.Errorf("%s: %w", addr, err)
+	}
+	return nil
+}
+
+func (c *natsClient) write(p []byte) error {
+	_, err := c.conn.Write(p)
+	return err
+}
+
+// awaitPong 读取到 PONG 为止，回应服务器的 PING，-ERR 为错误
+func (c *natsClient) awaitPong() error {
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if err := c.write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats: %s", strings.Trim(strings.TrimSpace(line[4:]), "'"))
+		}
+		// +OK 和后续的 INFO 忽略
+	}
+}
+
+func (c *natsClient) readLine() (string, error) {
+	line, err := c.rd.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *natsClient) close() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// natsSubject 检查 NATS subject 前缀，不能含有空白和通配符
+func natsSubject(topic string) error {
+	if strings.ContainsAny(topic, " \t\r\n*>") || strings.HasPrefix(topic, ".") || strings.HasSuffix(topic, ".") || strings.Contains(topic, "..") {
+		return fmt.Errorf("invalid nats subject %q", topic)
+	}
+	return nil
+}
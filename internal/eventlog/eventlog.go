@@ -0,0 +1,325 @@
+package eventlog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/notify"
+	"plus/internal/types"
+)
+
+// FileName 事件日志在 DatabasePath 下的文件名，每行一个 JSON 记录
+const FileName = "events.log"
+
+// DefaultRetention 事件默认保留的天数
+const DefaultRetention = 90
+
+// 只记入日志、不发送通知的事件
+const (
+	EventRepoRefreshed = "repo.refreshed"
+	EventRepoDeleted   = "repo.deleted"
+)
+
+// 每次读取的事件数
+const (
+	DefaultLimit = 1000
+	MaxLimit     = 10000
+)
+
+// Log 只追加的事件日志。文件中保存超过保留期前的事件，内存中只保存每条记录的偏移
+type Log struct {
+	path      string
+	node      string
+	retention time.Duration
+	now       func() time.Time
+	hooks     []func(rec types.EventRecord)
+
+	mu      sync.Mutex
+	f       *os.File
+	size    int64
+	first   int64   // offsets[0] 对应的 seq
+	last    int64   // 最新事件的 seq，日志为空时为 0
+	offsets []int64 // 各记录在文件中的起始位置
+}
+
+// Open 打开 dir 下的事件日志，删除超过 retention 天的事件。
+// 上次退出时未写完的最后一行会被截掉
+func Open(dir, node string, retention int) (*Log, error) {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create event log directory: %w", err)
+	}
+	l := &Log{
+		path:      filepath.Join(dir, FileName),
+		node:      node,
+		retention: time.Duration(retention) * 24 * time.Hour,
+		now:       time.Now,
+	}
+	if err := l.Compact(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// scan 逐条读取日志，返回有效部分的长度
+func scan(r io.Reader, fn func(rec *types.EventRecord, line []byte) error) (int64, error) {
+	br := bufio.NewReader(r)
+	var valid int64
+	for {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				log.Logger.Warnf("Dropping an incomplete record at the end of the event log")
+			}
+			return valid, nil
+		}
+		if err != nil {
+			return valid, err
+		}
+		var rec types.EventRecord
+		if err := rec.UnmarshalJSON(line); err != nil || rec.Seq <= 0 {
+			log.Logger.Warnf("Event log is corrupt at offset %d, dropping the rest: %v", valid, err)
+			return valid, nil
+		}
+		if err := fn(&rec, line); err != nil {
+			return valid, err
+		}
+		valid += int64(len(line))
+	}
+}
+
+// Compact 删除超过保留期的事件，最新的一条总是保留，使 seq 在重启后继续递增
+func (l *Log) Compact() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	in, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l.reopen()
+	}
+	if err != nil {
+		return fmt.Errorf("read event log: %w", err)
+	}
+	defer in.Close()
+
+	// 先找到最新的 seq，再复制未过期的记录
+	var last int64
+	if _, err := scan(in, func(rec *types.EventRecord, _ []byte) error {
+		last = rec.Seq
+		return nil
+	}); err != nil {
+		return fmt.Errorf("read event log: %w", err)
+	}
+	if _, err := in.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	cutoff := l.now().Add(-l.retention)
+	tmp := l.path + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("write event log: %w", err)
+	}
+	w := bufio.NewWriter(out)
+	dropped := 0
+	_, err = scan(in, func(rec *types.EventRecord, line []byte) error {
+		if t, err := time.Parse(time.RFC3339Nano, rec.Time); err == nil && t.Before(cutoff) && rec.Seq != last {
+			dropped++
+			return nil
+		}
+		_, err := w.Write(line)
+		return err
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, l.path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write event log: %w", err)
+	}
+	if dropped > 0 {
+		log.Logger.Infof("Removed %d events older than %s from the event log", dropped, cutoff.UTC().Format(time.RFC3339))
+	}
+	return l.reopen()
+}
+
+// reopen 打开日志用于追加并重建偏移，调用方持有锁
+func (l *Log) reopen() error {
+	if l.f != nil {
+		l.f.Close()
+	}
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open event log: %w", err)
+	}
+	l.f, l.offsets, l.first, l.size = f, nil, 0, 0
+	size, err := scan(f, func(rec *types.EventRecord, line []byte) error {
+		if l.first == 0 {
+			l.first = rec.Seq
+		} else if rec.Seq != l.last+1 {
+			return fmt.Errorf("event %d follows event %d", rec.Seq, l.last)
+		}
+		l.offsets = append(l.offsets, l.size)
+		l.last = rec.Seq
+		l.size += int64(len(line))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read event log: %w", err)
+	}
+	// 截掉未写完的记录
+	if err := f.Truncate(size); err != nil {
+		return fmt.Errorf("truncate event log: %w", err)
+	}
+	if l.first == 0 {
+		l.first = l.last + 1
+	}
+	return nil
+}
+
+// Send 实现 notify.Sender，记录事件
+func (l *Log) Send(e notify.Event) {
+	if _, err := l.Append(e); err != nil {
+		log.Logger.Warnf("Failed to record %s event for %s: %v", e.Name, e.Repo, err)
+	}
+}
+
+// NewRecord 把事件转换为记录。事件没有时间时使用 now
+func NewRecord(e notify.Event, node string, now time.Time) types.EventRecord {
+	if !e.Time.IsZero() {
+		now = e.Time
+	}
+	return types.EventRecord{
+		Seq:       e.Seq,
+		Time:      now.UTC().Format(time.RFC3339Nano),
+		Event:     e.Name,
+		Repo:      e.Repo,
+		User:      e.User,
+		Operation: e.Operation,
+		Approval:  e.Approval,
+		Files:     e.Files,
+		Count:     e.Count,
+		Message:   e.Message,
+		Node:      node,
+	}
+}
+
+// Event 把记录转换回事件
+func Event(rec types.EventRecord) notify.Event {
+	t, _ := time.Parse(time.RFC3339Nano, rec.Time)
+	return notify.Event{
+		Seq:       rec.Seq,
+		Name:      rec.Event,
+		Repo:      rec.Repo,
+		User:      rec.User,
+		Operation: rec.Operation,
+		Approval:  rec.Approval,
+		Files:     rec.Files,
+		Count:     rec.Count,
+		Message:   rec.Message,
+		Node:      rec.Node,
+		Time:      t,
+	}
+}
+
+// OnAppend 注册记录事件后的回调，回调按 seq 的顺序调用且不能阻塞，需在记录事件前注册
+func (l *Log) OnAppend(fn func(rec types.EventRecord)) {
+	l.hooks = append(l.hooks, fn)
+}
+
+// Append 记录一个事件，返回分配了 seq 的记录
+func (l *Log) Append(e notify.Event) (types.EventRecord, error) {
+	rec := NewRecord(e, l.node, l.now())
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rec.Seq = l.last + 1
+	line, err := rec.MarshalJSON()
+	if err != nil {
+		return rec, err
+	}
+	line = append(line, '\n')
+	if _, err := l.f.Write(line); err != nil {
+		// 写了一部分时截掉，保持每行一条完整记录
+		l.f.Truncate(l.size)
+		return rec, err
+	}
+	l.offsets = append(l.offsets, l.size)
+	l.size += int64(len(line))
+	l.last = rec.Seq
+	for _, fn := range l.hooks {
+		fn(rec)
+	}
+	return rec, nil
+}
+
+// Read 返回 seq 大于 since 的最多 limit 个事件、最新的 seq，以及 since 之后是否有事件已被删除
+func (l *Log) Read(since int64, limit int) ([]types.EventRecord, int64, bool, error) {
+	if limit <= 0 || limit > MaxLimit {
+		limit = DefaultLimit
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	start := since + 1
+	truncated := start < l.first && since < l.last
+	if start < l.first {
+		start = l.first
+	}
+	records := []types.EventRecord{}
+	if start > l.last {
+		return records, l.last, truncated, nil
+	}
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, l.last, truncated, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(l.offsets[start-l.first], io.SeekStart); err != nil {
+		return nil, l.last, truncated, err
+	}
+	br := bufio.NewReader(io.LimitReader(f, l.size-l.offsets[start-l.first]))
+	for len(records) < limit {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, l.last, truncated, err
+		}
+		var rec types.EventRecord
+		if err := rec.UnmarshalJSON(line); err != nil {
+			return nil, l.last, truncated, fmt.Errorf("event log is corrupt: %w", err)
+		}
+		records = append(records, rec)
+	}
+	return records, l.last, truncated, nil
+}
+
+// Close 关闭日志
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
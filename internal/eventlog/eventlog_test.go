@@ -0,0 +1,107 @@
+package eventlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/notify"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestAppendRead(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Open(dir, "node-1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := l.Append(notify.Event{Name: notify.EventPackagePublished, Repo: "prod/el9", Files: []string{"a.rpm"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, latest, truncated, err := l.Read(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != 5 || truncated || len(records) != 2 || records[0].Seq != 3 || records[1].Seq != 4 {
+		t.Fatalf("Read(2, 2) = %+v, %d, %v", records, latest, truncated)
+	}
+	if records[0].Node != "node-1" || records[0].Repo != "prod/el9" || records[0].Files[0] != "a.rpm" {
+		t.Errorf("Unexpected record %+v", records[0])
+	}
+	if records, _, _, _ := l.Read(5, 0); len(records) != 0 {
+		t.Errorf("Read(5) = %+v, want none", records)
+	}
+
+	// 模拟写到一半时退出
+	l.Close()
+	f, err := os.OpenFile(filepath.Join(dir, FileName), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"seq":6,"time":"20`)
+	f.Close()
+
+	l, err = Open(dir, "node-1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	rec, err := l.Append(notify.Event{Name: EventRepoDeleted, Repo: "prod/el9"})
+	if err != nil || rec.Seq != 6 {
+		t.Fatalf("Append after reopen = %+v, %v", rec, err)
+	}
+	if records, latest, _, err := l.Read(0, 0); err != nil || latest != 6 || len(records) != 6 || records[5].Event != EventRepoDeleted {
+		t.Errorf("Read(0) after reopen = %+v, %d, %v", records, latest, err)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Open(dir, "", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	for _, age := range []int{90, 60, 10, 1} {
+		l.Append(notify.Event{Name: notify.EventSyncFailed, Time: now.AddDate(0, 0, -age)})
+	}
+
+	if err := l.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	records, latest, truncated, err := l.Read(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != 4 || !truncated || len(records) != 2 || records[0].Seq != 3 {
+		t.Errorf("Read(0) after compact = %+v, %d, %v", records, latest, truncated)
+	}
+	if _, _, truncated, _ := l.Read(2, 0); truncated {
+		t.Error("Read(2) should not be truncated")
+	}
+
+	// 全部过期时保留最新的一条，seq 继续递增
+	now = now.AddDate(1, 0, 0)
+	if err := l.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if records, _, _, _ := l.Read(0, 0); len(records) != 1 || records[0].Seq != 4 {
+		t.Errorf("Read(0) after expiry = %+v", records)
+	}
+	if rec, _ := l.Append(notify.Event{Name: notify.EventSyncFailed}); rec.Seq != 5 {
+		t.Errorf("Append after expiry got seq %d, want 5", rec.Seq)
+	}
+}
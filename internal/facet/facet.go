@@ -0,0 +1,157 @@
+package facet
+
+import (
+	"sort"
+	"time"
+
+	"plus/internal/types"
+)
+
+// 按区间统计的字段
+const (
+	Size  = "size"
+	MTime = "mtime"
+)
+
+// maxValues 每个字段最多返回的取值数
+const maxValues = 100
+
+// bucket 一个区间，上限为 0 表示不限
+type bucket struct {
+	name  string
+	limit int64
+}
+
+var sizeBuckets = []bucket{
+	{"0-1MB", 1 << 20},
+	{"1MB-10MB", 10 << 20},
+	{"10MB-100MB", 100 << 20},
+	{"100MB-1GB", 1 << 30},
+	{"1GB+", 0},
+}
+
+var ageBuckets = []bucket{
+	{"24h", int64(24 * time.Hour)},
+	{"7d", int64(7 * 24 * time.Hour)},
+	{"30d", int64(30 * 24 * time.Hour)},
+	{"365d", int64(365 * 24 * time.Hour)},
+	{"older", 0},
+}
+
+func find(buckets []bucket, v int64) string {
+	for _, b := range buckets {
+		if b.limit == 0 || v < b.limit {
+			return b.name
+		}
+	}
+	return ""
+}
+
+func valid(buckets []bucket, name string) bool {
+	for _, b := range buckets {
+		if b.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SizeBucket 返回文件大小所在的区间
+func SizeBucket(size int64) string { return find(sizeBuckets, size) }
+
+// AgeBucket 返回 RFC3339 修改时间距 now 的区间，如 "7d" 表示 1 到 7 天前；时间未知时为空
+func AgeBucket(mtime string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339, mtime)
+	if err != nil {
+		return ""
+	}
+	return find(ageBuckets, int64(now.Sub(t)))
+}
+
+// ValidBucket 判断 name 是否为字段 field 的区间名
+func ValidBucket(field, name string) bool {
+	switch field {
+	case Size:
+		return valid(sizeBuckets, name)
+	case MTime:
+		return valid(ageBuckets, name)
+	}
+	return false
+}
+
+// Counter 统计搜索结果在各字段上的取值数量
+type Counter struct {
+	now    time.Time
+	counts map[string]map[string]int
+}
+
+// New 创建统计 fields 的 Counter，区间字段按 now 计算
+func New(fields []string, now time.Time) *Counter {
+	c := &Counter{now: now, counts: make(map[string]map[string]int, len(fields))}
+	for _, f := range fields {
+		c.counts[f] = make(map[string]int)
+	}
+	return c
+}
+
+// Add 计入字段的一个取值，未请求的字段和空值忽略
+func (c *Counter) Add(field, value string) {
+	if counts, ok := c.counts[field]; ok && value != "" {
+		counts[value]++
+	}
+}
+
+// AddArtifact 计入一个制品：repo、type、size、mtime 以及各标签
+func (c *Counter) AddArtifact(a *types.Artifact) {
+	for field := range c.counts {
+		switch field {
+		case "repo":
+			c.Add(field, a.Repo)
+		case "type":
+			c.Add(field, a.Type)
+		case Size:
+			c.Add(field, SizeBucket(a.Size))
+		case MTime:
+			c.Add(field, AgeBucket(a.ModTime, c.now))
+		default:
+			c.Add(field, a.Labels[field])
+		}
+	}
+}
+
+// Result 返回各字段的取值和数量。区间字段按区间顺序排列并包含数量为 0 的区间，
+// 其他字段按数量从多到少排列，最多 maxValues 个
+func (c *Counter) Result() map[string][]types.FacetValue {
+	result := make(map[string][]types.FacetValue, len(c.counts))
+	for field, counts := range c.counts {
+		var buckets []bucket
+		switch field {
+		case Size:
+			buckets = sizeBuckets
+		case MTime:
+			buckets = ageBuckets
+		}
+		values := []types.FacetValue{}
+		if buckets != nil {
+			for _, b := range buckets {
+				values = append(values, types.FacetValue{Value: b.name, Count: counts[b.name]})
+			}
+			result[field] = values
+			continue
+		}
+		for v, n := range counts {
+			values = append(values, types.FacetValue{Value: v, Count: n})
+		}
+		sort.Slice(values, func(i, j int) bool {
+			if values[i].Count != values[j].Count {
+				return values[i].Count > values[j].Count
+			}
+			return values[i].Value < values[j].Value
+		})
+		if len(values) > maxValues {
+			values = values[:maxValues]
+		}
+		result[field] = values
+	}
+	return result
+}
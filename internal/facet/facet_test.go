@@ -0,0 +1,75 @@
+package facet
+
+import (
+	"testing"
+	"time"
+
+	"plus/internal/types"
+)
+
+func TestBuckets(t *testing.T) {
+	sizes := map[int64]string{0: "0-1MB", 1<<20 - 1: "0-1MB", 1 << 20: "1MB-10MB", 200 << 20: "100MB-1GB", 5 << 30: "1GB+"}
+	for size, want := range sizes {
+		if got := SizeBucket(size); got != want {
+			t.Errorf("SizeBucket(%d) = %s, want %s", size, got, want)
+		}
+	}
+
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	ages := map[string]string{
+		"2026-02-28T12:00:00Z": "24h",
+		"2026-02-25T00:00:00Z": "7d",
+		"2025-12-01T00:00:00Z": "365d",
+		"2020-01-01T00:00:00Z": "older",
+		"":                     "",
+	}
+	for mtime, want := range ages {
+		if got := AgeBucket(mtime, now); got != want {
+			t.Errorf("AgeBucket(%q) = %q, want %q", mtime, got, want)
+		}
+	}
+
+	if !ValidBucket(Size, "1GB+") || !ValidBucket(MTime, "30d") || ValidBucket(Size, "30d") || ValidBucket("repo", "el9") {
+		t.Error("ValidBucket mismatch")
+	}
+}
+
+func TestCounter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	c := New([]string{"repo", "arch", Size, MTime}, now)
+	for _, a := range []types.Artifact{
+		{Repo: "el9", Type: "rpm", Size: 10, ModTime: "2026-02-28T12:00:00Z", Labels: map[string]string{"arch": "x86_64"}},
+		{Repo: "el9", Type: "rpm", Size: 20 << 20, ModTime: "2026-02-28T12:00:00Z", Labels: map[string]string{"arch": "aarch64"}},
+		{Repo: "jammy", Type: "deb", Size: 30, ModTime: "2020-01-01T00:00:00Z", Labels: map[string]string{"arch": "x86_64"}},
+		{Repo: "raw", Type: "files", Size: 40},
+	} {
+		c.AddArtifact(&a)
+	}
+	c.Add("type", "rpm")
+
+	result := c.Result()
+	if _, ok := result["type"]; ok {
+		t.Error("unrequested field counted")
+	}
+	want := map[string][]types.FacetValue{
+		"repo": {{Value: "el9", Count: 2}, {Value: "jammy", Count: 1}, {Value: "raw", Count: 1}},
+		"arch": {{Value: "x86_64", Count: 2}, {Value: "aarch64", Count: 1}},
+		Size: {{Value: "0-1MB", Count: 3}, {Value: "1MB-10MB"}, {Value: "10MB-100MB", Count: 1},
+			{Value: "100MB-1GB"}, {Value: "1GB+"}},
+		MTime: {{Value: "24h", Count: 2}, {Value: "7d"}, {Value: "30d"}, {Value: "365d"},
+			{Value: "older", Count: 1}},
+	}
+	for field, values := range want {
+		got := result[field]
+		if len(got) != len(values) {
+			t.Errorf("%s = %v, want %v", field, got, values)
+			continue
+		}
+		for i := range values {
+			if got[i] != values[i] {
+				t.Errorf("%s = %v, want %v", field, got, values)
+				break
+			}
+		}
+	}
+}
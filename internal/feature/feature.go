@@ -0,0 +1,295 @@
+// Package feature 功能开关，用于逐步上线新的子系统（暗发布），出问题时不用重新部署就能关闭。
+// 子系统用 Register 声明开关，配置设置初始状态，管理员可以在运行时修改
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/types"
+)
+
+// FileName 运行时设置在 DatabasePath 下的文件名
+const FileName = "features.json"
+
+// 开关状态的来源
+const (
+	SourceDefault = "default" // 已声明但没有配置，关闭
+	SourceConfig  = "config"
+	SourceRuntime = "runtime"
+)
+
+var (
+	ErrNotFound = errors.New("feature flag not found")
+	ErrInvalid  = errors.New("invalid feature flag")
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]string) // 名称到说明
+)
+
+// Register 声明功能开关，在子系统的 init 中调用。名称使用小写字母、数字和连字符
+func Register(name, description string) {
+	if !namePattern.MatchString(name) {
+		panic(fmt.Sprintf("feature: invalid flag name %q", name))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = description
+}
+
+// Registered 返回开关是否已声明
+func Registered(name string) bool {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	_, ok := registry[name]
+	return ok
+}
+
+func description(name string) string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return registry[name]
+}
+
+// Set 全部功能开关。运行时设置保存在内存中，有 DatabasePath 时每次修改重写其下的文件
+type Set struct {
+	file string // 为空时运行时设置在重启后丢失
+
+	mu        sync.RWMutex
+	config    map[string]types.FeatureFlag
+	overrides map[string]types.FeatureFlag
+	onChange  []func(types.FeatureFlag)
+}
+
+// New 检查配置的开关，加载 dir 下保存的运行时设置，dir 为空时不保存
+func New(cfg map[string]config.FeatureConfig, dir string) (*Set, error) {
+	s := &Set{config: make(map[string]types.FeatureFlag), overrides: make(map[string]types.FeatureFlag)}
+	for name, fc := range cfg {
+		if !namePattern.MatchString(name) {
+			return nil, fmt.Errorf("feature flag %q: name must use lowercase letters, digits and hyphens", name)
+		}
+		rollout := fc.Rollout
+		if rollout == 0 {
+			rollout = 100
+		}
+		if rollout < 1 || rollout > 100 {
+			return nil, fmt.Errorf("feature flag %s: rollout %d, expected 1-100", name, fc.Rollout)
+		}
+		s.config[name] = types.FeatureFlag{Name: name, Enabled: fc.Enabled, Rollout: rollout, Source: SourceConfig}
+	}
+	if dir == "" {
+		return s, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create feature directory: %w", err)
+	}
+	s.file = filepath.Join(dir, FileName)
+	data, err := os.ReadFile(s.file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read feature flags: %w", err)
+	}
+	var saved types.FeatureData
+	if err := saved.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("feature flags %s are corrupt: %w", s.file, err)
+	}
+	for _, f := range saved.Overrides {
+		f.Source = SourceRuntime
+		s.overrides[f.Name] = f
+	}
+	return s, nil
+}
+
+// OnChange 在运行时修改开关后调用 fn，子系统可以据此启动或停止
+func (s *Set) OnChange(fn func(types.FeatureFlag)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = append(s.onChange, fn)
+}
+
+// lookup 返回开关的当前状态，没有声明也没有配置时 ok 为 false。调用方持有锁
+func (s *Set) lookup(name string) (types.FeatureFlag, bool) {
+	f, ok := s.overrides[name]
+	if !ok {
+		f, ok = s.config[name]
+	}
+	if !ok {
+		if !Registered(name) {
+			return types.FeatureFlag{}, false
+		}
+		f = types.FeatureFlag{Name: name, Rollout: 100, Source: SourceDefault}
+	}
+	f.Description = description(name)
+	return f, true
+}
+
+// Enabled 返回开关是否对全部请求开启，部分开启时返回 false。s 为 nil 时全部关闭
+func (s *Set) Enabled(name string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, _ := s.lookup(name)
+	return f.Enabled && f.Rollout >= 100
+}
+
+// EnabledFor 返回开关对 key（如仓库名或客户端 IP）是否开启。
+// 部分开启时按开关名和 key 的哈希选择，同一个 key 的结果不变，调高百分比时已开启的 key 保持开启
+func (s *Set) EnabledFor(name, key string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, _ := s.lookup(name)
+	if !f.Enabled {
+		return false
+	}
+	return f.Rollout >= 100 || bucket(name, key) < f.Rollout
+}
+
+// bucket 返回 key 在开关 name 下的分桶（0-99）
+func bucket(name, key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return int(h.Sum32() % 100)
+}
+
+// Get 返回开关的当前状态
+func (s *Set) Get(name string) (types.FeatureFlag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, ok := s.lookup(name)
+	if !ok {
+		return types.FeatureFlag{}, ErrNotFound
+	}
+	return f, nil
+}
+
+// List 按名称返回已声明和已配置的全部开关
+func (s *Set) List() []types.FeatureFlag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make(map[string]bool)
+	registryMu.Lock()
+	for name := range registry {
+		names[name] = true
+	}
+	registryMu.Unlock()
+	for name := range s.config {
+		names[name] = true
+	}
+	for name := range s.overrides {
+		names[name] = true
+	}
+	list := make([]types.FeatureFlag, 0, len(names))
+	for name := range names {
+		if f, ok := s.lookup(name); ok {
+			list = append(list, f)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Override 在运行时修改开关，enabled 或 rollout 为 nil 时保持当前值
+func (s *Set) Override(name string, enabled *bool, rollout *int, by string) (types.FeatureFlag, error) {
+	if rollout != nil && (*rollout < 1 || *rollout > 100) {
+		return types.FeatureFlag{}, fmt.Errorf("rollout %d, expected 1-100: %w", *rollout, ErrInvalid)
+	}
+	s.mu.Lock()
+	f, ok := s.lookup(name)
+	if !ok {
+		s.mu.Unlock()
+		return types.FeatureFlag{}, ErrNotFound
+	}
+	if enabled != nil {
+		f.Enabled = *enabled
+	}
+	if rollout != nil {
+		f.Rollout = *rollout
+	}
+	f.Source = SourceRuntime
+	f.By = by
+	f.Updated = time.Now().UTC().Format(time.RFC3339)
+	f.Description = ""
+	s.overrides[name] = f
+	err := s.save()
+	f, _ = s.lookup(name)
+	hooks := s.onChange
+	s.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn(f)
+	}
+	return f, err
+}
+
+// Reset 删除运行时设置，恢复配置的状态
+func (s *Set) Reset(name string) (types.FeatureFlag, error) {
+	s.mu.Lock()
+	if _, ok := s.overrides[name]; !ok {
+		s.mu.Unlock()
+		return types.FeatureFlag{}, ErrNotFound
+	}
+	delete(s.overrides, name)
+	err := s.save()
+	f, ok := s.lookup(name)
+	hooks := s.onChange
+	s.mu.Unlock()
+
+	// 没有声明也没有配置的开关删除后不再存在，视为关闭
+	if !ok {
+		f = types.FeatureFlag{Name: name, Rollout: 100, Source: SourceDefault}
+	}
+	for _, fn := range hooks {
+		fn(f)
+	}
+	return f, err
+}
+
+// save 重写运行时设置的文件。调用方持有锁
+func (s *Set) save() error {
+	if s.file == "" {
+		return nil
+	}
+	saved := types.FeatureData{Overrides: make([]types.FeatureFlag, 0, len(s.overrides))}
+	for _, f := range s.overrides {
+		f.Source = ""
+		saved.Overrides = append(saved.Overrides, f)
+	}
+	sort.Slice(saved.Overrides, func(i, j int) bool { return saved.Overrides[i].Name < saved.Overrides[j].Name })
+
+	data, err := saved.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	tmp := s.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("write feature flags: %w", err)
+	}
+	if err := os.Rename(tmp, s.file); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write feature flags: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"plus/internal/config"
+	"plus/internal/types"
+)
+
+func TestSet(t *testing.T) {
+	Register("test-router", "New request router")
+	dir := t.TempDir()
+	s, err := New(map[string]config.FeatureConfig{
+		"test-cas":   {Enabled: true, Rollout: 30},
+		"test-async": {Enabled: true},
+	}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Enabled("test-router") || !s.Enabled("test-async") || s.Enabled("test-cas") || s.Enabled("missing") {
+		t.Errorf("Enabled = %v %v %v", s.Enabled("test-router"), s.Enabled("test-async"), s.Enabled("test-cas"))
+	}
+	// 部分开启时同一个键的结果不变，约 30% 的键开启
+	on := 0
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("repo-%d", i)
+		if s.EnabledFor("test-cas", key) != s.EnabledFor("test-cas", key) {
+			t.Fatalf("EnabledFor(%s) is not stable", key)
+		}
+		if s.EnabledFor("test-cas", key) {
+			on++
+		}
+	}
+	if on < 200 || on > 400 {
+		t.Errorf("rollout 30%% enabled %d of 1000 keys", on)
+	}
+
+	var changed []types.FeatureFlag
+	s.OnChange(func(f types.FeatureFlag) { changed = append(changed, f) })
+	enabled, rollout := true, 100
+	f, err := s.Override("test-router", &enabled, nil, "alice")
+	if err != nil || !f.Enabled || f.Source != SourceRuntime || f.Description != "New request router" || f.By != "alice" {
+		t.Errorf("Override = %+v, %v", f, err)
+	}
+	disabled := false
+	if _, err := s.Override("test-async", &disabled, &rollout, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 2 || !s.Enabled("test-router") || s.Enabled("test-async") {
+		t.Errorf("after override changed = %+v", changed)
+	}
+	bad := 0
+	if _, err := s.Override("test-cas", nil, &bad, "alice"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("rollout 0: err = %v", err)
+	}
+	if _, err := s.Override("missing", &enabled, nil, "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown flag: err = %v", err)
+	}
+
+	// 运行时设置在重启后保留
+	s, err = New(map[string]config.FeatureConfig{"test-async": {Enabled: true}}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Enabled("test-router") || s.Enabled("test-async") {
+		t.Errorf("after reload: %+v", s.List())
+	}
+	f, err = s.Reset("test-async")
+	if err != nil || !f.Enabled || f.Source != SourceConfig {
+		t.Errorf("Reset = %+v, %v", f, err)
+	}
+	if _, err := s.Reset("test-async"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Reset without override: err = %v", err)
+	}
+	if list := s.List(); len(list) != 2 || list[0].Name != "test-async" || list[1].Name != "test-router" {
+		t.Errorf("List = %+v", list)
+	}
+
+	if _, err := New(map[string]config.FeatureConfig{"x": {Rollout: 101}}, ""); err == nil {
+		t.Error("rollout 101 accepted")
+	}
+	if _, err := New(map[string]config.FeatureConfig{"New_Router": {}}, ""); err == nil {
+		t.Error("invalid name accepted")
+	}
+	if (*Set)(nil).Enabled("test-router") {
+		t.Error("nil set enabled a flag")
+	}
+}
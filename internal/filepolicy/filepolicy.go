@@ -0,0 +1,108 @@
+package filepolicy
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Policy 仓库的文件名规则：文件名（不含目录）必须匹配 allow 中的一个模式，且不能匹配 deny 中的任何模式
+type Policy struct {
+	allow   []*regexp.Regexp
+	deny    []*regexp.Regexp
+	message string // 拒绝时附加的说明
+}
+
+// Violation 上传的文件名不符合仓库的规则
+type Violation struct {
+	Filename string
+	Pattern  string // 匹配的 deny 模式，未匹配任何 allow 模式时为空
+	Allowed  []string
+	Message  string
+	Hidden   bool // 路径中有以点开头的段，这类名字留给仓库的状态文件
+}
+
+func (v *Violation) Error() string {
+	var reason string
+	if v.Hidden {
+		reason = fmt.Sprintf("file name %q has a path segment starting with a dot, which is reserved for repository state files", v.Filename)
+	} else if v.Pattern != "" {
+		reason = fmt.Sprintf("file name %q matches the denied pattern %s", v.Filename, v.Pattern)
+	} else {
+		reason = fmt.Sprintf("file name %q does not match any allowed pattern (%s)", v.Filename, strings.Join(v.Allowed, ", "))
+	}
+	if v.Message != "" {
+		reason += ": " + v.Message
+	}
+	return reason
+}
+
+// New 编译 allow 和 deny 中的正则表达式，两者都为空时返回 nil，即不限制
+func New(allow, deny []string, message string) (*Policy, error) {
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil, nil
+	}
+	p := &Policy{message: message}
+	var err error
+	if p.allow, err = compile(allow); err != nil {
+		return nil, err
+	}
+	if p.deny, err = compile(deny); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func compile(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file name pattern %q: %w", pattern, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// CheckHidden 拒绝任何一段以点开头的路径，不论仓库是否配置了规则。
+// 仓库根目录的 .notes.json、.legal-holds.json 等状态文件都以点开头，上传不能覆盖它们
+func CheckHidden(filename string) error {
+	for _, segment := range strings.Split(filename, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return &Violation{Filename: filename, Hidden: true}
+		}
+	}
+	return nil
+}
+
+// Check 检查上传的文件名，不符合规则时返回 *Violation。p 为 nil 时接受任何文件名
+func (p *Policy) Check(filename string) error {
+	if p == nil {
+		return nil
+	}
+	base := path.Base(filename)
+	for _, re := range p.deny {
+		if re.MatchString(base) {
+			return &Violation{Filename: base, Pattern: re.String(), Message: p.message}
+		}
+	}
+	if len(p.allow) == 0 {
+		return nil
+	}
+	for _, re := range p.allow {
+		if re.MatchString(base) {
+			return nil
+		}
+	}
+	return &Violation{Filename: base, Allowed: p.allowed(), Message: p.message}
+}
+
+func (p *Policy) allowed() []string {
+	patterns := make([]string, len(p.allow))
+	for i, re := range p.allow {
+		patterns[i] = re.String()
+	}
+	return patterns
+}
@@ -0,0 +1,69 @@
+package filepolicy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	p, err := New([]string{`^[a-z0-9.-]+\.el9\.x86_64\.rpm$`}, []string{`-debuginfo-`}, "EL9 x86_64 packages only")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, ok := range map[string]bool{
+		"nginx-1.24.0-1.el9.x86_64.rpm":           true,
+		"Packages/nginx-1.24.0-1.el9.x86_64.rpm":  true, // 只检查文件名
+		"nginx-1.24.0-1.el8.x86_64.rpm":           false,
+		"Nginx-1.24.0-1.el9.x86_64.rpm":           false,
+		"nginx-debuginfo-1.24.0-1.el9.x86_64.rpm": false,
+	} {
+		if err := p.Check(name); (err == nil) != ok {
+			t.Errorf("Check(%q) = %v", name, err)
+		}
+	}
+
+	var v *Violation
+	if err := p.Check("nginx-debuginfo-1.0-1.el9.x86_64.rpm"); !errors.As(err, &v) || v.Pattern != "-debuginfo-" {
+		t.Errorf("deny violation = %v", err)
+	}
+	err = p.Check("app.el8.rpm")
+	if !errors.As(err, &v) || v.Pattern != "" || !strings.Contains(err.Error(), `el9\.x86_64`) || !strings.HasSuffix(err.Error(), ": EL9 x86_64 packages only") {
+		t.Errorf("allow violation = %v", err)
+	}
+}
+
+func TestCheckHidden(t *testing.T) {
+	for name, ok := range map[string]bool{
+		"app-1.0.tar.gz":         true,
+		"docs/app.v1.tar.gz":     true,
+		".legal-holds.json":      false,
+		"sub/.attestations.json": false,
+		".approvals/1/app.rpm":   false,
+	} {
+		if err := CheckHidden(name); (err == nil) != ok {
+			t.Errorf("CheckHidden(%q) = %v", name, err)
+		}
+	}
+	var v *Violation
+	if err := CheckHidden(".notes.json"); !errors.As(err, &v) || !v.Hidden || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("hidden violation = %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if p, err := New(nil, nil, "unused"); p != nil || err != nil {
+		t.Errorf("empty policy = %v, %v", p, err)
+	}
+	var none *Policy
+	if err := none.Check("anything"); err != nil {
+		t.Errorf("nil policy: %v", err)
+	}
+	if _, err := New([]string{"("}, nil, ""); err == nil {
+		t.Error("invalid pattern accepted")
+	}
+	p, _ := New(nil, []string{`\.exe$`}, "")
+	if p.Check("tool.tar.gz") != nil || p.Check("tool.exe") == nil {
+		t.Error("deny-only policy")
+	}
+}
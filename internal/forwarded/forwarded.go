@@ -0,0 +1,172 @@
+package forwarded
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// 请求中保存 X-Forwarded-Proto/Host 的键
+const (
+	protoKey = "forwarded.proto"
+	hostKey  = "forwarded.host"
+)
+
+// Resolver 识别可信反向代理，按 X-Forwarded-For/Proto/Host 确定客户端 IP 和对外地址
+type Resolver struct {
+	trusted     []*net.IPNet
+	trustUnix   bool     // 信任 unix socket 上的连接
+	externalURL *url.URL // 配置的对外地址，优先于请求头
+}
+
+// New 解析可信代理列表（IP、CIDR 或 "unix"）和对外地址，两者都可以为空
+func New(trustedProxies []string, externalURL string) (*Resolver, error) {
+	r := &Resolver{}
+	for _, p := range trustedProxies {
+		if p == "unix" {
+			r.trustUnix = true
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", p)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			r.trusted = append(r.trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", p, err)
+		}
+		r.trusted = append(r.trusted, ipNet)
+	}
+	if externalURL != "" {
+		u, err := url.Parse(externalURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid external-url %q: must be an http(s) URL", externalURL)
+		}
+		if u.RawQuery != "" || u.Fragment != "" {
+			return nil, fmt.Errorf("invalid external-url %q: must not have a query or fragment", externalURL)
+		}
+		u.Path = strings.TrimSuffix(u.Path, "/")
+		r.externalURL = u
+	}
+	return r, nil
+}
+
+// isTrusted 判断 IP 是否属于可信代理
+func (r *Resolver) isTrusted(ip net.IP) bool {
+	for _, n := range r.trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler 来自可信代理的请求按 X-Forwarded-For 设置客户端地址，之后的限流、日志和授权都使用该地址
+func (r *Resolver) Handler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		// 同一连接上的前一个请求可能已改写地址，始终从连接地址开始
+		peer := ctx.Conn().RemoteAddr()
+		ctx.SetRemoteAddr(peer)
+		ctx.RemoveUserValue(protoKey)
+		ctx.RemoveUserValue(hostKey)
+		if r.FromTrustedProxy(peer) {
+			if client := r.clientIP(ctx); client != nil {
+				ctx.SetRemoteAddr(&net.TCPAddr{IP: client})
+			}
+			proto := strings.ToLower(firstValue(ctx.Request.Header.Peek("X-Forwarded-Proto")))
+			if proto == "http" || proto == "https" {
+				ctx.SetUserValue(protoKey, proto)
+			}
+			if host := firstValue(ctx.Request.Header.Peek("X-Forwarded-Host")); host != "" {
+				ctx.SetUserValue(hostKey, host)
+			}
+		}
+		next(ctx)
+	}
+}
+
+// FromTrustedProxy 判断连接的对端是否为可信代理
+func (r *Resolver) FromTrustedProxy(peer net.Addr) bool {
+	switch addr := peer.(type) {
+	case *net.TCPAddr:
+		return r.isTrusted(addr.IP)
+	case *net.UnixAddr:
+		return r.trustUnix
+	}
+	return false
+}
+
+// clientIP 从右向左跳过可信代理，第一个不可信的地址即客户端；都可信时取最左边的地址
+func (r *Resolver) clientIP(ctx *fasthttp.RequestCtx) net.IP {
+	var hops []string
+	ctx.Request.Header.VisitAll(func(key, value []byte) {
+		if strings.EqualFold(string(key), "X-Forwarded-For") {
+			hops = append(hops, strings.Split(string(value), ",")...)
+		}
+	})
+	var client net.IP
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			break
+		}
+		client = ip
+		if !r.isTrusted(ip) {
+			break
+		}
+	}
+	return client
+}
+
+// firstValue 返回逗号分隔的头中的第一个值
+func firstValue(v []byte) string {
+	first, _, _ := strings.Cut(string(v), ",")
+	return strings.TrimSpace(first)
+}
+
+// BaseURL 返回生成绝对地址使用的前缀（不以 / 结尾）：配置了 external-url 时使用该地址，
+// 否则使用可信代理的 X-Forwarded-Proto/Host，最后使用连接和 Host 头
+func (r *Resolver) BaseURL(ctx *fasthttp.RequestCtx) string {
+	if r != nil && r.externalURL != nil {
+		return r.externalURL.String()
+	}
+	scheme := "http"
+	if ctx.IsTLS() {
+		scheme = "https"
+	}
+	if proto, ok := ctx.UserValue(protoKey).(string); ok {
+		scheme = proto
+	}
+	host := string(ctx.Host())
+	if h, ok := ctx.UserValue(hostKey).(string); ok {
+		host = h
+	}
+	return scheme + "://" + host
+}
+
+// ExternalURL 返回配置的对外地址（不以 / 结尾），没有配置时为空
+func (r *Resolver) ExternalURL() string {
+	if r == nil || r.externalURL == nil {
+		return ""
+	}
+	return r.externalURL.String()
+}
+
+// PathPrefix 返回 external-url 中的路径前缀，如 "/plus"，没有时为空
+func (r *Resolver) PathPrefix() string {
+	if r == nil || r.externalURL == nil {
+		return ""
+	}
+	return r.externalURL.Path
+}
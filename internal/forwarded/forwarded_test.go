@@ -0,0 +1,99 @@
+package forwarded
+
+import (
+	"net"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+// request 构造来自 peer 的请求，headers 按顺序添加
+func request(peer net.Addr, headers ...string) *fasthttp.RequestCtx {
+	var req fasthttp.Request
+	req.SetRequestURI("/repo/")
+	req.Header.SetHost("plus.internal:8080")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Add(headers[i], headers[i+1])
+	}
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(&req, peer, nil)
+	return ctx
+}
+
+func tcp(ip string) net.Addr { return &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000} }
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		proxies []string
+		url     string
+	}{
+		{[]string{"10.0.0.300"}, ""},
+		{[]string{"10.0.0.0/33"}, ""},
+		{nil, "repo.example.com"},
+		{nil, "ftp://repo.example.com"},
+		{nil, "https://repo.example.com/?x=1"},
+	} {
+		if _, err := New(tt.proxies, tt.url); err == nil {
+			t.Errorf("New(%v, %q) accepted invalid config", tt.proxies, tt.url)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r, err := New([]string{"10.0.0.0/8", "192.168.1.1", "unix"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotIP, gotBase string
+	handler := r.Handler(func(ctx *fasthttp.RequestCtx) {
+		gotIP, gotBase = ctx.RemoteIP().String(), r.BaseURL(ctx)
+	})
+
+	for _, tt := range []struct {
+		name     string
+		peer     net.Addr
+		headers  []string
+		ip, base string
+	}{
+		{"untrusted peer", tcp("203.0.113.9"), []string{"X-Forwarded-For", "198.51.100.1", "X-Forwarded-Proto", "https"},
+			"203.0.113.9", "http://plus.internal:8080"},
+		{"trusted peer", tcp("10.1.2.3"), []string{"X-Forwarded-For", "198.51.100.1", "X-Forwarded-Proto", "https", "X-Forwarded-Host", "repo.example.com"},
+			"198.51.100.1", "https://repo.example.com"},
+		{"spoofed hop", tcp("10.1.2.3"), []string{"X-Forwarded-For", "1.1.1.1, 198.51.100.1, 192.168.1.1"},
+			"198.51.100.1", "http://plus.internal:8080"},
+		{"multiple headers", tcp("10.1.2.3"), []string{"X-Forwarded-For", "198.51.100.1", "X-Forwarded-For", "10.9.9.9"},
+			"198.51.100.1", "http://plus.internal:8080"},
+		{"all trusted", tcp("10.1.2.3"), []string{"X-Forwarded-For", "10.0.0.1, 10.0.0.2"},
+			"10.0.0.1", "http://plus.internal:8080"},
+		{"no header", tcp("10.1.2.3"), nil, "10.1.2.3", "http://plus.internal:8080"},
+		{"invalid proto", tcp("10.1.2.3"), []string{"X-Forwarded-Proto", "gopher"}, "10.1.2.3", "http://plus.internal:8080"},
+		{"unix socket", &net.UnixAddr{Name: "@", Net: "unix"}, []string{"X-Forwarded-For", "2001:db8::1", "X-Forwarded-Proto", "https, http"},
+			"2001:db8::1", "https://plus.internal:8080"},
+	} {
+		handler(request(tt.peer, tt.headers...))
+		if gotIP != tt.ip || gotBase != tt.base {
+			t.Errorf("%s: ip = %s, base = %s; want %s, %s", tt.name, gotIP, gotBase, tt.ip, tt.base)
+		}
+	}
+}
+
+func TestExternalURL(t *testing.T) {
+	r, err := New(nil, "https://repo.example.com/plus/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := request(tcp("10.1.2.3"), "X-Forwarded-Host", "evil.example.com")
+	r.Handler(func(*fasthttp.RequestCtx) {})(ctx)
+	if base := r.BaseURL(ctx); base != "https://repo.example.com/plus" {
+		t.Errorf("BaseURL = %s", base)
+	}
+	if prefix := r.PathPrefix(); prefix != "/plus" {
+		t.Errorf("PathPrefix = %s", prefix)
+	}
+
+	// 未配置时按连接和 Host 头生成
+	var none *Resolver
+	if base := none.BaseURL(ctx); base != "http://plus.internal:8080" || none.PathPrefix() != "" {
+		t.Errorf("nil BaseURL = %s", base)
+	}
+}
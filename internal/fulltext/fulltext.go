@@ -0,0 +1,292 @@
+package fulltext
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// BM25 参数
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// 各字段中词出现一次计入的词频
+const (
+	nameWeight        = 3
+	summaryWeight     = 2
+	descriptionWeight = 1
+)
+
+// prefixWeight 查询词作为前缀匹配（如 compress 匹配 compression）时的得分系数
+const prefixWeight = 0.5
+
+// minPrefixLen 按前缀匹配的查询词的最短长度
+const minPrefixLen = 3
+
+var stopWords = map[string]bool{
+	"an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "into": true, "is": true, "it": true, "of": true,
+	"on": true, "or": true, "that": true, "the": true, "this": true, "to": true, "with": true,
+}
+
+// Document 一个被索引的包
+type Document struct {
+	Name        string
+	Version     string
+	Arch        string
+	Path        string // 相对仓库根目录的路径
+	Summary     string
+	Description string
+	License     string
+}
+
+// Hit 一个搜索结果
+type Hit struct {
+	Document
+	Repo  string
+	Type  string
+	Score float64
+}
+
+// Query 搜索条件
+type Query struct {
+	Text    string // 所有词都要出现在名称、摘要或描述中
+	Repo    string // 仓库名，同时匹配其下的多级仓库
+	Type    string // 仓库类型
+	Arch    string // 架构，noarch 和 all 的包总是匹配
+	License string // 许可证，需完全相同
+}
+
+type posting struct {
+	doc int32
+	tf  float32
+}
+
+// segment 一个仓库的索引，建立后不再修改
+type segment struct {
+	typ      string
+	docs     []Document
+	lengths  []float32 // 按字段权重计算的文档长度
+	totalLen float64
+	terms    []string // 排序后的词，用于前缀匹配
+	postings map[string][]posting
+}
+
+func newSegment(typ string, docs []Document) *segment {
+	seg := &segment{typ: typ, docs: docs, lengths: make([]float32, len(docs)), postings: make(map[string][]posting)}
+	for i, d := range docs {
+		tf := make(map[string]float32)
+		for _, field := range []struct {
+			text   string
+			weight float32
+		}{{d.Name, nameWeight}, {d.Summary, summaryWeight}, {d.Description, descriptionWeight}} {
+			for _, t := range Tokenize(field.text) {
+				tf[t] += field.weight
+				seg.lengths[i] += field.weight
+			}
+		}
+		for t, n := range tf {
+			seg.postings[t] = append(seg.postings[t], posting{doc: int32(i), tf: n})
+		}
+		seg.totalLen += float64(seg.lengths[i])
+	}
+	seg.terms = make([]string, 0, len(seg.postings))
+	for t := range seg.postings {
+		seg.terms = append(seg.terms, t)
+	}
+	sort.Strings(seg.terms)
+	return seg
+}
+
+// expand 返回与查询词匹配的索引词及系数：词本身，以及以它为前缀的词
+func (seg *segment) expand(term string) map[string]float64 {
+	matches := make(map[string]float64)
+	if _, ok := seg.postings[term]; ok {
+		matches[term] = 1
+	}
+	if len(term) < minPrefixLen {
+		return matches
+	}
+	for i := sort.SearchStrings(seg.terms, term); i < len(seg.terms) && strings.HasPrefix(seg.terms[i], term); i++ {
+		if seg.terms[i] != term {
+			matches[seg.terms[i]] = prefixWeight
+		}
+	}
+	return matches
+}
+
+// Index 按仓库保存的包全文索引，使用 BM25 排序。仓库的索引在元数据刷新后整体替换
+type Index struct {
+	mu       sync.RWMutex
+	segments map[string]*segment
+}
+
+// New 创建空索引
+func New() *Index {
+	return &Index{segments: make(map[string]*segment)}
+}
+
+// Replace 替换仓库的全部文档
+func (x *Index) Replace(repo, typ string, docs []Document) {
+	seg := newSegment(typ, docs)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.segments[repo] = seg
+}
+
+// Drop 删除仓库的索引
+func (x *Index) Drop(repo string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	delete(x.segments, repo)
+}
+
+// Has 判断仓库是否已建立索引
+func (x *Index) Has(repo string) bool {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	_, ok := x.segments[repo]
+	return ok
+}
+
+// Docs 返回索引中的文档数
+func (x *Index) Docs() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	n := 0
+	for _, seg := range x.segments {
+		n += len(seg.docs)
+	}
+	return n
+}
+
+// Search 返回包含所有查询词的包，按得分从高到低排列，最多 limit 个（小于等于 0 时不限制），
+// 第二个返回值表示结果被截断。词频统计在所有符合仓库条件的仓库上计算，不同仓库的得分可以比较
+func (x *Index) Search(q Query, limit int) ([]Hit, bool) {
+	terms := Tokenize(q.Text)
+	if len(terms) == 0 {
+		return nil, false
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	repos := make([]string, 0, len(x.segments))
+	var docs int
+	var totalLen float64
+	for name, seg := range x.segments {
+		if q.Type != "" && seg.typ != q.Type {
+			continue
+		}
+		if q.Repo != "" && name != q.Repo && !strings.HasPrefix(name, q.Repo+"/") {
+			continue
+		}
+		repos = append(repos, name)
+		docs += len(seg.docs)
+		totalLen += seg.totalLen
+	}
+	if docs == 0 {
+		return nil, false
+	}
+	avgLen := totalLen / float64(docs)
+
+	// 每个查询词在各仓库中匹配的索引词，以及索引词的文档频率
+	expansions := make([]map[string]map[string]float64, len(terms))
+	df := make(map[string]int)
+	for i, t := range terms {
+		expansions[i] = make(map[string]map[string]float64, len(repos))
+		for _, name := range repos {
+			seg := x.segments[name]
+			matches := seg.expand(t)
+			expansions[i][name] = matches
+			for term := range matches {
+				df[term] += len(seg.postings[term])
+			}
+		}
+	}
+	idf := func(term string) float64 {
+		n := float64(df[term])
+		return math.Log(1 + (float64(docs)-n+0.5)/(n+0.5))
+	}
+
+	exact := strings.ToLower(strings.TrimSpace(q.Text))
+	var hits []Hit
+	for _, name := range repos {
+		seg := x.segments[name]
+		var scores map[int32]float64
+		for i := range terms {
+			// 同一查询词匹配多个索引词时取最高分
+			best := make(map[int32]float64)
+			for term, weight := range expansions[i][name] {
+				w := idf(term) * weight
+				for _, p := range seg.postings[term] {
+					tf := float64(p.tf)
+					norm := tf * (k1 + 1) / (tf + k1*(1-b+b*float64(seg.lengths[p.doc])/avgLen))
+					if s := w * norm; s > best[p.doc] {
+						best[p.doc] = s
+					}
+				}
+			}
+			if i == 0 {
+				scores = best
+				continue
+			}
+			for doc, s := range scores {
+				if add, ok := best[doc]; ok {
+					scores[doc] = s + add
+				} else {
+					delete(scores, doc)
+				}
+			}
+		}
+		for doc, score := range scores {
+			d := seg.docs[doc]
+			if q.Arch != "" && d.Arch != q.Arch && d.Arch != "noarch" && d.Arch != "all" {
+				continue
+			}
+			if q.License != "" && d.License != q.License {
+				continue
+			}
+			// 名称与查询完全相同的包排在前面
+			if strings.ToLower(d.Name) == exact {
+				score *= 2
+			}
+			hits = append(hits, Hit{Document: d, Repo: name, Type: seg.typ, Score: score})
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		hi, hj := hits[i], hits[j]
+		if hi.Score != hj.Score {
+			return hi.Score > hj.Score
+		}
+		if hi.Repo != hj.Repo {
+			return hi.Repo < hj.Repo
+		}
+		if hi.Name != hj.Name {
+			return hi.Name < hj.Name
+		}
+		return hi.Path < hj.Path
+	})
+	if limit > 0 && len(hits) > limit {
+		return hits[:limit], true
+	}
+	return hits, false
+}
+
+// Tokenize 把文本切分为小写的词：连续的字母和数字，去掉单个字符和常见虚词
+func Tokenize(text string) []string {
+	var tokens []string
+	for _, t := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(t)) < 2 || stopWords[t] {
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
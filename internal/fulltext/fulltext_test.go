@@ -0,0 +1,87 @@
+package fulltext
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("The GNU C Library: shared libraries (glibc-2.34), für Übersetzung")
+	want := []string{"gnu", "library", "shared", "libraries", "glibc", "34", "für", "übersetzung"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize = %q", got)
+	}
+}
+
+func newTestIndex() *Index {
+	x := New()
+	x.Replace("el9", "rpm", []Document{
+		{Name: "zstd", Arch: "x86_64", Path: "Packages/zstd.rpm", Summary: "Zstd compression library",
+			Description: "Zstandard is a fast lossless compression algorithm.", License: "BSD"},
+		{Name: "xz", Arch: "x86_64", Path: "Packages/xz.rpm", Summary: "LZMA compression utilities",
+			Description: "XZ Utils are an attempt to make LZMA compression easy to use.", License: "GPLv2+"},
+		{Name: "nginx", Arch: "x86_64", Path: "Packages/nginx.rpm", Summary: "A high performance web server and reverse proxy server",
+			Description: "Nginx is a web server and a reverse proxy server for HTTP, SMTP, POP3 and IMAP protocols."},
+		{Name: "python3-zstd", Arch: "noarch", Path: "Packages/python3-zstd.rpm", Summary: "Python bindings",
+			Description: "Bindings to the zstd library."},
+	})
+	x.Replace("el9/updates", "rpm", []Document{
+		{Name: "zstd", Arch: "aarch64", Path: "Packages/zstd-new.rpm", Summary: "Zstd compression library"},
+	})
+	x.Replace("jammy", "deb", []Document{
+		{Name: "apache2", Arch: "amd64", Path: "apache2.deb", Summary: "Apache HTTP Server",
+			Description: "The Apache HTTP Server Project's goal is to build a secure, efficient and\nextensible HTTP server."},
+	})
+	return x
+}
+
+func names(hits []Hit) []string {
+	var out []string
+	for _, h := range hits {
+		out = append(out, h.Repo+":"+h.Name)
+	}
+	return out
+}
+
+func TestSearch(t *testing.T) {
+	x := newTestIndex()
+	for _, tt := range []struct {
+		name string
+		q    Query
+		want []string
+	}{
+		{"name match ranks first", Query{Text: "zstd", Repo: "el9/updates"}, []string{"el9/updates:zstd"}},
+		{"summary and description", Query{Text: "compression", Repo: "el9", Type: "rpm", Arch: "x86_64"},
+			[]string{"el9:zstd", "el9:xz"}},
+		{"all terms required", Query{Text: "reverse proxy"}, []string{"el9:nginx"}},
+		{"prefix", Query{Text: "compr lzma"}, []string{"el9:xz"}},
+		{"type filter", Query{Text: "server", Type: "deb"}, []string{"jammy:apache2"}},
+		{"noarch matches any arch", Query{Text: "bindings", Arch: "aarch64"}, []string{"el9:python3-zstd"}},
+		{"license filter", Query{Text: "compression", License: "GPLv2+"}, []string{"el9:xz"}},
+		{"no match", Query{Text: "kernel"}, nil},
+		{"stop words only", Query{Text: "the and of"}, nil},
+	} {
+		hits, _ := x.Search(tt.q, 0)
+		if got := names(hits); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	hits, _ := x.Search(Query{Text: "zstd"}, 0)
+	if len(hits) != 3 || hits[0].Name != "zstd" || hits[2].Name != "python3-zstd" {
+		t.Errorf("zstd ranking = %v", names(hits))
+	}
+	if hits[0].Score <= hits[2].Score {
+		t.Errorf("exact name score %f <= %f", hits[0].Score, hits[2].Score)
+	}
+
+	hits, truncated := x.Search(Query{Text: "zstd"}, 1)
+	if len(hits) != 1 || !truncated {
+		t.Errorf("limit: %d hits, truncated %t", len(hits), truncated)
+	}
+
+	x.Drop("el9")
+	if x.Has("el9") || !x.Has("el9/updates") || x.Docs() != 2 {
+		t.Errorf("after drop: %d docs", x.Docs())
+	}
+}
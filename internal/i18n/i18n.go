@@ -0,0 +1,120 @@
+// Package i18n 按请求的 Accept-Language 翻译服务生成的页面和 JSON 消息，目前支持英文和中文。
+// 英文原文即消息的键，没有译文时原样返回
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Lang 支持的语言
+type Lang string
+
+const (
+	English Lang = "en"
+	Chinese Lang = "zh"
+)
+
+// catalog 一种语言的译文
+type catalog struct {
+	messages map[string]string // JSON 消息，按英文原文索引
+	patterns []pattern         // 页面中带数字等变量的片段，在 page 之前替换
+	page     *strings.Replacer // 页面片段，带有标签以免替换文件名等内容
+	tag      string            // <html lang> 的值
+}
+
+type pattern struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+var catalogs = map[Lang]*catalog{
+	Chinese: {
+		messages: zhMessages,
+		patterns: zhPatterns,
+		page:     strings.NewReplacer(zhPage...),
+		tag:      "zh-CN",
+	},
+}
+
+// Negotiate 按 Accept-Language 选择语言，没有支持的语言时返回英文。
+// zh、zh-CN、zh-Hans 等都选择中文
+func Negotiate(header string) Lang {
+	type choice struct {
+		lang Lang
+		q    float64
+	}
+	var choices []choice
+	for i, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = f
+		}
+		if q <= 0 {
+			continue
+		}
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		switch primary {
+		case "en":
+			choices = append(choices, choice{English, q - float64(i)*1e-6})
+		case "zh":
+			choices = append(choices, choice{Chinese, q - float64(i)*1e-6})
+		}
+	}
+	if len(choices) == 0 {
+		return English
+	}
+	// q 相同时按出现顺序
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+	return choices[0].lang
+}
+
+// FromRequest 返回请求的语言
+func FromRequest(ctx *fasthttp.RequestCtx) Lang {
+	return Negotiate(string(ctx.Request.Header.Peek(fasthttp.HeaderAcceptLanguage)))
+}
+
+// Message 翻译 JSON 消息。没有完全相同的译文时翻译 "消息: 详情" 中的消息部分
+func Message(lang Lang, msg string) string {
+	c := catalogs[lang]
+	if c == nil || msg == "" {
+		return msg
+	}
+	if t, ok := c.messages[msg]; ok {
+		return t
+	}
+	if head, rest, ok := strings.Cut(msg, ": "); ok {
+		if t, ok := c.messages[head]; ok {
+			return t + "：" + rest
+		}
+	}
+	return msg
+}
+
+// Page 翻译服务生成的 HTML 页面
+func Page(lang Lang, html string) string {
+	c := catalogs[lang]
+	if c == nil {
+		return html
+	}
+	for _, p := range c.patterns {
+		html = p.re.ReplaceAllString(html, p.repl)
+	}
+	html = c.page.Replace(html)
+	return strings.Replace(html, "<html>", `<html lang="`+c.tag+`">`, 1)
+}
+
+// SetHeaders 设置响应的 Content-Language，并声明响应随 Accept-Language 变化
+func SetHeaders(ctx *fasthttp.RequestCtx, lang Lang) {
+	ctx.Response.Header.Set(fasthttp.HeaderContentLanguage, string(lang))
+	ctx.Response.Header.Add(fasthttp.HeaderVary, fasthttp.HeaderAcceptLanguage)
+}
@@ -0,0 +1,68 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]Lang{
+		"":                           English,
+		"zh-CN":                      Chinese,
+		"zh-Hans-CN,zh;q=0.9":        Chinese,
+		"en-US,en;q=0.9,zh-CN;q=0.8": English,
+		"fr-FR,zh-TW;q=0.7,en;q=0.5": Chinese,
+		"de":                         English,
+		"zh;q=0,en":                  English,
+		"en;q=0.5,zh;q=0.5":          English,
+		"ZH-cn;q=bad,zh-CN;q=0.3,fr": Chinese,
+	}
+	for header, want := range cases {
+		if got := Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %s, want %s", header, got, want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	cases := []struct {
+		lang Lang
+		msg  string
+		want string
+	}{
+		{English, "Repository not found", "Repository not found"},
+		{Chinese, "Repository not found", "仓库不存在"},
+		{Chinese, "Job not found: 42", "任务不存在：42"},
+		{Chinese, "Changeset partially published: disk full", "变更集部分发布：disk full"},
+		{Chinese, "Something new", "Something new"},
+		{Chinese, "", ""},
+	}
+	for _, c := range cases {
+		if got := Message(c.lang, c.msg); got != c.want {
+			t.Errorf("Message(%s, %q) = %q, want %q", c.lang, c.msg, got, c.want)
+		}
+	}
+}
+
+func TestPage(t *testing.T) {
+	page := `<html><head><title>Index of /repo/docs</title></head><body>
+<div class="file-meta">Directory</div><a href="/repo/docs/Directory">📄 Directory</a>
+<strong>Statistics:</strong> 3 directories, 12 files (2 RPM, 1 DEB packages), Total size: 4 MB
+</body></html>`
+	if got := Page(English, page); got != page {
+		t.Errorf("English page changed: %s", got)
+	}
+	got := Page(Chinese, page)
+	for _, want := range []string{
+		`<html lang="zh-CN">`,
+		"<title>目录索引 /repo/docs</title>",
+		`<div class="file-meta">目录</div>`,
+		// 文件名不翻译
+		`<a href="/repo/docs/Directory">📄 Directory</a>`,
+		"<strong>统计：</strong> 3 个目录，12 个文件 （2 个 RPM 包，1 个 DEB 包），总大小：4 MB",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Chinese page missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package i18n
+
+import "regexp"
+
+// zhMessages JSON 消息的中文译文，以 ": " 结尾的前缀消息只写前缀
+var zhMessages = map[string]string{
+	"API key required":                                                   "需要 API 密钥",
+	"Administrator role required":                                        "需要管理员角色",
+	"Alerts are not enabled":                                             "未启用告警",
+	"An absolute path is required":                                       "需要绝对路径",
+	"Approval request failed":                                            "审批请求失败",
+	"Approval request not found":                                         "审批请求不存在",
+	"Approval request was already decided":                               "审批请求已处理",
+	"Archive contents not found":                                         "归档内容不存在",
+	"Artifact is not held":                                               "制品未被法律保留",
+	"Artifact is under legal hold":                                       "制品处于法律保留中",
+	"At least one package name is required":                              "至少需要一个包名",
+	"Attestation deleted":                                                "来源证明已删除",
+	"Attestation not found":                                              "来源证明不存在",
+	"Attestation request failed":                                         "来源证明请求失败",
+	"Attestation signature not verified":                                 "来源证明签名未通过验证",
+	"Authorization required":                                             "需要认证",
+	"Authorization service unavailable":                                  "授权服务不可用",
+	"Both a and b repositories are required":                             "需要同时指定仓库 a 和 b",
+	"Bundles are only available for rpm and deb repositories":            "只有 rpm 和 deb 仓库支持打包",
+	"Cannot generate a thumbnail for this file":                          "无法为此文件生成缩略图",
+	"Cannot read directory":                                              "无法读取目录",
+	"Cannot read the package license":                                    "无法读取包的许可证",
+	"Changeset partially published":                                      "变更集部分发布",
+	"Changeset published":                                                "变更集已发布",
+	"Chat webhook deleted":                                               "聊天 Webhook 已删除",
+	"Chat webhook failed":                                                "聊天 Webhook 失败",
+	"Chat webhook not found":                                             "聊天 Webhook 不存在",
+	"Chat webhook request failed":                                        "聊天 Webhook 请求失败",
+	"Chat webhooks require database-path":                                "聊天 Webhook 需要配置 database-path",
+	"Checksum retrieved successfully":                                    "已获取校验和",
+	"Consistency check failed":                                           "一致性检查失败",
+	"Consistency checks are only available for rpm and deb repositories": "只有 rpm 和 deb 仓库支持一致性检查",
+	"Delivery deleted":                                                   "投递已删除",
+	"Delivery not found":                                                 "投递不存在",
+	"Delivery request failed":                                            "投递请求失败",
+	"Feature flag changed but not saved":                                 "功能开关已修改但未保存",
+	"Feature flag not found":                                             "功能开关不存在",
+	"Failed to access repository":                                        "访问仓库失败",
+	"Failed to build metadata bundle":                                    "生成元数据包失败",
+	"Failed to change maintenance state":                                 "切换维护状态失败",
+	"Failed to clone repository":                                         "克隆仓库失败",
+	"Failed to create repository":                                        "创建仓库失败",
+	"Failed to delete repository":                                        "删除仓库失败",
+	"Failed to discard staged files":                                     "丢弃暂存文件失败",
+	"Failed to encode bundle manifest":                                   "编码包清单失败",
+	"Failed to generate directory listing":                               "生成目录列表失败",
+	"Failed to generate thumbnail":                                       "生成缩略图失败",
+	"Failed to get checksum":                                             "获取校验和失败",
+	"Failed to get repository info":                                      "获取仓库信息失败",
+	"Failed to list files":                                               "列出文件失败",
+	"Failed to list metadata generations":                                "列出元数据版本失败",
+	"Failed to list repositories":                                        "列出仓库失败",
+	"Failed to list staged files":                                        "列出暂存文件失败",
+	"Failed to load repositories":                                        "加载仓库失败",
+	"Failed to open uploaded file":                                       "打开上传的文件失败",
+	"Failed to parse multipart form":                                     "解析 multipart 表单失败",
+	"Failed to publish":                                                  "发布失败",
+	"Failed to read metadata":                                            "读取元数据失败",
+	"Failed to read the event log":                                       "读取事件日志失败",
+	"Failed to record approval request":                                  "记录审批请求失败",
+	"Failed to resolve bundle":                                           "解析包失败",
+	"File already exists and the repository does not allow overwrites":   "文件已存在，且仓库不允许覆盖",
+	"File name does not match the RPM header":                            "文件名与 RPM 头信息不符",
+	"File name rejected by the repository policy":                        "文件名被仓库策略拒绝",
+	"File not found":                                                     "文件不存在",
+	"Files repositories do not require metadata refresh":                 "files 仓库不需要刷新元数据",
+	"Forbidden":                                            "禁止访问",
+	"Group deleted":                                        "用户组已删除",
+	"Image is too large for a thumbnail":                   "图片过大，无法生成缩略图",
+	"Import failed":                                        "导入失败",
+	"Import path not found":                                "导入路径不存在",
+	"Install tree file uploaded successfully":              "安装树文件上传成功",
+	"Install tree validation failed":                       "安装树校验失败",
+	"Install trees are only supported in rpm repositories": "只有 rpm 仓库支持安装树",
+	"Invalid API key":                                      "API 密钥无效",
+	"Invalid JSON format":                                  "JSON 格式无效",
+	"Invalid attestation":                                  "来源证明无效",
+	"Invalid authorization format":                         "认证格式无效",
+	"Invalid browse path":                                  "浏览路径无效",
+	"Invalid checksum path format":                         "校验和路径格式无效",
+	"Invalid refresh path":                                 "刷新路径无效",
+	"Invalid repository path":                              "仓库路径无效",
+	"Invalid repository path. Use only letters, numbers, hyphens, underscores and forward slashes": "仓库路径无效，只能使用字母、数字、连字符、下划线和斜杠",
+	"Invalid repository type. Must be one of":                                                      "仓库类型无效，必须是以下之一",
+	"Invalid signature":                         "签名无效",
+	"Invalid token":                             "令牌无效",
+	"Invalid upload path":                       "上传路径无效",
+	"Job not found":                             "任务不存在",
+	"Key not found":                             "密钥不存在",
+	"LDAP sync failed":                          "LDAP 同步失败",
+	"LDAP sync is not configured":               "未配置 LDAP 同步",
+	"Legal hold released":                       "法律保留已解除",
+	"Legal hold request failed":                 "法律保留请求失败",
+	"License rejected by the repository policy": "许可证被仓库策略拒绝",
+	"Metadata bundles are only available for rpm and deb repositories": "只有 rpm 和 deb 仓库支持元数据包",
+	"Metadata generation not found":                                    "元数据版本不存在",
+	"Metadata generations are only kept for rpm and deb repositories":  "只有 rpm 和 deb 仓库保留元数据版本",
+	"Metadata not found":                       "元数据不存在",
+	"No file uploaded":                         "没有上传文件",
+	"No files uploaded":                        "没有上传文件",
+	"No repository is protected":               "没有受保护的仓库",
+	"No signing key configured for repository": "仓库未配置签名密钥",
+	"Not Found":                                "未找到",
+	"Not a valid RPM package":                  "不是有效的 RPM 包",
+	"Not found":                                "未找到",
+	"Notes removed":                            "备注已删除",
+	"Nothing is staged":                        "没有暂存的文件",
+	"Only administrators can view the usage of other users": "只有管理员可以查看其他用户的用量",
+	"Package has no notes":                                            "包没有备注",
+	"Package not found":                                               "包不存在",
+	"Package not found in repository metadata":                        "仓库元数据中没有此包",
+	"Package notes request failed":                                    "包备注请求失败",
+	"Path not found":                                                  "路径不存在",
+	"Query q must contain at least one word":                          "查询参数 q 至少需要一个词",
+	"Rate limit exceeded":                                             "请求过于频繁",
+	"Readme deleted":                                                  "仓库说明已删除",
+	"Readme request failed":                                           "仓库说明请求失败",
+	"Refresh failed":                                                  "刷新失败",
+	"Repository cloned successfully":                                  "仓库克隆成功",
+	"Repository deleted successfully":                                 "仓库删除成功",
+	"Repository has no metadata, refresh it first":                    "仓库没有元数据，请先刷新",
+	"Repository has no readme":                                        "仓库没有说明",
+	"Repository is not a proxy repository":                            "仓库不是代理仓库",
+	"Repository metadata not available, refresh the repository first": "仓库元数据不可用，请先刷新仓库",
+	"Repository metadata refreshed successfully":                      "仓库元数据刷新成功",
+	"Repository name is required":                                     "需要仓库名称",
+	"Repository not found":                                            "仓库不存在",
+	"Repository path is required":                                     "需要仓库路径",
+	"Repository type is required":                                     "需要仓库类型",
+	"Request body too large":                                          "请求体过大",
+	"Requested range not satisfiable":                                 "请求的范围无法满足",
+	"Requests must be approved by a different admin":                  "请求必须由其他管理员审批",
+	"Role deleted":                                                    "角色已删除",
+	"Scheduler is not enabled":                                        "未启用调度器",
+	"Search failed":                                                   "搜索失败",
+	"Service not ready":                                               "服务未就绪",
+	"Session revoked":                                                 "会话已吊销",
+	"Setup script is only available for rpm and deb repositories":     "只有 rpm 和 deb 仓库提供安装脚本",
+	"Signature deleted":                                               "签名已删除",
+	"Signature not found":                                             "签名不存在",
+	"Signature not verified":                                          "签名未通过验证",
+	"Specify either paths or dir":                                     "请指定 paths 或 dir 之一",
+	"Staged files discarded":                                          "暂存文件已丢弃",
+	"Staging area changed since the changeset was reviewed, list it again": "变更集审阅后暂存区已变化，请重新列出",
+	"Storage temporarily unavailable":                                      "存储暂时不可用",
+	"Test message sent":                                                    "测试消息已发送",
+	"The delivery queue requires database-path":                            "投递队列需要配置 database-path",
+	"The event log requires database-path":                                 "事件日志需要配置 database-path",
+	"Thumbnails are not enabled":                                           "未启用缩略图",
+	"Thumbnails are only available for PNG, JPEG and GIF images":           "只有 PNG、JPEG 和 GIF 图片支持缩略图",
+	"Thumbnails are only available in files repositories":                  "只有 files 仓库支持缩略图",
+	"Token revoked":                                       "令牌已吊销",
+	"Too many concurrent downloads":                       "并发下载过多",
+	"Unsupported config format. Must be one of: yum, apt": "不支持的配置格式，必须是 yum、apt 之一",
+	"Unsupported package type":                            "不支持的包类型",
+	"Upload failed":                                       "上传失败",
+	"Upload not found":                                    "上传不存在",
+	"Upload token revoked":                                "上传令牌已吊销",
+	"Upstream content failed verification":                "上游内容未通过校验",
+	"Upstream fetch failed":                               "获取上游失败",
+	"Upstream health check failed":                        "上游健康检查失败",
+	"Upstream metadata synced and verified":               "上游元数据已同步并校验",
+	"Upstream sync failed":                                "上游同步失败",
+	"Upstream verification failed":                        "上游校验失败",
+	"Usage tracking is not enabled":                       "未启用用量统计",
+	"User deleted":                                        "用户已删除",
+	"User management is not enabled":                      "未启用用户管理",
+	"enabled or rollout is required":                      "需要 enabled 或 rollout",
+	"from must not be after to":                           "from 不能晚于 to",
+	"readme text is empty":                                "仓库说明为空",
+	"since must be a non-negative sequence number":        "since 必须是非负的序号",
+	"state must be pending or dead":                       "state 必须是 pending 或 dead",
+}
+
+// zhPatterns 页面中带数字的片段
+var zhPatterns = []pattern{
+	{regexp.MustCompile(`(\d+) directories, (\d+) files`), "$1 个目录，$2 个文件"},
+	{regexp.MustCompile(`\((\d+) RPM, (\d+) DEB packages\)`), "（$1 个 RPM 包，$2 个 DEB 包）"},
+	{regexp.MustCompile(`(\d+) files, Total size: `), "$1 个文件，总大小："},
+}
+
+// zhPage 页面片段的中文译文，成对排列
+var zhPage = []string{
+	// 目录列表
+	"<title>Index of ", "<title>目录索引 ",
+	"<h1>📁 Repository: ", "<h1>📁 仓库：",
+	`<div class="file-meta">Directory</div>`, `<div class="file-meta">目录</div>`,
+	`<div class="file-meta">Parent Directory</div>`, `<div class="file-meta">上级目录</div>`,
+	`<div class="file-meta">Back to repositories</div>`, `<div class="file-meta">返回仓库列表</div>`,
+	"<em>Generated by Plus Artifacts Server</em>", "<em>由 Plus 制品服务器生成</em>",
+	"<strong>Statistics:</strong>", "<strong>统计：</strong>",
+	", Total size: ", "，总大小：",
+	"<div>Type: <strong>", "<div>类型：<strong>",
+	"<div>Path: <code>", "<div>路径：<code>",
+	"<div>Repository: <code>", "<div>仓库：<code>",
+	"<div>Files: <strong>", "<div>文件数：<strong>",
+	"🔄 Refresh Metadata", "🔄 刷新元数据",
+	"ℹ️ Repository Info", "ℹ️ 仓库信息",
+	"📤 Upload File", "📤 上传文件",
+	"🤔 Repository type unknown", "🤔 未知的仓库类型",
+	"<title>Files Repository: ", "<title>文件仓库：",
+	"<strong>Files Repository (Object Storage)</strong>", "<strong>文件仓库（对象存储）</strong>",
+	// 仓库列表
+	"<title>Repository List", "<title>仓库列表",
+	"<title>Repository: ", "<title>仓库：",
+	">← Back to Home</a>", ">← 返回首页</a>",
+	"📁 All Repositories<", "📁 全部仓库<",
+	"<li>No repositories found.</li>", "<li>没有仓库。</li>",
+	`')">Refresh</button>`, `')">刷新</button>`,
+	`">Browse</a>`, `">浏览</a>`,
+	`">Info</a>`, `">信息</a>`,
+	// 页面脚本中的提示
+	"'Refresh metadata for repository: '", "'刷新仓库元数据：'",
+	"'⏳ Refreshing...'", "'⏳ 正在刷新...'",
+	"'Repository metadata refreshed successfully!'", "'仓库元数据刷新成功！'",
+	"'Refresh failed: '", "'刷新失败：'",
+	"'Unknown error'", "'未知错误'",
+	"<h3>Upload File to Repository</h3>", "<h3>上传文件到仓库</h3>",
+	"<label>Select file:</label>", "<label>选择文件：</label>",
+	">Cancel</button>", ">取消</button>",
+	`<button type="submit">Upload</button>`, `<button type="submit">上传</button>`,
+	"textContent = 'Upload';", "textContent = '上传';",
+	"'Uploading...'", "'正在上传...'",
+	"'File uploaded successfully!'", "'文件上传成功！'",
+	"'Upload failed: '", "'上传失败：'",
+	// 首页
+	"<title>Plus Artifacts Server</title>", "<title>Plus 制品服务器</title>",
+	"<h1>Plus Artifacts Server</h1>", "<h1>Plus 制品服务器</h1>",
+	"<p>Choose your preferred interface:</p>", "<p>选择界面：</p>",
+	"📱 Modern Web UI<", "📱 现代 Web 界面<",
+	"<p>Feature-rich web interface for package management</p>", "<p>功能完整的包管理 Web 界面</p>",
+	"📁 Browse Repositories<", "📁 浏览仓库<",
+	"<p>Traditional file browser (nginx-style)</p>", "<p>传统文件浏览（nginx 风格）</p>",
+	"🔧 API Endpoints<", "🔧 API 接口<",
+	"<p>JSON API for programmatic access</p>", "<p>供程序访问的 JSON API</p>",
+}
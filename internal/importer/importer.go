@@ -0,0 +1,177 @@
+package importer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 仓库树的布局
+const (
+	LayoutYum  = "yum"  // createrepo 生成的 repodata/
+	LayoutApt  = "apt"  // reprepro、aptly 等生成的 dists/ 和 pool/
+	LayoutFlat = "flat" // dpkg-scanpackages 生成的扁平 Packages 索引
+)
+
+// Tree 扫描到的一个仓库
+type Tree struct {
+	Name     string   // 仓库名，前缀加上相对扫描根目录的路径
+	Type     string   // rpm 或 deb
+	Layout   string   // LayoutYum、LayoutApt 或 LayoutFlat
+	Dir      string   // 树的绝对路径
+	Packages []string // 包文件，相对 Dir，使用 / 分隔
+	Size     int64    // 包的总大小
+	Skipped  []string // 与已收集的包同名的包，导入后的仓库中每个文件名只有一个
+	Metadata []string // yum 树 repodata/ 下的文件，相对 Dir
+}
+
+// Preservable 现有元数据可以原样保留：元数据引用的位置与导入后包的位置相同，
+// 即所有包都直接位于 Packages/ 下
+func (t *Tree) Preservable() bool {
+	if t.Layout != LayoutYum || len(t.Metadata) == 0 || len(t.Skipped) > 0 {
+		return false
+	}
+	for _, p := range t.Packages {
+		if path.Dir(p) != "Packages" {
+			return false
+		}
+	}
+	return true
+}
+
+// Scan 在 root 下查找 yum 和 apt 仓库树，按名称排序返回。找到的树内部不再继续查找，
+// 名称为 prefix 加上树相对 root 的路径，root 本身是一个树时使用 root 的目录名
+func Scan(root, prefix string) ([]Tree, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	var trees []Tree
+	err = filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if dir != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		typ, layout := detect(dir)
+		if typ == "" {
+			return nil
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			rel = filepath.Base(root)
+		}
+		t := Tree{
+			Name:   path.Join(prefix, filepath.ToSlash(rel)),
+			Type:   typ,
+			Layout: layout,
+			Dir:    dir,
+		}
+		if err := collect(&t); err != nil {
+			return err
+		}
+		trees = append(trees, t)
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(trees, func(i, j int) bool { return trees[i].Name < trees[j].Name })
+	return trees, nil
+}
+
+// detect 根据目录中的元数据判断仓库类型和布局
+func detect(dir string) (string, string) {
+	if isFile(filepath.Join(dir, "repodata", "repomd.xml")) {
+		return "rpm", LayoutYum
+	}
+	if isDir(filepath.Join(dir, "dists")) && isDir(filepath.Join(dir, "pool")) {
+		return "deb", LayoutApt
+	}
+	if isFile(filepath.Join(dir, "Packages")) || isFile(filepath.Join(dir, "Packages.gz")) {
+		return "deb", LayoutFlat
+	}
+	return "", ""
+}
+
+// collect 收集树中的包和 yum 元数据。符号链接指向的包按普通文件处理
+func collect(t *Tree) error {
+	ext := "." + t.Type
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(t.Dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != t.Dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ext) {
+			return nil
+		}
+		info, err := os.Stat(p)
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(t.Dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if seen[d.Name()] {
+			t.Skipped = append(t.Skipped, rel)
+			return nil
+		}
+		seen[d.Name()] = true
+		t.Packages = append(t.Packages, rel)
+		t.Size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if t.Layout != LayoutYum {
+		return nil
+	}
+	entries, err := os.ReadDir(filepath.Join(t.Dir, "repodata"))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			t.Metadata = append(t.Metadata, "repodata/"+entry.Name())
+		}
+	}
+	return nil
+}
+
+func isFile(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.Mode().IsRegular()
+}
+
+func isDir(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.IsDir()
+}
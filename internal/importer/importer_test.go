@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func write(t *testing.T, root string, files ...string) {
+	t.Helper()
+	for _, f := range files {
+		p := filepath.Join(root, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(f), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	write(t, root,
+		"el9/x86_64/repodata/repomd.xml",
+		"el9/x86_64/repodata/abc-primary.xml.gz",
+		"el9/x86_64/Packages/a-1.0-1.el9.x86_64.rpm",
+		"el9/x86_64/Packages/b-1.0-1.el9.x86_64.rpm",
+		"el9/x86_64/Packages/notes.txt",
+		"el9/source/repodata/repomd.xml",
+		"el9/source/Packages/a/a-1.0-1.el9.src.rpm",
+		"ubuntu/dists/jammy/Release",
+		"ubuntu/pool/main/h/hello/hello_2.0-1_amd64.deb",
+		"ubuntu/pool/universe/h/hello/hello_2.0-1_amd64.deb",
+		"tools/Packages.gz",
+		"tools/tool_1.0_all.deb",
+		".snapshots/el9/repodata/repomd.xml",
+		"README",
+	)
+
+	trees, err := Scan(root, "legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tree := range trees {
+		names = append(names, tree.Name+" "+tree.Type+" "+tree.Layout)
+	}
+	want := []string{
+		"legacy/el9/source rpm yum",
+		"legacy/el9/x86_64 rpm yum",
+		"legacy/tools deb flat",
+		"legacy/ubuntu deb apt",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("Trees = %v, want %v", names, want)
+	}
+
+	source, x86, tools, ubuntu := trees[0], trees[1], trees[2], trees[3]
+	if !reflect.DeepEqual(x86.Packages, []string{"Packages/a-1.0-1.el9.x86_64.rpm", "Packages/b-1.0-1.el9.x86_64.rpm"}) {
+		t.Errorf("Packages = %v", x86.Packages)
+	}
+	if !reflect.DeepEqual(x86.Metadata, []string{"repodata/abc-primary.xml.gz", "repodata/repomd.xml"}) {
+		t.Errorf("Metadata = %v", x86.Metadata)
+	}
+	if x86.Size != int64(len("el9/x86_64/Packages/a-1.0-1.el9.x86_64.rpm")*2) {
+		t.Errorf("Size = %d", x86.Size)
+	}
+	if !x86.Preservable() {
+		t.Error("Packages/ tree should keep its metadata")
+	}
+	// 包位于 Packages/ 的子目录时，导入后位置改变，元数据需要重新生成
+	if source.Preservable() {
+		t.Error("Packages/a/ tree should not keep its metadata")
+	}
+	if tools.Preservable() || len(tools.Packages) != 1 {
+		t.Errorf("Flat tree = %+v", tools)
+	}
+	if !reflect.DeepEqual(ubuntu.Packages, []string{"pool/main/h/hello/hello_2.0-1_amd64.deb"}) ||
+		!reflect.DeepEqual(ubuntu.Skipped, []string{"pool/universe/h/hello/hello_2.0-1_amd64.deb"}) {
+		t.Errorf("Apt tree = %+v", ubuntu)
+	}
+}
+
+func TestScanRoot(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "baseos")
+	write(t, root, "repodata/repomd.xml", "Packages/a-1.0-1.x86_64.rpm")
+	trees, err := Scan(root, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trees) != 1 || trees[0].Name != "baseos" || trees[0].Dir != root {
+		t.Errorf("Trees = %+v", trees)
+	}
+
+	if _, err := Scan(filepath.Join(root, "missing"), ""); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
@@ -0,0 +1,617 @@
+package index
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/facet"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+)
+
+// FileName 索引日志在 DatabasePath 下的文件名
+const FileName = "index.log"
+
+// compactSlack 日志中过期的记录超过该数量后重写为快照
+const compactSlack = 4096
+
+const (
+	opRepo       = "repo"       // 仓库存在，Type 为仓库类型
+	opDrop       = "drop"       // 仓库已删除
+	opPut        = "put"        // 新增或更新制品
+	opDelete     = "delete"     // 制品已删除
+	opScanned    = "scanned"    // 仓库的制品列表完整
+	opReconciled = "reconciled" // 仓库列表完整，完成一次全量扫描
+)
+
+// Index 制品元数据索引。全部数据保存在内存中，每次修改追加一条记录到 DatabasePath 下的日志，
+// 启动时重放日志恢复；过期记录过多时把当前内容重写为快照
+type Index struct {
+	file string
+
+	mu      sync.RWMutex
+	repos   map[string]*repoState
+	dropped map[string]time.Time // 仓库删除时间，扫描期间删除的仓库不被扫描结果恢复
+	journal *os.File
+	records int // 日志中的记录数
+	live    int // 当前内容对应的记录数
+
+	ready         bool
+	reconciles    int64
+	lastReconcile time.Time
+	reconcileTime time.Duration
+	lastError     string
+}
+
+type repoState struct {
+	typ       string
+	scanned   bool
+	artifacts map[string]*types.Artifact
+	touched   map[string]time.Time // 制品最近一次写入的时间，只保存在内存中
+	created   time.Time
+}
+
+// Query 搜索条件，各条件同时满足
+type Query struct {
+	Text   string            // 路径包含该文本（不区分大小写）
+	Repo   string            // 仓库名，同时匹配其下的多级仓库
+	Type   string            // 仓库类型
+	Labels map[string]string // 标签全部相同
+	Size   string            // 大小区间，见 facet.SizeBucket
+	MTime  string            // 修改时间区间，见 facet.AgeBucket
+}
+
+// Open 打开 dir 下的索引日志并重放，日志末尾不完整的记录（写入中断）被截掉
+func Open(dir string) (*Index, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create index directory: %w", err)
+	}
+	idx := &Index{
+		file:    filepath.Join(dir, FileName),
+		repos:   make(map[string]*repoState),
+		dropped: make(map[string]time.Time),
+	}
+	f, err := os.OpenFile(idx.file, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open index: %w", err)
+	}
+	good, err := idx.replay(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Truncate(good); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("truncate index: %w", err)
+	}
+	if _, err := f.Seek(good, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	idx.journal = f
+	log.Logger.Debugf("Index loaded from %s: %d repos, %d records", idx.file, len(idx.repos), idx.records)
+	return idx, nil
+}
+
+// replay 应用日志中的记录，返回最后一条完整记录之后的偏移
+func (idx *Index) replay(r io.Reader) (int64, error) {
+	br := bufio.NewReaderSize(r, 64<<10)
+	var offset int64
+	for {
+		line, err := br.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				log.Logger.Warnf("Index %s ends with an incomplete record, discarding it", idx.file)
+			}
+			return offset, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read index: %w", err)
+		}
+		var rec types.IndexRecord
+		if err := rec.UnmarshalJSON(bytes.TrimSpace(line)); err != nil {
+			log.Logger.Warnf("Index %s has a corrupt record at offset %d, discarding the rest: %v", idx.file, offset, err)
+			return offset, nil
+		}
+		idx.apply(&rec, time.Time{})
+		idx.records++
+		offset += int64(len(line))
+	}
+}
+
+// apply 修改内存中的内容，at 为写入时间（重放时为零值）
+func (idx *Index) apply(rec *types.IndexRecord, at time.Time) {
+	switch rec.Op {
+	case opRepo:
+		if state, ok := idx.repos[rec.Repo]; ok {
+			state.typ = rec.Type
+			return
+		}
+		idx.repos[rec.Repo] = &repoState{
+			typ:       rec.Type,
+			artifacts: make(map[string]*types.Artifact),
+			touched:   make(map[string]time.Time),
+			created:   at,
+		}
+		idx.live++
+		delete(idx.dropped, rec.Repo)
+	case opDrop:
+		if state, ok := idx.repos[rec.Repo]; ok {
+			idx.live -= 1 + len(state.artifacts)
+			if state.scanned {
+				idx.live--
+			}
+			delete(idx.repos, rec.Repo)
+		}
+		if !at.IsZero() {
+			idx.dropped[rec.Repo] = at
+		}
+	case opPut:
+		state, ok := idx.repos[rec.Repo]
+		if !ok || rec.Artifact == nil {
+			return
+		}
+		if _, exists := state.artifacts[rec.Artifact.Path]; !exists {
+			idx.live++
+		}
+		a := *rec.Artifact
+		state.artifacts[a.Path] = &a
+		if !at.IsZero() {
+			state.touched[a.Path] = at
+		}
+	case opDelete:
+		state, ok := idx.repos[rec.Repo]
+		if !ok {
+			return
+		}
+		if _, exists := state.artifacts[rec.Path]; exists {
+			idx.live--
+			delete(state.artifacts, rec.Path)
+		}
+		if !at.IsZero() {
+			state.touched[rec.Path] = at
+		}
+	case opScanned:
+		if state, ok := idx.repos[rec.Repo]; ok && !state.scanned {
+			state.scanned = true
+			idx.live++
+		}
+	case opReconciled:
+		idx.ready = true
+		idx.lastReconcile, _ = time.Parse(time.RFC3339, rec.Time)
+	}
+}
+
+// write 应用记录并追加到日志，调用方持有写锁。日志写入失败时内存中的内容仍然更新，
+// 重启后由全量扫描修正
+func (idx *Index) write(recs ...*types.IndexRecord) {
+	now := time.Now()
+	var buf bytes.Buffer
+	for _, rec := range recs {
+		idx.apply(rec, now)
+		data, err := rec.MarshalJSON()
+		if err != nil {
+			idx.lastError = err.Error()
+			continue
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if idx.journal == nil || buf.Len() == 0 {
+		return
+	}
+	if _, err := idx.journal.Write(buf.Bytes()); err != nil {
+		idx.lastError = err.Error()
+		log.Logger.Warnf("Failed to append to index %s: %v", idx.file, err)
+		return
+	}
+	idx.records += len(recs)
+	if idx.records > 2*idx.live+compactSlack {
+		if err := idx.compact(); err != nil {
+			idx.lastError = err.Error()
+			log.Logger.Warnf("Failed to compact index %s: %v", idx.file, err)
+		}
+	}
+}
+
+// compact 把当前内容写入新文件后替换日志
+func (idx *Index) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(idx.file), FileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriterSize(tmp, 64<<10)
+	records := 0
+	emit := func(rec *types.IndexRecord) {
+		data, _ := rec.MarshalJSON()
+		w.Write(data)
+		w.WriteByte('\n')
+		records++
+	}
+	for _, name := range idx.sortedRepos() {
+		state := idx.repos[name]
+		emit(&types.IndexRecord{Op: opRepo, Repo: name, Type: state.typ})
+		for _, p := range sortedPaths(state.artifacts) {
+			emit(&types.IndexRecord{Op: opPut, Repo: name, Artifact: state.artifacts[p]})
+		}
+		if state.scanned {
+			emit(&types.IndexRecord{Op: opScanned, Repo: name})
+		}
+	}
+	if idx.ready {
+		emit(&types.IndexRecord{Op: opReconciled, Time: idx.lastReconcile.UTC().Format(time.RFC3339)})
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := os.Rename(tmp.Name(), idx.file); err != nil {
+		tmp.Close()
+		return err
+	}
+	idx.journal.Close()
+	idx.journal = tmp
+	idx.records = records
+	log.Logger.Debugf("Index %s compacted to %d records", idx.file, records)
+	return nil
+}
+
+// Close 关闭日志文件
+func (idx *Index) Close() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.journal == nil {
+		return nil
+	}
+	err := idx.journal.Close()
+	idx.journal = nil
+	return err
+}
+
+// Ready 是否已完成全量扫描，之前的仓库列表可能不完整
+func (idx *Index) Ready() bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.ready
+}
+
+// Repos 返回仓库名和类型
+func (idx *Index) Repos() map[string]string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	repos := make(map[string]string, len(idx.repos))
+	for name, state := range idx.repos {
+		repos[name] = state.typ
+	}
+	return repos
+}
+
+// RepoType 返回仓库类型，仓库不在索引中时返回 false
+func (idx *Index) RepoType(repo string) (string, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	if state, ok := idx.repos[repo]; ok {
+		return state.typ, true
+	}
+	return "", false
+}
+
+// List 返回仓库的全部制品（按路径排序）；仓库尚未扫描时返回 false，由调用方遍历存储
+func (idx *Index) List(repo string) ([]types.Artifact, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	state, ok := idx.repos[repo]
+	if !ok || !state.scanned {
+		return nil, false
+	}
+	artifacts := make([]types.Artifact, 0, len(state.artifacts))
+	for _, p := range sortedPaths(state.artifacts) {
+		artifacts = append(artifacts, *state.artifacts[p])
+	}
+	return artifacts, true
+}
+
+// Search 返回满足条件的制品，最多 limit 个（小于等于 0 时不限制），第二个返回值表示结果被截断
+func (idx *Index) Search(q Query, limit int) ([]types.Artifact, bool) {
+	return idx.SearchCount(q, limit, nil)
+}
+
+// SearchCount 与 Search 相同，同时把满足条件的全部制品（包括超出 limit 的）计入 counts
+func (idx *Index) SearchCount(q Query, limit int, counts *facet.Counter) ([]types.Artifact, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	var result []types.Artifact
+	truncated := false
+	for _, name := range idx.sortedRepos() {
+		state := idx.repos[name]
+		if q.Type != "" && state.typ != q.Type {
+			continue
+		}
+		if q.Repo != "" && name != q.Repo && !strings.HasPrefix(name, q.Repo+"/") {
+			continue
+		}
+		for _, p := range sortedPaths(state.artifacts) {
+			a := state.artifacts[p]
+			if !q.Match(a) {
+				continue
+			}
+			if counts != nil {
+				counts.AddArtifact(a)
+			}
+			if limit > 0 && len(result) == limit {
+				if counts == nil {
+					return result, true
+				}
+				truncated = true
+				continue
+			}
+			result = append(result, *a)
+		}
+	}
+	return result, truncated
+}
+
+// Match 判断制品是否满足条件，不使用索引的搜索也按该规则过滤
+func (q Query) Match(a *types.Artifact) bool {
+	if q.Type != "" && a.Type != q.Type {
+		return false
+	}
+	if q.Repo != "" && a.Repo != q.Repo && !strings.HasPrefix(a.Repo, q.Repo+"/") {
+		return false
+	}
+	if q.Text != "" && !strings.Contains(strings.ToLower(a.Path), strings.ToLower(q.Text)) {
+		return false
+	}
+	for k, v := range q.Labels {
+		if a.Labels[k] != v {
+			return false
+		}
+	}
+	if q.Size != "" && facet.SizeBucket(a.Size) != q.Size {
+		return false
+	}
+	if q.MTime != "" && facet.AgeBucket(a.ModTime, time.Now()) != q.MTime {
+		return false
+	}
+	return true
+}
+
+// PutRepo 记录仓库，已存在时只更新类型
+func (idx *Index) PutRepo(repo, typ string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if state, ok := idx.repos[repo]; ok && state.typ == typ {
+		return
+	}
+	idx.write(&types.IndexRecord{Op: opRepo, Repo: repo, Type: typ})
+}
+
+// DropRepo 删除仓库及其下的制品
+func (idx *Index) DropRepo(repo string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.write(&types.IndexRecord{Op: opDrop, Repo: repo})
+}
+
+// Put 写入后更新制品；仓库不在索引中时忽略，由扫描补全
+func (idx *Index) Put(a types.Artifact) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if _, ok := idx.repos[a.Repo]; !ok {
+		return
+	}
+	idx.write(&types.IndexRecord{Op: opPut, Repo: a.Repo, Artifact: &a})
+}
+
+// SetChecksum 记录后台计算的校验和
+func (idx *Index) SetChecksum(repo, p, sum string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	state, ok := idx.repos[repo]
+	if !ok {
+		return
+	}
+	a, ok := state.artifacts[p]
+	if !ok || a.Checksum == sum {
+		return
+	}
+	updated := *a
+	updated.Checksum = sum
+	idx.write(&types.IndexRecord{Op: opPut, Repo: repo, Artifact: &updated})
+}
+
+// ReplaceRepo 用扫描结果替换仓库的制品，只写入变化的部分。since 为扫描开始时间，
+// 扫描期间写入的制品和删除的仓库以写入为准
+func (idx *Index) ReplaceRepo(repo, typ string, artifacts []types.Artifact, since time.Time) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.replaceLocked(repo, typ, artifacts, since)
+}
+
+func (idx *Index) replaceLocked(repo, typ string, artifacts []types.Artifact, since time.Time) {
+	if at, ok := idx.dropped[repo]; ok && at.After(since) {
+		return
+	}
+	var recs []*types.IndexRecord
+	state, ok := idx.repos[repo]
+	if !ok || state.typ != typ {
+		recs = append(recs, &types.IndexRecord{Op: opRepo, Repo: repo, Type: typ})
+	}
+
+	seen := make(map[string]bool, len(artifacts))
+	for i := range artifacts {
+		a := &artifacts[i]
+		seen[a.Path] = true
+		if ok {
+			if state.touched[a.Path].After(since) {
+				continue
+			}
+			if old, exists := state.artifacts[a.Path]; exists {
+				if a.Checksum == "" && old.Size == a.Size {
+					// 扫描不计算校验和，大小不变时保留已知的校验和
+					a.Checksum = old.Checksum
+				}
+				if equalArtifact(old, a) {
+					continue
+				}
+			}
+		}
+		recs = append(recs, &types.IndexRecord{Op: opPut, Repo: repo, Artifact: a})
+	}
+	if ok {
+		for _, p := range sortedPaths(state.artifacts) {
+			if !seen[p] && !state.touched[p].After(since) {
+				recs = append(recs, &types.IndexRecord{Op: opDelete, Repo: repo, Path: p})
+			}
+		}
+	}
+	if !ok || !state.scanned {
+		recs = append(recs, &types.IndexRecord{Op: opScanned, Repo: repo})
+	}
+	if len(recs) > 0 {
+		idx.write(recs...)
+	}
+}
+
+// RepoScan 一个仓库的扫描结果
+type RepoScan struct {
+	Name      string
+	Type      string
+	Artifacts []types.Artifact
+}
+
+// Reconcile 用全量扫描结果修正索引：替换各仓库的制品，删除扫描中不存在的仓库
+func (idx *Index) Reconcile(scans []RepoScan, since time.Time) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	found := make(map[string]bool, len(scans))
+	for _, scan := range scans {
+		found[scan.Name] = true
+		idx.replaceLocked(scan.Name, scan.Type, scan.Artifacts, since)
+	}
+	for _, name := range idx.sortedRepos() {
+		if found[name] {
+			continue
+		}
+		// 扫描开始后创建或写入的仓库保留
+		if recent(idx.repos[name], since) {
+			continue
+		}
+		idx.write(&types.IndexRecord{Op: opDrop, Repo: name})
+	}
+
+	now := time.Now()
+	idx.write(&types.IndexRecord{Op: opReconciled, Time: now.UTC().Format(time.RFC3339)})
+	idx.lastReconcile = now
+	idx.reconcileTime = now.Sub(since)
+	idx.reconciles++
+	// 扫描期间的写入时间只用于本次合并
+	for name, at := range idx.dropped {
+		if !at.After(since) {
+			delete(idx.dropped, name)
+		}
+	}
+}
+
+// ReconcileFailed 记录扫描失败
+func (idx *Index) ReconcileFailed(err error) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.lastError = err.Error()
+}
+
+// Metrics 返回索引统计
+func (idx *Index) Metrics() *types.IndexMetrics {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	m := &types.IndexMetrics{
+		Ready:            idx.ready,
+		Repos:            len(idx.repos),
+		JournalRecords:   idx.records,
+		Reconciles:       idx.reconciles,
+		ReconcileSeconds: idx.reconcileTime.Seconds(),
+		LastError:        idx.lastError,
+	}
+	if !idx.lastReconcile.IsZero() {
+		m.LastReconcile = idx.lastReconcile.UTC().Format(time.RFC3339)
+	}
+	for _, state := range idx.repos {
+		m.Artifacts += len(state.artifacts)
+		for _, a := range state.artifacts {
+			m.Bytes += a.Size
+		}
+	}
+	return m
+}
+
+func recent(state *repoState, since time.Time) bool {
+	for _, at := range state.touched {
+		if at.After(since) {
+			return true
+		}
+	}
+	return state.created.After(since)
+}
+
+func equalArtifact(a, b *types.Artifact) bool {
+	if a.Repo != b.Repo || a.Type != b.Type || a.Path != b.Path || a.Size != b.Size ||
+		a.Checksum != b.Checksum || a.ModTime != b.ModTime || len(a.Labels) != len(b.Labels) {
+		return false
+	}
+	for k, v := range a.Labels {
+		if b.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (idx *Index) sortedRepos() []string {
+	names := make([]string, 0, len(idx.repos))
+	for name := range idx.repos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedPaths(artifacts map[string]*types.Artifact) []string {
+	paths := make([]string, 0, len(artifacts))
+	for p := range artifacts {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Labels 根据仓库类型和文件名生成制品标签：扩展名，rpm、deb 包的架构
+func Labels(typ, p string) map[string]string {
+	name := path.Base(p)
+	labels := make(map[string]string)
+	if ext := strings.TrimPrefix(path.Ext(name), "."); ext != "" {
+		labels["ext"] = strings.ToLower(ext)
+	}
+	if (typ == "rpm" && strings.HasSuffix(name, ".rpm")) || (typ == "deb" && strings.HasSuffix(name, ".deb")) {
+		if arch := utils.PackageArch(name); arch != "" {
+			labels["arch"] = arch
+		}
+	}
+	return labels
+}
@@ -0,0 +1,186 @@
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"plus/internal/facet"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func artifact(repo, p string, size int64) types.Artifact {
+	return types.Artifact{Repo: repo, Type: "rpm", Path: p, Size: size, Labels: Labels("rpm", p)}
+}
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+	idx, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx.PutRepo("centos", "rpm")
+	idx.ReplaceRepo("centos", "rpm", []types.Artifact{artifact("centos", "a-1-1.x86_64.rpm", 10)}, time.Now())
+	idx.Put(artifact("centos", "b-1-1.noarch.rpm", 20))
+	idx.SetChecksum("centos", "b-1-1.noarch.rpm", "abc")
+	idx.Reconcile([]RepoScan{{Name: "centos", Type: "rpm", Artifacts: []types.Artifact{
+		artifact("centos", "a-1-1.x86_64.rpm", 10), artifact("centos", "b-1-1.noarch.rpm", 20),
+	}}}, time.Now())
+	idx.Close()
+
+	// 写入中断留下的半条记录在重新打开时被截掉
+	f, _ := os.OpenFile(filepath.Join(dir, FileName), os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString(`{"op":"put","repo":"cen`)
+	f.Close()
+
+	idx, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+	if !idx.Ready() {
+		t.Error("reconciled index not ready after replay")
+	}
+	list, ok := idx.List("centos")
+	if !ok || len(list) != 2 {
+		t.Fatalf("List = %v, %v", list, ok)
+	}
+	if list[1].Checksum != "abc" {
+		t.Errorf("checksum lost on rescan: %+v", list[1])
+	}
+	idx.Put(artifact("centos", "c-1-1.x86_64.rpm", 30))
+	if list, _ := idx.List("centos"); len(list) != 3 {
+		t.Errorf("List after torn tail = %d artifacts", len(list))
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	idx, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx.PutRepo("files", "files")
+	for i := 0; i < 3*compactSlack; i++ {
+		idx.Put(types.Artifact{Repo: "files", Type: "files", Path: "a.txt", Size: int64(i)})
+	}
+	if m := idx.Metrics(); m.JournalRecords > compactSlack+2 {
+		t.Errorf("journal has %d records after compaction", m.JournalRecords)
+	}
+	idx.Close()
+
+	idx, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+	res, _ := idx.Search(Query{Repo: "files"}, 0)
+	if len(res) != 1 || res[0].Size != 3*compactSlack-1 {
+		t.Errorf("after compaction = %+v", res)
+	}
+}
+
+func TestReconcileKeepsConcurrentWrites(t *testing.T) {
+	idx, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+	idx.Reconcile([]RepoScan{
+		{Name: "old", Type: "rpm", Artifacts: []types.Artifact{artifact("old", "a-1-1.x86_64.rpm", 1)}},
+		{Name: "gone", Type: "rpm"},
+	}, time.Now())
+
+	since := time.Now()
+	time.Sleep(time.Millisecond)
+	// 扫描期间：上传到 old、创建 new、删除 gone
+	idx.Put(artifact("old", "b-1-1.x86_64.rpm", 2))
+	idx.PutRepo("new", "deb")
+	idx.DropRepo("gone")
+
+	// 扫描结果不包含这些变化
+	idx.Reconcile([]RepoScan{
+		{Name: "old", Type: "rpm"},
+		{Name: "gone", Type: "rpm"},
+	}, since)
+
+	repos := idx.Repos()
+	if _, ok := repos["gone"]; ok {
+		t.Error("repo deleted during the scan was restored")
+	}
+	if repos["new"] != "deb" {
+		t.Error("repo created during the scan was dropped")
+	}
+	list, _ := idx.List("old")
+	if len(list) != 1 || list[0].Path != "b-1-1.x86_64.rpm" {
+		t.Errorf("old = %+v", list)
+	}
+
+	// 下一次扫描以存储为准
+	idx.Reconcile([]RepoScan{{Name: "old", Type: "rpm"}}, time.Now())
+	if list, _ := idx.List("old"); len(list) != 0 {
+		t.Errorf("old = %+v", list)
+	}
+	if len(idx.Repos()) != 1 {
+		t.Errorf("repos = %v", idx.Repos())
+	}
+}
+
+func TestSearch(t *testing.T) {
+	idx, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+	idx.Reconcile([]RepoScan{
+		{Name: "el/9", Type: "rpm", Artifacts: []types.Artifact{
+			artifact("el/9", "nginx-1.24-1.x86_64.rpm", 1),
+			artifact("el/9", "nginx-1.24-1.aarch64.rpm", 1),
+		}},
+		{Name: "ubuntu", Type: "deb", Artifacts: []types.Artifact{
+			{Repo: "ubuntu", Type: "deb", Path: "nginx_1.24_amd64.deb", Labels: Labels("deb", "nginx_1.24_amd64.deb")},
+		}},
+	}, time.Now())
+
+	cases := []struct {
+		q    Query
+		want int
+	}{
+		{Query{Text: "NGINX"}, 3},
+		{Query{Text: "nginx", Repo: "el"}, 2},
+		{Query{Type: "deb"}, 1},
+		{Query{Labels: map[string]string{"arch": "x86_64"}}, 1},
+		{Query{Labels: map[string]string{"arch": "amd64", "ext": "deb"}}, 1},
+		{Query{Text: "httpd"}, 0},
+	}
+	for _, c := range cases {
+		if res, _ := idx.Search(c.q, 0); len(res) != c.want {
+			t.Errorf("Search(%+v) = %d results, want %d", c.q, len(res), c.want)
+		}
+	}
+	if res, truncated := idx.Search(Query{}, 2); len(res) != 2 || !truncated {
+		t.Errorf("limited search = %d, %v", len(res), truncated)
+	}
+
+	// 统计包括超出 limit 的结果
+	counts := facet.New([]string{"repo", "arch"}, time.Now())
+	if res, truncated := idx.SearchCount(Query{Text: "nginx"}, 1, counts); len(res) != 1 || !truncated {
+		t.Errorf("counted search = %d, %v", len(res), truncated)
+	}
+	facets := counts.Result()
+	if repos := facets["repo"]; len(repos) != 2 || repos[0] != (types.FacetValue{Value: "el/9", Count: 2}) {
+		t.Errorf("repo facet = %v", repos)
+	}
+	if arches := facets["arch"]; len(arches) != 3 {
+		t.Errorf("arch facet = %v", arches)
+	}
+}
@@ -0,0 +1,152 @@
+package journal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+// Dir 操作日志在存储根目录下的目录，每个未完成的操作一个文件
+const Dir = ".journal"
+
+// 记录的多步操作
+const (
+	OpRefresh   = "refresh"   // 已排队的元数据刷新，重放时重新刷新
+	OpMove      = "move"      // 把暂存区或等待区的文件移入仓库再刷新，重放时移入剩余文件并刷新
+	OpRetention = "retention" // 删除旧版本后刷新，重放时刷新，元数据不再引用已删除的文件
+	OpClone     = "clone"     // 复制仓库，重放时删除未复制完的新仓库
+	OpExpire    = "expire"    // 删除过期的制品后刷新，重放时刷新
+)
+
+// timeFormat 固定位数，记录可以按开始时间的字符串排序
+const timeFormat = "2006-01-02T15:04:05.000000000Z07:00"
+
+// Journal 写前日志：多步操作开始前记录意图，完成后删除记录，启动时剩下的记录就是崩溃时进行中的操作
+type Journal struct {
+	dir string
+}
+
+// Open 打开 dir 下的操作日志
+func Open(dir string) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create journal directory: %w", err)
+	}
+	return &Journal{dir: dir}, nil
+}
+
+// Begin 持久化操作意图，返回记录 ID。写入失败时调用方不应开始操作。j 为 nil 时不记录
+func (j *Journal) Begin(e types.JournalEntry) (string, error) {
+	if j == nil {
+		return "", nil
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	e.ID = hex.EncodeToString(id)
+	e.Started = time.Now().UTC().Format(timeFormat)
+	data, err := e.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	if err := j.write(e.ID, data); err != nil {
+		return "", fmt.Errorf("write journal entry: %w", err)
+	}
+	return e.ID, nil
+}
+
+// End 操作完成（成功或已向调用方报告失败），删除记录
+func (j *Journal) End(id string) {
+	if j == nil || id == "" {
+		return
+	}
+	if err := os.Remove(filepath.Join(j.dir, id+".json")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Logger.Warnf("Failed to remove journal entry %s: %v", id, err)
+	}
+}
+
+// Pending 按开始时间返回未完成的操作。多个实例共用存储时只返回早于 minAge 的记录，
+// 不重放其他实例进行中的操作。无法解析的记录记录日志后删除
+func (j *Journal) Pending(minAge time.Duration) ([]types.JournalEntry, error) {
+	if j == nil {
+		return nil, nil
+	}
+	files, err := os.ReadDir(j.dir)
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().Add(-minAge)
+	var entries []types.JournalEntry
+	for _, f := range files {
+		id, ok := strings.CutSuffix(f.Name(), ".json")
+		if !ok || f.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(j.dir, f.Name()))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var e types.JournalEntry
+		if err := e.UnmarshalJSON(data); err != nil || e.ID != id {
+			log.Logger.Warnf("Dropping corrupt journal entry %s: %v", f.Name(), err)
+			j.End(id)
+			continue
+		}
+		started, err := time.Parse(time.RFC3339Nano, e.Started)
+		if err == nil && started.After(cutoff) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].Started != entries[b].Started {
+			return entries[a].Started < entries[b].Started
+		}
+		return entries[a].ID < entries[b].ID
+	})
+	return entries, nil
+}
+
+// write 写入临时文件并同步后重命名，崩溃后不会留下不完整的记录
+func (j *Journal) write(id string, data []byte) error {
+	tmp := filepath.Join(j.dir, id+".tmp")
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(j.dir, id+".json")); err != nil {
+		return err
+	}
+	// 目录同步后重命名才持久
+	d, err := os.Open(j.dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,77 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestJournal(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), Dir)
+	j, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	move, err := j.Begin(types.JournalEntry{Op: OpMove, Repo: "el9", Source: ".staging/el9", Files: []string{"a.rpm"}})
+	if err != nil || move == "" {
+		t.Fatalf("begin move: %q, %v", move, err)
+	}
+	refresh, err := j.Begin(types.JournalEntry{Op: OpRefresh, Repo: "el8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done, _ := j.Begin(types.JournalEntry{Op: OpRefresh, Repo: "el7"})
+	j.End(done)
+	j.End(done)
+	os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644)
+
+	// 重新打开后剩下的是进行中的操作，按开始时间排列
+	j, _ = Open(dir)
+	entries, err := j.Pending(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].ID != move || entries[1].ID != refresh {
+		t.Fatalf("pending = %+v", entries)
+	}
+	if e := entries[0]; e.Op != OpMove || e.Source != ".staging/el9" || len(e.Files) != 1 || e.Started == "" {
+		t.Errorf("move entry = %+v", e)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "broken.json")); err == nil {
+		t.Error("corrupt entry kept")
+	}
+
+	// 其他实例刚开始的操作不重放
+	if entries, _ := j.Pending(time.Hour); len(entries) != 0 {
+		t.Errorf("recent entries replayed: %+v", entries)
+	}
+
+	j.End(move)
+	j.End(refresh)
+	if entries, _ := j.Pending(0); len(entries) != 0 {
+		t.Errorf("pending after end = %+v", entries)
+	}
+}
+
+func TestNilJournal(t *testing.T) {
+	var j *Journal
+	if id, err := j.Begin(types.JournalEntry{Op: OpRefresh, Repo: "el9"}); id != "" || err != nil {
+		t.Errorf("begin = %q, %v", id, err)
+	}
+	j.End("x")
+	if entries, err := j.Pending(0); entries != nil || err != nil {
+		t.Errorf("pending = %v, %v", entries, err)
+	}
+}
@@ -0,0 +1,177 @@
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"plus/internal/log"
+)
+
+// FileName 目录根下记录布局版本的文件
+const FileName = ".layout-version"
+
+// lockName 升级期间持有的锁文件，多个实例共用目录时只有一个执行升级
+const lockName = ".layout-lock"
+
+// Baseline 引入版本文件之前的布局版本
+const Baseline = 1
+
+// ErrMigrationRequired 目录布局旧于当前版本，但不允许自动升级
+var ErrMigrationRequired = errors.New("layout migration required")
+
+// Migration 把布局从 Version-1 升级到 Version。升级中断后从该步骤重新执行，Run 必须可以重复执行
+type Migration struct {
+	Version     int
+	Description string
+	Run         func(root string) error
+}
+
+// Tree 带布局版本的目录
+type Tree struct {
+	Name       string // 日志和错误中的名称，如 storage
+	Root       string
+	Migrations []Migration // 按版本从小到大排列，版本从 Baseline+1 开始
+}
+
+// Current 本版本 plus 使用的布局版本
+func (t Tree) Current() int {
+	if n := len(t.Migrations); n > 0 {
+		return t.Migrations[n-1].Version
+	}
+	return Baseline
+}
+
+// Version 读取目录的布局版本。没有版本文件时，空目录或不存在的目录返回 0，已有数据的目录返回 Baseline
+func Version(root string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(root, FileName))
+	if err == nil {
+		v, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil || v < Baseline {
+			return 0, fmt.Errorf("invalid %s in %s: %q", FileName, root, strings.TrimSpace(string(data)))
+		}
+		return v, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+	entries, err := os.ReadDir(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range entries {
+		if e.Name() != lockName {
+			return Baseline, nil
+		}
+	}
+	return 0, nil
+}
+
+// Upgrade 依次执行高于目录版本的升级，每步完成后更新版本文件，返回升级前后的版本。新目录直接记录当前版本。
+// noMigrate 时不修改目录，需要升级时返回 ErrMigrationRequired；目录版本高于当前版本时说明已被更新的 plus 升级过，返回错误
+func (t Tree) Upgrade(noMigrate bool) (from, to int, err error) {
+	current := t.Current()
+	if !noMigrate {
+		unlock, err := lock(t.Root)
+		if err != nil {
+			return 0, 0, err
+		}
+		defer unlock()
+	}
+
+	from, err = Version(t.Root)
+	if err != nil {
+		return 0, 0, err
+	}
+	switch {
+	case from > current:
+		return from, from, fmt.Errorf("%s layout version %d in %s is newer than this plus supports (%d), use a newer release",
+			t.Name, from, t.Root, current)
+	case noMigrate && from != 0 && from < current:
+		return from, from, fmt.Errorf("%s layout in %s is version %d, this plus needs version %d: %w",
+			t.Name, t.Root, from, current, ErrMigrationRequired)
+	case noMigrate:
+		return from, from, nil
+	case from == 0:
+		// 新目录没有需要升级的数据
+		if err := writeVersion(t.Root, current); err != nil {
+			return 0, 0, err
+		}
+		log.Logger.Debugf("Initialized %s layout version %d in %s", t.Name, current, t.Root)
+		return from, current, nil
+	}
+
+	to = from
+	for _, m := range t.Migrations {
+		if m.Version <= to {
+			continue
+		}
+		start := time.Now()
+		log.Logger.Infof("Upgrading %s layout in %s from version %d to %d: %s", t.Name, t.Root, to, m.Version, m.Description)
+		if err := m.Run(t.Root); err != nil {
+			return from, to, fmt.Errorf("upgrade %s layout to version %d: %w", t.Name, m.Version, err)
+		}
+		if err := writeVersion(t.Root, m.Version); err != nil {
+			return from, to, err
+		}
+		to = m.Version
+		log.Logger.Infof("Upgraded %s layout to version %d in %s", t.Name, to, time.Since(start).Round(time.Millisecond))
+	}
+	// 引入版本文件之前的目录，或升级后写入版本文件前中断的目录
+	if data, err := os.ReadFile(filepath.Join(t.Root, FileName)); err != nil || strings.TrimSpace(string(data)) != strconv.Itoa(to) {
+		if err := writeVersion(t.Root, to); err != nil {
+			return from, to, err
+		}
+		log.Logger.Infof("Recorded %s layout version %d in %s", t.Name, to, t.Root)
+	}
+	return from, to, nil
+}
+
+// lock 持有目录的升级锁，其他实例等待升级完成
+func lock(root string) (func(), error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(root, lockName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("lock %s: %w", f.Name(), err)
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// writeVersion 写入临时文件后重命名
+func writeVersion(root string, v int) error {
+	f, err := os.CreateTemp(root, FileName+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := fmt.Fprintf(f, "%d\n", v); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(root, FileName))
+}
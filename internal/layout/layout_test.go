@@ -0,0 +1,117 @@
+package layout
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/internal/log"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func version(t *testing.T, root string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func TestUpgrade(t *testing.T) {
+	var ran []string
+	step := func(name string) func(string) error {
+		return func(root string) error {
+			ran = append(ran, name)
+			return os.WriteFile(filepath.Join(root, name), nil, 0644)
+		}
+	}
+	migrations := []Migration{
+		{Version: 2, Description: "content-addressed blobs", Run: step("v2")},
+		{Version: 3, Description: "per-arch directories", Run: step("v3")},
+	}
+
+	// 新目录直接记录当前版本，不执行升级
+	fresh := Tree{Name: "storage", Root: filepath.Join(t.TempDir(), "new"), Migrations: migrations}
+	if from, to, err := fresh.Upgrade(false); err != nil || from != 0 || to != 3 || len(ran) != 0 {
+		t.Fatalf("fresh: %d -> %d, %v, ran %v", from, to, err, ran)
+	}
+	if v := version(t, fresh.Root); v != "3" {
+		t.Errorf("fresh version file = %q", v)
+	}
+
+	// 引入版本之前的目录从 Baseline 开始升级
+	root := t.TempDir()
+	os.Mkdir(filepath.Join(root, "el9"), 0755)
+	tree := Tree{Name: "storage", Root: root, Migrations: migrations[:1]}
+	if _, _, err := tree.Upgrade(true); !errors.Is(err, ErrMigrationRequired) {
+		t.Fatalf("no-migrate: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, FileName)); err == nil || len(ran) != 0 {
+		t.Fatal("no-migrate changed the directory")
+	}
+	if from, to, err := tree.Upgrade(false); err != nil || from != 1 || to != 2 || strings.Join(ran, ",") != "v2" {
+		t.Fatalf("upgrade: %d -> %d, %v, ran %v", from, to, err, ran)
+	}
+
+	// 新版本只执行新增的升级
+	tree.Migrations = migrations
+	if from, to, err := tree.Upgrade(false); err != nil || from != 2 || to != 3 || strings.Join(ran, ",") != "v2,v3" {
+		t.Fatalf("second upgrade: %d -> %d, %v, ran %v", from, to, err, ran)
+	}
+	if from, to, err := tree.Upgrade(true); err != nil || from != 3 || to != 3 {
+		t.Fatalf("current: %d -> %d, %v", from, to, err)
+	}
+
+	// 旧版本 plus 不能使用已升级的目录
+	tree.Migrations = migrations[:1]
+	if _, _, err := tree.Upgrade(false); err == nil || !strings.Contains(err.Error(), "newer than this plus supports") {
+		t.Fatalf("downgrade: %v", err)
+	}
+}
+
+func TestUpgradeFailureResumes(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, FileName), []byte("1\n"), 0644)
+	fail := true
+	tree := Tree{Name: "database", Root: root, Migrations: []Migration{
+		{Version: 2, Description: "ok", Run: func(string) error { return nil }},
+		{Version: 3, Description: "flaky", Run: func(string) error {
+			if fail {
+				return errors.New("disk full")
+			}
+			return nil
+		}},
+	}}
+
+	// 失败时保留已完成步骤的版本，重新启动后从失败的步骤继续
+	if _, to, err := tree.Upgrade(false); err == nil || to != 2 {
+		t.Fatalf("failed upgrade: to %d, %v", to, err)
+	}
+	if v := version(t, root); v != "2" {
+		t.Errorf("version after failure = %q", v)
+	}
+	fail = false
+	if from, to, err := tree.Upgrade(false); err != nil || from != 2 || to != 3 {
+		t.Fatalf("resumed upgrade: %d -> %d, %v", from, to, err)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	root := t.TempDir()
+	if v, err := Version(filepath.Join(root, "missing")); v != 0 || err != nil {
+		t.Errorf("missing dir = %d, %v", v, err)
+	}
+	os.WriteFile(filepath.Join(root, FileName), []byte("x\n"), 0644)
+	if _, err := Version(root); err == nil {
+		t.Error("invalid version file accepted")
+	}
+}
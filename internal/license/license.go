@@ -0,0 +1,224 @@
+package license
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cavaliergopher/rpm"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// maxControlSize DEB 包 control.tar 的最大长度，超过时认为包无效
+const maxControlSize = 16 << 20
+
+// ErrUnreadable 无法从上传的包中读取许可证
+var ErrUnreadable = errors.New("cannot read the package license")
+
+// Policy 仓库的许可证规则：包的许可证中有一项匹配 deny 中的模式时拒绝上传
+type Policy struct {
+	deny    []string // 小写的 path.Match 模式
+	message string   // 拒绝时附加的说明
+}
+
+// Violation 上传的包的许可证被仓库禁止
+type Violation struct {
+	Filename string
+	License  string // 包中的许可证
+	Pattern  string // 匹配的 deny 模式
+	Message  string
+}
+
+func (v *Violation) Error() string {
+	reason := fmt.Sprintf("license %q of %s matches the denied license %s", v.License, v.Filename, v.Pattern)
+	if v.Message != "" {
+		reason += ": " + v.Message
+	}
+	return reason
+}
+
+// New 创建禁止 deny 中许可证的规则，deny 为空时返回 nil，即不限制。
+// 模式不区分大小写，可以使用 * 等通配符，如 AGPL*
+func New(deny []string, message string) (*Policy, error) {
+	if len(deny) == 0 {
+		return nil, nil
+	}
+	p := &Policy{message: message}
+	for _, pattern := range deny {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return nil, fmt.Errorf("invalid license pattern %q", pattern)
+		}
+		p.deny = append(p.deny, pattern)
+	}
+	return p, nil
+}
+
+// Check 检查包的许可证，有一项被禁止时返回 *Violation。p 为 nil 或包没有许可证时接受
+func (p *Policy) Check(filename, license string) error {
+	if p == nil {
+		return nil
+	}
+	for _, term := range Terms(license) {
+		term = strings.ToLower(term)
+		for _, pattern := range p.deny {
+			if ok, _ := path.Match(pattern, term); ok {
+				return &Violation{Filename: path.Base(filename), License: license, Pattern: pattern, Message: p.message}
+			}
+		}
+	}
+	return nil
+}
+
+// Terms 拆分许可证表达式中的各个许可证，去掉 and、or、with 和括号，
+// 如 "GPLv2+ and (MIT or BSD)" 返回 GPLv2+、MIT、BSD
+func Terms(expr string) []string {
+	fields := strings.FieldsFunc(expr, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '(' || r == ')' || r == ','
+	})
+	terms := make([]string, 0, len(fields))
+	for _, f := range fields {
+		switch strings.ToLower(f) {
+		case "and", "or", "with":
+			continue
+		}
+		terms = append(terms, f)
+	}
+	return terms
+}
+
+// Read 读取包的许可证：RPM 为包头的 LICENSE，DEB 为 control 文件的 License 字段。
+// 其他文件和没有许可证的包返回空，包无法解析时返回 ErrUnreadable
+func Read(r io.Reader, filename string) (string, error) {
+	switch {
+	case strings.HasSuffix(filename, ".rpm"):
+		pkg, err := rpm.Read(fullReader{r})
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %v", ErrUnreadable, path.Base(filename), err)
+		}
+		return strings.TrimSpace(pkg.License()), nil
+	case strings.HasSuffix(filename, ".deb"):
+		control, err := debControl(r)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %v", ErrUnreadable, path.Base(filename), err)
+		}
+		return Field(control, "License"), nil
+	}
+	return "", nil
+}
+
+// Field 返回 control 格式文本中第一个段落的字段值，没有时返回空。续行按空格拼接
+func Field(control, name string) string {
+	var value []string
+	found := false
+	scanner := bufio.NewScanner(strings.NewReader(control))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			if found {
+				value = append(value, strings.TrimSpace(line))
+			}
+			continue
+		}
+		if found {
+			break
+		}
+		key, v, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(key, name) {
+			found = true
+			value = append(value, strings.TrimSpace(v))
+		}
+	}
+	return strings.Join(value, " ")
+}
+
+// debControl 读取 DEB 包（ar 格式）中 control.tar 里的 control 文件，读到后不再读取之后的内容
+func debControl(r io.Reader) (string, error) {
+	magic := make([]byte, 8)
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != "!<arch>\n" {
+		return "", errors.New("not an ar archive")
+	}
+	header := make([]byte, 60)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return "", errors.New("no control.tar member")
+		}
+		name := strings.TrimSuffix(strings.TrimSpace(string(header[:16])), "/")
+		size, err := strconv.ParseInt(strings.TrimSpace(string(header[48:58])), 10, 64)
+		if err != nil || size < 0 {
+			return "", fmt.Errorf("invalid size of member %s", name)
+		}
+		member := io.LimitReader(r, size)
+		if strings.HasPrefix(name, "control.tar") {
+			if size > maxControlSize {
+				return "", fmt.Errorf("%s is too large", name)
+			}
+			return controlFile(member, name)
+		}
+		// 成员按偶数字节对齐
+		if _, err := io.CopyN(io.Discard, r, size+size%2); err != nil {
+			return "", fmt.Errorf("truncated member %s", name)
+		}
+	}
+}
+
+// controlFile 按扩展名解压 control.tar 并返回其中的 control 文件
+func controlFile(r io.Reader, name string) (string, error) {
+	switch path.Ext(name) {
+	case ".gz":
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return "", err
+		}
+		r = zr
+	case ".xz":
+		xr, err := xz.NewReader(r)
+		if err != nil {
+			return "", err
+		}
+		r = xr
+	case ".zst":
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return "", err
+		}
+		defer d.Close()
+		r = d
+	case ".tar":
+	default:
+		return "", fmt.Errorf("unsupported compression of %s", name)
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return "", errors.New("no control file")
+		}
+		if err != nil {
+			return "", err
+		}
+		if path.Clean(hdr.Name) == "control" {
+			data, err := io.ReadAll(io.LimitReader(tr, maxControlSize))
+			return string(data), err
+		}
+	}
+}
+
+// fullReader rpm.Read 按固定长度读取包头结构，读取上传的流时每次读满
+type fullReader struct {
+	io.Reader
+}
+
+func (r fullReader) Read(p []byte) (int, error) {
+	return io.ReadFull(r.Reader, p)
+}
@@ -0,0 +1,118 @@
+package license
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	for expr, want := range map[string][]string{
+		"MIT":                     {"MIT"},
+		"GPLv2+ and (MIT or BSD)": {"GPLv2+", "MIT", "BSD"},
+		"GPL-2.0-only WITH Classpath-exception-2.0": {"GPL-2.0-only", "Classpath-exception-2.0"},
+		"(Apache-2.0 OR MIT) AND BSD-3-Clause":      {"Apache-2.0", "MIT", "BSD-3-Clause"},
+		"":                                          {},
+	} {
+		if got := Terms(expr); !reflect.DeepEqual(got, want) {
+			t.Errorf("Terms(%q) = %q, want %q", expr, got, want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p, err := New([]string{"AGPL*", "SSPL-1.0"}, "No network copyleft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for license, ok := range map[string]bool{
+		"MIT":                    true,
+		"":                       true,
+		"AGPL-3.0-or-later":      false,
+		"agplv3":                 false,
+		"MIT or SSPL-1.0":        false, // 任何一项被禁止都拒绝
+		"GPL-3.0 and Apache-2.0": true,
+	} {
+		if err := p.Check("Packages/app-1.0-1.x86_64.rpm", license); (err == nil) != ok {
+			t.Errorf("Check(%q) = %v", license, err)
+		}
+	}
+	var v *Violation
+	err = p.Check("Packages/app-1.0-1.x86_64.rpm", "AGPL-3.0")
+	if !errors.As(err, &v) || v.Pattern != "agpl*" || v.Filename != "app-1.0-1.x86_64.rpm" || !strings.HasSuffix(err.Error(), ": No network copyleft") {
+		t.Errorf("violation = %v", err)
+	}
+
+	if p, err := New(nil, "unused"); p != nil || err != nil {
+		t.Errorf("empty policy = %v, %v", p, err)
+	}
+	var none *Policy
+	if err := none.Check("app.rpm", "AGPL-3.0"); err != nil {
+		t.Errorf("nil policy: %v", err)
+	}
+	if _, err := New([]string{"[MIT"}, ""); err == nil {
+		t.Error("invalid pattern accepted")
+	}
+}
+
+func TestReadDEB(t *testing.T) {
+	control := "Package: hello\nVersion: 1.0-1\nArchitecture: amd64\nLicense: GPL-3.0-or-later\n and MIT\nDescription: test\n"
+	license, err := Read(bytes.NewReader(testDEB(control)), "hello_1.0-1_amd64.deb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "GPL-3.0-or-later and MIT"; license != want {
+		t.Errorf("license = %q, want %q", license, want)
+	}
+
+	if license, err := Read(bytes.NewReader(testDEB("Package: hello\n")), "hello.deb"); err != nil || license != "" {
+		t.Errorf("no License field = %q, %v", license, err)
+	}
+	for name, data := range map[string]string{"bad.deb": "not a deb", "bad.rpm": "not an rpm"} {
+		if _, err := Read(strings.NewReader(data), name); !errors.Is(err, ErrUnreadable) {
+			t.Errorf("Read(%s) = %v, want ErrUnreadable", name, err)
+		}
+	}
+	if license, err := Read(strings.NewReader("text"), "notes.txt"); err != nil || license != "" {
+		t.Errorf("other file = %q, %v", license, err)
+	}
+}
+
+// testDEB 生成只有 control 文件的 DEB 包
+func testDEB(control string) []byte {
+	targz := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for name, body := range files {
+			tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(body))})
+			tw.Write([]byte(body))
+		}
+		tw.Close()
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("!<arch>\n")
+	for _, member := range []struct {
+		name string
+		data []byte
+	}{
+		{"debian-binary", []byte("2.0\n")},
+		{"control.tar.gz", targz(map[string]string{"./control": control})},
+		{"data.tar.gz", targz(nil)},
+	} {
+		fmt.Fprintf(&buf, "%-16s%-12d%-6d%-6d%-8s%-10d`\n", member.name, 0, 0, 0, "100644", len(member.data))
+		buf.Write(member.data)
+		if len(member.data)%2 == 1 {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
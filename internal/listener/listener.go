@@ -0,0 +1,182 @@
+package listener
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"plus/internal/config"
+)
+
+// Listener 一个已打开的监听地址，TLS 不为空时需在最外层用 tls.NewListener 包装
+type Listener struct {
+	net.Listener
+	Network string // tcp4、tcp6、tcp 或 unix
+	TLS     *tls.Config
+	Systemd bool // 由 systemd 传入的 socket
+}
+
+// Address 实际监听的地址，端口为 0 时是系统分配的端口
+func (l *Listener) Address() string {
+	if l.Network == "unix" {
+		return "unix:" + l.Addr().String()
+	}
+	return l.Addr().String()
+}
+
+// Open 打开所有监听地址，任一地址失败时关闭已打开的地址
+func Open(configs []config.ListenerConfig) ([]*Listener, error) {
+	listeners := make([]*Listener, 0, len(configs))
+	for _, lc := range configs {
+		var opened []*Listener
+		var err error
+		if name, ok := systemdName(lc.Address); ok {
+			opened, err = openSystemd(lc, name)
+		} else {
+			var l *Listener
+			if l, err = open(lc); err == nil {
+				opened = []*Listener{l}
+			}
+		}
+		if err != nil {
+			Close(listeners)
+			return nil, err
+		}
+		listeners = append(listeners, opened...)
+	}
+	return listeners, nil
+}
+
+// Close 关闭监听地址，unix socket 文件随之删除
+func Close(listeners []*Listener) {
+	for _, l := range listeners {
+		l.Close()
+	}
+}
+
+func open(lc config.ListenerConfig) (*Listener, error) {
+	network, address, err := ParseAddress(lc.Address)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := newTLSConfig(lc.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("listener %s: %w", lc.Address, err)
+	}
+	if network == "unix" {
+		if err := removeStaleSocket(address); err != nil {
+			return nil, fmt.Errorf("listener %s: %w", lc.Address, err)
+		}
+	} else if lc.Mode != "" {
+		return nil, fmt.Errorf("listener %s: mode only applies to unix sockets", lc.Address)
+	}
+
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	if network == "unix" && lc.Mode != "" {
+		mode, err := strconv.ParseUint(lc.Mode, 8, 32)
+		if err == nil {
+			err = os.Chmod(address, os.FileMode(mode))
+		}
+		if err != nil {
+			ln.Close()
+			return nil, fmt.Errorf("listener %s: invalid mode %q: %w", lc.Address, lc.Mode, err)
+		}
+	}
+	return &Listener{Listener: ln, Network: network, TLS: tlsConfig}, nil
+}
+
+// ParseAddress 返回监听地址的网络类型。未指定主机和 IPv4 地址只监听 IPv4，
+// IPv6 地址只监听 IPv6，因此 ":8080" 和 "[::]:8080" 可以同时配置
+func ParseAddress(addr string) (network, address string, err error) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		if path == "" {
+			return "", "", fmt.Errorf("listener %q: empty unix socket path", addr)
+		}
+		return "unix", path, nil
+	}
+	if strings.HasPrefix(addr, "/") {
+		return "unix", addr, nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", "", fmt.Errorf("listener %q: %w", addr, err)
+	}
+	switch ip := net.ParseIP(host); {
+	case host == "" || (ip != nil && ip.To4() != nil):
+		return "tcp4", addr, nil
+	case ip != nil:
+		return "tcp6", addr, nil
+	default:
+		return "tcp", addr, nil
+	}
+}
+
+// removeStaleSocket 删除上次运行遗留的 socket 文件，仍有进程在监听时报错
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	if c, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		c.Close()
+		return fmt.Errorf("%s is in use by another process", path)
+	}
+	return os.Remove(path)
+}
+
+// newTLSConfig 根据配置加载证书，未配置证书时返回 nil
+func newTLSConfig(tc config.ListenerTLSConfig) (*tls.Config, error) {
+	if tc.CertFile == "" && tc.KeyFile == "" {
+		if tc.ClientCA != "" || tc.MinVersion != "" {
+			return nil, fmt.Errorf("tls requires cert-file and key-file")
+		}
+		return nil, nil
+	}
+	if tc.CertFile == "" || tc.KeyFile == "" {
+		return nil, fmt.Errorf("tls requires both cert-file and key-file")
+	}
+	cert, err := tls.LoadX509KeyPair(tc.CertFile, tc.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"http/1.1"},
+	}
+	switch tc.MinVersion {
+	case "", "1.2":
+	case "1.3":
+		cfg.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("invalid tls min-version %q: must be 1.2 or 1.3", tc.MinVersion)
+	}
+	if tc.ClientCA != "" {
+		data, err := os.ReadFile(tc.ClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client-ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in client-ca %s", tc.ClientCA)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
@@ -0,0 +1,161 @@
+package listener
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"plus/internal/config"
+)
+
+func TestParseAddress(t *testing.T) {
+	for _, tt := range []struct {
+		addr, network, address string
+	}{
+		{":8080", "tcp4", ":8080"},
+		{"0.0.0.0:8080", "tcp4", "0.0.0.0:8080"},
+		{"[::]:8080", "tcp6", "[::]:8080"},
+		{"[::1]:8080", "tcp6", "[::1]:8080"},
+		{"localhost:8080", "tcp", "localhost:8080"},
+		{"unix:/run/plus.sock", "unix", "/run/plus.sock"},
+		{"/run/plus.sock", "unix", "/run/plus.sock"},
+	} {
+		network, address, err := ParseAddress(tt.addr)
+		if err != nil || network != tt.network || address != tt.address {
+			t.Errorf("ParseAddress(%q) = %q, %q, %v", tt.addr, network, address, err)
+		}
+	}
+	for _, addr := range []string{"8080", "unix:", ""} {
+		if _, _, err := ParseAddress(addr); err == nil {
+			t.Errorf("ParseAddress(%q) accepted an invalid address", addr)
+		}
+	}
+}
+
+func TestOpenUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plus.sock")
+	// 上次运行遗留的 socket 文件
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	listeners, err := Open([]config.ListenerConfig{{Address: "unix:" + path, Mode: "0600"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := listeners[0]
+	if l.Network != "unix" || l.Address() != "unix:"+path || l.TLS != nil {
+		t.Errorf("listener = %s %s", l.Network, l.Address())
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("socket mode = %v, %v", info.Mode(), err)
+	}
+
+	// 仍在监听的 socket 不能被另一个进程占用
+	if _, err := Open([]config.ListenerConfig{{Address: path}}); err == nil {
+		t.Error("Open took over a socket in use")
+	}
+	Close(listeners)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket not removed on close: %v", err)
+	}
+
+	if _, err := Open([]config.ListenerConfig{{Address: "127.0.0.1:0", Mode: "0600"}}); err == nil {
+		t.Error("Open accepted mode for a tcp listener")
+	}
+}
+
+func TestOpenTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+
+	for _, tc := range []config.ListenerTLSConfig{
+		{CertFile: certFile},
+		{ClientCA: certFile},
+		{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.1"},
+		{CertFile: certFile, KeyFile: keyFile, ClientCA: keyFile},
+	} {
+		if _, err := Open([]config.ListenerConfig{{Address: "127.0.0.1:0", TLS: tc}}); err == nil {
+			t.Errorf("Open accepted tls %+v", tc)
+		}
+	}
+
+	listeners, err := Open([]config.ListenerConfig{
+		{Address: "127.0.0.1:0"},
+		{Address: "127.0.0.1:0", TLS: config.ListenerTLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.3"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Close(listeners)
+	if listeners[0].TLS != nil || listeners[1].Network != "tcp4" {
+		t.Fatalf("listeners = %+v", listeners)
+	}
+
+	ln := tls.NewListener(listeners[1], listeners[1].TLS)
+	go func() {
+		c, err := ln.Accept()
+		if err == nil {
+			c.(*tls.Conn).Handshake()
+			c.Close()
+		}
+	}()
+	pool := x509.NewCertPool()
+	data, _ := os.ReadFile(certFile)
+	pool.AppendCertsFromPEM(data)
+	c, err := tls.Dial("tcp", listeners[1].Address(), &tls.Config{RootCAs: pool, ServerName: "localhost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if v := c.ConnectionState().Version; v != tls.VersionTLS13 {
+		t.Errorf("tls version = %x", v)
+	}
+}
+
+// writeCertificate 生成 localhost 的自签名证书
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
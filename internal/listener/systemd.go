@@ -0,0 +1,129 @@
+package listener
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"plus/internal/config"
+)
+
+// listenFDsStart systemd 传入的第一个文件描述符（sd_listen_fds）
+const listenFDsStart = 3
+
+// socket 一个由 systemd 传入的 socket，name 为 socket 单元中的 FileDescriptorName
+type socket struct {
+	file *os.File
+	name string
+	used bool
+}
+
+var (
+	systemdOnce    sync.Once
+	systemdSockets []*socket
+	systemdErr     error
+	systemdMu      sync.Mutex
+)
+
+// systemdName 判断地址是否为 "systemd" 或 "systemd:<name>"
+func systemdName(addr string) (string, bool) {
+	if addr == "systemd" {
+		return "", true
+	}
+	return strings.CutPrefix(addr, "systemd:")
+}
+
+// Activated 返回 systemd 是否通过 socket 激活向本进程传入了 socket
+func Activated() bool {
+	sockets, err := systemdActivated()
+	return err == nil && len(sockets) > 0
+}
+
+// systemdActivated 读取 LISTEN_PID、LISTEN_FDS 和 LISTEN_FDNAMES，只读取一次，读取后清除这些环境变量，
+// 避免子进程误用
+func systemdActivated() ([]*socket, error) {
+	systemdOnce.Do(func() {
+		systemdSockets, systemdErr = activated(listenFDsStart)
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	})
+	return systemdSockets, systemdErr
+}
+
+// activated 按环境变量取出从 start 开始的文件描述符
+func activated(start int) ([]*socket, error) {
+	pid, fds := os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS")
+	if fds == "" {
+		return nil, nil
+	}
+	// 传给其他进程的 socket
+	if pid != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(fds)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS %q", fds)
+	}
+	var names []string
+	if v := os.Getenv("LISTEN_FDNAMES"); v != "" {
+		names = strings.Split(v, ":")
+	}
+	sockets := make([]*socket, 0, n)
+	for i := 0; i < n; i++ {
+		fd := start + i
+		syscall.CloseOnExec(fd)
+		name := "unknown"
+		if i < len(names) {
+			name = names[i]
+		}
+		sockets = append(sockets, &socket{file: os.NewFile(uintptr(fd), name), name: name})
+	}
+	return sockets, nil
+}
+
+// openSystemd 取出 systemd 传入的 socket。name 为空时取出所有尚未使用的 socket，
+// 否则取出 FileDescriptorName 为 name 的 socket
+func openSystemd(lc config.ListenerConfig, name string) ([]*Listener, error) {
+	sockets, err := systemdActivated()
+	if err != nil {
+		return nil, err
+	}
+	return openSockets(lc, name, sockets)
+}
+
+func openSockets(lc config.ListenerConfig, name string, sockets []*socket) ([]*Listener, error) {
+	if lc.Mode != "" {
+		return nil, fmt.Errorf("listener %s: set SocketMode in the socket unit instead of mode", lc.Address)
+	}
+	tlsConfig, err := newTLSConfig(lc.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("listener %s: %w", lc.Address, err)
+	}
+
+	systemdMu.Lock()
+	defer systemdMu.Unlock()
+	var listeners []*Listener
+	for _, s := range sockets {
+		if s.used || (name != "" && s.name != name) {
+			continue
+		}
+		ln, err := net.FileListener(s.file)
+		if err != nil {
+			Close(listeners)
+			return nil, fmt.Errorf("listener %s: socket %s: %w", lc.Address, s.name, err)
+		}
+		// FileListener 复制了文件描述符
+		s.file.Close()
+		s.used = true
+		listeners = append(listeners, &Listener{Listener: ln, Network: ln.Addr().Network(), TLS: tlsConfig, Systemd: true})
+	}
+	if len(listeners) == 0 {
+		return nil, fmt.Errorf("listener %s: no matching socket passed by systemd", lc.Address)
+	}
+	return listeners, nil
+}
@@ -0,0 +1,83 @@
+package listener
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"plus/internal/config"
+)
+
+func TestActivatedEnvironment(t *testing.T) {
+	t.Setenv("LISTEN_FDS", "1")
+	t.Setenv("LISTEN_PID", "1")
+	if sockets, err := activated(listenFDsStart); err != nil || sockets != nil {
+		t.Errorf("activated for another process = %v, %v", sockets, err)
+	}
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	t.Setenv("LISTEN_FDS", "x")
+	if _, err := activated(listenFDsStart); err == nil {
+		t.Error("activated accepted an invalid LISTEN_FDS")
+	}
+}
+
+func TestOpenSystemdSockets(t *testing.T) {
+	web := systemdSocket(t, "tcp", "127.0.0.1:0", "web")
+	path := filepath.Join(t.TempDir(), "plus.sock")
+	local := systemdSocket(t, "unix", path, "local")
+	sockets := []*socket{web, local}
+
+	if _, err := openSockets(config.ListenerConfig{Address: "systemd:web", Mode: "0660"}, "web", sockets); err == nil {
+		t.Error("openSockets accepted mode")
+	}
+	if _, err := openSockets(config.ListenerConfig{Address: "systemd:admin"}, "admin", sockets); err == nil {
+		t.Error("openSockets matched a missing name")
+	}
+
+	named, err := openSockets(config.ListenerConfig{Address: "systemd:web"}, "web", sockets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Close(named)
+	if len(named) != 1 || named[0].Network != "tcp" || !named[0].Systemd || !web.used || local.used {
+		t.Fatalf("systemd:web = %+v", named)
+	}
+	c, err := net.Dial("tcp", named[0].Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+
+	// "systemd" 取出其余的 socket
+	rest, err := openSockets(config.ListenerConfig{Address: "systemd"}, "", sockets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Close(rest)
+	if len(rest) != 1 || rest[0].Network != "unix" || rest[0].Address() != "unix:"+path {
+		t.Fatalf("systemd = %+v", rest)
+	}
+	if _, err := openSockets(config.ListenerConfig{Address: "systemd"}, "", sockets); err == nil {
+		t.Error("openSockets reused a socket")
+	}
+}
+
+// systemdSocket 模拟 systemd 传入的 socket
+func systemdSocket(t *testing.T, network, address, name string) *socket {
+	t.Helper()
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	if ul, ok := ln.(*net.UnixListener); ok {
+		ul.SetUnlinkOnClose(false)
+	}
+	f, err := ln.(interface{ File() (*os.File, error) }).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &socket{file: f, name: name}
+}
@@ -0,0 +1,130 @@
+package metalink
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// ContentType metalink 4（RFC 5854）文件的媒体类型
+	ContentType = "application/metalink4+xml"
+	// Suffix 在文件地址后加上该后缀得到 metalink 文件的地址
+	Suffix = ".meta4"
+
+	// DefaultPieceSize 分块校验的默认块大小
+	DefaultPieceSize = 4 << 20
+)
+
+// Digest 文件的整体校验和与分块校验和（SHA-256），下载工具按块校验并行下载的内容
+type Digest struct {
+	Size      int64
+	SHA256    string
+	PieceSize int64
+	Pieces    []string
+}
+
+// Compute 读取 r 的全部内容计算校验和
+func Compute(r io.Reader, pieceSize int64) (*Digest, error) {
+	if pieceSize <= 0 {
+		pieceSize = DefaultPieceSize
+	}
+	d := &Digest{PieceSize: pieceSize}
+	whole := sha256.New()
+	for {
+		piece := sha256.New()
+		n, err := io.Copy(io.MultiWriter(whole, piece), io.LimitReader(r, pieceSize))
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			d.Size += n
+			d.Pieces = append(d.Pieces, hex.EncodeToString(piece.Sum(nil)))
+		}
+		if n < pieceSize {
+			break
+		}
+	}
+	d.SHA256 = hex.EncodeToString(whole.Sum(nil))
+	return d, nil
+}
+
+// File metalink 描述的文件，URLs 按优先级从高到低排列
+type File struct {
+	Name      string
+	Published time.Time
+	Digest    *Digest
+	URLs      []string
+}
+
+type document struct {
+	XMLName   xml.Name `xml:"urn:ietf:params:xml:ns:metalink metalink"`
+	Generator string   `xml:"generator"`
+	Published string   `xml:"published,omitempty"`
+	Files     []file   `xml:"file"`
+}
+
+type file struct {
+	Name   string `xml:"name,attr"`
+	Size   int64  `xml:"size"`
+	Hash   hash   `xml:"hash"`
+	Pieces pieces `xml:"pieces"`
+	URLs   []url  `xml:"url"`
+}
+
+type hash struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type pieces struct {
+	Length int64    `xml:"length,attr"`
+	Type   string   `xml:"type,attr"`
+	Hashes []string `xml:"hash"`
+}
+
+type url struct {
+	Priority int    `xml:"priority,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// Build 生成 metalink 4 文档
+func Build(f File) ([]byte, error) {
+	if f.Digest == nil {
+		return nil, fmt.Errorf("metalink for %s has no digest", f.Name)
+	}
+	doc := document{
+		Generator: "plus",
+		Files: []file{{
+			Name:   f.Name,
+			Size:   f.Digest.Size,
+			Hash:   hash{Type: "sha-256", Value: f.Digest.SHA256},
+			Pieces: pieces{Length: f.Digest.PieceSize, Type: "sha-256", Hashes: f.Digest.Pieces},
+		}},
+	}
+	if !f.Published.IsZero() {
+		doc.Published = f.Published.UTC().Format(time.RFC3339)
+	}
+	for i, u := range f.URLs {
+		doc.Files[0].URLs = append(doc.Files[0].URLs, url{Priority: i + 1, Value: u})
+	}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// Options 提供 metalink 的文件范围和下载地址
+type Options struct {
+	MinSize   int64    // 小于该大小的文件不提供
+	PieceSize int64    // 分块校验的块大小
+	Mirrors   []string // 与本服务路径相同的镜像地址
+	Zsync     bool     // 同时提供 .zsync
+
+	TorrentMinSize int64    // 不小于该大小的文件同时提供 .torrent，0 表示不提供
+	Trackers       []string // .torrent 中的 tracker 地址
+}
@@ -0,0 +1,152 @@
+package metalink
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/md4"
+)
+
+func TestComputeAndBuild(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 250) // 2500 字节，3 块
+	d, err := Compute(bytes.NewReader(data), 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	whole := sha256.Sum256(data)
+	last := sha256.Sum256(data[2048:])
+	if d.Size != 2500 || d.SHA256 != hex.EncodeToString(whole[:]) || len(d.Pieces) != 3 || d.Pieces[2] != hex.EncodeToString(last[:]) {
+		t.Fatalf("Compute = %+v", d)
+	}
+
+	out, err := Build(File{
+		Name:      "disk.iso",
+		Published: time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+		Digest:    d,
+		URLs:      []string{"http://plus/isos/disk.iso", "https://mirror/isos/disk.iso"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc document
+	if err := xml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	if doc.XMLName.Space != "urn:ietf:params:xml:ns:metalink" || doc.Published != "2024-01-02T12:00:00Z" || len(doc.Files) != 1 {
+		t.Fatalf("metalink = %s", out)
+	}
+	f := doc.Files[0]
+	if f.Name != "disk.iso" || f.Size != 2500 || f.Hash.Type != "sha-256" || f.Pieces.Length != 1024 || len(f.Pieces.Hashes) != 3 {
+		t.Errorf("file = %+v", f)
+	}
+	if len(f.URLs) != 2 || f.URLs[0].Priority != 1 || f.URLs[1].Value != "https://mirror/isos/disk.iso" {
+		t.Errorf("urls = %+v", f.URLs)
+	}
+}
+
+func TestZsyncHashLengths(t *testing.T) {
+	for _, tt := range []struct {
+		size int64
+		want [3]int
+	}{
+		{5, [3]int{1, 2, 3}},
+		{1000000, [3]int{2, 2, 4}},
+	} {
+		seq, rsumLen, checksumLen := zsyncHashLengths(tt.size, zsyncBlockSize(tt.size))
+		if got := [3]int{seq, rsumLen, checksumLen}; got != tt.want {
+			t.Errorf("zsyncHashLengths(%d) = %v, want %v", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestZsync(t *testing.T) {
+	data := bytes.Repeat([]byte{1, 2, 3}, 1000) // 3000 字节，2 块
+	mtime := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	out, err := Zsync(bytes.NewReader(data), "disk.iso", "disk.iso", int64(len(data)), mtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, sums, ok := bytes.Cut(out, []byte("\n\n"))
+	if !ok {
+		t.Fatalf("no header terminator in %q", out)
+	}
+	for _, line := range []string{
+		"zsync: 0.6.2", "Filename: disk.iso", "MTime: Tue, 02 Jan 2024 12:00:00 +0000",
+		"Blocksize: 2048", "Length: 3000", "Hash-Lengths: 2,2,3", "URL: disk.iso",
+	} {
+		if !strings.Contains(string(header)+"\n", line+"\n") {
+			t.Errorf("header missing %q:\n%s", line, header)
+		}
+	}
+
+	// 每块 2 字节弱校验和 + 3 字节 MD4，最后一块补零
+	if len(sums) != 2*5 {
+		t.Fatalf("block sums = %d bytes", len(sums))
+	}
+	block := make([]byte, 2048)
+	copy(block, data[2048:])
+	var a, b uint16
+	for i, c := range block {
+		a += uint16(c)
+		b += uint16(2048-i) * uint16(c)
+	}
+	strong := md4.New()
+	strong.Write(block)
+	want := append([]byte{byte(b >> 8), byte(b)}, strong.Sum(nil)[:3]...)
+	if !bytes.Equal(sums[5:], want) {
+		t.Errorf("last block sums = %x, want %x", sums[5:], want)
+	}
+
+	if _, err := Zsync(bytes.NewReader(data), "disk.iso", "disk.iso", 10, mtime); err == nil {
+		t.Error("Zsync accepted a size mismatch")
+	}
+}
+
+func TestTorrentPieceLength(t *testing.T) {
+	for _, tt := range []struct {
+		size, want int64
+	}{
+		{3000, 256 << 10},
+		{512 << 20, 256 << 10},
+		{4 << 30, 2 << 20},
+		{4<<30 + 1, 4 << 20},
+		{1 << 40, 16 << 20},
+	} {
+		if got := torrentPieceLength(tt.size); got != tt.want {
+			t.Errorf("torrentPieceLength(%d) = %d, want %d", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestTorrent(t *testing.T) {
+	data := bytes.Repeat([]byte{1, 2, 3}, 1000)
+	created := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	out, err := Torrent(bytes.NewReader(data), "disk.iso", int64(len(data)), created,
+		[]string{"http://plus/isos/disk.iso"}, []string{"udp://tracker:6969", "http://tracker/announce"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	piece := sha1.Sum(data)
+	want := "d" +
+		"8:announce18:udp://tracker:6969" +
+		"13:announce-listll18:udp://tracker:6969el23:http://tracker/announceee" +
+		"10:created by4:plus" +
+		fmt.Sprintf("13:creation datei%de", created.Unix()) +
+		"4:infod6:lengthi3000e4:name8:disk.iso12:piece lengthi262144e6:pieces20:" + string(piece[:]) + "e" +
+		"8:url-listl25:http://plus/isos/disk.isoe" +
+		"e"
+	if string(out) != want {
+		t.Errorf("Torrent =\n%q\nwant\n%q", out, want)
+	}
+
+	if _, err := Torrent(bytes.NewReader(data), "disk.iso", 10, created, nil, nil); err == nil {
+		t.Error("Torrent accepted a size mismatch")
+	}
+}
@@ -0,0 +1,129 @@
+package metalink
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// TorrentContentType .torrent 文件的媒体类型
+	TorrentContentType = "application/x-bittorrent"
+	// TorrentSuffix 在文件地址后加上该后缀得到 .torrent 的地址
+	TorrentSuffix = ".torrent"
+
+	// 块大小为 2 的幂，块数不超过 maxTorrentPieces
+	minTorrentPiece  = 256 << 10
+	maxTorrentPiece  = 16 << 20
+	maxTorrentPieces = 2048
+)
+
+// torrentPieceLength 按文件大小选择块大小
+func torrentPieceLength(size int64) int64 {
+	length := int64(minTorrentPiece)
+	for length < maxTorrentPiece && (size+length-1)/length > maxTorrentPieces {
+		length *= 2
+	}
+	return length
+}
+
+// Torrent 生成单文件的 .torrent（BitTorrent v1）。webSeeds 为文件的 HTTP 下载地址（BEP 19），
+// 没有其他做种者时客户端从这些地址下载；trackers 为空时客户端只能通过 web seed 和 DHT 找到数据
+func Torrent(r io.Reader, name string, size int64, created time.Time, webSeeds, trackers []string) ([]byte, error) {
+	pieceLength := torrentPieceLength(size)
+	var pieces bytes.Buffer
+	var length int64
+	for {
+		h := sha1.New()
+		n, err := io.Copy(h, io.LimitReader(r, pieceLength))
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			length += n
+			pieces.Write(h.Sum(nil))
+		}
+		if n < pieceLength {
+			break
+		}
+	}
+	if length != size {
+		return nil, fmt.Errorf("torrent %s: read %d bytes, expected %d", name, length, size)
+	}
+
+	torrent := map[string]any{
+		"created by": "plus",
+		"info": map[string]any{
+			"length":       length,
+			"name":         name,
+			"piece length": pieceLength,
+			"pieces":       pieces.String(),
+		},
+	}
+	if !created.IsZero() {
+		torrent["creation date"] = created.Unix()
+	}
+	if len(webSeeds) > 0 {
+		torrent["url-list"] = webSeeds
+	}
+	if len(trackers) > 0 {
+		torrent["announce"] = trackers[0]
+		tiers := make([]any, len(trackers))
+		for i, t := range trackers {
+			tiers[i] = []string{t}
+		}
+		torrent["announce-list"] = tiers
+	}
+	var out bytes.Buffer
+	if err := bencode(&out, torrent); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// bencode 按 BEP 3 编码，字典的键按字节序排列
+func bencode(w *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case string:
+		w.WriteString(strconv.Itoa(len(v)))
+		w.WriteByte(':')
+		w.WriteString(v)
+	case int64:
+		fmt.Fprintf(w, "i%de", v)
+	case []string:
+		w.WriteByte('l')
+		for _, s := range v {
+			bencode(w, s)
+		}
+		w.WriteByte('e')
+	case []any:
+		w.WriteByte('l')
+		for _, item := range v {
+			if err := bencode(w, item); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('e')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w.WriteByte('d')
+		for _, k := range keys {
+			bencode(w, k)
+			if err := bencode(w, v[k]); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('e')
+	default:
+		return fmt.Errorf("bencode: unsupported type %T", v)
+	}
+	return nil
+}
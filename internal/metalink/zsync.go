@@ -0,0 +1,107 @@
+package metalink
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"golang.org/x/crypto/md4"
+)
+
+const (
+	// ZsyncContentType zsync 控制文件的媒体类型
+	ZsyncContentType = "application/x-zsync"
+	// ZsyncSuffix 在文件地址后加上该后缀得到 zsync 控制文件的地址
+	ZsyncSuffix = ".zsync"
+)
+
+// zsyncBlockSize 与 zsyncmake 相同，100MB 以下的文件使用 2KB 的块
+func zsyncBlockSize(size int64) int {
+	if size < 100000000 {
+		return 2048
+	}
+	return 4096
+}
+
+// zsyncHashLengths 与 zsyncmake 相同：按文件大小决定连续匹配的块数、弱校验和与强校验和保存的字节数
+func zsyncHashLengths(size int64, blockSize int) (seqMatches, rsumLen, checksumLen int) {
+	seqMatches = 1
+	if size > int64(blockSize) {
+		seqMatches = 2
+	}
+	n := math.Max(float64(size), 1)
+	blocks := float64(size / int64(blockSize))
+	rsumLen = int(math.Ceil(((math.Log(n)+math.Log(float64(blockSize)))/math.Log(2) - 8.6) / float64(seqMatches) / 8))
+	rsumLen = min(max(rsumLen, 2), 4)
+	checksumLen = int(math.Ceil((20 + (math.Log(n)+math.Log(1+blocks))/math.Log(2)) / float64(seqMatches) / 8))
+	checksumLen = max(checksumLen, int((7.9+(20+math.Log(1+blocks)/math.Log(2)))/8))
+	return seqMatches, rsumLen, min(checksumLen, 16)
+}
+
+// rsum zsync 的弱校验和（rsync 的滚动校验和），a、b 均按 16 位截断
+func rsum(block []byte) [4]byte {
+	var a, b uint16
+	n := len(block)
+	for _, c := range block {
+		a += uint16(c)
+		b += uint16(n) * uint16(c)
+		n--
+	}
+	var out [4]byte
+	binary.BigEndian.PutUint16(out[0:], a)
+	binary.BigEndian.PutUint16(out[2:], b)
+	return out
+}
+
+// Zsync 生成 zsync 0.6.2 控制文件，url 为文件的下载地址（相对控制文件或绝对地址），size 为文件大小
+func Zsync(r io.Reader, name, url string, size int64, mtime time.Time) ([]byte, error) {
+	blockSize := zsyncBlockSize(size)
+	seqMatches, rsumLen, checksumLen := zsyncHashLengths(size, blockSize)
+
+	var sums bytes.Buffer
+	whole := sha1.New()
+	block := make([]byte, blockSize)
+	var length int64
+	for {
+		n, err := io.ReadFull(r, block)
+		if n > 0 {
+			length += int64(n)
+			whole.Write(block[:n])
+			// 最后一块不足时补零
+			clear(block[n:])
+			weak := rsum(block)
+			strong := md4.New()
+			strong.Write(block)
+			sums.Write(weak[4-rsumLen:])
+			sums.Write(strong.Sum(nil)[:checksumLen])
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if length != size {
+		return nil, fmt.Errorf("zsync %s: read %d bytes, expected %d", name, length, size)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "zsync: 0.6.2\n")
+	fmt.Fprintf(&out, "Filename: %s\n", name)
+	if !mtime.IsZero() {
+		fmt.Fprintf(&out, "MTime: %s\n", mtime.UTC().Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	}
+	fmt.Fprintf(&out, "Blocksize: %d\n", blockSize)
+	fmt.Fprintf(&out, "Length: %d\n", length)
+	fmt.Fprintf(&out, "Hash-Lengths: %d,%d,%d\n", seqMatches, rsumLen, checksumLen)
+	fmt.Fprintf(&out, "URL: %s\n", url)
+	fmt.Fprintf(&out, "SHA-1: %s\n\n", hex.EncodeToString(whole.Sum(nil)))
+	out.Write(sums.Bytes())
+	return out.Bytes(), nil
+}
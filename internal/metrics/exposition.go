@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+)
+
+// 文本格式的 Content-Type
+const (
+	PrometheusContentType  = "text/plain; version=0.0.4; charset=utf-8"
+	OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// WriteText 以 Prometheus 文本格式写出计数器和响应时间直方图，openMetrics 为 true 时使用 OpenMetrics 格式
+func WriteText(w io.Writer, openMetrics bool) error {
+	bw := bufio.NewWriter(w)
+	m := GetMetrics()
+
+	for _, c := range []struct {
+		name, help string
+		value      int64
+	}{
+		{"plus_requests", "Requests received.", m.RequestCount},
+		{"plus_uploads", "Upload requests.", m.UploadCount},
+		{"plus_downloads", "Package downloads.", m.DownloadCount},
+		{"plus_redirects", "Downloads redirected to object storage or a CDN.", m.RedirectCount},
+		{"plus_errors", "Responses with status 400 or above.", m.ErrorCount},
+	} {
+		// OpenMetrics 的计数器类型名不带 _total 后缀，样本名带
+		family := c.name + "_total"
+		if openMetrics {
+			family = c.name
+		}
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s counter\n%s_total %d\n", family, c.help, family, c.name, c.value)
+	}
+	fmt.Fprintf(bw, "# HELP plus_active_requests Requests in progress.\n# TYPE plus_active_requests gauge\nplus_active_requests %d\n", m.ActiveRequests)
+	fmt.Fprintf(bw, "# HELP plus_goroutines Goroutines.\n# TYPE plus_goroutines gauge\nplus_goroutines %d\n", runtime.NumGoroutine())
+
+	const name = "plus_http_request_duration_seconds"
+	fmt.Fprintf(bw, "# HELP %s Time to handle a request by route class and status code.\n# TYPE %s histogram\n", name, name)
+	if openMetrics {
+		fmt.Fprintf(bw, "# UNIT %s seconds\n", name)
+	}
+	for _, h := range Latencies() {
+		labels := fmt.Sprintf(`class="%s",code="%d"`, h.Class, h.Code)
+		for i, n := range h.Cumulative {
+			le := "+Inf"
+			if i < len(latencyBuckets) {
+				le = strconv.FormatFloat(latencyBuckets[i], 'g', -1, 64)
+			}
+			fmt.Fprintf(bw, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, le, n)
+		}
+		fmt.Fprintf(bw, "%s_sum{%s} %s\n", name, labels, strconv.FormatFloat(h.Sum, 'g', -1, 64))
+		fmt.Fprintf(bw, "%s_count{%s} %d\n", name, labels, h.Count)
+	}
+
+	if openMetrics {
+		bw.WriteString("# EOF\n")
+	}
+	return bw.Flush()
+}
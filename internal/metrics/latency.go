@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"plus/internal/types"
+)
+
+// 统计响应时间的接口类别
+const (
+	ClassDownload = "download" // 下载仓库中的文件
+	ClassUpload   = "upload"   // 上传文件
+	ClassMetadata = "metadata" // 下载 repodata、Packages、Release 等元数据
+	ClassAPI      = "api"      // 其他接口和页面
+)
+
+// latencyBuckets 响应时间直方图各桶的上限（秒）
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+type routeKey struct {
+	class string
+	code  int
+}
+
+// histogram 一个类别和状态码的响应时间分布
+type histogram struct {
+	buckets []uint64 // 落入各桶的请求数（不累计），最后一个为超过最大上限的请求
+	count   uint64
+	sum     float64 // 秒
+}
+
+var latency = struct {
+	sync.Mutex
+	routes map[routeKey]*histogram
+}{routes: make(map[routeKey]*histogram)}
+
+// RecordLatency 记录一个请求的响应时间
+func RecordLatency(class string, code int, duration time.Duration) {
+	seconds := duration.Seconds()
+	i := sort.SearchFloat64s(latencyBuckets, seconds)
+	key := routeKey{class: class, code: code}
+
+	latency.Lock()
+	defer latency.Unlock()
+	h, ok := latency.routes[key]
+	if !ok {
+		h = &histogram{buckets: make([]uint64, len(latencyBuckets)+1)}
+		latency.routes[key] = h
+	}
+	h.buckets[i]++
+	h.count++
+	h.sum += seconds
+}
+
+// Histogram 一个类别和状态码的响应时间直方图快照
+type Histogram struct {
+	Class      string
+	Code       int
+	Cumulative []uint64 // 不超过 latencyBuckets 各上限的请求数，最后一个为全部请求数
+	Count      uint64
+	Sum        float64 // 秒
+}
+
+// Latencies 返回各类别和状态码的直方图，按类别和状态码排序
+func Latencies() []Histogram {
+	latency.Lock()
+	result := make([]Histogram, 0, len(latency.routes))
+	for key, h := range latency.routes {
+		cumulative := make([]uint64, len(h.buckets))
+		var n uint64
+		for i, c := range h.buckets {
+			n += c
+			cumulative[i] = n
+		}
+		result = append(result, Histogram{Class: key.class, Code: key.code, Cumulative: cumulative, Count: h.count, Sum: h.sum})
+	}
+	latency.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Class != result[j].Class {
+			return result[i].Class < result[j].Class
+		}
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+// ServerErrors 返回启动以来 5xx 响应的数量
+func ServerErrors() int64 {
+	latency.Lock()
+	defer latency.Unlock()
+	var n int64
+	for key, h := range latency.routes {
+		if key.code >= 500 {
+			n += int64(h.count)
+		}
+	}
+	return n
+}
+
+// Quantile 按桶内线性分布估算分位数（秒），落在最后一个桶时返回最大上限
+func (h Histogram) Quantile(q float64) float64 {
+	if h.Count == 0 {
+		return 0
+	}
+	rank := q * float64(h.Count)
+	for i, n := range h.Cumulative {
+		if float64(n) < rank {
+			continue
+		}
+		if i == len(latencyBuckets) {
+			return latencyBuckets[i-1]
+		}
+		lower, below := 0.0, uint64(0)
+		if i > 0 {
+			lower, below = latencyBuckets[i-1], h.Cumulative[i-1]
+		}
+		inBucket := float64(n - below)
+		return lower + (latencyBuckets[i]-lower)*(rank-float64(below))/inBucket
+	}
+	return latencyBuckets[len(latencyBuckets)-1]
+}
+
+// RouteLatencies 返回 /metrics JSON 中的响应时间统计
+func RouteLatencies() []types.RouteLatency {
+	histograms := Latencies()
+	result := make([]types.RouteLatency, 0, len(histograms))
+	ms := func(seconds float64) float64 { return math.Round(seconds*1e4) / 10 }
+	for _, h := range histograms {
+		result = append(result, types.RouteLatency{
+			Class: h.Class,
+			Code:  h.Code,
+			Count: h.Count,
+			AvgMs: ms(h.Sum / float64(h.Count)),
+			P50Ms: ms(h.Quantile(0.5)),
+			P95Ms: ms(h.Quantile(0.95)),
+			P99Ms: ms(h.Quantile(0.99)),
+		})
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatency(t *testing.T) {
+	for i := 0; i < 90; i++ {
+		RecordLatency(ClassDownload, 200, 3*time.Millisecond)
+	}
+	for i := 0; i < 10; i++ {
+		RecordLatency(ClassDownload, 200, 2*time.Minute)
+	}
+	RecordLatency(ClassAPI, 404, 20*time.Millisecond)
+
+	var download Histogram
+	for _, h := range Latencies() {
+		if h.Class == ClassDownload && h.Code == 200 {
+			download = h
+		}
+	}
+	if download.Count != 100 || download.Cumulative[0] != 90 || download.Cumulative[len(latencyBuckets)-1] != 90 ||
+		download.Cumulative[len(latencyBuckets)] != 100 {
+		t.Fatalf("download histogram = %+v", download)
+	}
+	if q := download.Quantile(0.5); q <= 0 || q > 0.005 {
+		t.Errorf("p50 = %f", q)
+	}
+	if q := download.Quantile(0.99); q != 60 {
+		t.Errorf("p99 = %f, want the largest bound", q)
+	}
+
+	var b strings.Builder
+	if err := WriteText(&b, false); err != nil {
+		t.Fatal(err)
+	}
+	text := b.String()
+	for _, want := range []string{
+		"# TYPE plus_requests_total counter\n",
+		`plus_http_request_duration_seconds_bucket{class="download",code="200",le="0.005"} 90`,
+		`plus_http_request_duration_seconds_bucket{class="download",code="200",le="+Inf"} 100`,
+		`plus_http_request_duration_seconds_count{class="api",code="404"} 1`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("prometheus output lacks %q", want)
+		}
+	}
+
+	b.Reset()
+	WriteText(&b, true)
+	if text := b.String(); !strings.Contains(text, "# TYPE plus_requests counter\nplus_requests_total ") ||
+		!strings.HasSuffix(text, "# EOF\n") {
+		t.Errorf("openmetrics output:\n%s", text)
+	}
+}
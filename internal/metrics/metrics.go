@@ -9,6 +9,7 @@ type Metrics struct {
 	RequestCount   int64
 	UploadCount    int64
 	DownloadCount  int64
+	RedirectCount  int64
 	ErrorCount     int64
 	ResponseTime   int64
 	ActiveRequests int64
@@ -28,6 +29,10 @@ func IncrementDownloads() {
 	atomic.AddInt64(&GlobalMetrics.DownloadCount, 1)
 }
 
+func IncrementRedirects() {
+	atomic.AddInt64(&GlobalMetrics.RedirectCount, 1)
+}
+
 func IncrementErrors() {
 	atomic.AddInt64(&GlobalMetrics.ErrorCount, 1)
 }
@@ -49,6 +54,7 @@ func GetMetrics() Metrics {
 		RequestCount:   atomic.LoadInt64(&GlobalMetrics.RequestCount),
 		UploadCount:    atomic.LoadInt64(&GlobalMetrics.UploadCount),
 		DownloadCount:  atomic.LoadInt64(&GlobalMetrics.DownloadCount),
+		RedirectCount:  atomic.LoadInt64(&GlobalMetrics.RedirectCount),
 		ErrorCount:     atomic.LoadInt64(&GlobalMetrics.ErrorCount),
 		ResponseTime:   atomic.LoadInt64(&GlobalMetrics.ResponseTime),
 		ActiveRequests: atomic.LoadInt64(&GlobalMetrics.ActiveRequests),
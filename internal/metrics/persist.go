@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+// StatsFileName 累计计数器在 DatabasePath 下的文件名
+const StatsFileName = "stats.json"
+
+// Store 把本进程的计数器累加到上次保存的值上，定期写入文件，重启后继续累计
+type Store struct {
+	file  string
+	bytes func() (sent, received int64) // 本进程发送和接收的字节数
+
+	mu   sync.Mutex
+	base types.LifetimeStats // 启动前累计的值
+	last types.LifetimeStats // 最近一次保存的值
+}
+
+// OpenStore 加载 dir 下保存的计数器，文件不存在或损坏时从零开始。bytes 可以为 nil
+func OpenStore(dir string, bytes func() (sent, received int64)) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create stats directory: %w", err)
+	}
+	s := &Store{file: filepath.Join(dir, StatsFileName), bytes: bytes}
+	data, err := os.ReadFile(s.file)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("read stats: %w", err)
+	default:
+		if err := s.base.UnmarshalJSON(data); err != nil {
+			// 统计数据损坏不影响启动：保留原文件，从零开始
+			log.Logger.Warnf("Stats %s is corrupt, starting from zero and keeping it as %s.corrupt: %v", s.file, s.file, err)
+			os.Rename(s.file, s.file+".corrupt")
+			s.base = types.LifetimeStats{}
+		}
+	}
+	if s.base.Since == "" {
+		s.base.Since = time.Now().UTC().Format(time.RFC3339)
+	}
+	s.last = s.base
+	return s, nil
+}
+
+// Stats 返回累计值：上次启动前的值加上本进程的计数
+func (s *Store) Stats() *types.LifetimeStats {
+	m := GetMetrics()
+	var sent, received int64
+	if s.bytes != nil {
+		sent, received = s.bytes()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &types.LifetimeStats{
+		Since:         s.base.Since,
+		SavedAt:       s.last.SavedAt,
+		Requests:      s.base.Requests + m.RequestCount,
+		Uploads:       s.base.Uploads + m.UploadCount,
+		Downloads:     s.base.Downloads + m.DownloadCount,
+		Redirects:     s.base.Redirects + m.RedirectCount,
+		Errors:        s.base.Errors + m.ErrorCount,
+		BytesSent:     s.base.BytesSent + sent,
+		BytesReceived: s.base.BytesReceived + received,
+	}
+}
+
+// Save 写入当前累计值，先写临时文件再替换，中途退出不会留下不完整的文件
+func (s *Store) Save() error {
+	stats := s.Stats()
+	stats.SavedAt = time.Now().UTC().Format(time.RFC3339)
+	data, err := stats.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	tmp := s.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("write stats: %w", err)
+	}
+	if err := os.Rename(tmp, s.file); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write stats: %w", err)
+	}
+
+	s.mu.Lock()
+	s.last = *stats
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"plus/internal/log"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestStoreSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	sent := int64(100)
+	bytes := func() (int64, int64) { return sent, 10 }
+
+	s, err := OpenStore(dir, bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := GetMetrics()
+	IncrementUploads()
+	IncrementDownloads()
+	IncrementDownloads()
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	first := s.Stats()
+	if first.Downloads != before.DownloadCount+2 || first.BytesSent != 100 || first.SavedAt == "" {
+		t.Errorf("stats = %+v", first)
+	}
+
+	// 重启：本进程的计数从保存的值继续累计
+	s, err = OpenStore(dir, bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.Stats()
+	if got.Since != first.Since || got.Uploads != first.Uploads+before.UploadCount+1 || got.BytesSent != 200 {
+		t.Errorf("after reload = %+v, saved %+v", got, first)
+	}
+
+	// 损坏的文件被移到一边，计数从零开始
+	os.WriteFile(filepath.Join(dir, StatsFileName), []byte("{"), 0644)
+	s, err = OpenStore(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Stats(); got.BytesSent != 0 || got.Uploads != GetMetrics().UploadCount {
+		t.Errorf("after corrupt file = %+v", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, StatsFileName+".corrupt")); err != nil {
+		t.Error(err)
+	}
+}
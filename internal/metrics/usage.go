@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+// UsageFileName 每日用量在 DatabasePath 下的文件名
+const UsageFileName = "usage.json"
+
+// DefaultUsageRetention 每日用量默认保留的天数
+const DefaultUsageRetention = 400
+
+// DateLayout 用量按 UTC 日期统计
+const DateLayout = "2006-01-02"
+
+// 用量报表的分组方式
+const (
+	UsageByUser  = "user"
+	UsageByToken = "token"
+	UsageByDay   = "day"
+)
+
+type usageKey struct {
+	date  string
+	user  string
+	token string
+}
+
+// Usage 按天记录每个用户和令牌的请求数、上传和下载字节数。
+// file 不为空时由 Save 写入文件，重启后继续累计；超过保留天数的记录在保存时删除
+type Usage struct {
+	file      string
+	retention int
+	now       func() time.Time
+
+	mu   sync.Mutex
+	days map[usageKey]*types.UsageRecord
+}
+
+// OpenUsage 加载 dir 下保存的用量；dir 为空时只在内存中统计。文件损坏时保留原文件，从零开始
+func OpenUsage(dir string, retention int) (*Usage, error) {
+	if retention <= 0 {
+		retention = DefaultUsageRetention
+	}
+	u := &Usage{retention: retention, now: time.Now, days: make(map[usageKey]*types.UsageRecord)}
+	if dir == "" {
+		return u, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create usage directory: %w", err)
+	}
+	u.file = filepath.Join(dir, UsageFileName)
+	data, err := os.ReadFile(u.file)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return u, nil
+	case err != nil:
+		return nil, fmt.Errorf("read usage: %w", err)
+	}
+	var saved types.UsageFile
+	if err := saved.UnmarshalJSON(data); err != nil {
+		log.Logger.Warnf("Usage %s is corrupt, starting from zero and keeping it as %s.corrupt: %v", u.file, u.file, err)
+		os.Rename(u.file, u.file+".corrupt")
+		return u, nil
+	}
+	for i := range saved.Days {
+		r := saved.Days[i]
+		u.days[usageKey{date: r.Date, user: r.User, token: r.Token}] = &r
+	}
+	return u, nil
+}
+
+// Record 记入一个请求的用量，token 为令牌 ID，没有时为空
+func (u *Usage) Record(user, token string, uploaded, downloaded int64) {
+	key := usageKey{date: u.now().UTC().Format(DateLayout), user: user, token: token}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	r, ok := u.days[key]
+	if !ok {
+		r = &types.UsageRecord{Date: key.date, User: user, Token: token}
+		u.days[key] = r
+	}
+	r.Requests++
+	r.BytesUploaded += uploaded
+	r.BytesDownloaded += downloaded
+}
+
+// Report 汇总 from 到 to（含，YYYY-MM-DD）之间的用量，user 不为空时只统计该用户。
+// group 为 user 时每个用户一行，token 时每个令牌一行，day 时每个用户每天一行
+func (u *Usage) Report(from, to, user, group string) ([]types.UsageRecord, types.UsageRecord) {
+	sums := make(map[usageKey]*types.UsageRecord)
+	var total types.UsageRecord
+
+	u.mu.Lock()
+	for key, r := range u.days {
+		if key.date < from || key.date > to || user != "" && key.user != user {
+			continue
+		}
+		k := usageKey{user: key.user}
+		switch group {
+		case UsageByToken:
+			k.token = key.token
+		case UsageByDay:
+			k.date = key.date
+		}
+		sum, ok := sums[k]
+		if !ok {
+			sum = &types.UsageRecord{Date: k.date, User: k.user, Token: k.token}
+			sums[k] = sum
+		}
+		for _, s := range []*types.UsageRecord{sum, &total} {
+			s.Requests += r.Requests
+			s.BytesUploaded += r.BytesUploaded
+			s.BytesDownloaded += r.BytesDownloaded
+		}
+	}
+	u.mu.Unlock()
+
+	records := make([]types.UsageRecord, 0, len(sums))
+	for _, r := range sums {
+		records = append(records, *r)
+	}
+	sortUsage(records)
+	return records, total
+}
+
+// Save 删除超过保留天数的记录并写入文件，未配置文件时只删除过期记录
+func (u *Usage) Save() error {
+	now := u.now().UTC()
+	oldest := now.AddDate(0, 0, -u.retention+1).Format(DateLayout)
+	saved := types.UsageFile{SavedAt: now.Format(time.RFC3339)}
+
+	u.mu.Lock()
+	for key, r := range u.days {
+		if key.date < oldest {
+			delete(u.days, key)
+			continue
+		}
+		saved.Days = append(saved.Days, *r)
+	}
+	u.mu.Unlock()
+
+	if u.file == "" {
+		return nil
+	}
+	sortUsage(saved.Days)
+	data, err := saved.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	tmp := u.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("write usage: %w", err)
+	}
+	if err := os.Rename(tmp, u.file); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write usage: %w", err)
+	}
+	return nil
+}
+
+// sortUsage 按日期、用户、令牌排序
+func sortUsage(records []types.UsageRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.User != b.User {
+			return a.User < b.User
+		}
+		return a.Token < b.Token
+	})
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUsage(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)
+	u, err := OpenUsage(dir, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.now = func() time.Time { return now }
+	u.Record("alice", "tok1", 100, 0)
+	u.Record("alice", "tok2", 0, 2000)
+	u.Record("anonymous", "", 0, 50)
+	now = now.Add(2 * time.Hour)
+	u.Record("alice", "tok1", 0, 300)
+	if err := u.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重启后继续累计
+	u, err = OpenUsage(dir, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.now = func() time.Time { return now }
+
+	records, total := u.Report("2026-03-01", "2026-04-30", "", UsageByUser)
+	if len(records) != 2 || records[0].User != "alice" || records[0].Requests != 3 ||
+		records[0].BytesUploaded != 100 || records[0].BytesDownloaded != 2300 {
+		t.Errorf("by user = %+v", records)
+	}
+	if total.Requests != 4 || total.BytesDownloaded != 2350 {
+		t.Errorf("total = %+v", total)
+	}
+
+	records, _ = u.Report("2026-03-01", "2026-04-30", "alice", UsageByToken)
+	if len(records) != 2 || records[0].Token != "tok1" || records[0].Requests != 2 {
+		t.Errorf("by token = %+v", records)
+	}
+	records, _ = u.Report("2026-03-01", "2026-04-30", "alice", UsageByDay)
+	if len(records) != 2 || records[0].Date != "2026-03-31" || records[1].Date != "2026-04-01" || records[1].Requests != 1 {
+		t.Errorf("by day = %+v", records)
+	}
+	if records, _ := u.Report("2026-04-01", "2026-04-01", "anonymous", UsageByUser); len(records) != 0 {
+		t.Errorf("date range = %+v", records)
+	}
+
+	// 超过保留天数的记录在保存时删除
+	now = now.AddDate(0, 0, 30)
+	u.Record("bob", "", 1, 1)
+	if err := u.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if records, _ := u.Report("2000-01-01", "2100-01-01", "", UsageByUser); len(records) != 1 || records[0].User != "bob" {
+		t.Errorf("after retention = %+v", records)
+	}
+
+	os.WriteFile(filepath.Join(dir, UsageFileName), []byte("{"), 0644)
+	if u, err = OpenUsage(dir, 30); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, UsageFileName+".corrupt")); err != nil {
+		t.Errorf("corrupt file not kept: %v", err)
+	}
+}
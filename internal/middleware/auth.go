@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/valyala/fasthttp"
+	"plus/internal/apierr"
 	"plus/internal/config"
 )
 
@@ -29,20 +30,20 @@ func AuthMiddleware(config *config.Config) func(fasthttp.RequestHandler) fasthtt
 			// 获取 Authorization 头
 			authHeader := string(ctx.Request.Header.Peek("Authorization"))
 			if authHeader == "" {
-				ctx.Error("Authorization required", fasthttp.StatusUnauthorized)
+				apierr.Write(ctx, fasthttp.StatusUnauthorized, apierr.CodeUnauthorized, "Authorization required", "")
 				ctx.Response.Header.Set("WWW-Authenticate", "Bearer")
 				return
 			}
 
 			// 检查 Bearer token
 			if !strings.HasPrefix(authHeader, "Bearer ") {
-				ctx.Error("Invalid authorization format", fasthttp.StatusUnauthorized)
+				apierr.Write(ctx, fasthttp.StatusUnauthorized, apierr.CodeUnauthorized, "Invalid authorization format", "")
 				return
 			}
 
 			token := strings.TrimPrefix(authHeader, "Bearer ")
 			if token != config.Auth.Token {
-				ctx.Error("Invalid token", fasthttp.StatusUnauthorized)
+				apierr.Write(ctx, fasthttp.StatusUnauthorized, apierr.CodeUnauthorized, "Invalid token", "")
 				return
 			}
 
@@ -68,13 +69,13 @@ func APIKeyMiddleware(config *config.Config) func(fasthttp.RequestHandler) fasth
 			}
 
 			if apiKey == "" {
-				ctx.Error("API key required", fasthttp.StatusUnauthorized)
+				apierr.Write(ctx, fasthttp.StatusUnauthorized, apierr.CodeUnauthorized, "API key required", "")
 				return
 			}
 
 			// 验证 API key（这里简化为单个 key，实际可以支持多个）
 			if apiKey != config.Auth.APIKey {
-				ctx.Error("Invalid API key", fasthttp.StatusUnauthorized)
+				apierr.Write(ctx, fasthttp.StatusUnauthorized, apierr.CodeUnauthorized, "Invalid API key", "")
 				return
 			}
 
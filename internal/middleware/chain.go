@@ -0,0 +1,208 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+	"plus/internal/config"
+	"plus/internal/metrics"
+	"plus/pkg/plugin"
+)
+
+// Func 中间件，包装下一个处理器
+type Func = func(fasthttp.RequestHandler) fasthttp.RequestHandler
+
+// 内置中间件的名称
+const (
+	CORS      = "cors"
+	Logging   = "logging"
+	Metrics   = "metrics"
+	Auth      = "auth"      // 启用认证时拒绝没有有效凭据的请求，由 API 提供
+	RateLimit = "ratelimit" // 按客户端 IP 限制 limits.rate-limit
+)
+
+// DefaultChain 没有配置 middleware.chain 时的顺序，启用认证时最后加上 auth
+var DefaultChain = []string{CORS, Logging, Metrics}
+
+// defaultChain 按认证配置返回默认的顺序
+func defaultChain(cfg *config.Config) []string {
+	if cfg.Auth.Enabled {
+		return append(DefaultChain[:len(DefaultChain):len(DefaultChain)], Auth)
+	}
+	return DefaultChain
+}
+
+var builtin = map[string]bool{CORS: true, Logging: true, Metrics: true, Auth: true, RateLimit: true}
+
+// routeClasses 可以单独配置中间件的路由类别，与响应时间统计的类别相同
+var routeClasses = []string{metrics.ClassAPI, metrics.ClassDownload, metrics.ClassMetadata, metrics.ClassUpload}
+
+// route 一类路由使用的中间件和钩子
+type route struct {
+	chain     []string
+	pre, post []plugin.Hook
+}
+
+// Chain 按配置组装的中间件
+type Chain struct {
+	routes  map[string]route // 按路由类别
+	uniform bool             // 所有类别相同，不用判断类别
+	limiter *RateLimiter
+}
+
+// Default 没有 middleware 配置时的中间件
+func Default(cfg *config.Config) *Chain {
+	c := &Chain{routes: make(map[string]route), uniform: true}
+	for _, class := range routeClasses {
+		c.routes[class] = route{chain: defaultChain(cfg)}
+	}
+	return c
+}
+
+// New 检查配置的中间件和钩子，插件注册的中间件和钩子需要先加载。
+// 启用认证时每类路由都必须使用 auth，否则认证只由各接口检查
+func New(cfg *config.Config) (*Chain, error) {
+	mc := cfg.Middleware
+	c := &Chain{routes: make(map[string]route), uniform: len(mc.Routes) == 0}
+	for class := range mc.Routes {
+		if !contains(routeClasses, class) {
+			return nil, fmt.Errorf("middleware route %q: expected one of %s", class, strings.Join(routeClasses, ", "))
+		}
+	}
+
+	for _, class := range routeClasses {
+		override := mc.Routes[class]
+		chain, pre, post := mc.Chain, mc.Pre, mc.Post
+		if chain == nil {
+			chain = defaultChain(cfg)
+		}
+		if override.Chain != nil {
+			chain = override.Chain
+		}
+		if override.Pre != nil {
+			pre = override.Pre
+		}
+		if override.Post != nil {
+			post = override.Post
+		}
+
+		if cfg.Auth.Enabled && !contains(chain, Auth) {
+			return nil, fmt.Errorf("middleware chain for %s routes leaves out %s while auth is enabled", class, Auth)
+		}
+
+		r := route{chain: chain}
+		seen := make(map[string]bool)
+		for _, name := range chain {
+			if seen[name] {
+				return nil, fmt.Errorf("middleware %s: listed twice for %s routes", name, class)
+			}
+			seen[name] = true
+			if _, ok := plugin.LookupMiddleware(name); !builtin[name] && !ok {
+				return nil, fmt.Errorf("middleware %s: not a built-in middleware or registered by a plugin", name)
+			}
+			if name == RateLimit && c.limiter == nil {
+				if cfg.Limits.RateLimit <= 0 {
+					return nil, fmt.Errorf("middleware %s: limits.rate-limit is not set", name)
+				}
+				c.limiter = NewRateLimiter(cfg.Limits.RateLimit)
+			}
+		}
+		var err error
+		if r.pre, err = lookupHooks(pre); err != nil {
+			return nil, err
+		}
+		if r.post, err = lookupHooks(post); err != nil {
+			return nil, err
+		}
+		c.routes[class] = r
+	}
+	return c, nil
+}
+
+func lookupHooks(names []string) ([]plugin.Hook, error) {
+	var list []plugin.Hook
+	for _, name := range names {
+		h, ok := plugin.LookupHook(name)
+		if !ok {
+			return nil, fmt.Errorf("middleware hook %s: not registered by a plugin", name)
+		}
+		list = append(list, h)
+	}
+	return list, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Uses 返回是否有路由类别使用该中间件
+func (c *Chain) Uses(name string) bool {
+	for _, r := range c.routes {
+		if contains(r.chain, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler 用中间件包装 next。provided 为调用方提供的内置中间件（如 auth），没有提供时跳过
+func (c *Chain) Handler(next fasthttp.RequestHandler, provided map[string]Func) fasthttp.RequestHandler {
+	if c.uniform {
+		return c.wrap(c.routes[metrics.ClassAPI], next, provided)
+	}
+	handlers := make(map[string]fasthttp.RequestHandler, len(c.routes))
+	for class, r := range c.routes {
+		handlers[class] = c.wrap(r, next, provided)
+	}
+	return func(ctx *fasthttp.RequestCtx) {
+		handlers[routeClass(string(ctx.Method()), string(ctx.Path()))](ctx)
+	}
+}
+
+// wrap 组装一类路由的处理器：中间件在外，靠前的在外层；钩子紧挨着 next
+func (c *Chain) wrap(r route, next fasthttp.RequestHandler, provided map[string]Func) fasthttp.RequestHandler {
+	h := next
+	if len(r.pre) > 0 || len(r.post) > 0 {
+		h = func(ctx *fasthttp.RequestCtx) {
+			for _, hook := range r.pre {
+				hook(ctx)
+			}
+			next(ctx)
+			for _, hook := range r.post {
+				hook(ctx)
+			}
+		}
+	}
+	for i := len(r.chain) - 1; i >= 0; i-- {
+		if m := c.lookup(r.chain[i], provided); m != nil {
+			h = m(h)
+		}
+	}
+	return h
+}
+
+func (c *Chain) lookup(name string, provided map[string]Func) Func {
+	switch name {
+	case CORS:
+		return CORSMiddleware
+	case Logging:
+		return LoggingMiddleware
+	case Metrics:
+		return MetricsMiddleware
+	case RateLimit:
+		return c.limiter.Handler
+	}
+	if m, ok := provided[name]; ok {
+		return m
+	}
+	if m, ok := plugin.LookupMiddleware(name); ok {
+		return m
+	}
+	return nil
+}
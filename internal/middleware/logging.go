@@ -4,9 +4,19 @@ import (
 	"log"
 	"time"
 
+	"plus/internal/apierr"
+
 	"github.com/valyala/fasthttp"
 )
 
+// RequestIDMiddleware 为每个请求分配 X-Request-ID（沿用请求中的 ID），写入响应头，错误响应和日志中引用
+func RequestIDMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		apierr.RequestID(ctx)
+		next(ctx)
+	}
+}
+
 func LoggingMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
 		start := time.Now()
@@ -14,12 +24,14 @@ func LoggingMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 		next(ctx)
 
 		duration := time.Since(start)
-		log.Printf("[%s] %s %s - %d - %v",
+		log.Printf("[%s] %s %s %s - %d - %v - %s",
 			time.Now().Format("2006-01-02 15:04:05"),
+			ctx.RemoteIP(),
 			ctx.Method(),
 			ctx.Path(),
 			ctx.Response.StatusCode(),
 			duration,
+			apierr.RequestID(ctx),
 		)
 	}
 }
@@ -28,7 +40,8 @@ func CORSMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
 		ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
 		ctx.Response.Header.Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		ctx.Response.Header.Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		ctx.Response.Header.Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+		ctx.Response.Header.Set("Access-Control-Expose-Headers", "X-Request-ID")
 
 		if string(ctx.Method()) == "OPTIONS" {
 			ctx.SetStatusCode(fasthttp.StatusOK)
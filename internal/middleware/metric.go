@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"path"
 	"plus/internal/metrics"
 	"strings"
 	"time"
@@ -8,15 +9,51 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
+// metadataFiles deb 仓库的元数据文件
+var metadataFiles = map[string]bool{
+	"InRelease": true, "Release": true, "Release.gpg": true,
+	"Packages": true, "Packages.gz": true, "Packages.xz": true, "SHA256SUMS": true,
+	"Sources": true, "Sources.gz": true,
+}
+
+// apiPrefixes 不是仓库文件的路径前缀
+var apiPrefixes = []string{"/api/", "/admin/", "/metrics", "/health", "/ready", "/live", "/repos", "/keys", "/static/"}
+
+// routeClass 按路径和方法判断请求所属的类别，用于统计响应时间
+func routeClass(method, p string) string {
+	switch {
+	case strings.Contains(p, "/repodata/") || strings.Contains(p, "/dists/") || metadataFiles[path.Base(p)]:
+		return metrics.ClassMetadata
+	case strings.HasSuffix(p, "/upload") || method == "PUT":
+		return metrics.ClassUpload
+	case method != "GET" && method != "HEAD", p == "/", p == "/repo/":
+		return metrics.ClassAPI
+	}
+	for _, prefix := range apiPrefixes {
+		if strings.HasPrefix(p, prefix) {
+			return metrics.ClassAPI
+		}
+	}
+	// /repo/ 下只有 rpm、deb 下载和带扩展名的文件算作下载，其余为仓库信息、浏览等接口
+	if strings.HasPrefix(p, "/repo/") && !strings.Contains(p, "/rpm/") && !strings.Contains(p, "/deb/") &&
+		!strings.Contains(path.Base(p), ".") {
+		return metrics.ClassAPI
+	}
+	return metrics.ClassDownload
+}
+
 func MetricsMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
 		start := time.Now()
 		metrics.IncrementRequests()
 		metrics.IncrementActiveRequests()
+		class := routeClass(string(ctx.Method()), string(ctx.Path()))
 
 		defer func() {
 			metrics.DecrementActiveRequests()
-			metrics.RecordResponseTime(time.Since(start))
+			duration := time.Since(start)
+			metrics.RecordResponseTime(duration)
+			metrics.RecordLatency(class, ctx.Response.StatusCode(), duration)
 		}()
 
 		next(ctx)
@@ -33,4 +70,4 @@ func MetricsMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 			metrics.IncrementErrors()
 		}
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"plus/internal/apierr"
+)
+
+// maxClients 超过该数量时清理已经恢复满额的客户端
+const maxClients = 10000
+
+// RateLimiter 按客户端 IP 限制每分钟的请求数。令牌桶的容量为一分钟的请求数，允许短时突发
+type RateLimiter struct {
+	rate     float64 // 每秒恢复的令牌数
+	capacity float64
+
+	mu      sync.Mutex
+	clients map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter 创建每个客户端每分钟 perMinute 个请求的限制
+func NewRateLimiter(perMinute int) *RateLimiter {
+	return &RateLimiter{
+		rate:     float64(perMinute) / 60,
+		capacity: float64(perMinute),
+		clients:  make(map[string]*bucket),
+	}
+}
+
+// Allow 取走 key 的一个令牌，没有令牌时返回需要等待的时间
+func (l *RateLimiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.clients[key]
+	if !ok {
+		if len(l.clients) >= maxClients {
+			l.sweep(now)
+		}
+		b = &bucket{tokens: l.capacity, last: now}
+		l.clients[key] = b
+	}
+	b.tokens = math.Min(l.capacity, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep 删除已经恢复满额的客户端。调用方持有锁
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, b := range l.clients {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.capacity {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// Handler 超过限制的请求返回 429 和 Retry-After
+func (l *RateLimiter) Handler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ok, wait := l.Allow(ctx.RemoteIP().String(), time.Now())
+		if !ok {
+			ctx.Response.Header.Set(fasthttp.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			apierr.Write(ctx, fasthttp.StatusTooManyRequests, apierr.CodeTooManyRequests, "Rate limit exceeded", "")
+			return
+		}
+		next(ctx)
+	}
+}
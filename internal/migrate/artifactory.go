@@ -0,0 +1,46 @@
+package migrate
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"plus/internal/types"
+)
+
+// artifactory JFrog Artifactory 的 REST API，url 包含 /artifactory 上下文路径
+type artifactory struct {
+	client
+}
+
+func (a *artifactory) Repo(ctx context.Context, name string) (string, string, error) {
+	var repo types.ArtifactoryRepository
+	if err := a.getJSON(ctx, a.base+"/api/repositories/"+url.PathEscape(name), &repo); err != nil {
+		return "", "", err
+	}
+	typ, err := repoType(repo.PackageType)
+	return repo.PackageType, typ, err
+}
+
+// Artifacts 用 File List API 一次列出仓库中的全部文件
+func (a *artifactory) Artifacts(ctx context.Context, name string) ([]Artifact, error) {
+	var list types.ArtifactoryFileList
+	if err := a.getJSON(ctx, a.base+"/api/storage/"+url.PathEscape(name)+"?list&deep=1&listFolders=0", &list); err != nil {
+		return nil, err
+	}
+	artifacts := make([]Artifact, 0, len(list.Files))
+	for _, f := range list.Files {
+		if f.Folder {
+			continue
+		}
+		p := strings.TrimPrefix(f.URI, "/")
+		artifacts = append(artifacts, Artifact{
+			Path:   p,
+			URL:    a.base + "/" + url.PathEscape(name) + "/" + escapePath(p),
+			Size:   f.Size,
+			SHA256: f.SHA2,
+			SHA1:   f.SHA1,
+		})
+	}
+	return artifacts, nil
+}
@@ -0,0 +1,267 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+// 迁移报告中文件的状态
+const (
+	StateCopied   = "copied"
+	StatePlanned  = "planned" // dry run 时将要复制的文件
+	StateExisting = "existing"
+	StateIgnored  = "ignored"
+	StateFailed   = "failed"
+)
+
+// Artifact 远程仓库中的一个文件
+type Artifact struct {
+	Path   string // 相对远程仓库根目录，使用 / 分隔
+	URL    string
+	Size   int64 // 未知时为 -1
+	SHA256 string
+	SHA1   string
+}
+
+// Source 远程仓库服务
+type Source interface {
+	// Repo 返回远程仓库的格式和对应的 plus 仓库类型（rpm、deb 或 files）
+	Repo(ctx context.Context, name string) (string, string, error)
+	// Artifacts 列出远程仓库中的全部文件
+	Artifacts(ctx context.Context, name string) ([]Artifact, error)
+	// Open 下载文件
+	Open(ctx context.Context, a Artifact) (io.ReadCloser, error)
+}
+
+// Target 写入迁移结果的仓库服务，由 service.RepoService 实现
+type Target interface {
+	GetRepoType(ctx context.Context, repoName string) (string, error)
+	CreateRepo(ctx context.Context, repoName string, repoType string) error
+	ListFiles(ctx context.Context, repoName string) ([]types.Artifact, error)
+	UploadPackage(ctx context.Context, repoName string, filename string, reader io.Reader) error
+	RefreshMetadata(ctx context.Context, repoName string) error
+}
+
+// entry 一个远程文件在 plus 中的位置，repo 为空时不迁移
+type entry struct {
+	artifact Artifact
+	repo     string
+	filename string // UploadPackage 的文件名
+	path     string // 相对仓库根目录的路径
+	reason   string // 不迁移的原因
+}
+
+// Migrate 把远程仓库 remote 复制到 plus 仓库 local。rpm 仓库中每个带 repodata/ 的子目录
+// 成为 local 下的一个子仓库，包按文件名保存，元数据重新生成；deb 仓库保存为扁平仓库；
+// files 仓库保持原有路径。已存在且大小相同的文件不再复制，中断后重新运行即可继续
+func Migrate(ctx context.Context, src Source, target Target, remote, local string, dryRun bool) types.MigratedRepo {
+	r := types.MigratedRepo{Remote: remote, Repo: local, Repos: []string{}, Artifacts: []types.MigratedArtifact{}}
+	format, repoType, err := src.Repo(ctx, remote)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.Format, r.Type = format, repoType
+	artifacts, err := src.Artifacts(ctx, remote)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	log.Logger.Infof("Migrating %s repository %s (%d files) to %s", format, remote, len(artifacts), local)
+
+	entries := plan(artifacts, local, repoType)
+	byRepo := make(map[string][]*entry)
+	for i := range entries {
+		e := &entries[i]
+		if e.repo == "" {
+			r.Ignored++
+			r.Artifacts = append(r.Artifacts, types.MigratedArtifact{Remote: e.artifact.Path, Size: e.artifact.Size, State: StateIgnored, Error: e.reason})
+			continue
+		}
+		if _, ok := byRepo[e.repo]; !ok {
+			r.Repos = append(r.Repos, e.repo)
+		}
+		byRepo[e.repo] = append(byRepo[e.repo], e)
+	}
+	sort.Strings(r.Repos)
+
+	for _, repoName := range r.Repos {
+		existing, perr := prepare(ctx, target, repoName, repoType, dryRun)
+		for _, e := range byRepo[repoName] {
+			a := types.MigratedArtifact{Remote: e.artifact.Path, Repo: repoName, Path: e.path, Size: e.artifact.Size}
+			size, ok := existing[e.path]
+			switch {
+			case perr != nil:
+				a.State, a.Error = StateFailed, perr.Error()
+				r.Failed++
+			case ok && (e.artifact.Size < 0 || size == e.artifact.Size):
+				a.State = StateExisting
+				r.Existing++
+			case dryRun:
+				a.State = StatePlanned
+				r.Copied++
+			default:
+				n, err := copyArtifact(ctx, src, target, e)
+				if err != nil {
+					log.Logger.Warnf("Failed to migrate %s/%s: %v", remote, e.artifact.Path, err)
+					a.State, a.Error = StateFailed, err.Error()
+					r.Failed++
+					break
+				}
+				a.State, a.Size = StateCopied, n
+				r.Copied++
+				r.Size += n
+			}
+			r.Artifacts = append(r.Artifacts, a)
+		}
+		// 上次运行中断时元数据可能尚未生成，因此每次都重新生成
+		if perr == nil && !dryRun && repoType != "files" {
+			if err := target.RefreshMetadata(ctx, repoName); err != nil {
+				log.Logger.Errorf("Failed to refresh metadata of %s: %v", repoName, err)
+				r.Error = fmt.Sprintf("refresh metadata of %s: %v", repoName, err)
+			}
+		}
+	}
+	log.Logger.Infof("Migrated %s to %s: %d copied, %d existing, %d ignored, %d failed", remote, local, r.Copied, r.Existing, r.Ignored, r.Failed)
+	return r
+}
+
+// prepare 确保仓库存在且类型相同，返回仓库中已有文件的大小。dry run 时不创建仓库
+func prepare(ctx context.Context, target Target, repoName, repoType string, dryRun bool) (map[string]int64, error) {
+	existing := make(map[string]int64)
+	typ, err := target.GetRepoType(ctx, repoName)
+	switch {
+	case err == nil && typ != repoType:
+		return nil, fmt.Errorf("repository %s already exists with type %s", repoName, typ)
+	case err != nil && dryRun:
+		return existing, nil
+	case err != nil:
+		// 没有索引时扁平的 deb 仓库无法识别，创建仓库不影响已有文件，之后照常列出
+		if err := target.CreateRepo(ctx, repoName, repoType); err != nil {
+			return nil, err
+		}
+	}
+	files, err := target.ListFiles(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		existing[f.Path] = f.Size
+	}
+	return existing, nil
+}
+
+// plan 按仓库类型决定每个远程文件在 plus 中的位置
+func plan(artifacts []Artifact, local, repoType string) []entry {
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Path < artifacts[j].Path })
+
+	// rpm 仓库中 repodata/repomd.xml 所在的目录各是一个 yum 仓库
+	var roots []string
+	if repoType == "rpm" {
+		for _, a := range artifacts {
+			if a.Path == "repodata/repomd.xml" || strings.HasSuffix(a.Path, "/repodata/repomd.xml") {
+				roots = append(roots, strings.TrimSuffix(strings.TrimSuffix(a.Path, "repodata/repomd.xml"), "/"))
+			}
+		}
+	}
+
+	entries := make([]entry, 0, len(artifacts))
+	seen := make(map[string]string)
+	for _, a := range artifacts {
+		e := entry{artifact: a}
+		base := path.Base(a.Path)
+		switch repoType {
+		case "rpm":
+			root := ""
+			for _, r := range roots {
+				if strings.HasPrefix(a.Path, r+"/") && len(r) > len(root) {
+					root = r
+				}
+			}
+			switch {
+			case strings.HasPrefix(a.Path, "repodata/") || strings.Contains(a.Path, "/repodata/"):
+				e.reason = "metadata is regenerated"
+			case !strings.HasSuffix(base, ".rpm"):
+				e.reason = "not an rpm package"
+			default:
+				e.repo, e.filename, e.path = path.Join(local, root), base, "Packages/"+base
+			}
+		case "deb":
+			if strings.HasSuffix(base, ".deb") {
+				e.repo, e.filename, e.path = local, base, base
+			} else {
+				e.reason = "not a deb package"
+			}
+		default:
+			e.repo, e.filename, e.path = local, a.Path, a.Path
+		}
+		if e.repo != "" {
+			key := e.repo + "\x00" + e.path
+			if first, ok := seen[key]; ok {
+				e.repo, e.reason = "", "same file name as "+first
+			} else {
+				seen[key] = a.Path
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// copyArtifact 下载到临时文件并校验大小和校验和，通过后写入仓库
+func copyArtifact(ctx context.Context, src Source, target Target, e *entry) (int64, error) {
+	rc, err := src.Open(ctx, e.artifact)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	tmp, err := os.CreateTemp("", "plus-migrate-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	var h hash.Hash
+	want := e.artifact.SHA256
+	if want != "" {
+		h = sha256.New()
+	} else if want = e.artifact.SHA1; want != "" {
+		h = sha1.New()
+	}
+	w := io.Writer(tmp)
+	if h != nil {
+		w = io.MultiWriter(tmp, h)
+	}
+	n, err := io.Copy(w, rc)
+	if err != nil {
+		return 0, fmt.Errorf("download: %w", err)
+	}
+	if e.artifact.Size >= 0 && n != e.artifact.Size {
+		return 0, fmt.Errorf("downloaded %d bytes, expected %d", n, e.artifact.Size)
+	}
+	if h != nil && !strings.EqualFold(hex.EncodeToString(h.Sum(nil)), want) {
+		return 0, fmt.Errorf("checksum mismatch")
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if err := target.UploadPackage(ctx, e.repo, e.filename, tmp); err != nil {
+		return 0, err
+	}
+	log.Logger.Debugf("Migrated %s to %s/%s", e.artifact.Path, e.repo, e.path)
+	return n, nil
+}
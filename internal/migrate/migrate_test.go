@@ -0,0 +1,313 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+// target 内存中的仓库，文件按仓库中的路径保存
+type target struct {
+	types     map[string]string
+	files     map[string]map[string]string
+	refreshed []string
+}
+
+func newTarget() *target {
+	return &target{types: map[string]string{}, files: map[string]map[string]string{}}
+}
+
+func (t *target) GetRepoType(_ context.Context, repoName string) (string, error) {
+	if typ, ok := t.types[repoName]; ok {
+		return typ, nil
+	}
+	return "", fmt.Errorf("repository %s not found", repoName)
+}
+
+func (t *target) CreateRepo(_ context.Context, repoName string, repoType string) error {
+	t.types[repoName] = repoType
+	if t.files[repoName] == nil {
+		t.files[repoName] = map[string]string{}
+	}
+	return nil
+}
+
+func (t *target) ListFiles(_ context.Context, repoName string) ([]types.Artifact, error) {
+	var list []types.Artifact
+	for p, data := range t.files[repoName] {
+		list = append(list, types.Artifact{Path: p, Size: int64(len(data))})
+	}
+	return list, nil
+}
+
+func (t *target) UploadPackage(_ context.Context, repoName string, filename string, reader io.Reader) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if t.types[repoName] == "rpm" {
+		filename = "Packages/" + filename
+	}
+	t.files[repoName][filename] = string(data)
+	return nil
+}
+
+func (t *target) RefreshMetadata(_ context.Context, repoName string) error {
+	t.refreshed = append(t.refreshed, repoName)
+	return nil
+}
+
+func (t *target) paths(repoName string) []string {
+	var paths []string
+	for p := range t.files[repoName] {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func sum(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(h[:])
+}
+
+func states(r types.MigratedRepo) map[string]string {
+	m := make(map[string]string)
+	for _, a := range r.Artifacts {
+		m[a.Remote] = a.State
+	}
+	return m
+}
+
+func TestNexus(t *testing.T) {
+	content := map[string]string{
+		"el9/x86_64/repodata/repomd.xml":         "<repomd/>",
+		"el9/x86_64/Packages/a-1.0-1.x86_64.rpm": "aaa",
+		"el9/x86_64/Packages/b-1.0-1.x86_64.rpm": "bbb",
+		"el9/source/repodata/repomd.xml":         "<repomd/>",
+		"el9/source/Packages/a-1.0-1.src.rpm":    "src",
+		"README.txt":                             "readme",
+	}
+	paths := make([]string, 0, len(content))
+	for p := range content {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	badSum := true
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/service/rest/v1/repositories/yum-hosted":
+			io.WriteString(w, `{"name":"yum-hosted","format":"yum","type":"hosted"}`)
+		case r.URL.Path == "/service/rest/v1/assets" && r.URL.Query().Get("repository") == "yum-hosted":
+			// 每页 4 个资产
+			page, token := paths[:4], "page2"
+			if r.URL.Query().Get("continuationToken") == "page2" {
+				page, token = paths[4:], ""
+			}
+			var items []string
+			for _, p := range page {
+				s := sum(content[p])
+				if badSum && strings.HasPrefix(path.Base(p), "b-") {
+					s = sum("other")
+				}
+				items = append(items, fmt.Sprintf(`{"downloadUrl":"%s/repository/yum-hosted/%s","path":"%s","checksum":{"sha256":"%s"},"fileSize":%d}`, srv.URL, p, p, s, len(content[p])))
+			}
+			fmt.Fprintf(w, `{"items":[%s],"continuationToken":%q}`, strings.Join(items, ","), token)
+		case strings.HasPrefix(r.URL.Path, "/repository/yum-hosted/"):
+			data, ok := content[strings.TrimPrefix(r.URL.Path, "/repository/yum-hosted/")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			io.WriteString(w, data)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	src, err := New(config.MigrationSourceConfig{Name: "nexus", Kind: KindNexus, URL: srv.URL, Username: "admin", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := newTarget()
+
+	// dry run 不创建仓库
+	r := Migrate(context.Background(), src, dst, "yum-hosted", "prod", true)
+	if r.Error != "" || r.Copied != 3 || len(dst.types) != 0 {
+		t.Fatalf("Dry run = %+v", r)
+	}
+
+	r = Migrate(context.Background(), src, dst, "yum-hosted", "prod", false)
+	if r.Error != "" || r.Format != "yum" || r.Type != "rpm" {
+		t.Fatalf("Migrate = %+v", r)
+	}
+	if want := []string{"prod/el9/source", "prod/el9/x86_64"}; !reflect.DeepEqual(r.Repos, want) {
+		t.Errorf("Repos = %v, want %v", r.Repos, want)
+	}
+	if r.Copied != 2 || r.Failed != 1 || r.Ignored != 3 || r.Size != 6 {
+		t.Errorf("Copied %d, failed %d, ignored %d, size %d", r.Copied, r.Failed, r.Ignored, r.Size)
+	}
+	st := states(r)
+	if st["el9/x86_64/Packages/b-1.0-1.x86_64.rpm"] != StateFailed || st["README.txt"] != StateIgnored || st["el9/source/repodata/repomd.xml"] != StateIgnored {
+		t.Errorf("States = %v", st)
+	}
+	if got := dst.paths("prod/el9/x86_64"); !reflect.DeepEqual(got, []string{"Packages/a-1.0-1.x86_64.rpm"}) {
+		t.Errorf("prod/el9/x86_64 = %v", got)
+	}
+
+	// 重新运行时只复制缺少的文件
+	badSum = false
+	r = Migrate(context.Background(), src, dst, "yum-hosted", "prod", false)
+	if r.Copied != 1 || r.Existing != 2 || r.Failed != 0 {
+		t.Errorf("Resumed migration = %+v", r)
+	}
+	if got := dst.paths("prod/el9/x86_64"); len(got) != 2 || dst.files["prod/el9/x86_64"]["Packages/b-1.0-1.x86_64.rpm"] != "bbb" {
+		t.Errorf("prod/el9/x86_64 = %v", got)
+	}
+	if len(dst.refreshed) != 4 {
+		t.Errorf("Refreshed %v", dst.refreshed)
+	}
+}
+
+func TestArtifactory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/artifactory/api/repositories/debian-local":
+			io.WriteString(w, `{"key":"debian-local","packageType":"debian","rclass":"local"}`)
+		case "/artifactory/api/storage/debian-local":
+			fmt.Fprintf(w, `{"files":[{"uri":"/pool/main/h/hello_2.0-1_amd64.deb","size":5,"folder":false,"sha1":"%x"},{"uri":"/dists/jammy/Release","size":3,"folder":false},{"uri":"/pool","folder":true}]}`,
+				sha1.Sum([]byte("hello")))
+		case "/artifactory/debian-local/pool/main/h/hello_2.0-1_amd64.deb":
+			io.WriteString(w, "hello")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	src, err := New(config.MigrationSourceConfig{Name: "jfrog", Kind: KindArtifactory, URL: srv.URL + "/artifactory/", Token: "tok"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := newTarget()
+	r := Migrate(context.Background(), src, dst, "debian-local", "ubuntu/jammy", false)
+	if r.Error != "" || r.Type != "deb" || r.Copied != 1 || r.Ignored != 1 {
+		t.Fatalf("Migrate = %+v", r)
+	}
+	if dst.files["ubuntu/jammy"]["hello_2.0-1_amd64.deb"] != "hello" {
+		t.Errorf("ubuntu/jammy = %v", dst.files["ubuntu/jammy"])
+	}
+
+	// 同名仓库类型不同时不写入
+	dst = newTarget()
+	dst.CreateRepo(context.Background(), "ubuntu/jammy", "rpm")
+	r = Migrate(context.Background(), src, dst, "debian-local", "ubuntu/jammy", false)
+	if r.Failed != 1 || len(dst.files["ubuntu/jammy"]) != 0 || len(dst.refreshed) != 0 {
+		t.Errorf("Migrate into an rpm repository = %+v", r)
+	}
+
+	if r := Migrate(context.Background(), src, dst, "missing", "x", false); !strings.Contains(r.Error, "not found") {
+		t.Errorf("Missing repository error = %q", r.Error)
+	}
+}
+
+func TestPulp(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/pulp/api/v3/repositories/":
+			if q.Get("name") == "isos" {
+				io.WriteString(w, `{"results":[{"pulp_href":"/pulp/api/v3/repositories/file/file/0190/","name":"isos","latest_version_href":"/pulp/api/v3/repositories/file/file/0190/versions/3/"}]}`)
+				return
+			}
+			io.WriteString(w, `{"results":[]}`)
+		case "/pulp/api/v3/distributions/file/file/":
+			if q.Get("repository") != "/pulp/api/v3/repositories/file/file/0190/" {
+				io.WriteString(w, `{"results":[]}`)
+				return
+			}
+			io.WriteString(w, `{"results":[{"name":"isos","base_url":"/pulp/content/isos/"}]}`)
+		case "/pulp/api/v3/content/file/files/":
+			if q.Get("repository_version") != "/pulp/api/v3/repositories/file/file/0190/versions/3/" {
+				t.Errorf("Content query %s", r.URL.RawQuery)
+			}
+			if q.Get("offset") == "" {
+				fmt.Fprintf(w, `{"next":"%s/pulp/api/v3/content/file/files/?offset=1&repository_version=%s","results":[{"relative_path":"el9/boot.iso","sha256":"%s","size":4}]}`,
+					srv.URL, q.Get("repository_version"), sum("boot"))
+				return
+			}
+			fmt.Fprintf(w, `{"next":null,"results":[{"relative_path":"el9/dvd 1.iso","sha256":"%s","size":3}]}`, sum("dvd"))
+		case "/pulp/content/isos/el9/boot.iso":
+			io.WriteString(w, "boot")
+		case "/pulp/content/isos/el9/dvd 1.iso":
+			io.WriteString(w, "dvd")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	src, err := New(config.MigrationSourceConfig{Name: "pulp", Kind: KindPulp, URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := newTarget()
+	r := Migrate(context.Background(), src, dst, "isos", "isos", false)
+	if r.Error != "" || r.Format != "file" || r.Type != "files" || r.Copied != 2 {
+		t.Fatalf("Migrate = %+v", r)
+	}
+	if got := dst.paths("isos"); !reflect.DeepEqual(got, []string{"el9/boot.iso", "el9/dvd 1.iso"}) {
+		t.Errorf("isos = %v", got)
+	}
+	// files 仓库没有元数据
+	if len(dst.refreshed) != 0 {
+		t.Errorf("Refreshed %v", dst.refreshed)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for name, cfg := range map[string]config.MigrationSourceConfig{
+		"kind":    {Kind: "quay", URL: "https://quay.io"},
+		"url":     {Kind: KindNexus, URL: "nexus.example.com"},
+		"auth":    {Kind: KindNexus, URL: "https://nexus.example.com", Username: "a", Token: "b"},
+		"timeout": {Kind: KindPulp, URL: "https://pulp.example.com", Timeout: "soon"},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
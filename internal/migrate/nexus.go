@@ -0,0 +1,57 @@
+package migrate
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"plus/internal/types"
+)
+
+// nexus Nexus Repository 3 的 REST API
+type nexus struct {
+	client
+}
+
+func (n *nexus) Repo(ctx context.Context, name string) (string, string, error) {
+	var repo types.NexusRepository
+	if err := n.getJSON(ctx, n.base+"/service/rest/v1/repositories/"+url.PathEscape(name), &repo); err != nil {
+		return "", "", err
+	}
+	typ, err := repoType(repo.Format)
+	return repo.Format, typ, err
+}
+
+// Artifacts 按 continuationToken 分页列出仓库中的资产
+func (n *nexus) Artifacts(ctx context.Context, name string) ([]Artifact, error) {
+	var artifacts []Artifact
+	token := ""
+	for {
+		u := n.base + "/service/rest/v1/assets?repository=" + url.QueryEscape(name)
+		if token != "" {
+			u += "&continuationToken=" + url.QueryEscape(token)
+		}
+		var page types.NexusAssets
+		if err := n.getJSON(ctx, u, &page); err != nil {
+			return nil, err
+		}
+		for _, asset := range page.Items {
+			a := Artifact{
+				Path:   strings.TrimPrefix(asset.Path, "/"),
+				URL:    asset.DownloadURL,
+				Size:   asset.FileSize,
+				SHA256: asset.Checksum["sha256"],
+				SHA1:   asset.Checksum["sha1"],
+			}
+			if a.Size == 0 {
+				// 旧版本不返回大小
+				a.Size = -1
+			}
+			artifacts = append(artifacts, a)
+		}
+		if page.ContinuationToken == "" {
+			return artifacts, nil
+		}
+		token = page.ContinuationToken
+	}
+}
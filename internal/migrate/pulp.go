@@ -0,0 +1,116 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"plus/internal/types"
+)
+
+// pulp Pulp 3 的 REST API。包从服务该仓库的 distribution 下载
+type pulp struct {
+	client
+}
+
+// pulpContent 各插件的内容接口
+var pulpContent = map[string]string{
+	"rpm":  "content/rpm/packages/",
+	"deb":  "content/deb/packages/",
+	"file": "content/file/files/",
+}
+
+// find 按名称查找仓库，返回仓库和 href 中的插件与类型，如 rpm/rpm、deb/apt
+func (p *pulp) find(ctx context.Context, name string) (types.PulpRepository, string, string, error) {
+	var list types.PulpRepositoryList
+	if err := p.getJSON(ctx, p.base+"/pulp/api/v3/repositories/?name="+url.QueryEscape(name), &list); err != nil {
+		return types.PulpRepository{}, "", "", err
+	}
+	for _, repo := range list.Results {
+		if repo.Name != name {
+			continue
+		}
+		_, rest, _ := strings.Cut(repo.PulpHref, "/repositories/")
+		parts := strings.Split(rest, "/")
+		if len(parts) < 2 {
+			return repo, "", "", fmt.Errorf("unexpected repository href %s", repo.PulpHref)
+		}
+		return repo, parts[0], parts[1], nil
+	}
+	return types.PulpRepository{}, "", "", fmt.Errorf("%s: %w", name, ErrNotFound)
+}
+
+func (p *pulp) Repo(ctx context.Context, name string) (string, string, error) {
+	_, plugin, _, err := p.find(ctx, name)
+	if err != nil {
+		return "", "", err
+	}
+	typ, err := repoType(plugin)
+	return plugin, typ, err
+}
+
+// Artifacts 列出仓库最新版本中的内容
+func (p *pulp) Artifacts(ctx context.Context, name string) ([]Artifact, error) {
+	repo, plugin, typ, err := p.find(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, ok := pulpContent[plugin]
+	if !ok {
+		return nil, fmt.Errorf("unsupported repository format %q", plugin)
+	}
+	if repo.LatestVersionHref == "" {
+		return nil, nil
+	}
+
+	var dists types.PulpDistributionList
+	if err := p.getJSON(ctx, p.base+"/pulp/api/v3/distributions/"+plugin+"/"+typ+"/?repository="+url.QueryEscape(repo.PulpHref), &dists); err != nil {
+		return nil, err
+	}
+	baseURL := ""
+	for _, d := range dists.Results {
+		if d.BaseURL != "" {
+			baseURL = strings.TrimSuffix(d.BaseURL, "/")
+			break
+		}
+	}
+	if baseURL == "" {
+		return nil, fmt.Errorf("no distribution serves repository %s", name)
+	}
+	if strings.HasPrefix(baseURL, "/") {
+		baseURL = p.origin() + baseURL
+	}
+
+	var artifacts []Artifact
+	next := p.base + "/pulp/api/v3/" + endpoint + "?repository_version=" + url.QueryEscape(repo.LatestVersionHref) + "&limit=1000"
+	for next != "" {
+		var page types.PulpContentList
+		if err := p.getJSON(ctx, next, &page); err != nil {
+			return nil, err
+		}
+		for _, c := range page.Results {
+			a := Artifact{Path: c.RelativePath, Size: c.Size, SHA256: c.SHA256}
+			if plugin == "rpm" {
+				a = Artifact{Path: c.LocationHref, Size: c.SizePackage}
+				if c.ChecksumType == "sha256" {
+					a.SHA256 = c.PkgID
+				}
+			}
+			if a.Size == 0 {
+				a.Size = -1
+			}
+			a.Path = strings.TrimPrefix(a.Path, "/")
+			a.URL = baseURL + "/" + escapePath(a.Path)
+			artifacts = append(artifacts, a)
+		}
+		next = page.Next
+	}
+	return artifacts, nil
+}
+
+// origin 返回 url 的 scheme 和主机，用于拼接 API 返回的 href
+func (p *pulp) origin() string {
+	u, _ := url.Parse(p.base)
+	return u.Scheme + "://" + u.Host
+}
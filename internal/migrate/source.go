@@ -0,0 +1,150 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"plus/internal/config"
+)
+
+// 支持的远程仓库服务
+const (
+	KindNexus       = "nexus"
+	KindArtifactory = "artifactory"
+	KindPulp        = "pulp"
+)
+
+const (
+	defaultTimeout = 10 * time.Minute
+	maxResponse    = 256 << 20 // 列表接口响应的最大长度
+)
+
+var ErrNotFound = errors.New("repository not found")
+
+// New 按配置创建远程仓库服务的客户端
+func New(cfg config.MigrationSourceConfig) (Source, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("migration source %s: invalid url %q", cfg.Name, cfg.URL)
+	}
+	if cfg.Token != "" && cfg.Username != "" {
+		return nil, fmt.Errorf("migration source %s: set either token or username", cfg.Name)
+	}
+	timeout := defaultTimeout
+	if cfg.Timeout != "" {
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("migration source %s: invalid timeout %q", cfg.Name, cfg.Timeout)
+		}
+	}
+	c := client{
+		base:     strings.TrimSuffix(cfg.URL, "/"),
+		host:     u.Host,
+		username: cfg.Username,
+		password: cfg.Password,
+		token:    cfg.Token,
+		http:     &http.Client{Timeout: timeout},
+	}
+	switch cfg.Kind {
+	case KindNexus:
+		return &nexus{c}, nil
+	case KindArtifactory:
+		return &artifactory{c}, nil
+	case KindPulp:
+		return &pulp{c}, nil
+	}
+	return nil, fmt.Errorf("migration source %s: unsupported kind %q (nexus, artifactory or pulp)", cfg.Name, cfg.Kind)
+}
+
+// repoType 把远程仓库的格式转换为 plus 仓库类型
+func repoType(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "yum", "rpm":
+		return "rpm", nil
+	case "apt", "debian", "deb":
+		return "deb", nil
+	case "raw", "generic", "file":
+		return "files", nil
+	}
+	return "", fmt.Errorf("unsupported repository format %q", format)
+}
+
+type unmarshaler interface {
+	UnmarshalJSON([]byte) error
+}
+
+// client 各服务共用的 HTTP 客户端
+type client struct {
+	base     string
+	host     string
+	username string
+	password string
+	token    string
+	http     *http.Client
+}
+
+// get 发送 GET 请求，只向配置的主机发送凭据
+func (c *client) get(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.URL.Host == c.host {
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		} else if c.username != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("GET %s: %w", req.URL.Path, ErrNotFound)
+		}
+		return nil, fmt.Errorf("GET %s: %s", req.URL.Path, resp.Status)
+	}
+	return resp, nil
+}
+
+func (c *client) getJSON(ctx context.Context, rawURL string, v unmarshaler) error {
+	resp, err := c.get(ctx, rawURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponse))
+	if err != nil {
+		return err
+	}
+	if err := v.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("GET %s: invalid response: %w", resp.Request.URL.Path, err)
+	}
+	return nil
+}
+
+// Open 实现 Source
+func (c *client) Open(ctx context.Context, a Artifact) (io.ReadCloser, error) {
+	resp, err := c.get(ctx, a.URL)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// escapePath 逐段转义路径
+func escapePath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,86 @@
+// Package mimetype 按扩展名确定下载文件的 Content-Type，配置的 mime-types 优先于内置的类型
+package mimetype
+
+import (
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultType 没有对应类型的文件的 Content-Type
+const DefaultType = "application/octet-stream"
+
+// builtin 内置的扩展名到 Content-Type 的映射
+var builtin = map[string]string{
+	".txt":  "text/plain; charset=utf-8",
+	".log":  "text/plain; charset=utf-8",
+	".sql":  "text/plain; charset=utf-8",
+	".json": "application/json",
+	".xml":  "application/xml",
+	".html": "text/html; charset=utf-8",
+	".htm":  "text/html; charset=utf-8",
+	".css":  "text/css",
+	".js":   "application/javascript",
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".svg":  "image/svg+xml",
+	".pdf":  "application/pdf",
+	".zip":  "application/zip",
+	".gz":   "application/gzip",
+	".rpm":  "application/x-rpm",
+	".deb":  "application/vnd.debian.binary-package",
+}
+
+var (
+	mu     sync.RWMutex
+	custom map[string]string
+)
+
+// Set 设置配置的扩展名（如 .qcow2，可省略前导点，不区分大小写）到 Content-Type 的映射，替换之前的设置。
+// 同时注册到标准库 mime，直接读取本地文件的下载（fasthttp.ServeFile）也使用这些类型
+func Set(types map[string]string) error {
+	m := make(map[string]string, len(types))
+	for ext, contentType := range types {
+		normalized := "." + strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ext), "."))
+		if normalized == "." || strings.ContainsAny(normalized[1:], "./") {
+			return fmt.Errorf("invalid mime-types extension %q", ext)
+		}
+		if _, _, err := mime.ParseMediaType(contentType); err != nil {
+			return fmt.Errorf("invalid mime-types content type %q for %s: %w", contentType, ext, err)
+		}
+		m[normalized] = contentType
+	}
+	for ext, contentType := range m {
+		if err := mime.AddExtensionType(ext, contentType); err != nil {
+			return fmt.Errorf("failed to register content type of %s: %w", ext, err)
+		}
+	}
+
+	mu.Lock()
+	custom = m
+	mu.Unlock()
+	return nil
+}
+
+// Lookup 返回配置中 filename 扩展名的 Content-Type，没有配置时返回 false
+func Lookup(filename string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	contentType, ok := custom[strings.ToLower(filepath.Ext(filename))]
+	return contentType, ok
+}
+
+// ByExtension 返回 filename 的 Content-Type：先查配置，再查内置的类型，都没有时为 application/octet-stream
+func ByExtension(filename string) string {
+	if contentType, ok := Lookup(filename); ok {
+		return contentType
+	}
+	if contentType, ok := builtin[strings.ToLower(filepath.Ext(filename))]; ok {
+		return contentType
+	}
+	return DefaultType
+}
@@ -0,0 +1,51 @@
+package mimetype
+
+import (
+	"mime"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	t.Cleanup(func() { Set(nil) })
+	if err := Set(map[string]string{
+		".qcow2": "application/x-qemu-disk",
+		"VMDK":   "application/x-vmdk",
+		".rpm":   "application/x-redhat-package-manager",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"disk.QCOW2":           "application/x-qemu-disk",
+		"images/disk.vmdk":     "application/x-vmdk",
+		"nginx-1.0.x86_64.rpm": "application/x-redhat-package-manager",
+		"hello_1.0_amd64.deb":  "application/vnd.debian.binary-package",
+		"LICENSE":              DefaultType,
+	} {
+		if got := ByExtension(name); got != want {
+			t.Errorf("ByExtension(%q) = %q, want %q", name, got, want)
+		}
+	}
+	if got := mime.TypeByExtension(".qcow2"); got != "application/x-qemu-disk" {
+		t.Errorf("mime.TypeByExtension(.qcow2) = %q", got)
+	}
+
+	for _, types := range []map[string]string{
+		{"": "text/plain"},
+		{".tar.gz": "application/gzip"},
+		{".iso": "not a type"},
+	} {
+		if err := Set(types); err == nil {
+			t.Errorf("Set(%v) accepted an invalid mapping", types)
+		}
+	}
+	// 设置失败时保留原来的映射
+	if got := ByExtension("disk.qcow2"); got != "application/x-qemu-disk" {
+		t.Errorf("after invalid Set, ByExtension(disk.qcow2) = %q", got)
+	}
+	if err := Set(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := ByExtension("disk.qcow2"); got != DefaultType {
+		t.Errorf("after reset, ByExtension(disk.qcow2) = %q", got)
+	}
+}
@@ -0,0 +1,394 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"plus/internal/alert"
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+)
+
+// 事件名称
+const (
+	EventProtectedUpload   = "upload.protected"   // 受保护的仓库中上传了文件（未覆盖已有文件）
+	EventSyncFailed        = "sync.failed"        // 代理仓库同步上游失败
+	EventQuota             = "quota.approaching"  // 存储用量告警触发
+	EventApprovalRequested = "approval.requested" // 新的待批准请求
+	EventPackagePublished  = "package.published"  // 文件进入仓库：上传、发布暂存区或放入批准的上传
+	EventRetentionDeleted  = "retention.deleted"  // 保留策略删除了旧版本
+)
+
+// Events 所有事件，按名称排序
+var Events = []string{EventApprovalRequested, EventPackagePublished, EventQuota, EventRetentionDeleted, EventSyncFailed, EventProtectedUpload}
+
+// Valid 事件名称是否存在
+func Valid(name string) bool {
+	_, ok := defaultTemplates[name]
+	return ok
+}
+
+// footer 默认正文末尾的公共部分
+const footer = `
+Time: {{.Time.Format "2006-01-02 15:04:05 MST"}}
+{{- if .Node}}
+Node: {{.Node}}
+{{- end}}
+`
+
+// defaultTemplates 各事件默认的主题和正文
+var defaultTemplates = map[string]config.EmailTemplate{
+	EventProtectedUpload: {
+		Subject: `[plus] {{.User}} uploaded to protected repository {{.Repo}}`,
+		Body: `{{.User}} uploaded {{join .Files ", "}} to the protected repository {{.Repo}}.
+` + footer,
+	},
+	EventSyncFailed: {
+		Subject: `[plus] Sync of proxy repository {{.Repo}} failed`,
+		Body: `Syncing the proxy repository {{.Repo}} from its upstream failed:
+
+{{.Message}}
+
+Clients keep receiving the last verified metadata until a sync succeeds.
+` + footer,
+	},
+	EventQuota: {
+		Subject: `[plus] Storage is approaching its quota`,
+		Body: `{{.Message}}
+` + footer,
+	},
+	EventPackagePublished: {
+		Subject: `[plus] Published {{len .Files}} file(s) to {{.Repo}}`,
+		Body: `{{len .Files}} file(s) were published to {{.Repo}}:
+{{range .Files}}
+  {{.}}
+{{- end}}
+` + footer,
+	},
+	EventRetentionDeleted: {
+		Subject: `[plus] Retention deleted {{.Count}} package(s) from {{.Repo}}`,
+		Body: `The retention policy of {{.Repo}} deleted {{.Count}} old package version(s):
+{{range .Files}}
+  {{.}}
+{{- end}}
+` + footer,
+	},
+	EventApprovalRequested: {
+		Subject: `[plus] Approval requested: {{.Operation}} on {{.Repo}}`,
+		Body: `{{.User}} requested {{.Operation}} on the protected repository {{.Repo}}.
+{{- if .Files}}
+
+Files: {{join .Files ", "}}
+{{- end}}
+
+Another admin must approve or reject request {{.Approval}}
+{{- if .URL}} at {{.URL}}{{end}}.
+` + footer,
+	},
+}
+
+// Event 一次通知，也是模板的数据
+type Event struct {
+	Seq       int64 // 事件日志中的 seq，没有事件日志时为 0
+	Name      string
+	Repo      string
+	User      string
+	Operation string   // approval.requested：需要批准的操作
+	Approval  string   // approval.requested：批准请求 ID
+	Files     []string // 上传、发布、删除或等待批准的文件
+	Count     int      // retention.deleted：删除的文件数
+	Message   string
+	URL       string // 批准请求的接口地址，配置 external-url 时才有
+	Node      string
+	Time      time.Time
+}
+
+// Sender 接收事件的通知方式，Send 不能阻塞
+type Sender interface {
+	Send(e Event)
+}
+
+// Channel 由投递队列调用的通知方式。队列先按 Targets 为每个目标保存一次投递，
+// 再调用 Deliver，返回错误时稍后重试，直到成功或次数用完
+type Channel interface {
+	Name() string
+	// Targets 返回订阅了事件的目标，如 webhook ID，没有时返回空
+	Targets(e Event) []string
+	// Deliver 把事件发给一个目标，不重试
+	Deliver(ctx context.Context, target string, e Event) error
+}
+
+// ErrPermanent 重试也不会成功的投递错误，如目标拒绝了消息，投递直接失败
+var ErrPermanent = errors.New("permanent failure")
+
+// Fanout 把事件交给每个通知方式
+type Fanout []Sender
+
+// Send 实现 Sender
+func (f Fanout) Send(e Event) {
+	for _, s := range f {
+		s.Send(e)
+	}
+}
+
+type message struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// Notifier 按路由把事件发给收件人
+type Notifier struct {
+	addr        string
+	auth        smtp.Auth
+	from        string
+	node        string
+	externalURL string
+	routes      []config.NotificationRoute
+	templates   map[string]message
+
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// New 检查配置并解析模板。externalURL 用于生成批准请求的地址，可以为空
+func New(cfg config.NotificationsConfig, node, externalURL string) (*Notifier, error) {
+	host, _, err := net.SplitHostPort(cfg.SMTP.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notifications smtp addr %q, expected host:port", cfg.SMTP.Addr)
+	}
+	if cfg.SMTP.From == "" {
+		return nil, fmt.Errorf("notifications smtp requires from")
+	}
+	if len(cfg.Routes) == 0 {
+		return nil, fmt.Errorf("notifications require at least one route")
+	}
+	for i, r := range cfg.Routes {
+		if len(r.Events) == 0 || len(r.To) == 0 {
+			return nil, fmt.Errorf("notification route %d requires events and to", i+1)
+		}
+		for _, name := range r.Events {
+			if !Valid(name) && name != "*" {
+				return nil, fmt.Errorf("notification route %d: unknown event %q, expected one of %s", i+1, name, strings.Join(Events, ", "))
+			}
+		}
+		for _, pattern := range r.Repos {
+			if !utils.ValidGlob(pattern) {
+				return nil, fmt.Errorf("notification route %d: invalid repository pattern %q", i+1, pattern)
+			}
+		}
+	}
+	for name := range cfg.Templates {
+		if !Valid(name) {
+			return nil, fmt.Errorf("notification template for unknown event %q", name)
+		}
+	}
+
+	n := &Notifier{
+		addr:        cfg.SMTP.Addr,
+		from:        cfg.SMTP.From,
+		node:        node,
+		externalURL: strings.TrimSuffix(externalURL, "/"),
+		routes:      cfg.Routes,
+		templates:   make(map[string]message),
+		send:        smtp.SendMail,
+	}
+	if cfg.SMTP.Username != "" {
+		n.auth = smtp.PlainAuth("", cfg.SMTP.Username, cfg.SMTP.Password, host)
+	}
+	funcs := template.FuncMap{"join": strings.Join}
+	for name, def := range defaultTemplates {
+		t := cfg.Templates[name]
+		if t.Subject == "" {
+			t.Subject = def.Subject
+		}
+		if t.Body == "" {
+			t.Body = def.Body
+		}
+		subject, err := template.New(name).Funcs(funcs).Parse(t.Subject)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification subject for %s: %w", name, err)
+		}
+		body, err := template.New(name).Funcs(funcs).Parse(t.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification body for %s: %w", name, err)
+		}
+		n.templates[name] = message{subject: subject, body: body}
+	}
+	return n, nil
+}
+
+// Send 在后台发送事件，没有匹配的路由时不发送，失败时不重试
+func (n *Notifier) Send(e Event) {
+	to := n.Recipients(e.Name, e.Repo)
+	if len(to) == 0 {
+		return
+	}
+	go func() {
+		if err := n.deliver(e, to); err != nil {
+			log.Logger.Warnf("Failed to send %s notification for %s: %v", e.Name, e.Repo, err)
+		}
+	}()
+}
+
+// Name 实现 Channel
+func (n *Notifier) Name() string { return "email" }
+
+// Targets 实现 Channel，所有收件人是一个目标，收件人以 ", " 分隔
+func (n *Notifier) Targets(e Event) []string {
+	to := n.Recipients(e.Name, e.Repo)
+	if len(to) == 0 {
+		return nil
+	}
+	return []string{strings.Join(to, ", ")}
+}
+
+// Deliver 实现 Channel，发给入队时的收件人。SMTP 服务器返回 5xx 时不再重试
+func (n *Notifier) Deliver(_ context.Context, target string, e Event) error {
+	err := n.deliver(e, strings.Split(target, ", "))
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+		return fmt.Errorf("%w: %w", ErrPermanent, err)
+	}
+	return err
+}
+
+// Alerts 把触发的存储用量告警转为 quota.approaching 事件，用作 alert.Notifier
+type Alerts struct {
+	Sender Sender
+}
+
+// Notify 实现 alert.Notifier
+func (a Alerts) Notify(_ context.Context, al types.Alert) error {
+	if al.Name == alert.Storage && al.State == alert.StateFiring {
+		a.Sender.Send(Event{Name: EventQuota, Message: al.Message})
+	}
+	return nil
+}
+
+// maxSummaryFiles 一行摘要中最多列出的文件数
+const maxSummaryFiles = 5
+
+// Summary 返回事件的一行摘要，用于聊天消息
+func Summary(e Event) string {
+	files := e.Files
+	more := ""
+	if len(files) > maxSummaryFiles {
+		files, more = files[:maxSummaryFiles], fmt.Sprintf(" and %d more", len(e.Files)-maxSummaryFiles)
+	}
+	list := strings.Join(files, ", ") + more
+	switch e.Name {
+	case EventPackagePublished:
+		return fmt.Sprintf("Published to %s: %s", e.Repo, list)
+	case EventRetentionDeleted:
+		return fmt.Sprintf("Retention deleted %d old package version(s) from %s", e.Count, e.Repo)
+	case EventProtectedUpload:
+		return fmt.Sprintf("%s uploaded %s to protected repository %s", e.User, list, e.Repo)
+	case EventApprovalRequested:
+		return fmt.Sprintf("%s requested %s on %s, waiting for approval %s", e.User, e.Operation, e.Repo, e.Approval)
+	case EventSyncFailed:
+		return fmt.Sprintf("Sync of proxy repository %s failed: %s", e.Repo, e.Message)
+	}
+	return e.Message
+}
+
+// Recipients 返回事件的收件人，去重并排序。只记入事件日志的事件没有收件人
+func (n *Notifier) Recipients(name, repo string) []string {
+	if !Valid(name) {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, r := range n.routes {
+		if !Match(r.Events, r.Repos, name, repo) {
+			continue
+		}
+		for _, to := range r.To {
+			seen[to] = true
+		}
+	}
+	to := make([]string, 0, len(seen))
+	for addr := range seen {
+		to = append(to, addr)
+	}
+	sort.Strings(to)
+	return to
+}
+
+// Match 事件是否匹配订阅：events 包含事件名称或 "*"，repos 为空或有模式匹配事件的仓库
+func Match(events, repos []string, name, repo string) bool {
+	return matchEvent(events, name) && matchRepo(repos, repo)
+}
+
+func matchEvent(events []string, name string) bool {
+	for _, e := range events {
+		if e == "*" || e == name {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRepo 没有仓库的事件（如存储用量）只匹配未限定仓库的路由
+func matchRepo(patterns []string, repo string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if repo != "" && utils.MatchGlob(pattern, strings.Trim(repo, "/"), true) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver 渲染模板并发送一封邮件给所有收件人，模板出错时不再重试
+func (n *Notifier) deliver(e Event, to []string) error {
+	msg, err := n.render(e, to)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPermanent, err)
+	}
+	return n.send(n.addr, n.auth, n.from, to, msg)
+}
+
+// render 生成邮件，补全事件的时间、实例和地址
+func (n *Notifier) render(e Event, to []string) ([]byte, error) {
+	t, ok := n.templates[e.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %q", e.Name)
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.Node == "" {
+		e.Node = n.node
+	}
+	if e.URL == "" && e.Approval != "" && n.externalURL != "" {
+		e.URL = n.externalURL + "/api/v1/approvals/" + e.Approval
+	}
+
+	var subject, body strings.Builder
+	if err := t.subject.Execute(&subject, e); err != nil {
+		return nil, fmt.Errorf("render %s subject: %w", e.Name, err)
+	}
+	if err := t.body.Execute(&body, e); err != nil {
+		return nil, fmt.Errorf("render %s body: %w", e.Name, err)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject.String())))
+	fmt.Fprintf(&msg, "Date: %s\r\n", e.Time.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(body.String(), "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(msg.String()), nil
+}
@@ -0,0 +1,188 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"plus/internal/alert"
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+// mailbox 记录发送的邮件
+type mailbox struct {
+	mu   sync.Mutex
+	sent []sent
+}
+
+type sent struct {
+	to  []string
+	msg string
+}
+
+func (b *mailbox) send(_ string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent = append(b.sent, sent{to: to, msg: string(msg)})
+	return nil
+}
+
+func testConfig() config.NotificationsConfig {
+	return config.NotificationsConfig{
+		Enabled: true,
+		SMTP:    config.SMTPConfig{Addr: "smtp.example.com:25", From: "plus@example.com"},
+		Routes: []config.NotificationRoute{
+			{Events: []string{"*"}, To: []string{"ops@example.com"}},
+			{Events: []string{EventApprovalRequested}, Repos: []string{"prod/**"}, To: []string{"release@example.com", "ops@example.com"}},
+			{Events: []string{EventSyncFailed}, Repos: []string{"mirror/*"}, To: []string{"mirror@example.com"}},
+		},
+	}
+}
+
+func TestRecipients(t *testing.T) {
+	n, err := New(testConfig(), "node-1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		event, repo string
+		want        []string
+	}{
+		{EventApprovalRequested, "prod/el9/x86_64", []string{"ops@example.com", "release@example.com"}},
+		{EventApprovalRequested, "dev/el9", []string{"ops@example.com"}},
+		{EventSyncFailed, "mirror/baseos", []string{"mirror@example.com", "ops@example.com"}},
+		{EventSyncFailed, "mirror/a/b", []string{"ops@example.com"}},
+		{EventQuota, "", []string{"ops@example.com"}},
+		{"repo.refreshed", "prod/el9", nil},
+	}
+	for _, c := range cases {
+		if got := n.Recipients(c.event, c.repo); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Recipients(%s, %q) = %v, want %v", c.event, c.repo, got, c.want)
+		}
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	cfg := testConfig()
+	cfg.Templates = map[string]config.EmailTemplate{
+		EventProtectedUpload: {Subject: "上传 {{.Repo}}: {{join .Files \" \"}}"},
+	}
+	n, err := New(cfg, "node-1", "https://repo.example.com/plus/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	box := &mailbox{}
+	n.send = box.send
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctx := context.Background()
+	for _, e := range []Event{
+		{Name: EventApprovalRequested, Repo: "prod/el9", User: "alice", Operation: "delete_repo", Approval: "abc123", Time: at},
+		{Name: EventProtectedUpload, Repo: "prod/el9", User: "bob", Files: []string{"a.rpm", "b.rpm"}, Time: at},
+	} {
+		targets := n.Targets(e)
+		if len(targets) != 1 {
+			t.Fatalf("Targets(%s) = %v", e.Name, targets)
+		}
+		if err := n.Deliver(ctx, targets[0], e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(box.sent) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(box.sent))
+	}
+
+	approval := box.sent[0].msg
+	for _, want := range []string{
+		"To: ops@example.com, release@example.com\r\n",
+		"Subject: [plus] Approval requested: delete_repo on prod/el9\r\n",
+		"alice requested delete_repo on the protected repository prod/el9.\r\n",
+		"request abc123 at https://repo.example.com/plus/api/v1/approvals/abc123.\r\n",
+		"Node: node-1\r\n",
+	} {
+		if !strings.Contains(approval, want) {
+			t.Errorf("Approval message missing %q:\n%s", want, approval)
+		}
+	}
+
+	upload := box.sent[1].msg
+	if !strings.Contains(upload, "Subject: =?utf-8?q?") || !strings.Contains(upload, "bob uploaded a.rpm, b.rpm to the protected repository prod/el9.") {
+		t.Errorf("Unexpected upload message:\n%s", upload)
+	}
+}
+
+func TestDeliverErrors(t *testing.T) {
+	n, err := New(testConfig(), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := Event{Name: EventSyncFailed, Repo: "mirror/baseos", Message: "timeout"}
+	for _, c := range []struct {
+		err       error
+		permanent bool
+	}{
+		{&textproto.Error{Code: 550, Msg: "mailbox unavailable"}, true},
+		{&textproto.Error{Code: 421, Msg: "try again later"}, false},
+		{errors.New("connection refused"), false},
+	} {
+		n.send = func(string, smtp.Auth, string, []string, []byte) error { return c.err }
+		err := n.Deliver(context.Background(), "ops@example.com", e)
+		if err == nil || errors.Is(err, ErrPermanent) != c.permanent {
+			t.Errorf("Deliver with %v = %v, permanent %v", c.err, err, c.permanent)
+		}
+	}
+}
+
+// events 记录收到的事件
+type events []Event
+
+func (e *events) Send(ev Event) { *e = append(*e, ev) }
+
+func TestQuotaAlert(t *testing.T) {
+	var got events
+	a := Alerts{Sender: &got}
+	ctx := context.Background()
+	a.Notify(ctx, types.Alert{Name: alert.ErrorRate, State: alert.StateFiring})
+	a.Notify(ctx, types.Alert{Name: alert.Storage, State: alert.StateResolved})
+	a.Notify(ctx, types.Alert{Name: alert.Storage, State: alert.StateFiring, Message: "Storage is 91.0% full"})
+	if len(got) != 1 || got[0].Name != EventQuota || got[0].Message != "Storage is 91.0% full" {
+		t.Errorf("Expected one quota event, got %+v", got)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	for name, mutate := range map[string]func(*config.NotificationsConfig){
+		"addr":     func(c *config.NotificationsConfig) { c.SMTP.Addr = "smtp.example.com" },
+		"from":     func(c *config.NotificationsConfig) { c.SMTP.From = "" },
+		"routes":   func(c *config.NotificationsConfig) { c.Routes = nil },
+		"event":    func(c *config.NotificationsConfig) { c.Routes[0].Events = []string{"upload"} },
+		"to":       func(c *config.NotificationsConfig) { c.Routes[0].To = nil },
+		"repos":    func(c *config.NotificationsConfig) { c.Routes[0].Repos = []string{"["} },
+		"template": func(c *config.NotificationsConfig) { c.Templates = map[string]config.EmailTemplate{"upload": {}} },
+		"syntax": func(c *config.NotificationsConfig) {
+			c.Templates = map[string]config.EmailTemplate{EventSyncFailed: {Body: "{{.Repo"}}
+		},
+	} {
+		cfg := testConfig()
+		mutate(&cfg)
+		if _, err := New(cfg, "", ""); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package pathnorm
+
+import (
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer 规范化上传和查找的文件名：转换为 Unicode NFC，Lowercase 时再转为小写。
+// 只处理路径的最后一段，仓库名和目录不变。nil 表示不规范化
+type Normalizer struct {
+	Lowercase bool
+}
+
+// Name 返回规范化后的路径，无效的 UTF-8 原样保留
+func (n *Normalizer) Name(p string) string {
+	if n == nil {
+		return p
+	}
+	dir, base := path.Split(p)
+	if utf8.ValidString(base) {
+		base = norm.NFC.String(base)
+	}
+	if n.Lowercase {
+		base = strings.ToLower(base)
+	}
+	return dir + base
+}
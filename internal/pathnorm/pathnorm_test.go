@@ -0,0 +1,47 @@
+package pathnorm
+
+import "testing"
+
+func TestNFC(t *testing.T) {
+	nfc := &Normalizer{}
+	for in, want := range map[string]string{
+		"plain-1.0.tar.gz":         "plain-1.0.tar.gz",
+		"cafe\u0301.txt":           "caf\u00e9.txt",
+		"caf\u00e9.txt":            "caf\u00e9.txt",
+		"\u212bngstro\u0308m":      "\u00c5ngstr\u00f6m", // 单字分解
+		"a\u0302\u0323":            "\u1ead",             // 组合前按组合类排序
+		"\u1100\u1161\u11a8":       "\uac01",             // 谚文音节
+		"\uac00\u11a8":             "\uac01",             // LV + T
+		"\u0915\u093c.deb":         "\u0915\u093c.deb",   // 组合排除
+		"\u0301e":                  "\u0301e",            // 以组合字符开头
+		"e\u0301\u0301":            "\u00e9\u0301",       // 同类的组合字符被阻断
+		"\u304b\u3099\u30d8\u309a": "\u304c\u30da",       // 假名浊音和半浊音
+		"\u1e9b\u0323":             "\u1e9b\u0323",       // 不再组合
+		"o\u0308\u0304":            "\u022b",             // 连续组合
+		"x\u0327\u0301y":           "x\u0327\u0301y",     // 无组合的字符对保持不变
+		"\xff\xfe":                 "\xff\xfe",           // 无效的 UTF-8
+		"":                         "",
+	} {
+		if got := nfc.Name(in); got != want {
+			t.Errorf("Name(%+q) = %+q, want %+q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizer(t *testing.T) {
+	var off *Normalizer
+	if got := off.Name("Tools/Cafe\u0301.RPM"); got != "Tools/Cafe\u0301.RPM" {
+		t.Errorf("nil normalizer changed the name: %q", got)
+	}
+	nfc := &Normalizer{}
+	if got := nfc.Name("Tools/Cafe\u0301.RPM"); got != "Tools/Caf\u00e9.RPM" {
+		t.Errorf("NFC name = %q", got)
+	}
+	lower := &Normalizer{Lowercase: true}
+	if got := lower.Name("Tools/Cafe\u0301.RPM"); got != "Tools/caf\u00e9.rpm" {
+		t.Errorf("lowercase name = %q", got)
+	}
+	if got := lower.Name("Foo.rpm"); got != lower.Name("foo.rpm") {
+		t.Errorf("case variants differ: %q", got)
+	}
+}
@@ -0,0 +1,187 @@
+package progress
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"plus/internal/types"
+)
+
+// 上传状态
+const (
+	StateReceiving  = "receiving"  // 正在接收请求体
+	StateProcessing = "processing" // 请求体已接收，正在写入仓库
+	StateComplete   = "complete"
+	StateFailed     = "failed"
+)
+
+// rateWindow 计算速率的时间窗口
+const rateWindow = 10 * time.Second
+
+var (
+	// ErrInvalidID 上传 ID 格式不正确
+	ErrInvalidID = errors.New("upload id must be 8-128 characters of letters, digits, '.', '_' or '-'")
+	// ErrDuplicate 同一 ID 的上传仍在进行
+	ErrDuplicate = errors.New("upload id is already in use")
+)
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9._-]{8,128}$`)
+
+// Tracker 记录进行中和最近完成的上传，完成的上传保留 keep 时间供客户端查询最终状态
+type Tracker struct {
+	mu      sync.Mutex
+	uploads map[string]*Upload
+	keep    time.Duration
+	now     func() time.Time
+}
+
+// New 创建 Tracker
+func New(keep time.Duration) *Tracker {
+	return &Tracker{uploads: map[string]*Upload{}, keep: keep, now: time.Now}
+}
+
+// sample 某一时刻已接收的字节数
+type sample struct {
+	at    time.Time
+	bytes int64
+}
+
+// Upload 一个上传请求的进度
+type Upload struct {
+	t        *Tracker
+	id       string
+	total    int64 // 请求体大小，未知时为 -1
+	received int64
+	state    string
+	code     int
+	started  time.Time
+	finished time.Time
+	samples  []sample // rateWindow 内的采样，用于计算速率
+}
+
+// Start 开始记录 id 的上传，total 为请求体大小，未知时传 -1。
+// 同一 ID 的上传已完成时覆盖旧记录
+func (t *Tracker) Start(id string, total int64) (*Upload, error) {
+	if !validID.MatchString(id) {
+		return nil, ErrInvalidID
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	t.prune(now)
+	if u, ok := t.uploads[id]; ok && u.finished.IsZero() {
+		return nil, ErrDuplicate
+	}
+	u := &Upload{t: t, id: id, total: total, state: StateReceiving, started: now,
+		samples: []sample{{at: now}}}
+	t.uploads[id] = u
+	return u, nil
+}
+
+// Get 返回 id 的上传进度
+func (t *Tracker) Get(id string) (types.UploadProgress, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	t.prune(now)
+	u, ok := t.uploads[id]
+	if !ok {
+		return types.UploadProgress{}, false
+	}
+	return u.progress(now), true
+}
+
+// prune 删除完成超过 keep 的记录，调用时持有锁
+func (t *Tracker) prune(now time.Time) {
+	for id, u := range t.uploads {
+		if !u.finished.IsZero() && now.Sub(u.finished) > t.keep {
+			delete(t.uploads, id)
+		}
+	}
+}
+
+// Reader 返回统计读取字节数的 r
+func (u *Upload) Reader(r io.Reader) io.Reader {
+	return &countingReader{r: r, u: u}
+}
+
+func (u *Upload) add(n int) {
+	u.t.mu.Lock()
+	defer u.t.mu.Unlock()
+	u.received += int64(n)
+	now := u.t.now()
+	// 每秒最多记录一次采样
+	if last := u.samples[len(u.samples)-1]; now.Sub(last.at) >= time.Second {
+		u.samples = append(u.samples, sample{at: now, bytes: u.received})
+	}
+	for len(u.samples) > 1 && now.Sub(u.samples[1].at) >= rateWindow {
+		u.samples = u.samples[1:]
+	}
+}
+
+// Received 请求体接收完毕
+func (u *Upload) Received() {
+	u.t.mu.Lock()
+	defer u.t.mu.Unlock()
+	if u.finished.IsZero() {
+		u.state = StateProcessing
+	}
+}
+
+// Finish 请求结束，code 为响应状态码，小于 400 表示成功
+func (u *Upload) Finish(code int) {
+	u.t.mu.Lock()
+	defer u.t.mu.Unlock()
+	if !u.finished.IsZero() {
+		return
+	}
+	u.finished, u.code = u.t.now(), code
+	u.state = StateComplete
+	if code >= 400 {
+		u.state = StateFailed
+	}
+}
+
+// progress 生成进度快照，调用时持有锁
+func (u *Upload) progress(now time.Time) types.UploadProgress {
+	p := types.UploadProgress{
+		ID:       u.id,
+		State:    u.state,
+		Received: u.received,
+		Started:  u.started.UTC().Format(time.RFC3339),
+		Code:     u.code,
+	}
+	if u.total >= 0 {
+		p.Total = u.total
+	}
+	if !u.finished.IsZero() {
+		p.Finished = u.finished.UTC().Format(time.RFC3339)
+	}
+	if u.state != StateReceiving {
+		return p
+	}
+	first := u.samples[0]
+	if elapsed := now.Sub(first.at).Seconds(); elapsed > 0 {
+		p.Rate = int64(float64(u.received-first.bytes) / elapsed)
+	}
+	if p.Rate > 0 && u.total > u.received {
+		p.ETASeconds = (u.total - u.received + p.Rate - 1) / p.Rate
+	}
+	return p
+}
+
+type countingReader struct {
+	r io.Reader
+	u *Upload
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.u.add(n)
+	}
+	return n, err
+}
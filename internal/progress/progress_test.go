@@ -0,0 +1,97 @@
+package progress
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clock 手动推进的时钟
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+func newTracker(c *clock, keep time.Duration) *Tracker {
+	t := New(keep)
+	t.now = c.now
+	return t
+}
+
+func TestUpload(t *testing.T) {
+	c := &clock{t: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	tr := newTracker(c, time.Minute)
+
+	if _, err := tr.Start("short", 100); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("short id err = %v", err)
+	}
+	u, err := tr.Start("upload-0001", 3000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tr.Start("upload-0001", 3000); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("duplicate id err = %v", err)
+	}
+
+	r := u.Reader(strings.NewReader(strings.Repeat("x", 1000)))
+	c.advance(2 * time.Second)
+	io.ReadAll(r)
+	p, ok := tr.Get("upload-0001")
+	if !ok {
+		t.Fatal("upload not found")
+	}
+	if p.State != StateReceiving || p.Received != 1000 || p.Total != 3000 || p.Rate != 500 || p.ETASeconds != 4 {
+		t.Errorf("progress = %+v", p)
+	}
+
+	u.Received()
+	if p, _ := tr.Get("upload-0001"); p.State != StateProcessing || p.Rate != 0 || p.ETASeconds != 0 {
+		t.Errorf("after body = %+v", p)
+	}
+	c.advance(time.Second)
+	u.Finish(201)
+	u.Finish(500)
+	if p, _ := tr.Get("upload-0001"); p.State != StateComplete || p.Code != 201 || p.Finished != "2026-01-02T03:04:08Z" {
+		t.Errorf("finished = %+v", p)
+	}
+
+	// 完成的记录可以被同一 ID 覆盖，超过保留时间后删除
+	if _, err := tr.Start("upload-0001", -1); err != nil {
+		t.Errorf("restart finished id err = %v", err)
+	}
+	u, _ = tr.Start("upload-0002", -1)
+	u.Finish(413)
+	if p, _ := tr.Get("upload-0002"); p.State != StateFailed || p.Total != 0 {
+		t.Errorf("failed = %+v", p)
+	}
+	c.advance(2 * time.Minute)
+	if _, ok := tr.Get("upload-0002"); ok {
+		t.Error("finished upload not pruned")
+	}
+	if _, ok := tr.Get("upload-0001"); !ok {
+		t.Error("active upload pruned")
+	}
+}
+
+func TestRateWindow(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	tr := newTracker(c, time.Minute)
+	u, _ := tr.Start("rate-window", -1)
+	// 前 20 秒 1000 字节/秒，之后 100 字节/秒
+	for i := 0; i < 40; i++ {
+		c.advance(time.Second)
+		n := 1000
+		if i >= 20 {
+			n = 100
+		}
+		u.add(n)
+	}
+	p, _ := tr.Get("rate-window")
+	if p.Rate < 90 || p.Rate > 110 {
+		t.Errorf("rate = %d, want about 100", p.Rate)
+	}
+	if p.ETASeconds != 0 {
+		t.Errorf("eta with unknown size = %d", p.ETASeconds)
+	}
+}
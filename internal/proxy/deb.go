@@ -0,0 +1,290 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+)
+
+type releaseEntry struct {
+	algo string
+	sum  string
+	path string
+}
+
+// releasePrefix 返回 Release 文件所在目录（扁平仓库为空）
+func releasePrefix(u *upstream) string {
+	if u.cfg.Distribution == "" {
+		return ""
+	}
+	return "dists/" + strings.Trim(u.cfg.Distribution, "/") + "/"
+}
+
+// syncDEB 校验 InRelease（或 Release + Release.gpg）签名，再按 Release 中的校验和下载 Packages 索引
+func (m *Manager) syncDEB(ctx context.Context, u *upstream, mr *mirror) (string, error) {
+	prefix := releasePrefix(u)
+
+	var signer string
+	var release []byte
+	cached := make(map[string][]byte)
+
+	inRelease, err := m.fetchBytes(ctx, u, mr, prefix+"InRelease")
+	switch {
+	case err == nil:
+		if u.verifier != nil {
+			if release, signer, err = u.verifier.VerifyClearsigned(inRelease); err != nil {
+				return "", fmt.Errorf("%w: InRelease: %v", ErrVerification, err)
+			}
+		} else {
+			release = releasePlaintext(inRelease)
+		}
+		cached[prefix+"InRelease"] = inRelease
+	case errors.Is(err, ErrNotFound):
+		if release, err = m.fetchBytes(ctx, u, mr, prefix+"Release"); err != nil {
+			return "", err
+		}
+		if u.verifier != nil {
+			sig, err := m.fetchBytes(ctx, u, mr, prefix+"Release.gpg")
+			if err != nil {
+				if errors.Is(err, ErrNotFound) {
+					return "", fmt.Errorf("%w: upstream Release is not signed", ErrVerification)
+				}
+				return "", err
+			}
+			if signer, err = u.verifier.VerifyDetached(release, sig); err != nil {
+				return "", fmt.Errorf("%w: Release: %v", ErrVerification, err)
+			}
+			cached[prefix+"Release.gpg"] = sig
+		}
+		cached[prefix+"Release"] = release
+	default:
+		return "", err
+	}
+
+	entries := parseRelease(release)
+	if len(entries) == 0 {
+		return "", fmt.Errorf("%w: Release has no checksums", ErrVerification)
+	}
+
+	tmps := make(map[string]string)
+	defer func() {
+		for _, tmp := range tmps {
+			os.Remove(tmp)
+		}
+	}()
+
+	for _, e := range entries {
+		if !isPackagesIndex(e.path) {
+			continue
+		}
+		rel, err := safeRel(prefix + e.path)
+		if err != nil {
+			return "", err
+		}
+		tmp, err := m.download(ctx, u, mr, rel, e.algo, e.sum)
+		if err != nil {
+			// Release 中常列出未发布的未压缩版本
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return "", err
+		}
+		tmps[rel] = tmp
+	}
+	if len(tmps) == 0 {
+		return "", fmt.Errorf("no Packages index found upstream")
+	}
+
+	index := make(map[string]indexEntry)
+	for rel, tmp := range tmps {
+		if err := parsePackagesFile(tmp, rel, index); err != nil {
+			return "", err
+		}
+	}
+
+	for rel, tmp := range tmps {
+		if err := m.storeFile(ctx, u, rel, tmp); err != nil {
+			return "", err
+		}
+	}
+	// Release 最后写入
+	for rel, data := range cached {
+		if err := m.storeBytes(ctx, u, rel, data); err != nil {
+			return "", err
+		}
+	}
+
+	u.setIndex(index)
+	return signer, nil
+}
+
+func (m *Manager) loadDEBIndex(ctx context.Context, u *upstream) error {
+	prefix := releasePrefix(u)
+
+	data, err := m.readCached(ctx, u, prefix+"InRelease")
+	if err != nil {
+		if data, err = m.readCached(ctx, u, prefix+"Release"); err != nil {
+			return err
+		}
+	}
+
+	index := make(map[string]indexEntry)
+	found := false
+	for _, e := range parseRelease(releasePlaintext(data)) {
+		if !isPackagesIndex(e.path) {
+			continue
+		}
+		reader, err := m.storage.Get(ctx, path.Join(u.name, prefix+e.path))
+		if err != nil {
+			continue
+		}
+		err = parsePackages(reader, e.path, index)
+		reader.Close()
+		if err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("no cached Packages index")
+	}
+
+	u.setIndex(index)
+	return nil
+}
+
+// releasePlaintext 返回 clearsign 消息中的明文，非 clearsign 时原样返回
+func releasePlaintext(data []byte) []byte {
+	if block, _ := clearsign.Decode(data); block != nil {
+		return block.Plaintext
+	}
+	return data
+}
+
+// parseRelease 解析 Release 中的校验和列表，优先使用 SHA256
+func parseRelease(data []byte) []releaseEntry {
+	sections := map[string][]releaseEntry{}
+	current := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			current = ""
+			if key, _, ok := strings.Cut(line, ":"); ok {
+				switch key {
+				case "SHA256", "SHA512", "SHA1", "MD5Sum":
+					current = key
+				}
+			}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		algo := strings.ToLower(strings.TrimSuffix(current, "Sum"))
+		sections[current] = append(sections[current], releaseEntry{algo: algo, sum: fields[0], path: fields[2]})
+	}
+
+	for _, algo := range []string{"SHA256", "SHA512", "SHA1", "MD5Sum"} {
+		if len(sections[algo]) > 0 {
+			return sections[algo]
+		}
+	}
+	return nil
+}
+
+func isPackagesIndex(p string) bool {
+	base := path.Base(p)
+	return base == "Packages" || base == "Packages.gz" || base == "Packages.xz"
+}
+
+// isMetadataPath 判断是否为只能通过同步获取的仓库元数据
+func isMetadataPath(repoType, rel string) bool {
+	if repoType == "rpm" {
+		return strings.HasPrefix(rel, "repodata/")
+	}
+	switch path.Base(rel) {
+	case "InRelease", "Release", "Release.gpg", "Packages", "Packages.gz", "Packages.xz":
+		return true
+	}
+	return strings.HasPrefix(rel, "dists/")
+}
+
+func parsePackagesFile(file, name string, index map[string]indexEntry) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return parsePackages(f, name, index)
+}
+
+// parsePackages 解析 Packages 索引，记录 Filename 对应的校验和
+func parsePackages(r io.Reader, name string, index map[string]indexEntry) error {
+	reader, err := decompress(r, name)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var filename string
+	var entry indexEntry
+	flush := func() error {
+		if filename != "" && entry.sum != "" {
+			rel, err := safeRel(filename)
+			if err != nil {
+				return err
+			}
+			index[rel] = entry
+		}
+		filename, entry = "", indexEntry{}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if err := flush(); err != nil {
+				return err
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || line[0] == ' ' {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Filename":
+			filename = value
+		case "SHA256":
+			entry = indexEntry{algo: "sha256", sum: value}
+		case "MD5sum":
+			if entry.sum == "" {
+				entry = indexEntry{algo: "md5", sum: value}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return flush()
+}
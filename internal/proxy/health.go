@@ -0,0 +1,254 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+const defaultCheckInterval = 5 * time.Minute
+
+type healthCheck struct {
+	interval   time.Duration
+	maxAge     time.Duration
+	spotChecks int
+}
+
+func parseHealthCheck(hc config.HealthCheckConfig) (healthCheck, error) {
+	c := healthCheck{interval: defaultCheckInterval, spotChecks: hc.SpotChecks}
+	if hc.Interval != "" {
+		d, err := time.ParseDuration(hc.Interval)
+		if err != nil {
+			return c, fmt.Errorf("invalid health-check interval: %w", err)
+		}
+		c.interval = d
+	}
+	if hc.MaxAge != "" {
+		d, err := time.ParseDuration(hc.MaxAge)
+		if err != nil {
+			return c, fmt.Errorf("invalid health-check max-age: %w", err)
+		}
+		c.maxAge = d
+	}
+	return c, nil
+}
+
+// StartHealthChecks 为每个代理仓库启动后台上游健康检查，ctx 取消后停止
+func (m *Manager) StartHealthChecks(ctx context.Context) {
+	for _, u := range m.upstreams {
+		if u.check.interval <= 0 {
+			continue
+		}
+		go m.healthLoop(ctx, u)
+	}
+}
+
+func (m *Manager) healthLoop(ctx context.Context, u *upstream) {
+	ticker := time.NewTicker(u.check.interval)
+	defer ticker.Stop()
+
+	for {
+		m.checkUpstream(ctx, u)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckHealth 立即检查代理仓库的所有上游并返回结果
+func (m *Manager) CheckHealth(ctx context.Context, repoName string) (*types.UpstreamHealth, error) {
+	u, ok := m.upstreams[strings.Trim(repoName, "/")]
+	if !ok {
+		return nil, fmt.Errorf("repository %s is not a proxy repository", repoName)
+	}
+	m.checkUpstream(ctx, u)
+	health, _ := m.Health(repoName)
+	return health, nil
+}
+
+// Health 返回代理仓库各上游的健康状态
+func (m *Manager) Health(repoName string) (*types.UpstreamHealth, bool) {
+	u, ok := m.upstreams[strings.Trim(repoName, "/")]
+	if !ok {
+		return nil, false
+	}
+
+	status, _ := m.Status(repoName)
+	u.statusMu.RLock()
+	active := u.active
+	u.statusMu.RUnlock()
+
+	health := &types.UpstreamHealth{
+		Repo:     u.name,
+		Strategy: u.strategy,
+		Mirrors:  make([]types.MirrorHealth, 0, len(u.mirrors)),
+		Sync:     status,
+	}
+	for _, mr := range u.mirrors {
+		h := mr.snapshot()
+		h.Active = mr.url == active
+		health.Mirrors = append(health.Mirrors, h)
+		if h.Healthy {
+			health.Healthy = true
+		}
+	}
+	return health, true
+}
+
+func (m *Manager) checkUpstream(ctx context.Context, u *upstream) {
+	sample := u.sample(u.check.spotChecks)
+	for _, mr := range u.mirrors {
+		m.checkMirror(ctx, u, mr, sample)
+	}
+}
+
+// checkMirror 检查单个上游：元数据可达性与延迟、元数据新鲜度、随机抽查包的校验和
+func (m *Manager) checkMirror(ctx context.Context, u *upstream, mr *mirror, sample map[string]indexEntry) {
+	start := time.Now()
+	data, err := m.fetchRepoMetadata(ctx, u, mr)
+	latency := time.Since(start)
+
+	var metaTime time.Time
+	if err == nil {
+		metaTime = metadataTime(u.repoType, data)
+	}
+
+	spotFailures := 0
+	var spotErr error
+	if err == nil {
+		for rel, entry := range sample {
+			tmp, err := m.download(ctx, u, mr, rel, entry.algo, entry.sum)
+			if err != nil {
+				spotFailures++
+				spotErr = err
+				continue
+			}
+			os.Remove(tmp)
+		}
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	mr.health.LastCheck = time.Now().UTC().Format(time.RFC3339)
+	mr.health.LatencyMs = latency.Milliseconds()
+	if err != nil {
+		mr.failLocked(err)
+		log.Logger.Warnf("Upstream %s of proxy repository %s is unreachable: %v", mr.url, u.name, err)
+		return
+	}
+
+	mr.health.LastError = ""
+	mr.health.Stale = false
+	mr.health.MetadataTime, mr.health.MetadataAge = "", ""
+	if !metaTime.IsZero() {
+		age := time.Since(metaTime).Truncate(time.Second)
+		mr.health.MetadataTime = metaTime.Format(time.RFC3339)
+		mr.health.MetadataAge = age.String()
+		mr.health.Stale = u.check.maxAge > 0 && age > u.check.maxAge
+	}
+	mr.health.SpotChecks = len(sample)
+	mr.health.SpotCheckFailures = spotFailures
+	if spotErr != nil {
+		mr.health.LastError = spotErr.Error()
+	} else if mr.health.Stale {
+		mr.health.LastError = fmt.Sprintf("metadata is older than %s", u.check.maxAge)
+	}
+
+	mr.degraded = mr.health.Stale || spotFailures > 0
+	mr.succeedLocked()
+	if mr.degraded {
+		log.Logger.Warnf("Upstream %s of proxy repository %s is degraded: %s", mr.url, u.name, mr.health.LastError)
+	}
+}
+
+// fetchRepoMetadata 读取上游的顶层元数据（repomd.xml 或 InRelease/Release）
+func (m *Manager) fetchRepoMetadata(ctx context.Context, u *upstream, mr *mirror) ([]byte, error) {
+	if u.repoType == "rpm" {
+		return m.fetchBytes(ctx, u, mr, repomdPath)
+	}
+	prefix := releasePrefix(u)
+	data, err := m.fetchBytes(ctx, u, mr, prefix+"InRelease")
+	if errors.Is(err, ErrNotFound) {
+		return m.fetchBytes(ctx, u, mr, prefix+"Release")
+	}
+	return data, err
+}
+
+// sample 从已校验的索引中随机选取 n 个包用于抽查
+func (u *upstream) sample(n int) map[string]indexEntry {
+	result := make(map[string]indexEntry)
+	if n <= 0 {
+		return result
+	}
+
+	index := u.getIndex()
+	var candidates []string
+	for rel, entry := range index {
+		if entry.algo != "" && !isMetadataPath(u.repoType, rel) {
+			candidates = append(candidates, rel)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	for _, rel := range candidates {
+		if len(result) >= n {
+			break
+		}
+		result[rel] = index[rel]
+	}
+	return result
+}
+
+// metadataTime 返回上游元数据的生成时间，无法识别时返回零值
+func metadataTime(repoType string, data []byte) time.Time {
+	if repoType == "rpm" {
+		var md repomd
+		if err := xml.Unmarshal(data, &md); err != nil {
+			return time.Time{}
+		}
+		var ts int64
+		for _, d := range md.Data {
+			if d.Timestamp > ts {
+				ts = d.Timestamp
+			}
+		}
+		if ts == 0 {
+			ts, _ = strconv.ParseInt(strings.TrimSpace(md.Revision), 10, 64)
+		}
+		if ts <= 0 {
+			return time.Time{}
+		}
+		return time.Unix(ts, 0).UTC()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(releasePlaintext(data)))
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(scanner.Text(), "Date:")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		for _, layout := range []string{time.RFC1123, time.RFC1123Z, "Mon, 2 Jan 2006 15:04:05 MST", "Mon, 2 Jan 2006 15:04:05 -0700"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t.UTC()
+			}
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,277 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+const (
+	StrategyFailover = "failover" // 按配置顺序使用第一个可用的上游
+	StrategyWeighted = "weighted" // 按权重在可用的上游间分流
+
+	defaultBreakerFailures = 3
+	defaultBreakerCooldown = 30 * time.Second
+)
+
+const (
+	circuitClosed   = "closed"
+	circuitOpen     = "open"
+	circuitHalfOpen = "half-open"
+)
+
+type breaker struct {
+	failures int           // 连续失败达到该次数后熔断
+	cooldown time.Duration // 熔断持续时间，之后放行一次试探请求
+}
+
+func parseBreaker(bc config.CircuitBreakerConfig) (breaker, error) {
+	b := breaker{failures: bc.Failures, cooldown: defaultBreakerCooldown}
+	if b.failures <= 0 {
+		b.failures = defaultBreakerFailures
+	}
+	if bc.Cooldown != "" {
+		d, err := time.ParseDuration(bc.Cooldown)
+		if err != nil {
+			return b, fmt.Errorf("invalid circuit-breaker cooldown: %w", err)
+		}
+		b.cooldown = d
+	}
+	return b, nil
+}
+
+// mirror 代理仓库的一个上游地址
+type mirror struct {
+	url     string
+	weight  int
+	breaker breaker
+
+	mu         sync.Mutex
+	health     types.MirrorHealth
+	degraded   bool      // 最近一次健康检查发现元数据过期或抽查校验失败
+	openUntil  time.Time // 熔断打开时到期时间
+	probeUntil time.Time // 半开状态下已放行的试探请求的有效期
+}
+
+func newMirror(url string, weight int, b breaker) *mirror {
+	if weight <= 0 {
+		weight = 1
+	}
+	return &mirror{
+		url:     url,
+		weight:  weight,
+		breaker: b,
+		health:  types.MirrorHealth{URL: url, Weight: weight, Healthy: true, Circuit: circuitClosed},
+	}
+}
+
+func (mr *mirror) circuitLocked() string {
+	if mr.health.ConsecutiveFailures < mr.breaker.failures {
+		return circuitClosed
+	}
+	if time.Now().Before(mr.openUntil) {
+		return circuitOpen
+	}
+	return circuitHalfOpen
+}
+
+func (mr *mirror) updateLocked() {
+	mr.health.Circuit = mr.circuitLocked()
+	mr.health.Healthy = mr.health.Circuit == circuitClosed && !mr.degraded
+}
+
+// acquire 判断当前是否可以向该上游发送请求；半开状态只放行一个试探请求
+func (mr *mirror) acquire() bool {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if mr.degraded {
+		return false
+	}
+	switch mr.circuitLocked() {
+	case circuitClosed:
+		return true
+	case circuitHalfOpen:
+		// 试探请求未被使用（前面的上游已成功）时到期后重新放行
+		if now := time.Now(); now.After(mr.probeUntil) {
+			mr.probeUntil = now.Add(mr.breaker.cooldown)
+			return true
+		}
+	}
+	return false
+}
+
+// succeed 上游正常响应（包括文件不存在），关闭熔断
+func (mr *mirror) succeed() {
+	mr.mu.Lock()
+	mr.succeedLocked()
+	mr.mu.Unlock()
+}
+
+func (mr *mirror) succeedLocked() {
+	mr.health.ConsecutiveFailures = 0
+	mr.probeUntil = time.Time{}
+	mr.updateLocked()
+}
+
+func (mr *mirror) fail(err error) {
+	mr.mu.Lock()
+	mr.failLocked(err)
+	mr.mu.Unlock()
+}
+
+func (mr *mirror) failLocked(err error) {
+	mr.health.ConsecutiveFailures++
+	mr.health.Failures++
+	mr.health.LastError = err.Error()
+	mr.probeUntil = time.Time{}
+	if mr.health.ConsecutiveFailures >= mr.breaker.failures {
+		if mr.health.ConsecutiveFailures == mr.breaker.failures {
+			log.Logger.Warnf("Circuit opened for upstream %s after %d consecutive failures", mr.url, mr.health.ConsecutiveFailures)
+		}
+		mr.openUntil = time.Now().Add(mr.breaker.cooldown)
+	}
+	mr.updateLocked()
+}
+
+// served 记录由该上游提供的请求
+func (mr *mirror) served(bytes int64) {
+	mr.mu.Lock()
+	mr.health.Served++
+	mr.health.BytesServed += bytes
+	mr.mu.Unlock()
+}
+
+func (mr *mirror) snapshot() types.MirrorHealth {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+	mr.updateLocked()
+	return mr.health
+}
+
+// candidates 返回本次请求尝试上游的顺序：可用的上游按策略排列在前，
+// 熔断或降级的上游作为最后手段
+func (u *upstream) candidates() []*mirror {
+	var available, rest []*mirror
+	for _, mr := range u.mirrors {
+		if mr.acquire() {
+			available = append(available, mr)
+		} else {
+			rest = append(rest, mr)
+		}
+	}
+	if u.strategy == StrategyWeighted {
+		available = weightedOrder(available)
+	}
+	return append(available, rest...)
+}
+
+// weightedOrder 按权重随机排序（权重越大越可能排在前面）
+func weightedOrder(mirrors []*mirror) []*mirror {
+	type keyed struct {
+		mr  *mirror
+		key float64
+	}
+	keys := make([]keyed, len(mirrors))
+	for i, mr := range mirrors {
+		// Efraimidis-Spirakis 加权随机抽样
+		keys[i] = keyed{mr: mr, key: -rand.ExpFloat64() / float64(mr.weight)}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].key > keys[j].key })
+
+	ordered := make([]*mirror, len(keys))
+	for i, k := range keys {
+		ordered[i] = k.mr
+	}
+	return ordered
+}
+
+// tryMirrors 依次在各上游执行 fn 直到成功，失败时自动切换到下一个上游
+func (m *Manager) tryMirrors(ctx context.Context, u *upstream, fn func(mr *mirror) error) error {
+	var result error
+	for _, mr := range u.candidates() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := fn(mr)
+		if err == nil {
+			mr.succeed()
+			u.statusMu.Lock()
+			u.active = mr.url
+			u.statusMu.Unlock()
+			return nil
+		}
+
+		// 上游没有该文件不代表上游故障
+		if errors.Is(err, ErrNotFound) {
+			mr.succeed()
+		} else {
+			mr.fail(err)
+		}
+		if len(u.mirrors) > 1 {
+			log.Logger.Debugf("Upstream %s failed for proxy repository %s: %v", mr.url, u.name, err)
+		}
+		result = preferError(result, err)
+	}
+	return result
+}
+
+// preferError 多个上游均失败时返回最有意义的错误：校验失败 > 其他错误 > 未找到
+func preferError(current, err error) error {
+	switch {
+	case current == nil:
+		return err
+	case errors.Is(current, ErrVerification):
+		return current
+	case errors.Is(err, ErrVerification):
+		return err
+	case errors.Is(current, ErrNotFound):
+		return err
+	}
+	return current
+}
+
+// Metrics 返回各代理仓库每个上游的请求统计
+func (m *Manager) Metrics() []types.UpstreamMetrics {
+	names := make([]string, 0, len(m.upstreams))
+	for name := range m.upstreams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []types.UpstreamMetrics
+	for _, name := range names {
+		for _, mr := range m.upstreams[name].mirrors {
+			h := mr.snapshot()
+			result = append(result, types.UpstreamMetrics{
+				Repo:        name,
+				URL:         h.URL,
+				Served:      h.Served,
+				BytesServed: h.BytesServed,
+				Failures:    h.Failures,
+				Circuit:     h.Circuit,
+			})
+		}
+	}
+	return result
+}
+
+func parseStrategy(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "", StrategyFailover:
+		return StrategyFailover, nil
+	case StrategyWeighted:
+		return StrategyWeighted, nil
+	}
+	return "", fmt.Errorf("unsupported upstream strategy %q", s)
+}
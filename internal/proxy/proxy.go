@@ -0,0 +1,522 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/signing"
+	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/pkg/storage"
+)
+
+var (
+	// ErrVerification 上游内容签名或校验和不匹配，内容不会被缓存
+	ErrVerification = errors.New("upstream verification failed")
+	// ErrNotFound 上游没有该文件，或文件不在已校验的元数据中
+	ErrNotFound = errors.New("upstream file not found")
+)
+
+const (
+	defaultTimeout  = 60 * time.Second
+	maxMetadataSize = 64 << 20
+)
+
+type indexEntry struct {
+	algo string
+	sum  string
+}
+
+type upstream struct {
+	name     string
+	repoType string
+	cfg      config.UpstreamConfig
+	mirrors  []*mirror // 按配置顺序排列，第一个为 url
+	strategy string
+	check    healthCheck
+	interval time.Duration // 定期同步间隔，0 表示不定期同步
+	client   *http.Client
+	verifier *signing.Verifier
+
+	mu      sync.Mutex            // 串行化同步与按需拉取
+	indexMu sync.RWMutex          // 保护 index 的替换，供健康检查并发读取
+	index   map[string]indexEntry // 相对路径 -> 已校验元数据中的校验和
+
+	statusMu sync.RWMutex
+	status   types.UpstreamStatus
+	active   string // 最近一次成功提供内容的上游
+}
+
+// Manager 管理代理仓库：从上游拉取内容，校验签名与校验和后再缓存到本地存储
+type Manager struct {
+	storage   storage.Storage
+	upstreams map[string]*upstream
+	syncHooks []func(repoName string)
+	failHooks []func(repoName string, err error)
+}
+
+// NewManager 根据仓库配置中的 upstream 创建代理管理器
+func NewManager(cfg *config.Config, store storage.Storage) (*Manager, error) {
+	m := &Manager{
+		storage:   store,
+		upstreams: make(map[string]*upstream),
+	}
+
+	for name, rc := range cfg.Repositories {
+		uc := rc.Upstream
+		if uc.URL == "" && len(uc.Mirrors) == 0 {
+			continue
+		}
+		name = strings.Trim(name, "/")
+
+		if rc.Type != "rpm" && rc.Type != "deb" {
+			return nil, fmt.Errorf("proxy repository %s: unsupported type %q", name, rc.Type)
+		}
+
+		timeout := defaultTimeout
+		if uc.Timeout != "" {
+			d, err := time.ParseDuration(uc.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("proxy repository %s: invalid timeout: %w", name, err)
+			}
+			timeout = d
+		}
+
+		var interval time.Duration
+		if uc.SyncInterval != "" {
+			d, err := time.ParseDuration(uc.SyncInterval)
+			if err != nil || d < 0 {
+				return nil, fmt.Errorf("proxy repository %s: invalid sync-interval %q", name, uc.SyncInterval)
+			}
+			interval = d
+		}
+
+		check, err := parseHealthCheck(uc.HealthCheck)
+		if err != nil {
+			return nil, fmt.Errorf("proxy repository %s: %w", name, err)
+		}
+		strategy, err := parseStrategy(uc.Strategy)
+		if err != nil {
+			return nil, fmt.Errorf("proxy repository %s: %w", name, err)
+		}
+		b, err := parseBreaker(uc.CircuitBreaker)
+		if err != nil {
+			return nil, fmt.Errorf("proxy repository %s: %w", name, err)
+		}
+
+		u := &upstream{
+			name:     name,
+			repoType: rc.Type,
+			cfg:      uc,
+			strategy: strategy,
+			check:    check,
+			interval: interval,
+			client: &http.Client{
+				Transport: &http.Transport{
+					Proxy:                 http.ProxyFromEnvironment,
+					ResponseHeaderTimeout: timeout,
+				},
+			},
+		}
+		for _, mc := range append([]config.MirrorConfig{{URL: uc.URL, Weight: uc.Weight}}, uc.Mirrors...) {
+			if mc.URL != "" {
+				u.mirrors = append(u.mirrors, newMirror(strings.TrimSuffix(mc.URL, "/")+"/", mc.Weight, b))
+			}
+		}
+		u.status = types.UpstreamStatus{URL: u.mirrors[0].url, VerifyMode: "gpg"}
+
+		if uc.SkipVerify {
+			u.status.VerifyMode = "disabled"
+			log.Logger.Warnf("Upstream signature verification disabled for proxy repository %s", name)
+		} else {
+			if len(uc.GPGKeys) == 0 {
+				return nil, fmt.Errorf("proxy repository %s: upstream gpg-keys are required unless skip-verify is set", name)
+			}
+			verifier, err := signing.NewVerifier(uc.GPGKeys)
+			if err != nil {
+				return nil, fmt.Errorf("proxy repository %s: %w", name, err)
+			}
+			u.verifier = verifier
+		}
+
+		m.upstreams[name] = u
+	}
+
+	return m, nil
+}
+
+// Repos 返回所有代理仓库及其类型
+func (m *Manager) Repos() map[string]string {
+	repos := make(map[string]string, len(m.upstreams))
+	for name, u := range m.upstreams {
+		repos[name] = u.repoType
+	}
+	return repos
+}
+
+// SyncIntervals 返回配置了定期同步的代理仓库及其同步间隔
+func (m *Manager) SyncIntervals() map[string]time.Duration {
+	intervals := make(map[string]time.Duration)
+	for name, u := range m.upstreams {
+		if u.interval > 0 {
+			intervals[name] = u.interval
+		}
+	}
+	return intervals
+}
+
+// IsProxy 判断仓库是否为代理仓库
+func (m *Manager) IsProxy(repoName string) bool {
+	_, ok := m.upstreams[strings.Trim(repoName, "/")]
+	return ok
+}
+
+// Presign 返回缓存文件的预签名下载地址，path 为相对存储根目录的路径；
+// 缓存所在的存储不支持时返回 storage.ErrNotPresignable
+func (m *Manager) Presign(ctx context.Context, path string, expires time.Duration) (string, error) {
+	return storage.Presign(ctx, m.storage, path, expires)
+}
+
+// Match 将 "仓库名/路径" 形式的路径拆分为代理仓库名和仓库内相对路径（最长匹配）
+func (m *Manager) Match(p string) (string, string, bool) {
+	p = strings.Trim(path.Clean("/"+p), "/")
+	names := make([]string, 0, len(m.upstreams))
+	for name := range m.upstreams {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+
+	for _, name := range names {
+		if p == name {
+			return name, "", true
+		}
+		if rel, ok := strings.CutPrefix(p, name+"/"); ok {
+			return name, rel, true
+		}
+	}
+	return "", "", false
+}
+
+// Status 返回代理仓库的上游校验状态
+func (m *Manager) Status(repoName string) (*types.UpstreamStatus, bool) {
+	u, ok := m.upstreams[strings.Trim(repoName, "/")]
+	if !ok {
+		return nil, false
+	}
+	u.statusMu.RLock()
+	defer u.statusMu.RUnlock()
+	status := u.status
+	return &status, true
+}
+
+// Sync 从上游同步元数据：签名校验通过且所有索引文件校验和一致后才写入缓存
+func (m *Manager) Sync(ctx context.Context, repoName string) error {
+	u, ok := m.upstreams[strings.Trim(repoName, "/")]
+	if !ok {
+		return fmt.Errorf("repository %s is not a proxy repository", repoName)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return m.sync(ctx, u)
+}
+
+func (m *Manager) sync(ctx context.Context, u *upstream) error {
+	var signer string
+	var source *mirror
+	// 元数据必须整套来自同一个上游，失败时整体切换到下一个上游
+	err := m.tryMirrors(ctx, u, func(mr *mirror) error {
+		log.Logger.Debugf("Syncing proxy repository %s from %s", u.name, mr.url)
+
+		var err error
+		switch u.repoType {
+		case "rpm":
+			signer, err = m.syncRPM(ctx, u, mr)
+		case "deb":
+			signer, err = m.syncDEB(ctx, u, mr)
+		}
+		if err == nil {
+			source = mr
+		}
+		return err
+	})
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	u.statusMu.Lock()
+	failing := u.status.LastError != ""
+	u.status.LastSync = now
+	if err != nil {
+		u.status.LastError = err.Error()
+		if errors.Is(err, ErrVerification) {
+			u.status.Verified = false
+			u.status.Rejected++
+		}
+	} else {
+		u.status.URL = source.url
+		u.status.LastError = ""
+		u.status.Verified = u.verifier != nil
+		u.status.SignedBy = signer
+		u.status.LastVerified = now
+	}
+	u.statusMu.Unlock()
+
+	if err != nil {
+		log.Logger.Warnf("Sync of proxy repository %s failed: %v", u.name, err)
+		if !failing {
+			for _, fn := range m.failHooks {
+				fn(u.name, err)
+			}
+		}
+		return err
+	}
+	log.Logger.Debugf("Proxy repository %s synced from %s, %d files indexed", u.name, source.url, len(u.index))
+	for _, fn := range m.syncHooks {
+		fn(u.name)
+	}
+	return nil
+}
+
+// OnSync 注册代理仓库元数据同步成功后的回调，需在启动前注册
+func (m *Manager) OnSync(fn func(repoName string)) {
+	m.syncHooks = append(m.syncHooks, fn)
+}
+
+// OnSyncFailed 注册同步失败的回调，需在启动前注册。只在上次同步成功（或首次同步）后失败时调用，
+// 恢复前的后续失败不再调用
+func (m *Manager) OnSyncFailed(fn func(repoName string, err error)) {
+	m.failHooks = append(m.failHooks, fn)
+}
+
+// Fetch 确保代理仓库中的文件已缓存：不存在时从上游拉取并按已校验元数据中的校验和校验
+func (m *Manager) Fetch(ctx context.Context, repoName, filePath string) error {
+	u, ok := m.upstreams[strings.Trim(repoName, "/")]
+	if !ok {
+		return nil
+	}
+
+	rel := strings.Trim(path.Clean("/"+filePath), "/")
+	if rel == "" {
+		return nil
+	}
+	if exists, _ := m.storage.Exists(ctx, path.Join(u.name, rel)); exists {
+		return nil
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// 等待锁期间可能已被其他请求拉取
+	if exists, _ := m.storage.Exists(ctx, path.Join(u.name, rel)); exists {
+		return nil
+	}
+
+	if u.index == nil {
+		if err := m.loadIndex(ctx, u); err != nil {
+			log.Logger.Debugf("No cached index for proxy repository %s (%v), syncing", u.name, err)
+			if err := m.sync(ctx, u); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 元数据只能通过同步获取
+	if isMetadataPath(u.repoType, rel) {
+		if exists, _ := m.storage.Exists(ctx, path.Join(u.name, rel)); exists {
+			return nil
+		}
+		return ErrNotFound
+	}
+
+	entry, ok := u.index[rel]
+	if !ok && u.verifier != nil {
+		return ErrNotFound
+	}
+
+	var tmp string
+	var source *mirror
+	err := m.tryMirrors(ctx, u, func(mr *mirror) error {
+		var err error
+		if tmp, err = m.download(ctx, u, mr, rel, entry.algo, entry.sum); err == nil {
+			source = mr
+		}
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, ErrVerification) {
+			u.statusMu.Lock()
+			u.status.Rejected++
+			u.status.LastError = err.Error()
+			u.statusMu.Unlock()
+		}
+		return err
+	}
+	defer os.Remove(tmp)
+
+	var size int64
+	if info, err := os.Stat(tmp); err == nil {
+		size = info.Size()
+	}
+	source.served(size)
+	log.Logger.Debugf("Proxy repository %s: %s served by %s", u.name, rel, source.url)
+
+	return m.storeFile(ctx, u, rel, tmp)
+}
+
+// download 下载上游文件到临时文件，并校验校验和（algo 为空时不校验）
+func (m *Manager) download(ctx context.Context, u *upstream, mr *mirror, rel, algo, want string) (string, error) {
+	body, err := m.open(ctx, u, mr, rel)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	f, err := os.CreateTemp("", "plus-upstream-*")
+	if err != nil {
+		return "", err
+	}
+	ok := false
+	defer func() {
+		f.Close()
+		if !ok {
+			os.Remove(f.Name())
+		}
+	}()
+
+	var h hash.Hash
+	var w io.Writer = f
+	if algo != "" {
+		if h = utils.NewHash(algo); h == nil {
+			return "", fmt.Errorf("%w: unsupported checksum type %s for %s", ErrVerification, algo, rel)
+		}
+		w = io.MultiWriter(f, h)
+	}
+
+	if _, err := io.Copy(w, body); err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", rel, err)
+	}
+
+	if h != nil {
+		if got := fmt.Sprintf("%x", h.Sum(nil)); got != strings.ToLower(want) {
+			return "", fmt.Errorf("%w: %s checksum mismatch (expected %s, got %s)", ErrVerification, rel, want, got)
+		}
+	}
+
+	ok = true
+	return f.Name(), nil
+}
+
+// fetchBytes 读取上游小文件（元数据、签名）
+func (m *Manager) fetchBytes(ctx context.Context, u *upstream, mr *mirror, rel string) ([]byte, error) {
+	body, err := m.open(ctx, u, mr, rel)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxMetadataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+	}
+	if len(data) > maxMetadataSize {
+		return nil, fmt.Errorf("%s exceeds metadata size limit", rel)
+	}
+	return data, nil
+}
+
+func (m *Manager) open(ctx context.Context, u *upstream, mr *mirror, rel string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mr.url+rel, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rel, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, rel)
+	case resp.StatusCode != http.StatusOK:
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to fetch %s: upstream returned %s", rel, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func (m *Manager) storeFile(ctx context.Context, u *upstream, rel, tmp string) error {
+	f, err := os.Open(tmp)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := m.storage.Store(ctx, path.Join(u.name, rel), f); err != nil {
+		return fmt.Errorf("failed to cache %s: %w", rel, err)
+	}
+	return nil
+}
+
+func (m *Manager) storeBytes(ctx context.Context, u *upstream, rel string, data []byte) error {
+	if err := m.storage.Store(ctx, path.Join(u.name, rel), bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to cache %s: %w", rel, err)
+	}
+	return nil
+}
+
+func (m *Manager) readCached(ctx context.Context, u *upstream, rel string) ([]byte, error) {
+	reader, err := m.storage.Get(ctx, path.Join(u.name, rel))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func (u *upstream) getIndex() map[string]indexEntry {
+	u.indexMu.RLock()
+	defer u.indexMu.RUnlock()
+	return u.index
+}
+
+// setIndex 替换索引，调用方需持有 u.mu
+func (u *upstream) setIndex(index map[string]indexEntry) {
+	u.indexMu.Lock()
+	u.index = index
+	u.indexMu.Unlock()
+}
+
+// loadIndex 从本地已校验的缓存元数据重建索引（重启后无需重新同步）
+func (m *Manager) loadIndex(ctx context.Context, u *upstream) error {
+	switch u.repoType {
+	case "rpm":
+		return m.loadRPMIndex(ctx, u)
+	case "deb":
+		return m.loadDEBIndex(ctx, u)
+	}
+	return fmt.Errorf("unsupported repository type %s", u.repoType)
+}
+
+// safeRel 校验元数据中引用的相对路径，拒绝跳出仓库目录的路径
+func safeRel(p string) (string, error) {
+	clean := path.Clean(p)
+	if p == "" || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("%w: invalid path in metadata: %q", ErrVerification, p)
+	}
+	return clean, nil
+}
@@ -0,0 +1,324 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/pkg/storage"
+	_ "plus/pkg/storage/local"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+type fakeUpstream struct {
+	files  map[string][]byte
+	server *httptest.Server
+}
+
+func sum(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// newFakeRPMUpstream 构造一个签名的 RPM 上游仓库
+func newFakeRPMUpstream(t *testing.T, signer *openpgp.Entity, pkg []byte) *fakeUpstream {
+	t.Helper()
+
+	primaryXML := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<metadata xmlns="http://linux.duke.edu/metadata/common" packages="1">
+<package type="rpm"><name>hello</name><arch>x86_64</arch>
+<checksum type="sha256" pkgid="YES">%s</checksum>
+<location href="Packages/h/hello-1.0-1.x86_64.rpm"/></package>
+</metadata>`, sum(pkg))
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte(primaryXML))
+	zw.Close()
+	primary := gz.Bytes()
+
+	repomd := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<repomd xmlns="http://linux.duke.edu/metadata/repo">
+<data type="primary"><checksum type="sha256">%s</checksum><location href="repodata/primary.xml.gz"/></data>
+</repomd>`, sum(primary))
+
+	var sig bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sig, signer, bytes.NewReader([]byte(repomd)), nil); err != nil {
+		t.Fatalf("Failed to sign repomd.xml: %v", err)
+	}
+
+	u := &fakeUpstream{files: map[string][]byte{
+		"/repodata/repomd.xml":               []byte(repomd),
+		"/repodata/repomd.xml.asc":           sig.Bytes(),
+		"/repodata/primary.xml.gz":           primary,
+		"/Packages/h/hello-1.0-1.x86_64.rpm": pkg,
+	}}
+	u.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := u.files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(u.server.Close)
+	return u
+}
+
+func newTestManager(t *testing.T, upstreamURL string, pinned *openpgp.Entity) (*Manager, string) {
+	t.Helper()
+	return newTestManagerWithConfig(t, config.UpstreamConfig{URL: upstreamURL}, pinned)
+}
+
+func newTestManagerWithConfig(t *testing.T, uc config.UpstreamConfig, pinned *openpgp.Entity) (*Manager, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "upstream.asc")
+	var buf bytes.Buffer
+	w, _ := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	pinned.Serialize(w)
+	w.Close()
+	if err := os.WriteFile(keyPath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	root := filepath.Join(dir, "storage")
+	store, err := storage.Create(storage.Local, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uc.GPGKeys = []string{keyPath}
+	cfg := &config.Config{Repositories: map[string]config.RepoConfig{
+		"mirror/baseos": {
+			Type:     "rpm",
+			Upstream: uc,
+		},
+	}}
+	m, err := NewManager(cfg, store)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	return m, root
+}
+
+func newEntity(t *testing.T, name string) *openpgp.Entity {
+	t.Helper()
+	e, err := openpgp.NewEntity(name, "", name+"@example.com", nil)
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	return e
+}
+
+func TestSyncAndFetchVerified(t *testing.T) {
+	key := newEntity(t, "upstream")
+	pkg := []byte("rpm payload")
+	upstream := newFakeRPMUpstream(t, key, pkg)
+	m, root := newTestManager(t, upstream.server.URL, key)
+	ctx := context.Background()
+
+	if err := m.Sync(ctx, "mirror/baseos"); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "mirror/baseos/repodata/repomd.xml")); err != nil {
+		t.Errorf("repomd.xml not cached: %v", err)
+	}
+
+	status, ok := m.Status("mirror/baseos")
+	if !ok || !status.Verified || status.SignedBy == "" {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+
+	if err := m.Fetch(ctx, "mirror/baseos", "Packages/h/hello-1.0-1.x86_64.rpm"); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	cached, err := os.ReadFile(filepath.Join(root, "mirror/baseos/Packages/h/hello-1.0-1.x86_64.rpm"))
+	if err != nil || !bytes.Equal(cached, pkg) {
+		t.Errorf("Package not cached correctly: %v", err)
+	}
+
+	if err := m.Fetch(ctx, "mirror/baseos", "Packages/unknown.rpm"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unlisted file, got %v", err)
+	}
+}
+
+func TestSyncRejectsUntrustedSignature(t *testing.T) {
+	upstream := newFakeRPMUpstream(t, newEntity(t, "attacker"), []byte("rpm payload"))
+	m, root := newTestManager(t, upstream.server.URL, newEntity(t, "upstream"))
+	var failed []string
+	m.OnSyncFailed(func(repoName string, err error) { failed = append(failed, repoName) })
+
+	err := m.Sync(context.Background(), "mirror/baseos")
+	if !errors.Is(err, ErrVerification) {
+		t.Fatalf("Expected verification error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "mirror/baseos/repodata/repomd.xml")); !os.IsNotExist(err) {
+		t.Errorf("Unverified repomd.xml must not be cached")
+	}
+
+	status, _ := m.Status("mirror/baseos")
+	if status.Verified || status.Rejected != 1 || status.LastError == "" {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+
+	// 持续失败时只回调一次
+	m.Sync(context.Background(), "mirror/baseos")
+	if len(failed) != 1 || failed[0] != "mirror/baseos" {
+		t.Errorf("Expected one failure callback, got %v", failed)
+	}
+}
+
+func TestFetchRejectsTamperedPackage(t *testing.T) {
+	key := newEntity(t, "upstream")
+	upstream := newFakeRPMUpstream(t, key, []byte("rpm payload"))
+	m, root := newTestManager(t, upstream.server.URL, key)
+	ctx := context.Background()
+
+	if err := m.Sync(ctx, "mirror/baseos"); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	upstream.files["/Packages/h/hello-1.0-1.x86_64.rpm"] = []byte("tampered")
+	err := m.Fetch(ctx, "mirror/baseos", "Packages/h/hello-1.0-1.x86_64.rpm")
+	if !errors.Is(err, ErrVerification) {
+		t.Fatalf("Expected verification error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "mirror/baseos/Packages/h/hello-1.0-1.x86_64.rpm")); !os.IsNotExist(err) {
+		t.Errorf("Tampered package must not be cached")
+	}
+}
+
+func TestParseRelease(t *testing.T) {
+	release := []byte(`Origin: Example
+Suite: stable
+MD5Sum:
+ d41d8cd98f00b204e9800998ecf8427e 0 main/binary-amd64/Packages
+SHA256:
+ e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 0 main/binary-amd64/Packages
+ e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 20 main/binary-amd64/Packages.gz
+`)
+	entries := parseRelease(release)
+	if len(entries) != 2 || entries[0].algo != "sha256" || entries[1].path != "main/binary-amd64/Packages.gz" {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+}
+
+func TestSyncFailsOverToMirror(t *testing.T) {
+	key := newEntity(t, "upstream")
+	pkg := []byte("rpm payload")
+	upstream := newFakeRPMUpstream(t, key, pkg)
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	m, _ := newTestManagerWithConfig(t, config.UpstreamConfig{
+		URL:         downURL,
+		Mirrors:     []config.MirrorConfig{{URL: upstream.server.URL}},
+		HealthCheck: config.HealthCheckConfig{SpotChecks: 1, MaxAge: "1h"},
+	}, key)
+	ctx := context.Background()
+
+	if err := m.Sync(ctx, "mirror/baseos"); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if status, _ := m.Status("mirror/baseos"); status.URL != upstream.server.URL+"/" {
+		t.Errorf("Expected sync from mirror, got %s", status.URL)
+	}
+
+	health, err := m.CheckHealth(ctx, "mirror/baseos")
+	if err != nil {
+		t.Fatalf("CheckHealth failed: %v", err)
+	}
+	if len(health.Mirrors) != 2 || !health.Healthy {
+		t.Fatalf("Unexpected health: %+v", health)
+	}
+	if primary := health.Mirrors[0]; primary.ConsecutiveFailures != 2 || primary.LastError == "" {
+		t.Errorf("Unexpected primary health: %+v", primary)
+	}
+	if mirror := health.Mirrors[1]; !mirror.Healthy || !mirror.Active || mirror.SpotChecks != 1 || mirror.SpotCheckFailures != 0 {
+		t.Errorf("Unexpected mirror health: %+v", mirror)
+	}
+
+	// 抽查发现包内容被篡改的上游标记为不可用
+	upstream.files["/Packages/h/hello-1.0-1.x86_64.rpm"] = []byte("tampered")
+	health, _ = m.CheckHealth(ctx, "mirror/baseos")
+	if mirror := health.Mirrors[1]; mirror.Healthy || mirror.SpotCheckFailures != 1 {
+		t.Errorf("Tampered mirror should be unhealthy: %+v", mirror)
+	}
+}
+
+func TestMetadataTime(t *testing.T) {
+	release := []byte("Origin: Debian\nDate: Sat, 10 Jun 2023 09:31:00 UTC\n")
+	if got := metadataTime("deb", release); got.Format(time.RFC3339) != "2023-06-10T09:31:00Z" {
+		t.Errorf("Unexpected Release date: %v", got)
+	}
+
+	repomd := []byte(`<repomd><revision>1</revision><data type="primary"><timestamp>1700000000</timestamp></data></repomd>`)
+	if got := metadataTime("rpm", repomd); got.Unix() != 1700000000 {
+		t.Errorf("Unexpected repomd timestamp: %v", got)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	mr := newMirror("http://a/", 1, breaker{failures: 2, cooldown: time.Hour})
+	for i := 0; i < 2; i++ {
+		if !mr.acquire() {
+			t.Fatalf("Closed circuit must allow requests")
+		}
+		mr.fail(errors.New("connection refused"))
+	}
+	if mr.acquire() {
+		t.Fatalf("Open circuit must reject requests")
+	}
+	if h := mr.snapshot(); h.Circuit != circuitOpen || h.Healthy || h.Failures != 2 {
+		t.Errorf("Unexpected state: %+v", h)
+	}
+
+	// 冷却结束后只放行一个试探请求
+	mr.mu.Lock()
+	mr.openUntil = time.Now().Add(-time.Second)
+	mr.mu.Unlock()
+	if !mr.acquire() || mr.acquire() {
+		t.Fatalf("Half-open circuit must allow exactly one probe")
+	}
+	mr.succeed()
+	if h := mr.snapshot(); h.Circuit != circuitClosed || !h.Healthy {
+		t.Errorf("Successful probe must close the circuit: %+v", h)
+	}
+}
+
+func TestWeightedOrder(t *testing.T) {
+	light := newMirror("http://light/", 1, breaker{failures: 3})
+	heavy := newMirror("http://heavy/", 9, breaker{failures: 3})
+
+	first := 0
+	for i := 0; i < 1000; i++ {
+		if weightedOrder([]*mirror{light, heavy})[0] == heavy {
+			first++
+		}
+	}
+	if first < 800 || first > 980 {
+		t.Errorf("Heavy mirror chosen first %d/1000 times, expected about 900", first)
+	}
+}
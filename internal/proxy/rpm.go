@@ -0,0 +1,216 @@
+package proxy
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"plus/internal/types"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const repomdPath = "repodata/repomd.xml"
+
+type repomd struct {
+	XMLName  xml.Name     `xml:"repomd"`
+	Revision string       `xml:"revision"`
+	Data     []repomdData `xml:"data"`
+}
+
+type repomdData struct {
+	Type      string         `xml:"type,attr"`
+	Checksum  types.Checksum `xml:"checksum"`
+	Location  types.Location `xml:"location"`
+	Timestamp int64          `xml:"timestamp"`
+}
+
+// syncRPM 校验 repomd.xml 签名，再按 repomd 中的校验和下载所有元数据文件
+func (m *Manager) syncRPM(ctx context.Context, u *upstream, mr *mirror) (string, error) {
+	data, err := m.fetchBytes(ctx, u, mr, repomdPath)
+	if err != nil {
+		return "", err
+	}
+
+	var sig []byte
+	var signer string
+	if u.verifier != nil {
+		sig, err = m.fetchBytes(ctx, u, mr, repomdPath+".asc")
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return "", fmt.Errorf("%w: upstream repomd.xml is not signed", ErrVerification)
+			}
+			return "", err
+		}
+		if signer, err = u.verifier.VerifyDetached(data, sig); err != nil {
+			return "", fmt.Errorf("%w: repomd.xml: %v", ErrVerification, err)
+		}
+	}
+
+	var md repomd
+	if err := xml.Unmarshal(data, &md); err != nil {
+		return "", fmt.Errorf("%w: invalid repomd.xml: %v", ErrVerification, err)
+	}
+
+	// 先下载并校验全部元数据，全部通过后再写入缓存
+	tmps := make(map[string]string)
+	defer func() {
+		for _, tmp := range tmps {
+			os.Remove(tmp)
+		}
+	}()
+
+	primary := ""
+	for _, d := range md.Data {
+		rel, err := safeRel(d.Location.Href)
+		if err != nil {
+			return "", err
+		}
+		tmp, err := m.download(ctx, u, mr, rel, d.Checksum.Type, strings.TrimSpace(d.Checksum.Value))
+		if err != nil {
+			return "", err
+		}
+		tmps[rel] = tmp
+		if d.Type == "primary" {
+			primary = rel
+		}
+	}
+	if primary == "" {
+		return "", fmt.Errorf("%w: repomd.xml has no primary metadata", ErrVerification)
+	}
+
+	index, err := parsePrimaryFile(tmps[primary], primary)
+	if err != nil {
+		return "", err
+	}
+
+	for rel, tmp := range tmps {
+		if err := m.storeFile(ctx, u, rel, tmp); err != nil {
+			return "", err
+		}
+	}
+	// repomd.xml 最后写入，保证客户端看到的索引文件均已就绪
+	if sig != nil {
+		if err := m.storeBytes(ctx, u, repomdPath+".asc", sig); err != nil {
+			return "", err
+		}
+	}
+	if err := m.storeBytes(ctx, u, repomdPath, data); err != nil {
+		return "", err
+	}
+
+	u.setIndex(index)
+	return signer, nil
+}
+
+func (m *Manager) loadRPMIndex(ctx context.Context, u *upstream) error {
+	data, err := m.readCached(ctx, u, repomdPath)
+	if err != nil {
+		return err
+	}
+
+	var md repomd
+	if err := xml.Unmarshal(data, &md); err != nil {
+		return fmt.Errorf("invalid cached repomd.xml: %w", err)
+	}
+
+	for _, d := range md.Data {
+		if d.Type != "primary" {
+			continue
+		}
+		rel, err := safeRel(d.Location.Href)
+		if err != nil {
+			return err
+		}
+		reader, err := m.storage.Get(ctx, u.name+"/"+rel)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		index, err := parsePrimary(reader, rel)
+		if err != nil {
+			return err
+		}
+		u.setIndex(index)
+		return nil
+	}
+	return fmt.Errorf("cached repomd.xml has no primary metadata")
+}
+
+func parsePrimaryFile(file, name string) (map[string]indexEntry, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parsePrimary(f, name)
+}
+
+// parsePrimary 解析 primary.xml，返回包路径到校验和的索引
+func parsePrimary(r io.Reader, name string) (map[string]indexEntry, error) {
+	reader, err := decompress(r, name)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	index := make(map[string]indexEntry)
+	decoder := xml.NewDecoder(reader)
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "package" {
+			continue
+		}
+
+		var pkg types.Package
+		if err := decoder.DecodeElement(&pkg, &start); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		rel, err := safeRel(pkg.Location.Href)
+		if err != nil {
+			return nil, err
+		}
+		index[rel] = indexEntry{algo: pkg.Checksum.Type, sum: strings.TrimSpace(pkg.Checksum.Value)}
+	}
+	return index, nil
+}
+
+// decompress 按文件扩展名解压元数据
+func decompress(r io.Reader, name string) (io.ReadCloser, error) {
+	switch {
+	case strings.HasSuffix(name, ".gz"):
+		return gzip.NewReader(r)
+	case strings.HasSuffix(name, ".zst"):
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case strings.HasSuffix(name, ".xz"):
+		x, err := xz.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(x), nil
+	case strings.HasSuffix(name, ".bz2"):
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	default:
+		return io.NopCloser(r), nil
+	}
+}
@@ -0,0 +1,183 @@
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"plus/internal/index"
+	"plus/internal/journal"
+	"plus/internal/layout"
+	"plus/internal/utils"
+	"plus/pkg/repo"
+	"plus/pkg/repo/rpm"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+)
+
+// Summary 启动时清理的上次崩溃的残留
+type Summary struct {
+	BuildDirs int      // 未完成的元数据生成目录
+	TempFiles int      // 写入中断的临时文件
+	Bytes     int64    // 释放的空间
+	Repos     []string // 元数据发布中断的仓库，需要重新生成元数据
+	Errors    []string
+}
+
+// Empty 没有发现残留
+func (s *Summary) Empty() bool {
+	return s.BuildDirs == 0 && s.TempFiles == 0 && len(s.Repos) == 0 && len(s.Errors) == 0
+}
+
+// Add 合并另一个目录的结果
+func (s *Summary) Add(o Summary) {
+	s.BuildDirs += o.BuildDirs
+	s.TempFiles += o.TempFiles
+	s.Bytes += o.Bytes
+	s.Repos = append(s.Repos, o.Repos...)
+	s.Errors = append(s.Errors, o.Errors...)
+}
+
+// mindbDirs 对象存储自己管理的目录，其中的分片上传和临时文件由对象存储清理
+var mindbDirs = map[string]bool{".db.sys": true, "buckets": true}
+
+// Storage 清理存储目录中的残留：repo.RefreshRoot 下的元数据生成目录、本地存储写入中断的文件、
+// 仓库 repodata 中发布中断的临时文件，以及根目录下替换中断的版本和存储切换文件、写入中断的操作日志。
+// 发布中断的仓库记录在 Repos 中，旧的 repomd.xml 仍然完整，重新生成元数据后才包含中断前上传的包。
+// 只清理修改时间早于 minAge 的残留，多个实例共用存储时不删除其他实例正在写入的文件
+func Storage(root string, minAge time.Duration) Summary {
+	var s Summary
+	cutoff := time.Now().Add(-minAge)
+
+	builds, err := os.ReadDir(filepath.Join(root, repo.RefreshRoot))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		s.Errors = append(s.Errors, err.Error())
+	}
+	for _, e := range builds {
+		p := filepath.Join(root, repo.RefreshRoot, e.Name())
+		info, err := e.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		size := dirSize(p)
+		if err := os.RemoveAll(p); err != nil {
+			s.Errors = append(s.Errors, err.Error())
+			continue
+		}
+		s.BuildDirs++
+		s.Bytes += size
+	}
+
+	repos := make(map[string]bool)
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && p == root {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			s.Errors = append(s.Errors, err.Error())
+			return nil
+		}
+		rel, _ := filepath.Rel(root, p)
+		if d.IsDir() {
+			if rel == repo.RefreshRoot || mindbDirs[rel] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		name, dir := d.Name(), filepath.Base(filepath.Dir(p))
+		switch {
+		case strings.HasPrefix(name, local.TempPrefix):
+		case dir == "repodata" && (strings.HasPrefix(name, rpm.PublishPrefix) || strings.HasSuffix(name, ".tmp")):
+			// SRPMS 子仓库的元数据随所在仓库一起刷新
+			repoName := strings.TrimSuffix(filepath.ToSlash(filepath.Dir(filepath.Dir(rel))), "/"+repo.SourceRPMDir)
+			if !strings.HasPrefix(repoName, ".") {
+				repos[repoName] = true
+			}
+		case filepath.Dir(rel) == "." && (strings.HasPrefix(name, layout.FileName+".tmp-") || strings.HasPrefix(name, storage.BackendsFile+".tmp-")):
+		case filepath.Dir(rel) == journal.Dir && strings.HasSuffix(name, ".tmp"):
+		default:
+			return nil
+		}
+		s.remove(p, d, cutoff)
+		return nil
+	})
+	if err != nil {
+		s.Errors = append(s.Errors, err.Error())
+	}
+	for name := range repos {
+		s.Repos = append(s.Repos, name)
+	}
+	sort.Strings(s.Repos)
+	return s
+}
+
+// Database 清理数据库目录中替换中断的文件：各数据文件先写入 .tmp 再重命名，索引压缩时写入 index.log.<随机数>
+func Database(root string, minAge time.Duration) Summary {
+	return Files(root, minAge, func(name string) bool {
+		if strings.HasSuffix(name, ".tmp") {
+			return true
+		}
+		suffix, ok := strings.CutPrefix(name, index.FileName+".")
+		return ok && suffix != "" && strings.Trim(suffix, "0123456789") == ""
+	})
+}
+
+// Files 删除 root 下名称满足 match 的文件
+func Files(root string, minAge time.Duration, match func(name string) bool) Summary {
+	var s Summary
+	cutoff := time.Now().Add(-minAge)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && p == root {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			s.Errors = append(s.Errors, err.Error())
+			return nil
+		}
+		if !d.IsDir() && match(d.Name()) {
+			s.remove(p, d, cutoff)
+		}
+		return nil
+	})
+	if err != nil {
+		s.Errors = append(s.Errors, err.Error())
+	}
+	return s
+}
+
+func (s *Summary) remove(p string, d fs.DirEntry, cutoff time.Time) {
+	info, err := d.Info()
+	if err != nil || info.ModTime().After(cutoff) {
+		return
+	}
+	if err := os.Remove(p); err != nil {
+		s.Errors = append(s.Errors, err.Error())
+		return
+	}
+	s.TempFiles++
+	s.Bytes += info.Size()
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// String 日志中的恢复摘要
+func (s *Summary) String() string {
+	return fmt.Sprintf("removed %d metadata build directories and %d temporary files (%s), %d repositories need a metadata refresh",
+		s.BuildDirs, s.TempFiles, utils.FormatFileSize(s.Bytes), len(s.Repos))
+}
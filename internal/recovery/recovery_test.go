@@ -0,0 +1,97 @@
+package recovery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func write(t *testing.T, path string, age time.Duration) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-age)
+	os.Chtimes(path, old, old)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestStorage(t *testing.T) {
+	root := t.TempDir()
+	keep := []string{
+		"el9/Packages/a.rpm",
+		"el9/repodata/repomd.xml",
+		"tools/.repo-type",
+		"buckets/.plus-tmp-owned-by-mindb",
+		".journal/0123456789abcdef.json",
+	}
+	remove := []string{
+		"el9/Packages/.plus-tmp-b.rpm.123",
+		"el9/repodata/.publish-repomd.xml",
+		"el8/x86_64/repodata/primary.xml.gz.tmp",
+		"el7/SRPMS/repodata/.publish-repomd.xml",
+		".staging/el9/.plus-tmp-c.rpm.1",
+		".storage-backends.tmp-42",
+		".journal/fedcba9876543210.tmp",
+	}
+	for _, p := range append(keep, remove...) {
+		write(t, filepath.Join(root, p), 2*time.Hour)
+	}
+	write(t, filepath.Join(root, ".refresh/rpm-1/repodata/primary.xml.gz"), 2*time.Hour)
+	os.Chtimes(filepath.Join(root, ".refresh/rpm-1"), time.Now().Add(-2*time.Hour), time.Now().Add(-2*time.Hour))
+	// 其他实例刚开始的写入
+	write(t, filepath.Join(root, "tools/.plus-tmp-new.tar.1"), 0)
+
+	s := Storage(root, time.Hour)
+	if s.BuildDirs != 1 || s.TempFiles != len(remove) || s.Bytes != int64(4*(len(remove)+1)) || len(s.Errors) != 0 {
+		t.Fatalf("summary = %+v", s)
+	}
+	if strings.Join(s.Repos, ",") != "el7,el8/x86_64,el9" {
+		t.Errorf("repos = %v", s.Repos)
+	}
+	for _, p := range keep {
+		if !exists(filepath.Join(root, p)) {
+			t.Errorf("%s removed", p)
+		}
+	}
+	for _, p := range remove {
+		if exists(filepath.Join(root, p)) {
+			t.Errorf("%s kept", p)
+		}
+	}
+	if exists(filepath.Join(root, ".refresh/rpm-1")) || !exists(filepath.Join(root, "tools/.plus-tmp-new.tar.1")) {
+		t.Error("build directory kept or recent temporary file removed")
+	}
+
+	if s := Storage(root, time.Hour); !s.Empty() {
+		t.Errorf("second run = %+v", s)
+	}
+	if s := Storage(filepath.Join(root, "missing"), 0); !s.Empty() {
+		t.Errorf("missing root = %+v", s)
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	root := t.TempDir()
+	for _, p := range []string{"users.json", "index.log", "index.log.123456", "users.json.tmp", "deliveries/d1.json.tmp", "deliveries/d2.json"} {
+		write(t, filepath.Join(root, p), time.Minute)
+	}
+	s := Database(root, 0)
+	if s.TempFiles != 3 || len(s.Errors) != 0 {
+		t.Fatalf("summary = %+v", s)
+	}
+	for _, p := range []string{"users.json", "index.log", "deliveries/d2.json"} {
+		if !exists(filepath.Join(root, p)) {
+			t.Errorf("%s removed", p)
+		}
+	}
+}
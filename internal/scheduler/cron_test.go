@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2025, time.March, 14, 10, 30, 15, 0, time.UTC) // 周五
+	testCases := []struct {
+		expr     string
+		expected string
+	}{
+		{"*/15 * * * *", "2025-03-14T10:45:00Z"},
+		{"0 3 * * *", "2025-03-15T03:00:00Z"},
+		{"@daily", "2025-03-15T00:00:00Z"},
+		{"@weekly", "2025-03-16T00:00:00Z"},
+		{"0 2 * * 7", "2025-03-16T02:00:00Z"},
+		{"@monthly", "2025-04-01T00:00:00Z"},
+		{"30 10 14 3 *", "2026-03-14T10:30:00Z"},
+		{"0 9-17/4 * * 1-5", "2025-03-14T13:00:00Z"},
+		{"5,35 * * * *", "2025-03-14T10:35:00Z"},
+		// 日和周都有限制时满足其一
+		{"0 0 1 * 1", "2025-03-17T00:00:00Z"},
+		{"0 0 29 2 *", "2028-02-29T00:00:00Z"},
+	}
+
+	for _, tc := range testCases {
+		c, err := ParseCron(tc.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) failed: %v", tc.expr, err)
+			continue
+		}
+		if got := c.Next(base).Format(time.RFC3339); got != tc.expected {
+			t.Errorf("%q: Next = %s, expected %s", tc.expr, got, tc.expected)
+		}
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@often", "0 0 30 2 *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) accepted an invalid expression", expr)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+// Scope 任务在多实例部署中的执行范围
+type Scope string
+
+const (
+	// ScopeCluster 只在领导者实例上执行，整个集群同一时间只执行一次
+	ScopeCluster Scope = "cluster"
+	// ScopeNode 每个实例各自执行，用于只影响本实例的任务（如本地索引）
+	ScopeNode Scope = "node"
+)
+
+const (
+	stateScheduled = "scheduled"
+	stateRunning   = "running"
+	stateStandby   = "standby" // 集群任务，本实例不是领导者
+	stateStopped   = "stopped"
+)
+
+// historySize 保留的最近执行记录数
+const historySize = 100
+
+var (
+	// ErrUnknownJob 任务不存在
+	ErrUnknownJob = errors.New("unknown job")
+	// ErrNotLeader 集群任务只能在领导者实例上执行
+	ErrNotLeader = errors.New("this node is not the leader")
+	// ErrRunning 任务正在执行
+	ErrRunning = errors.New("job is already running")
+)
+
+// Job 定期执行的后台任务
+type Job struct {
+	Name     string
+	Scope    Scope
+	Interval time.Duration
+	// Cron 不为空时按 cron 表达式执行，忽略 Interval，启动后不立即执行
+	Cron *Cron
+	// Run 执行一次任务；集群任务失去领导权时 ctx 被取消
+	Run func(ctx context.Context) error
+}
+
+// RepoJobName 仓库定时任务的名称，如 repo-refresh/centos/9
+func RepoJobName(action, repoName string) string {
+	return "repo-" + action + "/" + repoName
+}
+
+// Elector 领导者选举
+type Elector interface {
+	// Lead 本实例是领导者时返回 true 和一个在失去领导权时取消的 ctx
+	Lead() (context.Context, bool)
+}
+
+// Ledger 记录集群任务最近一次开始执行的时间，领导者切换后新的领导者据此继续原来的计划，
+// 不会立即重复执行。Elector 实现该接口时自动使用
+type Ledger interface {
+	LastRun(ctx context.Context, job string) (time.Time, bool, error)
+	RecordRun(ctx context.Context, job string, started time.Time) error
+}
+
+// Scheduler 按间隔或 cron 表达式执行任务，同一任务不会重叠执行
+type Scheduler struct {
+	node    string
+	elector Elector
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	history []types.JobRun
+	started bool
+	ctx     context.Context
+}
+
+type job struct {
+	Job
+	trigger chan struct{}
+
+	running   bool
+	next      time.Time
+	lastStart time.Time
+	lastEnd   time.Time
+	lastError string
+	runs      int64
+	failures  int64
+}
+
+// New 创建调度器。elector 为 nil 时本实例总是领导者（单实例部署）
+func New(node string, elector Elector) *Scheduler {
+	return &Scheduler{node: node, elector: elector, jobs: make(map[string]*job)}
+}
+
+// Add 注册任务，需在 Start 之前调用
+func (s *Scheduler) Add(j Job) error {
+	if j.Name == "" || j.Run == nil || (j.Interval <= 0 && j.Cron == nil) {
+		return fmt.Errorf("job %q needs a name, a run function and a positive interval or a cron schedule", j.Name)
+	}
+	if j.Scope == "" {
+		j.Scope = ScopeCluster
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return fmt.Errorf("job %s added after the scheduler started", j.Name)
+	}
+	if _, exists := s.jobs[j.Name]; exists {
+		return fmt.Errorf("job %s is already registered", j.Name)
+	}
+	s.jobs[j.Name] = &job{Job: j, trigger: make(chan struct{}, 1)}
+	return nil
+}
+
+// Start 为每个任务启动后台循环，按间隔执行的任务第一次执行在启动后立即进行，ctx 取消后停止
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started = true
+	s.ctx = ctx
+	for _, j := range s.jobs {
+		go s.loop(ctx, j)
+	}
+	log.Logger.Debugf("Scheduler started with %d jobs", len(s.jobs))
+}
+
+// Trigger 立即执行一次任务（不改变之后的计划）
+func (s *Scheduler) Trigger(name string) error {
+	s.mu.Lock()
+	j, ok := s.jobs[name]
+	if !ok {
+		s.mu.Unlock()
+		return ErrUnknownJob
+	}
+	running := j.running
+	s.mu.Unlock()
+
+	if j.Scope == ScopeCluster {
+		if _, leader := s.lead(); !leader {
+			return ErrNotLeader
+		}
+	}
+	if running {
+		return ErrRunning
+	}
+	select {
+	case j.trigger <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	var first time.Duration
+	if j.Cron != nil {
+		first = j.delay(time.Now())
+		s.mu.Lock()
+		j.next = time.Now().Add(first)
+		s.mu.Unlock()
+	}
+	timer := time.NewTimer(first)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			s.runOnce(ctx, j, false)
+		case <-j.trigger:
+			timer.Stop()
+			s.runOnce(ctx, j, true)
+		}
+		d := j.delay(time.Now())
+		s.mu.Lock()
+		j.next = time.Now().Add(d)
+		s.mu.Unlock()
+		timer.Reset(d)
+	}
+}
+
+// delay 返回从 now 到下一次执行的时间
+func (j *job) delay(now time.Time) time.Duration {
+	if j.Cron != nil {
+		return j.Cron.Next(now).Sub(now)
+	}
+	return j.Interval
+}
+
+// covered 判断 last 开始的执行是否已经覆盖了 now 这一次计划
+func (j *job) covered(last, now time.Time) bool {
+	if j.Cron != nil {
+		return j.Cron.Next(last).After(now)
+	}
+	return now.Sub(last) < j.Interval*9/10
+}
+
+// runOnce 执行一次任务，forced 为手动触发
+func (s *Scheduler) runOnce(ctx context.Context, j *job, forced bool) {
+	runCtx := ctx
+	start := time.Now()
+	if j.Scope == ScopeCluster {
+		leaderCtx, leader := s.lead()
+		if !leader {
+			return
+		}
+		ledger, _ := s.elector.(Ledger)
+		if ledger != nil && !forced {
+			// 上一任领导者刚执行过时跳过
+			if last, ok, err := ledger.LastRun(ctx, j.Name); err == nil && ok && j.covered(last, start) {
+				log.Logger.Debugf("Job %s ran at %s on another node, skipping", j.Name, last.Format(time.RFC3339))
+				return
+			}
+		}
+		if ledger != nil {
+			if err := ledger.RecordRun(ctx, j.Name, start); err != nil {
+				log.Logger.Warnf("Failed to record run of job %s: %v", j.Name, err)
+			}
+		}
+		var cancel context.CancelFunc
+		runCtx, cancel = mergeContext(ctx, leaderCtx)
+		defer cancel()
+	}
+
+	s.mu.Lock()
+	j.running = true
+	j.lastStart = start
+	s.mu.Unlock()
+
+	err := j.Run(runCtx)
+
+	end := time.Now()
+	run := types.JobRun{
+		Job:        j.Name,
+		Started:    start.UTC().Format(time.RFC3339),
+		Finished:   end.UTC().Format(time.RFC3339),
+		DurationMs: end.Sub(start).Milliseconds(),
+	}
+	s.mu.Lock()
+	j.running = false
+	j.lastEnd = end
+	j.runs++
+	j.lastError = ""
+	if err != nil {
+		j.failures++
+		j.lastError = err.Error()
+		run.Error = err.Error()
+	}
+	s.history = append(s.history, run)
+	if len(s.history) > historySize {
+		s.history = s.history[len(s.history)-historySize:]
+	}
+	s.mu.Unlock()
+
+	if err != nil && ctx.Err() == nil {
+		log.Logger.Warnf("Job %s failed after %s: %v", j.Name, end.Sub(start).Round(time.Millisecond), err)
+	} else {
+		log.Logger.Debugf("Job %s finished in %s", j.Name, end.Sub(start).Round(time.Millisecond))
+	}
+}
+
+func (s *Scheduler) lead() (context.Context, bool) {
+	if s.elector == nil {
+		return context.Background(), true
+	}
+	return s.elector.Lead()
+}
+
+// Status 返回任务列表和最近的执行记录（新的在前）
+func (s *Scheduler) Status() *types.JobList {
+	_, leader := s.lead()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := &types.JobList{
+		Node:   s.node,
+		Leader: leader,
+		Jobs:   make([]types.JobStatus, 0, len(s.jobs)),
+		Recent: make([]types.JobRun, 0, len(s.history)),
+	}
+	stopped := s.ctx != nil && s.ctx.Err() != nil
+	for _, j := range s.jobs {
+		list.Jobs = append(list.Jobs, s.jobStatus(j, leader, stopped))
+	}
+	sort.Slice(list.Jobs, func(i, k int) bool { return list.Jobs[i].Name < list.Jobs[k].Name })
+	for i := len(s.history) - 1; i >= 0; i-- {
+		list.Recent = append(list.Recent, s.history[i])
+	}
+	return list
+}
+
+// Job 返回单个任务的状态
+func (s *Scheduler) Job(name string) (types.JobStatus, bool) {
+	_, leader := s.lead()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[name]
+	if !ok {
+		return types.JobStatus{}, false
+	}
+	return s.jobStatus(j, leader, s.ctx != nil && s.ctx.Err() != nil), true
+}
+
+// jobStatus 调用方持有 s.mu
+func (s *Scheduler) jobStatus(j *job, leader, stopped bool) types.JobStatus {
+	st := types.JobStatus{
+		Name:     j.Name,
+		Scope:    string(j.Scope),
+		State:    stateScheduled,
+		Runs:     j.runs,
+		Failures: j.failures,
+	}
+	if j.Cron != nil {
+		st.Schedule = j.Cron.String()
+	} else {
+		st.Interval = j.Interval.String()
+	}
+	st.LastError = j.lastError
+	switch {
+	case j.running:
+		st.State = stateRunning
+	case !s.started || stopped:
+		st.State = stateStopped
+	case j.Scope == ScopeCluster && !leader:
+		st.State = stateStandby
+	}
+	if !j.next.IsZero() && st.State == stateScheduled {
+		st.NextRun = j.next.UTC().Format(time.RFC3339)
+	}
+	if !j.lastStart.IsZero() {
+		st.LastStart = j.lastStart.UTC().Format(time.RFC3339)
+	}
+	if !j.lastEnd.IsZero() && !j.running {
+		st.LastEnd = j.lastEnd.UTC().Format(time.RFC3339)
+		st.LastDurationMs = j.lastEnd.Sub(j.lastStart).Milliseconds()
+	}
+	return st
+}
+
+// mergeContext 返回在 a 或 b 结束时取消的 ctx
+func mergeContext(a, b context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(a)
+	stop := context.AfterFunc(b, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,205 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+// fakeElector 可切换领导权的选举，同时记录集群任务的执行时间
+type fakeElector struct {
+	mu     sync.Mutex
+	leader bool
+	runs   map[string]time.Time
+}
+
+func (e *fakeElector) Lead() (context.Context, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return context.Background(), e.leader
+}
+
+func (e *fakeElector) setLeader(leader bool) {
+	e.mu.Lock()
+	e.leader = leader
+	e.mu.Unlock()
+}
+
+func (e *fakeElector) LastRun(ctx context.Context, job string) (time.Time, bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	t, ok := e.runs[job]
+	return t, ok, nil
+}
+
+func (e *fakeElector) RecordRun(ctx context.Context, job string, started time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.runs[job] = started
+	return nil
+}
+
+func counter(n *int64) func(context.Context) error {
+	return func(context.Context) error {
+		atomic.AddInt64(n, 1)
+		return nil
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRunsOnInterval(t *testing.T) {
+	s := New("a", nil)
+	var runs int64
+	failing := errors.New("boom")
+	if err := s.Add(Job{Name: "gc", Interval: 50 * time.Millisecond, Run: counter(&runs)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(Job{Name: "broken", Interval: time.Hour, Run: func(context.Context) error { return failing }}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(Job{Name: "gc", Interval: time.Hour, Run: counter(&runs)}); err == nil {
+		t.Fatal("duplicate job accepted")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	waitFor(t, func() bool { return atomic.LoadInt64(&runs) >= 3 })
+
+	if err := s.Add(Job{Name: "late", Interval: time.Hour, Run: counter(&runs)}); err == nil {
+		t.Fatal("job added after start")
+	}
+	waitFor(t, func() bool { return len(s.Status().Recent) >= 4 })
+	list := s.Status()
+	if list.Node != "a" || !list.Leader || len(list.Jobs) != 2 {
+		t.Fatalf("unexpected status %+v", list)
+	}
+	broken := list.Jobs[0]
+	if broken.Name != "broken" || broken.Failures != 1 || broken.LastError != "boom" || broken.State != stateScheduled {
+		t.Fatalf("unexpected job status %+v", broken)
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	s := New("a", nil)
+	var runs int64
+	s.Add(Job{Name: "sync", Interval: time.Hour, Run: counter(&runs)})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	waitFor(t, func() bool { return atomic.LoadInt64(&runs) == 1 })
+
+	if err := s.Trigger("missing"); !errors.Is(err, ErrUnknownJob) {
+		t.Fatalf("expected ErrUnknownJob, got %v", err)
+	}
+	if err := s.Trigger("sync"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return atomic.LoadInt64(&runs) == 2 })
+}
+
+func TestClusterJobFollowsLeader(t *testing.T) {
+	e := &fakeElector{runs: make(map[string]time.Time)}
+	s := New("b", e)
+	var clusterRuns, nodeRuns int64
+	s.Add(Job{Name: "retention", Interval: 50 * time.Millisecond, Run: counter(&clusterRuns)})
+	s.Add(Job{Name: "index", Scope: ScopeNode, Interval: 50 * time.Millisecond, Run: counter(&nodeRuns)})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	waitFor(t, func() bool { return atomic.LoadInt64(&nodeRuns) >= 2 })
+	if n := atomic.LoadInt64(&clusterRuns); n != 0 {
+		t.Fatalf("cluster job ran %d times on a standby node", n)
+	}
+	if err := s.Trigger("retention"); !errors.Is(err, ErrNotLeader) {
+		t.Fatalf("expected ErrNotLeader, got %v", err)
+	}
+	if st := s.Status().Jobs[1]; st.Name != "retention" || st.State != stateStandby {
+		t.Fatalf("unexpected job status %+v", st)
+	}
+
+	e.setLeader(true)
+	waitFor(t, func() bool { return atomic.LoadInt64(&clusterRuns) >= 1 })
+}
+
+func TestLedgerSkipsRecentRun(t *testing.T) {
+	// 上一任领导者刚执行过，新领导者等到下一个间隔
+	e := &fakeElector{leader: true, runs: map[string]time.Time{"gc": time.Now()}}
+	s := New("b", e)
+	var runs int64
+	s.Add(Job{Name: "gc", Interval: time.Hour, Run: counter(&runs)})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	time.Sleep(100 * time.Millisecond)
+	if n := atomic.LoadInt64(&runs); n != 0 {
+		t.Fatalf("job ran %d times despite a recent run on another node", n)
+	}
+	// 手动触发不受限制
+	if err := s.Trigger("gc"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return atomic.LoadInt64(&runs) == 1 })
+}
+
+func TestCronJobWaitsForSchedule(t *testing.T) {
+	s := New("a", nil)
+	var runs int64
+	cron, err := ParseCron("0 0 1 1 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(Job{Name: "repo-refresh/el9", Cron: cron, Run: counter(&runs)}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	waitFor(t, func() bool {
+		st, _ := s.Job("repo-refresh/el9")
+		return st.NextRun != ""
+	})
+	st, ok := s.Job("repo-refresh/el9")
+	if !ok || st.Schedule != "0 0 1 1 *" || st.Interval != "" || st.LastStart != "" {
+		t.Fatalf("unexpected job status %+v", st)
+	}
+	if n := atomic.LoadInt64(&runs); n != 0 {
+		t.Fatalf("cron job ran %d times at start", n)
+	}
+	if err := s.Trigger("repo-refresh/el9"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		st, _ := s.Job("repo-refresh/el9")
+		return st.LastEnd != ""
+	})
+	if _, ok := s.Job("missing"); ok {
+		t.Fatal("status returned for an unknown job")
+	}
+}
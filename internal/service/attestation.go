@@ -0,0 +1,203 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"time"
+
+	"plus/internal/attest"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+// SetAttestationVerifier 设置验证来源证明签名的公钥，requireVerified 时拒绝没有有效签名的证明
+func (s *RepoService) SetAttestationVerifier(v *attest.Verifier, requireVerified bool) {
+	s.attestKeys = v
+	s.requireVerified = requireVerified
+}
+
+// readAttestations 读取仓库的全部来源证明和签名，调用方持有锁
+func readAttestations(ctx context.Context, sr repo.StateRepo, repoName string) (*types.RepoAttestations, error) {
+	saved := &types.RepoAttestations{}
+	if err := loadState(ctx, sr, repoName, repo.AttestationsFile, saved); err != nil {
+		return nil, err
+	}
+	if saved.Attestations == nil {
+		saved.Attestations = make(map[string][]types.Attestation)
+	}
+	if saved.Signatures == nil {
+		saved.Signatures = make(map[string][]types.Signature)
+	}
+	return saved, nil
+}
+
+// writeAttestations 替换仓库的来源证明文件，调用方持有锁
+func writeAttestations(ctx context.Context, sr repo.StateRepo, repoName string, saved *types.RepoAttestations) error {
+	return saveState(ctx, sr, repoName, repo.AttestationsFile, saved)
+}
+
+// artifactSum 计算仓库中制品的 SHA256
+func (s *RepoService) artifactSum(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName, name string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	location, err := packageLocation(ctx, repoInstance, repoType, repoName, name)
+	if err != nil {
+		return "", err
+	}
+	if location == "" {
+		return "", fmt.Errorf("%s in %s: %w", name, repoName, fs.ErrNotExist)
+	}
+	// RPM 按文件名下载，源码包在 SRPMS 子仓库
+	if repoType == repo.RPM {
+		location = path.Base(location)
+	}
+	reader, err := repoInstance.DownloadPackage(ctx, repoName, location)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, reader); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// AddAttestation 保存制品 name 的来源证明。证明的对象中必须有与制品 SHA256 相同的（attest.ErrSubjectMismatch），
+// 用配置的公钥验证签名，requireVerified 时拒绝无法验证的（attest.ErrUnverified）。相同的证明只保存一次
+func (s *RepoService) AddAttestation(ctx context.Context, repoName, name string, data []byte, by string) (types.Attestation, error) {
+	repoInstance, sr, repoType, err := s.stateRepo(repoName, "attestations")
+	if err != nil {
+		return types.Attestation{}, err
+	}
+	st, err := attest.Parse(data)
+	if err != nil {
+		return types.Attestation{}, err
+	}
+	key := NoteKey(string(repoType), name)
+	sum, err := s.artifactSum(ctx, repoInstance, repoType, repoName, key)
+	if err != nil {
+		return types.Attestation{}, err
+	}
+	if !st.Covers(sum) {
+		return types.Attestation{}, fmt.Errorf("%w: %s has sha256 %s", attest.ErrSubjectMismatch, key, sum)
+	}
+	keyName, verified := s.attestKeys.Verify(st)
+	if !verified && s.requireVerified {
+		return types.Attestation{}, fmt.Errorf("%w: no configured key verifies a signature", attest.ErrUnverified)
+	}
+
+	envelope := bytes.TrimSpace(data)
+	id := sha256.Sum256(envelope)
+	a := types.Attestation{
+		ID:            hex.EncodeToString(id[:]),
+		PredicateType: st.PredicateType,
+		Subjects:      st.SubjectNames(),
+		Digest:        sum,
+		Verified:      verified,
+		Key:           keyName,
+		By:            by,
+		Created:       time.Now().UTC().Format(time.RFC3339),
+		Envelope:      envelope,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved, err := readAttestations(ctx, sr, repoName)
+	if err != nil {
+		return types.Attestation{}, err
+	}
+	list := saved.Attestations[key]
+	for i := range list {
+		if list[i].ID == a.ID {
+			// 已有的证明只更新验证结果
+			list[i].Verified, list[i].Key = a.Verified, a.Key
+			a = list[i]
+			return a, writeAttestations(ctx, sr, repoName, saved)
+		}
+	}
+	saved.Attestations[key] = append(list, a)
+	return a, writeAttestations(ctx, sr, repoName, saved)
+}
+
+// Attestations 返回制品 name 的来源证明，按上传顺序
+func (s *RepoService) Attestations(ctx context.Context, repoName, name string) ([]types.Attestation, error) {
+	_, sr, repoType, err := s.stateRepo(repoName, "attestations")
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	saved, err := readAttestations(ctx, sr, repoName)
+	if err != nil {
+		return nil, err
+	}
+	return saved.Attestations[NoteKey(string(repoType), name)], nil
+}
+
+// DeleteAttestation 删除制品 name 的一个来源证明，不存在时返回 false
+func (s *RepoService) DeleteAttestation(ctx context.Context, repoName, name, id string) (bool, error) {
+	_, sr, repoType, err := s.stateRepo(repoName, "attestations")
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved, err := readAttestations(ctx, sr, repoName)
+	if err != nil {
+		return false, err
+	}
+	key := NoteKey(string(repoType), name)
+	list := saved.Attestations[key]
+	for i := range list {
+		if list[i].ID != id {
+			continue
+		}
+		list = append(list[:i], list[i+1:]...)
+		if len(list) == 0 {
+			delete(saved.Attestations, key)
+		} else {
+			saved.Attestations[key] = list
+		}
+		return true, writeAttestations(ctx, sr, repoName, saved)
+	}
+	return false, nil
+}
+
+// dropAttestations 删除已删除的包的来源证明和签名，调用方持有锁。失败时只记录日志
+func (s *RepoService) dropAttestations(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName string, names []string) {
+	sr, ok := repoInstance.(repo.StateRepo)
+	if !ok {
+		return
+	}
+	saved, err := readAttestations(ctx, sr, repoName)
+	if err != nil {
+		log.Logger.Warnf("Failed to read attestations of %s: %v", repoName, err)
+		return
+	}
+	changed := false
+	for _, name := range names {
+		key := NoteKey(string(repoType), name)
+		if _, ok := saved.Attestations[key]; ok {
+			delete(saved.Attestations, key)
+			changed = true
+		}
+		if _, ok := saved.Signatures[key]; ok {
+			delete(saved.Signatures, key)
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	if err := writeAttestations(ctx, sr, repoName, saved); err != nil {
+		log.Logger.Warnf("Failed to remove attestations of deleted packages from %s: %v", repoName, err)
+	}
+}
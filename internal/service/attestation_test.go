@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/attest"
+	"plus/internal/config"
+	"plus/internal/filepolicy"
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+func TestAttestations(t *testing.T) {
+	s, _ := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "ci", "files"); err != nil {
+		t.Fatal(err)
+	}
+	artifact := "app-1.0.tar.gz contents"
+	if err := s.UploadPackage(ctx, "ci", "app-1.0.tar.gz", strings.NewReader(artifact)); err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	keyFile := filepath.Join(t.TempDir(), "ci.pub")
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644)
+	v, err := attest.NewVerifier([]config.AttestationKeyConfig{{Name: "ci", PublicKey: keyFile}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetAttestationVerifier(v, false)
+
+	sum := fmt.Sprintf("%x", sha256.Sum256([]byte(artifact)))
+	signed := testAttestation(sum, key)
+	a, err := s.AddAttestation(ctx, "ci", "/app-1.0.tar.gz", signed, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Verified || a.Key != "ci" || a.Digest != sum || a.By != "alice" || a.PredicateType != "https://slsa.dev/provenance/v1" {
+		t.Errorf("attestation = %+v", a)
+	}
+	// 相同的证明只保存一次
+	if again, err := s.AddAttestation(ctx, "ci", "app-1.0.tar.gz", signed, "bob"); err != nil || again.ID != a.ID || again.By != "alice" {
+		t.Errorf("duplicate = %+v, %v", again, err)
+	}
+
+	// 没有签名的证明在不要求验证时也保存
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	unsigned := testAttestation(sum, other)
+	if u, err := s.AddAttestation(ctx, "ci", "app-1.0.tar.gz", unsigned, "bob"); err != nil || u.Verified {
+		t.Errorf("unverified = %+v, %v", u, err)
+	}
+	list, err := s.Attestations(ctx, "ci", "app-1.0.tar.gz")
+	if err != nil || len(list) != 2 || list[0].ID != a.ID || string(list[0].Envelope) != string(signed) {
+		t.Fatalf("Attestations = %d, %v", len(list), err)
+	}
+
+	s.SetAttestationVerifier(v, true)
+	if _, err := s.AddAttestation(ctx, "ci", "app-1.0.tar.gz", testAttestation(sum, other), "bob"); !errors.Is(err, attest.ErrUnverified) {
+		t.Errorf("require verified: err = %v", err)
+	}
+	if _, err := s.AddAttestation(ctx, "ci", "app-1.0.tar.gz", testAttestation(fmt.Sprintf("%x", sha256.Sum256(nil)), key), "bob"); !errors.Is(err, attest.ErrSubjectMismatch) {
+		t.Errorf("other artifact: err = %v", err)
+	}
+	if _, err := s.AddAttestation(ctx, "ci", "missing.tar.gz", signed, "bob"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing artifact: err = %v", err)
+	}
+
+	// 证明文件不是仓库中的文件
+	listed, err := s.ListFiles(ctx, "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range listed {
+		if f.Path == repo.AttestationsFile {
+			t.Errorf("ListFiles returned the attestations file")
+		}
+	}
+
+	if ok, err := s.DeleteAttestation(ctx, "ci", "app-1.0.tar.gz", list[1].ID); !ok || err != nil {
+		t.Errorf("DeleteAttestation = %v, %v", ok, err)
+	}
+	if ok, _ := s.DeleteAttestation(ctx, "ci", "app-1.0.tar.gz", list[1].ID); ok {
+		t.Error("deleted twice")
+	}
+
+	// 制品过期后证明一起删除
+	now := time.Now()
+	if err := s.SetExpiry(ctx, "ci", "app-1.0.tar.gz", now.Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ExpireArtifacts(ctx, "ci", now); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := s.Attestations(ctx, "ci", "app-1.0.tar.gz"); err != nil || len(list) != 0 {
+		t.Errorf("after expiry = %v, %v", list, err)
+	}
+}
+
+// testAttestation 生成用 key 签名的 SLSA 来源证明，对象的 SHA256 为 sum
+func testAttestation(sum string, key *ecdsa.PrivateKey) []byte {
+	const payloadType = "application/vnd.in-toto+json"
+	statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"app-1.0.tar.gz","digest":{"sha256":"%s"}}],"predicateType":"https://slsa.dev/provenance/v1","predicate":{}}`, sum)
+	message := fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(statement), statement)
+	digest := sha256.Sum256([]byte(message))
+	sig, _ := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	env := types.DSSEEnvelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString([]byte(statement)),
+		Signatures:  []types.DSSESignature{{Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}
+	data, _ := env.MarshalJSON()
+	return data
+}
+
+func TestAttestationsFileNotUploadable(t *testing.T) {
+	s, _ := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "ci", "files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "ci", "app-1.0.tar.gz", strings.NewReader("app")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 伪造的已验证证明和签名不能通过上传写入
+	forged := `{"attestations":{"app-1.0.tar.gz":[{"id":"x","verified":true,"key":"ci"}]},` +
+		`"signatures":{"app-1.0.tar.gz":[{"id":"y","verified":true,"key":"ci"}]}}`
+	var v *filepolicy.Violation
+	if err := s.UploadPackage(ctx, "ci", ".attestations.json", strings.NewReader(forged)); !errors.As(err, &v) {
+		t.Errorf("upload: err = %v, want *filepolicy.Violation", err)
+	}
+	if _, err := s.StagePackageAs(ctx, "ci", ".attestations.json", strings.NewReader(forged)); !errors.As(err, &v) {
+		t.Errorf("stage: err = %v, want *filepolicy.Violation", err)
+	}
+	if list, err := s.Attestations(ctx, "ci", "app-1.0.tar.gz"); err != nil || len(list) != 0 {
+		t.Errorf("Attestations = %+v, %v", list, err)
+	}
+	if list, err := s.Signatures(ctx, "ci", "app-1.0.tar.gz"); err != nil || len(list) != 0 {
+		t.Errorf("Signatures = %+v, %v", list, err)
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+)
+
+// SetBackends 设置按仓库类型返回存储后端的函数，例如 RepoFactory.Storage
+func (s *RepoService) SetBackends(backends func(repo.RepoType) storage.Storage) {
+	s.backends = backends
+}
+
+// backend 返回仓库使用的存储后端
+func (s *RepoService) backend(repoName string) (storage.Storage, error) {
+	_, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, err
+	}
+	var backend storage.Storage
+	if s.backends != nil {
+		backend = s.backends(repoType)
+	}
+	if backend == nil {
+		return nil, fmt.Errorf("no storage backend for %s repositories", repoType)
+	}
+	return backend, nil
+}
+
+// StatFile 在仓库的存储后端中查找文件，path 为相对存储根目录的路径
+func (s *RepoService) StatFile(ctx context.Context, repoName, path string) (storage.FileInfo, error) {
+	backend, err := s.backend(repoName)
+	if err != nil {
+		return storage.FileInfo{}, err
+	}
+	return backend.Stat(ctx, path)
+}
+
+// PresignFile 返回仓库存储后端中 path 的预签名下载地址，后端不支持时返回 storage.ErrNotPresignable
+func (s *RepoService) PresignFile(ctx context.Context, repoName, path string, expires time.Duration) (string, error) {
+	backend, err := s.backend(repoName)
+	if err != nil {
+		return "", err
+	}
+	return storage.Presign(ctx, backend, path, expires)
+}
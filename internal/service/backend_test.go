@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/pkg/repo"
+	"plus/pkg/repo/files"
+	"plus/pkg/storage"
+)
+
+type prefixPresigner string
+
+func (p prefixPresigner) PresignGet(_ context.Context, path string, _ time.Duration) (string, error) {
+	return string(p) + path, nil
+}
+
+func TestPresignFile(t *testing.T) {
+	store := newTestStorage(t, t.TempDir())
+	backend := storage.WithPresigner(store, prefixPresigner("https://bucket/"))
+	s := NewRepoService(files.NewFilesRepo(backend))
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "artifacts", "files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "artifacts", "a.tar.gz", strings.NewReader("abc")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 没有设置存储后端时不能查找和签名
+	if _, err := s.StatFile(ctx, "artifacts", "artifacts/a.tar.gz"); err == nil {
+		t.Fatal("stat without backends succeeded")
+	}
+	s.SetBackends(func(rt repo.RepoType) storage.Storage {
+		if rt == repo.Files {
+			return backend
+		}
+		return nil
+	})
+	info, err := s.StatFile(ctx, "artifacts", "artifacts/a.tar.gz")
+	if err != nil || info.Size != 3 {
+		t.Fatalf("stat = %+v, %v", info, err)
+	}
+	url, err := s.PresignFile(ctx, "artifacts", "artifacts/a.tar.gz", time.Minute)
+	if err != nil || url != "https://bucket/artifacts/a.tar.gz" {
+		t.Fatalf("presign = %q, %v", url, err)
+	}
+
+	s.SetBackends(func(repo.RepoType) storage.Storage { return store })
+	if _, err := s.PresignFile(ctx, "artifacts", "artifacts/a.tar.gz", time.Minute); !errors.Is(err, storage.ErrNotPresignable) {
+		t.Fatalf("expected ErrNotPresignable, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"plus/internal/journal"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/worker"
+)
+
+// SetWorkerPool 设置上传后台任务的工作池；未设置时校验和按需计算，元数据刷新在请求中同步执行
+func (s *RepoService) SetWorkerPool(p *worker.Pool) {
+	s.pool = p
+}
+
+// WorkerMetrics 返回工作池统计，未设置工作池时返回 nil
+func (s *RepoService) WorkerMetrics() *types.WorkerMetrics {
+	if s.pool == nil {
+		return nil
+	}
+	return s.pool.Metrics()
+}
+
+// ScheduleRefresh 在工作池中刷新仓库元数据；同一仓库尚未开始的刷新只保留一个
+// 排队的刷新记录在操作日志中，刷新前崩溃时启动后重新刷新
+func (s *RepoService) ScheduleRefresh(ctx context.Context, repoName string) error {
+	if s.pool == nil {
+		done, err := s.beginOp(types.JournalEntry{Op: journal.OpRefresh, Repo: repoName})
+		if err != nil {
+			return err
+		}
+		defer done()
+		return s.RefreshMetadata(ctx, repoName)
+	}
+
+	s.bgMu.Lock()
+	if s.pendingRefresh[repoName] {
+		s.bgMu.Unlock()
+		return nil
+	}
+	s.pendingRefresh[repoName] = true
+	s.bgMu.Unlock()
+
+	done, err := s.beginOp(types.JournalEntry{Op: journal.OpRefresh, Repo: repoName})
+	if err == nil {
+		err = s.pool.Submit("refresh "+repoName, func(ctx context.Context) error {
+			defer done()
+			// 开始执行后再上传的包需要新的一次刷新
+			s.bgMu.Lock()
+			delete(s.pendingRefresh, repoName)
+			s.bgMu.Unlock()
+			return s.RefreshMetadata(ctx, repoName)
+		})
+		if err != nil {
+			done()
+		}
+	}
+	if err != nil {
+		s.bgMu.Lock()
+		delete(s.pendingRefresh, repoName)
+		s.bgMu.Unlock()
+	}
+	return err
+}
+
+// afterUpload 上传完成后在后台预先计算校验和，队列已满时留到查询时计算
+func (s *RepoService) afterUpload(repoName, filename string) {
+	key := checksumKey(repoName, filename)
+	s.bgMu.Lock()
+	delete(s.checksums, key)
+	s.bgMu.Unlock()
+
+	if s.pool == nil {
+		return
+	}
+	err := s.pool.Submit("checksum "+key, func(ctx context.Context) error {
+		_, err := s.GetPackageChecksum(ctx, repoName, filename)
+		return err
+	})
+	if err != nil {
+		log.Logger.Debugf("Checksum of %s deferred: %v", key, err)
+	}
+}
+
+func (s *RepoService) cachedChecksum(repoName, filename string) (string, bool) {
+	s.bgMu.Lock()
+	defer s.bgMu.Unlock()
+	sum, ok := s.checksums[checksumKey(repoName, filename)]
+	return sum, ok
+}
+
+func (s *RepoService) storeChecksum(repoName, filename, sum string) {
+	s.bgMu.Lock()
+	defer s.bgMu.Unlock()
+	s.checksums[checksumKey(repoName, filename)] = sum
+	if s.index != nil {
+		s.index.SetChecksum(repoName, filename, sum)
+	}
+}
+
+// forgetChecksums 删除仓库下的全部校验和缓存
+func (s *RepoService) forgetChecksums(repoName string) {
+	prefix := repoName + "/"
+	s.bgMu.Lock()
+	defer s.bgMu.Unlock()
+	for key := range s.checksums {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.checksums, key)
+		}
+	}
+}
+
+func checksumKey(repoName, filename string) string {
+	return path.Join(repoName, filename)
+}
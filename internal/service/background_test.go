@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/worker"
+)
+
+func TestUploadChecksumInBackground(t *testing.T) {
+	s, _ := newFilesService(t)
+	pool := worker.New(2, 8)
+	defer pool.Close()
+	s.SetWorkerPool(pool)
+	var published []string
+	s.OnPublish(func(repoName string, files []string) {
+		published = append(published, repoName+"/"+strings.Join(files, ","))
+	})
+
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "artifacts", "files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "artifacts", "a.tar.gz", strings.NewReader("v1")); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for s.WorkerMetrics().Completed < 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("checksum job not run: %+v", s.WorkerMetrics())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if sum, ok := s.cachedChecksum("artifacts", "a.tar.gz"); !ok || sum != fmt.Sprintf("%x", sha256.Sum256([]byte("v1"))) {
+		t.Errorf("cached checksum = %q, %v", sum, ok)
+	}
+
+	// 覆盖上传后旧的校验和失效
+	pool.Close()
+	if err := s.UploadPackage(ctx, "artifacts", "a.tar.gz", strings.NewReader("v2")); err != nil {
+		t.Fatal(err)
+	}
+	sum, err := s.GetPackageChecksum(ctx, "artifacts", "a.tar.gz")
+	if err != nil || sum != fmt.Sprintf("%x", sha256.Sum256([]byte("v2"))) {
+		t.Errorf("checksum after overwrite = %q, %v", sum, err)
+	}
+	if len(published) != 2 || published[0] != "artifacts/a.tar.gz" {
+		t.Errorf("published = %v", published)
+	}
+}
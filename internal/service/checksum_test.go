@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/pkg/repo/deb"
+)
+
+func TestDEBChecksum(t *testing.T) {
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "debs")
+	if err := os.MkdirAll(filepath.Join(repoDir, "pool"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string]string{"pool/indexed.deb": "rebuilt", "fresh.deb": "fresh"} {
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Packages 中记录的校验和优先于文件内容
+	packages := fmt.Sprintf("Package: indexed\nVersion: 1.0\nArchitecture: all\nFilename: ./pool/indexed.deb\nSHA256: %x\n", sha256.Sum256([]byte("original")))
+	if err := os.WriteFile(filepath.Join(repoDir, "Packages"), []byte(packages), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := newTestStorage(t, root)
+	s := NewRepoService(deb.NewDEBRepo(store))
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "debs", "deb"); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string]string{"pool/indexed.deb": "original", "fresh.deb": "fresh"} {
+		sum, err := s.GetPackageChecksum(ctx, "debs", name)
+		if want := fmt.Sprintf("%x", sha256.Sum256([]byte(data))); err != nil || sum != want {
+			t.Errorf("%s = %q, %v, want %s", name, sum, err, want)
+		}
+	}
+	if _, err := s.GetPackageChecksum(ctx, "debs", "missing.deb"); err == nil {
+		t.Error("checksum of a missing package")
+	}
+
+	// 上传时计算校验和，刷新元数据前即可查询
+	if err := s.UploadPackage(ctx, "debs", "new.deb", strings.NewReader("new")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "new.deb"), []byte("changed on disk"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if sum, err := s.GetPackageChecksum(ctx, "debs", "new.deb"); err != nil || sum != fmt.Sprintf("%x", sha256.Sum256([]byte("new"))) {
+		t.Errorf("uploaded package = %q, %v", sum, err)
+	}
+}
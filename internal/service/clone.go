@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"plus/internal/journal"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+// CloneRepo 创建与 src 类型相同的仓库 dst，在存储内复制 src 的包和元数据（本地存储使用硬链接，
+// S3 为服务端复制），返回复制的文件数和总大小。复制失败时删除已创建的 dst
+func (s *RepoService) CloneRepo(ctx context.Context, src, dst string) (int, int64, error) {
+	repoInstance, repoType, err := s.getRepoInstance(src)
+	if err != nil {
+		return 0, 0, err
+	}
+	copier, ok := repoInstance.(repo.CopyRepo)
+	if !ok {
+		return 0, 0, fmt.Errorf("repository type %s does not support cloning", repoType)
+	}
+
+	files, err := s.ListFiles(ctx, src)
+	if err != nil {
+		return 0, 0, err
+	}
+	// 崩溃后重放时删除未复制完的 dst
+	done, err := s.beginOp(types.JournalEntry{Op: journal.OpClone, Repo: dst, Source: src})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer done()
+	if err := s.CreateRepo(ctx, dst, string(repoType)); err != nil {
+		return 0, 0, err
+	}
+
+	var size int64
+	s.mu.RLock()
+	for _, f := range files {
+		if err = copier.CopyFile(ctx, src, dst, f.Path); err != nil {
+			err = fmt.Errorf("failed to copy %s: %w", f.Path, err)
+			break
+		}
+		size += f.Size
+	}
+	s.mu.RUnlock()
+	if err != nil {
+		if derr := s.DeleteRepo(ctx, dst); derr != nil {
+			log.Logger.Warnf("Failed to remove incomplete clone %s: %v", dst, derr)
+		}
+		return 0, 0, err
+	}
+
+	// 元数据已复制，更新索引和依赖索引
+	s.mu.Lock()
+	s.afterRefresh(ctx, repoInstance, repoType, dst)
+	s.mu.Unlock()
+
+	log.Logger.Infof("Cloned %s repository %s to %s: %d files, %d bytes", repoType, src, dst, len(files), size)
+	return len(files), size, nil
+}
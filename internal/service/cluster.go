@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+
+	"plus/internal/cluster"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+// SetCluster 设置多实例共享的仓库注册表和锁，需在服务启动前设置
+func (s *RepoService) SetCluster(c *cluster.Cluster) {
+	s.cluster = c
+}
+
+// ClusterMetrics 返回分布式锁统计，单实例部署时返回 nil
+func (s *RepoService) ClusterMetrics() *types.ClusterMetrics {
+	if s.cluster == nil {
+		return nil
+	}
+	return s.cluster.Metrics()
+}
+
+// LoadRepoTypes 从共享注册表加载其他实例创建的仓库类型
+func (s *RepoService) LoadRepoTypes(ctx context.Context) error {
+	if s.cluster == nil {
+		return nil
+	}
+	registered, err := s.cluster.RepoTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for repoName, typ := range registered {
+		repoType := repo.RepoType(typ)
+		if _, ok := s.repos[repoType]; !ok {
+			log.Logger.Warnf("Registered repository %s has unknown type %s", repoName, typ)
+			continue
+		}
+		if _, exists := s.repoTypes[repoName]; !exists {
+			s.repoTypes[repoName] = repoType
+		}
+	}
+	log.Logger.Debugf("Loaded %d repositories from the cluster registry", len(registered))
+	return nil
+}
+
+// registerRepo 把仓库类型写入共享注册表。写入失败时其他实例仍可从存储推断类型，只记录警告
+func (s *RepoService) registerRepo(ctx context.Context, repoName string, repoType repo.RepoType) {
+	if s.cluster == nil {
+		return
+	}
+	if err := s.cluster.RegisterRepo(ctx, repoName, string(repoType)); err != nil {
+		log.Logger.Warnf("Failed to register %s in the cluster registry: %v", repoName, err)
+	}
+}
+
+func (s *RepoService) unregisterRepo(ctx context.Context, repoName string) {
+	if s.cluster == nil {
+		return
+	}
+	if err := s.cluster.UnregisterRepo(ctx, repoName); err != nil {
+		log.Logger.Warnf("Failed to remove %s from the cluster registry: %v", repoName, err)
+	}
+}
+
+func (s *RepoService) registeredRepoType(repoName string) (repo.RepoType, bool) {
+	if s.cluster == nil {
+		return "", false
+	}
+	typ, ok, err := s.cluster.RepoType(context.Background(), repoName)
+	if err != nil {
+		log.Logger.Debugf("Cluster registry lookup of %s failed: %v", repoName, err)
+		return "", false
+	}
+	if _, known := s.repos[repo.RepoType(typ)]; !ok || !known {
+		return "", false
+	}
+	return repo.RepoType(typ), true
+}
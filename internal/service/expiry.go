@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"plus/internal/journal"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+// readExpiry 读取仓库中制品的过期时间，调用方持有锁
+func readExpiry(ctx context.Context, sr repo.StateRepo, repoName string) (map[string]time.Time, error) {
+	var saved types.RepoExpiry
+	if err := loadState(ctx, sr, repoName, repo.ExpiryFile, &saved); err != nil {
+		return nil, err
+	}
+	expires := make(map[string]time.Time, len(saved.Expires))
+	for name, value := range saved.Expires {
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("expiry of %s in %s is corrupt: %w", name, repoName, err)
+		}
+		expires[name] = at
+	}
+	return expires, nil
+}
+
+// writeExpiry 替换仓库的过期时间文件，调用方持有锁
+func writeExpiry(ctx context.Context, sr repo.StateRepo, repoName string, expires map[string]time.Time) error {
+	saved := types.RepoExpiry{Expires: make(map[string]string, len(expires))}
+	for name, at := range expires {
+		saved.Expires[name] = at.UTC().Format(time.RFC3339)
+	}
+	return saveState(ctx, sr, repoName, repo.ExpiryFile, &saved)
+}
+
+// SetExpiry 设置文件 name 到期删除的时间，at 为零值时取消。不检查文件是否存在，
+// 暂存或等待批准的上传发布后同样按时删除
+func (s *RepoService) SetExpiry(ctx context.Context, repoName, name string, at time.Time) error {
+	_, sr, repoType, err := s.stateRepo(repoName, "artifact expiry")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expires, err := readExpiry(ctx, sr, repoName)
+	if err != nil {
+		return err
+	}
+	key := NoteKey(string(repoType), name)
+	if at.IsZero() {
+		if _, ok := expires[key]; !ok {
+			return nil
+		}
+		delete(expires, key)
+	} else {
+		expires[key] = at
+	}
+	return writeExpiry(ctx, sr, repoName, expires)
+}
+
+// Expiries 返回仓库中制品的过期时间，按 NoteKey 索引
+func (s *RepoService) Expiries(ctx context.Context, repoName string) (map[string]time.Time, error) {
+	_, sr, _, err := s.stateRepo(repoName, "artifact expiry")
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return readExpiry(ctx, sr, repoName)
+}
+
+// ExpireArtifacts 删除仓库中在 now 之前到期的制品并更新元数据，返回删除的文件。有保留标记的制品不删除。
+// 到期时文件已不存在（已删除，或仍在暂存区）的记录同样移除
+func (s *RepoService) ExpireArtifacts(ctx context.Context, repoName string, now time.Time) ([]string, error) {
+	repoInstance, sr, repoType, err := s.stateRepo(repoName, "artifact expiry")
+	if err != nil {
+		return nil, err
+	}
+	deleter, ok := repoInstance.(repo.DeleteFileRepo)
+	if !ok {
+		return nil, fmt.Errorf("repository type %s does not support deleting files", repoType)
+	}
+
+	s.mu.Lock()
+	expires, err := readExpiry(ctx, sr, repoName)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	// 有保留标记的制品在解除标记前不删除，到期时间保留，解除后再删除
+	held, err := heldArtifacts(ctx, repoInstance, repoName)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	var due, locations []string
+	for name, at := range expires {
+		if _, ok := held[name]; ok || at.After(now) {
+			continue
+		}
+		location, err := packageLocation(ctx, repoInstance, repoType, repoName, name)
+		if err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		due = append(due, name)
+		if location != "" {
+			locations = append(locations, location)
+		}
+	}
+	if len(due) == 0 {
+		s.mu.Unlock()
+		return nil, nil
+	}
+	sort.Strings(locations)
+
+	var removed []string
+	done, err := s.beginOp(types.JournalEntry{Op: journal.OpExpire, Repo: repoName, Files: locations})
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	defer done()
+	for _, location := range locations {
+		if err = deleter.DeleteFile(ctx, repoName, location); err != nil {
+			err = fmt.Errorf("delete %s: %w", location, err)
+			break
+		}
+		removed = append(removed, location)
+	}
+	// 删除失败时保留该文件及之后文件的记录，下次再删除
+	if err == nil {
+		for _, name := range due {
+			delete(expires, name)
+		}
+	} else {
+		for _, location := range removed {
+			delete(expires, NoteKey(string(repoType), location))
+		}
+	}
+	if writeErr := writeExpiry(ctx, sr, repoName, expires); writeErr != nil && err == nil {
+		err = writeErr
+	}
+	s.dropNotes(ctx, repoInstance, repoType, repoName, removed)
+	s.dropAttestations(ctx, repoInstance, repoType, repoName, removed)
+	if repoType == repo.Files {
+		s.updateSums(ctx, repoInstance, repoName, removed)
+	}
+	s.mu.Unlock()
+
+	if len(removed) > 0 {
+		log.Logger.Infof("Expired %d artifacts from %s: %v", len(removed), repoName, removed)
+		if repoType != repo.Files {
+			if refreshErr := s.RefreshMetadata(ctx, repoName); refreshErr != nil && err == nil {
+				err = refreshErr
+			}
+		}
+	}
+	return removed, err
+}
+
+// ExpireAll 删除所有仓库中已到期的制品，由定时任务调用
+func (s *RepoService) ExpireAll(ctx context.Context) error {
+	repos, err := s.ListRepos(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, repoName := range repos {
+		repoInstance, _, err := s.getRepoInstance(repoName)
+		if err != nil {
+			continue
+		}
+		if _, ok := repoInstance.(repo.StateRepo); !ok {
+			continue
+		}
+		if _, err := s.ExpireArtifacts(ctx, repoName, time.Now()); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", repoName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
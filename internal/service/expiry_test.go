@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/pkg/repo"
+)
+
+func TestExpireArtifacts(t *testing.T) {
+	s, root := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "ci", "files"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"build-1.tar.gz", "build-2.tar.gz", "release.tar.gz"} {
+		if err := s.UploadPackage(ctx, "ci", name, strings.NewReader(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for name, at := range map[string]time.Time{
+		"build-1.tar.gz": now.Add(-time.Minute),
+		"build-2.tar.gz": now.Add(time.Hour),
+		"missing.tar.gz": now.Add(-time.Hour),
+	} {
+		if err := s.SetExpiry(ctx, "ci", name, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 过期时间不是仓库中的文件
+	list, err := s.ListFiles(ctx, "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range list {
+		if f.Path == repo.ExpiryFile {
+			t.Errorf("ListFiles returned the expiry file")
+		}
+	}
+
+	removed, err := s.ExpireArtifacts(ctx, "ci", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"build-1.tar.gz"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+	for name, want := range map[string]bool{"build-1.tar.gz": false, "build-2.tar.gz": true, "release.tar.gz": true} {
+		if _, err := os.Stat(filepath.Join(root, "ci", name)); (err == nil) != want {
+			t.Errorf("%s exists = %v, want %v", name, err == nil, want)
+		}
+	}
+	expires, err := s.Expiries(ctx, "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]time.Time{"build-2.tar.gz": now.Add(time.Hour)}; !reflect.DeepEqual(expires, want) {
+		t.Errorf("Expiries = %v, want %v", expires, want)
+	}
+
+	// 取消后不再删除
+	if err := s.SetExpiry(ctx, "ci", "build-2.tar.gz", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err := s.ExpireArtifacts(ctx, "ci", now.Add(2*time.Hour)); err != nil || len(removed) != 0 {
+		t.Errorf("ExpireArtifacts after cancel = %v, %v", removed, err)
+	}
+}
@@ -0,0 +1,20 @@
+package service
+
+import "plus/internal/filepolicy"
+
+// SetFilenamePolicy 设置每个仓库的文件名规则，不符合规则的上传返回 *filepolicy.Violation
+func (s *RepoService) SetFilenamePolicy(policy func(repoName string) *filepolicy.Policy) {
+	s.filenames = policy
+}
+
+// checkFilename 按仓库的文件名规则检查上传的文件名，在覆盖策略改名之前检查。
+// 以点开头的路径段总是拒绝，避免上传覆盖仓库的状态文件
+func (s *RepoService) checkFilename(repoName, filename string) error {
+	if err := filepolicy.CheckHidden(filename); err != nil {
+		return err
+	}
+	if s.filenames == nil {
+		return nil
+	}
+	return s.filenames(repoName).Check(filename)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"plus/internal/filepolicy"
+)
+
+func TestFilenamePolicy(t *testing.T) {
+	s, _ := newFilesService(t)
+	policy, err := filepolicy.New([]string{`^[a-z0-9.-]+\.tar\.gz$`}, nil, "lowercase tarballs only")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetFilenamePolicy(func(repoName string) *filepolicy.Policy {
+		if repoName == "releases" {
+			return policy
+		}
+		return nil
+	})
+	s.SetOverwritePolicy(func(string) string { return OverwriteVersion })
+
+	ctx := context.Background()
+	for _, name := range []string{"releases", "scratch"} {
+		if err := s.SetRepoType(ctx, name, "files"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var v *filepolicy.Violation
+	if _, err := s.UploadPackageAs(ctx, "releases", "App-1.0.zip", strings.NewReader("x")); !errors.As(err, &v) {
+		t.Fatalf("upload = %v", err)
+	}
+	if _, err := s.StagePackageAs(ctx, "releases", "App-1.0.zip", strings.NewReader("x")); !errors.As(err, &v) {
+		t.Fatalf("stage = %v", err)
+	}
+	if _, err := s.UploadPackageAs(ctx, "scratch", "App-1.0.zip", strings.NewReader("x")); err != nil {
+		t.Fatalf("repository without rules: %v", err)
+	}
+
+	// 规则检查请求的文件名，auto-version 改名后的文件名不再检查
+	for _, want := range []string{"app-1.0.tar.gz", "app-1.0-1.tar.gz"} {
+		if stored, err := s.UploadPackageAs(ctx, "releases", "app-1.0.tar.gz", strings.NewReader("x")); err != nil || stored != want {
+			t.Fatalf("upload = %q, %v", stored, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"plus/internal/index"
+	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/pkg/repo"
+)
+
+// ListFiles 递归列出仓库中的文件，不包括嵌套在其中的其他仓库。
+// 已知的校验和来自上传后的计算结果和索引，未计算的为空
+func (s *RepoService) ListFiles(ctx context.Context, repoName string) ([]types.Artifact, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := repoInstance.(repo.FileListRepo)
+	if !ok {
+		return nil, fmt.Errorf("repository type %s does not support file listing", repoType)
+	}
+
+	// 嵌套仓库（如 el9 下的 el9/updates）的文件不属于本仓库
+	repos, err := s.ListRepos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var nested []string
+	for _, r := range repos {
+		if strings.HasPrefix(r, repoName+"/") {
+			nested = append(nested, strings.TrimPrefix(r, repoName+"/")+"/")
+		}
+	}
+
+	s.mu.RLock()
+	files, err := lister.ListFiles(ctx, repoName)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make(map[string]string)
+	if s.index != nil {
+		if artifacts, ok := s.index.List(repoName); ok {
+			for _, a := range artifacts {
+				if a.Checksum != "" {
+					checksums[a.Path] = a.Checksum
+				}
+			}
+		}
+	}
+
+	artifacts := make([]types.Artifact, 0, len(files))
+next:
+	for _, file := range files {
+		if repo.StateFile(file.Name) {
+			continue
+		}
+		for _, prefix := range nested {
+			if strings.HasPrefix(file.Name, prefix) {
+				continue next
+			}
+		}
+		a := types.Artifact{
+			Repo:    repoName,
+			Type:    string(repoType),
+			Path:    file.Name,
+			Size:    file.Size,
+			ModTime: utils.FormatModTime(file.ModTime),
+			Labels:  index.Labels(string(repoType), file.Name),
+		}
+		// RPM 和 DEB 的校验和按文件名记录，files 仓库按相对路径记录
+		for _, name := range []string{file.Name, path.Base(file.Name)} {
+			if sum, ok := s.cachedChecksum(repoName, name); ok {
+				a.Checksum = sum
+				break
+			}
+			if sum, ok := checksums[name]; ok {
+				a.Checksum = sum
+				break
+			}
+		}
+		artifacts = append(artifacts, a)
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Path < artifacts[j].Path })
+	return artifacts, nil
+}
+
+// OpenFile 打开 ListFiles 返回的文件，name 为相对仓库根目录的路径
+func (s *RepoService) OpenFile(ctx context.Context, repoName, name string) (io.ReadCloser, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	switch r := repoInstance.(type) {
+	case repo.RangeRepo:
+		return r.OpenFileRange(ctx, repoName, name, 0, -1)
+	case repo.DependencyRepo:
+		return r.OpenPackageFile(ctx, repoName, name)
+	}
+	return nil, fmt.Errorf("repository type %s does not support reading files", repoType)
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"plus/internal/deps"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/pkg/repo"
+)
+
+// CheckRepo 检查仓库一致性：元数据引用的包是否存在且校验和一致，以及未被引用的孤立包。
+// repair 为 true 时通过刷新元数据修复缺失/孤立问题；损坏的包需重新上传，不会自动修复
+func (s *RepoService) CheckRepo(ctx context.Context, repoName string, repair bool) (*types.FsckReport, error) {
+	start := time.Now()
+
+	report, err := s.checkRepo(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	if repair && !report.Healthy {
+		if report.Corrupt > 0 {
+			report.Status.Message = "Corrupt packages must be re-uploaded, skipping repair"
+		} else {
+			log.Logger.Infof("Repairing repository %s: refreshing metadata", repoName)
+			if err := s.RefreshMetadata(ctx, repoName); err != nil {
+				return nil, fmt.Errorf("failed to repair repository: %w", err)
+			}
+			if report, err = s.checkRepo(ctx, repoName); err != nil {
+				return nil, err
+			}
+			report.Repaired = true
+		}
+	}
+
+	report.Duration = time.Since(start).String()
+	return report, nil
+}
+
+func (s *RepoService) checkRepo(ctx context.Context, repoName string) (*types.FsckReport, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	depRepo, ok := repoInstance.(repo.DependencyRepo)
+	checker, ok2 := repoInstance.(repo.ConsistencyRepo)
+	if !ok || !ok2 {
+		return nil, fmt.Errorf("%s repository does not support consistency checks", repoType)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report := &types.FsckReport{
+		Status: types.Status{Status: "success"},
+		Repo:   repoName,
+		Type:   string(repoType),
+		Issues: []types.FsckIssue{},
+	}
+
+	referenced := make(map[string]bool)
+	pkgs, err := depRepo.PackageDeps(ctx, repoName)
+	if err != nil {
+		report.Issues = append(report.Issues, types.FsckIssue{
+			Type:    "metadata",
+			Message: err.Error(),
+		})
+	}
+	// 源码包索引中的包同样需要校验，否则会被当作未引用的文件
+	if sourceRepo, ok := depRepo.(repo.SourceRepo); ok {
+		srcPkgs, err := sourceRepo.SourcePackages(ctx, repoName)
+		if err != nil {
+			report.Issues = append(report.Issues, types.FsckIssue{
+				Type:    "metadata",
+				Message: err.Error(),
+			})
+		}
+		pkgs = append(pkgs, srcPkgs...)
+	}
+	for i := range pkgs {
+		p := &pkgs[i]
+		rel := strings.TrimPrefix(path.Clean("/"+p.Location), "/")
+		if referenced[rel] {
+			continue
+		}
+		referenced[rel] = true
+		report.Referenced++
+
+		if issue := checkPackageFile(ctx, depRepo, repoName, rel, p); issue != nil {
+			if issue.Type == "missing" {
+				report.Missing++
+			} else {
+				report.Corrupt++
+			}
+			report.Issues = append(report.Issues, *issue)
+			continue
+		}
+		report.Checked++
+	}
+
+	files, err := checker.PackageFiles(ctx, repoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list package files: %w", err)
+	}
+	for _, file := range files {
+		if !referenced[file] {
+			report.Orphans++
+			report.Issues = append(report.Issues, types.FsckIssue{
+				Type:    "orphan",
+				Path:    file,
+				Message: "package is not referenced by repository metadata",
+			})
+		}
+	}
+
+	report.Healthy = len(report.Issues) == 0
+	if !report.Healthy {
+		report.Status.Status = "unhealthy"
+	}
+	log.Logger.Debugf("Checked repository %s: %d referenced, %d ok, %d issues",
+		repoName, report.Referenced, report.Checked, len(report.Issues))
+	return report, nil
+}
+
+// checkPackageFile 校验单个包的大小与校验和，正常时返回 nil
+func checkPackageFile(ctx context.Context, depRepo repo.DependencyRepo, repoName, rel string, p *deps.Package) *types.FsckIssue {
+	reader, err := depRepo.OpenPackageFile(ctx, repoName, rel)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &types.FsckIssue{Type: "missing", Path: rel, Message: "package referenced by metadata does not exist"}
+		}
+		return &types.FsckIssue{Type: "unreadable", Path: rel, Message: err.Error()}
+	}
+	defer reader.Close()
+
+	h := utils.NewHash(p.SumType)
+	var w io.Writer = io.Discard
+	if h != nil {
+		w = h
+	}
+	size, err := io.Copy(w, reader)
+	if err != nil {
+		return &types.FsckIssue{Type: "unreadable", Path: rel, Message: err.Error()}
+	}
+
+	if p.Size > 0 && size != p.Size {
+		return &types.FsckIssue{
+			Type:     "size_mismatch",
+			Path:     rel,
+			Expected: fmt.Sprintf("%d", p.Size),
+			Actual:   fmt.Sprintf("%d", size),
+		}
+	}
+	if h != nil && p.Checksum != "" {
+		if actual := fmt.Sprintf("%x", h.Sum(nil)); !strings.EqualFold(actual, p.Checksum) {
+			return &types.FsckIssue{
+				Type:     "corrupt",
+				Path:     rel,
+				Expected: p.SumType + ":" + p.Checksum,
+				Actual:   p.SumType + ":" + actual,
+			}
+		}
+	}
+	return nil
+}
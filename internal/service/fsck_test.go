@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"plus/internal/log"
+	"plus/pkg/repo/deb"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestCheckRepo(t *testing.T) {
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "debs")
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	good := []byte("good package")
+	files := map[string][]byte{
+		"good.deb":    good,
+		"corrupt.deb": []byte("bit rot"),
+		"orphan.deb":  []byte("not indexed"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(repoDir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	packages := fmt.Sprintf(`Package: good
+Version: 1.0
+Architecture: all
+Filename: ./good.deb
+Size: %d
+SHA256: %x
+
+Package: corrupt
+Version: 1.0
+Architecture: all
+Filename: ./corrupt.deb
+Size: 7
+SHA256: %x
+
+Package: gone
+Version: 1.0
+Architecture: all
+Filename: ./gone.deb
+Size: 4
+SHA256: %x
+`, len(good), sha256.Sum256(good), sha256.Sum256([]byte("original")), sha256.Sum256([]byte("gone")))
+	if err := os.WriteFile(filepath.Join(repoDir, "Packages"), []byte(packages), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := newTestStorage(t, root)
+	s := NewRepoService(deb.NewDEBRepo(store))
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "debs", "deb"); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := s.CheckRepo(ctx, "debs", false)
+	if err != nil {
+		t.Fatalf("CheckRepo failed: %v", err)
+	}
+	if report.Healthy || report.Referenced != 3 || report.Checked != 1 ||
+		report.Missing != 1 || report.Corrupt != 1 || report.Orphans != 1 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	issues := make(map[string]string)
+	for _, issue := range report.Issues {
+		issues[issue.Path] = issue.Type
+	}
+	if issues["corrupt.deb"] != "corrupt" || issues["gone.deb"] != "missing" || issues["orphan.deb"] != "orphan" {
+		t.Errorf("Unexpected issues: %+v", report.Issues)
+	}
+
+	// 存在损坏的包时不自动修复
+	report, err = s.CheckRepo(ctx, "debs", true)
+	if err != nil {
+		t.Fatalf("CheckRepo with repair failed: %v", err)
+	}
+	if report.Repaired {
+		t.Errorf("Repair must be skipped when packages are corrupt")
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"plus/internal/deps"
+	"plus/internal/facet"
+	"plus/internal/fulltext"
+	"plus/internal/log"
+	"plus/pkg/repo"
+)
+
+// indexText 用元数据中的包替换仓库的全文索引
+func (s *RepoService) indexText(depRepo repo.DependencyRepo, repoName string, pkgs []deps.Package) {
+	r, ok := depRepo.(repo.Repo)
+	if !ok {
+		return
+	}
+	docs := make([]fulltext.Document, 0, len(pkgs))
+	for _, p := range pkgs {
+		docs = append(docs, fulltext.Document{
+			Name:        p.Name,
+			Version:     p.Version,
+			Arch:        p.Arch,
+			Path:        p.Location,
+			Summary:     p.Summary,
+			Description: p.Description,
+			License:     p.License,
+		})
+	}
+	s.text.Replace(repoName, string(r.Type()), docs)
+}
+
+// SearchPackages 按名称、摘要和描述全文搜索 rpm、deb 仓库中的包，counts 不为空时统计全部结果的
+// repo、type、arch、license。启动后尚未刷新过的仓库在第一次搜索时读取元数据建立索引
+func (s *RepoService) SearchPackages(ctx context.Context, q fulltext.Query, limit int, counts *facet.Counter) ([]fulltext.Hit, bool, error) {
+	repos, err := s.ListRepos(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, name := range repos {
+		if q.Repo != "" && name != q.Repo && !strings.HasPrefix(name, q.Repo+"/") {
+			continue
+		}
+		repoType, err := s.GetRepoType(ctx, name)
+		if err != nil || (repoType != string(repo.RPM) && repoType != string(repo.DEB)) {
+			continue
+		}
+		if q.Type != "" && repoType != q.Type {
+			continue
+		}
+		if s.text.Has(name) {
+			continue
+		}
+		depRepo, _, err := s.dependencyRepo(name)
+		if err != nil {
+			continue
+		}
+		s.mu.RLock()
+		_, err = s.buildDependencyIndex(ctx, depRepo, repo.RepoType(repoType), name)
+		s.mu.RUnlock()
+		if err != nil {
+			// 没有元数据的仓库记为空，刷新元数据后重建
+			log.Logger.Debugf("Full-text index of %s is empty: %v", name, err)
+			s.text.Replace(name, repoType, nil)
+		}
+	}
+	if counts == nil {
+		hits, truncated := s.text.Search(q, limit)
+		return hits, truncated, nil
+	}
+	hits, _ := s.text.Search(q, 0)
+	for _, hit := range hits {
+		counts.Add("repo", hit.Repo)
+		counts.Add("type", hit.Type)
+		counts.Add("arch", hit.Arch)
+		counts.Add("license", hit.License)
+	}
+	if limit > 0 && len(hits) > limit {
+		return hits[:limit], true, nil
+	}
+	return hits, false, nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+// GenerationsRoot 元数据历史在存储中的根目录，仓库 el9 的一代元数据保存在 .generations/el9/.{id}，
+// 以 . 开头的目录不会与子仓库重名
+const GenerationsRoot = ".generations"
+
+// generationLayout 元数据版本号，为生成时的 UTC 时间，同一秒内的多代加 -2、-3 后缀
+const generationLayout = "20060102T150405Z"
+
+// ErrGenerationNotFound 请求的元数据版本不存在或已被清理
+var ErrGenerationNotFound = errors.New("metadata generation not found")
+
+func generationsDir(repoName string) string {
+	return path.Join(GenerationsRoot, repoName)
+}
+
+func generationRepo(repoName, id string) string {
+	return path.Join(GenerationsRoot, repoName, "."+id)
+}
+
+// SetGenerations 设置每个仓库保留的元数据历史版本数，返回 0 时不保留，需在服务启动前设置
+func (s *RepoService) SetGenerations(keep func(repoName string) int) {
+	s.generations = keep
+}
+
+func (s *RepoService) keepGenerations(repoName string) int {
+	if s.generations == nil {
+		return 0
+	}
+	return s.generations(repoName)
+}
+
+// MetadataFileName 返回仓库中相对路径 rel 对应的元数据文件名（GetMetadata 的参数），不是元数据时返回 false
+func MetadataFileName(repoType, rel string) (string, bool) {
+	return metadataName(repo.RepoType(repoType), path.Clean(rel))
+}
+
+// listGenerations 列出仓库保留的元数据版本，从新到旧
+func listGenerations(ctx context.Context, lister repo.FileListRepo, repoName string) ([]types.MetadataGeneration, error) {
+	files, err := lister.ListFiles(ctx, generationsDir(repoName))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*types.MetadataGeneration)
+	for _, f := range files {
+		dir, _, ok := strings.Cut(f.Name, "/")
+		if !ok || !strings.HasPrefix(dir, ".") {
+			// 子仓库的元数据历史
+			continue
+		}
+		id := strings.TrimPrefix(dir, ".")
+		created, err := time.Parse(generationLayout, id[:min(len(id), len(generationLayout))])
+		if err != nil {
+			continue
+		}
+		g := byID[id]
+		if g == nil {
+			g = &types.MetadataGeneration{ID: id, Created: created.Format(time.RFC3339)}
+			byID[id] = g
+		}
+		g.Files++
+		g.Size += f.Size
+	}
+	generations := make([]types.MetadataGeneration, 0, len(byID))
+	for _, g := range byID {
+		generations = append(generations, *g)
+	}
+	sort.Slice(generations, func(i, j int) bool {
+		if generations[i].Created != generations[j].Created {
+			return generations[i].Created > generations[j].Created
+		}
+		// 同一秒内的 -10 排在 -9 之前
+		if len(generations[i].ID) != len(generations[j].ID) {
+			return len(generations[i].ID) > len(generations[j].ID)
+		}
+		return generations[i].ID > generations[j].ID
+	})
+	return generations, nil
+}
+
+// resolveGeneration 按版本号查找元数据版本；不是已有的版本号时按时间（RFC3339 或版本号格式）查找当时使用的版本
+func resolveGeneration(generations []types.MetadataGeneration, want string) (string, error) {
+	want = strings.TrimPrefix(strings.TrimSpace(want), ".")
+	for _, g := range generations {
+		if g.ID == want {
+			return g.ID, nil
+		}
+	}
+	at, err := time.Parse(time.RFC3339, want)
+	if err != nil {
+		if at, err = time.Parse(generationLayout, want); err != nil {
+			return "", fmt.Errorf("%w: %s", ErrGenerationNotFound, want)
+		}
+	}
+	for _, g := range generations {
+		if created, err := time.Parse(time.RFC3339, g.Created); err == nil && !created.After(at) {
+			return g.ID, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no generation before %s", ErrGenerationNotFound, want)
+}
+
+func sameMetadata(a, b []MetadataFile) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || !bytes.Equal(a[i].Data, b[i].Data) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordGeneration 把刷新后的元数据保存为新的一代，与最新一代相同时不保存，只保留最新的 keep 代。
+// 本地存储使用硬链接，不复制文件内容。调用方持有写锁
+func (s *RepoService) recordGeneration(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName string) error {
+	keep := s.keepGenerations(repoName)
+	if keep < 1 || (repoType != repo.RPM && repoType != repo.DEB) {
+		return nil
+	}
+	lister, listOK := repoInstance.(repo.FileListRepo)
+	copier, copyOK := repoInstance.(repo.CopyRepo)
+	if !listOK || !copyOK {
+		return nil
+	}
+	current, err := readMetadata(ctx, repoInstance, repoType, repoName)
+	if errors.Is(err, ErrNoMetadata) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	generations, err := listGenerations(ctx, lister, repoName)
+	if err != nil {
+		return err
+	}
+
+	latest := len(generations) > 0
+	if latest {
+		previous, err := readMetadata(ctx, repoInstance, repoType, generationRepo(repoName, generations[0].ID))
+		latest = err == nil && sameMetadata(current, previous)
+	}
+	if !latest {
+		id := newGenerationID(generations, time.Now())
+		dst := generationRepo(repoName, id)
+		for _, f := range current {
+			if err := copier.CopyFile(ctx, repoName, dst, f.Name); err != nil {
+				if err := repoInstance.DeleteRepo(ctx, dst); err != nil {
+					log.Logger.Debugf("Failed to remove %s: %v", dst, err)
+				}
+				return fmt.Errorf("save metadata generation %s: %w", id, err)
+			}
+		}
+		log.Logger.Infof("Saved metadata generation %s of %s (%d files)", id, repoName, len(current))
+		generations = append([]types.MetadataGeneration{{ID: id}}, generations...)
+	}
+
+	for _, g := range generations[min(keep, len(generations)):] {
+		if err := repoInstance.DeleteRepo(ctx, generationRepo(repoName, g.ID)); err != nil {
+			return fmt.Errorf("remove metadata generation %s: %w", g.ID, err)
+		}
+		log.Logger.Debugf("Removed metadata generation %s of %s", g.ID, repoName)
+	}
+	return nil
+}
+
+// newGenerationID 返回 now 对应的版本号，与已有版本重复时加后缀
+func newGenerationID(generations []types.MetadataGeneration, now time.Time) string {
+	base := now.UTC().Format(generationLayout)
+	taken := make(map[string]bool, len(generations))
+	for _, g := range generations {
+		taken[g.ID] = true
+	}
+	id := base
+	for n := 2; taken[id]; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	return id
+}
+
+// dropGenerations 删除仓库的元数据历史，调用方持有写锁
+func (s *RepoService) dropGenerations(ctx context.Context, repoInstance repo.Repo, repoName string) {
+	lister, ok := repoInstance.(repo.FileListRepo)
+	if !ok {
+		return
+	}
+	generations, err := listGenerations(ctx, lister, repoName)
+	if err != nil {
+		log.Logger.Warnf("Failed to list metadata generations of %s: %v", repoName, err)
+		return
+	}
+	for _, g := range generations {
+		if err := repoInstance.DeleteRepo(ctx, generationRepo(repoName, g.ID)); err != nil {
+			log.Logger.Warnf("Failed to remove metadata generation %s of %s: %v", g.ID, repoName, err)
+		}
+	}
+}
+
+// Generations 返回仓库保留的元数据版本（从新到旧）和配置的保留数量
+func (s *RepoService) Generations(ctx context.Context, repoName string) ([]types.MetadataGeneration, int, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, 0, err
+	}
+	lister, ok := repoInstance.(repo.FileListRepo)
+	if !ok || (repoType != repo.RPM && repoType != repo.DEB) {
+		return nil, 0, fmt.Errorf("repository type %s has no metadata", repoType)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	generations, err := listGenerations(ctx, lister, repoName)
+	if err != nil || len(generations) == 0 {
+		return generations, s.keepGenerations(repoName), err
+	}
+	current, err := readMetadata(ctx, repoInstance, repoType, repoName)
+	if err == nil {
+		latest, err := readMetadata(ctx, repoInstance, repoType, generationRepo(repoName, generations[0].ID))
+		generations[0].Current = err == nil && sameMetadata(current, latest)
+	}
+	return generations, s.keepGenerations(repoName), nil
+}
+
+// GenerationMetadata 读取指定版本中的元数据文件，generation 为版本号或时间，返回实际使用的版本号
+func (s *RepoService) GenerationMetadata(ctx context.Context, repoName, generation, filename string) (io.ReadCloser, string, error) {
+	repoInstance, _, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, "", err
+	}
+	lister, ok := repoInstance.(repo.FileListRepo)
+	if !ok {
+		return nil, "", ErrGenerationNotFound
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	generations, err := listGenerations(ctx, lister, repoName)
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := resolveGeneration(generations, generation)
+	if err != nil {
+		return nil, "", err
+	}
+	reader, err := repoInstance.GetMetadata(ctx, generationRepo(repoName, id), filename)
+	return reader, id, err
+}
+
+// GenerationSnapshot 读取指定版本的全部元数据，返回实际使用的版本号
+func (s *RepoService) GenerationSnapshot(ctx context.Context, repoName, generation string) ([]MetadataFile, string, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, "", err
+	}
+	lister, ok := repoInstance.(repo.FileListRepo)
+	if !ok {
+		return nil, "", ErrGenerationNotFound
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	generations, err := listGenerations(ctx, lister, repoName)
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := resolveGeneration(generations, generation)
+	if err != nil {
+		return nil, "", err
+	}
+	files, err := readMetadata(ctx, repoInstance, repoType, generationRepo(repoName, id))
+	return files, id, err
+}
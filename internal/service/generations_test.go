@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/types"
+	"plus/pkg/repo"
+	"plus/pkg/repo/deb"
+)
+
+func TestMetadataGenerations(t *testing.T) {
+	root := t.TempDir()
+	store := newTestStorage(t, root)
+	debRepo := deb.NewDEBRepo(store)
+	s := NewRepoService(debRepo)
+	s.SetGenerations(func(string) int { return 2 })
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "debs", "deb"); err != nil {
+		t.Fatal(err)
+	}
+
+	refresh := func(packages string) {
+		t.Helper()
+		// 与刷新元数据相同，替换文件而不是原地写入（历史版本与仓库共用硬链接）
+		if err := store.Store(ctx, "debs/Packages", strings.NewReader(packages)); err != nil {
+			t.Fatal(err)
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.recordGeneration(ctx, debRepo, repo.DEB, "debs"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	refresh("Package: app\nVersion: 1.0\n")
+	refresh("Package: app\nVersion: 1.0\n") // 元数据没有变化，不保存新版本
+	refresh("Package: app\nVersion: 1.1\n")
+	refresh("Package: app\nVersion: 1.2\n")
+
+	generations, keep, err := s.Generations(ctx, "debs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keep != 2 || len(generations) != 2 || !generations[0].Current || generations[1].Current {
+		t.Fatalf("Generations = %+v, keep %d", generations, keep)
+	}
+
+	reader, id, err := s.GenerationMetadata(ctx, "debs", generations[1].ID, "Packages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if id != generations[1].ID || string(data) != "Package: app\nVersion: 1.1\n" {
+		t.Errorf("GenerationMetadata = %s, %q", id, data)
+	}
+	if _, _, err := s.GenerationMetadata(ctx, "debs", "2000-01-01T00:00:00Z", "Packages"); !errors.Is(err, ErrGenerationNotFound) {
+		t.Errorf("GenerationMetadata before the first generation = %v", err)
+	}
+
+	// 元数据历史不是仓库
+	repos, err := s.ListRepos(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range repos {
+		if IsHiddenPath(r) {
+			t.Errorf("ListRepos returned %s", r)
+		}
+	}
+
+	if err := s.DeleteRepo(ctx, "debs"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, GenerationsRoot, "debs", "."+generations[0].ID)); !os.IsNotExist(err) {
+		t.Errorf("generation left after DeleteRepo: %v", err)
+	}
+}
+
+func TestResolveGeneration(t *testing.T) {
+	generations := []types.MetadataGeneration{
+		{ID: "20240103T000000Z", Created: "2024-01-03T00:00:00Z"},
+		{ID: "20240102T120000Z-2", Created: "2024-01-02T12:00:00Z"},
+		{ID: "20240102T120000Z", Created: "2024-01-02T12:00:00Z"},
+	}
+	for _, tt := range []struct {
+		want, id string
+	}{
+		{"20240102T120000Z", "20240102T120000Z"},
+		{".20240102T120000Z-2", "20240102T120000Z-2"},
+		{"2024-01-02T23:59:59Z", "20240102T120000Z-2"},
+		{"20240104T000000Z", "20240103T000000Z"},
+		{"2024-01-03T09:00:00+09:00", "20240103T000000Z"},
+		{"2024-01-01T00:00:00Z", ""},
+		{"yesterday", ""},
+	} {
+		id, err := resolveGeneration(generations, tt.want)
+		if id != tt.id || (tt.id == "") != errors.Is(err, ErrGenerationNotFound) {
+			t.Errorf("resolveGeneration(%q) = %q, %v, want %q", tt.want, id, err, tt.id)
+		}
+	}
+
+	if id := newGenerationID(generations, time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)); id != "20240102T120000Z-3" {
+		t.Errorf("newGenerationID = %s", id)
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"plus/internal/journal"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+)
+
+// HoldRoot 等待批准的上传在存储中的根目录，请求 id 的文件保存在 .approvals/{id}
+const HoldRoot = ".approvals"
+
+func holdRepo(id string) string {
+	return path.Join(HoldRoot, id)
+}
+
+// overwrites 返回 files 中仓库已有的文件
+func overwrites(ctx context.Context, repoInstance repo.Repo, repoName string, files []storage.FileInfo) ([]string, error) {
+	checker, ok := repoInstance.(repo.FileExistsRepo)
+	if !ok {
+		return nil, fmt.Errorf("repository type %s cannot check for existing files", repoInstance.Type())
+	}
+	var existing []string
+	for _, f := range files {
+		exists, err := checker.FileExists(ctx, repoName, f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			existing = append(existing, f.Name)
+		}
+	}
+	return existing, nil
+}
+
+// UploadProtected 上传到受保护的仓库，返回保存的文件名。不覆盖已有文件时直接放入仓库，被覆盖的文件为空；
+// 会覆盖时文件保存在 .approvals/{id}，返回被覆盖的文件，批准后由 ReleaseHeld 放入仓库。
+// 覆盖策略为 reject 或 auto-version 时先按策略处理，不会产生等待批准的覆盖
+func (s *RepoService) UploadProtected(ctx context.Context, repoName, id, filename string, reader io.Reader) (string, []string, error) {
+	repoInstance, repoType, err := s.stagingRepoInstance(repoName)
+	if err != nil {
+		return "", nil, err
+	}
+	filename, reader, err = s.validateUpload(ctx, repoInstance, repoType, repoName, filename, reader)
+	if err != nil {
+		return "", nil, err
+	}
+	defer s.mu.Unlock()
+
+	// 先放在等待区，按仓库布局得到文件在仓库中的路径后再检查是否覆盖
+	hold := holdRepo(id)
+	counter := &countingReader{Reader: reader}
+	if err := repoInstance.UploadPackage(ctx, hold, filename, counter); err != nil {
+		return "", nil, err
+	}
+	files, err := repoInstance.(repo.FileListRepo).ListFiles(ctx, hold)
+	var existing []string
+	if err == nil {
+		existing, err = overwrites(ctx, repoInstance, repoName, files)
+	}
+	if err == nil && len(existing) > 0 {
+		log.Logger.Infof("Upload of %s would overwrite %v in protected repository %s, holding it as %s", filename, existing, repoName, id)
+		return filename, existing, nil
+	}
+	var done func()
+	if err == nil {
+		done, err = s.beginOp(types.JournalEntry{Op: journal.OpMove, Repo: repoName, Source: hold, Files: fileNames(files)})
+	}
+	if err == nil {
+		_, err = s.moveFiles(ctx, repoInstance, repoType, hold, repoName, files)
+		done()
+	}
+	if err != nil {
+		if err := repoInstance.DeleteRepo(ctx, hold); err != nil {
+			log.Logger.Debugf("Failed to remove %s: %v", hold, err)
+		}
+		return "", nil, err
+	}
+	s.indexUpload(repoName, repoType, filename, counter.n)
+	s.notifyPublish(repoName, []string{filename})
+	return filename, nil, nil
+}
+
+// ReleaseHeld 把等待批准的上传放入仓库并刷新元数据，返回放入的文件
+func (s *RepoService) ReleaseHeld(ctx context.Context, repoName, id string) ([]string, error) {
+	repoInstance, repoType, err := s.stagingRepoInstance(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	files, err := repoInstance.(repo.FileListRepo).ListFiles(ctx, holdRepo(id))
+	if err == nil && len(files) == 0 {
+		err = fmt.Errorf("held upload %s is missing", id)
+	}
+	var moved []string
+	if err == nil {
+		var done func()
+		if done, err = s.beginOp(types.JournalEntry{Op: journal.OpMove, Repo: repoName, Source: holdRepo(id), Files: fileNames(files)}); err == nil {
+			defer done()
+			moved, err = s.moveFiles(ctx, repoInstance, repoType, holdRepo(id), repoName, files)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(moved) > 0 {
+		log.Logger.Infof("Released held upload %s into %s: %v", id, repoName, moved)
+		if refreshErr := s.RefreshMetadata(ctx, repoName); refreshErr != nil && err == nil {
+			err = refreshErr
+		}
+		s.notifyPublish(repoName, moved)
+	}
+	return moved, err
+}
+
+// DropHeld 删除等待批准的上传
+func (s *RepoService) DropHeld(ctx context.Context, repoName, id string) error {
+	repoInstance, _, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return repoInstance.DeleteRepo(ctx, holdRepo(id))
+}
+
+// StagedOverwrites 返回暂存区中会覆盖仓库已有文件的文件，以及暂存区的变更集标识
+func (s *RepoService) StagedOverwrites(ctx context.Context, repoName string) ([]string, string, error) {
+	repoInstance, _, err := s.stagingRepoInstance(repoName)
+	if err != nil {
+		return nil, "", err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	files, id, err := s.staged(ctx, repoInstance, repoName)
+	if err != nil {
+		return nil, "", err
+	}
+	existing, err := overwrites(ctx, repoInstance, repoName, files)
+	return existing, id, err
+}
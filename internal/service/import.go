@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"plus/internal/importer"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/pkg/repo"
+)
+
+// 导入后元数据的来源
+const (
+	MetadataPreserved   = "preserved"
+	MetadataRegenerated = "regenerated"
+)
+
+// Import 扫描 root 下现有的 yum 和 apt 仓库树，逐个创建仓库并把包写入存储。
+// 包都位于 Packages/ 下的 yum 树原样保留 repodata，其他树重新生成元数据；
+// regenerate 为 true 时总是重新生成。已存在的仓库不会被修改，在报告中记为失败
+func (s *RepoService) Import(ctx context.Context, root, prefix string, regenerate, dryRun bool) (*types.ImportReport, error) {
+	trees, err := importer.Scan(root, prefix)
+	if err != nil {
+		return nil, err
+	}
+	report := &types.ImportReport{
+		Status: types.Status{Status: "success"},
+		Path:   root,
+		DryRun: dryRun,
+		Repos:  make([]types.ImportedRepo, 0, len(trees)),
+	}
+	for i := range trees {
+		t := &trees[i]
+		r := types.ImportedRepo{
+			Repo:     t.Name,
+			Type:     t.Type,
+			Layout:   t.Layout,
+			Source:   t.Dir,
+			Packages: len(t.Packages),
+			Size:     t.Size,
+			Skipped:  t.Skipped,
+			Metadata: MetadataRegenerated,
+		}
+		if !regenerate && t.Preservable() {
+			r.Metadata = MetadataPreserved
+		}
+		err := s.checkImport(ctx, t)
+		if err == nil && !dryRun {
+			err = s.importTree(ctx, t, r.Metadata)
+		}
+		if err != nil {
+			log.Logger.Errorf("Failed to import %s as %s: %v", t.Dir, t.Name, err)
+			r.Error = err.Error()
+			report.Failed++
+		} else {
+			report.Imported++
+		}
+		report.Repos = append(report.Repos, r)
+	}
+	if report.Failed > 0 {
+		report.Status.Status = "partial"
+	}
+	return report, nil
+}
+
+// checkImport 检查树能否导入为新仓库
+func (s *RepoService) checkImport(ctx context.Context, t *importer.Tree) error {
+	if !utils.IsValidRepoName(t.Name) {
+		return fmt.Errorf("invalid repository name %s", t.Name)
+	}
+	if _, err := s.GetRepoType(ctx, t.Name); err == nil {
+		return fmt.Errorf("repository %s already exists", t.Name)
+	}
+	// 存储中已有文件的目录也视为已存在，不与其中的文件混合
+	if lister, ok := s.repos[repo.RepoType(t.Type)].(repo.FileListRepo); ok {
+		if files, err := lister.ListFiles(ctx, t.Name); err == nil && len(files) > 0 {
+			return fmt.Errorf("repository %s already exists", t.Name)
+		}
+	}
+	return nil
+}
+
+// importTree 创建仓库并写入包和保留的元数据，失败时删除已创建的仓库
+func (s *RepoService) importTree(ctx context.Context, t *importer.Tree, metadata string) error {
+	preserve := metadata == MetadataPreserved
+	if err := s.CreateRepo(ctx, t.Name, t.Type); err != nil {
+		return err
+	}
+	repoInstance, repoType, err := s.getRepoInstance(t.Name)
+	if err == nil {
+		err = s.importFiles(ctx, repoInstance, t, preserve)
+	}
+	if err == nil {
+		if preserve {
+			s.mu.Lock()
+			s.afterRefresh(ctx, repoInstance, repoType, t.Name)
+			s.mu.Unlock()
+		} else {
+			err = s.RefreshMetadata(ctx, t.Name)
+		}
+	}
+	if err != nil {
+		if derr := s.DeleteRepo(ctx, t.Name); derr != nil {
+			log.Logger.Warnf("Failed to remove incomplete import %s: %v", t.Name, derr)
+		}
+		return err
+	}
+	log.Logger.Infof("Imported %s repository %s from %s: %d packages, %d bytes, metadata %s",
+		repoType, t.Name, t.Dir, len(t.Packages), t.Size, metadata)
+	return nil
+}
+
+// importFiles 按文件名写入包，preserve 时再写入 repodata，repomd.xml 最后写入
+func (s *RepoService) importFiles(ctx context.Context, repoInstance repo.Repo, t *importer.Tree, preserve bool) error {
+	for _, p := range t.Packages {
+		if err := s.importFile(t, p, func(f *os.File) error {
+			return repoInstance.UploadPackage(ctx, t.Name, path.Base(p), f)
+		}); err != nil {
+			return err
+		}
+	}
+	if !preserve {
+		return nil
+	}
+	treeRepo, ok := repoInstance.(repo.InstallTreeRepo)
+	if !ok {
+		return fmt.Errorf("repository type %s cannot keep existing metadata", repoInstance.Type())
+	}
+	var repomd string
+	for _, p := range t.Metadata {
+		if path.Base(p) == "repomd.xml" {
+			repomd = p
+			continue
+		}
+		if err := s.importFile(t, p, func(f *os.File) error {
+			return treeRepo.UploadTreeFile(ctx, t.Name, p, f)
+		}); err != nil {
+			return err
+		}
+	}
+	return s.importFile(t, repomd, func(f *os.File) error {
+		return treeRepo.UploadTreeFile(ctx, t.Name, repomd, f)
+	})
+}
+
+func (s *RepoService) importFile(t *importer.Tree, name string, store func(*os.File) error) error {
+	f, err := os.Open(filepath.Join(t.Dir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := store(f); err != nil {
+		return fmt.Errorf("failed to import %s: %w", name, err)
+	}
+	return nil
+}
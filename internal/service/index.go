@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"plus/internal/facet"
+	"plus/internal/index"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/pkg/repo"
+)
+
+// SetIndex 设置制品索引；完成全量扫描后仓库列表、包列表和搜索从索引读取，不再遍历存储
+func (s *RepoService) SetIndex(idx *index.Index) {
+	s.index = idx
+}
+
+// IndexMetrics 返回索引统计，未启用索引时返回 nil
+func (s *RepoService) IndexMetrics() *types.IndexMetrics {
+	if s.index == nil {
+		return nil
+	}
+	return s.index.Metrics()
+}
+
+// Reconcile 遍历存储中的全部仓库和制品并修正索引
+func (s *RepoService) Reconcile(ctx context.Context) error {
+	if s.index == nil {
+		return fmt.Errorf("index is not enabled")
+	}
+	start := time.Now()
+	repoTypes, err := s.scanRepos(ctx)
+	if err != nil {
+		s.index.ReconcileFailed(err)
+		return err
+	}
+
+	scans := make([]index.RepoScan, 0, len(repoTypes))
+	for name, repoType := range repoTypes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.mu.RLock()
+		artifacts, err := s.scanArtifacts(ctx, s.repos[repoType], repoType, name)
+		s.mu.RUnlock()
+		if err != nil {
+			// 单个仓库失败时放弃本次扫描，避免把该仓库当作已删除
+			err = fmt.Errorf("scan %s: %w", name, err)
+			s.index.ReconcileFailed(err)
+			return err
+		}
+		scans = append(scans, index.RepoScan{Name: name, Type: string(repoType), Artifacts: artifacts})
+	}
+	s.index.Reconcile(scans, start)
+	log.Logger.Debugf("Index reconciled: %d repos in %s", len(scans), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// ReindexRepo 重新扫描一个仓库的制品，用于存储被服务之外的组件修改之后（如代理仓库同步）
+func (s *RepoService) ReindexRepo(ctx context.Context, repoName string) {
+	if s.index == nil {
+		return
+	}
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.reindexLocked(ctx, repoInstance, repoType, repoName)
+}
+
+func (s *RepoService) reindexLocked(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName string) {
+	start := time.Now()
+	artifacts, err := s.scanArtifacts(ctx, repoInstance, repoType, repoName)
+	if err != nil {
+		log.Logger.Warnf("Failed to reindex %s: %v", repoName, err)
+		return
+	}
+	s.index.ReplaceRepo(repoName, string(repoType), artifacts, start)
+}
+
+// scanRepos 遍历各类型存储中的仓库，类型的选择与 ListRepos 相同
+func (s *RepoService) scanRepos(ctx context.Context) (map[string]repo.RepoType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := make(map[string]repo.RepoType)
+	for repoType, repoInstance := range s.repos {
+		repos, err := repoInstance.ListRepos(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list %s repositories: %w", repoType, err)
+		}
+		for _, repoName := range repos {
+			if IsHiddenPath(repoName) {
+				continue
+			}
+			if _, exists := s.repoTypes[repoName]; !exists {
+				s.repoTypes[repoName] = repoType
+			}
+			found[repoName] = s.repoTypes[repoName]
+		}
+	}
+	return found, nil
+}
+
+func (s *RepoService) scanArtifacts(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName string) ([]types.Artifact, error) {
+	packages, err := repoInstance.ListPackages(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+	artifacts := make([]types.Artifact, 0, len(packages))
+	for _, pkg := range packages {
+		artifacts = append(artifacts, types.Artifact{
+			Repo:     repoName,
+			Type:     string(repoType),
+			Path:     pkg.Name,
+			Size:     pkg.Size,
+			Checksum: pkg.Checksum,
+			ModTime:  pkg.ModTime,
+			Labels:   index.Labels(string(repoType), pkg.Name),
+		})
+	}
+	return artifacts, nil
+}
+
+// indexedPackages 从索引读取仓库的包列表，仓库尚未扫描时返回 false
+func (s *RepoService) indexedPackages(repoName string) ([]types.PackageInfo, bool) {
+	if s.index == nil {
+		return nil, false
+	}
+	artifacts, ok := s.index.List(repoName)
+	if !ok {
+		return nil, false
+	}
+	packages := make([]types.PackageInfo, 0, len(artifacts))
+	for _, a := range artifacts {
+		packages = append(packages, types.PackageInfo{
+			Name:     a.Path,
+			Size:     a.Size,
+			Checksum: a.Checksum,
+			ModTime:  a.ModTime,
+		})
+	}
+	return packages, true
+}
+
+// indexUpload 上传成功后记录制品，大小为实际写入的字节数
+func (s *RepoService) indexUpload(repoName string, repoType repo.RepoType, filename string, size int64) {
+	if s.index == nil {
+		return
+	}
+	s.index.Put(types.Artifact{
+		Repo:    repoName,
+		Type:    string(repoType),
+		Path:    filename,
+		Size:    size,
+		ModTime: utils.FormatModTime(time.Now()),
+		Labels:  index.Labels(string(repoType), filename),
+	})
+}
+
+// Search 按条件搜索制品；索引未就绪时遍历存储，第三个返回值表示结果来自索引
+func (s *RepoService) Search(ctx context.Context, q index.Query, limit int, counts *facet.Counter) ([]types.Artifact, bool, bool, error) {
+	if s.index != nil && s.index.Ready() {
+		artifacts, truncated := s.index.SearchCount(q, limit, counts)
+		return artifacts, truncated, true, nil
+	}
+
+	repos, err := s.ListRepos(ctx)
+	if err != nil {
+		return nil, false, false, err
+	}
+	sort.Strings(repos)
+	var result []types.Artifact
+	truncated := false
+	for _, repoName := range repos {
+		repoInstance, repoType, err := s.getRepoInstance(repoName)
+		if err != nil {
+			continue
+		}
+		if q.Type != "" && string(repoType) != q.Type {
+			continue
+		}
+		s.mu.RLock()
+		artifacts, err := s.scanArtifacts(ctx, repoInstance, repoType, repoName)
+		s.mu.RUnlock()
+		if err != nil {
+			return nil, false, false, err
+		}
+		for i := range artifacts {
+			if !q.Match(&artifacts[i]) {
+				continue
+			}
+			if counts != nil {
+				counts.AddArtifact(&artifacts[i])
+			}
+			if limit > 0 && len(result) == limit {
+				if counts == nil {
+					return result, true, false, nil
+				}
+				truncated = true
+				continue
+			}
+			result = append(result, artifacts[i])
+		}
+	}
+	return result, truncated, false, nil
+}
+
+// countingReader 统计上传的字节数
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
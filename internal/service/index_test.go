@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/internal/index"
+)
+
+func TestIndexedListing(t *testing.T) {
+	s, root := newFilesService(t)
+	idx, err := index.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+	s.SetIndex(idx)
+
+	ctx := context.Background()
+	if err := s.CreateRepo(ctx, "artifacts", "files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "artifacts", "app.tar.gz", strings.NewReader("payload")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 绕过服务写入存储的文件在下一次扫描后出现
+	if err := os.WriteFile(filepath.Join(root, "artifacts", "manual.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	packages, err := s.ListPackages(ctx, "artifacts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sizes := make(map[string]int64)
+	for _, pkg := range packages {
+		sizes[pkg.Name] = pkg.Size
+	}
+	if _, ok := sizes["manual.txt"]; ok || sizes["app.tar.gz"] != 7 {
+		t.Fatalf("ListPackages = %+v", packages)
+	}
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatal(err)
+	}
+	res, _, indexed, err := s.Search(ctx, index.Query{Labels: map[string]string{"ext": "txt"}}, 10, nil)
+	if err != nil || !indexed || len(res) != 1 || res[0].Path != "manual.txt" {
+		t.Errorf("Search = %+v, %v, %v", res, indexed, err)
+	}
+
+	if err := s.DeleteRepo(ctx, "artifacts"); err != nil {
+		t.Fatal(err)
+	}
+	if repos, _ := s.ListRepos(ctx); len(repos) != 0 {
+		t.Errorf("ListRepos after delete = %v", repos)
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"plus/internal/journal"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+)
+
+// SetJournal 设置多步操作的写前日志。刷新、发布、批准放入、保留策略和克隆开始前记录意图，完成后删除
+func (s *RepoService) SetJournal(j *journal.Journal) {
+	s.journal = j
+}
+
+// beginOp 记录操作意图，返回完成时调用的函数。意图无法持久化时不开始操作
+func (s *RepoService) beginOp(e types.JournalEntry) (func(), error) {
+	if s.cluster != nil {
+		e.Node = s.cluster.Node()
+	}
+	id, err := s.journal.Begin(e)
+	if err != nil {
+		return nil, err
+	}
+	return func() { s.journal.End(id) }, nil
+}
+
+// fileNames 日志记录中的文件名
+func fileNames(files []storage.FileInfo) []string {
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// ReplayJournal 处理上次崩溃时未完成的操作：移入剩余文件、重新刷新元数据，或删除未复制完的克隆，
+// 返回处理的记录数。多个实例共用存储时其他实例的操作可能仍在进行，见 replayable。
+// 需要在设置工作池之后、开始服务之前调用
+func (s *RepoService) ReplayJournal(ctx context.Context, minAge time.Duration) (int, error) {
+	entries, err := s.journal.Pending(0)
+	if err != nil {
+		return 0, fmt.Errorf("read journal: %w", err)
+	}
+	n := 0
+	for _, e := range entries {
+		if ok, err := s.replayable(ctx, e, minAge); !ok {
+			if err != nil {
+				log.Logger.Warnf("Skipping journal entry %s: %v", e.ID, err)
+			}
+			continue
+		}
+		n++
+		log.Logger.Infof("Replaying interrupted %s of %s started at %s", e.Op, e.Repo, e.Started)
+		// 重放失败时同样删除记录，留在暂存区或等待区的文件可以重新发布
+		if err := s.replay(ctx, e); err != nil {
+			log.Logger.Errorf("Failed to replay %s of %s: %v", e.Op, e.Repo, err)
+		}
+		s.journal.End(e.ID)
+	}
+	return n, nil
+}
+
+// replayable 集群模式下只重放本实例或已没有心跳的实例记录的操作，不能只按时间判断：
+// 其他实例的长时间克隆仍在进行时删除目标仓库会破坏它。没有记录实例的记录只重放早于 minAge 的
+func (s *RepoService) replayable(ctx context.Context, e types.JournalEntry, minAge time.Duration) (bool, error) {
+	if s.cluster == nil || e.Node == "" {
+		started, err := time.Parse(time.RFC3339Nano, e.Started)
+		return err != nil || time.Since(started) >= minAge, nil
+	}
+	if e.Node == s.cluster.Node() {
+		return true, nil
+	}
+	alive, err := s.cluster.NodeAlive(ctx, e.Node)
+	if err != nil {
+		return false, fmt.Errorf("check cluster node %s: %w", e.Node, err)
+	}
+	return !alive, nil
+}
+
+func (s *RepoService) replay(ctx context.Context, e types.JournalEntry) error {
+	switch e.Op {
+	case journal.OpRefresh, journal.OpRetention, journal.OpExpire:
+		// 保留策略和过期删除的文件不会再出现在刷新后的元数据中
+		return s.ScheduleRefresh(ctx, e.Repo)
+	case journal.OpMove:
+		moved, err := s.replayMove(ctx, e)
+		if len(moved) > 0 {
+			log.Logger.Infof("Moved %d remaining files from %s into %s", len(moved), e.Source, e.Repo)
+			s.notifyPublish(e.Repo, moved)
+		}
+		if refreshErr := s.ScheduleRefresh(ctx, e.Repo); refreshErr != nil && err == nil {
+			err = refreshErr
+		}
+		return err
+	case journal.OpClone:
+		if _, _, err := s.getRepoInstance(e.Repo); err != nil {
+			// 崩溃时尚未创建
+			return nil
+		}
+		log.Logger.Infof("Removing incomplete clone %s of %s", e.Repo, e.Source)
+		return s.DeleteRepo(ctx, e.Repo)
+	}
+	return fmt.Errorf("unknown journal operation %q", e.Op)
+}
+
+// replayMove 把来源中剩余的文件移入仓库。移动期间来源持有写锁，剩余的文件都属于中断的操作
+func (s *RepoService) replayMove(ctx context.Context, e types.JournalEntry) ([]string, error) {
+	repoInstance, repoType, err := s.stagingRepoInstance(e.Repo)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files, err := repoInstance.(repo.FileListRepo).ListFiles(ctx, e.Source)
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	return s.moveFiles(ctx, repoInstance, repoType, e.Source, e.Repo, files)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/cluster"
+	"plus/internal/journal"
+	"plus/internal/types"
+	"plus/pkg/repo/files"
+)
+
+func TestReplayJournal(t *testing.T) {
+	s, root := newFilesService(t)
+	j, err := journal.Open(filepath.Join(root, journal.Dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetJournal(j)
+
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "artifacts", "files"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 完成的操作不留下记录
+	if err := s.StagePackage(ctx, "artifacts", "a.tar.gz", strings.NewReader("a")); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Publish(ctx, "artifacts", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.CloneRepo(ctx, "artifacts", "done"); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := j.Pending(0); len(entries) != 0 {
+		t.Fatalf("entries after completed operations: %+v", entries)
+	}
+
+	// 发布移入一个文件后崩溃，复制进行到一半时崩溃
+	s.StagePackage(ctx, "artifacts", "b.tar.gz", strings.NewReader("b"))
+	s.StagePackage(ctx, "artifacts", "c.tar.gz", strings.NewReader("c"))
+	j.Begin(types.JournalEntry{Op: journal.OpMove, Repo: "artifacts", Source: stagingRepo("artifacts"), Files: []string{"b.tar.gz", "c.tar.gz"}})
+	s.repos["files"].(*files.FilesRepo).CopyFile(ctx, stagingRepo("artifacts"), "artifacts", "b.tar.gz")
+	j.Begin(types.JournalEntry{Op: journal.OpClone, Repo: "partial", Source: "artifacts"})
+	s.CreateRepo(ctx, "partial", "files")
+	j.Begin(types.JournalEntry{Op: journal.OpClone, Repo: "never-created", Source: "artifacts"})
+
+	n, err := s.ReplayJournal(ctx, 0)
+	if err != nil || n != 3 {
+		t.Fatalf("replay = %d, %v", n, err)
+	}
+	list, _ := s.ListFiles(ctx, "artifacts")
+	var names []string
+	for _, f := range list {
+		names = append(names, f.Path)
+	}
+	if strings.Join(names, ",") != "a.tar.gz,b.tar.gz,c.tar.gz" {
+		t.Errorf("files after replay = %v", names)
+	}
+	if staged, _, _ := s.Staged(ctx, "artifacts"); len(staged) != 0 {
+		t.Errorf("staged after replay = %+v", staged)
+	}
+	if _, _, err := s.getRepoInstance("partial"); err == nil {
+		t.Error("incomplete clone kept")
+	}
+	if _, _, err := s.getRepoInstance("done"); err != nil {
+		t.Errorf("completed clone removed: %v", err)
+	}
+	if entries, _ := j.Pending(0); len(entries) != 0 {
+		t.Errorf("entries after replay: %+v", entries)
+	}
+}
+
+func TestReplayJournalCluster(t *testing.T) {
+	s, root := newFilesService(t)
+	j, err := journal.Open(filepath.Join(root, journal.Dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetJournal(j)
+	shared := cluster.NewMemoryStore()
+	s.SetCluster(cluster.New(shared, "a", time.Minute))
+
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "artifacts", "files"); err != nil {
+		t.Fatal(err)
+	}
+	// 仍有心跳的实例 b 正在克隆，已停止的实例 c 和本实例的克隆中断
+	if err := cluster.New(shared, "b", time.Minute).Beat(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []types.JournalEntry{
+		{Op: journal.OpClone, Repo: "running", Source: "artifacts", Node: "b"},
+		{Op: journal.OpClone, Repo: "stopped", Source: "artifacts", Node: "c"},
+		{Op: journal.OpClone, Repo: "own", Source: "artifacts", Node: "a"},
+	} {
+		j.Begin(e)
+		s.CreateRepo(ctx, e.Repo, "files")
+	}
+
+	n, err := s.ReplayJournal(ctx, time.Hour)
+	if err != nil || n != 2 {
+		t.Fatalf("replay = %d, %v", n, err)
+	}
+	if _, _, err := s.getRepoInstance("running"); err != nil {
+		t.Errorf("clone of a live node removed: %v", err)
+	}
+	for _, name := range []string{"stopped", "own"} {
+		if _, _, err := s.getRepoInstance(name); err == nil {
+			t.Errorf("incomplete clone %s kept", name)
+		}
+	}
+	if entries, _ := j.Pending(0); len(entries) != 1 || entries[0].Node != "b" {
+		t.Errorf("entries after replay: %+v", entries)
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+// ErrLegalHold 制品有保留标记，不能删除或覆盖
+var ErrLegalHold = errors.New("artifact is under legal hold")
+
+// readLegalHolds 读取仓库的保留标记，调用方持有锁
+func readLegalHolds(ctx context.Context, sr repo.StateRepo, repoName string) (map[string]types.LegalHold, error) {
+	var saved types.RepoLegalHolds
+	if err := loadState(ctx, sr, repoName, repo.LegalHoldsFile, &saved); err != nil {
+		return nil, err
+	}
+	if saved.Holds == nil {
+		saved.Holds = make(map[string]types.LegalHold)
+	}
+	return saved.Holds, nil
+}
+
+// writeLegalHolds 替换仓库的保留标记文件，调用方持有锁
+func writeLegalHolds(ctx context.Context, sr repo.StateRepo, repoName string, holds map[string]types.LegalHold) error {
+	return saveState(ctx, sr, repoName, repo.LegalHoldsFile, &types.RepoLegalHolds{Holds: holds})
+}
+
+// heldArtifacts 返回仓库中有保留标记的制品，按 NoteKey 索引，调用方持有锁。
+// 读取失败时返回错误，调用方不能删除任何文件
+func heldArtifacts(ctx context.Context, repoInstance repo.Repo, repoName string) (map[string]types.LegalHold, error) {
+	sr, ok := repoInstance.(repo.StateRepo)
+	if !ok {
+		return nil, nil
+	}
+	return readLegalHolds(ctx, sr, repoName)
+}
+
+// LegalHolds 返回仓库中的保留标记，按 NoteKey 索引
+func (s *RepoService) LegalHolds(ctx context.Context, repoName string) (map[string]types.LegalHold, error) {
+	_, sr, _, err := s.stateRepo(repoName, "legal holds")
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return readLegalHolds(ctx, sr, repoName)
+}
+
+// LegalHold 返回制品的保留标记，没有时返回 false
+func (s *RepoService) LegalHold(ctx context.Context, repoName, name string) (types.LegalHold, bool, error) {
+	_, sr, repoType, err := s.stateRepo(repoName, "legal holds")
+	if err != nil {
+		return types.LegalHold{}, false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	holds, err := readLegalHolds(ctx, sr, repoName)
+	if err != nil {
+		return types.LegalHold{}, false, err
+	}
+	hold, ok := holds[NoteKey(string(repoType), name)]
+	return hold, ok, nil
+}
+
+// SetLegalHold 为制品设置保留标记，已有标记时替换。标记解除前保留策略、过期和删除仓库都不会删除它
+func (s *RepoService) SetLegalHold(ctx context.Context, repoName, name string, hold types.LegalHold) error {
+	_, sr, repoType, err := s.stateRepo(repoName, "legal holds")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	holds, err := readLegalHolds(ctx, sr, repoName)
+	if err != nil {
+		return err
+	}
+	holds[NoteKey(string(repoType), name)] = hold
+	return writeLegalHolds(ctx, sr, repoName, holds)
+}
+
+// ReleaseLegalHold 解除制品的保留标记，没有标记时返回 false
+func (s *RepoService) ReleaseLegalHold(ctx context.Context, repoName, name string) (bool, error) {
+	_, sr, repoType, err := s.stateRepo(repoName, "legal holds")
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	holds, err := readLegalHolds(ctx, sr, repoName)
+	if err != nil {
+		return false, err
+	}
+	key := NoteKey(string(repoType), name)
+	if _, ok := holds[key]; !ok {
+		return false, nil
+	}
+	delete(holds, key)
+	return true, writeLegalHolds(ctx, sr, repoName, holds)
+}
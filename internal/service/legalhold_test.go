@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/filepolicy"
+	"plus/internal/types"
+)
+
+func TestLegalHold(t *testing.T) {
+	s, root := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "ci", "files"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"build-1.tar.gz", "build-2.tar.gz"} {
+		if err := s.UploadPackage(ctx, "ci", name, strings.NewReader(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hold := types.LegalHold{Reason: "audit", By: "alice", Since: "2024-01-01T00:00:00Z"}
+	if err := s.SetLegalHold(ctx, "ci", "/build-1.tar.gz", hold); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok, err := s.LegalHold(ctx, "ci", "build-1.tar.gz"); err != nil || !ok || got != hold {
+		t.Errorf("LegalHold = %v, %v, %v", got, ok, err)
+	}
+
+	// 到期后有保留标记的制品不删除
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"build-1.tar.gz", "build-2.tar.gz"} {
+		if err := s.SetExpiry(ctx, "ci", name, now.Add(-time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	removed, err := s.ExpireArtifacts(ctx, "ci", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 || removed[0] != "build-2.tar.gz" {
+		t.Errorf("removed = %v, want [build-2.tar.gz]", removed)
+	}
+	if _, err := os.Stat(filepath.Join(root, "ci", "build-1.tar.gz")); err != nil {
+		t.Errorf("held file was expired: %v", err)
+	}
+
+	// 不能覆盖，也不能删除仓库
+	if err := s.UploadPackage(ctx, "ci", "build-1.tar.gz", strings.NewReader("new")); !errors.Is(err, ErrLegalHold) {
+		t.Errorf("overwrite held file: err = %v, want ErrLegalHold", err)
+	}
+	if err := s.DeleteRepo(ctx, "ci"); !errors.Is(err, ErrLegalHold) {
+		t.Errorf("DeleteRepo: err = %v, want ErrLegalHold", err)
+	}
+
+	// 解除后按原来的到期时间删除
+	if ok, err := s.ReleaseLegalHold(ctx, "ci", "build-1.tar.gz"); err != nil || !ok {
+		t.Fatalf("ReleaseLegalHold = %v, %v", ok, err)
+	}
+	if ok, err := s.ReleaseLegalHold(ctx, "ci", "build-1.tar.gz"); err != nil || ok {
+		t.Errorf("second ReleaseLegalHold = %v, %v", ok, err)
+	}
+	if removed, err := s.ExpireArtifacts(ctx, "ci", now); err != nil || len(removed) != 1 || removed[0] != "build-1.tar.gz" {
+		t.Errorf("ExpireArtifacts after release = %v, %v", removed, err)
+	}
+}
+
+func TestLegalHoldsFileNotUploadable(t *testing.T) {
+	s, _ := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "ci", "files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "ci", "build-1.tar.gz", strings.NewReader("x")); err != nil {
+		t.Fatal(err)
+	}
+	hold := types.LegalHold{Reason: "audit", By: "alice"}
+	if err := s.SetLegalHold(ctx, "ci", "build-1.tar.gz", hold); err != nil {
+		t.Fatal(err)
+	}
+
+	// 上传同名文件不能清掉保留标记
+	var v *filepolicy.Violation
+	for _, name := range []string{".legal-holds.json", "sub/.legal-holds.json"} {
+		if err := s.UploadPackage(ctx, "ci", name, strings.NewReader(`{"holds":{}}`)); !errors.As(err, &v) {
+			t.Errorf("upload %s: err = %v, want *filepolicy.Violation", name, err)
+		}
+	}
+	if _, err := s.StagePackageAs(ctx, "ci", ".legal-holds.json", strings.NewReader(`{"holds":{}}`)); !errors.As(err, &v) {
+		t.Errorf("stage: err = %v, want *filepolicy.Violation", err)
+	}
+	if got, ok, err := s.LegalHold(ctx, "ci", "build-1.tar.gz"); err != nil || !ok || got != hold {
+		t.Errorf("LegalHold after upload = %v, %v, %v", got, ok, err)
+	}
+}
+
+func TestLegalHoldBlocksPublish(t *testing.T) {
+	s, root := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "ci", "files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "ci", "build-1.tar.gz", strings.NewReader("old")); err != nil {
+		t.Fatal(err)
+	}
+	// 暂存和等待批准时还没有保留标记，发布和批准时才有
+	if _, err := s.StagePackageAs(ctx, "ci", "build-1.tar.gz", strings.NewReader("staged")); err != nil {
+		t.Fatal(err)
+	}
+	if _, existing, err := s.UploadProtected(ctx, "ci", "req-1", "build-1.tar.gz", strings.NewReader("held")); err != nil || len(existing) != 1 {
+		t.Fatalf("UploadProtected = %v, %v", existing, err)
+	}
+	if err := s.SetLegalHold(ctx, "ci", "build-1.tar.gz", types.LegalHold{Reason: "audit"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if published, _, err := s.Publish(ctx, "ci", ""); !errors.Is(err, ErrLegalHold) || len(published) != 0 {
+		t.Errorf("Publish = %v, %v, want ErrLegalHold", published, err)
+	}
+	if moved, err := s.ReleaseHeld(ctx, "ci", "req-1"); !errors.Is(err, ErrLegalHold) || len(moved) != 0 {
+		t.Errorf("ReleaseHeld = %v, %v, want ErrLegalHold", moved, err)
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "ci", "build-1.tar.gz")); err != nil || string(data) != "old" {
+		t.Errorf("held file = %q, %v", data, err)
+	}
+	// 暂存区保持原样，解除标记后可以发布
+	if ok, err := s.ReleaseLegalHold(ctx, "ci", "build-1.tar.gz"); err != nil || !ok {
+		t.Fatalf("ReleaseLegalHold = %v, %v", ok, err)
+	}
+	if published, _, err := s.Publish(ctx, "ci", ""); err != nil || len(published) != 1 {
+		t.Errorf("Publish after release = %v, %v", published, err)
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"bytes"
+	"io"
+
+	"plus/internal/license"
+)
+
+// SetLicensePolicy 设置每个仓库禁止的许可证，许可证被禁止的上传返回 *license.Violation
+func (s *RepoService) SetLicensePolicy(policy func(repoName string) *license.Policy) {
+	s.licenses = policy
+}
+
+// checkLicense 仓库配置了许可证规则时读取上传的包的许可证并检查，无法读取时返回 license.ErrUnreadable。
+// 返回的 reader 包含已读取的部分；没有规则时原样返回，不读取
+func (s *RepoService) checkLicense(repoName, filename string, reader io.Reader) (io.Reader, error) {
+	if s.licenses == nil {
+		return reader, nil
+	}
+	policy := s.licenses(repoName)
+	if policy == nil {
+		return reader, nil
+	}
+	head := &bytes.Buffer{}
+	value, err := license.Read(io.TeeReader(reader, head), filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.Check(filename, value); err != nil {
+		return nil, err
+	}
+	return io.MultiReader(head, reader), nil
+}
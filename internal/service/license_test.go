@@ -0,0 +1,54 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/internal/license"
+	"plus/pkg/repo/deb"
+)
+
+func TestLicensePolicy(t *testing.T) {
+	root := t.TempDir()
+	store := newTestStorage(t, root)
+	s := NewRepoService(deb.NewDEBRepo(store))
+	policy, err := license.New([]string{"AGPL*"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetLicensePolicy(func(repoName string) *license.Policy { return policy })
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "debs", "deb"); err != nil {
+		t.Fatal(err)
+	}
+
+	denied := testDEBControl("Package: server\nVersion: 1.0\nArchitecture: amd64\nLicense: AGPL-3.0\n")
+	var v *license.Violation
+	if err := s.UploadPackage(ctx, "debs", "server_1.0_amd64.deb", bytes.NewReader(denied)); !errors.As(err, &v) || v.License != "AGPL-3.0" {
+		t.Errorf("denied upload = %v", err)
+	}
+	if _, err := s.StagePackageAs(ctx, "debs", "server_1.0_amd64.deb", bytes.NewReader(denied)); !errors.As(err, &v) {
+		t.Errorf("denied stage = %v", err)
+	}
+	if err := s.UploadPackage(ctx, "debs", "bad_1.0_amd64.deb", strings.NewReader("garbage")); !errors.Is(err, license.ErrUnreadable) {
+		t.Errorf("unreadable upload = %v", err)
+	}
+
+	// 许可证允许或没有许可证时完整保存
+	for name, data := range map[string][]byte{
+		"client_1.0_amd64.deb":  testDEBControl("Package: client\nVersion: 1.0\nArchitecture: amd64\nLicense: MIT\n"),
+		"hello_1.0-1_amd64.deb": testDEB("hello", "1.0-1", "amd64"),
+	} {
+		if err := s.UploadPackage(ctx, "debs", name, bytes.NewReader(data)); err != nil {
+			t.Fatalf("upload %s: %v", name, err)
+		}
+		if stored, err := os.ReadFile(filepath.Join(root, "debs", name)); err != nil || !bytes.Equal(stored, data) {
+			t.Errorf("%s stored incompletely: %v", name, err)
+		}
+	}
+}
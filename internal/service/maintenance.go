@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+// maintenanceCacheTTL 多实例部署时本地缓存共享维护状态的时间
+const maintenanceCacheTTL = 5 * time.Second
+
+// SetMaintenance 让仓库进入维护状态，多实例部署时通过共享注册表通知其他实例
+func (s *RepoService) SetMaintenance(ctx context.Context, repoName string, m types.Maintenance) error {
+	if _, _, err := s.getRepoInstance(repoName); err != nil {
+		return err
+	}
+	if s.cluster != nil {
+		data, err := m.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if err := s.cluster.SetMaintenance(ctx, repoName, string(data)); err != nil {
+			return err
+		}
+	}
+	s.updateMaintenance(repoName, &m)
+	log.Logger.Infof("Repository %s is in maintenance (by %s): %s", repoName, m.By, m.Message)
+	return nil
+}
+
+// ClearMaintenance 让仓库退出维护状态
+func (s *RepoService) ClearMaintenance(ctx context.Context, repoName string) error {
+	if s.cluster != nil {
+		if err := s.cluster.SetMaintenance(ctx, repoName, ""); err != nil {
+			return err
+		}
+	}
+	if s.updateMaintenance(repoName, nil) {
+		log.Logger.Infof("Repository %s left maintenance", repoName)
+	}
+	return nil
+}
+
+// Maintenance 返回 path 所在仓库的维护状态，上级仓库处于维护状态时嵌套的仓库同样不可用
+func (s *RepoService) Maintenance(path string) (string, types.Maintenance, bool) {
+	state := s.maintenanceState()
+	var found string
+	for repoName := range state {
+		if (path == repoName || strings.HasPrefix(path, repoName+"/")) && len(repoName) > len(found) {
+			found = repoName
+		}
+	}
+	if found == "" {
+		return "", types.Maintenance{}, false
+	}
+	return found, *state[found], true
+}
+
+// MaintenanceRepos 返回所有处于维护状态的仓库
+func (s *RepoService) MaintenanceRepos() map[string]*types.Maintenance {
+	return s.maintenanceState()
+}
+
+// maintenanceState 返回维护状态表。表只整体替换，返回后调用方可以直接读取
+func (s *RepoService) maintenanceState() map[string]*types.Maintenance {
+	s.maintMu.Lock()
+	defer s.maintMu.Unlock()
+	if s.cluster == nil || time.Since(s.maintLoaded) < maintenanceCacheTTL {
+		return s.maintenance
+	}
+	// 读取失败时继续使用上次的状态
+	s.maintLoaded = time.Now()
+	shared, err := s.cluster.Maintenance(context.Background())
+	if err != nil {
+		log.Logger.Debugf("Failed to load maintenance state from the cluster registry: %v", err)
+		return s.maintenance
+	}
+	state := make(map[string]*types.Maintenance, len(shared))
+	for repoName, data := range shared {
+		m := &types.Maintenance{}
+		if err := m.UnmarshalJSON([]byte(data)); err != nil {
+			log.Logger.Warnf("Invalid maintenance state of %s in the cluster registry: %v", repoName, err)
+			continue
+		}
+		state[repoName] = m
+	}
+	s.maintenance = state
+	return state
+}
+
+// updateMaintenance 复制并替换状态表，m 为 nil 时删除，返回状态是否变化
+func (s *RepoService) updateMaintenance(repoName string, m *types.Maintenance) bool {
+	s.maintMu.Lock()
+	defer s.maintMu.Unlock()
+	_, existed := s.maintenance[repoName]
+	if m == nil && !existed {
+		return false
+	}
+	state := make(map[string]*types.Maintenance, len(s.maintenance)+1)
+	for k, v := range s.maintenance {
+		state[k] = v
+	}
+	if m == nil {
+		delete(state, repoName)
+	} else {
+		state[repoName] = m
+	}
+	s.maintenance = state
+	return true
+}
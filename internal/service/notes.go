@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/pkg/repo"
+)
+
+// NotesFile 仓库目录中保存包说明的文件，列表中不显示
+const NotesFile = repo.NotesFile
+
+// NoteKey 返回说明的索引：RPM 和 DEB 按包文件名，files 仓库按相对路径
+func NoteKey(repoType string, name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if repoType == string(repo.Files) {
+		return name
+	}
+	return path.Base(name)
+}
+
+// readNotes 读取仓库的全部说明，调用方持有锁
+func readNotes(ctx context.Context, sr repo.StateRepo, repoName string) (map[string]types.PackageNote, error) {
+	var saved types.RepoNotes
+	if err := loadState(ctx, sr, repoName, NotesFile, &saved); err != nil {
+		return nil, err
+	}
+	if saved.Notes == nil {
+		saved.Notes = make(map[string]types.PackageNote)
+	}
+	return saved.Notes, nil
+}
+
+// writeNotes 替换仓库的说明文件，调用方持有锁
+func writeNotes(ctx context.Context, sr repo.StateRepo, repoName string, notes map[string]types.PackageNote) error {
+	return saveState(ctx, sr, repoName, NotesFile, &types.RepoNotes{Notes: notes})
+}
+
+// Notes 返回仓库的全部包说明，按 NoteKey 索引
+func (s *RepoService) Notes(ctx context.Context, repoName string) (map[string]types.PackageNote, error) {
+	_, sr, _, err := s.stateRepo(repoName, "package notes")
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return readNotes(ctx, sr, repoName)
+}
+
+// Note 返回包的说明，没有说明时返回 false
+func (s *RepoService) Note(ctx context.Context, repoName, name string) (types.PackageNote, bool, error) {
+	_, sr, repoType, err := s.stateRepo(repoName, "package notes")
+	if err != nil {
+		return types.PackageNote{}, false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	notes, err := readNotes(ctx, sr, repoName)
+	if err != nil {
+		return types.PackageNote{}, false, err
+	}
+	note, ok := notes[NoteKey(string(repoType), name)]
+	return note, ok, nil
+}
+
+// SetNote 设置包的说明。不检查包是否存在，暂存或等待批准的上传也可以先附加说明
+func (s *RepoService) SetNote(ctx context.Context, repoName, name string, note types.PackageNote) error {
+	_, sr, repoType, err := s.stateRepo(repoName, "package notes")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	notes, err := readNotes(ctx, sr, repoName)
+	if err != nil {
+		return err
+	}
+	notes[NoteKey(string(repoType), name)] = note
+	return writeNotes(ctx, sr, repoName, notes)
+}
+
+// DeleteNote 删除包的说明，没有说明时返回 false
+func (s *RepoService) DeleteNote(ctx context.Context, repoName, name string) (bool, error) {
+	_, sr, repoType, err := s.stateRepo(repoName, "package notes")
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	notes, err := readNotes(ctx, sr, repoName)
+	if err != nil {
+		return false, err
+	}
+	key := NoteKey(string(repoType), name)
+	if _, ok := notes[key]; !ok {
+		return false, nil
+	}
+	delete(notes, key)
+	return true, writeNotes(ctx, sr, repoName, notes)
+}
+
+// dropNotes 删除已删除的包的说明，调用方持有锁。失败只记录日志
+func (s *RepoService) dropNotes(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName string, names []string) {
+	sr, ok := repoInstance.(repo.StateRepo)
+	if !ok {
+		return
+	}
+	notes, err := readNotes(ctx, sr, repoName)
+	if err != nil {
+		log.Logger.Warnf("Failed to read notes of %s: %v", repoName, err)
+		return
+	}
+	changed := false
+	for _, name := range names {
+		key := NoteKey(string(repoType), name)
+		if _, ok := notes[key]; ok {
+			delete(notes, key)
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	if err := writeNotes(ctx, sr, repoName, notes); err != nil {
+		log.Logger.Warnf("Failed to remove notes of deleted packages from %s: %v", repoName, err)
+	}
+}
+
+// PackageExists 检查仓库中是否有该包。RPM 包在 Packages 目录下（源码包在 SRPMS/Packages 下），也可以只写文件名
+func (s *RepoService) PackageExists(ctx context.Context, repoName, name string) (bool, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	location, err := packageLocation(ctx, repoInstance, repoType, repoName, name)
+	return location != "", err
+}
+
+// packageLocation 返回包在仓库中的路径，不存在时为空，调用方持有锁
+func packageLocation(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName, name string) (string, error) {
+	checker, ok := repoInstance.(repo.FileExistsRepo)
+	if !ok {
+		return "", fmt.Errorf("repository type %s cannot check for existing files", repoType)
+	}
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	candidates := []string{name}
+	if repoType == repo.RPM && !strings.Contains(name, "/") {
+		candidates = append(candidates, repo.RPMPackagePath(name))
+		if utils.IsSourceRPM(name) {
+			candidates = append(candidates, path.Join("Packages", name))
+		}
+	}
+	for _, c := range candidates {
+		exists, err := checker.FileExists(ctx, repoName, c)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return c, nil
+		}
+	}
+	return "", nil
+}
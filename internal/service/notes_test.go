@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"plus/internal/types"
+	"plus/pkg/repo/deb"
+)
+
+func TestPackageNotes(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "debs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "debs", "app_1.0_all.deb"), []byte("deb"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := newTestStorage(t, root)
+	s := NewRepoService(deb.NewDEBRepo(store))
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "debs", "deb"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok, err := s.Note(ctx, "debs", "app_1.0_all.deb"); err != nil || ok {
+		t.Fatalf("Note before SetNote = %v, %v", ok, err)
+	}
+	if exists, err := s.PackageExists(ctx, "debs", "app_1.0_all.deb"); err != nil || !exists {
+		t.Fatalf("PackageExists = %v, %v", exists, err)
+	}
+
+	note := types.PackageNote{Text: "## 1.0\n\nFirst release", Source: "CHANGELOG.md", Updated: "2024-01-01T00:00:00Z"}
+	if err := s.SetNote(ctx, "debs", "./app_1.0_all.deb", note); err != nil {
+		t.Fatal(err)
+	}
+	got, ok, err := s.Note(ctx, "debs", "app_1.0_all.deb")
+	if err != nil || !ok || got != note {
+		t.Fatalf("Note = %+v, %v, %v", got, ok, err)
+	}
+
+	// 说明文件不是仓库中的文件
+	files, err := s.ListFiles(ctx, "debs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if f.Path == NotesFile {
+			t.Errorf("ListFiles returned the notes file")
+		}
+	}
+
+	if removed, err := s.DeleteNote(ctx, "debs", "app_1.0_all.deb"); err != nil || !removed {
+		t.Fatalf("DeleteNote = %v, %v", removed, err)
+	}
+	if notes, err := s.Notes(ctx, "debs"); err != nil || len(notes) != 0 {
+		t.Errorf("Notes after DeleteNote = %v, %v", notes, err)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"plus/internal/log"
+	"plus/pkg/repo"
+)
+
+// 覆盖策略：上传的文件名在仓库中已存在时的处理
+const (
+	OverwriteReplace = "overwrite"    // 替换已有文件，默认
+	OverwriteReject  = "reject"       // 拒绝上传
+	OverwriteVersion = "auto-version" // 在扩展名前加构建号另存，如 app-1.0.tar.gz 保存为 app-1.0-1.tar.gz
+)
+
+// ErrFileExists 覆盖策略为 reject 时上传了仓库中已有的文件名
+var ErrFileExists = errors.New("file already exists")
+
+// maxBuildNumber auto-version 尝试的最大构建号
+const maxBuildNumber = 10000
+
+// ValidOverwritePolicy 判断是否为支持的覆盖策略，空字符串表示默认的 overwrite
+func ValidOverwritePolicy(policy string) bool {
+	switch policy {
+	case "", OverwriteReplace, OverwriteReject, OverwriteVersion:
+		return true
+	}
+	return false
+}
+
+// SetOverwritePolicy 设置每个仓库的覆盖策略，未设置时上传替换已有文件
+func (s *RepoService) SetOverwritePolicy(policy func(repoName string) string) {
+	s.overwrite = policy
+}
+
+func (s *RepoService) overwritePolicy(repoName string) string {
+	if s.overwrite == nil {
+		return OverwriteReplace
+	}
+	if policy := s.overwrite(repoName); policy != "" {
+		return policy
+	}
+	return OverwriteReplace
+}
+
+// uploadPath 上传的文件在仓库中的路径，RPM 包保存在 Packages 目录下，源码包保存在 SRPMS/Packages 下
+func uploadPath(repoType repo.RepoType, filename string) string {
+	if repoType == repo.RPM {
+		return repo.RPMPackagePath(filename)
+	}
+	return filename
+}
+
+// versionedName 在扩展名前加构建号，.tar.gz 等双重扩展名保持完整
+func versionedName(filename string, n int) string {
+	dir, base := path.Split(filename)
+	ext := path.Ext(base)
+	if path.Ext(strings.TrimSuffix(base, ext)) == ".tar" {
+		ext = ".tar" + ext
+	}
+	return dir + strings.TrimSuffix(base, ext) + "-" + strconv.Itoa(n) + ext
+}
+
+// uploadName 按仓库的覆盖策略返回上传实际保存的文件名。除仓库外还检查 areas（如暂存区）中的文件，调用方持有写锁
+func (s *RepoService) uploadName(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName, filename string, areas ...string) (string, error) {
+	policy := s.overwritePolicy(repoName)
+	if policy == OverwriteReplace {
+		// 有保留标记的制品不能被覆盖
+		held, err := heldArtifacts(ctx, repoInstance, repoName)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := held[NoteKey(string(repoType), filename)]; ok {
+			return "", fmt.Errorf("%w: %s", ErrLegalHold, filename)
+		}
+		return filename, nil
+	}
+	checker, ok := repoInstance.(repo.FileExistsRepo)
+	if !ok {
+		return "", fmt.Errorf("repository type %s cannot check for existing files", repoType)
+	}
+	exists := func(name string) (bool, error) {
+		for _, r := range append([]string{repoName}, areas...) {
+			found, err := checker.FileExists(ctx, r, uploadPath(repoType, name))
+			if err != nil || found {
+				return found, err
+			}
+		}
+		return false, nil
+	}
+
+	taken, err := exists(filename)
+	if err != nil || !taken {
+		return filename, err
+	}
+	if policy == OverwriteReject {
+		return "", fmt.Errorf("%s in %s: %w", filename, repoName, ErrFileExists)
+	}
+	for n := 1; n <= maxBuildNumber; n++ {
+		name := versionedName(filename, n)
+		if taken, err = exists(name); err != nil {
+			return "", err
+		}
+		if !taken {
+			log.Logger.Infof("%s already exists in %s, storing the upload as %s", filename, repoName, name)
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no free build number for %s in %s", filename, repoName)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestOverwritePolicy(t *testing.T) {
+	s, _ := newFilesService(t)
+	policies := map[string]string{"strict": OverwriteReject, "builds": OverwriteVersion}
+	s.SetOverwritePolicy(func(repoName string) string { return policies[repoName] })
+
+	ctx := context.Background()
+	for _, name := range []string{"open", "strict", "builds"} {
+		if err := s.SetRepoType(ctx, name, "files"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.UploadPackage(ctx, name, "app-1.0.tar.gz", strings.NewReader("v1")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 默认替换已有文件
+	if stored, err := s.UploadPackageAs(ctx, "open", "app-1.0.tar.gz", strings.NewReader("v2")); err != nil || stored != "app-1.0.tar.gz" {
+		t.Fatalf("overwrite = %q, %v", stored, err)
+	}
+	if got := read(t, s, "open", "app-1.0.tar.gz"); got != "v2" {
+		t.Errorf("overwritten content = %q", got)
+	}
+
+	if _, err := s.UploadPackageAs(ctx, "strict", "app-1.0.tar.gz", strings.NewReader("v2")); !errors.Is(err, ErrFileExists) {
+		t.Fatalf("reject: %v", err)
+	}
+	if got := read(t, s, "strict", "app-1.0.tar.gz"); got != "v1" {
+		t.Errorf("rejected upload changed the file: %q", got)
+	}
+	if stored, err := s.UploadPackageAs(ctx, "strict", "app-1.1.tar.gz", strings.NewReader("v1")); err != nil || stored != "app-1.1.tar.gz" {
+		t.Errorf("new file in strict repo = %q, %v", stored, err)
+	}
+
+	// 构建号依次递增，暂存区中的文件同样占用文件名
+	for i, want := range []string{"app-1.0-1.tar.gz", "app-1.0-2.tar.gz"} {
+		stored, err := s.UploadPackageAs(ctx, "builds", "app-1.0.tar.gz", strings.NewReader("v2"))
+		if err != nil || stored != want {
+			t.Fatalf("auto-version %d = %q, %v", i, stored, err)
+		}
+	}
+	if stored, err := s.StagePackageAs(ctx, "builds", "app-1.0.tar.gz", strings.NewReader("v3")); err != nil || stored != "app-1.0-3.tar.gz" {
+		t.Fatalf("staged auto-version = %q, %v", stored, err)
+	}
+	if stored, err := s.StagePackageAs(ctx, "builds", "app-1.0.tar.gz", strings.NewReader("v4")); err != nil || stored != "app-1.0-4.tar.gz" {
+		t.Fatalf("second staged auto-version = %q, %v", stored, err)
+	}
+	if got := read(t, s, "builds", "app-1.0.tar.gz"); got != "v1" {
+		t.Errorf("original file changed: %q", got)
+	}
+}
+
+func TestVersionedName(t *testing.T) {
+	for name, want := range map[string]string{
+		"app-1.0.tar.gz":         "app-1.0-2.tar.gz",
+		"tool.zip":               "tool-2.zip",
+		"README":                 "README-2",
+		"bin/agent.x86_64.rpm":   "bin/agent.x86_64-2.rpm",
+		"nginx_1.24_amd64.deb":   "nginx_1.24_amd64-2.deb",
+		"backup.2024.tar.zst":    "backup.2024-2.tar.zst",
+		"archive.tar":            "archive-2.tar",
+		"libfoo.so.1.tar.bz2":    "libfoo.so.1-2.tar.bz2",
+		"nested/dir/file.tar.xz": "nested/dir/file-2.tar.xz",
+	} {
+		if got := versionedName(name, 2); got != want {
+			t.Errorf("versionedName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func read(t *testing.T, s *RepoService, repoName, filename string) string {
+	t.Helper()
+	r, err := s.DownloadPackage(context.Background(), repoName, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	data, _ := io.ReadAll(r)
+	return string(data)
+}
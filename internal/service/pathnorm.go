@@ -0,0 +1,23 @@
+package service
+
+import "plus/internal/pathnorm"
+
+// SetPathNormalizer 设置文件名规范化，上传的文件按规范化后的名称保存，
+// 查找时先按请求的名称，找不到再按规范化后的名称，规范化之前上传的文件仍可访问
+func (s *RepoService) SetPathNormalizer(n *pathnorm.Normalizer) {
+	s.paths = n
+}
+
+// PathNormalizer 返回文件名规范化，未启用时为 nil
+func (s *RepoService) PathNormalizer() *pathnorm.Normalizer {
+	return s.paths
+}
+
+// normalizedName 按请求的名称查找失败时返回规范化后的名称，名称不变或未启用时返回 false
+func (s *RepoService) normalizedName(filename string, err error) (string, bool) {
+	if err == nil || s.paths == nil {
+		return "", false
+	}
+	name := s.paths.Name(filename)
+	return name, name != filename
+}
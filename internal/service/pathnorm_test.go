@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"plus/internal/pathnorm"
+)
+
+func TestPathNormalizer(t *testing.T) {
+	s, _ := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "tools", "files"); err != nil {
+		t.Fatal(err)
+	}
+	// 启用之前上传的文件保持原名
+	if err := s.UploadPackage(ctx, "tools", "Legacy.TXT", strings.NewReader("old")); err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetPathNormalizer(&pathnorm.Normalizer{Lowercase: true})
+	stored, err := s.UploadPackageAs(ctx, "tools", "Cafe\u0301.TXT", strings.NewReader("v1"))
+	if err != nil || stored != "caf\u00e9.txt" {
+		t.Fatalf("upload = %q, %v", stored, err)
+	}
+	if _, err := s.UploadPackageAs(ctx, "tools", "CAF\u00c9.txt", strings.NewReader("v2")); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"caf\u00e9.txt", "Cafe\u0301.TXT", "CAF\u00c9.TXT"} {
+		if got := read(t, s, "tools", name); got != "v2" {
+			t.Errorf("%+q = %q", name, got)
+		}
+	}
+	if got := read(t, s, "tools", "Legacy.TXT"); got != "old" {
+		t.Errorf("file uploaded before normalization = %q", got)
+	}
+	if info, err := s.StatPackageFile(ctx, "tools", "CAFE\u0301.txt"); err != nil || info.Size != 2 {
+		t.Errorf("stat = %+v, %v", info, err)
+	}
+	if _, err := s.GetPackageChecksum(ctx, "tools", "Caf\u00e9.Txt"); err != nil {
+		t.Errorf("checksum: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+// RepoReadme 返回仓库说明，没有设置时 ok 为 false
+func (s *RepoService) RepoReadme(ctx context.Context, repoName string) (types.RepoReadme, bool, error) {
+	_, sr, _, err := s.stateRepo(repoName, "a readme")
+	if err != nil {
+		return types.RepoReadme{}, false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var readme types.RepoReadme
+	if err := loadState(ctx, sr, repoName, repo.ReadmeFile, &readme); err != nil {
+		return types.RepoReadme{}, false, err
+	}
+	// 删除后保存为空说明
+	return readme, readme.Text != "", nil
+}
+
+// SetRepoReadme 设置仓库说明，Text 为空时删除
+func (s *RepoService) SetRepoReadme(ctx context.Context, repoName string, readme types.RepoReadme) error {
+	_, sr, _, err := s.stateRepo(repoName, "a readme")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return saveState(ctx, sr, repoName, repo.ReadmeFile, &readme)
+}
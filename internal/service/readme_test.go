@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+func TestRepoReadme(t *testing.T) {
+	s, _ := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "tools", "files"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "tools", "cli.tar.gz", strings.NewReader("cli")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := s.RepoReadme(ctx, "tools"); ok || err != nil {
+		t.Errorf("RepoReadme before set = %v, %v", ok, err)
+	}
+
+	readme := types.RepoReadme{Text: "# Tools\n\ncurl {{base_url}}cli.tar.gz\n", Author: "alice", Updated: "2024-01-01T00:00:00Z"}
+	if err := s.SetRepoReadme(ctx, "tools", readme); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok, err := s.RepoReadme(ctx, "tools"); !ok || err != nil || got != readme {
+		t.Errorf("RepoReadme = %+v, %v, %v", got, ok, err)
+	}
+
+	// 说明文件不是仓库中的文件
+	list, err := s.ListFiles(ctx, "tools")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Path == repo.ReadmeFile {
+		t.Errorf("ListFiles = %+v", list)
+	}
+
+	if err := s.SetRepoReadme(ctx, "tools", types.RepoReadme{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := s.RepoReadme(ctx, "tools"); ok || err != nil {
+		t.Errorf("RepoReadme after delete = %v, %v", ok, err)
+	}
+}
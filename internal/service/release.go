@@ -0,0 +1,12 @@
+package service
+
+import "plus/pkg/repo"
+
+// SetReleaseFields 设置 DEB 仓库 Release 文件的字段，刷新元数据时写入
+func (s *RepoService) SetReleaseFields(fields func(repoName string) repo.ReleaseFields) {
+	for _, r := range s.repos {
+		if releaser, ok := r.(repo.ReleaseRepo); ok {
+			releaser.SetReleaseFields(fields)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/pkg/repo"
+	"plus/pkg/repo/deb"
+)
+
+func TestDEBRelease(t *testing.T) {
+	if _, err := exec.LookPath("dpkg-scanpackages"); err != nil {
+		t.Skip("dpkg-scanpackages not installed")
+	}
+	root := t.TempDir()
+	store := newTestStorage(t, root)
+	s := NewRepoService(deb.NewDEBRepo(store))
+	fields := repo.ReleaseFields{Origin: "Example", Label: "Example Tools", Codename: "bookworm"}
+	s.SetReleaseFields(func(repoName string) repo.ReleaseFields { return fields })
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "debs", "deb"); err != nil {
+		t.Fatal(err)
+	}
+	hello := testDEB("hello", "1.0-1", "amd64")
+	if err := s.UploadPackage(ctx, "debs", "hello_1.0-1_amd64.deb", bytes.NewReader(hello)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RefreshMetadata(ctx, "debs"); err != nil {
+		t.Fatal(err)
+	}
+
+	readFile := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(root, "debs", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	if got, want := readFile("SHA256SUMS"), fmt.Sprintf("%x  hello_1.0-1_amd64.deb\n", sha256.Sum256(hello)); got != want {
+		t.Errorf("SHA256SUMS = %q, want %q", got, want)
+	}
+	release := readFile("Release")
+	packages := readFile("Packages")
+	for _, want := range []string{
+		"Origin: Example\nLabel: Example Tools\nCodename: bookworm\nDate: ",
+		"Architectures: amd64\n",
+		fmt.Sprintf("SHA256:\n %x %d Packages\n", sha256.Sum256([]byte(packages)), len(packages)),
+	} {
+		if !strings.Contains(release, want) {
+			t.Errorf("Release does not contain %q:\n%s", want, release)
+		}
+	}
+	if strings.Contains(release, "Suite:") || strings.Contains(release, "Valid-Until:") {
+		t.Errorf("Release has unset fields:\n%s", release)
+	}
+
+	// 元数据没有变化时保留原来的 Release
+	date := release[strings.Index(release, "Date: "):strings.Index(release, "Architectures:")]
+	old := strings.Replace(release, date, "Date: Mon, 01 Jan 2024 00:00:00 UTC\n", 1)
+	if err := os.WriteFile(filepath.Join(root, "debs", "Release"), []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RefreshMetadata(ctx, "debs"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile("Release"); got != old {
+		t.Errorf("unchanged refresh rewrote Release:\n%s", got)
+	}
+
+	// 配置了有效期时每次刷新都重新生成
+	fields.ValidFor = 7 * 24 * time.Hour
+	if err := s.RefreshMetadata(ctx, "debs"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile("Release"); got == old || !strings.Contains(got, "Valid-Until: ") {
+		t.Errorf("Release with valid-until:\n%s", got)
+	}
+}
+
+func TestDEBReleaseSignatures(t *testing.T) {
+	if _, err := exec.LookPath("dpkg-scanpackages"); err != nil {
+		t.Skip("dpkg-scanpackages not installed")
+	}
+	root := t.TempDir()
+	store := newTestStorage(t, root)
+	s := NewRepoService(deb.NewDEBRepo(store))
+	signs := 0
+	fields := repo.ReleaseFields{
+		Origin: "Example",
+		Sign: func(data []byte) ([]byte, error) {
+			signs++
+			return append([]byte("detached:"), data...), nil
+		},
+		ClearSign: func(data []byte) ([]byte, error) {
+			return append([]byte("clear:"), data...), nil
+		},
+	}
+	s.SetReleaseFields(func(repoName string) repo.ReleaseFields { return fields })
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "debs", "deb"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "debs", "hello_1.0-1_amd64.deb", bytes.NewReader(testDEB("hello", "1.0-1", "amd64"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RefreshMetadata(ctx, "debs"); err != nil {
+		t.Fatal(err)
+	}
+
+	readFile := func(name string) (string, bool) {
+		data, err := os.ReadFile(filepath.Join(root, "debs", name))
+		return string(data), err == nil
+	}
+	release, _ := readFile("Release")
+	if got, ok := readFile("InRelease"); !ok || got != "clear:"+release {
+		t.Errorf("InRelease = %q, want clearsigned Release", got)
+	}
+	if got, ok := readFile("Release.gpg"); !ok || got != "detached:"+release {
+		t.Errorf("Release.gpg = %q, want signature of Release", got)
+	}
+
+	// Release 没有变化时不重新签名
+	if err := s.RefreshMetadata(ctx, "debs"); err != nil {
+		t.Fatal(err)
+	}
+	if signs != 1 {
+		t.Errorf("Release signed %d times, want 1", signs)
+	}
+
+	// 不再签名时删除旧的签名
+	fields.Sign, fields.ClearSign = nil, nil
+	if err := s.RefreshMetadata(ctx, "debs"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"InRelease", "Release.gpg"} {
+		if _, ok := readFile(name); ok {
+			t.Errorf("%s kept after signing was turned off", name)
+		}
+	}
+}
+
+// testDEB 生成只有 control 文件的 DEB 包
+func testDEB(name, version, arch string) []byte {
+	return testDEBControl(fmt.Sprintf("Package: %s\nVersion: %s\nArchitecture: %s\nMaintainer: Test <test@example.com>\nDescription: test package\n", name, version, arch))
+}
+
+// testDEBControl 生成 control 文件内容为 control 的 DEB 包
+func testDEBControl(control string) []byte {
+	targz := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for name, body := range files {
+			tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(body))})
+			tw.Write([]byte(body))
+		}
+		tw.Close()
+		zw.Close()
+		return buf.Bytes()
+	}
+	var buf bytes.Buffer
+	buf.WriteString("!<arch>\n")
+	for _, member := range []struct {
+		name string
+		data []byte
+	}{
+		{"debian-binary", []byte("2.0\n")},
+		{"control.tar.gz", targz(map[string]string{"./control": control})},
+		{"data.tar.gz", targz(nil)},
+	} {
+		fmt.Fprintf(&buf, "%-16s%-12d%-6d%-6d%-8s%-10d`\n", member.name, 0, 0, 0, "100644", len(member.data))
+		buf.Write(member.data)
+		if len(member.data)%2 == 1 {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"plus/internal/journal"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+// ApplyRetention 每个包（按 name/arch）只保留元数据中最新的 keep 个版本，删除其余版本后刷新元数据。
+// 尚未刷新进元数据的包不参与比较。返回删除的文件数
+func (s *RepoService) ApplyRetention(ctx context.Context, repoName string, keep int) (int, error) {
+	if keep < 1 {
+		return 0, fmt.Errorf("retention must keep at least one version")
+	}
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return 0, err
+	}
+	deleter, ok := repoInstance.(repo.DeleteFileRepo)
+	if !ok {
+		return 0, fmt.Errorf("repository type %s does not support retention", repoType)
+	}
+	ix, err := s.DependencyIndex(ctx, repoName)
+	if err != nil {
+		return 0, err
+	}
+	old := ix.Superseded(keep)
+	if len(old) == 0 {
+		return 0, nil
+	}
+
+	locations := make([]string, len(old))
+	for i, p := range old {
+		locations[i] = p.Location
+	}
+	done, err := s.beginOp(types.JournalEntry{Op: journal.OpRetention, Repo: repoName, Files: locations})
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+
+	var removed []string
+	s.mu.Lock()
+	// 有保留标记的旧版本不删除
+	held, err := heldArtifacts(ctx, repoInstance, repoName)
+	for _, p := range old {
+		if err != nil {
+			break
+		}
+		if _, ok := held[NoteKey(string(repoType), p.Location)]; ok {
+			continue
+		}
+		if err = deleter.DeleteFile(ctx, repoName, p.Location); err != nil {
+			err = fmt.Errorf("delete %s: %w", p.Location, err)
+			break
+		}
+		removed = append(removed, p.Location)
+	}
+	s.dropNotes(ctx, repoInstance, repoType, repoName, removed)
+	s.dropAttestations(ctx, repoInstance, repoType, repoName, removed)
+	s.mu.Unlock()
+
+	// 部分删除失败时同样需要刷新，避免元数据指向已删除的文件
+	if len(removed) > 0 {
+		log.Logger.Infof("Retention removed %d old package versions from %s (keeping %d)", len(removed), repoName, keep)
+		if refreshErr := s.RefreshMetadata(ctx, repoName); refreshErr != nil && err == nil {
+			err = refreshErr
+		}
+		for _, fn := range s.retentionHooks {
+			fn(repoName, removed)
+		}
+	}
+	return len(removed), err
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"plus/pkg/repo"
+
+	"github.com/cavaliergopher/rpm"
+)
+
+var (
+	// ErrInvalidPackage 上传的 RPM 包无法读取包头
+	ErrInvalidPackage = errors.New("not a valid RPM package")
+	// ErrPackageMismatch RPM 包的文件名与包头中的 name-version-release.arch 不一致
+	ErrPackageMismatch = errors.New("file name does not match the package header")
+)
+
+// checkRPMHeader 读取上传的 RPM 包头，文件名与包头中的 name-version-release.arch 不一致时拒绝上传，
+// 避免改名的包写入错误的元数据。返回的 reader 包含已读取的包头，其他类型的仓库原样返回
+func (s *RepoService) checkRPMHeader(repoType repo.RepoType, filename string, reader io.Reader) (io.Reader, error) {
+	if repoType != repo.RPM {
+		return reader, nil
+	}
+	header := &bytes.Buffer{}
+	pkg, err := rpm.Read(fullReader{io.TeeReader(reader, header)})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPackage, filename, err)
+	}
+	base := path.Base(filename)
+	names := headerNames(pkg)
+	for _, name := range names {
+		if s.paths.Name(name) == base {
+			return io.MultiReader(header, reader), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s, the header is %s", ErrPackageMismatch, base, names[0])
+}
+
+// headerNames 按包头生成的文件名，源码包的架构为 src 或 nosrc
+func headerNames(pkg *rpm.Package) []string {
+	nvr := pkg.Name() + "-" + pkg.Version() + "-" + pkg.Release()
+	if pkg.SourceRPM() == "" {
+		return []string{nvr + ".src.rpm", nvr + ".nosrc.rpm"}
+	}
+	return []string{nvr + "." + pkg.Architecture() + ".rpm"}
+}
+
+// fullReader rpm.Read 按固定长度读取包头结构，读取上传的流时每次读满
+type fullReader struct {
+	io.Reader
+}
+
+func (r fullReader) Read(p []byte) (int, error) {
+	return io.ReadFull(r.Reader, p)
+}
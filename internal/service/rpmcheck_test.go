@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"plus/internal/pathnorm"
+	"plus/pkg/repo/rpm"
+)
+
+func TestRPMHeaderCheck(t *testing.T) {
+	dir := t.TempDir()
+	store := newTestStorage(t, dir)
+	s := NewRepoService(rpm.NewRPMRepo(store))
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "el9", "rpm"); err != nil {
+		t.Fatal(err)
+	}
+	nginx := testRPM("nginx", "1.24.0", "1.el9", "x86_64", "nginx-1.24.0-1.el9.src.rpm")
+
+	if err := s.UploadPackage(ctx, "el9", "nginx-1.24.0-1.el9.x86_64.rpm", bytes.NewReader(nginx)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := os.ReadFile(filepath.Join(dir, "el9/Packages/nginx-1.24.0-1.el9.x86_64.rpm")); err != nil || !bytes.Equal(got, nginx) {
+		t.Errorf("stored package differs from the upload (%d of %d bytes): %v", len(got), len(nginx), err)
+	}
+	for _, name := range []string{"nginx-1.25.0-1.el9.x86_64.rpm", "nginx-1.24.0-1.el9.noarch.rpm", "httpd-1.24.0-1.el9.x86_64.rpm"} {
+		if err := s.UploadPackage(ctx, "el9", name, bytes.NewReader(nginx)); !errors.Is(err, ErrPackageMismatch) {
+			t.Errorf("%s: %v", name, err)
+		}
+		if _, err := s.StagePackageAs(ctx, "el9", name, bytes.NewReader(nginx)); !errors.Is(err, ErrPackageMismatch) {
+			t.Errorf("staged %s: %v", name, err)
+		}
+	}
+	if err := s.UploadPackage(ctx, "el9", "junk-1.0-1.x86_64.rpm", bytes.NewReader([]byte("not an rpm"))); !errors.Is(err, ErrInvalidPackage) {
+		t.Errorf("junk: %v", err)
+	}
+
+	// 源码包没有 SOURCERPM，架构为 src
+	src := testRPM("nginx", "1.24.0", "1.el9", "x86_64", "")
+	if err := s.UploadPackage(ctx, "el9", "nginx-1.24.0-1.el9.src.rpm", bytes.NewReader(src)); err != nil {
+		t.Errorf("source package: %v", err)
+	}
+
+	// 规范化为小写时与小写后的包头比较
+	s.SetPathNormalizer(&pathnorm.Normalizer{Lowercase: true})
+	upper := testRPM("NetworkManager", "1.46.0", "1.el9", "x86_64", "NetworkManager-1.46.0-1.el9.src.rpm")
+	if stored, err := s.UploadPackageAs(ctx, "el9", "NetworkManager-1.46.0-1.el9.x86_64.rpm", bytes.NewReader(upper)); err != nil || stored != "networkmanager-1.46.0-1.el9.x86_64.rpm" {
+		t.Errorf("normalized upload = %q, %v", stored, err)
+	}
+}
+
+// testRPM 生成只有 NAME、VERSION、RELEASE、ARCH 和 SOURCERPM 标签的 RPM 包，后接一段载荷
+func testRPM(name, version, release, arch, sourceRPM string) []byte {
+	var buf bytes.Buffer
+	lead := make([]byte, 96)
+	copy(lead, []byte{0xED, 0xAB, 0xEE, 0xDB, 3, 0})
+	buf.Write(lead)
+
+	header := func(tags map[uint32]string) {
+		var index, store bytes.Buffer
+		for _, tag := range []uint32{1000, 1001, 1002, 1022, 1044} {
+			value, ok := tags[tag]
+			if !ok {
+				continue
+			}
+			binary.Write(&index, binary.BigEndian, [4]uint32{tag, 6, uint32(store.Len()), 1})
+			store.WriteString(value + "\x00")
+		}
+		buf.Write([]byte{0x8E, 0xAD, 0xE8, 0x01, 0, 0, 0, 0})
+		binary.Write(&buf, binary.BigEndian, [2]uint32{uint32(index.Len() / 16), uint32(store.Len())})
+		buf.Write(index.Bytes())
+		buf.Write(store.Bytes())
+	}
+	header(nil) // 空的签名头，长度为 0 无需对齐
+	tags := map[uint32]string{1000: name, 1001: version, 1002: release, 1022: arch}
+	if sourceRPM != "" {
+		tags[1044] = sourceRPM
+	}
+	header(tags)
+	buf.WriteString("payload")
+	return buf.Bytes()
+}
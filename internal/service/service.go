@@ -2,14 +2,30 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"strings"
 	"sync"
-
+	"time"
+
+	"plus/internal/attest"
+	"plus/internal/cluster"
+	"plus/internal/deps"
+	"plus/internal/filepolicy"
+	"plus/internal/fulltext"
+	"plus/internal/index"
+	"plus/internal/journal"
+	"plus/internal/license"
 	"plus/internal/log"
+	"plus/internal/pathnorm"
 	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/internal/worker"
 	"plus/pkg/repo"
+	"plus/pkg/storage"
 )
 
 type RepoService struct {
@@ -17,6 +33,44 @@ type RepoService struct {
 	repoTypes   map[string]repo.RepoType    // 存储每个仓库名对应的类型
 	repoConfigs map[string]string           // 存储仓库配置信息（如描述等）
 	mu          sync.RWMutex
+
+	depIndexes map[string]*deps.Index // 每个仓库的依赖索引，刷新元数据时重建
+	depMu      sync.Mutex
+	text       *fulltext.Index // 包摘要和描述的全文索引，与依赖索引一起建立
+
+	changeHooks    []func(repoName string, deleted bool)
+	publishHooks   []func(repoName string, files []string)
+	retentionHooks []func(repoName string, removed []string)
+
+	pool           *worker.Pool
+	checksums      map[string]string // repo/filename -> SHA256，上传后在后台计算
+	pendingRefresh map[string]bool   // 已排队尚未开始的元数据刷新
+	bgMu           sync.Mutex
+
+	index *index.Index // 制品索引，为 nil 时列表和搜索直接遍历存储
+
+	cluster *cluster.Cluster // 多实例共享的仓库注册表和锁，单实例时为 nil
+
+	maintenance map[string]*types.Maintenance // 处于维护状态的仓库，只整体替换
+	maintLoaded time.Time                     // 上次从共享注册表读取维护状态的时间
+	maintMu     sync.Mutex
+
+	generations func(repoName string) int // 每个仓库保留的元数据历史版本数
+
+	journal *journal.Journal // 多步操作的写前日志，为 nil 时不记录
+
+	overwrite func(repoName string) string // 每个仓库的覆盖策略
+
+	paths *pathnorm.Normalizer // 上传和查找时规范化文件名，为 nil 时不处理
+
+	filenames func(repoName string) *filepolicy.Policy // 每个仓库的文件名规则
+
+	licenses func(repoName string) *license.Policy // 每个仓库禁止的许可证
+
+	attestKeys      *attest.Verifier // 验证来源证明签名的公钥，为 nil 时不验证
+	requireVerified bool             // 拒绝没有有效签名的来源证明
+
+	backends func(repo.RepoType) storage.Storage // 按仓库类型返回存储后端，用于下载重定向
 }
 
 func NewRepoService(repos ...repo.Repo) *RepoService {
@@ -24,6 +78,11 @@ func NewRepoService(repos ...repo.Repo) *RepoService {
 		repos:       make(map[repo.RepoType]repo.Repo),
 		repoTypes:   make(map[string]repo.RepoType),
 		repoConfigs: make(map[string]string),
+		depIndexes:  make(map[string]*deps.Index),
+		text:        fulltext.New(),
+
+		checksums:      make(map[string]string),
+		pendingRefresh: make(map[string]bool),
 	}
 	
 	// 注册所有类型的 repo
@@ -35,6 +94,34 @@ func NewRepoService(repos ...repo.Repo) *RepoService {
 	return rs
 }
 
+// OnChange 注册仓库元数据刷新或仓库删除后的回调，需在服务启动前注册
+func (s *RepoService) OnChange(fn func(repoName string, deleted bool)) {
+	s.changeHooks = append(s.changeHooks, fn)
+}
+
+func (s *RepoService) notifyChange(repoName string, deleted bool) {
+	for _, fn := range s.changeHooks {
+		fn(repoName, deleted)
+	}
+}
+
+// OnPublish 注册文件进入仓库后的回调：上传、发布暂存区、放入批准的上传。
+// 需在服务启动前注册；回调可能在持有仓库锁时调用，不能阻塞或调用服务
+func (s *RepoService) OnPublish(fn func(repoName string, files []string)) {
+	s.publishHooks = append(s.publishHooks, fn)
+}
+
+func (s *RepoService) notifyPublish(repoName string, files []string) {
+	for _, fn := range s.publishHooks {
+		fn(repoName, files)
+	}
+}
+
+// OnRetention 注册保留策略删除旧版本后的回调，需在服务启动前注册
+func (s *RepoService) OnRetention(fn func(repoName string, removed []string)) {
+	s.retentionHooks = append(s.retentionHooks, fn)
+}
+
 // 获取指定仓库的 repo 实例
 func (s *RepoService) getRepoInstance(repoName string) (repo.Repo, repo.RepoType, error) {
 	s.mu.RLock()
@@ -66,6 +153,14 @@ func (s *RepoService) getRepoInstance(repoName string) (repo.Repo, repo.RepoType
 
 // 推断仓库类型
 func (s *RepoService) inferRepoType(repoName string) (repo.RepoType, error) {
+	if s.index != nil {
+		if typ, ok := s.index.RepoType(repoName); ok {
+			return repo.RepoType(typ), nil
+		}
+	}
+	if typ, ok := s.registeredRepoType(repoName); ok {
+		return typ, nil
+	}
 	// 尝试从不同类型的 repo 中查找
 	for repoType, repoInstance := range s.repos {
 		log.Logger.Debugf("Checking repo type and instance: %s\n", repoType)
@@ -86,21 +181,68 @@ func (s *RepoService) inferRepoType(repoName string) (repo.RepoType, error) {
 }
 
 func (s *RepoService) UploadPackage(ctx context.Context, repoName string, filename string, reader io.Reader) error {
+	_, err := s.UploadPackageAs(ctx, repoName, filename, reader)
+	return err
+}
+
+// UploadPackageAs 上传包并返回保存的文件名。文件名已存在时按仓库的覆盖策略替换、拒绝（ErrFileExists）或加构建号另存
+func (s *RepoService) UploadPackageAs(ctx context.Context, repoName string, filename string, reader io.Reader) (string, error) {
 	repoInstance, repoType, err := s.getRepoInstance(repoName)
 	if err != nil {
-		return err
+		return "", err
 	}
-	
-	// 验证文件类型
-	if err := s.validateFileType(filename, repoType); err != nil {
-		return err
+	filename, reader, err = s.validateUpload(ctx, repoInstance, repoType, repoName, filename, reader)
+	if err != nil {
+		return "", err
 	}
-	
-	s.mu.Lock()
 	defer s.mu.Unlock()
-	
+
 	log.Logger.Debugf("Uploading %s to %s repository: %s", filename, repoType, repoName)
-	return repoInstance.UploadPackage(ctx, repoName, filename, reader)
+	counter := &countingReader{Reader: reader}
+	var sum hash.Hash
+	if repoType == repo.DEB {
+		// Packages 中的校验和在刷新元数据后才有，DEB 包在上传时计算
+		sum = sha256.New()
+		counter.Reader = io.TeeReader(reader, sum)
+	}
+	if err := repoInstance.UploadPackage(ctx, repoName, filename, counter); err != nil {
+		return "", err
+	}
+	s.indexUpload(repoName, repoType, filename, counter.n)
+	s.updateSums(ctx, repoInstance, repoName, []string{filename})
+	if sum != nil {
+		s.storeChecksum(repoName, filename, hex.EncodeToString(sum.Sum(nil)))
+	} else {
+		s.afterUpload(repoName, filename)
+	}
+	s.notifyPublish(repoName, []string{filename})
+	return filename, nil
+}
+
+// validateUpload 规范化文件名，依次检查文件类型、文件名策略、RPM 头和许可证，再按覆盖策略确定保存的文件名
+// （除仓库外还检查 areas 中的文件）。检查时不持有锁；成功返回时持有 s.mu 写锁，由调用方释放
+func (s *RepoService) validateUpload(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName, filename string, reader io.Reader, areas ...string) (string, io.Reader, error) {
+	filename = s.paths.Name(filename)
+	if err := s.validateFileType(filename, repoType); err != nil {
+		return "", nil, err
+	}
+	if err := s.checkFilename(repoName, filename); err != nil {
+		return "", nil, err
+	}
+	reader, err := s.checkRPMHeader(repoType, filename, reader)
+	if err != nil {
+		return "", nil, err
+	}
+	if reader, err = s.checkLicense(repoName, filename, reader); err != nil {
+		return "", nil, err
+	}
+
+	s.mu.Lock()
+	if filename, err = s.uploadName(ctx, repoInstance, repoType, repoName, filename, areas...); err != nil {
+		s.mu.Unlock()
+		return "", nil, err
+	}
+	return filename, reader, nil
 }
 
 func (s *RepoService) DownloadPackage(ctx context.Context, repoName string, filename string) (io.ReadCloser, error) {
@@ -114,11 +256,45 @@ func (s *RepoService) DownloadPackage(ctx context.Context, repoName string, file
 
 	log.Logger.Debugf("Downloading %s from types %s", filename, repoInstance.Type())
 	
-	return repoInstance.DownloadPackage(ctx, repoName, filename)
+	reader, err := repoInstance.DownloadPackage(ctx, repoName, filename)
+	if name, ok := s.normalizedName(filename, err); ok {
+		return repoInstance.DownloadPackage(ctx, repoName, name)
+	}
+	return reader, err
 }
 
 func (s *RepoService) DownloadPackageFiles(ctx context.Context, repoName string, filename string) (io.ReadCloser, error) {
-	return s.repos[repo.Files].DownloadPackage(ctx, repoName, filename)
+	reader, err := s.repos[repo.Files].DownloadPackage(ctx, repoName, filename)
+	if name, ok := s.normalizedName(filename, err); ok {
+		return s.repos[repo.Files].DownloadPackage(ctx, repoName, name)
+	}
+	return reader, err
+}
+
+// StatPackageFile 获取 files 仓库中文件的大小和修改时间
+func (s *RepoService) StatPackageFile(ctx context.Context, repoName string, filename string) (storage.FileInfo, error) {
+	rangeRepo, ok := s.repos[repo.Files].(repo.RangeRepo)
+	if !ok {
+		return storage.FileInfo{}, fmt.Errorf("files repository does not support stat")
+	}
+	info, err := rangeRepo.StatFile(ctx, repoName, filename)
+	if name, ok := s.normalizedName(filename, err); ok {
+		return rangeRepo.StatFile(ctx, repoName, name)
+	}
+	return info, err
+}
+
+// OpenPackageFileRange 读取 files 仓库中文件的一部分
+func (s *RepoService) OpenPackageFileRange(ctx context.Context, repoName string, filename string, offset, length int64) (io.ReadCloser, error) {
+	rangeRepo, ok := s.repos[repo.Files].(repo.RangeRepo)
+	if !ok {
+		return nil, fmt.Errorf("files repository does not support range reads")
+	}
+	reader, err := rangeRepo.OpenFileRange(ctx, repoName, filename, offset, length)
+	if name, ok := s.normalizedName(filename, err); ok {
+		return rangeRepo.OpenFileRange(ctx, repoName, name, offset, length)
+	}
+	return reader, err
 }
 
 func (s *RepoService) RefreshMetadata(ctx context.Context, repoName string) error {
@@ -127,11 +303,50 @@ func (s *RepoService) RefreshMetadata(ctx context.Context, repoName string) erro
 		return err
 	}
 	
+	// 多实例部署时同一仓库只有一个实例生成元数据，锁在 s.mu 之外获取，等待期间不阻塞其他请求
+	if s.cluster != nil {
+		refreshed, err := s.cluster.RefreshMetadata(ctx, repoName, time.Now(), func(ctx context.Context) error {
+			return s.refreshMetadata(ctx, repoInstance, repoType, repoName)
+		})
+		if err == nil && !refreshed {
+			// 其他实例已生成元数据，只更新本实例的派生数据
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.afterRefresh(ctx, repoInstance, repoType, repoName)
+		}
+		return err
+	}
+	return s.refreshMetadata(ctx, repoInstance, repoType, repoName)
+}
+
+func (s *RepoService) refreshMetadata(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	
 	log.Logger.Debugf("Refreshing metadata for %s repository: %s", repoType, repoName)
-	return repoInstance.RefreshMetadata(ctx, repoName)
+	if err := repoInstance.RefreshMetadata(ctx, repoName); err != nil {
+		return err
+	}
+	s.afterRefresh(ctx, repoInstance, repoType, repoName)
+	return nil
+}
+
+// afterRefresh 元数据变化后更新索引、依赖索引并通知缓存，调用方持有写锁
+func (s *RepoService) afterRefresh(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, repoName string) {
+	if s.index != nil {
+		s.reindexLocked(ctx, repoInstance, repoType, repoName)
+	}
+
+	// 元数据变化后重建依赖索引
+	if depRepo, ok := repoInstance.(repo.DependencyRepo); ok {
+		if _, err := s.buildDependencyIndex(ctx, depRepo, repoType, repoName); err != nil {
+			log.Logger.Warnf("Failed to build dependency index for %s: %v", repoName, err)
+		}
+	}
+	if err := s.recordGeneration(ctx, repoInstance, repoType, repoName); err != nil {
+		log.Logger.Warnf("Failed to save metadata generation of %s: %v", repoName, err)
+	}
+	s.notifyChange(repoName, false)
 }
 
 func (s *RepoService) GetMetadata(ctx context.Context, repoName string, filename string) (io.ReadCloser, error) {
@@ -147,30 +362,54 @@ func (s *RepoService) GetMetadata(ctx context.Context, repoName string, filename
 }
 
 func (s *RepoService) ListPackages(ctx context.Context, repoName string) ([]types.PackageInfo, error) {
-	repoInstance, _, err := s.getRepoInstance(repoName)
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
 	if err != nil {
 		return nil, err
 	}
+	if packages, ok := s.indexedPackages(repoName); ok {
+		return packages, nil
+	}
 	
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	
+	if s.index != nil {
+		// 仓库尚未进入索引：遍历一次存储并写入索引
+		start := time.Now()
+		artifacts, err := s.scanArtifacts(ctx, repoInstance, repoType, repoName)
+		if err != nil {
+			return nil, err
+		}
+		s.index.ReplaceRepo(repoName, string(repoType), artifacts, start)
+		if packages, ok := s.indexedPackages(repoName); ok {
+			return packages, nil
+		}
+	}
 	return repoInstance.ListPackages(ctx, repoName)
 }
 
-// 修改：添加类型参数
-func (s *RepoService) CreateRepo(ctx context.Context, repoName string, repoTypeStr string) error {
-	// 解析仓库类型
-	var repoType repo.RepoType
+// parseRepoType 解析仓库类型，插件注册的类型区分大小写
+func (s *RepoService) parseRepoType(repoTypeStr string) (repo.RepoType, error) {
 	switch strings.ToLower(repoTypeStr) {
 	case "rpm":
-		repoType = repo.RPM
+		return repo.RPM, nil
 	case "deb":
-		repoType = repo.DEB
+		return repo.DEB, nil
 	case "files":
-		repoType = repo.Files
-	default:
-		return fmt.Errorf("unsupported repository type: %s", repoTypeStr)
+		return repo.Files, nil
+	}
+	if _, ok := s.repos[repo.RepoType(repoTypeStr)]; ok {
+		return repo.RepoType(repoTypeStr), nil
+	}
+	return "", fmt.Errorf("unsupported repository type: %s", repoTypeStr)
+}
+
+// 修改：添加类型参数
+func (s *RepoService) CreateRepo(ctx context.Context, repoName string, repoTypeStr string) error {
+	// 解析仓库类型
+	repoType, err := s.parseRepoType(repoTypeStr)
+	if err != nil {
+		return err
 	}
 	
 	repoInstance, exists := s.repos[repoType]
@@ -188,6 +427,10 @@ func (s *RepoService) CreateRepo(ctx context.Context, repoName string, repoTypeS
 	
 	// 记录仓库类型
 	s.repoTypes[repoName] = repoType
+	if s.index != nil {
+		s.index.PutRepo(repoName, string(repoType))
+	}
+	s.registerRepo(ctx, repoName, repoType)
 	
 	log.Logger.Debugf("Created %s repository: %s", repoType, repoName)
 	return nil
@@ -202,15 +445,36 @@ func (s *RepoService) DeleteRepo(ctx context.Context, repoName string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	
+	// 仓库中有保留标记的制品时不能删除仓库
+	held, err := heldArtifacts(ctx, repoInstance, repoName)
+	if err != nil {
+		return err
+	}
+	if len(held) > 0 {
+		return fmt.Errorf("%w: %d artifacts in %s are held", ErrLegalHold, len(held), repoName)
+	}
 	if err := repoInstance.DeleteRepo(ctx, repoName); err != nil {
 		return err
 	}
+	s.dropGenerations(ctx, repoInstance, repoName)
 	
 	// 清理类型记录
 	delete(s.repoTypes, repoName)
 	delete(s.repoConfigs, repoName)
+
+	s.depMu.Lock()
+	delete(s.depIndexes, repoName)
+	s.depMu.Unlock()
+	s.text.Drop(repoName)
+	s.forgetChecksums(repoName)
+	if s.index != nil {
+		s.index.DropRepo(repoName)
+	}
+	s.updateMaintenance(repoName, nil)
+	s.unregisterRepo(ctx, repoName)
 	
 	log.Logger.Debugf("Deleted repository: %s", repoName)
+	s.notifyChange(repoName, true)
 	return nil
 }
 
@@ -218,6 +482,21 @@ func (s *RepoService) ListRepos(ctx context.Context) ([]string, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	
+	// 索引完成全量扫描后直接读取索引
+	if s.index != nil && s.index.Ready() {
+		var result []string
+		for repoName, typ := range s.index.Repos() {
+			if IsHiddenPath(repoName) {
+				continue
+			}
+			result = append(result, repoName)
+			if _, exists := s.repoTypes[repoName]; !exists {
+				s.repoTypes[repoName] = repo.RepoType(typ)
+			}
+		}
+		return result, nil
+	}
+	
 	allRepos := make(map[string]bool)
 	
 	// 从所有类型的 repo 中收集仓库列表
@@ -230,6 +509,10 @@ func (s *RepoService) ListRepos(ctx context.Context) ([]string, error) {
 		}
 		
 		for _, repoName := range repos {
+			// 暂存区和等待批准的上传不是仓库
+			if IsHiddenPath(repoName) {
+				continue
+			}
 			allRepos[repoName] = true
 			// 更新类型映射
 			if _, exists := s.repoTypes[repoName]; !exists {
@@ -272,22 +555,16 @@ func (s *RepoService) GetRepoType(ctx context.Context, repoName string) (string,
 
 // 新增：设置仓库类型
 func (s *RepoService) SetRepoType(ctx context.Context, repoName string, repoTypeStr string) error {
-	var repoType repo.RepoType
-	switch strings.ToLower(repoTypeStr) {
-	case "rpm":
-		repoType = repo.RPM
-	case "deb":
-		repoType = repo.DEB
-	case "files":
-		repoType = repo.Files
-	default:
-		return fmt.Errorf("unsupported repository type: %s", repoTypeStr)
+	repoType, err := s.parseRepoType(repoTypeStr)
+	if err != nil {
+		return err
 	}
 	
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	
 	s.repoTypes[repoName] = repoType
+	s.registerRepo(ctx, repoName, repoType)
 	return nil
 }
 
@@ -299,19 +576,46 @@ func (s *RepoService) validateFileType(filename string, repoType repo.RepoType)
 			return fmt.Errorf("RPM repository only accepts .rpm files")
 		}
 	case repo.DEB:
-		if !strings.HasSuffix(strings.ToLower(filename), ".deb") {
-			return fmt.Errorf("DEB repository only accepts .deb files")
+		if !strings.HasSuffix(strings.ToLower(filename), ".deb") && !utils.IsDebianSourceFile(filename) {
+			return fmt.Errorf("DEB repository only accepts .deb files and source packages")
 		}
 	case repo.Files:
 		// Files 类型接受任何文件
 		return nil
 	default:
-		return fmt.Errorf("unknown repository type: %s", repoType)
+		// 插件注册的仓库类型自行检查文件名
+		r, ok := s.repos[repoType]
+		if !ok {
+			return fmt.Errorf("unknown repository type: %s", repoType)
+		}
+		if v, ok := r.(repo.FileValidator); ok {
+			return v.ValidateFileName(filename)
+		}
 	}
 	return nil
 }
 
+// ValidateFileName 检查文件能否上传到该类型的仓库，插件注册的类型由插件检查
+func (s *RepoService) ValidateFileName(filename, repoType string) error {
+	return s.validateFileType(filename, repo.RepoType(repoType))
+}
+
+// RepoTypes 返回可以创建的仓库类型，包括插件注册的类型
+func (s *RepoService) RepoTypes() []string {
+	var list []string
+	for _, rt := range repo.Types() {
+		if _, ok := s.repos[rt]; ok {
+			list = append(list, string(rt))
+		}
+	}
+	return list
+}
+
 func (s *RepoService) GetPackageChecksum(ctx context.Context, repoName string, filename string) (string, error) {
+	if sum, ok := s.cachedChecksum(repoName, filename); ok {
+		return sum, nil
+	}
+
 	repoInstance, _, err := s.getRepoInstance(repoName)
 	if err != nil {
 		return "", err
@@ -320,7 +624,15 @@ func (s *RepoService) GetPackageChecksum(ctx context.Context, repoName string, f
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	
-	return repoInstance.GetPackageChecksum(ctx, repoName, filename)
+	sum, err := repoInstance.GetPackageChecksum(ctx, repoName, filename)
+	if name, ok := s.normalizedName(filename, err); ok {
+		sum, err = repoInstance.GetPackageChecksum(ctx, repoName, name)
+	}
+	if err != nil {
+		return "", err
+	}
+	s.storeChecksum(repoName, filename, sum)
+	return sum, nil
 }
 
 // 新增：获取仓库详细信息
@@ -366,6 +678,131 @@ func (s *RepoService) GetRepoInfo(ctx context.Context, repoName string) (*types.
 	}, nil
 }
 
+// 上传安装树文件（仅 RPM 仓库支持）
+func (s *RepoService) UploadTreeFile(ctx context.Context, repoName string, treePath string, reader io.Reader) error {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return err
+	}
+
+	treeRepo, ok := repoInstance.(repo.InstallTreeRepo)
+	if !ok {
+		return fmt.Errorf("%s repository does not support install trees", repoType)
+	}
+
+	if !utils.IsInstallTreePath(treePath) {
+		return fmt.Errorf("invalid install tree path: %s", treePath)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.Logger.Debugf("Uploading install tree file %s to %s repository: %s", treePath, repoType, repoName)
+	return treeRepo.UploadTreeFile(ctx, repoName, treePath, reader)
+}
+
+// 校验安装树
+func (s *RepoService) ValidateInstallTree(ctx context.Context, repoName string) (*types.InstallTreeReport, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	treeRepo, ok := repoInstance.(repo.InstallTreeRepo)
+	if !ok {
+		return nil, fmt.Errorf("%s repository does not support install trees", repoType)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return treeRepo.ValidateInstallTree(ctx, repoName)
+}
+
+// 解析离线安装包集合：请求的包及其在仓库内可满足的全部依赖
+func (s *RepoService) ResolveBundle(ctx context.Context, repoName string, names []string, arch string) (*deps.Resolution, error) {
+	depRepo, repoType, err := s.dependencyRepo(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pkgs, err := depRepo.PackageDeps(ctx, repoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package metadata: %w", err)
+	}
+
+	return deps.NewIndex(pkgs, arch, deps.VersionComparer(string(repoType))).Resolve(names), nil
+}
+
+// 按元数据中的相对路径打开包文件
+func (s *RepoService) OpenPackageFile(ctx context.Context, repoName string, location string) (io.ReadCloser, error) {
+	depRepo, _, err := s.dependencyRepo(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return depRepo.OpenPackageFile(ctx, repoName, location)
+}
+
+// 获取仓库的依赖索引，未缓存时从元数据构建
+func (s *RepoService) DependencyIndex(ctx context.Context, repoName string) (*deps.Index, error) {
+	s.depMu.Lock()
+	ix, ok := s.depIndexes[repoName]
+	s.depMu.Unlock()
+	if ok {
+		return ix, nil
+	}
+
+	depRepo, repoType, err := s.dependencyRepo(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.buildDependencyIndex(ctx, depRepo, repoType, repoName)
+}
+
+// buildDependencyIndex 按仓库类型的版本规则（rpm 为 rpmvercmp，deb 为 dpkg）建立依赖索引
+func (s *RepoService) buildDependencyIndex(ctx context.Context, depRepo repo.DependencyRepo, repoType repo.RepoType, repoName string) (*deps.Index, error) {
+	pkgs, err := depRepo.PackageDeps(ctx, repoName)
+	if err != nil {
+		s.depMu.Lock()
+		delete(s.depIndexes, repoName)
+		s.depMu.Unlock()
+		return nil, fmt.Errorf("failed to read package metadata: %w", err)
+	}
+
+	ix := deps.NewIndex(pkgs, "", deps.VersionComparer(string(repoType)))
+	s.depMu.Lock()
+	s.depIndexes[repoName] = ix
+	s.depMu.Unlock()
+	s.indexText(depRepo, repoName, pkgs)
+
+	log.Logger.Debugf("Built dependency index for %s: %d packages", repoName, len(ix.Packages()))
+	return ix, nil
+}
+
+func (s *RepoService) dependencyRepo(repoName string) (repo.DependencyRepo, repo.RepoType, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	depRepo, ok := repoInstance.(repo.DependencyRepo)
+	if !ok {
+		return nil, "", fmt.Errorf("%s repository does not support dependency resolution", repoType)
+	}
+	return depRepo, repoType, nil
+}
+
 // MultiRepoService 保持不变，但可以添加类型支持
 type MultiRepoService struct {
 	repositories map[string]repo.Repo
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+
+	"plus/pkg/repo/files"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+)
+
+// newTestStorage 创建根目录为 root 的本地存储
+func newTestStorage(t *testing.T, root string) storage.Storage {
+	t.Helper()
+	store, err := local.NewLocalStorage(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// newFilesService 创建使用临时目录的 files 仓库服务，返回服务和存储根目录
+func newFilesService(t *testing.T) (*RepoService, string) {
+	t.Helper()
+	root := t.TempDir()
+	return NewRepoService(files.NewFilesRepo(newTestStorage(t, root))), root
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"plus/internal/attest"
+	"plus/internal/types"
+)
+
+// AddSignature 保存制品 name 的 cosign 签名，用配置的公钥验证，requireVerified 时拒绝无法验证的
+// （attest.ErrUnverified）。相同的签名只保存一次
+func (s *RepoService) AddSignature(ctx context.Context, repoName, name string, data []byte, by string) (types.Signature, error) {
+	repoInstance, sr, repoType, err := s.stateRepo(repoName, "attestations")
+	if err != nil {
+		return types.Signature{}, err
+	}
+	parsed, err := attest.ParseSignature(data)
+	if err != nil {
+		return types.Signature{}, err
+	}
+	key := NoteKey(string(repoType), name)
+	sum, err := s.artifactSum(ctx, repoInstance, repoType, repoName, key)
+	if err != nil {
+		return types.Signature{}, err
+	}
+	digest, _ := hex.DecodeString(sum)
+	keyName, verified := s.attestKeys.VerifyDigest(digest, parsed.Raw)
+	if !verified && s.requireVerified {
+		return types.Signature{}, fmt.Errorf("%w: no configured key verifies the signature of %s", attest.ErrUnverified, key)
+	}
+
+	id := sha256.Sum256(parsed.Raw)
+	sig := types.Signature{
+		ID:          hex.EncodeToString(id[:]),
+		Signature:   base64.StdEncoding.EncodeToString(parsed.Raw),
+		Certificate: parsed.Certificate,
+		Digest:      sum,
+		Verified:    verified,
+		Key:         keyName,
+		By:          by,
+		Created:     time.Now().UTC().Format(time.RFC3339),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved, err := readAttestations(ctx, sr, repoName)
+	if err != nil {
+		return types.Signature{}, err
+	}
+	list := saved.Signatures[key]
+	for i := range list {
+		if list[i].ID == sig.ID {
+			list[i].Verified, list[i].Key = sig.Verified, sig.Key
+			sig = list[i]
+			return sig, writeAttestations(ctx, sr, repoName, saved)
+		}
+	}
+	saved.Signatures[key] = append(list, sig)
+	return sig, writeAttestations(ctx, sr, repoName, saved)
+}
+
+// Signatures 返回制品 name 的 cosign 签名，按上传顺序
+func (s *RepoService) Signatures(ctx context.Context, repoName, name string) ([]types.Signature, error) {
+	_, sr, repoType, err := s.stateRepo(repoName, "attestations")
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	saved, err := readAttestations(ctx, sr, repoName)
+	if err != nil {
+		return nil, err
+	}
+	return saved.Signatures[NoteKey(string(repoType), name)], nil
+}
+
+// DeleteSignature 删除制品 name 的一个签名，不存在时返回 false
+func (s *RepoService) DeleteSignature(ctx context.Context, repoName, name, id string) (bool, error) {
+	_, sr, repoType, err := s.stateRepo(repoName, "attestations")
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved, err := readAttestations(ctx, sr, repoName)
+	if err != nil {
+		return false, err
+	}
+	key := NoteKey(string(repoType), name)
+	list := saved.Signatures[key]
+	for i := range list {
+		if list[i].ID != id {
+			continue
+		}
+		list = append(list[:i], list[i+1:]...)
+		if len(list) == 0 {
+			delete(saved.Signatures, key)
+		} else {
+			saved.Signatures[key] = list
+		}
+		return true, writeAttestations(ctx, sr, repoName, saved)
+	}
+	return false, nil
+}
+
+// VerifyArtifact 按制品当前的内容和当前配置的公钥重新验证保存的签名和来源证明。
+// keyName 不为空时只用该公钥验证，没有该公钥时返回 attest.ErrUnknownKey
+func (s *RepoService) VerifyArtifact(ctx context.Context, repoName, name, keyName string) (types.Verification, error) {
+	repoInstance, sr, repoType, err := s.stateRepo(repoName, "attestations")
+	if err != nil {
+		return types.Verification{}, err
+	}
+	keys := s.attestKeys
+	if keyName != "" {
+		if keys, err = keys.Only(keyName); err != nil {
+			return types.Verification{}, err
+		}
+	}
+	key := NoteKey(string(repoType), name)
+	sum, err := s.artifactSum(ctx, repoInstance, repoType, repoName, key)
+	if err != nil {
+		return types.Verification{}, err
+	}
+	s.mu.RLock()
+	saved, err := readAttestations(ctx, sr, repoName)
+	s.mu.RUnlock()
+	if err != nil {
+		return types.Verification{}, err
+	}
+
+	result := types.Verification{
+		File:         key,
+		Digest:       sum,
+		Signatures:   []types.VerifiedItem{},
+		Attestations: []types.VerifiedItem{},
+	}
+	accept := func(item *types.VerifiedItem, name string, ok bool) {
+		if !ok {
+			item.Reason = "no configured key verifies the signature"
+			return
+		}
+		item.Verified, item.Key = true, name
+		result.Verified = true
+	}
+	digest, _ := hex.DecodeString(sum)
+	for _, sig := range saved.Signatures[key] {
+		item := types.VerifiedItem{ID: sig.ID}
+		raw, err := base64.StdEncoding.DecodeString(sig.Signature)
+		if err != nil {
+			item.Reason = "stored signature is corrupt"
+		} else {
+			name, ok := keys.VerifyDigest(digest, raw)
+			accept(&item, name, ok)
+			if !ok && sig.Digest != sum {
+				item.Reason = "the file was replaced after it was signed"
+			}
+		}
+		result.Signatures = append(result.Signatures, item)
+	}
+	for _, a := range saved.Attestations[key] {
+		item := types.VerifiedItem{ID: a.ID}
+		st, err := attest.Parse(a.Envelope)
+		switch {
+		case err != nil:
+			item.Reason = err.Error()
+		case !st.Covers(sum):
+			item.Reason = "no subject matches the current digest"
+		default:
+			name, ok := keys.Verify(st)
+			accept(&item, name, ok)
+		}
+		result.Attestations = append(result.Attestations, item)
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/internal/attest"
+	"plus/internal/config"
+)
+
+func TestSignatures(t *testing.T) {
+	s, _ := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "ci", "files"); err != nil {
+		t.Fatal(err)
+	}
+	artifact := "app-1.0.tar.gz contents"
+	if err := s.UploadPackage(ctx, "ci", "app-1.0.tar.gz", strings.NewReader(artifact)); err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	keyFile := filepath.Join(t.TempDir(), "ci.pub")
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644)
+	v, err := attest.NewVerifier([]config.AttestationKeyConfig{{Name: "ci", PublicKey: keyFile}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetAttestationVerifier(v, false)
+
+	digest := sha256.Sum256([]byte(artifact))
+	raw, _ := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	sig, err := s.AddSignature(ctx, "ci", "app-1.0.tar.gz", []byte(base64.StdEncoding.EncodeToString(raw)), "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sig.Verified || sig.Key != "ci" || sig.Digest != fmt.Sprintf("%x", digest) {
+		t.Errorf("signature = %+v", sig)
+	}
+	if _, err := s.AddSignature(ctx, "ci", "app-1.0.tar.gz", []byte("not a signature"), "alice"); !errors.Is(err, attest.ErrInvalidSignature) {
+		t.Errorf("invalid signature: err = %v", err)
+	}
+	// 与来源证明一起验证
+	if _, err := s.AddAttestation(ctx, "ci", "app-1.0.tar.gz", testAttestation(sig.Digest, key), "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.VerifyArtifact(ctx, "ci", "app-1.0.tar.gz", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Verified || len(result.Signatures) != 1 || !result.Signatures[0].Verified || len(result.Attestations) != 1 || !result.Attestations[0].Verified {
+		t.Errorf("VerifyArtifact = %+v", result)
+	}
+	if _, err := s.VerifyArtifact(ctx, "ci", "app-1.0.tar.gz", "release"); !errors.Is(err, attest.ErrUnknownKey) {
+		t.Errorf("unknown key: err = %v", err)
+	}
+
+	// 覆盖上传后签名不再有效
+	if err := s.UploadPackage(ctx, "ci", "app-1.0.tar.gz", strings.NewReader("rebuilt")); err != nil {
+		t.Fatal(err)
+	}
+	result, err = s.VerifyArtifact(ctx, "ci", "app-1.0.tar.gz", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Verified || result.Signatures[0].Reason != "the file was replaced after it was signed" {
+		t.Errorf("after replace = %+v", result)
+	}
+
+	// 要求验证时拒绝
+	s.SetAttestationVerifier(v, true)
+	if _, err := s.AddSignature(ctx, "ci", "app-1.0.tar.gz", []byte(base64.StdEncoding.EncodeToString(raw)), "alice"); !errors.Is(err, attest.ErrUnverified) {
+		t.Errorf("require verified: err = %v", err)
+	}
+	if ok, err := s.DeleteSignature(ctx, "ci", "app-1.0.tar.gz", sig.ID); !ok || err != nil {
+		t.Errorf("DeleteSignature = %v, %v", ok, err)
+	}
+	if list, err := s.Signatures(ctx, "ci", "app-1.0.tar.gz"); err != nil || len(list) != 0 {
+		t.Errorf("Signatures = %v, %v", list, err)
+	}
+}
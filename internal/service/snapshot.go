@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"plus/pkg/repo"
+)
+
+// ErrNoMetadata 仓库还没有生成元数据
+var ErrNoMetadata = errors.New("repository has no metadata")
+
+// MetadataFile 元数据快照中的文件，Name 为相对仓库根目录的路径
+type MetadataFile struct {
+	Name    string
+	Data    []byte
+	ModTime time.Time
+}
+
+// debMetadata DEB 仓库根目录下的元数据文件
+var debMetadata = map[string]bool{
+	"Packages": true, "Packages.gz": true, "Packages.xz": true, "Sources": true, "Sources.gz": true,
+	"Release": true, "Release.gpg": true, "InRelease": true, "SHA256SUMS": true,
+}
+
+// metadataName 返回仓库文件在 GetMetadata 中的名称，不是元数据时返回 false
+func metadataName(repoType repo.RepoType, rel string) (string, bool) {
+	switch repoType {
+	case repo.RPM:
+		// 以 . 开头的是 createrepo 的历史记录、配置和写入中的临时文件
+		if name := strings.TrimPrefix(rel, "repodata/"); name != rel && !strings.Contains(name, "/") && !strings.HasPrefix(name, ".") {
+			return name, true
+		}
+	case repo.DEB:
+		if debMetadata[rel] {
+			return rel, true
+		}
+	}
+	return "", false
+}
+
+// MetadataSnapshot 在读锁内读取仓库的全部元数据（repodata/ 或 Packages/Release），
+// 读取期间不会有刷新替换其中的文件，返回的文件按名称排序
+func (s *RepoService) MetadataSnapshot(ctx context.Context, repoName string) ([]MetadataFile, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return readMetadata(ctx, repoInstance, repoType, repoName)
+}
+
+// readMetadata 读取存储目录 root 中按仓库布局保存的元数据，root 为仓库或其历史版本的目录，调用方持有锁
+func readMetadata(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, root string) ([]MetadataFile, error) {
+	lister, ok := repoInstance.(repo.FileListRepo)
+	if !ok || (repoType != repo.RPM && repoType != repo.DEB) {
+		return nil, fmt.Errorf("repository type %s has no metadata", repoType)
+	}
+	files, err := lister.ListFiles(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot []MetadataFile
+	for _, f := range files {
+		name, ok := metadataName(repoType, f.Name)
+		if !ok {
+			continue
+		}
+		reader, err := repoInstance.GetMetadata(ctx, root, name)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		snapshot = append(snapshot, MetadataFile{Name: path.Clean(f.Name), Data: data, ModTime: f.ModTime})
+	}
+	if len(snapshot) == 0 {
+		return nil, ErrNoMetadata
+	}
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Name < snapshot[j].Name })
+	return snapshot, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/pkg/repo/deb"
+	"plus/pkg/repo/rpm"
+)
+
+func TestSourceRPMUpload(t *testing.T) {
+	dir := t.TempDir()
+	store := newTestStorage(t, dir)
+	s := NewRepoService(rpm.NewRPMRepo(store))
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "el9", "rpm"); err != nil {
+		t.Fatal(err)
+	}
+	src := testRPM("nginx", "1.24.0", "1.el9", "x86_64", "")
+	if err := s.UploadPackage(ctx, "el9", "nginx-1.24.0-1.el9.src.rpm", bytes.NewReader(src)); err != nil {
+		t.Fatal(err)
+	}
+	bin := testRPM("nginx", "1.24.0", "1.el9", "x86_64", "nginx-1.24.0-1.el9.src.rpm")
+	if err := s.UploadPackage(ctx, "el9", "nginx-1.24.0-1.el9.x86_64.rpm", bytes.NewReader(bin)); err != nil {
+		t.Fatal(err)
+	}
+
+	// 源码包保存在 SRPMS 子仓库，二进制包仍在 Packages 下
+	for name, want := range map[string][]byte{
+		"el9/SRPMS/Packages/nginx-1.24.0-1.el9.src.rpm": src,
+		"el9/Packages/nginx-1.24.0-1.el9.x86_64.rpm":    bin,
+	} {
+		if got, err := os.ReadFile(filepath.Join(dir, name)); err != nil || !bytes.Equal(got, want) {
+			t.Errorf("%s: %d of %d bytes, %v", name, len(got), len(want), err)
+		}
+	}
+	for _, name := range []string{"nginx-1.24.0-1.el9.src.rpm", "SRPMS/Packages/nginx-1.24.0-1.el9.src.rpm"} {
+		if exists, err := s.PackageExists(ctx, "el9", name); err != nil || !exists {
+			t.Errorf("PackageExists(%s) = %v, %v", name, exists, err)
+		}
+	}
+	pkgs, err := s.ListPackages(ctx, "el9")
+	if err != nil || len(pkgs) != 2 {
+		t.Errorf("ListPackages = %v, %v", pkgs, err)
+	}
+	repos, err := rpm.NewRPMRepo(store).ListRepos(ctx)
+	if err != nil || len(repos) != 1 || repos[0] != "el9" {
+		t.Errorf("ListRepos = %v, %v", repos, err)
+	}
+}
+
+func TestDEBSources(t *testing.T) {
+	for _, tool := range []string{"dpkg-scanpackages", "dpkg-scansources"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skip(tool + " not installed")
+		}
+	}
+	root := t.TempDir()
+	store := newTestStorage(t, root)
+	s := NewRepoService(deb.NewDEBRepo(store))
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "debs", "deb"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UploadPackage(ctx, "debs", "hello_1.0-1_amd64.deb", bytes.NewReader(testDEB("hello", "1.0-1", "amd64"))); err != nil {
+		t.Fatal(err)
+	}
+	tarball := []byte("orig tarball")
+	dsc := fmt.Sprintf("Format: 3.0 (quilt)\nSource: hello\nBinary: hello\nArchitecture: any\nVersion: 1.0-1\nMaintainer: Test <test@example.com>\nFiles:\n %x %d hello_1.0.orig.tar.gz\n", md5.Sum(tarball), len(tarball))
+	for name, data := range map[string]string{"hello_1.0-1.dsc": dsc, "hello_1.0.orig.tar.gz": string(tarball)} {
+		if err := s.UploadPackage(ctx, "debs", name, strings.NewReader(data)); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	if err := s.UploadPackage(ctx, "debs", "hello_1.0-1.changes", strings.NewReader("changes")); err == nil {
+		t.Error("uploaded a .changes file")
+	}
+	if err := s.RefreshMetadata(ctx, "debs"); err != nil {
+		t.Fatal(err)
+	}
+
+	readFile := func(name string) string {
+		t.Helper()
+		reader, err := s.GetMetadata(ctx, "debs", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer reader.Close()
+		data, _ := io.ReadAll(reader)
+		return string(data)
+	}
+	sources := readFile("Sources")
+	if !strings.Contains(sources, "Package: hello\n") || !strings.Contains(sources, "hello_1.0.orig.tar.gz") {
+		t.Errorf("Sources:\n%s", sources)
+	}
+	release := readFile("Release")
+	for _, want := range []string{"Architectures: amd64 source\n", " Sources\n", " Sources.gz\n"} {
+		if !strings.Contains(release, want) {
+			t.Errorf("Release does not contain %q:\n%s", want, release)
+		}
+	}
+
+	// 删除源码包后刷新，删除旧的 Sources
+	if err := os.Remove(filepath.Join(root, "debs", "hello_1.0-1.dsc")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RefreshMetadata(ctx, "debs"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "debs", "Sources")); !os.IsNotExist(err) {
+		t.Errorf("Sources still exists: %v", err)
+	}
+	if release := readFile("Release"); strings.Contains(release, "Sources") {
+		t.Errorf("Release still lists Sources:\n%s", release)
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"plus/internal/journal"
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+)
+
+// StagingRoot 暂存区在存储中的根目录，仓库 el9 的暂存区为 .staging/el9。
+// 以 . 开头的路径不是合法的仓库名，暂存区不会出现在仓库列表和元数据中
+const StagingRoot = ".staging"
+
+var (
+	// ErrNothingStaged 暂存区为空
+	ErrNothingStaged = errors.New("nothing is staged")
+	// ErrChangesetChanged 暂存区在审核后发生了变化
+	ErrChangesetChanged = errors.New("staging area changed since the changeset was reviewed")
+)
+
+// IsHiddenPath 判断存储路径是否位于暂存区、等待批准的上传、元数据生成目录、元数据历史或操作日志中
+func IsHiddenPath(p string) bool {
+	p = strings.Trim(p, "/")
+	for _, root := range []string{StagingRoot, HoldRoot, repo.RefreshRoot, GenerationsRoot, journal.Dir} {
+		if p == root || strings.HasPrefix(p, root+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func stagingRepo(repoName string) string {
+	return path.Join(StagingRoot, repoName)
+}
+
+// stagingRepoInstance 返回支持暂存的仓库实例
+func (s *RepoService) stagingRepoInstance(repoName string) (repo.Repo, repo.RepoType, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, "", err
+	}
+	_, listOK := repoInstance.(repo.FileListRepo)
+	_, copyOK := repoInstance.(repo.CopyRepo)
+	_, deleteOK := repoInstance.(repo.DeleteFileRepo)
+	if !listOK || !copyOK || !deleteOK {
+		return nil, "", fmt.Errorf("repository type %s does not support staging", repoType)
+	}
+	return repoInstance, repoType, nil
+}
+
+// StagePackage 把上传的包放入仓库的暂存区，发布前不出现在仓库和元数据中
+func (s *RepoService) StagePackage(ctx context.Context, repoName string, filename string, reader io.Reader) error {
+	_, err := s.StagePackageAs(ctx, repoName, filename, reader)
+	return err
+}
+
+// StagePackageAs 把上传的包放入暂存区并返回保存的文件名，覆盖策略同时检查仓库和暂存区中的文件
+func (s *RepoService) StagePackageAs(ctx context.Context, repoName string, filename string, reader io.Reader) (string, error) {
+	repoInstance, repoType, err := s.stagingRepoInstance(repoName)
+	if err != nil {
+		return "", err
+	}
+	filename, reader, err = s.validateUpload(ctx, repoInstance, repoType, repoName, filename, reader, stagingRepo(repoName))
+	if err != nil {
+		return "", err
+	}
+	defer s.mu.Unlock()
+
+	log.Logger.Debugf("Staging %s for %s repository: %s", filename, repoType, repoName)
+	return filename, repoInstance.UploadPackage(ctx, stagingRepo(repoName), filename, reader)
+}
+
+// Staged 返回暂存区中的文件（按路径排序）和变更集标识
+func (s *RepoService) Staged(ctx context.Context, repoName string) ([]storage.FileInfo, string, error) {
+	repoInstance, _, err := s.stagingRepoInstance(repoName)
+	if err != nil {
+		return nil, "", err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.staged(ctx, repoInstance, repoName)
+}
+
+// staged 调用方持有锁
+func (s *RepoService) staged(ctx context.Context, repoInstance repo.Repo, repoName string) ([]storage.FileInfo, string, error) {
+	files, err := repoInstance.(repo.FileListRepo).ListFiles(ctx, stagingRepo(repoName))
+	if err != nil {
+		return nil, "", err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files, changesetID(files), nil
+}
+
+// changesetID 由暂存文件的路径、大小和修改时间计算，任何文件变化都会改变标识
+func changesetID(files []storage.FileInfo) string {
+	if len(files) == 0 {
+		return ""
+	}
+	h := sha256.New()
+	for _, f := range files {
+		io.WriteString(h, f.Name+"\x00"+strconv.FormatInt(f.Size, 10)+"\x00"+strconv.FormatInt(f.ModTime.UnixNano(), 10)+"\n")
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Publish 把暂存区的文件移入仓库并刷新元数据，返回发布的文件和变更集标识。
+// changeset 不为空时必须与当前暂存区一致，保证发布的正是审核过的内容
+func (s *RepoService) Publish(ctx context.Context, repoName, changeset string) ([]string, string, error) {
+	repoInstance, repoType, err := s.stagingRepoInstance(repoName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mu.Lock()
+	files, id, err := s.staged(ctx, repoInstance, repoName)
+	switch {
+	case err != nil:
+	case len(files) == 0:
+		err = ErrNothingStaged
+	case changeset != "" && changeset != id:
+		err = ErrChangesetChanged
+	}
+	var done func()
+	if err == nil {
+		done, err = s.beginOp(types.JournalEntry{Op: journal.OpMove, Repo: repoName, Source: stagingRepo(repoName), Files: fileNames(files)})
+	}
+	if err != nil {
+		s.mu.Unlock()
+		return nil, id, err
+	}
+	defer done()
+
+	published, err := s.moveFiles(ctx, repoInstance, repoType, stagingRepo(repoName), repoName, files)
+	s.mu.Unlock()
+
+	// 部分文件发布失败时同样刷新，已移入的文件不会留在元数据之外
+	if len(published) > 0 {
+		log.Logger.Infof("Published changeset %s of %s: %d files", id, repoName, len(published))
+		if refreshErr := s.RefreshMetadata(ctx, repoName); refreshErr != nil && err == nil {
+			err = refreshErr
+		}
+		s.notifyPublish(repoName, published)
+	}
+	return published, id, err
+}
+
+// moveFiles 把 src 中的文件移入仓库，全部成功后删除 src。返回移入的文件，调用方持有写锁。
+// 任何一个文件会覆盖有保留标记的制品时返回 ErrLegalHold，不移入任何文件
+func (s *RepoService) moveFiles(ctx context.Context, repoInstance repo.Repo, repoType repo.RepoType, src, repoName string, files []storage.FileInfo) ([]string, error) {
+	held, err := heldArtifacts(ctx, repoInstance, repoName)
+	if err != nil {
+		return nil, err
+	}
+	var blocked []string
+	for _, f := range files {
+		if _, ok := held[NoteKey(string(repoType), f.Name)]; ok {
+			blocked = append(blocked, f.Name)
+		}
+	}
+	if len(blocked) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrLegalHold, strings.Join(blocked, ", "))
+	}
+
+	copier := repoInstance.(repo.CopyRepo)
+	deleter := repoInstance.(repo.DeleteFileRepo)
+	moved := make([]string, 0, len(files))
+	for _, f := range files {
+		if err := copier.CopyFile(ctx, src, repoName, f.Name); err != nil {
+			return moved, fmt.Errorf("move %s: %w", f.Name, err)
+		}
+		if err := deleter.DeleteFile(ctx, src, f.Name); err != nil {
+			log.Logger.Warnf("Failed to remove %s from %s: %v", f.Name, src, err)
+		}
+		moved = append(moved, f.Name)
+		// 校验和按上传时的文件名记录，替换同名文件后需要重新计算
+		name := f.Name
+		if repoType != repo.Files {
+			name = path.Base(name)
+		}
+		s.afterUpload(repoName, name)
+	}
+	s.updateSums(ctx, repoInstance, repoName, moved)
+	if err := repoInstance.DeleteRepo(ctx, src); err != nil {
+		log.Logger.Debugf("Failed to remove %s: %v", src, err)
+	}
+	return moved, nil
+}
+
+// DiscardStaged 清空暂存区，返回丢弃的文件数
+func (s *RepoService) DiscardStaged(ctx context.Context, repoName string) (int, error) {
+	repoInstance, _, err := s.stagingRepoInstance(repoName)
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files, _, err := s.staged(ctx, repoInstance, repoName)
+	if err != nil || len(files) == 0 {
+		return 0, err
+	}
+	if err := repoInstance.DeleteRepo(ctx, stagingRepo(repoName)); err != nil {
+		return 0, err
+	}
+	log.Logger.Infof("Discarded %d staged files of %s", len(files), repoName)
+	return len(files), nil
+}
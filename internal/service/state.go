@@ -0,0 +1,55 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"plus/pkg/repo"
+)
+
+// stateRepo 返回支持状态文件的仓库实例，feature 为不支持时错误信息中的功能名称
+func (s *RepoService) stateRepo(repoName, feature string) (repo.Repo, repo.StateRepo, repo.RepoType, error) {
+	repoInstance, repoType, err := s.getRepoInstance(repoName)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	sr, ok := repoInstance.(repo.StateRepo)
+	if !ok {
+		return nil, nil, "", fmt.Errorf("repository type %s does not support %s", repoType, feature)
+	}
+	return repoInstance, sr, repoType, nil
+}
+
+// loadState 把仓库的状态文件 file 解析到 v，文件不存在时不修改 v，调用方持有锁
+func loadState(ctx context.Context, sr repo.StateRepo, repoName, file string, v json.Unmarshaler) error {
+	reader, err := sr.ReadState(ctx, repoName, file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if err := v.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("%s of %s is corrupt: %w", file, repoName, err)
+	}
+	return nil
+}
+
+// saveState 用 v 替换仓库的状态文件 file，调用方持有锁
+func saveState(ctx context.Context, sr repo.StateRepo, repoName, file string, v json.Marshaler) error {
+	data, err := v.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return sr.WriteState(ctx, repoName, file, bytes.NewReader(data))
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/internal/types"
+	"plus/pkg/repo"
+)
+
+func TestStateFiles(t *testing.T) {
+	s, root := newFilesService(t)
+	ctx := context.Background()
+	if err := s.SetRepoType(ctx, "ci", "files"); err != nil {
+		t.Fatal(err)
+	}
+	_, sr, _, err := s.stateRepo("ci", "state files")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 只能读写已知的状态文件
+	if err := sr.WriteState(ctx, "ci", "app.tar.gz", strings.NewReader("{}")); err == nil {
+		t.Error("WriteState accepted a package name")
+	}
+	if _, err := sr.ReadState(ctx, "ci", "../other/"+repo.NotesFile); err == nil {
+		t.Error("ReadState accepted a path outside the repository")
+	}
+
+	// 文件不存在时保持原值，损坏时返回错误
+	readme := types.RepoReadme{Text: "kept"}
+	if err := loadState(ctx, sr, "ci", repo.ReadmeFile, &readme); err != nil || readme.Text != "kept" {
+		t.Errorf("missing file: %+v, %v", readme, err)
+	}
+	if err := saveState(ctx, sr, "ci", repo.ReadmeFile, &types.RepoReadme{Text: "saved"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadState(ctx, sr, "ci", repo.ReadmeFile, &readme); err != nil || readme.Text != "saved" {
+		t.Errorf("saved file: %+v, %v", readme, err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "ci", repo.ReadmeFile), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadState(ctx, sr, "ci", repo.ReadmeFile, &readme); err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Errorf("corrupt file: %v", err)
+	}
+}
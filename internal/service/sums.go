@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"path"
+
+	"plus/internal/log"
+	"plus/pkg/repo"
+)
+
+// SetDirectorySums 设置 files 仓库是否在每个目录中维护 SHA256SUMS，上传、发布和刷新元数据时重新生成
+func (s *RepoService) SetDirectorySums(sums func(repoName string) repo.DirectorySums) {
+	for _, r := range s.repos {
+		if summer, ok := r.(repo.SumsRepo); ok {
+			summer.SetDirectorySums(sums)
+		}
+	}
+}
+
+// updateSums 文件写入仓库后重新生成所在目录的 SHA256SUMS，调用方持有写锁。
+// 文件已经写入，失败时只记录日志，刷新元数据时会重新生成
+func (s *RepoService) updateSums(ctx context.Context, repoInstance repo.Repo, repoName string, files []string) {
+	summer, ok := repoInstance.(repo.SumsRepo)
+	if !ok || len(files) == 0 {
+		return
+	}
+	dirs := make([]string, 0, len(files))
+	for _, f := range files {
+		dirs = append(dirs, path.Dir(f))
+	}
+	if err := summer.UpdateSums(ctx, repoName, dirs); err != nil {
+		log.Logger.Warnf("Failed to update SHA256SUMS of %s: %v", repoName, err)
+	}
+}
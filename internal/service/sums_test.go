@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/pkg/repo"
+)
+
+func TestDirectorySums(t *testing.T) {
+	s, root := newFilesService(t)
+	s.SetDirectorySums(func(repoName string) repo.DirectorySums {
+		if repoName != "releases" {
+			return repo.DirectorySums{}
+		}
+		return repo.DirectorySums{Enabled: true, Sign: func(data []byte) ([]byte, error) {
+			return []byte(fmt.Sprintf("signature of %x\n", sha256.Sum256(data))), nil
+		}}
+	})
+	ctx := context.Background()
+	for _, name := range []string{"releases", "scratch"} {
+		if err := s.SetRepoType(ctx, name, "files"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sumLine := func(body, name string) string {
+		return fmt.Sprintf("%x  %s\n", sha256.Sum256([]byte(body)), name)
+	}
+	readFile := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(root, "releases", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// 以点开头的文件不能上传，直接写入存储，SHA256SUMS 不包含它
+	if err := os.MkdirAll(filepath.Join(root, "releases", "v1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "releases", "v1", ".hidden.conf"), []byte("hidden"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range map[string]string{
+		"b.tar.gz":      "b",
+		"a.tar.gz":      "a",
+		"v1/app.tar.gz": "app",
+	} {
+		if err := s.UploadPackage(ctx, "releases", name, strings.NewReader(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.UploadPackage(ctx, "scratch", "a.tar.gz", strings.NewReader("a")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readFile("SHA256SUMS"), sumLine("a", "a.tar.gz")+sumLine("b", "b.tar.gz"); got != want {
+		t.Errorf("SHA256SUMS = %q, want %q", got, want)
+	}
+	sums := readFile("v1/SHA256SUMS")
+	if want := sumLine("app", "app.tar.gz"); sums != want {
+		t.Errorf("v1/SHA256SUMS = %q, want %q", sums, want)
+	}
+	if got, want := readFile("v1/SHA256SUMS.asc"), fmt.Sprintf("signature of %x\n", sha256.Sum256([]byte(sums))); got != want {
+		t.Errorf("v1/SHA256SUMS.asc = %q, want %q", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(root, "scratch", "SHA256SUMS")); !os.IsNotExist(err) {
+		t.Errorf("repository without sha256sums has SHA256SUMS: %v", err)
+	}
+
+	// 刷新元数据时重新计算服务之外修改的文件，删除没有文件的目录中的 SHA256SUMS
+	if err := os.WriteFile(filepath.Join(root, "releases", "a.tar.gz"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(root, "releases", "v1", "app.tar.gz")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RefreshMetadata(ctx, "releases"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readFile("SHA256SUMS"), sumLine("changed", "a.tar.gz")+sumLine("b", "b.tar.gz"); got != want {
+		t.Errorf("refreshed SHA256SUMS = %q, want %q", got, want)
+	}
+	for _, name := range []string{"v1/SHA256SUMS", "v1/SHA256SUMS.asc"} {
+		if _, err := os.Stat(filepath.Join(root, "releases", name)); !os.IsNotExist(err) {
+			t.Errorf("%s of a directory without files: %v", name, err)
+		}
+	}
+}
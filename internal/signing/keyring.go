@@ -0,0 +1,165 @@
+package signing
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"plus/internal/config"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+// PublicKey 对外发布的签名公钥
+type PublicKey struct {
+	Name        string
+	Fingerprint string
+	KeyID       string
+	UserIDs     []string
+	Created     time.Time
+	Armored     []byte
+}
+
+// Keyring 管理配置中声明的签名公钥
+type Keyring struct {
+	keys       map[string]*PublicKey
+	signers    map[string]*openpgp.Entity // 配置了私钥的密钥
+	order      []string
+	defaultKey string
+}
+
+// NewKeyring 加载配置中的所有公钥文件
+func NewKeyring(cfg config.SigningConfig) (*Keyring, error) {
+	k := &Keyring{
+		keys:       make(map[string]*PublicKey),
+		signers:    make(map[string]*openpgp.Entity),
+		defaultKey: cfg.DefaultKey,
+	}
+
+	for _, kc := range cfg.Keys {
+		if kc.Name == "" {
+			return nil, fmt.Errorf("signing key name is required")
+		}
+		if _, exists := k.keys[kc.Name]; exists {
+			return nil, fmt.Errorf("duplicate signing key: %s", kc.Name)
+		}
+
+		data, err := os.ReadFile(kc.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key %s: %w", kc.Name, err)
+		}
+
+		key, err := LoadPublicKey(kc.Name, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if kc.PrivateKey != "" {
+			signer, err := loadSigner(kc, key.Fingerprint)
+			if err != nil {
+				return nil, err
+			}
+			k.signers[kc.Name] = signer
+		}
+
+		k.keys[kc.Name] = key
+		k.order = append(k.order, kc.Name)
+	}
+
+	// 只有一个公钥时默认使用它
+	if k.defaultKey == "" && len(k.order) == 1 {
+		k.defaultKey = k.order[0]
+	}
+	if k.defaultKey != "" {
+		if _, ok := k.keys[k.defaultKey]; !ok {
+			return nil, fmt.Errorf("default signing key not found: %s", k.defaultKey)
+		}
+	}
+
+	return k, nil
+}
+
+// LoadPublicKey 解析 armored 或二进制格式的 OpenPGP 公钥
+func LoadPublicKey(name string, data []byte) (*PublicKey, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		// 尝试二进制格式
+		entities, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key %s: %w", name, err)
+		}
+	}
+	if len(entities) == 0 {
+		return nil, fmt.Errorf("no public key found in %s", name)
+	}
+
+	// 统一以 armored 形式对外提供
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to armor public key %s: %w", name, err)
+	}
+	for _, e := range entities {
+		if err := e.Serialize(w); err != nil {
+			return nil, fmt.Errorf("failed to serialize public key %s: %w", name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to armor public key %s: %w", name, err)
+	}
+	buf.WriteByte('\n')
+
+	primary := entities[0].PrimaryKey
+	var uids []string
+	for uid := range entities[0].Identities {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+
+	return &PublicKey{
+		Name:        name,
+		Fingerprint: strings.ToUpper(fmt.Sprintf("%x", primary.Fingerprint)),
+		KeyID:       primary.KeyIdString(),
+		UserIDs:     uids,
+		Created:     primary.CreationTime,
+		Armored:     buf.Bytes(),
+	}, nil
+}
+
+// List 按配置顺序返回所有公钥
+func (k *Keyring) List() []*PublicKey {
+	keys := make([]*PublicKey, 0, len(k.order))
+	for _, name := range k.order {
+		keys = append(keys, k.keys[name])
+	}
+	return keys
+}
+
+// Get 按名称获取公钥
+func (k *Keyring) Get(name string) (*PublicKey, bool) {
+	key, ok := k.keys[name]
+	return key, ok
+}
+
+// DefaultName 返回默认公钥名称
+func (k *Keyring) DefaultName() string {
+	return k.defaultKey
+}
+
+// ForRepo 返回仓库使用的公钥：优先仓库配置的 gpg-key，否则使用默认公钥
+func (k *Keyring) ForRepo(cfg *config.Config, repoName string) (*PublicKey, bool) {
+	name := k.defaultKey
+	if cfg != nil {
+		if rc, ok := cfg.RepoConfig(repoName); ok && rc.GPGKey != "" {
+			name = rc.GPGKey
+		}
+	}
+	if name == "" {
+		return nil, false
+	}
+	return k.Get(name)
+}
@@ -0,0 +1,206 @@
+package signing
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"plus/internal/config"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+func writeTestKey(t *testing.T, dir, name string, armored bool) (string, *openpgp.Entity) {
+	entity, err := openpgp.NewEntity(name, "test", name+"@example.com", nil)
+	if err != nil {
+		t.Fatalf("Failed to create entity: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if armored {
+		w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+		if err != nil {
+			t.Fatalf("Failed to create armor writer: %v", err)
+		}
+		if err := entity.Serialize(w); err != nil {
+			t.Fatalf("Failed to serialize entity: %v", err)
+		}
+		w.Close()
+	} else if err := entity.Serialize(&buf); err != nil {
+		t.Fatalf("Failed to serialize entity: %v", err)
+	}
+
+	path := filepath.Join(dir, name+".key")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return path, entity
+}
+
+func TestNewKeyring(t *testing.T) {
+	dir := t.TempDir()
+	armoredPath, entity := writeTestKey(t, dir, "release", true)
+	binaryPath, _ := writeTestKey(t, dir, "legacy", false)
+
+	kr, err := NewKeyring(config.SigningConfig{
+		DefaultKey: "release",
+		Keys: []config.KeyConfig{
+			{Name: "release", PublicKey: armoredPath},
+			{Name: "legacy", PublicKey: binaryPath},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+
+	if len(kr.List()) != 2 {
+		t.Fatalf("Expected 2 keys, got %d", len(kr.List()))
+	}
+
+	key, ok := kr.Get("release")
+	if !ok {
+		t.Fatal("Expected release key")
+	}
+	want := entity.PrimaryKey.KeyIdString()
+	if !strings.HasSuffix(key.Fingerprint, want) {
+		t.Errorf("Fingerprint %s does not end with key id %s", key.Fingerprint, want)
+	}
+	if len(key.Fingerprint) != 40 {
+		t.Errorf("Expected 40 hex chars fingerprint, got %q", key.Fingerprint)
+	}
+
+	legacy, _ := kr.Get("legacy")
+	if !bytes.HasPrefix(legacy.Armored, []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----")) {
+		t.Errorf("Binary key should be served armored")
+	}
+}
+
+func TestKeyringForRepo(t *testing.T) {
+	dir := t.TempDir()
+	releasePath, _ := writeTestKey(t, dir, "release", true)
+	testingPath, _ := writeTestKey(t, dir, "testing", true)
+
+	kr, err := NewKeyring(config.SigningConfig{
+		DefaultKey: "release",
+		Keys: []config.KeyConfig{
+			{Name: "release", PublicKey: releasePath},
+			{Name: "testing", PublicKey: testingPath},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+
+	cfg := &config.Config{
+		Repositories: map[string]config.RepoConfig{
+			"centos/7/testing": {GPGKey: "testing"},
+		},
+	}
+
+	if key, _ := kr.ForRepo(cfg, "centos/7/testing"); key == nil || key.Name != "testing" {
+		t.Errorf("Expected repo specific key, got %v", key)
+	}
+	if key, _ := kr.ForRepo(cfg, "centos/7/base"); key == nil || key.Name != "release" {
+		t.Errorf("Expected default key, got %v", key)
+	}
+}
+
+func TestNewKeyringErrors(t *testing.T) {
+	if _, err := NewKeyring(config.SigningConfig{DefaultKey: "missing"}); err == nil {
+		t.Error("Expected error for missing default key")
+	}
+
+	if _, err := NewKeyring(config.SigningConfig{
+		Keys: []config.KeyConfig{{Name: "broken", PublicKey: "/nonexistent/key.asc"}},
+	}); err == nil {
+		t.Error("Expected error for unreadable key file")
+	}
+
+	kr, err := NewKeyring(config.SigningConfig{})
+	if err != nil {
+		t.Fatalf("Empty keyring should be valid: %v", err)
+	}
+	if _, ok := kr.ForRepo(nil, "any"); ok {
+		t.Error("Empty keyring should not resolve repo keys")
+	}
+}
+
+func TestKeyringSign(t *testing.T) {
+	dir := t.TempDir()
+	publicPath, entity := writeTestKey(t, dir, "release", true)
+	legacyPath, _ := writeTestKey(t, dir, "legacy", true)
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatalf("Failed to create armor writer: %v", err)
+	}
+	if err := entity.SerializePrivate(w, nil); err != nil {
+		t.Fatalf("Failed to serialize private key: %v", err)
+	}
+	w.Close()
+	privatePath := filepath.Join(dir, "release.sec")
+	if err := os.WriteFile(privatePath, buf.Bytes(), 0600); err != nil {
+		t.Fatalf("Failed to write private key: %v", err)
+	}
+
+	kr, err := NewKeyring(config.SigningConfig{
+		DefaultKey: "release",
+		Keys: []config.KeyConfig{
+			{Name: "release", PublicKey: publicPath, PrivateKey: privatePath},
+			{Name: "legacy", PublicKey: legacyPath},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+	if !kr.CanSign("release") || kr.CanSign("legacy") {
+		t.Errorf("CanSign: release=%v legacy=%v", kr.CanSign("release"), kr.CanSign("legacy"))
+	}
+
+	data := []byte("0123  file.tar.gz\n")
+	sign := kr.SignForRepo(nil, "files")
+	if sign == nil {
+		t.Fatal("Expected signing function for the default key")
+	}
+	signature, err := sign(data)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if _, err := openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{entity}, bytes.NewReader(data), bytes.NewReader(signature), nil); err != nil {
+		t.Errorf("Signature does not verify: %v", err)
+	}
+
+	// InRelease 形式的 clearsign 消息
+	release := []byte("Origin: Example\nDate: Mon, 01 Jan 2024 00:00:00 UTC\n")
+	clearSign := kr.ClearSignForRepo(nil, "debs")
+	if clearSign == nil {
+		t.Fatal("Expected clearsign function for the default key")
+	}
+	inRelease, err := clearSign(release)
+	if err != nil {
+		t.Fatalf("ClearSign failed: %v", err)
+	}
+	verifier := &Verifier{keyring: openpgp.EntityList{entity}}
+	plaintext, _, err := verifier.VerifyClearsigned(inRelease)
+	if err != nil {
+		t.Errorf("Clearsigned message does not verify: %v", err)
+	} else if !bytes.Equal(plaintext, release) {
+		t.Errorf("Clearsigned plaintext = %q, want %q", plaintext, release)
+	}
+
+	cfg := &config.Config{Repositories: map[string]config.RepoConfig{"old": {GPGKey: "legacy"}}}
+	if kr.SignForRepo(cfg, "old") != nil || kr.ClearSignForRepo(cfg, "old") != nil {
+		t.Error("Key without private key should not sign")
+	}
+
+	// 私钥与公钥不匹配
+	if _, err := NewKeyring(config.SigningConfig{
+		Keys: []config.KeyConfig{{Name: "legacy", PublicKey: legacyPath, PrivateKey: privatePath}},
+	}); err == nil {
+		t.Error("Expected error for mismatched private key")
+	}
+}
@@ -0,0 +1,122 @@
+package signing
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"plus/internal/config"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+)
+
+// loadSigner 读取 armored 或二进制格式的私钥，返回与公钥指纹相同的密钥，加密的私钥用 passphrase-file 中的口令解密
+func loadSigner(kc config.KeyConfig, fingerprint string) (*openpgp.Entity, error) {
+	data, err := os.ReadFile(kc.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key %s: %w", kc.Name, err)
+	}
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		// 尝试二进制格式
+		entities, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key %s: %w", kc.Name, err)
+		}
+	}
+
+	var signer *openpgp.Entity
+	for _, e := range entities {
+		if e.PrivateKey != nil && strings.ToUpper(fmt.Sprintf("%x", e.PrimaryKey.Fingerprint)) == fingerprint {
+			signer = e
+			break
+		}
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("private key %s does not contain the secret key of %s", kc.Name, fingerprint)
+	}
+
+	if signer.PrivateKey.Encrypted {
+		if kc.PassphraseFile == "" {
+			return nil, fmt.Errorf("private key %s is encrypted, passphrase-file is required", kc.Name)
+		}
+		passphrase, err := os.ReadFile(kc.PassphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase of %s: %w", kc.Name, err)
+		}
+		if err := signer.PrivateKey.Decrypt(bytes.TrimRight(passphrase, "\r\n")); err != nil {
+			return nil, fmt.Errorf("failed to decrypt private key %s: %w", kc.Name, err)
+		}
+	}
+	return signer, nil
+}
+
+// CanSign 判断名为 name 的密钥是否配置了私钥
+func (k *Keyring) CanSign(name string) bool {
+	_, ok := k.signers[name]
+	return ok
+}
+
+// Sign 用名为 name 的私钥为 data 生成 armored 分离签名
+func (k *Keyring) Sign(name string, data []byte) ([]byte, error) {
+	signer, ok := k.signers[name]
+	if !ok {
+		return nil, fmt.Errorf("signing key %s has no private key", name)
+	}
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, signer, bytes.NewReader(data), nil); err != nil {
+		return nil, fmt.Errorf("failed to sign with %s: %w", name, err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// ClearSign 用名为 name 的私钥生成 data 的 clearsign 消息，如 DEB 仓库的 InRelease
+func (k *Keyring) ClearSign(name string, data []byte) ([]byte, error) {
+	signer, ok := k.signers[name]
+	if !ok {
+		return nil, fmt.Errorf("signing key %s has no private key", name)
+	}
+	key, ok := signer.SigningKey(time.Now())
+	if !ok {
+		return nil, fmt.Errorf("signing key %s has no usable signing key", name)
+	}
+	var buf bytes.Buffer
+	w, err := clearsign.Encode(&buf, key.PrivateKey, nil)
+	if err == nil {
+		_, err = w.Write(data)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to clearsign with %s: %w", name, err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// ClearSignForRepo 返回用仓库的密钥生成 clearsign 消息的函数，该密钥没有配置私钥时返回 nil
+func (k *Keyring) ClearSignForRepo(cfg *config.Config, repoName string) func(data []byte) ([]byte, error) {
+	key, ok := k.ForRepo(cfg, repoName)
+	if !ok || !k.CanSign(key.Name) {
+		return nil
+	}
+	return func(data []byte) ([]byte, error) {
+		return k.ClearSign(key.Name, data)
+	}
+}
+
+// SignForRepo 返回用仓库的密钥（见 ForRepo）生成分离签名的函数，该密钥没有配置私钥时返回 nil
+func (k *Keyring) SignForRepo(cfg *config.Config, repoName string) func(data []byte) ([]byte, error) {
+	key, ok := k.ForRepo(cfg, repoName)
+	if !ok || !k.CanSign(key.Name) {
+		return nil
+	}
+	return func(data []byte) ([]byte, error) {
+		return k.Sign(key.Name, data)
+	}
+}
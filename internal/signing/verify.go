@@ -0,0 +1,74 @@
+package signing
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+)
+
+// Verifier 使用固定（pinned）的公钥校验上游签名
+type Verifier struct {
+	keyring openpgp.EntityList
+}
+
+// NewVerifier 从公钥文件（armored 或二进制格式）加载固定公钥
+func NewVerifier(paths []string) (*Verifier, error) {
+	v := &Verifier{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pinned key %s: %w", path, err)
+		}
+
+		entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+		if err != nil {
+			entities, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse pinned key %s: %w", path, err)
+			}
+		}
+		v.keyring = append(v.keyring, entities...)
+	}
+
+	if len(v.keyring) == 0 {
+		return nil, fmt.Errorf("no pinned keys configured")
+	}
+	return v, nil
+}
+
+// VerifyDetached 校验分离签名（armored 或二进制），返回签名公钥指纹
+func (v *Verifier) VerifyDetached(signed, signature []byte) (string, error) {
+	signer, err := openpgp.CheckArmoredDetachedSignature(v.keyring, bytes.NewReader(signed), bytes.NewReader(signature), nil)
+	if err != nil {
+		signer, err = openpgp.CheckDetachedSignature(v.keyring, bytes.NewReader(signed), bytes.NewReader(signature), nil)
+	}
+	if err != nil {
+		return "", fmt.Errorf("signature verification failed: %w", err)
+	}
+	return fingerprint(signer), nil
+}
+
+// VerifyClearsigned 校验 clearsign 消息（如 InRelease），返回明文与签名公钥指纹
+func (v *Verifier) VerifyClearsigned(data []byte) ([]byte, string, error) {
+	block, _ := clearsign.Decode(data)
+	if block == nil {
+		return nil, "", fmt.Errorf("no clearsigned message found")
+	}
+
+	signer, err := openpgp.CheckDetachedSignature(v.keyring, bytes.NewReader(block.Bytes), block.ArmoredSignature.Body, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("signature verification failed: %w", err)
+	}
+	return block.Plaintext, fingerprint(signer), nil
+}
+
+func fingerprint(e *openpgp.Entity) string {
+	if e == nil || e.PrimaryKey == nil {
+		return ""
+	}
+	return strings.ToUpper(fmt.Sprintf("%x", e.PrimaryKey.Fingerprint))
+}
@@ -0,0 +1,413 @@
+package soak
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	stdlog "log"
+	mrand "math/rand"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"plus/internal/api"
+	"plus/internal/cache"
+	"plus/internal/config"
+	"plus/internal/log"
+	"plus/internal/service"
+	"plus/internal/types"
+	"plus/internal/worker"
+	"plus/pkg/repo/files"
+	"plus/pkg/storage/fault"
+	"plus/pkg/storage/local"
+	"plus/pkg/storage/retry"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+)
+
+// 发布前验证时加大时长，如 go test ./internal/soak -run TestSoak -timeout 0 -soak.duration 2h
+var (
+	duration    = flag.Duration("soak.duration", 3*time.Second, "how long to run the mixed workload")
+	concurrency = flag.Int("soak.concurrency", 8, "number of concurrent clients")
+	seed        = flag.Int64("soak.seed", 0, "random seed for faults and workload, 0 uses the current time")
+)
+
+const (
+	repoName       = "soak"
+	objectsPerUser = 8
+	maxPayload     = 64 << 10
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	// 路由的访问日志
+	stdlog.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// TestSoak 在注入慢 IO、磁盘已满和对象存储错误的情况下持续执行上传、下载、列表和校验和请求，
+// 然后关闭故障确认已确认的上传完整可读、没有读到损坏的内容，并且关闭服务后没有泄漏协程
+func TestSoak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("soak test skipped in short mode")
+	}
+	s := *seed
+	if s == 0 {
+		s = time.Now().UnixNano()
+	}
+	t.Logf("soak seed %d, duration %s, concurrency %d", s, *duration, *concurrency)
+
+	baseline := runtime.NumGoroutine()
+	h := start(t, s)
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	users := make([]*user, *concurrency)
+	for i := range users {
+		users[i] = &user{h: h, id: i, rnd: mrand.New(mrand.NewSource(s + int64(i))), objects: make(map[string]map[string]bool)}
+		wg.Add(1)
+		go func(u *user) {
+			defer wg.Done()
+			u.run(ctx)
+		}(users[i])
+	}
+	wg.Wait()
+	cancel()
+
+	st := h.faults.Stats()
+	t.Logf("requests %d, failed %d; injected ENOSPC %d, object store errors %d, read errors %d",
+		atomic.LoadInt64(&h.requests), atomic.LoadInt64(&h.failed), st.NoSpace, st.Errors, st.ReadErrors)
+
+	// 关闭故障后逐个核对
+	h.faults.SetEnabled(false)
+	for _, u := range users {
+		u.verify()
+	}
+	h.verifyListing(users)
+
+	for _, msg := range h.problems() {
+		t.Error(msg)
+	}
+
+	h.stop()
+	checkGoroutines(t, baseline)
+}
+
+// harness 进程内的 plus 服务，files 仓库的存储注入故障，并带重试和磁盘读缓存
+type harness struct {
+	t      *testing.T
+	url    string
+	client *http.Client
+	faults *fault.Storage
+	pool   *worker.Pool
+	server *fasthttp.Server
+	ln     net.Listener
+	served chan error
+
+	requests int64
+	failed   int64
+
+	mu     sync.Mutex
+	issues []string
+}
+
+func start(t *testing.T, seed int64) *harness {
+	dir := t.TempDir()
+	backend, _ := local.NewLocalStorage(filepath.Join(dir, "objects"))
+	faults := fault.New(backend, fault.Config{
+		Latency:   time.Millisecond,
+		SlowIO:    100 * time.Microsecond,
+		NoSpace:   0.05,
+		Error:     0.05,
+		ReadError: 0.05,
+		Seed:      seed,
+	})
+	readCache, err := cache.NewDiskCache(filepath.Join(dir, "cache"), 4<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 服务的本地目录为空，files 仓库的访问都经过存储，模拟对象存储后端
+	cfg := &config.Config{StoragePath: filepath.Join(dir, "storage")}
+	if err := os.MkdirAll(cfg.StoragePath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// 与对象存储仓库相同：读缓存在外层，其下重试
+	backendWithRetry := retry.New(faults, retry.Config{Attempts: 3, Backoff: time.Millisecond})
+	repoService := service.NewRepoService(files.NewFilesRepo(readCache.Wrap(backendWithRetry, "files")))
+	pool := worker.New(4, 64)
+	repoService.SetWorkerPool(pool)
+	if err := repoService.SetRepoType(context.Background(), repoName, "files"); err != nil {
+		t.Fatal(err)
+	}
+
+	r := api.NewAPI(repoService, cfg)
+	r.SetReadCache(readCache)
+
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &harness{
+		t:      t,
+		url:    "http://" + ln.Addr().String(),
+		client: &http.Client{Timeout: 30 * time.Second, Transport: &http.Transport{MaxIdleConnsPerHost: *concurrency}},
+		faults: faults,
+		pool:   pool,
+		server: &fasthttp.Server{
+			Handler:      api.SetupRouter(r),
+			ReadTimeout:  30 * time.Second,
+			WriteTimeout: 30 * time.Second,
+			// 关闭后空闲工作协程的清理协程尽快退出
+			MaxIdleWorkerDuration: time.Second,
+		},
+		ln:     ln,
+		served: make(chan error, 1),
+	}
+	go func() { h.served <- h.server.Serve(ln) }()
+	return h
+}
+
+// stop 关闭客户端连接、监听和工作池。与 plus 进程退出时一样不调用 Shutdown：
+// fasthttp 的 Shutdown 与请求 ctx 的 Done() 之间没有同步，-race 下会报数据竞争
+func (h *harness) stop() {
+	h.client.CloseIdleConnections()
+	h.ln.Close()
+	<-h.served
+	h.pool.Close()
+}
+
+func (h *harness) problem(format string, args ...interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.issues = append(h.issues, fmt.Sprintf(format, args...))
+}
+
+func (h *harness) problems() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.issues
+}
+
+// do 发送请求并读完响应体；读取中断时返回错误
+func (h *harness) do(req *http.Request) (int, []byte, error) {
+	atomic.AddInt64(&h.requests, 1)
+	resp, err := h.client.Do(req)
+	if err != nil {
+		atomic.AddInt64(&h.failed, 1)
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		atomic.AddInt64(&h.failed, 1)
+	}
+	return resp.StatusCode, body, err
+}
+
+func (h *harness) get(ctx context.Context, path string) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url+path, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	return h.do(req)
+}
+
+// verifyListing 列表中只能出现客户端写过的文件，确认上传成功的文件必须出现
+func (h *harness) verifyListing(users []*user) {
+	status, body, err := h.get(context.Background(), "/"+repoName)
+	if err != nil || status != http.StatusOK {
+		h.problem("listing after faults disabled: status %d, %v", status, err)
+		return
+	}
+	listing := string(body)
+	for _, name := range listedNames(listing) {
+		if !known(users, name) {
+			h.problem("listing shows unexpected file %s", name)
+		}
+	}
+	for _, u := range users {
+		for name, sums := range u.objects {
+			if len(sums) > 0 && !sums[""] && !strings.Contains(listing, name) {
+				h.problem("listing misses %s", name)
+			}
+		}
+	}
+}
+
+// listedNames 从目录页面中取出指向仓库内文件的链接
+func listedNames(page string) []string {
+	var names []string
+	for _, part := range strings.Split(page, `href="`)[1:] {
+		link := part[:strings.IndexByte(part, '"')]
+		name := filepath.Base(strings.TrimSuffix(link, "/"))
+		if strings.Contains(link, repoName+"/") && strings.Contains(name, ".") {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func known(users []*user, name string) bool {
+	var id, n int
+	if _, err := fmt.Sscanf(name, "u%d-%d.bin", &id, &n); err != nil {
+		return false
+	}
+	return id < len(users) && n < objectsPerUser && fmt.Sprintf("u%d-%d.bin", id, n) == name
+}
+
+// user 一个客户端，只写自己的文件，顺序执行请求，因此每个文件的预期内容是确定的
+type user struct {
+	h   *harness
+	id  int
+	rnd *mrand.Rand
+	// 文件名 -> 可接受内容的 SHA256；空字符串表示文件可以不存在
+	objects map[string]map[string]bool
+}
+
+func (u *user) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		name := fmt.Sprintf("u%d-%d.bin", u.id, u.rnd.Intn(objectsPerUser))
+		switch n := u.rnd.Intn(100); {
+		case n < 30:
+			u.upload(ctx, name)
+		case n < 75:
+			u.download(ctx, name)
+		case n < 90:
+			u.checksum(ctx, name)
+		default:
+			u.h.get(ctx, "/"+repoName)
+		}
+	}
+}
+
+// accepted 文件当前可接受的内容，未上传过的文件只能不存在
+func (u *user) accepted(name string) map[string]bool {
+	if sums, ok := u.objects[name]; ok {
+		return sums
+	}
+	return map[string]bool{"": true}
+}
+
+func (u *user) upload(ctx context.Context, name string) {
+	payload := make([]byte, u.rnd.Intn(maxPayload))
+	u.rnd.Read(payload)
+	sum := checksum(payload)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", name)
+	fw.Write(payload)
+	mw.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.h.url+"/repo/"+repoName+"/upload", &body)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	status, _, err := u.h.do(req)
+	switch {
+	case err != nil:
+		// 不知道服务端是否已写入，新旧内容都可以接受
+		sums := u.accepted(name)
+		sums[sum] = true
+		u.objects[name] = sums
+	case status == http.StatusOK:
+		u.objects[name] = map[string]bool{sum: true}
+	}
+	// 服务端返回错误时旧内容必须保持不变
+}
+
+func (u *user) download(ctx context.Context, name string) {
+	status, body, err := u.h.get(ctx, "/"+repoName+"/"+name)
+	if err != nil || status != http.StatusOK {
+		// 注入的错误导致下载失败是允许的，只有返回了内容时才检查
+		return
+	}
+	if sums := u.accepted(name); !sums[checksum(body)] {
+		u.h.problem("download %s returned %d bytes with unexpected content", name, len(body))
+	}
+}
+
+func (u *user) checksum(ctx context.Context, name string) {
+	status, body, err := u.h.get(ctx, "/repo/"+repoName+"/checksum/"+name)
+	if err != nil || status != http.StatusOK {
+		return
+	}
+	result := &types.PackageChecksum{}
+	if err := result.UnmarshalJSON(body); err != nil {
+		u.h.problem("checksum %s: invalid response %q", name, body)
+		return
+	}
+	if sums := u.accepted(name); !sums[result.SHA256] {
+		u.h.problem("checksum %s is %s, not one of the uploaded contents", name, result.SHA256)
+	}
+}
+
+// verify 故障关闭后每个文件都应能完整读取，内容与最后一次成功的上传一致
+func (u *user) verify() {
+	ctx := context.Background()
+	for i := 0; i < objectsPerUser; i++ {
+		name := fmt.Sprintf("u%d-%d.bin", u.id, i)
+		sums := u.accepted(name)
+		status, body, err := u.h.get(ctx, "/"+repoName+"/"+name)
+		switch {
+		case err != nil:
+			u.h.problem("verify %s: %v", name, err)
+		case status == http.StatusOK:
+			if !sums[checksum(body)] {
+				u.h.problem("verify %s: corrupted content (%d bytes)", name, len(body))
+			}
+		case !sums[""]:
+			u.h.problem("verify %s: acknowledged upload lost, status %d", name, status)
+		}
+	}
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// checkGoroutines 服务关闭后不应残留 plus 的协程，总数回到启动前的水平
+func checkGoroutines(t *testing.T, baseline int) {
+	var leaked []string
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		leaked = leaked[:0]
+		var buf bytes.Buffer
+		pprof.Lookup("goroutine").WriteTo(&buf, 2)
+		for _, g := range strings.Split(buf.String(), "\n\n") {
+			if strings.Contains(g, "plus/") && !strings.Contains(g, "plus/internal/soak.") {
+				leaked = append(leaked, g)
+			}
+		}
+		// fasthttp 常驻更新日期头和清理静态文件缓存的两个协程
+		if len(leaked) == 0 && runtime.NumGoroutine() <= baseline+2 {
+			return
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	sort.Strings(leaked)
+	t.Errorf("goroutines leaked: %d running, %d before the test\n%s",
+		runtime.NumGoroutine(), baseline, strings.Join(leaked, "\n\n"))
+}
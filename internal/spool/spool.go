@@ -0,0 +1,237 @@
+package spool
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"sync/atomic"
+)
+
+// maxValueBytes 表单中非文件字段的总长度上限
+const maxValueBytes = 10 << 20
+
+var (
+	// ErrTooLarge 请求体超过 max-body-size
+	ErrTooLarge = errors.New("request body too large")
+	// ErrMissingFile 表单中没有该文件字段
+	ErrMissingFile = errors.New("no such file in form")
+)
+
+// Spooler 读取 multipart 请求体：整个表单最多 threshold 字节保存在内存中，其余文件内容写入 dir。
+// 临时文件创建后立即删除，只通过打开的文件访问，进程退出或崩溃后不会留下文件
+type Spooler struct {
+	dir       string
+	threshold int64
+	maxSize   int64
+
+	active int64 // 当前打开的临时文件数
+	bytes  int64 // 当前临时文件的总大小
+}
+
+// New 创建 spool 目录，dir 为空时使用系统临时目录，maxSize 为 0 表示不限制请求体大小
+func New(dir string, threshold, maxSize int64) (*Spooler, error) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create spool dir: %w", err)
+	}
+	// 检查目录可写且支持删除打开的文件
+	f, err := os.CreateTemp(dir, "upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("spool dir %s is not writable: %w", dir, err)
+	}
+	defer f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return nil, fmt.Errorf("spool dir %s: %w", dir, err)
+	}
+	return &Spooler{dir: dir, threshold: threshold, maxSize: maxSize}, nil
+}
+
+// Dir 返回 spool 目录
+func (s *Spooler) Dir() string { return s.dir }
+
+// Usage 返回当前打开的临时文件数和总大小
+func (s *Spooler) Usage() (files, bytes int64) {
+	return atomic.LoadInt64(&s.active), atomic.LoadInt64(&s.bytes)
+}
+
+// File 表单中的一个文件
+type File struct {
+	Filename string
+	Header   textproto.MIMEHeader
+	Size     int64
+
+	data []byte   // 保存在内存中的内容
+	file *os.File // 写入 spool 的内容
+}
+
+// Open 返回文件内容，多次打开互不影响，关闭返回的文件不会释放临时文件
+func (f *File) Open() (multipart.File, error) {
+	if f.file != nil {
+		return sectionFile{io.NewSectionReader(f.file, 0, f.Size)}, nil
+	}
+	return sectionFile{io.NewSectionReader(bytes.NewReader(f.data), 0, f.Size)}, nil
+}
+
+// Spooled 返回文件内容是否写入了 spool
+func (f *File) Spooled() bool { return f.file != nil }
+
+type sectionFile struct{ *io.SectionReader }
+
+func (sectionFile) Close() error { return nil }
+
+// Form 解析后的表单，使用完后调用 Close 释放临时文件
+type Form struct {
+	Value map[string][]string
+	File  map[string][]*File
+
+	s *Spooler
+}
+
+// FileHeader 返回字段 name 的第一个文件
+func (f *Form) FileHeader(name string) (*File, error) {
+	if files := f.File[name]; len(files) > 0 {
+		return files[0], nil
+	}
+	return nil, ErrMissingFile
+}
+
+// FormValue 返回字段 name 的第一个值
+func (f *Form) FormValue(name string) string {
+	if values := f.Value[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Close 关闭所有临时文件，可以多次调用
+func (f *Form) Close() error {
+	for _, files := range f.File {
+		for _, file := range files {
+			if file.file != nil {
+				file.file.Close()
+				file.file = nil
+				atomic.AddInt64(&f.s.active, -1)
+				atomic.AddInt64(&f.s.bytes, -file.Size)
+			}
+		}
+	}
+	return nil
+}
+
+// ReadForm 读取 boundary 分隔的 multipart 请求体，出错时已写入的临时文件会被释放
+func (s *Spooler) ReadForm(r io.Reader, boundary string) (*Form, error) {
+	form := &Form{Value: map[string][]string{}, File: map[string][]*File{}, s: s}
+	ok := false
+	defer func() {
+		if !ok {
+			form.Close()
+		}
+	}()
+
+	body := &limitedReader{r: r, n: s.maxSize}
+	mr := multipart.NewReader(body, boundary)
+	memory, values := s.threshold, int64(0)
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			ok = true
+			return form, nil
+		}
+		if err != nil {
+			return nil, body.wrap(err)
+		}
+		name := p.FormName()
+		if name == "" {
+			continue
+		}
+
+		if p.FileName() == "" {
+			var b bytes.Buffer
+			n, err := io.Copy(&b, io.LimitReader(p, maxValueBytes-values+1))
+			if err != nil {
+				return nil, body.wrap(err)
+			}
+			if values += n; values > maxValueBytes {
+				return nil, fmt.Errorf("multipart form values exceed %d bytes", maxValueBytes)
+			}
+			form.Value[name] = append(form.Value[name], b.String())
+			continue
+		}
+
+		file := &File{Filename: p.FileName(), Header: p.Header}
+		var b bytes.Buffer
+		n, err := io.Copy(&b, io.LimitReader(p, memory+1))
+		if err != nil {
+			return nil, body.wrap(err)
+		}
+		if n <= memory {
+			file.data, file.Size = b.Bytes(), n
+			memory -= n
+		} else if err := s.spoolPart(file, &b, p); err != nil {
+			return nil, body.wrap(err)
+		}
+		form.File[name] = append(form.File[name], file)
+	}
+}
+
+// spoolPart 把已读取的 head 和 part 的其余内容写入临时文件
+func (s *Spooler) spoolPart(file *File, head *bytes.Buffer, part io.Reader) error {
+	f, err := os.CreateTemp(s.dir, "upload-*")
+	if err != nil {
+		return err
+	}
+	// 删除后文件只通过打开的描述符访问，关闭时由系统回收
+	if err := os.Remove(f.Name()); err != nil {
+		f.Close()
+		return err
+	}
+	n, err := io.Copy(f, io.MultiReader(head, part))
+	if err != nil {
+		f.Close()
+		return err
+	}
+	file.file, file.Size = f, n
+	atomic.AddInt64(&s.active, 1)
+	atomic.AddInt64(&s.bytes, n)
+	return nil
+}
+
+// limitedReader 读取超过 n 字节时返回 ErrTooLarge，n 为 0 表示不限制
+type limitedReader struct {
+	r        io.Reader
+	n        int64
+	read     int64
+	exceeded bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n > 0 && l.read >= l.n {
+		// 多读一个字节判断是否超过上限
+		var one [1]byte
+		if n, _ := io.ReadFull(l.r, one[:]); n > 0 {
+			l.exceeded = true
+			return 0, ErrTooLarge
+		}
+		return 0, io.EOF
+	}
+	if l.n > 0 && int64(len(p)) > l.n-l.read {
+		p = p[:l.n-l.read]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	return n, err
+}
+
+// wrap multipart 解析错误在超过上限时统一为 ErrTooLarge
+func (l *limitedReader) wrap(err error) error {
+	if l.exceeded || errors.Is(err, ErrTooLarge) {
+		return ErrTooLarge
+	}
+	return err
+}
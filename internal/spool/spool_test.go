@@ -0,0 +1,109 @@
+package spool
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"os"
+	"testing"
+)
+
+// multipartBody 构造包含 fields 和 files 的请求体
+func multipartBody(t *testing.T, fields map[string]string, files map[string][]byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		w.WriteField(k, v)
+	}
+	for name, data := range files {
+		fw, err := w.CreateFormFile(name, name+".rpm")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write(data)
+	}
+	w.Close()
+	return &body, w.Boundary()
+}
+
+func TestReadForm(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(dir, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	small := bytes.Repeat([]byte("s"), 100)
+	large := bytes.Repeat([]byte("l"), 5000)
+	body, boundary := multipartBody(t, map[string]string{"repository": "el9"}, map[string][]byte{"small": small, "large": large})
+
+	form, err := s.ReadForm(body, boundary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.FormValue("repository") != "el9" || form.FormValue("missing") != "" {
+		t.Errorf("values = %v", form.Value)
+	}
+	for name, want := range map[string][]byte{"small": small, "large": large} {
+		f, err := form.FileHeader(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Filename != name+".rpm" || f.Size != int64(len(want)) || f.Spooled() != (name == "large") {
+			t.Errorf("%s: filename %s, size %d, spooled %t", name, f.Filename, f.Size, f.Spooled())
+		}
+		// 每次打开都从头读取
+		for i := 0; i < 2; i++ {
+			r, _ := f.Open()
+			got, _ := io.ReadAll(r)
+			r.Close()
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s: read %d bytes", name, len(got))
+			}
+		}
+	}
+	if _, err := form.FileHeader("missing"); !errors.Is(err, ErrMissingFile) {
+		t.Errorf("missing file err = %v", err)
+	}
+
+	// spool 文件创建后即删除，不占用目录
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("spool dir has %d entries", len(entries))
+	}
+	if files, size := s.Usage(); files != 1 || size != 5000 {
+		t.Errorf("usage = %d files, %d bytes", files, size)
+	}
+	form.Close()
+	form.Close()
+	if files, size := s.Usage(); files != 0 || size != 0 {
+		t.Errorf("usage after close = %d files, %d bytes", files, size)
+	}
+}
+
+func TestReadFormLimits(t *testing.T) {
+	s, err := New(t.TempDir(), 1024, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, boundary := multipartBody(t, nil, map[string][]byte{"file": make([]byte, 8192)})
+	if _, err := s.ReadForm(body, boundary); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("oversized body err = %v", err)
+	}
+	if files, _ := s.Usage(); files != 0 {
+		t.Errorf("%d spool files left after error", files)
+	}
+
+	body, boundary = multipartBody(t, nil, map[string][]byte{"file": make([]byte, 2048)})
+	if form, err := s.ReadForm(body, boundary); err != nil {
+		t.Errorf("body under limit err = %v", err)
+	} else {
+		form.Close()
+	}
+
+	s, _ = New(t.TempDir(), 1024, 0)
+	body, boundary = multipartBody(t, map[string]string{"notes": string(make([]byte, maxValueBytes+1))}, nil)
+	if _, err := s.ReadForm(body, boundary); err == nil {
+		t.Error("ReadForm accepted oversized values")
+	}
+}
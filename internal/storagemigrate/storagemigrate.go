@@ -0,0 +1,165 @@
+package storagemigrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+
+	"plus/internal/types"
+	"plus/pkg/repo/files"
+	"plus/pkg/storage"
+)
+
+// Sync 把 src 中 files 仓库的全部对象复制到 dst，写入后从 dst 读回校验 SHA-256。
+// dst 中已有且内容相同的对象跳过，仓库中已从 src 删除的对象也从 dst 删除；dryRun 时只统计，不写入 dst。
+// 整个仓库在两次 Sync 之间被删除时，dst 中的副本保留
+func Sync(ctx context.Context, src, dst storage.Storage, dryRun bool) types.StorageSyncPass {
+	pass := types.StorageSyncPass{Repos: []string{}}
+	fail := func(format string, args ...interface{}) {
+		pass.Failed++
+		pass.Errors = append(pass.Errors, fmt.Sprintf(format, args...))
+	}
+
+	repos, err := files.NewFilesRepo(src).ListRepos(ctx)
+	if err != nil {
+		fail("list repositories: %v", err)
+		return pass
+	}
+	sort.Strings(repos)
+	pass.Repos = append(pass.Repos, repos...)
+
+	// 嵌套仓库的对象也出现在上层仓库的列表中，只处理一次
+	seen := make(map[string]bool)
+	for _, name := range repos {
+		objects, err := src.ListWithOptions(ctx, name, storage.ListOptions{MaxDepth: -1})
+		if err != nil {
+			fail("%s: %v", name, err)
+			continue
+		}
+		if !dryRun {
+			if err := dst.CreateDir(ctx, name); err != nil {
+				fail("%s: %v", name, err)
+				continue
+			}
+		}
+		for _, obj := range objects {
+			p := path.Join(name, obj.Name)
+			if obj.IsDir || seen[p] {
+				continue
+			}
+			seen[p] = true
+			pass.Objects++
+
+			same, err := identical(ctx, src, dst, p)
+			if err != nil {
+				fail("%s: %v", p, err)
+				continue
+			}
+			if same {
+				pass.Existing++
+				continue
+			}
+			if !dryRun {
+				n, err := copyObject(ctx, src, dst, p)
+				if err != nil {
+					fail("%s: %v", p, err)
+					continue
+				}
+				pass.Size += n
+			}
+			pass.Copied++
+		}
+
+		// 第一轮之后从源仓库删除的对象
+		stale, err := dst.ListWithOptions(ctx, name, storage.ListOptions{MaxDepth: -1})
+		if err != nil {
+			fail("%s: %v", name, err)
+			continue
+		}
+		for _, obj := range stale {
+			p := path.Join(name, obj.Name)
+			if obj.IsDir || seen[p] {
+				continue
+			}
+			if !dryRun {
+				if err := dst.Delete(ctx, p); err != nil {
+					fail("%s: %v", p, err)
+					continue
+				}
+			}
+			pass.Deleted++
+		}
+	}
+	return pass
+}
+
+// identical dst 中已有大小和 SHA-256 都相同的对象
+func identical(ctx context.Context, src, dst storage.Storage, p string) (bool, error) {
+	dstInfo, err := dst.Stat(ctx, p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	srcInfo, err := src.Stat(ctx, p)
+	if err != nil {
+		return false, err
+	}
+	if srcInfo.Size != dstInfo.Size {
+		return false, nil
+	}
+	srcSum, _, err := checksum(ctx, src, p)
+	if err != nil {
+		return false, err
+	}
+	dstSum, _, err := checksum(ctx, dst, p)
+	if err != nil {
+		return false, err
+	}
+	return srcSum == dstSum, nil
+}
+
+// copyObject 复制时计算源内容的 SHA-256，写入后读回比较；不一致时删除写入的对象
+func copyObject(ctx context.Context, src, dst storage.Storage, p string) (int64, error) {
+	rc, err := src.Get(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	if err := dst.Store(ctx, p, io.TeeReader(rc, h)); err != nil {
+		return 0, err
+	}
+	want := fmt.Sprintf("%x", h.Sum(nil))
+
+	got, n, err := checksum(ctx, dst, p)
+	if err != nil {
+		return 0, fmt.Errorf("verify copy: %w", err)
+	}
+	if got != want {
+		dst.Delete(ctx, p)
+		return 0, fmt.Errorf("checksum mismatch after copy: sha256 %s, want %s", got, want)
+	}
+	return n, nil
+}
+
+func checksum(ctx context.Context, s storage.Storage, p string) (string, int64, error) {
+	rc, err := s.Get(ctx, p)
+	if err != nil {
+		return "", 0, err
+	}
+	defer rc.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, rc)
+	if err != nil {
+		return "", 0, err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), n, nil
+}
@@ -0,0 +1,124 @@
+package storagemigrate
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"plus/internal/log"
+	"plus/pkg/repo/files"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func store(t *testing.T, s storage.Storage, path, content string) {
+	t.Helper()
+	if err := s.Store(context.Background(), path, strings.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func read(t *testing.T, s storage.Storage, path string) string {
+	t.Helper()
+	rc, err := s.Get(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Get %s: %v", path, err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	return string(data)
+}
+
+// corrupting 写入的内容被截断
+type corrupting struct {
+	storage.Storage
+}
+
+func (c *corrupting) Store(ctx context.Context, path string, reader io.Reader) error {
+	data, _ := io.ReadAll(reader)
+	return c.Storage.Store(ctx, path, strings.NewReader(string(data[:len(data)/2])))
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	src, _ := local.NewLocalStorage(t.TempDir())
+	dst, _ := local.NewLocalStorage(t.TempDir())
+
+	for _, name := range []string{"tools", "tools/nightly"} {
+		if err := files.NewFilesRepo(src).CreateRepo(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store(t, src, "tools/a.tar", "aaaa")
+	store(t, src, "tools/docs/b.txt", "bb")
+	store(t, src, "tools/nightly/c.bin", "c")
+
+	// 试运行不写入目标
+	pass := Sync(ctx, src, dst, true)
+	if pass.Objects != 5 || pass.Copied != 5 || pass.Failed != 0 {
+		t.Fatalf("dry run = %+v", pass)
+	}
+	if ok, _ := dst.Exists(ctx, "tools/a.tar"); ok {
+		t.Fatal("dry run wrote to the target")
+	}
+
+	pass = Sync(ctx, src, dst, false)
+	if pass.Objects != 5 || pass.Copied != 5 || pass.Existing != 0 || pass.Failed != 0 || pass.Size != int64(7+2*len("files")) {
+		t.Fatalf("first pass = %+v", pass)
+	}
+	if strings.Join(pass.Repos, ",") != "tools,tools/nightly" {
+		t.Errorf("repos = %v", pass.Repos)
+	}
+	if got := read(t, dst, "tools/docs/b.txt"); got != "bb" {
+		t.Errorf("copied content = %q", got)
+	}
+	repos, _ := files.NewFilesRepo(dst).ListRepos(ctx)
+	if len(repos) != 2 {
+		t.Errorf("target repos = %v", repos)
+	}
+
+	// 第二轮只复制改动，删除源中已删除的对象
+	store(t, src, "tools/a.tar", "AAAA")
+	store(t, src, "tools/new.txt", "n")
+	if err := src.Delete(ctx, "tools/docs/b.txt"); err != nil {
+		t.Fatal(err)
+	}
+	pass = Sync(ctx, src, dst, false)
+	if pass.Objects != 5 || pass.Copied != 2 || pass.Existing != 3 || pass.Deleted != 1 || pass.Failed != 0 {
+		t.Fatalf("second pass = %+v", pass)
+	}
+	if got := read(t, dst, "tools/a.tar"); got != "AAAA" {
+		t.Errorf("changed object = %q", got)
+	}
+	if ok, _ := dst.Exists(ctx, "tools/docs/b.txt"); ok {
+		t.Error("deleted object still on the target")
+	}
+}
+
+func TestSyncVerifiesCopies(t *testing.T) {
+	ctx := context.Background()
+	src, _ := local.NewLocalStorage(t.TempDir())
+	backend, _ := local.NewLocalStorage(t.TempDir())
+
+	if err := files.NewFilesRepo(src).CreateRepo(ctx, "tools"); err != nil {
+		t.Fatal(err)
+	}
+	store(t, src, "tools/a.tar", "aaaa")
+
+	pass := Sync(ctx, src, &corrupting{backend}, false)
+	if pass.Failed != 2 || len(pass.Errors) != 2 || !strings.Contains(pass.Errors[0], "checksum mismatch") {
+		t.Fatalf("pass = %+v", pass)
+	}
+	if ok, _ := backend.Exists(ctx, "tools/a.tar"); ok {
+		t.Error("corrupt copy left on the target")
+	}
+}
@@ -0,0 +1,168 @@
+package throttle
+
+import (
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// route 连接当前使用的限速器，upload 作用于读，download 作用于写
+type route struct {
+	upload   []*limiter
+	download []*limiter
+}
+
+// Conn 按当前 route 限速的连接
+type Conn struct {
+	net.Conn
+	route atomic.Pointer[route]
+
+	// 限速等待会拉长读写时间，超时按每个分块重新计算
+	mu           sync.Mutex
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+func newConn(c net.Conn, r *route) *Conn {
+	tc := &Conn{Conn: c}
+	tc.route.Store(r)
+	return tc
+}
+
+// setRoute 切换后续读写使用的限速器
+func (c *Conn) setRoute(r *route) {
+	c.route.Store(r)
+}
+
+func (c *Conn) Read(p []byte) (int, error) {
+	r := c.route.Load()
+	if len(p) > chunkSize {
+		p = p[:chunkSize]
+	}
+	if limited(r.upload) {
+		c.refreshDeadline(true)
+	}
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		account(r.upload, n)
+	}
+	return n, err
+}
+
+func (c *Conn) Write(p []byte) (int, error) {
+	r := c.route.Load()
+	written := 0
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > chunkSize {
+			chunk = chunk[:chunkSize]
+		}
+		account(r.download, len(chunk))
+		if limited(r.download) {
+			c.refreshDeadline(false)
+		}
+		n, err := c.Conn.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// ReadFrom 未限速时交给底层连接（可使用 sendfile），否则按分块写入
+func (c *Conn) ReadFrom(src io.Reader) (int64, error) {
+	r := c.route.Load()
+	if rf, ok := c.Conn.(io.ReaderFrom); ok && !limited(r.download) {
+		n, err := rf.ReadFrom(src)
+		account(r.download, int(n))
+		return n, err
+	}
+	buf := make([]byte, chunkSize)
+	return io.CopyBuffer(writerOnly{c}, src, buf)
+}
+
+func (c *Conn) SetDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.readTimeout, c.writeTimeout = timeout(t), timeout(t)
+	c.mu.Unlock()
+	return c.Conn.SetDeadline(t)
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.readTimeout = timeout(t)
+	c.mu.Unlock()
+	return c.Conn.SetReadDeadline(t)
+}
+
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.writeTimeout = timeout(t)
+	c.mu.Unlock()
+	return c.Conn.SetWriteDeadline(t)
+}
+
+func (c *Conn) refreshDeadline(read bool) {
+	c.mu.Lock()
+	d := c.writeTimeout
+	if read {
+		d = c.readTimeout
+	}
+	c.mu.Unlock()
+	if d <= 0 {
+		return
+	}
+	if read {
+		c.Conn.SetReadDeadline(time.Now().Add(d))
+	} else {
+		c.Conn.SetWriteDeadline(time.Now().Add(d))
+	}
+}
+
+func timeout(t time.Time) time.Duration {
+	if t.IsZero() {
+		return 0
+	}
+	return time.Until(t)
+}
+
+// writerOnly 隐藏 ReadFrom，避免 io.CopyBuffer 递归
+type writerOnly struct {
+	io.Writer
+}
+
+func limited(ls []*limiter) bool {
+	for _, l := range ls {
+		if l.rl != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// account 等待各级限速器的配额并记录流量
+func account(ls []*limiter, n int) {
+	now := time.Now()
+	for _, l := range ls {
+		l.wait(n)
+		l.meter.add(n, now)
+	}
+}
+
+// Listener 为接受的连接加上限速
+type Listener struct {
+	net.Listener
+	t *Throttle
+}
+
+func (l *Listener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return newConn(c, l.t.connRoute(c.RemoteAddr())), nil
+}
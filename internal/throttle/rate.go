@@ -0,0 +1,103 @@
+package throttle
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/utils"
+
+	"golang.org/x/time/rate"
+)
+
+// chunkSize 单次读写的最大字节数，也是令牌桶的最小容量
+const chunkSize = 32 * 1024
+
+// meterWindow 计算实时吞吐量的窗口（秒）
+const meterWindow = 5
+
+// ParseRate 解析 "10MB"、"512KB/s"、"1048576" 形式的速率（字节/秒），空字符串或 0 表示不限制
+func ParseRate(s string) (int64, error) {
+	v := strings.TrimSpace(s)
+	if strings.HasSuffix(strings.ToUpper(v), "/S") {
+		v = v[:len(v)-2]
+	}
+	n, err := utils.ParseSize(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth rate %q", s)
+	}
+	return n, nil
+}
+
+// limiter 单方向的带宽限制，同时统计经过的流量
+type limiter struct {
+	limit int64 // 字节/秒，0 表示只统计不限制
+	rl    *rate.Limiter
+	meter meter
+}
+
+func newLimiter(limit int64) *limiter {
+	l := &limiter{limit: limit}
+	if limit > 0 {
+		burst := int(limit)
+		if burst < chunkSize {
+			burst = chunkSize
+		}
+		l.rl = rate.NewLimiter(rate.Limit(limit), burst)
+	}
+	return l
+}
+
+// wait 等待 n 字节的配额（n 不超过 chunkSize）
+func (l *limiter) wait(n int) {
+	if l.rl != nil {
+		time.Sleep(l.rl.ReserveN(time.Now(), n).Delay())
+	}
+}
+
+// meter 按秒分桶统计流量
+type meter struct {
+	mu    sync.Mutex
+	total int64
+	slots [meterWindow + 1]int64
+	last  int64 // 最近一次写入的秒
+}
+
+func (m *meter) add(n int, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advance(now.Unix())
+	m.slots[m.last%int64(len(m.slots))] += int64(n)
+	m.total += int64(n)
+}
+
+// advance 清空 last 之后到 sec 之间的桶
+func (m *meter) advance(sec int64) {
+	if sec <= m.last {
+		return
+	}
+	for s := m.last + 1; s <= sec && s <= m.last+int64(len(m.slots)); s++ {
+		m.slots[s%int64(len(m.slots))] = 0
+	}
+	m.last = sec
+}
+
+// rate 返回最近 meterWindow 个完整秒的平均速率（字节/秒）
+func (m *meter) rate(now time.Time) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sec := now.Unix()
+	m.advance(sec)
+	var sum int64
+	for s := sec - meterWindow; s < sec; s++ {
+		sum += m.slots[s%int64(len(m.slots))]
+	}
+	return sum / meterWindow
+}
+
+func (m *meter) bytes() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total
+}
@@ -0,0 +1,252 @@
+package throttle
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"plus/internal/config"
+	"plus/internal/types"
+
+	"github.com/valyala/fasthttp"
+)
+
+// clientIdle 客户端限速器闲置多久后回收
+const clientIdle = 10 * time.Minute
+
+// pair 一组上下行限速器
+type pair struct {
+	name     string
+	upload   *limiter
+	download *limiter
+	lastUsed time.Time
+}
+
+func newPair(name string, l config.BandwidthLimit, fallback config.BandwidthLimit) (*pair, error) {
+	if l.Download == "" {
+		l.Download = fallback.Download
+	}
+	if l.Upload == "" {
+		l.Upload = fallback.Upload
+	}
+	down, err := ParseRate(l.Download)
+	if err != nil {
+		return nil, err
+	}
+	up, err := ParseRate(l.Upload)
+	if err != nil {
+		return nil, err
+	}
+	return &pair{name: name, upload: newLimiter(up), download: newLimiter(down)}, nil
+}
+
+func (p *pair) usage(now time.Time) types.BandwidthUsage {
+	return types.BandwidthUsage{
+		Name:          p.name,
+		DownloadBps:   p.download.meter.rate(now),
+		UploadBps:     p.upload.meter.rate(now),
+		DownloadLimit: p.download.limit,
+		UploadLimit:   p.upload.limit,
+		BytesSent:     p.download.meter.bytes(),
+		BytesReceived: p.upload.meter.bytes(),
+	}
+}
+
+// Throttle 全局、仓库、令牌/客户端三级带宽限制
+type Throttle struct {
+	global    *pair
+	perClient config.BandwidthLimit
+	repos     map[string]*pair
+	tokens    map[string]*pair // token -> 限速器
+
+	streamUploads bool
+
+	mu      sync.Mutex
+	clients map[string]*pair // 客户端 IP -> 限速器
+}
+
+// New 根据配置创建带宽限制，未配置限制时只统计流量
+func New(cfg config.BandwidthConfig) (*Throttle, error) {
+	global, err := newPair("global", config.BandwidthLimit{Download: cfg.Download, Upload: cfg.Upload}, config.BandwidthLimit{})
+	if err != nil {
+		return nil, err
+	}
+	t := &Throttle{
+		global:    global,
+		perClient: cfg.PerClient,
+		repos:     make(map[string]*pair),
+		tokens:    make(map[string]*pair),
+		clients:   make(map[string]*pair),
+	}
+	// 提前校验默认客户端限制
+	client, err := newPair("", cfg.PerClient, config.BandwidthLimit{})
+	if err != nil {
+		return nil, err
+	}
+	t.streamUploads = global.upload.rl != nil || client.upload.rl != nil
+
+	for name, l := range cfg.Repos {
+		name = strings.Trim(name, "/")
+		if t.repos[name], err = newPair(name, l, config.BandwidthLimit{}); err != nil {
+			return nil, fmt.Errorf("repo %s: %w", name, err)
+		}
+		t.streamUploads = t.streamUploads || t.repos[name].upload.rl != nil
+	}
+	for i, tb := range cfg.Tokens {
+		if tb.Token == "" {
+			return nil, fmt.Errorf("bandwidth token #%d: token is required", i+1)
+		}
+		name := tb.Name
+		if name == "" {
+			name = fmt.Sprintf("token-%d", i+1)
+		}
+		if t.tokens[tb.Token], err = newPair(name, config.BandwidthLimit{Download: tb.Download, Upload: tb.Upload}, cfg.PerClient); err != nil {
+			return nil, fmt.Errorf("token %s: %w", name, err)
+		}
+		t.streamUploads = t.streamUploads || t.tokens[tb.Token].upload.rl != nil
+	}
+	return t, nil
+}
+
+// StreamUploads 是否配置了上行限制；此时请求体需在处理请求时按限速流式读取
+func (t *Throttle) StreamUploads() bool {
+	return t.streamUploads
+}
+
+// Listen 包装监听器，所有连接都经过限速
+func (t *Throttle) Listen(ln net.Listener) net.Listener {
+	return &Listener{Listener: ln, t: t}
+}
+
+// Handler 识别请求所属仓库和令牌，为本次请求的上传和响应设置限速器
+func (t *Throttle) Handler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		conn := ctx.Conn()
+		// TLS 在限速连接之外
+		if tc, ok := conn.(*tls.Conn); ok {
+			conn = tc.NetConn()
+		}
+		if c, ok := conn.(*Conn); ok {
+			c.setRoute(t.requestRoute(ctx))
+		}
+		next(ctx)
+	}
+}
+
+func (t *Throttle) requestRoute(ctx *fasthttp.RequestCtx) *route {
+	pairs := []*pair{t.global}
+	if p := t.matchRepo(string(ctx.Path())); p != nil {
+		pairs = append(pairs, p)
+	}
+	if p := t.tokens[requestToken(ctx)]; p != nil {
+		pairs = append(pairs, p)
+	} else {
+		pairs = append(pairs, t.client(ctx.RemoteIP().String()))
+	}
+	return newRoute(pairs)
+}
+
+// connRoute 连接建立时尚未解析请求，只能按全局和客户端 IP 限速
+func (t *Throttle) connRoute(addr net.Addr) *route {
+	host := addr.String()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return newRoute([]*pair{t.global, t.client(host)})
+}
+
+func newRoute(pairs []*pair) *route {
+	r := &route{}
+	for _, p := range pairs {
+		r.upload = append(r.upload, p.upload)
+		r.download = append(r.download, p.download)
+	}
+	return r
+}
+
+// matchRepo 按路径匹配配置了限制的仓库（/repo/{name}/... 或 /{name}/...），取最长的名称
+func (t *Throttle) matchRepo(path string) *pair {
+	if len(t.repos) == 0 {
+		return nil
+	}
+	path = strings.TrimPrefix(path, "/")
+	var best *pair
+	for _, prefix := range []string{"repo/", ""} {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(path, prefix)
+		for name, p := range t.repos {
+			if (rest == name || strings.HasPrefix(rest, name+"/")) && (best == nil || len(name) > len(best.name)) {
+				best = p
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// requestToken 读取 Bearer 令牌或 API Key
+func requestToken(ctx *fasthttp.RequestCtx) string {
+	if auth := string(ctx.Request.Header.Peek("Authorization")); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	if key := ctx.Request.Header.Peek("X-API-Key"); len(key) > 0 {
+		return string(key)
+	}
+	return string(ctx.QueryArgs().Peek("api_key"))
+}
+
+// client 返回客户端 IP 的限速器，并回收闲置的限速器
+func (t *Throttle) client(ip string) *pair {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	p, ok := t.clients[ip]
+	if !ok {
+		for k, c := range t.clients {
+			if now.Sub(c.lastUsed) > clientIdle {
+				delete(t.clients, k)
+			}
+		}
+		// 配置已在 New 中校验
+		p, _ = newPair(ip, t.perClient, config.BandwidthLimit{})
+		t.clients[ip] = p
+	}
+	p.lastUsed = now
+	return p
+}
+
+// Metrics 返回当前吞吐量
+func (t *Throttle) Metrics() *types.BandwidthMetrics {
+	now := time.Now()
+	g := t.global.usage(now)
+	m := &types.BandwidthMetrics{
+		DownloadBps:   g.DownloadBps,
+		UploadBps:     g.UploadBps,
+		DownloadLimit: g.DownloadLimit,
+		UploadLimit:   g.UploadLimit,
+		BytesSent:     g.BytesSent,
+		BytesReceived: g.BytesReceived,
+	}
+	for _, p := range t.repos {
+		m.Repos = append(m.Repos, p.usage(now))
+	}
+	for _, p := range t.tokens {
+		m.Tokens = append(m.Tokens, p.usage(now))
+	}
+	sort.Slice(m.Repos, func(i, j int) bool { return m.Repos[i].Name < m.Repos[j].Name })
+	sort.Slice(m.Tokens, func(i, j int) bool { return m.Tokens[i].Name < m.Tokens[j].Name })
+
+	t.mu.Lock()
+	m.Clients = len(t.clients)
+	t.mu.Unlock()
+	return m
+}
@@ -0,0 +1,138 @@
+package throttle
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"plus/internal/config"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestParseRate(t *testing.T) {
+	cases := map[string]int64{
+		"":         0,
+		"0":        0,
+		"1024":     1024,
+		"10MB":     10 << 20,
+		"512KB/s":  512 << 10,
+		"1.5g":     3 << 29,
+		" 100 kb ": 100 << 10,
+	}
+	for in, want := range cases {
+		got, err := ParseRate(in)
+		if err != nil || got != want {
+			t.Errorf("ParseRate(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"fast", "-1MB", "10TB"} {
+		if _, err := ParseRate(in); err == nil {
+			t.Errorf("ParseRate(%q) should fail", in)
+		}
+	}
+}
+
+func TestMeter(t *testing.T) {
+	var m meter
+	now := time.Unix(1000, 0)
+	for i := 0; i < meterWindow; i++ {
+		m.add(1000, now.Add(time.Duration(i)*time.Second))
+	}
+	// 当前秒尚未结束，不计入速率
+	m.add(5000, now.Add(meterWindow*time.Second))
+	if got := m.rate(now.Add(meterWindow * time.Second)); got != 1000 {
+		t.Errorf("rate = %d, want 1000", got)
+	}
+	if got := m.rate(now.Add(100 * time.Second)); got != 0 {
+		t.Errorf("rate after idle = %d, want 0", got)
+	}
+	if got := m.bytes(); got != 10000 {
+		t.Errorf("bytes = %d, want 10000", got)
+	}
+}
+
+func TestRequestRoute(t *testing.T) {
+	th, err := New(config.BandwidthConfig{
+		Download:  "100MB",
+		PerClient: config.BandwidthLimit{Download: "1MB"},
+		Repos: map[string]config.BandwidthLimit{
+			"centos":   {Download: "10MB"},
+			"centos/8": {Download: "20MB"},
+		},
+		Tokens: []config.TokenBandwidth{{Name: "mirror-bot", Token: "secret", Download: "2MB"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limits := func(path, token string) []int64 {
+		var ctx fasthttp.RequestCtx
+		ctx.Request.SetRequestURI(path)
+		if token != "" {
+			ctx.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		var got []int64
+		for _, l := range th.requestRoute(&ctx).download {
+			got = append(got, l.limit)
+		}
+		return got
+	}
+
+	cases := []struct {
+		path, token string
+		want        []int64
+	}{
+		{"/repo/centos/8/rpm/a.rpm", "", []int64{100 << 20, 20 << 20, 1 << 20}},
+		{"/centos/7/Packages/a.rpm", "secret", []int64{100 << 20, 10 << 20, 2 << 20}},
+		{"/ubuntu/pool/a.deb", "unknown", []int64{100 << 20, 1 << 20}},
+	}
+	for _, c := range cases {
+		got := limits(c.path, c.token)
+		if len(got) != len(c.want) {
+			t.Errorf("%s: limits = %v, want %v", c.path, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("%s: limits = %v, want %v", c.path, got, c.want)
+				break
+			}
+		}
+	}
+
+	if th.StreamUploads() {
+		t.Error("no upload limit configured, uploads should not be streamed")
+	}
+}
+
+func TestConnThrottle(t *testing.T) {
+	th, err := New(config.BandwidthConfig{Download: "128KB"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, client := net.Pipe()
+	defer client.Close()
+	c := newConn(server, th.connRoute(server.RemoteAddr()))
+
+	const size = 192 << 10
+	go func() {
+		c.Write(make([]byte, size))
+		c.Close()
+	}()
+
+	start := time.Now()
+	n, err := io.Copy(io.Discard, client)
+	if err != nil || n != size {
+		t.Fatalf("copy = %d, %v", n, err)
+	}
+	// 令牌桶容量 128KB，剩余 64KB 需要约 0.5s
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("transfer took %v, expected throttling", elapsed)
+	}
+	if got := th.Metrics().BytesSent; got != size {
+		t.Errorf("bytes sent = %d, want %d", got, size)
+	}
+}
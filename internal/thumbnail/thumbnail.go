@@ -0,0 +1,133 @@
+// Package thumbnail 为 files 仓库中的图片生成缩略图
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // 注册 GIF 解码器
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultSize 缩略图长边的默认像素数
+	DefaultSize = 256
+	// MinSize、MaxSize 请求的缩略图长边的范围
+	MinSize = 16
+	MaxSize = 1024
+	// MaxSourceSize 超过该大小的图片不生成缩略图
+	MaxSourceSize = 32 << 20
+	// maxPixels 原图的像素上限，避免解码体积很小但尺寸极大的图片耗尽内存
+	maxPixels = 50_000_000
+)
+
+var (
+	// ErrUnsupported 不是支持的图片格式
+	ErrUnsupported = errors.New("unsupported image format")
+	// ErrTooLarge 图片超过 MaxSourceSize 或像素上限
+	ErrTooLarge = errors.New("image too large for a thumbnail")
+)
+
+// Supported 判断 name 是否为可以生成缩略图的图片（PNG、JPEG、GIF）
+func Supported(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		return true
+	}
+	return false
+}
+
+// Generate 读取图片并按比例缩小到长边不超过 size 像素，不放大。
+// 不透明的图片输出 JPEG，有透明像素的输出 PNG
+func Generate(r io.Reader, size int) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxSourceSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxSourceSize {
+		return nil, ErrTooLarge
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrUnsupported
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxPixels {
+		return nil, ErrTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+
+	dst := scale(src, size)
+	var buf bytes.Buffer
+	if opaque(dst) {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// scale 用区域平均把 src 缩小到长边不超过 size
+func scale(src image.Image, size int) *image.NRGBA {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, max(1, h*size/b.Dx())
+		} else {
+			w, h = max(1, w*size/b.Dy()), size
+		}
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	if w == b.Dx() && h == b.Dy() {
+		draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+		return dst
+	}
+
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := max(y0+1, b.Min.Y+(y+1)*b.Dy()/h)
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := max(x0+1, b.Min.X+(x+1)*b.Dx()/w)
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// 预乘 alpha 的分量，透明像素不影响平均颜色
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+// opaque 判断图片是否没有透明像素
+func opaque(img *image.NRGBA) bool {
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 0xff {
+			return false
+		}
+	}
+	return true
+}
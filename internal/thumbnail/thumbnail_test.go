@@ -0,0 +1,76 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerate(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 800; x++ {
+			src.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	data, err := Generate(bytes.NewReader(encodePNG(t, src)), 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thumb, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("opaque image should give a JPEG thumbnail: %v", err)
+	}
+	if got := thumb.Bounds().Size(); got != image.Pt(200, 100) {
+		t.Errorf("thumbnail size = %v, want 200x100", got)
+	}
+	if r, g, b, _ := thumb.At(100, 50).RGBA(); r>>8 < 190 || g>>8 < 90 || g>>8 > 110 || b>>8 > 60 {
+		t.Errorf("thumbnail color = %d,%d,%d", r>>8, g>>8, b>>8)
+	}
+
+	// 透明图片输出 PNG，小图不放大
+	small := image.NewNRGBA(image.Rect(0, 0, 40, 60))
+	data, err = Generate(bytes.NewReader(encodePNG(t, small)), 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thumb, err = png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("transparent image should give a PNG thumbnail: %v", err)
+	}
+	if got := thumb.Bounds().Size(); got != image.Pt(40, 60) {
+		t.Errorf("small thumbnail size = %v, want 40x60", got)
+	}
+
+	if _, err := Generate(strings.NewReader("not an image"), 200); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Generate(text) = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestSupported(t *testing.T) {
+	for name, want := range map[string]bool{
+		"shots/login.PNG": true,
+		"a.jpeg":          true,
+		"anim.gif":        true,
+		"diagram.svg":     false,
+		"app.tar.gz":      false,
+	} {
+		if got := Supported(name); got != want {
+			t.Errorf("Supported(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 //go:generate easyjson -all types.go
 type RepoTable struct {
 	Name        string `json:"name"`
-	Type        string `json:"type"`        // 新增类型字段
+	Type        string `json:"type"` // 新增类型字段
 	Description string `json:"description"`
 	Path        string `json:"path"` // 新增路径字段，支持多层目录
 }
@@ -26,6 +26,7 @@ type BatchUploadResponse struct {
 	Total   int                 `json:"total"`
 	Success int                 `json:"success"`
 	Failed  int                 `json:"failed"`
+	Pending int                 `json:"pending,omitempty"` // 等待批准的覆盖
 	Results []BatchUploadResult `json:"results"`
 }
 
@@ -36,6 +37,8 @@ type BatchUploadResult struct {
 	Filename string `json:"filename"`
 	Status   string `json:"status"`
 	Error    string `json:"error,omitempty"`
+	Approval string `json:"approval,omitempty"`  // status 为 pending 时的批准请求 ID
+	StoredAs string `json:"stored_as,omitempty"` // 覆盖策略为 auto-version 时保存的文件名
 }
 
 //go:generate easyjson -all types.go
@@ -48,95 +51,1589 @@ type Status struct {
 
 func (r *Status) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
 
+// UploadResult 单文件上传的结果。server、status、message、code 与 Status 相同，
+// filename 为保存的文件名，覆盖策略为 auto-version 时与上传的文件名不同
+//
+//go:generate easyjson -all types.go
+type UploadResult struct {
+	Server    string `json:"server"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+	Code      int    `json:"code"`
+	Filename  string `json:"filename"`
+	Renamed   bool   `json:"renamed"`
+	ExpiresAt string `json:"expires_at,omitempty"` // 上传时指定了有效期的制品到期删除的时间（RFC3339）
+}
+
+func (r *UploadResult) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// ErrorResponse 错误响应。status、message、code 与 Status 相同，error 中是错误码、详情和请求 ID
+//
+//go:generate easyjson -all types.go
+type ErrorResponse struct {
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+	Code    int         `json:"code"`
+	Error   ErrorDetail `json:"error"`
+}
+
+//go:generate easyjson -all types.go
+type ErrorDetail struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Detail    string `json:"detail,omitempty"`
+	RequestID string `json:"request_id"`
+}
+
+// Problem RFC 9457 problem details，请求 Accept: application/problem+json 时返回
+//
+//go:generate easyjson -all types.go
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id"`
+}
+
 //go:generate easyjson -all types.go
 type RepoStatus struct {
 	Status Status `json:",inline"`
 	Repo   string `json:"repo"`
 }
 
-func (r *RepoStatus) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+func (r *RepoStatus) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type RepoMeta struct {
+	Status       Status                  `json:",inline"`
+	Repositories []string                `json:"repositories"`
+	Tree         map[string]*TreeNode    `json:"tree"`
+	Count        int                     `json:"count"`
+	Maintenance  map[string]*Maintenance `json:"maintenance,omitempty"` // 处于维护状态的仓库
+}
+
+func (r *RepoMeta) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type TreeNode struct {
+	Type        string               `json:"type"`           // "repo" 或 "directory"
+	Path        string               `json:"path,omitempty"` // 仅对 repo 类型有效
+	RepoType    string               `json:"repoType,omitempty"`
+	Maintenance bool                 `json:"maintenance,omitempty"`
+	Children    map[string]*TreeNode `json:"children,omitempty"` // 仅对 directory 类型有效
+}
+
+//go:generate easyjson -all types.go
+type PackageInfo struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Release  string `json:"release"`
+	Arch     string `json:"arch"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+	ModTime  string `json:"mtime,omitempty"`   // RFC3339
+	License  string `json:"license,omitempty"` // 元数据中的许可证，仅 RPM、DEB 仓库
+}
+
+// DirectoryListing 目录列表，请求 Accept: application/json 或 ?format=json 时返回
+//
+//go:generate easyjson -all types.go
+type DirectoryListing struct {
+	Path     string           `json:"path"`
+	Repo     string           `json:"repo,omitempty"`
+	RepoType string           `json:"repo_type,omitempty"`
+	Entries  []DirectoryEntry `json:"entries"`
+	Readme   *Readme          `json:"readme,omitempty"` // 目录中的 README.md 等说明文件
+}
+
+//go:generate easyjson -all types.go
+type Readme struct {
+	Name   string `json:"name"`
+	Source string `json:"source"` // 文件内容
+	HTML   string `json:"html"`   // 服务端渲染的 HTML，已去掉其中的原始 HTML 和不安全的链接
+}
+
+func (r *DirectoryListing) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type DirectoryEntry struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"` // "file"、"dir" 或 "repo"
+	Size         int64  `json:"size"`
+	ModTime      string `json:"mtime,omitempty"` // RFC3339
+	URL          string `json:"url"`
+	RepoType     string `json:"repo_type,omitempty"`
+	Checksum     string `json:"checksum,omitempty"`
+	ChecksumURL  string `json:"checksum_url,omitempty"`
+	Maintenance  bool   `json:"maintenance,omitempty"` // 仓库处于维护状态
+	Notes        string `json:"notes,omitempty"`       // 包说明的第一行
+	NotesURL     string `json:"notes_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"` // files 仓库中图片的缩略图
+	ExpiresAt    string `json:"expires_at,omitempty"`    // 到期删除的时间（RFC3339）
+	LegalHold    bool   `json:"legal_hold,omitempty"`    // 有保留标记，不会被删除
+}
+
+// FileList 仓库文件列表: GET /api/v1/repos/{repo}/files?glob=&recursive=
+//
+//go:generate easyjson -all types.go
+type FileList struct {
+	Status    Status           `json:",inline"`
+	Repo      string           `json:"repo"`
+	Glob      string           `json:"glob"`
+	Recursive bool             `json:"recursive"`
+	Count     int              `json:"count"`
+	Truncated bool             `json:"truncated"`
+	Files     []DirectoryEntry `json:"files"` // name 为相对仓库根目录的路径
+}
+
+func (r *FileList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type RepoInfo struct {
+	Status       Status          `json:",inline"`
+	Type         string          `json:"type"`
+	Name         string          `json:"name"`
+	PackageCount int             `json:"package_count"`
+	RPMCount     int             `json:"rpm_count"`
+	DEBCount     int             `json:"deb_count"`
+	TotalSize    int64           `json:"total_size"`
+	Packages     []PackageInfo   `json:"packages"`
+	Arches       []ArchStats     `json:"arches,omitempty"` // 按架构统计仓库中的全部包，不受 arch 参数影响
+	Upstream     *UpstreamStatus `json:"upstream,omitempty"`
+	Jobs         []JobStatus     `json:"jobs,omitempty"`        // 仓库的定时任务及最近一次执行
+	Description  string          `json:"description,omitempty"` // 配置中仓库的 description
+	Readme       *Readme         `json:"readme,omitempty"`      // 仓库说明，已替换其中的 {{base_url}} 等变量
+}
+
+func (r *RepoInfo) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// ArchStats 仓库中一种架构的包数量和总大小
+//
+//go:generate easyjson -all types.go
+type ArchStats struct {
+	Arch         string `json:"arch"`
+	PackageCount int    `json:"package_count"`
+	TotalSize    int64  `json:"total_size"`
+}
+
+//go:generate easyjson -all types.go
+type UpstreamStatus struct {
+	URL          string `json:"url"`
+	VerifyMode   string `json:"verify_mode"` // gpg, disabled
+	Verified     bool   `json:"verified"`
+	SignedBy     string `json:"signed_by,omitempty"`
+	LastSync     string `json:"last_sync,omitempty"`
+	LastVerified string `json:"last_verified,omitempty"`
+	LastError    string `json:"last_error,omitempty"`
+	Rejected     int64  `json:"rejected"`
+}
+
+//go:generate easyjson -all types.go
+type MirrorHealth struct {
+	URL                 string `json:"url"`
+	Weight              int    `json:"weight"`
+	Healthy             bool   `json:"healthy"`
+	Active              bool   `json:"active"`  // 最近一次成功提供内容的上游
+	Circuit             string `json:"circuit"` // closed、open、half-open
+	LatencyMs           int64  `json:"latency_ms"`
+	MetadataTime        string `json:"metadata_time,omitempty"`
+	MetadataAge         string `json:"metadata_age,omitempty"`
+	Stale               bool   `json:"stale"`
+	SpotChecks          int    `json:"spot_checks"`
+	SpotCheckFailures   int    `json:"spot_check_failures"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	Failures            int64  `json:"failures"`
+	Served              int64  `json:"served"` // 由该上游提供的请求数
+	BytesServed         int64  `json:"bytes_served"`
+	LastCheck           string `json:"last_check,omitempty"`
+	LastError           string `json:"last_error,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type UpstreamHealth struct {
+	Status   Status          `json:",inline"`
+	Repo     string          `json:"repo"`
+	Strategy string          `json:"strategy"`
+	Healthy  bool            `json:"healthy"` // 至少一个上游可用
+	Mirrors  []MirrorHealth  `json:"mirrors"`
+	Sync     *UpstreamStatus `json:"sync"`
+}
+
+func (r *UpstreamHealth) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type Metrics struct {
+	Requests       Requests              `json:"requests"`
+	Performance    Performance           `json:"performance"`
+	Memory         Memory                `json:"memory"`
+	Upstreams      []UpstreamMetrics     `json:"upstreams,omitempty"`
+	Bandwidth      *BandwidthMetrics     `json:"bandwidth,omitempty"`
+	Connections    *ConnectionMetrics    `json:"connections,omitempty"`
+	ReadCache      *ReadCacheMetrics     `json:"read_cache,omitempty"`
+	MetadataCache  *MetadataCacheMetrics `json:"metadata_cache,omitempty"`
+	Workers        *WorkerMetrics        `json:"workers,omitempty"`
+	StorageBreaker *BreakerMetrics       `json:"storage_breaker,omitempty"`
+	Compression    *CompressionMetrics   `json:"compression,omitempty"`
+	Replication    *ReplicationMetrics   `json:"replication,omitempty"`
+	Index          *IndexMetrics         `json:"index,omitempty"`
+	Lifetime       *LifetimeStats        `json:"lifetime,omitempty"`
+	Cluster        *ClusterMetrics       `json:"cluster,omitempty"`
+}
+
+func (r *Metrics) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type UpstreamMetrics struct {
+	Repo        string `json:"repo"`
+	URL         string `json:"url"`
+	Served      int64  `json:"served"`
+	BytesServed int64  `json:"bytes_served"`
+	Failures    int64  `json:"failures"`
+	Circuit     string `json:"circuit"`
+}
+
+//go:generate easyjson -all types.go
+type BandwidthMetrics struct {
+	DownloadBps   int64            `json:"download_bps"`
+	UploadBps     int64            `json:"upload_bps"`
+	DownloadLimit int64            `json:"download_limit"` // 0 表示不限制
+	UploadLimit   int64            `json:"upload_limit"`
+	BytesSent     int64            `json:"bytes_sent"`
+	BytesReceived int64            `json:"bytes_received"`
+	Clients       int              `json:"clients"` // 当前跟踪的客户端 IP 数
+	Repos         []BandwidthUsage `json:"repos,omitempty"`
+	Tokens        []BandwidthUsage `json:"tokens,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type ConnectionMetrics struct {
+	Open              int   `json:"open"`      // 打开的连接数
+	Clients           int   `json:"clients"`   // 有连接的客户端 IP 数
+	Downloads         int   `json:"downloads"` // 进行中的下载数
+	MaxConnsPerIP     int   `json:"max_conns_per_ip"`
+	MaxDownloadsPerIP int   `json:"max_downloads_per_ip"`
+	RejectedConns     int64 `json:"rejected_conns"`
+	RejectedDownloads int64 `json:"rejected_downloads"`
+}
+
+//go:generate easyjson -all types.go
+type BandwidthUsage struct {
+	Name          string `json:"name"`
+	DownloadBps   int64  `json:"download_bps"`
+	UploadBps     int64  `json:"upload_bps"`
+	DownloadLimit int64  `json:"download_limit"`
+	UploadLimit   int64  `json:"upload_limit"`
+	BytesSent     int64  `json:"bytes_sent"`
+	BytesReceived int64  `json:"bytes_received"`
+}
+
+//go:generate easyjson -all types.go
+type ReadCacheMetrics struct {
+	Path      string `json:"path"`
+	MaxSize   int64  `json:"max_size"`
+	Size      int64  `json:"size"`
+	Entries   int    `json:"entries"`
+	Hits      int64  `json:"hits"`
+	Misses    int64  `json:"misses"`
+	Evictions int64  `json:"evictions"`
+}
+
+//go:generate easyjson -all types.go
+type MetadataCacheMetrics struct {
+	MaxSize int64 `json:"max_size"`
+	Size    int64 `json:"size"`
+	Entries int   `json:"entries"`
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+	Shared  int64 `json:"shared"` // 并发未命中时复用其他请求加载结果的次数
+	Stale   int64 `json:"stale"`  // 存储熔断时返回过期内容的次数
+}
+
+//go:generate easyjson -all types.go
+type WorkerMetrics struct {
+	Workers   int   `json:"workers"`
+	QueueSize int   `json:"queue_size"`
+	Queued    int   `json:"queued"`
+	Running   int64 `json:"running"`
+	Completed int64 `json:"completed"`
+	Failed    int64 `json:"failed"`
+	Rejected  int64 `json:"rejected"` // 队列已满未能提交的任务
+	AvgWaitMs int64 `json:"avg_wait_ms"`
+}
+
+//go:generate easyjson -all types.go
+type BreakerMetrics struct {
+	State               string `json:"state"` // closed、open、half-open
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	Failures            int64  `json:"failures"`
+	Trips               int64  `json:"trips"`
+	Rejected            int64  `json:"rejected"` // 熔断期间直接拒绝的操作
+	LastError           string `json:"last_error,omitempty"`
+	RetryAfterSeconds   int64  `json:"retry_after_seconds,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type CompressionMetrics struct {
+	Compressed int64   `json:"compressed"` // 压缩存储的对象数
+	Skipped    int64   `json:"skipped"`    // 判断为不可压缩、原样存储的对象数
+	BytesIn    int64   `json:"bytes_in"`   // 压缩前大小
+	BytesOut   int64   `json:"bytes_out"`  // 压缩后大小
+	Ratio      float64 `json:"ratio"`      // bytes_out / bytes_in
+}
+
+//go:generate easyjson -all types.go
+type IndexMetrics struct {
+	Ready            bool    `json:"ready"` // 已完成首次全量扫描，列表和搜索从索引读取
+	Repos            int     `json:"repos"`
+	Artifacts        int     `json:"artifacts"`
+	Bytes            int64   `json:"bytes"`           // 制品的总大小
+	JournalRecords   int     `json:"journal_records"` // 上次压缩后追加的记录数
+	Reconciles       int64   `json:"reconciles"`
+	LastReconcile    string  `json:"last_reconcile,omitempty"`
+	ReconcileSeconds float64 `json:"reconcile_seconds"`
+	LastError        string  `json:"last_error,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type Artifact struct {
+	Repo     string            `json:"repo"`
+	Type     string            `json:"type"` // rpm、deb、files
+	Path     string            `json:"path"` // 与 ListPackages 返回的名称相同
+	Size     int64             `json:"size"`
+	Checksum string            `json:"checksum,omitempty"`
+	ModTime  string            `json:"mtime,omitempty"` // RFC3339
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type SearchResult struct {
+	Status    Status                  `json:",inline"`
+	Query     string                  `json:"query"`
+	Count     int                     `json:"count"`
+	Truncated bool                    `json:"truncated"`
+	Indexed   bool                    `json:"indexed"` // false 表示索引未就绪，结果来自遍历存储
+	Artifacts []Artifact              `json:"artifacts"`
+	Facets    map[string][]FacetValue `json:"facets,omitempty"` // 全部结果（不受 limit 限制）在各字段上的数量
+}
+
+func (r *SearchResult) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// PackageSearchResult 包全文搜索结果，按相关度排序
+//
+//go:generate easyjson -all types.go
+type PackageSearchResult struct {
+	Status    Status       `json:",inline"`
+	Query     string       `json:"query"`
+	Count     int          `json:"count"`
+	Truncated bool         `json:"truncated"`
+	Packages  []PackageHit `json:"packages"`
+
+	Facets map[string][]FacetValue `json:"facets,omitempty"`
+}
+
+func (r *PackageSearchResult) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type PackageHit struct {
+	Repo        string  `json:"repo"`
+	Type        string  `json:"type"`
+	Name        string  `json:"name"`
+	Version     string  `json:"version"`
+	Arch        string  `json:"arch"`
+	Path        string  `json:"path"`
+	Summary     string  `json:"summary,omitempty"`
+	Description string  `json:"description,omitempty"`
+	License     string  `json:"license,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+// FacetValue 搜索结果中某个字段取该值的数量
+//
+//go:generate easyjson -all types.go
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// IndexRecord 索引日志中的一条记录
+//
+//go:generate easyjson -all types.go
+type IndexRecord struct {
+	Op       string    `json:"op"` // repo、drop、put、delete、reconciled
+	Repo     string    `json:"repo,omitempty"`
+	Type     string    `json:"type,omitempty"`
+	Path     string    `json:"path,omitempty"`
+	Artifact *Artifact `json:"artifact,omitempty"`
+	Time     string    `json:"time,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type ReplicationMetrics struct {
+	WriteQuorum int              `json:"write_quorum"`
+	Fallbacks   int64            `json:"fallbacks"` // 主存储不可用或缺少文件、由其他副本提供的读取
+	Replicas    []ReplicaMetrics `json:"replicas"`
+}
+
+//go:generate easyjson -all types.go
+type ReplicaMetrics struct {
+	Name         string `json:"name"`
+	State        string `json:"state"` // closed、open、half-open
+	Failures     int64  `json:"failures"`
+	MissedWrites int64  `json:"missed_writes"` // 未写入该副本的操作，副本可能缺少这些文件
+	LastError    string `json:"last_error,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type BenchReport struct {
+	Scenario        string           `json:"scenario"`
+	URL             string           `json:"url"`
+	Concurrency     int              `json:"concurrency"`
+	StartedAt       string           `json:"started_at"`
+	WarmupSeconds   float64          `json:"warmup_seconds"`
+	DurationSeconds float64          `json:"duration_seconds"`
+	Requests        int64            `json:"requests"`
+	Errors          int64            `json:"errors"`
+	RPS             float64          `json:"rps"`
+	Operations      []BenchOperation `json:"operations"`
+}
+
+func (r *BenchReport) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type BenchOperation struct {
+	Name     string       `json:"name"`
+	Requests int64        `json:"requests"`
+	Errors   int64        `json:"errors"`
+	RPS      float64      `json:"rps"`
+	Bytes    int64        `json:"bytes"`
+	Latency  BenchLatency `json:"latency_ms"`
+}
+
+//go:generate easyjson -all types.go
+type BenchLatency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+//go:generate easyjson -all types.go
+type PurgeRequest struct {
+	Event         string   `json:"event"` // refresh、delete
+	Repo          string   `json:"repo"`
+	SurrogateKeys []string `json:"surrogate_keys"`
+}
+
+//go:generate easyjson -all types.go
+type Performance struct {
+	ResponseTimeMs int64          `json:"response_time_ms"`
+	Goroutines     int            `json:"goroutines"`
+	Routes         []RouteLatency `json:"routes,omitempty"`
+}
+
+// 按接口类别和状态码统计的响应时间，分位数由直方图估算
+//
+//go:generate easyjson -all types.go
+type RouteLatency struct {
+	Class string  `json:"class"`
+	Code  int     `json:"code"`
+	Count uint64  `json:"count"`
+	AvgMs float64 `json:"avg_ms"`
+	P50Ms float64 `json:"p50_ms"`
+	P95Ms float64 `json:"p95_ms"`
+	P99Ms float64 `json:"p99_ms"`
+}
+
+//go:generate easyjson -all types.go
+type Requests struct {
+	Total     int64 `json:"total"`
+	Uploads   int64 `json:"uploads"`
+	Downloads int64 `json:"downloads"`
+	Redirects int64 `json:"redirects"` // 重定向到对象存储的下载
+	Errors    int64 `json:"errors"`
+	Active    int64 `json:"active"`
+}
+
+// JobList 定时任务列表: GET /api/v1/jobs
+//
+//go:generate easyjson -all types.go
+type JobList struct {
+	Node   string      `json:"node,omitempty"`
+	Leader bool        `json:"leader"` // 本实例是否执行集群任务
+	Jobs   []JobStatus `json:"jobs"`
+	Recent []JobRun    `json:"recent"` // 最近完成的执行，新的在前
+}
+
+func (r *JobList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type JobStatus struct {
+	Name           string `json:"name"`
+	Scope          string `json:"scope"` // cluster 或 node
+	Interval       string `json:"interval,omitempty"`
+	Schedule       string `json:"schedule,omitempty"` // cron 表达式，与 interval 二选一
+	State          string `json:"state"`              // scheduled、running、standby、stopped
+	NextRun        string `json:"next_run,omitempty"`
+	LastStart      string `json:"last_start,omitempty"`
+	LastEnd        string `json:"last_end,omitempty"`
+	LastDurationMs int64  `json:"last_duration_ms,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	Runs           int64  `json:"runs"`
+	Failures       int64  `json:"failures"`
+}
+
+//go:generate easyjson -all types.go
+type JobRun struct {
+	Job        string `json:"job"`
+	Started    string `json:"started"`
+	Finished   string `json:"finished"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Permission 对匹配 Repo 的仓库授予的操作
+//
+//go:generate easyjson -all types.go
+type Permission struct {
+	Repo    string   `json:"repo"`    // 仓库路径模式，* 匹配一级路径，同时作用于下级路径
+	Actions []string `json:"actions"` // read、write、delete
+}
+
+// Role 角色：一组仓库权限
+//
+//go:generate easyjson -all types.go
+type Role struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Admin       bool         `json:"admin,omitempty"` // 管理用户和权限、排空、任务
+	Permissions []Permission `json:"permissions"`
+	Builtin     bool         `json:"builtin,omitempty"`
+}
+
+func (r *Role) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// Group 用户组，组内用户拥有组的角色
+//
+//go:generate easyjson -all types.go
+type Group struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Roles       []string `json:"roles"`
+	Source      string   `json:"source,omitempty"`  // local、csv、ldap
+	Members     []string `json:"members,omitempty"` // 只在响应中返回
+}
+
+func (r *Group) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type User struct {
+	Name     string      `json:"name"`
+	Email    string      `json:"email,omitempty"`
+	Groups   []string    `json:"groups"`
+	Roles    []string    `json:"roles"`
+	Disabled bool        `json:"disabled,omitempty"`
+	Source   string      `json:"source,omitempty"` // local、csv、ldap
+	Created  string      `json:"created,omitempty"`
+	Updated  string      `json:"updated,omitempty"`
+	Tokens   []UserToken `json:"tokens,omitempty"` // 只在响应中返回
+}
+
+func (r *User) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// UserToken 用户的访问令牌，Token 只在创建时返回一次
+//
+//go:generate easyjson -all types.go
+type UserToken struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Token   string `json:"token,omitempty"`
+	Created string `json:"created"`
+}
+
+func (r *UserToken) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type UserList struct {
+	Users []User `json:"users"`
+}
+
+func (r *UserList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type GroupList struct {
+	Groups []Group `json:"groups"`
+}
+
+func (r *GroupList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type RoleList struct {
+	Roles []Role `json:"roles"`
+}
+
+func (r *RoleList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// ImportResult 批量导入或 LDAP 同步的结果
+//
+//go:generate easyjson -all types.go
+type ImportResult struct {
+	Created  int      `json:"created"`
+	Updated  int      `json:"updated"`
+	Disabled int      `json:"disabled"`
+	Groups   int      `json:"groups_created"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+func (r *ImportResult) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// Identity 当前请求的身份和有效权限: GET /api/v1/whoami
+//
+//go:generate easyjson -all types.go
+type Identity struct {
+	Authenticated bool         `json:"authenticated"`
+	Name          string       `json:"name,omitempty"`
+	Admin         bool         `json:"admin"`
+	Permissions   []Permission `json:"permissions"`
+}
+
+func (r *Identity) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// AccessData 用户库在 database-path 下保存的内容
+//
+//go:generate easyjson -all types.go
+type AccessData struct {
+	Users      []User           `json:"users"`
+	Groups     []Group          `json:"groups"`
+	Roles      []Role           `json:"roles"`
+	Tokens     []TokenRecord    `json:"tokens"`
+	Uploads    []UploadToken    `json:"uploads,omitempty"`
+	Sessions   []Session        `json:"sessions,omitempty"`
+	Revoked    []RevokedSession `json:"revoked,omitempty"`
+	SessionKey string           `json:"session_key,omitempty"` // 未配置 session-secret 时用于签名会话
+}
+
+// TokenRecord 保存的令牌，只保存 SHA256
+//
+//go:generate easyjson -all types.go
+type TokenRecord struct {
+	ID      string `json:"id"`
+	User    string `json:"user"`
+	Name    string `json:"name,omitempty"`
+	Hash    string `json:"hash"`
+	Created string `json:"created"`
+}
+
+// UploadToken 只能向一个仓库上传、有效期有限的令牌，供 CI 使用
+//
+//go:generate easyjson -all types.go
+type UploadToken struct {
+	ID        string `json:"id"`
+	Repo      string `json:"repo"`
+	Name      string `json:"name,omitempty"`
+	Token     string `json:"token,omitempty"` // 只在创建时返回
+	Hash      string `json:"hash,omitempty"`  // 只在用户库中保存
+	CreatedBy string `json:"created_by"`
+	Created   string `json:"created"`
+	Expires   string `json:"expires"`
+}
+
+func (r *UploadToken) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// UploadTokenRequest 签发上传令牌: POST /api/v1/repos/{repo}/upload-tokens
+//
+//go:generate easyjson -all types.go
+type UploadTokenRequest struct {
+	Name string `json:"name"`
+	TTL  string `json:"ttl"` // 默认 1h，不能超过 access.upload-token-max-ttl
+}
+
+// UploadTokenList 仓库的上传令牌: GET /api/v1/repos/{repo}/upload-tokens
+//
+//go:generate easyjson -all types.go
+type UploadTokenList struct {
+	Tokens []UploadToken `json:"tokens"`
+}
+
+func (r *UploadTokenList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// Session 用户令牌换取的短期会话
+//
+//go:generate easyjson -all types.go
+type Session struct {
+	ID        string `json:"id"`
+	User      string `json:"user"`
+	Token     string `json:"token,omitempty"`    // 只在创建时返回
+	TokenID   string `json:"token_id,omitempty"` // 换取会话的用户令牌，撤销令牌时一并撤销会话
+	Address   string `json:"address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	Created   string `json:"created"`
+	Expires   string `json:"expires"`
+}
+
+func (r *Session) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// SessionList 会话列表: GET /api/v1/sessions
+//
+//go:generate easyjson -all types.go
+type SessionList struct {
+	Sessions []Session `json:"sessions"`
+}
+
+func (r *SessionList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// RevokedSession 已撤销的会话，保留到会话过期
+//
+//go:generate easyjson -all types.go
+type RevokedSession struct {
+	ID      string `json:"id"`
+	Expires string `json:"expires"`
+}
+
+// SessionClaims 会话令牌（HS256 JWT）的内容
+//
+//go:generate easyjson -all types.go
+type SessionClaims struct {
+	Subject  string `json:"sub"`
+	ID       string `json:"jti"`
+	IssuedAt int64  `json:"iat"`
+	Expires  int64  `json:"exp"`
+}
+
+// AuthzRequest 授权 webhook 的请求体，与 OPA 的 Data API 兼容
+//
+//go:generate easyjson -all types.go
+type AuthzRequest struct {
+	Input AuthzInput `json:"input"`
+}
+
+// AuthzInput 写操作的上下文
+//
+//go:generate easyjson -all types.go
+type AuthzInput struct {
+	Operation string        `json:"operation"` // create、clone、upload、tree_upload、refresh、publish、delete
+	User      string        `json:"user"`      // 匿名时为空
+	Roles     []string      `json:"roles"`
+	Repo      string        `json:"repo"`
+	RepoType  string        `json:"repo_type,omitempty"`
+	Source    string        `json:"source,omitempty"` // clone 的源仓库
+	Path      string        `json:"path,omitempty"`   // 上传的文件在仓库中的路径
+	Files     []string      `json:"files,omitempty"`  // publish 发布的文件
+	Size      int64         `json:"size,omitempty"`
+	Method    string        `json:"method"`
+	ClientIP  string        `json:"client_ip"`
+	Package   *AuthzPackage `json:"package,omitempty"`
+}
+
+// AuthzPackage 上传的软件包
+//
+//go:generate easyjson -all types.go
+type AuthzPackage struct {
+	Format  string `json:"format"` // rpm、deb
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Release string `json:"release,omitempty"`
+	Arch    string `json:"arch"`
+	Signed  bool   `json:"signed"`            // 签名通过 trusted-keys 中的公钥校验
+	KeyID   string `json:"key_id,omitempty"`  // 校验通过的签名密钥 ID，16 位十六进制
+	License string `json:"license,omitempty"` // RPM 包头的 LICENSE 或 DEB control 的 License 字段
+}
+
+// AuthzResponse OPA 返回 {"result": true} 或 {"result": {"allow": true, "reason": "..."}}，
+// 其他服务也可以直接返回 {"allow": true, "reason": "..."}
+//
+//go:generate easyjson -all types.go
+type AuthzResponse struct {
+	Result json.RawMessage `json:"result"`
+	Allow  bool            `json:"allow"`
+	Reason string          `json:"reason"`
+}
+
+//go:generate easyjson -all types.go
+type AuthzResult struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason"`
+}
+
+// ClusterMetrics 本实例的分布式锁统计
+//
+//go:generate easyjson -all types.go
+type ClusterMetrics struct {
+	Node          string `json:"node"`
+	Leader        bool   `json:"leader"` // 本实例是否持有领导权（执行集群定时任务）
+	LocksHeld     int64  `json:"locks_held"`
+	LocksAcquired int64  `json:"locks_acquired"`
+	LockWaits     int64  `json:"lock_waits"` // 获取时锁被其他实例持有的次数
+	Coalesced     int64  `json:"coalesced"`  // 其他实例已完成而跳过的元数据刷新
+	LeasesLost    int64  `json:"leases_lost"`
+	LastError     string `json:"last_error,omitempty"`
+}
+
+// LifetimeStats 跨重启累计的计数器，定期保存到 database-path
+//
+//go:generate easyjson -all types.go
+type LifetimeStats struct {
+	Since         string `json:"since"`              // 首次开始统计的时间，RFC3339
+	SavedAt       string `json:"saved_at,omitempty"` // 最近一次保存的时间
+	Requests      int64  `json:"requests"`
+	Uploads       int64  `json:"uploads"`
+	Downloads     int64  `json:"downloads"`
+	Redirects     int64  `json:"redirects"`
+	Errors        int64  `json:"errors"`
+	BytesSent     int64  `json:"bytes_sent"`
+	BytesReceived int64  `json:"bytes_received"`
+}
+
+// Alert 一条告警
+//
+//go:generate easyjson -all types.go
+type Alert struct {
+	Name      string  `json:"name"`  // error-rate、storage 或 job/<任务名>
+	State     string  `json:"state"` // firing、resolved
+	Message   string  `json:"message"`
+	Value     float64 `json:"value,omitempty"`
+	Threshold float64 `json:"threshold,omitempty"`
+	Node      string  `json:"node,omitempty"`
+	Since     string  `json:"since"`              // 开始触发的时间
+	Resolved  string  `json:"resolved,omitempty"` // 恢复的时间
+}
+
+// AlertList 当前触发的告警
+//
+//go:generate easyjson -all types.go
+type AlertList struct {
+	Status Status  `json:",inline"`
+	Alerts []Alert `json:"alerts"`
+}
+
+func (r *AlertList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// EventRecord 事件日志中的一条记录，seq 在本实例内递增且不重复使用
+//
+//go:generate easyjson -all types.go
+type EventRecord struct {
+	Seq       int64    `json:"seq,omitempty"` // 没有事件日志时为 0
+	Time      string   `json:"time"`
+	Event     string   `json:"event"`
+	Repo      string   `json:"repo,omitempty"`
+	User      string   `json:"user,omitempty"`
+	Operation string   `json:"operation,omitempty"`
+	Approval  string   `json:"approval,omitempty"`
+	Files     []string `json:"files,omitempty"`
+	Count     int      `json:"count,omitempty"`
+	Message   string   `json:"message,omitempty"`
+	Node      string   `json:"node,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type EventList struct {
+	Status    Status        `json:",inline"`
+	Events    []EventRecord `json:"events"`
+	Next      int64         `json:"next"`                // 下次请求的 since
+	Latest    int64         `json:"latest"`              // 最新事件的 seq
+	Truncated bool          `json:"truncated,omitempty"` // since 之后的部分事件已超过保留期被删除
+}
+
+func (r *EventList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// ChatWebhook 聊天工具（Slack、Teams、Mattermost）的传入 webhook 及其订阅的事件
+//
+//go:generate easyjson -all types.go
+type ChatWebhook struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"` // slack、teams、mattermost
+	URL       string   `json:"url"`  // 接口返回时隐藏路径
+	Events    []string `json:"events"`
+	Repos     []string `json:"repos,omitempty"` // 仓库模式，为空时匹配全部仓库
+	CreatedBy string   `json:"created_by,omitempty"`
+	CreatedAt string   `json:"created_at"`
+	LastSent  string   `json:"last_sent,omitempty"`
+	LastError string   `json:"last_error,omitempty"`
+}
+
+// ChatWebhookData 聊天 webhook 在 DatabasePath 下的持久化格式
+//
+//go:generate easyjson -all types.go
+type ChatWebhookData struct {
+	Webhooks []ChatWebhook `json:"webhooks"`
+}
+
+//go:generate easyjson -all types.go
+type ChatWebhookList struct {
+	Status   Status        `json:",inline"`
+	Count    int           `json:"count"`
+	Webhooks []ChatWebhook `json:"webhooks"`
+}
+
+func (r *ChatWebhookList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type ChatWebhookResponse struct {
+	Status  Status      `json:",inline"`
+	Webhook ChatWebhook `json:"webhook"`
+}
+
+func (r *ChatWebhookResponse) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// ChatMessage Slack 和 Mattermost 传入 webhook 的消息
+//
+//go:generate easyjson -all types.go
+type ChatMessage struct {
+	Text string `json:"text"`
+}
+
+// TeamsMessage Microsoft Teams 传入 webhook 的消息卡片
+//
+//go:generate easyjson -all types.go
+type TeamsMessage struct {
+	Type    string `json:"@type"` // MessageCard
+	Context string `json:"@context"`
+	Summary string `json:"summary"`
+	Title   string `json:"title,omitempty"`
+	Text    string `json:"text"`
+}
+
+// Delivery 投递队列中的一项：一个事件发给一种通知方式的一个目标
+//
+//go:generate easyjson -all types.go
+type Delivery struct {
+	ID          string      `json:"id"`
+	Channel     string      `json:"channel"` // email、chat、kafka 或 nats
+	Target      string      `json:"target"`  // 收件人、webhook ID 或主题
+	State       string      `json:"state"`   // pending 或 dead
+	Attempts    int         `json:"attempts"`
+	NextAttempt string      `json:"next_attempt,omitempty"`
+	LastError   string      `json:"last_error,omitempty"`
+	CreatedAt   string      `json:"created_at"`
+	Event       EventRecord `json:"event"`
+}
+
+// DeliveryList 投递列表
+//
+//go:generate easyjson -all types.go
+type DeliveryList struct {
+	Status     Status     `json:",inline"`
+	Count      int        `json:"count"`
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+func (r *DeliveryList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// DeliveryResponse 单个投递
+//
+//go:generate easyjson -all types.go
+type DeliveryResponse struct {
+	Status   Status   `json:",inline"`
+	Delivery Delivery `json:"delivery"`
+}
+
+func (r *DeliveryResponse) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// NATSInfo NATS 服务器在连接后发送的 INFO
+//
+//go:generate easyjson -all types.go
+type NATSInfo struct {
+	TLSRequired bool  `json:"tls_required,omitempty"`
+	MaxPayload  int64 `json:"max_payload,omitempty"`
+}
+
+// NATSConnect 客户端发送的 CONNECT
+//
+//go:generate easyjson -all types.go
+type NATSConnect struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	TLSRequired bool   `json:"tls_required"`
+	Name        string `json:"name"`
+	Lang        string `json:"lang"`
+	Version     string `json:"version"`
+	User        string `json:"user,omitempty"`
+	Pass        string `json:"pass,omitempty"`
+	AuthToken   string `json:"auth_token,omitempty"`
+}
+
+// UsageRecord 一个身份在一段时间内的用量
+//
+//go:generate easyjson -all types.go
+type UsageRecord struct {
+	Date            string `json:"date,omitempty"`  // 按天统计时的日期，YYYY-MM-DD（UTC）
+	User            string `json:"user,omitempty"`  // 用户名，未认证的请求为 anonymous
+	Token           string `json:"token,omitempty"` // 令牌 ID，按令牌统计时才有
+	Requests        int64  `json:"requests"`
+	BytesUploaded   int64  `json:"bytes_uploaded"`
+	BytesDownloaded int64  `json:"bytes_downloaded"`
+}
+
+// UsageFile 保存在 database-path 下的每日用量
+//
+//go:generate easyjson -all types.go
+type UsageFile struct {
+	SavedAt string        `json:"saved_at"`
+	Days    []UsageRecord `json:"days"`
+}
+
+// UsageReport 用量报表
+//
+//go:generate easyjson -all types.go
+type UsageReport struct {
+	Status  Status        `json:",inline"`
+	From    string        `json:"from"`
+	To      string        `json:"to"`
+	Group   string        `json:"group"`
+	Records []UsageRecord `json:"records"`
+	Total   UsageRecord   `json:"total"`
+}
+
+func (r *UsageReport) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type Memory struct {
+	AllocMB      uint64 `json:"alloc_mb"`
+	TotalAllocMB uint64 `json:"total_alloc_mb"`
+	SysMB        uint64 `json:"sys_mb"`
+	GCCycles     uint32 `json:"gc_cycles"`
+}
+
+//go:generate easyjson -all types.go
+type ReadyCheck struct {
+	Status Status `json:"status"`
+	Checks Checks `json:"checks"`
+}
+
+func (r *ReadyCheck) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// DrainStatus 排空状态，排空期间 /ready 返回 503，负载均衡器不再转发新请求
+//
+//go:generate easyjson -all types.go
+type DrainStatus struct {
+	Draining       bool   `json:"draining"`
+	Since          string `json:"since,omitempty"` // 开始排空的时间，RFC3339
+	ActiveRequests int64  `json:"active_requests"`
+}
+
+func (r *DrainStatus) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// Maintenance 仓库维护状态，维护期间仓库的端点返回 503
+//
+//go:generate easyjson -all types.go
+type Maintenance struct {
+	Message string `json:"message,omitempty"` // 返回给客户端的说明
+	Since   string `json:"since"`             // 进入维护的时间，RFC3339
+	By      string `json:"by,omitempty"`
+}
+
+// MaintenanceRequest 进入维护状态的请求
+//
+//go:generate easyjson -all types.go
+type MaintenanceRequest struct {
+	Message string `json:"message"`
+}
+
+//go:generate easyjson -all types.go
+type MaintenanceStatus struct {
+	Status      Status       `json:",inline"`
+	Repo        string       `json:"repo"`
+	Enabled     bool         `json:"enabled"`
+	Maintenance *Maintenance `json:"maintenance,omitempty"`
+}
+
+func (r *MaintenanceStatus) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// StagingList 仓库暂存区: GET /repo/{repo}/staging
+//
+//go:generate easyjson -all types.go
+type StagingList struct {
+	Status    Status           `json:",inline"`
+	Repo      string           `json:"repo"`
+	Changeset string           `json:"changeset"` // 暂存内容的标识，发布时传入以确认发布的是审核过的内容
+	Count     int              `json:"count"`
+	Files     []DirectoryEntry `json:"files"` // name 为相对仓库根目录的路径
+}
+
+func (r *StagingList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type PublishRequest struct {
+	Changeset string `json:"changeset"`
+}
+
+//go:generate easyjson -all types.go
+type PublishResult struct {
+	Status    Status   `json:",inline"`
+	Repo      string   `json:"repo"`
+	Changeset string   `json:"changeset"`
+	Count     int      `json:"count"`
+	Files     []string `json:"files"`
+}
+
+func (r *PublishResult) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// ApprovalRequest 受保护仓库中等待第二位管理员批准的删除或覆盖
+//
+//go:generate easyjson -all types.go
+type ApprovalRequest struct {
+	ID          string   `json:"id"`
+	Operation   string   `json:"operation"` // delete_repo、overwrite、publish
+	Repo        string   `json:"repo"`
+	Files       []string `json:"files,omitempty"`     // 会被覆盖的文件
+	Changeset   string   `json:"changeset,omitempty"` // publish 的变更集
+	State       string   `json:"state"`               // pending、approved（执行中）、rejected、executed、failed
+	RequestedBy string   `json:"requested_by"`
+	RequestedAt string   `json:"requested_at"`
+	DecidedBy   string   `json:"decided_by,omitempty"`
+	DecidedAt   string   `json:"decided_at,omitempty"`
+	Reason      string   `json:"reason,omitempty"` // 拒绝原因
+	Error       string   `json:"error,omitempty"`  // 执行失败的原因
+}
+
+// ApprovalData 批准请求在 DatabasePath 下的持久化格式
+//
+//go:generate easyjson -all types.go
+type ApprovalData struct {
+	Requests []ApprovalRequest `json:"requests"`
+}
+
+//go:generate easyjson -all types.go
+type ApprovalList struct {
+	Status    Status            `json:",inline"`
+	Count     int               `json:"count"`
+	Approvals []ApprovalRequest `json:"approvals"`
+}
+
+func (r *ApprovalList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type ApprovalResponse struct {
+	Status   Status          `json:",inline"`
+	Approval ApprovalRequest `json:"approval"`
+}
+
+func (r *ApprovalResponse) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// ApprovalDecision POST /api/v1/approvals/{id}/reject 的可选请求体
+//
+//go:generate easyjson -all types.go
+type ApprovalDecision struct {
+	Reason string `json:"reason"`
+}
+
+// PackageNote 附加到软件包的说明或发布说明
+//
+//go:generate easyjson -all types.go
+type PackageNote struct {
+	Text    string `json:"text"`
+	Source  string `json:"source,omitempty"` // 上传的说明文件名，如 CHANGELOG.md
+	Author  string `json:"author,omitempty"`
+	Updated string `json:"updated"` // RFC3339
+}
+
+// RepoNotes 仓库的包说明，保存在仓库目录的 .notes.json，按文件名索引
+//
+//go:generate easyjson -all types.go
+type RepoNotes struct {
+	Notes map[string]PackageNote `json:"notes"`
+}
+
+// LegalHold 制品的保留标记，标记的制品不会被保留策略、过期或删除仓库删除，同名上传不会覆盖
+type LegalHold struct {
+	Reason string `json:"reason,omitempty"`
+	By     string `json:"by,omitempty"`
+	Since  string `json:"since"` // RFC3339
+}
+
+// RepoLegalHolds 仓库中制品的保留标记，保存在仓库目录的 .legal-holds.json，按文件名索引
+//
+//go:generate easyjson -all types.go
+type RepoLegalHolds struct {
+	Holds map[string]LegalHold `json:"holds"`
+}
+
+// LegalHoldRequest 设置保留标记: PUT /api/v1/repos/{repo}/holds/{file}
+//
+//go:generate easyjson -all types.go
+type LegalHoldRequest struct {
+	Reason string `json:"reason"`
+}
+
+//go:generate easyjson -all types.go
+type LegalHoldResponse struct {
+	Status Status    `json:",inline"`
+	Repo   string    `json:"repo"`
+	File   string    `json:"file"`
+	Hold   LegalHold `json:"hold"`
+}
+
+func (r *LegalHoldResponse) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// LegalHoldList 仓库中的保留标记: GET /api/v1/repos/{repo}/holds
+//
+//go:generate easyjson -all types.go
+type LegalHoldList struct {
+	Status Status               `json:",inline"`
+	Repo   string               `json:"repo"`
+	Holds  map[string]LegalHold `json:"holds"`
+}
+
+func (r *LegalHoldList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// DSSEEnvelope DSSE 封装的签名数据，payload 和 sig 为 base64 编码
+//
+//go:generate easyjson -all types.go
+type DSSEEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []DSSESignature `json:"signatures"`
+}
+
+//go:generate easyjson -all types.go
+type DSSESignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// InTotoStatement in-toto 声明，只读取证明的对象和类型，不解析 predicate
+//
+//go:generate easyjson -all types.go
+type InTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []InTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+}
+
+//go:generate easyjson -all types.go
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
 
+// Attestation 制品的来源证明（DSSE 封装的 in-toto 声明）
+//
 //go:generate easyjson -all types.go
-type RepoMeta struct {
-	Status       Status               `json:",inline"`
-	Repositories []string             `json:"repositories"`
-	Tree         map[string]*TreeNode `json:"tree"`
-	Count        int                  `json:"count"`
+type Attestation struct {
+	ID            string          `json:"id"`             // 封装内容的 SHA256
+	PredicateType string          `json:"predicate_type"` // 如 https://slsa.dev/provenance/v1
+	Subjects      []string        `json:"subjects"`
+	Digest        string          `json:"digest"`        // 上传时制品的 SHA256，覆盖上传后与当前文件不同
+	Verified      bool            `json:"verified"`      // 上传时有配置的密钥的有效签名
+	Key           string          `json:"key,omitempty"` // 验证签名的密钥名称
+	By            string          `json:"by,omitempty"`
+	Created       string          `json:"created"`            // RFC3339
+	URL           string          `json:"url,omitempty"`      // 下载原始封装的地址，只在响应中
+	Envelope      json.RawMessage `json:"envelope,omitempty"` // 原始封装，只保存在仓库中
 }
 
-func (r *RepoMeta) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+// RepoAttestations 仓库中制品的来源证明和 cosign 签名，保存在仓库目录的 .attestations.json，按文件名索引
+//
+//go:generate easyjson -all types.go
+type RepoAttestations struct {
+	Attestations map[string][]Attestation `json:"attestations"`
+	Signatures   map[string][]Signature   `json:"signatures,omitempty"`
+}
 
+// Signature 制品的 cosign 签名（cosign sign-blob），签名的内容为制品本身
+//
 //go:generate easyjson -all types.go
-type TreeNode struct {
-	Type     string               `json:"type"`               // "repo" 或 "directory"
-	Path     string               `json:"path,omitempty"`     // 仅对 repo 类型有效
-	RepoType string               `json:"repoType,omitempty"`
-	Children map[string]*TreeNode `json:"children,omitempty"` // 仅对 directory 类型有效
+type Signature struct {
+	ID          string `json:"id"`                    // 签名的 SHA256
+	Signature   string `json:"signature"`             // base64
+	Certificate string `json:"certificate,omitempty"` // 无密钥签名的证书（PEM），不验证
+	Digest      string `json:"digest"`                // 上传时制品的 SHA256
+	Verified    bool   `json:"verified"`              // 上传时有配置的密钥验证了签名
+	Key         string `json:"key,omitempty"`
+	By          string `json:"by,omitempty"`
+	Created     string `json:"created"`       // RFC3339
+	URL         string `json:"url,omitempty"` // 下载 base64 签名的地址，只在响应中
 }
 
+// CosignBundle cosign sign-blob --bundle 生成的文件，只读取签名和证书
+//
 //go:generate easyjson -all types.go
-type PackageInfo struct {
-	Name     string `json:"name"`
-	Version  string `json:"version"`
-	Release  string `json:"release"`
-	Arch     string `json:"arch"`
-	Size     int64  `json:"size"`
-	Checksum string `json:"checksum"`
+type CosignBundle struct {
+	Base64Signature string `json:"base64Signature"`
+	Cert            string `json:"cert"` // base64 编码的 PEM 证书
 }
 
+// SignatureResponse 上传的签名: POST /repo/{repo}/package/{file}/signatures
+//
 //go:generate easyjson -all types.go
-type RepoInfo struct {
+type SignatureResponse struct {
+	Status    Status    `json:",inline"`
+	Repo      string    `json:"repo"`
+	File      string    `json:"file"`
+	Signature Signature `json:"signature"`
+}
+
+func (r *SignatureResponse) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// SignatureList 制品的签名: GET /repo/{repo}/package/{file}/signatures
+//
+//go:generate easyjson -all types.go
+type SignatureList struct {
+	Status     Status      `json:",inline"`
+	Repo       string      `json:"repo"`
+	File       string      `json:"file"`
+	Signatures []Signature `json:"signatures"`
+}
+
+func (r *SignatureList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// VerifiedItem 一个签名或来源证明按当前的公钥和制品内容验证的结果
+//
+//go:generate easyjson -all types.go
+type VerifiedItem struct {
+	ID       string `json:"id"`
+	Verified bool   `json:"verified"`
+	Key      string `json:"key,omitempty"`
+	Reason   string `json:"reason,omitempty"` // 未验证的原因
+}
+
+// Verification 制品的验证结果: GET /repo/{repo}/package/{file}/verify
+//
+//go:generate easyjson -all types.go
+type Verification struct {
+	Status       Status         `json:",inline"`
+	Repo         string         `json:"repo"`
+	File         string         `json:"file"`
+	Digest       string         `json:"digest"`   // 制品当前的 SHA256
+	Verified     bool           `json:"verified"` // 至少有一个签名或来源证明通过验证
+	Signatures   []VerifiedItem `json:"signatures"`
+	Attestations []VerifiedItem `json:"attestations"`
+}
+
+func (r *Verification) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// AttestationResponse 上传的来源证明: POST /repo/{repo}/package/{file}/attestations
+//
+//go:generate easyjson -all types.go
+type AttestationResponse struct {
+	Status      Status      `json:",inline"`
+	Repo        string      `json:"repo"`
+	File        string      `json:"file"`
+	Attestation Attestation `json:"attestation"`
+}
+
+func (r *AttestationResponse) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// AttestationList 制品的来源证明: GET /repo/{repo}/package/{file}/attestations
+//
+//go:generate easyjson -all types.go
+type AttestationList struct {
 	Status       Status        `json:",inline"`
-	Type         string        `json:"type"`
-	Name         string        `json:"name"`
-	PackageCount int           `json:"package_count"`
-	RPMCount     int           `json:"rpm_count"`
-	DEBCount     int           `json:"deb_count"`
-	TotalSize    int64         `json:"total_size"`
-	Packages     []PackageInfo `json:"packages"`
+	Repo         string        `json:"repo"`
+	File         string        `json:"file"`
+	Attestations []Attestation `json:"attestations"`
 }
 
-func (r *RepoInfo) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+func (r *AttestationList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
 
+// AttestationKey 验证来源证明签名的公钥
+//
 //go:generate easyjson -all types.go
-type Metrics struct {
-	Requests    Requests    `json:"requests"`
-	Performance Performance `json:"performance"`
-	Memory      Memory      `json:"memory"`
+type AttestationKey struct {
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`  // ecdsa、ed25519、rsa
+	PublicKey string `json:"public_key"` // PEM
 }
 
-func (r *Metrics) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+// AttestationKeys 配置的公钥: GET /api/v1/attestation-keys
+//
+//go:generate easyjson -all types.go
+type AttestationKeys struct {
+	Status Status           `json:",inline"`
+	Keys   []AttestationKey `json:"keys"`
+}
 
+func (r *AttestationKeys) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// RepoExpiry 仓库中制品的过期时间（RFC3339），保存在仓库目录的 .expiry.json，按文件名索引
+//
 //go:generate easyjson -all types.go
-type Performance struct {
-	ResponseTimeMs int64 `json:"response_time_ms"`
-	Goroutines     int   `json:"goroutines"`
+type RepoExpiry struct {
+	Expires map[string]string `json:"expires"`
 }
 
+// NoteRequest 设置包说明: PUT /api/v1/repos/{repo}/notes/{file}
+//
 //go:generate easyjson -all types.go
-type Requests struct {
-	Total     int64 `json:"total"`
-	Uploads   int64 `json:"uploads"`
-	Downloads int64 `json:"downloads"`
-	Errors    int64 `json:"errors"`
-	Active    int64 `json:"active"`
+type NoteRequest struct {
+	Text   string `json:"text"`
+	Source string `json:"source,omitempty"`
 }
 
 //go:generate easyjson -all types.go
-type Memory struct {
-	AllocMB      uint64 `json:"alloc_mb"`
-	TotalAllocMB uint64 `json:"total_alloc_mb"`
-	SysMB        uint64 `json:"sys_mb"`
-	GCCycles     uint32 `json:"gc_cycles"`
+type NoteResponse struct {
+	Status Status      `json:",inline"`
+	Repo   string      `json:"repo"`
+	File   string      `json:"file"`
+	Note   PackageNote `json:"note"`
+}
+
+func (r *NoteResponse) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// RepoReadme 仓库说明，保存在仓库目录的 .readme.json
+//
+//go:generate easyjson -all types.go
+type RepoReadme struct {
+	Text    string `json:"text"` // Markdown
+	Author  string `json:"author,omitempty"`
+	Updated string `json:"updated"` // RFC3339
 }
 
+// ReadmeRequest 设置仓库说明: PUT /api/v1/repos/{repo}/readme
+//
 //go:generate easyjson -all types.go
-type ReadyCheck struct {
-	Status Status `json:"status"`
-	Checks Checks `json:"checks"`
+type ReadmeRequest struct {
+	Text string `json:"text"`
 }
 
-func (r *ReadyCheck) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+//go:generate easyjson -all types.go
+type ReadmeResponse struct {
+	Status Status     `json:",inline"`
+	Repo   string     `json:"repo"`
+	Readme RepoReadme `json:"readme"`
+	HTML   string     `json:"html"` // 替换变量后渲染的 HTML
+}
+
+func (r *ReadmeResponse) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// FeatureFlag 功能开关的当前状态，运行时的设置优先于配置
+//
+//go:generate easyjson -all types.go
+type FeatureFlag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Rollout     int    `json:"rollout"` // 开启的百分比，按仓库等键的哈希选择，100 为全部
+	Source      string `json:"source"`  // default、config 或 runtime
+	By          string `json:"by,omitempty"`
+	Updated     string `json:"updated,omitempty"`
+}
+
+// FeatureData 功能开关的运行时设置在 DatabasePath 下的持久化格式
+//
+//go:generate easyjson -all types.go
+type FeatureData struct {
+	Overrides []FeatureFlag `json:"overrides"`
+}
+
+// FeatureRequest 设置功能开关，省略的字段保持当前值
+//
+//go:generate easyjson -all types.go
+type FeatureRequest struct {
+	Enabled *bool `json:"enabled"`
+	Rollout *int  `json:"rollout"`
+}
+
+//go:generate easyjson -all types.go
+type FeatureList struct {
+	Status   Status        `json:",inline"`
+	Count    int           `json:"count"`
+	Features []FeatureFlag `json:"features"`
+}
+
+func (r *FeatureList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type FeatureResponse struct {
+	Status  Status      `json:",inline"`
+	Feature FeatureFlag `json:"feature"`
+}
+
+func (r *FeatureResponse) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// PluginInfo 启动时加载的插件及其注册的仓库类型和存储类型
+//
+//go:generate easyjson -all types.go
+type PluginInfo struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Path         string   `json:"path"`
+	RepoTypes    []string `json:"repo_types"`
+	StorageTypes []string `json:"storage_types"`
+	Middleware   []string `json:"middleware"`
+	Hooks        []string `json:"hooks"`
+}
+
+//go:generate easyjson -all types.go
+type PluginList struct {
+	Status       Status       `json:",inline"`
+	Count        int          `json:"count"`
+	Plugins      []PluginInfo `json:"plugins"`
+	RepoTypes    []string     `json:"repo_types"`    // 全部可用的仓库类型
+	StorageTypes []string     `json:"storage_types"` // 全部可用的存储类型
+}
+
+func (r *PluginList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// MetadataGeneration 保留的一代元数据，每次刷新后元数据发生变化时生成
+//
+//go:generate easyjson -all types.go
+type MetadataGeneration struct {
+	ID      string `json:"id"`
+	Created string `json:"created"` // RFC3339
+	Files   int    `json:"files"`
+	Size    int64  `json:"size"`
+	Current bool   `json:"current"` // 与仓库当前的元数据相同
+}
+
+// GenerationList 仓库保留的元数据历史: GET /api/v1/repos/{repo}/generations
+//
+//go:generate easyjson -all types.go
+type GenerationList struct {
+	Status      Status               `json:",inline"`
+	Repo        string               `json:"repo"`
+	Keep        int                  `json:"keep"`
+	Generations []MetadataGeneration `json:"generations"` // 从新到旧
+}
+
+func (r *GenerationList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// HealthCheck /health 的响应，前四个字段与 Status 相同，同时列出服务的监听地址
+//
+//go:generate easyjson -all types.go
+type HealthCheck struct {
+	Server    string         `json:"server"`
+	Status    string         `json:"status"`
+	Message   string         `json:"message"`
+	Code      int            `json:"code"`
+	Listeners []ListenerInfo `json:"listeners,omitempty"`
+}
+
+func (r *HealthCheck) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// ListenerInfo 一个监听地址
+//
+//go:generate easyjson -all types.go
+type ListenerInfo struct {
+	Address string `json:"address"`
+	Network string `json:"network"`
+	TLS     bool   `json:"tls"`
+	Systemd bool   `json:"systemd,omitempty"` // 由 systemd 传入的 socket
+}
+
+// UploadProgress 上传进度，字节数按请求体（含 multipart 边界）计算
+//
+//go:generate easyjson -all types.go
+type UploadProgress struct {
+	ID         string `json:"id"`
+	State      string `json:"state"` // receiving、processing、complete、failed
+	Received   int64  `json:"bytes_received"`
+	Total      int64  `json:"bytes_total,omitempty"` // 分块传输时未知
+	Rate       int64  `json:"rate"`                  // 最近 10 秒的平均速率，字节/秒
+	ETASeconds int64  `json:"eta_seconds,omitempty"`
+	Started    string `json:"started"`
+	Finished   string `json:"finished,omitempty"`
+	Code       int    `json:"code,omitempty"` // 上传请求的响应状态码
+}
+
+func (r *UploadProgress) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
 
 //go:generate easyjson -all types.go
 type PackageChecksum struct {
@@ -148,6 +1645,468 @@ type PackageChecksum struct {
 
 func (pc *PackageChecksum) WriteTo(w io.Writer) (int64, error) { return WriteTo(pc, w) }
 
+//go:generate easyjson -all types.go
+type KeyInfo struct {
+	Name        string   `json:"name"`
+	Fingerprint string   `json:"fingerprint"`
+	KeyID       string   `json:"key_id"`
+	UserIDs     []string `json:"user_ids"`
+	Created     string   `json:"created"`
+	Default     bool     `json:"default"`
+	URL         string   `json:"url"`
+}
+
+//go:generate easyjson -all types.go
+type KeyList struct {
+	Status Status    `json:",inline"`
+	Keys   []KeyInfo `json:"keys"`
+	Count  int       `json:"count"`
+}
+
+func (r *KeyList) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type TreeImage struct {
+	Platform string `json:"platform"`
+	Type     string `json:"type"`
+	Path     string `json:"path"`
+	Exists   bool   `json:"exists"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+//go:generate easyjson -all types.go
+type InstallTreeReport struct {
+	Status  Status      `json:",inline"`
+	Repo    string      `json:"repo"`
+	Valid   bool        `json:"valid"`
+	Family  string      `json:"family"`
+	Version string      `json:"version"`
+	Arch    string      `json:"arch"`
+	Images  []TreeImage `json:"images"`
+	Errors  []string    `json:"errors,omitempty"`
+}
+
+func (r *InstallTreeReport) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type BundleRequest struct {
+	Packages []string `json:"packages"`
+	Arch     string   `json:"arch"`
+	Strict   bool     `json:"strict"` // 存在无法满足的依赖时拒绝生成
+}
+
+// ArchiveRequest POST /repo/{repo}/archive 的请求，paths 和 dir 二选一
+//
+//go:generate easyjson -all types.go
+type ArchiveRequest struct {
+	Paths  []string `json:"paths,omitempty"`  // 相对仓库根目录的文件路径
+	Dir    string   `json:"dir,omitempty"`    // 打包目录下的所有文件，"/" 表示整个仓库
+	Format string   `json:"format,omitempty"` // tar（默认）或 zip
+}
+
+//go:generate easyjson -all types.go
+type BundleItem struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Arch    string `json:"arch"`
+	File    string `json:"file"`
+	Size    int64  `json:"size"`
+}
+
+//go:generate easyjson -all types.go
+type BundleManifest struct {
+	Status     Status       `json:",inline"`
+	Repo       string       `json:"repo"`
+	Requested  []string     `json:"requested"`
+	Packages   []BundleItem `json:"packages"`
+	Count      int          `json:"count"`
+	TotalSize  int64        `json:"total_size"`
+	Missing    []string     `json:"missing,omitempty"`
+	Unresolved []string     `json:"unresolved,omitempty"`
+}
+
+func (r *BundleManifest) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type RequirementInfo struct {
+	Requirement string   `json:"requirement"`
+	Providers   []string `json:"providers"`
+	Satisfied   bool     `json:"satisfied"`
+}
+
+//go:generate easyjson -all types.go
+type DependentInfo struct {
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	Arch         string `json:"arch"`
+	File         string `json:"file"`
+	Requirement  string `json:"requirement"`
+	SoleProvider bool   `json:"sole_provider,omitempty"`
+	Depth        int    `json:"depth,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type PackageDependencies struct {
+	Status     Status            `json:",inline"`
+	Repo       string            `json:"repo"`
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Arch       string            `json:"arch"`
+	File       string            `json:"file"`
+	Provides   []string          `json:"provides"`
+	Requires   []RequirementInfo `json:"requires"`
+	RequiredBy []DependentInfo   `json:"required_by"`
+}
+
+func (r *PackageDependencies) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type ReverseDependencies struct {
+	Status Status          `json:",inline"`
+	Repo   string          `json:"repo"`
+	File   string          `json:"file"`
+	Safe   bool            `json:"safe"` // 删除后不会破坏其他包的依赖
+	Broken []DependentInfo `json:"broken"`
+	Count  int             `json:"count"`
+}
+
+func (r *ReverseDependencies) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+//go:generate easyjson -all types.go
+type PackageVersion struct {
+	Version      string `json:"version"` // [epoch:]version[-release]
+	Arch         string `json:"arch"`
+	File         string `json:"file"`
+	Size         int64  `json:"size"`
+	Checksum     string `json:"checksum,omitempty"`
+	ChecksumType string `json:"checksum_type,omitempty"`
+	URL          string `json:"url"`
+	License      string `json:"license,omitempty"`
+}
+
+// PackageVersions 包的所有版本，从新到旧: GET /api/v1/repos/{repo}/packages/{name}/versions
+//
+//go:generate easyjson -all types.go
+type PackageVersions struct {
+	Status   Status           `json:",inline"`
+	Repo     string           `json:"repo"`
+	Name     string           `json:"name"`
+	Latest   string           `json:"latest"`
+	Count    int              `json:"count"`
+	Versions []PackageVersion `json:"versions"`
+}
+
+func (r *PackageVersions) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// PackageLatest 包的最新版本: GET /api/v1/repos/{repo}/packages/{name}/latest
+//
+//go:generate easyjson -all types.go
+type PackageLatest struct {
+	Status  Status         `json:",inline"`
+	Repo    string         `json:"repo"`
+	Name    string         `json:"name"`
+	Package PackageVersion `json:"package"`
+}
+
+func (r *PackageLatest) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// RepoDiff 两个仓库元数据中包的差异: GET /api/v1/diff?a=&b=
+//
+//go:generate easyjson -all types.go
+type RepoDiff struct {
+	Status     Status         `json:",inline"`
+	A          string         `json:"a"`
+	B          string         `json:"b"`
+	Identical  bool           `json:"identical"`
+	OnlyInA    []DiffPackage  `json:"only_in_a"`
+	OnlyInB    []DiffPackage  `json:"only_in_b"`
+	Mismatches []DiffMismatch `json:"mismatches"`
+}
+
+func (r *RepoDiff) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// CloneRequest 克隆仓库: POST /api/v1/repos/{repo}/clone
+//
+//go:generate easyjson -all types.go
+type CloneRequest struct {
+	Name string `json:"name"`
+}
+
+//go:generate easyjson -all types.go
+type CloneResult struct {
+	Status Status `json:",inline"`
+	Source string `json:"source"`
+	Repo   string `json:"repo"`
+	Type   string `json:"type"`
+	Files  int    `json:"files"`
+	Size   int64  `json:"size"`
+}
+
+func (r *CloneResult) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// ImportRequest 导入现有仓库树: POST /api/v1/import
+//
+//go:generate easyjson -all types.go
+type ImportRequest struct {
+	Path       string `json:"path"`
+	Prefix     string `json:"prefix"`
+	Regenerate bool   `json:"regenerate"` // 总是重新生成元数据，不保留现有的 repodata
+	DryRun     bool   `json:"dry_run"`
+}
+
+// ImportedRepo 导入的一个仓库
+//
+//go:generate easyjson -all types.go
+type ImportedRepo struct {
+	Repo     string   `json:"repo"`
+	Type     string   `json:"type"`
+	Layout   string   `json:"layout"`
+	Source   string   `json:"source"`
+	Packages int      `json:"packages"`
+	Size     int64    `json:"size"`
+	Skipped  []string `json:"skipped,omitempty"`
+	Metadata string   `json:"metadata"` // preserved 或 regenerated
+	Error    string   `json:"error,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type ImportReport struct {
+	Status   Status         `json:",inline"`
+	Path     string         `json:"path"`
+	DryRun   bool           `json:"dry_run"`
+	Imported int            `json:"imported"`
+	Failed   int            `json:"failed"`
+	Repos    []ImportedRepo `json:"repos"`
+}
+
+func (r *ImportReport) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// MigratedArtifact 迁移报告中的一个远程文件及其在 plus 中的位置
+//
+//go:generate easyjson -all types.go
+type MigratedArtifact struct {
+	Remote string `json:"remote"`
+	Repo   string `json:"repo,omitempty"`
+	Path   string `json:"path,omitempty"`
+	Size   int64  `json:"size"`
+	State  string `json:"state"` // copied, planned（dry run）, existing, ignored 或 failed
+	Error  string `json:"error,omitempty"`
+}
+
+// MigratedRepo 迁移的一个远程仓库
+//
+//go:generate easyjson -all types.go
+type MigratedRepo struct {
+	Remote    string             `json:"remote"`
+	Format    string             `json:"format"`
+	Repo      string             `json:"repo"`
+	Type      string             `json:"type"`
+	Repos     []string           `json:"repos"` // 写入的 plus 仓库，rpm 子仓库各一个
+	Copied    int                `json:"copied"`
+	Existing  int                `json:"existing"`
+	Ignored   int                `json:"ignored"`
+	Failed    int                `json:"failed"`
+	Size      int64              `json:"size"` // 复制的字节数
+	Error     string             `json:"error,omitempty"`
+	Artifacts []MigratedArtifact `json:"artifacts"`
+}
+
+//go:generate easyjson -all types.go
+type MigrationReport struct {
+	Status Status         `json:",inline"`
+	Source string         `json:"source"`
+	Kind   string         `json:"kind"`
+	DryRun bool           `json:"dry_run"`
+	Repos  []MigratedRepo `json:"repos"`
+}
+
+func (r *MigrationReport) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// NexusRepository Nexus 3: GET /service/rest/v1/repositories/{name}
+//
+//go:generate easyjson -all types.go
+type NexusRepository struct {
+	Name   string `json:"name"`
+	Format string `json:"format"` // yum, apt, raw ...
+	Type   string `json:"type"`   // hosted, proxy, group
+}
+
+// NexusAssets Nexus 3: GET /service/rest/v1/assets?repository={name}
+//
+//go:generate easyjson -all types.go
+type NexusAssets struct {
+	Items             []NexusAsset `json:"items"`
+	ContinuationToken string       `json:"continuationToken"`
+}
+
+//go:generate easyjson -all types.go
+type NexusAsset struct {
+	DownloadURL string            `json:"downloadUrl"`
+	Path        string            `json:"path"`
+	Checksum    map[string]string `json:"checksum"`
+	FileSize    int64             `json:"fileSize"` // 3.47 之前的版本没有
+}
+
+// ArtifactoryRepository Artifactory: GET /api/repositories/{key}
+//
+//go:generate easyjson -all types.go
+type ArtifactoryRepository struct {
+	Key         string `json:"key"`
+	PackageType string `json:"packageType"` // rpm, debian, generic ...
+	Rclass      string `json:"rclass"`
+}
+
+// ArtifactoryFileList Artifactory: GET /api/storage/{key}?list&deep=1
+//
+//go:generate easyjson -all types.go
+type ArtifactoryFileList struct {
+	Files []ArtifactoryFile `json:"files"`
+}
+
+//go:generate easyjson -all types.go
+type ArtifactoryFile struct {
+	URI    string `json:"uri"`
+	Size   int64  `json:"size"`
+	Folder bool   `json:"folder"`
+	SHA1   string `json:"sha1"`
+	SHA2   string `json:"sha2"`
+}
+
+// PulpRepositoryList Pulp 3: GET /pulp/api/v3/repositories/?name={name}
+//
+//go:generate easyjson -all types.go
+type PulpRepositoryList struct {
+	Results []PulpRepository `json:"results"`
+}
+
+//go:generate easyjson -all types.go
+type PulpRepository struct {
+	PulpHref          string `json:"pulp_href"`
+	Name              string `json:"name"`
+	LatestVersionHref string `json:"latest_version_href"`
+}
+
+// PulpContentList Pulp 3: GET /pulp/api/v3/content/{plugin}/{type}/?repository_version={href}
+// rpm 包使用 location_href、pkgId 和 size_package，deb 包和文件使用 relative_path、sha256 和 size
+//
+//go:generate easyjson -all types.go
+type PulpContentList struct {
+	Next    string        `json:"next"`
+	Results []PulpContent `json:"results"`
+}
+
+//go:generate easyjson -all types.go
+type PulpContent struct {
+	LocationHref string `json:"location_href"`
+	PkgID        string `json:"pkgId"`
+	ChecksumType string `json:"checksum_type"`
+	SizePackage  int64  `json:"size_package"`
+	RelativePath string `json:"relative_path"`
+	SHA256       string `json:"sha256"`
+	Size         int64  `json:"size"`
+}
+
+// PulpDistributionList Pulp 3: GET /pulp/api/v3/distributions/{plugin}/{type}/?repository={href}
+//
+//go:generate easyjson -all types.go
+type PulpDistributionList struct {
+	Results []PulpDistribution `json:"results"`
+}
+
+//go:generate easyjson -all types.go
+type PulpDistribution struct {
+	Name    string `json:"name"`
+	BaseURL string `json:"base_url"`
+}
+
+// StorageSyncPass plus migrate-storage 一轮复制的结果
+//
+//go:generate easyjson -all types.go
+type StorageSyncPass struct {
+	Repos    []string `json:"repos"`
+	Objects  int      `json:"objects"` // 源后端中的对象数
+	Copied   int      `json:"copied"`
+	Existing int      `json:"existing"` // 目标中已有且 SHA-256 一致
+	Deleted  int      `json:"deleted"`  // 源中已删除、从目标移除
+	Failed   int      `json:"failed"`
+	Size     int64    `json:"size"` // 复制的字节数
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// StorageMigrationReport plus migrate-storage 的结果；第二轮复制第一轮期间的改动，之后切换
+//
+//go:generate easyjson -all types.go
+type StorageMigrationReport struct {
+	Status   Status            `json:",inline"`
+	Label    string            `json:"label"`
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	DryRun   bool              `json:"dry_run"`
+	Passes   []StorageSyncPass `json:"passes"`
+	Switched bool              `json:"switched"`
+}
+
+func (r *StorageMigrationReport) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
+// JournalEntry 操作日志中尚未完成的多步操作，启动时重放或回滚
+//
+//go:generate easyjson -all types.go
+type JournalEntry struct {
+	ID      string   `json:"id"`
+	Op      string   `json:"op"`               // refresh、move、retention、clone
+	Repo    string   `json:"repo"`             // 操作的仓库，clone 为新仓库
+	Source  string   `json:"source,omitempty"` // move 的暂存区或等待区，clone 的源仓库
+	Files   []string `json:"files,omitempty"`
+	Started string   `json:"started"`
+	Node    string   `json:"node,omitempty"` // 集群模式下记录操作的实例，只有它或已停止的实例的操作才重放
+}
+
+//go:generate easyjson -all types.go
+type DiffPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Arch    string `json:"arch"`
+	File    string `json:"file"`
+}
+
+//go:generate easyjson -all types.go
+type DiffMismatch struct {
+	Name            string   `json:"name"`
+	Arch            string   `json:"arch"`
+	A               []string `json:"a"` // A 中的版本，从新到旧
+	B               []string `json:"b"`
+	Newer           string   `json:"newer"` // 最新版本较新的一方：a 或 b，相同时为空
+	ChecksumDiffers []string `json:"checksum_differs,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type FsckIssue struct {
+	Type     string `json:"type"` // missing、corrupt、size_mismatch、unreadable、orphan
+	Path     string `json:"path"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+//go:generate easyjson -all types.go
+type FsckReport struct {
+	Status     Status      `json:",inline"`
+	Repo       string      `json:"repo"`
+	Type       string      `json:"type"`
+	Healthy    bool        `json:"healthy"`
+	Referenced int         `json:"referenced"` // 元数据引用的包数
+	Checked    int         `json:"checked"`    // 校验通过的包数
+	Missing    int         `json:"missing"`
+	Corrupt    int         `json:"corrupt"`
+	Orphans    int         `json:"orphans"`
+	Issues     []FsckIssue `json:"issues"`
+	Repaired   bool        `json:"repaired"`
+	Duration   string      `json:"duration"`
+}
+
+func (r *FsckReport) WriteTo(w io.Writer) (int64, error) { return WriteTo(r, w) }
+
 //go:generate easyjson -all types.go
 type Checks struct {
 	Storage string
@@ -193,4 +2152,3 @@ func WriteTo(m json.Marshaler, w io.Writer) (int64, error) {
 	}
 	return int64(n), nil
 }
-
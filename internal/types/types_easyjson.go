@@ -18,7 +18,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson6601e8cdDecodePlusInternalTypes(in *jlexer.Lexer, out *Version) {
+func easyjson6601e8cdDecodePlusInternalTypes(in *jlexer.Lexer, out *WorkerMetrics) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -37,12 +37,22 @@ func easyjson6601e8cdDecodePlusInternalTypes(in *jlexer.Lexer, out *Version) {
 			continue
 		}
 		switch key {
-		case "Epoch":
-			out.Epoch = string(in.String())
-		case "Ver":
-			out.Ver = string(in.String())
-		case "Rel":
-			out.Rel = string(in.String())
+		case "workers":
+			out.Workers = int(in.Int())
+		case "queue_size":
+			out.QueueSize = int(in.Int())
+		case "queued":
+			out.Queued = int(in.Int())
+		case "running":
+			out.Running = int64(in.Int64())
+		case "completed":
+			out.Completed = int64(in.Int64())
+		case "failed":
+			out.Failed = int64(in.Int64())
+		case "rejected":
+			out.Rejected = int64(in.Int64())
+		case "avg_wait_ms":
+			out.AvgWaitMs = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -53,52 +63,77 @@ func easyjson6601e8cdDecodePlusInternalTypes(in *jlexer.Lexer, out *Version) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes(out *jwriter.Writer, in Version) {
+func easyjson6601e8cdEncodePlusInternalTypes(out *jwriter.Writer, in WorkerMetrics) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Epoch\":"
+		const prefix string = ",\"workers\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Epoch))
+		out.Int(int(in.Workers))
 	}
 	{
-		const prefix string = ",\"Ver\":"
+		const prefix string = ",\"queue_size\":"
 		out.RawString(prefix)
-		out.String(string(in.Ver))
+		out.Int(int(in.QueueSize))
 	}
 	{
-		const prefix string = ",\"Rel\":"
+		const prefix string = ",\"queued\":"
 		out.RawString(prefix)
-		out.String(string(in.Rel))
+		out.Int(int(in.Queued))
+	}
+	{
+		const prefix string = ",\"running\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Running))
+	}
+	{
+		const prefix string = ",\"completed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Completed))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Failed))
+	}
+	{
+		const prefix string = ",\"rejected\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Rejected))
+	}
+	{
+		const prefix string = ",\"avg_wait_ms\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.AvgWaitMs))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Version) MarshalJSON() ([]byte, error) {
+func (v WorkerMetrics) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Version) MarshalEasyJSON(w *jwriter.Writer) {
+func (v WorkerMetrics) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Version) UnmarshalJSON(data []byte) error {
+func (v *WorkerMetrics) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Version) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *WorkerMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes1(in *jlexer.Lexer, out *TreeNode) {
+func easyjson6601e8cdDecodePlusInternalTypes1(in *jlexer.Lexer, out *Version) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -117,40 +152,12 @@ func easyjson6601e8cdDecodePlusInternalTypes1(in *jlexer.Lexer, out *TreeNode) {
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "path":
-			out.Path = string(in.String())
-		case "repoType":
-			out.RepoType = string(in.String())
-		case "children":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				in.Delim('{')
-				if !in.IsDelim('}') {
-					out.Children = make(map[string]*TreeNode)
-				} else {
-					out.Children = nil
-				}
-				for !in.IsDelim('}') {
-					key := string(in.String())
-					in.WantColon()
-					var v1 *TreeNode
-					if in.IsNull() {
-						in.Skip()
-						v1 = nil
-					} else {
-						if v1 == nil {
-							v1 = new(TreeNode)
-						}
-						(*v1).UnmarshalEasyJSON(in)
-					}
-					(out.Children)[key] = v1
-					in.WantComma()
-				}
-				in.Delim('}')
-			}
+		case "Epoch":
+			out.Epoch = string(in.String())
+		case "Ver":
+			out.Ver = string(in.String())
+		case "Rel":
+			out.Rel = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -161,75 +168,52 @@ func easyjson6601e8cdDecodePlusInternalTypes1(in *jlexer.Lexer, out *TreeNode) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes1(out *jwriter.Writer, in TreeNode) {
+func easyjson6601e8cdEncodePlusInternalTypes1(out *jwriter.Writer, in Version) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"Epoch\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
-	}
-	if in.Path != "" {
-		const prefix string = ",\"path\":"
-		out.RawString(prefix)
-		out.String(string(in.Path))
+		out.String(string(in.Epoch))
 	}
-	if in.RepoType != "" {
-		const prefix string = ",\"repoType\":"
+	{
+		const prefix string = ",\"Ver\":"
 		out.RawString(prefix)
-		out.String(string(in.RepoType))
+		out.String(string(in.Ver))
 	}
-	if len(in.Children) != 0 {
-		const prefix string = ",\"children\":"
+	{
+		const prefix string = ",\"Rel\":"
 		out.RawString(prefix)
-		{
-			out.RawByte('{')
-			v2First := true
-			for v2Name, v2Value := range in.Children {
-				if v2First {
-					v2First = false
-				} else {
-					out.RawByte(',')
-				}
-				out.String(string(v2Name))
-				out.RawByte(':')
-				if v2Value == nil {
-					out.RawString("null")
-				} else {
-					(*v2Value).MarshalEasyJSON(out)
-				}
-			}
-			out.RawByte('}')
-		}
+		out.String(string(in.Rel))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v TreeNode) MarshalJSON() ([]byte, error) {
+func (v Version) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v TreeNode) MarshalEasyJSON(w *jwriter.Writer) {
+func (v Version) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *TreeNode) UnmarshalJSON(data []byte) error {
+func (v *Version) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *TreeNode) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *Version) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes1(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes2(in *jlexer.Lexer, out *Status) {
+func easyjson6601e8cdDecodePlusInternalTypes2(in *jlexer.Lexer, out *VerifiedItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -248,14 +232,14 @@ func easyjson6601e8cdDecodePlusInternalTypes2(in *jlexer.Lexer, out *Status) {
 			continue
 		}
 		switch key {
-		case "server":
-			out.Server = string(in.String())
-		case "status":
-			out.Status = string(in.String())
-		case "message":
-			out.Message = string(in.String())
-		case "code":
-			out.Code = int(in.Int())
+		case "id":
+			out.ID = string(in.String())
+		case "verified":
+			out.Verified = bool(in.Bool())
+		case "key":
+			out.Key = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -266,57 +250,57 @@ func easyjson6601e8cdDecodePlusInternalTypes2(in *jlexer.Lexer, out *Status) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes2(out *jwriter.Writer, in Status) {
+func easyjson6601e8cdEncodePlusInternalTypes2(out *jwriter.Writer, in VerifiedItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"server\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Server))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"status\":"
+		const prefix string = ",\"verified\":"
 		out.RawString(prefix)
-		out.String(string(in.Status))
+		out.Bool(bool(in.Verified))
 	}
-	{
-		const prefix string = ",\"message\":"
+	if in.Key != "" {
+		const prefix string = ",\"key\":"
 		out.RawString(prefix)
-		out.String(string(in.Message))
+		out.String(string(in.Key))
 	}
-	{
-		const prefix string = ",\"code\":"
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
 		out.RawString(prefix)
-		out.Int(int(in.Code))
+		out.String(string(in.Reason))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Status) MarshalJSON() ([]byte, error) {
+func (v VerifiedItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Status) MarshalEasyJSON(w *jwriter.Writer) {
+func (v VerifiedItem) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Status) UnmarshalJSON(data []byte) error {
+func (v *VerifiedItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Status) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *VerifiedItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes2(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes3(in *jlexer.Lexer, out *Requests) {
+func easyjson6601e8cdDecodePlusInternalTypes3(in *jlexer.Lexer, out *Verification) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -335,16 +319,62 @@ func easyjson6601e8cdDecodePlusInternalTypes3(in *jlexer.Lexer, out *Requests) {
 			continue
 		}
 		switch key {
-		case "total":
-			out.Total = int64(in.Int64())
-		case "uploads":
-			out.Uploads = int64(in.Int64())
-		case "downloads":
-			out.Downloads = int64(in.Int64())
-		case "errors":
-			out.Errors = int64(in.Int64())
-		case "active":
-			out.Active = int64(in.Int64())
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		case "verified":
+			out.Verified = bool(in.Bool())
+		case "signatures":
+			if in.IsNull() {
+				in.Skip()
+				out.Signatures = nil
+			} else {
+				in.Delim('[')
+				if out.Signatures == nil {
+					if !in.IsDelim(']') {
+						out.Signatures = make([]VerifiedItem, 0, 1)
+					} else {
+						out.Signatures = []VerifiedItem{}
+					}
+				} else {
+					out.Signatures = (out.Signatures)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 VerifiedItem
+					(v1).UnmarshalEasyJSON(in)
+					out.Signatures = append(out.Signatures, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "attestations":
+			if in.IsNull() {
+				in.Skip()
+				out.Attestations = nil
+			} else {
+				in.Delim('[')
+				if out.Attestations == nil {
+					if !in.IsDelim(']') {
+						out.Attestations = make([]VerifiedItem, 0, 1)
+					} else {
+						out.Attestations = []VerifiedItem{}
+					}
+				} else {
+					out.Attestations = (out.Attestations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 VerifiedItem
+					(v2).UnmarshalEasyJSON(in)
+					out.Attestations = append(out.Attestations, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -355,62 +385,94 @@ func easyjson6601e8cdDecodePlusInternalTypes3(in *jlexer.Lexer, out *Requests) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes3(out *jwriter.Writer, in Requests) {
+func easyjson6601e8cdEncodePlusInternalTypes3(out *jwriter.Writer, in Verification) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"total\":"
+		const prefix string = ",\"Status\":"
 		out.RawString(prefix[1:])
-		out.Int64(int64(in.Total))
+		(in.Status).MarshalEasyJSON(out)
 	}
 	{
-		const prefix string = ",\"uploads\":"
+		const prefix string = ",\"repo\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.Uploads))
+		out.String(string(in.Repo))
 	}
 	{
-		const prefix string = ",\"downloads\":"
+		const prefix string = ",\"file\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.Downloads))
+		out.String(string(in.File))
 	}
 	{
-		const prefix string = ",\"errors\":"
+		const prefix string = ",\"digest\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.Errors))
+		out.String(string(in.Digest))
 	}
 	{
-		const prefix string = ",\"active\":"
+		const prefix string = ",\"verified\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.Active))
+		out.Bool(bool(in.Verified))
+	}
+	{
+		const prefix string = ",\"signatures\":"
+		out.RawString(prefix)
+		if in.Signatures == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v3, v4 := range in.Signatures {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				(v4).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"attestations\":"
+		out.RawString(prefix)
+		if in.Attestations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Attestations {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Requests) MarshalJSON() ([]byte, error) {
+func (v Verification) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Requests) MarshalEasyJSON(w *jwriter.Writer) {
+func (v Verification) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Requests) UnmarshalJSON(data []byte) error {
+func (v *Verification) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Requests) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *Verification) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes3(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes4(in *jlexer.Lexer, out *RepoTable) {
+func easyjson6601e8cdDecodePlusInternalTypes4(in *jlexer.Lexer, out *UserToken) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -429,14 +491,14 @@ func easyjson6601e8cdDecodePlusInternalTypes4(in *jlexer.Lexer, out *RepoTable)
 			continue
 		}
 		switch key {
+		case "id":
+			out.ID = string(in.String())
 		case "name":
 			out.Name = string(in.String())
-		case "type":
-			out.Type = string(in.String())
-		case "description":
-			out.Description = string(in.String())
-		case "path":
-			out.Path = string(in.String())
+		case "token":
+			out.Token = string(in.String())
+		case "created":
+			out.Created = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -447,57 +509,57 @@ func easyjson6601e8cdDecodePlusInternalTypes4(in *jlexer.Lexer, out *RepoTable)
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes4(out *jwriter.Writer, in RepoTable) {
+func easyjson6601e8cdEncodePlusInternalTypes4(out *jwriter.Writer, in UserToken) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"name\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Name))
+		out.String(string(in.ID))
 	}
-	{
-		const prefix string = ",\"type\":"
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
 		out.RawString(prefix)
-		out.String(string(in.Type))
+		out.String(string(in.Name))
 	}
-	{
-		const prefix string = ",\"description\":"
+	if in.Token != "" {
+		const prefix string = ",\"token\":"
 		out.RawString(prefix)
-		out.String(string(in.Description))
+		out.String(string(in.Token))
 	}
 	{
-		const prefix string = ",\"path\":"
+		const prefix string = ",\"created\":"
 		out.RawString(prefix)
-		out.String(string(in.Path))
+		out.String(string(in.Created))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RepoTable) MarshalJSON() ([]byte, error) {
+func (v UserToken) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RepoTable) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UserToken) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RepoTable) UnmarshalJSON(data []byte) error {
+func (v *UserToken) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RepoTable) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UserToken) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes4(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes5(in *jlexer.Lexer, out *RepoStatus) {
+func easyjson6601e8cdDecodePlusInternalTypes5(in *jlexer.Lexer, out *UserList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -516,10 +578,29 @@ func easyjson6601e8cdDecodePlusInternalTypes5(in *jlexer.Lexer, out *RepoStatus)
 			continue
 		}
 		switch key {
-		case "Status":
-			(out.Status).UnmarshalEasyJSON(in)
-		case "repo":
-			out.Repo = string(in.String())
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]User, 0, 0)
+					} else {
+						out.Users = []User{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 User
+					(v7).UnmarshalEasyJSON(in)
+					out.Users = append(out.Users, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -530,47 +611,53 @@ func easyjson6601e8cdDecodePlusInternalTypes5(in *jlexer.Lexer, out *RepoStatus)
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes5(out *jwriter.Writer, in RepoStatus) {
+func easyjson6601e8cdEncodePlusInternalTypes5(out *jwriter.Writer, in UserList) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Status\":"
+		const prefix string = ",\"users\":"
 		out.RawString(prefix[1:])
-		(in.Status).MarshalEasyJSON(out)
-	}
-	{
-		const prefix string = ",\"repo\":"
-		out.RawString(prefix)
-		out.String(string(in.Repo))
+		if in.Users == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Users {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RepoStatus) MarshalJSON() ([]byte, error) {
+func (v UserList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RepoStatus) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UserList) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RepoStatus) UnmarshalJSON(data []byte) error {
+func (v *UserList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RepoStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UserList) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes5(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes6(in *jlexer.Lexer, out *RepoMeta) {
+func easyjson6601e8cdDecodePlusInternalTypes6(in *jlexer.Lexer, out *User) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -589,57 +676,87 @@ func easyjson6601e8cdDecodePlusInternalTypes6(in *jlexer.Lexer, out *RepoMeta) {
 			continue
 		}
 		switch key {
-		case "Status":
-			(out.Status).UnmarshalEasyJSON(in)
-		case "repositories":
+		case "name":
+			out.Name = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "groups":
 			if in.IsNull() {
 				in.Skip()
-				out.Repositories = nil
+				out.Groups = nil
 			} else {
 				in.Delim('[')
-				if out.Repositories == nil {
+				if out.Groups == nil {
 					if !in.IsDelim(']') {
-						out.Repositories = make([]string, 0, 4)
+						out.Groups = make([]string, 0, 4)
 					} else {
-						out.Repositories = []string{}
+						out.Groups = []string{}
 					}
 				} else {
-					out.Repositories = (out.Repositories)[:0]
+					out.Groups = (out.Groups)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v3 string
-					v3 = string(in.String())
-					out.Repositories = append(out.Repositories, v3)
+					var v10 string
+					v10 = string(in.String())
+					out.Groups = append(out.Groups, v10)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "tree":
+		case "roles":
 			if in.IsNull() {
 				in.Skip()
+				out.Roles = nil
 			} else {
-				in.Delim('{')
-				out.Tree = make(map[string]*TreeNode)
-				for !in.IsDelim('}') {
-					key := string(in.String())
-					in.WantColon()
-					var v4 *TreeNode
-					if in.IsNull() {
-						in.Skip()
-						v4 = nil
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]string, 0, 4)
 					} else {
-						if v4 == nil {
-							v4 = new(TreeNode)
-						}
-						(*v4).UnmarshalEasyJSON(in)
+						out.Roles = []string{}
 					}
-					(out.Tree)[key] = v4
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 string
+					v11 = string(in.String())
+					out.Roles = append(out.Roles, v11)
 					in.WantComma()
 				}
-				in.Delim('}')
+				in.Delim(']')
+			}
+		case "disabled":
+			out.Disabled = bool(in.Bool())
+		case "source":
+			out.Source = string(in.String())
+		case "created":
+			out.Created = string(in.String())
+		case "updated":
+			out.Updated = string(in.String())
+		case "tokens":
+			if in.IsNull() {
+				in.Skip()
+				out.Tokens = nil
+			} else {
+				in.Delim('[')
+				if out.Tokens == nil {
+					if !in.IsDelim(']') {
+						out.Tokens = make([]UserToken, 0, 1)
+					} else {
+						out.Tokens = []UserToken{}
+					}
+				} else {
+					out.Tokens = (out.Tokens)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v12 UserToken
+					(v12).UnmarshalEasyJSON(in)
+					out.Tokens = append(out.Tokens, v12)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
-		case "count":
-			out.Count = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -650,88 +767,113 @@ func easyjson6601e8cdDecodePlusInternalTypes6(in *jlexer.Lexer, out *RepoMeta) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes6(out *jwriter.Writer, in RepoMeta) {
+func easyjson6601e8cdEncodePlusInternalTypes6(out *jwriter.Writer, in User) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Status\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix[1:])
-		(in.Status).MarshalEasyJSON(out)
+		out.String(string(in.Name))
+	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
 	}
 	{
-		const prefix string = ",\"repositories\":"
+		const prefix string = ",\"groups\":"
 		out.RawString(prefix)
-		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Groups == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v5, v6 := range in.Repositories {
-				if v5 > 0 {
+			for v13, v14 := range in.Groups {
+				if v13 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v6))
+				out.String(string(v14))
 			}
 			out.RawByte(']')
 		}
 	}
 	{
-		const prefix string = ",\"tree\":"
+		const prefix string = ",\"roles\":"
 		out.RawString(prefix)
-		if in.Tree == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
-			out.RawString(`null`)
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
 		} else {
-			out.RawByte('{')
-			v7First := true
-			for v7Name, v7Value := range in.Tree {
-				if v7First {
-					v7First = false
-				} else {
+			out.RawByte('[')
+			for v15, v16 := range in.Roles {
+				if v15 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v7Name))
-				out.RawByte(':')
-				if v7Value == nil {
-					out.RawString("null")
-				} else {
-					(*v7Value).MarshalEasyJSON(out)
-				}
+				out.String(string(v16))
 			}
-			out.RawByte('}')
+			out.RawByte(']')
 		}
 	}
-	{
-		const prefix string = ",\"count\":"
+	if in.Disabled {
+		const prefix string = ",\"disabled\":"
 		out.RawString(prefix)
-		out.Int(int(in.Count))
+		out.Bool(bool(in.Disabled))
+	}
+	if in.Source != "" {
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	if in.Created != "" {
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	if in.Updated != "" {
+		const prefix string = ",\"updated\":"
+		out.RawString(prefix)
+		out.String(string(in.Updated))
+	}
+	if len(in.Tokens) != 0 {
+		const prefix string = ",\"tokens\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v17, v18 := range in.Tokens {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				(v18).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RepoMeta) MarshalJSON() ([]byte, error) {
+func (v User) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RepoMeta) MarshalEasyJSON(w *jwriter.Writer) {
+func (v User) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RepoMeta) UnmarshalJSON(data []byte) error {
+func (v *User) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RepoMeta) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *User) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes6(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes7(in *jlexer.Lexer, out *RepoInfo) {
+func easyjson6601e8cdDecodePlusInternalTypes7(in *jlexer.Lexer, out *UsageReport) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -752,41 +894,37 @@ func easyjson6601e8cdDecodePlusInternalTypes7(in *jlexer.Lexer, out *RepoInfo) {
 		switch key {
 		case "Status":
 			(out.Status).UnmarshalEasyJSON(in)
-		case "type":
-			out.Type = string(in.String())
-		case "name":
-			out.Name = string(in.String())
-		case "package_count":
-			out.PackageCount = int(in.Int())
-		case "rpm_count":
-			out.RPMCount = int(in.Int())
-		case "deb_count":
-			out.DEBCount = int(in.Int())
-		case "total_size":
-			out.TotalSize = int64(in.Int64())
-		case "packages":
+		case "from":
+			out.From = string(in.String())
+		case "to":
+			out.To = string(in.String())
+		case "group":
+			out.Group = string(in.String())
+		case "records":
 			if in.IsNull() {
 				in.Skip()
-				out.Packages = nil
+				out.Records = nil
 			} else {
 				in.Delim('[')
-				if out.Packages == nil {
+				if out.Records == nil {
 					if !in.IsDelim(']') {
-						out.Packages = make([]PackageInfo, 0, 0)
+						out.Records = make([]UsageRecord, 0, 0)
 					} else {
-						out.Packages = []PackageInfo{}
+						out.Records = []UsageRecord{}
 					}
 				} else {
-					out.Packages = (out.Packages)[:0]
+					out.Records = (out.Records)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v8 PackageInfo
-					(v8).UnmarshalEasyJSON(in)
-					out.Packages = append(out.Packages, v8)
+					var v19 UsageRecord
+					(v19).UnmarshalEasyJSON(in)
+					out.Records = append(out.Records, v19)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "total":
+			(out.Total).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -797,7 +935,7 @@ func easyjson6601e8cdDecodePlusInternalTypes7(in *jlexer.Lexer, out *RepoInfo) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes7(out *jwriter.Writer, in RepoInfo) {
+func easyjson6601e8cdEncodePlusInternalTypes7(out *jwriter.Writer, in UsageReport) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -807,78 +945,68 @@ func easyjson6601e8cdEncodePlusInternalTypes7(out *jwriter.Writer, in RepoInfo)
 		(in.Status).MarshalEasyJSON(out)
 	}
 	{
-		const prefix string = ",\"type\":"
-		out.RawString(prefix)
-		out.String(string(in.Type))
-	}
-	{
-		const prefix string = ",\"name\":"
-		out.RawString(prefix)
-		out.String(string(in.Name))
-	}
-	{
-		const prefix string = ",\"package_count\":"
-		out.RawString(prefix)
-		out.Int(int(in.PackageCount))
-	}
-	{
-		const prefix string = ",\"rpm_count\":"
+		const prefix string = ",\"from\":"
 		out.RawString(prefix)
-		out.Int(int(in.RPMCount))
+		out.String(string(in.From))
 	}
 	{
-		const prefix string = ",\"deb_count\":"
+		const prefix string = ",\"to\":"
 		out.RawString(prefix)
-		out.Int(int(in.DEBCount))
+		out.String(string(in.To))
 	}
 	{
-		const prefix string = ",\"total_size\":"
+		const prefix string = ",\"group\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.TotalSize))
+		out.String(string(in.Group))
 	}
 	{
-		const prefix string = ",\"packages\":"
+		const prefix string = ",\"records\":"
 		out.RawString(prefix)
-		if in.Packages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Records == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v9, v10 := range in.Packages {
-				if v9 > 0 {
+			for v20, v21 := range in.Records {
+				if v20 > 0 {
 					out.RawByte(',')
 				}
-				(v10).MarshalEasyJSON(out)
+				(v21).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		(in.Total).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RepoInfo) MarshalJSON() ([]byte, error) {
+func (v UsageReport) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RepoInfo) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UsageReport) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RepoInfo) UnmarshalJSON(data []byte) error {
+func (v *UsageReport) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RepoInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UsageReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes7(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes8(in *jlexer.Lexer, out *ReadyCheck) {
+func easyjson6601e8cdDecodePlusInternalTypes8(in *jlexer.Lexer, out *UsageRecord) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -897,10 +1025,18 @@ func easyjson6601e8cdDecodePlusInternalTypes8(in *jlexer.Lexer, out *ReadyCheck)
 			continue
 		}
 		switch key {
-		case "status":
-			(out.Status).UnmarshalEasyJSON(in)
-		case "checks":
-			(out.Checks).UnmarshalEasyJSON(in)
+		case "date":
+			out.Date = string(in.String())
+		case "user":
+			out.User = string(in.String())
+		case "token":
+			out.Token = string(in.String())
+		case "requests":
+			out.Requests = int64(in.Int64())
+		case "bytes_uploaded":
+			out.BytesUploaded = int64(in.Int64())
+		case "bytes_downloaded":
+			out.BytesDownloaded = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -911,47 +1047,83 @@ func easyjson6601e8cdDecodePlusInternalTypes8(in *jlexer.Lexer, out *ReadyCheck)
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes8(out *jwriter.Writer, in ReadyCheck) {
+func easyjson6601e8cdEncodePlusInternalTypes8(out *jwriter.Writer, in UsageRecord) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"status\":"
+	if in.Date != "" {
+		const prefix string = ",\"date\":"
+		first = false
 		out.RawString(prefix[1:])
-		(in.Status).MarshalEasyJSON(out)
+		out.String(string(in.Date))
+	}
+	if in.User != "" {
+		const prefix string = ",\"user\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.User))
+	}
+	if in.Token != "" {
+		const prefix string = ",\"token\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Token))
 	}
 	{
-		const prefix string = ",\"checks\":"
+		const prefix string = ",\"requests\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Requests))
+	}
+	{
+		const prefix string = ",\"bytes_uploaded\":"
 		out.RawString(prefix)
-		(in.Checks).MarshalEasyJSON(out)
+		out.Int64(int64(in.BytesUploaded))
+	}
+	{
+		const prefix string = ",\"bytes_downloaded\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesDownloaded))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ReadyCheck) MarshalJSON() ([]byte, error) {
+func (v UsageRecord) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ReadyCheck) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UsageRecord) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ReadyCheck) UnmarshalJSON(data []byte) error {
+func (v *UsageRecord) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ReadyCheck) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UsageRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes8(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes9(in *jlexer.Lexer, out *Performance) {
+func easyjson6601e8cdDecodePlusInternalTypes9(in *jlexer.Lexer, out *UsageFile) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -970,10 +1142,31 @@ func easyjson6601e8cdDecodePlusInternalTypes9(in *jlexer.Lexer, out *Performance
 			continue
 		}
 		switch key {
-		case "response_time_ms":
-			out.ResponseTimeMs = int64(in.Int64())
-		case "goroutines":
-			out.Goroutines = int(in.Int())
+		case "saved_at":
+			out.SavedAt = string(in.String())
+		case "days":
+			if in.IsNull() {
+				in.Skip()
+				out.Days = nil
+			} else {
+				in.Delim('[')
+				if out.Days == nil {
+					if !in.IsDelim(']') {
+						out.Days = make([]UsageRecord, 0, 0)
+					} else {
+						out.Days = []UsageRecord{}
+					}
+				} else {
+					out.Days = (out.Days)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 UsageRecord
+					(v22).UnmarshalEasyJSON(in)
+					out.Days = append(out.Days, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -984,47 +1177,58 @@ func easyjson6601e8cdDecodePlusInternalTypes9(in *jlexer.Lexer, out *Performance
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes9(out *jwriter.Writer, in Performance) {
+func easyjson6601e8cdEncodePlusInternalTypes9(out *jwriter.Writer, in UsageFile) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"response_time_ms\":"
+		const prefix string = ",\"saved_at\":"
 		out.RawString(prefix[1:])
-		out.Int64(int64(in.ResponseTimeMs))
+		out.String(string(in.SavedAt))
 	}
 	{
-		const prefix string = ",\"goroutines\":"
+		const prefix string = ",\"days\":"
 		out.RawString(prefix)
-		out.Int(int(in.Goroutines))
+		if in.Days == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.Days {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				(v24).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Performance) MarshalJSON() ([]byte, error) {
+func (v UsageFile) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Performance) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UsageFile) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Performance) UnmarshalJSON(data []byte) error {
+func (v *UsageFile) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Performance) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UsageFile) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes9(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes10(in *jlexer.Lexer, out *PackageInfo) {
+func easyjson6601e8cdDecodePlusInternalTypes10(in *jlexer.Lexer, out *UpstreamStatus) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1043,18 +1247,22 @@ func easyjson6601e8cdDecodePlusInternalTypes10(in *jlexer.Lexer, out *PackageInf
 			continue
 		}
 		switch key {
-		case "name":
-			out.Name = string(in.String())
-		case "version":
-			out.Version = string(in.String())
-		case "release":
-			out.Release = string(in.String())
-		case "arch":
-			out.Arch = string(in.String())
-		case "size":
-			out.Size = int64(in.Int64())
-		case "checksum":
-			out.Checksum = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		case "verify_mode":
+			out.VerifyMode = string(in.String())
+		case "verified":
+			out.Verified = bool(in.Bool())
+		case "signed_by":
+			out.SignedBy = string(in.String())
+		case "last_sync":
+			out.LastSync = string(in.String())
+		case "last_verified":
+			out.LastVerified = string(in.String())
+		case "last_error":
+			out.LastError = string(in.String())
+		case "rejected":
+			out.Rejected = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -1065,67 +1273,77 @@ func easyjson6601e8cdDecodePlusInternalTypes10(in *jlexer.Lexer, out *PackageInf
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes10(out *jwriter.Writer, in PackageInfo) {
+func easyjson6601e8cdEncodePlusInternalTypes10(out *jwriter.Writer, in UpstreamStatus) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"name\":"
+		const prefix string = ",\"url\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Name))
+		out.String(string(in.URL))
 	}
 	{
-		const prefix string = ",\"version\":"
+		const prefix string = ",\"verify_mode\":"
 		out.RawString(prefix)
-		out.String(string(in.Version))
+		out.String(string(in.VerifyMode))
 	}
 	{
-		const prefix string = ",\"release\":"
+		const prefix string = ",\"verified\":"
 		out.RawString(prefix)
-		out.String(string(in.Release))
+		out.Bool(bool(in.Verified))
 	}
-	{
-		const prefix string = ",\"arch\":"
+	if in.SignedBy != "" {
+		const prefix string = ",\"signed_by\":"
 		out.RawString(prefix)
-		out.String(string(in.Arch))
+		out.String(string(in.SignedBy))
 	}
-	{
-		const prefix string = ",\"size\":"
+	if in.LastSync != "" {
+		const prefix string = ",\"last_sync\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.Size))
+		out.String(string(in.LastSync))
+	}
+	if in.LastVerified != "" {
+		const prefix string = ",\"last_verified\":"
+		out.RawString(prefix)
+		out.String(string(in.LastVerified))
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
 	}
 	{
-		const prefix string = ",\"checksum\":"
+		const prefix string = ",\"rejected\":"
 		out.RawString(prefix)
-		out.String(string(in.Checksum))
+		out.Int64(int64(in.Rejected))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v PackageInfo) MarshalJSON() ([]byte, error) {
+func (v UpstreamStatus) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v PackageInfo) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UpstreamStatus) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *PackageInfo) UnmarshalJSON(data []byte) error {
+func (v *UpstreamStatus) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *PackageInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UpstreamStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes10(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes11(in *jlexer.Lexer, out *PackageChecksum) {
+func easyjson6601e8cdDecodePlusInternalTypes11(in *jlexer.Lexer, out *UpstreamMetrics) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1144,14 +1362,18 @@ func easyjson6601e8cdDecodePlusInternalTypes11(in *jlexer.Lexer, out *PackageChe
 			continue
 		}
 		switch key {
-		case "status":
-			(out.Status).UnmarshalEasyJSON(in)
-		case "filename":
-			out.Filename = string(in.String())
-		case "sha256":
-			out.SHA256 = string(in.String())
 		case "repo":
 			out.Repo = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		case "served":
+			out.Served = int64(in.Int64())
+		case "bytes_served":
+			out.BytesServed = int64(in.Int64())
+		case "failures":
+			out.Failures = int64(in.Int64())
+		case "circuit":
+			out.Circuit = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1162,57 +1384,67 @@ func easyjson6601e8cdDecodePlusInternalTypes11(in *jlexer.Lexer, out *PackageChe
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes11(out *jwriter.Writer, in PackageChecksum) {
+func easyjson6601e8cdEncodePlusInternalTypes11(out *jwriter.Writer, in UpstreamMetrics) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"status\":"
+		const prefix string = ",\"repo\":"
 		out.RawString(prefix[1:])
-		(in.Status).MarshalEasyJSON(out)
+		out.String(string(in.Repo))
 	}
 	{
-		const prefix string = ",\"filename\":"
+		const prefix string = ",\"url\":"
 		out.RawString(prefix)
-		out.String(string(in.Filename))
+		out.String(string(in.URL))
 	}
 	{
-		const prefix string = ",\"sha256\":"
+		const prefix string = ",\"served\":"
 		out.RawString(prefix)
-		out.String(string(in.SHA256))
+		out.Int64(int64(in.Served))
 	}
 	{
-		const prefix string = ",\"repo\":"
+		const prefix string = ",\"bytes_served\":"
 		out.RawString(prefix)
-		out.String(string(in.Repo))
+		out.Int64(int64(in.BytesServed))
+	}
+	{
+		const prefix string = ",\"failures\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Failures))
+	}
+	{
+		const prefix string = ",\"circuit\":"
+		out.RawString(prefix)
+		out.String(string(in.Circuit))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v PackageChecksum) MarshalJSON() ([]byte, error) {
+func (v UpstreamMetrics) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes11(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v PackageChecksum) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UpstreamMetrics) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes11(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *PackageChecksum) UnmarshalJSON(data []byte) error {
+func (v *UpstreamMetrics) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes11(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *PackageChecksum) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UpstreamMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes11(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes12(in *jlexer.Lexer, out *Package) {
+func easyjson6601e8cdDecodePlusInternalTypes12(in *jlexer.Lexer, out *UpstreamHealth) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1231,16 +1463,47 @@ func easyjson6601e8cdDecodePlusInternalTypes12(in *jlexer.Lexer, out *Package) {
 			continue
 		}
 		switch key {
-		case "Name":
-			out.Name = string(in.String())
-		case "Arch":
-			out.Arch = string(in.String())
-		case "Version":
-			(out.Version).UnmarshalEasyJSON(in)
-		case "Checksum":
-			(out.Checksum).UnmarshalEasyJSON(in)
-		case "Location":
-			(out.Location).UnmarshalEasyJSON(in)
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "strategy":
+			out.Strategy = string(in.String())
+		case "healthy":
+			out.Healthy = bool(in.Bool())
+		case "mirrors":
+			if in.IsNull() {
+				in.Skip()
+				out.Mirrors = nil
+			} else {
+				in.Delim('[')
+				if out.Mirrors == nil {
+					if !in.IsDelim(']') {
+						out.Mirrors = make([]MirrorHealth, 0, 0)
+					} else {
+						out.Mirrors = []MirrorHealth{}
+					}
+				} else {
+					out.Mirrors = (out.Mirrors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 MirrorHealth
+					(v25).UnmarshalEasyJSON(in)
+					out.Mirrors = append(out.Mirrors, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sync":
+			if in.IsNull() {
+				in.Skip()
+				out.Sync = nil
+			} else {
+				if out.Sync == nil {
+					out.Sync = new(UpstreamStatus)
+				}
+				(*out.Sync).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1251,62 +1514,82 @@ func easyjson6601e8cdDecodePlusInternalTypes12(in *jlexer.Lexer, out *Package) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes12(out *jwriter.Writer, in Package) {
+func easyjson6601e8cdEncodePlusInternalTypes12(out *jwriter.Writer, in UpstreamHealth) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Name\":"
+		const prefix string = ",\"Status\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Name))
+		(in.Status).MarshalEasyJSON(out)
 	}
 	{
-		const prefix string = ",\"Arch\":"
+		const prefix string = ",\"repo\":"
 		out.RawString(prefix)
-		out.String(string(in.Arch))
+		out.String(string(in.Repo))
 	}
 	{
-		const prefix string = ",\"Version\":"
+		const prefix string = ",\"strategy\":"
 		out.RawString(prefix)
-		(in.Version).MarshalEasyJSON(out)
+		out.String(string(in.Strategy))
 	}
 	{
-		const prefix string = ",\"Checksum\":"
+		const prefix string = ",\"healthy\":"
 		out.RawString(prefix)
-		(in.Checksum).MarshalEasyJSON(out)
+		out.Bool(bool(in.Healthy))
 	}
 	{
-		const prefix string = ",\"Location\":"
+		const prefix string = ",\"mirrors\":"
 		out.RawString(prefix)
-		(in.Location).MarshalEasyJSON(out)
+		if in.Mirrors == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v26, v27 := range in.Mirrors {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				(v27).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"sync\":"
+		out.RawString(prefix)
+		if in.Sync == nil {
+			out.RawString("null")
+		} else {
+			(*in.Sync).MarshalEasyJSON(out)
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Package) MarshalJSON() ([]byte, error) {
+func (v UpstreamHealth) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes12(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Package) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UpstreamHealth) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes12(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Package) UnmarshalJSON(data []byte) error {
+func (v *UpstreamHealth) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes12(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Package) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UpstreamHealth) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes12(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes13(in *jlexer.Lexer, out *Metrics) {
+func easyjson6601e8cdDecodePlusInternalTypes13(in *jlexer.Lexer, out *UploadTokenRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1325,12 +1608,10 @@ func easyjson6601e8cdDecodePlusInternalTypes13(in *jlexer.Lexer, out *Metrics) {
 			continue
 		}
 		switch key {
-		case "requests":
-			(out.Requests).UnmarshalEasyJSON(in)
-		case "performance":
-			(out.Performance).UnmarshalEasyJSON(in)
-		case "memory":
-			(out.Memory).UnmarshalEasyJSON(in)
+		case "name":
+			out.Name = string(in.String())
+		case "ttl":
+			out.TTL = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1341,52 +1622,47 @@ func easyjson6601e8cdDecodePlusInternalTypes13(in *jlexer.Lexer, out *Metrics) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes13(out *jwriter.Writer, in Metrics) {
+func easyjson6601e8cdEncodePlusInternalTypes13(out *jwriter.Writer, in UploadTokenRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"requests\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix[1:])
-		(in.Requests).MarshalEasyJSON(out)
-	}
-	{
-		const prefix string = ",\"performance\":"
-		out.RawString(prefix)
-		(in.Performance).MarshalEasyJSON(out)
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"memory\":"
+		const prefix string = ",\"ttl\":"
 		out.RawString(prefix)
-		(in.Memory).MarshalEasyJSON(out)
+		out.String(string(in.TTL))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Metrics) MarshalJSON() ([]byte, error) {
+func (v UploadTokenRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes13(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Metrics) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UploadTokenRequest) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes13(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Metrics) UnmarshalJSON(data []byte) error {
+func (v *UploadTokenRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes13(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Metrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UploadTokenRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes13(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes14(in *jlexer.Lexer, out *Metadata) {
+func easyjson6601e8cdDecodePlusInternalTypes14(in *jlexer.Lexer, out *UploadTokenList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1405,27 +1681,25 @@ func easyjson6601e8cdDecodePlusInternalTypes14(in *jlexer.Lexer, out *Metadata)
 			continue
 		}
 		switch key {
-		case "XMLName":
-			easyjson6601e8cdDecodeEncodingXml(in, &out.XMLName)
-		case "Packages":
+		case "tokens":
 			if in.IsNull() {
 				in.Skip()
-				out.Packages = nil
+				out.Tokens = nil
 			} else {
 				in.Delim('[')
-				if out.Packages == nil {
+				if out.Tokens == nil {
 					if !in.IsDelim(']') {
-						out.Packages = make([]Package, 0, 0)
+						out.Tokens = make([]UploadToken, 0, 0)
 					} else {
-						out.Packages = []Package{}
+						out.Tokens = []UploadToken{}
 					}
 				} else {
-					out.Packages = (out.Packages)[:0]
+					out.Tokens = (out.Tokens)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v11 Package
-					(v11).UnmarshalEasyJSON(in)
-					out.Packages = append(out.Packages, v11)
+					var v28 UploadToken
+					(v28).UnmarshalEasyJSON(in)
+					out.Tokens = append(out.Tokens, v28)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1440,27 +1714,22 @@ func easyjson6601e8cdDecodePlusInternalTypes14(in *jlexer.Lexer, out *Metadata)
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes14(out *jwriter.Writer, in Metadata) {
+func easyjson6601e8cdEncodePlusInternalTypes14(out *jwriter.Writer, in UploadTokenList) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"XMLName\":"
+		const prefix string = ",\"tokens\":"
 		out.RawString(prefix[1:])
-		easyjson6601e8cdEncodeEncodingXml(out, in.XMLName)
-	}
-	{
-		const prefix string = ",\"Packages\":"
-		out.RawString(prefix)
-		if in.Packages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Tokens == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v12, v13 := range in.Packages {
-				if v12 > 0 {
+			for v29, v30 := range in.Tokens {
+				if v29 > 0 {
 					out.RawByte(',')
 				}
-				(v13).MarshalEasyJSON(out)
+				(v30).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1469,29 +1738,29 @@ func easyjson6601e8cdEncodePlusInternalTypes14(out *jwriter.Writer, in Metadata)
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Metadata) MarshalJSON() ([]byte, error) {
+func (v UploadTokenList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes14(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Metadata) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UploadTokenList) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes14(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Metadata) UnmarshalJSON(data []byte) error {
+func (v *UploadTokenList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes14(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Metadata) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UploadTokenList) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes14(l, v)
 }
-func easyjson6601e8cdDecodeEncodingXml(in *jlexer.Lexer, out *xml.Name) {
+func easyjson6601e8cdDecodePlusInternalTypes15(in *jlexer.Lexer, out *UploadToken) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1510,10 +1779,22 @@ func easyjson6601e8cdDecodeEncodingXml(in *jlexer.Lexer, out *xml.Name) {
 			continue
 		}
 		switch key {
-		case "Space":
-			out.Space = string(in.String())
-		case "Local":
-			out.Local = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "token":
+			out.Token = string(in.String())
+		case "hash":
+			out.Hash = string(in.String())
+		case "created_by":
+			out.CreatedBy = string(in.String())
+		case "created":
+			out.Created = string(in.String())
+		case "expires":
+			out.Expires = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1524,110 +1805,77 @@ func easyjson6601e8cdDecodeEncodingXml(in *jlexer.Lexer, out *xml.Name) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeEncodingXml(out *jwriter.Writer, in xml.Name) {
+func easyjson6601e8cdEncodePlusInternalTypes15(out *jwriter.Writer, in UploadToken) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Space\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Space))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"Local\":"
+		const prefix string = ",\"repo\":"
 		out.RawString(prefix)
-		out.String(string(in.Local))
-	}
-	out.RawByte('}')
-}
-func easyjson6601e8cdDecodePlusInternalTypes15(in *jlexer.Lexer, out *Memory) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
+		out.String(string(in.Repo))
 	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "alloc_mb":
-			out.AllocMB = uint64(in.Uint64())
-		case "total_alloc_mb":
-			out.TotalAllocMB = uint64(in.Uint64())
-		case "sys_mb":
-			out.SysMB = uint64(in.Uint64())
-		case "gc_cycles":
-			out.GCCycles = uint32(in.Uint32())
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
 	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
+	if in.Token != "" {
+		const prefix string = ",\"token\":"
+		out.RawString(prefix)
+		out.String(string(in.Token))
 	}
-}
-func easyjson6601e8cdEncodePlusInternalTypes15(out *jwriter.Writer, in Memory) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"alloc_mb\":"
-		out.RawString(prefix[1:])
-		out.Uint64(uint64(in.AllocMB))
+	if in.Hash != "" {
+		const prefix string = ",\"hash\":"
+		out.RawString(prefix)
+		out.String(string(in.Hash))
 	}
 	{
-		const prefix string = ",\"total_alloc_mb\":"
+		const prefix string = ",\"created_by\":"
 		out.RawString(prefix)
-		out.Uint64(uint64(in.TotalAllocMB))
+		out.String(string(in.CreatedBy))
 	}
 	{
-		const prefix string = ",\"sys_mb\":"
+		const prefix string = ",\"created\":"
 		out.RawString(prefix)
-		out.Uint64(uint64(in.SysMB))
+		out.String(string(in.Created))
 	}
 	{
-		const prefix string = ",\"gc_cycles\":"
+		const prefix string = ",\"expires\":"
 		out.RawString(prefix)
-		out.Uint32(uint32(in.GCCycles))
+		out.String(string(in.Expires))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Memory) MarshalJSON() ([]byte, error) {
+func (v UploadToken) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes15(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Memory) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UploadToken) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes15(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Memory) UnmarshalJSON(data []byte) error {
+func (v *UploadToken) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes15(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Memory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UploadToken) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes15(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes16(in *jlexer.Lexer, out *Location) {
+func easyjson6601e8cdDecodePlusInternalTypes16(in *jlexer.Lexer, out *UploadResult) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1646,8 +1894,20 @@ func easyjson6601e8cdDecodePlusInternalTypes16(in *jlexer.Lexer, out *Location)
 			continue
 		}
 		switch key {
-		case "Href":
-			out.Href = string(in.String())
+		case "server":
+			out.Server = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "code":
+			out.Code = int(in.Int())
+		case "filename":
+			out.Filename = string(in.String())
+		case "renamed":
+			out.Renamed = bool(in.Bool())
+		case "expires_at":
+			out.ExpiresAt = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1658,42 +1918,72 @@ func easyjson6601e8cdDecodePlusInternalTypes16(in *jlexer.Lexer, out *Location)
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes16(out *jwriter.Writer, in Location) {
+func easyjson6601e8cdEncodePlusInternalTypes16(out *jwriter.Writer, in UploadResult) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Href\":"
+		const prefix string = ",\"server\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Href))
+		out.String(string(in.Server))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.Int(int(in.Code))
+	}
+	{
+		const prefix string = ",\"filename\":"
+		out.RawString(prefix)
+		out.String(string(in.Filename))
+	}
+	{
+		const prefix string = ",\"renamed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Renamed))
+	}
+	if in.ExpiresAt != "" {
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpiresAt))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Location) MarshalJSON() ([]byte, error) {
+func (v UploadResult) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes16(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Location) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UploadResult) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes16(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Location) UnmarshalJSON(data []byte) error {
+func (v *UploadResult) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes16(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Location) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UploadResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes16(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes17(in *jlexer.Lexer, out *Checksum) {
+func easyjson6601e8cdDecodePlusInternalTypes17(in *jlexer.Lexer, out *UploadProgress) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1712,12 +2002,24 @@ func easyjson6601e8cdDecodePlusInternalTypes17(in *jlexer.Lexer, out *Checksum)
 			continue
 		}
 		switch key {
-		case "Type":
-			out.Type = string(in.String())
-		case "Pkgid":
-			out.Pkgid = string(in.String())
-		case "Value":
-			out.Value = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "state":
+			out.State = string(in.String())
+		case "bytes_received":
+			out.Received = int64(in.Int64())
+		case "bytes_total":
+			out.Total = int64(in.Int64())
+		case "rate":
+			out.Rate = int64(in.Int64())
+		case "eta_seconds":
+			out.ETASeconds = int64(in.Int64())
+		case "started":
+			out.Started = string(in.String())
+		case "finished":
+			out.Finished = string(in.String())
+		case "code":
+			out.Code = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -1728,52 +2030,82 @@ func easyjson6601e8cdDecodePlusInternalTypes17(in *jlexer.Lexer, out *Checksum)
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes17(out *jwriter.Writer, in Checksum) {
+func easyjson6601e8cdEncodePlusInternalTypes17(out *jwriter.Writer, in UploadProgress) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Type\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"Pkgid\":"
+		const prefix string = ",\"state\":"
 		out.RawString(prefix)
-		out.String(string(in.Pkgid))
+		out.String(string(in.State))
 	}
 	{
-		const prefix string = ",\"Value\":"
+		const prefix string = ",\"bytes_received\":"
 		out.RawString(prefix)
-		out.String(string(in.Value))
+		out.Int64(int64(in.Received))
+	}
+	if in.Total != 0 {
+		const prefix string = ",\"bytes_total\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Total))
+	}
+	{
+		const prefix string = ",\"rate\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Rate))
+	}
+	if in.ETASeconds != 0 {
+		const prefix string = ",\"eta_seconds\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ETASeconds))
+	}
+	{
+		const prefix string = ",\"started\":"
+		out.RawString(prefix)
+		out.String(string(in.Started))
+	}
+	if in.Finished != "" {
+		const prefix string = ",\"finished\":"
+		out.RawString(prefix)
+		out.String(string(in.Finished))
+	}
+	if in.Code != 0 {
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.Int(int(in.Code))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Checksum) MarshalJSON() ([]byte, error) {
+func (v UploadProgress) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Checksum) MarshalEasyJSON(w *jwriter.Writer) {
+func (v UploadProgress) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Checksum) UnmarshalJSON(data []byte) error {
+func (v *UploadProgress) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Checksum) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *UploadProgress) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes17(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes18(in *jlexer.Lexer, out *Checks) {
+func easyjson6601e8cdDecodePlusInternalTypes18(in *jlexer.Lexer, out *TreeNode) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1792,8 +2124,42 @@ func easyjson6601e8cdDecodePlusInternalTypes18(in *jlexer.Lexer, out *Checks) {
 			continue
 		}
 		switch key {
-		case "Storage":
-			out.Storage = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "repoType":
+			out.RepoType = string(in.String())
+		case "maintenance":
+			out.Maintenance = bool(in.Bool())
+		case "children":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Children = make(map[string]*TreeNode)
+				} else {
+					out.Children = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v31 *TreeNode
+					if in.IsNull() {
+						in.Skip()
+						v31 = nil
+					} else {
+						if v31 == nil {
+							v31 = new(TreeNode)
+						}
+						(*v31).UnmarshalEasyJSON(in)
+					}
+					(out.Children)[key] = v31
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1804,42 +2170,80 @@ func easyjson6601e8cdDecodePlusInternalTypes18(in *jlexer.Lexer, out *Checks) {
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes18(out *jwriter.Writer, in Checks) {
+func easyjson6601e8cdEncodePlusInternalTypes18(out *jwriter.Writer, in TreeNode) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"Storage\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Storage))
+		out.String(string(in.Type))
+	}
+	if in.Path != "" {
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	if in.RepoType != "" {
+		const prefix string = ",\"repoType\":"
+		out.RawString(prefix)
+		out.String(string(in.RepoType))
+	}
+	if in.Maintenance {
+		const prefix string = ",\"maintenance\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Maintenance))
+	}
+	if len(in.Children) != 0 {
+		const prefix string = ",\"children\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v32First := true
+			for v32Name, v32Value := range in.Children {
+				if v32First {
+					v32First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v32Name))
+				out.RawByte(':')
+				if v32Value == nil {
+					out.RawString("null")
+				} else {
+					(*v32Value).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte('}')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Checks) MarshalJSON() ([]byte, error) {
+func (v TreeNode) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes18(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Checks) MarshalEasyJSON(w *jwriter.Writer) {
+func (v TreeNode) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes18(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Checks) UnmarshalJSON(data []byte) error {
+func (v *TreeNode) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes18(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Checks) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *TreeNode) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes18(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes19(in *jlexer.Lexer, out *BatchUploadResult) {
+func easyjson6601e8cdDecodePlusInternalTypes19(in *jlexer.Lexer, out *TreeImage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1858,12 +2262,18 @@ func easyjson6601e8cdDecodePlusInternalTypes19(in *jlexer.Lexer, out *BatchUploa
 			continue
 		}
 		switch key {
-		case "filename":
-			out.Filename = string(in.String())
-		case "status":
-			out.Status = string(in.String())
-		case "error":
-			out.Error = string(in.String())
+		case "platform":
+			out.Platform = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "exists":
+			out.Exists = bool(in.Bool())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "checksum":
+			out.Checksum = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1874,52 +2284,67 @@ func easyjson6601e8cdDecodePlusInternalTypes19(in *jlexer.Lexer, out *BatchUploa
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes19(out *jwriter.Writer, in BatchUploadResult) {
+func easyjson6601e8cdEncodePlusInternalTypes19(out *jwriter.Writer, in TreeImage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"filename\":"
+		const prefix string = ",\"platform\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Filename))
+		out.String(string(in.Platform))
 	}
 	{
-		const prefix string = ",\"status\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix)
-		out.String(string(in.Status))
+		out.String(string(in.Type))
 	}
-	if in.Error != "" {
-		const prefix string = ",\"error\":"
+	{
+		const prefix string = ",\"path\":"
 		out.RawString(prefix)
-		out.String(string(in.Error))
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"exists\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Exists))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"checksum\":"
+		out.RawString(prefix)
+		out.String(string(in.Checksum))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BatchUploadResult) MarshalJSON() ([]byte, error) {
+func (v TreeImage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes19(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BatchUploadResult) MarshalEasyJSON(w *jwriter.Writer) {
+func (v TreeImage) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes19(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BatchUploadResult) UnmarshalJSON(data []byte) error {
+func (v *TreeImage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes19(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BatchUploadResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *TreeImage) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes19(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes20(in *jlexer.Lexer, out *BatchUploadResponse) {
+func easyjson6601e8cdDecodePlusInternalTypes20(in *jlexer.Lexer, out *TokenRecord) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1938,37 +2363,16 @@ func easyjson6601e8cdDecodePlusInternalTypes20(in *jlexer.Lexer, out *BatchUploa
 			continue
 		}
 		switch key {
-		case "status":
-			out.Status = string(in.String())
-		case "total":
-			out.Total = int(in.Int())
-		case "success":
-			out.Success = int(in.Int())
-		case "failed":
-			out.Failed = int(in.Int())
-		case "results":
-			if in.IsNull() {
-				in.Skip()
-				out.Results = nil
-			} else {
-				in.Delim('[')
-				if out.Results == nil {
-					if !in.IsDelim(']') {
-						out.Results = make([]BatchUploadResult, 0, 1)
-					} else {
-						out.Results = []BatchUploadResult{}
-					}
-				} else {
-					out.Results = (out.Results)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v14 BatchUploadResult
-					(v14).UnmarshalEasyJSON(in)
-					out.Results = append(out.Results, v14)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
+		case "id":
+			out.ID = string(in.String())
+		case "user":
+			out.User = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "hash":
+			out.Hash = string(in.String())
+		case "created":
+			out.Created = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1979,73 +2383,62 @@ func easyjson6601e8cdDecodePlusInternalTypes20(in *jlexer.Lexer, out *BatchUploa
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes20(out *jwriter.Writer, in BatchUploadResponse) {
+func easyjson6601e8cdEncodePlusInternalTypes20(out *jwriter.Writer, in TokenRecord) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"status\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Status))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"total\":"
+		const prefix string = ",\"user\":"
 		out.RawString(prefix)
-		out.Int(int(in.Total))
+		out.String(string(in.User))
 	}
-	{
-		const prefix string = ",\"success\":"
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
 		out.RawString(prefix)
-		out.Int(int(in.Success))
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"failed\":"
+		const prefix string = ",\"hash\":"
 		out.RawString(prefix)
-		out.Int(int(in.Failed))
+		out.String(string(in.Hash))
 	}
 	{
-		const prefix string = ",\"results\":"
+		const prefix string = ",\"created\":"
 		out.RawString(prefix)
-		if in.Results == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v15, v16 := range in.Results {
-				if v15 > 0 {
-					out.RawByte(',')
-				}
-				(v16).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.Created))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BatchUploadResponse) MarshalJSON() ([]byte, error) {
+func (v TokenRecord) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes20(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BatchUploadResponse) MarshalEasyJSON(w *jwriter.Writer) {
+func (v TokenRecord) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes20(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BatchUploadResponse) UnmarshalJSON(data []byte) error {
+func (v *TokenRecord) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes20(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BatchUploadResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *TokenRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes20(l, v)
 }
-func easyjson6601e8cdDecodePlusInternalTypes21(in *jlexer.Lexer, out *BatchUploadRequest) {
+func easyjson6601e8cdDecodePlusInternalTypes21(in *jlexer.Lexer, out *TeamsMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2064,10 +2457,16 @@ func easyjson6601e8cdDecodePlusInternalTypes21(in *jlexer.Lexer, out *BatchUploa
 			continue
 		}
 		switch key {
-		case "repository":
-			out.Repository = string(in.String())
-		case "auto_refresh":
-			out.AutoRefresh = bool(in.Bool())
+		case "@type":
+			out.Type = string(in.String())
+		case "@context":
+			out.Context = string(in.String())
+		case "summary":
+			out.Summary = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "text":
+			out.Text = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2078,43 +2477,20713 @@ func easyjson6601e8cdDecodePlusInternalTypes21(in *jlexer.Lexer, out *BatchUploa
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodePlusInternalTypes21(out *jwriter.Writer, in BatchUploadRequest) {
+func easyjson6601e8cdEncodePlusInternalTypes21(out *jwriter.Writer, in TeamsMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"repository\":"
+		const prefix string = ",\"@type\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Repository))
+		out.String(string(in.Type))
 	}
 	{
-		const prefix string = ",\"auto_refresh\":"
+		const prefix string = ",\"@context\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.AutoRefresh))
+		out.String(string(in.Context))
+	}
+	{
+		const prefix string = ",\"summary\":"
+		out.RawString(prefix)
+		out.String(string(in.Summary))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix)
+		out.String(string(in.Text))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BatchUploadRequest) MarshalJSON() ([]byte, error) {
+func (v TeamsMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson6601e8cdEncodePlusInternalTypes21(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BatchUploadRequest) MarshalEasyJSON(w *jwriter.Writer) {
+func (v TeamsMessage) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson6601e8cdEncodePlusInternalTypes21(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BatchUploadRequest) UnmarshalJSON(data []byte) error {
+func (v *TeamsMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson6601e8cdDecodePlusInternalTypes21(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BatchUploadRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *TeamsMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodePlusInternalTypes21(l, v)
 }
+func easyjson6601e8cdDecodePlusInternalTypes22(in *jlexer.Lexer, out *StorageSyncPass) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "repos":
+			if in.IsNull() {
+				in.Skip()
+				out.Repos = nil
+			} else {
+				in.Delim('[')
+				if out.Repos == nil {
+					if !in.IsDelim(']') {
+						out.Repos = make([]string, 0, 4)
+					} else {
+						out.Repos = []string{}
+					}
+				} else {
+					out.Repos = (out.Repos)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v33 string
+					v33 = string(in.String())
+					out.Repos = append(out.Repos, v33)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "objects":
+			out.Objects = int(in.Int())
+		case "copied":
+			out.Copied = int(in.Int())
+		case "existing":
+			out.Existing = int(in.Int())
+		case "deleted":
+			out.Deleted = int(in.Int())
+		case "failed":
+			out.Failed = int(in.Int())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]string, 0, 4)
+					} else {
+						out.Errors = []string{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v34 string
+					v34 = string(in.String())
+					out.Errors = append(out.Errors, v34)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes22(out *jwriter.Writer, in StorageSyncPass) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"repos\":"
+		out.RawString(prefix[1:])
+		if in.Repos == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v35, v36 := range in.Repos {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v36))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"objects\":"
+		out.RawString(prefix)
+		out.Int(int(in.Objects))
+	}
+	{
+		const prefix string = ",\"copied\":"
+		out.RawString(prefix)
+		out.Int(int(in.Copied))
+	}
+	{
+		const prefix string = ",\"existing\":"
+		out.RawString(prefix)
+		out.Int(int(in.Existing))
+	}
+	{
+		const prefix string = ",\"deleted\":"
+		out.RawString(prefix)
+		out.Int(int(in.Deleted))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int(int(in.Failed))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	if len(in.Errors) != 0 {
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v37, v38 := range in.Errors {
+				if v37 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v38))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StorageSyncPass) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes22(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StorageSyncPass) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes22(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StorageSyncPass) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes22(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StorageSyncPass) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes22(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes23(in *jlexer.Lexer, out *StorageMigrationReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "label":
+			out.Label = string(in.String())
+		case "from":
+			out.From = string(in.String())
+		case "to":
+			out.To = string(in.String())
+		case "dry_run":
+			out.DryRun = bool(in.Bool())
+		case "passes":
+			if in.IsNull() {
+				in.Skip()
+				out.Passes = nil
+			} else {
+				in.Delim('[')
+				if out.Passes == nil {
+					if !in.IsDelim(']') {
+						out.Passes = make([]StorageSyncPass, 0, 0)
+					} else {
+						out.Passes = []StorageSyncPass{}
+					}
+				} else {
+					out.Passes = (out.Passes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v39 StorageSyncPass
+					(v39).UnmarshalEasyJSON(in)
+					out.Passes = append(out.Passes, v39)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "switched":
+			out.Switched = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes23(out *jwriter.Writer, in StorageMigrationReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"label\":"
+		out.RawString(prefix)
+		out.String(string(in.Label))
+	}
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		out.String(string(in.From))
+	}
+	{
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.String(string(in.To))
+	}
+	{
+		const prefix string = ",\"dry_run\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.DryRun))
+	}
+	{
+		const prefix string = ",\"passes\":"
+		out.RawString(prefix)
+		if in.Passes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v40, v41 := range in.Passes {
+				if v40 > 0 {
+					out.RawByte(',')
+				}
+				(v41).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"switched\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Switched))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StorageMigrationReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StorageMigrationReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StorageMigrationReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StorageMigrationReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes23(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes24(in *jlexer.Lexer, out *Status) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "server":
+			out.Server = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "code":
+			out.Code = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes24(out *jwriter.Writer, in Status) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"server\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Server))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.Int(int(in.Code))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Status) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Status) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Status) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Status) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes24(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes25(in *jlexer.Lexer, out *StagingList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "changeset":
+			out.Changeset = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]DirectoryEntry, 0, 0)
+					} else {
+						out.Files = []DirectoryEntry{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v42 DirectoryEntry
+					(v42).UnmarshalEasyJSON(in)
+					out.Files = append(out.Files, v42)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes25(out *jwriter.Writer, in StagingList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"changeset\":"
+		out.RawString(prefix)
+		out.String(string(in.Changeset))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		if in.Files == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v43, v44 := range in.Files {
+				if v43 > 0 {
+					out.RawByte(',')
+				}
+				(v44).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StagingList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StagingList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StagingList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StagingList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes25(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes26(in *jlexer.Lexer, out *SignatureResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "signature":
+			(out.Signature).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes26(out *jwriter.Writer, in SignatureResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"signature\":"
+		out.RawString(prefix)
+		(in.Signature).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SignatureResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SignatureResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes26(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SignatureResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SignatureResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes26(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes27(in *jlexer.Lexer, out *SignatureList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "signatures":
+			if in.IsNull() {
+				in.Skip()
+				out.Signatures = nil
+			} else {
+				in.Delim('[')
+				if out.Signatures == nil {
+					if !in.IsDelim(']') {
+						out.Signatures = make([]Signature, 0, 0)
+					} else {
+						out.Signatures = []Signature{}
+					}
+				} else {
+					out.Signatures = (out.Signatures)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v45 Signature
+					(v45).UnmarshalEasyJSON(in)
+					out.Signatures = append(out.Signatures, v45)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes27(out *jwriter.Writer, in SignatureList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"signatures\":"
+		out.RawString(prefix)
+		if in.Signatures == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v46, v47 := range in.Signatures {
+				if v46 > 0 {
+					out.RawByte(',')
+				}
+				(v47).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SignatureList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SignatureList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SignatureList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SignatureList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes27(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes28(in *jlexer.Lexer, out *Signature) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "signature":
+			out.Signature = string(in.String())
+		case "certificate":
+			out.Certificate = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		case "verified":
+			out.Verified = bool(in.Bool())
+		case "key":
+			out.Key = string(in.String())
+		case "by":
+			out.By = string(in.String())
+		case "created":
+			out.Created = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes28(out *jwriter.Writer, in Signature) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"signature\":"
+		out.RawString(prefix)
+		out.String(string(in.Signature))
+	}
+	if in.Certificate != "" {
+		const prefix string = ",\"certificate\":"
+		out.RawString(prefix)
+		out.String(string(in.Certificate))
+	}
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"verified\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Verified))
+	}
+	if in.Key != "" {
+		const prefix string = ",\"key\":"
+		out.RawString(prefix)
+		out.String(string(in.Key))
+	}
+	if in.By != "" {
+		const prefix string = ",\"by\":"
+		out.RawString(prefix)
+		out.String(string(in.By))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	if in.URL != "" {
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Signature) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Signature) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Signature) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Signature) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes28(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes29(in *jlexer.Lexer, out *SessionList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "sessions":
+			if in.IsNull() {
+				in.Skip()
+				out.Sessions = nil
+			} else {
+				in.Delim('[')
+				if out.Sessions == nil {
+					if !in.IsDelim(']') {
+						out.Sessions = make([]Session, 0, 0)
+					} else {
+						out.Sessions = []Session{}
+					}
+				} else {
+					out.Sessions = (out.Sessions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v48 Session
+					(v48).UnmarshalEasyJSON(in)
+					out.Sessions = append(out.Sessions, v48)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes29(out *jwriter.Writer, in SessionList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"sessions\":"
+		out.RawString(prefix[1:])
+		if in.Sessions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v49, v50 := range in.Sessions {
+				if v49 > 0 {
+					out.RawByte(',')
+				}
+				(v50).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SessionList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes29(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SessionList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes29(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SessionList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes29(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SessionList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes29(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes30(in *jlexer.Lexer, out *SessionClaims) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "sub":
+			out.Subject = string(in.String())
+		case "jti":
+			out.ID = string(in.String())
+		case "iat":
+			out.IssuedAt = int64(in.Int64())
+		case "exp":
+			out.Expires = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes30(out *jwriter.Writer, in SessionClaims) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"sub\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Subject))
+	}
+	{
+		const prefix string = ",\"jti\":"
+		out.RawString(prefix)
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"iat\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.IssuedAt))
+	}
+	{
+		const prefix string = ",\"exp\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Expires))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SessionClaims) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes30(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SessionClaims) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes30(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SessionClaims) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes30(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SessionClaims) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes30(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes31(in *jlexer.Lexer, out *Session) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "user":
+			out.User = string(in.String())
+		case "token":
+			out.Token = string(in.String())
+		case "token_id":
+			out.TokenID = string(in.String())
+		case "address":
+			out.Address = string(in.String())
+		case "user_agent":
+			out.UserAgent = string(in.String())
+		case "created":
+			out.Created = string(in.String())
+		case "expires":
+			out.Expires = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes31(out *jwriter.Writer, in Session) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.String(string(in.User))
+	}
+	if in.Token != "" {
+		const prefix string = ",\"token\":"
+		out.RawString(prefix)
+		out.String(string(in.Token))
+	}
+	if in.TokenID != "" {
+		const prefix string = ",\"token_id\":"
+		out.RawString(prefix)
+		out.String(string(in.TokenID))
+	}
+	if in.Address != "" {
+		const prefix string = ",\"address\":"
+		out.RawString(prefix)
+		out.String(string(in.Address))
+	}
+	if in.UserAgent != "" {
+		const prefix string = ",\"user_agent\":"
+		out.RawString(prefix)
+		out.String(string(in.UserAgent))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	{
+		const prefix string = ",\"expires\":"
+		out.RawString(prefix)
+		out.String(string(in.Expires))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Session) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes31(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Session) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes31(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Session) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes31(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Session) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes31(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes32(in *jlexer.Lexer, out *SearchResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "query":
+			out.Query = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		case "truncated":
+			out.Truncated = bool(in.Bool())
+		case "indexed":
+			out.Indexed = bool(in.Bool())
+		case "artifacts":
+			if in.IsNull() {
+				in.Skip()
+				out.Artifacts = nil
+			} else {
+				in.Delim('[')
+				if out.Artifacts == nil {
+					if !in.IsDelim(']') {
+						out.Artifacts = make([]Artifact, 0, 0)
+					} else {
+						out.Artifacts = []Artifact{}
+					}
+				} else {
+					out.Artifacts = (out.Artifacts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v51 Artifact
+					(v51).UnmarshalEasyJSON(in)
+					out.Artifacts = append(out.Artifacts, v51)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "facets":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Facets = make(map[string][]FacetValue)
+				} else {
+					out.Facets = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v52 []FacetValue
+					if in.IsNull() {
+						in.Skip()
+						v52 = nil
+					} else {
+						in.Delim('[')
+						if v52 == nil {
+							if !in.IsDelim(']') {
+								v52 = make([]FacetValue, 0, 2)
+							} else {
+								v52 = []FacetValue{}
+							}
+						} else {
+							v52 = (v52)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v53 FacetValue
+							(v53).UnmarshalEasyJSON(in)
+							v52 = append(v52, v53)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Facets)[key] = v52
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes32(out *jwriter.Writer, in SearchResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"query\":"
+		out.RawString(prefix)
+		out.String(string(in.Query))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"truncated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Truncated))
+	}
+	{
+		const prefix string = ",\"indexed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Indexed))
+	}
+	{
+		const prefix string = ",\"artifacts\":"
+		out.RawString(prefix)
+		if in.Artifacts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v54, v55 := range in.Artifacts {
+				if v54 > 0 {
+					out.RawByte(',')
+				}
+				(v55).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Facets) != 0 {
+		const prefix string = ",\"facets\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v56First := true
+			for v56Name, v56Value := range in.Facets {
+				if v56First {
+					v56First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v56Name))
+				out.RawByte(':')
+				if v56Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v57, v58 := range v56Value {
+						if v57 > 0 {
+							out.RawByte(',')
+						}
+						(v58).MarshalEasyJSON(out)
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SearchResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes32(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SearchResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes32(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SearchResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes32(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SearchResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes32(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes33(in *jlexer.Lexer, out *RouteLatency) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "class":
+			out.Class = string(in.String())
+		case "code":
+			out.Code = int(in.Int())
+		case "count":
+			out.Count = uint64(in.Uint64())
+		case "avg_ms":
+			out.AvgMs = float64(in.Float64())
+		case "p50_ms":
+			out.P50Ms = float64(in.Float64())
+		case "p95_ms":
+			out.P95Ms = float64(in.Float64())
+		case "p99_ms":
+			out.P99Ms = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes33(out *jwriter.Writer, in RouteLatency) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"class\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Class))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.Int(int(in.Code))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Count))
+	}
+	{
+		const prefix string = ",\"avg_ms\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.AvgMs))
+	}
+	{
+		const prefix string = ",\"p50_ms\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.P50Ms))
+	}
+	{
+		const prefix string = ",\"p95_ms\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.P95Ms))
+	}
+	{
+		const prefix string = ",\"p99_ms\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.P99Ms))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RouteLatency) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes33(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RouteLatency) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes33(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RouteLatency) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes33(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RouteLatency) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes33(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes34(in *jlexer.Lexer, out *RoleList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]Role, 0, 0)
+					} else {
+						out.Roles = []Role{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v59 Role
+					(v59).UnmarshalEasyJSON(in)
+					out.Roles = append(out.Roles, v59)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes34(out *jwriter.Writer, in RoleList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix[1:])
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v60, v61 := range in.Roles {
+				if v60 > 0 {
+					out.RawByte(',')
+				}
+				(v61).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RoleList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes34(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RoleList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes34(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RoleList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes34(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RoleList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes34(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes35(in *jlexer.Lexer, out *Role) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "admin":
+			out.Admin = bool(in.Bool())
+		case "permissions":
+			if in.IsNull() {
+				in.Skip()
+				out.Permissions = nil
+			} else {
+				in.Delim('[')
+				if out.Permissions == nil {
+					if !in.IsDelim(']') {
+						out.Permissions = make([]Permission, 0, 1)
+					} else {
+						out.Permissions = []Permission{}
+					}
+				} else {
+					out.Permissions = (out.Permissions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v62 Permission
+					(v62).UnmarshalEasyJSON(in)
+					out.Permissions = append(out.Permissions, v62)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "builtin":
+			out.Builtin = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes35(out *jwriter.Writer, in Role) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	if in.Admin {
+		const prefix string = ",\"admin\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Admin))
+	}
+	{
+		const prefix string = ",\"permissions\":"
+		out.RawString(prefix)
+		if in.Permissions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v63, v64 := range in.Permissions {
+				if v63 > 0 {
+					out.RawByte(',')
+				}
+				(v64).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Builtin {
+		const prefix string = ",\"builtin\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Builtin))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Role) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes35(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Role) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes35(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Role) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes35(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Role) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes35(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes36(in *jlexer.Lexer, out *RevokedSession) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "expires":
+			out.Expires = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes36(out *jwriter.Writer, in RevokedSession) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"expires\":"
+		out.RawString(prefix)
+		out.String(string(in.Expires))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RevokedSession) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes36(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RevokedSession) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes36(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RevokedSession) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes36(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RevokedSession) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes36(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes37(in *jlexer.Lexer, out *ReverseDependencies) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "safe":
+			out.Safe = bool(in.Bool())
+		case "broken":
+			if in.IsNull() {
+				in.Skip()
+				out.Broken = nil
+			} else {
+				in.Delim('[')
+				if out.Broken == nil {
+					if !in.IsDelim(']') {
+						out.Broken = make([]DependentInfo, 0, 0)
+					} else {
+						out.Broken = []DependentInfo{}
+					}
+				} else {
+					out.Broken = (out.Broken)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v65 DependentInfo
+					(v65).UnmarshalEasyJSON(in)
+					out.Broken = append(out.Broken, v65)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "count":
+			out.Count = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes37(out *jwriter.Writer, in ReverseDependencies) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"safe\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Safe))
+	}
+	{
+		const prefix string = ",\"broken\":"
+		out.RawString(prefix)
+		if in.Broken == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v66, v67 := range in.Broken {
+				if v66 > 0 {
+					out.RawByte(',')
+				}
+				(v67).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReverseDependencies) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes37(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReverseDependencies) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes37(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReverseDependencies) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes37(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReverseDependencies) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes37(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes38(in *jlexer.Lexer, out *RequirementInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "requirement":
+			out.Requirement = string(in.String())
+		case "providers":
+			if in.IsNull() {
+				in.Skip()
+				out.Providers = nil
+			} else {
+				in.Delim('[')
+				if out.Providers == nil {
+					if !in.IsDelim(']') {
+						out.Providers = make([]string, 0, 4)
+					} else {
+						out.Providers = []string{}
+					}
+				} else {
+					out.Providers = (out.Providers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v68 string
+					v68 = string(in.String())
+					out.Providers = append(out.Providers, v68)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "satisfied":
+			out.Satisfied = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes38(out *jwriter.Writer, in RequirementInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"requirement\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Requirement))
+	}
+	{
+		const prefix string = ",\"providers\":"
+		out.RawString(prefix)
+		if in.Providers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v69, v70 := range in.Providers {
+				if v69 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v70))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"satisfied\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Satisfied))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RequirementInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes38(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RequirementInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes38(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RequirementInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes38(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RequirementInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes38(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes39(in *jlexer.Lexer, out *Requests) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "total":
+			out.Total = int64(in.Int64())
+		case "uploads":
+			out.Uploads = int64(in.Int64())
+		case "downloads":
+			out.Downloads = int64(in.Int64())
+		case "redirects":
+			out.Redirects = int64(in.Int64())
+		case "errors":
+			out.Errors = int64(in.Int64())
+		case "active":
+			out.Active = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes39(out *jwriter.Writer, in Requests) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Total))
+	}
+	{
+		const prefix string = ",\"uploads\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Uploads))
+	}
+	{
+		const prefix string = ",\"downloads\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Downloads))
+	}
+	{
+		const prefix string = ",\"redirects\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Redirects))
+	}
+	{
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Errors))
+	}
+	{
+		const prefix string = ",\"active\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Active))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Requests) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes39(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Requests) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes39(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Requests) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes39(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Requests) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes39(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes40(in *jlexer.Lexer, out *RepoTable) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes40(out *jwriter.Writer, in RepoTable) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoTable) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes40(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoTable) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes40(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoTable) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes40(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoTable) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes40(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes41(in *jlexer.Lexer, out *RepoStatus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes41(out *jwriter.Writer, in RepoStatus) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoStatus) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes41(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoStatus) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes41(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoStatus) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes41(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes41(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes42(in *jlexer.Lexer, out *RepoReadme) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		case "updated":
+			out.Updated = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes42(out *jwriter.Writer, in RepoReadme) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	if in.Author != "" {
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"updated\":"
+		out.RawString(prefix)
+		out.String(string(in.Updated))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoReadme) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes42(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoReadme) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes42(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoReadme) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes42(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoReadme) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes42(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes43(in *jlexer.Lexer, out *RepoNotes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "notes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Notes = make(map[string]PackageNote)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v71 PackageNote
+					(v71).UnmarshalEasyJSON(in)
+					(out.Notes)[key] = v71
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes43(out *jwriter.Writer, in RepoNotes) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"notes\":"
+		out.RawString(prefix[1:])
+		if in.Notes == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v72First := true
+			for v72Name, v72Value := range in.Notes {
+				if v72First {
+					v72First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v72Name))
+				out.RawByte(':')
+				(v72Value).MarshalEasyJSON(out)
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoNotes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes43(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoNotes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes43(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoNotes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes43(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoNotes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes43(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes44(in *jlexer.Lexer, out *RepoMeta) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]string, 0, 4)
+					} else {
+						out.Repositories = []string{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v73 string
+					v73 = string(in.String())
+					out.Repositories = append(out.Repositories, v73)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tree":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Tree = make(map[string]*TreeNode)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v74 *TreeNode
+					if in.IsNull() {
+						in.Skip()
+						v74 = nil
+					} else {
+						if v74 == nil {
+							v74 = new(TreeNode)
+						}
+						(*v74).UnmarshalEasyJSON(in)
+					}
+					(out.Tree)[key] = v74
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "count":
+			out.Count = int(in.Int())
+		case "maintenance":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Maintenance = make(map[string]*Maintenance)
+				} else {
+					out.Maintenance = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v75 *Maintenance
+					if in.IsNull() {
+						in.Skip()
+						v75 = nil
+					} else {
+						if v75 == nil {
+							v75 = new(Maintenance)
+						}
+						(*v75).UnmarshalEasyJSON(in)
+					}
+					(out.Maintenance)[key] = v75
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes44(out *jwriter.Writer, in RepoMeta) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix)
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v76, v77 := range in.Repositories {
+				if v76 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v77))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"tree\":"
+		out.RawString(prefix)
+		if in.Tree == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v78First := true
+			for v78Name, v78Value := range in.Tree {
+				if v78First {
+					v78First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v78Name))
+				out.RawByte(':')
+				if v78Value == nil {
+					out.RawString("null")
+				} else {
+					(*v78Value).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	if len(in.Maintenance) != 0 {
+		const prefix string = ",\"maintenance\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v79First := true
+			for v79Name, v79Value := range in.Maintenance {
+				if v79First {
+					v79First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v79Name))
+				out.RawByte(':')
+				if v79Value == nil {
+					out.RawString("null")
+				} else {
+					(*v79Value).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoMeta) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes44(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoMeta) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes44(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoMeta) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes44(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoMeta) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes44(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes45(in *jlexer.Lexer, out *RepoLegalHolds) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "holds":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Holds = make(map[string]LegalHold)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v80 LegalHold
+					(v80).UnmarshalEasyJSON(in)
+					(out.Holds)[key] = v80
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes45(out *jwriter.Writer, in RepoLegalHolds) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"holds\":"
+		out.RawString(prefix[1:])
+		if in.Holds == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v81First := true
+			for v81Name, v81Value := range in.Holds {
+				if v81First {
+					v81First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v81Name))
+				out.RawByte(':')
+				(v81Value).MarshalEasyJSON(out)
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoLegalHolds) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes45(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoLegalHolds) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes45(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoLegalHolds) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes45(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoLegalHolds) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes45(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes46(in *jlexer.Lexer, out *RepoInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "type":
+			out.Type = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "package_count":
+			out.PackageCount = int(in.Int())
+		case "rpm_count":
+			out.RPMCount = int(in.Int())
+		case "deb_count":
+			out.DEBCount = int(in.Int())
+		case "total_size":
+			out.TotalSize = int64(in.Int64())
+		case "packages":
+			if in.IsNull() {
+				in.Skip()
+				out.Packages = nil
+			} else {
+				in.Delim('[')
+				if out.Packages == nil {
+					if !in.IsDelim(']') {
+						out.Packages = make([]PackageInfo, 0, 0)
+					} else {
+						out.Packages = []PackageInfo{}
+					}
+				} else {
+					out.Packages = (out.Packages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v82 PackageInfo
+					(v82).UnmarshalEasyJSON(in)
+					out.Packages = append(out.Packages, v82)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "arches":
+			if in.IsNull() {
+				in.Skip()
+				out.Arches = nil
+			} else {
+				in.Delim('[')
+				if out.Arches == nil {
+					if !in.IsDelim(']') {
+						out.Arches = make([]ArchStats, 0, 2)
+					} else {
+						out.Arches = []ArchStats{}
+					}
+				} else {
+					out.Arches = (out.Arches)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v83 ArchStats
+					(v83).UnmarshalEasyJSON(in)
+					out.Arches = append(out.Arches, v83)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "upstream":
+			if in.IsNull() {
+				in.Skip()
+				out.Upstream = nil
+			} else {
+				if out.Upstream == nil {
+					out.Upstream = new(UpstreamStatus)
+				}
+				(*out.Upstream).UnmarshalEasyJSON(in)
+			}
+		case "jobs":
+			if in.IsNull() {
+				in.Skip()
+				out.Jobs = nil
+			} else {
+				in.Delim('[')
+				if out.Jobs == nil {
+					if !in.IsDelim(']') {
+						out.Jobs = make([]JobStatus, 0, 0)
+					} else {
+						out.Jobs = []JobStatus{}
+					}
+				} else {
+					out.Jobs = (out.Jobs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v84 JobStatus
+					(v84).UnmarshalEasyJSON(in)
+					out.Jobs = append(out.Jobs, v84)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "description":
+			out.Description = string(in.String())
+		case "readme":
+			if in.IsNull() {
+				in.Skip()
+				out.Readme = nil
+			} else {
+				if out.Readme == nil {
+					out.Readme = new(Readme)
+				}
+				(*out.Readme).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes46(out *jwriter.Writer, in RepoInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"package_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.PackageCount))
+	}
+	{
+		const prefix string = ",\"rpm_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.RPMCount))
+	}
+	{
+		const prefix string = ",\"deb_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.DEBCount))
+	}
+	{
+		const prefix string = ",\"total_size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TotalSize))
+	}
+	{
+		const prefix string = ",\"packages\":"
+		out.RawString(prefix)
+		if in.Packages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v85, v86 := range in.Packages {
+				if v85 > 0 {
+					out.RawByte(',')
+				}
+				(v86).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Arches) != 0 {
+		const prefix string = ",\"arches\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v87, v88 := range in.Arches {
+				if v87 > 0 {
+					out.RawByte(',')
+				}
+				(v88).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Upstream != nil {
+		const prefix string = ",\"upstream\":"
+		out.RawString(prefix)
+		(*in.Upstream).MarshalEasyJSON(out)
+	}
+	if len(in.Jobs) != 0 {
+		const prefix string = ",\"jobs\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v89, v90 := range in.Jobs {
+				if v89 > 0 {
+					out.RawByte(',')
+				}
+				(v90).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	if in.Readme != nil {
+		const prefix string = ",\"readme\":"
+		out.RawString(prefix)
+		(*in.Readme).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes46(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes46(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes46(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes46(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes47(in *jlexer.Lexer, out *RepoExpiry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "expires":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Expires = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v91 string
+					v91 = string(in.String())
+					(out.Expires)[key] = v91
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes47(out *jwriter.Writer, in RepoExpiry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"expires\":"
+		out.RawString(prefix[1:])
+		if in.Expires == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v92First := true
+			for v92Name, v92Value := range in.Expires {
+				if v92First {
+					v92First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v92Name))
+				out.RawByte(':')
+				out.String(string(v92Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoExpiry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes47(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoExpiry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes47(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoExpiry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes47(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoExpiry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes47(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes48(in *jlexer.Lexer, out *RepoDiff) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "a":
+			out.A = string(in.String())
+		case "b":
+			out.B = string(in.String())
+		case "identical":
+			out.Identical = bool(in.Bool())
+		case "only_in_a":
+			if in.IsNull() {
+				in.Skip()
+				out.OnlyInA = nil
+			} else {
+				in.Delim('[')
+				if out.OnlyInA == nil {
+					if !in.IsDelim(']') {
+						out.OnlyInA = make([]DiffPackage, 0, 1)
+					} else {
+						out.OnlyInA = []DiffPackage{}
+					}
+				} else {
+					out.OnlyInA = (out.OnlyInA)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v93 DiffPackage
+					(v93).UnmarshalEasyJSON(in)
+					out.OnlyInA = append(out.OnlyInA, v93)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "only_in_b":
+			if in.IsNull() {
+				in.Skip()
+				out.OnlyInB = nil
+			} else {
+				in.Delim('[')
+				if out.OnlyInB == nil {
+					if !in.IsDelim(']') {
+						out.OnlyInB = make([]DiffPackage, 0, 1)
+					} else {
+						out.OnlyInB = []DiffPackage{}
+					}
+				} else {
+					out.OnlyInB = (out.OnlyInB)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v94 DiffPackage
+					(v94).UnmarshalEasyJSON(in)
+					out.OnlyInB = append(out.OnlyInB, v94)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "mismatches":
+			if in.IsNull() {
+				in.Skip()
+				out.Mismatches = nil
+			} else {
+				in.Delim('[')
+				if out.Mismatches == nil {
+					if !in.IsDelim(']') {
+						out.Mismatches = make([]DiffMismatch, 0, 0)
+					} else {
+						out.Mismatches = []DiffMismatch{}
+					}
+				} else {
+					out.Mismatches = (out.Mismatches)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v95 DiffMismatch
+					(v95).UnmarshalEasyJSON(in)
+					out.Mismatches = append(out.Mismatches, v95)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes48(out *jwriter.Writer, in RepoDiff) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"a\":"
+		out.RawString(prefix)
+		out.String(string(in.A))
+	}
+	{
+		const prefix string = ",\"b\":"
+		out.RawString(prefix)
+		out.String(string(in.B))
+	}
+	{
+		const prefix string = ",\"identical\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Identical))
+	}
+	{
+		const prefix string = ",\"only_in_a\":"
+		out.RawString(prefix)
+		if in.OnlyInA == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v96, v97 := range in.OnlyInA {
+				if v96 > 0 {
+					out.RawByte(',')
+				}
+				(v97).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"only_in_b\":"
+		out.RawString(prefix)
+		if in.OnlyInB == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v98, v99 := range in.OnlyInB {
+				if v98 > 0 {
+					out.RawByte(',')
+				}
+				(v99).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"mismatches\":"
+		out.RawString(prefix)
+		if in.Mismatches == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v100, v101 := range in.Mismatches {
+				if v100 > 0 {
+					out.RawByte(',')
+				}
+				(v101).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoDiff) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes48(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoDiff) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes48(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoDiff) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes48(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoDiff) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes48(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes49(in *jlexer.Lexer, out *RepoAttestations) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "attestations":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Attestations = make(map[string][]Attestation)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v102 []Attestation
+					if in.IsNull() {
+						in.Skip()
+						v102 = nil
+					} else {
+						in.Delim('[')
+						if v102 == nil {
+							if !in.IsDelim(']') {
+								v102 = make([]Attestation, 0, 0)
+							} else {
+								v102 = []Attestation{}
+							}
+						} else {
+							v102 = (v102)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v103 Attestation
+							(v103).UnmarshalEasyJSON(in)
+							v102 = append(v102, v103)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Attestations)[key] = v102
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "signatures":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Signatures = make(map[string][]Signature)
+				} else {
+					out.Signatures = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v104 []Signature
+					if in.IsNull() {
+						in.Skip()
+						v104 = nil
+					} else {
+						in.Delim('[')
+						if v104 == nil {
+							if !in.IsDelim(']') {
+								v104 = make([]Signature, 0, 0)
+							} else {
+								v104 = []Signature{}
+							}
+						} else {
+							v104 = (v104)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v105 Signature
+							(v105).UnmarshalEasyJSON(in)
+							v104 = append(v104, v105)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Signatures)[key] = v104
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes49(out *jwriter.Writer, in RepoAttestations) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"attestations\":"
+		out.RawString(prefix[1:])
+		if in.Attestations == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v106First := true
+			for v106Name, v106Value := range in.Attestations {
+				if v106First {
+					v106First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v106Name))
+				out.RawByte(':')
+				if v106Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v107, v108 := range v106Value {
+						if v107 > 0 {
+							out.RawByte(',')
+						}
+						(v108).MarshalEasyJSON(out)
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Signatures) != 0 {
+		const prefix string = ",\"signatures\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v109First := true
+			for v109Name, v109Value := range in.Signatures {
+				if v109First {
+					v109First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v109Name))
+				out.RawByte(':')
+				if v109Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v110, v111 := range v109Value {
+						if v110 > 0 {
+							out.RawByte(',')
+						}
+						(v111).MarshalEasyJSON(out)
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepoAttestations) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes49(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepoAttestations) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes49(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepoAttestations) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes49(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepoAttestations) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes49(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes50(in *jlexer.Lexer, out *ReplicationMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "write_quorum":
+			out.WriteQuorum = int(in.Int())
+		case "fallbacks":
+			out.Fallbacks = int64(in.Int64())
+		case "replicas":
+			if in.IsNull() {
+				in.Skip()
+				out.Replicas = nil
+			} else {
+				in.Delim('[')
+				if out.Replicas == nil {
+					if !in.IsDelim(']') {
+						out.Replicas = make([]ReplicaMetrics, 0, 1)
+					} else {
+						out.Replicas = []ReplicaMetrics{}
+					}
+				} else {
+					out.Replicas = (out.Replicas)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v112 ReplicaMetrics
+					(v112).UnmarshalEasyJSON(in)
+					out.Replicas = append(out.Replicas, v112)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes50(out *jwriter.Writer, in ReplicationMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"write_quorum\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.WriteQuorum))
+	}
+	{
+		const prefix string = ",\"fallbacks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Fallbacks))
+	}
+	{
+		const prefix string = ",\"replicas\":"
+		out.RawString(prefix)
+		if in.Replicas == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v113, v114 := range in.Replicas {
+				if v113 > 0 {
+					out.RawByte(',')
+				}
+				(v114).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReplicationMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes50(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReplicationMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes50(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReplicationMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes50(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReplicationMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes50(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes51(in *jlexer.Lexer, out *ReplicaMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "state":
+			out.State = string(in.String())
+		case "failures":
+			out.Failures = int64(in.Int64())
+		case "missed_writes":
+			out.MissedWrites = int64(in.Int64())
+		case "last_error":
+			out.LastError = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes51(out *jwriter.Writer, in ReplicaMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"state\":"
+		out.RawString(prefix)
+		out.String(string(in.State))
+	}
+	{
+		const prefix string = ",\"failures\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Failures))
+	}
+	{
+		const prefix string = ",\"missed_writes\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MissedWrites))
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReplicaMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes51(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReplicaMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes51(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReplicaMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes51(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReplicaMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes51(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes52(in *jlexer.Lexer, out *ReadyCheck) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "checks":
+			(out.Checks).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes52(out *jwriter.Writer, in ReadyCheck) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"checks\":"
+		out.RawString(prefix)
+		(in.Checks).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReadyCheck) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes52(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReadyCheck) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes52(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReadyCheck) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes52(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReadyCheck) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes52(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes53(in *jlexer.Lexer, out *ReadmeResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "readme":
+			(out.Readme).UnmarshalEasyJSON(in)
+		case "html":
+			out.HTML = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes53(out *jwriter.Writer, in ReadmeResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"readme\":"
+		out.RawString(prefix)
+		(in.Readme).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"html\":"
+		out.RawString(prefix)
+		out.String(string(in.HTML))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReadmeResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes53(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReadmeResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes53(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReadmeResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes53(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReadmeResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes53(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes54(in *jlexer.Lexer, out *ReadmeRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes54(out *jwriter.Writer, in ReadmeRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReadmeRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes54(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReadmeRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes54(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReadmeRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes54(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReadmeRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes54(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes55(in *jlexer.Lexer, out *Readme) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "source":
+			out.Source = string(in.String())
+		case "html":
+			out.HTML = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes55(out *jwriter.Writer, in Readme) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	{
+		const prefix string = ",\"html\":"
+		out.RawString(prefix)
+		out.String(string(in.HTML))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Readme) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes55(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Readme) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes55(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Readme) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes55(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Readme) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes55(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes56(in *jlexer.Lexer, out *ReadCacheMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "path":
+			out.Path = string(in.String())
+		case "max_size":
+			out.MaxSize = int64(in.Int64())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "entries":
+			out.Entries = int(in.Int())
+		case "hits":
+			out.Hits = int64(in.Int64())
+		case "misses":
+			out.Misses = int64(in.Int64())
+		case "evictions":
+			out.Evictions = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes56(out *jwriter.Writer, in ReadCacheMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"max_size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxSize))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"entries\":"
+		out.RawString(prefix)
+		out.Int(int(in.Entries))
+	}
+	{
+		const prefix string = ",\"hits\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Hits))
+	}
+	{
+		const prefix string = ",\"misses\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Misses))
+	}
+	{
+		const prefix string = ",\"evictions\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Evictions))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReadCacheMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes56(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReadCacheMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes56(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReadCacheMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes56(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReadCacheMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes56(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes57(in *jlexer.Lexer, out *PurgeRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "event":
+			out.Event = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "surrogate_keys":
+			if in.IsNull() {
+				in.Skip()
+				out.SurrogateKeys = nil
+			} else {
+				in.Delim('[')
+				if out.SurrogateKeys == nil {
+					if !in.IsDelim(']') {
+						out.SurrogateKeys = make([]string, 0, 4)
+					} else {
+						out.SurrogateKeys = []string{}
+					}
+				} else {
+					out.SurrogateKeys = (out.SurrogateKeys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v115 string
+					v115 = string(in.String())
+					out.SurrogateKeys = append(out.SurrogateKeys, v115)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes57(out *jwriter.Writer, in PurgeRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"event\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Event))
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"surrogate_keys\":"
+		out.RawString(prefix)
+		if in.SurrogateKeys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v116, v117 := range in.SurrogateKeys {
+				if v116 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v117))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PurgeRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes57(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PurgeRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes57(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PurgeRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes57(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PurgeRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes57(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes58(in *jlexer.Lexer, out *PulpRepositoryList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "results":
+			if in.IsNull() {
+				in.Skip()
+				out.Results = nil
+			} else {
+				in.Delim('[')
+				if out.Results == nil {
+					if !in.IsDelim(']') {
+						out.Results = make([]PulpRepository, 0, 1)
+					} else {
+						out.Results = []PulpRepository{}
+					}
+				} else {
+					out.Results = (out.Results)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v118 PulpRepository
+					(v118).UnmarshalEasyJSON(in)
+					out.Results = append(out.Results, v118)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes58(out *jwriter.Writer, in PulpRepositoryList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"results\":"
+		out.RawString(prefix[1:])
+		if in.Results == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v119, v120 := range in.Results {
+				if v119 > 0 {
+					out.RawByte(',')
+				}
+				(v120).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PulpRepositoryList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes58(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PulpRepositoryList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes58(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PulpRepositoryList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes58(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PulpRepositoryList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes58(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes59(in *jlexer.Lexer, out *PulpRepository) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "pulp_href":
+			out.PulpHref = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "latest_version_href":
+			out.LatestVersionHref = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes59(out *jwriter.Writer, in PulpRepository) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"pulp_href\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.PulpHref))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"latest_version_href\":"
+		out.RawString(prefix)
+		out.String(string(in.LatestVersionHref))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PulpRepository) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes59(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PulpRepository) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes59(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PulpRepository) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes59(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PulpRepository) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes59(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes60(in *jlexer.Lexer, out *PulpDistributionList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "results":
+			if in.IsNull() {
+				in.Skip()
+				out.Results = nil
+			} else {
+				in.Delim('[')
+				if out.Results == nil {
+					if !in.IsDelim(']') {
+						out.Results = make([]PulpDistribution, 0, 2)
+					} else {
+						out.Results = []PulpDistribution{}
+					}
+				} else {
+					out.Results = (out.Results)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v121 PulpDistribution
+					(v121).UnmarshalEasyJSON(in)
+					out.Results = append(out.Results, v121)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes60(out *jwriter.Writer, in PulpDistributionList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"results\":"
+		out.RawString(prefix[1:])
+		if in.Results == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v122, v123 := range in.Results {
+				if v122 > 0 {
+					out.RawByte(',')
+				}
+				(v123).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PulpDistributionList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes60(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PulpDistributionList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes60(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PulpDistributionList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes60(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PulpDistributionList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes60(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes61(in *jlexer.Lexer, out *PulpDistribution) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "base_url":
+			out.BaseURL = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes61(out *jwriter.Writer, in PulpDistribution) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"base_url\":"
+		out.RawString(prefix)
+		out.String(string(in.BaseURL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PulpDistribution) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes61(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PulpDistribution) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes61(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PulpDistribution) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes61(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PulpDistribution) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes61(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes62(in *jlexer.Lexer, out *PulpContentList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "next":
+			out.Next = string(in.String())
+		case "results":
+			if in.IsNull() {
+				in.Skip()
+				out.Results = nil
+			} else {
+				in.Delim('[')
+				if out.Results == nil {
+					if !in.IsDelim(']') {
+						out.Results = make([]PulpContent, 0, 0)
+					} else {
+						out.Results = []PulpContent{}
+					}
+				} else {
+					out.Results = (out.Results)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v124 PulpContent
+					(v124).UnmarshalEasyJSON(in)
+					out.Results = append(out.Results, v124)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes62(out *jwriter.Writer, in PulpContentList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"next\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Next))
+	}
+	{
+		const prefix string = ",\"results\":"
+		out.RawString(prefix)
+		if in.Results == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v125, v126 := range in.Results {
+				if v125 > 0 {
+					out.RawByte(',')
+				}
+				(v126).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PulpContentList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes62(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PulpContentList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes62(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PulpContentList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes62(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PulpContentList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes62(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes63(in *jlexer.Lexer, out *PulpContent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "location_href":
+			out.LocationHref = string(in.String())
+		case "pkgId":
+			out.PkgID = string(in.String())
+		case "checksum_type":
+			out.ChecksumType = string(in.String())
+		case "size_package":
+			out.SizePackage = int64(in.Int64())
+		case "relative_path":
+			out.RelativePath = string(in.String())
+		case "sha256":
+			out.SHA256 = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes63(out *jwriter.Writer, in PulpContent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"location_href\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.LocationHref))
+	}
+	{
+		const prefix string = ",\"pkgId\":"
+		out.RawString(prefix)
+		out.String(string(in.PkgID))
+	}
+	{
+		const prefix string = ",\"checksum_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ChecksumType))
+	}
+	{
+		const prefix string = ",\"size_package\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.SizePackage))
+	}
+	{
+		const prefix string = ",\"relative_path\":"
+		out.RawString(prefix)
+		out.String(string(in.RelativePath))
+	}
+	{
+		const prefix string = ",\"sha256\":"
+		out.RawString(prefix)
+		out.String(string(in.SHA256))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PulpContent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes63(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PulpContent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes63(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PulpContent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes63(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PulpContent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes63(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes64(in *jlexer.Lexer, out *PublishResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "changeset":
+			out.Changeset = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]string, 0, 4)
+					} else {
+						out.Files = []string{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v127 string
+					v127 = string(in.String())
+					out.Files = append(out.Files, v127)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes64(out *jwriter.Writer, in PublishResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"changeset\":"
+		out.RawString(prefix)
+		out.String(string(in.Changeset))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		if in.Files == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v128, v129 := range in.Files {
+				if v128 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v129))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PublishResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes64(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PublishResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes64(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PublishResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes64(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PublishResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes64(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes65(in *jlexer.Lexer, out *PublishRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "changeset":
+			out.Changeset = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes65(out *jwriter.Writer, in PublishRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"changeset\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Changeset))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PublishRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes65(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PublishRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes65(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PublishRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes65(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PublishRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes65(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes66(in *jlexer.Lexer, out *Problem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "status":
+			out.Status = int(in.Int())
+		case "detail":
+			out.Detail = string(in.String())
+		case "instance":
+			out.Instance = string(in.String())
+		case "code":
+			out.Code = string(in.String())
+		case "request_id":
+			out.RequestID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes66(out *jwriter.Writer, in Problem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int(int(in.Status))
+	}
+	if in.Detail != "" {
+		const prefix string = ",\"detail\":"
+		out.RawString(prefix)
+		out.String(string(in.Detail))
+	}
+	{
+		const prefix string = ",\"instance\":"
+		out.RawString(prefix)
+		out.String(string(in.Instance))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"request_id\":"
+		out.RawString(prefix)
+		out.String(string(in.RequestID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Problem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes66(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Problem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes66(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Problem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes66(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Problem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes66(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes67(in *jlexer.Lexer, out *PluginList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "count":
+			out.Count = int(in.Int())
+		case "plugins":
+			if in.IsNull() {
+				in.Skip()
+				out.Plugins = nil
+			} else {
+				in.Delim('[')
+				if out.Plugins == nil {
+					if !in.IsDelim(']') {
+						out.Plugins = make([]PluginInfo, 0, 0)
+					} else {
+						out.Plugins = []PluginInfo{}
+					}
+				} else {
+					out.Plugins = (out.Plugins)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v130 PluginInfo
+					(v130).UnmarshalEasyJSON(in)
+					out.Plugins = append(out.Plugins, v130)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "repo_types":
+			if in.IsNull() {
+				in.Skip()
+				out.RepoTypes = nil
+			} else {
+				in.Delim('[')
+				if out.RepoTypes == nil {
+					if !in.IsDelim(']') {
+						out.RepoTypes = make([]string, 0, 4)
+					} else {
+						out.RepoTypes = []string{}
+					}
+				} else {
+					out.RepoTypes = (out.RepoTypes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v131 string
+					v131 = string(in.String())
+					out.RepoTypes = append(out.RepoTypes, v131)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "storage_types":
+			if in.IsNull() {
+				in.Skip()
+				out.StorageTypes = nil
+			} else {
+				in.Delim('[')
+				if out.StorageTypes == nil {
+					if !in.IsDelim(']') {
+						out.StorageTypes = make([]string, 0, 4)
+					} else {
+						out.StorageTypes = []string{}
+					}
+				} else {
+					out.StorageTypes = (out.StorageTypes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v132 string
+					v132 = string(in.String())
+					out.StorageTypes = append(out.StorageTypes, v132)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes67(out *jwriter.Writer, in PluginList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"plugins\":"
+		out.RawString(prefix)
+		if in.Plugins == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v133, v134 := range in.Plugins {
+				if v133 > 0 {
+					out.RawByte(',')
+				}
+				(v134).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"repo_types\":"
+		out.RawString(prefix)
+		if in.RepoTypes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v135, v136 := range in.RepoTypes {
+				if v135 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v136))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"storage_types\":"
+		out.RawString(prefix)
+		if in.StorageTypes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v137, v138 := range in.StorageTypes {
+				if v137 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v138))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PluginList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes67(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PluginList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes67(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PluginList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes67(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PluginList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes67(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes68(in *jlexer.Lexer, out *PluginInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "repo_types":
+			if in.IsNull() {
+				in.Skip()
+				out.RepoTypes = nil
+			} else {
+				in.Delim('[')
+				if out.RepoTypes == nil {
+					if !in.IsDelim(']') {
+						out.RepoTypes = make([]string, 0, 4)
+					} else {
+						out.RepoTypes = []string{}
+					}
+				} else {
+					out.RepoTypes = (out.RepoTypes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v139 string
+					v139 = string(in.String())
+					out.RepoTypes = append(out.RepoTypes, v139)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "storage_types":
+			if in.IsNull() {
+				in.Skip()
+				out.StorageTypes = nil
+			} else {
+				in.Delim('[')
+				if out.StorageTypes == nil {
+					if !in.IsDelim(']') {
+						out.StorageTypes = make([]string, 0, 4)
+					} else {
+						out.StorageTypes = []string{}
+					}
+				} else {
+					out.StorageTypes = (out.StorageTypes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v140 string
+					v140 = string(in.String())
+					out.StorageTypes = append(out.StorageTypes, v140)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "middleware":
+			if in.IsNull() {
+				in.Skip()
+				out.Middleware = nil
+			} else {
+				in.Delim('[')
+				if out.Middleware == nil {
+					if !in.IsDelim(']') {
+						out.Middleware = make([]string, 0, 4)
+					} else {
+						out.Middleware = []string{}
+					}
+				} else {
+					out.Middleware = (out.Middleware)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v141 string
+					v141 = string(in.String())
+					out.Middleware = append(out.Middleware, v141)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "hooks":
+			if in.IsNull() {
+				in.Skip()
+				out.Hooks = nil
+			} else {
+				in.Delim('[')
+				if out.Hooks == nil {
+					if !in.IsDelim(']') {
+						out.Hooks = make([]string, 0, 4)
+					} else {
+						out.Hooks = []string{}
+					}
+				} else {
+					out.Hooks = (out.Hooks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v142 string
+					v142 = string(in.String())
+					out.Hooks = append(out.Hooks, v142)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes68(out *jwriter.Writer, in PluginInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.Version != "" {
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"repo_types\":"
+		out.RawString(prefix)
+		if in.RepoTypes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v143, v144 := range in.RepoTypes {
+				if v143 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v144))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"storage_types\":"
+		out.RawString(prefix)
+		if in.StorageTypes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v145, v146 := range in.StorageTypes {
+				if v145 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v146))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"middleware\":"
+		out.RawString(prefix)
+		if in.Middleware == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v147, v148 := range in.Middleware {
+				if v147 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v148))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"hooks\":"
+		out.RawString(prefix)
+		if in.Hooks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v149, v150 := range in.Hooks {
+				if v149 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v150))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PluginInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes68(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PluginInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes68(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PluginInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes68(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PluginInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes68(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes69(in *jlexer.Lexer, out *Permission) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "repo":
+			out.Repo = string(in.String())
+		case "actions":
+			if in.IsNull() {
+				in.Skip()
+				out.Actions = nil
+			} else {
+				in.Delim('[')
+				if out.Actions == nil {
+					if !in.IsDelim(']') {
+						out.Actions = make([]string, 0, 4)
+					} else {
+						out.Actions = []string{}
+					}
+				} else {
+					out.Actions = (out.Actions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v151 string
+					v151 = string(in.String())
+					out.Actions = append(out.Actions, v151)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes69(out *jwriter.Writer, in Permission) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"actions\":"
+		out.RawString(prefix)
+		if in.Actions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v152, v153 := range in.Actions {
+				if v152 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v153))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Permission) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes69(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Permission) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes69(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Permission) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes69(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Permission) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes69(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes70(in *jlexer.Lexer, out *Performance) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "response_time_ms":
+			out.ResponseTimeMs = int64(in.Int64())
+		case "goroutines":
+			out.Goroutines = int(in.Int())
+		case "routes":
+			if in.IsNull() {
+				in.Skip()
+				out.Routes = nil
+			} else {
+				in.Delim('[')
+				if out.Routes == nil {
+					if !in.IsDelim(']') {
+						out.Routes = make([]RouteLatency, 0, 1)
+					} else {
+						out.Routes = []RouteLatency{}
+					}
+				} else {
+					out.Routes = (out.Routes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v154 RouteLatency
+					(v154).UnmarshalEasyJSON(in)
+					out.Routes = append(out.Routes, v154)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes70(out *jwriter.Writer, in Performance) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"response_time_ms\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ResponseTimeMs))
+	}
+	{
+		const prefix string = ",\"goroutines\":"
+		out.RawString(prefix)
+		out.Int(int(in.Goroutines))
+	}
+	if len(in.Routes) != 0 {
+		const prefix string = ",\"routes\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v155, v156 := range in.Routes {
+				if v155 > 0 {
+					out.RawByte(',')
+				}
+				(v156).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Performance) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes70(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Performance) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes70(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Performance) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes70(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Performance) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes70(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes71(in *jlexer.Lexer, out *PackageVersions) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "latest":
+			out.Latest = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		case "versions":
+			if in.IsNull() {
+				in.Skip()
+				out.Versions = nil
+			} else {
+				in.Delim('[')
+				if out.Versions == nil {
+					if !in.IsDelim(']') {
+						out.Versions = make([]PackageVersion, 0, 0)
+					} else {
+						out.Versions = []PackageVersion{}
+					}
+				} else {
+					out.Versions = (out.Versions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v157 PackageVersion
+					(v157).UnmarshalEasyJSON(in)
+					out.Versions = append(out.Versions, v157)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes71(out *jwriter.Writer, in PackageVersions) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"latest\":"
+		out.RawString(prefix)
+		out.String(string(in.Latest))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"versions\":"
+		out.RawString(prefix)
+		if in.Versions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v158, v159 := range in.Versions {
+				if v158 > 0 {
+					out.RawByte(',')
+				}
+				(v159).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PackageVersions) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes71(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PackageVersions) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes71(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PackageVersions) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes71(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PackageVersions) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes71(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes72(in *jlexer.Lexer, out *PackageVersion) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "checksum":
+			out.Checksum = string(in.String())
+		case "checksum_type":
+			out.ChecksumType = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		case "license":
+			out.License = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes72(out *jwriter.Writer, in PackageVersion) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	if in.Checksum != "" {
+		const prefix string = ",\"checksum\":"
+		out.RawString(prefix)
+		out.String(string(in.Checksum))
+	}
+	if in.ChecksumType != "" {
+		const prefix string = ",\"checksum_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ChecksumType))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	if in.License != "" {
+		const prefix string = ",\"license\":"
+		out.RawString(prefix)
+		out.String(string(in.License))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PackageVersion) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes72(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PackageVersion) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes72(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PackageVersion) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes72(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PackageVersion) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes72(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes73(in *jlexer.Lexer, out *PackageSearchResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "query":
+			out.Query = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		case "truncated":
+			out.Truncated = bool(in.Bool())
+		case "packages":
+			if in.IsNull() {
+				in.Skip()
+				out.Packages = nil
+			} else {
+				in.Delim('[')
+				if out.Packages == nil {
+					if !in.IsDelim(']') {
+						out.Packages = make([]PackageHit, 0, 0)
+					} else {
+						out.Packages = []PackageHit{}
+					}
+				} else {
+					out.Packages = (out.Packages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v160 PackageHit
+					(v160).UnmarshalEasyJSON(in)
+					out.Packages = append(out.Packages, v160)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "facets":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Facets = make(map[string][]FacetValue)
+				} else {
+					out.Facets = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v161 []FacetValue
+					if in.IsNull() {
+						in.Skip()
+						v161 = nil
+					} else {
+						in.Delim('[')
+						if v161 == nil {
+							if !in.IsDelim(']') {
+								v161 = make([]FacetValue, 0, 2)
+							} else {
+								v161 = []FacetValue{}
+							}
+						} else {
+							v161 = (v161)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v162 FacetValue
+							(v162).UnmarshalEasyJSON(in)
+							v161 = append(v161, v162)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Facets)[key] = v161
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes73(out *jwriter.Writer, in PackageSearchResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"query\":"
+		out.RawString(prefix)
+		out.String(string(in.Query))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"truncated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Truncated))
+	}
+	{
+		const prefix string = ",\"packages\":"
+		out.RawString(prefix)
+		if in.Packages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v163, v164 := range in.Packages {
+				if v163 > 0 {
+					out.RawByte(',')
+				}
+				(v164).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Facets) != 0 {
+		const prefix string = ",\"facets\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v165First := true
+			for v165Name, v165Value := range in.Facets {
+				if v165First {
+					v165First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v165Name))
+				out.RawByte(':')
+				if v165Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v166, v167 := range v165Value {
+						if v166 > 0 {
+							out.RawByte(',')
+						}
+						(v167).MarshalEasyJSON(out)
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PackageSearchResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes73(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PackageSearchResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes73(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PackageSearchResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes73(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PackageSearchResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes73(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes74(in *jlexer.Lexer, out *PackageNote) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		case "source":
+			out.Source = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		case "updated":
+			out.Updated = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes74(out *jwriter.Writer, in PackageNote) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	if in.Source != "" {
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	if in.Author != "" {
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"updated\":"
+		out.RawString(prefix)
+		out.String(string(in.Updated))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PackageNote) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes74(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PackageNote) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes74(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PackageNote) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes74(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PackageNote) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes74(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes75(in *jlexer.Lexer, out *PackageLatest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "package":
+			(out.Package).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes75(out *jwriter.Writer, in PackageLatest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"package\":"
+		out.RawString(prefix)
+		(in.Package).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PackageLatest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes75(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PackageLatest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes75(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PackageLatest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes75(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PackageLatest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes75(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes76(in *jlexer.Lexer, out *PackageInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "release":
+			out.Release = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "checksum":
+			out.Checksum = string(in.String())
+		case "mtime":
+			out.ModTime = string(in.String())
+		case "license":
+			out.License = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes76(out *jwriter.Writer, in PackageInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"release\":"
+		out.RawString(prefix)
+		out.String(string(in.Release))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"checksum\":"
+		out.RawString(prefix)
+		out.String(string(in.Checksum))
+	}
+	if in.ModTime != "" {
+		const prefix string = ",\"mtime\":"
+		out.RawString(prefix)
+		out.String(string(in.ModTime))
+	}
+	if in.License != "" {
+		const prefix string = ",\"license\":"
+		out.RawString(prefix)
+		out.String(string(in.License))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PackageInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes76(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PackageInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes76(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PackageInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes76(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PackageInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes76(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes77(in *jlexer.Lexer, out *PackageHit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "repo":
+			out.Repo = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "summary":
+			out.Summary = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "license":
+			out.License = string(in.String())
+		case "score":
+			out.Score = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes77(out *jwriter.Writer, in PackageHit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	if in.Summary != "" {
+		const prefix string = ",\"summary\":"
+		out.RawString(prefix)
+		out.String(string(in.Summary))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	if in.License != "" {
+		const prefix string = ",\"license\":"
+		out.RawString(prefix)
+		out.String(string(in.License))
+	}
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Score))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PackageHit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes77(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PackageHit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes77(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PackageHit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes77(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PackageHit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes77(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes78(in *jlexer.Lexer, out *PackageDependencies) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "provides":
+			if in.IsNull() {
+				in.Skip()
+				out.Provides = nil
+			} else {
+				in.Delim('[')
+				if out.Provides == nil {
+					if !in.IsDelim(']') {
+						out.Provides = make([]string, 0, 4)
+					} else {
+						out.Provides = []string{}
+					}
+				} else {
+					out.Provides = (out.Provides)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v168 string
+					v168 = string(in.String())
+					out.Provides = append(out.Provides, v168)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "requires":
+			if in.IsNull() {
+				in.Skip()
+				out.Requires = nil
+			} else {
+				in.Delim('[')
+				if out.Requires == nil {
+					if !in.IsDelim(']') {
+						out.Requires = make([]RequirementInfo, 0, 1)
+					} else {
+						out.Requires = []RequirementInfo{}
+					}
+				} else {
+					out.Requires = (out.Requires)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v169 RequirementInfo
+					(v169).UnmarshalEasyJSON(in)
+					out.Requires = append(out.Requires, v169)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "required_by":
+			if in.IsNull() {
+				in.Skip()
+				out.RequiredBy = nil
+			} else {
+				in.Delim('[')
+				if out.RequiredBy == nil {
+					if !in.IsDelim(']') {
+						out.RequiredBy = make([]DependentInfo, 0, 0)
+					} else {
+						out.RequiredBy = []DependentInfo{}
+					}
+				} else {
+					out.RequiredBy = (out.RequiredBy)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v170 DependentInfo
+					(v170).UnmarshalEasyJSON(in)
+					out.RequiredBy = append(out.RequiredBy, v170)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes78(out *jwriter.Writer, in PackageDependencies) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"provides\":"
+		out.RawString(prefix)
+		if in.Provides == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v171, v172 := range in.Provides {
+				if v171 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v172))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"requires\":"
+		out.RawString(prefix)
+		if in.Requires == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v173, v174 := range in.Requires {
+				if v173 > 0 {
+					out.RawByte(',')
+				}
+				(v174).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"required_by\":"
+		out.RawString(prefix)
+		if in.RequiredBy == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v175, v176 := range in.RequiredBy {
+				if v175 > 0 {
+					out.RawByte(',')
+				}
+				(v176).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PackageDependencies) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes78(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PackageDependencies) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes78(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PackageDependencies) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes78(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PackageDependencies) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes78(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes79(in *jlexer.Lexer, out *PackageChecksum) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "filename":
+			out.Filename = string(in.String())
+		case "sha256":
+			out.SHA256 = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes79(out *jwriter.Writer, in PackageChecksum) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"filename\":"
+		out.RawString(prefix)
+		out.String(string(in.Filename))
+	}
+	{
+		const prefix string = ",\"sha256\":"
+		out.RawString(prefix)
+		out.String(string(in.SHA256))
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PackageChecksum) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes79(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PackageChecksum) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes79(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PackageChecksum) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes79(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PackageChecksum) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes79(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes80(in *jlexer.Lexer, out *Package) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Name":
+			out.Name = string(in.String())
+		case "Arch":
+			out.Arch = string(in.String())
+		case "Version":
+			(out.Version).UnmarshalEasyJSON(in)
+		case "Checksum":
+			(out.Checksum).UnmarshalEasyJSON(in)
+		case "Location":
+			(out.Location).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes80(out *jwriter.Writer, in Package) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"Arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"Version\":"
+		out.RawString(prefix)
+		(in.Version).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"Checksum\":"
+		out.RawString(prefix)
+		(in.Checksum).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"Location\":"
+		out.RawString(prefix)
+		(in.Location).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Package) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes80(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Package) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes80(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Package) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes80(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Package) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes80(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes81(in *jlexer.Lexer, out *NoteResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "note":
+			(out.Note).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes81(out *jwriter.Writer, in NoteResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"note\":"
+		out.RawString(prefix)
+		(in.Note).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NoteResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes81(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NoteResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes81(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NoteResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes81(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NoteResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes81(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes82(in *jlexer.Lexer, out *NoteRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		case "source":
+			out.Source = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes82(out *jwriter.Writer, in NoteRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	if in.Source != "" {
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NoteRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes82(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NoteRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes82(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NoteRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes82(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NoteRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes82(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes83(in *jlexer.Lexer, out *NexusRepository) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "format":
+			out.Format = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes83(out *jwriter.Writer, in NexusRepository) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"format\":"
+		out.RawString(prefix)
+		out.String(string(in.Format))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NexusRepository) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes83(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NexusRepository) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes83(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NexusRepository) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes83(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NexusRepository) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes83(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes84(in *jlexer.Lexer, out *NexusAssets) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]NexusAsset, 0, 1)
+					} else {
+						out.Items = []NexusAsset{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v177 NexusAsset
+					(v177).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v177)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "continuationToken":
+			out.ContinuationToken = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes84(out *jwriter.Writer, in NexusAssets) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v178, v179 := range in.Items {
+				if v178 > 0 {
+					out.RawByte(',')
+				}
+				(v179).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"continuationToken\":"
+		out.RawString(prefix)
+		out.String(string(in.ContinuationToken))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NexusAssets) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes84(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NexusAssets) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes84(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NexusAssets) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes84(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NexusAssets) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes84(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes85(in *jlexer.Lexer, out *NexusAsset) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "downloadUrl":
+			out.DownloadURL = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "checksum":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Checksum = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v180 string
+					v180 = string(in.String())
+					(out.Checksum)[key] = v180
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "fileSize":
+			out.FileSize = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes85(out *jwriter.Writer, in NexusAsset) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"downloadUrl\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.DownloadURL))
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"checksum\":"
+		out.RawString(prefix)
+		if in.Checksum == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v181First := true
+			for v181Name, v181Value := range in.Checksum {
+				if v181First {
+					v181First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v181Name))
+				out.RawByte(':')
+				out.String(string(v181Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"fileSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.FileSize))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NexusAsset) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes85(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NexusAsset) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes85(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NexusAsset) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes85(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NexusAsset) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes85(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes86(in *jlexer.Lexer, out *NATSInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "tls_required":
+			out.TLSRequired = bool(in.Bool())
+		case "max_payload":
+			out.MaxPayload = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes86(out *jwriter.Writer, in NATSInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.TLSRequired {
+		const prefix string = ",\"tls_required\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.TLSRequired))
+	}
+	if in.MaxPayload != 0 {
+		const prefix string = ",\"max_payload\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.MaxPayload))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NATSInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes86(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NATSInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes86(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NATSInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes86(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NATSInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes86(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes87(in *jlexer.Lexer, out *NATSConnect) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "verbose":
+			out.Verbose = bool(in.Bool())
+		case "pedantic":
+			out.Pedantic = bool(in.Bool())
+		case "tls_required":
+			out.TLSRequired = bool(in.Bool())
+		case "name":
+			out.Name = string(in.String())
+		case "lang":
+			out.Lang = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "user":
+			out.User = string(in.String())
+		case "pass":
+			out.Pass = string(in.String())
+		case "auth_token":
+			out.AuthToken = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes87(out *jwriter.Writer, in NATSConnect) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"verbose\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Verbose))
+	}
+	{
+		const prefix string = ",\"pedantic\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Pedantic))
+	}
+	{
+		const prefix string = ",\"tls_required\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.TLSRequired))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"lang\":"
+		out.RawString(prefix)
+		out.String(string(in.Lang))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	if in.User != "" {
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.String(string(in.User))
+	}
+	if in.Pass != "" {
+		const prefix string = ",\"pass\":"
+		out.RawString(prefix)
+		out.String(string(in.Pass))
+	}
+	if in.AuthToken != "" {
+		const prefix string = ",\"auth_token\":"
+		out.RawString(prefix)
+		out.String(string(in.AuthToken))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NATSConnect) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes87(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NATSConnect) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes87(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NATSConnect) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes87(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NATSConnect) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes87(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes88(in *jlexer.Lexer, out *MirrorHealth) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.URL = string(in.String())
+		case "weight":
+			out.Weight = int(in.Int())
+		case "healthy":
+			out.Healthy = bool(in.Bool())
+		case "active":
+			out.Active = bool(in.Bool())
+		case "circuit":
+			out.Circuit = string(in.String())
+		case "latency_ms":
+			out.LatencyMs = int64(in.Int64())
+		case "metadata_time":
+			out.MetadataTime = string(in.String())
+		case "metadata_age":
+			out.MetadataAge = string(in.String())
+		case "stale":
+			out.Stale = bool(in.Bool())
+		case "spot_checks":
+			out.SpotChecks = int(in.Int())
+		case "spot_check_failures":
+			out.SpotCheckFailures = int(in.Int())
+		case "consecutive_failures":
+			out.ConsecutiveFailures = int(in.Int())
+		case "failures":
+			out.Failures = int64(in.Int64())
+		case "served":
+			out.Served = int64(in.Int64())
+		case "bytes_served":
+			out.BytesServed = int64(in.Int64())
+		case "last_check":
+			out.LastCheck = string(in.String())
+		case "last_error":
+			out.LastError = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes88(out *jwriter.Writer, in MirrorHealth) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"weight\":"
+		out.RawString(prefix)
+		out.Int(int(in.Weight))
+	}
+	{
+		const prefix string = ",\"healthy\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Healthy))
+	}
+	{
+		const prefix string = ",\"active\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Active))
+	}
+	{
+		const prefix string = ",\"circuit\":"
+		out.RawString(prefix)
+		out.String(string(in.Circuit))
+	}
+	{
+		const prefix string = ",\"latency_ms\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.LatencyMs))
+	}
+	if in.MetadataTime != "" {
+		const prefix string = ",\"metadata_time\":"
+		out.RawString(prefix)
+		out.String(string(in.MetadataTime))
+	}
+	if in.MetadataAge != "" {
+		const prefix string = ",\"metadata_age\":"
+		out.RawString(prefix)
+		out.String(string(in.MetadataAge))
+	}
+	{
+		const prefix string = ",\"stale\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Stale))
+	}
+	{
+		const prefix string = ",\"spot_checks\":"
+		out.RawString(prefix)
+		out.Int(int(in.SpotChecks))
+	}
+	{
+		const prefix string = ",\"spot_check_failures\":"
+		out.RawString(prefix)
+		out.Int(int(in.SpotCheckFailures))
+	}
+	{
+		const prefix string = ",\"consecutive_failures\":"
+		out.RawString(prefix)
+		out.Int(int(in.ConsecutiveFailures))
+	}
+	{
+		const prefix string = ",\"failures\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Failures))
+	}
+	{
+		const prefix string = ",\"served\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Served))
+	}
+	{
+		const prefix string = ",\"bytes_served\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesServed))
+	}
+	if in.LastCheck != "" {
+		const prefix string = ",\"last_check\":"
+		out.RawString(prefix)
+		out.String(string(in.LastCheck))
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MirrorHealth) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes88(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MirrorHealth) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes88(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MirrorHealth) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes88(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MirrorHealth) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes88(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes89(in *jlexer.Lexer, out *MigrationReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "source":
+			out.Source = string(in.String())
+		case "kind":
+			out.Kind = string(in.String())
+		case "dry_run":
+			out.DryRun = bool(in.Bool())
+		case "repos":
+			if in.IsNull() {
+				in.Skip()
+				out.Repos = nil
+			} else {
+				in.Delim('[')
+				if out.Repos == nil {
+					if !in.IsDelim(']') {
+						out.Repos = make([]MigratedRepo, 0, 0)
+					} else {
+						out.Repos = []MigratedRepo{}
+					}
+				} else {
+					out.Repos = (out.Repos)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v182 MigratedRepo
+					(v182).UnmarshalEasyJSON(in)
+					out.Repos = append(out.Repos, v182)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes89(out *jwriter.Writer, in MigrationReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"dry_run\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.DryRun))
+	}
+	{
+		const prefix string = ",\"repos\":"
+		out.RawString(prefix)
+		if in.Repos == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v183, v184 := range in.Repos {
+				if v183 > 0 {
+					out.RawByte(',')
+				}
+				(v184).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MigrationReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes89(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MigrationReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes89(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MigrationReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes89(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MigrationReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes89(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes90(in *jlexer.Lexer, out *MigratedRepo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "remote":
+			out.Remote = string(in.String())
+		case "format":
+			out.Format = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "repos":
+			if in.IsNull() {
+				in.Skip()
+				out.Repos = nil
+			} else {
+				in.Delim('[')
+				if out.Repos == nil {
+					if !in.IsDelim(']') {
+						out.Repos = make([]string, 0, 4)
+					} else {
+						out.Repos = []string{}
+					}
+				} else {
+					out.Repos = (out.Repos)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v185 string
+					v185 = string(in.String())
+					out.Repos = append(out.Repos, v185)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "copied":
+			out.Copied = int(in.Int())
+		case "existing":
+			out.Existing = int(in.Int())
+		case "ignored":
+			out.Ignored = int(in.Int())
+		case "failed":
+			out.Failed = int(in.Int())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "error":
+			out.Error = string(in.String())
+		case "artifacts":
+			if in.IsNull() {
+				in.Skip()
+				out.Artifacts = nil
+			} else {
+				in.Delim('[')
+				if out.Artifacts == nil {
+					if !in.IsDelim(']') {
+						out.Artifacts = make([]MigratedArtifact, 0, 0)
+					} else {
+						out.Artifacts = []MigratedArtifact{}
+					}
+				} else {
+					out.Artifacts = (out.Artifacts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v186 MigratedArtifact
+					(v186).UnmarshalEasyJSON(in)
+					out.Artifacts = append(out.Artifacts, v186)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes90(out *jwriter.Writer, in MigratedRepo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"remote\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Remote))
+	}
+	{
+		const prefix string = ",\"format\":"
+		out.RawString(prefix)
+		out.String(string(in.Format))
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"repos\":"
+		out.RawString(prefix)
+		if in.Repos == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v187, v188 := range in.Repos {
+				if v187 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v188))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"copied\":"
+		out.RawString(prefix)
+		out.Int(int(in.Copied))
+	}
+	{
+		const prefix string = ",\"existing\":"
+		out.RawString(prefix)
+		out.Int(int(in.Existing))
+	}
+	{
+		const prefix string = ",\"ignored\":"
+		out.RawString(prefix)
+		out.Int(int(in.Ignored))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int(int(in.Failed))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	{
+		const prefix string = ",\"artifacts\":"
+		out.RawString(prefix)
+		if in.Artifacts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v189, v190 := range in.Artifacts {
+				if v189 > 0 {
+					out.RawByte(',')
+				}
+				(v190).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MigratedRepo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes90(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MigratedRepo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes90(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MigratedRepo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes90(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MigratedRepo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes90(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes91(in *jlexer.Lexer, out *MigratedArtifact) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "remote":
+			out.Remote = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "state":
+			out.State = string(in.String())
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes91(out *jwriter.Writer, in MigratedArtifact) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"remote\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Remote))
+	}
+	if in.Repo != "" {
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	if in.Path != "" {
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"state\":"
+		out.RawString(prefix)
+		out.String(string(in.State))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MigratedArtifact) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes91(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MigratedArtifact) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes91(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MigratedArtifact) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes91(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MigratedArtifact) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes91(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes92(in *jlexer.Lexer, out *Metrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "requests":
+			(out.Requests).UnmarshalEasyJSON(in)
+		case "performance":
+			(out.Performance).UnmarshalEasyJSON(in)
+		case "memory":
+			(out.Memory).UnmarshalEasyJSON(in)
+		case "upstreams":
+			if in.IsNull() {
+				in.Skip()
+				out.Upstreams = nil
+			} else {
+				in.Delim('[')
+				if out.Upstreams == nil {
+					if !in.IsDelim(']') {
+						out.Upstreams = make([]UpstreamMetrics, 0, 0)
+					} else {
+						out.Upstreams = []UpstreamMetrics{}
+					}
+				} else {
+					out.Upstreams = (out.Upstreams)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v191 UpstreamMetrics
+					(v191).UnmarshalEasyJSON(in)
+					out.Upstreams = append(out.Upstreams, v191)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "bandwidth":
+			if in.IsNull() {
+				in.Skip()
+				out.Bandwidth = nil
+			} else {
+				if out.Bandwidth == nil {
+					out.Bandwidth = new(BandwidthMetrics)
+				}
+				(*out.Bandwidth).UnmarshalEasyJSON(in)
+			}
+		case "connections":
+			if in.IsNull() {
+				in.Skip()
+				out.Connections = nil
+			} else {
+				if out.Connections == nil {
+					out.Connections = new(ConnectionMetrics)
+				}
+				(*out.Connections).UnmarshalEasyJSON(in)
+			}
+		case "read_cache":
+			if in.IsNull() {
+				in.Skip()
+				out.ReadCache = nil
+			} else {
+				if out.ReadCache == nil {
+					out.ReadCache = new(ReadCacheMetrics)
+				}
+				(*out.ReadCache).UnmarshalEasyJSON(in)
+			}
+		case "metadata_cache":
+			if in.IsNull() {
+				in.Skip()
+				out.MetadataCache = nil
+			} else {
+				if out.MetadataCache == nil {
+					out.MetadataCache = new(MetadataCacheMetrics)
+				}
+				(*out.MetadataCache).UnmarshalEasyJSON(in)
+			}
+		case "workers":
+			if in.IsNull() {
+				in.Skip()
+				out.Workers = nil
+			} else {
+				if out.Workers == nil {
+					out.Workers = new(WorkerMetrics)
+				}
+				(*out.Workers).UnmarshalEasyJSON(in)
+			}
+		case "storage_breaker":
+			if in.IsNull() {
+				in.Skip()
+				out.StorageBreaker = nil
+			} else {
+				if out.StorageBreaker == nil {
+					out.StorageBreaker = new(BreakerMetrics)
+				}
+				(*out.StorageBreaker).UnmarshalEasyJSON(in)
+			}
+		case "compression":
+			if in.IsNull() {
+				in.Skip()
+				out.Compression = nil
+			} else {
+				if out.Compression == nil {
+					out.Compression = new(CompressionMetrics)
+				}
+				(*out.Compression).UnmarshalEasyJSON(in)
+			}
+		case "replication":
+			if in.IsNull() {
+				in.Skip()
+				out.Replication = nil
+			} else {
+				if out.Replication == nil {
+					out.Replication = new(ReplicationMetrics)
+				}
+				(*out.Replication).UnmarshalEasyJSON(in)
+			}
+		case "index":
+			if in.IsNull() {
+				in.Skip()
+				out.Index = nil
+			} else {
+				if out.Index == nil {
+					out.Index = new(IndexMetrics)
+				}
+				(*out.Index).UnmarshalEasyJSON(in)
+			}
+		case "lifetime":
+			if in.IsNull() {
+				in.Skip()
+				out.Lifetime = nil
+			} else {
+				if out.Lifetime == nil {
+					out.Lifetime = new(LifetimeStats)
+				}
+				(*out.Lifetime).UnmarshalEasyJSON(in)
+			}
+		case "cluster":
+			if in.IsNull() {
+				in.Skip()
+				out.Cluster = nil
+			} else {
+				if out.Cluster == nil {
+					out.Cluster = new(ClusterMetrics)
+				}
+				(*out.Cluster).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes92(out *jwriter.Writer, in Metrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"requests\":"
+		out.RawString(prefix[1:])
+		(in.Requests).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"performance\":"
+		out.RawString(prefix)
+		(in.Performance).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"memory\":"
+		out.RawString(prefix)
+		(in.Memory).MarshalEasyJSON(out)
+	}
+	if len(in.Upstreams) != 0 {
+		const prefix string = ",\"upstreams\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v192, v193 := range in.Upstreams {
+				if v192 > 0 {
+					out.RawByte(',')
+				}
+				(v193).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Bandwidth != nil {
+		const prefix string = ",\"bandwidth\":"
+		out.RawString(prefix)
+		(*in.Bandwidth).MarshalEasyJSON(out)
+	}
+	if in.Connections != nil {
+		const prefix string = ",\"connections\":"
+		out.RawString(prefix)
+		(*in.Connections).MarshalEasyJSON(out)
+	}
+	if in.ReadCache != nil {
+		const prefix string = ",\"read_cache\":"
+		out.RawString(prefix)
+		(*in.ReadCache).MarshalEasyJSON(out)
+	}
+	if in.MetadataCache != nil {
+		const prefix string = ",\"metadata_cache\":"
+		out.RawString(prefix)
+		(*in.MetadataCache).MarshalEasyJSON(out)
+	}
+	if in.Workers != nil {
+		const prefix string = ",\"workers\":"
+		out.RawString(prefix)
+		(*in.Workers).MarshalEasyJSON(out)
+	}
+	if in.StorageBreaker != nil {
+		const prefix string = ",\"storage_breaker\":"
+		out.RawString(prefix)
+		(*in.StorageBreaker).MarshalEasyJSON(out)
+	}
+	if in.Compression != nil {
+		const prefix string = ",\"compression\":"
+		out.RawString(prefix)
+		(*in.Compression).MarshalEasyJSON(out)
+	}
+	if in.Replication != nil {
+		const prefix string = ",\"replication\":"
+		out.RawString(prefix)
+		(*in.Replication).MarshalEasyJSON(out)
+	}
+	if in.Index != nil {
+		const prefix string = ",\"index\":"
+		out.RawString(prefix)
+		(*in.Index).MarshalEasyJSON(out)
+	}
+	if in.Lifetime != nil {
+		const prefix string = ",\"lifetime\":"
+		out.RawString(prefix)
+		(*in.Lifetime).MarshalEasyJSON(out)
+	}
+	if in.Cluster != nil {
+		const prefix string = ",\"cluster\":"
+		out.RawString(prefix)
+		(*in.Cluster).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Metrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes92(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Metrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes92(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Metrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes92(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Metrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes92(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes93(in *jlexer.Lexer, out *MetadataGeneration) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "created":
+			out.Created = string(in.String())
+		case "files":
+			out.Files = int(in.Int())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "current":
+			out.Current = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes93(out *jwriter.Writer, in MetadataGeneration) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		out.Int(int(in.Files))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"current\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Current))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MetadataGeneration) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes93(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MetadataGeneration) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes93(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MetadataGeneration) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes93(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MetadataGeneration) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes93(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes94(in *jlexer.Lexer, out *MetadataCacheMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "max_size":
+			out.MaxSize = int64(in.Int64())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "entries":
+			out.Entries = int(in.Int())
+		case "hits":
+			out.Hits = int64(in.Int64())
+		case "misses":
+			out.Misses = int64(in.Int64())
+		case "shared":
+			out.Shared = int64(in.Int64())
+		case "stale":
+			out.Stale = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes94(out *jwriter.Writer, in MetadataCacheMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"max_size\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.MaxSize))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"entries\":"
+		out.RawString(prefix)
+		out.Int(int(in.Entries))
+	}
+	{
+		const prefix string = ",\"hits\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Hits))
+	}
+	{
+		const prefix string = ",\"misses\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Misses))
+	}
+	{
+		const prefix string = ",\"shared\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Shared))
+	}
+	{
+		const prefix string = ",\"stale\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Stale))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MetadataCacheMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes94(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MetadataCacheMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes94(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MetadataCacheMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes94(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MetadataCacheMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes94(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes95(in *jlexer.Lexer, out *Metadata) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "XMLName":
+			easyjson6601e8cdDecodeEncodingXml(in, &out.XMLName)
+		case "Packages":
+			if in.IsNull() {
+				in.Skip()
+				out.Packages = nil
+			} else {
+				in.Delim('[')
+				if out.Packages == nil {
+					if !in.IsDelim(']') {
+						out.Packages = make([]Package, 0, 0)
+					} else {
+						out.Packages = []Package{}
+					}
+				} else {
+					out.Packages = (out.Packages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v194 Package
+					(v194).UnmarshalEasyJSON(in)
+					out.Packages = append(out.Packages, v194)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes95(out *jwriter.Writer, in Metadata) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"XMLName\":"
+		out.RawString(prefix[1:])
+		easyjson6601e8cdEncodeEncodingXml(out, in.XMLName)
+	}
+	{
+		const prefix string = ",\"Packages\":"
+		out.RawString(prefix)
+		if in.Packages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v195, v196 := range in.Packages {
+				if v195 > 0 {
+					out.RawByte(',')
+				}
+				(v196).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Metadata) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes95(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Metadata) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes95(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Metadata) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes95(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Metadata) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes95(l, v)
+}
+func easyjson6601e8cdDecodeEncodingXml(in *jlexer.Lexer, out *xml.Name) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Space":
+			out.Space = string(in.String())
+		case "Local":
+			out.Local = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodeEncodingXml(out *jwriter.Writer, in xml.Name) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Space\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Space))
+	}
+	{
+		const prefix string = ",\"Local\":"
+		out.RawString(prefix)
+		out.String(string(in.Local))
+	}
+	out.RawByte('}')
+}
+func easyjson6601e8cdDecodePlusInternalTypes96(in *jlexer.Lexer, out *Memory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "alloc_mb":
+			out.AllocMB = uint64(in.Uint64())
+		case "total_alloc_mb":
+			out.TotalAllocMB = uint64(in.Uint64())
+		case "sys_mb":
+			out.SysMB = uint64(in.Uint64())
+		case "gc_cycles":
+			out.GCCycles = uint32(in.Uint32())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes96(out *jwriter.Writer, in Memory) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"alloc_mb\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.AllocMB))
+	}
+	{
+		const prefix string = ",\"total_alloc_mb\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.TotalAllocMB))
+	}
+	{
+		const prefix string = ",\"sys_mb\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.SysMB))
+	}
+	{
+		const prefix string = ",\"gc_cycles\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.GCCycles))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Memory) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes96(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Memory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes96(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Memory) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes96(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Memory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes96(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes97(in *jlexer.Lexer, out *MaintenanceStatus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "enabled":
+			out.Enabled = bool(in.Bool())
+		case "maintenance":
+			if in.IsNull() {
+				in.Skip()
+				out.Maintenance = nil
+			} else {
+				if out.Maintenance == nil {
+					out.Maintenance = new(Maintenance)
+				}
+				(*out.Maintenance).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes97(out *jwriter.Writer, in MaintenanceStatus) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"enabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Enabled))
+	}
+	if in.Maintenance != nil {
+		const prefix string = ",\"maintenance\":"
+		out.RawString(prefix)
+		(*in.Maintenance).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MaintenanceStatus) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes97(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MaintenanceStatus) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes97(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MaintenanceStatus) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes97(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MaintenanceStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes97(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes98(in *jlexer.Lexer, out *MaintenanceRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes98(out *jwriter.Writer, in MaintenanceRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MaintenanceRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes98(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MaintenanceRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes98(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MaintenanceRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes98(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MaintenanceRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes98(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes99(in *jlexer.Lexer, out *Maintenance) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message":
+			out.Message = string(in.String())
+		case "since":
+			out.Since = string(in.String())
+		case "by":
+			out.By = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes99(out *jwriter.Writer, in Maintenance) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"since\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Since))
+	}
+	if in.By != "" {
+		const prefix string = ",\"by\":"
+		out.RawString(prefix)
+		out.String(string(in.By))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Maintenance) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes99(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Maintenance) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes99(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Maintenance) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes99(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Maintenance) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes99(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes100(in *jlexer.Lexer, out *Location) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Href":
+			out.Href = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes100(out *jwriter.Writer, in Location) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Href\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Href))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Location) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes100(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Location) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes100(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Location) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes100(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Location) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes100(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes101(in *jlexer.Lexer, out *ListenerInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "address":
+			out.Address = string(in.String())
+		case "network":
+			out.Network = string(in.String())
+		case "tls":
+			out.TLS = bool(in.Bool())
+		case "systemd":
+			out.Systemd = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes101(out *jwriter.Writer, in ListenerInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"address\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Address))
+	}
+	{
+		const prefix string = ",\"network\":"
+		out.RawString(prefix)
+		out.String(string(in.Network))
+	}
+	{
+		const prefix string = ",\"tls\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.TLS))
+	}
+	if in.Systemd {
+		const prefix string = ",\"systemd\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Systemd))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ListenerInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes101(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ListenerInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes101(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ListenerInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes101(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ListenerInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes101(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes102(in *jlexer.Lexer, out *LifetimeStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "since":
+			out.Since = string(in.String())
+		case "saved_at":
+			out.SavedAt = string(in.String())
+		case "requests":
+			out.Requests = int64(in.Int64())
+		case "uploads":
+			out.Uploads = int64(in.Int64())
+		case "downloads":
+			out.Downloads = int64(in.Int64())
+		case "redirects":
+			out.Redirects = int64(in.Int64())
+		case "errors":
+			out.Errors = int64(in.Int64())
+		case "bytes_sent":
+			out.BytesSent = int64(in.Int64())
+		case "bytes_received":
+			out.BytesReceived = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes102(out *jwriter.Writer, in LifetimeStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"since\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Since))
+	}
+	if in.SavedAt != "" {
+		const prefix string = ",\"saved_at\":"
+		out.RawString(prefix)
+		out.String(string(in.SavedAt))
+	}
+	{
+		const prefix string = ",\"requests\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Requests))
+	}
+	{
+		const prefix string = ",\"uploads\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Uploads))
+	}
+	{
+		const prefix string = ",\"downloads\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Downloads))
+	}
+	{
+		const prefix string = ",\"redirects\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Redirects))
+	}
+	{
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Errors))
+	}
+	{
+		const prefix string = ",\"bytes_sent\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesSent))
+	}
+	{
+		const prefix string = ",\"bytes_received\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesReceived))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LifetimeStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes102(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LifetimeStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes102(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LifetimeStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes102(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LifetimeStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes102(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes103(in *jlexer.Lexer, out *LegalHoldResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "hold":
+			(out.Hold).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes103(out *jwriter.Writer, in LegalHoldResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"hold\":"
+		out.RawString(prefix)
+		(in.Hold).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LegalHoldResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes103(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LegalHoldResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes103(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LegalHoldResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes103(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LegalHoldResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes103(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes104(in *jlexer.Lexer, out *LegalHoldRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes104(out *jwriter.Writer, in LegalHoldRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LegalHoldRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes104(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LegalHoldRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes104(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LegalHoldRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes104(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LegalHoldRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes104(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes105(in *jlexer.Lexer, out *LegalHoldList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "holds":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Holds = make(map[string]LegalHold)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v197 LegalHold
+					(v197).UnmarshalEasyJSON(in)
+					(out.Holds)[key] = v197
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes105(out *jwriter.Writer, in LegalHoldList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"holds\":"
+		out.RawString(prefix)
+		if in.Holds == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v198First := true
+			for v198Name, v198Value := range in.Holds {
+				if v198First {
+					v198First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v198Name))
+				out.RawByte(':')
+				(v198Value).MarshalEasyJSON(out)
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LegalHoldList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes105(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LegalHoldList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes105(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LegalHoldList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes105(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LegalHoldList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes105(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes106(in *jlexer.Lexer, out *LegalHold) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reason":
+			out.Reason = string(in.String())
+		case "by":
+			out.By = string(in.String())
+		case "since":
+			out.Since = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes106(out *jwriter.Writer, in LegalHold) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Reason))
+	}
+	if in.By != "" {
+		const prefix string = ",\"by\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.By))
+	}
+	{
+		const prefix string = ",\"since\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Since))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LegalHold) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes106(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LegalHold) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes106(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LegalHold) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes106(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LegalHold) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes106(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes107(in *jlexer.Lexer, out *KeyList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "keys":
+			if in.IsNull() {
+				in.Skip()
+				out.Keys = nil
+			} else {
+				in.Delim('[')
+				if out.Keys == nil {
+					if !in.IsDelim(']') {
+						out.Keys = make([]KeyInfo, 0, 0)
+					} else {
+						out.Keys = []KeyInfo{}
+					}
+				} else {
+					out.Keys = (out.Keys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v199 KeyInfo
+					(v199).UnmarshalEasyJSON(in)
+					out.Keys = append(out.Keys, v199)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "count":
+			out.Count = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes107(out *jwriter.Writer, in KeyList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"keys\":"
+		out.RawString(prefix)
+		if in.Keys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v200, v201 := range in.Keys {
+				if v200 > 0 {
+					out.RawByte(',')
+				}
+				(v201).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v KeyList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes107(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v KeyList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes107(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *KeyList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes107(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *KeyList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes107(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes108(in *jlexer.Lexer, out *KeyInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "fingerprint":
+			out.Fingerprint = string(in.String())
+		case "key_id":
+			out.KeyID = string(in.String())
+		case "user_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.UserIDs = nil
+			} else {
+				in.Delim('[')
+				if out.UserIDs == nil {
+					if !in.IsDelim(']') {
+						out.UserIDs = make([]string, 0, 4)
+					} else {
+						out.UserIDs = []string{}
+					}
+				} else {
+					out.UserIDs = (out.UserIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v202 string
+					v202 = string(in.String())
+					out.UserIDs = append(out.UserIDs, v202)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "created":
+			out.Created = string(in.String())
+		case "default":
+			out.Default = bool(in.Bool())
+		case "url":
+			out.URL = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes108(out *jwriter.Writer, in KeyInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"fingerprint\":"
+		out.RawString(prefix)
+		out.String(string(in.Fingerprint))
+	}
+	{
+		const prefix string = ",\"key_id\":"
+		out.RawString(prefix)
+		out.String(string(in.KeyID))
+	}
+	{
+		const prefix string = ",\"user_ids\":"
+		out.RawString(prefix)
+		if in.UserIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v203, v204 := range in.UserIDs {
+				if v203 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v204))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	{
+		const prefix string = ",\"default\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Default))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v KeyInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes108(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v KeyInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes108(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *KeyInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes108(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *KeyInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes108(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes109(in *jlexer.Lexer, out *JournalEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "op":
+			out.Op = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "source":
+			out.Source = string(in.String())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]string, 0, 4)
+					} else {
+						out.Files = []string{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v205 string
+					v205 = string(in.String())
+					out.Files = append(out.Files, v205)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "started":
+			out.Started = string(in.String())
+		case "node":
+			out.Node = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes109(out *jwriter.Writer, in JournalEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"op\":"
+		out.RawString(prefix)
+		out.String(string(in.Op))
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	if in.Source != "" {
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	if len(in.Files) != 0 {
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v206, v207 := range in.Files {
+				if v206 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v207))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"started\":"
+		out.RawString(prefix)
+		out.String(string(in.Started))
+	}
+	if in.Node != "" {
+		const prefix string = ",\"node\":"
+		out.RawString(prefix)
+		out.String(string(in.Node))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JournalEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes109(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JournalEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes109(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JournalEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes109(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JournalEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes109(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes110(in *jlexer.Lexer, out *JobStatus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "scope":
+			out.Scope = string(in.String())
+		case "interval":
+			out.Interval = string(in.String())
+		case "schedule":
+			out.Schedule = string(in.String())
+		case "state":
+			out.State = string(in.String())
+		case "next_run":
+			out.NextRun = string(in.String())
+		case "last_start":
+			out.LastStart = string(in.String())
+		case "last_end":
+			out.LastEnd = string(in.String())
+		case "last_duration_ms":
+			out.LastDurationMs = int64(in.Int64())
+		case "last_error":
+			out.LastError = string(in.String())
+		case "runs":
+			out.Runs = int64(in.Int64())
+		case "failures":
+			out.Failures = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes110(out *jwriter.Writer, in JobStatus) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"scope\":"
+		out.RawString(prefix)
+		out.String(string(in.Scope))
+	}
+	if in.Interval != "" {
+		const prefix string = ",\"interval\":"
+		out.RawString(prefix)
+		out.String(string(in.Interval))
+	}
+	if in.Schedule != "" {
+		const prefix string = ",\"schedule\":"
+		out.RawString(prefix)
+		out.String(string(in.Schedule))
+	}
+	{
+		const prefix string = ",\"state\":"
+		out.RawString(prefix)
+		out.String(string(in.State))
+	}
+	if in.NextRun != "" {
+		const prefix string = ",\"next_run\":"
+		out.RawString(prefix)
+		out.String(string(in.NextRun))
+	}
+	if in.LastStart != "" {
+		const prefix string = ",\"last_start\":"
+		out.RawString(prefix)
+		out.String(string(in.LastStart))
+	}
+	if in.LastEnd != "" {
+		const prefix string = ",\"last_end\":"
+		out.RawString(prefix)
+		out.String(string(in.LastEnd))
+	}
+	if in.LastDurationMs != 0 {
+		const prefix string = ",\"last_duration_ms\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.LastDurationMs))
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	{
+		const prefix string = ",\"runs\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Runs))
+	}
+	{
+		const prefix string = ",\"failures\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Failures))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JobStatus) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes110(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JobStatus) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes110(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JobStatus) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes110(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JobStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes110(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes111(in *jlexer.Lexer, out *JobRun) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "job":
+			out.Job = string(in.String())
+		case "started":
+			out.Started = string(in.String())
+		case "finished":
+			out.Finished = string(in.String())
+		case "duration_ms":
+			out.DurationMs = int64(in.Int64())
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes111(out *jwriter.Writer, in JobRun) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"job\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Job))
+	}
+	{
+		const prefix string = ",\"started\":"
+		out.RawString(prefix)
+		out.String(string(in.Started))
+	}
+	{
+		const prefix string = ",\"finished\":"
+		out.RawString(prefix)
+		out.String(string(in.Finished))
+	}
+	{
+		const prefix string = ",\"duration_ms\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.DurationMs))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JobRun) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes111(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JobRun) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes111(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JobRun) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes111(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JobRun) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes111(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes112(in *jlexer.Lexer, out *JobList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "node":
+			out.Node = string(in.String())
+		case "leader":
+			out.Leader = bool(in.Bool())
+		case "jobs":
+			if in.IsNull() {
+				in.Skip()
+				out.Jobs = nil
+			} else {
+				in.Delim('[')
+				if out.Jobs == nil {
+					if !in.IsDelim(']') {
+						out.Jobs = make([]JobStatus, 0, 0)
+					} else {
+						out.Jobs = []JobStatus{}
+					}
+				} else {
+					out.Jobs = (out.Jobs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v208 JobStatus
+					(v208).UnmarshalEasyJSON(in)
+					out.Jobs = append(out.Jobs, v208)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "recent":
+			if in.IsNull() {
+				in.Skip()
+				out.Recent = nil
+			} else {
+				in.Delim('[')
+				if out.Recent == nil {
+					if !in.IsDelim(']') {
+						out.Recent = make([]JobRun, 0, 0)
+					} else {
+						out.Recent = []JobRun{}
+					}
+				} else {
+					out.Recent = (out.Recent)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v209 JobRun
+					(v209).UnmarshalEasyJSON(in)
+					out.Recent = append(out.Recent, v209)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes112(out *jwriter.Writer, in JobList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Node != "" {
+		const prefix string = ",\"node\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Node))
+	}
+	{
+		const prefix string = ",\"leader\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Leader))
+	}
+	{
+		const prefix string = ",\"jobs\":"
+		out.RawString(prefix)
+		if in.Jobs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v210, v211 := range in.Jobs {
+				if v210 > 0 {
+					out.RawByte(',')
+				}
+				(v211).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"recent\":"
+		out.RawString(prefix)
+		if in.Recent == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v212, v213 := range in.Recent {
+				if v212 > 0 {
+					out.RawByte(',')
+				}
+				(v213).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JobList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes112(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JobList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes112(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JobList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes112(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JobList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes112(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes113(in *jlexer.Lexer, out *InstallTreeReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "valid":
+			out.Valid = bool(in.Bool())
+		case "family":
+			out.Family = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "images":
+			if in.IsNull() {
+				in.Skip()
+				out.Images = nil
+			} else {
+				in.Delim('[')
+				if out.Images == nil {
+					if !in.IsDelim(']') {
+						out.Images = make([]TreeImage, 0, 0)
+					} else {
+						out.Images = []TreeImage{}
+					}
+				} else {
+					out.Images = (out.Images)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v214 TreeImage
+					(v214).UnmarshalEasyJSON(in)
+					out.Images = append(out.Images, v214)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]string, 0, 4)
+					} else {
+						out.Errors = []string{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v215 string
+					v215 = string(in.String())
+					out.Errors = append(out.Errors, v215)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes113(out *jwriter.Writer, in InstallTreeReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"valid\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Valid))
+	}
+	{
+		const prefix string = ",\"family\":"
+		out.RawString(prefix)
+		out.String(string(in.Family))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"images\":"
+		out.RawString(prefix)
+		if in.Images == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v216, v217 := range in.Images {
+				if v216 > 0 {
+					out.RawByte(',')
+				}
+				(v217).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Errors) != 0 {
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v218, v219 := range in.Errors {
+				if v218 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v219))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InstallTreeReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes113(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InstallTreeReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes113(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InstallTreeReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes113(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InstallTreeReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes113(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes114(in *jlexer.Lexer, out *IndexRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "op":
+			out.Op = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "artifact":
+			if in.IsNull() {
+				in.Skip()
+				out.Artifact = nil
+			} else {
+				if out.Artifact == nil {
+					out.Artifact = new(Artifact)
+				}
+				(*out.Artifact).UnmarshalEasyJSON(in)
+			}
+		case "time":
+			out.Time = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes114(out *jwriter.Writer, in IndexRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"op\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Op))
+	}
+	if in.Repo != "" {
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	if in.Type != "" {
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	if in.Path != "" {
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	if in.Artifact != nil {
+		const prefix string = ",\"artifact\":"
+		out.RawString(prefix)
+		(*in.Artifact).MarshalEasyJSON(out)
+	}
+	if in.Time != "" {
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		out.String(string(in.Time))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v IndexRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes114(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v IndexRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes114(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *IndexRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes114(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *IndexRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes114(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes115(in *jlexer.Lexer, out *IndexMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ready":
+			out.Ready = bool(in.Bool())
+		case "repos":
+			out.Repos = int(in.Int())
+		case "artifacts":
+			out.Artifacts = int(in.Int())
+		case "bytes":
+			out.Bytes = int64(in.Int64())
+		case "journal_records":
+			out.JournalRecords = int(in.Int())
+		case "reconciles":
+			out.Reconciles = int64(in.Int64())
+		case "last_reconcile":
+			out.LastReconcile = string(in.String())
+		case "reconcile_seconds":
+			out.ReconcileSeconds = float64(in.Float64())
+		case "last_error":
+			out.LastError = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes115(out *jwriter.Writer, in IndexMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ready\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Ready))
+	}
+	{
+		const prefix string = ",\"repos\":"
+		out.RawString(prefix)
+		out.Int(int(in.Repos))
+	}
+	{
+		const prefix string = ",\"artifacts\":"
+		out.RawString(prefix)
+		out.Int(int(in.Artifacts))
+	}
+	{
+		const prefix string = ",\"bytes\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Bytes))
+	}
+	{
+		const prefix string = ",\"journal_records\":"
+		out.RawString(prefix)
+		out.Int(int(in.JournalRecords))
+	}
+	{
+		const prefix string = ",\"reconciles\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Reconciles))
+	}
+	if in.LastReconcile != "" {
+		const prefix string = ",\"last_reconcile\":"
+		out.RawString(prefix)
+		out.String(string(in.LastReconcile))
+	}
+	{
+		const prefix string = ",\"reconcile_seconds\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ReconcileSeconds))
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v IndexMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes115(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v IndexMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes115(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *IndexMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes115(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *IndexMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes115(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes116(in *jlexer.Lexer, out *InTotoSubject) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "digest":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Digest = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v220 string
+					v220 = string(in.String())
+					(out.Digest)[key] = v220
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes116(out *jwriter.Writer, in InTotoSubject) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		if in.Digest == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v221First := true
+			for v221Name, v221Value := range in.Digest {
+				if v221First {
+					v221First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v221Name))
+				out.RawByte(':')
+				out.String(string(v221Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InTotoSubject) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes116(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InTotoSubject) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes116(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InTotoSubject) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes116(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InTotoSubject) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes116(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes117(in *jlexer.Lexer, out *InTotoStatement) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "_type":
+			out.Type = string(in.String())
+		case "subject":
+			if in.IsNull() {
+				in.Skip()
+				out.Subject = nil
+			} else {
+				in.Delim('[')
+				if out.Subject == nil {
+					if !in.IsDelim(']') {
+						out.Subject = make([]InTotoSubject, 0, 2)
+					} else {
+						out.Subject = []InTotoSubject{}
+					}
+				} else {
+					out.Subject = (out.Subject)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v222 InTotoSubject
+					(v222).UnmarshalEasyJSON(in)
+					out.Subject = append(out.Subject, v222)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "predicateType":
+			out.PredicateType = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes117(out *jwriter.Writer, in InTotoStatement) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"_type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"subject\":"
+		out.RawString(prefix)
+		if in.Subject == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v223, v224 := range in.Subject {
+				if v223 > 0 {
+					out.RawByte(',')
+				}
+				(v224).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"predicateType\":"
+		out.RawString(prefix)
+		out.String(string(in.PredicateType))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InTotoStatement) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes117(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InTotoStatement) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes117(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InTotoStatement) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes117(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InTotoStatement) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes117(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes118(in *jlexer.Lexer, out *ImportedRepo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "repo":
+			out.Repo = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "layout":
+			out.Layout = string(in.String())
+		case "source":
+			out.Source = string(in.String())
+		case "packages":
+			out.Packages = int(in.Int())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "skipped":
+			if in.IsNull() {
+				in.Skip()
+				out.Skipped = nil
+			} else {
+				in.Delim('[')
+				if out.Skipped == nil {
+					if !in.IsDelim(']') {
+						out.Skipped = make([]string, 0, 4)
+					} else {
+						out.Skipped = []string{}
+					}
+				} else {
+					out.Skipped = (out.Skipped)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v225 string
+					v225 = string(in.String())
+					out.Skipped = append(out.Skipped, v225)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "metadata":
+			out.Metadata = string(in.String())
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes118(out *jwriter.Writer, in ImportedRepo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"layout\":"
+		out.RawString(prefix)
+		out.String(string(in.Layout))
+	}
+	{
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	{
+		const prefix string = ",\"packages\":"
+		out.RawString(prefix)
+		out.Int(int(in.Packages))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	if len(in.Skipped) != 0 {
+		const prefix string = ",\"skipped\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v226, v227 := range in.Skipped {
+				if v226 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v227))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"metadata\":"
+		out.RawString(prefix)
+		out.String(string(in.Metadata))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportedRepo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes118(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportedRepo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes118(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportedRepo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes118(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportedRepo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes118(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes119(in *jlexer.Lexer, out *ImportResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "created":
+			out.Created = int(in.Int())
+		case "updated":
+			out.Updated = int(in.Int())
+		case "disabled":
+			out.Disabled = int(in.Int())
+		case "groups_created":
+			out.Groups = int(in.Int())
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]string, 0, 4)
+					} else {
+						out.Errors = []string{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v228 string
+					v228 = string(in.String())
+					out.Errors = append(out.Errors, v228)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes119(out *jwriter.Writer, in ImportResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Created))
+	}
+	{
+		const prefix string = ",\"updated\":"
+		out.RawString(prefix)
+		out.Int(int(in.Updated))
+	}
+	{
+		const prefix string = ",\"disabled\":"
+		out.RawString(prefix)
+		out.Int(int(in.Disabled))
+	}
+	{
+		const prefix string = ",\"groups_created\":"
+		out.RawString(prefix)
+		out.Int(int(in.Groups))
+	}
+	if len(in.Errors) != 0 {
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v229, v230 := range in.Errors {
+				if v229 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v230))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes119(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes119(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes119(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes119(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes120(in *jlexer.Lexer, out *ImportRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "path":
+			out.Path = string(in.String())
+		case "prefix":
+			out.Prefix = string(in.String())
+		case "regenerate":
+			out.Regenerate = bool(in.Bool())
+		case "dry_run":
+			out.DryRun = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes120(out *jwriter.Writer, in ImportRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"prefix\":"
+		out.RawString(prefix)
+		out.String(string(in.Prefix))
+	}
+	{
+		const prefix string = ",\"regenerate\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Regenerate))
+	}
+	{
+		const prefix string = ",\"dry_run\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.DryRun))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes120(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes120(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes120(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes120(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes121(in *jlexer.Lexer, out *ImportReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "path":
+			out.Path = string(in.String())
+		case "dry_run":
+			out.DryRun = bool(in.Bool())
+		case "imported":
+			out.Imported = int(in.Int())
+		case "failed":
+			out.Failed = int(in.Int())
+		case "repos":
+			if in.IsNull() {
+				in.Skip()
+				out.Repos = nil
+			} else {
+				in.Delim('[')
+				if out.Repos == nil {
+					if !in.IsDelim(']') {
+						out.Repos = make([]ImportedRepo, 0, 0)
+					} else {
+						out.Repos = []ImportedRepo{}
+					}
+				} else {
+					out.Repos = (out.Repos)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v231 ImportedRepo
+					(v231).UnmarshalEasyJSON(in)
+					out.Repos = append(out.Repos, v231)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes121(out *jwriter.Writer, in ImportReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"dry_run\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.DryRun))
+	}
+	{
+		const prefix string = ",\"imported\":"
+		out.RawString(prefix)
+		out.Int(int(in.Imported))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int(int(in.Failed))
+	}
+	{
+		const prefix string = ",\"repos\":"
+		out.RawString(prefix)
+		if in.Repos == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v232, v233 := range in.Repos {
+				if v232 > 0 {
+					out.RawByte(',')
+				}
+				(v233).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes121(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes121(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes121(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes121(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes122(in *jlexer.Lexer, out *Identity) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "authenticated":
+			out.Authenticated = bool(in.Bool())
+		case "name":
+			out.Name = string(in.String())
+		case "admin":
+			out.Admin = bool(in.Bool())
+		case "permissions":
+			if in.IsNull() {
+				in.Skip()
+				out.Permissions = nil
+			} else {
+				in.Delim('[')
+				if out.Permissions == nil {
+					if !in.IsDelim(']') {
+						out.Permissions = make([]Permission, 0, 1)
+					} else {
+						out.Permissions = []Permission{}
+					}
+				} else {
+					out.Permissions = (out.Permissions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v234 Permission
+					(v234).UnmarshalEasyJSON(in)
+					out.Permissions = append(out.Permissions, v234)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes122(out *jwriter.Writer, in Identity) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"authenticated\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Authenticated))
+	}
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"admin\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Admin))
+	}
+	{
+		const prefix string = ",\"permissions\":"
+		out.RawString(prefix)
+		if in.Permissions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v235, v236 := range in.Permissions {
+				if v235 > 0 {
+					out.RawByte(',')
+				}
+				(v236).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Identity) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes122(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Identity) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes122(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Identity) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes122(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Identity) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes122(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes123(in *jlexer.Lexer, out *HealthCheck) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "server":
+			out.Server = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "code":
+			out.Code = int(in.Int())
+		case "listeners":
+			if in.IsNull() {
+				in.Skip()
+				out.Listeners = nil
+			} else {
+				in.Delim('[')
+				if out.Listeners == nil {
+					if !in.IsDelim(']') {
+						out.Listeners = make([]ListenerInfo, 0, 1)
+					} else {
+						out.Listeners = []ListenerInfo{}
+					}
+				} else {
+					out.Listeners = (out.Listeners)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v237 ListenerInfo
+					(v237).UnmarshalEasyJSON(in)
+					out.Listeners = append(out.Listeners, v237)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes123(out *jwriter.Writer, in HealthCheck) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"server\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Server))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.Int(int(in.Code))
+	}
+	if len(in.Listeners) != 0 {
+		const prefix string = ",\"listeners\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v238, v239 := range in.Listeners {
+				if v238 > 0 {
+					out.RawByte(',')
+				}
+				(v239).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v HealthCheck) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes123(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v HealthCheck) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes123(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *HealthCheck) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes123(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *HealthCheck) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes123(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes124(in *jlexer.Lexer, out *GroupList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "groups":
+			if in.IsNull() {
+				in.Skip()
+				out.Groups = nil
+			} else {
+				in.Delim('[')
+				if out.Groups == nil {
+					if !in.IsDelim(']') {
+						out.Groups = make([]Group, 0, 0)
+					} else {
+						out.Groups = []Group{}
+					}
+				} else {
+					out.Groups = (out.Groups)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v240 Group
+					(v240).UnmarshalEasyJSON(in)
+					out.Groups = append(out.Groups, v240)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes124(out *jwriter.Writer, in GroupList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"groups\":"
+		out.RawString(prefix[1:])
+		if in.Groups == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v241, v242 := range in.Groups {
+				if v241 > 0 {
+					out.RawByte(',')
+				}
+				(v242).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GroupList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes124(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GroupList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes124(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GroupList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes124(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GroupList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes124(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes125(in *jlexer.Lexer, out *Group) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]string, 0, 4)
+					} else {
+						out.Roles = []string{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v243 string
+					v243 = string(in.String())
+					out.Roles = append(out.Roles, v243)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "source":
+			out.Source = string(in.String())
+		case "members":
+			if in.IsNull() {
+				in.Skip()
+				out.Members = nil
+			} else {
+				in.Delim('[')
+				if out.Members == nil {
+					if !in.IsDelim(']') {
+						out.Members = make([]string, 0, 4)
+					} else {
+						out.Members = []string{}
+					}
+				} else {
+					out.Members = (out.Members)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v244 string
+					v244 = string(in.String())
+					out.Members = append(out.Members, v244)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes125(out *jwriter.Writer, in Group) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix)
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v245, v246 := range in.Roles {
+				if v245 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v246))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Source != "" {
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	if len(in.Members) != 0 {
+		const prefix string = ",\"members\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v247, v248 := range in.Members {
+				if v247 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v248))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Group) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes125(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Group) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes125(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Group) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes125(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Group) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes125(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes126(in *jlexer.Lexer, out *GenerationList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "keep":
+			out.Keep = int(in.Int())
+		case "generations":
+			if in.IsNull() {
+				in.Skip()
+				out.Generations = nil
+			} else {
+				in.Delim('[')
+				if out.Generations == nil {
+					if !in.IsDelim(']') {
+						out.Generations = make([]MetadataGeneration, 0, 1)
+					} else {
+						out.Generations = []MetadataGeneration{}
+					}
+				} else {
+					out.Generations = (out.Generations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v249 MetadataGeneration
+					(v249).UnmarshalEasyJSON(in)
+					out.Generations = append(out.Generations, v249)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes126(out *jwriter.Writer, in GenerationList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"keep\":"
+		out.RawString(prefix)
+		out.Int(int(in.Keep))
+	}
+	{
+		const prefix string = ",\"generations\":"
+		out.RawString(prefix)
+		if in.Generations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v250, v251 := range in.Generations {
+				if v250 > 0 {
+					out.RawByte(',')
+				}
+				(v251).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GenerationList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes126(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GenerationList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes126(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GenerationList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes126(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GenerationList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes126(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes127(in *jlexer.Lexer, out *FsckReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "healthy":
+			out.Healthy = bool(in.Bool())
+		case "referenced":
+			out.Referenced = int(in.Int())
+		case "checked":
+			out.Checked = int(in.Int())
+		case "missing":
+			out.Missing = int(in.Int())
+		case "corrupt":
+			out.Corrupt = int(in.Int())
+		case "orphans":
+			out.Orphans = int(in.Int())
+		case "issues":
+			if in.IsNull() {
+				in.Skip()
+				out.Issues = nil
+			} else {
+				in.Delim('[')
+				if out.Issues == nil {
+					if !in.IsDelim(']') {
+						out.Issues = make([]FsckIssue, 0, 0)
+					} else {
+						out.Issues = []FsckIssue{}
+					}
+				} else {
+					out.Issues = (out.Issues)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v252 FsckIssue
+					(v252).UnmarshalEasyJSON(in)
+					out.Issues = append(out.Issues, v252)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "repaired":
+			out.Repaired = bool(in.Bool())
+		case "duration":
+			out.Duration = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes127(out *jwriter.Writer, in FsckReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"healthy\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Healthy))
+	}
+	{
+		const prefix string = ",\"referenced\":"
+		out.RawString(prefix)
+		out.Int(int(in.Referenced))
+	}
+	{
+		const prefix string = ",\"checked\":"
+		out.RawString(prefix)
+		out.Int(int(in.Checked))
+	}
+	{
+		const prefix string = ",\"missing\":"
+		out.RawString(prefix)
+		out.Int(int(in.Missing))
+	}
+	{
+		const prefix string = ",\"corrupt\":"
+		out.RawString(prefix)
+		out.Int(int(in.Corrupt))
+	}
+	{
+		const prefix string = ",\"orphans\":"
+		out.RawString(prefix)
+		out.Int(int(in.Orphans))
+	}
+	{
+		const prefix string = ",\"issues\":"
+		out.RawString(prefix)
+		if in.Issues == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v253, v254 := range in.Issues {
+				if v253 > 0 {
+					out.RawByte(',')
+				}
+				(v254).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"repaired\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Repaired))
+	}
+	{
+		const prefix string = ",\"duration\":"
+		out.RawString(prefix)
+		out.String(string(in.Duration))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FsckReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes127(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FsckReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes127(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FsckReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes127(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FsckReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes127(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes128(in *jlexer.Lexer, out *FsckIssue) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "expected":
+			out.Expected = string(in.String())
+		case "actual":
+			out.Actual = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes128(out *jwriter.Writer, in FsckIssue) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	if in.Expected != "" {
+		const prefix string = ",\"expected\":"
+		out.RawString(prefix)
+		out.String(string(in.Expected))
+	}
+	if in.Actual != "" {
+		const prefix string = ",\"actual\":"
+		out.RawString(prefix)
+		out.String(string(in.Actual))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FsckIssue) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes128(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FsckIssue) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes128(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FsckIssue) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes128(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FsckIssue) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes128(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes129(in *jlexer.Lexer, out *FileList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "glob":
+			out.Glob = string(in.String())
+		case "recursive":
+			out.Recursive = bool(in.Bool())
+		case "count":
+			out.Count = int(in.Int())
+		case "truncated":
+			out.Truncated = bool(in.Bool())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]DirectoryEntry, 0, 0)
+					} else {
+						out.Files = []DirectoryEntry{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v255 DirectoryEntry
+					(v255).UnmarshalEasyJSON(in)
+					out.Files = append(out.Files, v255)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes129(out *jwriter.Writer, in FileList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"glob\":"
+		out.RawString(prefix)
+		out.String(string(in.Glob))
+	}
+	{
+		const prefix string = ",\"recursive\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Recursive))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"truncated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Truncated))
+	}
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		if in.Files == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v256, v257 := range in.Files {
+				if v256 > 0 {
+					out.RawByte(',')
+				}
+				(v257).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FileList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes129(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FileList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes129(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FileList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes129(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FileList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes129(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes130(in *jlexer.Lexer, out *FeatureResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "feature":
+			(out.Feature).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes130(out *jwriter.Writer, in FeatureResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"feature\":"
+		out.RawString(prefix)
+		(in.Feature).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FeatureResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes130(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FeatureResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes130(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FeatureResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes130(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FeatureResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes130(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes131(in *jlexer.Lexer, out *FeatureRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "enabled":
+			if in.IsNull() {
+				in.Skip()
+				out.Enabled = nil
+			} else {
+				if out.Enabled == nil {
+					out.Enabled = new(bool)
+				}
+				*out.Enabled = bool(in.Bool())
+			}
+		case "rollout":
+			if in.IsNull() {
+				in.Skip()
+				out.Rollout = nil
+			} else {
+				if out.Rollout == nil {
+					out.Rollout = new(int)
+				}
+				*out.Rollout = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes131(out *jwriter.Writer, in FeatureRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"enabled\":"
+		out.RawString(prefix[1:])
+		if in.Enabled == nil {
+			out.RawString("null")
+		} else {
+			out.Bool(bool(*in.Enabled))
+		}
+	}
+	{
+		const prefix string = ",\"rollout\":"
+		out.RawString(prefix)
+		if in.Rollout == nil {
+			out.RawString("null")
+		} else {
+			out.Int(int(*in.Rollout))
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FeatureRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes131(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FeatureRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes131(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FeatureRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes131(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FeatureRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes131(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes132(in *jlexer.Lexer, out *FeatureList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "count":
+			out.Count = int(in.Int())
+		case "features":
+			if in.IsNull() {
+				in.Skip()
+				out.Features = nil
+			} else {
+				in.Delim('[')
+				if out.Features == nil {
+					if !in.IsDelim(']') {
+						out.Features = make([]FeatureFlag, 0, 0)
+					} else {
+						out.Features = []FeatureFlag{}
+					}
+				} else {
+					out.Features = (out.Features)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v258 FeatureFlag
+					(v258).UnmarshalEasyJSON(in)
+					out.Features = append(out.Features, v258)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes132(out *jwriter.Writer, in FeatureList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"features\":"
+		out.RawString(prefix)
+		if in.Features == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v259, v260 := range in.Features {
+				if v259 > 0 {
+					out.RawByte(',')
+				}
+				(v260).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FeatureList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes132(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FeatureList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes132(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FeatureList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes132(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FeatureList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes132(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes133(in *jlexer.Lexer, out *FeatureFlag) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "enabled":
+			out.Enabled = bool(in.Bool())
+		case "rollout":
+			out.Rollout = int(in.Int())
+		case "source":
+			out.Source = string(in.String())
+		case "by":
+			out.By = string(in.String())
+		case "updated":
+			out.Updated = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes133(out *jwriter.Writer, in FeatureFlag) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"enabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Enabled))
+	}
+	{
+		const prefix string = ",\"rollout\":"
+		out.RawString(prefix)
+		out.Int(int(in.Rollout))
+	}
+	{
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	if in.By != "" {
+		const prefix string = ",\"by\":"
+		out.RawString(prefix)
+		out.String(string(in.By))
+	}
+	if in.Updated != "" {
+		const prefix string = ",\"updated\":"
+		out.RawString(prefix)
+		out.String(string(in.Updated))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FeatureFlag) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes133(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FeatureFlag) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes133(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FeatureFlag) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes133(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FeatureFlag) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes133(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes134(in *jlexer.Lexer, out *FeatureData) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "overrides":
+			if in.IsNull() {
+				in.Skip()
+				out.Overrides = nil
+			} else {
+				in.Delim('[')
+				if out.Overrides == nil {
+					if !in.IsDelim(']') {
+						out.Overrides = make([]FeatureFlag, 0, 0)
+					} else {
+						out.Overrides = []FeatureFlag{}
+					}
+				} else {
+					out.Overrides = (out.Overrides)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v261 FeatureFlag
+					(v261).UnmarshalEasyJSON(in)
+					out.Overrides = append(out.Overrides, v261)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes134(out *jwriter.Writer, in FeatureData) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"overrides\":"
+		out.RawString(prefix[1:])
+		if in.Overrides == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v262, v263 := range in.Overrides {
+				if v262 > 0 {
+					out.RawByte(',')
+				}
+				(v263).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FeatureData) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes134(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FeatureData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes134(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FeatureData) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes134(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FeatureData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes134(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes135(in *jlexer.Lexer, out *FacetValue) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "value":
+			out.Value = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes135(out *jwriter.Writer, in FacetValue) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"value\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Value))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FacetValue) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes135(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FacetValue) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes135(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FacetValue) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes135(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FacetValue) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes135(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes136(in *jlexer.Lexer, out *EventRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "seq":
+			out.Seq = int64(in.Int64())
+		case "time":
+			out.Time = string(in.String())
+		case "event":
+			out.Event = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "user":
+			out.User = string(in.String())
+		case "operation":
+			out.Operation = string(in.String())
+		case "approval":
+			out.Approval = string(in.String())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]string, 0, 4)
+					} else {
+						out.Files = []string{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v264 string
+					v264 = string(in.String())
+					out.Files = append(out.Files, v264)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "count":
+			out.Count = int(in.Int())
+		case "message":
+			out.Message = string(in.String())
+		case "node":
+			out.Node = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes136(out *jwriter.Writer, in EventRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Seq != 0 {
+		const prefix string = ",\"seq\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Seq))
+	}
+	{
+		const prefix string = ",\"time\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Time))
+	}
+	{
+		const prefix string = ",\"event\":"
+		out.RawString(prefix)
+		out.String(string(in.Event))
+	}
+	if in.Repo != "" {
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	if in.User != "" {
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.String(string(in.User))
+	}
+	if in.Operation != "" {
+		const prefix string = ",\"operation\":"
+		out.RawString(prefix)
+		out.String(string(in.Operation))
+	}
+	if in.Approval != "" {
+		const prefix string = ",\"approval\":"
+		out.RawString(prefix)
+		out.String(string(in.Approval))
+	}
+	if len(in.Files) != 0 {
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v265, v266 := range in.Files {
+				if v265 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v266))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Count != 0 {
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if in.Node != "" {
+		const prefix string = ",\"node\":"
+		out.RawString(prefix)
+		out.String(string(in.Node))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes136(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes136(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes136(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes136(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes137(in *jlexer.Lexer, out *EventList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "events":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]EventRecord, 0, 0)
+					} else {
+						out.Events = []EventRecord{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v267 EventRecord
+					(v267).UnmarshalEasyJSON(in)
+					out.Events = append(out.Events, v267)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "next":
+			out.Next = int64(in.Int64())
+		case "latest":
+			out.Latest = int64(in.Int64())
+		case "truncated":
+			out.Truncated = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes137(out *jwriter.Writer, in EventList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"events\":"
+		out.RawString(prefix)
+		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v268, v269 := range in.Events {
+				if v268 > 0 {
+					out.RawByte(',')
+				}
+				(v269).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"next\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Next))
+	}
+	{
+		const prefix string = ",\"latest\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Latest))
+	}
+	if in.Truncated {
+		const prefix string = ",\"truncated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Truncated))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes137(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes137(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes137(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes137(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes138(in *jlexer.Lexer, out *ErrorResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "code":
+			out.Code = int(in.Int())
+		case "error":
+			(out.Error).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes138(out *jwriter.Writer, in ErrorResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.Int(int(in.Code))
+	}
+	{
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		(in.Error).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ErrorResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes138(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ErrorResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes138(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ErrorResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes138(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ErrorResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes138(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes139(in *jlexer.Lexer, out *ErrorDetail) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "detail":
+			out.Detail = string(in.String())
+		case "request_id":
+			out.RequestID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes139(out *jwriter.Writer, in ErrorDetail) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if in.Detail != "" {
+		const prefix string = ",\"detail\":"
+		out.RawString(prefix)
+		out.String(string(in.Detail))
+	}
+	{
+		const prefix string = ",\"request_id\":"
+		out.RawString(prefix)
+		out.String(string(in.RequestID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ErrorDetail) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes139(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ErrorDetail) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes139(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ErrorDetail) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes139(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ErrorDetail) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes139(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes140(in *jlexer.Lexer, out *DrainStatus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "draining":
+			out.Draining = bool(in.Bool())
+		case "since":
+			out.Since = string(in.String())
+		case "active_requests":
+			out.ActiveRequests = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes140(out *jwriter.Writer, in DrainStatus) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"draining\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Draining))
+	}
+	if in.Since != "" {
+		const prefix string = ",\"since\":"
+		out.RawString(prefix)
+		out.String(string(in.Since))
+	}
+	{
+		const prefix string = ",\"active_requests\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ActiveRequests))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DrainStatus) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes140(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DrainStatus) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes140(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DrainStatus) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes140(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DrainStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes140(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes141(in *jlexer.Lexer, out *DirectoryListing) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "path":
+			out.Path = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "repo_type":
+			out.RepoType = string(in.String())
+		case "entries":
+			if in.IsNull() {
+				in.Skip()
+				out.Entries = nil
+			} else {
+				in.Delim('[')
+				if out.Entries == nil {
+					if !in.IsDelim(']') {
+						out.Entries = make([]DirectoryEntry, 0, 0)
+					} else {
+						out.Entries = []DirectoryEntry{}
+					}
+				} else {
+					out.Entries = (out.Entries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v270 DirectoryEntry
+					(v270).UnmarshalEasyJSON(in)
+					out.Entries = append(out.Entries, v270)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "readme":
+			if in.IsNull() {
+				in.Skip()
+				out.Readme = nil
+			} else {
+				if out.Readme == nil {
+					out.Readme = new(Readme)
+				}
+				(*out.Readme).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes141(out *jwriter.Writer, in DirectoryListing) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Path))
+	}
+	if in.Repo != "" {
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	if in.RepoType != "" {
+		const prefix string = ",\"repo_type\":"
+		out.RawString(prefix)
+		out.String(string(in.RepoType))
+	}
+	{
+		const prefix string = ",\"entries\":"
+		out.RawString(prefix)
+		if in.Entries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v271, v272 := range in.Entries {
+				if v271 > 0 {
+					out.RawByte(',')
+				}
+				(v272).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Readme != nil {
+		const prefix string = ",\"readme\":"
+		out.RawString(prefix)
+		(*in.Readme).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DirectoryListing) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes141(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DirectoryListing) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes141(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DirectoryListing) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes141(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DirectoryListing) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes141(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes142(in *jlexer.Lexer, out *DirectoryEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "mtime":
+			out.ModTime = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		case "repo_type":
+			out.RepoType = string(in.String())
+		case "checksum":
+			out.Checksum = string(in.String())
+		case "checksum_url":
+			out.ChecksumURL = string(in.String())
+		case "maintenance":
+			out.Maintenance = bool(in.Bool())
+		case "notes":
+			out.Notes = string(in.String())
+		case "notes_url":
+			out.NotesURL = string(in.String())
+		case "thumbnail_url":
+			out.ThumbnailURL = string(in.String())
+		case "expires_at":
+			out.ExpiresAt = string(in.String())
+		case "legal_hold":
+			out.LegalHold = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes142(out *jwriter.Writer, in DirectoryEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	if in.ModTime != "" {
+		const prefix string = ",\"mtime\":"
+		out.RawString(prefix)
+		out.String(string(in.ModTime))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	if in.RepoType != "" {
+		const prefix string = ",\"repo_type\":"
+		out.RawString(prefix)
+		out.String(string(in.RepoType))
+	}
+	if in.Checksum != "" {
+		const prefix string = ",\"checksum\":"
+		out.RawString(prefix)
+		out.String(string(in.Checksum))
+	}
+	if in.ChecksumURL != "" {
+		const prefix string = ",\"checksum_url\":"
+		out.RawString(prefix)
+		out.String(string(in.ChecksumURL))
+	}
+	if in.Maintenance {
+		const prefix string = ",\"maintenance\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Maintenance))
+	}
+	if in.Notes != "" {
+		const prefix string = ",\"notes\":"
+		out.RawString(prefix)
+		out.String(string(in.Notes))
+	}
+	if in.NotesURL != "" {
+		const prefix string = ",\"notes_url\":"
+		out.RawString(prefix)
+		out.String(string(in.NotesURL))
+	}
+	if in.ThumbnailURL != "" {
+		const prefix string = ",\"thumbnail_url\":"
+		out.RawString(prefix)
+		out.String(string(in.ThumbnailURL))
+	}
+	if in.ExpiresAt != "" {
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpiresAt))
+	}
+	if in.LegalHold {
+		const prefix string = ",\"legal_hold\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.LegalHold))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DirectoryEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes142(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DirectoryEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes142(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DirectoryEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes142(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DirectoryEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes142(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes143(in *jlexer.Lexer, out *DiffPackage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes143(out *jwriter.Writer, in DiffPackage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DiffPackage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes143(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DiffPackage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes143(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DiffPackage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes143(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DiffPackage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes143(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes144(in *jlexer.Lexer, out *DiffMismatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "a":
+			if in.IsNull() {
+				in.Skip()
+				out.A = nil
+			} else {
+				in.Delim('[')
+				if out.A == nil {
+					if !in.IsDelim(']') {
+						out.A = make([]string, 0, 4)
+					} else {
+						out.A = []string{}
+					}
+				} else {
+					out.A = (out.A)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v273 string
+					v273 = string(in.String())
+					out.A = append(out.A, v273)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "b":
+			if in.IsNull() {
+				in.Skip()
+				out.B = nil
+			} else {
+				in.Delim('[')
+				if out.B == nil {
+					if !in.IsDelim(']') {
+						out.B = make([]string, 0, 4)
+					} else {
+						out.B = []string{}
+					}
+				} else {
+					out.B = (out.B)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v274 string
+					v274 = string(in.String())
+					out.B = append(out.B, v274)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "newer":
+			out.Newer = string(in.String())
+		case "checksum_differs":
+			if in.IsNull() {
+				in.Skip()
+				out.ChecksumDiffers = nil
+			} else {
+				in.Delim('[')
+				if out.ChecksumDiffers == nil {
+					if !in.IsDelim(']') {
+						out.ChecksumDiffers = make([]string, 0, 4)
+					} else {
+						out.ChecksumDiffers = []string{}
+					}
+				} else {
+					out.ChecksumDiffers = (out.ChecksumDiffers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v275 string
+					v275 = string(in.String())
+					out.ChecksumDiffers = append(out.ChecksumDiffers, v275)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes144(out *jwriter.Writer, in DiffMismatch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"a\":"
+		out.RawString(prefix)
+		if in.A == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v276, v277 := range in.A {
+				if v276 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v277))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"b\":"
+		out.RawString(prefix)
+		if in.B == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v278, v279 := range in.B {
+				if v278 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v279))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"newer\":"
+		out.RawString(prefix)
+		out.String(string(in.Newer))
+	}
+	if len(in.ChecksumDiffers) != 0 {
+		const prefix string = ",\"checksum_differs\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v280, v281 := range in.ChecksumDiffers {
+				if v280 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v281))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DiffMismatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes144(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DiffMismatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes144(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DiffMismatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes144(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DiffMismatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes144(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes145(in *jlexer.Lexer, out *DependentInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "requirement":
+			out.Requirement = string(in.String())
+		case "sole_provider":
+			out.SoleProvider = bool(in.Bool())
+		case "depth":
+			out.Depth = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes145(out *jwriter.Writer, in DependentInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"requirement\":"
+		out.RawString(prefix)
+		out.String(string(in.Requirement))
+	}
+	if in.SoleProvider {
+		const prefix string = ",\"sole_provider\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.SoleProvider))
+	}
+	if in.Depth != 0 {
+		const prefix string = ",\"depth\":"
+		out.RawString(prefix)
+		out.Int(int(in.Depth))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DependentInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes145(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DependentInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes145(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DependentInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes145(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DependentInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes145(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes146(in *jlexer.Lexer, out *DeliveryResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "delivery":
+			(out.Delivery).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes146(out *jwriter.Writer, in DeliveryResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"delivery\":"
+		out.RawString(prefix)
+		(in.Delivery).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DeliveryResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes146(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DeliveryResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes146(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DeliveryResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes146(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DeliveryResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes146(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes147(in *jlexer.Lexer, out *DeliveryList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "count":
+			out.Count = int(in.Int())
+		case "deliveries":
+			if in.IsNull() {
+				in.Skip()
+				out.Deliveries = nil
+			} else {
+				in.Delim('[')
+				if out.Deliveries == nil {
+					if !in.IsDelim(']') {
+						out.Deliveries = make([]Delivery, 0, 0)
+					} else {
+						out.Deliveries = []Delivery{}
+					}
+				} else {
+					out.Deliveries = (out.Deliveries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v282 Delivery
+					(v282).UnmarshalEasyJSON(in)
+					out.Deliveries = append(out.Deliveries, v282)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes147(out *jwriter.Writer, in DeliveryList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"deliveries\":"
+		out.RawString(prefix)
+		if in.Deliveries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v283, v284 := range in.Deliveries {
+				if v283 > 0 {
+					out.RawByte(',')
+				}
+				(v284).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DeliveryList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes147(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DeliveryList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes147(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DeliveryList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes147(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DeliveryList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes147(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes148(in *jlexer.Lexer, out *Delivery) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "channel":
+			out.Channel = string(in.String())
+		case "target":
+			out.Target = string(in.String())
+		case "state":
+			out.State = string(in.String())
+		case "attempts":
+			out.Attempts = int(in.Int())
+		case "next_attempt":
+			out.NextAttempt = string(in.String())
+		case "last_error":
+			out.LastError = string(in.String())
+		case "created_at":
+			out.CreatedAt = string(in.String())
+		case "event":
+			(out.Event).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes148(out *jwriter.Writer, in Delivery) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"channel\":"
+		out.RawString(prefix)
+		out.String(string(in.Channel))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.String(string(in.Target))
+	}
+	{
+		const prefix string = ",\"state\":"
+		out.RawString(prefix)
+		out.String(string(in.State))
+	}
+	{
+		const prefix string = ",\"attempts\":"
+		out.RawString(prefix)
+		out.Int(int(in.Attempts))
+	}
+	if in.NextAttempt != "" {
+		const prefix string = ",\"next_attempt\":"
+		out.RawString(prefix)
+		out.String(string(in.NextAttempt))
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.String(string(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"event\":"
+		out.RawString(prefix)
+		(in.Event).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Delivery) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes148(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Delivery) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes148(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Delivery) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes148(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Delivery) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes148(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes149(in *jlexer.Lexer, out *DSSESignature) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "keyid":
+			out.KeyID = string(in.String())
+		case "sig":
+			out.Sig = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes149(out *jwriter.Writer, in DSSESignature) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keyid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.KeyID))
+	}
+	{
+		const prefix string = ",\"sig\":"
+		out.RawString(prefix)
+		out.String(string(in.Sig))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DSSESignature) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes149(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DSSESignature) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes149(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DSSESignature) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes149(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DSSESignature) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes149(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes150(in *jlexer.Lexer, out *DSSEEnvelope) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "payloadType":
+			out.PayloadType = string(in.String())
+		case "payload":
+			out.Payload = string(in.String())
+		case "signatures":
+			if in.IsNull() {
+				in.Skip()
+				out.Signatures = nil
+			} else {
+				in.Delim('[')
+				if out.Signatures == nil {
+					if !in.IsDelim(']') {
+						out.Signatures = make([]DSSESignature, 0, 2)
+					} else {
+						out.Signatures = []DSSESignature{}
+					}
+				} else {
+					out.Signatures = (out.Signatures)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v285 DSSESignature
+					(v285).UnmarshalEasyJSON(in)
+					out.Signatures = append(out.Signatures, v285)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes150(out *jwriter.Writer, in DSSEEnvelope) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"payloadType\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.PayloadType))
+	}
+	{
+		const prefix string = ",\"payload\":"
+		out.RawString(prefix)
+		out.String(string(in.Payload))
+	}
+	{
+		const prefix string = ",\"signatures\":"
+		out.RawString(prefix)
+		if in.Signatures == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v286, v287 := range in.Signatures {
+				if v286 > 0 {
+					out.RawByte(',')
+				}
+				(v287).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DSSEEnvelope) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes150(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DSSEEnvelope) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes150(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DSSEEnvelope) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes150(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DSSEEnvelope) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes150(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes151(in *jlexer.Lexer, out *CosignBundle) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "base64Signature":
+			out.Base64Signature = string(in.String())
+		case "cert":
+			out.Cert = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes151(out *jwriter.Writer, in CosignBundle) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"base64Signature\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Base64Signature))
+	}
+	{
+		const prefix string = ",\"cert\":"
+		out.RawString(prefix)
+		out.String(string(in.Cert))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CosignBundle) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes151(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CosignBundle) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes151(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CosignBundle) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes151(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CosignBundle) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes151(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes152(in *jlexer.Lexer, out *ConnectionMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "open":
+			out.Open = int(in.Int())
+		case "clients":
+			out.Clients = int(in.Int())
+		case "downloads":
+			out.Downloads = int(in.Int())
+		case "max_conns_per_ip":
+			out.MaxConnsPerIP = int(in.Int())
+		case "max_downloads_per_ip":
+			out.MaxDownloadsPerIP = int(in.Int())
+		case "rejected_conns":
+			out.RejectedConns = int64(in.Int64())
+		case "rejected_downloads":
+			out.RejectedDownloads = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes152(out *jwriter.Writer, in ConnectionMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"open\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Open))
+	}
+	{
+		const prefix string = ",\"clients\":"
+		out.RawString(prefix)
+		out.Int(int(in.Clients))
+	}
+	{
+		const prefix string = ",\"downloads\":"
+		out.RawString(prefix)
+		out.Int(int(in.Downloads))
+	}
+	{
+		const prefix string = ",\"max_conns_per_ip\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxConnsPerIP))
+	}
+	{
+		const prefix string = ",\"max_downloads_per_ip\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxDownloadsPerIP))
+	}
+	{
+		const prefix string = ",\"rejected_conns\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.RejectedConns))
+	}
+	{
+		const prefix string = ",\"rejected_downloads\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.RejectedDownloads))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ConnectionMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes152(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ConnectionMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes152(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ConnectionMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes152(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ConnectionMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes152(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes153(in *jlexer.Lexer, out *CompressionMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "compressed":
+			out.Compressed = int64(in.Int64())
+		case "skipped":
+			out.Skipped = int64(in.Int64())
+		case "bytes_in":
+			out.BytesIn = int64(in.Int64())
+		case "bytes_out":
+			out.BytesOut = int64(in.Int64())
+		case "ratio":
+			out.Ratio = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes153(out *jwriter.Writer, in CompressionMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"compressed\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Compressed))
+	}
+	{
+		const prefix string = ",\"skipped\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Skipped))
+	}
+	{
+		const prefix string = ",\"bytes_in\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesIn))
+	}
+	{
+		const prefix string = ",\"bytes_out\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesOut))
+	}
+	{
+		const prefix string = ",\"ratio\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Ratio))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CompressionMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes153(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CompressionMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes153(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CompressionMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes153(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CompressionMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes153(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes154(in *jlexer.Lexer, out *ClusterMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "node":
+			out.Node = string(in.String())
+		case "leader":
+			out.Leader = bool(in.Bool())
+		case "locks_held":
+			out.LocksHeld = int64(in.Int64())
+		case "locks_acquired":
+			out.LocksAcquired = int64(in.Int64())
+		case "lock_waits":
+			out.LockWaits = int64(in.Int64())
+		case "coalesced":
+			out.Coalesced = int64(in.Int64())
+		case "leases_lost":
+			out.LeasesLost = int64(in.Int64())
+		case "last_error":
+			out.LastError = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes154(out *jwriter.Writer, in ClusterMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"node\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Node))
+	}
+	{
+		const prefix string = ",\"leader\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Leader))
+	}
+	{
+		const prefix string = ",\"locks_held\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.LocksHeld))
+	}
+	{
+		const prefix string = ",\"locks_acquired\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.LocksAcquired))
+	}
+	{
+		const prefix string = ",\"lock_waits\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.LockWaits))
+	}
+	{
+		const prefix string = ",\"coalesced\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Coalesced))
+	}
+	{
+		const prefix string = ",\"leases_lost\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.LeasesLost))
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ClusterMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes154(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ClusterMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes154(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ClusterMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes154(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ClusterMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes154(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes155(in *jlexer.Lexer, out *CloneResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "source":
+			out.Source = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "files":
+			out.Files = int(in.Int())
+		case "size":
+			out.Size = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes155(out *jwriter.Writer, in CloneResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		out.Int(int(in.Files))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CloneResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes155(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CloneResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes155(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CloneResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes155(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CloneResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes155(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes156(in *jlexer.Lexer, out *CloneRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes156(out *jwriter.Writer, in CloneRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CloneRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes156(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CloneRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes156(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CloneRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes156(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CloneRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes156(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes157(in *jlexer.Lexer, out *Checksum) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Type":
+			out.Type = string(in.String())
+		case "Pkgid":
+			out.Pkgid = string(in.String())
+		case "Value":
+			out.Value = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes157(out *jwriter.Writer, in Checksum) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"Pkgid\":"
+		out.RawString(prefix)
+		out.String(string(in.Pkgid))
+	}
+	{
+		const prefix string = ",\"Value\":"
+		out.RawString(prefix)
+		out.String(string(in.Value))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Checksum) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes157(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Checksum) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes157(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Checksum) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes157(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Checksum) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes157(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes158(in *jlexer.Lexer, out *Checks) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Storage":
+			out.Storage = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes158(out *jwriter.Writer, in Checks) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Storage\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Storage))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Checks) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes158(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Checks) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes158(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Checks) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes158(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Checks) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes158(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes159(in *jlexer.Lexer, out *ChatWebhookResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "webhook":
+			(out.Webhook).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes159(out *jwriter.Writer, in ChatWebhookResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"webhook\":"
+		out.RawString(prefix)
+		(in.Webhook).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatWebhookResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes159(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatWebhookResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes159(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatWebhookResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes159(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatWebhookResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes159(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes160(in *jlexer.Lexer, out *ChatWebhookList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "count":
+			out.Count = int(in.Int())
+		case "webhooks":
+			if in.IsNull() {
+				in.Skip()
+				out.Webhooks = nil
+			} else {
+				in.Delim('[')
+				if out.Webhooks == nil {
+					if !in.IsDelim(']') {
+						out.Webhooks = make([]ChatWebhook, 0, 0)
+					} else {
+						out.Webhooks = []ChatWebhook{}
+					}
+				} else {
+					out.Webhooks = (out.Webhooks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v288 ChatWebhook
+					(v288).UnmarshalEasyJSON(in)
+					out.Webhooks = append(out.Webhooks, v288)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes160(out *jwriter.Writer, in ChatWebhookList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"webhooks\":"
+		out.RawString(prefix)
+		if in.Webhooks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v289, v290 := range in.Webhooks {
+				if v289 > 0 {
+					out.RawByte(',')
+				}
+				(v290).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatWebhookList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes160(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatWebhookList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes160(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatWebhookList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes160(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatWebhookList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes160(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes161(in *jlexer.Lexer, out *ChatWebhookData) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "webhooks":
+			if in.IsNull() {
+				in.Skip()
+				out.Webhooks = nil
+			} else {
+				in.Delim('[')
+				if out.Webhooks == nil {
+					if !in.IsDelim(']') {
+						out.Webhooks = make([]ChatWebhook, 0, 0)
+					} else {
+						out.Webhooks = []ChatWebhook{}
+					}
+				} else {
+					out.Webhooks = (out.Webhooks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v291 ChatWebhook
+					(v291).UnmarshalEasyJSON(in)
+					out.Webhooks = append(out.Webhooks, v291)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes161(out *jwriter.Writer, in ChatWebhookData) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"webhooks\":"
+		out.RawString(prefix[1:])
+		if in.Webhooks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v292, v293 := range in.Webhooks {
+				if v292 > 0 {
+					out.RawByte(',')
+				}
+				(v293).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatWebhookData) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes161(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatWebhookData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes161(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatWebhookData) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes161(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatWebhookData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes161(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes162(in *jlexer.Lexer, out *ChatWebhook) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "kind":
+			out.Kind = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		case "events":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]string, 0, 4)
+					} else {
+						out.Events = []string{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v294 string
+					v294 = string(in.String())
+					out.Events = append(out.Events, v294)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "repos":
+			if in.IsNull() {
+				in.Skip()
+				out.Repos = nil
+			} else {
+				in.Delim('[')
+				if out.Repos == nil {
+					if !in.IsDelim(']') {
+						out.Repos = make([]string, 0, 4)
+					} else {
+						out.Repos = []string{}
+					}
+				} else {
+					out.Repos = (out.Repos)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v295 string
+					v295 = string(in.String())
+					out.Repos = append(out.Repos, v295)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "created_by":
+			out.CreatedBy = string(in.String())
+		case "created_at":
+			out.CreatedAt = string(in.String())
+		case "last_sent":
+			out.LastSent = string(in.String())
+		case "last_error":
+			out.LastError = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes162(out *jwriter.Writer, in ChatWebhook) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"events\":"
+		out.RawString(prefix)
+		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v296, v297 := range in.Events {
+				if v296 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v297))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Repos) != 0 {
+		const prefix string = ",\"repos\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v298, v299 := range in.Repos {
+				if v298 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v299))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.CreatedBy != "" {
+		const prefix string = ",\"created_by\":"
+		out.RawString(prefix)
+		out.String(string(in.CreatedBy))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.String(string(in.CreatedAt))
+	}
+	if in.LastSent != "" {
+		const prefix string = ",\"last_sent\":"
+		out.RawString(prefix)
+		out.String(string(in.LastSent))
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatWebhook) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes162(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatWebhook) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes162(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatWebhook) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes162(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatWebhook) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes162(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes163(in *jlexer.Lexer, out *ChatMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes163(out *jwriter.Writer, in ChatMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes163(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes163(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes163(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes163(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes164(in *jlexer.Lexer, out *BundleRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "packages":
+			if in.IsNull() {
+				in.Skip()
+				out.Packages = nil
+			} else {
+				in.Delim('[')
+				if out.Packages == nil {
+					if !in.IsDelim(']') {
+						out.Packages = make([]string, 0, 4)
+					} else {
+						out.Packages = []string{}
+					}
+				} else {
+					out.Packages = (out.Packages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v300 string
+					v300 = string(in.String())
+					out.Packages = append(out.Packages, v300)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "arch":
+			out.Arch = string(in.String())
+		case "strict":
+			out.Strict = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes164(out *jwriter.Writer, in BundleRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"packages\":"
+		out.RawString(prefix[1:])
+		if in.Packages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v301, v302 := range in.Packages {
+				if v301 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v302))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"strict\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Strict))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BundleRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes164(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BundleRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes164(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BundleRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes164(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BundleRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes164(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes165(in *jlexer.Lexer, out *BundleManifest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "requested":
+			if in.IsNull() {
+				in.Skip()
+				out.Requested = nil
+			} else {
+				in.Delim('[')
+				if out.Requested == nil {
+					if !in.IsDelim(']') {
+						out.Requested = make([]string, 0, 4)
+					} else {
+						out.Requested = []string{}
+					}
+				} else {
+					out.Requested = (out.Requested)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v303 string
+					v303 = string(in.String())
+					out.Requested = append(out.Requested, v303)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "packages":
+			if in.IsNull() {
+				in.Skip()
+				out.Packages = nil
+			} else {
+				in.Delim('[')
+				if out.Packages == nil {
+					if !in.IsDelim(']') {
+						out.Packages = make([]BundleItem, 0, 0)
+					} else {
+						out.Packages = []BundleItem{}
+					}
+				} else {
+					out.Packages = (out.Packages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v304 BundleItem
+					(v304).UnmarshalEasyJSON(in)
+					out.Packages = append(out.Packages, v304)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "count":
+			out.Count = int(in.Int())
+		case "total_size":
+			out.TotalSize = int64(in.Int64())
+		case "missing":
+			if in.IsNull() {
+				in.Skip()
+				out.Missing = nil
+			} else {
+				in.Delim('[')
+				if out.Missing == nil {
+					if !in.IsDelim(']') {
+						out.Missing = make([]string, 0, 4)
+					} else {
+						out.Missing = []string{}
+					}
+				} else {
+					out.Missing = (out.Missing)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v305 string
+					v305 = string(in.String())
+					out.Missing = append(out.Missing, v305)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "unresolved":
+			if in.IsNull() {
+				in.Skip()
+				out.Unresolved = nil
+			} else {
+				in.Delim('[')
+				if out.Unresolved == nil {
+					if !in.IsDelim(']') {
+						out.Unresolved = make([]string, 0, 4)
+					} else {
+						out.Unresolved = []string{}
+					}
+				} else {
+					out.Unresolved = (out.Unresolved)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v306 string
+					v306 = string(in.String())
+					out.Unresolved = append(out.Unresolved, v306)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes165(out *jwriter.Writer, in BundleManifest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"requested\":"
+		out.RawString(prefix)
+		if in.Requested == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v307, v308 := range in.Requested {
+				if v307 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v308))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"packages\":"
+		out.RawString(prefix)
+		if in.Packages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v309, v310 := range in.Packages {
+				if v309 > 0 {
+					out.RawByte(',')
+				}
+				(v310).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"total_size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TotalSize))
+	}
+	if len(in.Missing) != 0 {
+		const prefix string = ",\"missing\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v311, v312 := range in.Missing {
+				if v311 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v312))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Unresolved) != 0 {
+		const prefix string = ",\"unresolved\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v313, v314 := range in.Unresolved {
+				if v313 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v314))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BundleManifest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes165(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BundleManifest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes165(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BundleManifest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes165(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BundleManifest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes165(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes166(in *jlexer.Lexer, out *BundleItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes166(out *jwriter.Writer, in BundleItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BundleItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes166(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BundleItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes166(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BundleItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes166(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BundleItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes166(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes167(in *jlexer.Lexer, out *BreakerMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "state":
+			out.State = string(in.String())
+		case "consecutive_failures":
+			out.ConsecutiveFailures = int(in.Int())
+		case "failures":
+			out.Failures = int64(in.Int64())
+		case "trips":
+			out.Trips = int64(in.Int64())
+		case "rejected":
+			out.Rejected = int64(in.Int64())
+		case "last_error":
+			out.LastError = string(in.String())
+		case "retry_after_seconds":
+			out.RetryAfterSeconds = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes167(out *jwriter.Writer, in BreakerMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"state\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.State))
+	}
+	{
+		const prefix string = ",\"consecutive_failures\":"
+		out.RawString(prefix)
+		out.Int(int(in.ConsecutiveFailures))
+	}
+	{
+		const prefix string = ",\"failures\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Failures))
+	}
+	{
+		const prefix string = ",\"trips\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Trips))
+	}
+	{
+		const prefix string = ",\"rejected\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Rejected))
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	if in.RetryAfterSeconds != 0 {
+		const prefix string = ",\"retry_after_seconds\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.RetryAfterSeconds))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BreakerMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes167(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BreakerMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes167(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BreakerMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes167(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BreakerMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes167(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes168(in *jlexer.Lexer, out *BenchReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "scenario":
+			out.Scenario = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		case "concurrency":
+			out.Concurrency = int(in.Int())
+		case "started_at":
+			out.StartedAt = string(in.String())
+		case "warmup_seconds":
+			out.WarmupSeconds = float64(in.Float64())
+		case "duration_seconds":
+			out.DurationSeconds = float64(in.Float64())
+		case "requests":
+			out.Requests = int64(in.Int64())
+		case "errors":
+			out.Errors = int64(in.Int64())
+		case "rps":
+			out.RPS = float64(in.Float64())
+		case "operations":
+			if in.IsNull() {
+				in.Skip()
+				out.Operations = nil
+			} else {
+				in.Delim('[')
+				if out.Operations == nil {
+					if !in.IsDelim(']') {
+						out.Operations = make([]BenchOperation, 0, 0)
+					} else {
+						out.Operations = []BenchOperation{}
+					}
+				} else {
+					out.Operations = (out.Operations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v315 BenchOperation
+					(v315).UnmarshalEasyJSON(in)
+					out.Operations = append(out.Operations, v315)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes168(out *jwriter.Writer, in BenchReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"scenario\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Scenario))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"concurrency\":"
+		out.RawString(prefix)
+		out.Int(int(in.Concurrency))
+	}
+	{
+		const prefix string = ",\"started_at\":"
+		out.RawString(prefix)
+		out.String(string(in.StartedAt))
+	}
+	{
+		const prefix string = ",\"warmup_seconds\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.WarmupSeconds))
+	}
+	{
+		const prefix string = ",\"duration_seconds\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.DurationSeconds))
+	}
+	{
+		const prefix string = ",\"requests\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Requests))
+	}
+	{
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Errors))
+	}
+	{
+		const prefix string = ",\"rps\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.RPS))
+	}
+	{
+		const prefix string = ",\"operations\":"
+		out.RawString(prefix)
+		if in.Operations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v316, v317 := range in.Operations {
+				if v316 > 0 {
+					out.RawByte(',')
+				}
+				(v317).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BenchReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes168(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BenchReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes168(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BenchReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes168(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BenchReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes168(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes169(in *jlexer.Lexer, out *BenchOperation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "requests":
+			out.Requests = int64(in.Int64())
+		case "errors":
+			out.Errors = int64(in.Int64())
+		case "rps":
+			out.RPS = float64(in.Float64())
+		case "bytes":
+			out.Bytes = int64(in.Int64())
+		case "latency_ms":
+			(out.Latency).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes169(out *jwriter.Writer, in BenchOperation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"requests\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Requests))
+	}
+	{
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Errors))
+	}
+	{
+		const prefix string = ",\"rps\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.RPS))
+	}
+	{
+		const prefix string = ",\"bytes\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Bytes))
+	}
+	{
+		const prefix string = ",\"latency_ms\":"
+		out.RawString(prefix)
+		(in.Latency).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BenchOperation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes169(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BenchOperation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes169(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BenchOperation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes169(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BenchOperation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes169(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes170(in *jlexer.Lexer, out *BenchLatency) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "min":
+			out.Min = float64(in.Float64())
+		case "mean":
+			out.Mean = float64(in.Float64())
+		case "p50":
+			out.P50 = float64(in.Float64())
+		case "p90":
+			out.P90 = float64(in.Float64())
+		case "p95":
+			out.P95 = float64(in.Float64())
+		case "p99":
+			out.P99 = float64(in.Float64())
+		case "max":
+			out.Max = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes170(out *jwriter.Writer, in BenchLatency) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"min\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Min))
+	}
+	{
+		const prefix string = ",\"mean\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Mean))
+	}
+	{
+		const prefix string = ",\"p50\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.P50))
+	}
+	{
+		const prefix string = ",\"p90\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.P90))
+	}
+	{
+		const prefix string = ",\"p95\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.P95))
+	}
+	{
+		const prefix string = ",\"p99\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.P99))
+	}
+	{
+		const prefix string = ",\"max\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Max))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BenchLatency) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes170(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BenchLatency) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes170(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BenchLatency) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes170(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BenchLatency) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes170(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes171(in *jlexer.Lexer, out *BatchUploadResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "filename":
+			out.Filename = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "error":
+			out.Error = string(in.String())
+		case "approval":
+			out.Approval = string(in.String())
+		case "stored_as":
+			out.StoredAs = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes171(out *jwriter.Writer, in BatchUploadResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"filename\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Filename))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	if in.Approval != "" {
+		const prefix string = ",\"approval\":"
+		out.RawString(prefix)
+		out.String(string(in.Approval))
+	}
+	if in.StoredAs != "" {
+		const prefix string = ",\"stored_as\":"
+		out.RawString(prefix)
+		out.String(string(in.StoredAs))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchUploadResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes171(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchUploadResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes171(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchUploadResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes171(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchUploadResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes171(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes172(in *jlexer.Lexer, out *BatchUploadResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = string(in.String())
+		case "total":
+			out.Total = int(in.Int())
+		case "success":
+			out.Success = int(in.Int())
+		case "failed":
+			out.Failed = int(in.Int())
+		case "pending":
+			out.Pending = int(in.Int())
+		case "results":
+			if in.IsNull() {
+				in.Skip()
+				out.Results = nil
+			} else {
+				in.Delim('[')
+				if out.Results == nil {
+					if !in.IsDelim(']') {
+						out.Results = make([]BatchUploadResult, 0, 0)
+					} else {
+						out.Results = []BatchUploadResult{}
+					}
+				} else {
+					out.Results = (out.Results)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v318 BatchUploadResult
+					(v318).UnmarshalEasyJSON(in)
+					out.Results = append(out.Results, v318)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes172(out *jwriter.Writer, in BatchUploadResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int(int(in.Total))
+	}
+	{
+		const prefix string = ",\"success\":"
+		out.RawString(prefix)
+		out.Int(int(in.Success))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int(int(in.Failed))
+	}
+	if in.Pending != 0 {
+		const prefix string = ",\"pending\":"
+		out.RawString(prefix)
+		out.Int(int(in.Pending))
+	}
+	{
+		const prefix string = ",\"results\":"
+		out.RawString(prefix)
+		if in.Results == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v319, v320 := range in.Results {
+				if v319 > 0 {
+					out.RawByte(',')
+				}
+				(v320).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchUploadResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes172(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchUploadResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes172(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchUploadResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes172(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchUploadResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes172(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes173(in *jlexer.Lexer, out *BatchUploadRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "repository":
+			out.Repository = string(in.String())
+		case "auto_refresh":
+			out.AutoRefresh = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes173(out *jwriter.Writer, in BatchUploadRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"repository\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Repository))
+	}
+	{
+		const prefix string = ",\"auto_refresh\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.AutoRefresh))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchUploadRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes173(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchUploadRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes173(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchUploadRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes173(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchUploadRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes173(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes174(in *jlexer.Lexer, out *BandwidthUsage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "download_bps":
+			out.DownloadBps = int64(in.Int64())
+		case "upload_bps":
+			out.UploadBps = int64(in.Int64())
+		case "download_limit":
+			out.DownloadLimit = int64(in.Int64())
+		case "upload_limit":
+			out.UploadLimit = int64(in.Int64())
+		case "bytes_sent":
+			out.BytesSent = int64(in.Int64())
+		case "bytes_received":
+			out.BytesReceived = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes174(out *jwriter.Writer, in BandwidthUsage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"download_bps\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.DownloadBps))
+	}
+	{
+		const prefix string = ",\"upload_bps\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UploadBps))
+	}
+	{
+		const prefix string = ",\"download_limit\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.DownloadLimit))
+	}
+	{
+		const prefix string = ",\"upload_limit\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UploadLimit))
+	}
+	{
+		const prefix string = ",\"bytes_sent\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesSent))
+	}
+	{
+		const prefix string = ",\"bytes_received\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesReceived))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BandwidthUsage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes174(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BandwidthUsage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes174(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BandwidthUsage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes174(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BandwidthUsage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes174(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes175(in *jlexer.Lexer, out *BandwidthMetrics) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "download_bps":
+			out.DownloadBps = int64(in.Int64())
+		case "upload_bps":
+			out.UploadBps = int64(in.Int64())
+		case "download_limit":
+			out.DownloadLimit = int64(in.Int64())
+		case "upload_limit":
+			out.UploadLimit = int64(in.Int64())
+		case "bytes_sent":
+			out.BytesSent = int64(in.Int64())
+		case "bytes_received":
+			out.BytesReceived = int64(in.Int64())
+		case "clients":
+			out.Clients = int(in.Int())
+		case "repos":
+			if in.IsNull() {
+				in.Skip()
+				out.Repos = nil
+			} else {
+				in.Delim('[')
+				if out.Repos == nil {
+					if !in.IsDelim(']') {
+						out.Repos = make([]BandwidthUsage, 0, 1)
+					} else {
+						out.Repos = []BandwidthUsage{}
+					}
+				} else {
+					out.Repos = (out.Repos)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v321 BandwidthUsage
+					(v321).UnmarshalEasyJSON(in)
+					out.Repos = append(out.Repos, v321)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tokens":
+			if in.IsNull() {
+				in.Skip()
+				out.Tokens = nil
+			} else {
+				in.Delim('[')
+				if out.Tokens == nil {
+					if !in.IsDelim(']') {
+						out.Tokens = make([]BandwidthUsage, 0, 1)
+					} else {
+						out.Tokens = []BandwidthUsage{}
+					}
+				} else {
+					out.Tokens = (out.Tokens)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v322 BandwidthUsage
+					(v322).UnmarshalEasyJSON(in)
+					out.Tokens = append(out.Tokens, v322)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes175(out *jwriter.Writer, in BandwidthMetrics) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"download_bps\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.DownloadBps))
+	}
+	{
+		const prefix string = ",\"upload_bps\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UploadBps))
+	}
+	{
+		const prefix string = ",\"download_limit\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.DownloadLimit))
+	}
+	{
+		const prefix string = ",\"upload_limit\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UploadLimit))
+	}
+	{
+		const prefix string = ",\"bytes_sent\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesSent))
+	}
+	{
+		const prefix string = ",\"bytes_received\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BytesReceived))
+	}
+	{
+		const prefix string = ",\"clients\":"
+		out.RawString(prefix)
+		out.Int(int(in.Clients))
+	}
+	if len(in.Repos) != 0 {
+		const prefix string = ",\"repos\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v323, v324 := range in.Repos {
+				if v323 > 0 {
+					out.RawByte(',')
+				}
+				(v324).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Tokens) != 0 {
+		const prefix string = ",\"tokens\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v325, v326 := range in.Tokens {
+				if v325 > 0 {
+					out.RawByte(',')
+				}
+				(v326).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BandwidthMetrics) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes175(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BandwidthMetrics) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes175(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BandwidthMetrics) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes175(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BandwidthMetrics) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes175(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes176(in *jlexer.Lexer, out *AuthzResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "allow":
+			out.Allow = bool(in.Bool())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes176(out *jwriter.Writer, in AuthzResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"allow\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Allow))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuthzResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes176(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuthzResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes176(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuthzResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes176(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuthzResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes176(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes177(in *jlexer.Lexer, out *AuthzResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "result":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Result).UnmarshalJSON(data))
+			}
+		case "allow":
+			out.Allow = bool(in.Bool())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes177(out *jwriter.Writer, in AuthzResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"result\":"
+		out.RawString(prefix[1:])
+		out.Raw((in.Result).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"allow\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Allow))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuthzResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes177(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuthzResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes177(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuthzResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes177(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuthzResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes177(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes178(in *jlexer.Lexer, out *AuthzRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "input":
+			(out.Input).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes178(out *jwriter.Writer, in AuthzRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"input\":"
+		out.RawString(prefix[1:])
+		(in.Input).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuthzRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes178(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuthzRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes178(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuthzRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes178(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuthzRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes178(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes179(in *jlexer.Lexer, out *AuthzPackage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "format":
+			out.Format = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "release":
+			out.Release = string(in.String())
+		case "arch":
+			out.Arch = string(in.String())
+		case "signed":
+			out.Signed = bool(in.Bool())
+		case "key_id":
+			out.KeyID = string(in.String())
+		case "license":
+			out.License = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes179(out *jwriter.Writer, in AuthzPackage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"format\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Format))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	if in.Release != "" {
+		const prefix string = ",\"release\":"
+		out.RawString(prefix)
+		out.String(string(in.Release))
+	}
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix)
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"signed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Signed))
+	}
+	if in.KeyID != "" {
+		const prefix string = ",\"key_id\":"
+		out.RawString(prefix)
+		out.String(string(in.KeyID))
+	}
+	if in.License != "" {
+		const prefix string = ",\"license\":"
+		out.RawString(prefix)
+		out.String(string(in.License))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuthzPackage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes179(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuthzPackage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes179(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuthzPackage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes179(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuthzPackage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes179(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes180(in *jlexer.Lexer, out *AuthzInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "operation":
+			out.Operation = string(in.String())
+		case "user":
+			out.User = string(in.String())
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]string, 0, 4)
+					} else {
+						out.Roles = []string{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v327 string
+					v327 = string(in.String())
+					out.Roles = append(out.Roles, v327)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "repo":
+			out.Repo = string(in.String())
+		case "repo_type":
+			out.RepoType = string(in.String())
+		case "source":
+			out.Source = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]string, 0, 4)
+					} else {
+						out.Files = []string{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v328 string
+					v328 = string(in.String())
+					out.Files = append(out.Files, v328)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "size":
+			out.Size = int64(in.Int64())
+		case "method":
+			out.Method = string(in.String())
+		case "client_ip":
+			out.ClientIP = string(in.String())
+		case "package":
+			if in.IsNull() {
+				in.Skip()
+				out.Package = nil
+			} else {
+				if out.Package == nil {
+					out.Package = new(AuthzPackage)
+				}
+				(*out.Package).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes180(out *jwriter.Writer, in AuthzInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"operation\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Operation))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.String(string(in.User))
+	}
+	{
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix)
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v329, v330 := range in.Roles {
+				if v329 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v330))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	if in.RepoType != "" {
+		const prefix string = ",\"repo_type\":"
+		out.RawString(prefix)
+		out.String(string(in.RepoType))
+	}
+	if in.Source != "" {
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	if in.Path != "" {
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	if len(in.Files) != 0 {
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v331, v332 := range in.Files {
+				if v331 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v332))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Size != 0 {
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"method\":"
+		out.RawString(prefix)
+		out.String(string(in.Method))
+	}
+	{
+		const prefix string = ",\"client_ip\":"
+		out.RawString(prefix)
+		out.String(string(in.ClientIP))
+	}
+	if in.Package != nil {
+		const prefix string = ",\"package\":"
+		out.RawString(prefix)
+		(*in.Package).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuthzInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes180(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuthzInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes180(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuthzInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes180(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuthzInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes180(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes181(in *jlexer.Lexer, out *AttestationResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "attestation":
+			(out.Attestation).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes181(out *jwriter.Writer, in AttestationResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"attestation\":"
+		out.RawString(prefix)
+		(in.Attestation).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AttestationResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes181(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AttestationResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes181(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AttestationResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes181(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AttestationResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes181(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes182(in *jlexer.Lexer, out *AttestationList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "repo":
+			out.Repo = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "attestations":
+			if in.IsNull() {
+				in.Skip()
+				out.Attestations = nil
+			} else {
+				in.Delim('[')
+				if out.Attestations == nil {
+					if !in.IsDelim(']') {
+						out.Attestations = make([]Attestation, 0, 0)
+					} else {
+						out.Attestations = []Attestation{}
+					}
+				} else {
+					out.Attestations = (out.Attestations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v333 Attestation
+					(v333).UnmarshalEasyJSON(in)
+					out.Attestations = append(out.Attestations, v333)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes182(out *jwriter.Writer, in AttestationList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"attestations\":"
+		out.RawString(prefix)
+		if in.Attestations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v334, v335 := range in.Attestations {
+				if v334 > 0 {
+					out.RawByte(',')
+				}
+				(v335).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AttestationList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes182(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AttestationList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes182(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AttestationList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes182(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AttestationList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes182(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes183(in *jlexer.Lexer, out *AttestationKeys) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "keys":
+			if in.IsNull() {
+				in.Skip()
+				out.Keys = nil
+			} else {
+				in.Delim('[')
+				if out.Keys == nil {
+					if !in.IsDelim(']') {
+						out.Keys = make([]AttestationKey, 0, 1)
+					} else {
+						out.Keys = []AttestationKey{}
+					}
+				} else {
+					out.Keys = (out.Keys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v336 AttestationKey
+					(v336).UnmarshalEasyJSON(in)
+					out.Keys = append(out.Keys, v336)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes183(out *jwriter.Writer, in AttestationKeys) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"keys\":"
+		out.RawString(prefix)
+		if in.Keys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v337, v338 := range in.Keys {
+				if v337 > 0 {
+					out.RawByte(',')
+				}
+				(v338).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AttestationKeys) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes183(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AttestationKeys) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes183(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AttestationKeys) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes183(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AttestationKeys) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes183(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes184(in *jlexer.Lexer, out *AttestationKey) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "algorithm":
+			out.Algorithm = string(in.String())
+		case "public_key":
+			out.PublicKey = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes184(out *jwriter.Writer, in AttestationKey) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"algorithm\":"
+		out.RawString(prefix)
+		out.String(string(in.Algorithm))
+	}
+	{
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix)
+		out.String(string(in.PublicKey))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AttestationKey) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes184(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AttestationKey) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes184(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AttestationKey) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes184(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AttestationKey) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes184(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes185(in *jlexer.Lexer, out *Attestation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "predicate_type":
+			out.PredicateType = string(in.String())
+		case "subjects":
+			if in.IsNull() {
+				in.Skip()
+				out.Subjects = nil
+			} else {
+				in.Delim('[')
+				if out.Subjects == nil {
+					if !in.IsDelim(']') {
+						out.Subjects = make([]string, 0, 4)
+					} else {
+						out.Subjects = []string{}
+					}
+				} else {
+					out.Subjects = (out.Subjects)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v339 string
+					v339 = string(in.String())
+					out.Subjects = append(out.Subjects, v339)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "digest":
+			out.Digest = string(in.String())
+		case "verified":
+			out.Verified = bool(in.Bool())
+		case "key":
+			out.Key = string(in.String())
+		case "by":
+			out.By = string(in.String())
+		case "created":
+			out.Created = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		case "envelope":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Envelope).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes185(out *jwriter.Writer, in Attestation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"predicate_type\":"
+		out.RawString(prefix)
+		out.String(string(in.PredicateType))
+	}
+	{
+		const prefix string = ",\"subjects\":"
+		out.RawString(prefix)
+		if in.Subjects == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v340, v341 := range in.Subjects {
+				if v340 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v341))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"verified\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Verified))
+	}
+	if in.Key != "" {
+		const prefix string = ",\"key\":"
+		out.RawString(prefix)
+		out.String(string(in.Key))
+	}
+	if in.By != "" {
+		const prefix string = ",\"by\":"
+		out.RawString(prefix)
+		out.String(string(in.By))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	if in.URL != "" {
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	if len(in.Envelope) != 0 {
+		const prefix string = ",\"envelope\":"
+		out.RawString(prefix)
+		out.Raw((in.Envelope).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Attestation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes185(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Attestation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes185(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Attestation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes185(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Attestation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes185(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes186(in *jlexer.Lexer, out *ArtifactoryRepository) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "key":
+			out.Key = string(in.String())
+		case "packageType":
+			out.PackageType = string(in.String())
+		case "rclass":
+			out.Rclass = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes186(out *jwriter.Writer, in ArtifactoryRepository) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"key\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Key))
+	}
+	{
+		const prefix string = ",\"packageType\":"
+		out.RawString(prefix)
+		out.String(string(in.PackageType))
+	}
+	{
+		const prefix string = ",\"rclass\":"
+		out.RawString(prefix)
+		out.String(string(in.Rclass))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ArtifactoryRepository) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes186(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ArtifactoryRepository) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes186(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ArtifactoryRepository) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes186(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ArtifactoryRepository) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes186(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes187(in *jlexer.Lexer, out *ArtifactoryFileList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]ArtifactoryFile, 0, 1)
+					} else {
+						out.Files = []ArtifactoryFile{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v342 ArtifactoryFile
+					(v342).UnmarshalEasyJSON(in)
+					out.Files = append(out.Files, v342)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes187(out *jwriter.Writer, in ArtifactoryFileList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix[1:])
+		if in.Files == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v343, v344 := range in.Files {
+				if v343 > 0 {
+					out.RawByte(',')
+				}
+				(v344).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ArtifactoryFileList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes187(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ArtifactoryFileList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes187(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ArtifactoryFileList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes187(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ArtifactoryFileList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes187(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes188(in *jlexer.Lexer, out *ArtifactoryFile) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "uri":
+			out.URI = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "folder":
+			out.Folder = bool(in.Bool())
+		case "sha1":
+			out.SHA1 = string(in.String())
+		case "sha2":
+			out.SHA2 = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes188(out *jwriter.Writer, in ArtifactoryFile) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uri\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.URI))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"folder\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Folder))
+	}
+	{
+		const prefix string = ",\"sha1\":"
+		out.RawString(prefix)
+		out.String(string(in.SHA1))
+	}
+	{
+		const prefix string = ",\"sha2\":"
+		out.RawString(prefix)
+		out.String(string(in.SHA2))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ArtifactoryFile) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes188(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ArtifactoryFile) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes188(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ArtifactoryFile) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes188(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ArtifactoryFile) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes188(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes189(in *jlexer.Lexer, out *Artifact) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "repo":
+			out.Repo = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "checksum":
+			out.Checksum = string(in.String())
+		case "mtime":
+			out.ModTime = string(in.String())
+		case "labels":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Labels = make(map[string]string)
+				} else {
+					out.Labels = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v345 string
+					v345 = string(in.String())
+					(out.Labels)[key] = v345
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes189(out *jwriter.Writer, in Artifact) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Repo))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	if in.Checksum != "" {
+		const prefix string = ",\"checksum\":"
+		out.RawString(prefix)
+		out.String(string(in.Checksum))
+	}
+	if in.ModTime != "" {
+		const prefix string = ",\"mtime\":"
+		out.RawString(prefix)
+		out.String(string(in.ModTime))
+	}
+	if len(in.Labels) != 0 {
+		const prefix string = ",\"labels\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v346First := true
+			for v346Name, v346Value := range in.Labels {
+				if v346First {
+					v346First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v346Name))
+				out.RawByte(':')
+				out.String(string(v346Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Artifact) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes189(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Artifact) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes189(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Artifact) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes189(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Artifact) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes189(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes190(in *jlexer.Lexer, out *ArchiveRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "paths":
+			if in.IsNull() {
+				in.Skip()
+				out.Paths = nil
+			} else {
+				in.Delim('[')
+				if out.Paths == nil {
+					if !in.IsDelim(']') {
+						out.Paths = make([]string, 0, 4)
+					} else {
+						out.Paths = []string{}
+					}
+				} else {
+					out.Paths = (out.Paths)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v347 string
+					v347 = string(in.String())
+					out.Paths = append(out.Paths, v347)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "dir":
+			out.Dir = string(in.String())
+		case "format":
+			out.Format = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes190(out *jwriter.Writer, in ArchiveRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.Paths) != 0 {
+		const prefix string = ",\"paths\":"
+		first = false
+		out.RawString(prefix[1:])
+		{
+			out.RawByte('[')
+			for v348, v349 := range in.Paths {
+				if v348 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v349))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Dir != "" {
+		const prefix string = ",\"dir\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Dir))
+	}
+	if in.Format != "" {
+		const prefix string = ",\"format\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Format))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ArchiveRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes190(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ArchiveRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes190(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ArchiveRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes190(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ArchiveRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes190(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes191(in *jlexer.Lexer, out *ArchStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "arch":
+			out.Arch = string(in.String())
+		case "package_count":
+			out.PackageCount = int(in.Int())
+		case "total_size":
+			out.TotalSize = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes191(out *jwriter.Writer, in ArchStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"arch\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Arch))
+	}
+	{
+		const prefix string = ",\"package_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.PackageCount))
+	}
+	{
+		const prefix string = ",\"total_size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TotalSize))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ArchStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes191(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ArchStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes191(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ArchStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes191(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ArchStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes191(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes192(in *jlexer.Lexer, out *ApprovalResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "approval":
+			(out.Approval).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes192(out *jwriter.Writer, in ApprovalResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"approval\":"
+		out.RawString(prefix)
+		(in.Approval).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ApprovalResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes192(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ApprovalResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes192(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ApprovalResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes192(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ApprovalResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes192(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes193(in *jlexer.Lexer, out *ApprovalRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "operation":
+			out.Operation = string(in.String())
+		case "repo":
+			out.Repo = string(in.String())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]string, 0, 4)
+					} else {
+						out.Files = []string{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v350 string
+					v350 = string(in.String())
+					out.Files = append(out.Files, v350)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "changeset":
+			out.Changeset = string(in.String())
+		case "state":
+			out.State = string(in.String())
+		case "requested_by":
+			out.RequestedBy = string(in.String())
+		case "requested_at":
+			out.RequestedAt = string(in.String())
+		case "decided_by":
+			out.DecidedBy = string(in.String())
+		case "decided_at":
+			out.DecidedAt = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes193(out *jwriter.Writer, in ApprovalRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"operation\":"
+		out.RawString(prefix)
+		out.String(string(in.Operation))
+	}
+	{
+		const prefix string = ",\"repo\":"
+		out.RawString(prefix)
+		out.String(string(in.Repo))
+	}
+	if len(in.Files) != 0 {
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v351, v352 := range in.Files {
+				if v351 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v352))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Changeset != "" {
+		const prefix string = ",\"changeset\":"
+		out.RawString(prefix)
+		out.String(string(in.Changeset))
+	}
+	{
+		const prefix string = ",\"state\":"
+		out.RawString(prefix)
+		out.String(string(in.State))
+	}
+	{
+		const prefix string = ",\"requested_by\":"
+		out.RawString(prefix)
+		out.String(string(in.RequestedBy))
+	}
+	{
+		const prefix string = ",\"requested_at\":"
+		out.RawString(prefix)
+		out.String(string(in.RequestedAt))
+	}
+	if in.DecidedBy != "" {
+		const prefix string = ",\"decided_by\":"
+		out.RawString(prefix)
+		out.String(string(in.DecidedBy))
+	}
+	if in.DecidedAt != "" {
+		const prefix string = ",\"decided_at\":"
+		out.RawString(prefix)
+		out.String(string(in.DecidedAt))
+	}
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ApprovalRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes193(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ApprovalRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes193(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ApprovalRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes193(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ApprovalRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes193(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes194(in *jlexer.Lexer, out *ApprovalList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "count":
+			out.Count = int(in.Int())
+		case "approvals":
+			if in.IsNull() {
+				in.Skip()
+				out.Approvals = nil
+			} else {
+				in.Delim('[')
+				if out.Approvals == nil {
+					if !in.IsDelim(']') {
+						out.Approvals = make([]ApprovalRequest, 0, 0)
+					} else {
+						out.Approvals = []ApprovalRequest{}
+					}
+				} else {
+					out.Approvals = (out.Approvals)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v353 ApprovalRequest
+					(v353).UnmarshalEasyJSON(in)
+					out.Approvals = append(out.Approvals, v353)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes194(out *jwriter.Writer, in ApprovalList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"approvals\":"
+		out.RawString(prefix)
+		if in.Approvals == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v354, v355 := range in.Approvals {
+				if v354 > 0 {
+					out.RawByte(',')
+				}
+				(v355).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ApprovalList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes194(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ApprovalList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes194(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ApprovalList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes194(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ApprovalList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes194(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes195(in *jlexer.Lexer, out *ApprovalDecision) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes195(out *jwriter.Writer, in ApprovalDecision) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ApprovalDecision) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes195(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ApprovalDecision) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes195(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ApprovalDecision) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes195(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ApprovalDecision) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes195(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes196(in *jlexer.Lexer, out *ApprovalData) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "requests":
+			if in.IsNull() {
+				in.Skip()
+				out.Requests = nil
+			} else {
+				in.Delim('[')
+				if out.Requests == nil {
+					if !in.IsDelim(']') {
+						out.Requests = make([]ApprovalRequest, 0, 0)
+					} else {
+						out.Requests = []ApprovalRequest{}
+					}
+				} else {
+					out.Requests = (out.Requests)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v356 ApprovalRequest
+					(v356).UnmarshalEasyJSON(in)
+					out.Requests = append(out.Requests, v356)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes196(out *jwriter.Writer, in ApprovalData) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"requests\":"
+		out.RawString(prefix[1:])
+		if in.Requests == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v357, v358 := range in.Requests {
+				if v357 > 0 {
+					out.RawByte(',')
+				}
+				(v358).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ApprovalData) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes196(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ApprovalData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes196(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ApprovalData) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes196(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ApprovalData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes196(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes197(in *jlexer.Lexer, out *AlertList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Status":
+			(out.Status).UnmarshalEasyJSON(in)
+		case "alerts":
+			if in.IsNull() {
+				in.Skip()
+				out.Alerts = nil
+			} else {
+				in.Delim('[')
+				if out.Alerts == nil {
+					if !in.IsDelim(']') {
+						out.Alerts = make([]Alert, 0, 0)
+					} else {
+						out.Alerts = []Alert{}
+					}
+				} else {
+					out.Alerts = (out.Alerts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v359 Alert
+					(v359).UnmarshalEasyJSON(in)
+					out.Alerts = append(out.Alerts, v359)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes197(out *jwriter.Writer, in AlertList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Status\":"
+		out.RawString(prefix[1:])
+		(in.Status).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"alerts\":"
+		out.RawString(prefix)
+		if in.Alerts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v360, v361 := range in.Alerts {
+				if v360 > 0 {
+					out.RawByte(',')
+				}
+				(v361).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AlertList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes197(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AlertList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes197(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AlertList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes197(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AlertList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes197(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes198(in *jlexer.Lexer, out *Alert) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "state":
+			out.State = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "value":
+			out.Value = float64(in.Float64())
+		case "threshold":
+			out.Threshold = float64(in.Float64())
+		case "node":
+			out.Node = string(in.String())
+		case "since":
+			out.Since = string(in.String())
+		case "resolved":
+			out.Resolved = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes198(out *jwriter.Writer, in Alert) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"state\":"
+		out.RawString(prefix)
+		out.String(string(in.State))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if in.Value != 0 {
+		const prefix string = ",\"value\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Value))
+	}
+	if in.Threshold != 0 {
+		const prefix string = ",\"threshold\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Threshold))
+	}
+	if in.Node != "" {
+		const prefix string = ",\"node\":"
+		out.RawString(prefix)
+		out.String(string(in.Node))
+	}
+	{
+		const prefix string = ",\"since\":"
+		out.RawString(prefix)
+		out.String(string(in.Since))
+	}
+	if in.Resolved != "" {
+		const prefix string = ",\"resolved\":"
+		out.RawString(prefix)
+		out.String(string(in.Resolved))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Alert) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes198(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Alert) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes198(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Alert) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes198(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Alert) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes198(l, v)
+}
+func easyjson6601e8cdDecodePlusInternalTypes199(in *jlexer.Lexer, out *AccessData) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]User, 0, 0)
+					} else {
+						out.Users = []User{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v362 User
+					(v362).UnmarshalEasyJSON(in)
+					out.Users = append(out.Users, v362)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "groups":
+			if in.IsNull() {
+				in.Skip()
+				out.Groups = nil
+			} else {
+				in.Delim('[')
+				if out.Groups == nil {
+					if !in.IsDelim(']') {
+						out.Groups = make([]Group, 0, 0)
+					} else {
+						out.Groups = []Group{}
+					}
+				} else {
+					out.Groups = (out.Groups)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v363 Group
+					(v363).UnmarshalEasyJSON(in)
+					out.Groups = append(out.Groups, v363)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]Role, 0, 0)
+					} else {
+						out.Roles = []Role{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v364 Role
+					(v364).UnmarshalEasyJSON(in)
+					out.Roles = append(out.Roles, v364)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tokens":
+			if in.IsNull() {
+				in.Skip()
+				out.Tokens = nil
+			} else {
+				in.Delim('[')
+				if out.Tokens == nil {
+					if !in.IsDelim(']') {
+						out.Tokens = make([]TokenRecord, 0, 0)
+					} else {
+						out.Tokens = []TokenRecord{}
+					}
+				} else {
+					out.Tokens = (out.Tokens)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v365 TokenRecord
+					(v365).UnmarshalEasyJSON(in)
+					out.Tokens = append(out.Tokens, v365)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "uploads":
+			if in.IsNull() {
+				in.Skip()
+				out.Uploads = nil
+			} else {
+				in.Delim('[')
+				if out.Uploads == nil {
+					if !in.IsDelim(']') {
+						out.Uploads = make([]UploadToken, 0, 0)
+					} else {
+						out.Uploads = []UploadToken{}
+					}
+				} else {
+					out.Uploads = (out.Uploads)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v366 UploadToken
+					(v366).UnmarshalEasyJSON(in)
+					out.Uploads = append(out.Uploads, v366)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sessions":
+			if in.IsNull() {
+				in.Skip()
+				out.Sessions = nil
+			} else {
+				in.Delim('[')
+				if out.Sessions == nil {
+					if !in.IsDelim(']') {
+						out.Sessions = make([]Session, 0, 0)
+					} else {
+						out.Sessions = []Session{}
+					}
+				} else {
+					out.Sessions = (out.Sessions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v367 Session
+					(v367).UnmarshalEasyJSON(in)
+					out.Sessions = append(out.Sessions, v367)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "revoked":
+			if in.IsNull() {
+				in.Skip()
+				out.Revoked = nil
+			} else {
+				in.Delim('[')
+				if out.Revoked == nil {
+					if !in.IsDelim(']') {
+						out.Revoked = make([]RevokedSession, 0, 2)
+					} else {
+						out.Revoked = []RevokedSession{}
+					}
+				} else {
+					out.Revoked = (out.Revoked)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v368 RevokedSession
+					(v368).UnmarshalEasyJSON(in)
+					out.Revoked = append(out.Revoked, v368)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "session_key":
+			out.SessionKey = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6601e8cdEncodePlusInternalTypes199(out *jwriter.Writer, in AccessData) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix[1:])
+		if in.Users == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v369, v370 := range in.Users {
+				if v369 > 0 {
+					out.RawByte(',')
+				}
+				(v370).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"groups\":"
+		out.RawString(prefix)
+		if in.Groups == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v371, v372 := range in.Groups {
+				if v371 > 0 {
+					out.RawByte(',')
+				}
+				(v372).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix)
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v373, v374 := range in.Roles {
+				if v373 > 0 {
+					out.RawByte(',')
+				}
+				(v374).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"tokens\":"
+		out.RawString(prefix)
+		if in.Tokens == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v375, v376 := range in.Tokens {
+				if v375 > 0 {
+					out.RawByte(',')
+				}
+				(v376).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Uploads) != 0 {
+		const prefix string = ",\"uploads\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v377, v378 := range in.Uploads {
+				if v377 > 0 {
+					out.RawByte(',')
+				}
+				(v378).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Sessions) != 0 {
+		const prefix string = ",\"sessions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v379, v380 := range in.Sessions {
+				if v379 > 0 {
+					out.RawByte(',')
+				}
+				(v380).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Revoked) != 0 {
+		const prefix string = ",\"revoked\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v381, v382 := range in.Revoked {
+				if v381 > 0 {
+					out.RawByte(',')
+				}
+				(v382).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.SessionKey != "" {
+		const prefix string = ",\"session_key\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionKey))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AccessData) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6601e8cdEncodePlusInternalTypes199(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AccessData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6601e8cdEncodePlusInternalTypes199(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AccessData) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6601e8cdDecodePlusInternalTypes199(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AccessData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6601e8cdDecodePlusInternalTypes199(l, v)
+}
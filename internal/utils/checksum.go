@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"strings"
+)
+
+// NewHash 按仓库元数据中的校验和类型创建 hash，不支持时返回 nil
+func NewHash(algo string) hash.Hash {
+	switch strings.ToLower(algo) {
+	case "sha256":
+		return sha256.New()
+	case "sha512":
+		return sha512.New()
+	case "sha1", "sha":
+		return sha1.New()
+	case "md5":
+		return md5.New()
+	}
+	return nil
+}
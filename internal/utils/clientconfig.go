@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ClientRepoConfig 生成客户端仓库配置所需的信息
+type ClientRepoConfig struct {
+	ID      string // 仓库标识，用作 .repo 段名和 .list 文件名
+	Name    string // 仓库描述
+	BaseURL string // 仓库文件根地址，以 / 结尾
+	GPGKey  string // 公钥地址，为空表示不校验签名
+	KeyPath string // apt signed-by 使用的本地 keyring 路径，为空表示 Release 没有签名
+}
+
+var repoIDPattern = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// RepoID 将多层仓库路径转换为可用作配置标识的名称
+func RepoID(repoName string) string {
+	id := repoIDPattern.ReplaceAllString(strings.Trim(repoName, "/"), "-")
+	if id == "" {
+		return "plus"
+	}
+	return "plus-" + id
+}
+
+// GenerateYumRepoFile 生成 /etc/yum.repos.d/*.repo 文件内容
+func GenerateYumRepoFile(c ClientRepoConfig) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s]\n", c.ID)
+	fmt.Fprintf(&b, "name=%s\n", c.Name)
+	fmt.Fprintf(&b, "baseurl=%s\n", c.BaseURL)
+	b.WriteString("enabled=1\n")
+	if c.GPGKey != "" {
+		b.WriteString("gpgcheck=1\n")
+		fmt.Fprintf(&b, "gpgkey=%s\n", c.GPGKey)
+	} else {
+		b.WriteString("gpgcheck=0\n")
+	}
+	b.WriteString("metadata_expire=300\n")
+	return b.String()
+}
+
+// GenerateAptListFile 生成 /etc/apt/sources.list.d/*.list 文件内容。DEB 仓库是平铺仓库（./），
+// Release 有签名时用 signed-by 校验，否则标记为 trusted
+func GenerateAptListFile(c ClientRepoConfig) string {
+	options := "[trusted=yes] "
+	if c.KeyPath != "" {
+		options = fmt.Sprintf("[signed-by=%s] ", c.KeyPath)
+	}
+	return fmt.Sprintf("# %s\ndeb %s%s ./\n", c.Name, options, c.BaseURL)
+}
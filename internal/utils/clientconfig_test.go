@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRepoID(t *testing.T) {
+	testCases := map[string]string{
+		"oe-release":          "plus-oe-release",
+		"centos/7/x86_64":     "plus-centos-7-x86_64",
+		"/ubuntu_20_04/main/": "plus-ubuntu_20_04-main",
+		"":                    "plus",
+	}
+
+	for name, expected := range testCases {
+		if got := RepoID(name); got != expected {
+			t.Errorf("RepoID(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+func TestGenerateYumRepoFile(t *testing.T) {
+	c := ClientRepoConfig{
+		ID:      "plus-centos-7",
+		Name:    "centos/7",
+		BaseURL: "http://example.com/repo/centos/7/files/",
+	}
+
+	content := GenerateYumRepoFile(c)
+	for _, line := range []string{"[plus-centos-7]", "baseurl=http://example.com/repo/centos/7/files/", "gpgcheck=0"} {
+		if !strings.Contains(content, line+"\n") {
+			t.Errorf("Expected %q in:\n%s", line, content)
+		}
+	}
+
+	c.GPGKey = "http://example.com/repo/centos/7/gpg-key"
+	content = GenerateYumRepoFile(c)
+	if !strings.Contains(content, "gpgcheck=1\n") || !strings.Contains(content, "gpgkey="+c.GPGKey+"\n") {
+		t.Errorf("Expected gpg settings in:\n%s", content)
+	}
+}
+
+func TestGenerateAptListFile(t *testing.T) {
+	c := ClientRepoConfig{
+		Name:    "ubuntu",
+		BaseURL: "http://example.com/repo/ubuntu/files/",
+	}
+
+	if got := GenerateAptListFile(c); !strings.Contains(got, "deb [trusted=yes] http://example.com/repo/ubuntu/files/ ./\n") {
+		t.Errorf("Unexpected flat list file:\n%s", got)
+	}
+
+	// 有公钥但 Release 没有签名时仍然是 trusted
+	c.GPGKey = "http://example.com/repo/ubuntu/gpg-key"
+	if got := GenerateAptListFile(c); !strings.Contains(got, "deb [trusted=yes] http://example.com/repo/ubuntu/files/ ./\n") {
+		t.Errorf("Unexpected list file for unsigned Release:\n%s", got)
+	}
+
+	c.KeyPath = "/etc/apt/keyrings/plus-ubuntu.gpg"
+	if got := GenerateAptListFile(c); !strings.Contains(got, "deb [signed-by=/etc/apt/keyrings/plus-ubuntu.gpg] http://example.com/repo/ubuntu/files/ ./\n") {
+		t.Errorf("Unexpected signed list file:\n%s", got)
+	}
+}
+
+func TestGenerateSetupScript(t *testing.T) {
+	c := ClientRepoConfig{
+		ID:      "plus-centos-7",
+		Name:    "centos/7",
+		BaseURL: "http://example.com/repo/centos/7/files/",
+		GPGKey:  "http://example.com/repo/centos/7/gpg-key",
+		KeyPath: "/etc/apt/keyrings/plus-centos-7.gpg",
+	}
+
+	rpmScript := GenerateSetupScript("centos/7", "rpm", c)
+	for _, s := range []string{
+		"#!/bin/sh",
+		"rpm --import 'http://example.com/repo/centos/7/gpg-key'",
+		"printf '%s' '[plus-centos-7]\n",
+		"' > '/etc/yum.repos.d/plus-centos-7.repo'\n",
+		"--enablerepo='plus-centos-7'",
+		"curl -fsSL 'http://example.com/repo/centos/7/setup.sh' | sudo sh",
+	} {
+		if !strings.Contains(rpmScript, s) {
+			t.Errorf("Expected %q in rpm setup script", s)
+		}
+	}
+	if strings.Contains(rpmScript, "sources.list.d") {
+		t.Error("RPM setup script should not configure apt")
+	}
+
+	debScript := GenerateSetupScript("centos/7", "deb", c)
+	for _, s := range []string{
+		"fetch 'http://example.com/repo/centos/7/gpg-key' | gpg --dearmor --yes -o '/etc/apt/keyrings/plus-centos-7.gpg'",
+		"' > '/etc/apt/sources.list.d/plus-centos-7.list'\n",
+		"apt-get update -q",
+	} {
+		if !strings.Contains(debScript, s) {
+			t.Errorf("Expected %q in deb setup script", s)
+		}
+	}
+
+	// Release 没有签名时不安装公钥
+	c.KeyPath = ""
+	if debScript := GenerateSetupScript("centos/7", "deb", c); strings.Contains(debScript, "gpg --dearmor") || !strings.Contains(debScript, "[trusted=yes]") {
+		t.Errorf("Unsigned deb setup script:\n%s", debScript)
+	}
+}
+
+func TestSetupScriptQuoting(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not installed")
+	}
+	for _, s := range []string{"plain", "it's", "$(touch /tmp/x)", "`id`", "a'b'c", "\"quoted\" $HOME", "line\nPLUS_EOF\n"} {
+		out, err := exec.Command(sh, "-c", "printf '%s' "+shellQuote(s)).Output()
+		if err != nil {
+			t.Fatalf("sh failed for %q: %v", s, err)
+		}
+		if string(out) != s {
+			t.Errorf("shellQuote(%q) evaluates to %q", s, out)
+		}
+	}
+
+	c := ClientRepoConfig{
+		ID:      "plus-x';touch /tmp/pwned;'",
+		Name:    "x",
+		BaseURL: "http://evil$(id)/repo/x/files/",
+		GPGKey:  "http://evil$(id)/repo/x/gpg-key",
+		KeyPath: "/etc/apt/keyrings/x'$(id).gpg",
+	}
+	for _, typ := range []string{"rpm", "deb"} {
+		script := GenerateSetupScript("x'\n$(id)", typ, c)
+		for _, s := range []string{"--enablerepo=plus-x';", "rpm --import http", "fetch http", "-o /etc"} {
+			if strings.Contains(script, s) {
+				t.Errorf("Unquoted %q in %s setup script:\n%s", s, typ, script)
+			}
+		}
+		if strings.Contains(script, "# Plus repository setup script for x'\n") {
+			t.Errorf("Repository name breaks out of the comment in %s setup script", typ)
+		}
+		if err := exec.Command(sh, "-n", "-c", script).Run(); err != nil {
+			t.Errorf("Invalid %s setup script: %v\n%s", typ, err, script)
+		}
+	}
+}
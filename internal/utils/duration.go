@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration 解析 "90m"、"12h" 等 time.ParseDuration 支持的时长，以及按天和周的 "7d"、"2w"
+func ParseDuration(s string) (time.Duration, error) {
+	v := strings.TrimSpace(s)
+	for _, u := range []struct {
+		suffix string
+		scale  time.Duration
+	}{{"d", 24 * time.Hour}, {"w", 7 * 24 * time.Hour}} {
+		if n, ok := strings.CutSuffix(v, u.suffix); ok {
+			days, err := strconv.ParseFloat(n, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(days * float64(u.scale)), nil
+		}
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"path"
+	"strings"
+)
+
+// ValidGlob 检查 glob 模式的语法
+func ValidGlob(pattern string) bool {
+	for _, seg := range strings.Split(pattern, "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return false
+		}
+	}
+	return pattern != ""
+}
+
+// MatchGlob 按 / 分段匹配 glob，*、? 和 [...] 不跨目录。
+// recursive 时 ** 段匹配任意层目录（包括零层），否则 ** 与 * 相同
+func MatchGlob(pattern, name string, recursive bool) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"), recursive)
+}
+
+func matchSegments(pattern, name []string, recursive bool) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" && recursive {
+			// 合并连续的 **
+			for len(pattern) > 1 && pattern[1] == "**" {
+				pattern = pattern[1:]
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:], recursive) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		seg := pattern[0]
+		if seg == "**" {
+			seg = "*"
+		}
+		if ok, _ := path.Match(seg, name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
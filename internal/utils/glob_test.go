@@ -0,0 +1,40 @@
+package utils
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	testCases := []struct {
+		pattern   string
+		name      string
+		recursive bool
+		expected  bool
+	}{
+		{"**/*.rpm", "Packages/a.rpm", true, true},
+		{"**/*.rpm", "a.rpm", true, true},
+		{"**/*.rpm", "Packages/x86_64/a.rpm", true, true},
+		{"**/*.rpm", "Packages/a.deb", true, false},
+		{"**", "Packages/a.rpm", true, true},
+		{"Packages/**/a-*.rpm", "Packages/a-1.rpm", true, true},
+		{"Packages/**/**/a-*.rpm", "Packages/x/y/a-1.rpm", true, true},
+		{"*.rpm", "Packages/a.rpm", true, false},
+		{"Packages/*.rpm", "Packages/a.rpm", false, true},
+		{"Packages/*.rpm", "Packages/x86_64/a.rpm", false, false},
+		// 非递归时 ** 与 * 相同
+		{"**/*.rpm", "Packages/a.rpm", false, true},
+		{"**/*.rpm", "Packages/x86_64/a.rpm", false, false},
+		{"pool/main/[a-m]*/*.deb", "pool/main/nginx/nginx_1.0_amd64.deb", false, false},
+		{"pool/main/[n-z]*/*.deb", "pool/main/nginx/nginx_1.0_amd64.deb", false, true},
+	}
+
+	for _, tc := range testCases {
+		if got := MatchGlob(tc.pattern, tc.name, tc.recursive); got != tc.expected {
+			t.Errorf("MatchGlob(%q, %q, %v) = %v, expected %v", tc.pattern, tc.name, tc.recursive, got, tc.expected)
+		}
+	}
+
+	for pattern, expected := range map[string]bool{"**/*.rpm": true, "": false, "Packages/[a-": false} {
+		if got := ValidGlob(pattern); got != expected {
+			t.Errorf("ValidGlob(%q) = %v, expected %v", pattern, got, expected)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"path"
+	"strings"
+
+	"plus/internal/mimetype"
+)
+
+// 安装树（kickstart/PXE）允许的顶层目录
+var installTreeDirs = []string{
+	"images/", "EFI/", "isolinux/", "LiveOS/", "ppc/", "boot/",
+}
+
+// 安装树允许的顶层文件
+var installTreeFiles = map[string]bool{
+	".treeinfo":   true,
+	"treeinfo":    true,
+	".discinfo":   true,
+	"media.repo":  true,
+	"GPL":         true,
+	"EULA":        true,
+	"RPM-GPG-KEY": true,
+}
+
+// IsInstallTreePath 判断路径是否属于 RPM 仓库中的安装树文件
+func IsInstallTreePath(p string) bool {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if p == "" || p == "." {
+		return false
+	}
+	if installTreeFiles[p] || strings.HasPrefix(p, "RPM-GPG-KEY-") {
+		return true
+	}
+	for _, dir := range installTreeDirs {
+		if strings.HasPrefix(p, dir) && len(p) > len(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindInstallTreePath 在 "仓库名/路径" 形式的完整路径中查找安装树文件（仓库名可能为多级）
+func FindInstallTreePath(p string) (string, bool) {
+	segments := strings.Split(strings.Trim(path.Clean("/"+p), "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		candidate := strings.Join(segments[i:], "/")
+		if IsInstallTreePath(candidate) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// GetInstallTreeContentType 返回安装树文件的 Content-Type，配置的 mime-types 优先
+func GetInstallTreeContentType(p string) string {
+	base := path.Base(p)
+	if contentType, ok := mimetype.Lookup(base); ok {
+		return contentType
+	}
+	switch {
+	case base == ".treeinfo" || base == "treeinfo" || base == ".discinfo" || base == "media.repo":
+		return "text/plain; charset=utf-8"
+	case base == "GPL" || base == "EULA" || strings.HasPrefix(base, "RPM-GPG-KEY"):
+		return "text/plain; charset=utf-8"
+	case strings.HasSuffix(base, ".cfg") || strings.HasSuffix(base, ".conf") || strings.HasSuffix(base, ".msg"):
+		return "text/plain; charset=utf-8"
+	case strings.HasSuffix(base, ".iso"):
+		return "application/x-iso9660-image"
+	case strings.HasSuffix(base, ".efi") || strings.HasSuffix(base, ".EFI"):
+		return "application/efi"
+	case strings.HasSuffix(base, ".img") || strings.HasPrefix(base, "vmlinuz") || strings.HasPrefix(base, "initrd"):
+		return "application/octet-stream"
+	default:
+		return GetContentTypeByExtension(base)
+	}
+}
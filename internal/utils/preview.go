@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+
+	"plus/internal/mimetype"
+)
+
+// previewTextExtensions 按纯文本预览的扩展名，内置类型中没有或不是 text/*
+var previewTextExtensions = map[string]bool{
+	".md": true, ".markdown": true, ".rst": true, ".log": true, ".txt": true,
+	".json": true, ".xml": true, ".yaml": true, ".yml": true, ".toml": true,
+	".ini": true, ".conf": true, ".cfg": true, ".csv": true, ".spec": true,
+	".sh": true, ".py": true, ".go": true, ".c": true, ".h": true, ".js": true,
+	".diff": true, ".patch": true, ".asc": true, ".html": true, ".htm": true, ".svg": true,
+}
+
+// previewTextNames 没有扩展名、按纯文本预览的文件
+var previewTextNames = map[string]bool{
+	"README": true, "LICENSE": true, "COPYING": true, "CHANGELOG": true, "NOTICE": true,
+	"SHA256SUMS": true, "SHA512SUMS": true, "MD5SUMS": true, "Makefile": true, "Dockerfile": true,
+}
+
+// previewMediaTypes 浏览器可以直接显示、按原类型预览的文件
+var previewMediaTypes = map[string]bool{
+	"image/png": true, "image/jpeg": true, "image/gif": true, "image/webp": true, "image/avif": true,
+	"application/pdf": true,
+}
+
+// PreviewContentType 返回在浏览器中预览 filename 时使用的 Content-Type，不能预览时返回 false。
+// 文本类文件（包括 HTML 和 SVG）一律按纯文本显示，其中的脚本不会执行
+func PreviewContentType(filename string) (string, bool) {
+	base := filepath.Base(filename)
+	ext := strings.ToLower(filepath.Ext(base))
+	if previewTextExtensions[ext] || previewTextNames[base] {
+		return "text/plain; charset=utf-8", true
+	}
+	contentType := mimetype.ByExtension(base)
+	if mediaType, _, _ := strings.Cut(contentType, ";"); previewMediaTypes[strings.TrimSpace(mediaType)] {
+		return contentType, true
+	}
+	if strings.HasPrefix(contentType, "text/") {
+		return "text/plain; charset=utf-8", true
+	}
+	return "", false
+}
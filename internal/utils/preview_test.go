@@ -0,0 +1,22 @@
+package utils
+
+import "testing"
+
+func TestPreviewContentType(t *testing.T) {
+	for name, want := range map[string]string{
+		"build/output.log":     "text/plain; charset=utf-8",
+		"docs/README.md":       "text/plain; charset=utf-8",
+		"site/index.html":      "text/plain; charset=utf-8",
+		"SHA256SUMS":           "text/plain; charset=utf-8",
+		"screenshot.PNG":       "image/png",
+		"manual.pdf":           "application/pdf",
+		"app-1.0.tar.gz":       "",
+		"nginx-1.0.x86_64.rpm": "",
+		"disk.qcow2":           "",
+	} {
+		got, ok := PreviewContentType(name)
+		if got != want || ok != (want != "") {
+			t.Errorf("PreviewContentType(%q) = %q, %v, want %q", name, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+// ReadmeNames 目录列表下方显示的说明文件，按顺序查找，不区分大小写
+var ReadmeNames = []string{"README.md", "README.markdown", "README.txt", "README"}
+
+// MaxReadmeSize 超过该大小的说明文件不显示
+const MaxReadmeSize = 256 << 10
+
+// FindReadme 返回 names（目录中的文件名）中的说明文件，没有时返回 false
+func FindReadme(names []string) (string, bool) {
+	for _, readme := range ReadmeNames {
+		for _, name := range names {
+			if strings.EqualFold(name, readme) {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// RenderReadme 把说明文件渲染为 HTML。Markdown 跳过其中的原始 HTML，链接只保留安全的协议；其他文件按预格式文本显示
+func RenderReadme(name string, data []byte) string {
+	lower := strings.ToLower(name)
+	if !strings.HasSuffix(lower, ".md") && !strings.HasSuffix(lower, ".markdown") {
+		return "<pre>" + html.EscapeString(string(data)) + "</pre>\n"
+	}
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.SkipHTML | blackfriday.Safelink | blackfriday.NofollowLinks | blackfriday.NoreferrerLinks,
+	})
+	return string(blackfriday.Run(data, blackfriday.WithRenderer(renderer), blackfriday.WithExtensions(blackfriday.CommonExtensions)))
+}
+
+// InsertReadme 在目录列表页面的 </body> 之前插入渲染后的说明文件，没有 </body> 时追加到页面末尾
+func InsertReadme(page, name, rendered string) string {
+	i := strings.LastIndex(page, "</body>")
+	if i < 0 {
+		i = len(page)
+	}
+	block := fmt.Sprintf(`    <div class="readme" style="max-width: 1200px; margin: 20px auto; padding: 20px; background: white; border: 1px solid #e1e4e8; border-radius: 8px; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; line-height: 1.5;">
+        <div class="readme-name" style="color: #666; font-size: 0.9em; border-bottom: 1px solid #e1e4e8; padding-bottom: 8px; margin-bottom: 12px;">📖 %s</div>
+%s    </div>
+`, html.EscapeString(name), rendered)
+	return page[:i] + block + page[i:]
+}
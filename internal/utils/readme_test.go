@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindReadme(t *testing.T) {
+	if name, ok := FindReadme([]string{"app.tar.gz", "README", "readme.md"}); !ok || name != "readme.md" {
+		t.Errorf("FindReadme = %q, %v, want readme.md", name, ok)
+	}
+	if _, ok := FindReadme([]string{"README.rst", "docs"}); ok {
+		t.Error("FindReadme found a readme in a directory without one")
+	}
+}
+
+func TestRenderReadme(t *testing.T) {
+	got := RenderReadme("README.md", []byte("# Builds\n\nSee [docs](https://example.com) and [x](javascript:alert(1)).\n\n<script>alert(1)</script>\n"))
+	for _, want := range []string{"<h1>Builds</h1>", `href="https://example.com"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rendered README does not contain %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"<script>", "javascript:"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("rendered README contains %q:\n%s", unwanted, got)
+		}
+	}
+
+	if got := RenderReadme("README", []byte("a <b> c")); got != "<pre>a &lt;b&gt; c</pre>\n" {
+		t.Errorf("plain README = %q", got)
+	}
+
+	page := InsertReadme("<html><body><ul></ul></body></html>", "README.md", "<p>hi</p>\n")
+	if !strings.Contains(page, "<p>hi</p>\n    </div>\n</body>") {
+		t.Errorf("InsertReadme = %q", page)
+	}
+	if page := InsertReadme("<ul></ul>", "README", "<pre>x</pre>\n"); !strings.HasPrefix(page, "<ul></ul>") || !strings.HasSuffix(page, "<pre>x</pre>\n    </div>\n") {
+		t.Errorf("InsertReadme without </body> = %q", page)
+	}
+}
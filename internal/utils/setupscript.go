@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// shellQuote 用单引号包住 s 并转义其中的单引号，shell 不会展开其中的任何字符
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellComment 去掉换行，避免内容跳出注释行
+func shellComment(s string) string {
+	return strings.NewReplacer("\n", " ", "\r", " ").Replace(s)
+}
+
+// GenerateSetupScript 生成客户端一键配置脚本，自动识别 dnf/yum/apt。
+// 插入脚本的值都经过 shellQuote，仓库名和地址中的特殊字符不会被 shell 执行
+func GenerateSetupScript(repoName, repoType string, c ClientRepoConfig) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `#!/bin/sh
+# Plus repository setup script for %s
+#
+#   curl -fsSL %s | sudo sh
+#
+set -e
+
+if [ "$(id -u)" -ne 0 ]; then
+    echo "This script must be run as root" >&2
+    exit 1
+fi
+
+fetch() {
+    if command -v curl >/dev/null 2>&1; then
+        curl -fsSL "$1"
+    elif command -v wget >/dev/null 2>&1; then
+        wget -qO- "$1"
+    else
+        echo "curl or wget is required" >&2
+        exit 1
+    fi
+}
+
+if command -v dnf >/dev/null 2>&1; then
+    PM=dnf
+elif command -v yum >/dev/null 2>&1; then
+    PM=yum
+elif command -v apt-get >/dev/null 2>&1; then
+    PM=apt
+else
+    echo "No supported package manager found (dnf, yum, apt)" >&2
+    exit 1
+fi
+
+`, shellComment(repoName), shellComment(shellQuote(strings.TrimSuffix(c.BaseURL, "files/")+"setup.sh")))
+
+	switch repoType {
+	case "rpm":
+		fmt.Fprintf(&b, `if [ "$PM" = "apt" ]; then
+    echo %s >&2
+    exit 1
+fi
+
+`, shellQuote("Repository "+repoName+" is an RPM repository and requires dnf or yum"))
+		if c.GPGKey != "" {
+			fmt.Fprintf(&b, "echo \"Importing signing key...\"\nrpm --import %s\n\n", shellQuote(c.GPGKey))
+		}
+		path := shellQuote("/etc/yum.repos.d/" + c.ID + ".repo")
+		fmt.Fprintf(&b, "echo %s\n", shellQuote("Writing /etc/yum.repos.d/"+c.ID+".repo"))
+		fmt.Fprintf(&b, "printf '%%s' %s > %s\n\n", shellQuote(GenerateYumRepoFile(c)), path)
+		fmt.Fprintf(&b, "$PM -q makecache --disablerepo='*' --enablerepo=%s\n", shellQuote(c.ID))
+	case "deb":
+		fmt.Fprintf(&b, `if [ "$PM" != "apt" ]; then
+    echo %s >&2
+    exit 1
+fi
+
+`, shellQuote("Repository "+repoName+" is a DEB repository and requires apt"))
+		if c.GPGKey != "" && c.KeyPath != "" {
+			fmt.Fprintf(&b, `if ! command -v gpg >/dev/null 2>&1; then
+    apt-get update -q && apt-get install -y -q gnupg
+fi
+echo %[3]s
+mkdir -p "$(dirname %[1]s)"
+fetch %[2]s | gpg --dearmor --yes -o %[1]s
+
+`, shellQuote(c.KeyPath), shellQuote(c.GPGKey), shellQuote("Installing signing key to "+c.KeyPath))
+		}
+		path := shellQuote("/etc/apt/sources.list.d/" + c.ID + ".list")
+		fmt.Fprintf(&b, "echo %s\n", shellQuote("Writing /etc/apt/sources.list.d/"+c.ID+".list"))
+		fmt.Fprintf(&b, "printf '%%s' %s > %s\n\n", shellQuote(GenerateAptListFile(c)), path)
+		b.WriteString("apt-get update -q\n")
+	}
+
+	fmt.Fprintf(&b, "\necho %s\n", shellQuote("Repository "+repoName+" configured successfully"))
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+)
+
+// thumbnailURL 返回目录页面中文件链接对应的缩略图地址，为空时显示文件图标
+var thumbnailURL func(href string) string
+
+// SetThumbnailURL 设置目录页面中图片的缩略图地址，fn 的参数是文件的链接
+func SetThumbnailURL(fn func(href string) string) {
+	thumbnailURL = fn
+}
+
+// fileIcon 返回目录页面中文件的图标，有缩略图的图片显示缩略图
+func fileIcon(name, href string) string {
+	if thumbnailURL != nil {
+		if src := thumbnailURL(href); src != "" {
+			return fmt.Sprintf(`<img src="%s" alt="" loading="lazy" width="48" height="48" style="object-fit: contain; vertical-align: middle;">`, html.EscapeString(src))
+		}
+	}
+	return GetFileIcon(name)
+}
@@ -6,9 +6,11 @@ import (
 	"os"
 	"path/filepath"
 	"plus/internal/log"
+	"plus/internal/mimetype"
 	"plus/internal/types"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // 验证仓库名称
@@ -122,7 +124,7 @@ func GenerateDirectoryHTMLNew(repoPath string, entries []os.DirEntry) string {
 		} else {
 			linkPath := fmt.Sprintf("%s/%s", currentPath, name)
 			size := FormatFileSize(info.Size())
-			icon := GetFileIcon(name)
+			icon := fileIcon(name, linkPath)
 			modTime := info.ModTime().Format("2006-01-02 15:04:05")
 
 			html.WriteString(fmt.Sprintf(`        <li>
@@ -215,7 +217,7 @@ func GenerateDirectoryHTML(repoName, subPath string, entries []os.DirEntry) stri
 		} else {
 			linkPath := fmt.Sprintf("%s/%s", currentPath, name)
 			size := FormatFileSize(info.Size())
-			icon := GetFileIcon(name)
+			icon := fileIcon(name, linkPath)
 			modTime := info.ModTime().Format("2006-01-02 15:04:05")
 
 			html.WriteString(fmt.Sprintf(`        <li>
@@ -377,7 +379,7 @@ func GenerateObjectStorageRepoHTML(repoName string, packages []types.PackageInfo
 	for _, pkg := range packages {
 		linkPath := fmt.Sprintf("%s/%s", currentPath, pkg.Name)
 		size := FormatFileSize(pkg.Size)
-		icon := GetFileIcon(pkg.Name)
+		icon := fileIcon(pkg.Name, linkPath)
 
 		html.WriteString(fmt.Sprintf(`        <li>
 			<div class="file-info">
@@ -779,7 +781,7 @@ func GenerateEnhancedDirectoryHTML(cleanPath, fullPath, repoType string) (string
             
             linkPath := fmt.Sprintf("%s/%s", currentPath, name)
             size := FormatFileSize(info.Size())
-            icon := GetFileIcon(name)
+            icon := fileIcon(name, linkPath)
             modTime := info.ModTime().Format("2006-01-02 15:04")
             
             // 统计包文件
@@ -1090,9 +1092,9 @@ func GenerateObjectStorageDirectoryHTML(repoName, displayPath string, packages [
     // 文件列表
     for _, pkg := range packages {
         totalSize += pkg.Size
-        icon := GetFileIcon(pkg.Name)
-        size := FormatFileSize(pkg.Size)
         linkPath := fmt.Sprintf("/%s/%s", displayPath, pkg.Name)
+        icon := fileIcon(pkg.Name, linkPath)
+        size := FormatFileSize(pkg.Size)
 
         html.WriteString(fmt.Sprintf(`
                 <li class="file-item">
@@ -1161,44 +1163,9 @@ func HandleRootPath() string {
 	return html
 }
 
+// GetContentTypeByExtension 返回下载文件的 Content-Type，配置的 mime-types 优先
 func GetContentTypeByExtension(filename string) string {
-    ext := strings.ToLower(filepath.Ext(filename))
-    switch ext {
-    case ".txt", ".log":
-        return "text/plain; charset=utf-8"
-    case ".json":
-        return "application/json"
-    case ".xml":
-        return "application/xml"
-    case ".html", ".htm":
-        return "text/html; charset=utf-8"
-    case ".css":
-        return "text/css"
-    case ".js":
-        return "application/javascript"
-    case ".png":
-        return "image/png"
-    case ".jpg", ".jpeg":
-        return "image/jpeg"
-    case ".gif":
-        return "image/gif"
-    case ".svg":
-        return "image/svg+xml"
-    case ".pdf":
-        return "application/pdf"
-    case ".zip":
-        return "application/zip"
-    case ".gz":
-        return "application/gzip"
-    case ".rpm":
-        return "application/x-rpm"
-    case ".deb":
-        return "application/vnd.debian.binary-package"
-    case ".sql":
-        return "text/plain; charset=utf-8"
-    default:
-        return "application/octet-stream"
-    }
+	return mimetype.ByExtension(filename)
 }
 
 func GetRepoTypeIcon(repoType string) string {
@@ -1227,6 +1194,14 @@ func FormatFileSize(size int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
 }
 
+// FormatModTime 以 RFC3339 格式输出修改时间，零值返回空字符串
+func FormatModTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 func GetFileIcon(filename string) string {
 	ext := strings.ToLower(filepath.Ext(filename))
 	switch ext {
@@ -1304,7 +1279,7 @@ func GetFileTypeErrorMessage(repoType string) string {
 	case "rpm":
 		return "This RPM repository only accepts .rpm files"
 	case "deb":
-		return "This DEB repository only accepts .deb files"
+		return "This DEB repository only accepts .deb files and source packages (.dsc and tarballs)"
 	case "files":
 		return "Invalid file type"
 	default:
@@ -1319,7 +1294,7 @@ func ValidateFileTypeForRepo(filename, repoType string) bool {
 	case "rpm":
 		return strings.HasSuffix(filename, ".rpm")
 	case "deb":
-		return strings.HasSuffix(filename, ".deb")
+		return strings.HasSuffix(filename, ".deb") || IsDebianSourceFile(filename)
 	case "files":
 		return true // files 类型接受任何文件
 	default:
@@ -1327,6 +1302,50 @@ func ValidateFileTypeForRepo(filename, repoType string) bool {
 	}
 }
 
+// IsSourceRPM 判断是否为源码 RPM 包（.src.rpm 或 .nosrc.rpm）
+func IsSourceRPM(filename string) bool {
+	filename = strings.ToLower(filename)
+	return strings.HasSuffix(filename, ".src.rpm") || strings.HasSuffix(filename, ".nosrc.rpm")
+}
+
+// PackageArch 按文件名读取包的架构：name-version-release.arch.rpm 中的 arch（源码包为 src），
+// name_version_arch.deb 中的 arch，Debian 源码包的 .dsc 为 source，其他文件返回空
+func PackageArch(filename string) string {
+	name := filepath.Base(filename)
+	switch {
+	case strings.HasSuffix(name, ".rpm"):
+		base := strings.TrimSuffix(name, ".rpm")
+		if i := strings.LastIndex(base, "."); i > 0 {
+			return base[i+1:]
+		}
+	case strings.HasSuffix(name, ".deb"):
+		if parts := strings.Split(strings.TrimSuffix(name, ".deb"), "_"); len(parts) == 3 {
+			return parts[2]
+		}
+	case strings.HasSuffix(name, ".dsc"):
+		return "source"
+	}
+	return ""
+}
+
+// IsDebianSourceFile 判断是否为 Debian 源码包的文件：.dsc、原始和 debian 目录的 tar 包、旧格式的 .diff.gz，
+// 以及原始 tar 包的签名
+func IsDebianSourceFile(filename string) bool {
+	filename = strings.ToLower(filename)
+	if strings.HasSuffix(filename, ".dsc") || strings.HasSuffix(filename, ".diff.gz") {
+		return true
+	}
+	if strings.Contains(filename, ".orig") && strings.HasSuffix(filename, ".asc") {
+		filename = strings.TrimSuffix(filename, ".asc")
+	}
+	for _, ext := range []string{".tar.gz", ".tar.bz2", ".tar.xz", ".tar.lzma"} {
+		if strings.HasSuffix(filename, ext) {
+			return true
+		}
+	}
+	return false
+}
+
 // 常见文件扩展名列表
 var commonFileExtensions = map[string]bool{
     ".txt": true, ".log": true, ".json": true, ".xml": true,
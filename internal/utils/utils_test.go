@@ -43,3 +43,46 @@ func TestIsValidRepoName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFileTypeForRepo(t *testing.T) {
+	testCases := []struct {
+		filename string
+		repoType string
+		expected bool
+	}{
+		{"nginx-1.24.0-1.el9.x86_64.rpm", "rpm", true},
+		{"nginx-1.24.0-1.el9.src.rpm", "rpm", true},
+		{"hello_1.0-1_amd64.deb", "deb", true},
+		{"hello_1.0-1.dsc", "deb", true},
+		{"hello_1.0.orig.tar.gz", "deb", true},
+		{"hello_1.0.orig.tar.gz.asc", "deb", true},
+		{"hello_1.0-1.debian.tar.xz", "deb", true},
+		{"hello_1.0-1.diff.gz", "deb", true},
+		{"hello_1.0-1.changes", "deb", false},
+		{"hello_1.0-1.tar.gz.asc", "deb", false},
+		{"hello-1.0.rpm", "deb", false},
+	}
+	for _, tc := range testCases {
+		if got := ValidateFileTypeForRepo(tc.filename, tc.repoType); got != tc.expected {
+			t.Errorf("ValidateFileTypeForRepo(%q, %q) = %v, expected %v", tc.filename, tc.repoType, got, tc.expected)
+		}
+	}
+}
+
+func TestPackageArch(t *testing.T) {
+	testCases := map[string]string{
+		"nginx-1.24.0-1.el9.x86_64.rpm":           "x86_64",
+		"Packages/nginx-1.24.0-1.el9.aarch64.rpm": "aarch64",
+		"nginx-1.24.0-1.el9.src.rpm":              "src",
+		"hello_1.0-1_arm64.deb":                   "arm64",
+		"pool/h/hello_1.0-1_all.deb":              "all",
+		"hello_1.0-1.dsc":                         "source",
+		"hello.deb":                               "",
+		"README.md":                               "",
+	}
+	for filename, expected := range testCases {
+		if got := PackageArch(filename); got != expected {
+			t.Errorf("PackageArch(%q) = %q, expected %q", filename, got, expected)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+
+	"plus/internal/mimetype"
+)
+
+// websiteContentTypes 静态网站常见文件的 Content-Type，GetContentTypeByExtension 中没有或需要声明字符集的类型
+var websiteContentTypes = map[string]string{
+	".html":        "text/html; charset=utf-8",
+	".htm":         "text/html; charset=utf-8",
+	".css":         "text/css; charset=utf-8",
+	".js":          "text/javascript; charset=utf-8",
+	".mjs":         "text/javascript; charset=utf-8",
+	".json":        "application/json",
+	".map":         "application/json",
+	".webmanifest": "application/manifest+json",
+	".xml":         "application/xml",
+	".rss":         "application/rss+xml",
+	".atom":        "application/atom+xml",
+	".txt":         "text/plain; charset=utf-8",
+	".md":          "text/markdown; charset=utf-8",
+	".csv":         "text/csv; charset=utf-8",
+	".yaml":        "application/yaml",
+	".yml":         "application/yaml",
+	".svg":         "image/svg+xml",
+	".png":         "image/png",
+	".jpg":         "image/jpeg",
+	".jpeg":        "image/jpeg",
+	".gif":         "image/gif",
+	".webp":        "image/webp",
+	".avif":        "image/avif",
+	".ico":         "image/x-icon",
+	".woff":        "font/woff",
+	".woff2":       "font/woff2",
+	".ttf":         "font/ttf",
+	".otf":         "font/otf",
+	".wasm":        "application/wasm",
+	".mp4":         "video/mp4",
+	".webm":        "video/webm",
+	".mp3":         "audio/mpeg",
+	".pdf":         "application/pdf",
+}
+
+// GetWebsiteContentType 返回静态网站模式下文件的 Content-Type，浏览器按它渲染页面、加载样式和脚本。配置的 mime-types 优先
+func GetWebsiteContentType(filename string) string {
+	if contentType, ok := mimetype.Lookup(filename); ok {
+		return contentType
+	}
+	if contentType, ok := websiteContentTypes[strings.ToLower(filepath.Ext(filename))]; ok {
+		return contentType
+	}
+	return GetContentTypeByExtension(filename)
+}
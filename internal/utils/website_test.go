@@ -0,0 +1,20 @@
+package utils
+
+import "testing"
+
+func TestGetWebsiteContentType(t *testing.T) {
+	for name, want := range map[string]string{
+		"index.html":          "text/html; charset=utf-8",
+		"assets/app.JS":       "text/javascript; charset=utf-8",
+		"fonts/inter.woff2":   "font/woff2",
+		"logo.svg":            "image/svg+xml",
+		"site.webmanifest":    "application/manifest+json",
+		"plus-1.0.tar.gz":     "application/gzip",
+		"plus-1.0.x86_64.rpm": "application/x-rpm",
+		"LICENSE":             "application/octet-stream",
+	} {
+		if got := GetWebsiteContentType(name); got != want {
+			t.Errorf("GetWebsiteContentType(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+const defaultQueueSize = 256
+
+// ErrQueueFull 队列已满，任务未提交
+var ErrQueueFull = errors.New("worker queue is full")
+
+// Pool 固定数量的后台协程执行上传后的校验和计算、元数据生成等任务，与请求处理隔离
+type Pool struct {
+	workers int
+	queue   chan job
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	running   int64
+	completed int64
+	failed    int64
+	rejected  int64
+	waitNanos int64 // 已开始任务在队列中等待的总时长
+}
+
+type job struct {
+	name     string
+	fn       func(ctx context.Context) error
+	queuedAt time.Time
+}
+
+// New 创建并启动工作池，workers 为 0 时使用 CPU 数，queueSize 为 0 时使用默认值
+func New(workers, queueSize int) *Pool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		workers: workers,
+		queue:   make(chan job, queueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.run()
+	}
+	return p
+}
+
+// Submit 将任务加入队列，不阻塞；队列满时返回 ErrQueueFull
+func (p *Pool) Submit(name string, fn func(ctx context.Context) error) error {
+	select {
+	case p.queue <- job{name: name, fn: fn, queuedAt: time.Now()}:
+		return nil
+	default:
+		atomic.AddInt64(&p.rejected, 1)
+		return ErrQueueFull
+	}
+}
+
+// Close 停止工作池并等待进行中的任务结束，队列中未开始的任务被丢弃
+func (p *Pool) Close() {
+	p.cancel()
+	p.wg.Wait()
+}
+
+func (p *Pool) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case j := <-p.queue:
+			p.execute(j)
+		}
+	}
+}
+
+func (p *Pool) execute(j job) {
+	atomic.AddInt64(&p.waitNanos, int64(time.Since(j.queuedAt)))
+	atomic.AddInt64(&p.running, 1)
+	defer atomic.AddInt64(&p.running, -1)
+
+	start := time.Now()
+	err := p.safeRun(j)
+	if err != nil {
+		atomic.AddInt64(&p.failed, 1)
+		log.Logger.Warnf("Background job %s failed: %v", j.name, err)
+		return
+	}
+	atomic.AddInt64(&p.completed, 1)
+	log.Logger.Debugf("Background job %s done in %s", j.name, time.Since(start))
+}
+
+// safeRun 任务 panic 时记为失败，不影响工作协程
+func (p *Pool) safeRun(j job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("panic in background job")
+			log.Logger.Errorf("Background job %s panicked: %v", j.name, r)
+		}
+	}()
+	return j.fn(p.ctx)
+}
+
+// Metrics 返回队列和任务统计
+func (p *Pool) Metrics() *types.WorkerMetrics {
+	completed := atomic.LoadInt64(&p.completed)
+	failed := atomic.LoadInt64(&p.failed)
+	m := &types.WorkerMetrics{
+		Workers:   p.workers,
+		QueueSize: cap(p.queue),
+		Queued:    len(p.queue),
+		Running:   atomic.LoadInt64(&p.running),
+		Completed: completed,
+		Failed:    failed,
+		Rejected:  atomic.LoadInt64(&p.rejected),
+	}
+	if started := completed + failed + m.Running; started > 0 {
+		m.AvgWaitMs = atomic.LoadInt64(&p.waitNanos) / started / int64(time.Millisecond)
+	}
+	return m
+}
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestPoolQueue(t *testing.T) {
+	p := New(1, 2)
+	defer p.Close()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	block := func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}
+	noop := func(ctx context.Context) error { return nil }
+
+	if err := p.Submit("block", block); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	// 唯一的工作协程被占用，队列长度为 2
+	for i := 0; i < 2; i++ {
+		if err := p.Submit("queued", noop); err != nil {
+			t.Fatalf("submit %d: %v", i, err)
+		}
+	}
+	if err := p.Submit("overflow", noop); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+
+	m := p.Metrics()
+	if m.Workers != 1 || m.QueueSize != 2 || m.Queued != 2 || m.Running != 1 || m.Rejected != 1 {
+		t.Errorf("metrics while blocked = %+v", m)
+	}
+
+	close(release)
+	waitFor(t, func() bool { return p.Metrics().Completed == 3 })
+}
+
+func TestPoolFailures(t *testing.T) {
+	p := New(2, 0)
+	defer p.Close()
+
+	p.Submit("error", func(ctx context.Context) error { return errors.New("boom") })
+	p.Submit("panic", func(ctx context.Context) error { panic("boom") })
+	waitFor(t, func() bool { return p.Metrics().Failed == 2 })
+
+	// 任务 panic 后工作协程仍可用
+	done := make(chan struct{})
+	p.Submit("after", func(ctx context.Context) error { close(done); return nil })
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pool stopped after panic")
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
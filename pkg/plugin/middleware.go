@@ -0,0 +1,87 @@
+package plugin
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Middleware 包装请求处理器，与服务内置的中间件相同。按名称写入 middleware.chain 后生效
+type Middleware func(next fasthttp.RequestHandler) fasthttp.RequestHandler
+
+// Hook 在处理请求之前（middleware.pre）或之后（middleware.post）调用，可以修改请求或响应，
+// 例如加入请求头。需要拒绝请求时注册 Middleware
+type Hook func(ctx *fasthttp.RequestCtx)
+
+var (
+	handlersMu  sync.Mutex
+	middlewares = make(map[string]Middleware)
+	hooks       = make(map[string]Hook)
+)
+
+// RegisterMiddleware 注册中间件，在插件的 init 中调用。名称已注册时不生效
+func RegisterMiddleware(name string, m Middleware) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	if _, ok := middlewares[name]; !ok {
+		middlewares[name] = m
+	}
+}
+
+// RegisterHook 注册钩子，在插件的 init 中调用。名称已注册时不生效
+func RegisterHook(name string, h Hook) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	if _, ok := hooks[name]; !ok {
+		hooks[name] = h
+	}
+}
+
+// LookupMiddleware 返回插件注册的中间件
+func LookupMiddleware(name string) (Middleware, bool) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	m, ok := middlewares[name]
+	return m, ok
+}
+
+// LookupHook 返回插件注册的钩子
+func LookupHook(name string) (Hook, bool) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	h, ok := hooks[name]
+	return h, ok
+}
+
+func middlewareNames() map[string]bool {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	m := make(map[string]bool, len(middlewares))
+	for name := range middlewares {
+		m[name] = true
+	}
+	return m
+}
+
+func hookNames() map[string]bool {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	m := make(map[string]bool, len(hooks))
+	for name := range hooks {
+		m[name] = true
+	}
+	return m
+}
+
+// added 返回 after 中不在 before 里的名称，按名称排序
+func added(before, after map[string]bool) []string {
+	list := []string{}
+	for name := range after {
+		if !before[name] {
+			list = append(list, name)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,170 @@
+// Package plugin 加载第三方扩展，用于在不修改源码的情况下增加仓库类型、存储类型和请求中间件。
+//
+// 插件是用 go build -buildmode=plugin 编译的共享库，在 init 中调用 repo.Register 注册仓库类型、
+// storage.Register 注册存储类型、RegisterMiddleware 和 RegisterHook 注册请求中间件和钩子，
+// 并可以导出名为 PlusPlugin 的 Info 变量说明自身：
+//
+//	package main
+//
+//	func init() {
+//		repo.Register("acme", NewAcmeRepo)
+//	}
+//
+//	var PlusPlugin = plugin.Info{Name: "acme", Version: "1.0.0"}
+//
+// 插件必须与服务使用相同的 Go 版本和相同版本的 plus 模块编译
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	goplugin "plugin"
+	"sort"
+	"strings"
+	"sync"
+
+	"plus/internal/types"
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+)
+
+// Symbol 插件导出的说明变量名
+const Symbol = "PlusPlugin"
+
+// Info 插件的说明
+type Info struct {
+	Name        string
+	Version     string
+	Description string
+}
+
+var (
+	mu     sync.Mutex
+	loaded = make(map[string]types.PluginInfo) // 按绝对路径索引，同一个共享库只能加载一次
+)
+
+// Load 加载 paths 中的插件，目录中的 *.so 按名称顺序加载，返回本次新加载的插件。已加载的插件跳过
+func Load(paths []string) ([]types.PluginInfo, error) {
+	files, err := expand(paths)
+	if err != nil {
+		return nil, err
+	}
+	var result []types.PluginInfo
+	for _, file := range files {
+		info, added, err := load(file)
+		if err != nil {
+			return result, err
+		}
+		if added {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+// expand 把目录展开为其中的 *.so 文件
+func expand(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", p, err)
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(p, "*.so"))
+		if err != nil {
+			return nil, fmt.Errorf("plugin directory %s: %w", p, err)
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+func load(file string) (types.PluginInfo, bool, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return types.PluginInfo{}, false, fmt.Errorf("plugin %s: %w", file, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if info, ok := loaded[abs]; ok {
+		return info, false, nil
+	}
+
+	var p *goplugin.Plugin
+	info, err := track(abs, func() error {
+		var err error
+		p, err = goplugin.Open(abs)
+		return err
+	})
+	if err != nil {
+		return types.PluginInfo{}, false, fmt.Errorf("plugin %s: %w", file, err)
+	}
+	if sym, err := p.Lookup(Symbol); err == nil {
+		switch v := sym.(type) {
+		case *Info:
+			info.Name, info.Version, info.Description = v.Name, v.Version, v.Description
+		default:
+			return types.PluginInfo{}, false, fmt.Errorf("plugin %s: %s is %T, expected plugin.Info", file, Symbol, sym)
+		}
+	}
+	if info.Name == "" {
+		info.Name = strings.TrimSuffix(filepath.Base(abs), filepath.Ext(abs))
+	}
+	loaded[abs] = info
+	return info, true, nil
+}
+
+// track 调用 open 并返回其间新注册的仓库类型、存储类型、中间件和钩子。没有注册任何内容的插件视为错误
+func track(path string, open func() error) (types.PluginInfo, error) {
+	repoBefore, storageBefore := repoTypes(), storageTypes()
+	middlewareBefore, hookBefore := middlewareNames(), hookNames()
+	if err := open(); err != nil {
+		return types.PluginInfo{}, err
+	}
+	info := types.PluginInfo{
+		Path:         path,
+		RepoTypes:    added(repoBefore, repoTypes()),
+		StorageTypes: added(storageBefore, storageTypes()),
+		Middleware:   added(middlewareBefore, middlewareNames()),
+		Hooks:        added(hookBefore, hookNames()),
+	}
+	if len(info.RepoTypes) == 0 && len(info.StorageTypes) == 0 && len(info.Middleware) == 0 && len(info.Hooks) == 0 {
+		return types.PluginInfo{}, errors.New("registers no new repository type, storage type, middleware or hook")
+	}
+	return info, nil
+}
+
+func repoTypes() map[string]bool {
+	m := make(map[string]bool)
+	for _, rt := range repo.Types() {
+		m[string(rt)] = true
+	}
+	return m
+}
+
+func storageTypes() map[string]bool {
+	m := make(map[string]bool)
+	for _, st := range storage.Types() {
+		m[string(st)] = true
+	}
+	return m
+}
+
+// List 按路径返回已加载的插件
+func List() []types.PluginInfo {
+	mu.Lock()
+	defer mu.Unlock()
+	list := make([]types.PluginInfo, 0, len(loaded))
+	for _, info := range loaded {
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	return list
+}
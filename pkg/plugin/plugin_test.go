@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"plus/pkg/repo"
+	"plus/pkg/repo/files"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestTrack(t *testing.T) {
+	info, err := track("/plugins/acme.so", func() error {
+		repo.Register("acme-test", func(s storage.Storage) repo.Repo { return files.NewFilesRepo(s) })
+		storage.Register("acme-store-test", func(root string) (storage.Storage, error) { return local.NewLocalStorage(root) }, "acme-test")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(info.RepoTypes, []string{"acme-test"}) || !reflect.DeepEqual(info.StorageTypes, []string{"acme-store-test"}) {
+		t.Errorf("track = %+v", info)
+	}
+	// 插件注册的存储按标签用于插件的仓库类型
+	if st := storage.TypeByLable("acme-test"); st != "acme-store-test" {
+		t.Errorf("TypeByLable = %s", st)
+	}
+	if types := repo.Types(); types[0] != repo.RPM || types[len(types)-1] != "acme-test" {
+		t.Errorf("repo.Types = %v", types)
+	}
+
+	// 重复注册内置类型不生效
+	if _, err := track("/plugins/dup.so", func() error {
+		repo.Register(repo.RPM, func(s storage.Storage) repo.Repo { return files.NewFilesRepo(s) })
+		return nil
+	}); err == nil {
+		t.Error("plugin without new types accepted")
+	}
+}
+
+func TestTrackMiddleware(t *testing.T) {
+	info, err := track("/plugins/headers.so", func() error {
+		RegisterMiddleware("deny-test", func(next fasthttp.RequestHandler) fasthttp.RequestHandler { return next })
+		RegisterHook("inject-test", func(ctx *fasthttp.RequestCtx) { ctx.Request.Header.Set("X-Injected", "1") })
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(info.Middleware, []string{"deny-test"}) || !reflect.DeepEqual(info.Hooks, []string{"inject-test"}) ||
+		len(info.RepoTypes) != 0 {
+		t.Errorf("track = %+v", info)
+	}
+	hook, ok := LookupHook("inject-test")
+	if !ok {
+		t.Fatal("hook not registered")
+	}
+	var ctx fasthttp.RequestCtx
+	hook(&ctx)
+	if got := string(ctx.Request.Header.Peek("X-Injected")); got != "1" {
+		t.Errorf("X-Injected = %q", got)
+	}
+
+	// 同名的钩子只注册一次
+	if _, err := track("/plugins/dup.so", func() error {
+		RegisterHook("inject-test", func(ctx *fasthttp.RequestCtx) {})
+		return nil
+	}); err == nil {
+		t.Error("plugin without new hooks accepted")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Load([]string{filepath.Join(dir, "missing.so")}); err == nil {
+		t.Error("missing plugin accepted")
+	}
+	// 空目录没有插件
+	if list, err := Load([]string{dir}); err != nil || len(list) != 0 {
+		t.Errorf("Load(empty dir) = %v, %v", list, err)
+	}
+	os.WriteFile(filepath.Join(dir, "bad.so"), []byte("not a shared library"), 0644)
+	os.WriteFile(filepath.Join(dir, "README"), []byte("ignored"), 0644)
+	if _, err := Load([]string{dir}); err == nil {
+		t.Error("invalid shared library accepted")
+	}
+	if len(List()) != 0 {
+		t.Errorf("List = %v", List())
+	}
+}
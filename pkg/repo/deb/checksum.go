@@ -0,0 +1,63 @@
+package deb
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"plus/internal/deps"
+	"plus/internal/log"
+)
+
+// SumsFile 仓库根目录下按 Packages 生成的校验和文件，格式同 sha256sum 的输出
+const SumsFile = "SHA256SUMS"
+
+// GetPackageChecksum 返回包的 SHA256，优先使用 Packages 中记录的值；
+// 包还没有写入 Packages（上传后尚未刷新元数据）时读取文件计算
+func (d *DEBRepo) GetPackageChecksum(ctx context.Context, repoName string, filename string) (string, error) {
+	if !strings.HasSuffix(filename, ".deb") {
+		return "", fmt.Errorf("invalid file type, expected .deb")
+	}
+	location := strings.TrimPrefix(path.Clean("/"+filename), "/")
+
+	pkgs, err := d.PackageDeps(ctx, repoName)
+	if err != nil {
+		log.Logger.Debugf("No Packages index for %s, computing the checksum of %s: %v", repoName, filename, err)
+	}
+	for _, p := range pkgs {
+		if p.Location == location && p.SumType == "sha256" {
+			return p.Checksum, nil
+		}
+	}
+
+	reader, err := d.storage.Get(ctx, filepath.Join(repoName, location))
+	if err != nil {
+		return "", fmt.Errorf("package %s not found in repository %s: %w", filename, repoName, err)
+	}
+	defer reader.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return "", fmt.Errorf("failed to compute checksum for %s: %w", filename, err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// sha256Sums 按 Packages 中的包生成 SHA256SUMS，每行为 "校验和  路径"，按路径排序
+func sha256Sums(pkgs []deps.Package) []byte {
+	pkgs = append([]deps.Package(nil), pkgs...)
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Location < pkgs[j].Location })
+	var buf bytes.Buffer
+	for _, p := range pkgs {
+		if p.SumType == "sha256" {
+			fmt.Fprintf(&buf, "%s  %s\n", p.Checksum, p.Location)
+		}
+	}
+	return buf.Bytes()
+}
@@ -1,6 +1,8 @@
 package deb
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -8,7 +10,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"plus/internal/deps"
 	"plus/internal/types"
+	"plus/internal/utils"
 	"plus/pkg/repo"
 	"plus/pkg/storage"
 )
@@ -19,6 +23,7 @@ func init() {
 
 type DEBRepo struct {
 	storage storage.Storage
+	release func(repoName string) repo.ReleaseFields // 每个仓库 Release 文件的字段
 }
 
 func NewDEBRepo(storage storage.Storage) repo.Repo {
@@ -32,9 +37,9 @@ func (r *DEBRepo) Type() repo.RepoType {
 }
 
 func (d *DEBRepo) UploadPackage(ctx context.Context, repoName string, filename string, reader io.Reader) error {
-	// 验证是否为 DEB 文件
-	if !strings.HasSuffix(filename, ".deb") {
-		return fmt.Errorf("invalid file type, expected .deb")
+	// 验证是否为 DEB 文件或源码包的文件
+	if !strings.HasSuffix(filename, ".deb") && !utils.IsDebianSourceFile(filename) {
+		return fmt.Errorf("invalid file type, expected .deb or a source package file")
 	}
 
 	// 存储文件
@@ -49,8 +54,8 @@ func (d *DEBRepo) UploadPackage(ctx context.Context, repoName string, filename s
 func (d *DEBRepo) RefreshMetadata(ctx context.Context, repoName string) error {
 	repoPath := d.storage.GetPath(repoName)
 
-	// 使用 dpkg-scanpackages 生成 Packages 文件
-	cmd := exec.CommandContext(ctx, "dpkg-scanpackages", ".", "/dev/null")
+	// 使用 dpkg-scanpackages 生成 Packages 文件，--multiversion 保留同一个包的所有版本
+	cmd := exec.CommandContext(ctx, "dpkg-scanpackages", "--multiversion", ".", "/dev/null")
 	cmd.Dir = repoPath
 
 	output, err := cmd.Output()
@@ -58,32 +63,93 @@ func (d *DEBRepo) RefreshMetadata(ctx context.Context, repoName string) error {
 		return fmt.Errorf("failed to generate Packages file: %w", err)
 	}
 
+	// 使用 dpkg-scansources 按 .dsc 生成 Sources 文件，没有源码包时输出为空
+	cmd = exec.CommandContext(ctx, "dpkg-scansources", ".", "/dev/null")
+	cmd.Dir = repoPath
+
+	sources, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to generate Sources file: %w", err)
+	}
+
+	// 索引、SHA256SUMS 和 Release 都生成后再依次替换，生成失败时不会只更新其中一部分
+	compressed, err := compressPackages(output)
+	if err != nil {
+		return fmt.Errorf("failed to compress Packages file: %w", err)
+	}
+	indexes := []indexFile{{"Packages", output}, {"Packages.gz", compressed}}
+	var sourcesCompressed []byte
+	if len(sources) > 0 {
+		if sourcesCompressed, err = compressPackages(sources); err != nil {
+			return fmt.Errorf("failed to compress Sources file: %w", err)
+		}
+		indexes = append(indexes, indexFile{"Sources", sources}, indexFile{"Sources.gz", sourcesCompressed})
+	}
+	pkgs, err := deps.ParseDebPackages(bytes.NewReader(output))
+	if err != nil {
+		return fmt.Errorf("failed to parse Packages file: %w", err)
+	}
+	// 按 Packages 中的 SHA256 生成 SHA256SUMS，可以用 sha256sum -c 校验下载的包
+	sums := sha256Sums(pkgs)
+	release, changed := d.newRelease(ctx, repoName, pkgs, indexes)
+
 	// 保存 Packages 文件
 	packagesPath := filepath.Join(repoPath, "Packages")
-	if err := d.storage.Store(ctx, packagesPath, strings.NewReader(string(output))); err != nil {
+	if err := d.storage.Store(ctx, packagesPath, bytes.NewReader(output)); err != nil {
 		return fmt.Errorf("failed to save Packages file: %w", err)
 	}
 
-	// 生成压缩版本
-	if err := d.compressPackagesFile(ctx, repoPath); err != nil {
-		return fmt.Errorf("failed to compress Packages file: %w", err)
+	// 保存压缩版本
+	packagesGzPath := filepath.Join(repoPath, "Packages.gz")
+	if err := d.storage.Store(ctx, packagesGzPath, bytes.NewReader(compressed)); err != nil {
+		return fmt.Errorf("failed to save Packages.gz file: %w", err)
 	}
 
-	return nil
-}
+	// 保存 Sources 文件，源码包都已删除时删除旧的 Sources
+	for _, index := range []indexFile{{"Sources", sources}, {"Sources.gz", sourcesCompressed}} {
+		if len(sources) == 0 {
+			indexPath := filepath.Join(repoName, index.name)
+			if exists, _ := d.storage.Exists(ctx, indexPath); exists {
+				if err := d.storage.Delete(ctx, indexPath); err != nil {
+					return fmt.Errorf("failed to delete %s file: %w", index.name, err)
+				}
+			}
+			continue
+		}
+		if err := d.storage.Store(ctx, filepath.Join(repoPath, index.name), bytes.NewReader(index.data)); err != nil {
+			return fmt.Errorf("failed to save %s file: %w", index.name, err)
+		}
+	}
 
-func (d *DEBRepo) compressPackagesFile(ctx context.Context, repoPath string) error {
-	// 生成 Packages.gz
-	cmd := exec.CommandContext(ctx, "gzip", "-c", "Packages")
-	cmd.Dir = repoPath
+	// 保存 SHA256SUMS
+	if err := d.storage.Store(ctx, filepath.Join(repoPath, SumsFile), bytes.NewReader(sums)); err != nil {
+		return fmt.Errorf("failed to save %s file: %w", SumsFile, err)
+	}
 
-	output, err := cmd.Output()
-	if err != nil {
+	// Release 列出索引的校验和，最后替换
+	if changed {
+		if err := d.storage.Store(ctx, filepath.Join(repoPath, ReleaseFile), bytes.NewReader(release)); err != nil {
+			return fmt.Errorf("failed to save %s file: %w", ReleaseFile, err)
+		}
+	}
+	if err := d.writeReleaseSignatures(ctx, repoName, d.releaseFields(repoName), release, changed); err != nil {
 		return err
 	}
 
-	packagesGzPath := filepath.Join(repoPath, "Packages.gz")
-	return d.storage.Store(ctx, packagesGzPath, strings.NewReader(string(output)))
+	return nil
+}
+
+// compressPackages 生成 Packages.gz 的内容
+func compressPackages(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 // 实现其他接口方法...
@@ -97,6 +163,36 @@ func (d *DEBRepo) GetMetadata(ctx context.Context, repoName string, filename str
 	return d.storage.Get(ctx, path)
 }
 
+// ListFiles 递归列出仓库中的所有文件
+func (d *DEBRepo) ListFiles(ctx context.Context, repoName string) ([]storage.FileInfo, error) {
+	return repo.ListFiles(ctx, d.storage, repoName)
+}
+
+// CopyFile 在存储内把文件复制到另一个仓库
+func (d *DEBRepo) CopyFile(ctx context.Context, srcRepo, dstRepo, name string) error {
+	return d.storage.Copy(ctx, filepath.Join(srcRepo, name), filepath.Join(dstRepo, name))
+}
+
+// DeleteFile 删除仓库中的文件，name 为相对仓库根目录的路径
+func (d *DEBRepo) DeleteFile(ctx context.Context, repoName, name string) error {
+	return d.storage.Delete(ctx, filepath.Join(repoName, name))
+}
+
+// FileExists 检查仓库中是否有该文件，name 为相对仓库根目录的路径
+func (d *DEBRepo) FileExists(ctx context.Context, repoName, name string) (bool, error) {
+	return d.storage.Exists(ctx, filepath.Join(repoName, name))
+}
+
+// ReadState 读取仓库的状态文件
+func (d *DEBRepo) ReadState(ctx context.Context, repoName, file string) (io.ReadCloser, error) {
+	return repo.ReadState(ctx, d.storage, repoName, file)
+}
+
+// WriteState 替换仓库的状态文件
+func (d *DEBRepo) WriteState(ctx context.Context, repoName, file string, reader io.Reader) error {
+	return repo.WriteState(ctx, d.storage, repoName, file, reader)
+}
+
 func (d *DEBRepo) ListPackages(ctx context.Context, repoName string) ([]types.PackageInfo, error) {
 	files, err := d.storage.ListWithOptions(ctx, repoName, storage.ListOptions{
 		MaxDepth:    -1,
@@ -108,10 +204,13 @@ func (d *DEBRepo) ListPackages(ctx context.Context, repoName string) ([]types.Pa
 
 	var packages []types.PackageInfo
 	for _, file := range files {
-		if strings.HasSuffix(file.Name, ".deb") {
+		// 源码包按 .dsc 列出
+		if strings.HasSuffix(file.Name, ".deb") || strings.HasSuffix(file.Name, ".dsc") {
 			info := types.PackageInfo{
-				Name: file.Name,
-				Size: file.Size,
+				Name:    file.Name,
+				Arch:    utils.PackageArch(file.Name),
+				Size:    file.Size,
+				ModTime: utils.FormatModTime(file.ModTime),
 			}
 			packages = append(packages, info)
 		}
@@ -145,7 +244,3 @@ func (d *DEBRepo) ListRepos(ctx context.Context) ([]string, error) {
 
 	return repos, nil
 }
-
-func (d *DEBRepo) GetPackageChecksum(ctx context.Context, repoName string, filename string) (string, error) {
-	return "", nil
-}
@@ -0,0 +1,42 @@
+package deb
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"plus/internal/deps"
+)
+
+// PackageDeps 从 Packages（或 Packages.gz）读取包的 Depends/Provides
+func (d *DEBRepo) PackageDeps(ctx context.Context, repoName string) ([]deps.Package, error) {
+	reader, err := d.storage.Get(ctx, filepath.Join(repoName, "Packages"))
+	if err == nil {
+		defer reader.Close()
+		return deps.ParseDebPackages(reader)
+	}
+
+	gzFile, err := d.storage.Get(ctx, filepath.Join(repoName, "Packages.gz"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Packages index: %w", err)
+	}
+	defer gzFile.Close()
+
+	gzReader, err := gzip.NewReader(gzFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer gzReader.Close()
+
+	return deps.ParseDebPackages(gzReader)
+}
+
+// OpenPackageFile 按 Packages 中的 Filename 打开包文件
+func (d *DEBRepo) OpenPackageFile(ctx context.Context, repoName string, location string) (io.ReadCloser, error) {
+	clean := strings.TrimPrefix(path.Clean("/"+location), "/")
+	return d.storage.Get(ctx, filepath.Join(repoName, clean))
+}
@@ -0,0 +1,27 @@
+package deb
+
+import (
+	"context"
+	"path/filepath"
+
+	"plus/pkg/storage"
+)
+
+// PackageFiles 列出仓库中所有 .deb 文件
+func (d *DEBRepo) PackageFiles(ctx context.Context, repoName string) ([]string, error) {
+	files, err := d.storage.ListWithOptions(ctx, repoName, storage.ListOptions{
+		MaxDepth:   -1,
+		Extensions: []string{".deb"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, file := range files {
+		if !file.IsDir {
+			result = append(result, filepath.ToSlash(file.Name))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package deb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"plus/internal/deps"
+	"plus/pkg/repo"
+)
+
+// ReleaseFile 仓库根目录下的 Release 文件，列出索引文件的校验和，apt 按其中的 Origin、Label 等字段设置优先级
+const ReleaseFile = "Release"
+
+// 仓库的签名密钥配置了私钥时与 Release 一起生成的签名
+const (
+	InReleaseFile        = "InRelease"   // clearsign 的 Release
+	ReleaseSignatureFile = "Release.gpg" // Release 的 armored 分离签名
+)
+
+// releaseTimeFormat Release 中 Date 和 Valid-Until 的格式
+const releaseTimeFormat = "Mon, 02 Jan 2006 15:04:05 UTC"
+
+// SetReleaseFields 设置每个仓库 Release 文件的字段
+func (d *DEBRepo) SetReleaseFields(fields func(repoName string) repo.ReleaseFields) {
+	d.release = fields
+}
+
+func (d *DEBRepo) releaseFields(repoName string) repo.ReleaseFields {
+	if d.release == nil {
+		return repo.ReleaseFields{}
+	}
+	return d.release(repoName)
+}
+
+// indexFile Release 中列出的索引文件
+type indexFile struct {
+	name string
+	data []byte
+}
+
+// releaseFile 生成扁平仓库的 Release，Architectures 来自 Packages 中的包和 Sources 索引
+func releaseFile(fields repo.ReleaseFields, date time.Time, pkgs []deps.Package, indexes []indexFile) []byte {
+	var buf bytes.Buffer
+	for _, f := range []struct{ name, value string }{
+		{"Origin", fields.Origin},
+		{"Label", fields.Label},
+		{"Suite", fields.Suite},
+		{"Codename", fields.Codename},
+	} {
+		if f.value != "" {
+			fmt.Fprintf(&buf, "%s: %s\n", f.name, f.value)
+		}
+	}
+	date = date.UTC()
+	fmt.Fprintf(&buf, "Date: %s\n", date.Format(releaseTimeFormat))
+	if fields.ValidFor > 0 {
+		fmt.Fprintf(&buf, "Valid-Until: %s\n", date.Add(fields.ValidFor).Format(releaseTimeFormat))
+	}
+
+	archs := make(map[string]bool)
+	for _, p := range pkgs {
+		if p.Arch != "" {
+			archs[p.Arch] = true
+		}
+	}
+	// 有 Sources 索引时 Architectures 中包含 source
+	for _, index := range indexes {
+		if index.name == "Sources" {
+			archs["source"] = true
+		}
+	}
+	if len(archs) > 0 {
+		names := make([]string, 0, len(archs))
+		for arch := range archs {
+			names = append(names, arch)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(&buf, "Architectures: %s\n", strings.Join(names, " "))
+	}
+
+	for _, sum := range []struct {
+		field string
+		hash  func() hash.Hash
+	}{{"MD5Sum", md5.New}, {"SHA1", sha1.New}, {"SHA256", sha256.New}} {
+		fmt.Fprintf(&buf, "%s:\n", sum.field)
+		for _, index := range indexes {
+			h := sum.hash()
+			h.Write(index.data)
+			fmt.Fprintf(&buf, " %x %d %s\n", h.Sum(nil), len(index.data), index.name)
+		}
+	}
+	return buf.Bytes()
+}
+
+// newRelease 生成仓库的 Release。没有配置 Valid-Until 且除 Date 外与现有的 Release 相同时保留现有文件，
+// changed 为 false，元数据没有变化的刷新不产生新的元数据历史版本
+func (d *DEBRepo) newRelease(ctx context.Context, repoName string, pkgs []deps.Package, indexes []indexFile) ([]byte, bool) {
+	fields := d.releaseFields(repoName)
+	release := releaseFile(fields, time.Now(), pkgs, indexes)
+	if fields.ValidFor > 0 {
+		return release, true
+	}
+	reader, err := d.storage.Get(ctx, filepath.Join(repoName, ReleaseFile))
+	if err != nil {
+		return release, true
+	}
+	defer reader.Close()
+	current, err := io.ReadAll(reader)
+	if err != nil {
+		return release, true
+	}
+	if date, ok := releaseDate(current); ok && bytes.Equal(releaseFile(fields, date, pkgs, indexes), current) {
+		return current, false
+	}
+	return release, true
+}
+
+// writeReleaseSignatures 生成 InRelease 和 Release.gpg。Release 没有变化且签名都存在时保留现有签名；
+// 没有配置签名时删除旧的签名，避免客户端用旧签名校验新的 Release
+func (d *DEBRepo) writeReleaseSignatures(ctx context.Context, repoName string, fields repo.ReleaseFields, release []byte, changed bool) error {
+	for _, sig := range []struct {
+		name string
+		sign func([]byte) ([]byte, error)
+	}{{InReleaseFile, fields.ClearSign}, {ReleaseSignatureFile, fields.Sign}} {
+		sigPath := filepath.Join(repoName, sig.name)
+		exists, _ := d.storage.Exists(ctx, sigPath)
+		if sig.sign == nil {
+			if exists {
+				if err := d.storage.Delete(ctx, sigPath); err != nil {
+					return fmt.Errorf("failed to delete %s file: %w", sig.name, err)
+				}
+			}
+			continue
+		}
+		if exists && !changed {
+			continue
+		}
+		data, err := sig.sign(release)
+		if err != nil {
+			return fmt.Errorf("failed to sign %s file: %w", ReleaseFile, err)
+		}
+		if err := d.storage.Store(ctx, sigPath, bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("failed to save %s file: %w", sig.name, err)
+		}
+	}
+	return nil
+}
+
+// releaseDate 读取 Release 中的 Date，没有或无法解析时返回 false
+func releaseDate(release []byte) (time.Time, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(release))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "Date: "); ok {
+			date, err := time.Parse(releaseTimeFormat, value)
+			return date, err == nil
+		}
+	}
+	return time.Time{}, false
+}
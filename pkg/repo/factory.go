@@ -2,8 +2,16 @@ package repo
 
 import (
 	"fmt"
+	"sort"
+	"plus/internal/cache"
 	"plus/internal/config"
 	"plus/pkg/storage"
+	"plus/pkg/storage/breaker"
+	"plus/pkg/storage/compress"
+	"plus/pkg/storage/cutover"
+	"plus/pkg/storage/encrypt"
+	"plus/pkg/storage/replicated"
+	"plus/pkg/storage/retry"
 )
 
 type RepoType string
@@ -17,6 +25,14 @@ const (
 type RepoFactory struct {
 	storage storage.Storage
 	path string
+	readCache *cache.DiskCache
+	retry *retry.Config
+	breaker *breaker.Breaker
+	keyring *encrypt.Keyring
+	compressor *compress.Compressor
+	replicator *replicated.Replicator
+	backends map[RepoType]*cutover.Storage
+	presigner storage.Presigner
 }
 
 var factory = make(map[RepoType]func(storage.Storage) Repo)
@@ -28,20 +44,134 @@ func Register(rt RepoType, repo func(storage.Storage) Repo) {
 	factory[rt] = repo
 }
 
+// Types 返回已注册的仓库类型，内置类型在前，插件注册的类型按名称排序
+func Types() []RepoType {
+	list := []RepoType{RPM, DEB, Files}
+	var extra []RepoType
+	for rt := range factory {
+		if rt != RPM && rt != Files && rt != DEB {
+			extra = append(extra, rt)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i] < extra[j] })
+	return append(list, extra...)
+}
+
 func NewRepoFactory(cfg *config.Config) *RepoFactory {
 	return &RepoFactory{
 		path: cfg.StoragePath,
 	}
 }
 
+// SetReadCache 为对象存储后端的仓库启用本地磁盘读缓存
+func (f *RepoFactory) SetReadCache(c *cache.DiskCache) {
+	f.readCache = c
+}
+
+// SetRetry 对象存储后端的操作失败时按配置重试
+func (f *RepoFactory) SetRetry(cfg retry.Config) {
+	f.retry = &cfg
+}
+
+// SetBreaker 对象存储后端连续失败后熔断，各仓库共用同一个熔断器
+func (f *RepoFactory) SetBreaker(b *breaker.Breaker) {
+	f.breaker = b
+}
+
+// SetEncryption 对象存储后端的内容加密保存
+func (f *RepoFactory) SetEncryption(k *encrypt.Keyring) {
+	f.keyring = k
+}
+
+// SetCompression 对象存储后端压缩保存可压缩的文件
+func (f *RepoFactory) SetCompression(c *compress.Compressor) {
+	f.compressor = c
+}
+
+// SetReplication 对象存储后端的写入同时复制到其他副本
+func (f *RepoFactory) SetReplication(r *replicated.Replicator) {
+	f.replicator = r
+}
+
+// SetPresigner 本地存储后端的文件可由 p 生成对象存储中同一路径的预签名下载地址
+func (f *RepoFactory) SetPresigner(p storage.Presigner) {
+	f.presigner = p
+}
+
+// Storage 返回 repoType 仓库使用的存储后端，仓库尚未创建时返回 nil
+func (f *RepoFactory) Storage(repoType RepoType) storage.Storage {
+	if backend, ok := f.backends[repoType]; ok {
+		return backend
+	}
+	return nil
+}
+
 func (f *RepoFactory) CreateRepo(repoType RepoType) (Repo, error) {
-	s, err := storage.CreateByLable(f.path, string(repoType))
+	s, err := f.Backend(repoType, storage.TypeByLable(string(repoType)))
 	if err != nil {
 		return nil, err
 	}
-	f.storage = s
+	// 仓库经过 cutover 访问存储，切换后端时不需要重建仓库
+	backend := cutover.New(s)
+	if f.backends == nil {
+		f.backends = make(map[RepoType]*cutover.Storage)
+	}
+	f.backends[repoType] = backend
+	f.storage = backend
 	if repo, ok := factory[repoType]; ok {
 		return repo(f.storage), nil
 	}
 	return nil, fmt.Errorf("unsupported repository type: %s", repoType)
 }
+
+// Backend 创建 repoType 在 st 类型存储上的后端，对象存储加上配置的重试、副本、熔断、缓存、加密和压缩
+func (f *RepoFactory) Backend(repoType RepoType, st storage.StorageType) (storage.Storage, error) {
+	s, err := storage.Create(st, f.path)
+	if err != nil {
+		return nil, err
+	}
+	if st != storage.Local {
+		// 缓存在外层，命中时不经过重试和熔断；熔断只统计重试后仍失败的操作
+		if f.retry != nil {
+			s = retry.New(s, *f.retry)
+		}
+		// 各副本分别重试，重试后仍失败才使用其他副本；熔断只在所有副本都不可用时打开
+		if f.replicator != nil {
+			s = f.replicator.Wrap(s)
+		}
+		if f.breaker != nil {
+			s = f.breaker.Wrap(s)
+		}
+		if f.readCache != nil {
+			s = f.readCache.Wrap(s, string(repoType))
+		}
+		// 由外到内依次为压缩、加密、读缓存：加密包在读缓存外，读缓存中只有密文
+		if f.keyring != nil {
+			s = encrypt.New(s, f.keyring)
+		}
+		// 压缩在加密之前，密文无法压缩
+		if f.compressor != nil {
+			s = f.compressor.Wrap(s)
+		}
+	} else if f.presigner != nil {
+		// 对象存储中的副本与 storage-path 下的文件路径相同
+		s = storage.WithPresigner(s, f.presigner)
+	}
+	return s, nil
+}
+
+// SwitchBackend 把已创建的 repoType 仓库切换到 st 类型的存储。sync 在写入暂停期间把旧后端的最后改动复制到新后端，
+// 切换前后读取都不中断
+func (f *RepoFactory) SwitchBackend(repoType RepoType, st storage.StorageType, sync func(from, to storage.Storage) error) error {
+	backend, ok := f.backends[repoType]
+	if !ok {
+		return fmt.Errorf("repository type %s is not created", repoType)
+	}
+	next, err := f.Backend(repoType, st)
+	if err != nil {
+		return err
+	}
+	return backend.Switch(next, func(current storage.Storage) error {
+		return sync(current, next)
+	})
+}
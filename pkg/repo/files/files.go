@@ -10,6 +10,7 @@ import (
 
 	"plus/internal/types"
 	"plus/internal/log"
+	"plus/internal/utils"
 	"plus/pkg/repo"
 	"plus/pkg/storage"
 )
@@ -20,6 +21,7 @@ func init() {
 
 type FilesRepo struct {
 	storage storage.Storage
+	sums    func(repoName string) repo.DirectorySums // 每个仓库是否在目录中维护 SHA256SUMS
 }
 
 func NewFilesRepo(storage storage.Storage) repo.Repo {
@@ -59,10 +61,26 @@ func (r *FilesRepo) DownloadPackage(ctx context.Context, repoName string, filena
 	return reader, nil
 }
 
+func (r *FilesRepo) StatFile(ctx context.Context, repoName string, filename string) (storage.FileInfo, error) {
+	info, err := r.storage.Stat(ctx, filepath.Join(repoName, filename))
+	if err != nil {
+		return info, fmt.Errorf("failed to stat file %s: %w", filename, err)
+	}
+	return info, nil
+}
+
+func (r *FilesRepo) OpenFileRange(ctx context.Context, repoName string, filename string, offset, length int64) (io.ReadCloser, error) {
+	reader, err := r.storage.OpenRange(ctx, filepath.Join(repoName, filename), offset, length)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file %s: %w", filename, err)
+	}
+	return reader, nil
+}
+
 func (r *FilesRepo) RefreshMetadata(ctx context.Context, repoName string) error {
-	// Files 仓库不需要元数据刷新，直接返回成功
-	log.Logger.Debugf("RefreshMetadata called for Files repo: %s (no action needed)", repoName)
-	return nil
+	// Files 仓库没有元数据，开启了 SHA256SUMS 时重新生成所有目录的 SHA256SUMS
+	log.Logger.Debugf("RefreshMetadata called for Files repo: %s", repoName)
+	return r.refreshSums(ctx, repoName)
 }
 
 func (r *FilesRepo) GetMetadata(ctx context.Context, repoName string, filename string) (io.ReadCloser, error) {
@@ -71,6 +89,36 @@ func (r *FilesRepo) GetMetadata(ctx context.Context, repoName string, filename s
 	return nil, fmt.Errorf("metadata not supported for Files repository")
 }
 
+// ListFiles 递归列出仓库中的所有文件
+func (r *FilesRepo) ListFiles(ctx context.Context, repoName string) ([]storage.FileInfo, error) {
+	return repo.ListFiles(ctx, r.storage, repoName)
+}
+
+// CopyFile 在存储内把文件复制到另一个仓库
+func (r *FilesRepo) CopyFile(ctx context.Context, srcRepo, dstRepo, name string) error {
+	return r.storage.Copy(ctx, filepath.Join(srcRepo, name), filepath.Join(dstRepo, name))
+}
+
+// DeleteFile 删除仓库中的文件，name 为相对仓库根目录的路径
+func (r *FilesRepo) DeleteFile(ctx context.Context, repoName, name string) error {
+	return r.storage.Delete(ctx, filepath.Join(repoName, name))
+}
+
+// FileExists 检查仓库中是否有该文件，name 为相对仓库根目录的路径
+func (r *FilesRepo) FileExists(ctx context.Context, repoName, name string) (bool, error) {
+	return r.storage.Exists(ctx, filepath.Join(repoName, name))
+}
+
+// ReadState 读取仓库的状态文件
+func (r *FilesRepo) ReadState(ctx context.Context, repoName, file string) (io.ReadCloser, error) {
+	return repo.ReadState(ctx, r.storage, repoName, file)
+}
+
+// WriteState 替换仓库的状态文件
+func (r *FilesRepo) WriteState(ctx context.Context, repoName, file string, reader io.Reader) error {
+	return repo.WriteState(ctx, r.storage, repoName, file, reader)
+}
+
 func (r *FilesRepo) ListPackages(ctx context.Context, repoName string) ([]types.PackageInfo, error) {
 	log.Logger.Debugf("Listing files in Files repo: %s", repoName)
 
@@ -92,10 +140,15 @@ func (r *FilesRepo) ListPackages(ctx context.Context, repoName string) ([]types.
 			// 如果没有前缀，说明文件就在根目录
 			relativePath = filepath.Base(file.Name)
 		}
+		// 包说明和过期时间不是仓库中的文件
+		if repo.StateFile(relativePath) {
+			continue
+		}
 
 		info := types.PackageInfo{
 			Name: relativePath,
 			Size: file.Size,
+			ModTime: utils.FormatModTime(file.ModTime),
 		}
 		packages = append(packages, info)
 
@@ -0,0 +1,208 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"plus/internal/log"
+	"plus/pkg/repo"
+	"plus/pkg/storage"
+)
+
+const (
+	// SumsFile 目录中按 sha256sum 格式列出该目录下文件校验和的文件
+	SumsFile = "SHA256SUMS"
+	// SumsSignatureFile SumsFile 的 armored 分离签名
+	SumsSignatureFile = SumsFile + ".asc"
+)
+
+// SetDirectorySums 设置每个仓库是否在目录中维护 SHA256SUMS
+func (r *FilesRepo) SetDirectorySums(sums func(repoName string) repo.DirectorySums) {
+	r.sums = sums
+}
+
+func (r *FilesRepo) directorySums(repoName string) repo.DirectorySums {
+	if r.sums == nil {
+		return repo.DirectorySums{}
+	}
+	return r.sums(repoName)
+}
+
+// UpdateSums 重新生成 dirs 中的 SHA256SUMS。上次生成之后没有修改的文件沿用原来的校验和，只计算新文件
+func (r *FilesRepo) UpdateSums(ctx context.Context, repoName string, dirs []string) error {
+	sums := r.directorySums(repoName)
+	if !sums.Enabled {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		if dir = strings.Trim(path.Clean("/"+dir), "/"); seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		if err := r.writeSums(ctx, repoName, dir, sums.Sign, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshSums 重新计算仓库所有目录的 SHA256SUMS，用于修正服务之外对文件的修改
+func (r *FilesRepo) refreshSums(ctx context.Context, repoName string) error {
+	sums := r.directorySums(repoName)
+	if !sums.Enabled {
+		return nil
+	}
+	files, err := repo.ListFiles(ctx, r.storage, repoName)
+	if err != nil {
+		return err
+	}
+	dirs := map[string]bool{"": true}
+	for _, f := range files {
+		if dir := path.Dir(filepath.ToSlash(f.Name)); dir != "." && !hiddenPath(dir) {
+			dirs[dir] = true
+		}
+	}
+	names := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		names = append(names, dir)
+	}
+	sort.Strings(names)
+	for _, dir := range names {
+		if err := r.writeSums(ctx, repoName, dir, sums.Sign, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSums 生成目录 dir 的 SHA256SUMS，内容没有变化时不改写。目录中没有文件时删除 SHA256SUMS 和签名
+func (r *FilesRepo) writeSums(ctx context.Context, repoName, dir string, sign func([]byte) ([]byte, error), reuse bool) error {
+	dirPath := filepath.Join(repoName, filepath.FromSlash(dir))
+	sumsPath := filepath.Join(dirPath, SumsFile)
+	sigPath := filepath.Join(dirPath, SumsSignatureFile)
+
+	entries, err := r.storage.ListWithOptions(ctx, dirPath, storage.ListOptions{MaxDepth: 0})
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", dirPath, err)
+	}
+	var files []storage.FileInfo
+	for _, e := range entries {
+		name := path.Base(filepath.ToSlash(e.Name))
+		if e.IsDir || strings.HasPrefix(name, ".") || name == SumsFile || name == SumsSignatureFile {
+			continue
+		}
+		e.Name = name
+		files = append(files, e)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	existing, current, generated := r.readSums(ctx, sumsPath)
+	if len(files) == 0 {
+		for _, p := range []string{sumsPath, sigPath} {
+			if exists, _ := r.storage.Exists(ctx, p); exists {
+				if err := r.storage.Delete(ctx, p); err != nil {
+					return fmt.Errorf("failed to delete %s: %w", p, err)
+				}
+			}
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, f := range files {
+		sum, ok := current[f.Name]
+		if !ok || !reuse || !f.ModTime.Before(generated) {
+			if sum, err = r.fileSum(ctx, filepath.Join(dirPath, f.Name)); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(&buf, "%s  %s\n", sum, f.Name)
+	}
+	data := buf.Bytes()
+
+	unchanged := bytes.Equal(data, existing)
+	if !unchanged {
+		if err := r.storage.Store(ctx, sumsPath, bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("failed to save %s: %w", sumsPath, err)
+		}
+		log.Logger.Debugf("Updated %s: %d files", sumsPath, len(files))
+	}
+
+	if sign == nil {
+		if exists, _ := r.storage.Exists(ctx, sigPath); exists {
+			return r.storage.Delete(ctx, sigPath)
+		}
+		return nil
+	}
+	// 内容没有变化时保留原来的签名，全部重新计算时重新签名
+	if exists, _ := r.storage.Exists(ctx, sigPath); reuse && unchanged && exists {
+		return nil
+	}
+	signature, err := sign(data)
+	if err != nil {
+		return fmt.Errorf("failed to sign %s: %w", sumsPath, err)
+	}
+	if err := r.storage.Store(ctx, sigPath, bytes.NewReader(signature)); err != nil {
+		return fmt.Errorf("failed to save %s: %w", sigPath, err)
+	}
+	return nil
+}
+
+// readSums 读取现有的 SHA256SUMS、其中每个文件的校验和以及它的修改时间，不存在时返回空
+func (r *FilesRepo) readSums(ctx context.Context, sumsPath string) ([]byte, map[string]string, time.Time) {
+	info, err := r.storage.Stat(ctx, sumsPath)
+	if err != nil {
+		return nil, nil, time.Time{}
+	}
+	reader, err := r.storage.Get(ctx, sumsPath)
+	if err != nil {
+		return nil, nil, time.Time{}
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, time.Time{}
+	}
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if sum, name, ok := strings.Cut(scanner.Text(), "  "); ok {
+			sums[name] = sum
+		}
+	}
+	return data, sums, info.ModTime
+}
+
+// fileSum 计算存储中文件的 SHA256
+func (r *FilesRepo) fileSum(ctx context.Context, p string) (string, error) {
+	reader, err := r.storage.Get(ctx, p)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", p, err)
+	}
+	defer reader.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, reader); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", p, err)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// hiddenPath 判断路径中是否有以 . 开头的目录
+func hiddenPath(p string) bool {
+	for _, part := range strings.Split(p, "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
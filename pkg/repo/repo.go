@@ -2,8 +2,17 @@ package repo
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"time"
+
+	"plus/internal/deps"
 	"plus/internal/types"
+	"plus/internal/utils"
+	"plus/pkg/storage"
 )
 
 type Repo interface {
@@ -37,3 +46,178 @@ type Repo interface {
 	// 获取包校验和
 	GetPackageChecksum(ctx context.Context, repoName string, filename string) (string, error)
 }
+
+// 支持托管安装树（images/、EFI/、.treeinfo 等）的仓库
+type InstallTreeRepo interface {
+	// 上传安装树文件
+	UploadTreeFile(ctx context.Context, repoName string, treePath string, reader io.Reader) error
+
+	// 校验安装树
+	ValidateInstallTree(ctx context.Context, repoName string) (*types.InstallTreeReport, error)
+}
+
+// 检查上传的文件名的仓库，插件注册的仓库类型实现该接口限制可上传的文件，没有实现时接受任何文件
+type FileValidator interface {
+	ValidateFileName(filename string) error
+}
+
+// 支持依赖解析的仓库
+type DependencyRepo interface {
+	// 读取仓库元数据中的包及依赖信息
+	PackageDeps(ctx context.Context, repoName string) ([]deps.Package, error)
+
+	// 按元数据中的相对路径打开包文件
+	OpenPackageFile(ctx context.Context, repoName string, location string) (io.ReadCloser, error)
+}
+
+// 刷新元数据时另外生成源码包索引的仓库
+type SourceRepo interface {
+	// 读取源码包索引中的包，Location 相对仓库根目录，没有源码包时返回空
+	SourcePackages(ctx context.Context, repoName string) ([]deps.Package, error)
+}
+
+// SourceRPMDir RPM 仓库中源码包的子仓库，有自己的 Packages/ 和 repodata/
+const SourceRPMDir = "SRPMS"
+
+// RPMPackagePath RPM 包在仓库中的路径，源码包位于 SRPMS/Packages/ 下，其他包位于 Packages/ 下
+func RPMPackagePath(filename string) string {
+	if utils.IsSourceRPM(filename) {
+		return path.Join(SourceRPMDir, "Packages", filename)
+	}
+	return path.Join("Packages", filename)
+}
+
+// 支持按范围读取文件的仓库
+type RangeRepo interface {
+	// 获取文件大小和修改时间，不读取内容
+	StatFile(ctx context.Context, repoName string, filename string) (storage.FileInfo, error)
+	// 从 offset 开始读取 length 字节，length 小于 0 时读到末尾
+	OpenFileRange(ctx context.Context, repoName string, filename string, offset, length int64) (io.ReadCloser, error)
+}
+
+// 支持一致性检查的仓库
+type ConsistencyRepo interface {
+	// 列出仓库中实际存在的包文件（相对仓库根目录）
+	PackageFiles(ctx context.Context, repoName string) ([]string, error)
+}
+
+// 支持列出仓库中所有文件的仓库
+type FileListRepo interface {
+	// 递归列出仓库中的文件，名称相对仓库根目录，使用 / 分隔
+	ListFiles(ctx context.Context, repoName string) ([]storage.FileInfo, error)
+}
+
+// ListFiles 递归列出存储中仓库目录下的文件，跳过仓库类型标记
+func ListFiles(ctx context.Context, st storage.Storage, repoName string) ([]storage.FileInfo, error) {
+	files, err := st.ListWithOptions(ctx, repoName, storage.ListOptions{MaxDepth: -1})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]storage.FileInfo, 0, len(files))
+	for _, file := range files {
+		if file.IsDir || path.Base(file.Name) == ".repo-type" {
+			continue
+		}
+		file.Name = filepath.ToSlash(file.Name)
+		result = append(result, file)
+	}
+	return result, nil
+}
+
+// 支持在存储内复制文件的仓库
+type CopyRepo interface {
+	// 把 srcRepo 中的文件复制到 dstRepo 的相同路径，不经过应用读写数据（本地存储使用硬链接）
+	CopyFile(ctx context.Context, srcRepo, dstRepo, name string) error
+}
+
+// 支持删除单个文件的仓库，删除包后需要刷新元数据
+type DeleteFileRepo interface {
+	DeleteFile(ctx context.Context, repoName, name string) error
+}
+
+// 支持检查单个文件是否存在的仓库
+type FileExistsRepo interface {
+	FileExists(ctx context.Context, repoName, name string) (bool, error)
+}
+
+// RefreshRoot 生成元数据的临时目录，位于存储根目录，不属于任何仓库
+const RefreshRoot = ".refresh"
+
+// ReleaseFields DEB 仓库 Release 文件中可配置的字段，为空的字段不写入
+type ReleaseFields struct {
+	Origin   string
+	Label    string
+	Suite    string
+	Codename string
+	ValidFor time.Duration // Valid-Until 为生成时间加 ValidFor，为 0 时不写入
+
+	Sign      func(data []byte) ([]byte, error) // 生成 Release.gpg 的 armored 分离签名，为 nil 时不签名
+	ClearSign func(data []byte) ([]byte, error) // 生成 InRelease 的 clearsign 消息，为 nil 时不签名
+}
+
+// 刷新元数据时生成 Release 文件的仓库
+type ReleaseRepo interface {
+	// 设置每个仓库 Release 文件的字段
+	SetReleaseFields(fields func(repoName string) ReleaseFields)
+}
+
+// DirectorySums files 仓库目录中 SHA256SUMS 的配置
+type DirectorySums struct {
+	Enabled bool
+	Sign    func(data []byte) ([]byte, error) // 生成 SHA256SUMS.asc 的 armored 分离签名，为 nil 时不签名
+}
+
+// 在每个目录中维护 SHA256SUMS 的仓库
+type SumsRepo interface {
+	// 设置每个仓库是否维护 SHA256SUMS 以及如何签名
+	SetDirectorySums(sums func(repoName string) DirectorySums)
+	// 重新生成目录 dirs（相对仓库根目录，"" 为根目录）中的 SHA256SUMS，仓库没有开启时直接返回
+	UpdateSums(ctx context.Context, repoName string, dirs []string) error
+}
+
+// 仓库目录中服务自己保存的状态文件，不属于包和元数据
+const (
+	NotesFile        = ".notes.json"        // 包说明（发布说明、变更日志）
+	ExpiryFile       = ".expiry.json"       // 制品过期时间
+	LegalHoldsFile   = ".legal-holds.json"  // 制品保留标记
+	AttestationsFile = ".attestations.json" // 制品来源证明和 cosign 签名
+	ReadmeFile       = ".readme.json"       // 仓库说明（Markdown）
+)
+
+// 支持保存状态文件的仓库
+type StateRepo interface {
+	// 读取仓库的状态文件 file，不存在时返回的错误满足 errors.Is(err, fs.ErrNotExist)
+	ReadState(ctx context.Context, repoName, file string) (io.ReadCloser, error)
+	// 替换仓库的状态文件 file
+	WriteState(ctx context.Context, repoName, file string, reader io.Reader) error
+}
+
+// StateFile 判断仓库根目录下的文件是否是服务自己保存的状态文件，这些文件不在列表中显示
+func StateFile(name string) bool {
+	return name == NotesFile || name == ExpiryFile || name == LegalHoldsFile || name == AttestationsFile || name == ReadmeFile
+}
+
+// ReadState 读取存储中仓库的状态文件，file 必须是 StateFile 认可的文件名
+func ReadState(ctx context.Context, st storage.Storage, repoName, file string) (io.ReadCloser, error) {
+	if !StateFile(file) {
+		return nil, fmt.Errorf("%s is not a repository state file", file)
+	}
+	name := filepath.Join(repoName, file)
+	// 对象存储读取不存在的对象时不一定返回 fs.ErrNotExist
+	exists, err := st.Exists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fs.ErrNotExist
+	}
+	return st.Get(ctx, name)
+}
+
+// WriteState 替换存储中仓库的状态文件，file 必须是 StateFile 认可的文件名
+func WriteState(ctx context.Context, st storage.Storage, repoName, file string, reader io.Reader) error {
+	if !StateFile(file) {
+		return fmt.Errorf("%s is not a repository state file", file)
+	}
+	return st.Store(ctx, filepath.Join(repoName, file), reader)
+}
@@ -0,0 +1,62 @@
+package rpm
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"plus/internal/deps"
+	"plus/internal/log"
+	"plus/pkg/repo"
+)
+
+// PackageDeps 从最新的 primary.xml.gz 读取包的 Provides/Requires
+func (r *RPMRepo) PackageDeps(ctx context.Context, repoName string) ([]deps.Package, error) {
+	primaryFile, err := r.findLatestPrimaryXMLFile(ctx, repoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find primary.xml file: %w", err)
+	}
+
+	primaryPath := filepath.Join(repoName, "repodata", primaryFile)
+	log.Logger.Debugf("Reading package dependencies from: %s", primaryPath)
+
+	reader, err := r.storage.Get(ctx, primaryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get primary.xml.gz: %w", err)
+	}
+	defer reader.Close()
+
+	gzReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer gzReader.Close()
+
+	return deps.ParseRPMPrimary(gzReader)
+}
+
+// OpenPackageFile 按 primary.xml 中的 location 打开包文件
+func (r *RPMRepo) OpenPackageFile(ctx context.Context, repoName string, location string) (io.ReadCloser, error) {
+	clean := strings.TrimPrefix(path.Clean("/"+location), "/")
+	return r.storage.Get(ctx, filepath.Join(repoName, clean))
+}
+
+// SourcePackages 从 SRPMS 子仓库最新的 primary.xml.gz 读取源码包，Location 加上 SRPMS/ 前缀
+func (r *RPMRepo) SourcePackages(ctx context.Context, repoName string) ([]deps.Package, error) {
+	srcRepo := filepath.Join(repoName, repo.SourceRPMDir)
+	if exists, err := r.storage.Exists(ctx, filepath.Join(srcRepo, "repodata")); err != nil || !exists {
+		return nil, err
+	}
+	pkgs, err := r.PackageDeps(ctx, srcRepo)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pkgs {
+		pkgs[i].Location = path.Join(repo.SourceRPMDir, pkgs[i].Location)
+	}
+	return pkgs, nil
+}
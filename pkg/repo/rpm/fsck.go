@@ -0,0 +1,27 @@
+package rpm
+
+import (
+	"context"
+	"path/filepath"
+
+	"plus/pkg/storage"
+)
+
+// PackageFiles 列出仓库中所有 .rpm 文件（包括 Packages/ 下的子目录）
+func (r *RPMRepo) PackageFiles(ctx context.Context, repoName string) ([]string, error) {
+	files, err := r.storage.ListWithOptions(ctx, repoName, storage.ListOptions{
+		MaxDepth:   -1,
+		Extensions: []string{".rpm"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, file := range files {
+		if !file.IsDir {
+			result = append(result, filepath.ToSlash(file.Name))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,168 @@
+package rpm
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"plus/pkg/repo"
+)
+
+// repomdName 元数据的入口，客户端先读取它再按其中的路径读取其他文件
+const repomdName = "repomd.xml"
+
+// PublishPrefix 发布过程中的临时文件前缀
+const PublishPrefix = ".publish-"
+
+// prepareBuild 在 build 中建立与仓库 repoPath 相同布局的生成目录：RPM 为指向原文件的软链接，
+// repodata 为当前元数据的副本（含 createrepo 的历史记录），生成过程不修改仓库中的文件。
+// SRPMS 子仓库单独生成元数据，不放入生成目录
+func prepareBuild(repoPath, build string) error {
+	err := filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path == filepath.Join(repoPath, repo.SourceRPMDir) {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".rpm") {
+			return nil
+		}
+		rel, err := filepath.Rel(repoPath, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(build, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.Symlink(path, target)
+	})
+	if err != nil {
+		return fmt.Errorf("prepare metadata build: %w", err)
+	}
+
+	src := filepath.Join(repoPath, "repodata")
+	dst := filepath.Join(build, "repodata")
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		// createrepo 写入 name.tmp 后重命名，残留的临时文件不能与仓库共用硬链接
+		if !e.Type().IsRegular() || strings.HasPrefix(e.Name(), PublishPrefix) || strings.HasSuffix(e.Name(), ".tmp") {
+			continue
+		}
+		if err := linkOrCopy(filepath.Join(src, e.Name()), filepath.Join(dst, e.Name())); err != nil {
+			return fmt.Errorf("prepare metadata build: %w", err)
+		}
+	}
+	return nil
+}
+
+// publishRepodata 把生成的元数据 src 发布到仓库的 repodata 目录 dst。
+// 先放入新的数据文件，最后替换 repomd.xml，读到任何一个 repomd.xml 的客户端都能找到它引用的文件；
+// 之后才删除 createrepo 已从历史记录中清除的旧文件
+func publishRepodata(src, dst string) error {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	keep := make(map[string]bool, len(entries))
+	var hasRepomd bool
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		keep[e.Name()] = true
+		if e.Name() == repomdName {
+			hasRepomd = true
+			continue
+		}
+		if sameFile(filepath.Join(src, e.Name()), filepath.Join(dst, e.Name())) {
+			continue
+		}
+		if err := replaceFile(filepath.Join(src, e.Name()), dst, e.Name()); err != nil {
+			return err
+		}
+	}
+	if !hasRepomd {
+		return fmt.Errorf("createrepo did not generate %s", repomdName)
+	}
+	if !sameFile(filepath.Join(src, repomdName), filepath.Join(dst, repomdName)) {
+		if err := replaceFile(filepath.Join(src, repomdName), dst, repomdName); err != nil {
+			return err
+		}
+	}
+
+	old, err := os.ReadDir(dst)
+	if err != nil {
+		return err
+	}
+	for _, e := range old {
+		if e.Type().IsRegular() && !keep[e.Name()] {
+			if err := os.Remove(filepath.Join(dst, e.Name())); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sameFile 判断两个路径是否为同一个文件（未变化的元数据在生成目录中是仓库文件的硬链接）
+func sameFile(a, b string) bool {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bi, err := os.Stat(b)
+	return err == nil && os.SameFile(ai, bi)
+}
+
+// replaceFile 把 src 放到 dir/name，先写入同目录的临时文件再重命名
+func replaceFile(src, dir, name string) error {
+	tmp := filepath.Join(dir, PublishPrefix+name)
+	os.Remove(tmp)
+	if err := linkOrCopy(src, tmp); err != nil {
+		return fmt.Errorf("publish %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, name)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("publish %s: %w", name, err)
+	}
+	return nil
+}
+
+// linkOrCopy 创建 src 的硬链接，跨文件系统时复制
+func linkOrCopy(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,79 @@
+package rpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPrepareAndPublishRepodata(t *testing.T) {
+	repoPath := t.TempDir()
+	writeFiles(t, repoPath, map[string]string{
+		"Packages/a-1.0-1.noarch.rpm": "rpm",
+		"repodata/repomd.xml":         "old repomd",
+		"repodata/old-primary.xml.gz": "old primary",
+		"repodata/expired.xml.gz":     "expired",
+		"repodata/repomd.xml.tmp":     "partial write",
+	})
+
+	build := t.TempDir()
+	if err := prepareBuild(repoPath, build); err != nil {
+		t.Fatal(err)
+	}
+	if target, err := os.Readlink(filepath.Join(build, "Packages/a-1.0-1.noarch.rpm")); err != nil || target != filepath.Join(repoPath, "Packages/a-1.0-1.noarch.rpm") {
+		t.Errorf("package link = %q, %v", target, err)
+	}
+	if _, err := os.Stat(filepath.Join(build, "repodata/repomd.xml.tmp")); !os.IsNotExist(err) {
+		t.Errorf("leftover temporary file was copied into the build")
+	}
+
+	// 模拟 createrepo：写入新的数据文件和 repomd.xml，清除过期的文件
+	writeFiles(t, build, map[string]string{
+		"repodata/new-primary.xml.gz": "new primary",
+	})
+	os.Remove(filepath.Join(build, "repodata/repomd.xml"))
+	writeFiles(t, build, map[string]string{"repodata/repomd.xml": "new repomd"})
+	os.Remove(filepath.Join(build, "repodata/expired.xml.gz"))
+
+	// 生成后仓库中的元数据不变
+	if data, _ := os.ReadFile(filepath.Join(repoPath, "repodata/repomd.xml")); string(data) != "old repomd" {
+		t.Fatalf("repository metadata changed before publishing: %q", data)
+	}
+
+	if err := publishRepodata(filepath.Join(build, "repodata"), filepath.Join(repoPath, "repodata")); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(filepath.Join(repoPath, "repodata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	want := []string{"new-primary.xml.gz", "old-primary.xml.gz", "repomd.xml"}
+	if len(names) != len(want) {
+		t.Fatalf("repodata = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("repodata = %v, want %v", names, want)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(repoPath, "repodata/repomd.xml")); string(data) != "new repomd" {
+		t.Errorf("repomd.xml = %q", data)
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"plus/internal/log"
 	"plus/internal/types"
+	"plus/internal/utils"
 	"plus/pkg/repo"
 	"plus/pkg/storage"
 
@@ -53,8 +55,8 @@ func (r *RPMRepo) UploadPackage(ctx context.Context, repoName string, filename s
 	
 	log.Logger.Debugf("Upload Package Repository path: %s -> %s", repoPath, realPath)
 
-	// 存储文件到 Packages 子目录
-	path := filepath.Join(realPath, "Packages", filename)
+	// 存储文件到 Packages 子目录，源码包存储到 SRPMS 子仓库
+	path := filepath.Join(realPath, filepath.FromSlash(repo.RPMPackagePath(filename)))
 	if err := r.storage.Store(ctx, path, reader); err != nil {
 		return fmt.Errorf("failed to store package: %w", err)
 	}
@@ -74,8 +76,13 @@ func (r *RPMRepo) DownloadPackage(ctx context.Context, repoName string, filename
 	
 	log.Logger.Debugf("Download Package Repository path: %s -> %s", repoPath, realPath)
 
-	// 从 Packages 子目录获取文件
-	path := filepath.Join(realPath, "Packages", filename)
+	// 从 Packages 子目录获取文件，源码包位于 SRPMS 子仓库，找不到时再查找之前上传到 Packages 的源码包
+	path := filepath.Join(realPath, filepath.FromSlash(repo.RPMPackagePath(filename)))
+	if utils.IsSourceRPM(filename) {
+		if exists, err := r.storage.Exists(ctx, path); err == nil && !exists {
+			path = filepath.Join(realPath, "Packages", filename)
+		}
+	}
 	return r.storage.Get(ctx, path)
 }
 
@@ -91,24 +98,56 @@ func (r *RPMRepo) RefreshMetadata(ctx context.Context, repoName string) error {
 	
 	log.Logger.Debugf("Refresh Metadata Repository path: %s -> %s", repoPath, realPath)
 
-	// 使用 createrepo 生成元数据
+	if err := r.createRepodata(realPath); err != nil {
+		return err
+	}
+
+	// 源码包在 SRPMS 子仓库中单独生成元数据，二进制仓库的元数据不包含源码包
+	srcPath := filepath.Join(realPath, repo.SourceRPMDir)
+	if info, err := os.Stat(srcPath); err == nil && info.IsDir() {
+		if err := r.createRepodata(srcPath); err != nil {
+			return fmt.Errorf("%s: %w", repo.SourceRPMDir, err)
+		}
+	}
+	return nil
+}
+
+// createRepodata 为 repoPath 下的包生成 repodata。在仓库外的生成目录中运行 createrepo，完成后再发布到仓库，
+// 生成过程中客户端始终读到完整的旧元数据
+func (r *RPMRepo) createRepodata(repoPath string) error {
+	buildRoot := r.storage.GetPath(repo.RefreshRoot)
+	if err := os.MkdirAll(buildRoot, 0755); err != nil {
+		return fmt.Errorf("failed to create metadata build directory: %w", err)
+	}
+	build, err := os.MkdirTemp(buildRoot, "rpm-")
+	if err != nil {
+		return fmt.Errorf("failed to create metadata build directory: %w", err)
+	}
+	defer os.RemoveAll(build)
+	if err := prepareBuild(repoPath, build); err != nil {
+		return err
+	}
+
+	// 使用 createrepo 生成元数据，旧元数据在被替换后保留一天，仍持有旧 repomd.xml 的客户端可以继续读取
 	config := &createrepo.Config{
 		CompressAlgo:       "gz",
 		ExpungeOldMetadata: 86400,
 		WriteConfig:        true,
 	}
 
-	var err2 error
-	if r.repo, err2 = createrepo.NewRepo(realPath, config); err2 != nil {
-		return fmt.Errorf("failed to new repo: %w", err2)
+	if r.repo, err = createrepo.NewRepo(build, config); err != nil {
+		return fmt.Errorf("failed to new repo: %w", err)
 	}
 
-	sum, err2 := r.repo.Create()
-	if err2 != nil {
-		return fmt.Errorf("failed to create repo metadata: %w", err2)
+	sum, err := r.repo.Create()
+	if err != nil {
+		return fmt.Errorf("failed to create repo metadata: %w", err)
 	}
 
-	log.Logger.Debugf("Repository metadata created for %s: %s", repoName, sum)
+	if err := publishRepodata(filepath.Join(build, "repodata"), filepath.Join(repoPath, "repodata")); err != nil {
+		return fmt.Errorf("failed to publish repo metadata: %w", err)
+	}
+	log.Logger.Debugf("Repository metadata created for %s: %s", repoPath, sum)
 	return nil
 }
 
@@ -118,6 +157,36 @@ func (r *RPMRepo) GetMetadata(ctx context.Context, repoName string, filename str
 	return r.storage.Get(ctx, path)
 }
 
+// ListFiles 递归列出仓库中的所有文件
+func (r *RPMRepo) ListFiles(ctx context.Context, repoName string) ([]storage.FileInfo, error) {
+	return repo.ListFiles(ctx, r.storage, repoName)
+}
+
+// CopyFile 在存储内把文件复制到另一个仓库
+func (r *RPMRepo) CopyFile(ctx context.Context, srcRepo, dstRepo, name string) error {
+	return r.storage.Copy(ctx, filepath.Join(srcRepo, name), filepath.Join(dstRepo, name))
+}
+
+// DeleteFile 删除仓库中的文件，name 为相对仓库根目录的路径
+func (r *RPMRepo) DeleteFile(ctx context.Context, repoName, name string) error {
+	return r.storage.Delete(ctx, filepath.Join(repoName, name))
+}
+
+// FileExists 检查仓库中是否有该文件，name 为相对仓库根目录的路径
+func (r *RPMRepo) FileExists(ctx context.Context, repoName, name string) (bool, error) {
+	return r.storage.Exists(ctx, filepath.Join(repoName, name))
+}
+
+// ReadState 读取仓库的状态文件
+func (r *RPMRepo) ReadState(ctx context.Context, repoName, file string) (io.ReadCloser, error) {
+	return repo.ReadState(ctx, r.storage, repoName, file)
+}
+
+// WriteState 替换仓库的状态文件
+func (r *RPMRepo) WriteState(ctx context.Context, repoName, file string, reader io.Reader) error {
+	return repo.WriteState(ctx, r.storage, repoName, file, reader)
+}
+
 func (r *RPMRepo) ListPackages(ctx context.Context, repoName string) ([]types.PackageInfo, error) {
 	// 列出 Packages 目录下的文件
 	packagesPath := filepath.Join(repoName, "Packages")
@@ -130,12 +199,27 @@ func (r *RPMRepo) ListPackages(ctx context.Context, repoName string) ([]types.Pa
 		return nil, err
 	}
 
+	// SRPMS 子仓库中的源码包
+	srcPackagesPath := filepath.Join(repoName, repo.SourceRPMDir, "Packages")
+	if exists, _ := r.storage.Exists(ctx, srcPackagesPath); exists {
+		srcFiles, err := r.storage.ListWithOptions(ctx, srcPackagesPath, storage.ListOptions{
+			MaxDepth:   1,
+			Extensions: []string{".rpm"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, srcFiles...)
+	}
+
 	var packages []types.PackageInfo
 	for _, file := range files {
 		if strings.HasSuffix(file.Name, ".rpm") {
 			info := types.PackageInfo{
 				Name: file.Name,
+				Arch: utils.PackageArch(file.Name),
 				Size: file.Size,
+				ModTime: utils.FormatModTime(file.ModTime),
 			}
 			packages = append(packages, info)
 		}
@@ -180,6 +264,11 @@ func (r *RPMRepo) ListRepos(ctx context.Context) ([]string, error) {
 		// 1. 如果目录直接标记为仓库，添加它
 		// 2. 如果发现 Packages 或 repodata 目录，添加其父目录
 		if file.IsDir {
+			// SRPMS 是所在仓库的源码包子仓库，不是单独的仓库
+			if strings.HasSuffix(file.Name, "/"+repo.SourceRPMDir) || strings.Contains(file.Name, "/"+repo.SourceRPMDir+"/") {
+				continue
+			}
+
 			if file.IsRepo {
 				repoSet[file.Name] = true
 			}
@@ -210,6 +299,13 @@ func (r *RPMRepo) GetPackageChecksum(ctx context.Context, repoName string, filen
 		return "", fmt.Errorf("invalid file type, expected .rpm")
 	}
 
+	// 源码包在 SRPMS 子仓库的元数据中，之前上传到 Packages 的源码包仍在仓库的元数据中
+	if utils.IsSourceRPM(filename) && filepath.Base(repoName) != repo.SourceRPMDir {
+		if sum, err := r.GetPackageChecksum(ctx, filepath.Join(repoName, repo.SourceRPMDir), filename); err == nil {
+			return sum, nil
+		}
+	}
+
 	// 找到最新的 primary.xml.gz 文件
 	primaryFile, err := r.findLatestPrimaryXMLFile(ctx, repoName)
 	if err != nil {
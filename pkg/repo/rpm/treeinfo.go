@@ -0,0 +1,239 @@
+package rpm
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"plus/internal/log"
+	"plus/internal/types"
+)
+
+// TreeInfo 解析后的 .treeinfo 安装树描述（兼容 treeinfo 0.x 与 productmd 1.x 格式）
+type TreeInfo struct {
+	Family    string
+	Version   string
+	Arch      string
+	Images    map[string]map[string]string // platform -> image type -> path
+	Stage2    map[string]string
+	Checksums map[string]string // path -> "algo:hex"
+}
+
+// ParseTreeInfo 解析 .treeinfo（INI 格式）
+func ParseTreeInfo(r io.Reader) (*TreeInfo, error) {
+	sections := make(map[string]map[string]string)
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := sections[section]; !ok {
+				sections[section] = make(map[string]string)
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || section == "" {
+			return nil, fmt.Errorf("invalid treeinfo line: %q", line)
+		}
+		sections[section][strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read treeinfo: %w", err)
+	}
+
+	ti := &TreeInfo{
+		Images:    make(map[string]map[string]string),
+		Stage2:    sections["stage2"],
+		Checksums: sections["checksums"],
+	}
+
+	// treeinfo 0.x: [general]，productmd 1.x: [release] + [tree]
+	if general, ok := sections["general"]; ok {
+		ti.Family = general["family"]
+		ti.Version = general["version"]
+		ti.Arch = general["arch"]
+	}
+	if release, ok := sections["release"]; ok {
+		if ti.Family == "" {
+			ti.Family = release["name"]
+		}
+		if ti.Version == "" {
+			ti.Version = release["version"]
+		}
+	}
+	if tree, ok := sections["tree"]; ok && ti.Arch == "" {
+		ti.Arch = tree["arch"]
+	}
+
+	for name, values := range sections {
+		if platform, ok := strings.CutPrefix(name, "images-"); ok {
+			ti.Images[platform] = values
+		}
+	}
+
+	if ti.Family == "" && len(ti.Images) == 0 {
+		return nil, fmt.Errorf("treeinfo has no release information or images")
+	}
+
+	return ti, nil
+}
+
+// ValidateInstallTree 校验 .treeinfo 中引用的镜像是否存在且校验和一致
+func (r *RPMRepo) ValidateInstallTree(ctx context.Context, repoName string) (*types.InstallTreeReport, error) {
+	var reader io.ReadCloser
+	var err error
+	for _, name := range []string{".treeinfo", "treeinfo"} {
+		if reader, err = r.storage.Get(ctx, filepath.Join(repoName, name)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no .treeinfo found in repository %s: %w", repoName, err)
+	}
+	defer reader.Close()
+
+	ti, err := ParseTreeInfo(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.InstallTreeReport{
+		Status:  types.Status{Status: "success"},
+		Repo:    repoName,
+		Family:  ti.Family,
+		Version: ti.Version,
+		Arch:    ti.Arch,
+		Valid:   true,
+		Images:  []types.TreeImage{},
+	}
+
+	// 按平台、类型排序，保证输出稳定
+	var entries []types.TreeImage
+	for platform, images := range ti.Images {
+		for imageType, imagePath := range images {
+			entries = append(entries, types.TreeImage{Platform: platform, Type: imageType, Path: imagePath})
+		}
+	}
+	for imageType, imagePath := range ti.Stage2 {
+		entries = append(entries, types.TreeImage{Platform: "stage2", Type: imageType, Path: imagePath})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Platform != entries[j].Platform {
+			return entries[i].Platform < entries[j].Platform
+		}
+		return entries[i].Type < entries[j].Type
+	})
+
+	checked := make(map[string]types.TreeImage)
+	for _, image := range entries {
+		if result, ok := checked[image.Path]; ok {
+			image.Exists, image.Size, image.Checksum = result.Exists, result.Size, result.Checksum
+		} else {
+			r.checkTreeImage(ctx, repoName, &image, ti.Checksums[image.Path])
+			checked[image.Path] = image
+		}
+
+		if !image.Exists {
+			report.Valid = false
+			report.Errors = append(report.Errors, fmt.Sprintf("%s image %s (%s) is missing", image.Platform, image.Type, image.Path))
+		} else if image.Checksum == "mismatch" {
+			report.Valid = false
+			report.Errors = append(report.Errors, fmt.Sprintf("%s image %s (%s) checksum mismatch", image.Platform, image.Type, image.Path))
+		}
+		report.Images = append(report.Images, image)
+	}
+
+	if !report.Valid {
+		report.Status.Status = "invalid"
+	}
+
+	log.Logger.Debugf("Install tree validation for %s: valid=%v, images=%d", repoName, report.Valid, len(report.Images))
+	return report, nil
+}
+
+func (r *RPMRepo) checkTreeImage(ctx context.Context, repoName string, image *types.TreeImage, expected string) {
+	image.Checksum = "unchecked"
+
+	reader, err := r.storage.Get(ctx, filepath.Join(repoName, path.Clean("/"+image.Path)))
+	if err != nil {
+		image.Checksum = "missing"
+		return
+	}
+	defer reader.Close()
+	image.Exists = true
+
+	algo, want, _ := strings.Cut(expected, ":")
+	var h hash.Hash
+	switch strings.ToLower(algo) {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	case "sha1":
+		h = sha1.New()
+	case "md5":
+		h = md5.New()
+	default:
+		h = nil
+	}
+
+	var w io.Writer = io.Discard
+	if h != nil {
+		w = h
+	}
+	n, err := io.Copy(w, reader)
+	image.Size = n
+	if err != nil {
+		log.Logger.Debugf("Failed to read tree image %s: %v", image.Path, err)
+		image.Checksum = "unreadable"
+		return
+	}
+
+	if h != nil {
+		if fmt.Sprintf("%x", h.Sum(nil)) == strings.ToLower(want) {
+			image.Checksum = "ok"
+		} else {
+			image.Checksum = "mismatch"
+		}
+	}
+}
+
+// UploadTreeFile 上传安装树文件（images/、EFI/、.treeinfo 等），保持树内相对路径
+func (r *RPMRepo) UploadTreeFile(ctx context.Context, repoName string, treePath string, reader io.Reader) error {
+	treePath = strings.TrimPrefix(path.Clean("/"+treePath), "/")
+	if treePath == "" || treePath == "." {
+		return fmt.Errorf("install tree path is required")
+	}
+
+	repoPath := r.storage.GetPath(repoName)
+
+	// 检查是否是符号链接，如果是则解析到实际路径
+	realPath, err := filepath.EvalSymlinks(repoPath)
+	if err != nil {
+		log.Logger.Warnf("Failed to resolve symlinks for %s: %v", repoPath, err)
+		realPath = repoPath
+	}
+
+	log.Logger.Debugf("Upload install tree file %s to repository path: %s", treePath, realPath)
+
+	if err := r.storage.Store(ctx, filepath.Join(realPath, treePath), reader); err != nil {
+		return fmt.Errorf("failed to store install tree file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package rpm
+
+import (
+	"strings"
+	"testing"
+)
+
+const productmdTreeInfo = `[header]
+type = productmd.treeinfo
+version = 1.2
+
+[release]
+name = Rocky Linux
+short = Rocky
+version = 9.4
+
+[tree]
+arch = x86_64
+platforms = x86_64,xen
+
+[images-x86_64]
+boot.iso = images/boot.iso
+initrd = images/pxeboot/initrd.img
+kernel = images/pxeboot/vmlinuz
+
+[images-xen]
+initrd = images/pxeboot/initrd.img
+kernel = images/pxeboot/vmlinuz
+
+[stage2]
+mainimage = images/install.img
+
+[checksums]
+images/boot.iso = sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
+images/pxeboot/vmlinuz = sha256:abc
+`
+
+func TestParseTreeInfoProductmd(t *testing.T) {
+	ti, err := ParseTreeInfo(strings.NewReader(productmdTreeInfo))
+	if err != nil {
+		t.Fatalf("ParseTreeInfo failed: %v", err)
+	}
+
+	if ti.Family != "Rocky Linux" || ti.Version != "9.4" || ti.Arch != "x86_64" {
+		t.Errorf("Unexpected release info: %+v", ti)
+	}
+	if ti.Images["x86_64"]["kernel"] != "images/pxeboot/vmlinuz" {
+		t.Errorf("Unexpected x86_64 images: %v", ti.Images["x86_64"])
+	}
+	if len(ti.Images) != 2 {
+		t.Errorf("Expected 2 platforms, got %d", len(ti.Images))
+	}
+	if ti.Stage2["mainimage"] != "images/install.img" {
+		t.Errorf("Unexpected stage2: %v", ti.Stage2)
+	}
+	if !strings.HasPrefix(ti.Checksums["images/boot.iso"], "sha256:") {
+		t.Errorf("Unexpected checksums: %v", ti.Checksums)
+	}
+}
+
+func TestParseTreeInfoLegacy(t *testing.T) {
+	legacy := `[general]
+family = CentOS
+version = 7
+arch = x86_64
+
+[images-x86_64]
+kernel = images/pxeboot/vmlinuz
+`
+	ti, err := ParseTreeInfo(strings.NewReader(legacy))
+	if err != nil {
+		t.Fatalf("ParseTreeInfo failed: %v", err)
+	}
+	if ti.Family != "CentOS" || ti.Version != "7" || ti.Arch != "x86_64" {
+		t.Errorf("Unexpected release info: %+v", ti)
+	}
+}
+
+func TestParseTreeInfoInvalid(t *testing.T) {
+	if _, err := ParseTreeInfo(strings.NewReader("kernel = images/pxeboot/vmlinuz\n")); err == nil {
+		t.Error("Expected error for key outside of section")
+	}
+	if _, err := ParseTreeInfo(strings.NewReader("[header]\nversion = 1.2\n")); err == nil {
+		t.Error("Expected error for treeinfo without release or images")
+	}
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BackendsFile 存储根目录下记录各标签所用存储类型的文件，每行一个 "标签 类型"。
+// plus migrate-storage 复制完成后原子替换该文件完成切换
+const BackendsFile = ".storage-backends"
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[string]StorageType)
+)
+
+// SetBackend 将标签切换到 st 类型的存储，覆盖注册时的默认类型
+func SetBackend(label string, st StorageType) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[label] = st
+}
+
+// backend 返回标签被 SetBackend 覆盖的存储类型
+func backend(label string) (StorageType, bool) {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	st, ok := backends[label]
+	return st, ok
+}
+
+// LoadBackends 读取 root 下的 BackendsFile，文件不存在时返回空
+func LoadBackends(root string) (map[string]StorageType, error) {
+	f, err := os.Open(filepath.Join(root, BackendsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]StorageType{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m := make(map[string]StorageType)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected \"label type\"", BackendsFile, line)
+		}
+		st := StorageType(fields[1])
+		if _, ok := factory[st]; !ok {
+			return nil, fmt.Errorf("%s:%d: unsupported storage type: %s", BackendsFile, line, st)
+		}
+		m[fields[0]] = st
+	}
+	return m, scanner.Err()
+}
+
+// SaveBackends 写入临时文件后重命名，读取方只会看到切换前或切换后的完整内容
+func SaveBackends(root string, m map[string]StorageType) error {
+	labels := make([]string, 0, len(m))
+	for label := range m {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var b strings.Builder
+	b.WriteString("# written by plus migrate-storage\n")
+	for _, label := range labels {
+		fmt.Fprintf(&b, "%s %s\n", label, m[label])
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(root, BackendsFile+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(root, BackendsFile))
+}
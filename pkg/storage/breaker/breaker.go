@@ -0,0 +1,282 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/storage"
+	"plus/pkg/storage/retry"
+)
+
+// 默认值与代理仓库上游的熔断一致
+const (
+	DefaultFailures = 3
+	DefaultCooldown = 30 * time.Second
+)
+
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half-open"
+)
+
+// ErrOpen 熔断期间拒绝的操作返回的错误都满足 errors.Is(err, ErrOpen)
+var ErrOpen = errors.New("storage circuit open")
+
+// OpenError 熔断期间拒绝操作，RetryAfter 为建议的重试等待时间
+type OpenError struct {
+	Name       string
+	RetryAfter time.Duration
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("storage backend %s unavailable (circuit open), retry after %s", e.Name, e.RetryAfter.Round(time.Second))
+}
+
+func (e *OpenError) Is(target error) bool {
+	return target == ErrOpen
+}
+
+// Config 熔断参数
+type Config struct {
+	Failures int           // 连续失败多少次后熔断
+	Cooldown time.Duration // 熔断持续时间，之后放行一个试探操作
+	// Failure 判断错误是否说明后端故障，为 nil 时使用 retry.IsRetryable（文件不存在等不计入）
+	Failure func(error) bool
+}
+
+// Breaker 后端连续失败后熔断：期间直接拒绝操作，不再压向故障的后端；冷却后放行一个试探操作，成功则恢复
+type Breaker struct {
+	name string
+	cfg  Config
+
+	mu          sync.Mutex
+	consecutive int
+	openUntil   time.Time
+	probing     bool // 半开状态下已放行的试探操作尚未结束
+
+	failures  int64
+	trips     int64
+	rejected  int64
+	lastError string
+}
+
+// New 创建熔断器，name 用于日志和错误信息
+func New(name string, cfg Config) *Breaker {
+	if cfg.Failures <= 0 {
+		cfg.Failures = DefaultFailures
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = DefaultCooldown
+	}
+	if cfg.Failure == nil {
+		cfg.Failure = retry.IsRetryable
+	}
+	return &Breaker{name: name, cfg: cfg}
+}
+
+// Wrap 为存储后端加上熔断，多个后端可共用同一个熔断器
+func (b *Breaker) Wrap(backend storage.Storage) storage.Storage {
+	return &breakerStorage{Storage: backend, b: b}
+}
+
+// State 返回当前状态
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stateLocked(time.Now())
+}
+
+// Metrics 返回熔断状态和统计
+func (b *Breaker) Metrics() *types.BreakerMetrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	m := &types.BreakerMetrics{
+		State:               b.stateLocked(now),
+		ConsecutiveFailures: b.consecutive,
+		Failures:            b.failures,
+		Trips:               b.trips,
+		Rejected:            b.rejected,
+		LastError:           b.lastError,
+	}
+	if m.State != StateClosed {
+		m.RetryAfterSeconds = int64((b.retryAfterLocked(now) + time.Second - 1) / time.Second)
+	}
+	return m
+}
+
+func (b *Breaker) stateLocked(now time.Time) string {
+	if b.consecutive < b.cfg.Failures {
+		return StateClosed
+	}
+	if now.Before(b.openUntil) {
+		return StateOpen
+	}
+	return StateHalfOpen
+}
+
+func (b *Breaker) retryAfterLocked(now time.Time) time.Duration {
+	if d := b.openUntil.Sub(now); d > time.Second {
+		return d
+	}
+	// 冷却结束，等待试探操作的结果
+	return time.Second
+}
+
+// allow 判断操作能否执行；半开状态只放行一个试探操作
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	switch b.stateLocked(now) {
+	case StateClosed:
+		return nil
+	case StateHalfOpen:
+		if !b.probing {
+			b.probing = true
+			return nil
+		}
+	}
+	b.rejected++
+	return &OpenError{Name: b.name, RetryAfter: b.retryAfterLocked(now)}
+}
+
+// done 记录操作结果；后端正常响应（包括文件不存在）时关闭熔断
+func (b *Breaker) done(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	// 客户端取消不说明后端的状态
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	now := time.Now()
+	prev := b.stateLocked(now)
+	if err == nil || !b.cfg.Failure(err) {
+		if prev != StateClosed {
+			log.Logger.Infof("Circuit closed for storage %s", b.name)
+		}
+		b.consecutive = 0
+		return
+	}
+
+	b.consecutive++
+	b.failures++
+	b.lastError = err.Error()
+	// 熔断前发出的操作在熔断期间失败时不延长冷却
+	if b.consecutive >= b.cfg.Failures && prev != StateOpen {
+		if prev == StateClosed {
+			log.Logger.Warnf("Circuit opened for storage %s after %d consecutive failures: %v", b.name, b.consecutive, err)
+		} else {
+			log.Logger.Debugf("Circuit probe for storage %s failed: %v", b.name, err)
+		}
+		b.trips++
+		b.openUntil = now.Add(b.cfg.Cooldown)
+	}
+}
+
+// breakerStorage 每个操作先经过熔断器
+type breakerStorage struct {
+	storage.Storage
+	b *Breaker
+}
+
+func (s *breakerStorage) Store(ctx context.Context, path string, reader io.Reader) error {
+	if err := s.b.allow(); err != nil {
+		return err
+	}
+	err := s.Storage.Store(ctx, path, reader)
+	s.b.done(err)
+	return err
+}
+
+// Get 只以打开对象的结果判断后端状态
+func (s *breakerStorage) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	if err := s.b.allow(); err != nil {
+		return nil, err
+	}
+	rc, err := s.Storage.Get(ctx, path)
+	s.b.done(err)
+	return rc, err
+}
+
+func (s *breakerStorage) OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	if err := s.b.allow(); err != nil {
+		return nil, err
+	}
+	rc, err := s.Storage.OpenRange(ctx, path, offset, length)
+	s.b.done(err)
+	return rc, err
+}
+
+func (s *breakerStorage) Delete(ctx context.Context, path string) error {
+	if err := s.b.allow(); err != nil {
+		return err
+	}
+	err := s.Storage.Delete(ctx, path)
+	s.b.done(err)
+	return err
+}
+
+func (s *breakerStorage) ListWithOptions(ctx context.Context, prefix string, opts storage.ListOptions) ([]storage.FileInfo, error) {
+	if err := s.b.allow(); err != nil {
+		return nil, err
+	}
+	files, err := s.Storage.ListWithOptions(ctx, prefix, opts)
+	s.b.done(err)
+	return files, err
+}
+
+func (s *breakerStorage) Copy(ctx context.Context, src, dst string) error {
+	if err := s.b.allow(); err != nil {
+		return err
+	}
+	err := s.Storage.Copy(ctx, src, dst)
+	s.b.done(err)
+	return err
+}
+
+func (s *breakerStorage) Rename(ctx context.Context, src, dst string) error {
+	if err := s.b.allow(); err != nil {
+		return err
+	}
+	err := s.Storage.Rename(ctx, src, dst)
+	s.b.done(err)
+	return err
+}
+
+func (s *breakerStorage) CreateDir(ctx context.Context, path string) error {
+	if err := s.b.allow(); err != nil {
+		return err
+	}
+	err := s.Storage.CreateDir(ctx, path)
+	s.b.done(err)
+	return err
+}
+
+func (s *breakerStorage) Stat(ctx context.Context, path string) (storage.FileInfo, error) {
+	if err := s.b.allow(); err != nil {
+		return storage.FileInfo{}, err
+	}
+	info, err := s.Storage.Stat(ctx, path)
+	s.b.done(err)
+	return info, err
+}
+
+func (s *breakerStorage) Exists(ctx context.Context, path string) (bool, error) {
+	if err := s.b.allow(); err != nil {
+		return false, err
+	}
+	exists, err := s.Storage.Exists(ctx, path)
+	s.b.done(err)
+	return exists, err
+}
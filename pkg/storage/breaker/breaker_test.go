@@ -0,0 +1,104 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+// failing err 不为 nil 时所有 Exists 返回 err
+type failing struct {
+	storage.Storage
+	err   error
+	calls int
+}
+
+func (f *failing) Exists(ctx context.Context, path string) (bool, error) {
+	f.calls++
+	if f.err != nil {
+		return false, f.err
+	}
+	return f.Storage.Exists(ctx, path)
+}
+
+func TestBreakerTripsAndRecovers(t *testing.T) {
+	backend, _ := local.NewLocalStorage(t.TempDir())
+	ctx := context.Background()
+
+	f := &failing{Storage: backend, err: fmt.Errorf("head object: %w", syscall.ECONNREFUSED)}
+	b := New("test", Config{Failures: 2, Cooldown: 50 * time.Millisecond})
+	s := b.Wrap(f)
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Exists(ctx, "a"); errors.Is(err, ErrOpen) {
+			t.Fatalf("closed circuit rejected call %d", i)
+		}
+	}
+	_, err := s.Exists(ctx, "a")
+	var open *OpenError
+	if !errors.As(err, &open) || open.RetryAfter <= 0 || f.calls != 2 {
+		t.Fatalf("open circuit: err = %v after %d backend calls", err, f.calls)
+	}
+	if m := b.Metrics(); m.State != StateOpen || m.Trips != 1 || m.Rejected != 1 || m.RetryAfterSeconds < 1 {
+		t.Errorf("metrics = %+v", m)
+	}
+
+	// 冷却后放行一个试探操作，失败则重新熔断
+	time.Sleep(60 * time.Millisecond)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("state = %s, want half-open", b.State())
+	}
+	if _, err := s.Exists(ctx, "a"); errors.Is(err, ErrOpen) || f.calls != 3 {
+		t.Fatalf("probe not allowed: %v", err)
+	}
+	if b.State() != StateOpen {
+		t.Fatalf("failed probe should reopen the circuit, state = %s", b.State())
+	}
+
+	// 后端恢复后试探成功，熔断关闭
+	time.Sleep(60 * time.Millisecond)
+	f.err = nil
+	if _, err := s.Exists(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if m := b.Metrics(); m.State != StateClosed || m.ConsecutiveFailures != 0 || m.Trips != 2 {
+		t.Errorf("metrics after recovery = %+v", m)
+	}
+}
+
+func TestBreakerIgnoresClientErrors(t *testing.T) {
+	backend, _ := local.NewLocalStorage(t.TempDir())
+	ctx := context.Background()
+	b := New("test", Config{Failures: 1, Cooldown: time.Hour})
+	s := b.Wrap(backend)
+
+	// 文件不存在、请求取消说明后端正常响应
+	for i := 0; i < 3; i++ {
+		if _, err := s.Get(ctx, "missing"); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("Get missing = %v", err)
+		}
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	s.Store(canceled, "a", strings.NewReader("x"))
+
+	if b.State() != StateClosed {
+		t.Errorf("state = %s, want closed", b.State())
+	}
+}
@@ -0,0 +1,391 @@
+package compress
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"plus/internal/types"
+	"plus/pkg/storage"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// 压缩对象的格式：标识帧 + zstd 数据 + 记录原始大小的尾帧。标识帧和尾帧都是 zstd 的可跳过帧，
+// 整个对象仍可直接用 zstd -d 解压
+const (
+	frameSize = 16 // 可跳过帧：4 字节 magic + 4 字节长度 + 8 字节内容
+	sniffSize = 512
+
+	DefaultMinSize = 1024
+)
+
+var (
+	headerFrame = skippableFrame([]byte("PLUSZST1"))
+
+	// DefaultExtensions 默认压缩的文件类型
+	DefaultExtensions = []string{
+		".log", ".txt", ".text", ".json", ".xml", ".yaml", ".yml", ".csv", ".tsv",
+		".md", ".html", ".htm", ".css", ".js", ".svg", ".sql", ".sh", ".conf", ".ini",
+		".cfg", ".spec", ".repo", ".tar", ".out", ".trace", ".ndjson",
+	}
+
+	// skipExtensions 已压缩的格式
+	skipExtensions = map[string]bool{
+		".gz": true, ".tgz": true, ".xz": true, ".txz": true, ".bz2": true, ".tbz2": true,
+		".zst": true, ".tzst": true, ".lz4": true, ".lzma": true, ".zip": true, ".7z": true,
+		".rar": true, ".jar": true, ".war": true, ".whl": true, ".rpm": true, ".deb": true,
+		".apk": true, ".iso": true, ".img": true, ".png": true, ".jpg": true, ".jpeg": true,
+		".gif": true, ".webp": true, ".mp4": true, ".mp3": true, ".pdf": true,
+	}
+
+	// compressedMagic 已压缩内容的文件头，扩展名不可信时按内容判断
+	compressedMagic = [][]byte{
+		{0x1f, 0x8b},                  // gzip
+		{0xfd, '7', 'z', 'X', 'Z', 0}, // xz
+		{0x28, 0xb5, 0x2f, 0xfd},      // zstd
+		{'B', 'Z', 'h'},               // bzip2
+		{'P', 'K', 0x03, 0x04},        // zip
+		{0x04, 0x22, 0x4d, 0x18},      // lz4
+		{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c},
+	}
+)
+
+// Config 压缩参数
+type Config struct {
+	Level      zstd.EncoderLevel
+	MinSize    int64    // 小于该大小的对象不压缩
+	Extensions []string // 压缩的扩展名；其他扩展名按内容判断是否为文本
+	// ReadOnly 新写入的对象不再压缩，已压缩的对象仍透明解压
+	ReadOnly bool
+}
+
+// Compressor 写入时按文件类型压缩可压缩的对象，读取时透明解压；未压缩的对象原样读取。
+// 多个后端共用同一个 Compressor，统计合并
+type Compressor struct {
+	cfg        Config
+	extensions map[string]bool
+	encoders   sync.Pool
+
+	compressed int64
+	skipped    int64
+	bytesIn    int64
+	bytesOut   int64
+}
+
+// New 创建压缩器，未设置的参数使用默认值
+func New(cfg Config) *Compressor {
+	if cfg.Level == 0 {
+		cfg.Level = zstd.SpeedDefault
+	}
+	if cfg.MinSize <= 0 {
+		cfg.MinSize = DefaultMinSize
+	}
+	if len(cfg.Extensions) == 0 {
+		cfg.Extensions = DefaultExtensions
+	}
+	c := &Compressor{cfg: cfg, extensions: make(map[string]bool)}
+	for _, ext := range cfg.Extensions {
+		c.extensions["."+strings.TrimPrefix(strings.ToLower(ext), ".")] = true
+	}
+	return c
+}
+
+// Wrap 为存储后端加上压缩
+func (c *Compressor) Wrap(backend storage.Storage) storage.Storage {
+	return &compressStorage{Storage: backend, c: c}
+}
+
+// Metrics 返回压缩统计
+func (c *Compressor) Metrics() *types.CompressionMetrics {
+	m := &types.CompressionMetrics{
+		Compressed: atomic.LoadInt64(&c.compressed),
+		Skipped:    atomic.LoadInt64(&c.skipped),
+		BytesIn:    atomic.LoadInt64(&c.bytesIn),
+		BytesOut:   atomic.LoadInt64(&c.bytesOut),
+	}
+	if m.BytesIn > 0 {
+		m.Ratio = float64(m.BytesOut) / float64(m.BytesIn)
+	}
+	return m
+}
+
+// compressStorage 按 Compressor 的规则压缩写入的对象
+type compressStorage struct {
+	storage.Storage
+	c *Compressor
+}
+
+func (s *compressStorage) Store(ctx context.Context, path string, reader io.Reader) error {
+	if s.c.cfg.ReadOnly {
+		return s.Storage.Store(ctx, path, reader)
+	}
+	seeker, _ := reader.(io.Seeker)
+	start := int64(-1)
+	if seeker != nil {
+		if pos, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			start = pos
+		}
+	}
+	// 至少读取 MinSize，不足时 head 就是全部内容
+	head := make([]byte, max(sniffSize, s.c.cfg.MinSize))
+	n, err := io.ReadFull(reader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	head = head[:n]
+	if int64(n) < s.c.cfg.MinSize || !s.c.compressible(path, head[:min(n, sniffSize)]) {
+		atomic.AddInt64(&s.c.skipped, 1)
+		// 可 Seek 时回到开头原样上传，下层仍可重试
+		if start >= 0 {
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return err
+			}
+			return s.Storage.Store(ctx, path, reader)
+		}
+		return s.Storage.Store(ctx, path, io.MultiReader(bytes.NewReader(head), reader))
+	}
+
+	enc, err := s.c.encoder()
+	if err != nil {
+		return err
+	}
+	defer s.c.encoders.Put(enc)
+	zr := newCompressReader(io.MultiReader(bytes.NewReader(head), reader), enc)
+	var upload io.Reader = zr
+	if start >= 0 {
+		upload = &seekableCompressReader{compressReader: zr, source: reader, seeker: seeker, start: start}
+	}
+	if err := s.Storage.Store(ctx, path, upload); err != nil {
+		return err
+	}
+	atomic.AddInt64(&s.c.compressed, 1)
+	atomic.AddInt64(&s.c.bytesIn, zr.in)
+	atomic.AddInt64(&s.c.bytesOut, zr.pos)
+	return nil
+}
+
+// compressible 按扩展名和内容判断是否值得压缩
+func (c *Compressor) compressible(path string, head []byte) bool {
+	for _, magic := range compressedMagic {
+		if bytes.HasPrefix(head, magic) {
+			return false
+		}
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if skipExtensions[ext] {
+		return false
+	}
+	if c.extensions[ext] {
+		return true
+	}
+	contentType := http.DetectContentType(head)
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.Contains(contentType, "json") || strings.Contains(contentType, "xml")
+}
+
+func (s *compressStorage) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	rc, err := s.Storage.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	head := make([]byte, frameSize)
+	n, err := io.ReadFull(rc, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		rc.Close()
+		return nil, err
+	}
+	if n < frameSize || !bytes.Equal(head, headerFrame) {
+		return &readCloser{Reader: io.MultiReader(bytes.NewReader(head[:n]), rc), Closer: rc}, nil
+	}
+	dec, err := zstd.NewReader(rc, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return &decompressReader{dec: dec, rc: rc}, nil
+}
+
+// OpenRange 压缩的对象需要从头解压到 offset
+func (s *compressStorage) OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	compressed, err := s.isCompressed(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if !compressed {
+		return s.Storage.OpenRange(ctx, path, offset, length)
+	}
+	rc, err := s.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return storage.Section(rc, offset, length)
+}
+
+// Stat 压缩的对象返回尾帧中记录的原始大小
+func (s *compressStorage) Stat(ctx context.Context, path string) (storage.FileInfo, error) {
+	info, err := s.Storage.Stat(ctx, path)
+	if err != nil || info.IsDir || info.Size < 2*frameSize {
+		return info, err
+	}
+	compressed, err := s.isCompressed(ctx, path)
+	if err != nil || !compressed {
+		return info, err
+	}
+	trailer, err := s.readAt(ctx, path, info.Size-frameSize)
+	if err != nil {
+		return info, err
+	}
+	info.Size = int64(binary.LittleEndian.Uint64(trailer[8:]))
+	return info, nil
+}
+
+func (s *compressStorage) isCompressed(ctx context.Context, path string) (bool, error) {
+	head, err := s.readAt(ctx, path, 0)
+	if err == io.ErrUnexpectedEOF || err == io.EOF {
+		return false, nil
+	}
+	return err == nil && bytes.Equal(head, headerFrame), err
+}
+
+// readAt 读取 offset 处的一个帧
+func (s *compressStorage) readAt(ctx context.Context, path string, offset int64) ([]byte, error) {
+	rc, err := s.Storage.OpenRange(ctx, path, offset, frameSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	buf := make([]byte, frameSize)
+	if _, err := io.ReadFull(rc, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (c *Compressor) encoder() (*zstd.Encoder, error) {
+	if enc, ok := c.encoders.Get().(*zstd.Encoder); ok {
+		return enc, nil
+	}
+	return zstd.NewWriter(nil, zstd.WithEncoderLevel(c.cfg.Level), zstd.WithEncoderConcurrency(1))
+}
+
+// skippableFrame 内容为 payload 的 zstd 可跳过帧
+func skippableFrame(payload []byte) []byte {
+	frame := make([]byte, 8, 8+len(payload))
+	binary.LittleEndian.PutUint32(frame, 0x184D2A50)
+	binary.LittleEndian.PutUint32(frame[4:], uint32(len(payload)))
+	return append(frame, payload...)
+}
+
+// compressReader 读取时压缩源数据，依次输出标识帧、zstd 数据和记录原始大小的尾帧
+type compressReader struct {
+	src   io.Reader
+	enc   *zstd.Encoder
+	chunk []byte
+	buf   bytes.Buffer // 待输出的数据
+	in    int64        // 已读取的原始数据大小
+	pos   int64
+	done  bool
+}
+
+func newCompressReader(src io.Reader, enc *zstd.Encoder) *compressReader {
+	r := &compressReader{src: src, enc: enc, chunk: make([]byte, 32<<10)}
+	r.reset()
+	return r
+}
+
+func (r *compressReader) reset() {
+	r.buf.Reset()
+	r.buf.Write(headerFrame)
+	r.enc.Reset(&r.buf)
+	r.in, r.pos, r.done = 0, 0, false
+}
+
+func (r *compressReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n, _ := r.buf.Read(p)
+	r.pos += int64(n)
+	return n, nil
+}
+
+// next 读取一块源数据交给编码器；编码器不使用并发，输出直接写入 buf
+func (r *compressReader) next() error {
+	n, err := r.src.Read(r.chunk)
+	if n > 0 {
+		r.in += int64(n)
+		if _, werr := r.enc.Write(r.chunk[:n]); werr != nil {
+			return werr
+		}
+	}
+	switch err {
+	case nil:
+		return nil
+	case io.EOF:
+		if err := r.enc.Close(); err != nil {
+			return err
+		}
+		size := make([]byte, 8)
+		binary.LittleEndian.PutUint64(size, uint64(r.in))
+		r.buf.Write(skippableFrame(size))
+		r.done = true
+		return nil
+	}
+	return err
+}
+
+type seekableCompressReader struct {
+	*compressReader
+	source io.Reader
+	seeker io.Seeker
+	start  int64
+}
+
+// Seek 只支持查询当前位置和回到开头（重试上传）
+func (r *seekableCompressReader) Seek(offset int64, whence int) (int64, error) {
+	switch {
+	case offset == 0 && whence == io.SeekCurrent:
+		return r.pos, nil
+	case offset == 0 && whence == io.SeekStart:
+		if _, err := r.seeker.Seek(r.start, io.SeekStart); err != nil {
+			return 0, err
+		}
+		r.src = r.source
+		r.reset()
+		return 0, nil
+	}
+	return 0, errors.New("compressed upload can only be rewound to the start")
+}
+
+// decompressReader 关闭时释放解码器
+type decompressReader struct {
+	dec *zstd.Decoder
+	rc  io.ReadCloser
+}
+
+func (r *decompressReader) Read(p []byte) (int, error) {
+	return r.dec.Read(p)
+}
+
+func (r *decompressReader) Close() error {
+	r.dec.Close()
+	return r.rc.Close()
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,151 @@
+package compress
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func readAll(rc io.ReadCloser, err error) []byte {
+	if err != nil {
+		return nil
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	return data
+}
+
+func newStorage(t *testing.T, cfg Config) (*Compressor, storage.Storage, storage.Storage) {
+	backend, err := local.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(cfg)
+	return c, c.Wrap(backend), backend
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	c, s, backend := newStorage(t, Config{})
+	ctx := context.Background()
+
+	text := strings.Repeat("2026-10-16 12:00:00 INFO request served\n", 2000)
+	random := make([]byte, 64<<10)
+	rand.Read(random)
+	files := map[string][]byte{
+		"build.log":  []byte(text),
+		"notes":      []byte(text), // 无扩展名，按内容判断
+		"small.txt":  []byte("tiny"),
+		"random.bin": random,
+		"data.txt":   append([]byte{0x1f, 0x8b}, text...), // 实际是 gzip 内容
+		"pkg.rpm":    []byte(text),
+	}
+	for name, data := range files {
+		if err := s.Store(ctx, name, bytes.NewReader(data)); err != nil {
+			t.Fatalf("Store %s: %v", name, err)
+		}
+		if got := readAll(s.Get(ctx, name)); !bytes.Equal(got, data) {
+			t.Errorf("Get %s returned %d bytes, want %d", name, len(got), len(data))
+		}
+		info, err := s.Stat(ctx, name)
+		if err != nil || info.Size != int64(len(data)) {
+			t.Errorf("Stat %s = %d, %v", name, info.Size, err)
+		}
+	}
+
+	for name, want := range map[string]bool{
+		"build.log": true, "notes": true, "small.txt": false,
+		"random.bin": false, "data.txt": false, "pkg.rpm": false,
+	} {
+		stored := readAll(backend.Get(ctx, name))
+		if compressed := bytes.HasPrefix(stored, headerFrame); compressed != want {
+			t.Errorf("%s compressed = %v, want %v", name, compressed, want)
+		}
+	}
+
+	m := c.Metrics()
+	if m.Compressed != 2 || m.Skipped != 4 || m.BytesIn != int64(2*len(text)) || m.Ratio >= 0.1 {
+		t.Errorf("metrics = %+v", m)
+	}
+
+	// 存储的对象仍是合法的 zstd 数据
+	dec, _ := zstd.NewReader(nil)
+	defer dec.Close()
+	plain, err := dec.DecodeAll(readAll(backend.Get(ctx, "build.log")), nil)
+	if err != nil || string(plain) != text {
+		t.Errorf("stored object is not plain zstd: %v", err)
+	}
+
+	// 范围读取按原始内容的偏移
+	got := readAll(s.OpenRange(ctx, "build.log", 40, 10))
+	if string(got) != text[40:50] {
+		t.Errorf("OpenRange = %q", got)
+	}
+	got = readAll(s.OpenRange(ctx, "random.bin", 100, -1))
+	if !bytes.Equal(got, random[100:]) {
+		t.Errorf("OpenRange uncompressed returned %d bytes", len(got))
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	_, s, backend := newStorage(t, Config{})
+	ctx := context.Background()
+	text := strings.Repeat("line\n", 1000)
+	s.Store(ctx, "a.log", strings.NewReader(text))
+
+	// 关闭压缩后新写入的对象原样保存，已压缩的对象仍可读取
+	ro := New(Config{ReadOnly: true}).Wrap(backend)
+	ro.Store(ctx, "b.log", strings.NewReader(text))
+	if stored := readAll(backend.Get(ctx, "b.log")); string(stored) != text {
+		t.Error("read-only compressor compressed a new object")
+	}
+	if got := readAll(ro.Get(ctx, "a.log")); string(got) != text {
+		t.Error("read-only compressor did not decompress an existing object")
+	}
+}
+
+// failOnce 第一次上传读走部分数据后失败，第二次回到开头重新上传
+type failOnce struct {
+	storage.Storage
+	failed bool
+}
+
+func (f *failOnce) Store(ctx context.Context, path string, reader io.Reader) error {
+	if !f.failed {
+		f.failed = true
+		io.CopyN(io.Discard, reader, 100)
+		if _, err := reader.(io.Seeker).Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	return f.Storage.Store(ctx, path, reader)
+}
+
+func TestRewind(t *testing.T) {
+	backend, _ := local.NewLocalStorage(t.TempDir())
+	s := New(Config{}).Wrap(&failOnce{Storage: backend})
+	ctx := context.Background()
+
+	text := strings.Repeat("rewind me\n", 1000)
+	if err := s.Store(ctx, "a.log", strings.NewReader(text)); err != nil {
+		t.Fatal(err)
+	}
+	if got := readAll(s.Get(ctx, "a.log")); string(got) != text {
+		t.Errorf("rewound upload returned %d bytes", len(got))
+	}
+}
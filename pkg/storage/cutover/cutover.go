@@ -0,0 +1,104 @@
+package cutover
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"plus/pkg/storage"
+)
+
+// Storage 访问当前后端，Switch 切换到新后端后不需要重建上层的仓库。
+// 读取不加锁，切换期间继续由旧后端提供；写入持有读锁，切换期间等待
+type Storage struct {
+	mu      sync.RWMutex
+	current atomic.Pointer[backend]
+}
+
+type backend struct {
+	storage.Storage
+}
+
+func New(s storage.Storage) *Storage {
+	c := &Storage{}
+	c.current.Store(&backend{s})
+	return c
+}
+
+// Current 返回当前后端
+func (c *Storage) Current() storage.Storage {
+	return c.current.Load().Storage
+}
+
+// Switch 等待进行中的写入完成并暂停新的写入，由 sync 把当前后端的最后改动复制到 next，
+// 成功后切换到 next。sync 返回错误时不切换
+func (c *Storage) Switch(next storage.Storage, sync func(current storage.Storage) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := sync(c.Current()); err != nil {
+		return err
+	}
+	c.current.Store(&backend{next})
+	return nil
+}
+
+func (c *Storage) Store(ctx context.Context, path string, reader io.Reader) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Current().Store(ctx, path, reader)
+}
+
+func (c *Storage) Delete(ctx context.Context, path string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Current().Delete(ctx, path)
+}
+
+func (c *Storage) CreateDir(ctx context.Context, path string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Current().CreateDir(ctx, path)
+}
+
+func (c *Storage) Copy(ctx context.Context, src, dst string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Current().Copy(ctx, src, dst)
+}
+
+func (c *Storage) Rename(ctx context.Context, src, dst string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Current().Rename(ctx, src, dst)
+}
+
+func (c *Storage) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	return c.Current().Get(ctx, path)
+}
+
+func (c *Storage) ListWithOptions(ctx context.Context, prefix string, opts storage.ListOptions) ([]storage.FileInfo, error) {
+	return c.Current().ListWithOptions(ctx, prefix, opts)
+}
+
+func (c *Storage) GetPath(path string) string {
+	return c.Current().GetPath(path)
+}
+
+func (c *Storage) Exists(ctx context.Context, path string) (bool, error) {
+	return c.Current().Exists(ctx, path)
+}
+
+func (c *Storage) Stat(ctx context.Context, path string) (storage.FileInfo, error) {
+	return c.Current().Stat(ctx, path)
+}
+
+// PresignGet 由当前后端生成预签名地址，不支持时返回 storage.ErrNotPresignable
+func (c *Storage) PresignGet(ctx context.Context, path string, expires time.Duration) (string, error) {
+	return storage.Presign(ctx, c.Current(), path, expires)
+}
+
+func (c *Storage) OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	return c.Current().OpenRange(ctx, path, offset, length)
+}
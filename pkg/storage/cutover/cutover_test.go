@@ -0,0 +1,101 @@
+package cutover
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func read(t *testing.T, s storage.Storage, path string) string {
+	t.Helper()
+	rc, err := s.Get(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Get %s: %v", path, err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	return string(data)
+}
+
+func TestSwitch(t *testing.T) {
+	ctx := context.Background()
+	old, _ := local.NewLocalStorage(t.TempDir())
+	next, _ := local.NewLocalStorage(t.TempDir())
+	s := New(old)
+
+	if err := s.Store(ctx, "repo/a.txt", strings.NewReader("a")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 同步失败时继续使用旧后端
+	if err := s.Switch(next, func(storage.Storage) error { return errors.New("copy failed") }); err == nil {
+		t.Fatal("Switch should return the sync error")
+	}
+	if s.Current() != old {
+		t.Fatal("failed switch changed the backend")
+	}
+
+	// 切换期间读取继续由旧后端提供，写入等待切换完成后写入新后端
+	syncing := make(chan struct{})
+	release := make(chan struct{})
+	switched := make(chan error)
+	go func() {
+		switched <- s.Switch(next, func(current storage.Storage) error {
+			close(syncing)
+			<-release
+			rc, err := current.Get(ctx, "repo/a.txt")
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			return next.Store(ctx, "repo/a.txt", rc)
+		})
+	}()
+	<-syncing
+
+	if got := read(t, s, "repo/a.txt"); got != "a" {
+		t.Errorf("read during switch = %q", got)
+	}
+	stored := make(chan error)
+	go func() { stored <- s.Store(ctx, "repo/b.txt", strings.NewReader("b")) }()
+	select {
+	case err := <-stored:
+		t.Fatalf("write finished during switch: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-switched; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-stored; err != nil {
+		t.Fatal(err)
+	}
+	if s.Current() != next {
+		t.Fatal("backend not switched")
+	}
+	if got := read(t, next, "repo/b.txt"); got != "b" {
+		t.Errorf("write after switch went to %q", got)
+	}
+	if ok, _ := old.Exists(ctx, "repo/b.txt"); ok {
+		t.Error("write after switch reached the old backend")
+	}
+	if got := read(t, s, "repo/a.txt"); got != "a" {
+		t.Errorf("read after switch = %q", got)
+	}
+}
@@ -0,0 +1,537 @@
+package encrypt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+)
+
+// 对象格式：固定长度的头部（标识、密钥 ID、被主密钥加密的数据密钥）后跟按块加密的内容。
+// 每个对象使用随机生成的数据密钥，块的 nonce 由块序号和是否为最后一块组成，截断或重排都无法通过校验
+const (
+	magic       = "PLUSENC1"
+	MaxKeyIDLen = 32
+	KeySize     = 32 // AES-256
+	chunkSize   = 64 << 10
+	tagSize     = 16
+	nonceSize   = 12
+
+	wrappedKeySize = nonceSize + KeySize + tagSize
+	headerSize     = len(magic) + 1 + MaxKeyIDLen + wrappedKeySize
+	sealedSize     = chunkSize + tagSize
+)
+
+var (
+	// ErrUnknownKey 对象使用的密钥不在密钥环中
+	ErrUnknownKey = errors.New("unknown encryption key")
+	// ErrCorrupt 对象内容被篡改或截断
+	ErrCorrupt = errors.New("encrypted object corrupt")
+)
+
+// Key 主密钥，ID 写入每个对象的头部
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// Keyring 主密钥集合；新对象使用 active 密钥，读取时按对象头部的 ID 选择密钥，轮换后旧密钥仍可解密
+type Keyring struct {
+	active string
+	keys   map[string]cipher.AEAD
+}
+
+// NewKeyring 创建密钥环，active 为空时使用第一个密钥
+func NewKeyring(active string, keys []Key) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no encryption keys configured")
+	}
+	k := &Keyring{active: active, keys: make(map[string]cipher.AEAD)}
+	for _, key := range keys {
+		if key.ID == "" || len(key.ID) > MaxKeyIDLen {
+			return nil, fmt.Errorf("encryption key id %q must be 1-%d bytes", key.ID, MaxKeyIDLen)
+		}
+		if _, ok := k.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate encryption key id %q", key.ID)
+		}
+		if len(key.Secret) != KeySize {
+			return nil, fmt.Errorf("encryption key %q must be %d bytes, got %d", key.ID, KeySize, len(key.Secret))
+		}
+		aead, err := newGCM(key.Secret)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[key.ID] = aead
+	}
+	if k.active == "" {
+		k.active = keys[0].ID
+	}
+	if _, ok := k.keys[k.active]; !ok {
+		return nil, fmt.Errorf("active encryption key %q not configured", k.active)
+	}
+	return k, nil
+}
+
+// Active 返回新对象使用的密钥 ID
+func (k *Keyring) Active() string {
+	return k.active
+}
+
+// newHeader 生成新的数据密钥，返回用 active 密钥加密后的头部和数据密钥
+func (k *Keyring) newHeader() ([]byte, cipher.AEAD, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, err
+	}
+	header, err := k.wrap(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := newGCM(dataKey)
+	return header, aead, err
+}
+
+func (k *Keyring) wrap(dataKey []byte) ([]byte, error) {
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = append(header, byte(len(k.active)))
+	header = append(header, k.active...)
+	header = append(header, make([]byte, MaxKeyIDLen-len(k.active))...)
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	header = append(header, nonce...)
+	return k.keys[k.active].Seal(header, nonce, dataKey, header[:len(magic)+1+MaxKeyIDLen]), nil
+}
+
+// unwrap 解析头部，返回密钥 ID 和数据密钥
+func (k *Keyring) unwrap(header []byte) (string, []byte, error) {
+	idLen := int(header[len(magic)])
+	if idLen == 0 || idLen > MaxKeyIDLen {
+		return "", nil, ErrCorrupt
+	}
+	id := string(header[len(magic)+1 : len(magic)+1+idLen])
+	kek, ok := k.keys[id]
+	if !ok {
+		return id, nil, fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+	aad := header[:len(magic)+1+MaxKeyIDLen]
+	nonce := header[len(aad) : len(aad)+nonceSize]
+	dataKey, err := kek.Open(nil, nonce, header[len(aad)+nonceSize:], aad)
+	if err != nil {
+		return id, nil, ErrCorrupt
+	}
+	return id, dataKey, nil
+}
+
+// rewrap 用 active 密钥重新加密头部中的数据密钥，内容不变
+func (k *Keyring) rewrap(header []byte) ([]byte, error) {
+	_, dataKey, err := k.unwrap(header)
+	if err != nil {
+		return nil, err
+	}
+	return k.wrap(dataKey)
+}
+
+func (k *Keyring) open(header []byte) (cipher.AEAD, error) {
+	_, dataKey, err := k.unwrap(header)
+	if err != nil {
+		return nil, err
+	}
+	return newGCM(dataKey)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Storage 写入时加密、读取时解密，底层存储只保存密文。没有加密头部的旧对象按明文读取，Rotate 时加密
+type Storage struct {
+	storage.Storage
+	keys *Keyring
+}
+
+// New 为存储后端加上加密
+func New(backend storage.Storage, keys *Keyring) *Storage {
+	return &Storage{Storage: backend, keys: keys}
+}
+
+func (s *Storage) Store(ctx context.Context, path string, reader io.Reader) error {
+	header, aead, err := s.keys.newHeader()
+	if err != nil {
+		return err
+	}
+	return s.Storage.Store(ctx, path, newEncryptReader(reader, header, aead))
+}
+
+func (s *Storage) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	rc, err := s.Storage.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	header, encrypted, err := readHeader(rc)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	if !encrypted {
+		return &readCloser{Reader: io.MultiReader(bytes.NewReader(header), rc), Closer: rc}, nil
+	}
+	aead, err := s.keys.open(header)
+	if err != nil {
+		rc.Close()
+		return nil, fmt.Errorf("decrypt %s: %w", path, err)
+	}
+	return newDecryptReader(rc, aead, 0), nil
+}
+
+// OpenRange 只读取并解密包含所需范围的块
+func (s *Storage) OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	header, encrypted, err := s.header(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return s.Storage.OpenRange(ctx, path, offset, length)
+	}
+	aead, err := s.keys.open(header)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", path, err)
+	}
+
+	index := offset / chunkSize
+	// 读到对象末尾：只有读到结尾才能确认最后一块
+	rc, err := s.Storage.OpenRange(ctx, path, int64(headerSize)+index*sealedSize, -1)
+	if err != nil {
+		return nil, err
+	}
+	return storage.Section(newDecryptReader(rc, aead, uint64(index)), offset-index*chunkSize, length)
+}
+
+// Stat 返回明文大小
+func (s *Storage) Stat(ctx context.Context, path string) (storage.FileInfo, error) {
+	info, err := s.Storage.Stat(ctx, path)
+	if err != nil || info.IsDir {
+		return info, err
+	}
+	_, encrypted, err := s.header(ctx, path)
+	if err != nil {
+		return info, err
+	}
+	if encrypted {
+		info.Size = plainSize(info.Size)
+	}
+	return info, nil
+}
+
+// ListWithOptions 按加密格式换算文件大小，未加密的旧对象的大小可能不准确
+func (s *Storage) ListWithOptions(ctx context.Context, prefix string, opts storage.ListOptions) ([]storage.FileInfo, error) {
+	files, err := s.Storage.ListWithOptions(ctx, prefix, opts)
+	for i := range files {
+		if !files[i].IsDir && files[i].Size >= int64(headerSize+tagSize) {
+			files[i].Size = plainSize(files[i].Size)
+		}
+	}
+	return files, err
+}
+
+// RotateStats 密钥轮换结果
+type RotateStats struct {
+	Rotated   int // 重新加密了数据密钥
+	Encrypted int // 旧的明文对象已加密
+	Current   int // 已使用 active 密钥
+	Failed    int
+}
+
+// Rotate 将 prefix 下的对象改为使用 active 密钥：只重新加密头部中的数据密钥，内容密文原样写回；
+// 明文对象加密后写回。单个对象失败时记录日志并继续
+func (s *Storage) Rotate(ctx context.Context, prefix string) (RotateStats, error) {
+	var stats RotateStats
+	files, err := s.Storage.ListWithOptions(ctx, prefix, storage.ListOptions{MaxDepth: -1})
+	if err != nil {
+		return stats, err
+	}
+	for _, f := range files {
+		if f.IsDir {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+		name := path.Join(prefix, f.Name)
+		if err := s.rotate(ctx, name, &stats); err != nil {
+			log.Logger.Warnf("Failed to rotate encryption key of %s: %v", name, err)
+			stats.Failed++
+		}
+	}
+	return stats, nil
+}
+
+func (s *Storage) rotate(ctx context.Context, name string, stats *RotateStats) error {
+	rc, err := s.Storage.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	header, encrypted, err := readHeader(rc)
+	if err != nil {
+		return err
+	}
+	if !encrypted {
+		if err := s.Store(ctx, name, io.MultiReader(bytes.NewReader(header), rc)); err != nil {
+			return err
+		}
+		stats.Encrypted++
+		return nil
+	}
+
+	id, _, err := s.keys.unwrap(header)
+	if err != nil {
+		return err
+	}
+	if id == s.keys.Active() {
+		stats.Current++
+		return nil
+	}
+	newHeader, err := s.keys.rewrap(header)
+	if err != nil {
+		return err
+	}
+	if err := s.Storage.Store(ctx, name, io.MultiReader(bytes.NewReader(newHeader), rc)); err != nil {
+		return err
+	}
+	stats.Rotated++
+	return nil
+}
+
+// header 读取对象头部
+func (s *Storage) header(ctx context.Context, path string) ([]byte, bool, error) {
+	rc, err := s.Storage.OpenRange(ctx, path, 0, int64(headerSize))
+	if err != nil {
+		return nil, false, err
+	}
+	defer rc.Close()
+	return readHeader(rc)
+}
+
+// readHeader 读取头部；对象不以加密标识开头时返回已读取的内容和 false
+func readHeader(r io.Reader) ([]byte, bool, error) {
+	header := make([]byte, headerSize)
+	n, err := io.ReadFull(r, header)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return header[:n], false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return header, string(header[:len(magic)]) == magic, nil
+}
+
+// plainSize 由密文大小计算明文大小
+func plainSize(size int64) int64 {
+	body := size - int64(headerSize)
+	chunks := (body + sealedSize - 1) / sealedSize
+	if plain := body - chunks*tagSize; plain > 0 {
+		return plain
+	}
+	return 0
+}
+
+// chunkNonce 第 index 块的 nonce，最后一块单独标记，防止截断
+func chunkNonce(nonce []byte, index uint64, final bool) []byte {
+	binary.BigEndian.PutUint64(nonce, index)
+	nonce[8] = 0
+	if final {
+		nonce[8] = 1
+	}
+	return nonce
+}
+
+// encryptReader 输出头部和按块加密的内容
+type encryptReader struct {
+	source io.Reader
+	src    *bufio.Reader
+	aead   cipher.AEAD
+	header []byte
+
+	plain  []byte
+	sealed []byte
+	nonce  []byte
+	out    []byte // 待输出的密文
+	index  uint64
+	pos    int64
+	done   bool
+}
+
+// newEncryptReader 源数据可 Seek 时返回的 reader 也可回到开头，上传失败时可以重试
+func newEncryptReader(src io.Reader, header []byte, aead cipher.AEAD) io.Reader {
+	r := &encryptReader{
+		source: src,
+		src:    bufio.NewReaderSize(src, chunkSize),
+		aead:   aead,
+		header: header,
+		plain:  make([]byte, chunkSize),
+		sealed: make([]byte, 0, sealedSize),
+		nonce:  make([]byte, nonceSize),
+		out:    header,
+	}
+	if seeker, ok := src.(io.Seeker); ok {
+		if start, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			return &seekableEncryptReader{encryptReader: r, seeker: seeker, start: start}
+		}
+	}
+	return r
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	r.pos += int64(n)
+	return n, nil
+}
+
+func (r *encryptReader) next() error {
+	n, err := io.ReadFull(r.src, r.plain)
+	final := false
+	switch err {
+	case nil:
+		if _, err := r.src.Peek(1); err == io.EOF {
+			final = true
+		} else if err != nil {
+			return err
+		}
+	case io.EOF, io.ErrUnexpectedEOF:
+		final = true
+	default:
+		return err
+	}
+	r.out = r.aead.Seal(r.sealed[:0], chunkNonce(r.nonce, r.index, final), r.plain[:n], nil)
+	r.index++
+	r.done = final
+	return nil
+}
+
+type seekableEncryptReader struct {
+	*encryptReader
+	seeker io.Seeker
+	start  int64
+}
+
+// Seek 只支持查询当前位置和回到开头（重试上传），数据密钥不变，重新输出的内容相同
+func (r *seekableEncryptReader) Seek(offset int64, whence int) (int64, error) {
+	switch {
+	case offset == 0 && whence == io.SeekCurrent:
+		return r.pos, nil
+	case offset == 0 && whence == io.SeekStart:
+		if _, err := r.seeker.Seek(r.start, io.SeekStart); err != nil {
+			return 0, err
+		}
+		r.src.Reset(r.source)
+		r.out = r.header
+		r.index, r.pos, r.done = 0, 0, false
+		return 0, nil
+	}
+	return 0, errors.New("encrypted upload can only be rewound to the start")
+}
+
+// decryptReader 按块解密，从第 index 块开始
+type decryptReader struct {
+	src    *bufio.Reader
+	closer io.Closer
+	aead   cipher.AEAD
+
+	sealed []byte
+	nonce  []byte
+	out    []byte
+	index  uint64
+	done   bool
+	err    error
+}
+
+func newDecryptReader(rc io.ReadCloser, aead cipher.AEAD, index uint64) *decryptReader {
+	return &decryptReader{
+		src:    bufio.NewReaderSize(rc, sealedSize),
+		closer: rc,
+		aead:   aead,
+		sealed: make([]byte, sealedSize),
+		nonce:  make([]byte, nonceSize),
+		index:  index,
+	}
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.next()
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *decryptReader) next() error {
+	n, err := io.ReadFull(r.src, r.sealed)
+	final := false
+	switch err {
+	case nil:
+		if _, err := r.src.Peek(1); err == io.EOF {
+			final = true
+		} else if err != nil {
+			return err
+		}
+	case io.EOF, io.ErrUnexpectedEOF:
+		final = true
+	default:
+		return err
+	}
+	if n < tagSize {
+		return ErrCorrupt
+	}
+	plain, err := r.aead.Open(r.sealed[:0], chunkNonce(r.nonce, r.index, final), r.sealed[:n], nil)
+	if err != nil {
+		return ErrCorrupt
+	}
+	r.out = plain
+	r.index++
+	r.done = final
+	return nil
+}
+
+func (r *decryptReader) Close() error {
+	return r.closer.Close()
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
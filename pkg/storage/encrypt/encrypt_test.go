@@ -0,0 +1,201 @@
+package encrypt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func testKey(id string, b byte) Key {
+	return Key{ID: id, Secret: bytes.Repeat([]byte{b}, KeySize)}
+}
+
+func newTestStorage(t *testing.T, active string, keys ...Key) (*Storage, storage.Storage, string) {
+	t.Helper()
+	dir := t.TempDir()
+	backend, _ := local.NewLocalStorage(dir)
+	keyring, err := NewKeyring(active, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(backend, keyring), backend, dir
+}
+
+// readAll 读取全部内容，出错时返回 nil
+func readAll(rc io.ReadCloser, err error) []byte {
+	if err != nil {
+		return nil
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func TestRoundTrip(t *testing.T) {
+	s, _, dir := newTestStorage(t, "", testKey("k1", 1))
+	ctx := context.Background()
+	rnd := rand.New(rand.NewSource(1))
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3*chunkSize + 5} {
+		content := make([]byte, size)
+		rnd.Read(content)
+		if err := s.Store(ctx, "a.bin", bytes.NewReader(content)); err != nil {
+			t.Fatal(err)
+		}
+
+		stored, _ := os.ReadFile(filepath.Join(dir, "a.bin"))
+		if size > 16 && bytes.Contains(stored, content[:16]) {
+			t.Errorf("size %d: plaintext stored in backend", size)
+		}
+		if got := readAll(s.Get(ctx, "a.bin")); !bytes.Equal(got, content) {
+			t.Errorf("size %d: Get returned %d bytes", size, len(got))
+		}
+		info, err := s.Stat(ctx, "a.bin")
+		if err != nil || info.Size != int64(size) {
+			t.Errorf("size %d: Stat = %+v, %v", size, info, err)
+		}
+		files, _ := s.ListWithOptions(ctx, "", storage.ListOptions{MaxDepth: -1})
+		if len(files) != 1 || files[0].Size != int64(size) {
+			t.Errorf("size %d: list = %+v", size, files)
+		}
+
+		for _, r := range [][2]int64{{0, -1}, {1, 10}, {chunkSize - 2, 5}, {chunkSize, -1}, {int64(size) / 2, int64(size) / 3}} {
+			want := content[min(int(r[0]), size):]
+			if r[1] >= 0 && int(r[1]) < len(want) {
+				want = want[:r[1]]
+			}
+			got := readAll(s.OpenRange(ctx, "a.bin", r[0], r[1]))
+			if !bytes.Equal(got, want) {
+				t.Errorf("size %d: OpenRange(%d, %d) returned %d bytes, want %d", size, r[0], r[1], len(got), len(want))
+			}
+		}
+	}
+}
+
+func TestTamperDetected(t *testing.T) {
+	s, backend, dir := newTestStorage(t, "", testKey("k1", 1))
+	ctx := context.Background()
+	content := bytes.Repeat([]byte("x"), 2*chunkSize+100)
+	if err := s.Store(ctx, "a.bin", bytes.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	stored, _ := os.ReadFile(filepath.Join(dir, "a.bin"))
+
+	// 修改内容
+	tampered := append([]byte(nil), stored...)
+	tampered[headerSize+chunkSize+100] ^= 1
+	backend.Store(ctx, "a.bin", bytes.NewReader(tampered))
+	rc, _ := s.Get(ctx, "a.bin")
+	if _, err := io.ReadAll(rc); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("tampered read = %v, want ErrCorrupt", err)
+	}
+	rc.Close()
+
+	// 截掉最后一块
+	backend.Store(ctx, "a.bin", bytes.NewReader(stored[:headerSize+2*sealedSize]))
+	rc, _ = s.Get(ctx, "a.bin")
+	if _, err := io.ReadAll(rc); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("truncated read = %v, want ErrCorrupt", err)
+	}
+	rc.Close()
+}
+
+func TestRotate(t *testing.T) {
+	old, backend, dir := newTestStorage(t, "", testKey("old", 1))
+	ctx := context.Background()
+	old.Store(ctx, "repo/a.txt", bytes.NewReader([]byte("encrypted with old key")))
+	backend.Store(ctx, "repo/legacy.txt", bytes.NewReader([]byte("written before encryption was enabled")))
+
+	keyring, err := NewKeyring("new", []Key{testKey("old", 1), testKey("new", 2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New(backend, keyring)
+	if got := readAll(s.Get(ctx, "repo/legacy.txt")); string(got) != "written before encryption was enabled" {
+		t.Errorf("legacy object = %q", got)
+	}
+
+	stats, err := s.Rotate(ctx, "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats != (RotateStats{Rotated: 1, Encrypted: 1}) {
+		t.Errorf("first rotation = %+v", stats)
+	}
+	if stats, _ := s.Rotate(ctx, "repo"); stats != (RotateStats{Current: 2}) {
+		t.Errorf("second rotation = %+v", stats)
+	}
+
+	// 轮换后不再需要旧密钥
+	keyring, _ = NewKeyring("", []Key{testKey("new", 2)})
+	s = New(backend, keyring)
+	if got := readAll(s.Get(ctx, "repo/a.txt")); string(got) != "encrypted with old key" {
+		t.Errorf("rotated object = %q", got)
+	}
+	if got := readAll(s.Get(ctx, "repo/legacy.txt")); string(got) != "written before encryption was enabled" {
+		t.Errorf("encrypted legacy object = %q", got)
+	}
+	if stored, _ := os.ReadFile(filepath.Join(dir, "repo/legacy.txt")); bytes.Contains(stored, []byte("encryption")) {
+		t.Error("legacy object still stored as plaintext")
+	}
+
+	// 缺少密钥时报错
+	_, err = old.Get(ctx, "repo/a.txt")
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Get with missing key = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestEncryptReaderRewind(t *testing.T) {
+	keyring, _ := NewKeyring("", []Key{testKey("k1", 1)})
+	header, aead, _ := keyring.newHeader()
+	r := newEncryptReader(bytes.NewReader(bytes.Repeat([]byte("y"), chunkSize+10)), header, aead)
+
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		t.Fatal("reader over a seekable source should be seekable")
+	}
+	first, _ := io.ReadAll(r)
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := io.ReadAll(r)
+	if !bytes.Equal(first, second) || len(first) != headerSize+chunkSize+10+2*tagSize {
+		t.Errorf("rewound upload differs: %d vs %d bytes", len(first), len(second))
+	}
+}
+
+func TestNewKeyringValidation(t *testing.T) {
+	for _, keys := range [][]Key{
+		nil,
+		{{ID: "short", Secret: []byte("too short")}},
+		{testKey("", 1)},
+		{testKey("a", 1), testKey("a", 2)},
+	} {
+		if _, err := NewKeyring("", keys); err == nil {
+			t.Errorf("NewKeyring(%v) should fail", keys)
+		}
+	}
+	if _, err := NewKeyring("missing", []Key{testKey("a", 1)}); err == nil {
+		t.Error("unknown active key should fail")
+	}
+}
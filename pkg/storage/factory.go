@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"sort"
 	"plus/internal/log"
 )
 
@@ -31,6 +32,22 @@ func Register(st StorageType, fn storageFn, labels ...string) {
 	}
 }
 
+// Registered 返回存储类型是否已注册
+func Registered(st StorageType) bool {
+	_, ok := factory[st]
+	return ok
+}
+
+// Types 按名称返回已注册的存储类型
+func Types() []StorageType {
+	list := make([]StorageType, 0, len(factory))
+	for st := range factory {
+		list = append(list, st)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
 func Create(storeType StorageType, path string) (Storage, error) {
 	if fn, ok := factory[storeType]; ok {
 		return fn.fn(path)
@@ -38,7 +55,25 @@ func Create(storeType StorageType, path string) (Storage, error) {
 	return nil, fmt.Errorf("unsupported storage type: %s", storeType)
 }
 
+// TypeByLable 返回 CreateByLable 为该标签选择的存储类型
+func TypeByLable(label string) StorageType {
+	if st, ok := backend(label); ok {
+		return st
+	}
+	for st, fn := range factory {
+		for _, l := range fn.labels {
+			if l == label {
+				return st
+			}
+		}
+	}
+	return Local
+}
+
 func CreateByLable(path string, label string) (Storage, error) {
+	if st, ok := backend(label); ok {
+		return Create(st, path)
+	}
 	for _, fn := range factory {
 		for _, l := range fn.labels {
 			if l == label {
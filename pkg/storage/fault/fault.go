@@ -0,0 +1,274 @@
+package fault
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"plus/pkg/storage"
+)
+
+// ErrObjectStore 注入的对象存储错误，属于可重试的临时错误
+var ErrObjectStore error = objectStoreError{}
+
+type objectStoreError struct{}
+
+func (objectStoreError) Error() string   { return "injected object storage error" }
+func (objectStoreError) Temporary() bool { return true }
+
+// Config 故障注入参数，概率取值 0~1
+type Config struct {
+	Latency   time.Duration // 每次操作前的延迟
+	SlowIO    time.Duration // 读写每个数据块前的延迟
+	NoSpace   float64       // Store 写入部分数据后返回 ENOSPC 的概率
+	Error     float64       // Get、Delete、ListWithOptions、Exists 直接返回错误的概率
+	ReadError float64       // Get 返回的数据读到中途出错的概率
+	Seed      int64         // 随机种子，为 0 时使用当前时间
+}
+
+// Stats 已注入的故障次数
+type Stats struct {
+	Operations int64
+	NoSpace    int64
+	Errors     int64
+	ReadErrors int64
+}
+
+// Storage 在底层存储上按概率注入慢 IO、磁盘已满和对象存储错误，用于压力测试
+type Storage struct {
+	storage.Storage
+	cfg Config
+
+	enabled int32
+	mu      sync.Mutex
+	rnd     *rand.Rand
+
+	operations int64
+	noSpace    int64
+	errors     int64
+	readErrors int64
+}
+
+// New 包装存储，创建后即开始注入故障
+func New(backend storage.Storage, cfg Config) *Storage {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Storage{
+		Storage: backend,
+		cfg:     cfg,
+		enabled: 1,
+		rnd:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// SetEnabled 开启或关闭故障注入，关闭后所有操作直接转发
+func (s *Storage) SetEnabled(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&s.enabled, v)
+}
+
+// Stats 返回故障注入统计
+func (s *Storage) Stats() Stats {
+	return Stats{
+		Operations: atomic.LoadInt64(&s.operations),
+		NoSpace:    atomic.LoadInt64(&s.noSpace),
+		Errors:     atomic.LoadInt64(&s.errors),
+		ReadErrors: atomic.LoadInt64(&s.readErrors),
+	}
+}
+
+func (s *Storage) Store(ctx context.Context, path string, reader io.Reader) error {
+	if !s.begin() {
+		return s.Storage.Store(ctx, path, reader)
+	}
+	r := &faultReader{Reader: reader, delay: s.cfg.SlowIO, limit: -1}
+	if s.chance(s.cfg.NoSpace) {
+		// 写入不超过 4KB 后磁盘已满
+		r.limit = s.intn(4096)
+		r.err = &os.PathError{Op: "write", Path: path, Err: syscall.ENOSPC}
+		r.counter = &s.noSpace
+	}
+	return s.Storage.Store(ctx, path, r)
+}
+
+func (s *Storage) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	if !s.begin() {
+		return s.Storage.Get(ctx, path)
+	}
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	rc, err := s.Storage.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	r := &faultReader{Reader: rc, delay: s.cfg.SlowIO, limit: -1}
+	if s.chance(s.cfg.ReadError) {
+		// 读取不超过 4KB 后连接中断
+		r.limit = s.intn(4096)
+		r.err = ErrObjectStore
+		r.counter = &s.readErrors
+	}
+	return &readCloser{Reader: r, Closer: rc}, nil
+}
+
+// OpenRange 与 Get 相同地注入打开失败和读取中断
+func (s *Storage) OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	if !s.begin() {
+		return s.Storage.OpenRange(ctx, path, offset, length)
+	}
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	rc, err := s.Storage.OpenRange(ctx, path, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	r := &faultReader{Reader: rc, delay: s.cfg.SlowIO, limit: -1}
+	if s.chance(s.cfg.ReadError) {
+		r.limit = s.intn(4096)
+		r.err = ErrObjectStore
+		r.counter = &s.readErrors
+	}
+	return &readCloser{Reader: r, Closer: rc}, nil
+}
+
+func (s *Storage) Delete(ctx context.Context, path string) error {
+	if s.begin() {
+		if err := s.fail(); err != nil {
+			return err
+		}
+	}
+	return s.Storage.Delete(ctx, path)
+}
+
+func (s *Storage) Copy(ctx context.Context, src, dst string) error {
+	if s.begin() {
+		if err := s.fail(); err != nil {
+			return err
+		}
+	}
+	return s.Storage.Copy(ctx, src, dst)
+}
+
+func (s *Storage) Rename(ctx context.Context, src, dst string) error {
+	if s.begin() {
+		if err := s.fail(); err != nil {
+			return err
+		}
+	}
+	return s.Storage.Rename(ctx, src, dst)
+}
+
+func (s *Storage) ListWithOptions(ctx context.Context, prefix string, opts storage.ListOptions) ([]storage.FileInfo, error) {
+	if s.begin() {
+		if err := s.fail(); err != nil {
+			return nil, err
+		}
+	}
+	return s.Storage.ListWithOptions(ctx, prefix, opts)
+}
+
+func (s *Storage) Stat(ctx context.Context, path string) (storage.FileInfo, error) {
+	if s.begin() {
+		if err := s.fail(); err != nil {
+			return storage.FileInfo{}, err
+		}
+	}
+	return s.Storage.Stat(ctx, path)
+}
+
+func (s *Storage) Exists(ctx context.Context, path string) (bool, error) {
+	if s.begin() {
+		if err := s.fail(); err != nil {
+			return false, err
+		}
+	}
+	return s.Storage.Exists(ctx, path)
+}
+
+// begin 记录一次操作并施加延迟，返回是否注入故障
+func (s *Storage) begin() bool {
+	if atomic.LoadInt32(&s.enabled) == 0 {
+		return false
+	}
+	atomic.AddInt64(&s.operations, 1)
+	if s.cfg.Latency > 0 {
+		time.Sleep(s.cfg.Latency)
+	}
+	return true
+}
+
+func (s *Storage) fail() error {
+	if !s.chance(s.cfg.Error) {
+		return nil
+	}
+	atomic.AddInt64(&s.errors, 1)
+	return ErrObjectStore
+}
+
+func (s *Storage) chance(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.Float64() < p
+}
+
+func (s *Storage) intn(n int) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(s.rnd.Intn(n))
+}
+
+// faultReader 每次读取前延迟，读满 limit 字节后返回 err；limit 为 -1 时不出错
+type faultReader struct {
+	io.Reader
+	delay   time.Duration
+	limit   int64
+	err     error
+	counter *int64
+	read    int64
+	eof     bool
+}
+
+func (r *faultReader) Read(p []byte) (int, error) {
+	if r.delay > 0 {
+		time.Sleep(r.delay)
+	}
+	if r.limit >= 0 && !r.eof {
+		if r.read >= r.limit {
+			if r.counter != nil {
+				atomic.AddInt64(r.counter, 1)
+				r.counter = nil
+			}
+			return 0, r.err
+		}
+		if remaining := r.limit - r.read; int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+	}
+	n, err := r.Reader.Read(p)
+	r.read += int64(n)
+	// 数据在达到 limit 前读完时不注入故障
+	if err == io.EOF {
+		r.eof = true
+	}
+	return n, err
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
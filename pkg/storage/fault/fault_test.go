@@ -0,0 +1,67 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func TestInjectFaults(t *testing.T) {
+	dir := t.TempDir()
+	backend, _ := local.NewLocalStorage(dir)
+	ctx := context.Background()
+	content := strings.Repeat("x", 8192)
+
+	s := New(backend, Config{NoSpace: 1, Error: 1, ReadError: 1, Seed: 1})
+
+	err := s.Store(ctx, "a.bin", strings.NewReader(content))
+	if !errors.Is(err, syscall.ENOSPC) {
+		t.Fatalf("Store error = %v, want ENOSPC", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a.bin")); !os.IsNotExist(err) {
+		t.Errorf("failed store left a file behind: %v", err)
+	}
+	if _, err := s.Get(ctx, "a.bin"); err != ErrObjectStore {
+		t.Errorf("Get error = %v, want ErrObjectStore", err)
+	}
+	if _, err := s.ListWithOptions(ctx, "", storage.ListOptions{MaxDepth: -1}); err != ErrObjectStore {
+		t.Errorf("List error = %v, want ErrObjectStore", err)
+	}
+
+	s.SetEnabled(false)
+	if err := s.Store(ctx, "a.bin", strings.NewReader(content)); err != nil {
+		t.Fatalf("Store with faults disabled: %v", err)
+	}
+
+	// 只注入读取中断
+	s = New(backend, Config{ReadError: 1, Seed: 1})
+	rc, err := s.Get(ctx, "a.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != ErrObjectStore || len(data) >= len(content) {
+		t.Errorf("read %d bytes, err %v; want a truncated read", len(data), err)
+	}
+
+	if st := s.Stats(); st.Operations != 1 || st.ReadErrors != 1 {
+		t.Errorf("stats = %+v", st)
+	}
+}
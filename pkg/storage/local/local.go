@@ -2,11 +2,14 @@ package local
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"io/fs"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 
 	"plus/internal/log"
 	"plus/pkg/storage"
@@ -26,22 +29,58 @@ func NewLocalStorage(basePath string) (storage.Storage, error) {
 	}, nil
 }
 
-func (l *LocalStorage) Store(ctx context.Context, fullPath string, reader io.Reader) error {
+func (l *LocalStorage) Store(ctx context.Context, path string, reader io.Reader) error {
+	fullPath := l.storePath(path)
+
 	// 确保目录存在
 	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
 		return err
 	}
 
-	file, err := os.Create(fullPath)
+	// 目标为软链接时写入链接指向的文件
+	if realPath, err := filepath.EvalSymlinks(fullPath); err == nil {
+		fullPath = realPath
+	}
+
+	// 先写入同目录的临时文件再重命名，写入失败（如磁盘已满）时不留下不完整的文件，也不破坏旧文件
+	file, err := os.CreateTemp(filepath.Dir(fullPath), TempPrefix+filepath.Base(fullPath)+".*")
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	tmpPath := file.Name()
 
 	_, err = io.Copy(file, reader)
+	if err == nil {
+		err = file.Chmod(0644)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, fullPath)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+	}
 	return err
 }
 
+// TempPrefix 写入中的临时文件前缀，列表时跳过
+const TempPrefix = ".plus-tmp-"
+
+// storePath 兼容两种调用方式：GetPath 返回的完整路径，或相对 basePath 的路径
+func (l *LocalStorage) storePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	base := filepath.Clean(l.basePath)
+	clean := filepath.Clean(path)
+	if base == "." || clean == base || strings.HasPrefix(clean, base+string(filepath.Separator)) {
+		return clean
+	}
+	return filepath.Join(l.basePath, path)
+}
+
 func (l *LocalStorage) Get(ctx context.Context, path string) (io.ReadCloser, error) {
 	fullPath := filepath.Join(l.basePath, path)
 	
@@ -83,6 +122,11 @@ func (l *LocalStorage) ListWithOptions(ctx context.Context, prefix string, opts
 			return nil
 		}
 
+		// 跳过写入中的临时文件
+		if strings.HasPrefix(d.Name(), TempPrefix) {
+			return nil
+		}
+
 		// 处理软链接
 		originalPath := path
 		isSymlink := d.Type()&fs.ModeSymlink != 0
@@ -228,6 +272,95 @@ func (l *LocalStorage) isRepoDirectory(dirPath string) bool {
 	return false
 }
 
+// Stat 跟随软链接返回文件信息
+func (l *LocalStorage) Stat(ctx context.Context, path string) (storage.FileInfo, error) {
+	info, err := os.Stat(filepath.Join(l.basePath, path))
+	if err != nil {
+		return storage.FileInfo{}, err
+	}
+	return storage.FileInfo{
+		Name:    path,
+		Size:    info.Size(),
+		IsDir:   info.IsDir(),
+		ModTime: info.ModTime(),
+	}, nil
+}
+
+func (l *LocalStorage) OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	file, err := l.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return storage.Section(file, offset, length)
+}
+
+// Copy 优先使用硬链接，不复制数据；Store 总是替换文件而不是就地修改，链接后的两个文件互不影响。
+// 不支持硬链接（如跨文件系统）时复制内容
+func (l *LocalStorage) Copy(ctx context.Context, src, dst string) error {
+	srcPath := filepath.Join(l.basePath, src)
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return &os.PathError{Op: "copy", Path: srcPath, Err: syscall.EISDIR}
+	}
+	if realPath, err := filepath.EvalSymlinks(srcPath); err == nil {
+		srcPath = realPath
+	}
+
+	dstPath, err := l.prepareTarget(dst)
+	if err != nil {
+		return err
+	}
+	err = l.link(srcPath, dstPath)
+	if err == nil {
+		return nil
+	}
+	log.Logger.Debugf("Hard link %s -> %s failed, copying: %v", srcPath, dstPath, err)
+
+	file, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return l.Store(ctx, dstPath, file)
+}
+
+// Rename 移动文件，同一文件系统上是原子操作
+func (l *LocalStorage) Rename(ctx context.Context, src, dst string) error {
+	dstPath, err := l.prepareTarget(dst)
+	if err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(l.basePath, src), dstPath)
+}
+
+// prepareTarget 创建目标所在目录，目标为软链接时返回链接指向的文件
+func (l *LocalStorage) prepareTarget(path string) (string, error) {
+	fullPath := l.storePath(path)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return "", err
+	}
+	if realPath, err := filepath.EvalSymlinks(fullPath); err == nil {
+		fullPath = realPath
+	}
+	return fullPath, nil
+}
+
+// link 先链接到同目录的临时文件再重命名，原子地替换已存在的目标
+func (l *LocalStorage) link(srcPath, dstPath string) error {
+	tmpPath := filepath.Join(filepath.Dir(dstPath), fmt.Sprintf("%s%s.%d", TempPrefix, filepath.Base(dstPath), rand.Int63()))
+	if err := os.Link(srcPath, tmpPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, dstPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
 func (l *LocalStorage) CreateDir(ctx context.Context, path string) error {
 	fullPath := filepath.Join(l.basePath, path)
 	return os.MkdirAll(fullPath, 0755)
@@ -3,14 +3,24 @@ package local
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"plus/internal/log"
 	"plus/pkg/storage"
 	"strings"
 	"testing"
+
+	"go.uber.org/zap"
 )
 
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
 func setupTestDir(t *testing.T) (string, func()) {
 	// 创建临时测试目录
 	tempDir, err := os.MkdirTemp("", "localstorage-test-*")
@@ -30,7 +40,7 @@ func TestStore(t *testing.T) {
 	tempDir, cleanup := setupTestDir(t)
 	defer cleanup()
 
-	localStorage := NewLocalStorage(tempDir)
+	localStorage, _ := NewLocalStorage(tempDir)
 	ctx := context.Background()
 
 	// 测试存储文件
@@ -67,11 +77,46 @@ func TestStore(t *testing.T) {
 	}
 }
 
+func TestStoreFailureKeepsOldFile(t *testing.T) {
+	tempDir, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	localStorage, _ := NewLocalStorage(tempDir)
+	ctx := context.Background()
+
+	if err := localStorage.Store(ctx, "pkg.bin", strings.NewReader("old content")); err != nil {
+		t.Fatalf("Failed to store file: %v", err)
+	}
+
+	// 写入中途出错时旧文件保持不变，且不留下临时文件
+	failing := io.MultiReader(strings.NewReader("partial"), &errReader{err: io.ErrUnexpectedEOF})
+	if err := localStorage.Store(ctx, "pkg.bin", failing); err == nil {
+		t.Fatal("Store should fail when the reader fails")
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "pkg.bin"))
+	if err != nil || string(content) != "old content" {
+		t.Errorf("old file changed: %q, %v", content, err)
+	}
+	entries, _ := os.ReadDir(tempDir)
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
 func TestGet(t *testing.T) {
 	tempDir, cleanup := setupTestDir(t)
 	defer cleanup()
 
-	localStorage := NewLocalStorage(tempDir)
+	localStorage, _ := NewLocalStorage(tempDir)
 	ctx := context.Background()
 
 	// 创建测试文件
@@ -117,7 +162,7 @@ func TestDelete(t *testing.T) {
 	tempDir, cleanup := setupTestDir(t)
 	defer cleanup()
 
-	localStorage := NewLocalStorage(tempDir)
+	localStorage, _ := NewLocalStorage(tempDir)
 	ctx := context.Background()
 
 	// 创建测试文件
@@ -171,7 +216,7 @@ func TestListWithOptions(t *testing.T) {
 	tempDir, cleanup := setupTestDir(t)
 	defer cleanup()
 
-	localStorage := NewLocalStorage(tempDir)
+	localStorage, _ := NewLocalStorage(tempDir)
 	ctx := context.Background()
 
 	// 创建测试目录结构
@@ -295,7 +340,7 @@ func TestExists(t *testing.T) {
 	tempDir, cleanup := setupTestDir(t)
 	defer cleanup()
 
-	localStorage := NewLocalStorage(tempDir)
+	localStorage, _ := NewLocalStorage(tempDir)
 	ctx := context.Background()
 
 	// 创建测试文件
@@ -326,11 +371,99 @@ func TestExists(t *testing.T) {
 	}
 }
 
+func TestStatAndOpenRange(t *testing.T) {
+	tempDir, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	localStorage, _ := NewLocalStorage(tempDir)
+	ctx := context.Background()
+
+	if err := os.WriteFile(filepath.Join(tempDir, "test.txt"), []byte("0123456789"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	info, err := localStorage.Stat(ctx, "test.txt")
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Size != 10 || info.IsDir || info.ModTime.IsZero() {
+		t.Errorf("Unexpected file info: %+v", info)
+	}
+	if _, err := localStorage.Stat(ctx, "nonexistent.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat of missing file should return ErrNotExist, got %v", err)
+	}
+
+	cases := []struct {
+		offset, length int64
+		want           string
+	}{
+		{0, -1, "0123456789"},
+		{3, 4, "3456"},
+		{8, -1, "89"},
+		{8, 10, "89"},
+		{20, -1, ""},
+	}
+	for _, c := range cases {
+		rc, err := localStorage.OpenRange(ctx, "test.txt", c.offset, c.length)
+		if err != nil {
+			t.Fatalf("OpenRange(%d, %d) failed: %v", c.offset, c.length, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if string(data) != c.want {
+			t.Errorf("OpenRange(%d, %d) = %q, want %q", c.offset, c.length, data, c.want)
+		}
+	}
+}
+
+func TestCopyAndRename(t *testing.T) {
+	tempDir, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	localStorage, _ := NewLocalStorage(tempDir)
+	ctx := context.Background()
+
+	if err := localStorage.Store(ctx, "repo/a.txt", strings.NewReader("original")); err != nil {
+		t.Fatalf("Failed to store file: %v", err)
+	}
+	if err := localStorage.Copy(ctx, "repo/a.txt", "other/b.txt"); err != nil {
+		t.Fatalf("Failed to copy file: %v", err)
+	}
+
+	// 覆盖原文件不影响副本
+	if err := localStorage.Store(ctx, "repo/a.txt", strings.NewReader("changed")); err != nil {
+		t.Fatalf("Failed to overwrite file: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(tempDir, "other/b.txt")); string(data) != "original" {
+		t.Errorf("Copy content = %q, want %q", data, "original")
+	}
+
+	if err := localStorage.Rename(ctx, "other/b.txt", "repo/a.txt"); err != nil {
+		t.Fatalf("Failed to rename file: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(tempDir, "repo/a.txt")); string(data) != "original" {
+		t.Errorf("Renamed content = %q, want %q", data, "original")
+	}
+	if exists, _ := localStorage.Exists(ctx, "other/b.txt"); exists {
+		t.Errorf("Rename source should be gone")
+	}
+
+	if err := localStorage.Copy(ctx, "missing.txt", "c.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Copy of missing file should return ErrNotExist, got %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(tempDir, "repo"))
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), TempPrefix) {
+			t.Errorf("Temporary file left behind: %s", e.Name())
+		}
+	}
+}
+
 func TestCreateDir(t *testing.T) {
 	tempDir, cleanup := setupTestDir(t)
 	defer cleanup()
 
-	localStorage := NewLocalStorage(tempDir)
+	localStorage, _ := NewLocalStorage(tempDir)
 	ctx := context.Background()
 
 	// 测试创建目录
@@ -355,7 +488,7 @@ func TestGetPath(t *testing.T) {
 	tempDir, cleanup := setupTestDir(t)
 	defer cleanup()
 
-	localStorage := NewLocalStorage(tempDir)
+	localStorage, _ := NewLocalStorage(tempDir)
 
 	// 测试获取路径
 	testPath := "test/path"
@@ -371,7 +504,8 @@ func TestIsRepoDirectory(t *testing.T) {
 	tempDir, cleanup := setupTestDir(t)
 	defer cleanup()
 
-	localStorage := NewLocalStorage(tempDir).(*LocalStorage)
+	s, _ := NewLocalStorage(tempDir)
+	localStorage := s.(*LocalStorage)
 
 	// 创建一个普通目录
 	normalDir := filepath.Join(tempDir, "normal")
@@ -420,7 +554,7 @@ func TestFileInfoModTime(t *testing.T) {
 	tempDir, cleanup := setupTestDir(t)
 	defer cleanup()
 
-	localStorage := NewLocalStorage(tempDir)
+	localStorage, _ := NewLocalStorage(tempDir)
 	ctx := context.Background()
 
 	// 创建测试文件
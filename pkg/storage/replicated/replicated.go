@@ -0,0 +1,335 @@
+package replicated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"plus/internal/log"
+	"plus/internal/types"
+	"plus/pkg/storage"
+	"plus/pkg/storage/breaker"
+)
+
+// Replica 一个副本后端
+type Replica struct {
+	Name    string
+	Storage storage.Storage
+}
+
+// SpoolPrefix 分发中的上传在 SpoolDir 中的临时文件前缀
+const SpoolPrefix = "plus-replicate-"
+
+// Config 复制参数
+type Config struct {
+	// WriteQuorum 写入成功的最少副本数，默认全部副本
+	WriteQuorum int
+	// Breaker 每个副本的熔断参数，熔断的副本读取时跳过
+	Breaker breaker.Config
+	// SpoolDir 不可重复读取的上传先写入该目录再分发到各副本，默认系统临时目录
+	SpoolDir string
+}
+
+// Replicator 每个对象写入所有副本，读取时使用第一个可用的副本；
+// 单个副本丢失或故障时服务不中断
+type Replicator struct {
+	cfg      Config
+	replicas []*replica
+
+	mu        sync.Mutex
+	primaries []*replica
+	fallbacks int64
+}
+
+type replica struct {
+	name    string
+	storage storage.Storage
+	breaker *breaker.Breaker
+	missed  int64 // 未写入该副本的操作数
+}
+
+// New 创建复制器，replicas 为主存储之外的副本
+func New(replicas []Replica, cfg Config) (*Replicator, error) {
+	if len(replicas) == 0 {
+		return nil, errors.New("no storage replicas configured")
+	}
+	names := map[string]bool{"primary": true}
+	r := &Replicator{cfg: cfg}
+	for _, rep := range replicas {
+		if rep.Name == "" || names[rep.Name] {
+			return nil, fmt.Errorf("storage replica name %q is empty or duplicated", rep.Name)
+		}
+		names[rep.Name] = true
+		r.replicas = append(r.replicas, r.newReplica(rep.Name, rep.Storage))
+	}
+	total := len(replicas) + 1
+	if r.cfg.WriteQuorum <= 0 {
+		r.cfg.WriteQuorum = total
+	}
+	if r.cfg.WriteQuorum > total {
+		return nil, fmt.Errorf("storage write-quorum %d exceeds the %d replicas", r.cfg.WriteQuorum, total)
+	}
+	return r, nil
+}
+
+func (r *Replicator) newReplica(name string, s storage.Storage) *replica {
+	b := breaker.New("replica "+name, r.cfg.Breaker)
+	return &replica{name: name, storage: b.Wrap(s), breaker: b}
+}
+
+// Wrap 以 primary 为第一个副本组成复制存储，读取优先使用 primary
+func (r *Replicator) Wrap(primary storage.Storage) storage.Storage {
+	p := r.newReplica("primary", primary)
+	r.mu.Lock()
+	r.primaries = append(r.primaries, p)
+	r.mu.Unlock()
+	return &replicatedStorage{Storage: primary, r: r, replicas: append([]*replica{p}, r.replicas...)}
+}
+
+// Metrics 返回各副本的状态
+func (r *Replicator) Metrics() *types.ReplicationMetrics {
+	replicas := r.all()
+
+	m := &types.ReplicationMetrics{
+		WriteQuorum: r.cfg.WriteQuorum,
+		Fallbacks:   atomic.LoadInt64(&r.fallbacks),
+	}
+	for _, rep := range replicas {
+		bm := rep.breaker.Metrics()
+		m.Replicas = append(m.Replicas, types.ReplicaMetrics{
+			Name:         rep.name,
+			State:        bm.State,
+			Failures:     bm.Failures,
+			MissedWrites: atomic.LoadInt64(&rep.missed),
+			LastError:    bm.LastError,
+		})
+	}
+	return m
+}
+
+// Unavailable 返回熔断中的副本名称
+func (r *Replicator) Unavailable() []string {
+	var names []string
+	for _, rep := range r.all() {
+		if rep.breaker.State() != breaker.StateClosed {
+			names = append(names, rep.name)
+		}
+	}
+	return names
+}
+
+func (r *Replicator) all() []*replica {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append(append([]*replica{}, r.primaries...), r.replicas...)
+}
+
+// replicatedStorage 写操作分发到所有副本，读操作依次尝试
+type replicatedStorage struct {
+	storage.Storage
+	r        *Replicator
+	replicas []*replica
+}
+
+// write 在所有副本上并发执行 op，成功的副本数达到 WriteQuorum 即成功；
+// 失败的副本记录一次遗漏的写入，副本之间可能不一致
+func (s *replicatedStorage) write(op, path string, fn func(storage.Storage) error) error {
+	errs := make([]error, len(s.replicas))
+	var wg sync.WaitGroup
+	for i, rep := range s.replicas {
+		wg.Add(1)
+		go func(i int, rep *replica) {
+			defer wg.Done()
+			errs[i] = fn(rep.storage)
+		}(i, rep)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	var failed []error
+	for i, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		failed = append(failed, fmt.Errorf("replica %s: %w", s.replicas[i].name, err))
+	}
+	if succeeded == len(s.replicas) {
+		return nil
+	}
+	// 所有副本的错误相同（如源文件不存在）时不算副本不一致
+	if succeeded == 0 && allNotExist(errs) {
+		return errs[0]
+	}
+	for i, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			atomic.AddInt64(&s.replicas[i].missed, 1)
+			log.Logger.Warnf("Replicated %s of %s missed replica %s: %v", op, path, s.replicas[i].name, err)
+		}
+	}
+	if succeeded >= s.r.cfg.WriteQuorum {
+		return nil
+	}
+	return fmt.Errorf("replicated %s %s: %d of %d replicas succeeded, write-quorum is %d: %w",
+		op, path, succeeded, len(s.replicas), s.r.cfg.WriteQuorum, errors.Join(failed...))
+}
+
+func allNotExist(errs []error) bool {
+	for _, err := range errs {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return false
+		}
+	}
+	return true
+}
+
+// read 依次在各副本上执行 fn，直到成功。所有副本都失败时，
+// 优先返回后端故障的错误：故障的副本上文件可能存在，不能当作不存在
+func (s *replicatedStorage) read(fn func(storage.Storage) error) error {
+	var notExist, failure error
+	for i, rep := range s.replicas {
+		err := fn(rep.storage)
+		if err == nil {
+			if i > 0 {
+				atomic.AddInt64(&s.r.fallbacks, 1)
+			}
+			return nil
+		}
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+		if errors.Is(err, fs.ErrNotExist) {
+			if notExist == nil {
+				notExist = err
+			}
+		} else if failure == nil {
+			failure = err
+		}
+	}
+	if failure != nil {
+		return failure
+	}
+	return notExist
+}
+
+func (s *replicatedStorage) Store(ctx context.Context, path string, reader io.Reader) error {
+	src, size, cleanup, err := s.r.spool(reader)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return s.write("store", path, func(rs storage.Storage) error {
+		return rs.Store(ctx, path, io.NewSectionReader(src, 0, size))
+	})
+}
+
+// spool 返回可并发读取的数据：reader 支持 ReaderAt 时直接使用，否则先写入临时文件
+func (r *Replicator) spool(reader io.Reader) (io.ReaderAt, int64, func(), error) {
+	if ra, ok := reader.(io.ReaderAt); ok {
+		if seeker, ok := reader.(io.Seeker); ok {
+			start, err := seeker.Seek(0, io.SeekCurrent)
+			if err == nil {
+				end, err := seeker.Seek(0, io.SeekEnd)
+				if err == nil {
+					return io.NewSectionReader(ra, start, end-start), end - start, func() {}, nil
+				}
+			}
+		}
+	}
+
+	f, err := os.CreateTemp(r.cfg.SpoolDir, SpoolPrefix+"*")
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("spool upload: %w", err)
+	}
+	cleanup := func() {
+		f.Close()
+		os.Remove(f.Name())
+	}
+	size, err := io.Copy(f, reader)
+	if err != nil {
+		cleanup()
+		return nil, 0, nil, err
+	}
+	return f, size, cleanup, nil
+}
+
+func (s *replicatedStorage) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	var rc io.ReadCloser
+	err := s.read(func(rs storage.Storage) (err error) {
+		rc, err = rs.Get(ctx, path)
+		return err
+	})
+	return rc, err
+}
+
+func (s *replicatedStorage) OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	var rc io.ReadCloser
+	err := s.read(func(rs storage.Storage) (err error) {
+		rc, err = rs.OpenRange(ctx, path, offset, length)
+		return err
+	})
+	return rc, err
+}
+
+func (s *replicatedStorage) Stat(ctx context.Context, path string) (storage.FileInfo, error) {
+	var info storage.FileInfo
+	err := s.read(func(rs storage.Storage) (err error) {
+		info, err = rs.Stat(ctx, path)
+		return err
+	})
+	return info, err
+}
+
+// Exists 任一副本上存在即为存在
+func (s *replicatedStorage) Exists(ctx context.Context, path string) (bool, error) {
+	err := s.read(func(rs storage.Storage) error {
+		exists, err := rs.Exists(ctx, path)
+		if err == nil && !exists {
+			return fs.ErrNotExist
+		}
+		return err
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ListWithOptions 返回第一个可用副本的列表
+func (s *replicatedStorage) ListWithOptions(ctx context.Context, prefix string, opts storage.ListOptions) ([]storage.FileInfo, error) {
+	var files []storage.FileInfo
+	err := s.read(func(rs storage.Storage) (err error) {
+		files, err = rs.ListWithOptions(ctx, prefix, opts)
+		return err
+	})
+	return files, err
+}
+
+func (s *replicatedStorage) Delete(ctx context.Context, path string) error {
+	return s.write("delete", path, func(rs storage.Storage) error {
+		return rs.Delete(ctx, path)
+	})
+}
+
+func (s *replicatedStorage) CreateDir(ctx context.Context, path string) error {
+	return s.write("create-dir", path, func(rs storage.Storage) error {
+		return rs.CreateDir(ctx, path)
+	})
+}
+
+func (s *replicatedStorage) Copy(ctx context.Context, src, dst string) error {
+	return s.write("copy", src+" -> "+dst, func(rs storage.Storage) error {
+		return rs.Copy(ctx, src, dst)
+	})
+}
+
+func (s *replicatedStorage) Rename(ctx context.Context, src, dst string) error {
+	return s.write("rename", src+" -> "+dst, func(rs storage.Storage) error {
+		return rs.Rename(ctx, src, dst)
+	})
+}
@@ -0,0 +1,161 @@
+package replicated
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+	"plus/pkg/storage/breaker"
+	"plus/pkg/storage/fault"
+	"plus/pkg/storage/local"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+func newLocal(t *testing.T) storage.Storage {
+	s, err := local.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func read(t *testing.T, s storage.Storage, path string) string {
+	t.Helper()
+	rc, err := s.Get(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Get %s: %v", path, err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	return string(data)
+}
+
+func TestReplicatedWrites(t *testing.T) {
+	primary, second, third := newLocal(t), newLocal(t), newLocal(t)
+	r, err := New([]Replica{{Name: "b", Storage: second}, {Name: "c", Storage: third}}, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := r.Wrap(primary)
+	ctx := context.Background()
+
+	// 不可 Seek 的上传先写入临时文件
+	if err := s.Store(ctx, "repo/a.txt", io.MultiReader(strings.NewReader("hello "), strings.NewReader("world"))); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Copy(ctx, "repo/a.txt", "repo/b.txt"); err != nil {
+		t.Fatal(err)
+	}
+	for _, rs := range []storage.Storage{primary, second, third} {
+		if got := read(t, rs, "repo/b.txt"); got != "hello world" {
+			t.Errorf("replica has %q", got)
+		}
+	}
+
+	if err := s.Delete(ctx, "repo/a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	for _, rs := range []storage.Storage{primary, second, third} {
+		if exists, _ := rs.Exists(ctx, "repo/a.txt"); exists {
+			t.Error("delete not replicated")
+		}
+	}
+
+	// 所有副本上都不存在时返回不存在，而不是副本不一致
+	if err := s.Rename(ctx, "repo/missing.txt", "repo/c.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Rename missing = %v", err)
+	}
+	if m := r.Metrics(); m.WriteQuorum != 3 || m.Replicas[1].MissedWrites != 0 {
+		t.Errorf("metrics = %+v", m)
+	}
+}
+
+func TestReplicaLoss(t *testing.T) {
+	primary := fault.New(newLocal(t), fault.Config{})
+	primary.SetEnabled(false)
+	second := newLocal(t)
+	r, err := New([]Replica{{Name: "b", Storage: second}}, Config{
+		WriteQuorum: 1,
+		Breaker:     breaker.Config{Failures: 1, Cooldown: time.Minute},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := r.Wrap(primary)
+	ctx := context.Background()
+
+	if err := s.Store(ctx, "a.txt", strings.NewReader("before")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 主副本故障：读取由副本 b 提供，写入满足 write-quorum
+	primary = fault.New(primary.Storage, fault.Config{Error: 1, NoSpace: 1})
+	s = r.Wrap(primary)
+	if got := read(t, s, "a.txt"); got != "before" {
+		t.Errorf("fallback read = %q", got)
+	}
+	// 注入的磁盘已满在写入不超过 4KB 后发生
+	during := strings.Repeat("during", 1000)
+	if err := s.Store(ctx, "b.txt", strings.NewReader(during)); err != nil {
+		t.Fatalf("Store with one replica down: %v", err)
+	}
+	if got := read(t, second, "b.txt"); got != during {
+		t.Errorf("replica b has %q", got)
+	}
+	if down := r.Unavailable(); len(down) != 1 || down[0] != "primary" {
+		t.Errorf("Unavailable = %v", down)
+	}
+	m := r.Metrics()
+	if m.Fallbacks != 1 {
+		t.Errorf("fallbacks = %d", m.Fallbacks)
+	}
+	var missed int64
+	for _, rep := range m.Replicas {
+		missed += rep.MissedWrites
+	}
+	if missed != 1 {
+		t.Errorf("missed writes = %d", missed)
+	}
+
+	// 文件只在副本 b 上（主副本丢失后重建）
+	empty := r.Wrap(newLocal(t))
+	if got := read(t, empty, "b.txt"); got != during {
+		t.Errorf("read from rebuilt primary = %q", got)
+	}
+	if exists, err := empty.Exists(ctx, "b.txt"); !exists || err != nil {
+		t.Errorf("Exists = %v, %v", exists, err)
+	}
+	if _, err := empty.Stat(ctx, "missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat missing = %v", err)
+	}
+}
+
+func TestWriteQuorum(t *testing.T) {
+	if _, err := New([]Replica{{Name: "b", Storage: newLocal(t)}}, Config{WriteQuorum: 3}); err == nil {
+		t.Error("write-quorum larger than the replica count accepted")
+	}
+	if _, err := New([]Replica{{Name: "primary", Storage: newLocal(t)}}, Config{}); err == nil {
+		t.Error("duplicate replica name accepted")
+	}
+
+	// 默认要求所有副本写入成功
+	r, _ := New([]Replica{{Name: "b", Storage: fault.New(newLocal(t), fault.Config{NoSpace: 1})}}, Config{})
+	s := r.Wrap(newLocal(t))
+	if err := s.Store(context.Background(), "a.txt", strings.NewReader(strings.Repeat("x", 8192))); err == nil ||
+		!strings.Contains(err.Error(), "1 of 2 replicas succeeded") {
+		t.Errorf("Store = %v", err)
+	}
+}
@@ -0,0 +1,283 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand"
+	"strings"
+	"syscall"
+	"time"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+)
+
+// 默认值
+const (
+	DefaultAttempts   = 3
+	DefaultBackoff    = 100 * time.Millisecond
+	DefaultMaxBackoff = 2 * time.Second
+	DefaultTimeout    = 30 * time.Second
+)
+
+// transientMessages 对象存储返回的可重试错误特征，按小写匹配
+var transientMessages = []string{
+	"slowdown", "slow down", "throttl", "too many requests", "toomanyrequests",
+	"serviceunavailable", "service unavailable", "internalerror", "internal error",
+	"requesttimeout", "request timeout", "timeout", "timed out",
+	"connection reset", "connection refused", "broken pipe", "unexpected eof",
+	"503", "502", "504",
+}
+
+// Config 重试参数
+type Config struct {
+	Attempts   int           // 总尝试次数，1 表示不重试
+	Backoff    time.Duration // 第一次重试前的等待，之后每次翻倍
+	MaxBackoff time.Duration
+	Timeout    time.Duration // 每次尝试的超时；上传和读取数据流不受限制
+	// Retryable 判断错误是否可重试，为 nil 时使用 IsRetryable
+	Retryable func(error) bool
+}
+
+// Storage 对底层存储的失败操作按指数退避重试，避免对象存储的短暂故障直接变成 500
+type Storage struct {
+	storage.Storage
+	cfg Config
+}
+
+// New 包装存储，未设置的参数使用默认值
+func New(backend storage.Storage, cfg Config) *Storage {
+	if cfg.Attempts <= 0 {
+		cfg.Attempts = DefaultAttempts
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = DefaultBackoff
+	}
+	if cfg.MaxBackoff < cfg.Backoff {
+		cfg.MaxBackoff = DefaultMaxBackoff
+		if cfg.MaxBackoff < cfg.Backoff {
+			cfg.MaxBackoff = cfg.Backoff
+		}
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Retryable == nil {
+		cfg.Retryable = IsRetryable
+	}
+	return &Storage{Storage: backend, cfg: cfg}
+}
+
+// IsRetryable 网络错误、超时、连接中断和对象存储的限流/5xx 错误可重试；
+// 文件不存在、权限不足、磁盘已满等错误重试也不会成功
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) ||
+		errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EROFS) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ETIMEDOUT) {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return true
+	}
+	return MatchMessage(err, transientMessages)
+}
+
+// MatchMessage 错误信息（小写）是否包含任一特征
+func MatchMessage(err error, patterns []string) bool {
+	msg := strings.ToLower(err.Error())
+	for _, p := range patterns {
+		if p != "" && strings.Contains(msg, strings.ToLower(p)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Storage) Store(ctx context.Context, path string, reader io.Reader) error {
+	// 只有可以回到开头的数据才能重新上传
+	seeker, ok := reader.(io.Seeker)
+	var start int64
+	if ok {
+		var err error
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			ok = false
+		}
+	}
+	if !ok {
+		return s.Storage.Store(ctx, path, reader)
+	}
+
+	first := true
+	return s.do(ctx, "store", path, func(ctx context.Context) error {
+		if !first {
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return fmt.Errorf("failed to rewind upload: %w", err)
+			}
+		}
+		first = false
+		return s.Storage.Store(ctx, path, reader)
+	}, false)
+}
+
+// Get 带重试地打开对象；超时只限制打开，之后读取数据流时中断不重试，避免拼接出不同版本的内容
+func (s *Storage) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	return s.open(ctx, "get", path, s.Storage.Get)
+}
+
+func (s *Storage) OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	return s.open(ctx, "get", path, func(ctx context.Context, path string) (io.ReadCloser, error) {
+		return s.Storage.OpenRange(ctx, path, offset, length)
+	})
+}
+
+// open 带重试和打开超时地执行 Get/OpenRange
+func (s *Storage) open(ctx context.Context, op, path string, get func(context.Context, string) (io.ReadCloser, error)) (io.ReadCloser, error) {
+	var rc io.ReadCloser
+	err := s.do(ctx, op, path, func(ctx context.Context) error {
+		// 数据流可能依赖 ctx，打开成功后保持到 Close
+		openCtx, cancel := context.WithCancel(ctx)
+		timer := time.AfterFunc(s.cfg.Timeout, cancel)
+		r, err := get(openCtx, path)
+		if !timer.Stop() {
+			if err == nil {
+				r.Close()
+			}
+			err = fmt.Errorf("open timed out after %s: %w", s.cfg.Timeout, context.DeadlineExceeded)
+		}
+		if err != nil {
+			cancel()
+			return err
+		}
+		rc = &cancelCloser{ReadCloser: r, cancel: cancel}
+		return nil
+	}, false)
+	return rc, err
+}
+
+func (s *Storage) Delete(ctx context.Context, path string) error {
+	return s.do(ctx, "delete", path, func(ctx context.Context) error {
+		return s.Storage.Delete(ctx, path)
+	}, true)
+}
+
+func (s *Storage) ListWithOptions(ctx context.Context, prefix string, opts storage.ListOptions) ([]storage.FileInfo, error) {
+	var files []storage.FileInfo
+	err := s.do(ctx, "list", prefix, func(ctx context.Context) error {
+		var err error
+		files, err = s.Storage.ListWithOptions(ctx, prefix, opts)
+		return err
+	}, true)
+	return files, err
+}
+
+func (s *Storage) Copy(ctx context.Context, src, dst string) error {
+	return s.do(ctx, "copy", src, func(ctx context.Context) error {
+		return s.Storage.Copy(ctx, src, dst)
+	}, false)
+}
+
+func (s *Storage) Rename(ctx context.Context, src, dst string) error {
+	return s.do(ctx, "rename", src, func(ctx context.Context) error {
+		return s.Storage.Rename(ctx, src, dst)
+	}, false)
+}
+
+func (s *Storage) CreateDir(ctx context.Context, path string) error {
+	return s.do(ctx, "mkdir", path, func(ctx context.Context) error {
+		return s.Storage.CreateDir(ctx, path)
+	}, true)
+}
+
+func (s *Storage) Stat(ctx context.Context, path string) (storage.FileInfo, error) {
+	var info storage.FileInfo
+	err := s.do(ctx, "stat", path, func(ctx context.Context) error {
+		var err error
+		info, err = s.Storage.Stat(ctx, path)
+		return err
+	}, true)
+	return info, err
+}
+
+func (s *Storage) Exists(ctx context.Context, path string) (bool, error) {
+	var exists bool
+	err := s.do(ctx, "exists", path, func(ctx context.Context) error {
+		var err error
+		exists, err = s.Storage.Exists(ctx, path)
+		return err
+	}, true)
+	return exists, err
+}
+
+// do 执行操作，失败且可重试时等待后再试；timed 为 true 时每次尝试受 Timeout 限制
+func (s *Storage) do(ctx context.Context, op, path string, fn func(ctx context.Context) error, timed bool) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if timed {
+			attemptCtx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		}
+		err = fn(attemptCtx)
+		cancel()
+		if err == nil || !s.retry(ctx, op, path, attempt, err) {
+			return err
+		}
+	}
+}
+
+// retry 判断是否重试，需要时等待退避时间；请求已取消时不再重试
+func (s *Storage) retry(ctx context.Context, op, path string, attempt int, err error) bool {
+	if ctx.Err() != nil || !s.cfg.Retryable(err) {
+		return false
+	}
+	if attempt >= s.cfg.Attempts {
+		log.Logger.Warnf("Storage %s %s failed after %d attempts: %v", op, path, attempt, err)
+		return false
+	}
+
+	delay := s.backoff(attempt)
+	log.Logger.Debugf("Storage %s %s failed (attempt %d/%d), retrying in %s: %v", op, path, attempt, s.cfg.Attempts, delay, err)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// backoff 第 attempt 次失败后的等待时间，在指数退避值的一半到全部之间随机
+func (s *Storage) backoff(attempt int) time.Duration {
+	d := s.cfg.MaxBackoff
+	if shift := attempt - 1; shift < 32 && s.cfg.Backoff<<shift < s.cfg.MaxBackoff {
+		d = s.cfg.Backoff << shift
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// cancelCloser 关闭数据流时释放打开时的 ctx
+type cancelCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelCloser) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
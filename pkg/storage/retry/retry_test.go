@@ -0,0 +1,157 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"plus/internal/log"
+	"plus/pkg/storage"
+	"plus/pkg/storage/local"
+
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	log.Logger = zap.NewNop().Sugar()
+	os.Exit(m.Run())
+}
+
+// flaky 前 failures 次操作返回 err
+type flaky struct {
+	storage.Storage
+	failures int
+	err      error
+	calls    int
+	block    time.Duration
+}
+
+func (f *flaky) fail() error {
+	f.calls++
+	if f.calls <= f.failures {
+		return f.err
+	}
+	return nil
+}
+
+func (f *flaky) Store(ctx context.Context, path string, reader io.Reader) error {
+	if err := f.fail(); err != nil {
+		// 失败前读走部分数据，重试时必须从头上传
+		io.CopyN(io.Discard, reader, 3)
+		return err
+	}
+	return f.Storage.Store(ctx, path, reader)
+}
+
+func (f *flaky) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	if f.calls++; f.calls <= f.failures && f.block > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(f.block):
+		}
+	}
+	return f.Storage.Get(ctx, path)
+}
+
+func (f *flaky) Exists(ctx context.Context, path string) (bool, error) {
+	if err := f.fail(); err != nil {
+		return false, err
+	}
+	return f.Storage.Exists(ctx, path)
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	backend, _ := local.NewLocalStorage(t.TempDir())
+	ctx := context.Background()
+
+	f := &flaky{Storage: backend, failures: 2, err: fmt.Errorf("put object: %w", syscall.ECONNRESET)}
+	s := New(f, Config{Attempts: 3, Backoff: time.Millisecond})
+	if err := s.Store(ctx, "a.txt", strings.NewReader("content")); err != nil {
+		t.Fatalf("Store should succeed on the third attempt: %v", err)
+	}
+	rc, err := s.Get(ctx, "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "content" || f.calls != 4 {
+		t.Errorf("stored %q after %d calls", data, f.calls)
+	}
+
+	// 超过次数后返回最后一次的错误
+	f = &flaky{Storage: backend, failures: 5, err: errors.New("503 SlowDown: please reduce your request rate")}
+	s = New(f, Config{Attempts: 3, Backoff: time.Millisecond})
+	if _, err := s.Exists(ctx, "a.txt"); err == nil || f.calls != 3 {
+		t.Errorf("Exists error = %v after %d calls", err, f.calls)
+	}
+
+	// 不可重试的错误只尝试一次
+	f = &flaky{Storage: backend, failures: 5, err: &os.PathError{Op: "write", Path: "a.txt", Err: syscall.ENOSPC}}
+	s = New(f, Config{Attempts: 3, Backoff: time.Millisecond})
+	if err := s.Store(ctx, "a.txt", strings.NewReader("x")); err == nil || f.calls != 1 {
+		t.Errorf("Store error = %v after %d calls", err, f.calls)
+	}
+
+	// 自定义判断
+	f = &flaky{Storage: backend, failures: 1, err: errors.New("mindb: bucket busy")}
+	s = New(f, Config{Attempts: 2, Backoff: time.Millisecond, Retryable: func(err error) bool {
+		return MatchMessage(err, []string{"busy"})
+	}})
+	if _, err := s.Exists(ctx, "a.txt"); err != nil {
+		t.Errorf("custom retryable error not retried: %v", err)
+	}
+}
+
+func TestRetryOpenTimeout(t *testing.T) {
+	backend, _ := local.NewLocalStorage(t.TempDir())
+	ctx := context.Background()
+	backend.Store(ctx, "a.txt", strings.NewReader("content"))
+
+	// 第一次打开卡住，超时后重试
+	f := &flaky{Storage: backend, failures: 1, block: time.Second}
+	s := New(f, Config{Attempts: 2, Backoff: time.Millisecond, Timeout: 50 * time.Millisecond})
+	start := time.Now()
+	rc, err := s.Get(ctx, "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("open took %s, timeout not applied", d)
+	}
+	if data, _ := io.ReadAll(rc); string(data) != "content" {
+		t.Errorf("read %q", data)
+	}
+
+	// 不存在的对象不重试
+	if _, err := s.Get(ctx, "missing.txt"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Get missing = %v", err)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{context.DeadlineExceeded, true},
+		{context.Canceled, false},
+		{io.ErrUnexpectedEOF, true},
+		{fmt.Errorf("get: %w", os.ErrNotExist), false},
+		{errors.New("ServiceUnavailable: try again"), true},
+		{errors.New("access denied"), false},
+	}
+	for _, c := range cases {
+		if got := IsRetryable(c.err); got != c.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
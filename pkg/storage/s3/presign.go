@@ -0,0 +1,148 @@
+package s3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signAlgorithm   = "AWS4-HMAC-SHA256"
+	defaultRegion   = "us-east-1"
+	maxPresignValid = 7 * 24 * time.Hour
+)
+
+// PresignConfig S3 兼容对象存储的预签名参数
+type PresignConfig struct {
+	Endpoint  string // 如 https://s3.us-east-1.amazonaws.com、http://minio:9000
+	Region    string
+	Bucket    string
+	Prefix    string // 对象键前缀
+	AccessKey string
+	SecretKey string
+	PathStyle bool // 使用 {endpoint}/{bucket}/{key} 形式的地址
+}
+
+// Presigner 使用 AWS Signature V4 查询参数签名生成 GET 预签名地址
+type Presigner struct {
+	cfg      PresignConfig
+	endpoint *url.URL
+	now      func() time.Time
+}
+
+// NewPresigner 创建预签名器
+func NewPresigner(cfg PresignConfig) (*Presigner, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("presign requires endpoint, bucket, access key and secret key")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid object storage endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultRegion
+	}
+	cfg.Prefix = strings.Trim(cfg.Prefix, "/")
+	return &Presigner{cfg: cfg, endpoint: endpoint, now: time.Now}, nil
+}
+
+// PresignGet 返回对象在 expires 时间内有效的下载地址
+func (p *Presigner) PresignGet(_ context.Context, path string, expires time.Duration) (string, error) {
+	if expires <= 0 || expires > maxPresignValid {
+		return "", fmt.Errorf("presign expiry must be between 1s and %s", maxPresignValid)
+	}
+	key := strings.TrimPrefix(path, "/")
+	if p.cfg.Prefix != "" {
+		key = p.cfg.Prefix + "/" + key
+	}
+
+	host := p.endpoint.Host
+	uri := strings.TrimSuffix(p.endpoint.Path, "/")
+	if p.cfg.PathStyle {
+		uri += "/" + p.cfg.Bucket
+	} else {
+		host = p.cfg.Bucket + "." + host
+	}
+	uri += "/" + encodePath(key)
+
+	now := p.now().UTC()
+	date := now.Format("20060102")
+	stamp := now.Format("20060102T150405Z")
+	scope := date + "/" + p.cfg.Region + "/s3/aws4_request"
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     signAlgorithm,
+		"X-Amz-Credential":    p.cfg.AccessKey + "/" + scope,
+		"X-Amz-Date":          stamp,
+		"X-Amz-Expires":       strconv.Itoa(int(expires.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+	canonicalQuery := canonicalQueryString(query)
+
+	canonicalRequest := strings.Join([]string{
+		"GET",
+		uri,
+		canonicalQuery,
+		"host:" + host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{signAlgorithm, stamp, scope, hex.EncodeToString(hash[:])}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+p.cfg.SecretKey), date)
+	for _, part := range []string{p.cfg.Region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return fmt.Sprintf("%s://%s%s?%s&X-Amz-Signature=%s", p.endpoint.Scheme, host, uri, canonicalQuery, signature), nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalQueryString(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = uriEncode(k, true) + "=" + uriEncode(query[k], true)
+	}
+	return strings.Join(parts, "&")
+}
+
+// encodePath 按 S3 规则编码对象键，保留 /
+func encodePath(key string) string {
+	return uriEncode(key, false)
+}
+
+// uriEncode 除 A-Z a-z 0-9 - _ . ~ 外全部百分号编码（encodeSlash 为 false 时保留 /）
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"path/filepath"
+	"plus/internal/mimetype"
 	"plus/pkg/storage"
 	"strings"
 	"time"
@@ -343,6 +345,66 @@ func (m *MinDBStorage) Exists(ctx context.Context, path string) (bool, error) {
 	return len(objects) > 0, nil
 }
 
+// Stat 读取对象元数据，不读取内容
+func (m *MinDBStorage) Stat(ctx context.Context, path string) (storage.FileInfo, error) {
+	reader, objectData, err := m.db.GetObjectStream(m.bucket, m.normalizePath(path))
+	if err != nil {
+		return storage.FileInfo{}, objectError(err)
+	}
+	reader.Close()
+
+	return storage.FileInfo{
+		Name:    path,
+		Size:    objectData.Size,
+		ModTime: objectData.LastModified,
+	}, nil
+}
+
+// OpenRange 流式读取对象的一部分，避免把整个对象读入内存
+func (m *MinDBStorage) OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	reader, _, err := m.db.GetObjectStream(m.bucket, m.normalizePath(path))
+	if err != nil {
+		return nil, objectError(err)
+	}
+	return storage.Section(reader, offset, length)
+}
+
+// Copy 在 MinDB 内部流式复制对象
+func (m *MinDBStorage) Copy(ctx context.Context, src, dst string) error {
+	reader, objectData, err := m.db.GetObjectStream(m.bucket, m.normalizePath(src))
+	if err != nil {
+		return objectError(err)
+	}
+	defer reader.Close()
+
+	metadata := map[string]string{
+		"upload-time": time.Now().UTC().Format(time.RFC3339),
+	}
+	if _, err := m.db.PutObjectStream(m.bucket, m.normalizePath(dst), reader, objectData.Size, m.getContentType(dst), metadata); err != nil {
+		return fmt.Errorf("复制对象失败: %w", err)
+	}
+	return nil
+}
+
+// Rename 复制后删除原对象，MinDB 没有原生的移动操作
+func (m *MinDBStorage) Rename(ctx context.Context, src, dst string) error {
+	if err := m.Copy(ctx, src, dst); err != nil {
+		return err
+	}
+	if err := m.db.DeleteObject(m.bucket, m.normalizePath(src)); err != nil {
+		return fmt.Errorf("删除对象失败: %w", err)
+	}
+	return nil
+}
+
+// objectError 将对象不存在的错误转换为 fs.ErrNotExist
+func objectError(err error) error {
+	if strings.Contains(err.Error(), "object not found") {
+		return fmt.Errorf("获取对象失败: %w", fs.ErrNotExist)
+	}
+	return fmt.Errorf("获取对象失败: %w", err)
+}
+
 // Close 关闭数据库连接
 func (m *MinDBStorage) Close() error {
 	return m.db.Close()
@@ -359,33 +421,9 @@ func (m *MinDBStorage) normalizePath(path string) string {
 	return path
 }
 
-// getContentType 根据文件扩展名获取内容类型
+// getContentType 根据文件扩展名获取内容类型，配置的 mime-types 优先
 func (m *MinDBStorage) getContentType(path string) string {
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".txt":
-		return "text/plain"
-	case ".json":
-		return "application/json"
-	case ".xml":
-		return "application/xml"
-	case ".html", ".htm":
-		return "text/html"
-	case ".css":
-		return "text/css"
-	case ".js":
-		return "application/javascript"
-	case ".png":
-		return "image/png"
-	case ".jpg", ".jpeg":
-		return "image/jpeg"
-	case ".gif":
-		return "image/gif"
-	case ".pdf":
-		return "application/pdf"
-	default:
-		return "application/octet-stream"
-	}
+	return mimetype.ByExtension(path)
 }
 
 // isDirectory 检查路径是否为目录
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io"
 	"time"
 )
@@ -14,6 +15,45 @@ type Storage interface {
 	CreateDir(ctx context.Context, path string) error
 	GetPath(path string) string
 	Exists(ctx context.Context, path string) (bool, error)
+	// Stat 返回文件大小和修改时间，不读取内容；不存在时返回的错误满足 errors.Is(err, fs.ErrNotExist)
+	Stat(ctx context.Context, path string) (FileInfo, error)
+	// OpenRange 从 offset 开始读取 length 字节，length 小于 0 时读到末尾
+	OpenRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error)
+	// Copy 在存储内部复制文件，不经过应用读写数据；已存在的 dst 被替换
+	Copy(ctx context.Context, src, dst string) error
+	// Rename 在存储内部移动文件，已存在的 dst 被替换
+	Rename(ctx context.Context, src, dst string) error
+}
+
+// Presigner 可生成预签名下载地址的存储，客户端可直接从对象存储下载
+type Presigner interface {
+	PresignGet(ctx context.Context, path string, expires time.Duration) (string, error)
+}
+
+// ErrNotPresignable 存储不能生成预签名地址
+var ErrNotPresignable = errors.New("storage does not support presigned URLs")
+
+// Presign 返回 s 中 path 的预签名下载地址，s 不支持时返回 ErrNotPresignable
+func Presign(ctx context.Context, s Storage, path string, expires time.Duration) (string, error) {
+	p, ok := s.(Presigner)
+	if !ok {
+		return "", ErrNotPresignable
+	}
+	return p.PresignGet(ctx, path, expires)
+}
+
+// WithPresigner 为 s 加上预签名，p 按 s 中的路径签名同一文件在对象存储中的副本
+func WithPresigner(s Storage, p Presigner) Storage {
+	return &presigned{Storage: s, presigner: p}
+}
+
+type presigned struct {
+	Storage
+	presigner Presigner
+}
+
+func (s *presigned) PresignGet(ctx context.Context, path string, expires time.Duration) (string, error) {
+	return s.presigner.PresignGet(ctx, path, expires)
 }
 
 type FileInfo struct {
@@ -29,3 +69,28 @@ type ListOptions struct {
 	IncludeDirs bool
 	Extensions  []string // 文件扩展名过滤
 }
+
+// Section 跳过 rc 的前 offset 字节并限制读取 length 字节（小于 0 时不限制），rc 可 Seek 时直接定位
+func Section(rc io.ReadCloser, offset, length int64) (io.ReadCloser, error) {
+	if offset > 0 {
+		var err error
+		if seeker, ok := rc.(io.Seeker); ok {
+			_, err = seeker.Seek(offset, io.SeekStart)
+		} else if _, err = io.CopyN(io.Discard, rc, offset); err == io.EOF {
+			err = nil
+		}
+		if err != nil {
+			rc.Close()
+			return nil, err
+		}
+	}
+	if length < 0 {
+		return rc, nil
+	}
+	return &sectionReader{Reader: io.LimitReader(rc, length), Closer: rc}, nil
+}
+
+type sectionReader struct {
+	io.Reader
+	io.Closer
+}